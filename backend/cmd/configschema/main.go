@@ -0,0 +1,108 @@
+// Command configschema writes a JSON Schema for the router config file,
+// generated from config.Config. Property names follow the mapstructure tags;
+// descriptions come from `description` struct tags, falling back to the
+// fields' doc comments.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"go/build"
+	"log"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/invopop/jsonschema"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+)
+
+const configPackage = "github.com/ncecere/open_model_gateway/backend/internal/config"
+
+func main() {
+	out := flag.String("out", "", "write the schema to this file instead of stdout")
+	flag.Parse()
+
+	schema, err := buildSchema()
+	if err != nil {
+		log.Fatalf("build schema: %v", err)
+	}
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		log.Fatalf("encode schema: %v", err)
+	}
+	data = append(data, '\n')
+
+	if *out == "" {
+		if _, err := os.Stdout.Write(data); err != nil {
+			log.Fatalf("write schema: %v", err)
+		}
+		return
+	}
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		log.Fatalf("write schema: %v", err)
+	}
+}
+
+func buildSchema() (*jsonschema.Schema, error) {
+	r := &jsonschema.Reflector{
+		FieldNameTag:               "mapstructure",
+		RequiredFromJSONSchemaTags: true,
+		ExpandedStruct:             true,
+		Mapper:                     mapType,
+		LookupComment:              descriptionTag,
+	}
+	if err := addConfigComments(r); err != nil {
+		return nil, err
+	}
+	schema := r.Reflect(&config.Config{})
+	schema.ID = ""
+	schema.Title = "Open Model Gateway router config"
+	return schema, nil
+}
+
+// addConfigComments loads the config package's doc comments. The package is
+// located through the build context so the command works from any directory
+// in the module.
+func addConfigComments(r *jsonschema.Reflector) error {
+	pkg, err := build.Import(configPackage, "", build.FindOnly)
+	if err != nil {
+		return err
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	// Comment keys are built from the walked path, so walk from inside the
+	// package directory.
+	if err := os.Chdir(pkg.Dir); err != nil {
+		return err
+	}
+	defer os.Chdir(wd)
+	return r.AddGoComments(configPackage, ".")
+}
+
+// mapType describes durations as the strings the config file uses, such as
+// "30s" or "24h".
+func mapType(t reflect.Type) *jsonschema.Schema {
+	if t == reflect.TypeOf(time.Duration(0)) {
+		return &jsonschema.Schema{
+			Type:    "string",
+			Pattern: `^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$|^0$`,
+		}
+	}
+	return nil
+}
+
+// descriptionTag returns the `description` struct tag of field on t.
+func descriptionTag(t reflect.Type, field string) string {
+	if field == "" || t.Kind() != reflect.Struct {
+		return ""
+	}
+	f, ok := t.FieldByName(field)
+	if !ok {
+		return ""
+	}
+	return f.Tag.Get("description")
+}
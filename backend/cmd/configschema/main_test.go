@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"gopkg.in/yaml.v3"
+)
+
+const committedSchema = "../../../deploy/router.schema.json"
+
+func TestCommittedSchemaIsCurrent(t *testing.T) {
+	schema, err := buildSchema()
+	if err != nil {
+		t.Fatalf("build schema: %v", err)
+	}
+	want, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		t.Fatalf("encode schema: %v", err)
+	}
+	got, err := os.ReadFile(committedSchema)
+	if err != nil {
+		t.Fatalf("read committed schema: %v", err)
+	}
+	if !bytes.Equal(bytes.TrimSpace(got), want) {
+		t.Fatal("deploy/router.schema.json is stale; run go generate ./internal/config")
+	}
+}
+
+func TestExampleConfigsMatchSchema(t *testing.T) {
+	compiler := jsonschema.NewCompiler()
+	data, err := os.ReadFile(committedSchema)
+	if err != nil {
+		t.Fatalf("read schema: %v", err)
+	}
+	if err := compiler.AddResource("router.schema.json", bytes.NewReader(data)); err != nil {
+		t.Fatalf("add schema: %v", err)
+	}
+	schema, err := compiler.Compile("router.schema.json")
+	if err != nil {
+		t.Fatalf("compile schema: %v", err)
+	}
+
+	for _, path := range []string{"../../../deploy/router.example.yaml", "../../../docs/runtime/router.example.yaml"} {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read %s: %v", path, err)
+		}
+		var doc any
+		if err := yaml.Unmarshal(raw, &doc); err != nil {
+			t.Fatalf("parse %s: %v", path, err)
+		}
+		// Round-trip through JSON so the validator sees JSON types.
+		encoded, err := json.Marshal(doc)
+		if err != nil {
+			t.Fatalf("encode %s: %v", path, err)
+		}
+		var value any
+		if err := json.Unmarshal(encoded, &value); err != nil {
+			t.Fatalf("decode %s: %v", path, err)
+		}
+		if err := schema.Validate(value); err != nil {
+			t.Errorf("%s does not match the schema: %v", path, err)
+		}
+	}
+}
@@ -2,7 +2,7 @@ package main
 
 import (
 	"context"
-	"log"
+	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
@@ -10,12 +10,23 @@ import (
 
 	"github.com/ncecere/open_model_gateway/backend/internal/app"
 	"github.com/ncecere/open_model_gateway/backend/internal/batchworker"
+	"github.com/ncecere/open_model_gateway/backend/internal/cache"
 	"github.com/ncecere/open_model_gateway/backend/internal/config"
 	"github.com/ncecere/open_model_gateway/backend/internal/database"
 	"github.com/ncecere/open_model_gateway/backend/internal/executor"
+	"github.com/ncecere/open_model_gateway/backend/internal/grpcserver"
 	"github.com/ncecere/open_model_gateway/backend/internal/httpserver"
+	"github.com/ncecere/open_model_gateway/backend/internal/keysweeper"
+	"github.com/ncecere/open_model_gateway/backend/internal/logging"
 	"github.com/ncecere/open_model_gateway/backend/internal/redisclient"
+	admincatalogsvc "github.com/ncecere/open_model_gateway/backend/internal/services/admincatalog"
+	admintenantsvc "github.com/ncecere/open_model_gateway/backend/internal/services/admintenant"
+	batchsvc "github.com/ncecere/open_model_gateway/backend/internal/services/batches"
 	filesvc "github.com/ncecere/open_model_gateway/backend/internal/services/files"
+	imagejobsvc "github.com/ncecere/open_model_gateway/backend/internal/services/imagejobs"
+	usagearchivesvc "github.com/ncecere/open_model_gateway/backend/internal/services/usagearchive"
+	usagepipeline "github.com/ncecere/open_model_gateway/backend/internal/services/usagepipeline"
+	webhooksvc "github.com/ncecere/open_model_gateway/backend/internal/services/webhooks"
 )
 
 func main() {
@@ -24,50 +35,144 @@ func main() {
 
 	cfg, err := config.Load(config.Options{})
 	if err != nil {
-		log.Fatalf("load config: %v", err)
+		fatal("load config", err)
 	}
+	logging.Setup(cfg.Logging, os.Stderr)
 
 	if err := database.RunMigrations(ctx, cfg.Database); err != nil {
-		log.Fatalf("run migrations: %v", err)
+		fatal("run migrations", err)
 	}
 
 	dbPool, err := database.Connect(ctx, cfg.Database)
 	if err != nil {
-		log.Fatalf("connect database: %v", err)
+		fatal("connect database", err)
 	}
 	defer dbPool.Close()
 
 	redisClient := redisclient.New(cfg.Redis)
 	if err := redisclient.Ping(ctx, redisClient); err != nil {
-		log.Fatalf("connect redis: %v", err)
+		fatal("connect redis", err)
 	}
 	defer redisClient.Close()
 
 	container, err := app.NewContainer(ctx, cfg, dbPool, redisClient)
 	if err != nil {
-		log.Fatalf("build container: %v", err)
+		fatal("build container", err)
 	}
 	if container.Observability != nil {
 		defer container.Observability.Shutdown(ctx)
 	}
 
+	exec := executor.New(container)
+	var batchWorkerStopped <-chan struct{}
 	if container.Batches != nil {
-		go batchworker.New(container, executor.New(container)).Run(ctx)
+		batchWorker := batchworker.New(container, exec)
+		batchWorker.SetShutdownItemTimeout(cfg.Batches.ShutdownItemTimeout)
+		// The worker outlives the signal context so Shutdown can let
+		// in-flight items finish.
+		go batchWorker.Run(context.WithoutCancel(ctx))
+		batchWorkerStopped = stopBatchWorkerOnSignal(ctx, batchWorker, cfg.Batches)
+		startBatchSchedulerSweeper(ctx, batchsvc.NewScheduler(container.Queries), cfg.Batches)
 	}
 	if container.Files != nil {
 		startFileSweeper(ctx, container.Files, cfg.Files)
 	}
+	if container.AdminTenants != nil {
+		startTenantExportSweeper(ctx, container.AdminTenants, cfg.Files)
+	}
+	if container.ImageJobs != nil {
+		go container.ImageJobs.Run(ctx, cfg.Image.WorkerCount, exec.GenerateImage)
+		startImageJobSweeper(ctx, container.ImageJobs, cfg.Files)
+	}
+	if container.IdempotencyDB != nil {
+		startIdempotencySweeper(ctx, container.IdempotencyDB, cfg.Idempotency)
+	}
+	if cfg.APIKeys.InactiveKeyTTL > 0 {
+		var mailer keysweeper.Mailer
+		if smtp := usagepipeline.NewSMTPMailer(cfg.Budgets.Alert.SMTP); smtp != nil {
+			mailer = smtp
+		}
+		startKeySweeper(ctx, keysweeper.New(container.Queries, mailer, cfg.APIKeys.InactiveKeyWarningPeriod, nil), cfg.APIKeys)
+	}
+	if cfg.APIKeys.FingerprintAbuse.Enabled {
+		abuse := cfg.APIKeys.FingerprintAbuse
+		startAbuseSuspender(ctx, keysweeper.NewAbuseSuspender(container.Queries, container.Webhooks, abuse.Webhooks, nil), abuse)
+	}
+	if container.Payloads != nil {
+		go container.Payloads.Run(ctx)
+		startPayloadSweeper(ctx, container.Payloads, cfg.Retention)
+	}
+	startUsageSpikeDetector(ctx, container.UsageSpikes)
+	go container.HealthHistory.Run(ctx)
+	if container.Webhooks != nil {
+		go container.Webhooks.Run(ctx)
+		startWebhookSweeper(ctx, container.Webhooks, cfg.Retention)
+	}
+	partitions := database.NewPartitionManager(dbPool, cfg.Retention.MetadataDays)
+	if container.UsageArchive != nil {
+		partitions.KeepNonEmpty()
+		startArchivalWorker(ctx, container.UsageArchive, cfg.Archive)
+	}
+	startPartitionManager(ctx, partitions, cfg.Retention)
+	if cfg.Deprecation.AutoDisable && container.AdminCatalog != nil {
+		startDeprecationSweeper(ctx, container.AdminCatalog, cfg.Deprecation)
+	}
+
+	if cfg.Server.WatchConfig {
+		startConfigWatcher(ctx, container)
+	}
 
 	server, err := httpserver.New(container)
 	if err != nil {
-		log.Fatalf("construct server: %v", err)
+		fatal("construct server", err)
+	}
+
+	if cfg.Server.GRPCListenAddr != "" {
+		grpcServer, err := grpcserver.New(container, exec)
+		if err != nil {
+			fatal("construct grpc server", err)
+		}
+		go func() {
+			if err := grpcServer.Listen(ctx); err != nil {
+				fatal("grpc server stopped", err)
+			}
+		}()
 	}
 
 	if err := server.Listen(ctx); err != nil && err != context.Canceled {
-		log.Fatalf("server stopped: %v", err)
+		fatal("server stopped", err)
+	}
+	if batchWorkerStopped != nil {
+		<-batchWorkerStopped
 	}
 }
 
+// stopBatchWorkerOnSignal shuts the batch worker down once ctx ends, giving
+// in-flight items batches.shutdown_item_timeout to finish before they are
+// queued again. The returned channel closes when the worker has stopped.
+func stopBatchWorkerOnSignal(ctx context.Context, worker *batchworker.Worker, cfg config.BatchesConfig) <-chan struct{} {
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownItemTimeout+10*time.Second)
+		defer cancel()
+		if err := worker.Shutdown(shutdownCtx); err != nil {
+			slog.Error("batch worker shutdown", slog.String("error", err.Error()))
+			return
+		}
+		slog.Info("batch worker stopped")
+	}()
+	return stopped
+}
+
+// fatal logs err and exits; it stands in for log.Fatalf so startup failures
+// are structured too.
+func fatal(msg string, err error) {
+	slog.Error(msg, slog.String("error", err.Error()))
+	os.Exit(1)
+}
+
 func startFileSweeper(ctx context.Context, svc *filesvc.Service, cfg config.FilesConfig) {
 	if svc == nil {
 		return
@@ -85,7 +190,7 @@ func startFileSweeper(ctx context.Context, svc *filesvc.Service, cfg config.File
 		defer ticker.Stop()
 		run := func() {
 			if err := svc.SweepExpired(ctx, int32(batchSize)); err != nil {
-				log.Printf("files sweeper error: %v", err)
+				slog.Error("files sweeper failed", slog.String("error", err.Error()))
 			}
 		}
 		run()
@@ -99,3 +204,360 @@ func startFileSweeper(ctx context.Context, svc *filesvc.Service, cfg config.File
 		}
 	}()
 }
+
+func startBatchSchedulerSweeper(ctx context.Context, scheduler *batchsvc.Scheduler, cfg config.BatchesConfig) {
+	interval := cfg.SchedulerInterval
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		run := func() {
+			if _, err := scheduler.ActivateDue(ctx); err != nil {
+				slog.Error("batch scheduler sweeper failed", slog.String("error", err.Error()))
+			}
+		}
+		run()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
+
+func startAbuseSuspender(ctx context.Context, suspender *keysweeper.AbuseSuspender, cfg config.FingerprintAbuseConfig) {
+	ticker := time.NewTicker(cfg.Interval)
+	go func() {
+		defer ticker.Stop()
+		run := func() {
+			if _, err := suspender.SuspendFingerprintAbuse(ctx, int32(cfg.Threshold), cfg.Window); err != nil {
+				slog.Error("fingerprint abuse suspender failed", slog.String("error", err.Error()))
+			}
+		}
+		run()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
+
+func startKeySweeper(ctx context.Context, sweeper *keysweeper.Sweeper, cfg config.APIKeyConfig) {
+	interval := cfg.SweepInterval
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		run := func() {
+			if _, err := sweeper.SweepInactiveAPIKeys(ctx, cfg.InactiveKeyTTL, 200); err != nil {
+				slog.Error("api key sweeper failed", slog.String("error", err.Error()))
+			}
+		}
+		run()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
+
+func startPayloadSweeper(ctx context.Context, store *usagepipeline.PayloadStore, cfg config.RetentionConfig) {
+	if store == nil {
+		return
+	}
+	interval := cfg.PayloadSweepInterval
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		run := func() {
+			if _, err := store.PurgeExpired(ctx, time.Now().UTC()); err != nil {
+				slog.Error("payload sweeper failed", slog.String("error", err.Error()))
+			}
+		}
+		run()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
+
+func startImageJobSweeper(ctx context.Context, svc *imagejobsvc.Service, cfg config.FilesConfig) {
+	interval := cfg.SweepInterval
+	if interval <= 0 {
+		interval = 15 * time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		run := func() {
+			if _, err := svc.PurgeExpired(ctx, time.Now().UTC()); err != nil {
+				slog.Error("image job sweeper failed", slog.String("error", err.Error()))
+			}
+		}
+		run()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
+
+// startTenantExportSweeper fails exports abandoned by a stopped process and
+// removes expired export archives. It runs at least every five minutes so an
+// export interrupted by a restart does not report "running" for long.
+func startTenantExportSweeper(ctx context.Context, svc *admintenantsvc.Service, cfg config.FilesConfig) {
+	interval := cfg.SweepInterval
+	if interval <= 0 || interval > 5*time.Minute {
+		interval = 5 * time.Minute
+	}
+	batchSize := cfg.SweepBatchSize
+	if batchSize <= 0 {
+		batchSize = 200
+	}
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		run := func() {
+			if err := svc.SweepExports(ctx, int32(batchSize)); err != nil {
+				slog.Error("tenant export sweeper failed", slog.String("error", err.Error()))
+			}
+		}
+		run()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
+
+func startIdempotencySweeper(ctx context.Context, store *cache.DBIdempotencyCache, cfg config.IdempotencyConfig) {
+	interval := cfg.TTL
+	if interval <= 0 || interval > time.Hour {
+		interval = time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		run := func() {
+			if _, err := store.PurgeExpired(ctx, time.Now().UTC()); err != nil {
+				slog.Error("idempotency sweeper failed", slog.String("error", err.Error()))
+			}
+		}
+		run()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
+
+func startWebhookSweeper(ctx context.Context, svc *webhooksvc.Service, cfg config.RetentionConfig) {
+	if svc == nil {
+		return
+	}
+	interval := cfg.PayloadSweepInterval
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		run := func() {
+			if _, err := svc.PurgeExpired(ctx, time.Now().UTC()); err != nil {
+				slog.Error("webhook sweeper failed", slog.String("error", err.Error()))
+			}
+		}
+		run()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
+
+func startArchivalWorker(ctx context.Context, svc *usagearchivesvc.Service, cfg config.ArchiveConfig) {
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = 7 * 24 * time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		run := func() {
+			result, err := svc.ArchiveExpired(ctx, time.Now().UTC())
+			if err != nil {
+				slog.Error("archival worker failed", slog.String("error", err.Error()))
+			}
+			for _, name := range result.Archived {
+				slog.Info("archival worker uploaded partition", slog.String("db.sql.table", name))
+			}
+		}
+		run()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
+
+func startPartitionManager(ctx context.Context, manager *database.PartitionManager, cfg config.RetentionConfig) {
+	interval := cfg.PayloadSweepInterval
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		run := func() {
+			created, dropped, err := manager.Maintain(ctx, time.Now().UTC())
+			if err != nil {
+				slog.Error("partition manager failed", slog.String("error", err.Error()))
+			}
+			for _, name := range created {
+				slog.Info("partition manager created partition", slog.String("db.sql.table", name))
+			}
+			for _, name := range dropped {
+				slog.Info("partition manager dropped partition", slog.String("db.sql.table", name))
+			}
+		}
+		run()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
+
+// startUsageSpikeDetector checks the previous hour's usage for spikes once an
+// hour.
+func startUsageSpikeDetector(ctx context.Context, detector *usagepipeline.SpikeDetector) {
+	if detector == nil {
+		return
+	}
+	ticker := time.NewTicker(time.Hour)
+	go func() {
+		defer ticker.Stop()
+		run := func() {
+			if _, err := detector.Run(ctx, time.Now()); err != nil {
+				slog.Error("usage spike detector failed", slog.String("error", err.Error()))
+			}
+		}
+		run()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
+
+func startDeprecationSweeper(ctx context.Context, svc *admincatalogsvc.Service, cfg config.DeprecationConfig) {
+	interval := cfg.SweepInterval
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		run := func() {
+			aliases, err := svc.DisableExpired(ctx, time.Now().UTC())
+			if err != nil {
+				slog.Error("deprecation sweeper failed", slog.String("error", err.Error()))
+			}
+			for _, alias := range aliases {
+				slog.Info("deprecation sweeper disabled model", slog.String("gen_ai.request.model", alias))
+			}
+		}
+		run()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
+
+// startConfigWatcher reloads the model catalog whenever the config file
+// changes. Settings that are only read at startup are reported instead.
+func startConfigWatcher(ctx context.Context, container *app.Container) {
+	path := container.Config.File
+	if path == "" {
+		slog.Warn("config watcher disabled: no config file loaded")
+		return
+	}
+	changes, err := config.NewWatcher().Watch(ctx, path)
+	if err != nil {
+		slog.Warn("config watcher disabled", slog.String("error", err.Error()))
+		return
+	}
+	go func() {
+		for range changes {
+			next, err := config.Load(config.Options{ConfigFile: path})
+			if err != nil {
+				slog.Error("config reload skipped", slog.String("error", err.Error()))
+				continue
+			}
+			for _, key := range config.RestartRequired(container.Config, next) {
+				slog.Warn("config reload: setting changed; restart required to take effect", slog.String("config.key", key))
+			}
+			if err := container.ReloadCatalog(ctx, next.ModelCatalog); err != nil {
+				slog.Error("config reload: rebuild router failed", slog.String("error", err.Error()))
+				continue
+			}
+			slog.Info("config reload: model catalog reloaded", slog.String("file.path", path))
+		}
+	}()
+}
@@ -55,29 +55,60 @@ func main() {
 		}
 
 		_, err = q.UpsertModelCatalogEntry(ctx, db.UpsertModelCatalogEntryParams{
-			Alias:              entry.Alias,
-			Provider:           entry.Provider,
-			ProviderModel:      entry.ProviderModel,
-			ContextWindow:      entry.ContextWindow,
-			MaxOutputTokens:    entry.MaxOutputTokens,
-			ModalitiesJson:     modalitiesJSON,
-			SupportsTools:      entry.SupportsTools,
-			PriceInput:         priceInput,
-			PriceOutput:        priceOutput,
-			Currency:           currency,
-			Enabled:            enabled,
-			Deployment:         entry.Deployment,
-			Endpoint:           entry.Endpoint,
-			ApiKey:             entry.APIKey,
-			ApiVersion:         entry.APIVersion,
-			Region:             entry.Region,
-			MetadataJson:       metadataJSON,
-			Weight:             int32(entry.Weight),
-			ProviderConfigJson: providerCfgJSON,
+			Alias:               entry.Alias,
+			Provider:            entry.Provider,
+			ProviderModel:       entry.ProviderModel,
+			ContextWindow:       entry.ContextWindow,
+			MaxOutputTokens:     entry.MaxOutputTokens,
+			MaxDimensions:       entry.MaxDimensions,
+			MirrorAlias:         entry.MirrorAlias,
+			MirrorSampleRate:    decimal.NewFromFloat(entry.MirrorSampleRate),
+			ModalitiesJson:      modalitiesJSON,
+			SupportsTools:       entry.SupportsTools,
+			PriceInput:          priceInput,
+			PriceOutput:         priceOutput,
+			Currency:            currency,
+			Enabled:             enabled,
+			Deployment:          entry.Deployment,
+			Endpoint:            entry.Endpoint,
+			ApiKey:              entry.APIKey,
+			ApiVersion:          entry.APIVersion,
+			Region:              entry.Region,
+			MetadataJson:        metadataJSON,
+			Weight:              int32(entry.Weight),
+			ProviderConfigJson:  providerCfgJSON,
+			RoutingPolicy:       entry.RoutingPolicy,
+			SupportsVision:      entry.SupportsVision,
+			FallbackVisionAlias: entry.FallbackVisionAlias,
+			CachedPriceRatio:    decimal.NewFromFloat(entry.CachedTokenPriceRatio),
 		})
 		if err != nil {
 			log.Fatalf("upsert %s: %v", entry.Alias, err)
 		}
+		if pooled := pooledKeyCount(entry.ProviderOverrides); pooled > 0 {
+			log.Printf("seeded %s (%d pooled api keys)", entry.Alias, pooled)
+			continue
+		}
 		log.Printf("seeded %s", entry.Alias)
 	}
 }
+
+// pooledKeyCount reports how many api_keys entries the provider blocks carry.
+// They are stored with the rest of provider_config_json; the router builds the
+// rotation pool from them at load time.
+func pooledKeyCount(o config.ProviderOverrides) int {
+	count := 0
+	if o.OpenAI != nil {
+		count += len(o.OpenAI.APIKeys)
+	}
+	if o.OpenAICompatible != nil {
+		count += len(o.OpenAICompatible.APIKeys)
+	}
+	if o.Anthropic != nil {
+		count += len(o.Anthropic.APIKeys)
+	}
+	if o.Vertex != nil {
+		count += len(o.Vertex.APIKeys)
+	}
+	return count
+}
@@ -0,0 +1,32 @@
+// Command validateconfig loads a router config the same way routerd does,
+// including ROUTER_* environment overrides, and reports whether it is valid.
+// It exits 0 when the config loads and 1 otherwise.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+)
+
+func main() {
+	configFile := flag.String("config", "", "config file to validate (defaults to ROUTER_CONFIG_FILE, then ./router.yaml)")
+	envFile := flag.String("env", "", "optional .env file to load before the config")
+	flag.Parse()
+
+	cfg, err := config.Load(config.Options{ConfigFile: *configFile, EnvFile: *envFile})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid config: %v\n", err)
+		os.Exit(1)
+	}
+
+	source := cfg.File
+	if source == "" {
+		source = "(defaults and environment only)"
+	}
+	fmt.Printf("config OK: %s\n", source)
+	fmt.Printf("  model catalog entries: %d\n", len(cfg.ModelCatalog))
+	fmt.Printf("  bootstrap tenants: %d, api keys: %d\n", len(cfg.Bootstrap.Tenants), len(cfg.Bootstrap.APIKeys))
+}
@@ -6,13 +6,16 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
+	decimal "github.com/shopspring/decimal"
 
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
 	"github.com/ncecere/open_model_gateway/backend/internal/db"
 )
 
@@ -21,6 +24,7 @@ type PersonalService struct {
 	pool            *pgxpool.Pool
 	queries         *db.Queries
 	setTenantModels func(uuid.UUID, []string)
+	budgetDefaults  func() config.BudgetConfig
 }
 
 // NewPersonalService returns a helper for managing personal tenants.
@@ -33,6 +37,12 @@ func (s *PersonalService) SetTenantModelUpdater(cb func(uuid.UUID, []string)) {
 	s.setTenantModels = cb
 }
 
+// SetBudgetDefaults registers the source of the budget settings seeded onto
+// new personal tenants.
+func (s *PersonalService) SetBudgetDefaults(cb func() config.BudgetConfig) {
+	s.budgetDefaults = cb
+}
+
 // EnsurePersonalTenant guarantees that the provided user has a dedicated personal tenant
 // and owner membership. It returns the (possibly updated) user record and the tenant.
 func (s *PersonalService) EnsurePersonalTenant(ctx context.Context, user db.User) (db.User, db.Tenant, error) {
@@ -117,6 +127,9 @@ func (s *PersonalService) EnsurePersonalTenant(ctx context.Context, user db.User
 	if err != nil {
 		return user, db.Tenant{}, err
 	}
+	if err := s.seedBudget(ctx, qtx, tenant.ID); err != nil {
+		return user, db.Tenant{}, err
+	}
 	if s.setTenantModels != nil {
 		if tenantUUID, err := toUUID(tenant.ID); err == nil {
 			s.setTenantModels(tenantUUID, aliases)
@@ -145,6 +158,52 @@ func (s *PersonalService) seedDefaultModels(ctx context.Context, q *db.Queries,
 	return s.replaceTenantModels(ctx, q, tenantID, normalizeAliases(aliases))
 }
 
+// seedBudget stores budgets.personal_default_usd as the budget override of a
+// new personal tenant so it is enforced like any tenant budget. An existing
+// override is left alone.
+func (s *PersonalService) seedBudget(ctx context.Context, q *db.Queries, tenantID pgtype.UUID) error {
+	if s.budgetDefaults == nil {
+		return nil
+	}
+	params, ok := personalBudgetOverride(tenantID, s.budgetDefaults())
+	if !ok {
+		return nil
+	}
+	if _, err := q.GetTenantBudgetOverride(ctx, tenantID); err == nil {
+		return nil
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("load personal budget: %w", err)
+	}
+	if _, err := q.UpsertTenantBudgetOverride(ctx, params); err != nil {
+		return fmt.Errorf("seed personal budget: %w", err)
+	}
+	return nil
+}
+
+// personalBudgetOverride builds the seeded budget override from the budget
+// config. It reports false when budgets.personal_default_usd is unset.
+func personalBudgetOverride(tenantID pgtype.UUID, cfg config.BudgetConfig) (db.UpsertTenantBudgetOverrideParams, bool) {
+	if cfg.PersonalDefaultUSD <= 0 {
+		return db.UpsertTenantBudgetOverrideParams{}, false
+	}
+	cooldown := int32(cfg.Alert.Cooldown / time.Second)
+	if cooldown <= 0 {
+		cooldown = int32(time.Hour / time.Second)
+	}
+	params := db.UpsertTenantBudgetOverrideParams{
+		TenantID:             tenantID,
+		BudgetUsd:            decimal.NewFromFloat(cfg.PersonalDefaultUSD).Round(2),
+		WarningThreshold:     decimal.NewFromFloat(cfg.WarningThresholdPerc),
+		RefreshSchedule:      config.NormalizeBudgetRefreshSchedule(cfg.RefreshSchedule),
+		AlertCooldownSeconds: cooldown,
+	}
+	if cfg.Alert.Enabled {
+		params.AlertEmails = cfg.Alert.Emails
+		params.AlertWebhooks = cfg.Alert.Webhooks
+	}
+	return params, true
+}
+
 // SyncDefaultModels reapplies the current default model list to every personal tenant.
 func (s *PersonalService) SyncDefaultModels(ctx context.Context) error {
 	if s == nil || s.pool == nil || s.queries == nil {
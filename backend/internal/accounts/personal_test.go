@@ -0,0 +1,46 @@
+package accounts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+)
+
+func TestPersonalBudgetOverrideSeedsDefault(t *testing.T) {
+	tenantID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	cfg := config.BudgetConfig{
+		DefaultUSD:           100,
+		PersonalDefaultUSD:   12.345,
+		WarningThresholdPerc: 0.8,
+		RefreshSchedule:      "weekly",
+		Alert: config.BudgetAlertConfig{
+			Enabled:  true,
+			Emails:   []string{"ops@example.com"},
+			Cooldown: 30 * time.Minute,
+		},
+	}
+
+	params, ok := personalBudgetOverride(tenantID, cfg)
+	if !ok {
+		t.Fatal("expected an override to be seeded")
+	}
+	if params.TenantID != tenantID || params.BudgetUsd.String() != "12.35" {
+		t.Fatalf("unexpected budget %+v", params)
+	}
+	if params.WarningThreshold.String() != "0.8" || params.RefreshSchedule != "weekly" {
+		t.Fatalf("unexpected threshold or schedule %+v", params)
+	}
+	if params.AlertCooldownSeconds != 1800 || len(params.AlertEmails) != 1 {
+		t.Fatalf("unexpected alert settings %+v", params)
+	}
+}
+
+func TestPersonalBudgetOverrideSkippedWithoutDefault(t *testing.T) {
+	if _, ok := personalBudgetOverride(pgtype.UUID{Bytes: uuid.New(), Valid: true}, config.BudgetConfig{DefaultUSD: 100}); ok {
+		t.Fatal("expected no override when personal_default_usd is zero")
+	}
+}
@@ -123,11 +123,8 @@ func (a *Adapter) ChatStream(ctx context.Context, req models.ChatRequest) (<-cha
 					}},
 				}
 				if usage.InputTokens > 0 || usage.OutputTokens > 0 {
-					chunk.Usage = &models.Usage{
-						PromptTokens:     usage.InputTokens,
-						CompletionTokens: usage.OutputTokens,
-						TotalTokens:      usage.InputTokens + usage.OutputTokens,
-					}
+					converted := usage.toModel()
+					chunk.Usage = &converted
 				}
 				_ = yield(chunk)
 				return
@@ -188,11 +185,8 @@ func (a *Adapter) ChatStream(ctx context.Context, req models.ChatRequest) (<-cha
 					}},
 				}
 				if usage.InputTokens > 0 || usage.OutputTokens > 0 {
-					chunk.Usage = &models.Usage{
-						PromptTokens:     usage.InputTokens,
-						CompletionTokens: usage.OutputTokens,
-						TotalTokens:      usage.InputTokens + usage.OutputTokens,
-					}
+					converted := usage.toModel()
+					chunk.Usage = &converted
 				}
 				_ = yield(chunk)
 				return
@@ -276,9 +270,13 @@ func buildAnthropicMessageRequest(req models.ChatRequest, defaultMax int32, stre
 				Content: []anthropicContent{{Type: "text", Text: msg.Content}},
 			})
 		default:
+			content, err := anthropicUserContent(msg)
+			if err != nil {
+				return anthropicRequestBody{}, err
+			}
 			messages = append(messages, anthropicMessage{
 				Role:    "user",
-				Content: []anthropicContent{{Type: "text", Text: msg.Content}},
+				Content: content,
 			})
 		}
 	}
@@ -333,8 +331,45 @@ type anthropicMessage struct {
 }
 
 type anthropicContent struct {
-	Type string `json:"type"`
-	Text string `json:"text"`
+	Type   string                `json:"type"`
+	Text   string                `json:"text,omitempty"`
+	Source *anthropicImageSource `json:"source,omitempty"`
+}
+
+// anthropicImageSource is an image block's source: inline base64 data or a
+// URL Anthropic fetches itself.
+type anthropicImageSource struct {
+	Type      string `json:"type"`
+	MediaType string `json:"media_type,omitempty"`
+	Data      string `json:"data,omitempty"`
+	URL       string `json:"url,omitempty"`
+}
+
+// anthropicUserContent converts a user message, keeping its image parts in
+// order. Data URLs are sent inline; http(s) URLs are passed through.
+func anthropicUserContent(msg models.ChatMessage) ([]anthropicContent, error) {
+	if len(msg.Parts) == 0 {
+		return []anthropicContent{{Type: "text", Text: msg.Content}}, nil
+	}
+	content := make([]anthropicContent, 0, len(msg.Parts))
+	for _, part := range msg.Parts {
+		switch part.Type {
+		case "image_url":
+			if mediaType, data, ok := models.ParseImageDataURL(part.ImageURL); ok {
+				content = append(content, anthropicContent{Type: "image", Source: &anthropicImageSource{Type: "base64", MediaType: mediaType, Data: data}})
+				continue
+			}
+			if !strings.HasPrefix(part.ImageURL, "https://") && !strings.HasPrefix(part.ImageURL, "http://") {
+				return nil, fmt.Errorf("anthropic: image_url must be an http(s) or base64 data URL: %w", models.ErrUnsupportedContent)
+			}
+			content = append(content, anthropicContent{Type: "image", Source: &anthropicImageSource{Type: "url", URL: part.ImageURL}})
+		default:
+			if part.Text != "" {
+				content = append(content, anthropicContent{Type: "text", Text: part.Text})
+			}
+		}
+	}
+	return content, nil
 }
 
 type anthropicResponse struct {
@@ -356,8 +391,21 @@ func (a anthropicResponse) JoinText() string {
 }
 
 type anthropicUsage struct {
-	InputTokens  int32 `json:"input_tokens"`
-	OutputTokens int32 `json:"output_tokens"`
+	InputTokens          int32 `json:"input_tokens"`
+	OutputTokens         int32 `json:"output_tokens"`
+	CacheReadInputTokens int32 `json:"cache_read_input_tokens"`
+}
+
+// toModel converts Anthropic usage. Anthropic reports cache reads separately
+// from input_tokens, so they are added back into PromptTokens.
+func (u anthropicUsage) toModel() models.Usage {
+	prompt := u.InputTokens + u.CacheReadInputTokens
+	return models.Usage{
+		PromptTokens:      prompt,
+		CompletionTokens:  u.OutputTokens,
+		TotalTokens:       prompt + u.OutputTokens,
+		CachedInputTokens: u.CacheReadInputTokens,
+	}
 }
 
 type anthropicStreamEvent struct {
@@ -405,11 +453,7 @@ func convertAnthropicResponse(resp anthropicResponse, model string) models.ChatR
 			Message:      message,
 			FinishReason: mapAnthropicStopReason(resp.StopReason),
 		}},
-		Usage: models.Usage{
-			PromptTokens:     resp.Usage.InputTokens,
-			CompletionTokens: resp.Usage.OutputTokens,
-			TotalTokens:      resp.Usage.InputTokens + resp.Usage.OutputTokens,
-		},
+		Usage: resp.Usage.toModel(),
 	}
 }
 
@@ -424,7 +468,21 @@ func mapAnthropicStopReason(reason string) string {
 	}
 }
 
+// apiError is a non-2xx response from the Anthropic API.
+type apiError struct {
+	status int
+	body   string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("anthropic api error %d: %s", e.status, e.body)
+}
+
+// HTTPStatusCode reports the response status so callers can tell transient
+// failures apart.
+func (e *apiError) HTTPStatusCode() int { return e.status }
+
 func decodeAPIError(resp *http.Response) error {
 	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
-	return fmt.Errorf("anthropic api error %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	return &apiError{status: resp.StatusCode, body: strings.TrimSpace(string(body))}
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"math"
 	"net/http"
 	"net/url"
 	"strings"
@@ -52,6 +53,8 @@ func New(opts Options) (*Adapter, error) {
 	options := []option.RequestOption{
 		azure.WithEndpoint(endpoint, opts.APIVersion),
 		azure.WithAPIKey(opts.APIKey),
+		// The executor retries transient errors itself; see retry.*.
+		option.WithMaxRetries(0),
 	}
 	options = append(options, opts.Extra...)
 
@@ -73,7 +76,7 @@ func New(opts Options) (*Adapter, error) {
 // Chat performs a non-streaming chat completion request against Azure OpenAI.
 func (a *Adapter) Chat(ctx context.Context, req models.ChatRequest) (models.ChatResponse, error) {
 	params := buildChatParams(req)
-	resp, err := a.client.Chat.Completions.New(ctx, params)
+	resp, err := a.client.Chat.Completions.New(ctx, params, chatRequestOptions(req)...)
 	if err != nil {
 		return models.ChatResponse{}, err
 	}
@@ -84,7 +87,7 @@ func (a *Adapter) Chat(ctx context.Context, req models.ChatRequest) (models.Chat
 func (a *Adapter) ChatStream(ctx context.Context, req models.ChatRequest) (<-chan models.ChatChunk, func() error, error) {
 	params := buildChatParams(req)
 	params.StreamOptions.IncludeUsage = param.NewOpt(true)
-	stream := a.client.Chat.Completions.NewStreaming(ctx, params)
+	stream := a.client.Chat.Completions.NewStreaming(ctx, params, chatRequestOptions(req)...)
 
 	if err := stream.Err(); err != nil {
 		stream.Close()
@@ -111,6 +114,11 @@ func (a *Adapter) ChatStream(ctx context.Context, req models.ChatRequest) (<-cha
 	return chunks, cancel, nil
 }
 
+// BatchSize reports no limit; Azure deployments accept input arrays natively.
+func (a *Adapter) BatchSize() int {
+	return math.MaxInt
+}
+
 // Embed creates embeddings using an Azure OpenAI deployment.
 func (a *Adapter) Embed(ctx context.Context, req models.EmbeddingsRequest) (models.EmbeddingsResponse, error) {
 	if len(req.Input) == 0 {
@@ -120,6 +128,9 @@ func (a *Adapter) Embed(ctx context.Context, req models.EmbeddingsRequest) (mode
 	params := openai.EmbeddingNewParams{
 		Model: openai.EmbeddingModel(req.Model),
 	}
+	if req.Dimensions != nil {
+		params.Dimensions = param.NewOpt(int64(*req.Dimensions))
+	}
 
 	if len(req.Input) == 1 {
 		params.Input.OfString = param.NewOpt(req.Input[0])
@@ -261,6 +272,15 @@ func (a *Adapter) Translate(ctx context.Context, req models.AudioTranscriptionRe
 	return models.AudioTranscriptionResponse{Text: resp.Text}, nil
 }
 
+// chatRequestOptions forwards request fields the typed params do not model,
+// such as a caller-supplied response_format.
+func chatRequestOptions(req models.ChatRequest) []option.RequestOption {
+	if req.ResponseFormat == nil {
+		return nil
+	}
+	return []option.RequestOption{option.WithJSONSet("response_format", req.ResponseFormat)}
+}
+
 func buildChatParams(req models.ChatRequest) openai.ChatCompletionNewParams {
 	messages := make([]openai.ChatCompletionMessageParamUnion, 0, len(req.Messages))
 	for _, msg := range req.Messages {
@@ -274,7 +294,7 @@ func buildChatParams(req models.ChatRequest) openai.ChatCompletionNewParams {
 			// tool responses require id; not supported yet, skip to user fallback
 			fallthrough
 		default:
-			union := openai.UserMessage(msg.Content)
+			union := userMessage(msg)
 			if name := strings.TrimSpace(msg.Name); name != "" {
 				if union.OfUser != nil {
 					union.OfUser.Name = param.NewOpt(name)
@@ -307,6 +327,26 @@ func buildChatParams(req models.ChatRequest) openai.ChatCompletionNewParams {
 	return params
 }
 
+// userMessage builds a user turn, forwarding image parts as array content
+// when the message has them.
+func userMessage(msg models.ChatMessage) openai.ChatCompletionMessageParamUnion {
+	if len(msg.Parts) == 0 {
+		return openai.UserMessage(msg.Content)
+	}
+	parts := make([]openai.ChatCompletionContentPartUnionParam, 0, len(msg.Parts))
+	for _, part := range msg.Parts {
+		if part.Type == "image_url" {
+			parts = append(parts, openai.ImageContentPart(openai.ChatCompletionContentPartImageImageURLParam{
+				URL:    part.ImageURL,
+				Detail: part.ImageDetail,
+			}))
+			continue
+		}
+		parts = append(parts, openai.TextContentPart(part.Text))
+	}
+	return openai.UserMessage(parts)
+}
+
 func convertChatResponse(resp openai.ChatCompletion) models.ChatResponse {
 	choices := make([]models.ChatChoice, 0, len(resp.Choices))
 	for _, choice := range resp.Choices {
@@ -326,6 +366,7 @@ func convertChatResponse(resp openai.ChatCompletion) models.ChatResponse {
 	usage.PromptTokens = int32(resp.Usage.PromptTokens)
 	usage.CompletionTokens = int32(resp.Usage.CompletionTokens)
 	usage.TotalTokens = int32(resp.Usage.TotalTokens)
+	usage.CachedInputTokens = int32(resp.Usage.PromptTokensDetails.CachedTokens)
 
 	return models.ChatResponse{
 		ID:      resp.ID,
@@ -364,9 +405,10 @@ func convertUsagePointer(u openai.CompletionUsage) *models.Usage {
 		return nil
 	}
 	usage := models.Usage{
-		PromptTokens:     int32(u.PromptTokens),
-		CompletionTokens: int32(u.CompletionTokens),
-		TotalTokens:      int32(u.TotalTokens),
+		PromptTokens:      int32(u.PromptTokens),
+		CompletionTokens:  int32(u.CompletionTokens),
+		TotalTokens:       int32(u.TotalTokens),
+		CachedInputTokens: int32(u.PromptTokensDetails.CachedTokens),
 	}
 	if usage.TotalTokens == 0 {
 		usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
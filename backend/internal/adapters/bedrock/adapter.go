@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
@@ -18,15 +19,24 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/ncecere/open_model_gateway/backend/internal/models"
+	"github.com/ncecere/open_model_gateway/backend/internal/observability"
 	"github.com/ncecere/open_model_gateway/backend/internal/providers/streamutil"
 )
 
 const (
 	ChatFormatAnthropicMessages = "anthropic_messages"
+	ChatFormatNovaMessages      = "nova_messages"
 
 	EmbeddingFormatTitanText = "titan_text"
+	EmbeddingFormatTitanV2   = "titan_v2"
+
+	ImageTaskTypeTitanV2 = "titan_image_v2"
 )
 
+// errInlineImagesOnly rejects image URLs Bedrock cannot take; the gateway
+// does not fetch remote images on the caller's behalf.
+var errInlineImagesOnly = fmt.Errorf("bedrock: image_url must be a base64 data URL: %w", models.ErrUnsupportedContent)
+
 // Options controls how the Bedrock adapter is initialised.
 type Options struct {
 	Region          string
@@ -83,6 +93,17 @@ type Adapter struct {
 	opts      Options
 }
 
+// traceContextClient forwards the caller's W3C trace context to Bedrock. The
+// headers are added after SigV4 signing, so they stay out of the signature.
+type traceContextClient struct {
+	next bedrockruntime.HTTPClient
+}
+
+func (c traceContextClient) Do(req *http.Request) (*http.Response, error) {
+	observability.InjectTraceContext(req.Context(), req.Header)
+	return c.next.Do(req)
+}
+
 // New creates a Bedrock adapter using the provided credentials/region.
 func New(ctx context.Context, opts Options) (*Adapter, error) {
 	if opts.Region == "" {
@@ -111,7 +132,9 @@ func New(ctx context.Context, opts Options) (*Adapter, error) {
 		awsCfg.Region = opts.Region
 	}
 
-	client := bedrockruntime.NewFromConfig(awsCfg)
+	client := bedrockruntime.NewFromConfig(awsCfg, func(o *bedrockruntime.Options) {
+		o.HTTPClient = traceContextClient{next: o.HTTPClient}
+	})
 	stsClient := sts.NewFromConfig(awsCfg)
 
 	if opts.AnthropicVersion == "" {
@@ -138,6 +161,8 @@ func (a *Adapter) Chat(ctx context.Context, req models.ChatRequest) (models.Chat
 	switch a.opts.ChatFormat {
 	case ChatFormatAnthropicMessages:
 		return a.chatAnthropic(ctx, req)
+	case ChatFormatNovaMessages:
+		return a.chatNova(ctx, req)
 	default:
 		return models.ChatResponse{}, fmt.Errorf("chat format %q unsupported", a.opts.ChatFormat)
 	}
@@ -229,6 +254,77 @@ func (a *Adapter) generateTitan(ctx context.Context, req models.ImageRequest) (m
 	}, nil
 }
 
+// generateTitanV2 targets amazon.titan-image-generator-v2, which adds negative
+// prompts to the v1 schema and drops the style option.
+func (a *Adapter) generateTitanV2(ctx context.Context, req models.ImageRequest) (models.ImageResponse, error) {
+	prompt := strings.TrimSpace(req.Prompt)
+	if prompt == "" {
+		return models.ImageResponse{}, errors.New("prompt required")
+	}
+	if req.ResponseFormat != "" && req.ResponseFormat != "b64_json" {
+		return models.ImageResponse{}, errors.New("bedrock image generation currently supports only base64 responses")
+	}
+
+	width, height := parseImageSize(req.Size)
+	quality := strings.TrimSpace(req.Quality)
+	if quality == "" {
+		quality = strings.TrimSpace(a.opts.Metadata["bedrock_image_quality"])
+	}
+	if quality == "" {
+		quality = "standard"
+	}
+	seed := parseIntMetadata(a.opts.Metadata, "bedrock_image_seed", int(rand.New(rand.NewSource(time.Now().UnixNano())).Int31()))
+
+	payload := titanImageV2Request{
+		TaskType: "TEXT_IMAGE",
+		TextToImageParams: titanImageV2TextParams{
+			Text:         prompt,
+			NegativeText: strings.TrimSpace(a.opts.Metadata["bedrock_image_negative_prompt"]),
+		},
+		ImageGenerationConfig: titanImageV2Config{
+			NumberOfImages: clampImageCount(req.N, 5),
+			Quality:        quality,
+			CfgScale:       parseFloatMetadata(a.opts.Metadata, "bedrock_image_cfg_scale", 8),
+			Height:         height,
+			Width:          width,
+			Seed:           int32(seed),
+		},
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return models.ImageResponse{}, fmt.Errorf("encode titan image v2 request: %w", err)
+	}
+
+	resp, err := a.client.InvokeModel(ctx, &bedrockruntime.InvokeModelInput{
+		ModelId:     aws.String(a.opts.ModelID),
+		Body:        body,
+		ContentType: aws.String("application/json"),
+		Accept:      aws.String("application/json"),
+	})
+	if err != nil {
+		return models.ImageResponse{}, err
+	}
+
+	var parsed titanImageResponse
+	if err := json.Unmarshal(resp.Body, &parsed); err != nil {
+		return models.ImageResponse{}, fmt.Errorf("decode titan image v2 response: %w", err)
+	}
+	if len(parsed.Images) == 0 {
+		return models.ImageResponse{}, errors.New("titan image v2 response missing images")
+	}
+
+	data := make([]models.ImageData, 0, len(parsed.Images))
+	for _, img := range parsed.Images {
+		data = append(data, models.ImageData{B64JSON: img})
+	}
+
+	return models.ImageResponse{
+		Created: time.Now().UTC(),
+		Data:    data,
+	}, nil
+}
+
 func (a *Adapter) generateStableDiffusion(ctx context.Context, req models.ImageRequest) (models.ImageResponse, error) {
 	prompt := strings.TrimSpace(req.Prompt)
 	if prompt == "" {
@@ -553,6 +649,11 @@ func (a *Adapter) Models(ctx context.Context) ([]models.Model, error) {
 	return nil, errors.New("bedrock models listing not implemented")
 }
 
+// BatchSize is 1: Titan embeds a single inputText per InvokeModel call.
+func (a *Adapter) BatchSize() int {
+	return 1
+}
+
 // Embed generates embeddings using the configured embedding format.
 func (a *Adapter) Embed(ctx context.Context, req models.EmbeddingsRequest) (models.EmbeddingsResponse, error) {
 	if a.opts.EmbeddingFormat == "" {
@@ -562,6 +663,8 @@ func (a *Adapter) Embed(ctx context.Context, req models.EmbeddingsRequest) (mode
 	switch a.opts.EmbeddingFormat {
 	case EmbeddingFormatTitanText:
 		return a.embedTitan(ctx, req)
+	case EmbeddingFormatTitanV2:
+		return a.embedTitanV2(ctx, req)
 	default:
 		return models.EmbeddingsResponse{}, fmt.Errorf("embedding format %q unsupported", a.opts.EmbeddingFormat)
 	}
@@ -572,6 +675,8 @@ func (a *Adapter) Generate(ctx context.Context, req models.ImageRequest) (models
 	switch {
 	case task == "":
 		return models.ImageResponse{}, errors.New("image generation not supported for this bedrock route")
+	case task == ImageTaskTypeTitanV2:
+		return a.generateTitanV2(ctx, req)
 	case strings.Contains(task, "stability"), strings.Contains(task, "diffusion"):
 		return a.generateStableDiffusion(ctx, req)
 	default:
@@ -660,6 +765,33 @@ func (a *Adapter) chatAnthropic(ctx context.Context, req models.ChatRequest) (mo
 	return resp, nil
 }
 
+func (a *Adapter) chatNova(ctx context.Context, req models.ChatRequest) (models.ChatResponse, error) {
+	if len(req.Messages) == 0 {
+		return models.ChatResponse{}, errors.New("at least one message is required")
+	}
+
+	body, err := a.buildNovaBody(req)
+	if err != nil {
+		return models.ChatResponse{}, err
+	}
+
+	out, err := a.client.InvokeModel(ctx, &bedrockruntime.InvokeModelInput{
+		ModelId:     aws.String(a.opts.ModelID),
+		Body:        body,
+		ContentType: aws.String("application/json"),
+		Accept:      aws.String("application/json"),
+	})
+	if err != nil {
+		return models.ChatResponse{}, err
+	}
+
+	var parsed novaResponse
+	if err := json.Unmarshal(out.Body, &parsed); err != nil {
+		return models.ChatResponse{}, fmt.Errorf("decode nova response: %w", err)
+	}
+	return parsed.toChatResponse(req.Model), nil
+}
+
 func (a *Adapter) embedTitan(ctx context.Context, req models.EmbeddingsRequest) (models.EmbeddingsResponse, error) {
 	if len(req.Input) == 0 {
 		return models.EmbeddingsResponse{}, errors.New("embedding input required")
@@ -675,7 +807,9 @@ func (a *Adapter) embedTitan(ctx context.Context, req models.EmbeddingsRequest)
 		if body.InputText == "" {
 			return models.EmbeddingsResponse{}, fmt.Errorf("input %d is empty", idx)
 		}
-		if a.opts.EmbedDimensions > 0 {
+		if req.Dimensions != nil {
+			body.Dimensions = *req.Dimensions
+		} else if a.opts.EmbedDimensions > 0 {
 			body.Dimensions = a.opts.EmbedDimensions
 		}
 		if a.opts.EmbedNormalize {
@@ -721,6 +855,77 @@ func (a *Adapter) embedTitan(ctx context.Context, req models.EmbeddingsRequest)
 	}, nil
 }
 
+// embedTitanV2 targets amazon.titan-embed-text-v2, whose output embedding
+// length is limited to 256, 512, or 1024 dimensions.
+func (a *Adapter) embedTitanV2(ctx context.Context, req models.EmbeddingsRequest) (models.EmbeddingsResponse, error) {
+	if len(req.Input) == 0 {
+		return models.EmbeddingsResponse{}, errors.New("embedding input required")
+	}
+
+	var dimensions int32
+	if req.Dimensions != nil {
+		dimensions = *req.Dimensions
+	} else if a.opts.EmbedDimensions > 0 {
+		dimensions = a.opts.EmbedDimensions
+	}
+	switch dimensions {
+	case 0, 256, 512, 1024:
+	default:
+		return models.EmbeddingsResponse{}, fmt.Errorf("titan v2 dimensions must be 256, 512, or 1024 (got %d)", dimensions)
+	}
+
+	embeddings := make([]models.Embedding, 0, len(req.Input))
+	var totalTokens int32
+
+	for idx, text := range req.Input {
+		body := titanV2EmbedRequest{
+			InputText:  strings.TrimSpace(text),
+			Dimensions: dimensions,
+		}
+		if body.InputText == "" {
+			return models.EmbeddingsResponse{}, fmt.Errorf("input %d is empty", idx)
+		}
+		if a.opts.EmbedNormalize {
+			body.Normalize = aws.Bool(true)
+		}
+
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return models.EmbeddingsResponse{}, fmt.Errorf("encode titan v2 request: %w", err)
+		}
+
+		out, err := a.client.InvokeModel(ctx, &bedrockruntime.InvokeModelInput{
+			ModelId:     aws.String(a.opts.ModelID),
+			Body:        raw,
+			ContentType: aws.String("application/json"),
+			Accept:      aws.String("application/json"),
+		})
+		if err != nil {
+			return models.EmbeddingsResponse{}, err
+		}
+
+		vector, tokens, err := parseTitanV2Embedding(out.Body)
+		if err != nil {
+			return models.EmbeddingsResponse{}, err
+		}
+
+		embeddings = append(embeddings, models.Embedding{
+			Index:  idx,
+			Vector: vector,
+		})
+		totalTokens += tokens
+	}
+
+	return models.EmbeddingsResponse{
+		Model:      req.Model,
+		Embeddings: embeddings,
+		Usage: models.Usage{
+			PromptTokens: totalTokens,
+			TotalTokens:  totalTokens,
+		},
+	}, nil
+}
+
 func (a *Adapter) buildAnthropicBody(req models.ChatRequest) ([]byte, error) {
 	var systemPrompts []string
 	messages := make([]anthropicMessage, 0, len(req.Messages))
@@ -737,11 +942,13 @@ func (a *Adapter) buildAnthropicBody(req models.ChatRequest) ([]byte, error) {
 				},
 			})
 		default:
+			content, err := anthropicUserContent(msg)
+			if err != nil {
+				return nil, err
+			}
 			messages = append(messages, anthropicMessage{
-				Role: "user",
-				Content: []anthropicContent{
-					{Type: "text", Text: msg.Content},
-				},
+				Role:    "user",
+				Content: content,
 			})
 		}
 	}
@@ -795,8 +1002,40 @@ type anthropicMessage struct {
 }
 
 type anthropicContent struct {
-	Type string `json:"type"`
-	Text string `json:"text"`
+	Type   string                `json:"type"`
+	Text   string                `json:"text,omitempty"`
+	Source *anthropicImageSource `json:"source,omitempty"`
+}
+
+type anthropicImageSource struct {
+	Type      string `json:"type"`
+	MediaType string `json:"media_type"`
+	Data      string `json:"data"`
+}
+
+// anthropicUserContent converts a user message, keeping its image parts in
+// order. Bedrock only accepts inline images, so image parts must be base64
+// data URLs.
+func anthropicUserContent(msg models.ChatMessage) ([]anthropicContent, error) {
+	if len(msg.Parts) == 0 {
+		return []anthropicContent{{Type: "text", Text: msg.Content}}, nil
+	}
+	content := make([]anthropicContent, 0, len(msg.Parts))
+	for _, part := range msg.Parts {
+		switch part.Type {
+		case "image_url":
+			mediaType, data, ok := models.ParseImageDataURL(part.ImageURL)
+			if !ok {
+				return nil, errInlineImagesOnly
+			}
+			content = append(content, anthropicContent{Type: "image", Source: &anthropicImageSource{Type: "base64", MediaType: mediaType, Data: data}})
+		default:
+			if part.Text != "" {
+				content = append(content, anthropicContent{Type: "text", Text: part.Text})
+			}
+		}
+	}
+	return content, nil
 }
 
 type anthropicUsage struct {
@@ -871,6 +1110,177 @@ func mapAnthropicStopReason(reason string) string {
 	}
 }
 
+func (a *Adapter) buildNovaBody(req models.ChatRequest) ([]byte, error) {
+	var system []novaContent
+	messages := make([]novaMessage, 0, len(req.Messages))
+
+	for _, msg := range req.Messages {
+		switch strings.ToLower(msg.Role) {
+		case "system":
+			system = append(system, novaContent{Text: msg.Content})
+		case "assistant":
+			messages = append(messages, novaMessage{
+				Role:    "assistant",
+				Content: []novaContent{{Text: msg.Content}},
+			})
+		default:
+			content, err := novaUserContent(msg)
+			if err != nil {
+				return nil, err
+			}
+			messages = append(messages, novaMessage{
+				Role:    "user",
+				Content: content,
+			})
+		}
+	}
+
+	body := novaRequest{
+		SchemaVersion: "messages-v1",
+		System:        system,
+		Messages:      messages,
+	}
+
+	maxTokens := int32(0)
+	if req.MaxTokens != nil {
+		maxTokens = *req.MaxTokens
+	} else if a.opts.DefaultMaxTokens > 0 {
+		maxTokens = a.opts.DefaultMaxTokens
+	}
+	if maxTokens > 0 {
+		body.InferenceConfig.MaxTokens = maxTokens
+	}
+	if req.Temperature != nil {
+		temp := float64(*req.Temperature)
+		body.InferenceConfig.Temperature = &temp
+	}
+	if req.TopP != nil {
+		topP := float64(*req.TopP)
+		body.InferenceConfig.TopP = &topP
+	}
+	if len(req.Stop) > 0 {
+		body.InferenceConfig.StopSequences = append(body.InferenceConfig.StopSequences, req.Stop...)
+	}
+
+	return json.Marshal(body)
+}
+
+// novaRequest models the Amazon Nova "messages-v1" InvokeModel payload. Unlike
+// Claude, system prompts are a list of content blocks and sampling settings
+// live under inferenceConfig.
+type novaRequest struct {
+	SchemaVersion   string              `json:"schemaVersion"`
+	System          []novaContent       `json:"system,omitempty"`
+	Messages        []novaMessage       `json:"messages"`
+	InferenceConfig novaInferenceConfig `json:"inferenceConfig"`
+}
+
+type novaMessage struct {
+	Role    string        `json:"role"`
+	Content []novaContent `json:"content"`
+}
+
+type novaContent struct {
+	Text  string     `json:"text,omitempty"`
+	Image *novaImage `json:"image,omitempty"`
+}
+
+type novaImage struct {
+	Format string          `json:"format"`
+	Source novaImageSource `json:"source"`
+}
+
+type novaImageSource struct {
+	Bytes string `json:"bytes"`
+}
+
+// novaUserContent converts a user message, keeping its image parts in order.
+// Nova takes the image format ("png", "jpeg", ...) rather than a media type.
+func novaUserContent(msg models.ChatMessage) ([]novaContent, error) {
+	if len(msg.Parts) == 0 {
+		return []novaContent{{Text: msg.Content}}, nil
+	}
+	content := make([]novaContent, 0, len(msg.Parts))
+	for _, part := range msg.Parts {
+		switch part.Type {
+		case "image_url":
+			mediaType, data, ok := models.ParseImageDataURL(part.ImageURL)
+			if !ok {
+				return nil, errInlineImagesOnly
+			}
+			content = append(content, novaContent{Image: &novaImage{
+				Format: strings.TrimPrefix(mediaType, "image/"),
+				Source: novaImageSource{Bytes: data},
+			}})
+		default:
+			if part.Text != "" {
+				content = append(content, novaContent{Text: part.Text})
+			}
+		}
+	}
+	return content, nil
+}
+
+type novaInferenceConfig struct {
+	MaxTokens     int32    `json:"maxTokens,omitempty"`
+	Temperature   *float64 `json:"temperature,omitempty"`
+	TopP          *float64 `json:"topP,omitempty"`
+	StopSequences []string `json:"stopSequences,omitempty"`
+}
+
+type novaResponse struct {
+	Output struct {
+		Message novaMessage `json:"message"`
+	} `json:"output"`
+	StopReason string `json:"stopReason"`
+	Usage      struct {
+		InputTokens  int32 `json:"inputTokens"`
+		OutputTokens int32 `json:"outputTokens"`
+		TotalTokens  int32 `json:"totalTokens"`
+	} `json:"usage"`
+}
+
+func (r novaResponse) toChatResponse(model string) models.ChatResponse {
+	var b strings.Builder
+	for _, c := range r.Output.Message.Content {
+		b.WriteString(c.Text)
+	}
+	total := r.Usage.TotalTokens
+	if total == 0 {
+		total = r.Usage.InputTokens + r.Usage.OutputTokens
+	}
+	created := time.Now().UTC()
+	return models.ChatResponse{
+		ID:      fmt.Sprintf("chatcmpl-bedrock-%d", created.UnixNano()),
+		Created: created,
+		Model:   model,
+		Choices: []models.ChatChoice{
+			{
+				Index: 0,
+				Message: models.ChatMessage{
+					Role:    "assistant",
+					Content: b.String(),
+				},
+				FinishReason: mapNovaStopReason(r.StopReason),
+			},
+		},
+		Usage: models.Usage{
+			PromptTokens:     r.Usage.InputTokens,
+			CompletionTokens: r.Usage.OutputTokens,
+			TotalTokens:      total,
+		},
+	}
+}
+
+func mapNovaStopReason(reason string) string {
+	switch reason {
+	case "content_filtered":
+		return "content_filter"
+	default:
+		return mapAnthropicStopReason(reason)
+	}
+}
+
 type titanEmbedRequest struct {
 	InputText  string `json:"inputText"`
 	Dimensions int32  `json:"dimensions,omitempty"`
@@ -922,6 +1332,26 @@ func int32Ptr(v int32) *int32 {
 	return &v
 }
 
+type titanV2EmbedRequest struct {
+	InputText string `json:"inputText"`
+	// Dimensions is the output embedding length.
+	Dimensions int32 `json:"dimensions,omitempty"`
+	Normalize  *bool `json:"normalize,omitempty"`
+}
+
+type titanV2EmbedResponse struct {
+	Embedding           []float64 `json:"embedding"`
+	InputTextTokenCount int32     `json:"inputTextTokenCount"`
+}
+
+func parseTitanV2Embedding(payload []byte) ([]float32, int32, error) {
+	var parsed titanV2EmbedResponse
+	if err := json.Unmarshal(payload, &parsed); err != nil || len(parsed.Embedding) == 0 {
+		return nil, 0, errors.New("unexpected titan v2 embedding response")
+	}
+	return float64To32(parsed.Embedding), parsed.InputTextTokenCount, nil
+}
+
 type titanEmbedResponseAlt struct {
 	Embeddings []struct {
 		Values []float64 `json:"values"`
@@ -971,6 +1401,26 @@ type titanImageConfig struct {
 	Style          string  `json:"style,omitempty"`
 }
 
+type titanImageV2Request struct {
+	TaskType              string                 `json:"taskType"`
+	TextToImageParams     titanImageV2TextParams `json:"textToImageParams"`
+	ImageGenerationConfig titanImageV2Config     `json:"imageGenerationConfig"`
+}
+
+type titanImageV2TextParams struct {
+	Text         string `json:"text"`
+	NegativeText string `json:"negativeText,omitempty"`
+}
+
+type titanImageV2Config struct {
+	NumberOfImages int     `json:"numberOfImages"`
+	Quality        string  `json:"quality"`
+	CfgScale       float64 `json:"cfgScale"`
+	Height         int     `json:"height"`
+	Width          int     `json:"width"`
+	Seed           int32   `json:"seed"`
+}
+
 type titanImageResponse struct {
 	Images []string `json:"images"`
 }
@@ -0,0 +1,135 @@
+package bedrock
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+	"github.com/ncecere/open_model_gateway/backend/internal/providers/fixtures"
+)
+
+func TestBuildNovaBody(t *testing.T) {
+	a := &Adapter{opts: Options{DefaultMaxTokens: 256}}
+	temp := float32(0.5)
+	raw, err := a.buildNovaBody(models.ChatRequest{
+		Messages: []models.ChatMessage{
+			{Role: "system", Content: "be brief"},
+			{Role: "user", Content: "hi"},
+			{Role: "assistant", Content: "hello"},
+		},
+		Temperature: &temp,
+		Stop:        []string{"END"},
+	})
+	if err != nil {
+		t.Fatalf("build body: %v", err)
+	}
+
+	var body map[string]any
+	if err := json.Unmarshal(raw, &body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if body["schemaVersion"] != "messages-v1" {
+		t.Fatalf("unexpected schema version %v", body["schemaVersion"])
+	}
+	system, ok := body["system"].([]any)
+	if !ok || len(system) != 1 || system[0].(map[string]any)["text"] != "be brief" {
+		t.Fatalf("system prompt should be a list of text blocks, got %v", body["system"])
+	}
+	if messages := body["messages"].([]any); len(messages) != 2 {
+		t.Fatalf("expected system prompt lifted out of messages, got %v", messages)
+	}
+	cfg := body["inferenceConfig"].(map[string]any)
+	if cfg["maxTokens"] != float64(256) || cfg["temperature"] != 0.5 || len(cfg["stopSequences"].([]any)) != 1 {
+		t.Fatalf("unexpected inferenceConfig %v", cfg)
+	}
+	if _, ok := cfg["topP"]; ok {
+		t.Fatalf("unset topP should be omitted, got %v", cfg)
+	}
+}
+
+func TestNovaResponseFixture(t *testing.T) {
+	var parsed novaResponse
+	if err := fixtures.Load("bedrock_nova_response.json", &parsed); err != nil {
+		t.Fatalf("load fixture: %v", err)
+	}
+	resp := parsed.toChatResponse("nova-pro")
+	if resp.Model != "nova-pro" || len(resp.Choices) != 1 {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	choice := resp.Choices[0]
+	if choice.Message.Content != "Hello from Nova" || choice.FinishReason != "length" {
+		t.Fatalf("unexpected choice %+v", choice)
+	}
+	if resp.Usage.PromptTokens != 12 || resp.Usage.CompletionTokens != 30 || resp.Usage.TotalTokens != 42 {
+		t.Fatalf("unexpected usage %+v", resp.Usage)
+	}
+	if got := mapNovaStopReason("content_filtered"); got != "content_filter" {
+		t.Fatalf("content_filtered should map to content_filter, got %s", got)
+	}
+}
+
+func TestParseTitanV2EmbeddingFixture(t *testing.T) {
+	payload, err := fixtures.Read("titan_embed_v2.json")
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	vec, tokens, err := parseTitanV2Embedding(payload)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if tokens != 9 || len(vec) != 3 || vec[1] != float32(-0.5) {
+		t.Fatalf("unexpected embedding %v (%d tokens)", vec, tokens)
+	}
+	if _, _, err := parseTitanV2Embedding([]byte(`{"embedding":[]}`)); err == nil {
+		t.Fatal("expected error for an empty embedding")
+	}
+}
+
+func TestBedrockBodiesForwardImages(t *testing.T) {
+	a := &Adapter{opts: Options{AnthropicVersion: "bedrock-2023-05-31"}}
+	req := models.ChatRequest{Messages: []models.ChatMessage{{
+		Role:    "user",
+		Content: "what is this?",
+		Parts: []models.ChatContentPart{
+			{Type: "text", Text: "what is this?"},
+			{Type: "image_url", ImageURL: "data:image/png;base64,iVBORw0KGgo="},
+		},
+	}}}
+
+	raw, err := a.buildAnthropicBody(req)
+	if err != nil {
+		t.Fatalf("build anthropic body: %v", err)
+	}
+	var claude anthropicRequest
+	if err := json.Unmarshal(raw, &claude); err != nil {
+		t.Fatalf("decode anthropic body: %v", err)
+	}
+	content := claude.Messages[0].Content
+	if len(content) != 2 || content[1].Type != "image" || content[1].Source == nil ||
+		content[1].Source.MediaType != "image/png" || content[1].Source.Data != "iVBORw0KGgo=" {
+		t.Fatalf("image part not forwarded to claude: %+v", content)
+	}
+
+	raw, err = a.buildNovaBody(req)
+	if err != nil {
+		t.Fatalf("build nova body: %v", err)
+	}
+	var nova novaRequest
+	if err := json.Unmarshal(raw, &nova); err != nil {
+		t.Fatalf("decode nova body: %v", err)
+	}
+	parts := nova.Messages[0].Content
+	if len(parts) != 2 || parts[0].Text != "what is this?" || parts[1].Image == nil ||
+		parts[1].Image.Format != "png" || parts[1].Image.Source.Bytes != "iVBORw0KGgo=" {
+		t.Fatalf("image part not forwarded to nova: %+v", parts)
+	}
+
+	req.Messages[0].Parts[1].ImageURL = "https://example.com/cat.png"
+	if _, err := a.buildAnthropicBody(req); !errors.Is(err, models.ErrUnsupportedContent) {
+		t.Fatalf("expected ErrUnsupportedContent for a remote image, got %v", err)
+	}
+	if _, err := a.buildNovaBody(req); !errors.Is(err, models.ErrUnsupportedContent) {
+		t.Fatalf("expected ErrUnsupportedContent for a remote image, got %v", err)
+	}
+}
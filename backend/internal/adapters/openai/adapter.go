@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"math"
 	"net/http"
 	"strings"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"github.com/openai/openai-go/v3/packages/param"
 
 	"github.com/ncecere/open_model_gateway/backend/internal/models"
+	"github.com/ncecere/open_model_gateway/backend/internal/observability"
 	"github.com/ncecere/open_model_gateway/backend/internal/providers/streamutil"
 )
 
@@ -22,7 +24,9 @@ type Options struct {
 	APIKey       string
 	BaseURL      string
 	Organization string
-	Extra        []option.RequestOption
+	// HTTPClient overrides the SDK transport, e.g. to rotate pooled API keys.
+	HTTPClient *http.Client
+	Extra      []option.RequestOption
 }
 
 // Adapter wraps the official OpenAI SDK for native + compatible deployments.
@@ -37,13 +41,19 @@ func New(opts Options) (*Adapter, error) {
 		return nil, errors.New("openai: api key required")
 	}
 
-	requestOpts := []option.RequestOption{option.WithAPIKey(opts.APIKey)}
+	// The executor retries transient errors itself (see retry.*), so the
+	// SDK's own retries are off to avoid multiplying attempts.
+	requestOpts := []option.RequestOption{option.WithAPIKey(opts.APIKey), option.WithMaxRetries(0)}
 	if strings.TrimSpace(opts.BaseURL) != "" {
 		requestOpts = append(requestOpts, option.WithBaseURL(strings.TrimRight(opts.BaseURL, "/")))
 	}
 	if strings.TrimSpace(opts.Organization) != "" {
 		requestOpts = append(requestOpts, option.WithOrganization(strings.TrimSpace(opts.Organization)))
 	}
+	if opts.HTTPClient != nil {
+		requestOpts = append(requestOpts, option.WithHTTPClient(opts.HTTPClient))
+	}
+	requestOpts = append(requestOpts, option.WithMiddleware(injectTraceContext))
 	requestOpts = append(requestOpts, opts.Extra...)
 
 	client := openai.NewClient(requestOpts...)
@@ -52,10 +62,16 @@ func New(opts Options) (*Adapter, error) {
 	return &Adapter{client: &client, httpClient: httpClient}, nil
 }
 
+// injectTraceContext forwards the caller's W3C trace context to OpenAI.
+func injectTraceContext(req *http.Request, next option.MiddlewareNext) (*http.Response, error) {
+	observability.InjectTraceContext(req.Context(), req.Header)
+	return next(req)
+}
+
 // Chat performs a non-streaming chat completion request.
 func (a *Adapter) Chat(ctx context.Context, req models.ChatRequest) (models.ChatResponse, error) {
 	params := buildChatParams(req)
-	resp, err := a.client.Chat.Completions.New(ctx, params)
+	resp, err := a.client.Chat.Completions.New(ctx, params, chatRequestOptions(req)...)
 	if err != nil {
 		return models.ChatResponse{}, err
 	}
@@ -66,7 +82,7 @@ func (a *Adapter) Chat(ctx context.Context, req models.ChatRequest) (models.Chat
 func (a *Adapter) ChatStream(ctx context.Context, req models.ChatRequest) (<-chan models.ChatChunk, func() error, error) {
 	params := buildChatParams(req)
 	params.StreamOptions.IncludeUsage = param.NewOpt(true)
-	stream := a.client.Chat.Completions.NewStreaming(ctx, params)
+	stream := a.client.Chat.Completions.NewStreaming(ctx, params, chatRequestOptions(req)...)
 	if err := stream.Err(); err != nil {
 		stream.Close()
 		return nil, nil, err
@@ -85,12 +101,20 @@ func (a *Adapter) ChatStream(ctx context.Context, req models.ChatRequest) (<-cha
 	return chunks, cancel, nil
 }
 
+// BatchSize reports no limit; the embeddings API accepts input arrays natively.
+func (a *Adapter) BatchSize() int {
+	return math.MaxInt
+}
+
 // Embed creates embeddings using the selected OpenAI model.
 func (a *Adapter) Embed(ctx context.Context, req models.EmbeddingsRequest) (models.EmbeddingsResponse, error) {
 	if len(req.Input) == 0 {
 		return models.EmbeddingsResponse{}, errors.New("openai: embeddings input required")
 	}
 	params := openai.EmbeddingNewParams{Model: openai.EmbeddingModel(req.Model)}
+	if req.Dimensions != nil {
+		params.Dimensions = param.NewOpt(int64(*req.Dimensions))
+	}
 	if len(req.Input) == 1 {
 		params.Input.OfString = param.NewOpt(req.Input[0])
 	} else {
@@ -361,6 +385,15 @@ func convertAudioUsage(usage openai.AudioTranscriptionNewResponseUnionUsage) mod
 	}
 }
 
+// chatRequestOptions forwards request fields the typed params do not model,
+// such as a caller-supplied response_format.
+func chatRequestOptions(req models.ChatRequest) []option.RequestOption {
+	if req.ResponseFormat == nil {
+		return nil
+	}
+	return []option.RequestOption{option.WithJSONSet("response_format", req.ResponseFormat)}
+}
+
 func buildChatParams(req models.ChatRequest) openai.ChatCompletionNewParams {
 	messages := make([]openai.ChatCompletionMessageParamUnion, 0, len(req.Messages))
 	for _, msg := range req.Messages {
@@ -373,7 +406,7 @@ func buildChatParams(req models.ChatRequest) openai.ChatCompletionNewParams {
 		case "tool":
 			fallthrough
 		default:
-			union := openai.UserMessage(msg.Content)
+			union := userMessage(msg)
 			if name := strings.TrimSpace(msg.Name); name != "" && union.OfUser != nil {
 				union.OfUser.Name = param.NewOpt(name)
 			}
@@ -402,6 +435,26 @@ func buildChatParams(req models.ChatRequest) openai.ChatCompletionNewParams {
 	return params
 }
 
+// userMessage builds a user turn, forwarding image parts as array content
+// when the message has them.
+func userMessage(msg models.ChatMessage) openai.ChatCompletionMessageParamUnion {
+	if len(msg.Parts) == 0 {
+		return openai.UserMessage(msg.Content)
+	}
+	parts := make([]openai.ChatCompletionContentPartUnionParam, 0, len(msg.Parts))
+	for _, part := range msg.Parts {
+		if part.Type == "image_url" {
+			parts = append(parts, openai.ImageContentPart(openai.ChatCompletionContentPartImageImageURLParam{
+				URL:    part.ImageURL,
+				Detail: part.ImageDetail,
+			}))
+			continue
+		}
+		parts = append(parts, openai.TextContentPart(part.Text))
+	}
+	return openai.UserMessage(parts)
+}
+
 func convertChatResponse(resp openai.ChatCompletion) models.ChatResponse {
 	choices := make([]models.ChatChoice, 0, len(resp.Choices))
 	for _, choice := range resp.Choices {
@@ -417,9 +470,10 @@ func convertChatResponse(resp openai.ChatCompletion) models.ChatResponse {
 	}
 
 	usage := models.Usage{
-		PromptTokens:     int32(resp.Usage.PromptTokens),
-		CompletionTokens: int32(resp.Usage.CompletionTokens),
-		TotalTokens:      int32(resp.Usage.TotalTokens),
+		PromptTokens:      int32(resp.Usage.PromptTokens),
+		CompletionTokens:  int32(resp.Usage.CompletionTokens),
+		TotalTokens:       int32(resp.Usage.TotalTokens),
+		CachedInputTokens: int32(resp.Usage.PromptTokensDetails.CachedTokens),
 	}
 
 	return models.ChatResponse{
@@ -459,9 +513,10 @@ func convertUsagePointer(u openai.CompletionUsage) *models.Usage {
 		return nil
 	}
 	usage := models.Usage{
-		PromptTokens:     int32(u.PromptTokens),
-		CompletionTokens: int32(u.CompletionTokens),
-		TotalTokens:      int32(u.TotalTokens),
+		PromptTokens:      int32(u.PromptTokens),
+		CompletionTokens:  int32(u.CompletionTokens),
+		TotalTokens:       int32(u.TotalTokens),
+		CachedInputTokens: int32(u.PromptTokensDetails.CachedTokens),
 	}
 	if usage.TotalTokens == 0 {
 		usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
@@ -0,0 +1,141 @@
+// Package plugin adapts out-of-tree provider plugins to the gateway's
+// provider interfaces. RPC plugins are HTTP services that receive one JSON
+// envelope per call; Go plugins are shared objects loaded at startup.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+)
+
+// Operations carried in the RPC envelope.
+const (
+	OperationChat            = "chat"
+	OperationEmbeddings      = "embeddings"
+	OperationImageGeneration = "image_generation"
+	OperationImageEdit       = "image_edit"
+	OperationImageVariation  = "image_variation"
+)
+
+// Options configure an RPC plugin adapter.
+type Options struct {
+	// Name is the plugin name from providers.plugins; it is echoed in every
+	// envelope so one endpoint can serve several plugins.
+	Name       string
+	Endpoint   string
+	HTTPClient *http.Client
+}
+
+// Request is the envelope POSTed to an RPC plugin. Request holds the
+// operation's models request (models.ChatRequest for "chat", and so on); the
+// plugin answers with the matching models response as the JSON body.
+type Request struct {
+	Plugin    string `json:"plugin"`
+	Operation string `json:"operation"`
+	Request   any    `json:"request"`
+}
+
+// Adapter forwards chat, embeddings, and image calls to an RPC plugin.
+type Adapter struct {
+	name     string
+	endpoint string
+	client   *http.Client
+}
+
+// New creates an RPC plugin adapter.
+func New(opts Options) (*Adapter, error) {
+	endpoint := strings.TrimSpace(opts.Endpoint)
+	if endpoint == "" {
+		return nil, errors.New("plugin: endpoint required")
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = &http.Client{Timeout: 120 * time.Second}
+	}
+	return &Adapter{name: opts.Name, endpoint: endpoint, client: opts.HTTPClient}, nil
+}
+
+func (a *Adapter) Chat(ctx context.Context, req models.ChatRequest) (models.ChatResponse, error) {
+	// Plugins answer with a whole response; streaming is not part of the
+	// protocol.
+	req.Stream = false
+	var resp models.ChatResponse
+	err := a.call(ctx, OperationChat, req, &resp)
+	return resp, err
+}
+
+func (a *Adapter) Embed(ctx context.Context, req models.EmbeddingsRequest) (models.EmbeddingsResponse, error) {
+	var resp models.EmbeddingsResponse
+	err := a.call(ctx, OperationEmbeddings, req, &resp)
+	return resp, err
+}
+
+// BatchSize reports no limit; plugins receive every input in one call.
+func (a *Adapter) BatchSize() int { return 0 }
+
+func (a *Adapter) Generate(ctx context.Context, req models.ImageRequest) (models.ImageResponse, error) {
+	var resp models.ImageResponse
+	err := a.call(ctx, OperationImageGeneration, req, &resp)
+	return resp, err
+}
+
+func (a *Adapter) Edit(ctx context.Context, req models.ImageEditRequest) (models.ImageResponse, error) {
+	var resp models.ImageResponse
+	err := a.call(ctx, OperationImageEdit, req, &resp)
+	return resp, err
+}
+
+func (a *Adapter) Variation(ctx context.Context, req models.ImageVariationRequest) (models.ImageResponse, error) {
+	var resp models.ImageResponse
+	err := a.call(ctx, OperationImageVariation, req, &resp)
+	return resp, err
+}
+
+func (a *Adapter) call(ctx context.Context, operation string, payload any, out any) error {
+	body, err := json.Marshal(Request{Plugin: a.name, Operation: operation, Request: payload})
+	if err != nil {
+		return err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, a.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+
+	resp, err := a.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return &apiError{plugin: a.name, status: resp.StatusCode, body: strings.TrimSpace(string(raw))}
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("plugin %s: decode %s response: %w", a.name, operation, err)
+	}
+	return nil
+}
+
+type apiError struct {
+	plugin string
+	status int
+	body   string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("plugin %s error %d: %s", e.plugin, e.status, e.body)
+}
+
+// HTTPStatusCode reports the response status so callers can tell transient
+// failures apart.
+func (e *apiError) HTTPStatusCode() int { return e.status }
@@ -0,0 +1,39 @@
+package plugin
+
+import (
+	"fmt"
+	goplugin "plugin"
+)
+
+// NewAdapterSymbol is the function a Go plugin must export:
+//
+//	func NewAdapter() (any, error)
+//
+// The returned value is used for every provider interface it implements.
+const NewAdapterSymbol = "NewAdapter"
+
+// Load opens the Go plugin at path and returns the adapter built by its
+// NewAdapter function. Go plugins must be built with the same toolchain and
+// dependency versions as the gateway.
+func Load(path string) (any, error) {
+	lib, err := goplugin.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("plugin: open %s: %w", path, err)
+	}
+	sym, err := lib.Lookup(NewAdapterSymbol)
+	if err != nil {
+		return nil, fmt.Errorf("plugin: %s: %w", path, err)
+	}
+	newAdapter, ok := sym.(func() (any, error))
+	if !ok {
+		return nil, fmt.Errorf("plugin: %s: %s must be func() (any, error), got %T", path, NewAdapterSymbol, sym)
+	}
+	adapter, err := newAdapter()
+	if err != nil {
+		return nil, fmt.Errorf("plugin: %s: %w", path, err)
+	}
+	if adapter == nil {
+		return nil, fmt.Errorf("plugin: %s: %s returned nil", path, NewAdapterSymbol)
+	}
+	return adapter, nil
+}
@@ -25,6 +25,10 @@ import (
 
 const cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
 
+// vertexEmbedBatchSize is the most instances a text embedding predict call
+// accepts.
+const vertexEmbedBatchSize = 250
+
 // Options configure the Vertex adapter.
 type Options struct {
 	ProjectID       string
@@ -49,7 +53,8 @@ type Adapter struct {
 	metadata  map[string]string
 }
 
-// New creates a Vertex adapter using service-account credentials.
+// New creates a Vertex adapter using service-account credentials, or the
+// supplied HTTPClient when it already handles authentication.
 func New(ctx context.Context, opts Options) (*Adapter, error) {
 	if opts.ProjectID == "" {
 		return nil, errors.New("vertex: project id required")
@@ -60,9 +65,6 @@ func New(ctx context.Context, opts Options) (*Adapter, error) {
 	if opts.Model == "" {
 		return nil, errors.New("vertex: model id required")
 	}
-	if len(opts.CredentialsJSON) == 0 {
-		return nil, errors.New("vertex: credentials json required")
-	}
 
 	publisher := strings.TrimSpace(opts.Publisher)
 	if publisher == "" {
@@ -81,6 +83,9 @@ func New(ctx context.Context, opts Options) (*Adapter, error) {
 
 	httpClient := opts.HTTPClient
 	if httpClient == nil {
+		if len(opts.CredentialsJSON) == 0 {
+			return nil, errors.New("vertex: credentials json required")
+		}
 		creds, err := google.CredentialsFromJSON(ctx, opts.CredentialsJSON, cloudPlatformScope)
 		if err != nil {
 			return nil, fmt.Errorf("vertex: load credentials: %w", err)
@@ -192,6 +197,12 @@ func (a *Adapter) ChatStream(ctx context.Context, req models.ChatRequest) (<-cha
 	return chunks, cancel, nil
 }
 
+// BatchSize is the per-request instance limit of the Vertex predict API for
+// text embedding models.
+func (a *Adapter) BatchSize() int {
+	return vertexEmbedBatchSize
+}
+
 func (a *Adapter) Embed(ctx context.Context, req models.EmbeddingsRequest) (models.EmbeddingsResponse, error) {
 	if len(req.Input) == 0 {
 		return models.EmbeddingsResponse{}, errors.New("vertex embeddings input required")
@@ -204,6 +215,9 @@ func (a *Adapter) Embed(ctx context.Context, req models.EmbeddingsRequest) (mode
 	for _, text := range req.Input {
 		payload.Instances = append(payload.Instances, vertexPredictInstance{Content: text})
 	}
+	if req.Dimensions != nil {
+		payload.Parameters = &vertexEmbedParameters{OutputDimensionality: *req.Dimensions}
+	}
 
 	var vertexResp vertexPredictResponse
 	if err := a.postJSON(ctx, a.embedURL, payload, &vertexResp); err != nil {
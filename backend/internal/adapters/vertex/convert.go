@@ -2,6 +2,9 @@ package vertex
 
 import (
 	"errors"
+	"fmt"
+	"mime"
+	"path"
 	"strings"
 
 	"github.com/ncecere/open_model_gateway/backend/internal/models"
@@ -17,10 +20,21 @@ func buildGenerateContentRequest(req models.ChatRequest) (vertexGenerateRequest,
 
 	for _, msg := range req.Messages {
 		text := strings.TrimSpace(msg.Content)
+		role := strings.ToLower(msg.Role)
+		if role != "system" && role != "assistant" && len(msg.Parts) > 0 {
+			parts, err := vertexUserParts(msg.Parts)
+			if err != nil {
+				return vertexGenerateRequest{}, err
+			}
+			if len(parts) > 0 {
+				contents = append(contents, vertexContent{Role: "user", Parts: parts})
+			}
+			continue
+		}
 		if text == "" {
 			continue
 		}
-		switch strings.ToLower(msg.Role) {
+		switch role {
 		case "system":
 			systemParts = append(systemParts, text)
 		case "assistant":
@@ -77,3 +91,29 @@ func buildGenerateContentRequest(req models.ChatRequest) (vertexGenerateRequest,
 		GenerationConfig:  cfg,
 	}, nil
 }
+
+// vertexUserParts converts array-form user content, keeping image parts in
+// order. Base64 data URLs are sent inline and gs:// URIs as file references;
+// the gateway does not fetch other URLs on the caller's behalf.
+func vertexUserParts(parts []models.ChatContentPart) ([]vertexPart, error) {
+	out := make([]vertexPart, 0, len(parts))
+	for _, part := range parts {
+		switch part.Type {
+		case "image_url":
+			if mediaType, data, ok := models.ParseImageDataURL(part.ImageURL); ok {
+				out = append(out, vertexPart{InlineData: &vertexBlob{MimeType: mediaType, Data: data}})
+				continue
+			}
+			mediaType := mime.TypeByExtension(path.Ext(part.ImageURL))
+			if !strings.HasPrefix(part.ImageURL, "gs://") || !strings.HasPrefix(mediaType, "image/") {
+				return nil, fmt.Errorf("vertex: image_url must be a base64 data URL or a gs:// image URI: %w", models.ErrUnsupportedContent)
+			}
+			out = append(out, vertexPart{FileData: &vertexFileData{MimeType: mediaType, FileURI: part.ImageURL}})
+		default:
+			if text := strings.TrimSpace(part.Text); text != "" {
+				out = append(out, vertexPart{Text: text})
+			}
+		}
+	}
+	return out, nil
+}
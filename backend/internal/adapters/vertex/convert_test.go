@@ -0,0 +1,44 @@
+package vertex
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+)
+
+func TestBuildGenerateContentRequestForwardsImages(t *testing.T) {
+	req := models.ChatRequest{Messages: []models.ChatMessage{{
+		Role: "user",
+		Parts: []models.ChatContentPart{
+			{Type: "image_url", ImageURL: "data:image/jpeg;base64,/9j/4AAQ"},
+			{Type: "image_url", ImageURL: "gs://bucket/cat.png"},
+			{Type: "text", Text: "compare these"},
+		},
+	}}}
+	body, err := buildGenerateContentRequest(req)
+	if err != nil {
+		t.Fatalf("build request: %v", err)
+	}
+	if len(body.Contents) != 1 {
+		t.Fatalf("image-only text should not drop the message, got %+v", body.Contents)
+	}
+	parts := body.Contents[0].Parts
+	if len(parts) != 3 {
+		t.Fatalf("expected three parts, got %+v", parts)
+	}
+	if parts[0].InlineData == nil || parts[0].InlineData.MimeType != "image/jpeg" || parts[0].InlineData.Data != "/9j/4AAQ" {
+		t.Fatalf("data URL not sent inline: %+v", parts[0])
+	}
+	if parts[1].FileData == nil || parts[1].FileData.MimeType != "image/png" || parts[1].FileData.FileURI != "gs://bucket/cat.png" {
+		t.Fatalf("gs:// URI not sent as file data: %+v", parts[1])
+	}
+	if parts[2].Text != "compare these" {
+		t.Fatalf("text part lost: %+v", parts[2])
+	}
+
+	req.Messages[0].Parts[0].ImageURL = "https://example.com/cat.png"
+	if _, err := buildGenerateContentRequest(req); !errors.Is(err, models.ErrUnsupportedContent) {
+		t.Fatalf("expected ErrUnsupportedContent for a remote image, got %v", err)
+	}
+}
@@ -9,7 +9,21 @@ import (
 )
 
 type vertexPart struct {
-	Text string `json:"text,omitempty"`
+	Text       string          `json:"text,omitempty"`
+	InlineData *vertexBlob     `json:"inlineData,omitempty"`
+	FileData   *vertexFileData `json:"fileData,omitempty"`
+}
+
+// vertexBlob is inline base64 media in a request part.
+type vertexBlob struct {
+	MimeType string `json:"mimeType"`
+	Data     string `json:"data"`
+}
+
+// vertexFileData references media stored in Cloud Storage.
+type vertexFileData struct {
+	MimeType string `json:"mimeType"`
+	FileURI  string `json:"fileUri"`
 }
 
 type vertexContent struct {
@@ -80,7 +94,12 @@ type vertexPredictInstance struct {
 }
 
 type vertexPredictRequest struct {
-	Instances []vertexPredictInstance `json:"instances"`
+	Instances  []vertexPredictInstance `json:"instances"`
+	Parameters *vertexEmbedParameters  `json:"parameters,omitempty"`
+}
+
+type vertexEmbedParameters struct {
+	OutputDimensionality int32 `json:"outputDimensionality,omitempty"`
 }
 
 type vertexPrediction struct {
@@ -100,11 +119,23 @@ type vertexAPIError struct {
 	} `json:"error"`
 }
 
+// statusError is a non-2xx response from the Vertex API.
+type statusError struct {
+	status int
+	msg    string
+}
+
+func (e *statusError) Error() string { return e.msg }
+
+// HTTPStatusCode reports the response status so callers can tell transient
+// failures apart.
+func (e *statusError) HTTPStatusCode() int { return e.status }
+
 func decodeAPIError(resp *http.Response) error {
 	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
 	var apiErr vertexAPIError
 	if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Error.Message != "" {
-		return fmt.Errorf("vertex api error %d (%s): %s", apiErr.Error.Code, apiErr.Error.Status, apiErr.Error.Message)
+		return &statusError{status: resp.StatusCode, msg: fmt.Sprintf("vertex api error %d (%s): %s", apiErr.Error.Code, apiErr.Error.Status, apiErr.Error.Message)}
 	}
-	return fmt.Errorf("vertex api error %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	return &statusError{status: resp.StatusCode, msg: fmt.Sprintf("vertex api error %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))}
 }
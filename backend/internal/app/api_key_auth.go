@@ -0,0 +1,137 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/auth"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+)
+
+// APIKeyAuthError explains why an API key was rejected. Status is the HTTP
+// status the public API answers with; other transports map it to their own
+// codes.
+type APIKeyAuthError struct {
+	Status  int
+	Message string
+}
+
+func (e *APIKeyAuthError) Error() string { return e.Message }
+
+func apiKeyAuthError(status int, msg string) error {
+	return &APIKeyAuthError{Status: status, Message: msg}
+}
+
+// AuthenticateAPIKey verifies an "sk-<prefix>.<secret>" key, checks that its
+// tenant is active, marks the key as used and builds the request context.
+// Rejections are returned as *APIKeyAuthError.
+func (c *Container) AuthenticateAPIKey(ctx context.Context, key string) (*requestctx.Context, error) {
+	prefix, secret, err := splitAPIKey(key)
+	if err != nil {
+		return nil, apiKeyAuthError(http.StatusUnauthorized, err.Error())
+	}
+
+	record, err := c.Queries.GetAPIKeyByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apiKeyAuthError(http.StatusUnauthorized, "invalid api key")
+		}
+		return nil, apiKeyAuthError(http.StatusInternalServerError, "api key lookup failed")
+	}
+
+	if record.RevokedAt.Valid {
+		return nil, apiKeyAuthError(http.StatusUnauthorized, "api key revoked")
+	}
+	if record.SuspendedAt.Valid {
+		return nil, apiKeyAuthError(http.StatusForbidden, "api key suspended")
+	}
+
+	if record.SecretHash == "" {
+		return nil, apiKeyAuthError(http.StatusUnauthorized, "api key invalid")
+	}
+
+	match, err := auth.VerifyPassword(secret, record.SecretHash)
+	if err != nil {
+		return nil, apiKeyAuthError(http.StatusInternalServerError, "api key verification failed")
+	}
+	if !match {
+		return nil, apiKeyAuthError(http.StatusUnauthorized, "invalid api key")
+	}
+
+	return c.authorizeAPIKey(ctx, record)
+}
+
+// AuthenticateAPIKeyPrefix re-checks a key the caller has already proven it
+// holds, such as the key a one-time WebSocket token was issued for. The
+// secret is not verified again, but revocation, suspension and tenant status
+// are.
+func (c *Container) AuthenticateAPIKeyPrefix(ctx context.Context, prefix string) (*requestctx.Context, error) {
+	record, err := c.Queries.GetAPIKeyByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apiKeyAuthError(http.StatusUnauthorized, "invalid api key")
+		}
+		return nil, apiKeyAuthError(http.StatusInternalServerError, "api key lookup failed")
+	}
+	if record.RevokedAt.Valid {
+		return nil, apiKeyAuthError(http.StatusUnauthorized, "api key revoked")
+	}
+	if record.SuspendedAt.Valid {
+		return nil, apiKeyAuthError(http.StatusForbidden, "api key suspended")
+	}
+	return c.authorizeAPIKey(ctx, record)
+}
+
+func (c *Container) authorizeAPIKey(ctx context.Context, record db.ApiKey) (*requestctx.Context, error) {
+	tenant, err := c.Queries.GetTenantByID(ctx, record.TenantID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apiKeyAuthError(http.StatusUnauthorized, "tenant not found")
+		}
+		return nil, apiKeyAuthError(http.StatusInternalServerError, "tenant lookup failed")
+	}
+	if tenant.Status != db.TenantStatusActive {
+		return nil, apiKeyAuthError(http.StatusForbidden, "tenant is not active")
+	}
+
+	rc, err := BuildRequestContext(ctx, c, record)
+	if err != nil {
+		return nil, apiKeyAuthError(http.StatusInternalServerError, err.Error())
+	}
+
+	if err := c.Queries.UpdateAPIKeyLastUsed(ctx, record.ID); err != nil {
+		return nil, apiKeyAuthError(http.StatusInternalServerError, "failed to update key usage")
+	}
+	c.ensureOwnerPersonalTenant(record.OwnerUserID)
+
+	return rc, nil
+}
+
+func splitAPIKey(token string) (string, string, error) {
+	if token == "" {
+		return "", "", errors.New("api key required")
+	}
+
+	withoutPrefix := strings.TrimPrefix(token, "sk-")
+	if withoutPrefix == token {
+		return "", "", errors.New("api key must start with sk-")
+	}
+
+	parts := strings.SplitN(withoutPrefix, ".", 2)
+	if len(parts) != 2 {
+		return "", "", errors.New("api key format invalid")
+	}
+
+	prefix := parts[0]
+	secret := strings.TrimSpace(parts[1])
+	if prefix == "" || secret == "" {
+		return "", "", errors.New("api key format invalid")
+	}
+
+	return prefix, secret, nil
+}
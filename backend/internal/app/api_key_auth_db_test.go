@@ -0,0 +1,51 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/database/dbtest"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+func TestAuthenticateAPIKeyPrefixRefusesSuspendedKeys(t *testing.T) {
+	pool := dbtest.Open(t)
+	queries := db.New(pool)
+	ctx := context.Background()
+
+	tenant, err := queries.CreateTenant(ctx, db.CreateTenantParams{
+		Name:   "suspended-key-" + uuid.NewString(),
+		Status: db.TenantStatusActive,
+		Kind:   db.TenantKindOrganization,
+	})
+	if err != nil {
+		t.Fatalf("create tenant: %v", err)
+	}
+	t.Cleanup(func() { _ = queries.DeleteTenant(context.Background(), tenant.ID) })
+	key, err := queries.CreateAPIKey(ctx, db.CreateAPIKeyParams{
+		TenantID:   tenant.ID,
+		Prefix:     "sp" + uuid.NewString()[:8],
+		SecretHash: "hash",
+		Name:       "suspended",
+		ScopesJson: []byte("[]"),
+		QuotaJson:  []byte("{}"),
+		Kind:       db.ApiKeyKindService,
+	})
+	if err != nil {
+		t.Fatalf("create key: %v", err)
+	}
+	if _, err := queries.SuspendAPIKey(ctx, db.SuspendAPIKeyParams{ID: key.ID, SuspensionReason: "fingerprint_abuse"}); err != nil {
+		t.Fatalf("suspend: %v", err)
+	}
+
+	container := &Container{Queries: queries}
+	_, err = container.AuthenticateAPIKeyPrefix(ctx, key.Prefix)
+	var authErr *APIKeyAuthError
+	if !errors.As(err, &authErr) || authErr.Status != http.StatusForbidden {
+		t.Fatalf("expected a 403 for a suspended key, got %v", err)
+	}
+}
@@ -23,7 +23,9 @@ import (
 	"github.com/ncecere/open_model_gateway/backend/internal/cache"
 	"github.com/ncecere/open_model_gateway/backend/internal/catalog"
 	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/database"
 	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/debug"
 	"github.com/ncecere/open_model_gateway/backend/internal/health"
 	"github.com/ncecere/open_model_gateway/backend/internal/limits"
 	"github.com/ncecere/open_model_gateway/backend/internal/observability"
@@ -35,6 +37,7 @@ import (
 	adminbudgetsvc "github.com/ncecere/open_model_gateway/backend/internal/services/adminbudget"
 	admincatalogsvc "github.com/ncecere/open_model_gateway/backend/internal/services/admincatalog"
 	adminconfigsvc "github.com/ncecere/open_model_gateway/backend/internal/services/adminconfig"
+	admindashboardsvc "github.com/ncecere/open_model_gateway/backend/internal/services/admindashboard"
 	adminprovidersvc "github.com/ncecere/open_model_gateway/backend/internal/services/adminprovider"
 	adminratelimitsvc "github.com/ncecere/open_model_gateway/backend/internal/services/adminratelimit"
 	adminrbacsvc "github.com/ncecere/open_model_gateway/backend/internal/services/adminrbac"
@@ -43,10 +46,16 @@ import (
 	auditservice "github.com/ncecere/open_model_gateway/backend/internal/services/audit"
 	batchsvc "github.com/ncecere/open_model_gateway/backend/internal/services/batches"
 	filesvc "github.com/ncecere/open_model_gateway/backend/internal/services/files"
+	imagejobsvc "github.com/ncecere/open_model_gateway/backend/internal/services/imagejobs"
+	notificationsvc "github.com/ncecere/open_model_gateway/backend/internal/services/notifications"
+	responsesvc "github.com/ncecere/open_model_gateway/backend/internal/services/responses"
 	tenantservice "github.com/ncecere/open_model_gateway/backend/internal/services/tenant"
 	usageService "github.com/ncecere/open_model_gateway/backend/internal/services/usage"
+	usagearchivesvc "github.com/ncecere/open_model_gateway/backend/internal/services/usagearchive"
 	usagepipeline "github.com/ncecere/open_model_gateway/backend/internal/services/usagepipeline"
+	webhooksvc "github.com/ncecere/open_model_gateway/backend/internal/services/webhooks"
 	"github.com/ncecere/open_model_gateway/backend/internal/storage/blob"
+	"github.com/ncecere/open_model_gateway/backend/internal/wildcard"
 )
 
 // Container aggregates runtime dependencies for handlers and services.
@@ -59,6 +68,7 @@ type Container struct {
 	AdminUsers         *adminusersvc.Service
 	AdminCatalog       *admincatalogsvc.Service
 	AdminBudgets       *adminbudgetsvc.Service
+	AdminDashboard     *admindashboardsvc.Service
 	AdminRateLimits    *adminratelimitsvc.Service
 	AdminProviders     *adminprovidersvc.Service
 	AdminTenants       *admintenantsvc.Service
@@ -67,28 +77,68 @@ type Container struct {
 	AdminAudit         *adminauditsvc.Service
 	Batches            *batchsvc.Service
 	DefaultModels      *catalog.DefaultModelService
+	TokenEstimators    *catalog.TokenEstimatorFactory
 	UsageService       *usageService.Service
 	TenantService      *tenantservice.Service
 	AdminAuth          *auth.AdminAuthService
 	Factory            *providers.Factory
+	CredentialCipher   *providers.CredentialCipher
 	Engine             *router.Engine
 	RateLimiter        *limits.RateLimiter
 	KeyRateLimits      map[string]limits.LimitConfig
 	TenantRateLimits   map[uuid.UUID]limits.LimitConfig
+	Quotas             *limits.QuotaCounter
+	TenantQuotas       map[uuid.UUID]limits.QuotaConfig
 	DefaultKeyLimit    limits.LimitConfig
 	DefaultTenantLimit limits.LimitConfig
 	UsageLogger        *usagepipeline.Logger
-	Idempotency        *cache.IdempotencyCache
+	Payloads           *usagepipeline.PayloadStore
+	Latency            *usagepipeline.LatencyPublisher
+	UsageSpikes        *usagepipeline.SpikeDetector
+	UsageArchive       *usagearchivesvc.Service
+	Webhooks           *webhooksvc.Service
+	Notifications      *notificationsvc.Service
+	Idempotency        cache.IdempotencyStore
+	IdempotencyDB      *cache.DBIdempotencyCache
+	StreamIdempotency  *cache.StreamIdempotencyCache
+	ModelLists         *cache.ModelListCache
+	DebugSamples       *debug.SampleBuffer
+	SystemPrompts      *cache.SystemPromptCache
+	EmbeddingCache     *cache.EmbeddingCache
 	HealthMon          *health.Monitor
+	HealthProbe        *health.Prober
+	HealthHistory      *health.History
 	Observability      *observability.Provider
 	Files              *filesvc.Service
+	Responses          *responsesvc.Service
+	ImageJobs          *imagejobsvc.Service
+	Streams            StreamTracker
 	tenantModelMu      sync.RWMutex
 	tenantModelAccess  map[uuid.UUID]map[string]struct{}
 	tenantRateLimitMu  sync.RWMutex
 	keyRateLimitMu     sync.RWMutex
+	tenantQuotaMu      sync.RWMutex
+	catalogMu          sync.RWMutex
 	ReportingLocation  *time.Location
 }
 
+// newIdempotencyStore builds the Idempotency-Key response cache selected by
+// cache.idempotency_cache_backend. The database cache is returned separately
+// so its expired rows can be swept.
+func newIdempotencyStore(cfg *config.Config, redisClient *redis.Client, queries *db.Queries) (cache.IdempotencyStore, *cache.DBIdempotencyCache) {
+	redisCache := cache.NewIdempotencyCache(redisClient, cfg.Idempotency.TTL)
+	switch cfg.Cache.IdempotencyCacheBackend {
+	case config.IdempotencyBackendPostgres:
+		dbCache := cache.NewDBIdempotencyCache(queries, cfg.Idempotency.TTL)
+		return dbCache, dbCache
+	case config.IdempotencyBackendRedisWithFallback:
+		dbCache := cache.NewDBIdempotencyCache(queries, cfg.Idempotency.TTL)
+		return cache.NewFallbackCache(redisCache, dbCache), dbCache
+	default:
+		return redisCache, nil
+	}
+}
+
 // NewContainer builds a dependency container from the provided primitives.
 func NewContainer(ctx context.Context, cfg *config.Config, pool *pgxpool.Pool, redisClient *redis.Client) (*Container, error) {
 	if cfg == nil {
@@ -129,7 +179,7 @@ func NewContainer(ctx context.Context, cfg *config.Config, pool *pgxpool.Pool, r
 	if err != nil {
 		return nil, fmt.Errorf("init admin auth: %w", err)
 	}
-	adminUserSvc := adminusersvc.NewService(queries, personalSvc, adminAuth)
+	adminUserSvc := adminusersvc.NewService(pool, queries, personalSvc, adminAuth)
 
 	dbEntries, err := queries.ListModelCatalog(ctx)
 	if err != nil {
@@ -144,13 +194,22 @@ func NewContainer(ctx context.Context, cfg *config.Config, pool *pgxpool.Pool, r
 	override := *cfg
 	override.ModelCatalog = entries
 
+	credentialCipher, err := providers.NewCredentialCipher(cfg.Providers.CredentialEncryptionKey)
+	if err != nil {
+		return nil, err
+	}
 	factory := providers.NewFactory(&override)
+	credentials, err := LoadProviderCredentials(ctx, queries, credentialCipher)
+	if err != nil {
+		return nil, fmt.Errorf("load provider credentials: %w", err)
+	}
+	factory.SetCredentials(credentials)
 	engine := router.NewEngine()
 	if err := engine.Reload(ctx, factory); err != nil {
 		return nil, fmt.Errorf("init router engine: %w", err)
 	}
 
-	if err := ensureCatalogPersisted(ctx, queries, entries); err != nil {
+	if err := ensureCatalogPersisted(ctx, pool, queries, entries); err != nil {
 		return nil, err
 	}
 
@@ -169,14 +228,41 @@ func NewContainer(ctx context.Context, cfg *config.Config, pool *pgxpool.Pool, r
 		tenantLimitOverrides = make(map[uuid.UUID]limits.LimitConfig)
 	}
 
+	tenantQuotas, err := LoadTenantQuotaOverrides(ctx, queries)
+	if err != nil {
+		return nil, fmt.Errorf("load tenant quotas: %w", err)
+	}
+
 	if err := ensureBootstrap(ctx, queries, adminAuth, personalSvc, cfg.Bootstrap, cfg.Budgets, keyLimitOverrides, tenantLimitOverrides); err != nil {
 		return nil, err
 	}
 
 	rateLimiter := limits.NewRateLimiter(redisClient)
-	idem := cache.NewIdempotencyCache(redisClient, 30*time.Minute)
-
+	idem, idemDB := newIdempotencyStore(cfg, redisClient, queries)
+	var streamIdem *cache.StreamIdempotencyCache
+	if cfg.Idempotency.EnableStreaming {
+		streamIdem = cache.NewStreamIdempotencyCache(redisClient, cfg.Idempotency.StreamTTL)
+	}
+	var debugSamples *debug.SampleBuffer
+	if cfg.Debug.SamplingEnabled {
+		debugSamples = debug.NewSampleBuffer(cfg.Debug.BufferSize)
+		slog.Default().Warn("debug sampling enabled; request and response bodies are kept in memory",
+			slog.Float64("sample_rate", cfg.Debug.SampleRate))
+	}
+	systemPrompts := cache.NewSystemPromptCache(redisClient, 5*time.Minute)
+	var embeddingCache *cache.EmbeddingCache
+	if cfg.Cache.EmbeddingCacheEnabled {
+		embeddingCache = cache.NewEmbeddingCache(redisClient, cfg.Cache.EmbeddingCacheTTL)
+	}
+
+	notificationService := notificationsvc.NewService(queries, redisClient)
+	healthHistory := health.NewHistory(redisClient)
+	engine.SetOutcomeObserver(healthHistory.Observe)
 	monitor := health.NewMonitor(engine, cfg.Health)
+	monitor.SetNotifier(notificationService)
+	monitor.SetPingers(pool.Ping, func(ctx context.Context) error {
+		return redisClient.Ping(ctx).Err()
+	})
 	monitor.Start(ctx, func() map[string][]providers.Route {
 		return engine.ListAliases()
 	})
@@ -185,32 +271,56 @@ func NewContainer(ctx context.Context, cfg *config.Config, pool *pgxpool.Pool, r
 	if err != nil {
 		return nil, fmt.Errorf("setup observability: %w", err)
 	}
+	if reg := obsProvider.Registerer(); reg != nil {
+		poolMetrics, err := database.NewDBMetricsCollector(reg, database.PgxPoolStats(pool), redisClient)
+		if err != nil {
+			return nil, fmt.Errorf("register pool metrics: %w", err)
+		}
+		go poolMetrics.Run(ctx)
+	}
 
+	webhookService := webhooksvc.NewService(queries, cfg.Budgets.Alert.Webhook, cfg.Retention, slog.Default())
 	alertSink := usagepipeline.NewCompositeSink(
 		usagepipeline.NewSMTPSink(cfg.Budgets.Alert.SMTP, slog.Default()),
-		usagepipeline.NewWebhookSink(cfg.Budgets.Alert.Webhook, slog.Default()),
+		usagepipeline.NewQueuedWebhookSink(webhookService, slog.Default()),
 		usagepipeline.NewLogAlertSink(slog.Default()),
 	)
-	usageLogger := usagepipeline.NewLogger(pool, queries, cfg.Budgets, alertSink, obsProvider)
+	payloadStore := usagepipeline.NewPayloadStore(queries, cfg.Retention)
+	budgetHolds := usagepipeline.NewBudgetHolds(redisClient, cfg.Server.ProviderTimeout)
+	latencyPublisher := usagepipeline.NewLatencyPublisher(redisClient)
+	usageLogger := usagepipeline.NewLogger(pool, queries, cfg.Budgets, alertSink, obsProvider, payloadStore, budgetHolds, usagepipeline.NewEscalationEvaluator(redisClient, alertSink), latencyPublisher)
 	usageLogger.LoadCatalog(entries)
+	usageLogger.SetNotifier(notificationService)
 
 	blobStore, err := blob.New(ctx, cfg.Files)
 	if err != nil {
 		return nil, fmt.Errorf("init blob store: %w", err)
 	}
+	adminUserSvc.SetBlobStore(blobStore)
 	filesService := filesvc.NewService(queries, blobStore, &cfg.Files)
-	batchesService := batchsvc.NewService(pool, queries, filesService, &cfg.Batches)
-	adminConfigService := adminconfigsvc.NewService(queries, cfg, filesService, batchesService)
+	batchesService := batchsvc.NewService(pool, queries, filesService, redisClient, &cfg.Batches)
+
+	var usageArchive *usagearchivesvc.Service
+	if cfg.Archive.Enabled {
+		archiveStore, err := blob.New(ctx, cfg.Archive.BlobConfig(cfg.Files.EncryptionKey))
+		if err != nil {
+			return nil, fmt.Errorf("init archive store: %w", err)
+		}
+		usageArchive = usagearchivesvc.NewService(queries, archiveStore, cfg.Archive, cfg.Retention.MetadataDays)
+	}
+	adminConfigService := adminconfigsvc.NewService(queries, cfg, filesService, batchesService, adminAuth)
 
 	defaultKeyLimit := limits.LimitConfig{
-		RequestsPerMinute: cfg.RateLimits.DefaultRequestsPerMinute,
-		TokensPerMinute:   cfg.RateLimits.DefaultTokensPerMinute,
-		ParallelRequests:  cfg.RateLimits.DefaultParallelRequestsKey,
+		RequestsPerMinute:  cfg.RateLimits.DefaultRequestsPerMinute,
+		TokensPerMinute:    cfg.RateLimits.DefaultTokensPerMinute,
+		ParallelRequests:   cfg.RateLimits.DefaultParallelRequestsKey,
+		ParallelRequestTTL: cfg.RateLimits.ParallelRequestTTL,
 	}
 	defaultTenantLimit := limits.LimitConfig{
-		RequestsPerMinute: cfg.RateLimits.DefaultRequestsPerMinute,
-		TokensPerMinute:   cfg.RateLimits.DefaultTokensPerMinute,
-		ParallelRequests:  cfg.RateLimits.DefaultParallelRequestsTenant,
+		RequestsPerMinute:  cfg.RateLimits.DefaultRequestsPerMinute,
+		TokensPerMinute:    cfg.RateLimits.DefaultTokensPerMinute,
+		ParallelRequests:   cfg.RateLimits.DefaultParallelRequestsTenant,
+		ParallelRequestTTL: cfg.RateLimits.ParallelRequestTTL,
 	}
 
 	container := &Container{
@@ -218,9 +328,11 @@ func NewContainer(ctx context.Context, cfg *config.Config, pool *pgxpool.Pool, r
 		DBPool:             pool,
 		Redis:              redisClient,
 		Queries:            queries,
+		CredentialCipher:   credentialCipher,
 		Accounts:           personalSvc,
 		AdminUsers:         adminUserSvc,
 		DefaultModels:      defaultModels,
+		TokenEstimators:    catalog.NewTokenEstimatorFactory(),
 		AdminProviders:     providerSvc,
 		UsageService:       usageSvc,
 		TenantService:      tenantSvc,
@@ -230,13 +342,31 @@ func NewContainer(ctx context.Context, cfg *config.Config, pool *pgxpool.Pool, r
 		RateLimiter:        rateLimiter,
 		KeyRateLimits:      keyLimitOverrides,
 		TenantRateLimits:   tenantLimitOverrides,
+		Quotas:             limits.NewQuotaCounter(redisClient),
+		TenantQuotas:       tenantQuotas,
 		DefaultKeyLimit:    defaultKeyLimit,
 		DefaultTenantLimit: defaultTenantLimit,
 		UsageLogger:        usageLogger,
+		Payloads:           payloadStore,
+		Latency:            latencyPublisher,
+		UsageSpikes:        usagepipeline.NewSpikeDetector(queries, redisClient, alertSink, cfg.Budgets.Alert),
+		UsageArchive:       usageArchive,
+		Webhooks:           webhookService,
 		Idempotency:        idem,
+		IdempotencyDB:      idemDB,
+		StreamIdempotency:  streamIdem,
+		ModelLists:         cache.NewModelListCache(redisClient, 30*time.Second),
+		DebugSamples:       debugSamples,
+		SystemPrompts:      systemPrompts,
+		EmbeddingCache:     embeddingCache,
+		Notifications:      notificationService,
 		HealthMon:          monitor,
+		HealthProbe:        health.NewProber(redisClient, 10*time.Second),
+		HealthHistory:      healthHistory,
 		Observability:      obsProvider,
 		Files:              filesService,
+		Responses:          responsesvc.NewService(queries),
+		ImageJobs:          imagejobsvc.NewService(queries, &cfg.Files, slog.Default()),
 		AdminConfig:        adminConfigService,
 		Batches:            batchesService,
 		ReportingLocation:  reportingLoc,
@@ -246,11 +376,27 @@ func NewContainer(ctx context.Context, cfg *config.Config, pool *pgxpool.Pool, r
 	personalSvc.SetTenantModelUpdater(func(id uuid.UUID, aliases []string) {
 		container.SetTenantModels(id, aliases)
 	})
+	personalSvc.SetBudgetDefaults(func() config.BudgetConfig {
+		return container.Config.Budgets
+	})
+	usageSvc.SetBudgetLookup(func(ctx context.Context, tenantID uuid.UUID) (usageService.BudgetStatus, error) {
+		budget, err := tenantSvc.TenantBudget(ctx, tenantID)
+		if err != nil {
+			return usageService.BudgetStatus{}, err
+		}
+		return usageService.NewBudgetStatus(budget.LimitUSD, budget.UsedUSD, budget.WarningThreshold, budget.RefreshSchedule), nil
+	})
 
-	container.AdminCatalog = admincatalogsvc.NewService(queries, container.ReloadRouter)
+	container.AdminCatalog = admincatalogsvc.NewService(pool, queries, container.ReloadRouter)
 	container.AdminBudgets = adminbudgetsvc.NewService(queries, cfg)
+	container.AdminDashboard = admindashboardsvc.NewService(queries, redisClient, cfg)
 	container.AdminRateLimits = adminratelimitsvc.NewService(queries, cfg)
-	container.AdminTenants = admintenantsvc.NewService(cfg, queries, reportingLoc, pool, personalSvc, adminAuth, container.SetTenantModels, container.UpdateTenantRateLimit, container.UpdateAPIKeyRateLimit)
+	var inviteMailer admintenantsvc.Mailer
+	if mailer := usagepipeline.NewSMTPMailer(cfg.Budgets.Alert.SMTP); mailer != nil {
+		inviteMailer = mailer
+	}
+	container.AdminTenants = admintenantsvc.NewService(cfg, queries, reportingLoc, pool, personalSvc, adminAuth, inviteMailer, container.SetTenantModels, container.UpdateTenantRateLimit, container.UpdateAPIKeyRateLimit, container.UpdateTenantQuota, container.InvalidateTenantSystemPrompt)
+	container.AdminTenants.SetExportStore(blobStore)
 	container.AdminRBAC = adminrbacsvc.NewService(queries)
 	container.AdminAudit = adminauditsvc.NewService(auditservice.NewService(queries))
 
@@ -261,6 +407,23 @@ func NewContainer(ctx context.Context, cfg *config.Config, pool *pgxpool.Pool, r
 	return container, nil
 }
 
+// ConfigCatalog returns the model catalog entries from the config file.
+func (c *Container) ConfigCatalog() []config.ModelCatalogEntry {
+	c.catalogMu.RLock()
+	defer c.catalogMu.RUnlock()
+	return c.Config.ModelCatalog
+}
+
+// ReloadCatalog swaps in the config file's model catalog entries and
+// rebuilds the router. The config watcher calls it from its own goroutine
+// while requests read the catalog.
+func (c *Container) ReloadCatalog(ctx context.Context, entries []config.ModelCatalogEntry) error {
+	c.catalogMu.Lock()
+	c.Config.ModelCatalog = entries
+	c.catalogMu.Unlock()
+	return c.ReloadRouter(ctx)
+}
+
 // ReloadRouter rebuilds provider routes after catalog changes.
 func (c *Container) ReloadRouter(ctx context.Context) error {
 	dbEntries, err := c.Queries.ListModelCatalog(ctx)
@@ -268,15 +431,21 @@ func (c *Container) ReloadRouter(ctx context.Context) error {
 		return err
 	}
 
-	entries, err := router.MergeEntries(c.Config.ModelCatalog, dbEntries)
+	c.catalogMu.RLock()
+	override := *c.Config
+	c.catalogMu.RUnlock()
+	entries, err := router.MergeEntries(override.ModelCatalog, dbEntries)
 	if err != nil {
 		return err
 	}
-
-	override := *c.Config
 	override.ModelCatalog = entries
 
 	factory := providers.NewFactory(&override)
+	credentials, err := LoadProviderCredentials(ctx, c.Queries, c.CredentialCipher)
+	if err != nil {
+		return err
+	}
+	factory.SetCredentials(credentials)
 	if err := c.Engine.Reload(ctx, factory); err != nil {
 		return err
 	}
@@ -376,8 +545,15 @@ func (c *Container) IsModelAllowed(tenantID uuid.UUID, alias string) bool {
 		return false
 	}
 
-	_, exists := allowed[normalized]
-	return exists
+	if _, exists := allowed[normalized]; exists {
+		return true
+	}
+	for entry := range allowed {
+		if wildcard.IsPattern(entry) && wildcard.Match(entry, normalized) {
+			return true
+		}
+	}
+	return false
 }
 
 func (c *Container) SetTenantModels(tenantID uuid.UUID, aliases []string) {
@@ -471,7 +647,9 @@ func (c *Container) EffectiveTenantBudget(ctx context.Context, tenantID uuid.UUI
 	return limit, warning, nil
 }
 
-func ensureCatalogPersisted(ctx context.Context, queries *db.Queries, entries []config.ModelCatalogEntry) error {
+// ensureCatalogPersisted writes the config catalog to the database. Price
+// changes to entries already stored are recorded in the price history.
+func ensureCatalogPersisted(ctx context.Context, pool *pgxpool.Pool, queries *db.Queries, entries []config.ModelCatalogEntry) error {
 	for _, entry := range entries {
 		modalitiesJSON, err := json.Marshal(entry.Modalities)
 		if err != nil {
@@ -485,6 +663,14 @@ func ensureCatalogPersisted(ctx context.Context, queries *db.Queries, entries []
 		if err != nil {
 			return err
 		}
+		trafficSplit := entry.TrafficSplit
+		if trafficSplit == nil {
+			trafficSplit = []config.TrafficSplitEntry{}
+		}
+		trafficSplitJSON, err := json.Marshal(trafficSplit)
+		if err != nil {
+			return err
+		}
 
 		priceInput := decimal.NewFromFloat(entry.PriceInput)
 		priceOutput := decimal.NewFromFloat(entry.PriceOutput)
@@ -506,28 +692,43 @@ func ensureCatalogPersisted(ctx context.Context, queries *db.Queries, entries []
 			modelType = "llm"
 		}
 
-		_, err = queries.UpsertModelCatalogEntry(ctx, db.UpsertModelCatalogEntryParams{
-			Alias:              entry.Alias,
-			Provider:           provider,
-			ProviderModel:      entry.ProviderModel,
-			ModelType:          modelType,
-			ContextWindow:      entry.ContextWindow,
-			MaxOutputTokens:    entry.MaxOutputTokens,
-			ModalitiesJson:     modalitiesJSON,
-			SupportsTools:      entry.SupportsTools,
-			PriceInput:         priceInput,
-			PriceOutput:        priceOutput,
-			Currency:           currency,
-			Enabled:            entry.IsEnabled(),
-			Deployment:         entry.Deployment,
-			Endpoint:           entry.Endpoint,
-			ApiKey:             entry.APIKey,
-			ApiVersion:         entry.APIVersion,
-			Region:             entry.Region,
-			MetadataJson:       metadataJSON,
-			Weight:             int32(entry.Weight),
-			ProviderConfigJson: providerCfgJSON,
-		})
+		var deprecatedAt pgtype.Timestamptz
+		if entry.DeprecatedAt != nil {
+			deprecatedAt = pgtype.Timestamptz{Time: entry.DeprecatedAt.UTC(), Valid: true}
+		}
+
+		_, err = admincatalogsvc.SaveEntry(ctx, pool, queries, db.UpsertModelCatalogEntryParams{
+			Alias:               entry.Alias,
+			Provider:            provider,
+			ProviderModel:       entry.ProviderModel,
+			ModelType:           modelType,
+			ContextWindow:       entry.ContextWindow,
+			MaxOutputTokens:     entry.MaxOutputTokens,
+			MaxDimensions:       entry.MaxDimensions,
+			MirrorAlias:         entry.MirrorAlias,
+			MirrorSampleRate:    decimal.NewFromFloat(entry.MirrorSampleRate),
+			ModalitiesJson:      modalitiesJSON,
+			SupportsTools:       entry.SupportsTools,
+			PriceInput:          priceInput,
+			PriceOutput:         priceOutput,
+			Currency:            currency,
+			Enabled:             entry.IsEnabled(),
+			Deployment:          entry.Deployment,
+			Endpoint:            entry.Endpoint,
+			ApiKey:              entry.APIKey,
+			ApiVersion:          entry.APIVersion,
+			Region:              entry.Region,
+			MetadataJson:        metadataJSON,
+			Weight:              int32(entry.Weight),
+			ProviderConfigJson:  providerCfgJSON,
+			RoutingPolicy:       entry.RoutingPolicy,
+			TrafficSplitJson:    trafficSplitJSON,
+			DeprecatedAt:        deprecatedAt,
+			DeprecationMessage:  strings.TrimSpace(entry.DeprecationMessage),
+			SupportsVision:      entry.SupportsVision,
+			FallbackVisionAlias: entry.FallbackVisionAlias,
+			CachedPriceRatio:    decimal.NewFromFloat(entry.CachedTokenPriceRatio),
+		}, uuid.Nil, nil)
 		if err != nil {
 			return err
 		}
@@ -535,6 +736,8 @@ func ensureCatalogPersisted(ctx context.Context, queries *db.Queries, entries []
 	return nil
 }
 
+// normalizeModelAlias lower-cases and trims an alias. Wildcard characters
+// are kept, so allowlist patterns are normalized the same way as aliases.
 func normalizeModelAlias(alias string) string {
 	return strings.ToLower(strings.TrimSpace(alias))
 }
@@ -658,14 +861,20 @@ func ensureBootstrap(ctx context.Context, queries *db.Queries, adminAuth *auth.A
 			}
 			keyRecord = created
 		}
+		if keyRecord.ID.Valid {
+			if err := queries.MarkAPIKeyBootstrap(ctx, keyRecord.ID); err != nil {
+				return fmt.Errorf("bootstrap api key %q mark bootstrap: %w", prefix, err)
+			}
+		}
 		override := limitFromBootstrapRate(key.RateLimit)
 		keyLimits[prefix] = override
-		if keyRecord.ID.Valid && (override.RequestsPerMinute > 0 || override.TokensPerMinute > 0 || override.ParallelRequests > 0) {
+		if keyRecord.ID.Valid && (override.RequestsPerMinute > 0 || override.TokensPerMinute > 0 || override.ParallelRequests > 0 || override.ParallelRequestTTL > 0) {
 			if _, err := queries.UpsertAPIKeyRateLimit(ctx, db.UpsertAPIKeyRateLimitParams{
-				ApiKeyID:          keyRecord.ID,
-				RequestsPerMinute: int32(override.RequestsPerMinute),
-				TokensPerMinute:   int32(override.TokensPerMinute),
-				ParallelRequests:  int32(override.ParallelRequests),
+				ApiKeyID:                  keyRecord.ID,
+				RequestsPerMinute:         int32(override.RequestsPerMinute),
+				TokensPerMinute:           int32(override.TokensPerMinute),
+				ParallelRequests:          int32(override.ParallelRequests),
+				ParallelRequestTtlSeconds: int32(override.ParallelRequestTTL / time.Second),
 			}); err != nil {
 				return fmt.Errorf("bootstrap api key %q rate limit upsert: %w", prefix, err)
 			}
@@ -738,10 +947,11 @@ func ensureBootstrap(ctx context.Context, queries *db.Queries, adminAuth *auth.A
 		override := limitFromBootstrapRate(limit.Limits)
 		tenantLimits[tenantUUID] = override
 		if _, err := queries.UpsertTenantRateLimit(ctx, db.UpsertTenantRateLimitParams{
-			TenantID:          tenant.ID,
-			RequestsPerMinute: int32(limit.Limits.RequestsPerMinute),
-			TokensPerMinute:   int32(limit.Limits.TokensPerMinute),
-			ParallelRequests:  int32(limit.Limits.ParallelRequests),
+			TenantID:                  tenant.ID,
+			RequestsPerMinute:         int32(limit.Limits.RequestsPerMinute),
+			TokensPerMinute:           int32(limit.Limits.TokensPerMinute),
+			ParallelRequests:          int32(limit.Limits.ParallelRequests),
+			ParallelRequestTtlSeconds: int32(limit.Limits.ParallelRequestTTL / time.Second),
 		}); err != nil {
 			return fmt.Errorf("bootstrap tenant limit %q upsert: %w", tenantName, err)
 		}
@@ -810,14 +1020,33 @@ func ensureBootstrap(ctx context.Context, queries *db.Queries, adminAuth *auth.A
 		}
 	}
 
+	for _, entry := range bootstrap.TenantSystemPrompts {
+		tenantName := strings.TrimSpace(entry.Tenant)
+		if tenantName == "" {
+			continue
+		}
+		tenant, err := queries.GetTenantByName(ctx, tenantName)
+		if err != nil {
+			return fmt.Errorf("bootstrap tenant system prompt %q: %w", tenantName, err)
+		}
+		if _, err := queries.UpsertTenantSystemPrompt(ctx, db.UpsertTenantSystemPromptParams{
+			TenantID: tenant.ID,
+			Content:  entry.Content,
+			Mode:     db.SystemPromptMode(entry.Mode),
+		}); err != nil {
+			return fmt.Errorf("bootstrap tenant system prompt %q upsert: %w", tenantName, err)
+		}
+	}
+
 	return nil
 }
 
 func limitFromBootstrapRate(rate config.BootstrapRateLimit) limits.LimitConfig {
 	return limits.LimitConfig{
-		RequestsPerMinute: rate.RequestsPerMinute,
-		TokensPerMinute:   rate.TokensPerMinute,
-		ParallelRequests:  rate.ParallelRequests,
+		RequestsPerMinute:  rate.RequestsPerMinute,
+		TokensPerMinute:    rate.TokensPerMinute,
+		ParallelRequests:   rate.ParallelRequests,
+		ParallelRequestTTL: rate.ParallelRequestTTL,
 	}
 }
 
@@ -831,6 +1060,9 @@ func mergeLimitConfigs(base limits.LimitConfig, override limits.LimitConfig) lim
 	if override.ParallelRequests > 0 {
 		base.ParallelRequests = override.ParallelRequests
 	}
+	if override.ParallelRequestTTL > 0 {
+		base.ParallelRequestTTL = override.ParallelRequestTTL
+	}
 	return base
 }
 
@@ -884,38 +1116,36 @@ func (c *Container) AcquireRateLimits(ctx context.Context, alias string) (string
 		return "", limits.LimitConfig{}, "", limits.LimitConfig{}, nil, err
 	}
 
-	keyAcquired := false
-	tenantAcquired := false
+	var keyPermit, tenantPermit *limits.Permit
 
 	keyStorage := "key:" + keyKey
 	tenantStorage := "tenant:" + tenantKey
 
 	if keyCfg.RequestsPerMinute > 0 || keyCfg.ParallelRequests > 0 {
-		if err := c.RateLimiter.Allow(ctx, keyStorage, keyCfg); err != nil {
+		permit, err := c.RateLimiter.Allow(ctx, keyStorage, keyCfg)
+		if err != nil {
 			return "", limits.LimitConfig{}, "", limits.LimitConfig{}, nil, err
 		}
-		keyAcquired = true
+		keyPermit = permit
 	}
 
 	if tenantCfg.RequestsPerMinute > 0 || tenantCfg.ParallelRequests > 0 {
-		if err := c.RateLimiter.Allow(ctx, tenantStorage, tenantCfg); err != nil {
-			if keyAcquired {
-				c.RateLimiter.Release(ctx, keyStorage, keyCfg)
-			}
+		permit, err := c.RateLimiter.Allow(ctx, tenantStorage, tenantCfg)
+		if err != nil {
+			c.RateLimiter.Release(ctx, keyPermit)
 			return "", limits.LimitConfig{}, "", limits.LimitConfig{}, nil, err
 		}
-		tenantAcquired = true
+		tenantPermit = permit
 	}
 
+	// Release even after the client has gone away; slots that still leak
+	// (e.g. the process dies) expire after ParallelRequestTTL.
+	releaseCtx := context.WithoutCancel(ctx)
 	var once sync.Once
 	release := func() {
 		once.Do(func() {
-			if tenantAcquired {
-				c.RateLimiter.Release(ctx, tenantStorage, tenantCfg)
-			}
-			if keyAcquired {
-				c.RateLimiter.Release(ctx, keyStorage, keyCfg)
-			}
+			c.RateLimiter.Release(releaseCtx, tenantPermit)
+			c.RateLimiter.Release(releaseCtx, keyPermit)
 		})
 	}
 
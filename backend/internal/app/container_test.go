@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	miniredis "github.com/alicebob/miniredis/v2"
 	"github.com/google/uuid"
@@ -20,7 +21,8 @@ func TestEffectiveRateLimits_MergesOverrides(t *testing.T) {
 	container := &Container{
 		KeyRateLimits: map[string]limits.LimitConfig{
 			"tok-test": {
-				RequestsPerMinute: 120,
+				RequestsPerMinute:  120,
+				ParallelRequestTTL: 5 * time.Minute,
 			},
 		},
 		TenantRateLimits: map[uuid.UUID]limits.LimitConfig{
@@ -29,14 +31,16 @@ func TestEffectiveRateLimits_MergesOverrides(t *testing.T) {
 			},
 		},
 		DefaultKeyLimit: limits.LimitConfig{
-			RequestsPerMinute: 60,
-			TokensPerMinute:   10_000,
-			ParallelRequests:  2,
+			RequestsPerMinute:  60,
+			TokensPerMinute:    10_000,
+			ParallelRequests:   2,
+			ParallelRequestTTL: time.Minute,
 		},
 		DefaultTenantLimit: limits.LimitConfig{
-			RequestsPerMinute: 100,
-			TokensPerMinute:   20_000,
-			ParallelRequests:  4,
+			RequestsPerMinute:  100,
+			TokensPerMinute:    20_000,
+			ParallelRequests:   4,
+			ParallelRequestTTL: time.Minute,
 		},
 	}
 
@@ -48,6 +52,9 @@ func TestEffectiveRateLimits_MergesOverrides(t *testing.T) {
 	if keyCfg.TokensPerMinute != 10_000 || keyCfg.ParallelRequests != 2 {
 		t.Fatalf("expected key defaults to remain for unset fields, got %+v", keyCfg)
 	}
+	if keyCfg.ParallelRequestTTL != 5*time.Minute {
+		t.Fatalf("expected key parallel TTL override applied, got %s", keyCfg.ParallelRequestTTL)
+	}
 
 	if tenantCfg.TokensPerMinute != 50_000 {
 		t.Fatalf("expected tenant TPM override applied, got %d", tenantCfg.TokensPerMinute)
@@ -55,6 +62,9 @@ func TestEffectiveRateLimits_MergesOverrides(t *testing.T) {
 	if tenantCfg.RequestsPerMinute != 100 || tenantCfg.ParallelRequests != 4 {
 		t.Fatalf("expected tenant defaults persisted for non-overridden fields, got %+v", tenantCfg)
 	}
+	if tenantCfg.ParallelRequestTTL != time.Minute {
+		t.Fatalf("expected the default parallel TTL without an override, got %s", tenantCfg.ParallelRequestTTL)
+	}
 }
 
 func TestAcquireRateLimits_RespectsTenantParallelOverrides(t *testing.T) {
@@ -104,3 +114,23 @@ func TestAcquireRateLimits_RespectsTenantParallelOverrides(t *testing.T) {
 	}
 	releaseAgain()
 }
+
+func TestIsModelAllowedMatchesWildcards(t *testing.T) {
+	tenantID := uuid.New()
+	container := &Container{}
+	container.SetTenantModels(tenantID, []string{"GPT-4*", "claude-3-[hs]*", "text-embedding-3-small"})
+
+	for _, alias := range []string{"gpt-4-turbo", "gpt-4o", "Claude-3-Haiku", "text-embedding-3-small"} {
+		if !container.IsModelAllowed(tenantID, alias) {
+			t.Fatalf("expected %q to be allowed", alias)
+		}
+	}
+	for _, alias := range []string{"gpt-3.5-turbo", "claude-3-opus", "text-embedding-3-large"} {
+		if container.IsModelAllowed(tenantID, alias) {
+			t.Fatalf("expected %q to be denied", alias)
+		}
+	}
+	if !container.IsModelAllowed(uuid.New(), "gpt-3.5-turbo") {
+		t.Fatal("expected tenants without an allowlist to allow every model")
+	}
+}
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+)
+
+// CachedEmbeddings returns a response assembled from the embedding cache when
+// every input has a cached vector for the alias and requested dimensions.
+func (c *Container) CachedEmbeddings(ctx context.Context, alias string, dimensions *int32, inputs []string) (models.EmbeddingsResponse, bool) {
+	if c == nil || c.EmbeddingCache == nil {
+		return models.EmbeddingsResponse{}, false
+	}
+	vectors, ok := c.EmbeddingCache.GetAll(ctx, alias, cacheDimensions(dimensions), inputs)
+	if !ok {
+		return models.EmbeddingsResponse{}, false
+	}
+	resp := models.EmbeddingsResponse{
+		Model:      alias,
+		Embeddings: make([]models.Embedding, 0, len(vectors)),
+	}
+	for i, vector := range vectors {
+		resp.Embeddings = append(resp.Embeddings, models.Embedding{Index: i, Vector: vector})
+	}
+	return resp, true
+}
+
+// StoreEmbeddings caches each returned vector under its input. Failures are
+// logged and otherwise ignored.
+func (c *Container) StoreEmbeddings(ctx context.Context, alias string, dimensions *int32, inputs []string, resp models.EmbeddingsResponse) {
+	if c == nil || c.EmbeddingCache == nil {
+		return
+	}
+	for _, emb := range resp.Embeddings {
+		if emb.Index < 0 || emb.Index >= len(inputs) {
+			continue
+		}
+		if err := c.EmbeddingCache.Set(ctx, alias, cacheDimensions(dimensions), inputs[emb.Index], emb.Vector); err != nil {
+			slog.Warn("cache embedding", slog.String("alias", alias), slog.String("error", err.Error()))
+			return
+		}
+	}
+}
+
+func cacheDimensions(dimensions *int32) int32 {
+	if dimensions == nil {
+		return 0
+	}
+	return *dimensions
+}
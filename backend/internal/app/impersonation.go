@@ -0,0 +1,55 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+)
+
+// impersonationKeyPrefix stands in for the API key prefix on impersonated
+// requests, so rate limits and logs do not share a real key's bucket.
+const impersonationKeyPrefix = "impersonation"
+
+// AuthenticateImpersonation verifies an admin-issued impersonation token and
+// builds a request context as if one of the tenant's API keys were used,
+// with no key-level scopes or quota. Rejections are returned as
+// *APIKeyAuthError.
+func (c *Container) AuthenticateImpersonation(ctx context.Context, token string) (*requestctx.Context, error) {
+	if c.AdminAuth == nil {
+		return nil, apiKeyAuthError(http.StatusUnauthorized, "invalid api key")
+	}
+	claims, err := c.AdminAuth.ValidateImpersonationToken(token)
+	if err != nil {
+		return nil, apiKeyAuthError(http.StatusUnauthorized, "invalid or expired impersonation token")
+	}
+
+	tenantID := toPgUUID(claims.TenantID)
+	tenant, err := c.Queries.GetTenantByID(ctx, tenantID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apiKeyAuthError(http.StatusUnauthorized, "tenant not found")
+		}
+		return nil, apiKeyAuthError(http.StatusInternalServerError, "tenant lookup failed")
+	}
+	if tenant.Status != db.TenantStatusActive {
+		return nil, apiKeyAuthError(http.StatusForbidden, "tenant is not active")
+	}
+
+	rc, err := BuildRequestContext(ctx, c, db.ApiKey{
+		ID:       pgtype.UUID{Valid: true},
+		TenantID: tenantID,
+		Prefix:   impersonationKeyPrefix,
+	})
+	if err != nil {
+		return nil, apiKeyAuthError(http.StatusInternalServerError, err.Error())
+	}
+	rc.ImpersonatedBy = claims.AdminUserID
+	rc.ImpersonationReason = claims.Reason
+	return rc, nil
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	personalTenantCheckTTL     = 24 * time.Hour
+	personalTenantCheckTimeout = 5 * time.Second
+)
+
+// ensureOwnerPersonalTenant provisions the personal tenant of a key owner who
+// has never signed in, without delaying the request. The outcome is cached in
+// Redis for a day so each user costs at most one lookup per day.
+func (c *Container) ensureOwnerPersonalTenant(ownerID pgtype.UUID) {
+	if !ownerID.Valid || c.Accounts == nil || c.Queries == nil || c.Redis == nil {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), personalTenantCheckTimeout)
+		defer cancel()
+		if err := c.checkOwnerPersonalTenant(ctx, ownerID); err != nil {
+			slog.Warn("ensure personal tenant for api key owner failed",
+				slog.String("user_id", uuid.UUID(ownerID.Bytes).String()),
+				slog.String("error", err.Error()))
+		}
+	}()
+}
+
+func (c *Container) checkOwnerPersonalTenant(ctx context.Context, ownerID pgtype.UUID) error {
+	key := personalTenantCacheKey(ownerID)
+	// SETNX claims the check, so concurrent requests and replicas do not race
+	// to provision the same user.
+	claimed, err := c.Redis.SetNX(ctx, key, "pending", personalTenantCheckTTL).Result()
+	if err != nil || !claimed {
+		return err
+	}
+
+	user, err := c.Queries.GetUserByID(ctx, ownerID)
+	if err != nil {
+		c.Redis.Del(ctx, key)
+		return err
+	}
+	if !user.PersonalTenantID.Valid {
+		if user, _, err = c.Accounts.EnsurePersonalTenant(ctx, user); err != nil {
+			// Release the claim so the next request retries.
+			c.Redis.Del(ctx, key)
+			return err
+		}
+	}
+	return c.Redis.Set(ctx, key, uuid.UUID(user.PersonalTenantID.Bytes).String(), personalTenantCheckTTL).Err()
+}
+
+func personalTenantCacheKey(ownerID pgtype.UUID) string {
+	return "personal_tenant:" + uuid.UUID(ownerID.Bytes).String()
+}
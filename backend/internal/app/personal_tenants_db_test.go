@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/accounts"
+	"github.com/ncecere/open_model_gateway/backend/internal/database/dbtest"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+func TestCheckOwnerPersonalTenantProvisionsTenant(t *testing.T) {
+	pool := dbtest.Open(t)
+	queries := db.New(pool)
+	ctx := context.Background()
+
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	t.Cleanup(server.Close)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	user, err := queries.CreateUser(ctx, db.CreateUserParams{
+		Email: "owner-" + uuid.NewString() + "@example.com",
+		Name:  "Key Owner",
+	})
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	container := &Container{
+		Redis:    client,
+		Queries:  queries,
+		Accounts: accounts.NewPersonalService(pool, queries),
+	}
+	if err := container.checkOwnerPersonalTenant(ctx, user.ID); err != nil {
+		t.Fatalf("check: %v", err)
+	}
+
+	refreshed, err := queries.GetUserByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("reload user: %v", err)
+	}
+	if !refreshed.PersonalTenantID.Valid {
+		t.Fatal("expected the personal tenant to be provisioned")
+	}
+	t.Cleanup(func() { _ = queries.DeleteTenant(context.Background(), refreshed.PersonalTenantID) })
+	cached, _ := server.Get(personalTenantCacheKey(user.ID))
+	if cached != uuid.UUID(refreshed.PersonalTenantID.Bytes).String() {
+		t.Fatalf("expected the claim to hold the tenant id, got %q", cached)
+	}
+}
@@ -0,0 +1,158 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/accounts"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+// userLookupDB serves GetUserByID from a fixed record and counts lookups.
+type userLookupDB struct {
+	user    db.User
+	lookups atomic.Int32
+}
+
+func (d *userLookupDB) Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, errors.New("unexpected exec")
+}
+
+func (d *userLookupDB) Query(context.Context, string, ...interface{}) (pgx.Rows, error) {
+	return nil, errors.New("unexpected query")
+}
+
+func (d *userLookupDB) QueryRow(context.Context, string, ...interface{}) pgx.Row {
+	d.lookups.Add(1)
+	return userRow{user: d.user}
+}
+
+type userRow struct {
+	user db.User
+}
+
+func (r userRow) Scan(dest ...any) error {
+	*dest[0].(*pgtype.UUID) = r.user.ID
+	*dest[5].(*pgtype.UUID) = r.user.PersonalTenantID
+	return nil
+}
+
+func newPersonalTenantContainer(t *testing.T, user db.User) (*Container, *userLookupDB, *miniredis.Miniredis) {
+	t.Helper()
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	t.Cleanup(server.Close)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	store := &userLookupDB{user: user}
+	queries := db.New(store)
+	return &Container{
+		Redis:    client,
+		Queries:  queries,
+		Accounts: accounts.NewPersonalService(nil, queries),
+	}, store, server
+}
+
+func TestCheckOwnerPersonalTenantClaimsOnce(t *testing.T) {
+	ownerID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	tenantID := uuid.New()
+	container, store, server := newPersonalTenantContainer(t, db.User{
+		ID:               ownerID,
+		PersonalTenantID: pgtype.UUID{Bytes: tenantID, Valid: true},
+	})
+	ctx := context.Background()
+
+	if err := container.checkOwnerPersonalTenant(ctx, ownerID); err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	key := personalTenantCacheKey(ownerID)
+	if got, _ := server.Get(key); got != tenantID.String() {
+		t.Fatalf("expected the claim to hold the tenant id, got %q", got)
+	}
+	if ttl := server.TTL(key); ttl != personalTenantCheckTTL {
+		t.Fatalf("expected a %s claim, got %s", personalTenantCheckTTL, ttl)
+	}
+
+	if err := container.checkOwnerPersonalTenant(ctx, ownerID); err != nil {
+		t.Fatalf("repeat check: %v", err)
+	}
+	if got := store.lookups.Load(); got != 1 {
+		t.Fatalf("expected the repeat check to skip the lookup, got %d lookups", got)
+	}
+}
+
+func TestCheckOwnerPersonalTenantConcurrentFirstCallsLookUpOnce(t *testing.T) {
+	ownerID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	container, store, _ := newPersonalTenantContainer(t, db.User{
+		ID:               ownerID,
+		PersonalTenantID: pgtype.UUID{Bytes: uuid.New(), Valid: true},
+	})
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := container.checkOwnerPersonalTenant(context.Background(), ownerID); err != nil {
+				t.Errorf("check: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := store.lookups.Load(); got != 1 {
+		t.Fatalf("expected exactly one claim to win, got %d lookups", got)
+	}
+}
+
+func TestCheckOwnerPersonalTenantReleasesClaimOnFailure(t *testing.T) {
+	ownerID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	// The personal service has no pool, so provisioning the tenant fails.
+	container, store, server := newPersonalTenantContainer(t, db.User{ID: ownerID})
+	ctx := context.Background()
+
+	if err := container.checkOwnerPersonalTenant(ctx, ownerID); err == nil {
+		t.Fatal("expected provisioning to fail")
+	}
+	if server.Exists(personalTenantCacheKey(ownerID)) {
+		t.Fatal("expected the claim to be released after the failure")
+	}
+	if err := container.checkOwnerPersonalTenant(ctx, ownerID); err == nil {
+		t.Fatal("expected the retry to fail again")
+	}
+	if got := store.lookups.Load(); got != 2 {
+		t.Fatalf("expected the next call to retry, got %d lookups", got)
+	}
+}
+
+func TestEnsureOwnerPersonalTenantChecksInBackground(t *testing.T) {
+	ownerID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	container, _, server := newPersonalTenantContainer(t, db.User{
+		ID:               ownerID,
+		PersonalTenantID: pgtype.UUID{Bytes: uuid.New(), Valid: true},
+	})
+
+	container.ensureOwnerPersonalTenant(ownerID)
+	deadline := time.Now().Add(personalTenantCheckTimeout)
+	for !server.Exists(personalTenantCacheKey(ownerID)) {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the background check to claim the owner")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,160 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/providers"
+)
+
+const credentialTestTimeout = 30 * time.Second
+
+var (
+	// ErrNoProviderRoutes reports a rotation for a provider endpoint that
+	// serves no enabled catalog entries, so the new key cannot be tested.
+	ErrNoProviderRoutes = errors.New("provider endpoint has no enabled routes to test the credential against")
+	// ErrCredentialTestFailed wraps the provider error from the test
+	// inference made with a new credential.
+	ErrCredentialTestFailed = errors.New("credential test inference failed")
+	// ErrCredentialEncryptionDisabled reports a rotation while
+	// providers.credential_encryption_key is unset, since the key could not
+	// be stored encrypted.
+	ErrCredentialEncryptionDisabled = errors.New("providers.credential_encryption_key is not configured")
+)
+
+// ProviderCredentialRotation describes a completed credential rotation.
+type ProviderCredentialRotation struct {
+	Provider  string    `json:"provider"`
+	Endpoint  string    `json:"endpoint"`
+	KeyHash   string    `json:"key_hash"`
+	Routes    int       `json:"routes"`
+	RotatedAt time.Time `json:"rotated_at"`
+}
+
+// providerCredentialStore is the subset of db.Queries used to load rotated
+// credentials.
+type providerCredentialStore interface {
+	ListProviderCredentials(ctx context.Context) ([]db.ProviderCredential, error)
+	SealProviderCredential(ctx context.Context, arg db.SealProviderCredentialParams) error
+}
+
+// LoadProviderCredentials decrypts the rotated provider API keys stored in
+// the database, keyed by provider endpoint. Rows written in plaintext by
+// older releases are encrypted in place. Stored credentials cannot be read
+// without a cipher, so that is an error.
+func LoadProviderCredentials(ctx context.Context, store providerCredentialStore, cipher *providers.CredentialCipher) (map[providers.CredentialScope]string, error) {
+	result := make(map[providers.CredentialScope]string)
+	rows, err := store.ListProviderCredentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) > 0 && cipher == nil {
+		return nil, fmt.Errorf("%w: %d stored provider credentials cannot be decrypted", ErrCredentialEncryptionDisabled, len(rows))
+	}
+	for _, row := range rows {
+		scope := providers.NewCredentialScope(row.Provider, row.Endpoint)
+		if row.ApiKeyCiphertext.Valid {
+			key, err := cipher.Open(scope, row.ApiKeyCiphertext.String)
+			if err != nil {
+				return nil, fmt.Errorf("provider credential %s %q: %w", scope.Provider, scope.Endpoint, err)
+			}
+			result[scope] = key
+			continue
+		}
+		if !row.ApiKey.Valid {
+			continue
+		}
+		sealed, err := cipher.Seal(scope, row.ApiKey.String)
+		if err != nil {
+			return nil, err
+		}
+		if err := store.SealProviderCredential(ctx, db.SealProviderCredentialParams{
+			Provider:         row.Provider,
+			Endpoint:         row.Endpoint,
+			ApiKeyCiphertext: pgtype.Text{String: sealed, Valid: true},
+		}); err != nil {
+			return nil, fmt.Errorf("encrypt provider credential %s: %w", scope.Provider, err)
+		}
+		result[scope] = row.ApiKey.String
+	}
+	return result, nil
+}
+
+// RotateProviderCredential switches the provider instance in scope to a new
+// API key without reloading the router. Its routes are rebuilt with key and
+// a test inference must succeed before anything changes, so the old key
+// keeps serving traffic until then. The key is stored encrypted so it
+// survives restarts.
+func (c *Container) RotateProviderCredential(ctx context.Context, scope providers.CredentialScope, key string) (ProviderCredentialRotation, error) {
+	factory := c.Factory
+	if factory == nil || c.Engine == nil {
+		return ProviderCredentialRotation{}, errors.New("router unavailable")
+	}
+	if c.CredentialCipher == nil {
+		return ProviderCredentialRotation{}, ErrCredentialEncryptionDisabled
+	}
+	routes, err := factory.BuildProvider(ctx, scope, key)
+	if err != nil {
+		return ProviderCredentialRotation{}, err
+	}
+	probe, total := firstRoute(routes)
+	if total == 0 {
+		return ProviderCredentialRotation{}, ErrNoProviderRoutes
+	}
+
+	testCtx, cancel := context.WithTimeout(ctx, credentialTestTimeout)
+	defer cancel()
+	if err := probe.TestInference(testCtx); err != nil {
+		return ProviderCredentialRotation{}, fmt.Errorf("%w: %s: %v", ErrCredentialTestFailed, probe.Alias, err)
+	}
+
+	sealed, err := c.CredentialCipher.Seal(scope, key)
+	if err != nil {
+		return ProviderCredentialRotation{}, err
+	}
+	record, err := c.Queries.UpsertProviderCredential(ctx, db.UpsertProviderCredentialParams{
+		Provider:         scope.Provider,
+		Endpoint:         scope.Endpoint,
+		ApiKeyCiphertext: pgtype.Text{String: sealed, Valid: true},
+		KeyHash:          providers.CredentialHash(key),
+	})
+	if err != nil {
+		return ProviderCredentialRotation{}, err
+	}
+	if err := factory.RotateCredential(scope, key); err != nil {
+		return ProviderCredentialRotation{}, err
+	}
+	c.Engine.ReplaceProviderRoutes(scope, routes)
+
+	return ProviderCredentialRotation{
+		Provider:  scope.Provider,
+		Endpoint:  scope.Endpoint,
+		KeyHash:   record.KeyHash,
+		Routes:    total,
+		RotatedAt: record.RotatedAt.Time,
+	}, nil
+}
+
+// firstRoute returns the first route of the alphabetically first alias and
+// the total number of routes.
+func firstRoute(routes map[string][]providers.Route) (providers.Route, int) {
+	aliases := make([]string, 0, len(routes))
+	total := 0
+	for alias, rts := range routes {
+		if len(rts) > 0 {
+			aliases = append(aliases, alias)
+			total += len(rts)
+		}
+	}
+	if total == 0 {
+		return providers.Route{}, 0
+	}
+	sort.Strings(aliases)
+	return routes[aliases[0]][0], total
+}
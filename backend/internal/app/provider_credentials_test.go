@@ -0,0 +1,77 @@
+package app
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/providers"
+	"github.com/ncecere/open_model_gateway/backend/internal/router"
+)
+
+type stubCredentialStore struct {
+	rows   []db.ProviderCredential
+	sealed []db.SealProviderCredentialParams
+}
+
+func (s *stubCredentialStore) ListProviderCredentials(context.Context) ([]db.ProviderCredential, error) {
+	return s.rows, nil
+}
+
+func (s *stubCredentialStore) SealProviderCredential(_ context.Context, arg db.SealProviderCredentialParams) error {
+	s.sealed = append(s.sealed, arg)
+	return nil
+}
+
+func TestLoadProviderCredentialsDecryptsAndSealsLegacyRows(t *testing.T) {
+	cipher, err := providers.NewCredentialCipher(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32))))
+	if err != nil {
+		t.Fatalf("new cipher: %v", err)
+	}
+	proxy := providers.NewCredentialScope("openai", "https://proxy.example.com/v1")
+	ciphertext, err := cipher.Seal(proxy, "sk-proxy")
+	if err != nil {
+		t.Fatalf("seal: %v", err)
+	}
+	store := &stubCredentialStore{rows: []db.ProviderCredential{
+		{Provider: "anthropic", ApiKey: pgtype.Text{String: "sk-legacy", Valid: true}},
+		{Provider: "openai", Endpoint: proxy.Endpoint, ApiKeyCiphertext: pgtype.Text{String: ciphertext, Valid: true}},
+	}}
+
+	got, err := LoadProviderCredentials(context.Background(), store, cipher)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if got[proxy] != "sk-proxy" || got[providers.NewCredentialScope("anthropic", "")] != "sk-legacy" {
+		t.Fatalf("unexpected credentials %+v", got)
+	}
+	if len(store.sealed) != 1 || store.sealed[0].Provider != "anthropic" {
+		t.Fatalf("expected the legacy row to be sealed, got %+v", store.sealed)
+	}
+	opened, err := cipher.Open(providers.NewCredentialScope("anthropic", ""), store.sealed[0].ApiKeyCiphertext.String)
+	if err != nil || opened != "sk-legacy" {
+		t.Fatalf("sealed legacy row = %q, %v", opened, err)
+	}
+
+	if _, err := LoadProviderCredentials(context.Background(), store, nil); !errors.Is(err, ErrCredentialEncryptionDisabled) {
+		t.Fatalf("expected ErrCredentialEncryptionDisabled without a cipher, got %v", err)
+	}
+	empty, err := LoadProviderCredentials(context.Background(), &stubCredentialStore{}, nil)
+	if err != nil || len(empty) != 0 {
+		t.Fatalf("no rows should load without a cipher, got %+v %v", empty, err)
+	}
+}
+
+func TestRotateProviderCredentialRequiresCipher(t *testing.T) {
+	container := &Container{Factory: providers.NewFactory(&config.Config{}), Engine: router.NewEngine()}
+	_, err := container.RotateProviderCredential(context.Background(), providers.NewCredentialScope("openai", ""), "sk-new")
+	if !errors.Is(err, ErrCredentialEncryptionDisabled) {
+		t.Fatalf("expected ErrCredentialEncryptionDisabled, got %v", err)
+	}
+}
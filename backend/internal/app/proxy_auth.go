@@ -0,0 +1,68 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+)
+
+// proxyKeyPrefix stands in for the API key prefix on requests authenticated
+// by a trusted proxy; the user ID is appended so each user keeps their own
+// rate limit bucket.
+const proxyKeyPrefix = "proxy:"
+
+// AuthenticateProxyUser builds a request context for a user identified by a
+// trusted reverse proxy, billed to the user's personal tenant, which is
+// created on first use. The middleware must have verified the proxy before
+// calling this. Rejections are returned as *APIKeyAuthError.
+func (c *Container) AuthenticateProxyUser(ctx context.Context, rawUserID string) (*requestctx.Context, error) {
+	userID, err := uuid.Parse(strings.TrimSpace(rawUserID))
+	if err != nil {
+		return nil, apiKeyAuthError(http.StatusUnauthorized, "invalid proxy user id")
+	}
+	user, err := c.Queries.GetUserByID(ctx, toPgUUID(userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apiKeyAuthError(http.StatusUnauthorized, "proxy user not found")
+		}
+		return nil, apiKeyAuthError(http.StatusInternalServerError, "user lookup failed")
+	}
+	if !user.PersonalTenantID.Valid {
+		if c.Accounts == nil {
+			return nil, apiKeyAuthError(http.StatusForbidden, "proxy user has no personal tenant")
+		}
+		if user, _, err = c.Accounts.EnsurePersonalTenant(ctx, user); err != nil {
+			return nil, apiKeyAuthError(http.StatusInternalServerError, "personal tenant provisioning failed")
+		}
+	}
+
+	tenant, err := c.Queries.GetTenantByID(ctx, user.PersonalTenantID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apiKeyAuthError(http.StatusUnauthorized, "tenant not found")
+		}
+		return nil, apiKeyAuthError(http.StatusInternalServerError, "tenant lookup failed")
+	}
+	if tenant.Status != db.TenantStatusActive {
+		return nil, apiKeyAuthError(http.StatusForbidden, "tenant is not active")
+	}
+
+	rc, err := BuildRequestContext(ctx, c, db.ApiKey{
+		ID:          pgtype.UUID{Valid: true},
+		TenantID:    user.PersonalTenantID,
+		Prefix:      proxyKeyPrefix + userID.String(),
+		OwnerUserID: user.ID,
+	})
+	if err != nil {
+		return nil, apiKeyAuthError(http.StatusInternalServerError, err.Error())
+	}
+	return rc, nil
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/limits"
+)
+
+// LoadTenantQuotaOverrides returns the request quotas stored in the database.
+func LoadTenantQuotaOverrides(ctx context.Context, queries *db.Queries) (map[uuid.UUID]limits.QuotaConfig, error) {
+	result := make(map[uuid.UUID]limits.QuotaConfig)
+	if queries == nil {
+		return result, nil
+	}
+	rows, err := queries.ListTenantQuotaOverrides(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		tenantID, err := uuidFromPg(row.TenantID)
+		if err != nil {
+			continue
+		}
+		result[tenantID] = limits.QuotaConfig{
+			MaxRequests:       row.MaxRequestsPerPeriod,
+			RefreshSchedule:   config.NormalizeBudgetRefreshSchedule(row.RefreshSchedule),
+			MaxRequestBodyMB:  row.MaxRequestBodyMb,
+			StreamIdleTimeout: time.Duration(row.StreamIdleTimeoutSec) * time.Second,
+		}
+	}
+	return result, nil
+}
+
+// TenantQuota returns the tenant's request quota, if one is configured.
+func (c *Container) TenantQuota(tenantID uuid.UUID) (limits.QuotaConfig, bool) {
+	if c == nil {
+		return limits.QuotaConfig{}, false
+	}
+	c.tenantQuotaMu.RLock()
+	defer c.tenantQuotaMu.RUnlock()
+	cfg, ok := c.TenantQuotas[tenantID]
+	return cfg, ok
+}
+
+// UpdateTenantQuota sets (or clears) the tenant's request quota.
+func (c *Container) UpdateTenantQuota(tenantID uuid.UUID, cfg *limits.QuotaConfig) {
+	if c == nil {
+		return
+	}
+	c.tenantQuotaMu.Lock()
+	defer c.tenantQuotaMu.Unlock()
+	if cfg == nil {
+		delete(c.TenantQuotas, tenantID)
+		return
+	}
+	if c.TenantQuotas == nil {
+		c.TenantQuotas = make(map[uuid.UUID]limits.QuotaConfig)
+	}
+	c.TenantQuotas[tenantID] = *cfg
+}
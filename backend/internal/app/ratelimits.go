@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -50,14 +51,16 @@ func RateLimitConfigFromRecord(base config.RateLimitConfig, record db.RateLimitD
 func (c *Container) UpdateRateLimitConfig(cfg config.RateLimitConfig) {
 	c.Config.RateLimits = cfg
 	c.DefaultKeyLimit = limits.LimitConfig{
-		RequestsPerMinute: cfg.DefaultRequestsPerMinute,
-		TokensPerMinute:   cfg.DefaultTokensPerMinute,
-		ParallelRequests:  cfg.DefaultParallelRequestsKey,
+		RequestsPerMinute:  cfg.DefaultRequestsPerMinute,
+		TokensPerMinute:    cfg.DefaultTokensPerMinute,
+		ParallelRequests:   cfg.DefaultParallelRequestsKey,
+		ParallelRequestTTL: cfg.ParallelRequestTTL,
 	}
 	c.DefaultTenantLimit = limits.LimitConfig{
-		RequestsPerMinute: cfg.DefaultRequestsPerMinute,
-		TokensPerMinute:   cfg.DefaultTokensPerMinute,
-		ParallelRequests:  cfg.DefaultParallelRequestsTenant,
+		RequestsPerMinute:  cfg.DefaultRequestsPerMinute,
+		TokensPerMinute:    cfg.DefaultTokensPerMinute,
+		ParallelRequests:   cfg.DefaultParallelRequestsTenant,
+		ParallelRequestTTL: cfg.ParallelRequestTTL,
 	}
 }
 
@@ -77,9 +80,10 @@ func LoadTenantRateLimitOverrides(ctx context.Context, queries *db.Queries) (map
 			continue
 		}
 		result[tenantID] = limits.LimitConfig{
-			RequestsPerMinute: int(row.RequestsPerMinute),
-			TokensPerMinute:   int(row.TokensPerMinute),
-			ParallelRequests:  int(row.ParallelRequests),
+			RequestsPerMinute:  int(row.RequestsPerMinute),
+			TokensPerMinute:    int(row.TokensPerMinute),
+			ParallelRequests:   int(row.ParallelRequests),
+			ParallelRequestTTL: time.Duration(row.ParallelRequestTtlSeconds) * time.Second,
 		}
 	}
 	return result, nil
@@ -101,9 +105,10 @@ func LoadAPIKeyRateLimitOverrides(ctx context.Context, queries *db.Queries) (map
 			continue
 		}
 		result[prefix] = limits.LimitConfig{
-			RequestsPerMinute: int(row.RequestsPerMinute),
-			TokensPerMinute:   int(row.TokensPerMinute),
-			ParallelRequests:  int(row.ParallelRequests),
+			RequestsPerMinute:  int(row.RequestsPerMinute),
+			TokensPerMinute:    int(row.TokensPerMinute),
+			ParallelRequests:   int(row.ParallelRequests),
+			ParallelRequestTTL: time.Duration(row.ParallelRequestTtlSeconds) * time.Second,
 		}
 	}
 	return result, nil
@@ -4,12 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"math"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/ncecere/open_model_gateway/backend/internal/config"
@@ -37,6 +39,10 @@ func BuildRequestContext(ctx context.Context, container *Container, record db.Ap
 	if err != nil {
 		return nil, err
 	}
+	var ownerID uuid.UUID
+	if record.OwnerUserID.Valid {
+		ownerID, _ = uuidFromPg(record.OwnerUserID)
+	}
 
 	var scopes []string
 	if len(record.ScopesJson) > 0 {
@@ -63,6 +69,7 @@ func BuildRequestContext(ctx context.Context, container *Container, record db.Ap
 	alertLastLevel := ""
 	var alertLastSent time.Time
 	hasOverride := false
+	softLimit := false
 
 	if override, err := container.Queries.GetTenantBudgetOverride(ctx, record.TenantID); err == nil {
 		if budget, ok := override.BudgetUsd.Float64(); ok {
@@ -88,6 +95,7 @@ func BuildRequestContext(ctx context.Context, container *Container, record db.Ap
 			alertLastSent = override.LastAlertAt.Time
 		}
 		hasOverride = true
+		softLimit = override.EnforcementMode == config.BudgetEnforcementSoft
 	}
 	if len(alertEmails) > 0 || len(alertWebhooks) > 0 {
 		alertsEnabled = true
@@ -114,10 +122,26 @@ func BuildRequestContext(ctx context.Context, container *Container, record db.Ap
 		warn = defaultWarnFloor
 	}
 
+	prompt, err := container.TenantSystemPrompt(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("load tenant system prompt: %w", err)
+	}
+
+	modelOverrides, err := loadTenantModelOverrides(ctx, container, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("load tenant model overrides: %w", err)
+	}
+
+	residency, err := loadTenantDataResidency(ctx, container, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("load tenant data residency: %w", err)
+	}
+
 	return &requestctx.Context{
 		TenantID:              tenantID,
 		APIKeyID:              keyID,
 		APIKeyPrefix:          record.Prefix,
+		OwnerUserID:           ownerID,
 		Scopes:                scopes,
 		BudgetLimitCents:      limit,
 		WarningThreshold:      warn,
@@ -129,9 +153,66 @@ func BuildRequestContext(ctx context.Context, container *Container, record db.Ap
 		AlertLastLevel:        alertLastLevel,
 		AlertLastSent:         alertLastSent,
 		HasBudgetOverride:     hasOverride,
+		BudgetSoftLimit:       softLimit,
+		SystemPrompt:          prompt.Content,
+		SystemPromptMode:      prompt.Mode,
+		ModelOverrides:        modelOverrides,
+		ZeroRetention:         record.ZeroRetention,
+		RequestBodyLimit:      requestBodyLimit(container, tenantID),
+		StreamIdleTimeout:     streamIdleTimeout(container, tenantID),
+		DataResidency:         residency,
 	}, nil
 }
 
+// requestBodyLimit converts the tenant's max_request_body_mb override to bytes.
+func requestBodyLimit(container *Container, tenantID uuid.UUID) int64 {
+	cfg, ok := container.TenantQuota(tenantID)
+	if !ok || cfg.MaxRequestBodyMB <= 0 {
+		return 0
+	}
+	return int64(cfg.MaxRequestBodyMB) * 1024 * 1024
+}
+
+// streamIdleTimeout returns the tenant's stream_idle_timeout_sec override.
+func streamIdleTimeout(container *Container, tenantID uuid.UUID) time.Duration {
+	cfg, ok := container.TenantQuota(tenantID)
+	if !ok {
+		return 0
+	}
+	return cfg.StreamIdleTimeout
+}
+
+func loadTenantModelOverrides(ctx context.Context, container *Container, tenantID uuid.UUID) (map[string]requestctx.ModelLimits, error) {
+	rows, err := container.Queries.ListTenantModelOverrides(ctx, toPgUUID(tenantID))
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	overrides := make(map[string]requestctx.ModelLimits, len(rows))
+	for _, row := range rows {
+		overrides[row.ModelAlias] = requestctx.ModelLimits{
+			ContextWindow:   row.ContextWindowOverride,
+			MaxOutputTokens: row.MaxOutputTokensOverride,
+		}
+	}
+	return overrides, nil
+}
+
+// loadTenantDataResidency returns the tenant's data_residency setting; tenants
+// without stored settings are unrestricted.
+func loadTenantDataResidency(ctx context.Context, container *Container, tenantID uuid.UUID) ([]string, error) {
+	settings, err := container.Queries.GetTenantSettings(ctx, toPgUUID(tenantID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return settings.DataResidency, nil
+}
+
 func trimStrings(values []string) []string {
 	out := make([]string, 0, len(values))
 	for _, v := range values {
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// StreamTracker counts response bodies still being streamed to clients so
+// shutdown can let them finish. The zero value is ready to use.
+type StreamTracker struct {
+	wg     sync.WaitGroup
+	active atomic.Int64
+}
+
+// Start registers a stream; the returned func must be called when the stream
+// writer exits.
+func (t *StreamTracker) Start() func() {
+	t.wg.Add(1)
+	t.active.Add(1)
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			t.active.Add(-1)
+			t.wg.Done()
+		})
+	}
+}
+
+// Active reports how many streams are in flight.
+func (t *StreamTracker) Active() int64 {
+	return t.active.Load()
+}
+
+// Wait blocks until every tracked stream has finished or ctx is done.
+func (t *StreamTracker) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		t.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,43 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/cache"
+)
+
+// TenantSystemPrompt returns the prompt injected into every chat request for
+// the tenant, consulting Redis before Postgres. Tenants without a prompt yield
+// an empty Content.
+func (c *Container) TenantSystemPrompt(ctx context.Context, tenantID uuid.UUID) (cache.SystemPrompt, error) {
+	if c == nil || c.Queries == nil || tenantID == uuid.Nil {
+		return cache.SystemPrompt{}, nil
+	}
+	if prompt, ok := c.SystemPrompts.Get(ctx, tenantID); ok {
+		return prompt, nil
+	}
+
+	var prompt cache.SystemPrompt
+	record, err := c.Queries.GetTenantSystemPrompt(ctx, toPgUUID(tenantID))
+	switch {
+	case err == nil:
+		prompt = cache.SystemPrompt{Content: record.Content, Mode: string(record.Mode)}
+	case errors.Is(err, pgx.ErrNoRows):
+	default:
+		return cache.SystemPrompt{}, err
+	}
+	c.SystemPrompts.Set(ctx, tenantID, prompt)
+	return prompt, nil
+}
+
+// InvalidateTenantSystemPrompt drops the cached prompt after it is edited.
+func (c *Container) InvalidateTenantSystemPrompt(tenantID uuid.UUID) {
+	if c == nil {
+		return
+	}
+	c.SystemPrompts.Invalidate(context.Background(), tenantID)
+}
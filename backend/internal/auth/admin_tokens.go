@@ -0,0 +1,246 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+const (
+	AdminTokenRoleAdmin      = "admin"
+	AdminTokenRoleSuperAdmin = "super_admin"
+
+	adminTokenPrefix       = "oga-"
+	adminTokenPrefixLength = 10
+	adminTokenSecretLength = 48
+)
+
+var (
+	ErrInvalidAdminToken   = errors.New("invalid admin token")
+	ErrAdminTokenIPDenied  = errors.New("admin token not permitted from this address")
+	ErrAdminTokenNotFound  = errors.New("admin token not found")
+	ErrAdminTokenForbidden = errors.New("insufficient privileges for admin token")
+)
+
+// CreateAdminTokenInput describes a long-lived admin token request.
+type CreateAdminTokenInput struct {
+	Name       string
+	Role       string
+	AllowedIPs []string
+	ExpiresAt  *time.Time
+}
+
+// IsAdminToken reports whether the bearer value looks like a long-lived admin
+// token rather than a session JWT.
+func IsAdminToken(token string) bool {
+	return strings.HasPrefix(token, adminTokenPrefix)
+}
+
+// CreateAdminToken mints a named token acting on behalf of owner. The plaintext
+// token is only returned here; the database keeps an argon2 hash.
+func (s *AdminAuthService) CreateAdminToken(ctx context.Context, owner db.User, input CreateAdminTokenInput) (db.AdminToken, string, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return db.AdminToken{}, "", errors.New("token name required")
+	}
+	role := strings.ToLower(strings.TrimSpace(input.Role))
+	if role == "" {
+		role = AdminTokenRoleAdmin
+	}
+	switch role {
+	case AdminTokenRoleAdmin:
+	case AdminTokenRoleSuperAdmin:
+		if !owner.IsSuperAdmin {
+			return db.AdminToken{}, "", ErrAdminTokenForbidden
+		}
+	default:
+		return db.AdminToken{}, "", fmt.Errorf("role must be %q or %q", AdminTokenRoleAdmin, AdminTokenRoleSuperAdmin)
+	}
+
+	allowed, err := normalizeCIDRs(input.AllowedIPs)
+	if err != nil {
+		return db.AdminToken{}, "", err
+	}
+
+	expires := pgtype.Timestamptz{}
+	if input.ExpiresAt != nil {
+		if !input.ExpiresAt.After(time.Now()) {
+			return db.AdminToken{}, "", errors.New("expires_at must be in the future")
+		}
+		expires = pgtype.Timestamptz{Time: input.ExpiresAt.UTC(), Valid: true}
+	}
+
+	prefix, err := randomString(adminTokenPrefixLength)
+	if err != nil {
+		return db.AdminToken{}, "", err
+	}
+	secret, err := randomString(adminTokenSecretLength)
+	if err != nil {
+		return db.AdminToken{}, "", err
+	}
+	hash, err := HashPassword(secret)
+	if err != nil {
+		return db.AdminToken{}, "", err
+	}
+
+	record, err := s.queries.CreateAdminToken(ctx, db.CreateAdminTokenParams{
+		UserID:     owner.ID,
+		Name:       name,
+		Prefix:     prefix,
+		SecretHash: hash,
+		Role:       role,
+		AllowedIps: allowed,
+		ExpiresAt:  expires,
+	})
+	if err != nil {
+		return db.AdminToken{}, "", fmt.Errorf("create admin token: %w", err)
+	}
+	return record, fmt.Sprintf("%s%s.%s", adminTokenPrefix, prefix, secret), nil
+}
+
+// ListAdminTokens returns the caller's tokens, or every token for super admins.
+func (s *AdminAuthService) ListAdminTokens(ctx context.Context, owner db.User) ([]db.AdminToken, error) {
+	if owner.IsSuperAdmin {
+		return s.queries.ListAdminTokens(ctx)
+	}
+	return s.queries.ListAdminTokensByUser(ctx, owner.ID)
+}
+
+// RevokeAdminToken revokes a token owned by the caller (or any token for super admins).
+func (s *AdminAuthService) RevokeAdminToken(ctx context.Context, owner db.User, tokenID uuid.UUID) (db.AdminToken, error) {
+	id := pgtype.UUID{Bytes: tokenID, Valid: true}
+	record, err := s.queries.GetAdminTokenByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.AdminToken{}, ErrAdminTokenNotFound
+		}
+		return db.AdminToken{}, err
+	}
+	if !owner.IsSuperAdmin && record.UserID != owner.ID {
+		return db.AdminToken{}, ErrAdminTokenNotFound
+	}
+	if record.RevokedAt.Valid {
+		return record, nil
+	}
+	revoked, err := s.queries.RevokeAdminToken(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return record, nil
+		}
+		return db.AdminToken{}, err
+	}
+	return revoked, nil
+}
+
+// AuthorizeAdminToken validates a long-lived admin token presented from
+// clientIP and returns the owning user with privileges capped to the token role.
+func (s *AdminAuthService) AuthorizeAdminToken(ctx context.Context, token string, clientIP string) (db.User, db.AdminToken, error) {
+	prefix, secret, ok := splitAdminToken(token)
+	if !ok {
+		return db.User{}, db.AdminToken{}, ErrInvalidAdminToken
+	}
+	record, err := s.queries.GetAdminTokenByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.User{}, db.AdminToken{}, ErrInvalidAdminToken
+		}
+		return db.User{}, db.AdminToken{}, err
+	}
+	if record.RevokedAt.Valid {
+		return db.User{}, db.AdminToken{}, ErrInvalidAdminToken
+	}
+	if record.ExpiresAt.Valid && !record.ExpiresAt.Time.After(time.Now()) {
+		return db.User{}, db.AdminToken{}, ErrInvalidAdminToken
+	}
+	match, err := VerifyPassword(secret, record.SecretHash)
+	if err != nil || !match {
+		return db.User{}, db.AdminToken{}, ErrInvalidAdminToken
+	}
+	if !ipAllowed(record.AllowedIps, clientIP) {
+		return db.User{}, db.AdminToken{}, ErrAdminTokenIPDenied
+	}
+
+	user, err := s.queries.GetUserByID(ctx, record.UserID)
+	if err != nil {
+		return db.User{}, db.AdminToken{}, err
+	}
+	switch record.Role {
+	case AdminTokenRoleSuperAdmin:
+		// The owner may have been demoted since the token was issued.
+		if !user.IsSuperAdmin {
+			return db.User{}, db.AdminToken{}, ErrInvalidAdminToken
+		}
+	default:
+		user.IsSuperAdmin = false
+	}
+
+	_ = s.queries.UpdateAdminTokenLastUsed(ctx, record.ID)
+	return user, record, nil
+}
+
+func splitAdminToken(token string) (string, string, bool) {
+	if !IsAdminToken(token) {
+		return "", "", false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(token, adminTokenPrefix), ".", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+func normalizeCIDRs(values []string) ([]string, error) {
+	out := make([]string, 0, len(values))
+	for _, raw := range values {
+		value := strings.TrimSpace(raw)
+		if value == "" {
+			continue
+		}
+		if !strings.Contains(value, "/") {
+			ip := net.ParseIP(value)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid ip address %q", value)
+			}
+			if ip.To4() != nil {
+				value += "/32"
+			} else {
+				value += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cidr %q", raw)
+		}
+		out = append(out, network.String())
+	}
+	return out, nil
+}
+
+func ipAllowed(cidrs []string, clientIP string) bool {
+	if len(cidrs) == 0 {
+		return true
+	}
+	ip := net.ParseIP(strings.TrimSpace(clientIP))
+	if ip == nil {
+		return false
+	}
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package auth
+
+import "testing"
+
+func TestNormalizeCIDRs(t *testing.T) {
+	got, err := normalizeCIDRs([]string{" 10.0.0.0/8 ", "192.168.1.7", "", "2001:db8::1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"10.0.0.0/8", "192.168.1.7/32", "2001:db8::1/128"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+	if _, err := normalizeCIDRs([]string{"not-an-ip"}); err == nil {
+		t.Fatalf("expected invalid address to be rejected")
+	}
+}
+
+func TestIPAllowed(t *testing.T) {
+	cidrs := []string{"10.0.0.0/8", "192.168.1.7/32"}
+	cases := map[string]bool{
+		"10.1.2.3":    true,
+		"192.168.1.7": true,
+		"192.168.1.8": false,
+		"garbage":     false,
+	}
+	for ip, want := range cases {
+		if got := ipAllowed(cidrs, ip); got != want {
+			t.Fatalf("ipAllowed(%q) = %v, want %v", ip, got, want)
+		}
+	}
+	if !ipAllowed(nil, "203.0.113.5") {
+		t.Fatalf("empty allow list should permit any address")
+	}
+}
+
+func TestSplitAdminToken(t *testing.T) {
+	prefix, secret, ok := splitAdminToken("oga-abc123.s3cr3t")
+	if !ok || prefix != "abc123" || secret != "s3cr3t" {
+		t.Fatalf("unexpected split: %q %q %v", prefix, secret, ok)
+	}
+	for _, token := range []string{"sk-abc.def", "oga-abc", "oga-.secret", "oga-abc."} {
+		if _, _, ok := splitAdminToken(token); ok {
+			t.Fatalf("expected %q to be rejected", token)
+		}
+	}
+}
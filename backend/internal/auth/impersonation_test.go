@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func newImpersonationTestService(t *testing.T) *AdminAuthService {
+	t.Helper()
+	tm, err := NewTokenManager("test-secret", time.Minute, time.Hour, "open-model-gateway-admin")
+	if err != nil {
+		t.Fatalf("token manager: %v", err)
+	}
+	return &AdminAuthService{tokenManager: tm}
+}
+
+func TestImpersonationTokenRoundTrip(t *testing.T) {
+	svc := newImpersonationTestService(t)
+	adminID, tenantID := uuid.New(), uuid.New()
+
+	token, expiresAt, err := svc.IssueImpersonationToken(adminID, tenantID, " ticket 42 ")
+	if err != nil {
+		t.Fatalf("issue: %v", err)
+	}
+	if !LooksLikeImpersonationToken(token) {
+		t.Fatalf("expected JWT to be detected as impersonation token")
+	}
+	if d := time.Until(expiresAt); d <= 14*time.Minute || d > ImpersonationTTL {
+		t.Fatalf("unexpected ttl %s", d)
+	}
+
+	claims, err := svc.ValidateImpersonationToken(token)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if claims.AdminUserID != adminID || claims.TenantID != tenantID || claims.Reason != "ticket 42" {
+		t.Fatalf("unexpected claims %+v", claims)
+	}
+
+	if _, _, err := svc.IssueImpersonationToken(adminID, tenantID, "  "); err == nil {
+		t.Fatal("expected empty reason to be rejected")
+	}
+}
+
+func TestImpersonationTokenRejectsSessionTokens(t *testing.T) {
+	svc := newImpersonationTestService(t)
+	pair, err := svc.tokenManager.Generate(uuid.New(), "admin@example.com")
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	for _, token := range []string{pair.AccessToken, pair.RefreshToken} {
+		if _, err := svc.ValidateImpersonationToken(token); !errors.Is(err, ErrInvalidImpersonationToken) {
+			t.Fatalf("session token accepted as impersonation token: %v", err)
+		}
+	}
+
+	token, _, err := svc.IssueImpersonationToken(uuid.New(), uuid.New(), "debug")
+	if err != nil {
+		t.Fatalf("issue: %v", err)
+	}
+	if _, err := svc.ValidateRefreshToken(token); err == nil {
+		t.Fatal("impersonation token must not be usable as a refresh token")
+	}
+	if _, err := svc.ValidateAccessToken(token); err == nil {
+		t.Fatal("impersonation token must not be usable as an admin access token")
+	}
+	if LooksLikeImpersonationToken("sk-abc.def") {
+		t.Fatal("api keys are not impersonation tokens")
+	}
+}
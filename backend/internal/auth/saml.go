@@ -0,0 +1,296 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/crewjam/saml"
+	dsig "github.com/russellhaering/goxmldsig"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+)
+
+type SAMLIdentity struct {
+	Issuer       string
+	Subject      string
+	Email        string
+	Name         string
+	Roles        []string
+	IsAdmin      bool
+	MetadataJSON []byte
+}
+
+// SAMLAuthRequest captures the pieces of an SP-initiated login that must be
+// remembered until the IdP posts back to the ACS endpoint.
+type SAMLAuthRequest struct {
+	ID          string
+	RedirectURL string
+}
+
+type SAMLProvider struct {
+	cfg          config.SAMLConfig
+	sp           *saml.ServiceProvider
+	emailAttr    string
+	nameAttr     string
+	rolesClaim   string
+	allowedRoles map[string]struct{}
+	adminRoles   map[string]struct{}
+}
+
+func NewSAMLProvider(ctx context.Context, cfg config.SAMLConfig) (*SAMLProvider, error) {
+	acsURL, err := url.Parse(cfg.ACSURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse saml acs url: %w", err)
+	}
+
+	metadata, err := fetchSAMLMetadata(ctx, cfg.MetadataURL, cfg.HTTPTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(cfg.IDPCertFile) != "" {
+		cert, err := loadCertificate(cfg.IDPCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("load saml idp certificate: %w", err)
+		}
+		pinIDPSigningCertificate(metadata, cert)
+	}
+
+	sp := &saml.ServiceProvider{
+		EntityID:          cfg.EntityID,
+		AcsURL:            *acsURL,
+		IDPMetadata:       metadata,
+		AuthnNameIDFormat: saml.EmailAddressNameIDFormat,
+	}
+	if cfg.SignRequests {
+		keyPair, err := tls.LoadX509KeyPair(cfg.SPCertFile, cfg.SPKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load saml sp key pair: %w", err)
+		}
+		key, ok := keyPair.PrivateKey.(*rsa.PrivateKey)
+		if !ok {
+			return nil, errors.New("saml sp key must be an RSA private key")
+		}
+		leaf, err := x509.ParseCertificate(keyPair.Certificate[0])
+		if err != nil {
+			return nil, fmt.Errorf("parse saml sp certificate: %w", err)
+		}
+		sp.Key = key
+		sp.Certificate = leaf
+		sp.SignatureMethod = dsig.RSASHA256SignatureMethod
+	}
+
+	emailAttr := strings.TrimSpace(cfg.EmailAttr)
+	if emailAttr == "" {
+		emailAttr = "email"
+	}
+	nameAttr := strings.TrimSpace(cfg.NameAttr)
+	if nameAttr == "" {
+		nameAttr = "name"
+	}
+
+	return &SAMLProvider{
+		cfg:          cfg,
+		sp:           sp,
+		emailAttr:    emailAttr,
+		nameAttr:     nameAttr,
+		rolesClaim:   strings.TrimSpace(cfg.RolesClaim),
+		allowedRoles: normalizeRoleSet(cfg.AllowedRoles),
+		adminRoles:   normalizeRoleSet(cfg.AdminRoles),
+	}, nil
+}
+
+// AuthRequest builds a redirect-binding AuthnRequest addressed to the IdP.
+func (p *SAMLProvider) AuthRequest(relayState string) (*SAMLAuthRequest, error) {
+	idpURL := p.sp.GetSSOBindingLocation(saml.HTTPRedirectBinding)
+	if idpURL == "" {
+		return nil, errors.New("saml: idp metadata has no redirect binding endpoint")
+	}
+	req, err := p.sp.MakeAuthenticationRequest(idpURL, saml.HTTPRedirectBinding, saml.HTTPPostBinding)
+	if err != nil {
+		return nil, fmt.Errorf("build saml authn request: %w", err)
+	}
+	redirectURL, err := req.Redirect(url.QueryEscape(relayState), p.sp)
+	if err != nil {
+		return nil, fmt.Errorf("encode saml authn request: %w", err)
+	}
+	return &SAMLAuthRequest{
+		ID:          req.ID,
+		RedirectURL: redirectURL.String(),
+	}, nil
+}
+
+// ParseResponse decodes and verifies the base64 SAMLResponse form value posted
+// to the ACS endpoint.
+func (p *SAMLProvider) ParseResponse(encoded string, requestIDs []string) (*saml.Assertion, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("decode saml response: %w", err)
+	}
+	assertion, err := p.sp.ParseXMLResponse(raw, requestIDs)
+	if err != nil {
+		var invalid *saml.InvalidResponseError
+		if errors.As(err, &invalid) && invalid.PrivateErr != nil {
+			return nil, fmt.Errorf("saml: invalid response: %w", invalid.PrivateErr)
+		}
+		return nil, fmt.Errorf("saml: invalid response: %w", err)
+	}
+	return assertion, nil
+}
+
+// Identity maps a verified assertion onto the gateway's user attributes and
+// applies the configured role rules.
+func (p *SAMLProvider) Identity(assertion *saml.Assertion) (*SAMLIdentity, error) {
+	if assertion == nil {
+		return nil, errors.New("saml: assertion required")
+	}
+	identity := &SAMLIdentity{Issuer: assertion.Issuer.Value}
+	if assertion.Subject != nil && assertion.Subject.NameID != nil {
+		identity.Subject = strings.TrimSpace(assertion.Subject.NameID.Value)
+	}
+
+	attrs := samlAttributeValues(assertion)
+	identity.Email = firstAttribute(attrs, p.emailAttr)
+	if identity.Email == "" && strings.Contains(identity.Subject, "@") {
+		identity.Email = identity.Subject
+	}
+	if identity.Email == "" {
+		return nil, errors.New("saml: email not present in assertion")
+	}
+	identity.Name = firstAttribute(attrs, p.nameAttr)
+	if p.rolesClaim != "" {
+		roles := make([]string, 0, len(attrs[p.rolesClaim]))
+		for _, value := range attrs[p.rolesClaim] {
+			if role := normalizeRole(value); role != "" {
+				roles = append(roles, role)
+			}
+		}
+		identity.Roles = dedupeRoles(roles)
+	}
+	if identity.Subject == "" {
+		identity.Subject = identity.Email
+	}
+
+	if len(p.allowedRoles) > 0 && !hasMatchingRole(identity.Roles, p.allowedRoles) {
+		return nil, errors.New("saml: user missing required role")
+	}
+	if len(p.adminRoles) > 0 {
+		identity.IsAdmin = hasMatchingRole(identity.Roles, p.adminRoles)
+	}
+
+	metadata, err := json.Marshal(map[string]any{
+		"email": identity.Email,
+		"name":  identity.Name,
+		"roles": identity.Roles,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal saml metadata: %w", err)
+	}
+	identity.MetadataJSON = metadata
+	return identity, nil
+}
+
+func samlAttributeValues(assertion *saml.Assertion) map[string][]string {
+	values := make(map[string][]string)
+	for _, statement := range assertion.AttributeStatements {
+		for _, attr := range statement.Attributes {
+			for _, value := range attr.Values {
+				v := strings.TrimSpace(value.Value)
+				if v == "" {
+					continue
+				}
+				if attr.Name != "" {
+					values[attr.Name] = append(values[attr.Name], v)
+				}
+				if attr.FriendlyName != "" && attr.FriendlyName != attr.Name {
+					values[attr.FriendlyName] = append(values[attr.FriendlyName], v)
+				}
+			}
+		}
+	}
+	return values
+}
+
+func firstAttribute(attrs map[string][]string, name string) string {
+	if vals := attrs[name]; len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
+
+func fetchSAMLMetadata(ctx context.Context, metadataURL string, timeout time.Duration) (*saml.EntityDescriptor, error) {
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	fetchCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(fetchCtx, http.MethodGet, metadataURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build saml metadata request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch saml metadata: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch saml metadata: unexpected status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("read saml metadata: %w", err)
+	}
+
+	var descriptor saml.EntityDescriptor
+	if err := xml.Unmarshal(body, &descriptor); err != nil {
+		return nil, fmt.Errorf("parse saml metadata: %w", err)
+	}
+	if len(descriptor.IDPSSODescriptors) == 0 {
+		return nil, errors.New("saml metadata missing IDPSSODescriptor")
+	}
+	return &descriptor, nil
+}
+
+func loadCertificate(path string) (*x509.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// pinIDPSigningCertificate replaces any signing keys advertised in metadata
+// with the operator-supplied certificate so rotated or spoofed metadata cannot
+// introduce a new trust anchor.
+func pinIDPSigningCertificate(metadata *saml.EntityDescriptor, cert *x509.Certificate) {
+	encoded := base64.StdEncoding.EncodeToString(cert.Raw)
+	descriptor := saml.KeyDescriptor{
+		Use: "signing",
+		KeyInfo: saml.KeyInfo{
+			X509Data: saml.X509Data{
+				X509Certificates: []saml.X509Certificate{{Data: encoded}},
+			},
+		},
+	}
+	for i := range metadata.IDPSSODescriptors {
+		metadata.IDPSSODescriptors[i].KeyDescriptors = []saml.KeyDescriptor{descriptor}
+	}
+}
@@ -0,0 +1,223 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/xml"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crewjam/saml"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+)
+
+const (
+	testSAMLEntityID = "https://gateway.test/saml"
+	testSAMLACSURL   = "https://gateway.test/admin/auth/saml/callback"
+)
+
+type mockIDP struct {
+	idp    *saml.IdentityProvider
+	server *httptest.Server
+}
+
+func newMockIDP(t *testing.T) *mockIDP {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "mock-idp"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parse certificate: %v", err)
+	}
+
+	mock := &mockIDP{}
+	mock.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf, err := xml.Marshal(mock.idp.Metadata())
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/samlmetadata+xml")
+		_, _ = w.Write(buf)
+	}))
+	t.Cleanup(mock.server.Close)
+
+	metadataURL, _ := url.Parse(mock.server.URL + "/metadata")
+	ssoURL, _ := url.Parse(mock.server.URL + "/sso")
+	mock.idp = &saml.IdentityProvider{
+		Key:         key,
+		Certificate: cert,
+		MetadataURL: *metadataURL,
+		SSOURL:      *ssoURL,
+	}
+	return mock
+}
+
+// respond signs a response to requestID carrying the supplied attributes.
+func (m *mockIDP) respond(t *testing.T, provider *SAMLProvider, requestID string, nameID string, attrs map[string][]string) string {
+	t.Helper()
+
+	spMetadata := provider.sp.Metadata()
+	req := &saml.IdpAuthnRequest{
+		IDP:                     m.idp,
+		HTTPRequest:             httptest.NewRequest(http.MethodPost, "/sso", nil),
+		Request:                 saml.AuthnRequest{ID: requestID},
+		ServiceProviderMetadata: spMetadata,
+		SPSSODescriptor:         &spMetadata.SPSSODescriptors[0],
+		ACSEndpoint:             &saml.IndexedEndpoint{Binding: saml.HTTPPostBinding, Location: testSAMLACSURL},
+		Now:                     saml.TimeNow(),
+	}
+
+	session := &saml.Session{
+		ID:         "session-1",
+		CreateTime: time.Now(),
+		ExpireTime: time.Now().Add(time.Hour),
+		Index:      "1",
+		NameID:     nameID,
+	}
+	for name, values := range attrs {
+		attr := saml.Attribute{Name: name, NameFormat: "urn:oasis:names:tc:SAML:2.0:attrname-format:basic"}
+		for _, v := range values {
+			attr.Values = append(attr.Values, saml.AttributeValue{Type: "xs:string", Value: v})
+		}
+		session.CustomAttributes = append(session.CustomAttributes, attr)
+	}
+
+	if err := (saml.DefaultAssertionMaker{}).MakeAssertion(req, session); err != nil {
+		t.Fatalf("make assertion: %v", err)
+	}
+	form, err := req.PostBinding()
+	if err != nil {
+		t.Fatalf("post binding: %v", err)
+	}
+	return form.SAMLResponse
+}
+
+func newTestSAMLProvider(t *testing.T, mock *mockIDP, cfg config.SAMLConfig) *SAMLProvider {
+	t.Helper()
+	cfg.Enabled = true
+	cfg.MetadataURL = mock.server.URL + "/metadata"
+	cfg.EntityID = testSAMLEntityID
+	cfg.ACSURL = testSAMLACSURL
+	cfg.HTTPTimeout = time.Second
+	provider, err := NewSAMLProvider(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("new saml provider: %v", err)
+	}
+	return provider
+}
+
+func TestSAMLProviderRoundTrip(t *testing.T) {
+	mock := newMockIDP(t)
+	provider := newTestSAMLProvider(t, mock, config.SAMLConfig{
+		RolesClaim: "groups",
+		AdminRoles: []string{"Gateway-Admins"},
+	})
+
+	authReq, err := provider.AuthRequest("relay-123")
+	if err != nil {
+		t.Fatalf("auth request: %v", err)
+	}
+	if !strings.HasPrefix(authReq.RedirectURL, mock.server.URL+"/sso?SAMLRequest=") {
+		t.Fatalf("unexpected redirect url %q", authReq.RedirectURL)
+	}
+	if !strings.Contains(authReq.RedirectURL, "RelayState=relay-123") {
+		t.Fatalf("relay state missing from %q", authReq.RedirectURL)
+	}
+
+	encoded := mock.respond(t, provider, authReq.ID, "ada@example.com", map[string][]string{
+		"email":  {"ada@example.com"},
+		"name":   {"Ada Lovelace"},
+		"groups": {"gateway-admins", "engineering", "Engineering"},
+	})
+
+	assertion, err := provider.ParseResponse(encoded, []string{authReq.ID})
+	if err != nil {
+		t.Fatalf("parse response: %v", err)
+	}
+	identity, err := provider.Identity(assertion)
+	if err != nil {
+		t.Fatalf("identity: %v", err)
+	}
+	if identity.Email != "ada@example.com" || identity.Name != "Ada Lovelace" {
+		t.Fatalf("unexpected identity %+v", identity)
+	}
+	if identity.Issuer != mock.server.URL+"/metadata" {
+		t.Fatalf("unexpected issuer %q", identity.Issuer)
+	}
+	if len(identity.Roles) != 2 {
+		t.Fatalf("expected deduped roles, got %v", identity.Roles)
+	}
+	if !identity.IsAdmin {
+		t.Fatalf("expected admin role mapping")
+	}
+}
+
+func TestSAMLProviderRejectsUnknownRequestID(t *testing.T) {
+	mock := newMockIDP(t)
+	provider := newTestSAMLProvider(t, mock, config.SAMLConfig{})
+
+	encoded := mock.respond(t, provider, "id-unexpected", "ada@example.com", map[string][]string{
+		"email": {"ada@example.com"},
+	})
+	if _, err := provider.ParseResponse(encoded, []string{"id-expected"}); err == nil {
+		t.Fatalf("expected response for unknown request to be rejected")
+	}
+}
+
+func TestSAMLProviderRejectsForeignSigner(t *testing.T) {
+	trusted := newMockIDP(t)
+	provider := newTestSAMLProvider(t, trusted, config.SAMLConfig{})
+
+	rogue := newMockIDP(t)
+	rogue.idp.MetadataURL = trusted.idp.MetadataURL
+	encoded := rogue.respond(t, provider, "id-1", "ada@example.com", map[string][]string{
+		"email": {"ada@example.com"},
+	})
+	if _, err := provider.ParseResponse(encoded, []string{"id-1"}); err == nil {
+		t.Fatalf("expected response signed by an untrusted key to be rejected")
+	}
+}
+
+func TestSAMLProviderAllowedRoles(t *testing.T) {
+	mock := newMockIDP(t)
+	provider := newTestSAMLProvider(t, mock, config.SAMLConfig{
+		RolesClaim:   "groups",
+		AllowedRoles: []string{"gateway-users"},
+	})
+
+	encoded := mock.respond(t, provider, "id-1", "bob@example.com", map[string][]string{
+		"email":  {"bob@example.com"},
+		"groups": {"marketing"},
+	})
+	assertion, err := provider.ParseResponse(encoded, []string{"id-1"})
+	if err != nil {
+		t.Fatalf("parse response: %v", err)
+	}
+	if _, err := provider.Identity(assertion); err == nil {
+		t.Fatalf("expected missing role to be rejected")
+	}
+}
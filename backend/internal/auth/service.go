@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 
+	"github.com/crewjam/saml"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -19,11 +21,13 @@ import (
 const (
 	ProviderLocal = "local"
 	ProviderOIDC  = "oidc"
+	ProviderSAML  = "saml"
 )
 
 var (
 	ErrInvalidCredentials = errors.New("invalid credentials")
 	ErrOIDCDisabled       = errors.New("oidc authentication disabled")
+	ErrSAMLDisabled       = errors.New("saml authentication disabled")
 )
 
 type AdminAuthService struct {
@@ -31,7 +35,11 @@ type AdminAuthService struct {
 	queries      *db.Queries
 	accounts     *accounts.PersonalService
 	tokenManager *TokenManager
-	oidc         *OIDCProvider
+	saml         *SAMLProvider
+
+	// oidcMu guards oidc and cfg.OIDC, which ReloadOIDC swaps at runtime.
+	oidcMu sync.RWMutex
+	oidc   *OIDCProvider
 }
 
 func NewAdminAuthService(ctx context.Context, cfg config.AdminConfig, queries *db.Queries, accountsSvc *accounts.PersonalService) (*AdminAuthService, error) {
@@ -48,12 +56,21 @@ func NewAdminAuthService(ctx context.Context, cfg config.AdminConfig, queries *d
 		}
 	}
 
+	var samlProvider *SAMLProvider
+	if cfg.SAML.Enabled {
+		samlProvider, err = NewSAMLProvider(ctx, cfg.SAML)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &AdminAuthService{
 		cfg:          cfg,
 		queries:      queries,
 		accounts:     accountsSvc,
 		tokenManager: tokenManager,
 		oidc:         oidcProvider,
+		saml:         samlProvider,
 	}, nil
 }
 
@@ -141,19 +158,52 @@ func (s *AdminAuthService) UpsertLocalPassword(ctx context.Context, userID uuid.
 	return nil
 }
 
+// ReloadOIDC replaces the OIDC provider with one built from cfg, fetching
+// the discovery document again. A disabled cfg turns OIDC login off. The
+// current provider is kept when discovery fails.
+func (s *AdminAuthService) ReloadOIDC(ctx context.Context, cfg config.OIDCConfig) error {
+	var provider *OIDCProvider
+	if cfg.Enabled {
+		if cfg.HTTPTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, cfg.HTTPTimeout)
+			defer cancel()
+		}
+		var err error
+		provider, err = NewOIDCProvider(ctx, cfg)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.oidcMu.Lock()
+	s.oidc = provider
+	s.cfg.OIDC = cfg
+	s.oidcMu.Unlock()
+	return nil
+}
+
+func (s *AdminAuthService) oidcProvider() *OIDCProvider {
+	s.oidcMu.RLock()
+	defer s.oidcMu.RUnlock()
+	return s.oidc
+}
+
 func (s *AdminAuthService) StartOIDCAuth(state, nonce string) (string, error) {
-	if s.oidc == nil {
+	provider := s.oidcProvider()
+	if provider == nil {
 		return "", ErrOIDCDisabled
 	}
-	return s.oidc.AuthCodeURL(state, nonce), nil
+	return provider.AuthCodeURL(state, nonce), nil
 }
 
 func (s *AdminAuthService) CompleteOIDCAuth(ctx context.Context, code string, expectedNonce string) (*TokenPair, db.User, error) {
-	if s.oidc == nil {
+	provider := s.oidcProvider()
+	if provider == nil {
 		return nil, db.User{}, ErrOIDCDisabled
 	}
 
-	identity, err := s.oidc.Exchange(ctx, code, expectedNonce)
+	identity, err := provider.Exchange(ctx, code, expectedNonce)
 	if err != nil {
 		return nil, db.User{}, err
 	}
@@ -182,11 +232,11 @@ func (s *AdminAuthService) CompleteOIDCAuth(ctx context.Context, code string, ex
 		user = updated
 	}
 
-	if err := s.syncUserAdminFlag(ctx, &user, identity); err != nil {
+	if err := s.syncUserAdminFlag(ctx, &user, len(provider.cfg.AdminRoles) > 0, identity.IsAdmin); err != nil {
 		return nil, db.User{}, err
 	}
 
-	if err := s.persistOIDCCredential(ctx, user.ID, identity); err != nil {
+	if err := s.persistOIDCCredential(ctx, user.ID, provider.cfg.Issuer, identity); err != nil {
 		return nil, db.User{}, err
 	}
 
@@ -227,10 +277,10 @@ func (s *AdminAuthService) createUserFromOIDC(ctx context.Context, identity *OID
 	return user, nil
 }
 
-func (s *AdminAuthService) persistOIDCCredential(ctx context.Context, userID pgtype.UUID, identity *OIDCIdentity) error {
+func (s *AdminAuthService) persistOIDCCredential(ctx context.Context, userID pgtype.UUID, fallbackIssuer string, identity *OIDCIdentity) error {
 	issuer := identity.Issuer
 	if issuer == "" {
-		issuer = s.cfg.OIDC.Issuer
+		issuer = fallbackIssuer
 	}
 
 	metadata := identity.MetadataJSON
@@ -257,31 +307,112 @@ func (s *AdminAuthService) persistOIDCCredential(ctx context.Context, userID pgt
 	return nil
 }
 
-func (s *AdminAuthService) syncUserAdminFlag(ctx context.Context, user *db.User, identity *OIDCIdentity) error {
-	if len(s.cfg.OIDC.AdminRoles) == 0 || user == nil || identity == nil {
+func (s *AdminAuthService) syncUserAdminFlag(ctx context.Context, user *db.User, managed bool, isAdmin bool) error {
+	if !managed || user == nil {
 		return nil
 	}
-	if user.IsSuperAdmin == identity.IsAdmin {
+	if user.IsSuperAdmin == isAdmin {
 		return nil
 	}
 	if err := s.queries.SetUserSuperAdmin(ctx, db.SetUserSuperAdminParams{
 		ID:           user.ID,
-		IsSuperAdmin: identity.IsAdmin,
+		IsSuperAdmin: isAdmin,
 	}); err != nil {
 		return fmt.Errorf("update user admin flag: %w", err)
 	}
-	user.IsSuperAdmin = identity.IsAdmin
+	user.IsSuperAdmin = isAdmin
 	return nil
 }
 
+func (s *AdminAuthService) StartSAMLAuth(relayState string) (*SAMLAuthRequest, error) {
+	if s.saml == nil {
+		return nil, ErrSAMLDisabled
+	}
+	return s.saml.AuthRequest(relayState)
+}
+
+// ParseSAMLResponse verifies the SAMLResponse posted by the IdP against the
+// outstanding AuthnRequest IDs.
+func (s *AdminAuthService) ParseSAMLResponse(encoded string, requestIDs []string) (*saml.Assertion, error) {
+	if s.saml == nil {
+		return nil, ErrSAMLDisabled
+	}
+	return s.saml.ParseResponse(encoded, requestIDs)
+}
+
+// LoginSAML upserts the user described by a verified assertion, syncing the
+// super-admin flag and SAML credential the same way OIDC logins do.
+func (s *AdminAuthService) LoginSAML(ctx context.Context, assertion *saml.Assertion) (db.User, error) {
+	if s.saml == nil {
+		return db.User{}, ErrSAMLDisabled
+	}
+
+	identity, err := s.saml.Identity(assertion)
+	if err != nil {
+		return db.User{}, err
+	}
+
+	user, err := s.queries.GetUserByEmail(ctx, identity.Email)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return db.User{}, fmt.Errorf("get user: %w", err)
+		}
+		name := identity.Name
+		if name == "" {
+			name = identity.Email
+		}
+		user, err = s.queries.CreateUser(ctx, db.CreateUserParams{
+			Email: identity.Email,
+			Name:  name,
+		})
+		if err != nil {
+			return db.User{}, fmt.Errorf("create user: %w", err)
+		}
+	}
+	if s.accounts != nil && !user.PersonalTenantID.Valid {
+		updated, _, perr := s.accounts.EnsurePersonalTenant(ctx, user)
+		if perr != nil {
+			return db.User{}, fmt.Errorf("ensure personal tenant: %w", perr)
+		}
+		user = updated
+	}
+
+	if err := s.syncUserAdminFlag(ctx, &user, len(s.cfg.SAML.AdminRoles) > 0, identity.IsAdmin); err != nil {
+		return db.User{}, err
+	}
+
+	issuer := identity.Issuer
+	if issuer == "" {
+		issuer = s.cfg.SAML.MetadataURL
+	}
+	if _, err := s.queries.UpsertCredential(ctx, db.UpsertCredentialParams{
+		UserID:       user.ID,
+		Provider:     ProviderSAML,
+		Issuer:       issuer,
+		Subject:      identity.Subject,
+		PasswordHash: pgtype.Text{Valid: false},
+		Metadata:     json.RawMessage(identity.MetadataJSON),
+	}); err != nil {
+		return db.User{}, fmt.Errorf("upsert saml credential: %w", err)
+	}
+
+	if err := s.queries.UpdateUserLastLogin(ctx, user.ID); err != nil {
+		return db.User{}, fmt.Errorf("update last login: %w", err)
+	}
+	return user, nil
+}
+
 func (s *AdminAuthService) AllowedAuthMethods() []string {
 	methods := []string{}
 	if s.cfg.Local.Enabled {
 		methods = append(methods, ProviderLocal)
 	}
-	if s.oidc != nil {
+	if s.oidcProvider() != nil {
 		methods = append(methods, ProviderOIDC)
 	}
+	if s.saml != nil {
+		methods = append(methods, ProviderSAML)
+	}
 	return methods
 }
 
@@ -0,0 +1,134 @@
+package batchworker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+	batchsvc "github.com/ncecere/open_model_gateway/backend/internal/services/batches"
+)
+
+var (
+	errItemTenantNotPermitted = errors.New("tenant_id is not a sub-tenant of the batch owner")
+	errItemTenantInactive     = errors.New("tenant_id names a tenant that is not active")
+)
+
+type itemHeaders struct {
+	TenantID string `json:"tenant_id"`
+}
+
+// parseItemTenant returns the tenant named by the item's headers.tenant_id,
+// or uuid.Nil when the item runs as the batch owner.
+func parseItemTenant(input []byte) (uuid.UUID, error) {
+	var req batchRequest
+	if err := json.Unmarshal(input, &req); err != nil || len(req.Headers) == 0 || string(req.Headers) == "null" {
+		// Malformed input is reported by the endpoint decoder.
+		return uuid.Nil, nil
+	}
+	var headers itemHeaders
+	if err := json.Unmarshal(req.Headers, &headers); err != nil {
+		return uuid.Nil, fmt.Errorf("invalid headers: %v", err)
+	}
+	raw := strings.TrimSpace(headers.TenantID)
+	if raw == "" {
+		return uuid.Nil, nil
+	}
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid headers.tenant_id %q", raw)
+	}
+	return id, nil
+}
+
+type itemTenantEntry struct {
+	rc  *requestctx.Context
+	err error
+}
+
+// itemTenants hands each batch item the request context of the tenant it runs
+// for. Contexts for sub-tenants are built once per batch and shared by the
+// item workers.
+type itemTenants struct {
+	owner uuid.UUID
+	base  *requestctx.Context
+	build func(ctx context.Context, tenantID uuid.UUID) (*requestctx.Context, error)
+
+	mu       sync.Mutex
+	contexts map[uuid.UUID]itemTenantEntry
+}
+
+func newItemTenants(owner uuid.UUID, base *requestctx.Context, build func(ctx context.Context, tenantID uuid.UUID) (*requestctx.Context, error)) *itemTenants {
+	return &itemTenants{
+		owner:    owner,
+		base:     base,
+		build:    build,
+		contexts: make(map[uuid.UUID]itemTenantEntry),
+	}
+}
+
+// resolve returns the context the item should execute under. Items naming a
+// tenant outside the owner's hierarchy fail with a permission error.
+func (t *itemTenants) resolve(ctx context.Context, item batchItem, traceID string) (*requestctx.Context, *itemOutcome) {
+	tenantID, err := parseItemTenant(item.Input)
+	if err != nil {
+		return nil, &itemOutcome{
+			statusCode: fiber.StatusBadRequest,
+			requestID:  traceID,
+			errPayload: encodeErrorPayload("invalid_request_error", err.Error()),
+		}
+	}
+	if tenantID == uuid.Nil || tenantID == t.owner || t.build == nil {
+		return t.base, nil
+	}
+
+	t.mu.Lock()
+	entry, ok := t.contexts[tenantID]
+	if !ok {
+		entry.rc, entry.err = t.build(ctx, tenantID)
+		// Cancellation is not a verdict on the tenant; let a later item retry.
+		if ctx.Err() == nil {
+			t.contexts[tenantID] = entry
+		}
+	}
+	t.mu.Unlock()
+
+	if entry.err != nil {
+		status := fiber.StatusInternalServerError
+		if errors.Is(entry.err, errItemTenantNotPermitted) || errors.Is(entry.err, errItemTenantInactive) {
+			status = fiber.StatusForbidden
+		}
+		return nil, &itemOutcome{
+			statusCode: status,
+			requestID:  traceID,
+			errPayload: encodeErrorPayload(mapStatusToCode(status), entry.err.Error()),
+		}
+	}
+	return entry.rc, nil
+}
+
+// buildItemTenantContext builds the request context for an item billed to a
+// sub-tenant of the batch owner. The batch's API key keeps its own limits and
+// scopes; budgets, quotas, and usage attribution follow the item tenant.
+func (w *Worker) buildItemTenantContext(ctx context.Context, batch batchsvc.Batch, keyRow db.ApiKey, tenantID uuid.UUID) (*requestctx.Context, error) {
+	if !w.container.Batches.ValidateItemTenant(ctx, batch.TenantID, tenantID) {
+		return nil, errItemTenantNotPermitted
+	}
+	tenantRow, err := w.container.Queries.GetTenantByID(ctx, toPgUUID(tenantID))
+	if err != nil {
+		return nil, err
+	}
+	if tenantRow.Status != db.TenantStatusActive {
+		return nil, errItemTenantInactive
+	}
+	keyRow.TenantID = toPgUUID(tenantID)
+	return app.BuildRequestContext(ctx, w.container, keyRow)
+}
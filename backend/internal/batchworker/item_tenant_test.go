@@ -0,0 +1,72 @@
+package batchworker
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+)
+
+func itemWithHeaders(t *testing.T, headers map[string]string) batchItem {
+	t.Helper()
+	line := map[string]any{
+		"custom_id": "req-1",
+		"url":       "/v1/chat/completions",
+		"body":      map[string]any{"model": "gpt-4o"},
+	}
+	if headers != nil {
+		line["headers"] = headers
+	}
+	raw, err := json.Marshal(line)
+	if err != nil {
+		t.Fatalf("marshal item: %v", err)
+	}
+	return batchItem{Input: raw}
+}
+
+func TestItemTenantsAttributeSubTenantItems(t *testing.T) {
+	owner, child, outsider := uuid.New(), uuid.New(), uuid.New()
+	base := &requestctx.Context{TenantID: owner}
+	builds := map[uuid.UUID]int{}
+	tenants := newItemTenants(owner, base, func(_ context.Context, tenantID uuid.UUID) (*requestctx.Context, error) {
+		builds[tenantID]++
+		if tenantID != child {
+			return nil, errItemTenantNotPermitted
+		}
+		return &requestctx.Context{TenantID: tenantID}, nil
+	})
+	ctx := context.Background()
+
+	rc, failure := tenants.resolve(ctx, itemWithHeaders(t, nil), "trace")
+	if failure != nil || rc != base {
+		t.Fatalf("items without headers should run as the owner, got %+v %+v", rc, failure)
+	}
+	rc, failure = tenants.resolve(ctx, itemWithHeaders(t, map[string]string{"tenant_id": owner.String()}), "trace")
+	if failure != nil || rc != base {
+		t.Fatalf("the owner's own id should reuse the batch context, got %+v %+v", rc, failure)
+	}
+
+	for i := 0; i < 2; i++ {
+		rc, failure = tenants.resolve(ctx, itemWithHeaders(t, map[string]string{"tenant_id": child.String()}), "trace")
+		if failure != nil || rc == nil || rc.TenantID != child {
+			t.Fatalf("expected usage attributed to the sub-tenant, got %+v %+v", rc, failure)
+		}
+	}
+	if builds[child] != 1 {
+		t.Fatalf("sub-tenant context should be built once per batch, built %d times", builds[child])
+	}
+
+	_, failure = tenants.resolve(ctx, itemWithHeaders(t, map[string]string{"tenant_id": outsider.String()}), "trace")
+	if failure == nil || failure.statusCode != fiber.StatusForbidden {
+		t.Fatalf("expected forbidden for a tenant outside the hierarchy, got %+v", failure)
+	}
+
+	_, failure = tenants.resolve(ctx, itemWithHeaders(t, map[string]string{"tenant_id": "not-a-uuid"}), "trace")
+	if failure == nil || failure.statusCode != fiber.StatusBadRequest {
+		t.Fatalf("expected bad request for a malformed tenant_id, got %+v", failure)
+	}
+}
@@ -0,0 +1,129 @@
+package batchworker
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultShutdownItemTimeout is how long Shutdown waits for in-flight
+	// items when no timeout is configured.
+	DefaultShutdownItemTimeout = 30 * time.Second
+
+	releaseItemTimeout = 5 * time.Second
+)
+
+// itemReleaser puts an abandoned item back in the queue;
+// *batchsvc.Service implements it.
+type itemReleaser interface {
+	ReleaseItem(ctx context.Context, itemID uuid.UUID) error
+}
+
+// inflightItems tracks the items being executed so Shutdown can cancel the
+// ones still running once its grace period ends.
+type inflightItems struct {
+	mu        sync.Mutex
+	cancels   map[uuid.UUID]context.CancelFunc
+	abandoned bool
+}
+
+// start returns the context to execute itemID under and a finish func that
+// reports whether Shutdown abandoned the item.
+func (t *inflightItems) start(ctx context.Context, itemID uuid.UUID) (context.Context, func() bool) {
+	itemCtx, cancel := context.WithCancel(ctx)
+	t.mu.Lock()
+	if t.cancels == nil {
+		t.cancels = make(map[uuid.UUID]context.CancelFunc)
+	}
+	t.cancels[itemID] = cancel
+	if t.abandoned {
+		cancel()
+	}
+	t.mu.Unlock()
+	return itemCtx, func() bool {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		delete(t.cancels, itemID)
+		cancel()
+		return t.abandoned
+	}
+}
+
+// abandon cancels every in-flight item and returns how many there were.
+func (t *inflightItems) abandon() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.abandoned = true
+	for _, cancel := range t.cancels {
+		cancel()
+	}
+	return len(t.cancels)
+}
+
+// SetShutdownItemTimeout sets how long Shutdown waits for in-flight items
+// before releasing them back to the queue.
+func (w *Worker) SetShutdownItemTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = DefaultShutdownItemTimeout
+	}
+	w.shutdownItemTimeout = timeout
+}
+
+// Shutdown stops the worker from claiming batches and items, then waits up
+// to the shutdown item timeout for in-flight items to finish. Items still
+// running after that are cancelled and put back in the queue, and the batch
+// is left for another worker to resume. It returns once Run has returned or
+// ctx ends, and must only be called after Run was started.
+func (w *Worker) Shutdown(ctx context.Context) error {
+	w.stopOnce.Do(func() { close(w.stop) })
+
+	timer := time.NewTimer(w.shutdownItemTimeout)
+	defer timer.Stop()
+	select {
+	case <-w.done:
+		return nil
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	if n := w.items.abandon(); n > 0 {
+		w.logger.Warn("batch worker: releasing unfinished items on shutdown", slog.Int("items", n))
+	}
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *Worker) stopping() bool {
+	select {
+	case <-w.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// runItem executes one claimed item. When Shutdown abandons the item it is
+// released back to the queue and ok is false; the outcome must then be
+// discarded.
+func (w *Worker) runItem(ctx context.Context, releaser itemReleaser, itemID uuid.UUID, exec func(context.Context) itemOutcome) (result itemOutcome, ok bool) {
+	itemCtx, finish := w.items.start(ctx, itemID)
+	result = exec(itemCtx)
+	if !finish() {
+		return result, true
+	}
+	releaseCtx, cancel := context.WithTimeout(context.Background(), releaseItemTimeout)
+	defer cancel()
+	if err := releaser.ReleaseItem(releaseCtx, itemID); err != nil {
+		// The item stays running until a later worker reclaims the batch.
+		w.logger.Error("batch worker: release item", slog.String("item_id", itemID.String()), slog.String("error", err.Error()))
+	}
+	return itemOutcome{}, false
+}
@@ -0,0 +1,93 @@
+package batchworker
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type recordingReleaser struct {
+	mu       sync.Mutex
+	released []uuid.UUID
+}
+
+func (r *recordingReleaser) ReleaseItem(_ context.Context, itemID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.released = append(r.released, itemID)
+	return nil
+}
+
+// runOneItem stands in for Run: it executes a single item and closes the
+// worker's done channel when the item is settled.
+func runOneItem(w *Worker, releaser itemReleaser, itemID uuid.UUID, exec func(context.Context) itemOutcome) <-chan bool {
+	result := make(chan bool, 1)
+	go func() {
+		defer close(w.done)
+		_, ok := w.runItem(context.Background(), releaser, itemID, exec)
+		result <- ok
+	}()
+	return result
+}
+
+func TestShutdownRequeuesItemStillRunning(t *testing.T) {
+	w := New(nil, nil)
+	w.SetShutdownItemTimeout(20 * time.Millisecond)
+	releaser := &recordingReleaser{}
+	itemID := uuid.New()
+
+	started := make(chan struct{})
+	result := runOneItem(w, releaser, itemID, func(ctx context.Context) itemOutcome {
+		close(started)
+		// A provider call that outlasts the grace period.
+		<-ctx.Done()
+		return itemOutcome{errPayload: encodeErrorPayload("provider_error", ctx.Err().Error())}
+	})
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := w.Shutdown(ctx); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if ok := <-result; ok {
+		t.Fatal("expected the abandoned item's outcome to be discarded")
+	}
+	if len(releaser.released) != 1 || releaser.released[0] != itemID {
+		t.Fatalf("expected item %s to be re-queued, got %v", itemID, releaser.released)
+	}
+	if !w.stopping() {
+		t.Fatal("expected the worker to stop claiming work")
+	}
+}
+
+func TestShutdownWaitsForItemWithinTimeout(t *testing.T) {
+	w := New(nil, nil)
+	w.SetShutdownItemTimeout(2 * time.Second)
+	releaser := &recordingReleaser{}
+
+	started := make(chan struct{})
+	result := runOneItem(w, releaser, uuid.New(), func(ctx context.Context) itemOutcome {
+		close(started)
+		select {
+		case <-ctx.Done():
+			return itemOutcome{errPayload: encodeErrorPayload("provider_error", "cancelled")}
+		case <-time.After(50 * time.Millisecond):
+			return itemOutcome{response: []byte(`{}`)}
+		}
+	})
+	<-started
+
+	if err := w.Shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if ok := <-result; !ok {
+		t.Fatal("expected the item to finish normally")
+	}
+	if len(releaser.released) != 0 {
+		t.Fatalf("expected no released items, got %v", releaser.released)
+	}
+}
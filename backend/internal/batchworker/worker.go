@@ -26,36 +26,51 @@ import (
 	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
 	batchsvc "github.com/ncecere/open_model_gateway/backend/internal/services/batches"
 	filesvc "github.com/ncecere/open_model_gateway/backend/internal/services/files"
+	"github.com/ncecere/open_model_gateway/backend/internal/services/notifications"
 	usagepipeline "github.com/ncecere/open_model_gateway/backend/internal/services/usagepipeline"
 )
 
 // Worker processes queued batch jobs and executes the corresponding /v1/* calls.
 type Worker struct {
-	container    *app.Container
-	executor     *executor.Executor
-	logger       *slog.Logger
-	pollInterval time.Duration
+	container           *app.Container
+	executor            *executor.Executor
+	logger              *slog.Logger
+	pollInterval        time.Duration
+	shutdownItemTimeout time.Duration
+	items               inflightItems
+	stop                chan struct{}
+	stopOnce            sync.Once
+	done                chan struct{}
 }
 
 // New returns a worker instance bound to the provided container + executor.
 func New(container *app.Container, exec *executor.Executor) *Worker {
 	interval := 2 * time.Second
 	return &Worker{
-		container:    container,
-		executor:     exec,
-		logger:       slog.Default(),
-		pollInterval: interval,
+		container:           container,
+		executor:            exec,
+		logger:              slog.Default(),
+		pollInterval:        interval,
+		shutdownItemTimeout: DefaultShutdownItemTimeout,
+		stop:                make(chan struct{}),
+		done:                make(chan struct{}),
 	}
 }
 
-// Run begins polling for queued batches until the context is canceled.
+// Run begins polling for queued batches until the context is canceled or
+// Shutdown is called. Canceling ctx abandons in-flight items without
+// releasing them; use Shutdown to stop gracefully.
 func (w *Worker) Run(ctx context.Context) {
-	if w == nil || w.container == nil || w.container.Batches == nil || w.executor == nil {
+	if w == nil {
+		return
+	}
+	defer close(w.done)
+	if w.container == nil || w.container.Batches == nil || w.executor == nil {
 		return
 	}
 
 	for {
-		if ctx.Err() != nil {
+		if ctx.Err() != nil || w.stopping() {
 			return
 		}
 
@@ -65,6 +80,8 @@ func (w *Worker) Run(ctx context.Context) {
 			select {
 			case <-ctx.Done():
 				return
+			case <-w.stop:
+				return
 			case <-time.After(3 * time.Second):
 			}
 			continue
@@ -74,6 +91,8 @@ func (w *Worker) Run(ctx context.Context) {
 			select {
 			case <-ctx.Done():
 				return
+			case <-w.stop:
+				return
 			case <-time.After(w.pollInterval):
 			}
 		}
@@ -90,6 +109,11 @@ func (w *Worker) processNextBatch(ctx context.Context) (bool, error) {
 	}
 
 	w.logger.Info("batch worker: claimed batch", slog.String("batch_id", batch.ID.String()), slog.String("endpoint", batch.Endpoint))
+	defer func() {
+		if err := w.container.Batches.ReleaseBatch(context.Background(), batch.ID); err != nil {
+			w.logger.Warn("batch worker: release batch", slog.String("batch_id", batch.ID.String()), slog.String("error", err.Error()))
+		}
+	}()
 	if err := w.processBatch(ctx, batch); err != nil {
 		return true, err
 	}
@@ -97,12 +121,16 @@ func (w *Worker) processNextBatch(ctx context.Context) (bool, error) {
 }
 
 func (w *Worker) processBatch(ctx context.Context, batch batchsvc.Batch) error {
-	rc, err := w.buildRequestContext(ctx, batch)
+	rc, keyRow, err := w.buildRequestContext(ctx, batch)
 	if err != nil {
 		w.logger.Error("batch worker: build request context", slog.String("batch_id", batch.ID.String()), slog.String("error", err.Error()))
 		return w.failEntireBatch(ctx, batch, "context_error", err.Error())
 	}
 
+	tenants := newItemTenants(batch.TenantID, rc, func(ctx context.Context, tenantID uuid.UUID) (*requestctx.Context, error) {
+		return w.buildItemTenantContext(ctx, batch, keyRow, tenantID)
+	})
+
 	workerCount := batch.MaxConcurrency
 	if workerCount <= 0 {
 		workerCount = 1
@@ -112,11 +140,19 @@ func (w *Worker) processBatch(ctx context.Context, batch batchsvc.Batch) error {
 	writer := newResultWriter(w.container.Files, batch, fileTTL(batch))
 	var completedCount atomic.Int64
 	var failedCount atomic.Int64
+	if batch.RequestCountCompleted+batch.RequestCountFailed > 0 {
+		if err := w.resumeResults(ctx, batch, writer); err != nil {
+			return err
+		}
+		completedCount.Store(int64(batch.RequestCountCompleted))
+		failedCount.Store(int64(batch.RequestCountFailed))
+	}
 
 	workCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
 	errCh := make(chan error, 1)
+	go w.heartbeat(workCtx, batch.ID, errCh, cancel)
 	var wg sync.WaitGroup
 
 	for i := 0; i < workerCount; i++ {
@@ -124,7 +160,7 @@ func (w *Worker) processBatch(ctx context.Context, batch batchsvc.Batch) error {
 		go func() {
 			defer wg.Done()
 			for {
-				if workCtx.Err() != nil {
+				if workCtx.Err() != nil || w.stopping() {
 					return
 				}
 				itemRow, err := w.container.Batches.ClaimNextItem(workCtx, batch.ID)
@@ -149,7 +185,12 @@ func (w *Worker) processBatch(ctx context.Context, batch batchsvc.Batch) error {
 					Input:    itemRow.Input,
 				}
 				traceID := fmt.Sprintf("%s%d", tracePrefix, item.Index)
-				result := w.executeItem(workCtx, batch, rc, traceID, item)
+				result, ok := w.runItem(workCtx, w.container.Batches, item.ID, func(ctx context.Context) itemOutcome {
+					return w.executeItem(ctx, batch, tenants, traceID, item)
+				})
+				if !ok {
+					return
+				}
 
 				if result.errPayload == nil {
 					if err := w.container.Batches.CompleteItem(workCtx, item.ID, result.response); err != nil {
@@ -158,6 +199,13 @@ func (w *Worker) processBatch(ctx context.Context, batch batchsvc.Batch) error {
 						return
 					}
 					completedCount.Add(1)
+					// Counts move with each item so clients polling the
+					// batch see progress before it finishes.
+					if err := w.container.Batches.IncrementCounts(workCtx, batch.ID, 1, 0, 0); err != nil {
+						w.sendWorkerError(errCh, err)
+						cancel()
+						return
+					}
 					if err := writer.AppendSuccess(item, result.statusCode, result.requestID, result.response); err != nil {
 						w.sendWorkerError(errCh, err)
 						cancel()
@@ -170,6 +218,11 @@ func (w *Worker) processBatch(ctx context.Context, batch batchsvc.Batch) error {
 						return
 					}
 					failedCount.Add(1)
+					if err := w.container.Batches.IncrementCounts(workCtx, batch.ID, 0, 1, 0); err != nil {
+						w.sendWorkerError(errCh, err)
+						cancel()
+						return
+					}
 					if err := writer.AppendError(item, result.statusCode, result.requestID, result.errPayload); err != nil {
 						w.sendWorkerError(errCh, err)
 						cancel()
@@ -189,14 +242,16 @@ func (w *Worker) processBatch(ctx context.Context, batch batchsvc.Batch) error {
 		}
 	default:
 	}
+	if w.stopping() {
+		// Unclaimed and released items stay queued; releasing the claim
+		// lock lets another worker reclaim the batch and finish it.
+		w.logger.Info("batch worker: stopped mid-batch", slog.String("batch_id", batch.ID.String()))
+		return nil
+	}
 
 	completed := int(completedCount.Load())
 	failed := int(failedCount.Load())
 
-	if err := w.container.Batches.IncrementCounts(ctx, batch.ID, completed, failed, 0); err != nil {
-		return err
-	}
-
 	if _, err := w.container.Batches.FinalizeBatch(ctx, batch.ID, "finalizing", nil, nil, nil); err != nil {
 		return err
 	}
@@ -225,8 +280,90 @@ func (w *Worker) processBatch(ctx context.Context, batch batchsvc.Batch) error {
 		}
 	}
 
-	_, err = w.container.Batches.FinalizeBatch(ctx, batch.ID, finalStatus, resultFileID, errorFileID, nil)
-	return err
+	if _, err := w.container.Batches.FinalizeBatch(ctx, batch.ID, finalStatus, resultFileID, errorFileID, nil); err != nil {
+		return err
+	}
+	if finalStatus == "failed" {
+		w.notifyFailed(batch, fmt.Sprintf("%d of %d requests failed", failed, batch.RequestCountTotal))
+	}
+	return nil
+}
+
+// notifyFailed posts a failed batch to the admin inbox.
+func (w *Worker) notifyFailed(batch batchsvc.Batch, reason string) {
+	if w.container.Notifications == nil {
+		return
+	}
+	notifications.Send(w.container.Notifications, notifications.Notification{
+		Severity:   notifications.SeverityWarning,
+		EntityType: notifications.EntityBatch,
+		EntityID:   batch.ID.String(),
+		Message:    fmt.Sprintf("batch %s for %s failed: %s", batch.ID, batch.Endpoint, reason),
+	})
+}
+
+// heartbeat extends the batch's claim lock until ctx ends. Losing the lock
+// cancels the batch's work so two workers never run it at once.
+func (w *Worker) heartbeat(ctx context.Context, batchID uuid.UUID, errCh chan<- error, cancel context.CancelFunc) {
+	ticker := time.NewTicker(heartbeatInterval(w.container.Batches.LockTTL()))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := w.container.Batches.HeartbeatBatch(ctx, batchID)
+			if err == nil || ctx.Err() != nil {
+				continue
+			}
+			if errors.Is(err, batchsvc.ErrBatchLockLost) {
+				w.sendWorkerError(errCh, err)
+				cancel()
+				return
+			}
+			w.logger.Warn("batch worker: heartbeat", slog.String("batch_id", batchID.String()), slog.String("error", err.Error()))
+		}
+	}
+}
+
+// heartbeatInterval refreshes the lock three times per TTL so a single
+// failed refresh does not let it expire.
+func heartbeatInterval(ttl time.Duration) time.Duration {
+	interval := ttl / 3
+	if interval < time.Second {
+		interval = time.Second
+	}
+	return interval
+}
+
+// resumeResults adds the items finished before the batch was reclaimed to
+// writer. Status codes and request IDs are not stored per item, so resumed
+// lines report 200 for completed items and 500 for failed ones.
+func (w *Worker) resumeResults(ctx context.Context, batch batchsvc.Batch, writer *resultWriter) error {
+	rows, err := w.container.Batches.ListFinishedItems(ctx, batch.ID)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		itemID, err := fromPgUUID(row.ID)
+		if err != nil {
+			return err
+		}
+		item := batchItem{
+			ID:       itemID,
+			Index:    row.ItemIndex,
+			CustomID: strings.TrimSpace(row.CustomID.String),
+		}
+		if row.Status == "completed" {
+			err = writer.AppendSuccess(item, fiber.StatusOK, "", row.Response)
+		} else {
+			err = writer.AppendError(item, fiber.StatusInternalServerError, "", row.Error)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func determineFinalStatus(currentStatus string, completed, failed int) string {
@@ -253,24 +390,28 @@ func (w *Worker) sendWorkerError(ch chan<- error, err error) {
 	}
 }
 
-func (w *Worker) buildRequestContext(ctx context.Context, batch batchsvc.Batch) (*requestctx.Context, error) {
+func (w *Worker) buildRequestContext(ctx context.Context, batch batchsvc.Batch) (*requestctx.Context, db.ApiKey, error) {
 	keyRow, err := w.container.Queries.GetAPIKeyByID(ctx, toPgUUID(batch.APIKeyID))
 	if err != nil {
-		return nil, err
+		return nil, db.ApiKey{}, err
 	}
 	if keyRow.RevokedAt.Valid {
-		return nil, fmt.Errorf("api key revoked")
+		return nil, db.ApiKey{}, fmt.Errorf("api key revoked")
+	}
+	if keyRow.SuspendedAt.Valid {
+		return nil, db.ApiKey{}, fmt.Errorf("api key suspended")
 	}
 
 	tenantRow, err := w.container.Queries.GetTenantByID(ctx, keyRow.TenantID)
 	if err != nil {
-		return nil, err
+		return nil, db.ApiKey{}, err
 	}
 	if tenantRow.Status != db.TenantStatusActive {
-		return nil, fmt.Errorf("tenant is not active")
+		return nil, db.ApiKey{}, fmt.Errorf("tenant is not active")
 	}
 
-	return app.BuildRequestContext(ctx, w.container, keyRow)
+	rc, err := app.BuildRequestContext(ctx, w.container, keyRow)
+	return rc, keyRow, err
 }
 
 func (w *Worker) failEntireBatch(ctx context.Context, batch batchsvc.Batch, code, message string) error {
@@ -315,11 +456,19 @@ func (w *Worker) failEntireBatch(ctx context.Context, batch batchsvc.Batch, code
 	errs := []batchsvc.BatchError{
 		{Code: code, Message: message},
 	}
-	_, err = w.container.Batches.FinalizeBatch(ctx, batch.ID, "failed", resultFileID, errorFileID, errs)
-	return err
+	if _, err := w.container.Batches.FinalizeBatch(ctx, batch.ID, "failed", resultFileID, errorFileID, errs); err != nil {
+		return err
+	}
+	w.notifyFailed(batch, message)
+	return nil
 }
 
-func (w *Worker) executeItem(ctx context.Context, batch batchsvc.Batch, rc *requestctx.Context, traceID string, item batchItem) itemOutcome {
+func (w *Worker) executeItem(ctx context.Context, batch batchsvc.Batch, tenants *itemTenants, traceID string, item batchItem) itemOutcome {
+	// headers.tenant_id bills the item to a sub-tenant of the batch owner.
+	rc, failure := tenants.resolve(ctx, item, traceID)
+	if failure != nil {
+		return *failure
+	}
 	switch batch.Endpoint {
 	case "/v1/chat/completions":
 		return w.runChatItem(ctx, rc, traceID, item)
@@ -496,12 +645,21 @@ func (w *Worker) runEmbeddingItem(ctx context.Context, rc *requestctx.Context, t
 		}
 	}
 
-	routes := w.container.Engine.SelectRoutes(body.Model)
-	if len(routes) == 0 {
+	routes, err := w.executor.SelectRoutes(rc, body.Model)
+	if err != nil {
+		status, msg, _ := executor.AsAPIError(err)
 		return itemOutcome{
-			statusCode: fiber.StatusServiceUnavailable,
+			statusCode: status,
 			requestID:  traceID,
-			errPayload: encodeErrorPayload("service_unavailable", "no backend available for model"),
+			errPayload: encodeErrorPayload(mapStatusToCode(status), msg),
+		}
+	}
+	if err := executor.ValidateEmbeddingDimensions(body.Model, routes, body.Dimensions); err != nil {
+		status, msg, _ := executor.AsAPIError(err)
+		return itemOutcome{
+			statusCode: status,
+			requestID:  traceID,
+			errPayload: encodeErrorPayload("invalid_request_error", msg),
 		}
 	}
 
@@ -515,7 +673,7 @@ func (w *Worker) runEmbeddingItem(ctx context.Context, rc *requestctx.Context, t
 			errPayload: encodeErrorPayload("budget_error", err.Error()),
 		}
 	}
-	if status.Exceeded {
+	if status.Blocked() {
 		_, _ = w.container.UsageLogger.Record(callCtx, usagepipeline.Record{
 			Context:   rc,
 			Alias:     body.Model,
@@ -550,6 +708,37 @@ func (w *Worker) runEmbeddingItem(ctx context.Context, rc *requestctx.Context, t
 	}
 	defer release()
 
+	if cached, ok := w.container.CachedEmbeddings(callCtx, body.Model, body.Dimensions, values); ok {
+		if _, err := w.container.UsageLogger.Record(callCtx, usagepipeline.Record{
+			Context:   rc,
+			Alias:     body.Model,
+			Provider:  "cache",
+			Status:    fiber.StatusOK,
+			TraceID:   traceID,
+			Timestamp: time.Now().UTC(),
+			Success:   true,
+		}); err != nil {
+			return itemOutcome{
+				statusCode: fiber.StatusInternalServerError,
+				requestID:  traceID,
+				errPayload: encodeErrorPayload("usage_error", err.Error()),
+			}
+		}
+		data, err := json.Marshal(convertEmbeddingResponse(cached, body.Model))
+		if err != nil {
+			return itemOutcome{
+				statusCode: fiber.StatusInternalServerError,
+				requestID:  traceID,
+				errPayload: encodeErrorPayload("serialization_error", err.Error()),
+			}
+		}
+		return itemOutcome{
+			statusCode: fiber.StatusOK,
+			requestID:  traceID,
+			response:   data,
+		}
+	}
+
 	var lastErr error
 	var lastRoute providers.Route
 	var lastLatency time.Duration
@@ -560,11 +749,15 @@ func (w *Worker) runEmbeddingItem(ctx context.Context, rc *requestctx.Context, t
 		}
 		lastRoute = route
 		modelReq := models.EmbeddingsRequest{
-			Model: route.ResolveDeployment(),
-			Input: values,
+			Model:      route.ResolveDeployment(),
+			Input:      values,
+			Dimensions: body.Dimensions,
 		}
 		start := time.Now()
-		resp, err := route.Embedding.Embed(callCtx, modelReq)
+		routeCtx, cancel, timeout := w.executor.RouteContext(callCtx, route)
+		resp, err := executor.Embed(routeCtx, route.Embedding, modelReq)
+		executor.LogRouteTimeout(routeCtx, body.Model, route, timeout, err)
+		cancel()
 		if err != nil {
 			w.container.Engine.ReportFailure(body.Model, route)
 			lastErr = err
@@ -604,10 +797,12 @@ func (w *Worker) runEmbeddingItem(ctx context.Context, rc *requestctx.Context, t
 		}
 
 		w.container.Engine.ReportSuccess(body.Model, route)
+		w.container.StoreEmbeddings(callCtx, body.Model, body.Dimensions, values, resp)
 		record := usagepipeline.Record{
 			Context:   rc,
 			Alias:     body.Model,
 			Provider:  route.Provider,
+			ABVariant: route.ABVariant,
 			Usage:     resp.Usage,
 			Latency:   time.Since(start),
 			Status:    fiber.StatusOK,
@@ -644,6 +839,7 @@ func (w *Worker) runEmbeddingItem(ctx context.Context, rc *requestctx.Context, t
 			Context:   rc,
 			Alias:     body.Model,
 			Provider:  lastRoute.Provider,
+			ABVariant: lastRoute.ABVariant,
 			Status:    fiber.StatusBadGateway,
 			ErrorCode: errMessage(lastErr),
 			TraceID:   traceID,
@@ -710,12 +906,13 @@ func (w *Worker) runImageItem(ctx context.Context, rc *requestctx.Context, trace
 		}
 	}
 
-	routes := w.container.Engine.SelectRoutes(alias)
-	if len(routes) == 0 {
+	routes, err := w.executor.SelectRoutes(rc, alias)
+	if err != nil {
+		status, msg, _ := executor.AsAPIError(err)
 		return itemOutcome{
-			statusCode: fiber.StatusServiceUnavailable,
+			statusCode: status,
 			requestID:  traceID,
-			errPayload: encodeErrorPayload("service_unavailable", "no backend available for model"),
+			errPayload: encodeErrorPayload(mapStatusToCode(status), msg),
 		}
 	}
 
@@ -729,7 +926,7 @@ func (w *Worker) runImageItem(ctx context.Context, rc *requestctx.Context, trace
 			errPayload: encodeErrorPayload("budget_error", err.Error()),
 		}
 	}
-	if status.Exceeded {
+	if status.Blocked() {
 		_, _ = w.container.UsageLogger.Record(callCtx, usagepipeline.Record{
 			Context:   rc,
 			Alias:     alias,
@@ -787,7 +984,10 @@ func (w *Worker) runImageItem(ctx context.Context, rc *requestctx.Context, trace
 		}
 
 		start := time.Now()
-		resp, err := route.Image.Generate(callCtx, modelReq)
+		routeCtx, cancel, timeout := w.executor.RouteContext(callCtx, route)
+		resp, err := route.Image.Generate(routeCtx, modelReq)
+		executor.LogRouteTimeout(routeCtx, alias, route, timeout, err)
+		cancel()
 		if err != nil {
 			w.container.Engine.ReportFailure(alias, route)
 			lastErr = err
@@ -830,6 +1030,7 @@ func (w *Worker) runImageItem(ctx context.Context, rc *requestctx.Context, trace
 			Context:   rc,
 			Alias:     alias,
 			Provider:  route.Provider,
+			ABVariant: route.ABVariant,
 			Usage:     resp.Usage,
 			Latency:   time.Since(start),
 			Status:    fiber.StatusOK,
@@ -874,6 +1075,7 @@ func (w *Worker) runImageItem(ctx context.Context, rc *requestctx.Context, trace
 			Context:   rc,
 			Alias:     alias,
 			Provider:  lastRoute.Provider,
+			ABVariant: lastRoute.ABVariant,
 			Latency:   lastLatency,
 			Status:    statusCode,
 			ErrorCode: msg,
@@ -922,8 +1124,9 @@ type chatMessage struct {
 }
 
 type openAIEmbeddingRequest struct {
-	Model string          `json:"model"`
-	Input json.RawMessage `json:"input"`
+	Model      string          `json:"model"`
+	Input      json.RawMessage `json:"input"`
+	Dimensions *int32          `json:"dimensions,omitempty"`
 }
 
 type openAIImageRequest struct {
@@ -1125,6 +1328,8 @@ func mapStatusToCode(status int) string {
 		return "rate_limit_error"
 	case fiber.StatusServiceUnavailable:
 		return "service_unavailable"
+	case fiber.StatusUnavailableForLegalReasons:
+		return "data_residency_error"
 	default:
 		return "provider_error"
 	}
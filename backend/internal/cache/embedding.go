@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var errInvalidVector = errors.New("invalid cached embedding")
+
+// EmbeddingCache stores embedding vectors keyed by model, requested
+// dimensions, and input hash so repeated inputs skip the provider call. Vectors are stored as little-endian
+// float32 bytes rather than JSON to keep entries compact.
+type EmbeddingCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func NewEmbeddingCache(client *redis.Client, ttl time.Duration) *EmbeddingCache {
+	if ttl <= 0 {
+		ttl = 24 * time.Hour
+	}
+	return &EmbeddingCache{client: client, ttl: ttl}
+}
+
+// Get returns the cached vector for input. dimensions is the size the caller
+// requested, or zero for the model's native size.
+func (c *EmbeddingCache) Get(ctx context.Context, model string, dimensions int32, input string) ([]float32, bool) {
+	if c == nil || c.client == nil {
+		return nil, false
+	}
+	data, err := c.client.Get(ctx, embeddingKey(model, dimensions, input)).Bytes()
+	if err != nil {
+		return nil, false
+	}
+	vector, err := decodeVector(data)
+	if err != nil {
+		return nil, false
+	}
+	return vector, true
+}
+
+func (c *EmbeddingCache) Set(ctx context.Context, model string, dimensions int32, input string, vector []float32) error {
+	if c == nil || c.client == nil || len(vector) == 0 {
+		return nil
+	}
+	return c.client.Set(ctx, embeddingKey(model, dimensions, input), encodeVector(vector), c.ttl).Err()
+}
+
+// GetAll returns cached vectors for every input, or false if any is missing.
+func (c *EmbeddingCache) GetAll(ctx context.Context, model string, dimensions int32, inputs []string) ([][]float32, bool) {
+	if c == nil || c.client == nil || len(inputs) == 0 {
+		return nil, false
+	}
+	vectors := make([][]float32, 0, len(inputs))
+	for _, input := range inputs {
+		vector, ok := c.Get(ctx, model, dimensions, input)
+		if !ok {
+			return nil, false
+		}
+		vectors = append(vectors, vector)
+	}
+	return vectors, true
+}
+
+// embeddingKey leaves native-size keys unchanged so existing entries stay
+// valid; reduced sizes get their own namespace.
+func embeddingKey(model string, dimensions int32, input string) string {
+	sum := sha256.Sum256([]byte(normalizeEmbeddingInput(input)))
+	prefix := "emb_cache:" + model + ":"
+	if dimensions > 0 {
+		prefix += "d" + strconv.Itoa(int(dimensions)) + ":"
+	}
+	return prefix + hex.EncodeToString(sum[:])
+}
+
+func normalizeEmbeddingInput(input string) string {
+	return strings.TrimSpace(input)
+}
+
+func encodeVector(vector []float32) []byte {
+	buf := make([]byte, 4*len(vector))
+	for i, v := range vector {
+		binary.LittleEndian.PutUint32(buf[i*4:], math.Float32bits(v))
+	}
+	return buf
+}
+
+func decodeVector(data []byte) ([]float32, error) {
+	if len(data) == 0 || len(data)%4 != 0 {
+		return nil, errInvalidVector
+	}
+	vector := make([]float32, len(data)/4)
+	for i := range vector {
+		vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[i*4:]))
+	}
+	return vector, nil
+}
@@ -0,0 +1,49 @@
+package cache
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVectorRoundTrip(t *testing.T) {
+	vector := []float32{0, 1.5, -2.25, math.MaxFloat32, float32(math.Inf(-1))}
+	decoded, err := decodeVector(encodeVector(vector))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(decoded) != len(vector) {
+		t.Fatalf("expected %d values, got %d", len(vector), len(decoded))
+	}
+	for i := range vector {
+		if decoded[i] != vector[i] {
+			t.Fatalf("value %d: expected %v, got %v", i, vector[i], decoded[i])
+		}
+	}
+	if len(encodeVector(vector)) != 4*len(vector) {
+		t.Fatal("expected 4 bytes per value")
+	}
+}
+
+func TestDecodeVectorRejectsTruncatedData(t *testing.T) {
+	if _, err := decodeVector([]byte{1, 2, 3}); err == nil {
+		t.Fatal("expected error for truncated data")
+	}
+	if _, err := decodeVector(nil); err == nil {
+		t.Fatal("expected error for empty data")
+	}
+}
+
+func TestEmbeddingKeyNormalizesInput(t *testing.T) {
+	if embeddingKey("m", 0, "  hello\n") != embeddingKey("m", 0, "hello") {
+		t.Fatal("expected surrounding whitespace to be ignored")
+	}
+	if embeddingKey("m", 0, "hello") == embeddingKey("other", 0, "hello") {
+		t.Fatal("expected model to be part of the key")
+	}
+	if got := embeddingKey("m", 0, "hello"); got[:len("emb_cache:m:")] != "emb_cache:m:" {
+		t.Fatalf("unexpected key prefix %q", got)
+	}
+	if embeddingKey("m", 256, "hello") == embeddingKey("m", 0, "hello") {
+		t.Fatal("expected dimensions to be part of the key")
+	}
+}
@@ -4,9 +4,18 @@ import (
 	context "context"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 )
 
+// IdempotencyStore keeps serialized responses for Idempotency-Key replays.
+// IdempotencyCache, DBIdempotencyCache and FallbackCache implement it.
+type IdempotencyStore interface {
+	Get(ctx context.Context, key string) ([]byte, bool)
+	Set(ctx context.Context, key string, value []byte)
+	SetWithTTL(ctx context.Context, key string, value []byte, ttl time.Duration)
+}
+
 // IdempotencyCache stores serialized responses keyed by request id.
 type IdempotencyCache struct {
 	client *redis.Client
@@ -24,7 +33,7 @@ func (c *IdempotencyCache) Get(ctx context.Context, key string) ([]byte, bool) {
 	if c == nil || c.client == nil || key == "" {
 		return nil, false
 	}
-	data, err := c.client.Get(ctx, c.prefixed(key)).Bytes()
+	data, err := c.get(ctx, key)
 	if err != nil {
 		return nil, false
 	}
@@ -35,9 +44,68 @@ func (c *IdempotencyCache) Set(ctx context.Context, key string, value []byte) {
 	if c == nil || c.client == nil || key == "" || len(value) == 0 {
 		return
 	}
-	c.client.Set(ctx, c.prefixed(key), value, c.ttl)
+	c.set(ctx, key, value, c.ttl)
+}
+
+// SetWithTTL stores value like Set but expires it after ttl instead of the
+// cache's configured TTL.
+func (c *IdempotencyCache) SetWithTTL(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	if c == nil || c.client == nil || key == "" || len(value) == 0 || ttl <= 0 {
+		return
+	}
+	c.set(ctx, key, value, ttl)
+}
+
+// get returns redis.Nil for a missing key so callers can tell a miss from a
+// Redis failure.
+func (c *IdempotencyCache) get(ctx context.Context, key string) ([]byte, error) {
+	return c.client.Get(ctx, c.prefixed(key)).Bytes()
+}
+
+func (c *IdempotencyCache) set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, c.prefixed(key), value, ttl).Err()
 }
 
 func (c *IdempotencyCache) prefixed(key string) string {
 	return "idem:" + key
 }
+
+// StreamIdempotencyCache stores the SSE transcript of a completed streaming
+// response keyed by request id, so a retried request can be replayed without
+// reaching the provider. Entries are raw "data: ..." frames and use their own
+// key space and TTL, separate from IdempotencyCache's JSON bodies. Keys are
+// scoped to the tenant and API key that made the request, so one caller's
+// Idempotency-Key can never replay another caller's transcript.
+type StreamIdempotencyCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func NewStreamIdempotencyCache(client *redis.Client, ttl time.Duration) *StreamIdempotencyCache {
+	if ttl <= 0 {
+		ttl = 10 * time.Minute
+	}
+	return &StreamIdempotencyCache{client: client, ttl: ttl}
+}
+
+func (c *StreamIdempotencyCache) Get(ctx context.Context, tenantID, apiKeyID uuid.UUID, key string) ([]byte, bool) {
+	if c == nil || c.client == nil || key == "" {
+		return nil, false
+	}
+	data, err := c.client.Get(ctx, c.prefixed(tenantID, apiKeyID, key)).Bytes()
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+func (c *StreamIdempotencyCache) Set(ctx context.Context, tenantID, apiKeyID uuid.UUID, key string, transcript []byte) {
+	if c == nil || c.client == nil || key == "" || len(transcript) == 0 {
+		return
+	}
+	c.client.Set(ctx, c.prefixed(tenantID, apiKeyID, key), transcript, c.ttl)
+}
+
+func (c *StreamIdempotencyCache) prefixed(tenantID, apiKeyID uuid.UUID, key string) string {
+	return "idem:stream:" + tenantID.String() + ":" + apiKeyID.String() + ":" + key
+}
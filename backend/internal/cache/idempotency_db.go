@@ -0,0 +1,75 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+type idempotencyQueries interface {
+	GetIdempotencyKey(ctx context.Context, key string) ([]byte, error)
+	UpsertIdempotencyKey(ctx context.Context, arg db.UpsertIdempotencyKeyParams) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context, expiresAt pgtype.Timestamptz) (int64, error)
+}
+
+// DBIdempotencyCache stores serialized responses in the idempotency_keys
+// table so replays survive Redis restarts. Expired rows are ignored on read
+// and removed by PurgeExpired.
+type DBIdempotencyCache struct {
+	queries idempotencyQueries
+	ttl     time.Duration
+	now     func() time.Time
+}
+
+func NewDBIdempotencyCache(queries idempotencyQueries, ttl time.Duration) *DBIdempotencyCache {
+	if ttl <= 0 {
+		ttl = 30 * time.Minute
+	}
+	return &DBIdempotencyCache{queries: queries, ttl: ttl, now: time.Now}
+}
+
+func (c *DBIdempotencyCache) Get(ctx context.Context, key string) ([]byte, bool) {
+	if c == nil || c.queries == nil || key == "" {
+		return nil, false
+	}
+	data, err := c.queries.GetIdempotencyKey(ctx, key)
+	if err != nil || len(data) == 0 {
+		return nil, false
+	}
+	return data, true
+}
+
+func (c *DBIdempotencyCache) Set(ctx context.Context, key string, value []byte) {
+	if c == nil {
+		return
+	}
+	c.SetWithTTL(ctx, key, value, c.ttl)
+}
+
+// SetWithTTL stores value like Set but expires it after ttl instead of the
+// cache's configured TTL.
+func (c *DBIdempotencyCache) SetWithTTL(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	if c == nil || c.queries == nil || key == "" || len(value) == 0 || ttl <= 0 {
+		return
+	}
+	c.set(ctx, key, value, ttl)
+}
+
+func (c *DBIdempotencyCache) set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.queries.UpsertIdempotencyKey(ctx, db.UpsertIdempotencyKeyParams{
+		Key:       key,
+		Response:  value,
+		ExpiresAt: pgtype.Timestamptz{Time: c.now().Add(ttl), Valid: true},
+	})
+}
+
+// PurgeExpired deletes entries whose TTL has lapsed.
+func (c *DBIdempotencyCache) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	if c == nil || c.queries == nil {
+		return 0, nil
+	}
+	return c.queries.DeleteExpiredIdempotencyKeys(ctx, pgtype.Timestamptz{Time: now, Valid: true})
+}
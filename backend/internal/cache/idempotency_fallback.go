@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// defaultFallbackTimeout bounds each Redis call before FallbackCache gives up
+// on it and uses the database.
+const defaultFallbackTimeout = 250 * time.Millisecond
+
+// FallbackCache tries Redis first and falls back to PostgreSQL when a Redis
+// call fails or times out. Reads that miss in Redis also check the database,
+// so responses stored during a Redis outage still replay after it recovers.
+type FallbackCache struct {
+	redis   *IdempotencyCache
+	db      *DBIdempotencyCache
+	timeout time.Duration
+}
+
+func NewFallbackCache(redisCache *IdempotencyCache, dbCache *DBIdempotencyCache) *FallbackCache {
+	return &FallbackCache{redis: redisCache, db: dbCache, timeout: defaultFallbackTimeout}
+}
+
+func (c *FallbackCache) Get(ctx context.Context, key string) ([]byte, bool) {
+	if c == nil || key == "" {
+		return nil, false
+	}
+	if c.redis != nil && c.redis.client != nil {
+		var data []byte
+		err := c.callRedis(ctx, func(ctx context.Context) (err error) {
+			data, err = c.redis.get(ctx, key)
+			return err
+		})
+		if err == nil {
+			return data, true
+		}
+		if !errors.Is(err, redis.Nil) {
+			slog.Warn("idempotency cache read fell back to database", slog.String("error", err.Error()))
+		}
+	}
+	return c.db.Get(ctx, key)
+}
+
+func (c *FallbackCache) Set(ctx context.Context, key string, value []byte) {
+	if c == nil || c.redis == nil {
+		return
+	}
+	c.SetWithTTL(ctx, key, value, c.redis.ttl)
+}
+
+// SetWithTTL stores value like Set but expires it after ttl instead of the
+// cache's configured TTL.
+func (c *FallbackCache) SetWithTTL(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	if c == nil || key == "" || len(value) == 0 || ttl <= 0 {
+		return
+	}
+	if c.redis != nil && c.redis.client != nil {
+		err := c.callRedis(ctx, func(ctx context.Context) error {
+			return c.redis.set(ctx, key, value, ttl)
+		})
+		if err == nil {
+			return
+		}
+		slog.Warn("idempotency cache write fell back to database", slog.String("error", err.Error()))
+	}
+	c.db.SetWithTTL(ctx, key, value, ttl)
+}
+
+// callRedis runs fn and gives up after the fallback timeout. The client only
+// honours context deadlines when configured to, so the wait is bounded here;
+// an abandoned call finishes in the background.
+func (c *FallbackCache) callRedis(ctx context.Context, fn func(context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- fn(ctx) }()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
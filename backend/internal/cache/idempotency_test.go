@@ -0,0 +1,192 @@
+package cache
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+// memoryIdempotencyQueries stands in for the idempotency_keys table.
+type memoryIdempotencyQueries struct {
+	rows map[string]db.UpsertIdempotencyKeyParams
+	now  time.Time
+}
+
+func newMemoryIdempotencyQueries(now time.Time) *memoryIdempotencyQueries {
+	return &memoryIdempotencyQueries{rows: map[string]db.UpsertIdempotencyKeyParams{}, now: now}
+}
+
+func (q *memoryIdempotencyQueries) GetIdempotencyKey(_ context.Context, key string) ([]byte, error) {
+	row, ok := q.rows[key]
+	if !ok || !row.ExpiresAt.Time.After(q.now) {
+		return nil, pgx.ErrNoRows
+	}
+	return row.Response, nil
+}
+
+func (q *memoryIdempotencyQueries) UpsertIdempotencyKey(_ context.Context, arg db.UpsertIdempotencyKeyParams) error {
+	q.rows[arg.Key] = arg
+	return nil
+}
+
+func (q *memoryIdempotencyQueries) DeleteExpiredIdempotencyKeys(_ context.Context, expiresAt pgtype.Timestamptz) (int64, error) {
+	var deleted int64
+	for key, row := range q.rows {
+		if row.ExpiresAt.Time.Before(expiresAt.Time) {
+			delete(q.rows, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func newTestDBCache(now time.Time) (*DBIdempotencyCache, *memoryIdempotencyQueries) {
+	queries := newMemoryIdempotencyQueries(now)
+	dbCache := NewDBIdempotencyCache(queries, time.Minute)
+	dbCache.now = func() time.Time { return now }
+	return dbCache, queries
+}
+
+// unresponsiveRedis accepts connections but never replies, so every command
+// runs into its deadline.
+func unresponsiveRedis(t *testing.T) *redis.Client {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	var (
+		mu    sync.Mutex
+		conns []net.Conn
+	)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			conns = append(conns, conn)
+			mu.Unlock()
+		}
+	}()
+	client := redis.NewClient(&redis.Options{Addr: listener.Addr().String(), MaxRetries: -1})
+	t.Cleanup(func() {
+		client.Close()
+		listener.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		for _, conn := range conns {
+			conn.Close()
+		}
+	})
+	return client
+}
+
+func TestFallbackCacheUsesDatabaseOnRedisTimeout(t *testing.T) {
+	now := time.Now()
+	dbCache, queries := newTestDBCache(now)
+	fallback := NewFallbackCache(NewIdempotencyCache(unresponsiveRedis(t), time.Minute), dbCache)
+	fallback.timeout = 50 * time.Millisecond
+	ctx := context.Background()
+
+	start := time.Now()
+	fallback.Set(ctx, "req-1", []byte(`{"id":"chatcmpl-1"}`))
+	if _, ok := queries.rows["req-1"]; !ok {
+		t.Fatal("expected the write to fall back to the database")
+	}
+	data, ok := fallback.Get(ctx, "req-1")
+	if !ok || string(data) != `{"id":"chatcmpl-1"}` {
+		t.Fatalf("expected the read to fall back to the database, got %q", data)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("fallback waited %s on Redis", elapsed)
+	}
+}
+
+func TestFallbackCachePrefersRedis(t *testing.T) {
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() {
+		client.Close()
+		server.Close()
+	})
+	dbCache, queries := newTestDBCache(time.Now())
+	fallback := NewFallbackCache(NewIdempotencyCache(client, time.Minute), dbCache)
+	ctx := context.Background()
+
+	fallback.Set(ctx, "req-1", []byte("cached"))
+	if len(queries.rows) != 0 {
+		t.Fatal("expected healthy Redis writes to skip the database")
+	}
+	if !server.Exists("idem:req-1") {
+		t.Fatal("expected the response in Redis")
+	}
+
+	// Responses stored while Redis was down still replay after it recovers.
+	queries.rows["req-2"] = db.UpsertIdempotencyKeyParams{
+		Key:       "req-2",
+		Response:  []byte("from-db"),
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(time.Minute), Valid: true},
+	}
+	if data, ok := fallback.Get(ctx, "req-2"); !ok || string(data) != "from-db" {
+		t.Fatalf("expected a Redis miss to check the database, got %q", data)
+	}
+}
+
+func TestDBIdempotencyCacheExpiry(t *testing.T) {
+	now := time.Now()
+	dbCache, queries := newTestDBCache(now)
+	ctx := context.Background()
+
+	dbCache.Set(ctx, "fresh", []byte("a"))
+	dbCache.SetWithTTL(ctx, "short", []byte("b"), time.Second)
+	if data, ok := dbCache.Get(ctx, "fresh"); !ok || string(data) != "a" {
+		t.Fatalf("expected stored response, got %q", data)
+	}
+
+	queries.now = now.Add(30 * time.Second)
+	if _, ok := dbCache.Get(ctx, "short"); ok {
+		t.Fatal("expected expired entry to be ignored")
+	}
+	deleted, err := dbCache.PurgeExpired(ctx, queries.now)
+	if err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	if deleted != 1 || len(queries.rows) != 1 {
+		t.Fatalf("expected only the expired row purged, deleted %d, left %d", deleted, len(queries.rows))
+	}
+}
+
+func TestModelListCacheIsSeparateFromIdempotencyKeys(t *testing.T) {
+	srv := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	ctx := context.Background()
+	idem := NewIdempotencyCache(client, time.Minute)
+	lists := NewModelListCache(client, time.Minute)
+
+	key := "tenant:p=openai:m=:t=:e=:s=:l=0:a="
+	idem.Set(ctx, key, []byte(`{"forged":true}`))
+	if _, ok := lists.Get(ctx, key); ok {
+		t.Fatal("an Idempotency-Key entry must not be served as a model listing")
+	}
+	lists.Set(ctx, key, []byte(`{"object":"list"}`))
+	if data, ok := idem.Get(ctx, key); !ok || string(data) != `{"forged":true}` {
+		t.Fatalf("model listing overwrote the idempotency entry: %q", data)
+	}
+	if data, ok := lists.Get(ctx, key); !ok || string(data) != `{"object":"list"}` {
+		t.Fatalf("unexpected cached listing %q", data)
+	}
+}
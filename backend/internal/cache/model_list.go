@@ -0,0 +1,45 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ModelListCache stores rendered GET /v1/models listings. It has its own key
+// space so a client-chosen Idempotency-Key can never collide with, read, or
+// overwrite a cached listing.
+type ModelListCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func NewModelListCache(client *redis.Client, ttl time.Duration) *ModelListCache {
+	if ttl <= 0 {
+		ttl = 30 * time.Second
+	}
+	return &ModelListCache{client: client, ttl: ttl}
+}
+
+func (c *ModelListCache) Get(ctx context.Context, key string) ([]byte, bool) {
+	if c == nil || c.client == nil || key == "" {
+		return nil, false
+	}
+	data, err := c.client.Get(ctx, c.prefixed(key)).Bytes()
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+func (c *ModelListCache) Set(ctx context.Context, key string, value []byte) {
+	if c == nil || c.client == nil || key == "" || len(value) == 0 {
+		return
+	}
+	c.client.Set(ctx, c.prefixed(key), value, c.ttl)
+}
+
+func (c *ModelListCache) prefixed(key string) string {
+	return "modellist:" + key
+}
@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// SystemPrompt is the cached form of a tenant's injected system prompt. An
+// empty Content records that the tenant has no prompt configured.
+type SystemPrompt struct {
+	Content string `json:"content"`
+	Mode    string `json:"mode"`
+}
+
+// SystemPromptCache stores tenant system prompts so request authentication does
+// not hit Postgres on every call.
+type SystemPromptCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func NewSystemPromptCache(client *redis.Client, ttl time.Duration) *SystemPromptCache {
+	if ttl <= 0 {
+		ttl = 5 * time.Minute
+	}
+	return &SystemPromptCache{client: client, ttl: ttl}
+}
+
+func (c *SystemPromptCache) Get(ctx context.Context, tenantID uuid.UUID) (SystemPrompt, bool) {
+	if c == nil || c.client == nil || tenantID == uuid.Nil {
+		return SystemPrompt{}, false
+	}
+	data, err := c.client.Get(ctx, c.key(tenantID)).Bytes()
+	if err != nil {
+		return SystemPrompt{}, false
+	}
+	var prompt SystemPrompt
+	if err := json.Unmarshal(data, &prompt); err != nil {
+		return SystemPrompt{}, false
+	}
+	return prompt, true
+}
+
+func (c *SystemPromptCache) Set(ctx context.Context, tenantID uuid.UUID, prompt SystemPrompt) {
+	if c == nil || c.client == nil || tenantID == uuid.Nil {
+		return
+	}
+	data, err := json.Marshal(prompt)
+	if err != nil {
+		return
+	}
+	c.client.Set(ctx, c.key(tenantID), data, c.ttl)
+}
+
+func (c *SystemPromptCache) Invalidate(ctx context.Context, tenantID uuid.UUID) {
+	if c == nil || c.client == nil || tenantID == uuid.Nil {
+		return
+	}
+	c.client.Del(ctx, c.key(tenantID))
+}
+
+func (c *SystemPromptCache) key(tenantID uuid.UUID) string {
+	return "sysprompt:" + tenantID.String()
+}
@@ -0,0 +1,84 @@
+package catalog
+
+import (
+	"math"
+	"unicode/utf8"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+)
+
+// TokenEstimator approximates prompt token counts without calling a provider.
+type TokenEstimator interface {
+	EstimateMessages(messages []models.ChatMessage) int
+	EstimateText(text string) int
+}
+
+// HeuristicEstimator counts characters and divides by an average token width.
+// Each message adds a fixed overhead for role and delimiter tokens, and the
+// prompt as a whole adds ReplyPriming for the assistant turn header.
+type HeuristicEstimator struct {
+	CharsPerToken   float64
+	MessageOverhead int
+	ReplyPriming    int
+}
+
+// EstimateMessages returns the estimated prompt tokens for messages.
+func (e HeuristicEstimator) EstimateMessages(messages []models.ChatMessage) int {
+	if len(messages) == 0 {
+		return 0
+	}
+	total := e.ReplyPriming
+	for _, msg := range messages {
+		chars := utf8.RuneCountInString(msg.Role) + utf8.RuneCountInString(msg.Content) + utf8.RuneCountInString(msg.Name)
+		total += e.MessageOverhead + e.tokensForChars(chars)
+	}
+	return total
+}
+
+// EstimateText returns the estimated tokens for raw text such as an
+// embedding input or image prompt.
+func (e HeuristicEstimator) EstimateText(text string) int {
+	return e.tokensForChars(utf8.RuneCountInString(text))
+}
+
+func (e HeuristicEstimator) tokensForChars(chars int) int {
+	width := e.CharsPerToken
+	if width <= 0 {
+		width = 4
+	}
+	return int(math.Ceil(float64(chars) / width))
+}
+
+var defaultTokenEstimator = HeuristicEstimator{CharsPerToken: 4, MessageOverhead: 3, ReplyPriming: 3}
+
+// TokenEstimatorFactory returns the estimator tuned for a provider. Providers
+// without a dedicated entry fall back to the OpenAI-style heuristic.
+type TokenEstimatorFactory struct {
+	estimators map[string]TokenEstimator
+	fallback   TokenEstimator
+}
+
+func NewTokenEstimatorFactory() *TokenEstimatorFactory {
+	return &TokenEstimatorFactory{
+		estimators: map[string]TokenEstimator{
+			"openai":            defaultTokenEstimator,
+			"azure":             defaultTokenEstimator,
+			"openai-compatible": defaultTokenEstimator,
+			"anthropic":         HeuristicEstimator{CharsPerToken: 3.5, MessageOverhead: 4, ReplyPriming: 2},
+			"bedrock":           HeuristicEstimator{CharsPerToken: 3.5, MessageOverhead: 4, ReplyPriming: 2},
+			"vertex":            HeuristicEstimator{CharsPerToken: 4, MessageOverhead: 4, ReplyPriming: 0},
+		},
+		fallback: defaultTokenEstimator,
+	}
+}
+
+// For returns the estimator registered for provider.
+func (f *TokenEstimatorFactory) For(provider string) TokenEstimator {
+	if f == nil {
+		return defaultTokenEstimator
+	}
+	if est, ok := f.estimators[NormalizeProviderSlug(provider)]; ok {
+		return est
+	}
+	return f.fallback
+}
@@ -0,0 +1,36 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+)
+
+func TestHeuristicEstimatorCountsMessages(t *testing.T) {
+	est := HeuristicEstimator{CharsPerToken: 4, MessageOverhead: 3, ReplyPriming: 3}
+	messages := []models.ChatMessage{
+		{Role: "user", Content: "hello world!"}, // 16 chars -> 4 tokens
+	}
+	if got, want := est.EstimateMessages(messages), 3+3+4; got != want {
+		t.Fatalf("expected %d tokens, got %d", want, got)
+	}
+	if got := est.EstimateMessages(nil); got != 0 {
+		t.Fatalf("expected 0 tokens for empty prompt, got %d", got)
+	}
+	if got := est.EstimateText("hello"); got != 2 {
+		t.Fatalf("expected 2 tokens for raw text, got %d", got)
+	}
+}
+
+func TestTokenEstimatorFactoryFallsBack(t *testing.T) {
+	factory := NewTokenEstimatorFactory()
+	if got := factory.For("Anthropic"); got.(HeuristicEstimator).CharsPerToken != 3.5 {
+		t.Fatalf("expected anthropic estimator, got %+v", got)
+	}
+	if got := factory.For("openai_compatible"); got != defaultTokenEstimator {
+		t.Fatalf("expected openai-compatible to use default, got %+v", got)
+	}
+	if got := factory.For("unknown"); got != defaultTokenEstimator {
+		t.Fatalf("expected fallback estimator, got %+v", got)
+	}
+}
@@ -1,9 +1,13 @@
 package config
 
 import (
+	"encoding/base64"
 	"fmt"
+	"net/netip"
+	"net/url"
 	"os"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -12,7 +16,9 @@ import (
 	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
 
+	"github.com/ncecere/open_model_gateway/backend/internal/currency"
 	"github.com/ncecere/open_model_gateway/backend/internal/rbac"
+	"github.com/ncecere/open_model_gateway/backend/internal/redact"
 )
 
 // Config captures the runtime configuration for the router service.
@@ -21,18 +27,30 @@ type Config struct {
 	Database      DatabaseConfig      `mapstructure:"database"`
 	Redis         RedisConfig         `mapstructure:"redis"`
 	RateLimits    RateLimitConfig     `mapstructure:"rate_limits"`
+	APIKeys       APIKeyConfig        `mapstructure:"api_keys"`
 	Budgets       BudgetConfig        `mapstructure:"budgets"`
 	Reporting     ReportingConfig     `mapstructure:"reporting"`
 	Providers     ProviderConfig      `mapstructure:"providers"`
 	Files         FilesConfig         `mapstructure:"files"`
 	Audio         AudioConfig         `mapstructure:"audio"`
+	Image         ImageConfig         `mapstructure:"image"`
 	Batches       BatchesConfig       `mapstructure:"batches"`
 	Retention     RetentionConfig     `mapstructure:"retention"`
+	Archive       ArchiveConfig       `mapstructure:"archive"`
+	Cache         CacheConfig         `mapstructure:"cache"`
+	Idempotency   IdempotencyConfig   `mapstructure:"idempotency"`
+	Debug         DebugConfig         `mapstructure:"debug"`
+	Deprecation   DeprecationConfig   `mapstructure:"deprecation"`
+	Retry         RetryConfig         `mapstructure:"retry"`
+	Logging       LoggingConfig       `mapstructure:"logging"`
 	Observability ObservabilityConfig `mapstructure:"observability"`
 	Health        HealthConfig        `mapstructure:"health"`
 	Admin         AdminConfig         `mapstructure:"admin"`
 	ModelCatalog  []ModelCatalogEntry `mapstructure:"model_catalog"`
 	Bootstrap     BootstrapConfig     `mapstructure:"bootstrap"`
+
+	// File is the config file Load read, empty when none was found.
+	File string `mapstructure:"-"`
 }
 
 type ServerConfig struct {
@@ -44,6 +62,38 @@ type ServerConfig struct {
 	ProviderTimeout       time.Duration `mapstructure:"provider_timeout"`
 	ReadHeaderTimeout     time.Duration `mapstructure:"read_header_timeout"`
 	GracefulShutdownDelay time.Duration `mapstructure:"graceful_shutdown_delay"`
+	// GracefulStreamDrainTimeout bounds how long shutdown waits for in-flight
+	// streaming responses to finish.
+	GracefulStreamDrainTimeout time.Duration `mapstructure:"graceful_stream_drain_timeout"`
+	// GRPCListenAddr enables the gRPC chat service when set (e.g. ":9090").
+	GRPCListenAddr string `mapstructure:"grpc_listen_addr"`
+	// WatchConfig reloads the model catalog when the config file changes.
+	WatchConfig bool `mapstructure:"watch_config"`
+	// CORS applies to the public /v1 API; AdminCORS to the admin and user
+	// portal APIs, which send the session cookie and so never allow "*".
+	CORS      CORSConfig `mapstructure:"cors"`
+	AdminCORS CORSConfig `mapstructure:"admin_cors"`
+	// ProxyAuth lets a trusted reverse proxy identify public API callers by
+	// user ID instead of an API key.
+	ProxyAuth ProxyAuthConfig `mapstructure:"proxy_auth"`
+}
+
+// ProxyAuthConfig trusts UserIDHeader on /v1 requests whose TCP peer falls
+// inside one of TrustedProxies (CIDRs or bare IPs). The header is ignored on
+// connections from anywhere else.
+type ProxyAuthConfig struct {
+	Enabled        bool     `mapstructure:"enabled"`
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
+	UserIDHeader   string   `mapstructure:"user_id_header"`
+}
+
+// CORSConfig controls the Access-Control-Allow-* headers sent to browsers.
+type CORSConfig struct {
+	Enabled        bool     `mapstructure:"enabled"`
+	AllowedOrigins []string `mapstructure:"allowed_origins"`
+	AllowedMethods []string `mapstructure:"allowed_methods"`
+	AllowedHeaders []string `mapstructure:"allowed_headers"`
+	MaxAgeSec      int      `mapstructure:"max_age_sec"`
 }
 
 type DatabaseConfig struct {
@@ -63,11 +113,29 @@ type RedisConfig struct {
 }
 
 type AdminConfig struct {
-	Session AdminSessionConfig `mapstructure:"session"`
-	Local   LocalAuthConfig    `mapstructure:"local"`
-	OIDC    OIDCConfig         `mapstructure:"oidc"`
+	Session       AdminSessionConfig `mapstructure:"session"`
+	Local         LocalAuthConfig    `mapstructure:"local"`
+	OIDC          OIDCConfig         `mapstructure:"oidc"`
+	SAML          SAMLConfig         `mapstructure:"saml"`
+	InvitationTTL time.Duration      `mapstructure:"invitation_ttl"`
+	// SuspensionNotification emails a tenant's owners when it is suspended.
+	SuspensionNotification SuspensionNotificationConfig `mapstructure:"suspension_notification"`
 }
 
+// SuspensionNotificationConfig controls the email sent to tenant owners on
+// suspension. Mail goes out through budgets.alert.smtp.
+type SuspensionNotificationConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// FromAddress is the sender and the contact address for appeals;
+	// empty uses budgets.alert.smtp.from.
+	FromAddress string `mapstructure:"from_address"`
+	// TemplateSubject is the email subject; {tenant} is replaced with the
+	// tenant name.
+	TemplateSubject string `mapstructure:"template_subject"`
+}
+
+const defaultSuspensionSubject = "Tenant {tenant} has been suspended"
+
 type AdminSessionConfig struct {
 	JWTSecret       string        `mapstructure:"jwt_secret"`
 	AccessTokenTTL  time.Duration `mapstructure:"access_token_ttl"`
@@ -93,11 +161,31 @@ type OIDCConfig struct {
 	AdminRoles     []string      `mapstructure:"admin_roles"`
 }
 
+type SAMLConfig struct {
+	Enabled      bool          `mapstructure:"enabled"`
+	MetadataURL  string        `mapstructure:"metadata_url"`
+	IDPCertFile  string        `mapstructure:"idp_cert_file"`
+	EntityID     string        `mapstructure:"entity_id"`
+	ACSURL       string        `mapstructure:"acs_url"`
+	SignRequests bool          `mapstructure:"sign_requests"`
+	SPCertFile   string        `mapstructure:"sp_cert_file"`
+	SPKeyFile    string        `mapstructure:"sp_key_file"`
+	HTTPTimeout  time.Duration `mapstructure:"http_timeout"`
+	EmailAttr    string        `mapstructure:"email_attribute"`
+	NameAttr     string        `mapstructure:"name_attribute"`
+	RolesClaim   string        `mapstructure:"roles_claim"`
+	AllowedRoles []string      `mapstructure:"allowed_roles"`
+	AdminRoles   []string      `mapstructure:"admin_roles"`
+}
+
 type RateLimitConfig struct {
 	DefaultTokensPerMinute        int `mapstructure:"default_tokens_per_minute"`
 	DefaultRequestsPerMinute      int `mapstructure:"default_requests_per_minute"`
 	DefaultParallelRequestsKey    int `mapstructure:"default_parallel_requests_key"`
 	DefaultParallelRequestsTenant int `mapstructure:"default_parallel_requests_tenant"`
+	// ParallelRequestTTL is how long a parallel-request slot outlives a
+	// gateway instance that stopped refreshing it, e.g. after a crash.
+	ParallelRequestTTL time.Duration `mapstructure:"parallel_request_ttl"`
 }
 
 type BudgetConfig struct {
@@ -105,6 +193,31 @@ type BudgetConfig struct {
 	WarningThresholdPerc float64           `mapstructure:"warning_threshold_perc"`
 	RefreshSchedule      string            `mapstructure:"refresh_schedule"`
 	Alert                BudgetAlertConfig `mapstructure:"alert"`
+	// EstimateCompletionBufferPerc is the share of a model's context window
+	// assumed as completion tokens by X-Estimate-Cost dry runs when the request
+	// does not set max_tokens.
+	EstimateCompletionBufferPerc float64 `mapstructure:"estimate_completion_buffer_perc"`
+	// MaxPersonalBudgetUSD caps the budget users may set on their personal
+	// tenant through /v1/me/budget.
+	MaxPersonalBudgetUSD float64 `mapstructure:"max_personal_budget_usd"`
+	// PersonalDefaultUSD is stored as the budget override of each newly
+	// created personal tenant. Zero leaves personal tenants on DefaultUSD.
+	PersonalDefaultUSD float64 `mapstructure:"personal_default_usd"`
+	// Currency is the ISO 4217 code usage reports are shown in when the
+	// caller does not pass one. Costs are recorded in USD and converted with
+	// the currency_rates table.
+	Currency string `mapstructure:"currency"`
+	// AlertEscalationLevels notify extra contacts as spend crosses each
+	// threshold. Every level fires at most once per budget period.
+	AlertEscalationLevels []EscalationLevel `mapstructure:"alert_escalation_levels"`
+}
+
+// EscalationLevel is one budget escalation tier. ThresholdPerc is a fraction
+// of the budget (0.95 = 95%, 1 = fully spent).
+type EscalationLevel struct {
+	ThresholdPerc float64  `mapstructure:"threshold_perc"`
+	Emails        []string `mapstructure:"emails"`
+	Webhooks      []string `mapstructure:"webhooks"`
 }
 
 type BudgetAlertConfig struct {
@@ -114,6 +227,23 @@ type BudgetAlertConfig struct {
 	Cooldown time.Duration `mapstructure:"cooldown"`
 	SMTP     SMTPConfig    `mapstructure:"smtp"`
 	Webhook  WebhookConfig `mapstructure:"webhook"`
+	// Usage holds the gateway-wide spike thresholds; tenants subscribe with
+	// their own usage_alert_config on tenant_budget_overrides.
+	Usage UsageAlertConfig `mapstructure:"usage"`
+}
+
+// UsageAlertConfig flags hours whose request or token volume exceeds the
+// rolling hourly average by the given percentage. A zero threshold disables
+// that check.
+type UsageAlertConfig struct {
+	RequestSpikeThreshold int           `mapstructure:"request_spike_threshold"`
+	TokenSpikeThreshold   int           `mapstructure:"token_spike_threshold"`
+	LookbackWindow        time.Duration `mapstructure:"lookback_window"`
+}
+
+// Enabled reports whether any spike threshold is set.
+func (c UsageAlertConfig) Enabled() bool {
+	return c.RequestSpikeThreshold > 0 || c.TokenSpikeThreshold > 0
 }
 
 type SMTPConfig struct {
@@ -130,6 +260,9 @@ type SMTPConfig struct {
 type WebhookConfig struct {
 	Timeout    time.Duration `mapstructure:"timeout"`
 	MaxRetries int           `mapstructure:"max_retries"`
+	// SigningSecret signs queued deliveries that have no tenant-specific
+	// alert_webhook_secret. Empty leaves them unsigned.
+	SigningSecret string `mapstructure:"signing_secret"`
 }
 
 type ReportingConfig struct {
@@ -148,6 +281,23 @@ type ProviderConfig struct {
 	GCPProjectID        string `mapstructure:"gcp_project_id"`
 	GCPJSONCredentials  string `mapstructure:"gcp_json_credentials"`
 	HuggingFaceToken    string `mapstructure:"hugging_face_token"`
+	// CredentialEncryptionKey is the base64 AES key (16/24/32 bytes) that
+	// encrypts rotated API keys in provider_credentials. Rotation is
+	// disabled without it.
+	CredentialEncryptionKey string `mapstructure:"credential_encryption_key"`
+
+	// Plugins are out-of-tree provider adapters keyed by name; catalog
+	// entries select one with provider "plugin:<name>".
+	Plugins map[string]PluginConfig `mapstructure:"plugins"`
+}
+
+// PluginConfig locates a provider adapter plugin. Type "rpc" forwards each
+// call as JSON to Endpoint over HTTP; type "go_plugin" loads the shared
+// object at Path, which must export NewAdapter.
+type PluginConfig struct {
+	Type     string `mapstructure:"type"`
+	Endpoint string `mapstructure:"endpoint"`
+	Path     string `mapstructure:"path"`
 }
 
 type FilesConfig struct {
@@ -160,6 +310,9 @@ type FilesConfig struct {
 	SweepBatchSize int              `mapstructure:"sweep_batch_size"`
 	S3             FilesS3Config    `mapstructure:"s3"`
 	Local          FilesLocalConfig `mapstructure:"local"`
+	// PurposeRules lists the media types /v1/files accepts per purpose;
+	// purposes without an entry (or with an empty list) accept any type.
+	PurposeRules map[string][]string `mapstructure:"purpose_rules"`
 }
 
 type FilesS3Config struct {
@@ -178,11 +331,26 @@ type AudioConfig struct {
 	MaxUploadMB int `mapstructure:"max_upload_mb"`
 }
 
+// ImageConfig controls the asynchronous image generation queue.
+type ImageConfig struct {
+	// WorkerCount is how many queued image jobs run concurrently.
+	WorkerCount int `mapstructure:"worker_count"`
+}
+
 type BatchesConfig struct {
 	MaxRequests    int           `mapstructure:"max_requests"`
 	MaxConcurrency int           `mapstructure:"max_concurrency"`
 	DefaultTTL     time.Duration `mapstructure:"default_ttl"`
 	MaxTTL         time.Duration `mapstructure:"max_ttl"`
+	// SchedulerInterval controls how often scheduled batches are checked
+	// for activation.
+	SchedulerInterval time.Duration `mapstructure:"scheduler_interval"`
+	// LockTTL is how long a worker's claim on a batch survives without a
+	// heartbeat before another worker may take the batch over.
+	LockTTL time.Duration `mapstructure:"lock_ttl"`
+	// ShutdownItemTimeout is how long shutdown waits for in-flight batch
+	// items before putting them back in the queue.
+	ShutdownItemTimeout time.Duration `mapstructure:"shutdown_item_timeout"`
 }
 
 type ModelCatalogEntry struct {
@@ -206,6 +374,135 @@ type ModelCatalogEntry struct {
 	PriceInput        float64 `mapstructure:"price_input"`
 	PriceOutput       float64 `mapstructure:"price_output"`
 	Currency          string  `mapstructure:"currency"`
+	// CachedTokenPriceRatio scales PriceInput for prompt tokens the provider
+	// served from its context cache. Zero means DefaultCachedTokenPriceRatio.
+	CachedTokenPriceRatio float64 `mapstructure:"cached_token_price_ratio"`
+	// RoutingPolicy selects how requests fan out across the alias's routes.
+	// Empty means sequential fallback in weighted order.
+	RoutingPolicy string `mapstructure:"routing_policy"`
+	// TrafficSplit runs an A/B experiment: each request to the alias is
+	// served by one of the listed aliases, chosen by weight. Listing the alias
+	// itself keeps that share on its own routes.
+	TrafficSplit []TrafficSplitEntry `mapstructure:"traffic_split"`
+	// MaxDimensions is the native embedding size. Requests may ask for fewer
+	// dimensions but never more; zero disables the check.
+	MaxDimensions int32 `mapstructure:"max_dimensions"`
+	// MirrorAlias shadows this alias: after the primary response is sent, a
+	// copy of each sampled HTTP chat request goes to MirrorAlias and its
+	// response is discarded. MirrorSampleRate (0-1) is the share mirrored;
+	// zero mirrors nothing and one mirrors every request.
+	MirrorAlias      string  `mapstructure:"mirror_alias"`
+	MirrorSampleRate float64 `mapstructure:"mirror_sample_rate"`
+	// DeprecatedAt marks the alias deprecated with a scheduled removal date.
+	// Responses then carry a Warning header built from DeprecationMessage,
+	// e.g. "use gpt-4o instead".
+	DeprecatedAt       *time.Time `mapstructure:"deprecated_at"`
+	DeprecationMessage string     `mapstructure:"deprecation_message"`
+	// SupportsVision marks models that accept image content parts. Chat
+	// requests with images sent to a model without it are redirected to
+	// FallbackVisionAlias, or rejected with 400 when that is empty.
+	SupportsVision      bool   `mapstructure:"supports_vision"`
+	FallbackVisionAlias string `mapstructure:"fallback_vision_alias"`
+	// DataResidency lists the country codes (or EU) the route keeps data
+	// in. Empty derives them from the region; see region.RegionToCountries.
+	DataResidency []string `mapstructure:"data_residency"`
+}
+
+// TrafficSplitEntry sends Weight parts of an alias's traffic to ModelAlias.
+type TrafficSplitEntry struct {
+	ModelAlias string `mapstructure:"model_alias" json:"model_alias"`
+	Weight     int    `mapstructure:"weight" json:"weight"`
+}
+
+// NormalizeMirror trims the mirror alias and rejects self-mirroring or a
+// sample rate outside 0-1.
+func NormalizeMirror(alias, mirrorAlias string, sampleRate float64) (string, error) {
+	mirrorAlias = strings.TrimSpace(mirrorAlias)
+	if mirrorAlias != "" && mirrorAlias == strings.TrimSpace(alias) {
+		return "", fmt.Errorf("mirror_alias must differ from the alias")
+	}
+	if sampleRate < 0 || sampleRate > 1 {
+		return "", fmt.Errorf("mirror_sample_rate must be between 0 and 1")
+	}
+	return mirrorAlias, nil
+}
+
+// DefaultCachedTokenPriceRatio is the share of the input price charged for
+// cached prompt tokens when a catalog entry does not set its own ratio.
+const DefaultCachedTokenPriceRatio = 0.1
+
+// NormalizeCachedTokenPriceRatio applies the default to an unset ratio and
+// rejects one outside 0-1.
+func NormalizeCachedTokenPriceRatio(ratio float64) (float64, error) {
+	if ratio < 0 || ratio > 1 {
+		return 0, fmt.Errorf("cached_token_price_ratio must be between 0 and 1")
+	}
+	if ratio == 0 {
+		return DefaultCachedTokenPriceRatio, nil
+	}
+	return ratio, nil
+}
+
+// ValidateErrorMapping rejects error_mapping statuses outside 400-599, so a
+// provider failure can never be reported to the client as a success.
+func ValidateErrorMapping(mapping map[string]int) error {
+	for key, status := range mapping {
+		if status < 400 || status > 599 {
+			return fmt.Errorf("error_mapping[%q] must be an HTTP status between 400 and 599, got %d", key, status)
+		}
+	}
+	return nil
+}
+
+// NormalizeFallbackVision trims the vision fallback alias and rejects one that
+// points back at the alias itself.
+func NormalizeFallbackVision(alias, fallbackAlias string) (string, error) {
+	fallbackAlias = strings.TrimSpace(fallbackAlias)
+	if fallbackAlias != "" && fallbackAlias == strings.TrimSpace(alias) {
+		return "", fmt.Errorf("fallback_vision_alias must differ from the alias")
+	}
+	return fallbackAlias, nil
+}
+
+// NormalizeTrafficSplit trims aliases and rejects empty or duplicate aliases
+// and non-positive weights.
+func NormalizeTrafficSplit(split []TrafficSplitEntry) ([]TrafficSplitEntry, error) {
+	if len(split) == 0 {
+		return nil, nil
+	}
+	out := make([]TrafficSplitEntry, 0, len(split))
+	seen := make(map[string]struct{}, len(split))
+	for i, entry := range split {
+		alias := strings.TrimSpace(entry.ModelAlias)
+		if alias == "" {
+			return nil, fmt.Errorf("traffic_split[%d].model_alias must be provided", i)
+		}
+		if entry.Weight <= 0 {
+			return nil, fmt.Errorf("traffic_split[%d].weight must be > 0", i)
+		}
+		if _, ok := seen[alias]; ok {
+			return nil, fmt.Errorf("traffic_split[%d].model_alias %q is listed twice", i, alias)
+		}
+		seen[alias] = struct{}{}
+		out = append(out, TrafficSplitEntry{ModelAlias: alias, Weight: entry.Weight})
+	}
+	return out, nil
+}
+
+// RoutingPolicyFastest dispatches chat requests to every healthy route at once
+// and returns whichever answers first.
+const RoutingPolicyFastest = "fastest"
+
+// NormalizeRoutingPolicy canonicalizes a catalog routing policy, rejecting
+// unknown values.
+func NormalizeRoutingPolicy(policy string) (string, error) {
+	policy = strings.ToLower(strings.TrimSpace(policy))
+	switch policy {
+	case "", RoutingPolicyFastest:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("routing_policy %q is not supported", policy)
+	}
 }
 
 func (e ModelCatalogEntry) IsEnabled() bool {
@@ -215,9 +512,170 @@ func (e ModelCatalogEntry) IsEnabled() bool {
 	return *e.Enabled
 }
 
+// APIKeyConfig controls automatic revocation of unused API keys.
+type APIKeyConfig struct {
+	// InactiveKeyTTL revokes keys unused for longer than this; zero disables
+	// the sweeper. Bootstrap keys are always exempt.
+	InactiveKeyTTL time.Duration `mapstructure:"inactive_key_ttl"`
+	// InactiveKeyWarningPeriod is how long before revocation the key owner is
+	// emailed. Keys are never revoked until a warning period has elapsed.
+	InactiveKeyWarningPeriod time.Duration `mapstructure:"inactive_key_warning_period"`
+	SweepInterval            time.Duration `mapstructure:"sweep_interval"`
+	// MaxPersonalAPIKeys caps the active personal keys a user may create
+	// through /v1/me/api-keys.
+	MaxPersonalAPIKeys int `mapstructure:"max_personal_api_keys"`
+	// FingerprintAbuse suspends keys that repeat one request fingerprint too
+	// often; see FingerprintAbuseConfig.
+	FingerprintAbuse FingerprintAbuseConfig `mapstructure:"fingerprint_abuse_suspension"`
+}
+
+// FingerprintAbuseConfig controls automatic suspension of API keys whose
+// requests look like a runaway loop: more than Threshold structurally
+// identical requests within Window. Tenants may override Threshold through
+// their settings. Each suspension is posted to Webhooks.
+type FingerprintAbuseConfig struct {
+	Enabled   bool          `mapstructure:"enabled"`
+	Threshold int           `mapstructure:"threshold"`
+	Window    time.Duration `mapstructure:"window"`
+	Interval  time.Duration `mapstructure:"interval"`
+	Webhooks  []string      `mapstructure:"webhooks"`
+}
+
 type RetentionConfig struct {
-	MetadataDays  int  `mapstructure:"metadata_days"`
-	ZeroRetention bool `mapstructure:"zero_retention"`
+	MetadataDays         int           `mapstructure:"metadata_days"`
+	ZeroRetention        bool          `mapstructure:"zero_retention"`
+	LogPayloads          bool          `mapstructure:"log_payloads"`
+	PayloadRetentionDays int           `mapstructure:"payload_retention_days"`
+	PayloadSweepInterval time.Duration `mapstructure:"payload_sweep_interval"`
+	WebhookRetentionDays int           `mapstructure:"webhook_retention_days"`
+	// Redaction masks fields of logged payloads before they are stored.
+	Redaction RedactionConfig `mapstructure:"redaction"`
+}
+
+// RedactionConfig lists the payload fields replaced with "[REDACTED]" before
+// request and response bodies are written to request_payloads. Paths are
+// JSON pointers where "*" matches every array element or object member, e.g.
+// "/messages/*/content".
+type RedactionConfig struct {
+	RedactPaths []string `mapstructure:"redact_paths"`
+}
+
+// ArchiveConfig controls export of request log rows to blob storage once
+// they pass retention.metadata_days. Archived rows are deleted afterwards.
+type ArchiveConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// StorageBackend is "s3", "gcs" (via the S3 interoperability API), or
+	// "local".
+	StorageBackend string `mapstructure:"storage_backend"`
+	Bucket         string `mapstructure:"bucket"`
+	Prefix         string `mapstructure:"prefix"`
+	Region         string `mapstructure:"region"`
+	Endpoint       string `mapstructure:"endpoint"`
+	// Directory is where the local backend writes archives.
+	Directory string `mapstructure:"directory"`
+	// PartitionBy groups rows into one archive per "month" or "day".
+	PartitionBy string `mapstructure:"partition_by"`
+	// Interval is how often the archival worker runs.
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+// BlobConfig maps the archive settings onto the blob store configuration,
+// encrypting archives with encryptionKey when it is set.
+func (a ArchiveConfig) BlobConfig(encryptionKey string) FilesConfig {
+	storage := a.StorageBackend
+	endpoint := a.Endpoint
+	region := a.Region
+	if storage == "gcs" {
+		storage = "s3"
+		if endpoint == "" {
+			endpoint = "https://storage.googleapis.com"
+		}
+		if region == "" {
+			region = "auto"
+		}
+	}
+	return FilesConfig{
+		Storage:       storage,
+		EncryptionKey: encryptionKey,
+		S3: FilesS3Config{
+			Bucket:   a.Bucket,
+			Prefix:   a.Prefix,
+			Region:   region,
+			Endpoint: endpoint,
+		},
+		Local: FilesLocalConfig{Directory: a.Directory},
+	}
+}
+
+// CacheConfig controls optional Redis response caches.
+type CacheConfig struct {
+	EmbeddingCacheEnabled bool          `mapstructure:"embedding_cache_enabled"`
+	EmbeddingCacheTTL     time.Duration `mapstructure:"embedding_cache_ttl"`
+	// IdempotencyCacheBackend stores Idempotency-Key responses in "redis",
+	// "postgres", or "redis_with_fallback" (Redis, switching to PostgreSQL
+	// when Redis fails or times out).
+	IdempotencyCacheBackend string `mapstructure:"idempotency_cache_backend"`
+}
+
+// Idempotency cache backends.
+const (
+	IdempotencyBackendRedis             = "redis"
+	IdempotencyBackendPostgres          = "postgres"
+	IdempotencyBackendRedisWithFallback = "redis_with_fallback"
+)
+
+// IdempotencyConfig controls replay of requests sent with an
+// Idempotency-Key header.
+type IdempotencyConfig struct {
+	// TTL is how long non-streaming responses are kept for replay.
+	TTL time.Duration `mapstructure:"ttl"`
+	// EnableStreaming also caches completed streaming chat responses.
+	EnableStreaming bool `mapstructure:"enable_streaming"`
+	// StreamTTL is how long streaming transcripts are kept for replay.
+	StreamTTL time.Duration `mapstructure:"stream_ttl"`
+}
+
+// DebugConfig controls sampling of /v1 request and response bodies into an
+// in-memory buffer exposed at /admin/debug/samples.
+type DebugConfig struct {
+	// SamplingEnabled is set only from the DEBUG_SAMPLING_ENABLED environment
+	// variable, so a shared config file cannot turn body capture on.
+	SamplingEnabled bool `mapstructure:"-"`
+	// SampleRate is the fraction of requests captured (0.01 = 1%).
+	SampleRate float64 `mapstructure:"sample_rate"`
+	// BufferSize is how many of the most recent samples are kept.
+	BufferSize int `mapstructure:"buffer_size"`
+	// MaxBodyBytes truncates each captured request and response body.
+	MaxBodyBytes int `mapstructure:"max_body_bytes"`
+}
+
+// DeprecationConfig controls the sweeper that retires deprecated models.
+type DeprecationConfig struct {
+	// AutoDisable disables catalog entries once their deprecated_at passes.
+	AutoDisable bool `mapstructure:"auto_disable"`
+	// SweepInterval is how often the sweeper checks for expired models.
+	SweepInterval time.Duration `mapstructure:"sweep_interval"`
+}
+
+// RetryConfig controls how often the executor retries a route after a
+// transient provider error (429, 502, 503, 504).
+type RetryConfig struct {
+	// MaxRetries is the number of extra attempts per route; zero disables
+	// retries.
+	MaxRetries int `mapstructure:"max_retries"`
+	// InitialBackoff is the base wait before the first retry. Each retry
+	// doubles it, with jitter.
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"`
+}
+
+// LoggingConfig configures the process-wide slog handler.
+type LoggingConfig struct {
+	// Format is "text" (logfmt-style key=value) or "json".
+	Format string `mapstructure:"format"`
+	// Level is the minimum level: debug, info, warn, or error.
+	Level string `mapstructure:"level"`
+	// AddSource adds the calling file and line to each record.
+	AddSource bool `mapstructure:"add_source"`
 }
 
 type ObservabilityConfig struct {
@@ -239,6 +697,8 @@ type BootstrapConfig struct {
 	Memberships   []BootstrapMembership   `mapstructure:"memberships"`
 	TenantLimits  []BootstrapTenantLimit  `mapstructure:"tenant_limits"`
 	TenantBudgets []BootstrapTenantBudget `mapstructure:"tenant_budgets"`
+
+	TenantSystemPrompts []BootstrapTenantSystemPrompt `mapstructure:"tenant_system_prompts"`
 }
 
 type BootstrapTenant struct {
@@ -289,10 +749,17 @@ type BootstrapTenantBudget struct {
 	AlertCooldown    time.Duration `mapstructure:"alert_cooldown"`
 }
 
+type BootstrapTenantSystemPrompt struct {
+	Tenant  string `mapstructure:"tenant"`
+	Content string `mapstructure:"content"`
+	Mode    string `mapstructure:"mode"`
+}
+
 type BootstrapRateLimit struct {
-	RequestsPerMinute int `mapstructure:"requests_per_minute"`
-	TokensPerMinute   int `mapstructure:"tokens_per_minute"`
-	ParallelRequests  int `mapstructure:"parallel_requests"`
+	RequestsPerMinute  int           `mapstructure:"requests_per_minute"`
+	TokensPerMinute    int           `mapstructure:"tokens_per_minute"`
+	ParallelRequests   int           `mapstructure:"parallel_requests"`
+	ParallelRequestTTL time.Duration `mapstructure:"parallel_request_ttl"`
 }
 
 // Options controls the config loader behavior.
@@ -341,9 +808,15 @@ func Load(opts Options) (*Config, error) {
 	v.AutomaticEnv()
 
 	var cfg Config
-	if err := v.Unmarshal(&cfg, viper.DecodeHook(timeStringToDurationHook())); err != nil {
+	if err := v.Unmarshal(&cfg, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		timeStringToDurationHook(),
+		stringToTimeHook(),
+		mapstructure.StringToSliceHookFunc(","),
+	))); err != nil {
 		return nil, fmt.Errorf("unmarshal config: %w", err)
 	}
+	cfg.File = v.ConfigFileUsed()
+	cfg.Debug.SamplingEnabled, _ = strconv.ParseBool(os.Getenv("DEBUG_SAMPLING_ENABLED"))
 
 	if err := cfg.Validate(); err != nil {
 		return nil, err
@@ -379,13 +852,45 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("missing required configuration: %s", strings.Join(missing, ", "))
 	}
 
+	if c.RateLimits.ParallelRequestTTL < time.Second {
+		return fmt.Errorf("rate_limits.parallel_request_ttl must be at least 1s")
+	}
+
 	if c.Budgets.DefaultUSD <= 0 {
 		return fmt.Errorf("budgets.default_usd must be > 0")
 	}
 	if c.Budgets.WarningThresholdPerc <= 0 || c.Budgets.WarningThresholdPerc >= 1 {
 		return fmt.Errorf("budgets.warning_threshold_perc must be between 0 and 1 exclusive")
 	}
+	if c.Budgets.EstimateCompletionBufferPerc < 0 || c.Budgets.EstimateCompletionBufferPerc > 1 {
+		return fmt.Errorf("budgets.estimate_completion_buffer_perc must be between 0 and 1")
+	}
+	if c.Budgets.MaxPersonalBudgetUSD <= 0 {
+		return fmt.Errorf("budgets.max_personal_budget_usd must be > 0")
+	}
+	if c.Budgets.PersonalDefaultUSD < 0 || c.Budgets.PersonalDefaultUSD > c.Budgets.MaxPersonalBudgetUSD {
+		return fmt.Errorf("budgets.personal_default_usd must be between 0 and budgets.max_personal_budget_usd")
+	}
+	budgetCurrency, err := currency.Normalize(c.Budgets.Currency)
+	if err != nil {
+		return fmt.Errorf("budgets.currency: %w", err)
+	}
+	c.Budgets.Currency = budgetCurrency
 	c.Budgets.RefreshSchedule = NormalizeBudgetRefreshSchedule(c.Budgets.RefreshSchedule)
+	for i := range c.Budgets.AlertEscalationLevels {
+		level := &c.Budgets.AlertEscalationLevels[i]
+		if level.ThresholdPerc <= 0 {
+			return fmt.Errorf("budgets.alert_escalation_levels[%d].threshold_perc must be > 0", i)
+		}
+		level.Emails = normalizeStringSlice(level.Emails)
+		level.Webhooks = normalizeStringSlice(level.Webhooks)
+		if len(level.Emails) == 0 && len(level.Webhooks) == 0 {
+			return fmt.Errorf("budgets.alert_escalation_levels[%d] requires at least one email or webhook", i)
+		}
+	}
+	sort.SliceStable(c.Budgets.AlertEscalationLevels, func(i, j int) bool {
+		return c.Budgets.AlertEscalationLevels[i].ThresholdPerc < c.Budgets.AlertEscalationLevels[j].ThresholdPerc
+	})
 	c.Budgets.Alert.Emails = normalizeStringSlice(c.Budgets.Alert.Emails)
 	c.Budgets.Alert.Webhooks = normalizeStringSlice(c.Budgets.Alert.Webhooks)
 	if c.Budgets.Alert.Cooldown <= 0 {
@@ -409,12 +914,25 @@ func (c *Config) Validate() error {
 			smtp.ConnectTimeout = 5 * time.Second
 		}
 	}
+	if c.Admin.SuspensionNotification.Enabled && strings.TrimSpace(smtp.Host) == "" {
+		return fmt.Errorf("admin.suspension_notification requires budgets.alert.smtp.host")
+	}
 	if c.Budgets.Alert.Webhook.Timeout <= 0 {
 		c.Budgets.Alert.Webhook.Timeout = 5 * time.Second
 	}
 	if c.Budgets.Alert.Webhook.MaxRetries <= 0 {
 		c.Budgets.Alert.Webhook.MaxRetries = 3
 	}
+	usage := &c.Budgets.Alert.Usage
+	if usage.RequestSpikeThreshold < 0 || usage.TokenSpikeThreshold < 0 {
+		return fmt.Errorf("budgets.alert.usage spike thresholds must be >= 0")
+	}
+	if usage.LookbackWindow <= 0 {
+		usage.LookbackWindow = 24 * time.Hour
+	}
+	if usage.LookbackWindow < time.Hour {
+		return fmt.Errorf("budgets.alert.usage.lookback_window must be at least 1h")
+	}
 	if c.Database.RunMigrations && c.Database.MigrationsDir == "" {
 		return fmt.Errorf("database.migrations_dir must be provided when run_migrations is true")
 	}
@@ -434,15 +952,57 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("redis.pool_size must be >= 0")
 	}
 
+	if err := c.Server.CORS.validate("server.cors", true); err != nil {
+		return err
+	}
+	if err := c.Server.AdminCORS.validate("server.admin_cors", false); err != nil {
+		return err
+	}
+	if err := c.Server.ProxyAuth.validate(); err != nil {
+		return err
+	}
+	if err := c.Providers.validate(); err != nil {
+		return err
+	}
 	if err := c.Files.validate(); err != nil {
 		return err
 	}
 	if err := c.Audio.validate(); err != nil {
 		return err
 	}
+	if err := c.Image.validate(); err != nil {
+		return err
+	}
 	if err := c.Batches.validate(); err != nil {
 		return err
 	}
+	if err := c.Retention.validate(); err != nil {
+		return err
+	}
+	if err := c.APIKeys.validate(); err != nil {
+		return err
+	}
+	if err := c.Archive.validate(); err != nil {
+		return err
+	}
+	if err := c.Cache.validate(); err != nil {
+		return err
+	}
+	if err := c.Idempotency.validate(); err != nil {
+		return err
+	}
+	if err := c.Debug.validate(); err != nil {
+		return err
+	}
+	if err := c.Deprecation.validate(); err != nil {
+		return err
+	}
+	if err := c.Retry.validate(); err != nil {
+		return err
+	}
+	if err := c.Logging.validate(); err != nil {
+		return err
+	}
 
 	if err := c.Admin.validate(); err != nil {
 		return err
@@ -467,9 +1027,42 @@ func (c *Config) Validate() error {
 		if entry.PriceInput < 0 || entry.PriceOutput < 0 {
 			return fmt.Errorf("model_catalog[%d] price_input and price_output must be >= 0", i)
 		}
-		if entry.Currency == "" {
-			c.ModelCatalog[i].Currency = "USD"
+		if entry.MaxDimensions < 0 {
+			return fmt.Errorf("model_catalog[%d].max_dimensions must be >= 0", i)
+		}
+		ratio, err := NormalizeCachedTokenPriceRatio(entry.CachedTokenPriceRatio)
+		if err != nil {
+			return fmt.Errorf("model_catalog[%d].%w", i, err)
+		}
+		c.ModelCatalog[i].CachedTokenPriceRatio = ratio
+		mirror, err := NormalizeMirror(entry.Alias, entry.MirrorAlias, entry.MirrorSampleRate)
+		if err != nil {
+			return fmt.Errorf("model_catalog[%d].%w", i, err)
+		}
+		c.ModelCatalog[i].MirrorAlias = mirror
+		fallbackVision, err := NormalizeFallbackVision(entry.Alias, entry.FallbackVisionAlias)
+		if err != nil {
+			return fmt.Errorf("model_catalog[%d].%w", i, err)
+		}
+		c.ModelCatalog[i].FallbackVisionAlias = fallbackVision
+		if err := ValidateErrorMapping(entry.ProviderOverrides.ErrorMapping); err != nil {
+			return fmt.Errorf("model_catalog[%d].%w", i, err)
+		}
+		catalogCurrency, err := currency.Normalize(entry.Currency)
+		if err != nil {
+			return fmt.Errorf("model_catalog[%d].currency: %w", i, err)
 		}
+		c.ModelCatalog[i].Currency = catalogCurrency
+		policy, err := NormalizeRoutingPolicy(entry.RoutingPolicy)
+		if err != nil {
+			return fmt.Errorf("model_catalog[%d].%w", i, err)
+		}
+		c.ModelCatalog[i].RoutingPolicy = policy
+		split, err := NormalizeTrafficSplit(entry.TrafficSplit)
+		if err != nil {
+			return fmt.Errorf("model_catalog[%d].%w", i, err)
+		}
+		c.ModelCatalog[i].TrafficSplit = split
 	}
 
 	if err := c.Bootstrap.validate(); err != nil {
@@ -492,11 +1085,18 @@ func (a *AdminConfig) validate() error {
 	if a.Session.CookieName == "" {
 		return fmt.Errorf("admin.session.cookie_name must be provided")
 	}
+	if a.InvitationTTL <= 0 {
+		return fmt.Errorf("admin.invitation_ttl must be > 0")
+	}
+	if strings.TrimSpace(a.SuspensionNotification.TemplateSubject) == "" {
+		a.SuspensionNotification.TemplateSubject = defaultSuspensionSubject
+	}
 
 	localEnabled := a.Local.Enabled
 	oidcEnabled := a.OIDC.Enabled
-	if !localEnabled && !oidcEnabled {
-		return fmt.Errorf("at least one admin authentication method must be enabled (local or oidc)")
+	samlEnabled := a.SAML.Enabled
+	if !localEnabled && !oidcEnabled && !samlEnabled {
+		return fmt.Errorf("at least one admin authentication method must be enabled (local, oidc, or saml)")
 	}
 
 	if oidcEnabled {
@@ -517,6 +1117,148 @@ func (a *AdminConfig) validate() error {
 		}
 	}
 
+	if samlEnabled {
+		if a.SAML.MetadataURL == "" {
+			return fmt.Errorf("admin.saml.metadata_url must be provided when SAML is enabled")
+		}
+		if a.SAML.EntityID == "" {
+			return fmt.Errorf("admin.saml.entity_id must be provided when SAML is enabled")
+		}
+		if a.SAML.ACSURL == "" {
+			return fmt.Errorf("admin.saml.acs_url must be provided when SAML is enabled")
+		}
+		if a.SAML.SignRequests && (a.SAML.SPCertFile == "" || a.SAML.SPKeyFile == "") {
+			return fmt.Errorf("admin.saml.sp_cert_file and admin.saml.sp_key_file are required when sign_requests is true")
+		}
+		if a.SAML.HTTPTimeout <= 0 {
+			return fmt.Errorf("admin.saml.http_timeout must be > 0")
+		}
+	}
+
+	return nil
+}
+
+// validate requires at least one origin when CORS is enabled and rejects
+// origins that are not scheme://host[:port]. The wildcard "*" is allowed only
+// when allowWildcard is set.
+func (c *CORSConfig) validate(key string, allowWildcard bool) error {
+	if !c.Enabled {
+		return nil
+	}
+	origins := make([]string, 0, len(c.AllowedOrigins))
+	for _, origin := range c.AllowedOrigins {
+		origin = strings.TrimRight(strings.TrimSpace(origin), "/")
+		if origin == "" {
+			continue
+		}
+		if origin == "*" {
+			if !allowWildcard {
+				return fmt.Errorf("%s.allowed_origins cannot be \"*\"", key)
+			}
+		} else if u, err := url.Parse(origin); err != nil || u.Scheme == "" || u.Host == "" || u.Path != "" {
+			return fmt.Errorf("%s.allowed_origins: %q must be scheme://host[:port]", key, origin)
+		}
+		origins = append(origins, origin)
+	}
+	if len(origins) == 0 {
+		return fmt.Errorf("%s.allowed_origins must list at least one origin when CORS is enabled", key)
+	}
+	c.AllowedOrigins = origins
+	if c.MaxAgeSec < 0 {
+		return fmt.Errorf("%s.max_age_sec must be >= 0", key)
+	}
+	return nil
+}
+
+func (p *ProxyAuthConfig) validate() error {
+	if !p.Enabled {
+		return nil
+	}
+	p.UserIDHeader = strings.TrimSpace(p.UserIDHeader)
+	if p.UserIDHeader == "" {
+		return fmt.Errorf("server.proxy_auth.user_id_header is required when proxy auth is enabled")
+	}
+	proxies := make([]string, 0, len(p.TrustedProxies))
+	for _, raw := range p.TrustedProxies {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		prefix, err := parseTrustedProxy(raw)
+		if err != nil {
+			return fmt.Errorf("server.proxy_auth.trusted_proxies: %q is not a CIDR or IP address", raw)
+		}
+		proxies = append(proxies, prefix.String())
+	}
+	if len(proxies) == 0 {
+		return fmt.Errorf("server.proxy_auth.trusted_proxies must list at least one CIDR when proxy auth is enabled")
+	}
+	p.TrustedProxies = proxies
+	return nil
+}
+
+// TrustedPrefixes parses TrustedProxies, skipping invalid entries.
+func (p ProxyAuthConfig) TrustedPrefixes() []netip.Prefix {
+	prefixes := make([]netip.Prefix, 0, len(p.TrustedProxies))
+	for _, raw := range p.TrustedProxies {
+		if prefix, err := parseTrustedProxy(strings.TrimSpace(raw)); err == nil {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes
+}
+
+func parseTrustedProxy(raw string) (netip.Prefix, error) {
+	if strings.Contains(raw, "/") {
+		prefix, err := netip.ParsePrefix(raw)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		// Client addresses are unmapped before matching, so an IPv4-mapped
+		// prefix such as ::ffff:10.0.0.0/104 must become 10.0.0.0/8.
+		if addr := prefix.Addr(); addr.Is4In6() && prefix.Bits() >= 96 {
+			prefix = netip.PrefixFrom(addr.Unmap(), prefix.Bits()-96)
+		}
+		return prefix.Masked(), nil
+	}
+	addr, err := netip.ParseAddr(raw)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()), nil
+}
+
+func (p *ProviderConfig) validate() error {
+	if raw := strings.TrimSpace(p.CredentialEncryptionKey); raw != "" {
+		key, err := base64.StdEncoding.DecodeString(raw)
+		if err != nil {
+			return fmt.Errorf("providers.credential_encryption_key must be base64: %w", err)
+		}
+		switch len(key) {
+		case 16, 24, 32:
+		default:
+			return fmt.Errorf("providers.credential_encryption_key must be 16/24/32 bytes after decoding")
+		}
+	}
+	for name, plugin := range p.Plugins {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("providers.plugins name must be provided")
+		}
+		plugin.Type = strings.ToLower(strings.TrimSpace(plugin.Type))
+		switch plugin.Type {
+		case "rpc":
+			if strings.TrimSpace(plugin.Endpoint) == "" {
+				return fmt.Errorf("providers.plugins.%s.endpoint must be provided for rpc plugins", name)
+			}
+		case "go_plugin":
+			if strings.TrimSpace(plugin.Path) == "" {
+				return fmt.Errorf("providers.plugins.%s.path must be provided for go_plugin plugins", name)
+			}
+		default:
+			return fmt.Errorf("providers.plugins.%s.type must be rpc or go_plugin", name)
+		}
+		p.Plugins[name] = plugin
+	}
 	return nil
 }
 
@@ -542,6 +1284,22 @@ func (f *FilesConfig) validate() error {
 	if f.SweepBatchSize <= 0 {
 		f.SweepBatchSize = 200
 	}
+	rules := make(map[string][]string, len(f.PurposeRules))
+	for purpose, types := range f.PurposeRules {
+		normalized := make([]string, 0, len(types))
+		for _, contentType := range types {
+			contentType = strings.ToLower(strings.TrimSpace(contentType))
+			if contentType == "" {
+				continue
+			}
+			if !strings.Contains(contentType, "/") {
+				return fmt.Errorf("files.purpose_rules.%s: %q is not a media type", purpose, contentType)
+			}
+			normalized = append(normalized, contentType)
+		}
+		rules[strings.ToLower(strings.TrimSpace(purpose))] = normalized
+	}
+	f.PurposeRules = rules
 	return nil
 }
 
@@ -552,6 +1310,16 @@ func (a *AudioConfig) validate() error {
 	return nil
 }
 
+func (i *ImageConfig) validate() error {
+	if i.WorkerCount < 0 {
+		return fmt.Errorf("image.worker_count must be >= 0")
+	}
+	if i.WorkerCount == 0 {
+		i.WorkerCount = 2
+	}
+	return nil
+}
+
 func (b *BatchesConfig) validate() error {
 	if b.MaxRequests <= 0 {
 		return fmt.Errorf("batches.max_requests must be > 0")
@@ -568,6 +1336,198 @@ func (b *BatchesConfig) validate() error {
 	if b.DefaultTTL > b.MaxTTL {
 		return fmt.Errorf("batches.default_ttl cannot exceed batches.max_ttl")
 	}
+	if b.SchedulerInterval <= 0 {
+		b.SchedulerInterval = 30 * time.Second
+	}
+	if b.LockTTL <= 0 {
+		b.LockTTL = 5 * time.Minute
+	}
+	if b.ShutdownItemTimeout <= 0 {
+		b.ShutdownItemTimeout = 30 * time.Second
+	}
+	return nil
+}
+
+func (r *RetentionConfig) validate() error {
+	if r.PayloadRetentionDays < 0 {
+		return fmt.Errorf("retention.payload_retention_days must be >= 0")
+	}
+	if r.PayloadRetentionDays == 0 {
+		r.PayloadRetentionDays = 7
+	}
+	if r.PayloadSweepInterval <= 0 {
+		r.PayloadSweepInterval = time.Hour
+	}
+	if r.WebhookRetentionDays < 0 {
+		return fmt.Errorf("retention.webhook_retention_days must be >= 0")
+	}
+	if r.WebhookRetentionDays == 0 {
+		r.WebhookRetentionDays = 30
+	}
+	paths := make([]string, 0, len(r.Redaction.RedactPaths))
+	for _, path := range r.Redaction.RedactPaths {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		if err := redact.ValidatePath(path); err != nil {
+			return fmt.Errorf("retention.redaction.redact_paths: %w", err)
+		}
+		paths = append(paths, path)
+	}
+	r.Redaction.RedactPaths = paths
+	return nil
+}
+
+func (a *APIKeyConfig) validate() error {
+	if a.InactiveKeyTTL < 0 {
+		return fmt.Errorf("api_keys.inactive_key_ttl must be >= 0")
+	}
+	if a.InactiveKeyWarningPeriod < 0 {
+		return fmt.Errorf("api_keys.inactive_key_warning_period must be >= 0")
+	}
+	if a.InactiveKeyTTL > 0 && a.InactiveKeyWarningPeriod >= a.InactiveKeyTTL {
+		return fmt.Errorf("api_keys.inactive_key_warning_period must be shorter than api_keys.inactive_key_ttl")
+	}
+	if a.SweepInterval <= 0 {
+		a.SweepInterval = time.Hour
+	}
+	if a.MaxPersonalAPIKeys <= 0 {
+		return fmt.Errorf("api_keys.max_personal_api_keys must be > 0")
+	}
+	if a.FingerprintAbuse.Enabled {
+		if a.FingerprintAbuse.Threshold <= 0 {
+			return fmt.Errorf("api_keys.fingerprint_abuse_suspension.threshold must be > 0")
+		}
+		if a.FingerprintAbuse.Window <= 0 {
+			return fmt.Errorf("api_keys.fingerprint_abuse_suspension.window must be > 0")
+		}
+	}
+	if a.FingerprintAbuse.Interval <= 0 {
+		a.FingerprintAbuse.Interval = 5 * time.Minute
+	}
+	return nil
+}
+
+func (c *CacheConfig) validate() error {
+	if c.EmbeddingCacheTTL < 0 {
+		return fmt.Errorf("cache.embedding_cache_ttl must be >= 0")
+	}
+	if c.EmbeddingCacheTTL == 0 {
+		c.EmbeddingCacheTTL = 24 * time.Hour
+	}
+	switch backend := strings.ToLower(strings.TrimSpace(c.IdempotencyCacheBackend)); backend {
+	case "":
+		c.IdempotencyCacheBackend = IdempotencyBackendRedis
+	case IdempotencyBackendRedis, IdempotencyBackendPostgres, IdempotencyBackendRedisWithFallback:
+		c.IdempotencyCacheBackend = backend
+	default:
+		return fmt.Errorf("cache.idempotency_cache_backend must be redis, postgres, or redis_with_fallback")
+	}
+	return nil
+}
+
+func (i *IdempotencyConfig) validate() error {
+	if i.TTL < 0 {
+		return fmt.Errorf("idempotency.ttl must be >= 0")
+	}
+	if i.StreamTTL < 0 {
+		return fmt.Errorf("idempotency.stream_ttl must be >= 0")
+	}
+	if i.TTL == 0 {
+		i.TTL = 30 * time.Minute
+	}
+	if i.StreamTTL == 0 {
+		i.StreamTTL = 10 * time.Minute
+	}
+	return nil
+}
+
+func (d *DebugConfig) validate() error {
+	if d.SampleRate < 0 || d.SampleRate > 1 {
+		return fmt.Errorf("debug.sample_rate must be between 0 and 1")
+	}
+	if d.BufferSize < 0 {
+		return fmt.Errorf("debug.buffer_size must be >= 0")
+	}
+	if d.MaxBodyBytes < 0 {
+		return fmt.Errorf("debug.max_body_bytes must be >= 0")
+	}
+	if d.BufferSize == 0 {
+		d.BufferSize = 200
+	}
+	if d.MaxBodyBytes == 0 {
+		d.MaxBodyBytes = 64 * 1024
+	}
+	return nil
+}
+
+func (a *ArchiveConfig) validate() error {
+	a.StorageBackend = strings.ToLower(strings.TrimSpace(a.StorageBackend))
+	a.PartitionBy = strings.ToLower(strings.TrimSpace(a.PartitionBy))
+	if a.PartitionBy == "" {
+		a.PartitionBy = "month"
+	}
+	if a.Interval < 0 {
+		return fmt.Errorf("archive.interval must be >= 0")
+	}
+	if a.Interval == 0 {
+		a.Interval = 7 * 24 * time.Hour
+	}
+	if !a.Enabled {
+		return nil
+	}
+	switch a.StorageBackend {
+	case "s3", "gcs":
+		if strings.TrimSpace(a.Bucket) == "" {
+			return fmt.Errorf("archive.bucket must be provided for %s storage", a.StorageBackend)
+		}
+	case "local":
+		if strings.TrimSpace(a.Directory) == "" {
+			a.Directory = "./data/archives"
+		}
+	default:
+		return fmt.Errorf("archive.storage_backend must be s3, gcs, or local")
+	}
+	if a.PartitionBy != "month" && a.PartitionBy != "day" {
+		return fmt.Errorf("archive.partition_by must be month or day")
+	}
+	return nil
+}
+
+func (d *DeprecationConfig) validate() error {
+	if d.SweepInterval < 0 {
+		return fmt.Errorf("deprecation.sweep_interval must be >= 0")
+	}
+	if d.SweepInterval == 0 {
+		d.SweepInterval = time.Hour
+	}
+	return nil
+}
+
+func (r *RetryConfig) validate() error {
+	if r.MaxRetries < 0 {
+		return fmt.Errorf("retry.max_retries must be >= 0")
+	}
+	if r.InitialBackoff < 0 {
+		return fmt.Errorf("retry.initial_backoff must be >= 0")
+	}
+	return nil
+}
+
+func (l *LoggingConfig) validate() error {
+	l.Format = strings.ToLower(strings.TrimSpace(l.Format))
+	l.Level = strings.ToLower(strings.TrimSpace(l.Level))
+	switch l.Format {
+	case "", "text", "json":
+	default:
+		return fmt.Errorf("logging.format must be text or json")
+	}
+	switch l.Level {
+	case "", "debug", "info", "warn", "error":
+	default:
+		return fmt.Errorf("logging.level must be debug, info, warn, or error")
+	}
 	return nil
 }
 
@@ -580,15 +1540,36 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("server.provider_timeout", "280s")
 	v.SetDefault("server.read_header_timeout", "5s")
 	v.SetDefault("server.graceful_shutdown_delay", "5s")
+	v.SetDefault("server.graceful_stream_drain_timeout", "30s")
+	v.SetDefault("server.grpc_listen_addr", "")
+	v.SetDefault("server.watch_config", false)
+	v.SetDefault("server.cors.enabled", false)
+	v.SetDefault("server.cors.allowed_origins", []string{})
+	v.SetDefault("server.cors.allowed_methods", []string{"GET", "POST", "DELETE", "OPTIONS"})
+	v.SetDefault("server.cors.allowed_headers", []string{"Authorization", "Content-Type", "Idempotency-Key", "X-Request-Tags", "X-Request-Metadata", "X-Estimate-Cost", "X-Request-Trace"})
+	v.SetDefault("server.cors.max_age_sec", 600)
+	v.SetDefault("server.admin_cors.enabled", false)
+	v.SetDefault("server.admin_cors.allowed_origins", []string{})
+	v.SetDefault("server.admin_cors.allowed_methods", []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"})
+	v.SetDefault("server.admin_cors.allowed_headers", []string{"Authorization", "Content-Type"})
+	v.SetDefault("server.admin_cors.max_age_sec", 600)
+	v.SetDefault("server.proxy_auth.enabled", false)
+	v.SetDefault("server.proxy_auth.trusted_proxies", []string{})
+	v.SetDefault("server.proxy_auth.user_id_header", "X-Forwarded-User")
 
 	v.SetDefault("rate_limits.default_tokens_per_minute", 1_000_000)
 	v.SetDefault("rate_limits.default_requests_per_minute", 1_000)
 	v.SetDefault("rate_limits.default_parallel_requests_key", 10)
 	v.SetDefault("rate_limits.default_parallel_requests_tenant", 100)
+	v.SetDefault("rate_limits.parallel_request_ttl", time.Minute)
 
 	v.SetDefault("budgets.default_usd", 100.0)
 	v.SetDefault("budgets.warning_threshold_perc", 0.8)
 	v.SetDefault("budgets.refresh_schedule", "calendar_month")
+	v.SetDefault("budgets.estimate_completion_buffer_perc", 0.1)
+	v.SetDefault("budgets.max_personal_budget_usd", 100.0)
+	v.SetDefault("budgets.personal_default_usd", 0.0)
+	v.SetDefault("budgets.currency", "USD")
 	v.SetDefault("budgets.alert.enabled", true)
 	v.SetDefault("budgets.alert.emails", []string{})
 	v.SetDefault("budgets.alert.webhooks", []string{})
@@ -599,9 +1580,51 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("budgets.alert.smtp.connect_timeout", "5s")
 	v.SetDefault("budgets.alert.webhook.timeout", "5s")
 	v.SetDefault("budgets.alert.webhook.max_retries", 3)
+	v.SetDefault("budgets.alert.webhook.signing_secret", "")
+	v.SetDefault("budgets.alert.usage.request_spike_threshold", 0)
+	v.SetDefault("budgets.alert.usage.token_spike_threshold", 0)
+	v.SetDefault("budgets.alert.usage.lookback_window", "24h")
 
 	v.SetDefault("retention.metadata_days", 30)
 	v.SetDefault("retention.zero_retention", false)
+	v.SetDefault("retention.log_payloads", false)
+	v.SetDefault("retention.payload_retention_days", 7)
+	v.SetDefault("retention.payload_sweep_interval", "1h")
+	v.SetDefault("retention.webhook_retention_days", 30)
+	v.SetDefault("retention.redaction.redact_paths", []string{})
+	v.SetDefault("archive.enabled", false)
+	v.SetDefault("archive.storage_backend", "local")
+	v.SetDefault("archive.partition_by", "month")
+	v.SetDefault("archive.interval", "168h")
+	v.SetDefault("api_keys.inactive_key_ttl", "0s")
+	v.SetDefault("api_keys.inactive_key_warning_period", "168h")
+	v.SetDefault("api_keys.sweep_interval", "1h")
+	v.SetDefault("api_keys.max_personal_api_keys", 5)
+	v.SetDefault("api_keys.fingerprint_abuse_suspension.enabled", false)
+	v.SetDefault("api_keys.fingerprint_abuse_suspension.threshold", 1000)
+	v.SetDefault("api_keys.fingerprint_abuse_suspension.window", "1h")
+	v.SetDefault("api_keys.fingerprint_abuse_suspension.interval", "5m")
+
+	v.SetDefault("cache.embedding_cache_enabled", false)
+	v.SetDefault("cache.embedding_cache_ttl", "24h")
+	v.SetDefault("cache.idempotency_cache_backend", "redis")
+
+	v.SetDefault("idempotency.ttl", "30m")
+	v.SetDefault("idempotency.enable_streaming", false)
+	v.SetDefault("idempotency.stream_ttl", "10m")
+
+	v.SetDefault("debug.sample_rate", 0.01)
+	v.SetDefault("debug.buffer_size", 200)
+	v.SetDefault("debug.max_body_bytes", 65536)
+
+	v.SetDefault("deprecation.auto_disable", false)
+	v.SetDefault("deprecation.sweep_interval", "1h")
+
+	v.SetDefault("retry.max_retries", 2)
+	v.SetDefault("retry.initial_backoff", "200ms")
+	v.SetDefault("logging.format", "text")
+	v.SetDefault("logging.level", "info")
+	v.SetDefault("logging.add_source", false)
 
 	v.SetDefault("observability.enable_otlp", true)
 	v.SetDefault("observability.enable_metrics", true)
@@ -629,23 +1652,39 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("files.max_ttl", "720h")
 	v.SetDefault("files.sweep_interval", "15m")
 	v.SetDefault("files.sweep_batch_size", 200)
+	v.SetDefault("files.purpose_rules", map[string][]string{
+		"batch":  {"application/json", "application/x-ndjson", "text/plain"},
+		"vision": {"image/png", "image/jpeg", "image/webp"},
+	})
 	v.SetDefault("files.local.directory", "./data/files")
 
 	v.SetDefault("audio.max_upload_mb", 50)
+	v.SetDefault("image.worker_count", 2)
 
 	v.SetDefault("batches.max_requests", 5000)
 	v.SetDefault("batches.max_concurrency", 50)
 	v.SetDefault("batches.default_ttl", "168h")
 	v.SetDefault("batches.max_ttl", "720h")
+	v.SetDefault("batches.scheduler_interval", "30s")
+	v.SetDefault("batches.lock_ttl", "5m")
+	v.SetDefault("batches.shutdown_item_timeout", "30s")
 
 	v.SetDefault("admin.session.access_token_ttl", "15m")
 	v.SetDefault("admin.session.refresh_token_ttl", "24h")
 	v.SetDefault("admin.session.cookie_name", "og_admin_session")
+	v.SetDefault("admin.invitation_ttl", "168h")
+	v.SetDefault("admin.suspension_notification.enabled", false)
+	v.SetDefault("admin.suspension_notification.template_subject", defaultSuspensionSubject)
 	v.SetDefault("admin.local.enabled", true)
 	v.SetDefault("admin.oidc.enabled", false)
 	v.SetDefault("admin.oidc.scopes", []string{"openid", "email", "profile"})
 	v.SetDefault("admin.oidc.http_timeout", "5s")
+	v.SetDefault("admin.saml.enabled", false)
+	v.SetDefault("admin.saml.http_timeout", "5s")
+	v.SetDefault("admin.saml.email_attribute", "email")
+	v.SetDefault("admin.saml.name_attribute", "name")
 	v.SetDefault("providers.azure_openai_version", "2024-07-01-preview")
+	v.SetDefault("providers.credential_encryption_key", "")
 }
 
 func (b *BootstrapConfig) validate() error {
@@ -730,6 +1769,23 @@ func (b *BootstrapConfig) validate() error {
 			return fmt.Errorf("bootstrap.tenant_budgets[%d].alert_cooldown must be >= 0", i)
 		}
 	}
+	for i := range b.TenantSystemPrompts {
+		prompt := &b.TenantSystemPrompts[i]
+		if strings.TrimSpace(prompt.Tenant) == "" {
+			return fmt.Errorf("bootstrap.tenant_system_prompts[%d].tenant must be provided", i)
+		}
+		if strings.TrimSpace(prompt.Content) == "" {
+			return fmt.Errorf("bootstrap.tenant_system_prompts[%d].content must be provided", i)
+		}
+		prompt.Mode = strings.ToLower(strings.TrimSpace(prompt.Mode))
+		switch prompt.Mode {
+		case "":
+			prompt.Mode = "prepend"
+		case "prepend", "append", "replace":
+		default:
+			return fmt.Errorf("bootstrap.tenant_system_prompts[%d].mode must be prepend, append, or replace", i)
+		}
+	}
 	return nil
 }
 
@@ -743,9 +1799,32 @@ func validateRateLimit(limit BootstrapRateLimit) error {
 	if limit.ParallelRequests < 0 {
 		return fmt.Errorf("parallel_requests must be >= 0")
 	}
+	if limit.ParallelRequestTTL != 0 && limit.ParallelRequestTTL < time.Second {
+		return fmt.Errorf("parallel_request_ttl must be 0 or at least 1s")
+	}
 	return nil
 }
 
+// Budget enforcement modes: hard rejects requests once the budget is spent,
+// soft lets them through and only flags the overage.
+const (
+	BudgetEnforcementHard = "hard"
+	BudgetEnforcementSoft = "soft"
+)
+
+// NormalizeBudgetEnforcementMode lower-cases mode, treating blank as hard.
+// ok is false for unknown modes.
+func NormalizeBudgetEnforcementMode(mode string) (string, bool) {
+	switch mode = strings.ToLower(strings.TrimSpace(mode)); mode {
+	case "":
+		return BudgetEnforcementHard, true
+	case BudgetEnforcementHard, BudgetEnforcementSoft:
+		return mode, true
+	default:
+		return "", false
+	}
+}
+
 func NormalizeBudgetRefreshSchedule(schedule string) string {
 	schedule = strings.ToLower(strings.TrimSpace(schedule))
 	if schedule == "" {
@@ -795,6 +1874,28 @@ func normalizeStringSlice(values []string) []string {
 	return clean
 }
 
+// stringToTimeHook parses RFC 3339 timestamps or plain YYYY-MM-DD dates.
+func stringToTimeHook() mapstructure.DecodeHookFunc {
+	return func(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+		if to != reflect.TypeOf(time.Time{}) {
+			return data, nil
+		}
+		raw, ok := data.(string)
+		if !ok {
+			return data, nil
+		}
+		raw = strings.TrimSpace(raw)
+		if t, err := time.Parse(time.RFC3339, raw); err == nil {
+			return t, nil
+		}
+		t, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode %q into time.Time: %w", raw, err)
+		}
+		return t, nil
+	}
+}
+
 func timeStringToDurationHook() mapstructure.DecodeHookFunc {
 	return func(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
 		if to != reflect.TypeOf(time.Duration(0)) {
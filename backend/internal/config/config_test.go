@@ -0,0 +1,108 @@
+package config
+
+import "testing"
+
+func TestLoggingConfigValidate(t *testing.T) {
+	valid := LoggingConfig{Format: " JSON ", Level: "Warn"}
+	if err := valid.validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if valid.Format != "json" || valid.Level != "warn" {
+		t.Fatalf("expected normalized values, got %+v", valid)
+	}
+	for _, cfg := range []LoggingConfig{{Format: "logfmt"}, {Level: "trace"}} {
+		if err := cfg.validate(); err == nil {
+			t.Fatalf("expected %+v to be rejected", cfg)
+		}
+	}
+}
+
+func TestCORSConfigValidate(t *testing.T) {
+	if err := (&CORSConfig{}).validate("server.cors", true); err != nil {
+		t.Fatalf("disabled CORS needs no origins: %v", err)
+	}
+	valid := CORSConfig{Enabled: true, AllowedOrigins: []string{" https://app.example.com/ ", ""}}
+	if err := valid.validate("server.cors", true); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if len(valid.AllowedOrigins) != 1 || valid.AllowedOrigins[0] != "https://app.example.com" {
+		t.Fatalf("expected trimmed origins, got %v", valid.AllowedOrigins)
+	}
+	if err := (&CORSConfig{Enabled: true, AllowedOrigins: []string{"*"}}).validate("server.cors", true); err != nil {
+		t.Fatalf("wildcard should be allowed on the public API: %v", err)
+	}
+	for _, cfg := range []CORSConfig{
+		{Enabled: true},
+		{Enabled: true, AllowedOrigins: []string{"app.example.com"}},
+		{Enabled: true, AllowedOrigins: []string{"https://app.example.com/path"}},
+		{Enabled: true, AllowedOrigins: []string{"https://app.example.com"}, MaxAgeSec: -1},
+	} {
+		if err := cfg.validate("server.cors", true); err == nil {
+			t.Fatalf("expected %+v to be rejected", cfg)
+		}
+	}
+	if err := (&CORSConfig{Enabled: true, AllowedOrigins: []string{"*"}}).validate("server.admin_cors", false); err == nil {
+		t.Fatal("admin CORS must reject the wildcard")
+	}
+}
+
+func TestProxyAuthConfigValidate(t *testing.T) {
+	if err := (&ProxyAuthConfig{}).validate(); err != nil {
+		t.Fatalf("disabled proxy auth needs no proxies: %v", err)
+	}
+	valid := ProxyAuthConfig{Enabled: true, UserIDHeader: " X-Forwarded-User ", TrustedProxies: []string{" 10.1.2.3/8 ", "192.168.0.5", "", "::ffff:172.16.5.0/108"}}
+	if err := valid.validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if valid.UserIDHeader != "X-Forwarded-User" || len(valid.TrustedProxies) != 3 ||
+		valid.TrustedProxies[0] != "10.0.0.0/8" || valid.TrustedProxies[1] != "192.168.0.5/32" ||
+		valid.TrustedProxies[2] != "172.16.0.0/12" {
+		t.Fatalf("expected normalized config, got %+v", valid)
+	}
+	for _, cfg := range []ProxyAuthConfig{
+		{Enabled: true, UserIDHeader: "X-Forwarded-User"},
+		{Enabled: true, UserIDHeader: "X-Forwarded-User", TrustedProxies: []string{"proxy.internal"}},
+		{Enabled: true, TrustedProxies: []string{"10.0.0.0/8"}},
+	} {
+		if err := cfg.validate(); err == nil {
+			t.Fatalf("expected %+v to be rejected", cfg)
+		}
+	}
+}
+
+func TestNormalizeBudgetEnforcementMode(t *testing.T) {
+	for input, want := range map[string]string{"": BudgetEnforcementHard, " Soft ": BudgetEnforcementSoft, "HARD": BudgetEnforcementHard} {
+		got, ok := NormalizeBudgetEnforcementMode(input)
+		if !ok || got != want {
+			t.Fatalf("NormalizeBudgetEnforcementMode(%q) = %q, %v; want %q", input, got, ok, want)
+		}
+	}
+	if _, ok := NormalizeBudgetEnforcementMode("warn"); ok {
+		t.Fatal("expected unknown mode to be rejected")
+	}
+}
+
+func TestRetentionConfigValidateRedactPaths(t *testing.T) {
+	valid := RetentionConfig{Redaction: RedactionConfig{RedactPaths: []string{" /messages/*/content ", ""}}}
+	if err := valid.validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if len(valid.Redaction.RedactPaths) != 1 || valid.Redaction.RedactPaths[0] != "/messages/*/content" {
+		t.Fatalf("expected trimmed paths, got %q", valid.Redaction.RedactPaths)
+	}
+	invalid := RetentionConfig{Redaction: RedactionConfig{RedactPaths: []string{"messages.content"}}}
+	if err := invalid.validate(); err == nil {
+		t.Fatal("expected a non-pointer path to be rejected")
+	}
+}
+
+func TestValidateErrorMapping(t *testing.T) {
+	if err := ValidateErrorMapping(map[string]int{"ThrottlingException": 429, "529": 503}); err != nil {
+		t.Fatalf("expected valid mapping, got %v", err)
+	}
+	for _, status := range []int{0, 200, 302, 399, 600} {
+		if err := ValidateErrorMapping(map[string]int{"overloaded": status}); err == nil {
+			t.Fatalf("expected status %d to be rejected", status)
+		}
+	}
+}
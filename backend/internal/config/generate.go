@@ -0,0 +1,4 @@
+package config
+
+// Regenerate deploy/router.schema.json after changing the Config structs.
+//go:generate go run ../../cmd/configschema -out ../../../deploy/router.schema.json
@@ -8,6 +8,10 @@ type ProviderOverrides struct {
 	OpenAI           *OpenAIProviderConfig           `mapstructure:"openai" json:"openai,omitempty"`
 	OpenAICompatible *OpenAICompatibleProviderConfig `mapstructure:"openai_compatible" json:"openai_compatible,omitempty"`
 	Anthropic        *AnthropicProviderConfig        `mapstructure:"anthropic" json:"anthropic,omitempty"`
+	// ErrorMapping maps provider error codes, types, statuses, or message
+	// fragments (e.g. "ThrottlingException") to the HTTP status the gateway
+	// returns; see providers.MapProviderError.
+	ErrorMapping map[string]int `mapstructure:"error_mapping" json:"error_mapping,omitempty"`
 }
 
 type AzureProviderConfig struct {
@@ -24,6 +28,9 @@ type VertexProviderConfig struct {
 	Publisher         string `mapstructure:"vertex_publisher" json:"vertex_publisher"`
 	CredentialsJSON   string `mapstructure:"gcp_credentials_json" json:"gcp_credentials_json"`
 	CredentialsFormat string `mapstructure:"gcp_credentials_format" json:"gcp_credentials_format"`
+	// APIKeys authenticates with Vertex API keys (x-goog-api-key) instead of
+	// service-account credentials, rotating round-robin when several are set.
+	APIKeys []string `mapstructure:"api_keys" json:"api_keys,omitempty"`
 }
 
 type BedrockProviderConfig struct {
@@ -41,20 +48,26 @@ type BedrockProviderConfig struct {
 	Profile          string `mapstructure:"aws_profile" json:"aws_profile"`
 }
 
+// OpenAIProviderConfig overrides the OpenAI adapter. APIKeys, here and on the
+// OpenAI-compatible and Anthropic blocks, adds keys to a round-robin pool
+// alongside the resolved APIKey.
 type OpenAIProviderConfig struct {
-	APIKey       string `mapstructure:"api_key" json:"api_key"`
-	Organization string `mapstructure:"openai_organization" json:"openai_organization"`
-	BaseURL      string `mapstructure:"base_url" json:"base_url"`
+	APIKey       string   `mapstructure:"api_key" json:"api_key"`
+	APIKeys      []string `mapstructure:"api_keys" json:"api_keys,omitempty"`
+	Organization string   `mapstructure:"openai_organization" json:"openai_organization"`
+	BaseURL      string   `mapstructure:"base_url" json:"base_url"`
 }
 
 type OpenAICompatibleProviderConfig struct {
-	BaseURL      string `mapstructure:"base_url" json:"base_url"`
-	APIKey       string `mapstructure:"api_key" json:"api_key"`
-	Organization string `mapstructure:"openai_organization" json:"openai_organization"`
+	BaseURL      string   `mapstructure:"base_url" json:"base_url"`
+	APIKey       string   `mapstructure:"api_key" json:"api_key"`
+	APIKeys      []string `mapstructure:"api_keys" json:"api_keys,omitempty"`
+	Organization string   `mapstructure:"openai_organization" json:"openai_organization"`
 }
 
 type AnthropicProviderConfig struct {
-	APIKey  string `mapstructure:"api_key" json:"api_key"`
-	BaseURL string `mapstructure:"base_url" json:"base_url"`
-	Version string `mapstructure:"version" json:"version"`
+	APIKey  string   `mapstructure:"api_key" json:"api_key"`
+	APIKeys []string `mapstructure:"api_keys" json:"api_keys,omitempty"`
+	BaseURL string   `mapstructure:"base_url" json:"base_url"`
+	Version string   `mapstructure:"version" json:"version"`
 }
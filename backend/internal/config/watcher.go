@@ -0,0 +1,106 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultWatchDebounce coalesces the burst of events editors emit while
+// saving a file into a single change notification.
+const DefaultWatchDebounce = 500 * time.Millisecond
+
+// Watcher reports changes to the config file on disk.
+type Watcher struct {
+	Debounce time.Duration
+}
+
+// NewWatcher returns a Watcher using DefaultWatchDebounce.
+func NewWatcher() *Watcher {
+	return &Watcher{Debounce: DefaultWatchDebounce}
+}
+
+// Watch emits on the returned channel once the file at path has been written,
+// created, or replaced and then left alone for the debounce interval. The
+// parent directory is watched so atomic rename-based saves are detected. The
+// channel is closed when ctx is cancelled.
+func (w *Watcher) Watch(ctx context.Context, path string) (<-chan struct{}, error) {
+	if path == "" {
+		return nil, fmt.Errorf("watch config: empty path")
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("watch config: %w", err)
+	}
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("watch config: %w", err)
+	}
+	if err := fsw.Add(filepath.Dir(abs)); err != nil {
+		fsw.Close()
+		return nil, fmt.Errorf("watch config: %w", err)
+	}
+
+	debounce := w.Debounce
+	if debounce <= 0 {
+		debounce = DefaultWatchDebounce
+	}
+	changes := make(chan struct{}, 1)
+	go func() {
+		defer close(changes)
+		defer fsw.Close()
+		timer := time.NewTimer(debounce)
+		timer.Stop()
+		defer timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-fsw.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != abs || !event.Has(fsnotify.Write|fsnotify.Create|fsnotify.Rename) {
+					continue
+				}
+				timer.Reset(debounce)
+			case _, ok := <-fsw.Errors:
+				if !ok {
+					return
+				}
+			case <-timer.C:
+				select {
+				case changes <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+	return changes, nil
+}
+
+// RestartRequired lists the settings that differ between old and next but
+// only take effect after a restart.
+func RestartRequired(old, next *Config) []string {
+	if old == nil || next == nil {
+		return nil
+	}
+	var changed []string
+	if old.Database.URL != next.Database.URL {
+		changed = append(changed, "database.url")
+	}
+	if old.Redis.URL != next.Redis.URL {
+		changed = append(changed, "redis.url")
+	}
+	if old.Admin.Session.JWTSecret != next.Admin.Session.JWTSecret {
+		changed = append(changed, "admin.session.jwt_secret")
+	}
+	if !reflect.DeepEqual(old.Server, next.Server) {
+		changed = append(changed, "server")
+	}
+	return changed
+}
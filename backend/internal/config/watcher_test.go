@@ -0,0 +1,71 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatcherDebouncesWrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "router.yaml")
+	if err := os.WriteFile(path, []byte("model_catalog: []\n"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	watcher := &Watcher{Debounce: 100 * time.Millisecond}
+	changes, err := watcher.Watch(ctx, path)
+	if err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := os.WriteFile(path, []byte("model_catalog: []\n# edit\n"), 0o600); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(filepath.Dir(path), "other.yaml"), []byte("x: 1\n"), 0o600); err != nil {
+		t.Fatalf("write sibling: %v", err)
+	}
+
+	select {
+	case <-changes:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a change notification")
+	}
+	select {
+	case <-changes:
+		t.Fatal("burst of writes should produce a single notification")
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	cancel()
+	select {
+	case _, ok := <-changes:
+		if ok {
+			t.Fatal("expected channel to close after cancel")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel not closed after cancel")
+	}
+}
+
+func TestRestartRequired(t *testing.T) {
+	old := &Config{}
+	old.Database.URL = "postgres://a"
+	old.Redis.URL = "redis://a"
+	next := *old
+	next.ModelCatalog = []ModelCatalogEntry{{Alias: "gpt-4o"}}
+	if got := RestartRequired(old, &next); len(got) != 0 {
+		t.Fatalf("catalog change should not need a restart, got %v", got)
+	}
+	next.Database.URL = "postgres://b"
+	next.Admin.Session.JWTSecret = "rotated"
+	got := RestartRequired(old, &next)
+	if len(got) != 2 || got[0] != "database.url" || got[1] != "admin.session.jwt_secret" {
+		t.Fatalf("unexpected restart keys %v", got)
+	}
+}
@@ -0,0 +1,50 @@
+// Package currency validates ISO 4217 currency codes.
+package currency
+
+import (
+	"errors"
+	"strings"
+)
+
+// Base is the currency costs are recorded in.
+const Base = "USD"
+
+var ErrUnknownCurrency = errors.New("unknown ISO 4217 currency code")
+
+// codes lists the active ISO 4217 currency codes.
+var codes = map[string]struct{}{}
+
+func init() {
+	for _, code := range strings.Fields(`
+		AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND
+		BOB BRL BSD BTN BWP BYN BZD CAD CDF CHF CLP CNY COP CRC CUP CVE CZK DJF
+		DKK DOP DZD EGP ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD GNF GTQ GYD HKD
+		HNL HTG HUF IDR ILS INR IQD IRR ISK JMD JOD JPY KES KGS KHR KMF KPW KRW
+		KWD KYD KZT LAK LBP LKR LRD LSL LYD MAD MDL MGA MKD MMK MNT MOP MRU MUR
+		MVR MWK MXN MYR MZN NAD NGN NIO NOK NPR NZD OMR PAB PEN PGK PHP PKR PLN
+		PYG QAR RON RSD RUB RWF SAR SBD SCR SDG SEK SGD SHP SLE SOS SRD SSP STN
+		SVC SYP SZL THB TJS TMT TND TOP TRY TTD TWD TZS UAH UGX USD UYU UZS VES
+		VND VUV WST XAF XCD XOF XPF YER ZAR ZMW ZWL`) {
+		codes[code] = struct{}{}
+	}
+}
+
+// IsKnown reports whether code is an active ISO 4217 code. It expects an
+// upper-case code; see Normalize.
+func IsKnown(code string) bool {
+	_, ok := codes[code]
+	return ok
+}
+
+// Normalize trims and upper-cases code, defaulting to Base when empty, and
+// rejects codes that are not ISO 4217.
+func Normalize(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return Base, nil
+	}
+	if !IsKnown(code) {
+		return "", ErrUnknownCurrency
+	}
+	return code, nil
+}
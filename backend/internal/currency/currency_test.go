@@ -0,0 +1,21 @@
+package currency
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	cases := map[string]string{"": "USD", " eur ": "EUR", "GBP": "GBP", "jpy": "JPY"}
+	for in, want := range cases {
+		got, err := Normalize(in)
+		if err != nil || got != want {
+			t.Fatalf("Normalize(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"XYZ", "EURO", "US"} {
+		if _, err := Normalize(in); !errors.Is(err, ErrUnknownCurrency) {
+			t.Fatalf("Normalize(%q) expected ErrUnknownCurrency, got %v", in, err)
+		}
+	}
+}
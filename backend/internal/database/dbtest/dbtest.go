@@ -0,0 +1,52 @@
+// Package dbtest connects tests to a real Postgres database. Tests that use
+// it are skipped unless ROUTER_TEST_DATABASE_URL points at a database the
+// tests may migrate and write to.
+package dbtest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/database"
+)
+
+// URLEnv names the environment variable holding the test database URL.
+const URLEnv = "ROUTER_TEST_DATABASE_URL"
+
+// Open migrates the test database to the latest schema and returns a pool
+// that is closed when t finishes. It skips t when URLEnv is unset.
+func Open(t testing.TB) *pgxpool.Pool {
+	t.Helper()
+	url := os.Getenv(URLEnv)
+	if url == "" {
+		t.Skipf("%s not set; skipping database test", URLEnv)
+	}
+	ctx := context.Background()
+	cfg := config.DatabaseConfig{
+		URL:           url,
+		RunMigrations: true,
+		MigrationsDir: migrationsDir(),
+	}
+	if err := database.RunMigrations(ctx, cfg); err != nil {
+		t.Fatalf("migrate test database: %v", err)
+	}
+	pool, err := database.Connect(ctx, cfg)
+	if err != nil {
+		t.Fatalf("connect test database: %v", err)
+	}
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+// migrationsDir locates backend/migrations relative to this file, so tests
+// find it whatever package directory they run from.
+func migrationsDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "..", "migrations")
+}
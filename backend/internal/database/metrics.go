@@ -0,0 +1,186 @@
+package database
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+)
+
+// PoolStats is a point-in-time view of the Postgres connection pool.
+// AcquireCount and AcquireDuration are cumulative since the pool started.
+type PoolStats struct {
+	TotalConns      int32
+	IdleConns       int32
+	AcquiredConns   int32
+	MaxConns        int32
+	AcquireCount    int64
+	AcquireDuration time.Duration
+}
+
+// PoolStatsSource reports Postgres pool statistics.
+type PoolStatsSource interface {
+	PoolStats() PoolStats
+}
+
+// RedisStatsSource reports Redis pool statistics; *redis.Client satisfies it.
+type RedisStatsSource interface {
+	PoolStats() *redis.PoolStats
+}
+
+type pgxPoolStats struct {
+	pool *pgxpool.Pool
+}
+
+// PgxPoolStats adapts a pgx pool to PoolStatsSource.
+func PgxPoolStats(pool *pgxpool.Pool) PoolStatsSource {
+	return pgxPoolStats{pool: pool}
+}
+
+func (p pgxPoolStats) PoolStats() PoolStats {
+	if p.pool == nil {
+		return PoolStats{}
+	}
+	stat := p.pool.Stat()
+	return PoolStats{
+		TotalConns:      stat.TotalConns(),
+		IdleConns:       stat.IdleConns(),
+		AcquiredConns:   stat.AcquiredConns(),
+		MaxConns:        stat.MaxConns(),
+		AcquireCount:    stat.AcquireCount(),
+		AcquireDuration: stat.AcquireDuration(),
+	}
+}
+
+const (
+	dbMetricsInterval = 10 * time.Second
+	// acquireWaitWindow is how many samples feed the p95 gauge; at the
+	// default interval that covers the last five minutes.
+	acquireWaitWindow = 30
+)
+
+// DBMetricsCollector samples the Postgres and Redis connection pools and
+// publishes them as Prometheus gauges. pgx only exposes cumulative acquire
+// totals, so db_pool_acquire_wait_ms_p95 is the 95th percentile of the mean
+// acquire wait per sample over a rolling window rather than per acquire.
+type DBMetricsCollector struct {
+	pool  PoolStatsSource
+	redis RedisStatsSource
+
+	mu           sync.Mutex
+	lastCount    int64
+	lastDuration time.Duration
+	waits        []float64
+
+	dbTotal       prometheus.Gauge
+	dbIdle        prometheus.Gauge
+	dbAcquired    prometheus.Gauge
+	dbMax         prometheus.Gauge
+	dbWaitP95     prometheus.Gauge
+	redisTotal    prometheus.Gauge
+	redisIdle     prometheus.Gauge
+	redisStale    prometheus.Gauge
+	redisHits     prometheus.Gauge
+	redisMisses   prometheus.Gauge
+	redisTimeouts prometheus.Gauge
+}
+
+// NewDBMetricsCollector registers the pool gauges with reg. Either source may
+// be nil, in which case its gauges stay at zero.
+func NewDBMetricsCollector(reg prometheus.Registerer, pool PoolStatsSource, redisStats RedisStatsSource) (*DBMetricsCollector, error) {
+	gauge := func(name, help string) prometheus.Gauge {
+		return prometheus.NewGauge(prometheus.GaugeOpts{Name: name, Help: help})
+	}
+	c := &DBMetricsCollector{
+		pool:          pool,
+		redis:         redisStats,
+		dbTotal:       gauge("db_pool_total_conns", "Open Postgres connections."),
+		dbIdle:        gauge("db_pool_idle_conns", "Idle Postgres connections."),
+		dbAcquired:    gauge("db_pool_acquired_conns", "Postgres connections currently checked out."),
+		dbMax:         gauge("db_pool_max_conns", "Maximum Postgres connections allowed by the pool."),
+		dbWaitP95:     gauge("db_pool_acquire_wait_ms_p95", "95th percentile of mean Postgres acquire wait per sample, in milliseconds."),
+		redisTotal:    gauge("redis_pool_total_conns", "Open Redis connections."),
+		redisIdle:     gauge("redis_pool_idle_conns", "Idle Redis connections."),
+		redisStale:    gauge("redis_pool_stale_conns", "Stale Redis connections removed from the pool."),
+		redisHits:     gauge("redis_pool_hits", "Times a free Redis connection was found in the pool."),
+		redisMisses:   gauge("redis_pool_misses", "Times a Redis connection had to be dialed."),
+		redisTimeouts: gauge("redis_pool_timeouts", "Times waiting for a Redis connection timed out."),
+	}
+	if reg != nil {
+		for _, collector := range []prometheus.Collector{
+			c.dbTotal, c.dbIdle, c.dbAcquired, c.dbMax, c.dbWaitP95,
+			c.redisTotal, c.redisIdle, c.redisStale, c.redisHits, c.redisMisses, c.redisTimeouts,
+		} {
+			if err := reg.Register(collector); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return c, nil
+}
+
+// Run samples the pools immediately and then every 10 seconds until ctx ends.
+func (c *DBMetricsCollector) Run(ctx context.Context) {
+	ticker := time.NewTicker(dbMetricsInterval)
+	defer ticker.Stop()
+	c.Collect()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.Collect()
+		}
+	}
+}
+
+// Collect takes one sample of both pools and updates the gauges.
+func (c *DBMetricsCollector) Collect() {
+	if c.pool != nil {
+		stats := c.pool.PoolStats()
+		c.dbTotal.Set(float64(stats.TotalConns))
+		c.dbIdle.Set(float64(stats.IdleConns))
+		c.dbAcquired.Set(float64(stats.AcquiredConns))
+		c.dbMax.Set(float64(stats.MaxConns))
+		c.dbWaitP95.Set(c.observeAcquire(stats.AcquireCount, stats.AcquireDuration))
+	}
+	if c.redis != nil {
+		if stats := c.redis.PoolStats(); stats != nil {
+			c.redisTotal.Set(float64(stats.TotalConns))
+			c.redisIdle.Set(float64(stats.IdleConns))
+			c.redisStale.Set(float64(stats.StaleConns))
+			c.redisHits.Set(float64(stats.Hits))
+			c.redisMisses.Set(float64(stats.Misses))
+			c.redisTimeouts.Set(float64(stats.Timeouts))
+		}
+	}
+}
+
+// observeAcquire records the mean wait of acquires since the previous sample
+// and returns the p95 of the window in milliseconds.
+func (c *DBMetricsCollector) observeAcquire(count int64, total time.Duration) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	deltaCount := count - c.lastCount
+	deltaDuration := total - c.lastDuration
+	c.lastCount = count
+	c.lastDuration = total
+	if deltaCount > 0 && deltaDuration >= 0 {
+		mean := float64(deltaDuration) / float64(deltaCount) / float64(time.Millisecond)
+		c.waits = append(c.waits, mean)
+		if len(c.waits) > acquireWaitWindow {
+			c.waits = c.waits[len(c.waits)-acquireWaitWindow:]
+		}
+	}
+	if len(c.waits) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), c.waits...)
+	sort.Float64s(sorted)
+	idx := (len(sorted)*95+99)/100 - 1
+	return sorted[idx]
+}
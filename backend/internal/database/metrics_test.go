@@ -0,0 +1,98 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
+)
+
+type fakePool struct {
+	stats PoolStats
+}
+
+func (f *fakePool) PoolStats() PoolStats { return f.stats }
+
+type fakeRedis struct {
+	stats redis.PoolStats
+}
+
+func (f *fakeRedis) PoolStats() *redis.PoolStats { return &f.stats }
+
+func TestDBMetricsCollectorSetsGauges(t *testing.T) {
+	pool := &fakePool{stats: PoolStats{
+		TotalConns:      8,
+		IdleConns:       3,
+		AcquiredConns:   5,
+		MaxConns:        20,
+		AcquireCount:    10,
+		AcquireDuration: 40 * time.Millisecond,
+	}}
+	rdb := &fakeRedis{stats: redis.PoolStats{Hits: 100, Misses: 4, Timeouts: 1, TotalConns: 6, IdleConns: 2, StaleConns: 7}}
+
+	collector, err := NewDBMetricsCollector(prometheus.NewRegistry(), pool, rdb)
+	if err != nil {
+		t.Fatalf("new collector: %v", err)
+	}
+	collector.Collect()
+
+	cases := map[string]struct {
+		gauge prometheus.Gauge
+		want  float64
+	}{
+		"db_pool_total_conns":         {collector.dbTotal, 8},
+		"db_pool_idle_conns":          {collector.dbIdle, 3},
+		"db_pool_acquired_conns":      {collector.dbAcquired, 5},
+		"db_pool_max_conns":           {collector.dbMax, 20},
+		"db_pool_acquire_wait_ms_p95": {collector.dbWaitP95, 4},
+		"redis_pool_total_conns":      {collector.redisTotal, 6},
+		"redis_pool_idle_conns":       {collector.redisIdle, 2},
+		"redis_pool_stale_conns":      {collector.redisStale, 7},
+		"redis_pool_hits":             {collector.redisHits, 100},
+		"redis_pool_misses":           {collector.redisMisses, 4},
+		"redis_pool_timeouts":         {collector.redisTimeouts, 1},
+	}
+	for name, tc := range cases {
+		if got := testutil.ToFloat64(tc.gauge); got != tc.want {
+			t.Errorf("%s = %v, want %v", name, got, tc.want)
+		}
+	}
+}
+
+func TestDBMetricsCollectorAcquireWaitP95UsesDeltas(t *testing.T) {
+	pool := &fakePool{}
+	collector, err := NewDBMetricsCollector(nil, pool, nil)
+	if err != nil {
+		t.Fatalf("new collector: %v", err)
+	}
+
+	// 19 quiet samples at 1ms mean wait, then one slow sample at 50ms.
+	for i := 1; i <= 19; i++ {
+		pool.stats.AcquireCount += 10
+		pool.stats.AcquireDuration += 10 * time.Millisecond
+		collector.Collect()
+	}
+	if got := testutil.ToFloat64(collector.dbWaitP95); got != 1 {
+		t.Fatalf("p95 before spike = %v, want 1", got)
+	}
+	pool.stats.AcquireCount += 2
+	pool.stats.AcquireDuration += 100 * time.Millisecond
+	collector.Collect()
+	if got := testutil.ToFloat64(collector.dbWaitP95); got != 1 {
+		t.Fatalf("p95 with one outlier in 20 = %v, want 1", got)
+	}
+	pool.stats.AcquireCount += 2
+	pool.stats.AcquireDuration += 100 * time.Millisecond
+	collector.Collect()
+	if got := testutil.ToFloat64(collector.dbWaitP95); got != 50 {
+		t.Fatalf("p95 with two outliers in 21 = %v, want 50", got)
+	}
+
+	// A sample with no new acquires keeps the previous window.
+	collector.Collect()
+	if got := testutil.ToFloat64(collector.dbWaitP95); got != 50 {
+		t.Fatalf("p95 after idle sample = %v, want 50", got)
+	}
+}
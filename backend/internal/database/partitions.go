@@ -0,0 +1,160 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// RequestsTable is the monthly range-partitioned request log.
+	RequestsTable = "requests"
+	// partitionLead is how far ahead next month's partition is created.
+	partitionLead   = 7 * 24 * time.Hour
+	partitionLayout = "requests_y2006m01"
+)
+
+// PartitionManager keeps the requests table's monthly partitions in step with
+// the calendar: it creates each month's partition a week before it starts and
+// drops partitions whose whole month is older than the retention window.
+type PartitionManager struct {
+	pool      *pgxpool.Pool
+	retention time.Duration
+	// keepNonEmpty leaves expired partitions that still hold rows in place,
+	// so the usage archiver can export them first.
+	keepNonEmpty bool
+}
+
+// NewPartitionManager builds a manager. metadataDays <= 0 keeps every
+// partition.
+func NewPartitionManager(pool *pgxpool.Pool, metadataDays int) *PartitionManager {
+	var retention time.Duration
+	if metadataDays > 0 {
+		retention = time.Duration(metadataDays) * 24 * time.Hour
+	}
+	return &PartitionManager{pool: pool, retention: retention}
+}
+
+// KeepNonEmpty makes Maintain drop expired partitions only once they are
+// empty. Enable it when archival deletes expired rows after exporting them.
+func (m *PartitionManager) KeepNonEmpty() {
+	m.keepNonEmpty = true
+}
+
+// PartitionName returns the partition holding rows for the month containing t.
+func PartitionName(t time.Time) string {
+	return monthStart(t).Format(partitionLayout)
+}
+
+// partitionMonth parses a name produced by PartitionName.
+func partitionMonth(name string) (time.Time, bool) {
+	t, err := time.Parse(partitionLayout, name)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t.UTC(), true
+}
+
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// PartitionsDue lists the month starts whose partitions must exist at now:
+// the current month and, within a week of its start, the next one.
+func PartitionsDue(now time.Time) []time.Time {
+	current := monthStart(now)
+	months := []time.Time{current}
+	if next := monthStart(now.Add(partitionLead)); next.After(current) {
+		months = append(months, next)
+	}
+	return months
+}
+
+// partitionExpired reports whether every row in the named partition is older
+// than now minus retention.
+func partitionExpired(name string, now time.Time, retention time.Duration) bool {
+	if retention <= 0 {
+		return false
+	}
+	month, ok := partitionMonth(name)
+	if !ok {
+		return false
+	}
+	end := month.AddDate(0, 1, 0)
+	return !end.After(now.Add(-retention))
+}
+
+// Maintain creates due partitions and drops expired ones, returning the
+// names of each.
+func (m *PartitionManager) Maintain(ctx context.Context, now time.Time) (created, dropped []string, err error) {
+	if m == nil || m.pool == nil {
+		return nil, nil, nil
+	}
+	existing, err := m.listPartitions(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	have := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		have[name] = true
+	}
+
+	for _, month := range PartitionsDue(now) {
+		name := PartitionName(month)
+		if have[name] {
+			continue
+		}
+		stmt := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s PARTITION OF %s FOR VALUES FROM ('%s') TO ('%s')",
+			pgx.Identifier{name}.Sanitize(),
+			pgx.Identifier{RequestsTable}.Sanitize(),
+			month.Format(time.RFC3339),
+			month.AddDate(0, 1, 0).Format(time.RFC3339),
+		)
+		if _, err := m.pool.Exec(ctx, stmt); err != nil {
+			return created, dropped, fmt.Errorf("create partition %s: %w", name, err)
+		}
+		created = append(created, name)
+	}
+
+	for _, name := range existing {
+		if !partitionExpired(name, now, m.retention) {
+			continue
+		}
+		ident := pgx.Identifier{name}.Sanitize()
+		if m.keepNonEmpty {
+			var hasRows bool
+			if err := m.pool.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM "+ident+")").Scan(&hasRows); err != nil {
+				return created, dropped, fmt.Errorf("check partition %s: %w", name, err)
+			}
+			if hasRows {
+				continue
+			}
+		}
+		detach := fmt.Sprintf("ALTER TABLE %s DETACH PARTITION %s", pgx.Identifier{RequestsTable}.Sanitize(), ident)
+		if _, err := m.pool.Exec(ctx, detach); err != nil {
+			return created, dropped, fmt.Errorf("detach partition %s: %w", name, err)
+		}
+		if _, err := m.pool.Exec(ctx, "DROP TABLE IF EXISTS "+ident); err != nil {
+			return created, dropped, fmt.Errorf("drop partition %s: %w", name, err)
+		}
+		dropped = append(dropped, name)
+	}
+	return created, dropped, nil
+}
+
+func (m *PartitionManager) listPartitions(ctx context.Context) ([]string, error) {
+	rows, err := m.pool.Query(ctx, `
+SELECT child.relname
+FROM pg_inherits i
+JOIN pg_class parent ON parent.oid = i.inhparent
+JOIN pg_class child ON child.oid = i.inhrelid
+WHERE parent.relname = $1`, RequestsTable)
+	if err != nil {
+		return nil, fmt.Errorf("list partitions: %w", err)
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
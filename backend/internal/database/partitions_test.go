@@ -0,0 +1,60 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPartitionName(t *testing.T) {
+	cases := map[string]time.Time{
+		"requests_y2025m11": time.Date(2025, 11, 17, 13, 0, 0, 0, time.UTC),
+		"requests_y2025m12": time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC),
+		"requests_y2026m01": time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		// Month boundaries are UTC regardless of the input zone.
+		"requests_y2026m03": time.Date(2026, 2, 28, 20, 0, 0, 0, time.FixedZone("EST", -5*3600)),
+	}
+	for want, ts := range cases {
+		if got := PartitionName(ts); got != want {
+			t.Errorf("PartitionName(%s) = %s, want %s", ts, got, want)
+		}
+	}
+}
+
+func TestPartitionsDueCreatesNextMonthAWeekAhead(t *testing.T) {
+	names := func(now time.Time) []string {
+		var out []string
+		for _, month := range PartitionsDue(now) {
+			out = append(out, PartitionName(month))
+		}
+		return out
+	}
+
+	if got, want := names(time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC)), []string{"requests_y2025m11"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("mid-month = %v, want %v", got, want)
+	}
+	if got, want := names(time.Date(2025, 11, 24, 0, 0, 1, 0, time.UTC)), []string{"requests_y2025m11", "requests_y2025m12"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("seven days out = %v, want %v", got, want)
+	}
+	if got, want := names(time.Date(2025, 12, 28, 0, 0, 0, 0, time.UTC)), []string{"requests_y2025m12", "requests_y2026m01"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("year rollover = %v, want %v", got, want)
+	}
+}
+
+func TestPartitionExpired(t *testing.T) {
+	now := time.Date(2025, 11, 17, 0, 0, 0, 0, time.UTC)
+	retention := 30 * 24 * time.Hour
+
+	if !partitionExpired("requests_y2025m09", now, retention) {
+		t.Fatal("september should be expired with 30 day retention in mid november")
+	}
+	if partitionExpired("requests_y2025m10", now, retention) {
+		t.Fatal("october still holds rows inside the retention window")
+	}
+	if partitionExpired("requests_y2025m09", now, 0) {
+		t.Fatal("zero retention keeps every partition")
+	}
+	if partitionExpired("requests_default", now, retention) {
+		t.Fatal("default partition must never be dropped")
+	}
+}
@@ -28,16 +28,18 @@ const getAPIKeyRateLimit = `-- name: GetAPIKeyRateLimit :one
 SELECT api_key_id,
        requests_per_minute,
        tokens_per_minute,
-       parallel_requests
+       parallel_requests,
+       parallel_request_ttl_seconds
 FROM api_key_rate_limits
 WHERE api_key_id = $1
 `
 
 type GetAPIKeyRateLimitRow struct {
-	ApiKeyID          pgtype.UUID `json:"api_key_id"`
-	RequestsPerMinute int32       `json:"requests_per_minute"`
-	TokensPerMinute   int32       `json:"tokens_per_minute"`
-	ParallelRequests  int32       `json:"parallel_requests"`
+	ApiKeyID                  pgtype.UUID `json:"api_key_id"`
+	RequestsPerMinute         int32       `json:"requests_per_minute"`
+	TokensPerMinute           int32       `json:"tokens_per_minute"`
+	ParallelRequests          int32       `json:"parallel_requests"`
+	ParallelRequestTtlSeconds int32       `json:"parallel_request_ttl_seconds"`
 }
 
 func (q *Queries) GetAPIKeyRateLimit(ctx context.Context, apiKeyID pgtype.UUID) (GetAPIKeyRateLimitRow, error) {
@@ -48,6 +50,7 @@ func (q *Queries) GetAPIKeyRateLimit(ctx context.Context, apiKeyID pgtype.UUID)
 		&i.RequestsPerMinute,
 		&i.TokensPerMinute,
 		&i.ParallelRequests,
+		&i.ParallelRequestTtlSeconds,
 	)
 	return i, err
 }
@@ -57,17 +60,19 @@ SELECT ak.prefix AS prefix,
        r.api_key_id,
        r.requests_per_minute,
        r.tokens_per_minute,
-       r.parallel_requests
+       r.parallel_requests,
+       r.parallel_request_ttl_seconds
 FROM api_key_rate_limits r
 JOIN api_keys ak ON ak.id = r.api_key_id
 `
 
 type ListAPIKeyRateLimitsRow struct {
-	Prefix            string      `json:"prefix"`
-	ApiKeyID          pgtype.UUID `json:"api_key_id"`
-	RequestsPerMinute int32       `json:"requests_per_minute"`
-	TokensPerMinute   int32       `json:"tokens_per_minute"`
-	ParallelRequests  int32       `json:"parallel_requests"`
+	Prefix                    string      `json:"prefix"`
+	ApiKeyID                  pgtype.UUID `json:"api_key_id"`
+	RequestsPerMinute         int32       `json:"requests_per_minute"`
+	TokensPerMinute           int32       `json:"tokens_per_minute"`
+	ParallelRequests          int32       `json:"parallel_requests"`
+	ParallelRequestTtlSeconds int32       `json:"parallel_request_ttl_seconds"`
 }
 
 func (q *Queries) ListAPIKeyRateLimits(ctx context.Context) ([]ListAPIKeyRateLimitsRow, error) {
@@ -85,6 +90,7 @@ func (q *Queries) ListAPIKeyRateLimits(ctx context.Context) ([]ListAPIKeyRateLim
 			&i.RequestsPerMinute,
 			&i.TokensPerMinute,
 			&i.ParallelRequests,
+			&i.ParallelRequestTtlSeconds,
 		); err != nil {
 			return nil, err
 		}
@@ -101,31 +107,36 @@ INSERT INTO api_key_rate_limits (
     api_key_id,
     requests_per_minute,
     tokens_per_minute,
-    parallel_requests
-) VALUES ($1, $2, $3, $4)
+    parallel_requests,
+    parallel_request_ttl_seconds
+) VALUES ($1, $2, $3, $4, $5)
 ON CONFLICT (api_key_id) DO UPDATE
 SET requests_per_minute = EXCLUDED.requests_per_minute,
     tokens_per_minute = EXCLUDED.tokens_per_minute,
     parallel_requests = EXCLUDED.parallel_requests,
+    parallel_request_ttl_seconds = EXCLUDED.parallel_request_ttl_seconds,
     updated_at = NOW()
 RETURNING api_key_id,
           requests_per_minute,
           tokens_per_minute,
-          parallel_requests
+          parallel_requests,
+          parallel_request_ttl_seconds
 `
 
 type UpsertAPIKeyRateLimitParams struct {
-	ApiKeyID          pgtype.UUID `json:"api_key_id"`
-	RequestsPerMinute int32       `json:"requests_per_minute"`
-	TokensPerMinute   int32       `json:"tokens_per_minute"`
-	ParallelRequests  int32       `json:"parallel_requests"`
+	ApiKeyID                  pgtype.UUID `json:"api_key_id"`
+	RequestsPerMinute         int32       `json:"requests_per_minute"`
+	TokensPerMinute           int32       `json:"tokens_per_minute"`
+	ParallelRequests          int32       `json:"parallel_requests"`
+	ParallelRequestTtlSeconds int32       `json:"parallel_request_ttl_seconds"`
 }
 
 type UpsertAPIKeyRateLimitRow struct {
-	ApiKeyID          pgtype.UUID `json:"api_key_id"`
-	RequestsPerMinute int32       `json:"requests_per_minute"`
-	TokensPerMinute   int32       `json:"tokens_per_minute"`
-	ParallelRequests  int32       `json:"parallel_requests"`
+	ApiKeyID                  pgtype.UUID `json:"api_key_id"`
+	RequestsPerMinute         int32       `json:"requests_per_minute"`
+	TokensPerMinute           int32       `json:"tokens_per_minute"`
+	ParallelRequests          int32       `json:"parallel_requests"`
+	ParallelRequestTtlSeconds int32       `json:"parallel_request_ttl_seconds"`
 }
 
 func (q *Queries) UpsertAPIKeyRateLimit(ctx context.Context, arg UpsertAPIKeyRateLimitParams) (UpsertAPIKeyRateLimitRow, error) {
@@ -134,6 +145,7 @@ func (q *Queries) UpsertAPIKeyRateLimit(ctx context.Context, arg UpsertAPIKeyRat
 		arg.RequestsPerMinute,
 		arg.TokensPerMinute,
 		arg.ParallelRequests,
+		arg.ParallelRequestTtlSeconds,
 	)
 	var i UpsertAPIKeyRateLimitRow
 	err := row.Scan(
@@ -141,6 +153,7 @@ func (q *Queries) UpsertAPIKeyRateLimit(ctx context.Context, arg UpsertAPIKeyRat
 		&i.RequestsPerMinute,
 		&i.TokensPerMinute,
 		&i.ParallelRequests,
+		&i.ParallelRequestTtlSeconds,
 	)
 	return i, err
 }
@@ -11,6 +11,21 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countActiveAPIKeys = `-- name: CountActiveAPIKeys :one
+SELECT COUNT(*)::bigint
+FROM api_keys k
+JOIN tenants t ON t.id = k.tenant_id
+WHERE k.revoked_at IS NULL
+  AND t.status = 'active'
+`
+
+func (q *Queries) CountActiveAPIKeys(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, countActiveAPIKeys)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
 const createAPIKey = `-- name: CreateAPIKey :one
 INSERT INTO api_keys (
     tenant_id,
@@ -20,21 +35,23 @@ INSERT INTO api_keys (
     scopes_json,
     quota_json,
     kind,
-    owner_user_id
+    owner_user_id,
+    zero_retention
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-RETURNING id, tenant_id, prefix, secret_hash, name, scopes_json, quota_json, kind, owner_user_id, created_at, revoked_at, last_used_at
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, tenant_id, prefix, secret_hash, name, scopes_json, quota_json, kind, owner_user_id, created_at, revoked_at, last_used_at, is_bootstrap, inactive_warning_sent_at, zero_retention, suspended_at, suspension_reason, reinstated_at
 `
 
 type CreateAPIKeyParams struct {
-	TenantID    pgtype.UUID `json:"tenant_id"`
-	Prefix      string      `json:"prefix"`
-	SecretHash  string      `json:"secret_hash"`
-	Name        string      `json:"name"`
-	ScopesJson  []byte      `json:"scopes_json"`
-	QuotaJson   []byte      `json:"quota_json"`
-	Kind        ApiKeyKind  `json:"kind"`
-	OwnerUserID pgtype.UUID `json:"owner_user_id"`
+	TenantID      pgtype.UUID `json:"tenant_id"`
+	Prefix        string      `json:"prefix"`
+	SecretHash    string      `json:"secret_hash"`
+	Name          string      `json:"name"`
+	ScopesJson    []byte      `json:"scopes_json"`
+	QuotaJson     []byte      `json:"quota_json"`
+	Kind          ApiKeyKind  `json:"kind"`
+	OwnerUserID   pgtype.UUID `json:"owner_user_id"`
+	ZeroRetention bool        `json:"zero_retention"`
 }
 
 func (q *Queries) CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (ApiKey, error) {
@@ -47,6 +64,7 @@ func (q *Queries) CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (Api
 		arg.QuotaJson,
 		arg.Kind,
 		arg.OwnerUserID,
+		arg.ZeroRetention,
 	)
 	var i ApiKey
 	err := row.Scan(
@@ -62,12 +80,31 @@ func (q *Queries) CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (Api
 		&i.CreatedAt,
 		&i.RevokedAt,
 		&i.LastUsedAt,
+		&i.IsBootstrap,
+		&i.InactiveWarningSentAt,
+		&i.ZeroRetention,
+		&i.SuspendedAt,
+		&i.SuspensionReason,
+		&i.ReinstatedAt,
 	)
 	return i, err
 }
 
+const deleteAPIKeysByOwner = `-- name: DeleteAPIKeysByOwner :execrows
+DELETE FROM api_keys
+WHERE owner_user_id = $1
+`
+
+func (q *Queries) DeleteAPIKeysByOwner(ctx context.Context, ownerUserID pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteAPIKeysByOwner, ownerUserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getAPIKeyByID = `-- name: GetAPIKeyByID :one
-SELECT id, tenant_id, prefix, secret_hash, name, scopes_json, quota_json, kind, owner_user_id, created_at, revoked_at, last_used_at
+SELECT id, tenant_id, prefix, secret_hash, name, scopes_json, quota_json, kind, owner_user_id, created_at, revoked_at, last_used_at, is_bootstrap, inactive_warning_sent_at, zero_retention, suspended_at, suspension_reason, reinstated_at
 FROM api_keys
 WHERE id = $1
 `
@@ -88,12 +125,18 @@ func (q *Queries) GetAPIKeyByID(ctx context.Context, id pgtype.UUID) (ApiKey, er
 		&i.CreatedAt,
 		&i.RevokedAt,
 		&i.LastUsedAt,
+		&i.IsBootstrap,
+		&i.InactiveWarningSentAt,
+		&i.ZeroRetention,
+		&i.SuspendedAt,
+		&i.SuspensionReason,
+		&i.ReinstatedAt,
 	)
 	return i, err
 }
 
 const getAPIKeyByPrefix = `-- name: GetAPIKeyByPrefix :one
-SELECT id, tenant_id, prefix, secret_hash, name, scopes_json, quota_json, kind, owner_user_id, created_at, revoked_at, last_used_at
+SELECT id, tenant_id, prefix, secret_hash, name, scopes_json, quota_json, kind, owner_user_id, created_at, revoked_at, last_used_at, is_bootstrap, inactive_warning_sent_at, zero_retention, suspended_at, suspension_reason, reinstated_at
 FROM api_keys
 WHERE prefix = $1
 `
@@ -114,12 +157,18 @@ func (q *Queries) GetAPIKeyByPrefix(ctx context.Context, prefix string) (ApiKey,
 		&i.CreatedAt,
 		&i.RevokedAt,
 		&i.LastUsedAt,
+		&i.IsBootstrap,
+		&i.InactiveWarningSentAt,
+		&i.ZeroRetention,
+		&i.SuspendedAt,
+		&i.SuspensionReason,
+		&i.ReinstatedAt,
 	)
 	return i, err
 }
 
 const listAPIKeysByIDs = `-- name: ListAPIKeysByIDs :many
-SELECT id, tenant_id, prefix, secret_hash, name, scopes_json, quota_json, kind, owner_user_id, created_at, revoked_at, last_used_at
+SELECT id, tenant_id, prefix, secret_hash, name, scopes_json, quota_json, kind, owner_user_id, created_at, revoked_at, last_used_at, is_bootstrap, inactive_warning_sent_at, zero_retention, suspended_at, suspension_reason, reinstated_at
 FROM api_keys
 WHERE id = ANY($1::uuid[])
 `
@@ -146,6 +195,12 @@ func (q *Queries) ListAPIKeysByIDs(ctx context.Context, dollar_1 []pgtype.UUID)
 			&i.CreatedAt,
 			&i.RevokedAt,
 			&i.LastUsedAt,
+			&i.IsBootstrap,
+			&i.InactiveWarningSentAt,
+			&i.ZeroRetention,
+			&i.SuspendedAt,
+			&i.SuspensionReason,
+			&i.ReinstatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -158,7 +213,7 @@ func (q *Queries) ListAPIKeysByIDs(ctx context.Context, dollar_1 []pgtype.UUID)
 }
 
 const listAPIKeysByOwnerAndTenant = `-- name: ListAPIKeysByOwnerAndTenant :many
-SELECT id, tenant_id, prefix, secret_hash, name, scopes_json, quota_json, kind, owner_user_id, created_at, revoked_at, last_used_at
+SELECT id, tenant_id, prefix, secret_hash, name, scopes_json, quota_json, kind, owner_user_id, created_at, revoked_at, last_used_at, is_bootstrap, inactive_warning_sent_at, zero_retention, suspended_at, suspension_reason, reinstated_at
 FROM api_keys
 WHERE owner_user_id = $1
   AND tenant_id = $2
@@ -192,6 +247,12 @@ func (q *Queries) ListAPIKeysByOwnerAndTenant(ctx context.Context, arg ListAPIKe
 			&i.CreatedAt,
 			&i.RevokedAt,
 			&i.LastUsedAt,
+			&i.IsBootstrap,
+			&i.InactiveWarningSentAt,
+			&i.ZeroRetention,
+			&i.SuspendedAt,
+			&i.SuspensionReason,
+			&i.ReinstatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -204,7 +265,7 @@ func (q *Queries) ListAPIKeysByOwnerAndTenant(ctx context.Context, arg ListAPIKe
 }
 
 const listAPIKeysByTenant = `-- name: ListAPIKeysByTenant :many
-SELECT id, tenant_id, prefix, secret_hash, name, scopes_json, quota_json, kind, owner_user_id, created_at, revoked_at, last_used_at
+SELECT id, tenant_id, prefix, secret_hash, name, scopes_json, quota_json, kind, owner_user_id, created_at, revoked_at, last_used_at, is_bootstrap, inactive_warning_sent_at, zero_retention, suspended_at, suspension_reason, reinstated_at
 FROM api_keys
 WHERE tenant_id = $1
 ORDER BY created_at DESC
@@ -232,6 +293,138 @@ func (q *Queries) ListAPIKeysByTenant(ctx context.Context, tenantID pgtype.UUID)
 			&i.CreatedAt,
 			&i.RevokedAt,
 			&i.LastUsedAt,
+			&i.IsBootstrap,
+			&i.InactiveWarningSentAt,
+			&i.ZeroRetention,
+			&i.SuspendedAt,
+			&i.SuspensionReason,
+			&i.ReinstatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAPIKeysByTenantForExport = `-- name: ListAPIKeysByTenantForExport :many
+SELECT id, tenant_id, prefix, secret_hash, name, scopes_json, quota_json, kind, owner_user_id, created_at, revoked_at, last_used_at, is_bootstrap, inactive_warning_sent_at, zero_retention, suspended_at, suspension_reason, reinstated_at
+FROM api_keys
+WHERE tenant_id = $1
+  AND id > $2::uuid
+ORDER BY id
+LIMIT $3
+`
+
+type ListAPIKeysByTenantForExportParams struct {
+	TenantID pgtype.UUID `json:"tenant_id"`
+	AfterID  pgtype.UUID `json:"after_id"`
+	RowLimit int32       `json:"row_limit"`
+}
+
+func (q *Queries) ListAPIKeysByTenantForExport(ctx context.Context, arg ListAPIKeysByTenantForExportParams) ([]ApiKey, error) {
+	rows, err := q.db.Query(ctx, listAPIKeysByTenantForExport, arg.TenantID, arg.AfterID, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ApiKey{}
+	for rows.Next() {
+		var i ApiKey
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.Prefix,
+			&i.SecretHash,
+			&i.Name,
+			&i.ScopesJson,
+			&i.QuotaJson,
+			&i.Kind,
+			&i.OwnerUserID,
+			&i.CreatedAt,
+			&i.RevokedAt,
+			&i.LastUsedAt,
+			&i.IsBootstrap,
+			&i.InactiveWarningSentAt,
+			&i.ZeroRetention,
+			&i.SuspendedAt,
+			&i.SuspensionReason,
+			&i.ReinstatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAPIKeysIdleSince = `-- name: ListAPIKeysIdleSince :many
+SELECT
+    k.id,
+    k.tenant_id,
+    k.prefix,
+    k.name,
+    k.owner_user_id,
+    k.created_at,
+    k.last_used_at,
+    k.inactive_warning_sent_at,
+    u.email AS owner_email
+FROM api_keys k
+LEFT JOIN users u ON u.id = k.owner_user_id
+WHERE k.revoked_at IS NULL
+  AND NOT k.is_bootstrap
+  AND COALESCE(k.last_used_at, k.created_at) < $1
+  AND (
+    ($2::timestamptz IS NULL AND k.inactive_warning_sent_at IS NULL)
+    OR k.inactive_warning_sent_at <= $2::timestamptz
+)
+ORDER BY COALESCE(k.last_used_at, k.created_at)
+LIMIT $3
+`
+
+type ListAPIKeysIdleSinceParams struct {
+	IdleBefore   pgtype.Timestamptz `json:"idle_before"`
+	WarnedBefore pgtype.Timestamptz `json:"warned_before"`
+	BatchSize    int32              `json:"batch_size"`
+}
+
+type ListAPIKeysIdleSinceRow struct {
+	ID                    pgtype.UUID        `json:"id"`
+	TenantID              pgtype.UUID        `json:"tenant_id"`
+	Prefix                string             `json:"prefix"`
+	Name                  string             `json:"name"`
+	OwnerUserID           pgtype.UUID        `json:"owner_user_id"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at"`
+	LastUsedAt            pgtype.Timestamptz `json:"last_used_at"`
+	InactiveWarningSentAt pgtype.Timestamptz `json:"inactive_warning_sent_at"`
+	OwnerEmail            pgtype.Text        `json:"owner_email"`
+}
+
+func (q *Queries) ListAPIKeysIdleSince(ctx context.Context, arg ListAPIKeysIdleSinceParams) ([]ListAPIKeysIdleSinceRow, error) {
+	rows, err := q.db.Query(ctx, listAPIKeysIdleSince, arg.IdleBefore, arg.WarnedBefore, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAPIKeysIdleSinceRow{}
+	for rows.Next() {
+		var i ListAPIKeysIdleSinceRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.Prefix,
+			&i.Name,
+			&i.OwnerUserID,
+			&i.CreatedAt,
+			&i.LastUsedAt,
+			&i.InactiveWarningSentAt,
+			&i.OwnerEmail,
 		); err != nil {
 			return nil, err
 		}
@@ -321,7 +514,7 @@ func (q *Queries) ListAllAPIKeys(ctx context.Context) ([]ListAllAPIKeysRow, erro
 }
 
 const listPersonalAPIKeysByUser = `-- name: ListPersonalAPIKeysByUser :many
-SELECT id, tenant_id, prefix, secret_hash, name, scopes_json, quota_json, kind, owner_user_id, created_at, revoked_at, last_used_at
+SELECT id, tenant_id, prefix, secret_hash, name, scopes_json, quota_json, kind, owner_user_id, created_at, revoked_at, last_used_at, is_bootstrap, inactive_warning_sent_at, zero_retention, suspended_at, suspension_reason, reinstated_at
 FROM api_keys
 WHERE owner_user_id = $1
 ORDER BY created_at DESC
@@ -349,6 +542,12 @@ func (q *Queries) ListPersonalAPIKeysByUser(ctx context.Context, ownerUserID pgt
 			&i.CreatedAt,
 			&i.RevokedAt,
 			&i.LastUsedAt,
+			&i.IsBootstrap,
+			&i.InactiveWarningSentAt,
+			&i.ZeroRetention,
+			&i.SuspendedAt,
+			&i.SuspensionReason,
+			&i.ReinstatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -360,11 +559,68 @@ func (q *Queries) ListPersonalAPIKeysByUser(ctx context.Context, ownerUserID pgt
 	return items, nil
 }
 
+const markAPIKeyBootstrap = `-- name: MarkAPIKeyBootstrap :exec
+UPDATE api_keys
+SET is_bootstrap = TRUE
+WHERE id = $1 AND NOT is_bootstrap
+`
+
+func (q *Queries) MarkAPIKeyBootstrap(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, markAPIKeyBootstrap, id)
+	return err
+}
+
+const markAPIKeyInactiveWarningSent = `-- name: MarkAPIKeyInactiveWarningSent :exec
+UPDATE api_keys
+SET inactive_warning_sent_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) MarkAPIKeyInactiveWarningSent(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, markAPIKeyInactiveWarningSent, id)
+	return err
+}
+
+const reinstateAPIKey = `-- name: ReinstateAPIKey :one
+UPDATE api_keys
+SET suspended_at = NULL,
+    suspension_reason = '',
+    reinstated_at = NOW()
+WHERE id = $1 AND suspended_at IS NOT NULL
+RETURNING id, tenant_id, prefix, secret_hash, name, scopes_json, quota_json, kind, owner_user_id, created_at, revoked_at, last_used_at, is_bootstrap, inactive_warning_sent_at, zero_retention, suspended_at, suspension_reason, reinstated_at
+`
+
+func (q *Queries) ReinstateAPIKey(ctx context.Context, id pgtype.UUID) (ApiKey, error) {
+	row := q.db.QueryRow(ctx, reinstateAPIKey, id)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.Prefix,
+		&i.SecretHash,
+		&i.Name,
+		&i.ScopesJson,
+		&i.QuotaJson,
+		&i.Kind,
+		&i.OwnerUserID,
+		&i.CreatedAt,
+		&i.RevokedAt,
+		&i.LastUsedAt,
+		&i.IsBootstrap,
+		&i.InactiveWarningSentAt,
+		&i.ZeroRetention,
+		&i.SuspendedAt,
+		&i.SuspensionReason,
+		&i.ReinstatedAt,
+	)
+	return i, err
+}
+
 const revokeAPIKey = `-- name: RevokeAPIKey :one
 UPDATE api_keys
 SET revoked_at = NOW()
 WHERE id = $1 AND revoked_at IS NULL
-RETURNING id, tenant_id, prefix, secret_hash, name, scopes_json, quota_json, kind, owner_user_id, created_at, revoked_at, last_used_at
+RETURNING id, tenant_id, prefix, secret_hash, name, scopes_json, quota_json, kind, owner_user_id, created_at, revoked_at, last_used_at, is_bootstrap, inactive_warning_sent_at, zero_retention, suspended_at, suspension_reason, reinstated_at
 `
 
 func (q *Queries) RevokeAPIKey(ctx context.Context, id pgtype.UUID) (ApiKey, error) {
@@ -383,13 +639,61 @@ func (q *Queries) RevokeAPIKey(ctx context.Context, id pgtype.UUID) (ApiKey, err
 		&i.CreatedAt,
 		&i.RevokedAt,
 		&i.LastUsedAt,
+		&i.IsBootstrap,
+		&i.InactiveWarningSentAt,
+		&i.ZeroRetention,
+		&i.SuspendedAt,
+		&i.SuspensionReason,
+		&i.ReinstatedAt,
+	)
+	return i, err
+}
+
+const suspendAPIKey = `-- name: SuspendAPIKey :one
+UPDATE api_keys
+SET suspended_at = NOW(),
+    suspension_reason = $2
+WHERE id = $1
+  AND revoked_at IS NULL
+  AND suspended_at IS NULL
+RETURNING id, tenant_id, prefix, secret_hash, name, scopes_json, quota_json, kind, owner_user_id, created_at, revoked_at, last_used_at, is_bootstrap, inactive_warning_sent_at, zero_retention, suspended_at, suspension_reason, reinstated_at
+`
+
+type SuspendAPIKeyParams struct {
+	ID               pgtype.UUID `json:"id"`
+	SuspensionReason string      `json:"suspension_reason"`
+}
+
+func (q *Queries) SuspendAPIKey(ctx context.Context, arg SuspendAPIKeyParams) (ApiKey, error) {
+	row := q.db.QueryRow(ctx, suspendAPIKey, arg.ID, arg.SuspensionReason)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.Prefix,
+		&i.SecretHash,
+		&i.Name,
+		&i.ScopesJson,
+		&i.QuotaJson,
+		&i.Kind,
+		&i.OwnerUserID,
+		&i.CreatedAt,
+		&i.RevokedAt,
+		&i.LastUsedAt,
+		&i.IsBootstrap,
+		&i.InactiveWarningSentAt,
+		&i.ZeroRetention,
+		&i.SuspendedAt,
+		&i.SuspensionReason,
+		&i.ReinstatedAt,
 	)
 	return i, err
 }
 
 const updateAPIKeyLastUsed = `-- name: UpdateAPIKeyLastUsed :exec
 UPDATE api_keys
-SET last_used_at = NOW()
+SET last_used_at = NOW(),
+    inactive_warning_sent_at = NULL
 WHERE id = $1
 `
 
@@ -402,7 +706,7 @@ const updateAPIKeyTenant = `-- name: UpdateAPIKeyTenant :one
 UPDATE api_keys
 SET tenant_id = $2
 WHERE id = $1
-RETURNING id, tenant_id, prefix, secret_hash, name, scopes_json, quota_json, kind, owner_user_id, created_at, revoked_at, last_used_at
+RETURNING id, tenant_id, prefix, secret_hash, name, scopes_json, quota_json, kind, owner_user_id, created_at, revoked_at, last_used_at, is_bootstrap, inactive_warning_sent_at, zero_retention, suspended_at, suspension_reason, reinstated_at
 `
 
 type UpdateAPIKeyTenantParams struct {
@@ -426,6 +730,12 @@ func (q *Queries) UpdateAPIKeyTenant(ctx context.Context, arg UpdateAPIKeyTenant
 		&i.CreatedAt,
 		&i.RevokedAt,
 		&i.LastUsedAt,
+		&i.IsBootstrap,
+		&i.InactiveWarningSentAt,
+		&i.ZeroRetention,
+		&i.SuspendedAt,
+		&i.SuspensionReason,
+		&i.ReinstatedAt,
 	)
 	return i, err
 }
@@ -111,3 +111,89 @@ func (q *Queries) ListAuditLogs(ctx context.Context, arg ListAuditLogsParams) ([
 	}
 	return items, nil
 }
+
+const listAuditLogsForExport = `-- name: ListAuditLogsForExport :many
+SELECT l.id, l.user_id, u.email AS actor_email, l.action, l.resource_type, l.resource_id, l.metadata, l.created_at
+FROM admin_audit_logs l
+LEFT JOIN users u ON u.id = l.user_id
+WHERE l.created_at >= $1
+  AND l.created_at < $2
+  AND (
+    $3::uuid IS NULL
+    OR l.user_id = $3::uuid
+)
+  AND (
+    $4::text IS NULL
+    OR l.action = $4::text
+)
+  AND (
+    $5::text IS NULL
+    OR l.resource_type = $5::text
+)
+  AND (
+    $6::timestamptz IS NULL
+    OR (l.created_at, l.id) > ($6::timestamptz, $7::uuid)
+)
+ORDER BY l.created_at, l.id
+LIMIT $8
+`
+
+type ListAuditLogsForExportParams struct {
+	StartTime      pgtype.Timestamptz `json:"start_time"`
+	EndTime        pgtype.Timestamptz `json:"end_time"`
+	UserIDFilter   pgtype.UUID        `json:"user_id_filter"`
+	ActionFilter   pgtype.Text        `json:"action_filter"`
+	ResourceFilter pgtype.Text        `json:"resource_filter"`
+	AfterCreatedAt pgtype.Timestamptz `json:"after_created_at"`
+	AfterID        pgtype.UUID        `json:"after_id"`
+	PageLimit      int32              `json:"page_limit"`
+}
+
+type ListAuditLogsForExportRow struct {
+	ID           pgtype.UUID        `json:"id"`
+	UserID       pgtype.UUID        `json:"user_id"`
+	ActorEmail   pgtype.Text        `json:"actor_email"`
+	Action       string             `json:"action"`
+	ResourceType string             `json:"resource_type"`
+	ResourceID   string             `json:"resource_id"`
+	Metadata     []byte             `json:"metadata"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) ListAuditLogsForExport(ctx context.Context, arg ListAuditLogsForExportParams) ([]ListAuditLogsForExportRow, error) {
+	rows, err := q.db.Query(ctx, listAuditLogsForExport,
+		arg.StartTime,
+		arg.EndTime,
+		arg.UserIDFilter,
+		arg.ActionFilter,
+		arg.ResourceFilter,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAuditLogsForExportRow{}
+	for rows.Next() {
+		var i ListAuditLogsForExportRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ActorEmail,
+			&i.Action,
+			&i.ResourceType,
+			&i.ResourceID,
+			&i.Metadata,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
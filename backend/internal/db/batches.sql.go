@@ -11,17 +11,33 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const activateScheduledBatches = `-- name: ActivateScheduledBatches :execrows
+UPDATE batches
+SET status = 'validating',
+    updated_at = NOW()
+WHERE status = 'scheduled'
+  AND scheduled_at <= $1::timestamptz
+`
+
+func (q *Queries) ActivateScheduledBatches(ctx context.Context, now pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, activateScheduledBatches, now)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const cancelBatch = `-- name: CancelBatch :one
 UPDATE batches
 SET status = CASE
-        WHEN status = 'validating' THEN 'cancelled'
+        WHEN status IN ('scheduled', 'validating') THEN 'cancelled'
         ELSE 'cancelling'
     END,
-    cancelled_at = CASE WHEN status = 'validating' THEN NOW() ELSE cancelled_at END,
-    cancelling_at = CASE WHEN status <> 'validating' THEN NOW() ELSE cancelling_at END,
+    cancelled_at = CASE WHEN status IN ('scheduled', 'validating') THEN NOW() ELSE cancelled_at END,
+    cancelling_at = CASE WHEN status NOT IN ('scheduled', 'validating') THEN NOW() ELSE cancelling_at END,
     updated_at = NOW()
-WHERE tenant_id = $1 AND id = $2 AND status IN ('validating', 'in_progress', 'finalizing')
-RETURNING id, tenant_id, api_key_id, status, endpoint, input_file_id, result_file_id, error_file_id, errors, completion_window, max_concurrency, metadata, request_count_total, request_count_completed, request_count_failed, request_count_cancelled, created_at, updated_at, in_progress_at, completed_at, cancelled_at, cancelling_at, finalizing_at, failed_at, expires_at, expired_at
+WHERE tenant_id = $1 AND id = $2 AND status IN ('scheduled', 'validating', 'in_progress', 'finalizing')
+RETURNING id, tenant_id, api_key_id, status, endpoint, input_file_id, result_file_id, error_file_id, errors, completion_window, max_concurrency, metadata, request_count_total, request_count_completed, request_count_failed, request_count_cancelled, created_at, updated_at, in_progress_at, completed_at, cancelled_at, cancelling_at, finalizing_at, failed_at, expires_at, expired_at, scheduled_at, model_alias
 `
 
 type CancelBatchParams struct {
@@ -59,6 +75,8 @@ func (q *Queries) CancelBatch(ctx context.Context, arg CancelBatchParams) (Batch
 		&i.FailedAt,
 		&i.ExpiresAt,
 		&i.ExpiredAt,
+		&i.ScheduledAt,
+		&i.ModelAlias,
 	)
 	return i, err
 }
@@ -130,10 +148,12 @@ INSERT INTO batches (
     max_concurrency,
     metadata,
     request_count_total,
-    expires_at
+    expires_at,
+    scheduled_at,
+    model_alias
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
-) RETURNING id, tenant_id, api_key_id, status, endpoint, input_file_id, result_file_id, error_file_id, errors, completion_window, max_concurrency, metadata, request_count_total, request_count_completed, request_count_failed, request_count_cancelled, created_at, updated_at, in_progress_at, completed_at, cancelled_at, cancelling_at, finalizing_at, failed_at, expires_at, expired_at
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+) RETURNING id, tenant_id, api_key_id, status, endpoint, input_file_id, result_file_id, error_file_id, errors, completion_window, max_concurrency, metadata, request_count_total, request_count_completed, request_count_failed, request_count_cancelled, created_at, updated_at, in_progress_at, completed_at, cancelled_at, cancelling_at, finalizing_at, failed_at, expires_at, expired_at, scheduled_at, model_alias
 `
 
 type CreateBatchParams struct {
@@ -147,6 +167,8 @@ type CreateBatchParams struct {
 	Metadata          []byte             `json:"metadata"`
 	RequestCountTotal int32              `json:"request_count_total"`
 	ExpiresAt         pgtype.Timestamptz `json:"expires_at"`
+	ScheduledAt       pgtype.Timestamptz `json:"scheduled_at"`
+	ModelAlias        string             `json:"model_alias"`
 }
 
 func (q *Queries) CreateBatch(ctx context.Context, arg CreateBatchParams) (Batch, error) {
@@ -161,6 +183,8 @@ func (q *Queries) CreateBatch(ctx context.Context, arg CreateBatchParams) (Batch
 		arg.Metadata,
 		arg.RequestCountTotal,
 		arg.ExpiresAt,
+		arg.ScheduledAt,
+		arg.ModelAlias,
 	)
 	var i Batch
 	err := row.Scan(
@@ -190,10 +214,30 @@ func (q *Queries) CreateBatch(ctx context.Context, arg CreateBatchParams) (Batch
 		&i.FailedAt,
 		&i.ExpiresAt,
 		&i.ExpiredAt,
+		&i.ScheduledAt,
+		&i.ModelAlias,
 	)
 	return i, err
 }
 
+const deleteBatchesForUser = `-- name: DeleteBatchesForUser :exec
+DELETE FROM batches
+WHERE tenant_id = $1
+   OR api_key_id IN (
+        SELECT id FROM api_keys WHERE owner_user_id = $2
+   )
+`
+
+type DeleteBatchesForUserParams struct {
+	TenantID    pgtype.UUID `json:"tenant_id"`
+	OwnerUserID pgtype.UUID `json:"owner_user_id"`
+}
+
+func (q *Queries) DeleteBatchesForUser(ctx context.Context, arg DeleteBatchesForUserParams) error {
+	_, err := q.db.Exec(ctx, deleteBatchesForUser, arg.TenantID, arg.OwnerUserID)
+	return err
+}
+
 const failBatchItem = `-- name: FailBatchItem :exec
 UPDATE batch_items
 SET status = 'failed',
@@ -213,7 +257,7 @@ func (q *Queries) FailBatchItem(ctx context.Context, arg FailBatchItemParams) er
 }
 
 const getBatch = `-- name: GetBatch :one
-SELECT id, tenant_id, api_key_id, status, endpoint, input_file_id, result_file_id, error_file_id, errors, completion_window, max_concurrency, metadata, request_count_total, request_count_completed, request_count_failed, request_count_cancelled, created_at, updated_at, in_progress_at, completed_at, cancelled_at, cancelling_at, finalizing_at, failed_at, expires_at, expired_at
+SELECT id, tenant_id, api_key_id, status, endpoint, input_file_id, result_file_id, error_file_id, errors, completion_window, max_concurrency, metadata, request_count_total, request_count_completed, request_count_failed, request_count_cancelled, created_at, updated_at, in_progress_at, completed_at, cancelled_at, cancelling_at, finalizing_at, failed_at, expires_at, expired_at, scheduled_at, model_alias
 FROM batches
 WHERE tenant_id = $1 AND id = $2
 `
@@ -253,12 +297,14 @@ func (q *Queries) GetBatch(ctx context.Context, arg GetBatchParams) (Batch, erro
 		&i.FailedAt,
 		&i.ExpiresAt,
 		&i.ExpiredAt,
+		&i.ScheduledAt,
+		&i.ModelAlias,
 	)
 	return i, err
 }
 
 const getBatchByID = `-- name: GetBatchByID :one
-SELECT id, tenant_id, api_key_id, status, endpoint, input_file_id, result_file_id, error_file_id, errors, completion_window, max_concurrency, metadata, request_count_total, request_count_completed, request_count_failed, request_count_cancelled, created_at, updated_at, in_progress_at, completed_at, cancelled_at, cancelling_at, finalizing_at, failed_at, expires_at, expired_at
+SELECT id, tenant_id, api_key_id, status, endpoint, input_file_id, result_file_id, error_file_id, errors, completion_window, max_concurrency, metadata, request_count_total, request_count_completed, request_count_failed, request_count_cancelled, created_at, updated_at, in_progress_at, completed_at, cancelled_at, cancelling_at, finalizing_at, failed_at, expires_at, expired_at, scheduled_at, model_alias
 FROM batches
 WHERE id = $1
 `
@@ -293,14 +339,17 @@ func (q *Queries) GetBatchByID(ctx context.Context, id pgtype.UUID) (Batch, erro
 		&i.FailedAt,
 		&i.ExpiresAt,
 		&i.ExpiredAt,
+		&i.ScheduledAt,
+		&i.ModelAlias,
 	)
 	return i, err
 }
 
 const getOldestQueuedBatch = `-- name: GetOldestQueuedBatch :one
-SELECT id, tenant_id, api_key_id, status, endpoint, input_file_id, result_file_id, error_file_id, errors, completion_window, max_concurrency, metadata, request_count_total, request_count_completed, request_count_failed, request_count_cancelled, created_at, updated_at, in_progress_at, completed_at, cancelled_at, cancelling_at, finalizing_at, failed_at, expires_at, expired_at
+SELECT id, tenant_id, api_key_id, status, endpoint, input_file_id, result_file_id, error_file_id, errors, completion_window, max_concurrency, metadata, request_count_total, request_count_completed, request_count_failed, request_count_cancelled, created_at, updated_at, in_progress_at, completed_at, cancelled_at, cancelling_at, finalizing_at, failed_at, expires_at, expired_at, scheduled_at, model_alias
 FROM batches
 WHERE status = 'validating'
+  AND (scheduled_at IS NULL OR scheduled_at <= NOW())
 ORDER BY created_at
 LIMIT 1
 FOR UPDATE SKIP LOCKED
@@ -336,6 +385,8 @@ func (q *Queries) GetOldestQueuedBatch(ctx context.Context) (Batch, error) {
 		&i.FailedAt,
 		&i.ExpiresAt,
 		&i.ExpiredAt,
+		&i.ScheduledAt,
+		&i.ModelAlias,
 	)
 	return i, err
 }
@@ -410,6 +461,122 @@ func (q *Queries) InsertBatchItem(ctx context.Context, arg InsertBatchItemParams
 	return i, err
 }
 
+const listActiveBatchIDs = `-- name: ListActiveBatchIDs :many
+SELECT id
+FROM batches
+WHERE status IN ('in_progress', 'cancelling')
+ORDER BY in_progress_at
+LIMIT $1
+`
+
+func (q *Queries) ListActiveBatchIDs(ctx context.Context, limit int32) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, listActiveBatchIDs, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []pgtype.UUID{}
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBatchAnalytics = `-- name: ListBatchAnalytics :many
+SELECT b.id,
+       b.tenant_id,
+       t.name AS tenant_name,
+       b.status,
+       b.model_alias,
+       COALESCE(items.total_items, 0)::bigint AS total_items,
+       COALESCE(items.completed_items, 0)::bigint AS completed_items,
+       COALESCE(items.failed_items, 0)::bigint AS failed_items,
+       COALESCE(items.timed_items, 0)::bigint AS timed_items,
+       COALESCE(items.processing_ms, 0)::double precision AS processing_ms,
+       COALESCE(costs.cost_cents, 0)::bigint AS cost_cents
+FROM batches b
+JOIN tenants t ON t.id = b.tenant_id
+LEFT JOIN LATERAL (
+    SELECT COUNT(*) AS total_items,
+           COUNT(*) FILTER (WHERE bi.status = 'completed') AS completed_items,
+           COUNT(*) FILTER (WHERE bi.status = 'failed') AS failed_items,
+           COUNT(*) FILTER (WHERE bi.started_at IS NOT NULL AND bi.completed_at IS NOT NULL) AS timed_items,
+           SUM(EXTRACT(EPOCH FROM (bi.completed_at - bi.started_at)) * 1000)
+               FILTER (WHERE bi.started_at IS NOT NULL AND bi.completed_at IS NOT NULL) AS processing_ms
+    FROM batch_items bi
+    WHERE bi.batch_id = b.id
+) items ON TRUE
+LEFT JOIN LATERAL (
+    SELECT SUM(r.cost_cents) AS cost_cents
+    FROM requests r
+    WHERE r.tenant_id = b.tenant_id
+      AND r.ts >= b.created_at
+      AND r.trace_id LIKE 'batch_' || b.id::text || '_%'
+) costs ON TRUE
+WHERE b.created_at >= $1
+  AND b.created_at < $2
+`
+
+type ListBatchAnalyticsParams struct {
+	StartTs pgtype.Timestamptz `json:"start_ts"`
+	EndTs   pgtype.Timestamptz `json:"end_ts"`
+}
+
+type ListBatchAnalyticsRow struct {
+	ID             pgtype.UUID `json:"id"`
+	TenantID       pgtype.UUID `json:"tenant_id"`
+	TenantName     string      `json:"tenant_name"`
+	Status         string      `json:"status"`
+	ModelAlias     string      `json:"model_alias"`
+	TotalItems     int64       `json:"total_items"`
+	CompletedItems int64       `json:"completed_items"`
+	FailedItems    int64       `json:"failed_items"`
+	TimedItems     int64       `json:"timed_items"`
+	ProcessingMs   float64     `json:"processing_ms"`
+	CostCents      int64       `json:"cost_cents"`
+}
+
+// Per-batch item counts, processing time, and cost for batches created in
+// the window. Cost comes from the requests logged with the batch's trace IDs.
+func (q *Queries) ListBatchAnalytics(ctx context.Context, arg ListBatchAnalyticsParams) ([]ListBatchAnalyticsRow, error) {
+	rows, err := q.db.Query(ctx, listBatchAnalytics, arg.StartTs, arg.EndTs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListBatchAnalyticsRow{}
+	for rows.Next() {
+		var i ListBatchAnalyticsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.TenantName,
+			&i.Status,
+			&i.ModelAlias,
+			&i.TotalItems,
+			&i.CompletedItems,
+			&i.FailedItems,
+			&i.TimedItems,
+			&i.ProcessingMs,
+			&i.CostCents,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listBatchItemsForOutput = `-- name: ListBatchItemsForOutput :many
 SELECT id, batch_id, item_index, status, custom_id, input, response, error, created_at, started_at, completed_at
 FROM batch_items
@@ -449,8 +616,55 @@ func (q *Queries) ListBatchItemsForOutput(ctx context.Context, batchID pgtype.UU
 	return items, nil
 }
 
+const listBatchItemsPage = `-- name: ListBatchItemsPage :many
+SELECT id, batch_id, item_index, status, custom_id, input, response, error, created_at, started_at, completed_at
+FROM batch_items
+WHERE batch_id = $1
+  AND item_index > $2
+ORDER BY item_index
+LIMIT $3
+`
+
+type ListBatchItemsPageParams struct {
+	BatchID    pgtype.UUID `json:"batch_id"`
+	AfterIndex int64       `json:"after_index"`
+	RowLimit   int32       `json:"row_limit"`
+}
+
+func (q *Queries) ListBatchItemsPage(ctx context.Context, arg ListBatchItemsPageParams) ([]BatchItem, error) {
+	rows, err := q.db.Query(ctx, listBatchItemsPage, arg.BatchID, arg.AfterIndex, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []BatchItem{}
+	for rows.Next() {
+		var i BatchItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.BatchID,
+			&i.ItemIndex,
+			&i.Status,
+			&i.CustomID,
+			&i.Input,
+			&i.Response,
+			&i.Error,
+			&i.CreatedAt,
+			&i.StartedAt,
+			&i.CompletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listBatches = `-- name: ListBatches :many
-SELECT id, tenant_id, api_key_id, status, endpoint, input_file_id, result_file_id, error_file_id, errors, completion_window, max_concurrency, metadata, request_count_total, request_count_completed, request_count_failed, request_count_cancelled, created_at, updated_at, in_progress_at, completed_at, cancelled_at, cancelling_at, finalizing_at, failed_at, expires_at, expired_at
+SELECT id, tenant_id, api_key_id, status, endpoint, input_file_id, result_file_id, error_file_id, errors, completion_window, max_concurrency, metadata, request_count_total, request_count_completed, request_count_failed, request_count_cancelled, created_at, updated_at, in_progress_at, completed_at, cancelled_at, cancelling_at, finalizing_at, failed_at, expires_at, expired_at, scheduled_at, model_alias
 FROM batches
 WHERE tenant_id = $1
 ORDER BY created_at DESC
@@ -499,6 +713,8 @@ func (q *Queries) ListBatches(ctx context.Context, arg ListBatchesParams) ([]Bat
 			&i.FailedAt,
 			&i.ExpiresAt,
 			&i.ExpiredAt,
+			&i.ScheduledAt,
+			&i.ModelAlias,
 		); err != nil {
 			return nil, err
 		}
@@ -511,7 +727,7 @@ func (q *Queries) ListBatches(ctx context.Context, arg ListBatchesParams) ([]Bat
 }
 
 const listBatchesAdmin = `-- name: ListBatchesAdmin :many
-SELECT b.id, b.tenant_id, b.api_key_id, b.status, b.endpoint, b.input_file_id, b.result_file_id, b.error_file_id, b.errors, b.completion_window, b.max_concurrency, b.metadata, b.request_count_total, b.request_count_completed, b.request_count_failed, b.request_count_cancelled, b.created_at, b.updated_at, b.in_progress_at, b.completed_at, b.cancelled_at, b.cancelling_at, b.finalizing_at, b.failed_at, b.expires_at, b.expired_at, t.name AS tenant_name, COUNT(*) OVER() AS total_count
+SELECT b.id, b.tenant_id, b.api_key_id, b.status, b.endpoint, b.input_file_id, b.result_file_id, b.error_file_id, b.errors, b.completion_window, b.max_concurrency, b.metadata, b.request_count_total, b.request_count_completed, b.request_count_failed, b.request_count_cancelled, b.created_at, b.updated_at, b.in_progress_at, b.completed_at, b.cancelled_at, b.cancelling_at, b.finalizing_at, b.failed_at, b.expires_at, b.expired_at, b.scheduled_at, b.model_alias, t.name AS tenant_name, COUNT(*) OVER() AS total_count
 FROM batches b
 JOIN tenants t ON t.id = b.tenant_id
 WHERE ($1::uuid IS NULL OR b.tenant_id = $1)
@@ -566,6 +782,8 @@ type ListBatchesAdminRow struct {
 	FailedAt              pgtype.Timestamptz `json:"failed_at"`
 	ExpiresAt             pgtype.Timestamptz `json:"expires_at"`
 	ExpiredAt             pgtype.Timestamptz `json:"expired_at"`
+	ScheduledAt           pgtype.Timestamptz `json:"scheduled_at"`
+	ModelAlias            string             `json:"model_alias"`
 	TenantName            string             `json:"tenant_name"`
 	TotalCount            int64              `json:"total_count"`
 }
@@ -612,6 +830,8 @@ func (q *Queries) ListBatchesAdmin(ctx context.Context, arg ListBatchesAdminPara
 			&i.FailedAt,
 			&i.ExpiresAt,
 			&i.ExpiredAt,
+			&i.ScheduledAt,
+			&i.ModelAlias,
 			&i.TenantName,
 			&i.TotalCount,
 		); err != nil {
@@ -631,7 +851,7 @@ WITH anchor AS (
     FROM batches b
     WHERE b.id = $3::uuid
 )
-SELECT b.id, b.tenant_id, b.api_key_id, b.status, b.endpoint, b.input_file_id, b.result_file_id, b.error_file_id, b.errors, b.completion_window, b.max_concurrency, b.metadata, b.request_count_total, b.request_count_completed, b.request_count_failed, b.request_count_cancelled, b.created_at, b.updated_at, b.in_progress_at, b.completed_at, b.cancelled_at, b.cancelling_at, b.finalizing_at, b.failed_at, b.expires_at, b.expired_at
+SELECT b.id, b.tenant_id, b.api_key_id, b.status, b.endpoint, b.input_file_id, b.result_file_id, b.error_file_id, b.errors, b.completion_window, b.max_concurrency, b.metadata, b.request_count_total, b.request_count_completed, b.request_count_failed, b.request_count_cancelled, b.created_at, b.updated_at, b.in_progress_at, b.completed_at, b.cancelled_at, b.cancelling_at, b.finalizing_at, b.failed_at, b.expires_at, b.expired_at, b.scheduled_at, b.model_alias
 FROM batches b
 LEFT JOIN anchor a ON true
 WHERE b.tenant_id = $1
@@ -686,6 +906,63 @@ func (q *Queries) ListBatchesCursor(ctx context.Context, arg ListBatchesCursorPa
 			&i.FailedAt,
 			&i.ExpiresAt,
 			&i.ExpiredAt,
+			&i.ScheduledAt,
+			&i.ModelAlias,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFinishedBatchItems = `-- name: ListFinishedBatchItems :many
+SELECT id, batch_id, item_index, status, custom_id, input, response, error, created_at, started_at, completed_at
+FROM batch_items
+WHERE batch_id = $1
+  AND status IN ('completed', 'failed')
+  AND item_index > $2
+  AND item_index < COALESCE((
+      SELECT MIN(pending.item_index)
+      FROM batch_items pending
+      WHERE pending.batch_id = $1
+        AND pending.status NOT IN ('completed', 'failed')
+        AND pending.item_index > $2
+  ), 9223372036854775807)
+ORDER BY item_index
+LIMIT $3
+`
+
+type ListFinishedBatchItemsParams struct {
+	BatchID    pgtype.UUID `json:"batch_id"`
+	AfterIndex int64       `json:"after_index"`
+	RowLimit   int32       `json:"row_limit"`
+}
+
+func (q *Queries) ListFinishedBatchItems(ctx context.Context, arg ListFinishedBatchItemsParams) ([]BatchItem, error) {
+	rows, err := q.db.Query(ctx, listFinishedBatchItems, arg.BatchID, arg.AfterIndex, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []BatchItem{}
+	for rows.Next() {
+		var i BatchItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.BatchID,
+			&i.ItemIndex,
+			&i.Status,
+			&i.CustomID,
+			&i.Input,
+			&i.Response,
+			&i.Error,
+			&i.CreatedAt,
+			&i.StartedAt,
+			&i.CompletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -711,7 +988,7 @@ SET status = $2,
     errors = COALESCE($5::jsonb, errors),
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, tenant_id, api_key_id, status, endpoint, input_file_id, result_file_id, error_file_id, errors, completion_window, max_concurrency, metadata, request_count_total, request_count_completed, request_count_failed, request_count_cancelled, created_at, updated_at, in_progress_at, completed_at, cancelled_at, cancelling_at, finalizing_at, failed_at, expires_at, expired_at
+RETURNING id, tenant_id, api_key_id, status, endpoint, input_file_id, result_file_id, error_file_id, errors, completion_window, max_concurrency, metadata, request_count_total, request_count_completed, request_count_failed, request_count_cancelled, created_at, updated_at, in_progress_at, completed_at, cancelled_at, cancelling_at, finalizing_at, failed_at, expires_at, expired_at, scheduled_at, model_alias
 `
 
 type MarkBatchFinalStatusParams struct {
@@ -758,6 +1035,8 @@ func (q *Queries) MarkBatchFinalStatus(ctx context.Context, arg MarkBatchFinalSt
 		&i.FailedAt,
 		&i.ExpiresAt,
 		&i.ExpiredAt,
+		&i.ScheduledAt,
+		&i.ModelAlias,
 	)
 	return i, err
 }
@@ -768,7 +1047,7 @@ SET status = 'in_progress',
     in_progress_at = NOW(),
     updated_at = NOW()
 WHERE id = $1 AND status = 'validating'
-RETURNING id, tenant_id, api_key_id, status, endpoint, input_file_id, result_file_id, error_file_id, errors, completion_window, max_concurrency, metadata, request_count_total, request_count_completed, request_count_failed, request_count_cancelled, created_at, updated_at, in_progress_at, completed_at, cancelled_at, cancelling_at, finalizing_at, failed_at, expires_at, expired_at
+RETURNING id, tenant_id, api_key_id, status, endpoint, input_file_id, result_file_id, error_file_id, errors, completion_window, max_concurrency, metadata, request_count_total, request_count_completed, request_count_failed, request_count_cancelled, created_at, updated_at, in_progress_at, completed_at, cancelled_at, cancelling_at, finalizing_at, failed_at, expires_at, expired_at, scheduled_at, model_alias
 `
 
 func (q *Queries) MarkBatchInProgress(ctx context.Context, id pgtype.UUID) (Batch, error) {
@@ -801,16 +1080,50 @@ func (q *Queries) MarkBatchInProgress(ctx context.Context, id pgtype.UUID) (Batc
 		&i.FailedAt,
 		&i.ExpiresAt,
 		&i.ExpiredAt,
+		&i.ScheduledAt,
+		&i.ModelAlias,
 	)
 	return i, err
 }
 
+const releaseBatchItem = `-- name: ReleaseBatchItem :execrows
+UPDATE batch_items
+SET status = 'queued',
+    started_at = NULL
+WHERE id = $1
+  AND status = 'running'
+`
+
+func (q *Queries) ReleaseBatchItem(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, releaseBatchItem, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const requeueRunningBatchItems = `-- name: RequeueRunningBatchItems :execrows
+UPDATE batch_items
+SET status = 'queued',
+    started_at = NULL
+WHERE batch_id = $1
+  AND status = 'running'
+`
+
+func (q *Queries) RequeueRunningBatchItems(ctx context.Context, batchID pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, requeueRunningBatchItems, batchID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateBatchCounts = `-- name: UpdateBatchCounts :one
 UPDATE batches
 SET request_count_total = request_count_total + $2,
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, tenant_id, api_key_id, status, endpoint, input_file_id, result_file_id, error_file_id, errors, completion_window, max_concurrency, metadata, request_count_total, request_count_completed, request_count_failed, request_count_cancelled, created_at, updated_at, in_progress_at, completed_at, cancelled_at, cancelling_at, finalizing_at, failed_at, expires_at, expired_at
+RETURNING id, tenant_id, api_key_id, status, endpoint, input_file_id, result_file_id, error_file_id, errors, completion_window, max_concurrency, metadata, request_count_total, request_count_completed, request_count_failed, request_count_cancelled, created_at, updated_at, in_progress_at, completed_at, cancelled_at, cancelling_at, finalizing_at, failed_at, expires_at, expired_at, scheduled_at, model_alias
 `
 
 type UpdateBatchCountsParams struct {
@@ -848,6 +1161,8 @@ func (q *Queries) UpdateBatchCounts(ctx context.Context, arg UpdateBatchCountsPa
 		&i.FailedAt,
 		&i.ExpiresAt,
 		&i.ExpiredAt,
+		&i.ScheduledAt,
+		&i.ModelAlias,
 	)
 	return i, err
 }
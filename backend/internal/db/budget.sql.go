@@ -68,7 +68,10 @@ SELECT tenant_id,
        last_alert_at,
        last_alert_level,
        created_at,
-       updated_at
+       updated_at,
+       usage_alert_config,
+       enforcement_mode,
+       alert_webhook_secret
 FROM tenant_budget_overrides
 WHERE tenant_id = $1
 `
@@ -88,6 +91,9 @@ func (q *Queries) GetTenantBudgetOverride(ctx context.Context, tenantID pgtype.U
 		&i.LastAlertLevel,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.UsageAlertConfig,
+		&i.EnforcementMode,
+		&i.AlertWebhookSecret,
 	)
 	return i, err
 }
@@ -136,7 +142,10 @@ SELECT tenant_id,
        last_alert_at,
        last_alert_level,
        created_at,
-       updated_at
+       updated_at,
+       usage_alert_config,
+       enforcement_mode,
+       alert_webhook_secret
 FROM tenant_budget_overrides
 ORDER BY created_at DESC
 `
@@ -162,6 +171,64 @@ func (q *Queries) ListTenantBudgetOverrides(ctx context.Context) ([]TenantBudget
 			&i.LastAlertLevel,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.UsageAlertConfig,
+			&i.EnforcementMode,
+			&i.AlertWebhookSecret,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTenantBudgetSpend = `-- name: ListTenantBudgetSpend :many
+SELECT
+    t.id AS tenant_id,
+    t.name,
+    COALESCE(o.budget_usd, 0)::numeric AS budget_usd,
+    COALESCE(o.warning_threshold, 0)::numeric AS warning_threshold,
+    COALESCE(SUM(u.cost_cents), 0)::bigint AS spent_cents
+FROM tenants t
+JOIN usage_records u ON u.tenant_id = t.id
+LEFT JOIN tenant_budget_overrides o ON o.tenant_id = t.id
+WHERE t.status = 'active'
+  AND u.ts >= $1
+  AND u.ts < $2
+GROUP BY t.id, t.name, o.budget_usd, o.warning_threshold
+`
+
+type ListTenantBudgetSpendParams struct {
+	Ts   pgtype.Timestamptz `json:"ts"`
+	Ts_2 pgtype.Timestamptz `json:"ts_2"`
+}
+
+type ListTenantBudgetSpendRow struct {
+	TenantID         pgtype.UUID     `json:"tenant_id"`
+	Name             string          `json:"name"`
+	BudgetUsd        decimal.Decimal `json:"budget_usd"`
+	WarningThreshold decimal.Decimal `json:"warning_threshold"`
+	SpentCents       int64           `json:"spent_cents"`
+}
+
+func (q *Queries) ListTenantBudgetSpend(ctx context.Context, arg ListTenantBudgetSpendParams) ([]ListTenantBudgetSpendRow, error) {
+	rows, err := q.db.Query(ctx, listTenantBudgetSpend, arg.Ts, arg.Ts_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTenantBudgetSpendRow{}
+	for rows.Next() {
+		var i ListTenantBudgetSpendRow
+		if err := rows.Scan(
+			&i.TenantID,
+			&i.Name,
+			&i.BudgetUsd,
+			&i.WarningThreshold,
+			&i.SpentCents,
 		); err != nil {
 			return nil, err
 		}
@@ -192,6 +259,54 @@ func (q *Queries) UpdateTenantBudgetAlertState(ctx context.Context, arg UpdateTe
 	return err
 }
 
+const updateTenantBudgetEnforcementMode = `-- name: UpdateTenantBudgetEnforcementMode :one
+UPDATE tenant_budget_overrides
+SET enforcement_mode = $2,
+    updated_at = NOW()
+WHERE tenant_id = $1
+RETURNING tenant_id,
+          budget_usd,
+          warning_threshold,
+          refresh_schedule,
+          alert_emails,
+          alert_webhooks,
+          alert_cooldown_seconds,
+          last_alert_at,
+          last_alert_level,
+          created_at,
+          updated_at,
+          usage_alert_config,
+          enforcement_mode,
+          alert_webhook_secret
+`
+
+type UpdateTenantBudgetEnforcementModeParams struct {
+	TenantID        pgtype.UUID `json:"tenant_id"`
+	EnforcementMode string      `json:"enforcement_mode"`
+}
+
+func (q *Queries) UpdateTenantBudgetEnforcementMode(ctx context.Context, arg UpdateTenantBudgetEnforcementModeParams) (TenantBudgetOverride, error) {
+	row := q.db.QueryRow(ctx, updateTenantBudgetEnforcementMode, arg.TenantID, arg.EnforcementMode)
+	var i TenantBudgetOverride
+	err := row.Scan(
+		&i.TenantID,
+		&i.BudgetUsd,
+		&i.WarningThreshold,
+		&i.RefreshSchedule,
+		&i.AlertEmails,
+		&i.AlertWebhooks,
+		&i.AlertCooldownSeconds,
+		&i.LastAlertAt,
+		&i.LastAlertLevel,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UsageAlertConfig,
+		&i.EnforcementMode,
+		&i.AlertWebhookSecret,
+	)
+	return i, err
+}
+
 const upsertBudgetDefaults = `-- name: UpsertBudgetDefaults :one
 INSERT INTO budget_defaults (
     id,
@@ -275,9 +390,12 @@ INSERT INTO tenant_budget_overrides (
     refresh_schedule,
     alert_emails,
     alert_webhooks,
-    alert_cooldown_seconds
+    alert_cooldown_seconds,
+    usage_alert_config,
+    enforcement_mode,
+    alert_webhook_secret
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, COALESCE($9::text, 'hard'), COALESCE($10::text, ''))
 ON CONFLICT (tenant_id) DO UPDATE
 SET budget_usd = EXCLUDED.budget_usd,
     warning_threshold = EXCLUDED.warning_threshold,
@@ -285,6 +403,9 @@ SET budget_usd = EXCLUDED.budget_usd,
     alert_emails = EXCLUDED.alert_emails,
     alert_webhooks = EXCLUDED.alert_webhooks,
     alert_cooldown_seconds = EXCLUDED.alert_cooldown_seconds,
+    usage_alert_config = COALESCE(EXCLUDED.usage_alert_config, tenant_budget_overrides.usage_alert_config),
+    enforcement_mode = COALESCE($9::text, tenant_budget_overrides.enforcement_mode),
+    alert_webhook_secret = COALESCE($10::text, tenant_budget_overrides.alert_webhook_secret),
     updated_at = NOW()
 RETURNING tenant_id,
           budget_usd,
@@ -296,7 +417,10 @@ RETURNING tenant_id,
           last_alert_at,
           last_alert_level,
           created_at,
-          updated_at
+          updated_at,
+          usage_alert_config,
+          enforcement_mode,
+          alert_webhook_secret
 `
 
 type UpsertTenantBudgetOverrideParams struct {
@@ -307,6 +431,9 @@ type UpsertTenantBudgetOverrideParams struct {
 	AlertEmails          []string        `json:"alert_emails"`
 	AlertWebhooks        []string        `json:"alert_webhooks"`
 	AlertCooldownSeconds int32           `json:"alert_cooldown_seconds"`
+	UsageAlertConfig     []byte          `json:"usage_alert_config"`
+	EnforcementMode      pgtype.Text     `json:"enforcement_mode"`
+	AlertWebhookSecret   pgtype.Text     `json:"alert_webhook_secret"`
 }
 
 func (q *Queries) UpsertTenantBudgetOverride(ctx context.Context, arg UpsertTenantBudgetOverrideParams) (TenantBudgetOverride, error) {
@@ -318,6 +445,9 @@ func (q *Queries) UpsertTenantBudgetOverride(ctx context.Context, arg UpsertTena
 		arg.AlertEmails,
 		arg.AlertWebhooks,
 		arg.AlertCooldownSeconds,
+		arg.UsageAlertConfig,
+		arg.EnforcementMode,
+		arg.AlertWebhookSecret,
 	)
 	var i TenantBudgetOverride
 	err := row.Scan(
@@ -332,6 +462,9 @@ func (q *Queries) UpsertTenantBudgetOverride(ctx context.Context, arg UpsertTena
 		&i.LastAlertLevel,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.UsageAlertConfig,
+		&i.EnforcementMode,
+		&i.AlertWebhookSecret,
 	)
 	return i, err
 }
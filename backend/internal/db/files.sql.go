@@ -101,6 +101,32 @@ func (q *Queries) DeleteFile(ctx context.Context, arg DeleteFileParams) error {
 	return err
 }
 
+const deleteFilesByTenant = `-- name: DeleteFilesByTenant :many
+DELETE FROM files
+WHERE tenant_id = $1
+RETURNING storage_key
+`
+
+func (q *Queries) DeleteFilesByTenant(ctx context.Context, tenantID pgtype.UUID) ([]string, error) {
+	rows, err := q.db.Query(ctx, deleteFilesByTenant, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var storage_key string
+		if err := rows.Scan(&storage_key); err != nil {
+			return nil, err
+		}
+		items = append(items, storage_key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getFile = `-- name: GetFile :one
 SELECT id, tenant_id, filename, purpose, content_type, bytes, storage_backend, storage_key, checksum, encrypted, metadata, expires_at, created_at, deleted_at, status, status_details, status_updated_at
 FROM files
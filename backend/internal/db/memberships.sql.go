@@ -36,6 +36,19 @@ func (q *Queries) AddTenantMembership(ctx context.Context, arg AddTenantMembersh
 	return i, err
 }
 
+const deleteUserMemberships = `-- name: DeleteUserMemberships :execrows
+DELETE FROM tenant_memberships
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteUserMemberships(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteUserMemberships, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getTenantMembership = `-- name: GetTenantMembership :one
 SELECT id, tenant_id, user_id, role, created_at
 FROM tenant_memberships
@@ -116,6 +129,70 @@ func (q *Queries) ListTenantMembers(ctx context.Context, tenantID pgtype.UUID) (
 	return items, nil
 }
 
+const listTenantMembersForExport = `-- name: ListTenantMembersForExport :many
+SELECT
+    tm.id,
+    tm.tenant_id,
+    tm.user_id,
+    tm.role,
+    tm.created_at,
+    u.email AS user_email,
+    u.name AS user_name,
+    u.created_at AS user_created_at
+FROM tenant_memberships tm
+JOIN users u ON u.id = tm.user_id
+WHERE tm.tenant_id = $1
+  AND tm.id > $2::uuid
+ORDER BY tm.id
+LIMIT $3
+`
+
+type ListTenantMembersForExportParams struct {
+	TenantID pgtype.UUID `json:"tenant_id"`
+	AfterID  pgtype.UUID `json:"after_id"`
+	RowLimit int32       `json:"row_limit"`
+}
+
+type ListTenantMembersForExportRow struct {
+	ID            pgtype.UUID        `json:"id"`
+	TenantID      pgtype.UUID        `json:"tenant_id"`
+	UserID        pgtype.UUID        `json:"user_id"`
+	Role          MembershipRole     `json:"role"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	UserEmail     string             `json:"user_email"`
+	UserName      string             `json:"user_name"`
+	UserCreatedAt pgtype.Timestamptz `json:"user_created_at"`
+}
+
+func (q *Queries) ListTenantMembersForExport(ctx context.Context, arg ListTenantMembersForExportParams) ([]ListTenantMembersForExportRow, error) {
+	rows, err := q.db.Query(ctx, listTenantMembersForExport, arg.TenantID, arg.AfterID, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTenantMembersForExportRow{}
+	for rows.Next() {
+		var i ListTenantMembersForExportRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.UserID,
+			&i.Role,
+			&i.CreatedAt,
+			&i.UserEmail,
+			&i.UserName,
+			&i.UserCreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listUserTenants = `-- name: ListUserTenants :many
 SELECT
     tm.id,
@@ -8,6 +8,7 @@ package db
 import (
 	"context"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	decimal "github.com/shopspring/decimal"
 )
 
@@ -21,8 +22,38 @@ func (q *Queries) DeleteModelCatalogEntry(ctx context.Context, alias string) err
 	return err
 }
 
+const disableDeprecatedModels = `-- name: DisableDeprecatedModels :many
+UPDATE model_catalog
+SET enabled = false,
+    updated_at = NOW()
+WHERE enabled = true
+  AND deprecated_at IS NOT NULL
+  AND deprecated_at <= $1
+RETURNING alias
+`
+
+func (q *Queries) DisableDeprecatedModels(ctx context.Context, deprecatedAt pgtype.Timestamptz) ([]string, error) {
+	rows, err := q.db.Query(ctx, disableDeprecatedModels, deprecatedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var alias string
+		if err := rows.Scan(&alias); err != nil {
+			return nil, err
+		}
+		items = append(items, alias)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getModelByAlias = `-- name: GetModelByAlias :one
-SELECT alias, provider, provider_model, model_type, context_window, max_output_tokens, modalities_json, supports_tools, price_input, price_output, currency, enabled, provider_config_json, updated_at, deployment, endpoint, api_key, api_version, region, metadata_json, weight
+SELECT alias, provider, provider_model, model_type, context_window, max_output_tokens, modalities_json, supports_tools, price_input, price_output, currency, enabled, provider_config_json, updated_at, deployment, endpoint, api_key, api_version, region, metadata_json, weight, routing_policy, traffic_split_json, max_dimensions, mirror_alias, mirror_sample_rate, deprecated_at, deprecation_message, supports_vision, fallback_vision_alias, cached_price_ratio
 FROM model_catalog
 WHERE alias = $1
 `
@@ -52,12 +83,127 @@ func (q *Queries) GetModelByAlias(ctx context.Context, alias string) (ModelCatal
 		&i.Region,
 		&i.MetadataJson,
 		&i.Weight,
+		&i.RoutingPolicy,
+		&i.TrafficSplitJson,
+		&i.MaxDimensions,
+		&i.MirrorAlias,
+		&i.MirrorSampleRate,
+		&i.DeprecatedAt,
+		&i.DeprecationMessage,
+		&i.SupportsVision,
+		&i.FallbackVisionAlias,
+		&i.CachedPriceRatio,
 	)
 	return i, err
 }
 
+const getModelByAliasForUpdate = `-- name: GetModelByAliasForUpdate :one
+SELECT alias, provider, provider_model, model_type, context_window, max_output_tokens, modalities_json, supports_tools, price_input, price_output, currency, enabled, provider_config_json, updated_at, deployment, endpoint, api_key, api_version, region, metadata_json, weight, routing_policy, traffic_split_json, max_dimensions, mirror_alias, mirror_sample_rate, deprecated_at, deprecation_message, supports_vision, fallback_vision_alias, cached_price_ratio
+FROM model_catalog
+WHERE alias = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetModelByAliasForUpdate(ctx context.Context, alias string) (ModelCatalog, error) {
+	row := q.db.QueryRow(ctx, getModelByAliasForUpdate, alias)
+	var i ModelCatalog
+	err := row.Scan(
+		&i.Alias,
+		&i.Provider,
+		&i.ProviderModel,
+		&i.ModelType,
+		&i.ContextWindow,
+		&i.MaxOutputTokens,
+		&i.ModalitiesJson,
+		&i.SupportsTools,
+		&i.PriceInput,
+		&i.PriceOutput,
+		&i.Currency,
+		&i.Enabled,
+		&i.ProviderConfigJson,
+		&i.UpdatedAt,
+		&i.Deployment,
+		&i.Endpoint,
+		&i.ApiKey,
+		&i.ApiVersion,
+		&i.Region,
+		&i.MetadataJson,
+		&i.Weight,
+		&i.RoutingPolicy,
+		&i.TrafficSplitJson,
+		&i.MaxDimensions,
+		&i.MirrorAlias,
+		&i.MirrorSampleRate,
+		&i.DeprecatedAt,
+		&i.DeprecationMessage,
+		&i.SupportsVision,
+		&i.FallbackVisionAlias,
+		&i.CachedPriceRatio,
+	)
+	return i, err
+}
+
+const listDeprecatedModels = `-- name: ListDeprecatedModels :many
+SELECT alias, provider, provider_model, model_type, context_window, max_output_tokens, modalities_json, supports_tools, price_input, price_output, currency, enabled, provider_config_json, updated_at, deployment, endpoint, api_key, api_version, region, metadata_json, weight, routing_policy, traffic_split_json, max_dimensions, mirror_alias, mirror_sample_rate, deprecated_at, deprecation_message, supports_vision, fallback_vision_alias, cached_price_ratio
+FROM model_catalog
+WHERE deprecated_at IS NOT NULL
+ORDER BY deprecated_at, alias
+`
+
+func (q *Queries) ListDeprecatedModels(ctx context.Context) ([]ModelCatalog, error) {
+	rows, err := q.db.Query(ctx, listDeprecatedModels)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ModelCatalog{}
+	for rows.Next() {
+		var i ModelCatalog
+		if err := rows.Scan(
+			&i.Alias,
+			&i.Provider,
+			&i.ProviderModel,
+			&i.ModelType,
+			&i.ContextWindow,
+			&i.MaxOutputTokens,
+			&i.ModalitiesJson,
+			&i.SupportsTools,
+			&i.PriceInput,
+			&i.PriceOutput,
+			&i.Currency,
+			&i.Enabled,
+			&i.ProviderConfigJson,
+			&i.UpdatedAt,
+			&i.Deployment,
+			&i.Endpoint,
+			&i.ApiKey,
+			&i.ApiVersion,
+			&i.Region,
+			&i.MetadataJson,
+			&i.Weight,
+			&i.RoutingPolicy,
+			&i.TrafficSplitJson,
+			&i.MaxDimensions,
+			&i.MirrorAlias,
+			&i.MirrorSampleRate,
+			&i.DeprecatedAt,
+			&i.DeprecationMessage,
+			&i.SupportsVision,
+			&i.FallbackVisionAlias,
+			&i.CachedPriceRatio,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listEnabledModels = `-- name: ListEnabledModels :many
-SELECT alias, provider, provider_model, model_type, context_window, max_output_tokens, modalities_json, supports_tools, price_input, price_output, currency, enabled, provider_config_json, updated_at, deployment, endpoint, api_key, api_version, region, metadata_json, weight
+SELECT alias, provider, provider_model, model_type, context_window, max_output_tokens, modalities_json, supports_tools, price_input, price_output, currency, enabled, provider_config_json, updated_at, deployment, endpoint, api_key, api_version, region, metadata_json, weight, routing_policy, traffic_split_json, max_dimensions, mirror_alias, mirror_sample_rate, deprecated_at, deprecation_message, supports_vision, fallback_vision_alias, cached_price_ratio
 FROM model_catalog
 WHERE enabled = true
 ORDER BY alias
@@ -94,6 +240,16 @@ func (q *Queries) ListEnabledModels(ctx context.Context) ([]ModelCatalog, error)
 			&i.Region,
 			&i.MetadataJson,
 			&i.Weight,
+			&i.RoutingPolicy,
+			&i.TrafficSplitJson,
+			&i.MaxDimensions,
+			&i.MirrorAlias,
+			&i.MirrorSampleRate,
+			&i.DeprecatedAt,
+			&i.DeprecationMessage,
+			&i.SupportsVision,
+			&i.FallbackVisionAlias,
+			&i.CachedPriceRatio,
 		); err != nil {
 			return nil, err
 		}
@@ -106,7 +262,7 @@ func (q *Queries) ListEnabledModels(ctx context.Context) ([]ModelCatalog, error)
 }
 
 const listModelCatalog = `-- name: ListModelCatalog :many
-SELECT alias, provider, provider_model, model_type, context_window, max_output_tokens, modalities_json, supports_tools, price_input, price_output, currency, enabled, provider_config_json, updated_at, deployment, endpoint, api_key, api_version, region, metadata_json, weight
+SELECT alias, provider, provider_model, model_type, context_window, max_output_tokens, modalities_json, supports_tools, price_input, price_output, currency, enabled, provider_config_json, updated_at, deployment, endpoint, api_key, api_version, region, metadata_json, weight, routing_policy, traffic_split_json, max_dimensions, mirror_alias, mirror_sample_rate, deprecated_at, deprecation_message, supports_vision, fallback_vision_alias, cached_price_ratio
 FROM model_catalog
 ORDER BY alias
 `
@@ -142,6 +298,16 @@ func (q *Queries) ListModelCatalog(ctx context.Context) ([]ModelCatalog, error)
 			&i.Region,
 			&i.MetadataJson,
 			&i.Weight,
+			&i.RoutingPolicy,
+			&i.TrafficSplitJson,
+			&i.MaxDimensions,
+			&i.MirrorAlias,
+			&i.MirrorSampleRate,
+			&i.DeprecatedAt,
+			&i.DeprecationMessage,
+			&i.SupportsVision,
+			&i.FallbackVisionAlias,
+			&i.CachedPriceRatio,
 		); err != nil {
 			return nil, err
 		}
@@ -154,7 +320,7 @@ func (q *Queries) ListModelCatalog(ctx context.Context) ([]ModelCatalog, error)
 }
 
 const listModelCatalogByAliases = `-- name: ListModelCatalogByAliases :many
-SELECT alias, provider, provider_model, model_type, context_window, max_output_tokens, modalities_json, supports_tools, price_input, price_output, currency, enabled, provider_config_json, updated_at, deployment, endpoint, api_key, api_version, region, metadata_json, weight
+SELECT alias, provider, provider_model, model_type, context_window, max_output_tokens, modalities_json, supports_tools, price_input, price_output, currency, enabled, provider_config_json, updated_at, deployment, endpoint, api_key, api_version, region, metadata_json, weight, routing_policy, traffic_split_json, max_dimensions, mirror_alias, mirror_sample_rate, deprecated_at, deprecation_message, supports_vision, fallback_vision_alias, cached_price_ratio
 FROM model_catalog
 WHERE alias = ANY($1::text[])
 `
@@ -190,6 +356,16 @@ func (q *Queries) ListModelCatalogByAliases(ctx context.Context, dollar_1 []stri
 			&i.Region,
 			&i.MetadataJson,
 			&i.Weight,
+			&i.RoutingPolicy,
+			&i.TrafficSplitJson,
+			&i.MaxDimensions,
+			&i.MirrorAlias,
+			&i.MirrorSampleRate,
+			&i.DeprecatedAt,
+			&i.DeprecationMessage,
+			&i.SupportsVision,
+			&i.FallbackVisionAlias,
+			&i.CachedPriceRatio,
 		); err != nil {
 			return nil, err
 		}
@@ -201,6 +377,60 @@ func (q *Queries) ListModelCatalogByAliases(ctx context.Context, dollar_1 []stri
 	return items, nil
 }
 
+const setModelDeprecation = `-- name: SetModelDeprecation :one
+UPDATE model_catalog
+SET deprecated_at = $2,
+    deprecation_message = $3,
+    updated_at = NOW()
+WHERE alias = $1
+RETURNING alias, provider, provider_model, model_type, context_window, max_output_tokens, modalities_json, supports_tools, price_input, price_output, currency, enabled, provider_config_json, updated_at, deployment, endpoint, api_key, api_version, region, metadata_json, weight, routing_policy, traffic_split_json, max_dimensions, mirror_alias, mirror_sample_rate, deprecated_at, deprecation_message, supports_vision, fallback_vision_alias, cached_price_ratio
+`
+
+type SetModelDeprecationParams struct {
+	Alias              string             `json:"alias"`
+	DeprecatedAt       pgtype.Timestamptz `json:"deprecated_at"`
+	DeprecationMessage string             `json:"deprecation_message"`
+}
+
+func (q *Queries) SetModelDeprecation(ctx context.Context, arg SetModelDeprecationParams) (ModelCatalog, error) {
+	row := q.db.QueryRow(ctx, setModelDeprecation, arg.Alias, arg.DeprecatedAt, arg.DeprecationMessage)
+	var i ModelCatalog
+	err := row.Scan(
+		&i.Alias,
+		&i.Provider,
+		&i.ProviderModel,
+		&i.ModelType,
+		&i.ContextWindow,
+		&i.MaxOutputTokens,
+		&i.ModalitiesJson,
+		&i.SupportsTools,
+		&i.PriceInput,
+		&i.PriceOutput,
+		&i.Currency,
+		&i.Enabled,
+		&i.ProviderConfigJson,
+		&i.UpdatedAt,
+		&i.Deployment,
+		&i.Endpoint,
+		&i.ApiKey,
+		&i.ApiVersion,
+		&i.Region,
+		&i.MetadataJson,
+		&i.Weight,
+		&i.RoutingPolicy,
+		&i.TrafficSplitJson,
+		&i.MaxDimensions,
+		&i.MirrorAlias,
+		&i.MirrorSampleRate,
+		&i.DeprecatedAt,
+		&i.DeprecationMessage,
+		&i.SupportsVision,
+		&i.FallbackVisionAlias,
+		&i.CachedPriceRatio,
+	)
+	return i, err
+}
+
 const upsertModelCatalogEntry = `-- name: UpsertModelCatalogEntry :one
 INSERT INTO model_catalog (
     alias,
@@ -222,9 +452,19 @@ INSERT INTO model_catalog (
     region,
     metadata_json,
     weight,
-    provider_config_json
+    provider_config_json,
+    routing_policy,
+    traffic_split_json,
+    max_dimensions,
+    mirror_alias,
+    mirror_sample_rate,
+    deprecated_at,
+    deprecation_message,
+    supports_vision,
+    fallback_vision_alias,
+    cached_price_ratio
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30)
 ON CONFLICT (alias)
 DO UPDATE SET
     provider = EXCLUDED.provider,
@@ -246,31 +486,51 @@ DO UPDATE SET
     metadata_json = EXCLUDED.metadata_json,
     weight = EXCLUDED.weight,
     provider_config_json = EXCLUDED.provider_config_json,
+    routing_policy = EXCLUDED.routing_policy,
+    traffic_split_json = EXCLUDED.traffic_split_json,
+    max_dimensions = EXCLUDED.max_dimensions,
+    mirror_alias = EXCLUDED.mirror_alias,
+    mirror_sample_rate = EXCLUDED.mirror_sample_rate,
+    deprecated_at = EXCLUDED.deprecated_at,
+    deprecation_message = EXCLUDED.deprecation_message,
+    supports_vision = EXCLUDED.supports_vision,
+    fallback_vision_alias = EXCLUDED.fallback_vision_alias,
+    cached_price_ratio = EXCLUDED.cached_price_ratio,
     updated_at = NOW()
-RETURNING alias, provider, provider_model, model_type, context_window, max_output_tokens, modalities_json, supports_tools, price_input, price_output, currency, enabled, provider_config_json, updated_at, deployment, endpoint, api_key, api_version, region, metadata_json, weight
+RETURNING alias, provider, provider_model, model_type, context_window, max_output_tokens, modalities_json, supports_tools, price_input, price_output, currency, enabled, provider_config_json, updated_at, deployment, endpoint, api_key, api_version, region, metadata_json, weight, routing_policy, traffic_split_json, max_dimensions, mirror_alias, mirror_sample_rate, deprecated_at, deprecation_message, supports_vision, fallback_vision_alias, cached_price_ratio
 `
 
 type UpsertModelCatalogEntryParams struct {
-	Alias              string          `json:"alias"`
-	Provider           string          `json:"provider"`
-	ProviderModel      string          `json:"provider_model"`
-	ModelType          string          `json:"model_type"`
-	ContextWindow      int32           `json:"context_window"`
-	MaxOutputTokens    int32           `json:"max_output_tokens"`
-	ModalitiesJson     []byte          `json:"modalities_json"`
-	SupportsTools      bool            `json:"supports_tools"`
-	PriceInput         decimal.Decimal `json:"price_input"`
-	PriceOutput        decimal.Decimal `json:"price_output"`
-	Currency           string          `json:"currency"`
-	Enabled            bool            `json:"enabled"`
-	Deployment         string          `json:"deployment"`
-	Endpoint           string          `json:"endpoint"`
-	ApiKey             string          `json:"api_key"`
-	ApiVersion         string          `json:"api_version"`
-	Region             string          `json:"region"`
-	MetadataJson       []byte          `json:"metadata_json"`
-	Weight             int32           `json:"weight"`
-	ProviderConfigJson []byte          `json:"provider_config_json"`
+	Alias               string             `json:"alias"`
+	Provider            string             `json:"provider"`
+	ProviderModel       string             `json:"provider_model"`
+	ModelType           string             `json:"model_type"`
+	ContextWindow       int32              `json:"context_window"`
+	MaxOutputTokens     int32              `json:"max_output_tokens"`
+	ModalitiesJson      []byte             `json:"modalities_json"`
+	SupportsTools       bool               `json:"supports_tools"`
+	PriceInput          decimal.Decimal    `json:"price_input"`
+	PriceOutput         decimal.Decimal    `json:"price_output"`
+	Currency            string             `json:"currency"`
+	Enabled             bool               `json:"enabled"`
+	Deployment          string             `json:"deployment"`
+	Endpoint            string             `json:"endpoint"`
+	ApiKey              string             `json:"api_key"`
+	ApiVersion          string             `json:"api_version"`
+	Region              string             `json:"region"`
+	MetadataJson        []byte             `json:"metadata_json"`
+	Weight              int32              `json:"weight"`
+	ProviderConfigJson  []byte             `json:"provider_config_json"`
+	RoutingPolicy       string             `json:"routing_policy"`
+	TrafficSplitJson    []byte             `json:"traffic_split_json"`
+	MaxDimensions       int32              `json:"max_dimensions"`
+	MirrorAlias         string             `json:"mirror_alias"`
+	MirrorSampleRate    decimal.Decimal    `json:"mirror_sample_rate"`
+	DeprecatedAt        pgtype.Timestamptz `json:"deprecated_at"`
+	DeprecationMessage  string             `json:"deprecation_message"`
+	SupportsVision      bool               `json:"supports_vision"`
+	FallbackVisionAlias string             `json:"fallback_vision_alias"`
+	CachedPriceRatio    decimal.Decimal    `json:"cached_price_ratio"`
 }
 
 func (q *Queries) UpsertModelCatalogEntry(ctx context.Context, arg UpsertModelCatalogEntryParams) (ModelCatalog, error) {
@@ -295,6 +555,16 @@ func (q *Queries) UpsertModelCatalogEntry(ctx context.Context, arg UpsertModelCa
 		arg.MetadataJson,
 		arg.Weight,
 		arg.ProviderConfigJson,
+		arg.RoutingPolicy,
+		arg.TrafficSplitJson,
+		arg.MaxDimensions,
+		arg.MirrorAlias,
+		arg.MirrorSampleRate,
+		arg.DeprecatedAt,
+		arg.DeprecationMessage,
+		arg.SupportsVision,
+		arg.FallbackVisionAlias,
+		arg.CachedPriceRatio,
 	)
 	var i ModelCatalog
 	err := row.Scan(
@@ -319,6 +589,16 @@ func (q *Queries) UpsertModelCatalogEntry(ctx context.Context, arg UpsertModelCa
 		&i.Region,
 		&i.MetadataJson,
 		&i.Weight,
+		&i.RoutingPolicy,
+		&i.TrafficSplitJson,
+		&i.MaxDimensions,
+		&i.MirrorAlias,
+		&i.MirrorSampleRate,
+		&i.DeprecatedAt,
+		&i.DeprecationMessage,
+		&i.SupportsVision,
+		&i.FallbackVisionAlias,
+		&i.CachedPriceRatio,
 	)
 	return i, err
 }
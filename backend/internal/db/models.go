@@ -54,6 +54,50 @@ func (ns NullApiKeyKind) Value() (driver.Value, error) {
 	return string(ns.ApiKeyKind), nil
 }
 
+type ImageJobStatus string
+
+const (
+	ImageJobStatusPending    ImageJobStatus = "pending"
+	ImageJobStatusProcessing ImageJobStatus = "processing"
+	ImageJobStatusCompleted  ImageJobStatus = "completed"
+	ImageJobStatusFailed     ImageJobStatus = "failed"
+)
+
+func (e *ImageJobStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ImageJobStatus(s)
+	case string:
+		*e = ImageJobStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ImageJobStatus: %T", src)
+	}
+	return nil
+}
+
+type NullImageJobStatus struct {
+	ImageJobStatus ImageJobStatus `json:"image_job_status"`
+	Valid          bool           `json:"valid"` // Valid is true if ImageJobStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullImageJobStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ImageJobStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ImageJobStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullImageJobStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ImageJobStatus), nil
+}
+
 type MembershipRole string
 
 const (
@@ -98,6 +142,92 @@ func (ns NullMembershipRole) Value() (driver.Value, error) {
 	return string(ns.MembershipRole), nil
 }
 
+type NotificationSeverity string
+
+const (
+	NotificationSeverityInfo     NotificationSeverity = "info"
+	NotificationSeverityWarning  NotificationSeverity = "warning"
+	NotificationSeverityCritical NotificationSeverity = "critical"
+)
+
+func (e *NotificationSeverity) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = NotificationSeverity(s)
+	case string:
+		*e = NotificationSeverity(s)
+	default:
+		return fmt.Errorf("unsupported scan type for NotificationSeverity: %T", src)
+	}
+	return nil
+}
+
+type NullNotificationSeverity struct {
+	NotificationSeverity NotificationSeverity `json:"notification_severity"`
+	Valid                bool                 `json:"valid"` // Valid is true if NotificationSeverity is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullNotificationSeverity) Scan(value interface{}) error {
+	if value == nil {
+		ns.NotificationSeverity, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.NotificationSeverity.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullNotificationSeverity) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.NotificationSeverity), nil
+}
+
+type SystemPromptMode string
+
+const (
+	SystemPromptModePrepend SystemPromptMode = "prepend"
+	SystemPromptModeAppend  SystemPromptMode = "append"
+	SystemPromptModeReplace SystemPromptMode = "replace"
+)
+
+func (e *SystemPromptMode) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SystemPromptMode(s)
+	case string:
+		*e = SystemPromptMode(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SystemPromptMode: %T", src)
+	}
+	return nil
+}
+
+type NullSystemPromptMode struct {
+	SystemPromptMode SystemPromptMode `json:"system_prompt_mode"`
+	Valid            bool             `json:"valid"` // Valid is true if SystemPromptMode is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSystemPromptMode) Scan(value interface{}) error {
+	if value == nil {
+		ns.SystemPromptMode, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SystemPromptMode.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSystemPromptMode) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SystemPromptMode), nil
+}
+
 type TenantKind string
 
 const (
@@ -182,6 +312,50 @@ func (ns NullTenantStatus) Value() (driver.Value, error) {
 	return string(ns.TenantStatus), nil
 }
 
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+	WebhookDeliveryStatusDead      WebhookDeliveryStatus = "dead"
+)
+
+func (e *WebhookDeliveryStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = WebhookDeliveryStatus(s)
+	case string:
+		*e = WebhookDeliveryStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for WebhookDeliveryStatus: %T", src)
+	}
+	return nil
+}
+
+type NullWebhookDeliveryStatus struct {
+	WebhookDeliveryStatus WebhookDeliveryStatus `json:"webhook_delivery_status"`
+	Valid                 bool                  `json:"valid"` // Valid is true if WebhookDeliveryStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullWebhookDeliveryStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.WebhookDeliveryStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.WebhookDeliveryStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullWebhookDeliveryStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.WebhookDeliveryStatus), nil
+}
+
 type AdminAuditLog struct {
 	ID           pgtype.UUID        `json:"id"`
 	UserID       pgtype.UUID        `json:"user_id"`
@@ -192,28 +366,68 @@ type AdminAuditLog struct {
 	CreatedAt    pgtype.Timestamptz `json:"created_at"`
 }
 
-type ApiKey struct {
-	ID          pgtype.UUID        `json:"id"`
+type AdminTenantScope struct {
+	AdminUserID pgtype.UUID        `json:"admin_user_id"`
 	TenantID    pgtype.UUID        `json:"tenant_id"`
-	Prefix      string             `json:"prefix"`
-	SecretHash  string             `json:"secret_hash"`
-	Name        string             `json:"name"`
-	ScopesJson  []byte             `json:"scopes_json"`
-	QuotaJson   []byte             `json:"quota_json"`
-	Kind        ApiKeyKind         `json:"kind"`
-	OwnerUserID pgtype.UUID        `json:"owner_user_id"`
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
-	RevokedAt   pgtype.Timestamptz `json:"revoked_at"`
-	LastUsedAt  pgtype.Timestamptz `json:"last_used_at"`
+}
+
+type AdminToken struct {
+	ID         pgtype.UUID        `json:"id"`
+	UserID     pgtype.UUID        `json:"user_id"`
+	Name       string             `json:"name"`
+	Prefix     string             `json:"prefix"`
+	SecretHash string             `json:"secret_hash"`
+	Role       string             `json:"role"`
+	AllowedIps []string           `json:"allowed_ips"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+	LastUsedAt pgtype.Timestamptz `json:"last_used_at"`
+	RevokedAt  pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type ApiKey struct {
+	ID                    pgtype.UUID        `json:"id"`
+	TenantID              pgtype.UUID        `json:"tenant_id"`
+	Prefix                string             `json:"prefix"`
+	SecretHash            string             `json:"secret_hash"`
+	Name                  string             `json:"name"`
+	ScopesJson            []byte             `json:"scopes_json"`
+	QuotaJson             []byte             `json:"quota_json"`
+	Kind                  ApiKeyKind         `json:"kind"`
+	OwnerUserID           pgtype.UUID        `json:"owner_user_id"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at"`
+	RevokedAt             pgtype.Timestamptz `json:"revoked_at"`
+	LastUsedAt            pgtype.Timestamptz `json:"last_used_at"`
+	IsBootstrap           bool               `json:"is_bootstrap"`
+	InactiveWarningSentAt pgtype.Timestamptz `json:"inactive_warning_sent_at"`
+	ZeroRetention         bool               `json:"zero_retention"`
+	SuspendedAt           pgtype.Timestamptz `json:"suspended_at"`
+	SuspensionReason      string             `json:"suspension_reason"`
+	ReinstatedAt          pgtype.Timestamptz `json:"reinstated_at"`
 }
 
 type ApiKeyRateLimit struct {
-	ApiKeyID          pgtype.UUID        `json:"api_key_id"`
-	RequestsPerMinute int32              `json:"requests_per_minute"`
-	TokensPerMinute   int32              `json:"tokens_per_minute"`
-	ParallelRequests  int32              `json:"parallel_requests"`
-	CreatedAt         pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+	ApiKeyID                  pgtype.UUID        `json:"api_key_id"`
+	RequestsPerMinute         int32              `json:"requests_per_minute"`
+	TokensPerMinute           int32              `json:"tokens_per_minute"`
+	ParallelRequests          int32              `json:"parallel_requests"`
+	CreatedAt                 pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt                 pgtype.Timestamptz `json:"updated_at"`
+	ParallelRequestTtlSeconds int32              `json:"parallel_request_ttl_seconds"`
+}
+
+type ArchivedPartition struct {
+	ID          pgtype.UUID        `json:"id"`
+	Name        string             `json:"name"`
+	TableName   string             `json:"table_name"`
+	Period      string             `json:"period"`
+	PeriodStart pgtype.Timestamptz `json:"period_start"`
+	PeriodEnd   pgtype.Timestamptz `json:"period_end"`
+	ObjectKey   string             `json:"object_key"`
+	RowCount    int64              `json:"row_count"`
+	SizeBytes   int64              `json:"size_bytes"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 }
 
 type Batch struct {
@@ -243,6 +457,8 @@ type Batch struct {
 	FailedAt              pgtype.Timestamptz `json:"failed_at"`
 	ExpiresAt             pgtype.Timestamptz `json:"expires_at"`
 	ExpiredAt             pgtype.Timestamptz `json:"expired_at"`
+	ScheduledAt           pgtype.Timestamptz `json:"scheduled_at"`
+	ModelAlias            string             `json:"model_alias"`
 }
 
 type BatchItem struct {
@@ -284,6 +500,15 @@ type BudgetDefault struct {
 	UpdatedByUserID      pgtype.UUID        `json:"updated_by_user_id"`
 }
 
+type CurrencyRate struct {
+	FromCurrency  string             `json:"from_currency"`
+	ToCurrency    string             `json:"to_currency"`
+	EffectiveDate pgtype.Date        `json:"effective_date"`
+	Rate          decimal.Decimal    `json:"rate"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+}
+
 type DefaultModel struct {
 	Alias     string             `json:"alias"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
@@ -309,28 +534,105 @@ type File struct {
 	StatusUpdatedAt pgtype.Timestamptz `json:"status_updated_at"`
 }
 
+type GdprErasureRequest struct {
+	ID          pgtype.UUID        `json:"id"`
+	UserID      pgtype.UUID        `json:"user_id"`
+	Status      string             `json:"status"`
+	Error       pgtype.Text        `json:"error"`
+	RequestedAt pgtype.Timestamptz `json:"requested_at"`
+	CompletedAt pgtype.Timestamptz `json:"completed_at"`
+}
+
+type IdempotencyKey struct {
+	Key       string             `json:"key"`
+	Response  []byte             `json:"response"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+type ImageJob struct {
+	ID           pgtype.UUID        `json:"id"`
+	TenantID     pgtype.UUID        `json:"tenant_id"`
+	ApiKeyID     pgtype.UUID        `json:"api_key_id"`
+	ModelAlias   string             `json:"model_alias"`
+	Request      []byte             `json:"request"`
+	Status       ImageJobStatus     `json:"status"`
+	Result       []byte             `json:"result"`
+	ErrorMessage pgtype.Text        `json:"error_message"`
+	TraceID      string             `json:"trace_id"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+	CompletedAt  pgtype.Timestamptz `json:"completed_at"`
+	ExpiresAt    pgtype.Timestamptz `json:"expires_at"`
+}
+
 type ModelCatalog struct {
-	Alias              string             `json:"alias"`
-	Provider           string             `json:"provider"`
-	ProviderModel      string             `json:"provider_model"`
-	ModelType          string             `json:"model_type"`
-	ContextWindow      int32              `json:"context_window"`
-	MaxOutputTokens    int32              `json:"max_output_tokens"`
-	ModalitiesJson     []byte             `json:"modalities_json"`
-	SupportsTools      bool               `json:"supports_tools"`
-	PriceInput         decimal.Decimal    `json:"price_input"`
-	PriceOutput        decimal.Decimal    `json:"price_output"`
-	Currency           string             `json:"currency"`
-	Enabled            bool               `json:"enabled"`
-	ProviderConfigJson []byte             `json:"provider_config_json"`
-	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
-	Deployment         string             `json:"deployment"`
-	Endpoint           string             `json:"endpoint"`
-	ApiKey             string             `json:"api_key"`
-	ApiVersion         string             `json:"api_version"`
-	Region             string             `json:"region"`
-	MetadataJson       []byte             `json:"metadata_json"`
-	Weight             int32              `json:"weight"`
+	Alias               string             `json:"alias"`
+	Provider            string             `json:"provider"`
+	ProviderModel       string             `json:"provider_model"`
+	ModelType           string             `json:"model_type"`
+	ContextWindow       int32              `json:"context_window"`
+	MaxOutputTokens     int32              `json:"max_output_tokens"`
+	ModalitiesJson      []byte             `json:"modalities_json"`
+	SupportsTools       bool               `json:"supports_tools"`
+	PriceInput          decimal.Decimal    `json:"price_input"`
+	PriceOutput         decimal.Decimal    `json:"price_output"`
+	Currency            string             `json:"currency"`
+	Enabled             bool               `json:"enabled"`
+	ProviderConfigJson  []byte             `json:"provider_config_json"`
+	UpdatedAt           pgtype.Timestamptz `json:"updated_at"`
+	Deployment          string             `json:"deployment"`
+	Endpoint            string             `json:"endpoint"`
+	ApiKey              string             `json:"api_key"`
+	ApiVersion          string             `json:"api_version"`
+	Region              string             `json:"region"`
+	MetadataJson        []byte             `json:"metadata_json"`
+	Weight              int32              `json:"weight"`
+	RoutingPolicy       string             `json:"routing_policy"`
+	TrafficSplitJson    []byte             `json:"traffic_split_json"`
+	MaxDimensions       int32              `json:"max_dimensions"`
+	MirrorAlias         string             `json:"mirror_alias"`
+	MirrorSampleRate    decimal.Decimal    `json:"mirror_sample_rate"`
+	DeprecatedAt        pgtype.Timestamptz `json:"deprecated_at"`
+	DeprecationMessage  string             `json:"deprecation_message"`
+	SupportsVision      bool               `json:"supports_vision"`
+	FallbackVisionAlias string             `json:"fallback_vision_alias"`
+	CachedPriceRatio    decimal.Decimal    `json:"cached_price_ratio"`
+}
+
+type ModelPriceHistory struct {
+	ID              pgtype.UUID        `json:"id"`
+	Alias           string             `json:"alias"`
+	OldPriceInput   decimal.Decimal    `json:"old_price_input"`
+	NewPriceInput   decimal.Decimal    `json:"new_price_input"`
+	OldPriceOutput  decimal.Decimal    `json:"old_price_output"`
+	NewPriceOutput  decimal.Decimal    `json:"new_price_output"`
+	ChangedAt       pgtype.Timestamptz `json:"changed_at"`
+	ChangedByUserID pgtype.UUID        `json:"changed_by_user_id"`
+}
+
+type Notification struct {
+	ID         pgtype.UUID          `json:"id"`
+	Severity   NotificationSeverity `json:"severity"`
+	EntityType string               `json:"entity_type"`
+	EntityID   string               `json:"entity_id"`
+	Message    string               `json:"message"`
+	CreatedAt  pgtype.Timestamptz   `json:"created_at"`
+	ReadAt     pgtype.Timestamptz   `json:"read_at"`
+}
+
+type Permission struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type ProviderCredential struct {
+	Provider         string             `json:"provider"`
+	ApiKey           pgtype.Text        `json:"api_key"`
+	KeyHash          string             `json:"key_hash"`
+	RotatedAt        pgtype.Timestamptz `json:"rotated_at"`
+	Endpoint         string             `json:"endpoint"`
+	ApiKeyCiphertext pgtype.Text        `json:"api_key_ciphertext"`
 }
 
 type RateLimitDefault struct {
@@ -344,21 +646,56 @@ type RateLimitDefault struct {
 }
 
 type Request struct {
-	ID             pgtype.UUID        `json:"id"`
-	TenantID       pgtype.UUID        `json:"tenant_id"`
-	ApiKeyID       pgtype.UUID        `json:"api_key_id"`
-	Ts             pgtype.Timestamptz `json:"ts"`
-	ModelAlias     string             `json:"model_alias"`
-	Provider       string             `json:"provider"`
-	LatencyMs      int32              `json:"latency_ms"`
-	Status         int32              `json:"status"`
-	ErrorCode      pgtype.Text        `json:"error_code"`
-	InputTokens    int64              `json:"input_tokens"`
-	OutputTokens   int64              `json:"output_tokens"`
-	CostCents      int64              `json:"cost_cents"`
-	CostUsdMicros  int64              `json:"cost_usd_micros"`
-	IdempotencyKey pgtype.Text        `json:"idempotency_key"`
-	TraceID        pgtype.Text        `json:"trace_id"`
+	ID                  pgtype.UUID        `json:"id"`
+	TenantID            pgtype.UUID        `json:"tenant_id"`
+	ApiKeyID            pgtype.UUID        `json:"api_key_id"`
+	Ts                  pgtype.Timestamptz `json:"ts"`
+	ModelAlias          string             `json:"model_alias"`
+	Provider            string             `json:"provider"`
+	LatencyMs           int32              `json:"latency_ms"`
+	Status              int32              `json:"status"`
+	ErrorCode           pgtype.Text        `json:"error_code"`
+	InputTokens         int64              `json:"input_tokens"`
+	OutputTokens        int64              `json:"output_tokens"`
+	CostCents           int64              `json:"cost_cents"`
+	CostUsdMicros       int64              `json:"cost_usd_micros"`
+	IdempotencyKey      pgtype.Text        `json:"idempotency_key"`
+	TraceID             pgtype.Text        `json:"trace_id"`
+	AbVariant           pgtype.Text        `json:"ab_variant"`
+	TagsJson            []byte             `json:"tags_json"`
+	TraceParent         pgtype.Text        `json:"trace_parent"`
+	RequestMetadata     []byte             `json:"request_metadata"`
+	Fingerprint         pgtype.Text        `json:"fingerprint"`
+	CachedTokens        int64              `json:"cached_tokens"`
+	PriceInputSnapshot  decimal.Decimal    `json:"price_input_snapshot"`
+	PriceOutputSnapshot decimal.Decimal    `json:"price_output_snapshot"`
+}
+
+type RequestPayload struct {
+	RequestID    pgtype.UUID        `json:"request_id"`
+	TenantID     pgtype.UUID        `json:"tenant_id"`
+	RequestBody  []byte             `json:"request_body"`
+	ResponseBody []byte             `json:"response_body"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+type Response struct {
+	ID                 string             `json:"id"`
+	TenantID           pgtype.UUID        `json:"tenant_id"`
+	ApiKeyID           pgtype.UUID        `json:"api_key_id"`
+	ModelAlias         string             `json:"model_alias"`
+	PreviousResponseID pgtype.Text        `json:"previous_response_id"`
+	Messages           []byte             `json:"messages"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+}
+
+type Role struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Permissions []byte             `json:"permissions"`
+	Builtin     bool               `json:"builtin"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
 }
 
 type Route struct {
@@ -384,11 +721,12 @@ type SystemSetting struct {
 }
 
 type Tenant struct {
-	ID        pgtype.UUID        `json:"id"`
-	Name      string             `json:"name"`
-	Status    TenantStatus       `json:"status"`
-	Kind      TenantKind         `json:"kind"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	ID         pgtype.UUID        `json:"id"`
+	Name       string             `json:"name"`
+	Status     TenantStatus       `json:"status"`
+	Kind       TenantKind         `json:"kind"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	CostCenter string             `json:"cost_center"`
 }
 
 type TenantBudgetOverride struct {
@@ -403,6 +741,39 @@ type TenantBudgetOverride struct {
 	LastAlertLevel       pgtype.Text        `json:"last_alert_level"`
 	CreatedAt            pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
+	UsageAlertConfig     []byte             `json:"usage_alert_config"`
+	EnforcementMode      string             `json:"enforcement_mode"`
+	AlertWebhookSecret   string             `json:"alert_webhook_secret"`
+}
+
+type TenantExportJob struct {
+	ID                pgtype.UUID        `json:"id"`
+	TenantID          pgtype.UUID        `json:"tenant_id"`
+	Status            string             `json:"status"`
+	SectionsCompleted int32              `json:"sections_completed"`
+	SectionsTotal     int32              `json:"sections_total"`
+	RowsExported      int64              `json:"rows_exported"`
+	Error             pgtype.Text        `json:"error"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	StartedAt         pgtype.Timestamptz `json:"started_at"`
+	CompletedAt       pgtype.Timestamptz `json:"completed_at"`
+	StorageKey        pgtype.Text        `json:"storage_key"`
+	Bytes             int64              `json:"bytes"`
+	ExpiresAt         pgtype.Timestamptz `json:"expires_at"`
+	HeartbeatAt       pgtype.Timestamptz `json:"heartbeat_at"`
+}
+
+type TenantInvitation struct {
+	ID         pgtype.UUID        `json:"id"`
+	TenantID   pgtype.UUID        `json:"tenant_id"`
+	Email      string             `json:"email"`
+	Role       MembershipRole     `json:"role"`
+	TokenHash  string             `json:"token_hash"`
+	InvitedBy  pgtype.UUID        `json:"invited_by"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+	AcceptedAt pgtype.Timestamptz `json:"accepted_at"`
+	RevokedAt  pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
 }
 
 type TenantMembership struct {
@@ -413,33 +784,86 @@ type TenantMembership struct {
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
+type TenantMembershipRole struct {
+	TenantID  pgtype.UUID        `json:"tenant_id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	Role      string             `json:"role"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
 type TenantModel struct {
 	TenantID  pgtype.UUID        `json:"tenant_id"`
 	Alias     string             `json:"alias"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
+type TenantModelOverride struct {
+	TenantID                pgtype.UUID        `json:"tenant_id"`
+	ModelAlias              string             `json:"model_alias"`
+	ContextWindowOverride   int32              `json:"context_window_override"`
+	MaxOutputTokensOverride int32              `json:"max_output_tokens_override"`
+	CreatedAt               pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt               pgtype.Timestamptz `json:"updated_at"`
+}
+
+type TenantParent struct {
+	TenantID       pgtype.UUID        `json:"tenant_id"`
+	ParentTenantID pgtype.UUID        `json:"parent_tenant_id"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+type TenantQuotaOverride struct {
+	TenantID             pgtype.UUID        `json:"tenant_id"`
+	MaxRequestsPerPeriod int64              `json:"max_requests_per_period"`
+	RefreshSchedule      string             `json:"refresh_schedule"`
+	CreatedAt            pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
+	MaxRequestBodyMb     int32              `json:"max_request_body_mb"`
+	StreamIdleTimeoutSec int32              `json:"stream_idle_timeout_sec"`
+}
+
 type TenantRateLimit struct {
-	TenantID          pgtype.UUID        `json:"tenant_id"`
-	RequestsPerMinute int32              `json:"requests_per_minute"`
-	TokensPerMinute   int32              `json:"tokens_per_minute"`
-	ParallelRequests  int32              `json:"parallel_requests"`
-	CreatedAt         pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+	TenantID                  pgtype.UUID        `json:"tenant_id"`
+	RequestsPerMinute         int32              `json:"requests_per_minute"`
+	TokensPerMinute           int32              `json:"tokens_per_minute"`
+	ParallelRequests          int32              `json:"parallel_requests"`
+	CreatedAt                 pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt                 pgtype.Timestamptz `json:"updated_at"`
+	ParallelRequestTtlSeconds int32              `json:"parallel_request_ttl_seconds"`
+}
+
+type TenantSetting struct {
+	TenantID                  pgtype.UUID        `json:"tenant_id"`
+	SchemaValidationMode      string             `json:"schema_validation_mode"`
+	CreatedAt                 pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt                 pgtype.Timestamptz `json:"updated_at"`
+	AbuseFingerprintThreshold int32              `json:"abuse_fingerprint_threshold"`
+	DataResidency             []string           `json:"data_residency"`
+}
+
+type TenantSystemPrompt struct {
+	TenantID  pgtype.UUID        `json:"tenant_id"`
+	Content   string             `json:"content"`
+	Mode      SystemPromptMode   `json:"mode"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 }
 
 type UsageRecord struct {
-	ID            pgtype.UUID        `json:"id"`
-	TenantID      pgtype.UUID        `json:"tenant_id"`
-	ApiKeyID      pgtype.UUID        `json:"api_key_id"`
-	Ts            pgtype.Timestamptz `json:"ts"`
-	ModelAlias    string             `json:"model_alias"`
-	Provider      string             `json:"provider"`
-	InputTokens   int64              `json:"input_tokens"`
-	OutputTokens  int64              `json:"output_tokens"`
-	Requests      int64              `json:"requests"`
-	CostCents     int64              `json:"cost_cents"`
-	CostUsdMicros int64              `json:"cost_usd_micros"`
+	ID                  pgtype.UUID        `json:"id"`
+	TenantID            pgtype.UUID        `json:"tenant_id"`
+	ApiKeyID            pgtype.UUID        `json:"api_key_id"`
+	Ts                  pgtype.Timestamptz `json:"ts"`
+	ModelAlias          string             `json:"model_alias"`
+	Provider            string             `json:"provider"`
+	InputTokens         int64              `json:"input_tokens"`
+	OutputTokens        int64              `json:"output_tokens"`
+	Requests            int64              `json:"requests"`
+	CostCents           int64              `json:"cost_cents"`
+	CostUsdMicros       int64              `json:"cost_usd_micros"`
+	CachedTokens        int64              `json:"cached_tokens"`
+	InputCostUsdMicros  int64              `json:"input_cost_usd_micros"`
+	OutputCostUsdMicros int64              `json:"output_cost_usd_micros"`
 }
 
 type User struct {
@@ -465,3 +889,17 @@ type UserCredential struct {
 	CreatedAt    pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
 }
+
+type WebhookDelivery struct {
+	ID          pgtype.UUID           `json:"id"`
+	Event       string                `json:"event"`
+	WebhookUrl  string                `json:"webhook_url"`
+	Payload     []byte                `json:"payload"`
+	Status      WebhookDeliveryStatus `json:"status"`
+	Attempts    int32                 `json:"attempts"`
+	LastError   pgtype.Text           `json:"last_error"`
+	NextRetryAt pgtype.Timestamptz    `json:"next_retry_at"`
+	CreatedAt   pgtype.Timestamptz    `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz    `json:"updated_at"`
+	TenantID    pgtype.UUID           `json:"tenant_id"`
+}
@@ -9,6 +9,7 @@ import (
 	"context"
 
 	"github.com/jackc/pgx/v5/pgtype"
+	decimal "github.com/shopspring/decimal"
 )
 
 const aggregateLatencyByModel = `-- name: AggregateLatencyByModel :many
@@ -102,8 +103,371 @@ func (q *Queries) AggregateRequestMetricsByModel(ctx context.Context, arg Aggreg
 	return items, nil
 }
 
+const aggregateRequestsByABVariant = `-- name: AggregateRequestsByABVariant :many
+SELECT
+    ab_variant,
+    COUNT(*)::bigint AS requests,
+    COUNT(*) FILTER (WHERE status < 400)::bigint AS successes,
+    COALESCE(SUM(input_tokens), 0)::bigint AS input_tokens,
+    COALESCE(SUM(output_tokens), 0)::bigint AS output_tokens,
+    COALESCE(SUM(cost_usd_micros), 0)::bigint AS cost_usd_micros,
+    COALESCE(AVG(latency_ms), 0)::double precision AS avg_latency_ms
+FROM requests
+WHERE model_alias = $1
+  AND ab_variant IS NOT NULL
+  AND ts >= $2
+  AND ts < $3
+GROUP BY ab_variant
+ORDER BY ab_variant
+`
+
+type AggregateRequestsByABVariantParams struct {
+	ModelAlias string             `json:"model_alias"`
+	Ts         pgtype.Timestamptz `json:"ts"`
+	Ts_2       pgtype.Timestamptz `json:"ts_2"`
+}
+
+type AggregateRequestsByABVariantRow struct {
+	AbVariant     pgtype.Text `json:"ab_variant"`
+	Requests      int64       `json:"requests"`
+	Successes     int64       `json:"successes"`
+	InputTokens   int64       `json:"input_tokens"`
+	OutputTokens  int64       `json:"output_tokens"`
+	CostUsdMicros int64       `json:"cost_usd_micros"`
+	AvgLatencyMs  float64     `json:"avg_latency_ms"`
+}
+
+func (q *Queries) AggregateRequestsByABVariant(ctx context.Context, arg AggregateRequestsByABVariantParams) ([]AggregateRequestsByABVariantRow, error) {
+	rows, err := q.db.Query(ctx, aggregateRequestsByABVariant, arg.ModelAlias, arg.Ts, arg.Ts_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AggregateRequestsByABVariantRow{}
+	for rows.Next() {
+		var i AggregateRequestsByABVariantRow
+		if err := rows.Scan(
+			&i.AbVariant,
+			&i.Requests,
+			&i.Successes,
+			&i.InputTokens,
+			&i.OutputTokens,
+			&i.CostUsdMicros,
+			&i.AvgLatencyMs,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const aggregateTaggedRequestsByModel = `-- name: AggregateTaggedRequestsByModel :many
+SELECT
+    model_alias,
+    COUNT(*)::bigint AS requests,
+    COALESCE(SUM(input_tokens + output_tokens), 0)::bigint AS tokens,
+    COALESCE(SUM(cost_cents), 0)::bigint AS cost_cents,
+    COALESCE(SUM(cost_usd_micros), 0)::bigint AS cost_usd_micros
+FROM requests
+WHERE ts >= $1
+  AND ts < $2
+  AND tags_json @> $3::jsonb
+  AND request_metadata @> $5::jsonb
+GROUP BY model_alias
+ORDER BY cost_cents DESC, requests DESC
+LIMIT $4
+`
+
+type AggregateTaggedRequestsByModelParams struct {
+	Ts      pgtype.Timestamptz `json:"ts"`
+	Ts_2    pgtype.Timestamptz `json:"ts_2"`
+	Column3 []byte             `json:"column_3"`
+	Limit   int32              `json:"limit"`
+	Column5 []byte             `json:"column_5"`
+}
+
+type AggregateTaggedRequestsByModelRow struct {
+	ModelAlias    string `json:"model_alias"`
+	Requests      int64  `json:"requests"`
+	Tokens        int64  `json:"tokens"`
+	CostCents     int64  `json:"cost_cents"`
+	CostUsdMicros int64  `json:"cost_usd_micros"`
+}
+
+func (q *Queries) AggregateTaggedRequestsByModel(ctx context.Context, arg AggregateTaggedRequestsByModelParams) ([]AggregateTaggedRequestsByModelRow, error) {
+	rows, err := q.db.Query(ctx, aggregateTaggedRequestsByModel,
+		arg.Ts,
+		arg.Ts_2,
+		arg.Column3,
+		arg.Limit,
+		arg.Column5,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AggregateTaggedRequestsByModelRow{}
+	for rows.Next() {
+		var i AggregateTaggedRequestsByModelRow
+		if err := rows.Scan(
+			&i.ModelAlias,
+			&i.Requests,
+			&i.Tokens,
+			&i.CostCents,
+			&i.CostUsdMicros,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const aggregateTaggedRequestsByTenant = `-- name: AggregateTaggedRequestsByTenant :many
+SELECT
+    r.tenant_id,
+    t.name,
+    COUNT(*)::bigint AS requests,
+    COALESCE(SUM(r.input_tokens + r.output_tokens), 0)::bigint AS tokens,
+    COALESCE(SUM(r.cost_cents), 0)::bigint AS cost_cents,
+    COALESCE(SUM(r.cost_usd_micros), 0)::bigint AS cost_usd_micros
+FROM requests r
+JOIN tenants t ON t.id = r.tenant_id
+WHERE r.ts >= $1
+  AND r.ts < $2
+  AND r.tags_json @> $3::jsonb
+  AND r.request_metadata @> $5::jsonb
+GROUP BY r.tenant_id, t.name
+ORDER BY cost_cents DESC, requests DESC
+LIMIT $4
+`
+
+type AggregateTaggedRequestsByTenantParams struct {
+	Ts      pgtype.Timestamptz `json:"ts"`
+	Ts_2    pgtype.Timestamptz `json:"ts_2"`
+	Column3 []byte             `json:"column_3"`
+	Limit   int32              `json:"limit"`
+	Column5 []byte             `json:"column_5"`
+}
+
+type AggregateTaggedRequestsByTenantRow struct {
+	TenantID      pgtype.UUID `json:"tenant_id"`
+	Name          string      `json:"name"`
+	Requests      int64       `json:"requests"`
+	Tokens        int64       `json:"tokens"`
+	CostCents     int64       `json:"cost_cents"`
+	CostUsdMicros int64       `json:"cost_usd_micros"`
+}
+
+func (q *Queries) AggregateTaggedRequestsByTenant(ctx context.Context, arg AggregateTaggedRequestsByTenantParams) ([]AggregateTaggedRequestsByTenantRow, error) {
+	rows, err := q.db.Query(ctx, aggregateTaggedRequestsByTenant,
+		arg.Ts,
+		arg.Ts_2,
+		arg.Column3,
+		arg.Limit,
+		arg.Column5,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AggregateTaggedRequestsByTenantRow{}
+	for rows.Next() {
+		var i AggregateTaggedRequestsByTenantRow
+		if err := rows.Scan(
+			&i.TenantID,
+			&i.Name,
+			&i.Requests,
+			&i.Tokens,
+			&i.CostCents,
+			&i.CostUsdMicros,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const aggregateTaggedRequestsDaily = `-- name: AggregateTaggedRequestsDaily :many
+SELECT
+    timezone($5::text, date_trunc('day', ts AT TIME ZONE $5::text))::timestamptz AS day,
+    COUNT(*)::bigint AS requests,
+    COALESCE(SUM(input_tokens + output_tokens), 0)::bigint AS tokens,
+    COALESCE(SUM(cost_cents), 0)::bigint AS cost_cents,
+    COALESCE(SUM(cost_usd_micros), 0)::bigint AS cost_usd_micros
+FROM requests
+WHERE ($1::uuid IS NULL OR tenant_id = $1)
+  AND ($2::text = '' OR model_alias = $2)
+  AND ts >= $3
+  AND ts < $4
+  AND tags_json @> $6::jsonb
+  AND request_metadata @> $7::jsonb
+GROUP BY day
+ORDER BY day
+`
+
+type AggregateTaggedRequestsDailyParams struct {
+	Column1 pgtype.UUID        `json:"column_1"`
+	Column2 string             `json:"column_2"`
+	Ts      pgtype.Timestamptz `json:"ts"`
+	Ts_2    pgtype.Timestamptz `json:"ts_2"`
+	Column5 string             `json:"column_5"`
+	Column6 []byte             `json:"column_6"`
+	Column7 []byte             `json:"column_7"`
+}
+
+type AggregateTaggedRequestsDailyRow struct {
+	Day           pgtype.Timestamptz `json:"day"`
+	Requests      int64              `json:"requests"`
+	Tokens        int64              `json:"tokens"`
+	CostCents     int64              `json:"cost_cents"`
+	CostUsdMicros int64              `json:"cost_usd_micros"`
+}
+
+func (q *Queries) AggregateTaggedRequestsDaily(ctx context.Context, arg AggregateTaggedRequestsDailyParams) ([]AggregateTaggedRequestsDailyRow, error) {
+	rows, err := q.db.Query(ctx, aggregateTaggedRequestsDaily,
+		arg.Column1,
+		arg.Column2,
+		arg.Ts,
+		arg.Ts_2,
+		arg.Column5,
+		arg.Column6,
+		arg.Column7,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AggregateTaggedRequestsDailyRow{}
+	for rows.Next() {
+		var i AggregateTaggedRequestsDailyRow
+		if err := rows.Scan(
+			&i.Day,
+			&i.Requests,
+			&i.Tokens,
+			&i.CostCents,
+			&i.CostUsdMicros,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteRequestsBeforeBatch = `-- name: DeleteRequestsBeforeBatch :execrows
+DELETE FROM requests
+WHERE id IN (
+    SELECT id
+    FROM requests
+    WHERE ts < $1
+      AND (
+        $2::uuid IS NULL
+        OR tenant_id = $2::uuid
+      )
+    LIMIT $3
+)
+`
+
+type DeleteRequestsBeforeBatchParams struct {
+	BeforeTs pgtype.Timestamptz `json:"before_ts"`
+	TenantID pgtype.UUID        `json:"tenant_id"`
+	RowLimit int32              `json:"row_limit"`
+}
+
+func (q *Queries) DeleteRequestsBeforeBatch(ctx context.Context, arg DeleteRequestsBeforeBatchParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteRequestsBeforeBatch, arg.BeforeTs, arg.TenantID, arg.RowLimit)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteRequestsBetween = `-- name: DeleteRequestsBetween :execrows
+DELETE FROM requests
+WHERE ts >= $1
+  AND ts < $2
+`
+
+type DeleteRequestsBetweenParams struct {
+	Ts   pgtype.Timestamptz `json:"ts"`
+	Ts_2 pgtype.Timestamptz `json:"ts_2"`
+}
+
+func (q *Queries) DeleteRequestsBetween(ctx context.Context, arg DeleteRequestsBetweenParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteRequestsBetween, arg.Ts, arg.Ts_2)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getOldestRequestBefore = `-- name: GetOldestRequestBefore :one
+SELECT MIN(ts)::timestamptz AS oldest
+FROM requests
+WHERE ts < $1
+`
+
+func (q *Queries) GetOldestRequestBefore(ctx context.Context, ts pgtype.Timestamptz) (pgtype.Timestamptz, error) {
+	row := q.db.QueryRow(ctx, getOldestRequestBefore, ts)
+	var oldest pgtype.Timestamptz
+	err := row.Scan(&oldest)
+	return oldest, err
+}
+
+const getRequestByID = `-- name: GetRequestByID :one
+SELECT id, tenant_id, api_key_id, ts, model_alias, provider, latency_ms, status, error_code, input_tokens, output_tokens, cost_cents, cost_usd_micros, idempotency_key, trace_id, ab_variant, tags_json, trace_parent, request_metadata, fingerprint, cached_tokens, price_input_snapshot, price_output_snapshot
+FROM requests
+WHERE id = $1
+`
+
+func (q *Queries) GetRequestByID(ctx context.Context, id pgtype.UUID) (Request, error) {
+	row := q.db.QueryRow(ctx, getRequestByID, id)
+	var i Request
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.ApiKeyID,
+		&i.Ts,
+		&i.ModelAlias,
+		&i.Provider,
+		&i.LatencyMs,
+		&i.Status,
+		&i.ErrorCode,
+		&i.InputTokens,
+		&i.OutputTokens,
+		&i.CostCents,
+		&i.CostUsdMicros,
+		&i.IdempotencyKey,
+		&i.TraceID,
+		&i.AbVariant,
+		&i.TagsJson,
+		&i.TraceParent,
+		&i.RequestMetadata,
+		&i.Fingerprint,
+		&i.CachedTokens,
+		&i.PriceInputSnapshot,
+		&i.PriceOutputSnapshot,
+	)
+	return i, err
+}
+
 const getRequestByIdempotencyKey = `-- name: GetRequestByIdempotencyKey :one
-SELECT id, tenant_id, api_key_id, ts, model_alias, provider, latency_ms, status, error_code, input_tokens, output_tokens, cost_cents, cost_usd_micros, idempotency_key, trace_id
+SELECT id, tenant_id, api_key_id, ts, model_alias, provider, latency_ms, status, error_code, input_tokens, output_tokens, cost_cents, cost_usd_micros, idempotency_key, trace_id, ab_variant, tags_json, trace_parent, request_metadata, fingerprint, cached_tokens, price_input_snapshot, price_output_snapshot
 FROM requests
 WHERE tenant_id = $1 AND idempotency_key = $2
 `
@@ -132,6 +496,14 @@ func (q *Queries) GetRequestByIdempotencyKey(ctx context.Context, arg GetRequest
 		&i.CostUsdMicros,
 		&i.IdempotencyKey,
 		&i.TraceID,
+		&i.AbVariant,
+		&i.TagsJson,
+		&i.TraceParent,
+		&i.RequestMetadata,
+		&i.Fingerprint,
+		&i.CachedTokens,
+		&i.PriceInputSnapshot,
+		&i.PriceOutputSnapshot,
 	)
 	return i, err
 }
@@ -151,27 +523,43 @@ INSERT INTO requests (
     cost_cents,
     cost_usd_micros,
     idempotency_key,
-    trace_id
+    trace_id,
+    ab_variant,
+    tags_json,
+    trace_parent,
+    request_metadata,
+    fingerprint,
+    cached_tokens,
+    price_input_snapshot,
+    price_output_snapshot
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
-RETURNING id, tenant_id, api_key_id, ts, model_alias, provider, latency_ms, status, error_code, input_tokens, output_tokens, cost_cents, cost_usd_micros, idempotency_key, trace_id
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)
+RETURNING id, tenant_id, api_key_id, ts, model_alias, provider, latency_ms, status, error_code, input_tokens, output_tokens, cost_cents, cost_usd_micros, idempotency_key, trace_id, ab_variant, tags_json, trace_parent, request_metadata, fingerprint, cached_tokens, price_input_snapshot, price_output_snapshot
 `
 
 type InsertRequestRecordParams struct {
-	TenantID       pgtype.UUID        `json:"tenant_id"`
-	ApiKeyID       pgtype.UUID        `json:"api_key_id"`
-	Ts             pgtype.Timestamptz `json:"ts"`
-	ModelAlias     string             `json:"model_alias"`
-	Provider       string             `json:"provider"`
-	LatencyMs      int32              `json:"latency_ms"`
-	Status         int32              `json:"status"`
-	ErrorCode      pgtype.Text        `json:"error_code"`
-	InputTokens    int64              `json:"input_tokens"`
-	OutputTokens   int64              `json:"output_tokens"`
-	CostCents      int64              `json:"cost_cents"`
-	CostUsdMicros  int64              `json:"cost_usd_micros"`
-	IdempotencyKey pgtype.Text        `json:"idempotency_key"`
-	TraceID        pgtype.Text        `json:"trace_id"`
+	TenantID            pgtype.UUID        `json:"tenant_id"`
+	ApiKeyID            pgtype.UUID        `json:"api_key_id"`
+	Ts                  pgtype.Timestamptz `json:"ts"`
+	ModelAlias          string             `json:"model_alias"`
+	Provider            string             `json:"provider"`
+	LatencyMs           int32              `json:"latency_ms"`
+	Status              int32              `json:"status"`
+	ErrorCode           pgtype.Text        `json:"error_code"`
+	InputTokens         int64              `json:"input_tokens"`
+	OutputTokens        int64              `json:"output_tokens"`
+	CostCents           int64              `json:"cost_cents"`
+	CostUsdMicros       int64              `json:"cost_usd_micros"`
+	IdempotencyKey      pgtype.Text        `json:"idempotency_key"`
+	TraceID             pgtype.Text        `json:"trace_id"`
+	AbVariant           pgtype.Text        `json:"ab_variant"`
+	TagsJson            []byte             `json:"tags_json"`
+	TraceParent         pgtype.Text        `json:"trace_parent"`
+	RequestMetadata     []byte             `json:"request_metadata"`
+	Fingerprint         pgtype.Text        `json:"fingerprint"`
+	CachedTokens        int64              `json:"cached_tokens"`
+	PriceInputSnapshot  decimal.Decimal    `json:"price_input_snapshot"`
+	PriceOutputSnapshot decimal.Decimal    `json:"price_output_snapshot"`
 }
 
 func (q *Queries) InsertRequestRecord(ctx context.Context, arg InsertRequestRecordParams) (Request, error) {
@@ -190,6 +578,14 @@ func (q *Queries) InsertRequestRecord(ctx context.Context, arg InsertRequestReco
 		arg.CostUsdMicros,
 		arg.IdempotencyKey,
 		arg.TraceID,
+		arg.AbVariant,
+		arg.TagsJson,
+		arg.TraceParent,
+		arg.RequestMetadata,
+		arg.Fingerprint,
+		arg.CachedTokens,
+		arg.PriceInputSnapshot,
+		arg.PriceOutputSnapshot,
 	)
 	var i Request
 	err := row.Scan(
@@ -208,12 +604,194 @@ func (q *Queries) InsertRequestRecord(ctx context.Context, arg InsertRequestReco
 		&i.CostUsdMicros,
 		&i.IdempotencyKey,
 		&i.TraceID,
+		&i.AbVariant,
+		&i.TagsJson,
+		&i.TraceParent,
+		&i.RequestMetadata,
+		&i.Fingerprint,
+		&i.CachedTokens,
+		&i.PriceInputSnapshot,
+		&i.PriceOutputSnapshot,
 	)
 	return i, err
 }
 
+const listChargebackUsage = `-- name: ListChargebackUsage :many
+SELECT
+    r.tenant_id,
+    t.name AS tenant_name,
+    t.cost_center,
+    r.model_alias,
+    r.provider,
+    COUNT(*)::bigint AS requests,
+    COALESCE(SUM(r.input_tokens + r.output_tokens), 0)::bigint AS tokens,
+    COALESCE(SUM(r.cost_usd_micros), 0)::bigint AS cost_usd_micros
+FROM requests r
+JOIN tenants t ON t.id = r.tenant_id
+WHERE r.ts >= $1
+  AND r.ts < $2
+  AND r.status < 400
+GROUP BY r.tenant_id, t.name, t.cost_center, r.model_alias, r.provider
+ORDER BY t.cost_center, t.name, r.tenant_id, cost_usd_micros DESC
+`
+
+type ListChargebackUsageParams struct {
+	StartTs pgtype.Timestamptz `json:"start_ts"`
+	EndTs   pgtype.Timestamptz `json:"end_ts"`
+}
+
+type ListChargebackUsageRow struct {
+	TenantID      pgtype.UUID `json:"tenant_id"`
+	TenantName    string      `json:"tenant_name"`
+	CostCenter    string      `json:"cost_center"`
+	ModelAlias    string      `json:"model_alias"`
+	Provider      string      `json:"provider"`
+	Requests      int64       `json:"requests"`
+	Tokens        int64       `json:"tokens"`
+	CostUsdMicros int64       `json:"cost_usd_micros"`
+}
+
+func (q *Queries) ListChargebackUsage(ctx context.Context, arg ListChargebackUsageParams) ([]ListChargebackUsageRow, error) {
+	rows, err := q.db.Query(ctx, listChargebackUsage, arg.StartTs, arg.EndTs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListChargebackUsageRow{}
+	for rows.Next() {
+		var i ListChargebackUsageRow
+		if err := rows.Scan(
+			&i.TenantID,
+			&i.TenantName,
+			&i.CostCenter,
+			&i.ModelAlias,
+			&i.Provider,
+			&i.Requests,
+			&i.Tokens,
+			&i.CostUsdMicros,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFingerprintAbuseCandidates = `-- name: ListFingerprintAbuseCandidates :many
+SELECT
+    r.api_key_id,
+    r.tenant_id,
+    k.prefix,
+    k.name,
+    r.fingerprint::text AS fingerprint,
+    COUNT(*)::bigint AS requests,
+    COALESCE(NULLIF(s.abuse_fingerprint_threshold, 0), $1::int)::bigint AS threshold
+FROM requests r
+JOIN api_keys k ON k.id = r.api_key_id
+LEFT JOIN tenant_settings s ON s.tenant_id = r.tenant_id
+WHERE r.ts >= $2
+  AND r.fingerprint IS NOT NULL
+  AND k.revoked_at IS NULL
+  AND k.suspended_at IS NULL
+  AND (k.reinstated_at IS NULL OR r.ts > k.reinstated_at)
+  AND NOT k.is_bootstrap
+GROUP BY r.api_key_id, r.tenant_id, k.prefix, k.name, r.fingerprint, s.abuse_fingerprint_threshold
+HAVING COUNT(*) > COALESCE(NULLIF(s.abuse_fingerprint_threshold, 0), $1::int)
+ORDER BY requests DESC
+`
+
+type ListFingerprintAbuseCandidatesParams struct {
+	DefaultThreshold int32              `json:"default_threshold"`
+	Since            pgtype.Timestamptz `json:"since"`
+}
+
+type ListFingerprintAbuseCandidatesRow struct {
+	ApiKeyID    pgtype.UUID `json:"api_key_id"`
+	TenantID    pgtype.UUID `json:"tenant_id"`
+	Prefix      string      `json:"prefix"`
+	Name        string      `json:"name"`
+	Fingerprint string      `json:"fingerprint"`
+	Requests    int64       `json:"requests"`
+	Threshold   int64       `json:"threshold"`
+}
+
+func (q *Queries) ListFingerprintAbuseCandidates(ctx context.Context, arg ListFingerprintAbuseCandidatesParams) ([]ListFingerprintAbuseCandidatesRow, error) {
+	rows, err := q.db.Query(ctx, listFingerprintAbuseCandidates, arg.DefaultThreshold, arg.Since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListFingerprintAbuseCandidatesRow{}
+	for rows.Next() {
+		var i ListFingerprintAbuseCandidatesRow
+		if err := rows.Scan(
+			&i.ApiKeyID,
+			&i.TenantID,
+			&i.Prefix,
+			&i.Name,
+			&i.Fingerprint,
+			&i.Requests,
+			&i.Threshold,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listModelErrorRates = `-- name: ListModelErrorRates :many
+SELECT
+    model_alias,
+    COUNT(*)::bigint AS requests,
+    COUNT(*) FILTER (WHERE status >= 400)::bigint AS errors
+FROM requests
+WHERE ts >= $1
+GROUP BY model_alias
+HAVING COUNT(*) FILTER (WHERE status >= 400) > 0
+ORDER BY COUNT(*) FILTER (WHERE status >= 400)::double precision / COUNT(*) DESC, errors DESC
+LIMIT $2
+`
+
+type ListModelErrorRatesParams struct {
+	Ts    pgtype.Timestamptz `json:"ts"`
+	Limit int32              `json:"limit"`
+}
+
+type ListModelErrorRatesRow struct {
+	ModelAlias string `json:"model_alias"`
+	Requests   int64  `json:"requests"`
+	Errors     int64  `json:"errors"`
+}
+
+func (q *Queries) ListModelErrorRates(ctx context.Context, arg ListModelErrorRatesParams) ([]ListModelErrorRatesRow, error) {
+	rows, err := q.db.Query(ctx, listModelErrorRates, arg.Ts, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListModelErrorRatesRow{}
+	for rows.Next() {
+		var i ListModelErrorRatesRow
+		if err := rows.Scan(&i.ModelAlias, &i.Requests, &i.Errors); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listRecentRequestsByAPIKeys = `-- name: ListRecentRequestsByAPIKeys :many
-SELECT id, tenant_id, api_key_id, ts, model_alias, provider, latency_ms, status, error_code, input_tokens, output_tokens, cost_cents, cost_usd_micros, idempotency_key, trace_id
+SELECT id, tenant_id, api_key_id, ts, model_alias, provider, latency_ms, status, error_code, input_tokens, output_tokens, cost_cents, cost_usd_micros, idempotency_key, trace_id, ab_variant, tags_json, trace_parent, request_metadata, fingerprint, cached_tokens, price_input_snapshot, price_output_snapshot
 FROM requests
 WHERE api_key_id = ANY($1::uuid[])
 ORDER BY ts DESC
@@ -250,6 +828,14 @@ func (q *Queries) ListRecentRequestsByAPIKeys(ctx context.Context, arg ListRecen
 			&i.CostUsdMicros,
 			&i.IdempotencyKey,
 			&i.TraceID,
+			&i.AbVariant,
+			&i.TagsJson,
+			&i.TraceParent,
+			&i.RequestMetadata,
+			&i.Fingerprint,
+			&i.CachedTokens,
+			&i.PriceInputSnapshot,
+			&i.PriceOutputSnapshot,
 		); err != nil {
 			return nil, err
 		}
@@ -262,7 +848,7 @@ func (q *Queries) ListRecentRequestsByAPIKeys(ctx context.Context, arg ListRecen
 }
 
 const listRequests = `-- name: ListRequests :many
-SELECT id, tenant_id, api_key_id, ts, model_alias, provider, latency_ms, status, error_code, input_tokens, output_tokens, cost_cents, cost_usd_micros, idempotency_key, trace_id
+SELECT id, tenant_id, api_key_id, ts, model_alias, provider, latency_ms, status, error_code, input_tokens, output_tokens, cost_cents, cost_usd_micros, idempotency_key, trace_id, ab_variant, tags_json, trace_parent, request_metadata, fingerprint, cached_tokens, price_input_snapshot, price_output_snapshot
 FROM requests
 WHERE tenant_id = $1
   AND ts >= $2
@@ -310,6 +896,213 @@ func (q *Queries) ListRequests(ctx context.Context, arg ListRequestsParams) ([]R
 			&i.CostUsdMicros,
 			&i.IdempotencyKey,
 			&i.TraceID,
+			&i.AbVariant,
+			&i.TagsJson,
+			&i.TraceParent,
+			&i.RequestMetadata,
+			&i.Fingerprint,
+			&i.CachedTokens,
+			&i.PriceInputSnapshot,
+			&i.PriceOutputSnapshot,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRequestsForArchive = `-- name: ListRequestsForArchive :many
+SELECT id, tenant_id, api_key_id, ts, model_alias, provider, latency_ms, status, error_code, input_tokens, output_tokens, cost_cents, cost_usd_micros, idempotency_key, trace_id, ab_variant, tags_json, trace_parent, request_metadata, fingerprint, cached_tokens, price_input_snapshot, price_output_snapshot
+FROM requests
+WHERE ts >= $1
+  AND ts < $2
+  AND (ts, id) > ($3::timestamptz, $4::uuid)
+ORDER BY ts, id
+LIMIT $5
+`
+
+type ListRequestsForArchiveParams struct {
+	StartTs  pgtype.Timestamptz `json:"start_ts"`
+	EndTs    pgtype.Timestamptz `json:"end_ts"`
+	AfterTs  pgtype.Timestamptz `json:"after_ts"`
+	AfterID  pgtype.UUID        `json:"after_id"`
+	RowLimit int32              `json:"row_limit"`
+}
+
+func (q *Queries) ListRequestsForArchive(ctx context.Context, arg ListRequestsForArchiveParams) ([]Request, error) {
+	rows, err := q.db.Query(ctx, listRequestsForArchive,
+		arg.StartTs,
+		arg.EndTs,
+		arg.AfterTs,
+		arg.AfterID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Request{}
+	for rows.Next() {
+		var i Request
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.ApiKeyID,
+			&i.Ts,
+			&i.ModelAlias,
+			&i.Provider,
+			&i.LatencyMs,
+			&i.Status,
+			&i.ErrorCode,
+			&i.InputTokens,
+			&i.OutputTokens,
+			&i.CostCents,
+			&i.CostUsdMicros,
+			&i.IdempotencyKey,
+			&i.TraceID,
+			&i.AbVariant,
+			&i.TagsJson,
+			&i.TraceParent,
+			&i.RequestMetadata,
+			&i.Fingerprint,
+			&i.CachedTokens,
+			&i.PriceInputSnapshot,
+			&i.PriceOutputSnapshot,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSuspiciousRequestPatterns = `-- name: ListSuspiciousRequestPatterns :many
+SELECT
+    r.api_key_id,
+    r.tenant_id,
+    k.prefix,
+    k.name,
+    r.fingerprint::text AS fingerprint,
+    COUNT(*)::bigint AS requests,
+    MIN(r.ts)::timestamptz AS first_seen,
+    MAX(r.ts)::timestamptz AS last_seen
+FROM requests r
+JOIN api_keys k ON k.id = r.api_key_id
+WHERE r.ts >= $1
+  AND r.fingerprint IS NOT NULL
+GROUP BY r.api_key_id, r.tenant_id, k.prefix, k.name, r.fingerprint
+HAVING COUNT(*) > $2::bigint
+ORDER BY requests DESC
+LIMIT $3
+`
+
+type ListSuspiciousRequestPatternsParams struct {
+	Since     pgtype.Timestamptz `json:"since"`
+	Threshold int64              `json:"threshold"`
+	RowLimit  int32              `json:"row_limit"`
+}
+
+type ListSuspiciousRequestPatternsRow struct {
+	ApiKeyID    pgtype.UUID        `json:"api_key_id"`
+	TenantID    pgtype.UUID        `json:"tenant_id"`
+	Prefix      string             `json:"prefix"`
+	Name        string             `json:"name"`
+	Fingerprint string             `json:"fingerprint"`
+	Requests    int64              `json:"requests"`
+	FirstSeen   pgtype.Timestamptz `json:"first_seen"`
+	LastSeen    pgtype.Timestamptz `json:"last_seen"`
+}
+
+func (q *Queries) ListSuspiciousRequestPatterns(ctx context.Context, arg ListSuspiciousRequestPatternsParams) ([]ListSuspiciousRequestPatternsRow, error) {
+	rows, err := q.db.Query(ctx, listSuspiciousRequestPatterns, arg.Since, arg.Threshold, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSuspiciousRequestPatternsRow{}
+	for rows.Next() {
+		var i ListSuspiciousRequestPatternsRow
+		if err := rows.Scan(
+			&i.ApiKeyID,
+			&i.TenantID,
+			&i.Prefix,
+			&i.Name,
+			&i.Fingerprint,
+			&i.Requests,
+			&i.FirstSeen,
+			&i.LastSeen,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTenantRequestsForExport = `-- name: ListTenantRequestsForExport :many
+SELECT id, tenant_id, api_key_id, ts, model_alias, provider, latency_ms, status, error_code, input_tokens, output_tokens, cost_cents, cost_usd_micros, idempotency_key, trace_id, ab_variant, tags_json, trace_parent, request_metadata, fingerprint, cached_tokens, price_input_snapshot, price_output_snapshot
+FROM requests
+WHERE tenant_id = $1
+  AND (ts, id) > ($2::timestamptz, $3::uuid)
+ORDER BY ts, id
+LIMIT $4
+`
+
+type ListTenantRequestsForExportParams struct {
+	TenantID pgtype.UUID        `json:"tenant_id"`
+	AfterTs  pgtype.Timestamptz `json:"after_ts"`
+	AfterID  pgtype.UUID        `json:"after_id"`
+	RowLimit int32              `json:"row_limit"`
+}
+
+func (q *Queries) ListTenantRequestsForExport(ctx context.Context, arg ListTenantRequestsForExportParams) ([]Request, error) {
+	rows, err := q.db.Query(ctx, listTenantRequestsForExport,
+		arg.TenantID,
+		arg.AfterTs,
+		arg.AfterID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Request{}
+	for rows.Next() {
+		var i Request
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.ApiKeyID,
+			&i.Ts,
+			&i.ModelAlias,
+			&i.Provider,
+			&i.LatencyMs,
+			&i.Status,
+			&i.ErrorCode,
+			&i.InputTokens,
+			&i.OutputTokens,
+			&i.CostCents,
+			&i.CostUsdMicros,
+			&i.IdempotencyKey,
+			&i.TraceID,
+			&i.AbVariant,
+			&i.TagsJson,
+			&i.TraceParent,
+			&i.RequestMetadata,
+			&i.Fingerprint,
+			&i.CachedTokens,
+			&i.PriceInputSnapshot,
+			&i.PriceOutputSnapshot,
 		); err != nil {
 			return nil, err
 		}
@@ -320,3 +1113,157 @@ func (q *Queries) ListRequests(ctx context.Context, arg ListRequestsParams) ([]R
 	}
 	return items, nil
 }
+
+const listTopRequestTags = `-- name: ListTopRequestTags :many
+SELECT
+    tag.key::text AS tag_key,
+    tag.value::text AS tag_value,
+    COUNT(*)::bigint AS requests,
+    COALESCE(SUM(r.cost_cents), 0)::bigint AS cost_cents,
+    COALESCE(SUM(r.cost_usd_micros), 0)::bigint AS cost_usd_micros
+FROM requests r
+CROSS JOIN LATERAL jsonb_each_text(r.tags_json) AS tag(key, value)
+WHERE ($1::uuid IS NULL OR r.tenant_id = $1)
+  AND r.ts >= $2
+  AND r.ts < $3
+  AND r.tags_json @> $4::jsonb
+  AND r.request_metadata @> $6::jsonb
+GROUP BY tag.key, tag.value
+ORDER BY cost_cents DESC, requests DESC
+LIMIT $5
+`
+
+type ListTopRequestTagsParams struct {
+	Column1 pgtype.UUID        `json:"column_1"`
+	Ts      pgtype.Timestamptz `json:"ts"`
+	Ts_2    pgtype.Timestamptz `json:"ts_2"`
+	Column4 []byte             `json:"column_4"`
+	Limit   int32              `json:"limit"`
+	Column6 []byte             `json:"column_6"`
+}
+
+type ListTopRequestTagsRow struct {
+	TagKey        string `json:"tag_key"`
+	TagValue      string `json:"tag_value"`
+	Requests      int64  `json:"requests"`
+	CostCents     int64  `json:"cost_cents"`
+	CostUsdMicros int64  `json:"cost_usd_micros"`
+}
+
+func (q *Queries) ListTopRequestTags(ctx context.Context, arg ListTopRequestTagsParams) ([]ListTopRequestTagsRow, error) {
+	rows, err := q.db.Query(ctx, listTopRequestTags,
+		arg.Column1,
+		arg.Ts,
+		arg.Ts_2,
+		arg.Column4,
+		arg.Limit,
+		arg.Column6,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTopRequestTagsRow{}
+	for rows.Next() {
+		var i ListTopRequestTagsRow
+		if err := rows.Scan(
+			&i.TagKey,
+			&i.TagValue,
+			&i.Requests,
+			&i.CostCents,
+			&i.CostUsdMicros,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const sumTaggedRequests = `-- name: SumTaggedRequests :one
+SELECT
+    COUNT(*)::bigint AS total_requests,
+    COALESCE(SUM(input_tokens + output_tokens), 0)::bigint AS total_tokens,
+    COALESCE(SUM(cost_cents), 0)::bigint AS total_cost_cents,
+    COALESCE(SUM(cost_usd_micros), 0)::bigint AS total_cost_usd_micros,
+    COALESCE(SUM(cached_tokens), 0)::bigint AS total_cached_tokens,
+    COALESCE(SUM(input_tokens), 0)::bigint AS total_input_tokens,
+    COALESCE(SUM(output_tokens), 0)::bigint AS total_output_tokens
+FROM requests
+WHERE ($1::uuid IS NULL OR tenant_id = $1)
+  AND ts >= $2
+  AND ts < $3
+  AND tags_json @> $4::jsonb
+  AND request_metadata @> $5::jsonb
+`
+
+type SumTaggedRequestsParams struct {
+	Column1 pgtype.UUID        `json:"column_1"`
+	Ts      pgtype.Timestamptz `json:"ts"`
+	Ts_2    pgtype.Timestamptz `json:"ts_2"`
+	Column4 []byte             `json:"column_4"`
+	Column5 []byte             `json:"column_5"`
+}
+
+type SumTaggedRequestsRow struct {
+	TotalRequests      int64 `json:"total_requests"`
+	TotalTokens        int64 `json:"total_tokens"`
+	TotalCostCents     int64 `json:"total_cost_cents"`
+	TotalCostUsdMicros int64 `json:"total_cost_usd_micros"`
+	TotalCachedTokens  int64 `json:"total_cached_tokens"`
+	TotalInputTokens   int64 `json:"total_input_tokens"`
+	TotalOutputTokens  int64 `json:"total_output_tokens"`
+}
+
+func (q *Queries) SumTaggedRequests(ctx context.Context, arg SumTaggedRequestsParams) (SumTaggedRequestsRow, error) {
+	row := q.db.QueryRow(ctx, sumTaggedRequests,
+		arg.Column1,
+		arg.Ts,
+		arg.Ts_2,
+		arg.Column4,
+		arg.Column5,
+	)
+	var i SumTaggedRequestsRow
+	err := row.Scan(
+		&i.TotalRequests,
+		&i.TotalTokens,
+		&i.TotalCostCents,
+		&i.TotalCostUsdMicros,
+		&i.TotalCachedTokens,
+		&i.TotalInputTokens,
+		&i.TotalOutputTokens,
+	)
+	return i, err
+}
+
+const summarizeRequestsSince = `-- name: SummarizeRequestsSince :one
+SELECT
+    COUNT(*)::bigint AS requests,
+    COALESCE(SUM(input_tokens + output_tokens), 0)::bigint AS tokens,
+    COALESCE(SUM(cost_usd_micros), 0)::bigint AS cost_usd_micros,
+    COALESCE(PERCENTILE_CONT(0.95) WITHIN GROUP (ORDER BY latency_ms), 0)::double precision AS p95_latency_ms
+FROM requests
+WHERE ts >= $1
+`
+
+type SummarizeRequestsSinceRow struct {
+	Requests      int64   `json:"requests"`
+	Tokens        int64   `json:"tokens"`
+	CostUsdMicros int64   `json:"cost_usd_micros"`
+	P95LatencyMs  float64 `json:"p95_latency_ms"`
+}
+
+func (q *Queries) SummarizeRequestsSince(ctx context.Context, ts pgtype.Timestamptz) (SummarizeRequestsSinceRow, error) {
+	row := q.db.QueryRow(ctx, summarizeRequestsSince, ts)
+	var i SummarizeRequestsSinceRow
+	err := row.Scan(
+		&i.Requests,
+		&i.Tokens,
+		&i.CostUsdMicros,
+		&i.P95LatencyMs,
+	)
+	return i, err
+}
@@ -22,7 +22,7 @@ func (q *Queries) DeleteTenantRateLimit(ctx context.Context, tenantID pgtype.UUI
 }
 
 const getTenantRateLimit = `-- name: GetTenantRateLimit :one
-SELECT tenant_id, requests_per_minute, tokens_per_minute, parallel_requests, created_at, updated_at
+SELECT tenant_id, requests_per_minute, tokens_per_minute, parallel_requests, created_at, updated_at, parallel_request_ttl_seconds
 FROM tenant_rate_limits
 WHERE tenant_id = $1
 `
@@ -37,12 +37,13 @@ func (q *Queries) GetTenantRateLimit(ctx context.Context, tenantID pgtype.UUID)
 		&i.ParallelRequests,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ParallelRequestTtlSeconds,
 	)
 	return i, err
 }
 
 const listTenantRateLimits = `-- name: ListTenantRateLimits :many
-SELECT tenant_id, requests_per_minute, tokens_per_minute, parallel_requests, created_at, updated_at
+SELECT tenant_id, requests_per_minute, tokens_per_minute, parallel_requests, created_at, updated_at, parallel_request_ttl_seconds
 FROM tenant_rate_limits
 `
 
@@ -62,6 +63,7 @@ func (q *Queries) ListTenantRateLimits(ctx context.Context) ([]TenantRateLimit,
 			&i.ParallelRequests,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ParallelRequestTtlSeconds,
 		); err != nil {
 			return nil, err
 		}
@@ -78,21 +80,24 @@ INSERT INTO tenant_rate_limits (
     tenant_id,
     requests_per_minute,
     tokens_per_minute,
-    parallel_requests
-) VALUES ($1, $2, $3, $4)
+    parallel_requests,
+    parallel_request_ttl_seconds
+) VALUES ($1, $2, $3, $4, $5)
 ON CONFLICT (tenant_id) DO UPDATE
 SET requests_per_minute = EXCLUDED.requests_per_minute,
     tokens_per_minute = EXCLUDED.tokens_per_minute,
     parallel_requests = EXCLUDED.parallel_requests,
+    parallel_request_ttl_seconds = EXCLUDED.parallel_request_ttl_seconds,
     updated_at = NOW()
-RETURNING tenant_id, requests_per_minute, tokens_per_minute, parallel_requests, created_at, updated_at
+RETURNING tenant_id, requests_per_minute, tokens_per_minute, parallel_requests, created_at, updated_at, parallel_request_ttl_seconds
 `
 
 type UpsertTenantRateLimitParams struct {
-	TenantID          pgtype.UUID `json:"tenant_id"`
-	RequestsPerMinute int32       `json:"requests_per_minute"`
-	TokensPerMinute   int32       `json:"tokens_per_minute"`
-	ParallelRequests  int32       `json:"parallel_requests"`
+	TenantID                  pgtype.UUID `json:"tenant_id"`
+	RequestsPerMinute         int32       `json:"requests_per_minute"`
+	TokensPerMinute           int32       `json:"tokens_per_minute"`
+	ParallelRequests          int32       `json:"parallel_requests"`
+	ParallelRequestTtlSeconds int32       `json:"parallel_request_ttl_seconds"`
 }
 
 func (q *Queries) UpsertTenantRateLimit(ctx context.Context, arg UpsertTenantRateLimitParams) (TenantRateLimit, error) {
@@ -101,6 +106,7 @@ func (q *Queries) UpsertTenantRateLimit(ctx context.Context, arg UpsertTenantRat
 		arg.RequestsPerMinute,
 		arg.TokensPerMinute,
 		arg.ParallelRequests,
+		arg.ParallelRequestTtlSeconds,
 	)
 	var i TenantRateLimit
 	err := row.Scan(
@@ -110,6 +116,7 @@ func (q *Queries) UpsertTenantRateLimit(ctx context.Context, arg UpsertTenantRat
 		&i.ParallelRequests,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ParallelRequestTtlSeconds,
 	)
 	return i, err
 }
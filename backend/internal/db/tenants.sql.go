@@ -11,10 +11,55 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const bulkUpdateTenantStatus = `-- name: BulkUpdateTenantStatus :many
+UPDATE tenants
+SET status = $1
+WHERE id = ANY($2::uuid[])
+RETURNING id
+`
+
+type BulkUpdateTenantStatusParams struct {
+	Status TenantStatus  `json:"status"`
+	Ids    []pgtype.UUID `json:"ids"`
+}
+
+func (q *Queries) BulkUpdateTenantStatus(ctx context.Context, arg BulkUpdateTenantStatusParams) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, bulkUpdateTenantStatus, arg.Status, arg.Ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []pgtype.UUID{}
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countActiveTenants = `-- name: CountActiveTenants :one
+SELECT COUNT(*)::bigint
+FROM tenants
+WHERE status = 'active'
+`
+
+func (q *Queries) CountActiveTenants(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, countActiveTenants)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
 const createTenant = `-- name: CreateTenant :one
 INSERT INTO tenants (name, status, kind)
 VALUES ($1, $2, $3)
-RETURNING id, name, status, kind, created_at
+RETURNING id, name, status, kind, created_at, cost_center
 `
 
 type CreateTenantParams struct {
@@ -32,12 +77,23 @@ func (q *Queries) CreateTenant(ctx context.Context, arg CreateTenantParams) (Ten
 		&i.Status,
 		&i.Kind,
 		&i.CreatedAt,
+		&i.CostCenter,
 	)
 	return i, err
 }
 
+const deleteTenant = `-- name: DeleteTenant :exec
+DELETE FROM tenants
+WHERE id = $1
+`
+
+func (q *Queries) DeleteTenant(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteTenant, id)
+	return err
+}
+
 const getTenantByID = `-- name: GetTenantByID :one
-SELECT id, name, status, kind, created_at
+SELECT id, name, status, kind, created_at, cost_center
 FROM tenants
 WHERE id = $1
 `
@@ -51,12 +107,13 @@ func (q *Queries) GetTenantByID(ctx context.Context, id pgtype.UUID) (Tenant, er
 		&i.Status,
 		&i.Kind,
 		&i.CreatedAt,
+		&i.CostCenter,
 	)
 	return i, err
 }
 
 const getTenantByName = `-- name: GetTenantByName :one
-SELECT id, name, status, kind, created_at
+SELECT id, name, status, kind, created_at, cost_center
 FROM tenants
 WHERE name = $1
 `
@@ -70,6 +127,7 @@ func (q *Queries) GetTenantByName(ctx context.Context, name string) (Tenant, err
 		&i.Status,
 		&i.Kind,
 		&i.CreatedAt,
+		&i.CostCenter,
 	)
 	return i, err
 }
@@ -171,7 +229,7 @@ func (q *Queries) ListPersonalTenants(ctx context.Context, arg ListPersonalTenan
 }
 
 const listTenants = `-- name: ListTenants :many
-SELECT id, name, status, kind, created_at
+SELECT id, name, status, kind, created_at, cost_center
 FROM tenants
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2
@@ -197,6 +255,7 @@ func (q *Queries) ListTenants(ctx context.Context, arg ListTenantsParams) ([]Ten
 			&i.Status,
 			&i.Kind,
 			&i.CreatedAt,
+			&i.CostCenter,
 		); err != nil {
 			return nil, err
 		}
@@ -246,11 +305,37 @@ func (q *Queries) ListTenantsByIDs(ctx context.Context, dollar_1 []pgtype.UUID)
 	return items, nil
 }
 
+const updateTenantCostCenter = `-- name: UpdateTenantCostCenter :one
+UPDATE tenants
+SET cost_center = $2
+WHERE id = $1
+RETURNING id, name, status, kind, created_at, cost_center
+`
+
+type UpdateTenantCostCenterParams struct {
+	ID         pgtype.UUID `json:"id"`
+	CostCenter string      `json:"cost_center"`
+}
+
+func (q *Queries) UpdateTenantCostCenter(ctx context.Context, arg UpdateTenantCostCenterParams) (Tenant, error) {
+	row := q.db.QueryRow(ctx, updateTenantCostCenter, arg.ID, arg.CostCenter)
+	var i Tenant
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Status,
+		&i.Kind,
+		&i.CreatedAt,
+		&i.CostCenter,
+	)
+	return i, err
+}
+
 const updateTenantName = `-- name: UpdateTenantName :one
 UPDATE tenants
 SET name = $2
 WHERE id = $1
-RETURNING id, name, status, kind, created_at
+RETURNING id, name, status, kind, created_at, cost_center
 `
 
 type UpdateTenantNameParams struct {
@@ -267,6 +352,7 @@ func (q *Queries) UpdateTenantName(ctx context.Context, arg UpdateTenantNamePara
 		&i.Status,
 		&i.Kind,
 		&i.CreatedAt,
+		&i.CostCenter,
 	)
 	return i, err
 }
@@ -275,7 +361,7 @@ const updateTenantStatus = `-- name: UpdateTenantStatus :one
 UPDATE tenants
 SET status = $2
 WHERE id = $1
-RETURNING id, name, status, kind, created_at
+RETURNING id, name, status, kind, created_at, cost_center
 `
 
 type UpdateTenantStatusParams struct {
@@ -292,6 +378,7 @@ func (q *Queries) UpdateTenantStatus(ctx context.Context, arg UpdateTenantStatus
 		&i.Status,
 		&i.Kind,
 		&i.CreatedAt,
+		&i.CostCenter,
 	)
 	return i, err
 }
@@ -78,7 +78,11 @@ SELECT
     COALESCE(SUM(requests), 0)::bigint AS requests,
     COALESCE(SUM(input_tokens + output_tokens), 0)::bigint AS tokens,
     COALESCE(SUM(cost_cents), 0)::bigint AS cost_cents,
-    COALESCE(SUM(cost_usd_micros), 0)::bigint AS cost_usd_micros
+    COALESCE(SUM(cost_usd_micros), 0)::bigint AS cost_usd_micros,
+    COALESCE(SUM(input_tokens), 0)::bigint AS input_tokens,
+    COALESCE(SUM(output_tokens), 0)::bigint AS output_tokens,
+    COALESCE(SUM(input_cost_usd_micros), 0)::bigint AS input_cost_usd_micros,
+    COALESCE(SUM(output_cost_usd_micros), 0)::bigint AS output_cost_usd_micros
 FROM usage_records
 WHERE model_alias = $1
   AND ts >= $2
@@ -95,11 +99,15 @@ type AggregateModelUsageDailyParams struct {
 }
 
 type AggregateModelUsageDailyRow struct {
-	Day           pgtype.Timestamptz `json:"day"`
-	Requests      int64              `json:"requests"`
-	Tokens        int64              `json:"tokens"`
-	CostCents     int64              `json:"cost_cents"`
-	CostUsdMicros int64              `json:"cost_usd_micros"`
+	Day                 pgtype.Timestamptz `json:"day"`
+	Requests            int64              `json:"requests"`
+	Tokens              int64              `json:"tokens"`
+	CostCents           int64              `json:"cost_cents"`
+	CostUsdMicros       int64              `json:"cost_usd_micros"`
+	InputTokens         int64              `json:"input_tokens"`
+	OutputTokens        int64              `json:"output_tokens"`
+	InputCostUsdMicros  int64              `json:"input_cost_usd_micros"`
+	OutputCostUsdMicros int64              `json:"output_cost_usd_micros"`
 }
 
 func (q *Queries) AggregateModelUsageDaily(ctx context.Context, arg AggregateModelUsageDailyParams) ([]AggregateModelUsageDailyRow, error) {
@@ -122,6 +130,10 @@ func (q *Queries) AggregateModelUsageDaily(ctx context.Context, arg AggregateMod
 			&i.Tokens,
 			&i.CostCents,
 			&i.CostUsdMicros,
+			&i.InputTokens,
+			&i.OutputTokens,
+			&i.InputCostUsdMicros,
+			&i.OutputCostUsdMicros,
 		); err != nil {
 			return nil, err
 		}
@@ -339,7 +351,11 @@ SELECT
     COALESCE(SUM(requests), 0)::bigint AS requests,
     COALESCE(SUM(input_tokens + output_tokens), 0)::bigint AS tokens,
     COALESCE(SUM(cost_cents), 0)::bigint AS cost_cents,
-    COALESCE(SUM(cost_usd_micros), 0)::bigint AS cost_usd_micros
+    COALESCE(SUM(cost_usd_micros), 0)::bigint AS cost_usd_micros,
+    COALESCE(SUM(input_tokens), 0)::bigint AS input_tokens,
+    COALESCE(SUM(output_tokens), 0)::bigint AS output_tokens,
+    COALESCE(SUM(input_cost_usd_micros), 0)::bigint AS input_cost_usd_micros,
+    COALESCE(SUM(output_cost_usd_micros), 0)::bigint AS output_cost_usd_micros
 FROM usage_records
 WHERE ts >= $1
   AND ts < $2
@@ -355,11 +371,15 @@ type AggregateUsageByModelParams struct {
 }
 
 type AggregateUsageByModelRow struct {
-	ModelAlias    string `json:"model_alias"`
-	Requests      int64  `json:"requests"`
-	Tokens        int64  `json:"tokens"`
-	CostCents     int64  `json:"cost_cents"`
-	CostUsdMicros int64  `json:"cost_usd_micros"`
+	ModelAlias          string `json:"model_alias"`
+	Requests            int64  `json:"requests"`
+	Tokens              int64  `json:"tokens"`
+	CostCents           int64  `json:"cost_cents"`
+	CostUsdMicros       int64  `json:"cost_usd_micros"`
+	InputTokens         int64  `json:"input_tokens"`
+	OutputTokens        int64  `json:"output_tokens"`
+	InputCostUsdMicros  int64  `json:"input_cost_usd_micros"`
+	OutputCostUsdMicros int64  `json:"output_cost_usd_micros"`
 }
 
 func (q *Queries) AggregateUsageByModel(ctx context.Context, arg AggregateUsageByModelParams) ([]AggregateUsageByModelRow, error) {
@@ -377,6 +397,10 @@ func (q *Queries) AggregateUsageByModel(ctx context.Context, arg AggregateUsageB
 			&i.Tokens,
 			&i.CostCents,
 			&i.CostUsdMicros,
+			&i.InputTokens,
+			&i.OutputTokens,
+			&i.InputCostUsdMicros,
+			&i.OutputCostUsdMicros,
 		); err != nil {
 			return nil, err
 		}
@@ -578,7 +602,11 @@ SELECT
     COALESCE(SUM(requests), 0)::bigint AS requests,
     COALESCE(SUM(input_tokens + output_tokens), 0)::bigint AS tokens,
     COALESCE(SUM(cost_cents), 0)::bigint AS cost_cents,
-    COALESCE(SUM(cost_usd_micros), 0)::bigint AS cost_usd_micros
+    COALESCE(SUM(cost_usd_micros), 0)::bigint AS cost_usd_micros,
+    COALESCE(SUM(input_tokens), 0)::bigint AS input_tokens,
+    COALESCE(SUM(output_tokens), 0)::bigint AS output_tokens,
+    COALESCE(SUM(input_cost_usd_micros), 0)::bigint AS input_cost_usd_micros,
+    COALESCE(SUM(output_cost_usd_micros), 0)::bigint AS output_cost_usd_micros
 FROM usage_records
 WHERE model_alias = ANY($1::text[])
   AND ts >= $2
@@ -597,12 +625,16 @@ type AggregateUsageDailyByModelsParams struct {
 }
 
 type AggregateUsageDailyByModelsRow struct {
-	ModelAlias    string             `json:"model_alias"`
-	Day           pgtype.Timestamptz `json:"day"`
-	Requests      int64              `json:"requests"`
-	Tokens        int64              `json:"tokens"`
-	CostCents     int64              `json:"cost_cents"`
-	CostUsdMicros int64              `json:"cost_usd_micros"`
+	ModelAlias          string             `json:"model_alias"`
+	Day                 pgtype.Timestamptz `json:"day"`
+	Requests            int64              `json:"requests"`
+	Tokens              int64              `json:"tokens"`
+	CostCents           int64              `json:"cost_cents"`
+	CostUsdMicros       int64              `json:"cost_usd_micros"`
+	InputTokens         int64              `json:"input_tokens"`
+	OutputTokens        int64              `json:"output_tokens"`
+	InputCostUsdMicros  int64              `json:"input_cost_usd_micros"`
+	OutputCostUsdMicros int64              `json:"output_cost_usd_micros"`
 }
 
 func (q *Queries) AggregateUsageDailyByModels(ctx context.Context, arg AggregateUsageDailyByModelsParams) ([]AggregateUsageDailyByModelsRow, error) {
@@ -627,6 +659,10 @@ func (q *Queries) AggregateUsageDailyByModels(ctx context.Context, arg Aggregate
 			&i.Tokens,
 			&i.CostCents,
 			&i.CostUsdMicros,
+			&i.InputTokens,
+			&i.OutputTokens,
+			&i.InputCostUsdMicros,
+			&i.OutputCostUsdMicros,
 		); err != nil {
 			return nil, err
 		}
@@ -832,6 +868,59 @@ func (q *Queries) AggregateUsageDailyForUserTenant(ctx context.Context, arg Aggr
 	return items, nil
 }
 
+const aggregateUsageHourly = `-- name: AggregateUsageHourly :many
+SELECT
+    date_trunc('hour', ts)::timestamptz AS hour,
+    COALESCE(SUM(requests), 0)::bigint AS requests,
+    COALESCE(SUM(input_tokens + output_tokens), 0)::bigint AS tokens
+FROM usage_records
+WHERE ($1::uuid IS NULL OR tenant_id = $1)
+  AND ts >= $2
+  AND ts < $3
+GROUP BY hour
+ORDER BY hour
+`
+
+type AggregateUsageHourlyParams struct {
+	Column1 pgtype.UUID        `json:"column_1"`
+	Ts      pgtype.Timestamptz `json:"ts"`
+	Ts_2    pgtype.Timestamptz `json:"ts_2"`
+}
+
+type AggregateUsageHourlyRow struct {
+	Hour     pgtype.Timestamptz `json:"hour"`
+	Requests int64              `json:"requests"`
+	Tokens   int64              `json:"tokens"`
+}
+
+func (q *Queries) AggregateUsageHourly(ctx context.Context, arg AggregateUsageHourlyParams) ([]AggregateUsageHourlyRow, error) {
+	rows, err := q.db.Query(ctx, aggregateUsageHourly,
+		arg.Column1,
+		arg.Ts,
+		arg.Ts_2,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AggregateUsageHourlyRow{}
+	for rows.Next() {
+		var i AggregateUsageHourlyRow
+		if err := rows.Scan(
+			&i.Hour,
+			&i.Requests,
+			&i.Tokens,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const aggregateUserUsageDailyByTenants = `-- name: AggregateUserUsageDailyByTenants :many
 SELECT
     timezone($4::text, date_trunc('day', r.ts AT TIME ZONE $4::text))::timestamptz AS day,
@@ -912,23 +1001,29 @@ INSERT INTO usage_records (
     output_tokens,
     requests,
     cost_cents,
-    cost_usd_micros
+    cost_usd_micros,
+    cached_tokens,
+    input_cost_usd_micros,
+    output_cost_usd_micros
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-RETURNING id, tenant_id, api_key_id, ts, model_alias, provider, input_tokens, output_tokens, requests, cost_cents, cost_usd_micros
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+RETURNING id, tenant_id, api_key_id, ts, model_alias, provider, input_tokens, output_tokens, requests, cost_cents, cost_usd_micros, cached_tokens, input_cost_usd_micros, output_cost_usd_micros
 `
 
 type InsertUsageRecordParams struct {
-	TenantID      pgtype.UUID        `json:"tenant_id"`
-	ApiKeyID      pgtype.UUID        `json:"api_key_id"`
-	Ts            pgtype.Timestamptz `json:"ts"`
-	ModelAlias    string             `json:"model_alias"`
-	Provider      string             `json:"provider"`
-	InputTokens   int64              `json:"input_tokens"`
-	OutputTokens  int64              `json:"output_tokens"`
-	Requests      int64              `json:"requests"`
-	CostCents     int64              `json:"cost_cents"`
-	CostUsdMicros int64              `json:"cost_usd_micros"`
+	TenantID            pgtype.UUID        `json:"tenant_id"`
+	ApiKeyID            pgtype.UUID        `json:"api_key_id"`
+	Ts                  pgtype.Timestamptz `json:"ts"`
+	ModelAlias          string             `json:"model_alias"`
+	Provider            string             `json:"provider"`
+	InputTokens         int64              `json:"input_tokens"`
+	OutputTokens        int64              `json:"output_tokens"`
+	Requests            int64              `json:"requests"`
+	CostCents           int64              `json:"cost_cents"`
+	CostUsdMicros       int64              `json:"cost_usd_micros"`
+	CachedTokens        int64              `json:"cached_tokens"`
+	InputCostUsdMicros  int64              `json:"input_cost_usd_micros"`
+	OutputCostUsdMicros int64              `json:"output_cost_usd_micros"`
 }
 
 func (q *Queries) InsertUsageRecord(ctx context.Context, arg InsertUsageRecordParams) (UsageRecord, error) {
@@ -943,6 +1038,9 @@ func (q *Queries) InsertUsageRecord(ctx context.Context, arg InsertUsageRecordPa
 		arg.Requests,
 		arg.CostCents,
 		arg.CostUsdMicros,
+		arg.CachedTokens,
+		arg.InputCostUsdMicros,
+		arg.OutputCostUsdMicros,
 	)
 	var i UsageRecord
 	err := row.Scan(
@@ -957,12 +1055,15 @@ func (q *Queries) InsertUsageRecord(ctx context.Context, arg InsertUsageRecordPa
 		&i.Requests,
 		&i.CostCents,
 		&i.CostUsdMicros,
+		&i.CachedTokens,
+		&i.InputCostUsdMicros,
+		&i.OutputCostUsdMicros,
 	)
 	return i, err
 }
 
 const listUsageRecords = `-- name: ListUsageRecords :many
-SELECT id, tenant_id, api_key_id, ts, model_alias, provider, input_tokens, output_tokens, requests, cost_cents, cost_usd_micros
+SELECT id, tenant_id, api_key_id, ts, model_alias, provider, input_tokens, output_tokens, requests, cost_cents, cost_usd_micros, cached_tokens, input_cost_usd_micros, output_cost_usd_micros
 FROM usage_records
 WHERE tenant_id = $1
   AND ts >= $2
@@ -1006,6 +1107,9 @@ func (q *Queries) ListUsageRecords(ctx context.Context, arg ListUsageRecordsPara
 			&i.Requests,
 			&i.CostCents,
 			&i.CostUsdMicros,
+			&i.CachedTokens,
+			&i.InputCostUsdMicros,
+			&i.OutputCostUsdMicros,
 		); err != nil {
 			return nil, err
 		}
@@ -1073,7 +1177,10 @@ SELECT
     COALESCE(SUM(requests), 0)::bigint AS total_requests,
     COALESCE(SUM(input_tokens + output_tokens), 0)::bigint AS total_tokens,
     COALESCE(SUM(cost_cents), 0)::bigint AS total_cost_cents,
-    COALESCE(SUM(cost_usd_micros), 0)::bigint AS total_cost_usd_micros
+    COALESCE(SUM(cost_usd_micros), 0)::bigint AS total_cost_usd_micros,
+    COALESCE(SUM(cached_tokens), 0)::bigint AS total_cached_tokens,
+    COALESCE(SUM(input_tokens), 0)::bigint AS total_input_tokens,
+    COALESCE(SUM(output_tokens), 0)::bigint AS total_output_tokens
 FROM usage_records
 WHERE ($1::uuid IS NULL OR tenant_id = $1)
   AND ts >= $2
@@ -1091,6 +1198,9 @@ type SumUsageRow struct {
 	TotalTokens        int64 `json:"total_tokens"`
 	TotalCostCents     int64 `json:"total_cost_cents"`
 	TotalCostUsdMicros int64 `json:"total_cost_usd_micros"`
+	TotalCachedTokens  int64 `json:"total_cached_tokens"`
+	TotalInputTokens   int64 `json:"total_input_tokens"`
+	TotalOutputTokens  int64 `json:"total_output_tokens"`
 }
 
 func (q *Queries) SumUsage(ctx context.Context, arg SumUsageParams) (SumUsageRow, error) {
@@ -1101,6 +1211,9 @@ func (q *Queries) SumUsage(ctx context.Context, arg SumUsageParams) (SumUsageRow
 		&i.TotalTokens,
 		&i.TotalCostCents,
 		&i.TotalCostUsdMicros,
+		&i.TotalCachedTokens,
+		&i.TotalInputTokens,
+		&i.TotalOutputTokens,
 	)
 	return i, err
 }
@@ -1111,7 +1224,12 @@ SELECT
     COALESCE(SUM(requests), 0)::bigint AS total_requests,
     COALESCE(SUM(input_tokens + output_tokens), 0)::bigint AS total_tokens,
     COALESCE(SUM(cost_cents), 0)::bigint AS total_cost_cents,
-    COALESCE(SUM(cost_usd_micros), 0)::bigint AS total_cost_usd_micros
+    COALESCE(SUM(cost_usd_micros), 0)::bigint AS total_cost_usd_micros,
+    COALESCE(SUM(cached_tokens), 0)::bigint AS total_cached_tokens,
+    COALESCE(SUM(input_tokens), 0)::bigint AS total_input_tokens,
+    COALESCE(SUM(output_tokens), 0)::bigint AS total_output_tokens,
+    COALESCE(SUM(input_cost_usd_micros), 0)::bigint AS total_input_cost_usd_micros,
+    COALESCE(SUM(output_cost_usd_micros), 0)::bigint AS total_output_cost_usd_micros
 FROM usage_records
 WHERE model_alias = ANY($1::text[])
   AND ts >= $2
@@ -1128,11 +1246,16 @@ type SumUsageByModelsParams struct {
 }
 
 type SumUsageByModelsRow struct {
-	ModelAlias         string `json:"model_alias"`
-	TotalRequests      int64  `json:"total_requests"`
-	TotalTokens        int64  `json:"total_tokens"`
-	TotalCostCents     int64  `json:"total_cost_cents"`
-	TotalCostUsdMicros int64  `json:"total_cost_usd_micros"`
+	ModelAlias               string `json:"model_alias"`
+	TotalRequests            int64  `json:"total_requests"`
+	TotalTokens              int64  `json:"total_tokens"`
+	TotalCostCents           int64  `json:"total_cost_cents"`
+	TotalCostUsdMicros       int64  `json:"total_cost_usd_micros"`
+	TotalCachedTokens        int64  `json:"total_cached_tokens"`
+	TotalInputTokens         int64  `json:"total_input_tokens"`
+	TotalOutputTokens        int64  `json:"total_output_tokens"`
+	TotalInputCostUsdMicros  int64  `json:"total_input_cost_usd_micros"`
+	TotalOutputCostUsdMicros int64  `json:"total_output_cost_usd_micros"`
 }
 
 func (q *Queries) SumUsageByModels(ctx context.Context, arg SumUsageByModelsParams) ([]SumUsageByModelsRow, error) {
@@ -1155,6 +1278,11 @@ func (q *Queries) SumUsageByModels(ctx context.Context, arg SumUsageByModelsPara
 			&i.TotalTokens,
 			&i.TotalCostCents,
 			&i.TotalCostUsdMicros,
+			&i.TotalCachedTokens,
+			&i.TotalInputTokens,
+			&i.TotalOutputTokens,
+			&i.TotalInputCostUsdMicros,
+			&i.TotalOutputCostUsdMicros,
 		); err != nil {
 			return nil, err
 		}
@@ -1172,7 +1300,10 @@ SELECT
     COALESCE(SUM(requests), 0)::bigint AS total_requests,
     COALESCE(SUM(input_tokens + output_tokens), 0)::bigint AS total_tokens,
     COALESCE(SUM(cost_cents), 0)::bigint AS total_cost_cents,
-    COALESCE(SUM(cost_usd_micros), 0)::bigint AS total_cost_usd_micros
+    COALESCE(SUM(cost_usd_micros), 0)::bigint AS total_cost_usd_micros,
+    COALESCE(SUM(cached_tokens), 0)::bigint AS total_cached_tokens,
+    COALESCE(SUM(input_tokens), 0)::bigint AS total_input_tokens,
+    COALESCE(SUM(output_tokens), 0)::bigint AS total_output_tokens
 FROM usage_records
 WHERE tenant_id = ANY($1::uuid[])
   AND ts >= $2
@@ -1192,6 +1323,9 @@ type SumUsageByTenantsRow struct {
 	TotalTokens        int64       `json:"total_tokens"`
 	TotalCostCents     int64       `json:"total_cost_cents"`
 	TotalCostUsdMicros int64       `json:"total_cost_usd_micros"`
+	TotalCachedTokens  int64       `json:"total_cached_tokens"`
+	TotalInputTokens   int64       `json:"total_input_tokens"`
+	TotalOutputTokens  int64       `json:"total_output_tokens"`
 }
 
 func (q *Queries) SumUsageByTenants(ctx context.Context, arg SumUsageByTenantsParams) ([]SumUsageByTenantsRow, error) {
@@ -1209,6 +1343,9 @@ func (q *Queries) SumUsageByTenants(ctx context.Context, arg SumUsageByTenantsPa
 			&i.TotalTokens,
 			&i.TotalCostCents,
 			&i.TotalCostUsdMicros,
+			&i.TotalCachedTokens,
+			&i.TotalInputTokens,
+			&i.TotalOutputTokens,
 		); err != nil {
 			return nil, err
 		}
@@ -1226,7 +1363,10 @@ SELECT
     COALESCE(SUM(r.requests), 0)::bigint AS total_requests,
     COALESCE(SUM(r.input_tokens + r.output_tokens), 0)::bigint AS total_tokens,
     COALESCE(SUM(r.cost_cents), 0)::bigint AS total_cost_cents,
-    COALESCE(SUM(r.cost_usd_micros), 0)::bigint AS total_cost_usd_micros
+    COALESCE(SUM(r.cost_usd_micros), 0)::bigint AS total_cost_usd_micros,
+    COALESCE(SUM(r.cached_tokens), 0)::bigint AS total_cached_tokens,
+    COALESCE(SUM(r.input_tokens), 0)::bigint AS total_input_tokens,
+    COALESCE(SUM(r.output_tokens), 0)::bigint AS total_output_tokens
 FROM usage_records r
 JOIN api_keys k ON r.api_key_id = k.id
 WHERE k.owner_user_id = ANY($1::uuid[])
@@ -1247,6 +1387,9 @@ type SumUsageByUsersRow struct {
 	TotalTokens        int64       `json:"total_tokens"`
 	TotalCostCents     int64       `json:"total_cost_cents"`
 	TotalCostUsdMicros int64       `json:"total_cost_usd_micros"`
+	TotalCachedTokens  int64       `json:"total_cached_tokens"`
+	TotalInputTokens   int64       `json:"total_input_tokens"`
+	TotalOutputTokens  int64       `json:"total_output_tokens"`
 }
 
 func (q *Queries) SumUsageByUsers(ctx context.Context, arg SumUsageByUsersParams) ([]SumUsageByUsersRow, error) {
@@ -1264,6 +1407,9 @@ func (q *Queries) SumUsageByUsers(ctx context.Context, arg SumUsageByUsersParams
 			&i.TotalTokens,
 			&i.TotalCostCents,
 			&i.TotalCostUsdMicros,
+			&i.TotalCachedTokens,
+			&i.TotalInputTokens,
+			&i.TotalOutputTokens,
 		); err != nil {
 			return nil, err
 		}
@@ -1280,7 +1426,10 @@ SELECT
     COALESCE(SUM(requests), 0)::bigint AS total_requests,
     COALESCE(SUM(input_tokens + output_tokens), 0)::bigint AS total_tokens,
     COALESCE(SUM(cost_cents), 0)::bigint AS total_cost_cents,
-    COALESCE(SUM(cost_usd_micros), 0)::bigint AS total_cost_usd_micros
+    COALESCE(SUM(cost_usd_micros), 0)::bigint AS total_cost_usd_micros,
+    COALESCE(SUM(cached_tokens), 0)::bigint AS total_cached_tokens,
+    COALESCE(SUM(input_tokens), 0)::bigint AS total_input_tokens,
+    COALESCE(SUM(output_tokens), 0)::bigint AS total_output_tokens
 FROM usage_records
 WHERE api_key_id = $1
   AND ts >= $2
@@ -1298,6 +1447,9 @@ type SumUsageForAPIKeyRow struct {
 	TotalTokens        int64 `json:"total_tokens"`
 	TotalCostCents     int64 `json:"total_cost_cents"`
 	TotalCostUsdMicros int64 `json:"total_cost_usd_micros"`
+	TotalCachedTokens  int64 `json:"total_cached_tokens"`
+	TotalInputTokens   int64 `json:"total_input_tokens"`
+	TotalOutputTokens  int64 `json:"total_output_tokens"`
 }
 
 func (q *Queries) SumUsageForAPIKey(ctx context.Context, arg SumUsageForAPIKeyParams) (SumUsageForAPIKeyRow, error) {
@@ -1308,6 +1460,9 @@ func (q *Queries) SumUsageForAPIKey(ctx context.Context, arg SumUsageForAPIKeyPa
 		&i.TotalTokens,
 		&i.TotalCostCents,
 		&i.TotalCostUsdMicros,
+		&i.TotalCachedTokens,
+		&i.TotalInputTokens,
+		&i.TotalOutputTokens,
 	)
 	return i, err
 }
@@ -1357,7 +1512,10 @@ SELECT
     COALESCE(SUM(u.requests), 0)::bigint AS total_requests,
     COALESCE(SUM(u.input_tokens + u.output_tokens), 0)::bigint AS total_tokens,
     COALESCE(SUM(u.cost_cents), 0)::bigint AS total_cost_cents,
-    COALESCE(SUM(u.cost_usd_micros), 0)::bigint AS total_cost_usd_micros
+    COALESCE(SUM(u.cost_usd_micros), 0)::bigint AS total_cost_usd_micros,
+    COALESCE(SUM(u.cached_tokens), 0)::bigint AS total_cached_tokens,
+    COALESCE(SUM(u.input_tokens), 0)::bigint AS total_input_tokens,
+    COALESCE(SUM(u.output_tokens), 0)::bigint AS total_output_tokens
 FROM usage_records u
 JOIN api_keys k ON u.api_key_id = k.id
 WHERE k.owner_user_id = $1
@@ -1378,6 +1536,9 @@ type SumUsageForUserTenantRow struct {
 	TotalTokens        int64 `json:"total_tokens"`
 	TotalCostCents     int64 `json:"total_cost_cents"`
 	TotalCostUsdMicros int64 `json:"total_cost_usd_micros"`
+	TotalCachedTokens  int64 `json:"total_cached_tokens"`
+	TotalInputTokens   int64 `json:"total_input_tokens"`
+	TotalOutputTokens  int64 `json:"total_output_tokens"`
 }
 
 func (q *Queries) SumUsageForUserTenant(ctx context.Context, arg SumUsageForUserTenantParams) (SumUsageForUserTenantRow, error) {
@@ -1393,6 +1554,9 @@ func (q *Queries) SumUsageForUserTenant(ctx context.Context, arg SumUsageForUser
 		&i.TotalTokens,
 		&i.TotalCostCents,
 		&i.TotalCostUsdMicros,
+		&i.TotalCachedTokens,
+		&i.TotalInputTokens,
+		&i.TotalOutputTokens,
 	)
 	return i, err
 }
@@ -11,6 +11,16 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const deleteCredentialsForUser = `-- name: DeleteCredentialsForUser :exec
+DELETE FROM user_credentials
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteCredentialsForUser(ctx context.Context, userID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteCredentialsForUser, userID)
+	return err
+}
+
 const getCredentialBySubject = `-- name: GetCredentialBySubject :one
 SELECT id, user_id, provider, issuer, subject, password_hash, metadata, created_at, updated_at
 FROM user_credentials
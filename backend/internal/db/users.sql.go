@@ -11,6 +11,41 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const anonymizeUser = `-- name: AnonymizeUser :one
+UPDATE users
+SET email = $2,
+    name = $3,
+    is_super_admin = FALSE,
+    personal_tenant_id = NULL,
+    last_login_at = NULL,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING id, email, name, theme_preference, is_super_admin, personal_tenant_id, created_at, updated_at, last_login_at
+`
+
+type AnonymizeUserParams struct {
+	ID    pgtype.UUID `json:"id"`
+	Email string      `json:"email"`
+	Name  string      `json:"name"`
+}
+
+func (q *Queries) AnonymizeUser(ctx context.Context, arg AnonymizeUserParams) (User, error) {
+	row := q.db.QueryRow(ctx, anonymizeUser, arg.ID, arg.Email, arg.Name)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Name,
+		&i.ThemePreference,
+		&i.IsSuperAdmin,
+		&i.PersonalTenantID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LastLoginAt,
+	)
+	return i, err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (email, name)
 VALUES ($1, $2)
@@ -0,0 +1,95 @@
+// Package debug holds opt-in diagnostics for live traffic.
+package debug
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DebugSample is one captured request/response pair.
+type DebugSample struct {
+	TraceID      string            `json:"trace_id"`
+	Method       string            `json:"method"`
+	Path         string            `json:"path"`
+	Status       int               `json:"status"`
+	Headers      map[string]string `json:"headers"`
+	RequestBody  string            `json:"request_body"`
+	ResponseBody string            `json:"response_body"`
+	DurationMS   int64             `json:"duration_ms"`
+	TenantID     string            `json:"tenant_id,omitempty"`
+	CapturedAt   time.Time         `json:"captured_at"`
+}
+
+// SampleBuffer keeps the most recent samples in a fixed-size ring; once full
+// each new sample overwrites the oldest. It is safe for concurrent use.
+type SampleBuffer struct {
+	mu      sync.RWMutex
+	samples []DebugSample
+	next    int
+	full    bool
+}
+
+func NewSampleBuffer(size int) *SampleBuffer {
+	if size <= 0 {
+		size = 1
+	}
+	return &SampleBuffer{samples: make([]DebugSample, size)}
+}
+
+// Add stores sample, evicting the oldest one when the buffer is full.
+func (b *SampleBuffer) Add(sample DebugSample) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.samples[b.next] = sample
+	b.next = (b.next + 1) % len(b.samples)
+	if b.next == 0 {
+		b.full = true
+	}
+}
+
+// Snapshot returns the buffered samples, newest first.
+func (b *SampleBuffer) Snapshot() []DebugSample {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	count := b.next
+	if b.full {
+		count = len(b.samples)
+	}
+	out := make([]DebugSample, 0, count)
+	for i := 1; i <= count; i++ {
+		idx := (b.next - i + len(b.samples)) % len(b.samples)
+		out = append(out, b.samples[idx])
+	}
+	return out
+}
+
+// Capacity reports how many samples the buffer retains.
+func (b *SampleBuffer) Capacity() int {
+	return len(b.samples)
+}
+
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"X-Api-Key":           true,
+	"Api-Key":             true,
+}
+
+// SanitizeHeaders copies headers without credentials: Authorization,
+// cookies, and any header whose name mentions a key, token, or secret.
+func SanitizeHeaders(headers map[string]string) map[string]string {
+	out := make(map[string]string, len(headers))
+	for name, value := range headers {
+		canonical := http.CanonicalHeaderKey(name)
+		lower := strings.ToLower(canonical)
+		if sensitiveHeaders[canonical] || strings.Contains(lower, "key") || strings.Contains(lower, "token") || strings.Contains(lower, "secret") {
+			continue
+		}
+		out[canonical] = value
+	}
+	return out
+}
@@ -0,0 +1,63 @@
+package debug
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestSampleBufferKeepsNewestSamples(t *testing.T) {
+	buffer := NewSampleBuffer(3)
+	if got := buffer.Snapshot(); len(got) != 0 {
+		t.Fatalf("expected empty buffer, got %d samples", len(got))
+	}
+	for i := range 5 {
+		buffer.Add(DebugSample{TraceID: fmt.Sprint(i)})
+	}
+	got := buffer.Snapshot()
+	if len(got) != 3 {
+		t.Fatalf("expected 3 samples, got %d", len(got))
+	}
+	for i, want := range []string{"4", "3", "2"} {
+		if got[i].TraceID != want {
+			t.Fatalf("sample %d: expected trace %s, got %s", i, want, got[i].TraceID)
+		}
+	}
+}
+
+func TestSampleBufferConcurrentUse(t *testing.T) {
+	buffer := NewSampleBuffer(16)
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				buffer.Add(DebugSample{Method: "POST"})
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				_ = buffer.Snapshot()
+			}
+		}()
+	}
+	wg.Wait()
+	if got := len(buffer.Snapshot()); got != 16 {
+		t.Fatalf("expected a full buffer, got %d samples", got)
+	}
+}
+
+func TestSanitizeHeadersDropsCredentials(t *testing.T) {
+	got := SanitizeHeaders(map[string]string{
+		"authorization":   "Bearer sk-secret",
+		"X-API-Key":       "sk-secret",
+		"Cookie":          "session=abc",
+		"X-Session-Token": "abc",
+		"Content-Type":    "application/json",
+	})
+	if len(got) != 1 || got["Content-Type"] != "application/json" {
+		t.Fatalf("expected only Content-Type to remain, got %v", got)
+	}
+}
@@ -0,0 +1,189 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/limits"
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+	"github.com/ncecere/open_model_gateway/backend/internal/providers"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+	usagepipeline "github.com/ncecere/open_model_gateway/backend/internal/services/usagepipeline"
+)
+
+// ChatStream executes a streaming chat completion and hands every content
+// chunk to emit. Budget, rate limit, routing and usage handling match the
+// HTTP SSE handler: the first route whose stream opens serves the request,
+// and usage is recorded once the stream ends. An emit error stops the stream
+// and is returned.
+func (e *Executor) ChatStream(ctx context.Context, rc *requestctx.Context, alias string, req models.ChatRequest, traceID string, idempotencyKey string, emit func(models.ChatChunk) error) (usagepipeline.BudgetStatus, error) {
+	alias, err := e.ResolveVisionAlias(ctx, rc, alias, req)
+	if err != nil {
+		return usagepipeline.BudgetStatus{}, err
+	}
+	routes, err := e.SelectRoutes(rc, alias)
+	if err != nil {
+		return usagepipeline.BudgetStatus{}, err
+	}
+
+	budgetStatus, err := e.container.UsageLogger.CheckBudget(ctx, rc, time.Now().UTC())
+	if err != nil {
+		return usagepipeline.BudgetStatus{}, err
+	}
+	if budgetStatus.Blocked() {
+		_, _ = e.container.UsageLogger.Record(ctx, usagepipeline.Record{
+			Context:   rc,
+			Alias:     alias,
+			Provider:  "budget",
+			Status:    fiber.StatusForbidden,
+			ErrorCode: "budget_exceeded",
+			TraceID:   traceID,
+			Timestamp: time.Now().UTC(),
+			Success:   false,
+		})
+		return budgetStatus, NewAPIError(fiber.StatusForbidden, "tenant budget exceeded")
+	}
+
+	req, _ = ApplySystemPrompt(rc, req)
+	req, err = e.EnforceContextWindow(rc, alias, routes[0], req)
+	if err != nil {
+		return budgetStatus, err
+	}
+
+	keyKey, keyCfg, tenantKey, tenantCfg, release, err := e.container.AcquireRateLimits(ctx, alias)
+	if err != nil {
+		if errors.Is(err, limits.ErrLimitExceeded) {
+			return budgetStatus, NewAPIError(fiber.StatusTooManyRequests, "rate limit exceeded")
+		}
+		return budgetStatus, err
+	}
+	defer func() {
+		if release != nil {
+			release()
+		}
+	}()
+
+	lastErr := errNoBackend
+	var lastRoute providers.Route
+	for _, route := range routes {
+		if route.ChatStream == nil {
+			continue
+		}
+		lastRoute = route
+		req.Model = route.ResolveDeployment()
+		var chunks <-chan models.ChatChunk
+		var cancel func() error
+		err := e.withRetry(ctx, alias, route, func(ctx context.Context) error {
+			var err error
+			chunks, cancel, err = route.ChatStream.ChatStream(ctx, req)
+			return err
+		})
+		if err != nil {
+			e.container.Engine.ReportFailure(alias, route)
+			lastErr = err
+			continue
+		}
+		err = e.relayStream(ctx, rc, alias, route, traceID, idempotencyKey, chunks, emit, keyKey, keyCfg, tenantKey, tenantCfg)
+		cancel()
+		return budgetStatus, err
+	}
+
+	failure := upstreamError(lastRoute, lastErr)
+	if lastRoute.Provider != "" {
+		status, _, _ := AsAPIError(failure)
+		_, _ = e.container.UsageLogger.Record(ctx, usagepipeline.Record{
+			Context:   rc,
+			Alias:     alias,
+			Provider:  lastRoute.Provider,
+			ABVariant: lastRoute.ABVariant,
+			Status:    status,
+			ErrorCode: lastErr.Error(),
+			TraceID:   traceID,
+			Timestamp: time.Now().UTC(),
+			Success:   false,
+		})
+	}
+	return budgetStatus, failure
+}
+
+// relayStream drains an open provider stream into emit and records usage
+// once it ends. Latency is measured to the first content chunk.
+func (e *Executor) relayStream(
+	ctx context.Context,
+	rc *requestctx.Context,
+	alias string,
+	route providers.Route,
+	traceID, idempotencyKey string,
+	chunks <-chan models.ChatChunk,
+	emit func(models.ChatChunk) error,
+	keyKey string,
+	keyCfg limits.LimitConfig,
+	tenantKey string,
+	tenantCfg limits.LimitConfig,
+) error {
+	start := time.Now()
+	var firstTokenLatency time.Duration
+	var usage models.Usage
+	status := fiber.StatusOK
+	success := false
+
+	var emitErr error
+	for chunk := range chunks {
+		if chunk.Usage != nil {
+			usage = *chunk.Usage
+		}
+		if chunk.IsUsageOnly() {
+			continue
+		}
+		if firstTokenLatency == 0 {
+			firstTokenLatency = time.Since(start)
+		}
+		if err := emit(chunk); err != nil {
+			emitErr = err
+			status = fiber.StatusInternalServerError
+			break
+		}
+		success = true
+	}
+
+	if emitErr == nil {
+		e.container.Engine.ReportSuccess(alias, route)
+	} else {
+		e.container.Engine.ReportFailure(alias, route)
+	}
+
+	if tokens := int(usage.TotalTokens); tokens > 0 {
+		if err := e.consumeTokens(ctx, keyKey, tenantKey, tokens, keyCfg, tenantCfg); err != nil {
+			status = fiber.StatusTooManyRequests
+			if _, _, ok := AsAPIError(err); !ok {
+				status = fiber.StatusInternalServerError
+			}
+			success = false
+		}
+	}
+
+	latency := time.Since(start)
+	if firstTokenLatency > 0 {
+		latency = firstTokenLatency
+	}
+	if _, err := e.container.UsageLogger.Record(ctx, usagepipeline.Record{
+		Context:        rc,
+		Alias:          alias,
+		Provider:       route.Provider,
+		ABVariant:      route.ABVariant,
+		Usage:          usage,
+		Latency:        latency,
+		Status:         status,
+		TraceID:        traceID,
+		IdempotencyKey: idempotencyKey,
+		Timestamp:      time.Now().UTC(),
+		Success:        success && status == fiber.StatusOK,
+	}); err != nil {
+		slog.Error("record stream usage", slog.String("alias", alias), slog.String("error", err.Error()))
+	}
+	return emitErr
+}
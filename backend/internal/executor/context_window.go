@@ -0,0 +1,58 @@
+package executor
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+	"github.com/ncecere/open_model_gateway/backend/internal/providers"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+)
+
+// EffectiveModelLimits returns the context window and output cap that apply to
+// alias for the caller: the catalog values carried on route, replaced by any
+// non-zero tenant override on rc.
+func EffectiveModelLimits(rc *requestctx.Context, alias string, route providers.Route) requestctx.ModelLimits {
+	limits := requestctx.ModelLimits{
+		ContextWindow:   route.ContextWindow,
+		MaxOutputTokens: route.MaxOutputTokens,
+	}
+	if rc == nil {
+		return limits
+	}
+	if override, ok := rc.ModelOverrides[alias]; ok {
+		if override.ContextWindow > 0 {
+			limits.ContextWindow = override.ContextWindow
+		}
+		if override.MaxOutputTokens > 0 {
+			limits.MaxOutputTokens = override.MaxOutputTokens
+		}
+	}
+	return limits
+}
+
+// EnforceContextWindow rejects prompts whose estimated size exceeds the
+// effective context window and max_tokens values above the effective output
+// cap, both with a 400. When the tenant overrides the output cap and the
+// caller left max_tokens unset, the cap is applied to the request so the
+// provider honours it. req should already carry the tenant system prompt.
+func (e *Executor) EnforceContextWindow(rc *requestctx.Context, alias string, route providers.Route, req models.ChatRequest) (models.ChatRequest, error) {
+	limits := EffectiveModelLimits(rc, alias, route)
+	if limits.ContextWindow > 0 {
+		tokens := e.container.TokenEstimators.For(route.Provider).EstimateMessages(req.Messages)
+		if tokens > int(limits.ContextWindow) {
+			return req, NewAPIError(fiber.StatusBadRequest, fmt.Sprintf("prompt is about %d tokens, which exceeds the %d token context window for %s", tokens, limits.ContextWindow, alias))
+		}
+	}
+	if limits.MaxOutputTokens > 0 {
+		if req.MaxTokens != nil && *req.MaxTokens > limits.MaxOutputTokens {
+			return req, NewAPIError(fiber.StatusBadRequest, fmt.Sprintf("max_tokens exceeds the %d token output limit for %s", limits.MaxOutputTokens, alias))
+		}
+		if req.MaxTokens == nil && rc != nil && rc.ModelOverrides[alias].MaxOutputTokens > 0 {
+			maxTokens := limits.MaxOutputTokens
+			req.MaxTokens = &maxTokens
+		}
+	}
+	return req, nil
+}
@@ -0,0 +1,64 @@
+package executor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/catalog"
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+	"github.com/ncecere/open_model_gateway/backend/internal/providers"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+)
+
+func TestEnforceContextWindowUsesTenantOverride(t *testing.T) {
+	exec := New(&app.Container{TokenEstimators: catalog.NewTokenEstimatorFactory()})
+	route := providers.Route{Alias: "gpt-4o", Provider: "openai", ContextWindow: 128000}
+	// Roughly 3000 tokens: over the override, well under the catalog window.
+	req := models.ChatRequest{Messages: []models.ChatMessage{{Role: "user", Content: strings.Repeat("word ", 2400)}}}
+
+	restricted := &requestctx.Context{
+		TenantID:       uuid.New(),
+		ModelOverrides: map[string]requestctx.ModelLimits{"gpt-4o": {ContextWindow: 2048}},
+	}
+	_, err := exec.EnforceContextWindow(restricted, "gpt-4o", route, req)
+	status, msg, ok := AsAPIError(err)
+	if !ok || status != fiber.StatusBadRequest {
+		t.Fatalf("expected 400 for overridden tenant, got %v", err)
+	}
+	if !strings.Contains(msg, "2048") {
+		t.Fatalf("expected error to name the override window, got %q", msg)
+	}
+
+	unrestricted := &requestctx.Context{TenantID: uuid.New()}
+	if _, err := exec.EnforceContextWindow(unrestricted, "gpt-4o", route, req); err != nil {
+		t.Fatalf("expected tenant without override to pass, got %v", err)
+	}
+}
+
+func TestEnforceContextWindowCapsOutputTokens(t *testing.T) {
+	exec := New(&app.Container{TokenEstimators: catalog.NewTokenEstimatorFactory()})
+	route := providers.Route{Alias: "gpt-4o", Provider: "openai", ContextWindow: 128000, MaxOutputTokens: 16384}
+	rc := &requestctx.Context{ModelOverrides: map[string]requestctx.ModelLimits{"gpt-4o": {MaxOutputTokens: 512}}}
+	req := models.ChatRequest{Messages: []models.ChatMessage{{Role: "user", Content: "hi"}}}
+
+	capped, err := exec.EnforceContextWindow(rc, "gpt-4o", route, req)
+	if err != nil {
+		t.Fatalf("enforce: %v", err)
+	}
+	if capped.MaxTokens == nil || *capped.MaxTokens != 512 {
+		t.Fatalf("expected max_tokens to default to the override, got %v", capped.MaxTokens)
+	}
+
+	tooMany := int32(1024)
+	req.MaxTokens = &tooMany
+	if _, err := exec.EnforceContextWindow(rc, "gpt-4o", route, req); err == nil {
+		t.Fatal("expected max_tokens above the override to be rejected")
+	}
+	if _, err := exec.EnforceContextWindow(&requestctx.Context{}, "gpt-4o", route, req); err != nil {
+		t.Fatalf("expected catalog output limit to allow 1024, got %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+package executor
+
+import (
+	"context"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+	"github.com/ncecere/open_model_gateway/backend/internal/providers"
+)
+
+// Embed sends req to provider in sub-batches no larger than its BatchSize and
+// reassembles the results. Embedding indexes refer to positions in req.Input
+// and usage is summed across calls. The first failing call aborts the rest.
+func Embed(ctx context.Context, provider providers.EmbeddingsProvider, req models.EmbeddingsRequest) (models.EmbeddingsResponse, error) {
+	size := provider.BatchSize()
+	if size < 1 || len(req.Input) <= size {
+		return provider.Embed(ctx, req)
+	}
+
+	out := models.EmbeddingsResponse{Embeddings: make([]models.Embedding, 0, len(req.Input))}
+	for start := 0; start < len(req.Input); start += size {
+		end := min(start+size, len(req.Input))
+		sub := req
+		sub.Input = req.Input[start:end]
+		resp, err := provider.Embed(ctx, sub)
+		if err != nil {
+			return models.EmbeddingsResponse{}, err
+		}
+		if out.Model == "" {
+			out.Model = resp.Model
+		}
+		for _, emb := range resp.Embeddings {
+			emb.Index += start
+			out.Embeddings = append(out.Embeddings, emb)
+		}
+		out.Usage.PromptTokens += resp.Usage.PromptTokens
+		out.Usage.CompletionTokens += resp.Usage.CompletionTokens
+		out.Usage.TotalTokens += resp.Usage.TotalTokens
+	}
+	return out, nil
+}
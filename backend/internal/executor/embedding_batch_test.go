@@ -0,0 +1,78 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+)
+
+type batchEmbedder struct {
+	size  int
+	calls [][]string
+	fail  int
+}
+
+func (b *batchEmbedder) BatchSize() int { return b.size }
+
+func (b *batchEmbedder) Embed(_ context.Context, req models.EmbeddingsRequest) (models.EmbeddingsResponse, error) {
+	b.calls = append(b.calls, req.Input)
+	if b.fail > 0 && len(b.calls) == b.fail {
+		return models.EmbeddingsResponse{}, errors.New("upstream failed")
+	}
+	resp := models.EmbeddingsResponse{Model: "embed-model"}
+	for i, text := range req.Input {
+		resp.Embeddings = append(resp.Embeddings, models.Embedding{Index: i, Vector: []float32{float32(len(text))}})
+	}
+	resp.Usage.PromptTokens = int32(len(req.Input))
+	resp.Usage.TotalTokens = int32(len(req.Input))
+	return resp, nil
+}
+
+func TestEmbedSplitsAndPreservesIndexes(t *testing.T) {
+	provider := &batchEmbedder{size: 2}
+	inputs := []string{"a", "bb", "ccc", "dddd", "eeeee"}
+
+	resp, err := Embed(context.Background(), provider, models.EmbeddingsRequest{Input: inputs})
+	if err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+	if len(provider.calls) != 3 {
+		t.Fatalf("expected 3 sub-batches, got %d", len(provider.calls))
+	}
+	if len(resp.Embeddings) != len(inputs) {
+		t.Fatalf("expected %d embeddings, got %d", len(inputs), len(resp.Embeddings))
+	}
+	for i, emb := range resp.Embeddings {
+		if emb.Index != i {
+			t.Fatalf("embedding %d has index %d", i, emb.Index)
+		}
+		if int(emb.Vector[0]) != len(inputs[i]) {
+			t.Fatalf("embedding %d belongs to another input", i)
+		}
+	}
+	if resp.Usage.TotalTokens != 5 || resp.Model != "embed-model" {
+		t.Fatalf("unexpected merged response: %+v", resp)
+	}
+}
+
+func TestEmbedUnlimitedBatchSizeMakesOneCall(t *testing.T) {
+	provider := &batchEmbedder{size: 0}
+	if _, err := Embed(context.Background(), provider, models.EmbeddingsRequest{Input: []string{"a", "b", "c"}}); err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+	if len(provider.calls) != 1 {
+		t.Fatalf("expected a single call, got %d", len(provider.calls))
+	}
+}
+
+func TestEmbedStopsOnSubBatchError(t *testing.T) {
+	provider := &batchEmbedder{size: 1, fail: 2}
+	if _, err := Embed(context.Background(), provider, models.EmbeddingsRequest{Input: []string{"a", "b", "c"}}); err == nil {
+		t.Fatal("expected error")
+	}
+	if len(provider.calls) != 2 {
+		t.Fatalf("expected to stop after the failing call, got %d calls", len(provider.calls))
+	}
+}
@@ -0,0 +1,28 @@
+package executor
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/providers"
+)
+
+// ValidateEmbeddingDimensions rejects a requested embedding size below one or
+// above the native size of any route serving alias, with a 400. Routes whose
+// catalog entry leaves max_dimensions unset are not checked. A nil dimensions
+// keeps the model's native size and always passes.
+func ValidateEmbeddingDimensions(alias string, routes []providers.Route, dimensions *int32) error {
+	if dimensions == nil {
+		return nil
+	}
+	if *dimensions < 1 {
+		return NewAPIError(fiber.StatusBadRequest, "dimensions must be at least 1")
+	}
+	for _, route := range routes {
+		if route.MaxDimensions > 0 && *dimensions > route.MaxDimensions {
+			return NewAPIError(fiber.StatusBadRequest, fmt.Sprintf("dimensions exceeds the %d native dimensions of %s", route.MaxDimensions, alias))
+		}
+	}
+	return nil
+}
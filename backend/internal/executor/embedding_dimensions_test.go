@@ -0,0 +1,30 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/providers"
+)
+
+func TestValidateEmbeddingDimensions(t *testing.T) {
+	routes := []providers.Route{{Alias: "embed", MaxDimensions: 1536}, {Alias: "embed"}}
+	dims := func(v int32) *int32 { return &v }
+
+	if err := ValidateEmbeddingDimensions("embed", routes, nil); err != nil {
+		t.Fatalf("expected unset dimensions to pass, got %v", err)
+	}
+	if err := ValidateEmbeddingDimensions("embed", routes, dims(256)); err != nil {
+		t.Fatalf("expected reduced dimensions to pass, got %v", err)
+	}
+	for _, bad := range []int32{0, -1, 3072} {
+		status, _, ok := AsAPIError(ValidateEmbeddingDimensions("embed", routes, dims(bad)))
+		if !ok || status != fiber.StatusBadRequest {
+			t.Fatalf("expected 400 for dimensions %d", bad)
+		}
+	}
+	if err := ValidateEmbeddingDimensions("embed", []providers.Route{{Alias: "embed"}}, dims(3072)); err != nil {
+		t.Fatalf("expected routes without max_dimensions to skip the check, got %v", err)
+	}
+}
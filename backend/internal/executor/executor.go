@@ -2,12 +2,14 @@ package executor
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 
 	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
 	"github.com/ncecere/open_model_gateway/backend/internal/limits"
 	"github.com/ncecere/open_model_gateway/backend/internal/models"
 	"github.com/ncecere/open_model_gateway/backend/internal/providers"
@@ -19,16 +21,19 @@ import (
 // background workers can invoke the same code path.
 type Executor struct {
 	container *app.Container
+	// mirrors holds one slot per in-flight mirror request; see MirrorChat.
+	mirrors chan struct{}
 }
 
 func New(container *app.Container) *Executor {
-	return &Executor{container: container}
+	return &Executor{container: container, mirrors: make(chan struct{}, maxInFlightMirrors)}
 }
 
 // ChatResult captures the outcome of a chat execution.
 type ChatResult struct {
-	Response     models.ChatResponse
-	BudgetStatus usagepipeline.BudgetStatus
+	Response            models.ChatResponse
+	BudgetStatus        usagepipeline.BudgetStatus
+	SystemPromptApplied bool
 }
 
 // apiError wraps an error with an HTTP status code so callers can map it
@@ -56,16 +61,25 @@ func AsAPIError(err error) (int, string, bool) {
 
 // Chat executes a chat completion against the routed providers.
 func (e *Executor) Chat(ctx context.Context, rc *requestctx.Context, alias string, req models.ChatRequest, traceID string, idempotencyKey string) (ChatResult, error) {
-	routes := e.container.Engine.SelectRoutes(alias)
-	if len(routes) == 0 {
-		return ChatResult{}, NewAPIError(fiber.StatusServiceUnavailable, "no backend available for model")
+	timings := requestctx.TimingsFromContext(ctx)
+	stageStart := time.Now()
+	alias, err := e.ResolveVisionAlias(ctx, rc, alias, req)
+	if err != nil {
+		return ChatResult{}, err
+	}
+	routes, err := e.SelectRoutes(rc, alias)
+	timings.Track(requestctx.StageRouteSelect, stageStart)
+	if err != nil {
+		return ChatResult{}, err
 	}
 
+	stageStart = time.Now()
 	budgetStatus, err := e.container.UsageLogger.CheckBudget(ctx, rc, time.Now().UTC())
+	timings.Track(requestctx.StageBudgetCheck, stageStart)
 	if err != nil {
 		return ChatResult{}, err
 	}
-	if budgetStatus.Exceeded {
+	if budgetStatus.Blocked() {
 		_, _ = e.container.UsageLogger.Record(ctx, usagepipeline.Record{
 			Context:   rc,
 			Alias:     alias,
@@ -79,7 +93,21 @@ func (e *Executor) Chat(ctx context.Context, rc *requestctx.Context, alias strin
 		return ChatResult{BudgetStatus: budgetStatus}, NewAPIError(fiber.StatusForbidden, "tenant budget exceeded")
 	}
 
+	var requestPayload []byte
+	if e.container.UsageLogger.PayloadLoggingEnabled() {
+		logged := req
+		logged.Model = alias
+		requestPayload, _ = json.Marshal(logged)
+	}
+	req, promptApplied := ApplySystemPrompt(rc, req)
+	req, err = e.EnforceContextWindow(rc, alias, routes[0], req)
+	if err != nil {
+		return ChatResult{BudgetStatus: budgetStatus}, err
+	}
+
+	stageStart = time.Now()
 	keyKey, keyCfg, tenantKey, tenantCfg, release, err := e.container.AcquireRateLimits(ctx, alias)
+	timings.Track(requestctx.StageRateLimit, stageStart)
 	if err != nil {
 		if errors.Is(err, limits.ErrLimitExceeded) {
 			return ChatResult{}, NewAPIError(fiber.StatusTooManyRequests, "rate limit exceeded")
@@ -92,30 +120,22 @@ func (e *Executor) Chat(ctx context.Context, rc *requestctx.Context, alias strin
 		}
 	}()
 
-	var lastErr error
-	var lastRoute providers.Route
-	var lastLatency time.Duration
-
-	for _, route := range routes {
-		if route.Chat == nil {
-			continue
-		}
-		lastRoute = route
-		req.Model = route.ResolveDeployment()
-		start := time.Now()
-		resp, err := route.Chat.Chat(ctx, req)
-		if err != nil {
-			e.container.Engine.ReportFailure(alias, route)
-			lastLatency = time.Since(start)
-			lastErr = err
-			continue
-		}
-		e.container.Engine.ReportSuccess(alias, route)
-		elapsed := time.Since(start)
-		lastLatency = elapsed
+	var attempt chatAttempt
+	stageStart = time.Now()
+	if routes[0].RoutingPolicy == config.RoutingPolicyFastest {
+		attempt = e.raceChat(ctx, alias, routes, req)
+	} else {
+		attempt = e.sequentialChat(ctx, alias, routes, req)
+	}
+	timings.Track(requestctx.StageProvider, stageStart)
 
+	if attempt.err == nil {
+		resp := attempt.resp
 		if tokens := int(resp.Usage.TotalTokens); tokens > 0 {
-			if err := e.consumeTokens(ctx, keyKey, tenantKey, tokens, keyCfg, tenantCfg); err != nil {
+			stageStart = time.Now()
+			err := e.consumeTokens(ctx, keyKey, tenantKey, tokens, keyCfg, tenantCfg)
+			timings.Track(requestctx.StageRateLimit, stageStart)
+			if err != nil {
 				return ChatResult{}, err
 			}
 		}
@@ -123,44 +143,146 @@ func (e *Executor) Chat(ctx context.Context, rc *requestctx.Context, alias strin
 		record := usagepipeline.Record{
 			Context:        rc,
 			Alias:          alias,
-			Provider:       route.Provider,
+			Provider:       attempt.route.Provider,
+			ABVariant:      attempt.route.ABVariant,
 			Usage:          resp.Usage,
-			Latency:        elapsed,
+			Latency:        attempt.latency,
 			Status:         fiber.StatusOK,
 			IdempotencyKey: idempotencyKey,
 			TraceID:        traceID,
 			Timestamp:      time.Now().UTC(),
 			Success:        true,
+			RequestPayload: requestPayload,
 		}
+		if requestPayload != nil {
+			record.ResponsePayload, _ = json.Marshal(resp)
+		}
+		stageStart = time.Now()
 		budgetStatus, err := e.container.UsageLogger.Record(ctx, record)
+		timings.Track(requestctx.StageUsageRecord, stageStart)
 		if err != nil {
 			return ChatResult{}, err
 		}
 
 		return ChatResult{
-			Response:     resp,
-			BudgetStatus: budgetStatus,
+			Response:            resp,
+			BudgetStatus:        budgetStatus,
+			SystemPromptApplied: promptApplied,
 		}, nil
 	}
 
-	if lastErr == nil {
-		lastErr = errors.New("no backend available")
-	}
-	if lastRoute.Provider != "" {
+	lastErr := attempt.err
+	failure := upstreamError(attempt.route, lastErr)
+	if attempt.route.Provider != "" {
+		status, _, _ := AsAPIError(failure)
 		_, _ = e.container.UsageLogger.Record(ctx, usagepipeline.Record{
-			Context:   rc,
-			Alias:     alias,
-			Provider:  lastRoute.Provider,
-			Latency:   lastLatency,
-			Status:    fiber.StatusBadGateway,
-			ErrorCode: lastErr.Error(),
-			TraceID:   traceID,
-			Timestamp: time.Now().UTC(),
-			Success:   false,
+			Context:        rc,
+			Alias:          alias,
+			Provider:       attempt.route.Provider,
+			ABVariant:      attempt.route.ABVariant,
+			Latency:        attempt.latency,
+			Status:         status,
+			ErrorCode:      lastErr.Error(),
+			TraceID:        traceID,
+			Timestamp:      time.Now().UTC(),
+			Success:        false,
+			RequestPayload: requestPayload,
 		})
 	}
 
-	return ChatResult{}, NewAPIError(fiber.StatusBadGateway, lastErr.Error())
+	return ChatResult{}, failure
+}
+
+// chatAttempt is the outcome of dispatching a chat request to one route.
+type chatAttempt struct {
+	route   providers.Route
+	resp    models.ChatResponse
+	latency time.Duration
+	err     error
+}
+
+var errNoBackend = errors.New("no backend available")
+
+// sequentialChat tries routes in order and returns the first success, or the
+// last failure when every route errors.
+func (e *Executor) sequentialChat(ctx context.Context, alias string, routes []providers.Route, req models.ChatRequest) chatAttempt {
+	last := chatAttempt{err: errNoBackend}
+	for _, route := range routes {
+		if route.Chat == nil {
+			continue
+		}
+		attempt := e.callChat(ctx, alias, route, req)
+		if attempt.err != nil {
+			e.container.Engine.ReportFailure(alias, route)
+			last = attempt
+			continue
+		}
+		e.container.Engine.ReportSuccess(alias, route)
+		e.container.Engine.ReportLatency(alias, route, attempt.latency)
+		return attempt
+	}
+	return last
+}
+
+// raceChat dispatches to every route concurrently and returns the first
+// success. The winner is the first goroutine to fill the single-slot channel;
+// returning cancels the shared context so the losing calls abort promptly.
+func (e *Executor) raceChat(ctx context.Context, alias string, routes []providers.Route, req models.ChatRequest) chatAttempt {
+	raceCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	winner := make(chan chatAttempt, 1)
+	failures := make(chan chatAttempt, len(routes))
+	launched := 0
+	for _, route := range routes {
+		if route.Chat == nil {
+			continue
+		}
+		launched++
+		go func(route providers.Route) {
+			attempt := e.callChat(raceCtx, alias, route, req)
+			if attempt.err != nil {
+				failures <- attempt
+				return
+			}
+			select {
+			case winner <- attempt:
+			default:
+			}
+		}(route)
+	}
+
+	last := chatAttempt{err: errNoBackend}
+	for pending := launched; pending > 0; pending-- {
+		select {
+		case attempt := <-winner:
+			cancel()
+			e.container.Engine.ReportSuccess(alias, attempt.route)
+			e.container.Engine.ReportLatency(alias, attempt.route, attempt.latency)
+			return attempt
+		case attempt := <-failures:
+			e.container.Engine.ReportFailure(alias, attempt.route)
+			last = attempt
+		}
+	}
+	return last
+}
+
+// callChat dispatches to one route, each attempt under its provider timeout,
+// retrying transient errors; see withRetry.
+func (e *Executor) callChat(ctx context.Context, alias string, route providers.Route, req models.ChatRequest) chatAttempt {
+	req.Model = route.ResolveDeployment()
+	start := time.Now()
+	var resp models.ChatResponse
+	err := e.withRetry(ctx, alias, route, func(ctx context.Context) error {
+		callCtx, cancel, timeout := e.RouteContext(ctx, route)
+		defer cancel()
+		var err error
+		resp, err = route.Chat.Chat(callCtx, req)
+		LogRouteTimeout(callCtx, alias, route, timeout, err)
+		return err
+	})
+	return chatAttempt{route: route, resp: resp, latency: time.Since(start), err: err}
 }
 
 func (e *Executor) consumeTokens(ctx context.Context, keyKey, tenantKey string, tokens int, keyCfg, tenantCfg limits.LimitConfig) error {
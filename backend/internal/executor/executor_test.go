@@ -0,0 +1,112 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+	"github.com/ncecere/open_model_gateway/backend/internal/providers"
+	"github.com/ncecere/open_model_gateway/backend/internal/router"
+)
+
+type delayedChat struct {
+	delay    time.Duration
+	id       string
+	canceled chan struct{}
+}
+
+func (d *delayedChat) Chat(ctx context.Context, req models.ChatRequest) (models.ChatResponse, error) {
+	select {
+	case <-time.After(d.delay):
+		return models.ChatResponse{ID: d.id, Model: req.Model}, nil
+	case <-ctx.Done():
+		close(d.canceled)
+		return models.ChatResponse{}, ctx.Err()
+	}
+}
+
+func TestRaceChatFastestRouteWins(t *testing.T) {
+	slow := &delayedChat{delay: 2 * time.Second, id: "slow", canceled: make(chan struct{})}
+	fast := &delayedChat{delay: 10 * time.Millisecond, id: "fast", canceled: make(chan struct{})}
+	routes := []providers.Route{
+		{Alias: "gpt", Provider: "openai", Model: "slow-model", Chat: slow, RoutingPolicy: config.RoutingPolicyFastest},
+		{Alias: "gpt", Provider: "azure", Model: "fast-model", Chat: fast, RoutingPolicy: config.RoutingPolicyFastest},
+	}
+	exec := New(&app.Container{Engine: router.NewEngine()})
+
+	start := time.Now()
+	attempt := exec.raceChat(context.Background(), "gpt", routes, models.ChatRequest{})
+	if attempt.err != nil {
+		t.Fatalf("raceChat: %v", attempt.err)
+	}
+	if attempt.resp.ID != "fast" || attempt.route.Provider != "azure" {
+		t.Fatalf("expected fast route to win, got %q from %q", attempt.resp.ID, attempt.route.Provider)
+	}
+	if attempt.resp.Model != "fast-model" {
+		t.Fatalf("expected request to carry winning deployment, got %q", attempt.resp.Model)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("raceChat waited for the slow route (%s)", elapsed)
+	}
+
+	select {
+	case <-slow.canceled:
+	case <-time.After(time.Second):
+		t.Fatal("slow route was not canceled")
+	}
+}
+
+func TestRouteTimeoutFromMetadata(t *testing.T) {
+	fallback := 280 * time.Second
+	cases := map[string]time.Duration{
+		"":     fallback,
+		"abc":  fallback,
+		"-1":   fallback,
+		"30":   30 * time.Second,
+		"0.1":  100 * time.Millisecond,
+		" 45 ": 45 * time.Second,
+	}
+	for raw, want := range cases {
+		route := providers.Route{Metadata: map[string]string{ProviderTimeoutKey: raw}}
+		if got := RouteTimeout(route, fallback); got != want {
+			t.Fatalf("RouteTimeout(%q) = %s, want %s", raw, got, want)
+		}
+	}
+}
+
+func TestRouteTimeoutMapsDeadlineTo502(t *testing.T) {
+	sleepy := &delayedChat{delay: 200 * time.Millisecond, id: "late", canceled: make(chan struct{})}
+	routes := []providers.Route{{
+		Alias: "gpt", Provider: "openai", Model: "gpt-4o", Chat: sleepy,
+		Metadata: map[string]string{ProviderTimeoutKey: "0.1"},
+	}}
+	exec := New(&app.Container{Engine: router.NewEngine(), Config: &config.Config{Server: config.ServerConfig{ProviderTimeout: 5 * time.Second}}})
+
+	start := time.Now()
+	attempt := exec.sequentialChat(context.Background(), "gpt", routes, models.ChatRequest{})
+	if !errors.Is(attempt.err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", attempt.err)
+	}
+	if elapsed := time.Since(start); elapsed >= 200*time.Millisecond {
+		t.Fatalf("expected the 100ms model timeout to cut the call short, took %s", elapsed)
+	}
+	status, _, ok := AsAPIError(upstreamError(attempt.route, attempt.err))
+	if !ok || status != fiber.StatusBadGateway {
+		t.Fatalf("expected 502, got %d (%v)", status, ok)
+	}
+}
+
+func TestUpstreamErrorAppliesRouteErrorMapping(t *testing.T) {
+	route := providers.Route{Provider: "bedrock", ErrorMapping: map[string]int{"ThrottlingException": fiber.StatusTooManyRequests}}
+	err := errors.New("operation error Bedrock Runtime: Converse, ThrottlingException: rate exceeded")
+	status, msg, ok := AsAPIError(upstreamError(route, err))
+	if !ok || status != fiber.StatusTooManyRequests || msg != err.Error() {
+		t.Fatalf("expected mapped 429, got %d %q (%v)", status, msg, ok)
+	}
+}
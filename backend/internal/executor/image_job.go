@@ -0,0 +1,99 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+	"github.com/ncecere/open_model_gateway/backend/internal/providers"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+	imagejobsvc "github.com/ncecere/open_model_gateway/backend/internal/services/imagejobs"
+	usagepipeline "github.com/ncecere/open_model_gateway/backend/internal/services/usagepipeline"
+)
+
+// GenerateImage runs a queued image job on behalf of the API key that
+// submitted it. Budget and rate limits were enforced at submission, so only
+// the outcome is recorded here. It satisfies imagejobs.Processor.
+func (e *Executor) GenerateImage(ctx context.Context, job imagejobsvc.Job) (models.ImageResponse, error) {
+	key, err := e.container.Queries.GetAPIKeyByID(ctx, pgtype.UUID{Bytes: job.APIKeyID, Valid: true})
+	if err != nil {
+		return models.ImageResponse{}, err
+	}
+	if key.RevokedAt.Valid {
+		return models.ImageResponse{}, errors.New("api key revoked")
+	}
+	if key.SuspendedAt.Valid {
+		return models.ImageResponse{}, errors.New("api key suspended")
+	}
+	rc, err := app.BuildRequestContext(ctx, e.container, key)
+	if err != nil {
+		return models.ImageResponse{}, err
+	}
+	ctx = requestctx.WithContext(ctx, rc)
+
+	routes, err := e.SelectRoutes(rc, job.Alias)
+	if err != nil {
+		return models.ImageResponse{}, err
+	}
+	var lastErr error
+	var lastRoute providers.Route
+	for _, route := range routes {
+		if route.Image == nil {
+			continue
+		}
+		lastRoute = route
+		req := job.Request
+		req.Model = route.ResolveDeployment()
+
+		start := time.Now()
+		routeCtx, cancel, timeout := e.RouteContext(ctx, route)
+		resp, err := route.Image.Generate(routeCtx, req)
+		LogRouteTimeout(routeCtx, job.Alias, route, timeout, err)
+		cancel()
+		if err != nil {
+			e.container.Engine.ReportFailure(job.Alias, route)
+			lastErr = err
+			continue
+		}
+		e.container.Engine.ReportSuccess(job.Alias, route)
+
+		if _, err := e.container.UsageLogger.Record(ctx, usagepipeline.Record{
+			Context:   rc,
+			Alias:     job.Alias,
+			Provider:  route.Provider,
+			ABVariant: route.ABVariant,
+			Usage:     resp.Usage,
+			Latency:   time.Since(start),
+			Status:    fiber.StatusOK,
+			TraceID:   job.TraceID,
+			Timestamp: time.Now().UTC(),
+			Success:   true,
+		}); err != nil {
+			return models.ImageResponse{}, err
+		}
+		return resp, nil
+	}
+
+	if lastErr == nil {
+		lastErr = errors.New("no backend available for model")
+	}
+	if lastRoute.Provider != "" {
+		_, _ = e.container.UsageLogger.Record(ctx, usagepipeline.Record{
+			Context:   rc,
+			Alias:     job.Alias,
+			Provider:  lastRoute.Provider,
+			ABVariant: lastRoute.ABVariant,
+			Status:    fiber.StatusBadGateway,
+			ErrorCode: lastErr.Error(),
+			TraceID:   job.TraceID,
+			Timestamp: time.Now().UTC(),
+			Success:   false,
+		})
+	}
+	return models.ImageResponse{}, lastErr
+}
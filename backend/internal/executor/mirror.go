@@ -0,0 +1,115 @@
+package executor
+
+import (
+	"context"
+	"log/slog"
+	"math/rand"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+	usagepipeline "github.com/ncecere/open_model_gateway/backend/internal/services/usagepipeline"
+)
+
+// MirrorTag marks usage recorded for mirrored requests.
+const MirrorTag = "is_mirror"
+
+const defaultMirrorTimeout = 280 * time.Second
+
+// maxInFlightMirrors caps the background mirror goroutines. Sampled requests
+// that arrive while every slot is taken are not mirrored, so a slow mirror
+// model cannot pile up goroutines under load.
+const maxInFlightMirrors = 64
+
+// MirrorChat sends a copy of req, as it was sent to the primary model (system
+// prompt applied), to the mirror model configured for alias, if any and if
+// the request is sampled. It returns immediately: the mirror runs in the
+// background under its own timeout, its response is discarded, and only its
+// usage is recorded, tagged is_mirror. Failures are logged and never reach
+// the caller. At most maxInFlightMirrors mirrors run at once; beyond that the
+// request is not mirrored. Only HTTP handlers call this; batches are not
+// mirrored. Of ctx only the span context is kept, so the mirror joins the
+// request's trace.
+func (e *Executor) MirrorChat(ctx context.Context, rc *requestctx.Context, alias string, req models.ChatRequest, traceID string) {
+	if rc == nil || e.container.Engine == nil {
+		return
+	}
+	mirrorAlias, rate := e.container.Engine.MirrorTarget(alias)
+	if mirrorAlias == "" || !mirrorSampled(rate, rand.Float64()) {
+		return
+	}
+	select {
+	case e.mirrors <- struct{}{}:
+	default:
+		slog.Debug("mirror skipped: too many mirrors in flight", slog.String("alias", alias), slog.String("mirror_alias", mirrorAlias))
+		return
+	}
+	req.Stream = false
+	mirrorRC := mirrorContext(rc)
+
+	timeout := defaultMirrorTimeout
+	if cfg := e.container.Config; cfg != nil && cfg.Server.ProviderTimeout > 0 {
+		timeout = cfg.Server.ProviderTimeout
+	}
+	spanCtx := trace.SpanContextFromContext(ctx)
+	go func() {
+		defer func() { <-e.mirrors }()
+		ctx, cancel := context.WithTimeout(trace.ContextWithSpanContext(context.Background(), spanCtx), timeout)
+		defer cancel()
+		e.runMirror(ctx, mirrorRC, alias, mirrorAlias, req, traceID)
+	}()
+}
+
+func (e *Executor) runMirror(ctx context.Context, rc *requestctx.Context, alias, mirrorAlias string, req models.ChatRequest, traceID string) {
+	routes, err := e.SelectRoutes(rc, mirrorAlias)
+	if err != nil {
+		slog.Warn("mirror skipped: no backend", slog.String("alias", alias), slog.String("mirror_alias", mirrorAlias), slog.String("error", err.Error()))
+		return
+	}
+	attempt := e.sequentialChat(ctx, mirrorAlias, routes, req)
+	record := usagepipeline.Record{
+		Context:   rc,
+		Alias:     mirrorAlias,
+		Provider:  attempt.route.Provider,
+		ABVariant: attempt.route.ABVariant,
+		Latency:   attempt.latency,
+		TraceID:   traceID,
+		Timestamp: time.Now().UTC(),
+	}
+	if attempt.err != nil {
+		slog.Warn("mirror request failed", slog.String("alias", alias), slog.String("mirror_alias", mirrorAlias), slog.String("error", attempt.err.Error()))
+		if attempt.route.Provider == "" {
+			return
+		}
+		record.Status = fiber.StatusBadGateway
+		record.ErrorCode = attempt.err.Error()
+	} else {
+		record.Status = fiber.StatusOK
+		record.Usage = attempt.resp.Usage
+		record.Success = true
+	}
+	if _, err := e.container.UsageLogger.Record(ctx, record); err != nil {
+		slog.Error("record mirror usage", slog.String("mirror_alias", mirrorAlias), slog.String("error", err.Error()))
+	}
+}
+
+// mirrorSampled reports whether a request with the given roll in [0,1) is
+// mirrored. A zero rate mirrors nothing and a rate of one mirrors everything.
+func mirrorSampled(rate, roll float64) bool {
+	return roll < rate
+}
+
+// mirrorContext copies rc so the background mirror never shares state with
+// the finished request, and adds the is_mirror tag.
+func mirrorContext(rc *requestctx.Context) *requestctx.Context {
+	out := *rc
+	out.Tags = make(map[string]string, len(rc.Tags)+1)
+	for k, v := range rc.Tags {
+		out.Tags[k] = v
+	}
+	out.Tags[MirrorTag] = "true"
+	return &out
+}
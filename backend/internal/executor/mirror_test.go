@@ -0,0 +1,37 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+)
+
+func TestMirrorSampled(t *testing.T) {
+	cases := []struct {
+		rate, roll float64
+		want       bool
+	}{
+		{0, 0, false},
+		{0, 0.99, false},
+		{1, 0.99, true},
+		{0.25, 0.1, true},
+		{0.25, 0.25, false},
+		{0.25, 0.9, false},
+	}
+	for _, tc := range cases {
+		if got := mirrorSampled(tc.rate, tc.roll); got != tc.want {
+			t.Fatalf("mirrorSampled(%v, %v) = %v, want %v", tc.rate, tc.roll, got, tc.want)
+		}
+	}
+}
+
+func TestMirrorContextTagsCopy(t *testing.T) {
+	rc := &requestctx.Context{Tags: map[string]string{"project": "a"}}
+	mirror := mirrorContext(rc)
+	if mirror.Tags[MirrorTag] != "true" || mirror.Tags["project"] != "a" {
+		t.Fatalf("unexpected mirror tags %v", mirror.Tags)
+	}
+	if _, ok := rc.Tags[MirrorTag]; ok {
+		t.Fatal("expected original tags to be left untouched")
+	}
+}
@@ -0,0 +1,33 @@
+package executor
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/providers"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+	"github.com/ncecere/open_model_gateway/backend/internal/router"
+)
+
+// noResidentRouteMessage is returned with 451 when the tenant's data residency
+// rules out every route for the model.
+const noResidentRouteMessage = "no backend for this model satisfies the tenant's data residency requirements"
+
+// SelectRoutes returns the alias's routes allowed by the caller's data
+// residency. It fails with 503 when the alias has no healthy route and with
+// 451 Unavailable For Legal Reasons when residency filtered out every route.
+func (e *Executor) SelectRoutes(rc *requestctx.Context, alias string) ([]providers.Route, error) {
+	var residency []string
+	if rc != nil {
+		residency = rc.DataResidency
+	}
+	routes, err := e.container.Engine.SelectResidentRoutes(alias, residency)
+	if errors.Is(err, router.ErrDataResidency) {
+		return nil, NewAPIError(fiber.StatusUnavailableForLegalReasons, noResidentRouteMessage)
+	}
+	if len(routes) == 0 {
+		return nil, NewAPIError(fiber.StatusServiceUnavailable, "no backend available for model")
+	}
+	return routes, nil
+}
@@ -0,0 +1,100 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/openai/openai-go/v3"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/providers"
+)
+
+// isTransient reports whether err is an upstream HTTP response worth retrying
+// on the same route: rate limiting or a gateway/availability failure. The
+// route's error_mapping is applied first, so an error mapped to 400 is not
+// retried and one mapped to 503 is.
+func isTransient(route providers.Route, err error) bool {
+	status := upstreamStatus(err)
+	if mapped, ok := providers.MappedErrorStatus(route.Provider, err, route.ErrorMapping); ok {
+		status = mapped
+	}
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// upstreamStatus extracts the provider's HTTP status from err, or 0 when the
+// failure never produced a response. OpenAI and Azure surface the SDK's
+// error type; the other adapters and the AWS SDK expose HTTPStatusCode.
+func upstreamStatus(err error) int {
+	var sdkErr *openai.Error
+	if errors.As(err, &sdkErr) {
+		return sdkErr.StatusCode
+	}
+	var coded interface{ HTTPStatusCode() int }
+	if errors.As(err, &coded) {
+		return coded.HTTPStatusCode()
+	}
+	return 0
+}
+
+// withRetry runs call, retrying transient failures up to retry.max_retries
+// times with jittered exponential backoff. A retry is skipped when its wait
+// would outlast ctx's deadline. Each retry is recorded on the active span.
+func (e *Executor) withRetry(ctx context.Context, alias string, route providers.Route, call func(context.Context) error) error {
+	var maxRetries int
+	var backoff time.Duration
+	if cfg := e.container.Config; cfg != nil {
+		maxRetries, backoff = cfg.Retry.MaxRetries, cfg.Retry.InitialBackoff
+	}
+
+	err := call(ctx)
+	for retry := 1; retry <= maxRetries && err != nil && isTransient(route, err); retry++ {
+		wait := retryBackoff(backoff, retry, rand.Float64())
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= wait {
+			break
+		}
+		status := upstreamStatus(err)
+		trace.SpanFromContext(ctx).AddEvent("provider.retry", trace.WithAttributes(
+			attribute.String("alias", alias),
+			attribute.String("provider", route.Provider),
+			attribute.Int("retry", retry),
+			attribute.Int("status", status),
+			attribute.Int64("backoff_ms", wait.Milliseconds()),
+		))
+		slog.Warn("retrying transient provider error",
+			slog.String("alias", alias),
+			slog.String("provider", route.Provider),
+			slog.Int("retry", retry),
+			slog.Int("status", status),
+			slog.Duration("backoff", wait))
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		err = call(ctx)
+	}
+	return err
+}
+
+// retryBackoff doubles base for each retry and keeps a random share between
+// half and all of it, so clients that failed together do not retry together.
+func retryBackoff(base time.Duration, retry int, roll float64) time.Duration {
+	if base <= 0 {
+		return 0
+	}
+	wait := base << (retry - 1)
+	return wait/2 + time.Duration(roll*float64(wait/2))
+}
@@ -0,0 +1,127 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/openai/openai-go/v3"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+	"github.com/ncecere/open_model_gateway/backend/internal/providers"
+	"github.com/ncecere/open_model_gateway/backend/internal/router"
+)
+
+type statusErr int
+
+func (s statusErr) Error() string       { return fmt.Sprintf("upstream status %d", int(s)) }
+func (s statusErr) HTTPStatusCode() int { return int(s) }
+
+// flakyChat fails with the queued errors before succeeding.
+type flakyChat struct {
+	failures []error
+	calls    int
+}
+
+func (f *flakyChat) Chat(ctx context.Context, req models.ChatRequest) (models.ChatResponse, error) {
+	f.calls++
+	if f.calls <= len(f.failures) {
+		return models.ChatResponse{}, f.failures[f.calls-1]
+	}
+	return models.ChatResponse{ID: "ok", Model: req.Model}, nil
+}
+
+func newRetryExecutor(maxRetries int) *Executor {
+	return New(&app.Container{
+		Engine: router.NewEngine(),
+		Config: &config.Config{Retry: config.RetryConfig{MaxRetries: maxRetries, InitialBackoff: time.Millisecond}},
+	})
+}
+
+func TestCallChatRetriesTransientErrors(t *testing.T) {
+	chat := &flakyChat{failures: []error{statusErr(503), statusErr(503)}}
+	route := providers.Route{Alias: "gpt", Provider: "openai", Model: "gpt-4o", Chat: chat}
+
+	attempt := newRetryExecutor(2).callChat(context.Background(), "gpt", route, models.ChatRequest{})
+	if attempt.err != nil {
+		t.Fatalf("expected the third attempt to succeed, got %v", attempt.err)
+	}
+	if chat.calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", chat.calls)
+	}
+	if attempt.resp.ID != "ok" || attempt.resp.Model != "gpt-4o" {
+		t.Fatalf("unexpected response %+v", attempt.resp)
+	}
+}
+
+func TestCallChatStopsAfterMaxRetries(t *testing.T) {
+	chat := &flakyChat{failures: []error{statusErr(503), statusErr(503), statusErr(503)}}
+	route := providers.Route{Alias: "gpt", Provider: "openai", Chat: chat}
+
+	attempt := newRetryExecutor(2).callChat(context.Background(), "gpt", route, models.ChatRequest{})
+	if attempt.err == nil || chat.calls != 3 {
+		t.Fatalf("expected failure after 3 calls, got %v after %d", attempt.err, chat.calls)
+	}
+}
+
+func TestCallChatDoesNotRetryClientErrors(t *testing.T) {
+	for _, status := range []int{400, 401, 403} {
+		chat := &flakyChat{failures: []error{statusErr(status)}}
+		route := providers.Route{Alias: "gpt", Provider: "openai", Chat: chat}
+		attempt := newRetryExecutor(2).callChat(context.Background(), "gpt", route, models.ChatRequest{})
+		if attempt.err == nil || chat.calls != 1 {
+			t.Fatalf("status %d: expected a single failed call, got %d calls", status, chat.calls)
+		}
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{statusErr(429), true},
+		{statusErr(502), true},
+		{fmt.Errorf("wrapped: %w", statusErr(504)), true},
+		{&openai.Error{StatusCode: 503}, true},
+		{statusErr(500), false},
+		{&openai.Error{StatusCode: 401}, false},
+		{errors.New("connection reset"), false},
+		{context.DeadlineExceeded, false},
+	}
+	for _, tc := range cases {
+		if got := isTransient(providers.Route{Provider: "openai"}, tc.err); got != tc.want {
+			t.Fatalf("isTransient(%v) = %v, want %v", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestIsTransientUsesErrorMapping(t *testing.T) {
+	route := providers.Route{Provider: "openai", ErrorMapping: map[string]int{"429": 400, "overloaded": 503}}
+	if isTransient(route, statusErr(429)) {
+		t.Fatal("a 429 mapped to 400 must not be retried")
+	}
+	if !isTransient(route, errors.New("model overloaded")) {
+		t.Fatal("an error mapped to 503 should be retried")
+	}
+	if !isTransient(providers.Route{Provider: "anthropic"}, statusErr(529)) {
+		t.Fatal("anthropic 529 maps to 503 by default and should be retried")
+	}
+}
+
+func TestRetryBackoffDoublesWithJitter(t *testing.T) {
+	base := 100 * time.Millisecond
+	if got := retryBackoff(base, 1, 0); got != 50*time.Millisecond {
+		t.Fatalf("retry 1 minimum = %s", got)
+	}
+	if got := retryBackoff(base, 2, 1); got != 200*time.Millisecond {
+		t.Fatalf("retry 2 maximum = %s", got)
+	}
+	if got := retryBackoff(0, 3, 0.5); got != 0 {
+		t.Fatalf("zero base should not wait, got %s", got)
+	}
+}
@@ -0,0 +1,50 @@
+package executor
+
+import (
+	"strings"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+)
+
+// ApplySystemPrompt injects the tenant system prompt carried on rc according to
+// its mode and reports whether anything was injected. The caller's message
+// slice is never modified, so the prompt cannot leak back into responses built
+// from the original request.
+//
+//   - prepend: the prompt becomes the first message.
+//   - append: the prompt follows the caller's leading system messages.
+//   - replace: caller system messages are dropped in favour of the prompt.
+func ApplySystemPrompt(rc *requestctx.Context, req models.ChatRequest) (models.ChatRequest, bool) {
+	if rc == nil || strings.TrimSpace(rc.SystemPrompt) == "" {
+		return req, false
+	}
+	prompt := models.ChatMessage{Role: "system", Content: rc.SystemPrompt}
+	messages := make([]models.ChatMessage, 0, len(req.Messages)+1)
+
+	switch db.SystemPromptMode(rc.SystemPromptMode) {
+	case db.SystemPromptModeAppend:
+		idx := 0
+		for idx < len(req.Messages) && strings.EqualFold(req.Messages[idx].Role, "system") {
+			idx++
+		}
+		messages = append(messages, req.Messages[:idx]...)
+		messages = append(messages, prompt)
+		messages = append(messages, req.Messages[idx:]...)
+	case db.SystemPromptModeReplace:
+		messages = append(messages, prompt)
+		for _, msg := range req.Messages {
+			if strings.EqualFold(msg.Role, "system") {
+				continue
+			}
+			messages = append(messages, msg)
+		}
+	default:
+		messages = append(messages, prompt)
+		messages = append(messages, req.Messages...)
+	}
+
+	req.Messages = messages
+	return req, true
+}
@@ -0,0 +1,50 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+)
+
+func TestApplySystemPromptModes(t *testing.T) {
+	base := []models.ChatMessage{
+		{Role: "system", Content: "caller"},
+		{Role: "user", Content: "hi"},
+	}
+	cases := []struct {
+		mode string
+		want []string
+	}{
+		{mode: "prepend", want: []string{"tenant", "caller", "hi"}},
+		{mode: "append", want: []string{"caller", "tenant", "hi"}},
+		{mode: "replace", want: []string{"tenant", "hi"}},
+		{mode: "", want: []string{"tenant", "caller", "hi"}},
+	}
+	for _, tc := range cases {
+		rc := &requestctx.Context{SystemPrompt: "tenant", SystemPromptMode: tc.mode}
+		req, applied := ApplySystemPrompt(rc, models.ChatRequest{Messages: base})
+		if !applied {
+			t.Fatalf("mode %q: expected prompt to be applied", tc.mode)
+		}
+		if len(req.Messages) != len(tc.want) {
+			t.Fatalf("mode %q: got %d messages, want %d", tc.mode, len(req.Messages), len(tc.want))
+		}
+		for i, content := range tc.want {
+			if req.Messages[i].Content != content {
+				t.Fatalf("mode %q: message %d = %q, want %q", tc.mode, i, req.Messages[i].Content, content)
+			}
+		}
+	}
+	if len(base) != 2 || base[0].Content != "caller" {
+		t.Fatalf("caller messages were modified: %+v", base)
+	}
+}
+
+func TestApplySystemPromptNoop(t *testing.T) {
+	req := models.ChatRequest{Messages: []models.ChatMessage{{Role: "user", Content: "hi"}}}
+	out, applied := ApplySystemPrompt(&requestctx.Context{}, req)
+	if applied || len(out.Messages) != 1 {
+		t.Fatalf("expected no injection, got %+v", out.Messages)
+	}
+}
@@ -0,0 +1,71 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/providers"
+)
+
+// ProviderTimeoutKey is the catalog metadata key that overrides
+// server.provider_timeout for one model. The value is in seconds; fractions
+// are allowed.
+const ProviderTimeoutKey = "provider_timeout_sec"
+
+// RouteTimeout returns the route's provider_timeout_sec metadata as a
+// duration, or fallback when it is missing or not a positive number.
+func RouteTimeout(route providers.Route, fallback time.Duration) time.Duration {
+	raw := strings.TrimSpace(route.Metadata[ProviderTimeoutKey])
+	if raw == "" {
+		return fallback
+	}
+	seconds, err := strconv.ParseFloat(raw, 64)
+	if err != nil || seconds <= 0 {
+		return fallback
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// RouteContext bounds a single provider call by the route's timeout, falling
+// back to server.provider_timeout. A zero timeout leaves ctx unbounded.
+func (e *Executor) RouteContext(ctx context.Context, route providers.Route) (context.Context, context.CancelFunc, time.Duration) {
+	var fallback time.Duration
+	if cfg := e.container.Config; cfg != nil {
+		fallback = cfg.Server.ProviderTimeout
+	}
+	timeout := RouteTimeout(route, fallback)
+	if timeout <= 0 {
+		ctx, cancel := context.WithCancel(ctx)
+		return ctx, cancel, 0
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, cancel, timeout
+}
+
+// LogRouteTimeout logs provider calls that failed because their deadline
+// passed.
+func LogRouteTimeout(ctx context.Context, alias string, route providers.Route, timeout time.Duration, err error) {
+	if err == nil || timeout <= 0 {
+		return
+	}
+	if !errors.Is(err, context.DeadlineExceeded) && !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return
+	}
+	slog.Error("provider timeout",
+		slog.String("alias", alias),
+		slog.String("provider", route.Provider),
+		slog.String("model", route.ResolveDeployment()),
+		slog.Duration("timeout", timeout),
+	)
+}
+
+// upstreamError maps a failed provider dispatch to the status returned to the
+// caller: the route's error_mapping match, or 502 (timeouts included).
+func upstreamError(route providers.Route, err error) error {
+	status, msg := providers.MapProviderError(route.Provider, err, route.ErrorMapping)
+	return NewAPIError(status, msg)
+}
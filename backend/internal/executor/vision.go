@@ -0,0 +1,78 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+)
+
+// RoutingOverrideAction is the audit action recorded when a request is
+// redirected away from the alias the client asked for.
+const RoutingOverrideAction = "model_routing_override"
+
+// ResolveVisionAlias returns the alias that should serve req. Requests with
+// image content aimed at a model without vision support go to the model's
+// fallback_vision_alias; without one they fail with 400 rather than an
+// opaque upstream error.
+func (e *Executor) ResolveVisionAlias(ctx context.Context, rc *requestctx.Context, alias string, req models.ChatRequest) (string, error) {
+	if !req.HasImageContent() {
+		return alias, nil
+	}
+	target, err := visionTarget(alias, e.container.Engine.VisionSupport)
+	if err != nil || target == alias {
+		return target, err
+	}
+	if rc != nil && !e.container.IsModelAllowed(rc.TenantID, target) {
+		return "", NewAPIError(fiber.StatusBadRequest, fmt.Sprintf("model %q does not support image content", alias))
+	}
+	e.recordVisionOverride(ctx, rc, alias, target)
+	return target, nil
+}
+
+// visionTarget picks the alias for an image request using support, which
+// reports an alias's vision support and fallback. The fallback must itself
+// support vision; fallbacks are not followed further.
+func visionTarget(alias string, support func(alias string) (bool, string)) (string, error) {
+	supports, fallback := support(alias)
+	if supports {
+		return alias, nil
+	}
+	if fallback == "" {
+		return "", NewAPIError(fiber.StatusBadRequest, fmt.Sprintf("model %q does not support image content", alias))
+	}
+	if fallbackSupports, _ := support(fallback); !fallbackSupports {
+		slog.Warn("fallback_vision_alias does not support vision", slog.String("alias", alias), slog.String("fallback_vision_alias", fallback))
+		return "", NewAPIError(fiber.StatusBadRequest, fmt.Sprintf("model %q does not support image content", alias))
+	}
+	return fallback, nil
+}
+
+func (e *Executor) recordVisionOverride(ctx context.Context, rc *requestctx.Context, alias, target string) {
+	metadata := map[string]any{
+		"reason":    "vision_content",
+		"requested": alias,
+		"routed_to": target,
+	}
+	var userID uuid.UUID
+	if rc != nil {
+		userID = rc.OwnerUserID
+		metadata["tenant_id"] = rc.TenantID.String()
+		metadata["api_key_prefix"] = rc.APIKeyPrefix
+	}
+	slog.Info(RoutingOverrideAction,
+		slog.String("requested_alias", alias),
+		slog.String("routed_alias", target))
+
+	if e.container.AdminAudit == nil {
+		return
+	}
+	if err := e.container.AdminAudit.Record(ctx, userID, RoutingOverrideAction, "model", alias, metadata); err != nil {
+		slog.Warn("record routing override audit failed", slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,38 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestVisionTarget(t *testing.T) {
+	catalog := map[string]struct {
+		supports bool
+		fallback string
+	}{
+		"gpt-4o":       {supports: true},
+		"gpt-3.5":      {fallback: "gpt-4o"},
+		"text-only":    {},
+		"bad-fallback": {fallback: "text-only"},
+	}
+	support := func(alias string) (bool, string) {
+		entry := catalog[alias]
+		return entry.supports, entry.fallback
+	}
+
+	if got, err := visionTarget("gpt-4o", support); err != nil || got != "gpt-4o" {
+		t.Fatalf("vision model should keep its alias, got %q %v", got, err)
+	}
+	if got, err := visionTarget("gpt-3.5", support); err != nil || got != "gpt-4o" {
+		t.Fatalf("expected redirect to the fallback, got %q %v", got, err)
+	}
+	_, err := visionTarget("text-only", support)
+	if status, _, ok := AsAPIError(err); !ok || status != fiber.StatusBadRequest {
+		t.Fatalf("expected 400 without a fallback, got %v", err)
+	}
+	_, err = visionTarget("bad-fallback", support)
+	if status, _, ok := AsAPIError(err); !ok || status != fiber.StatusBadRequest {
+		t.Fatalf("expected 400 when the fallback lacks vision, got %v", err)
+	}
+}
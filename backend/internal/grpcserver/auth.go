@@ -0,0 +1,102 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+)
+
+const bearerPrefix = "bearer "
+
+type authenticateFunc func(ctx context.Context, key string) (*requestctx.Context, error)
+
+// authenticator checks the "authorization: Bearer sk-..." metadata with the
+// same API key validation as the HTTP API and stores the request context.
+type authenticator struct {
+	authenticate authenticateFunc
+}
+
+func (a *authenticator) unary(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := a.authorize(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *authenticator) stream(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authorize(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+func (a *authenticator) authorize(ctx context.Context) (context.Context, error) {
+	raw := strings.TrimSpace(firstMetadata(ctx, "authorization"))
+	if raw == "" {
+		return nil, status.Error(codes.Unauthenticated, "authorization metadata required")
+	}
+	if !strings.HasPrefix(strings.ToLower(raw), bearerPrefix) {
+		return nil, status.Error(codes.Unauthenticated, "bearer token required")
+	}
+
+	rc, err := a.authenticate(ctx, strings.TrimSpace(raw[len(bearerPrefix):]))
+	if err != nil {
+		var authErr *app.APIKeyAuthError
+		if errors.As(err, &authErr) {
+			return nil, status.Error(codeForHTTPStatus(authErr.Status), authErr.Message)
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return requestctx.WithContext(ctx, rc), nil
+}
+
+// authenticatedStream swaps in the context carrying the request context.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context { return s.ctx }
+
+func firstMetadata(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// codeForHTTPStatus maps the statuses the executor and API key checks use
+// onto gRPC codes.
+func codeForHTTPStatus(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,189 @@
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/executor"
+	"github.com/ncecere/open_model_gateway/backend/internal/grpcserver/chatpb"
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+	usagepipeline "github.com/ncecere/open_model_gateway/backend/internal/services/usagepipeline"
+)
+
+type chatExecutor interface {
+	Chat(ctx context.Context, rc *requestctx.Context, alias string, req models.ChatRequest, traceID string, idempotencyKey string) (executor.ChatResult, error)
+	ChatStream(ctx context.Context, rc *requestctx.Context, alias string, req models.ChatRequest, traceID string, idempotencyKey string, emit func(models.ChatChunk) error) (usagepipeline.BudgetStatus, error)
+}
+
+type modelAllowedFunc func(tenantID uuid.UUID, alias string) bool
+
+type chatService struct {
+	chatpb.UnimplementedChatServiceServer
+
+	executor     chatExecutor
+	modelAllowed modelAllowedFunc
+	// streams is shared with the HTTP server, so shutdown drains gRPC
+	// streams along with SSE and WebSocket ones.
+	streams *app.StreamTracker
+}
+
+func (s *chatService) Chat(ctx context.Context, in *chatpb.ChatRequest) (*chatpb.ChatResponse, error) {
+	rc, alias, req, err := s.prepare(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.executor.Chat(ctx, rc, alias, req, traceID(ctx), firstMetadata(ctx, "idempotency-key"))
+	if err != nil {
+		return nil, executorError(err)
+	}
+	return toProtoResponse(result.Response, alias), nil
+}
+
+func (s *chatService) ChatStream(in *chatpb.ChatRequest, stream grpc.ServerStreamingServer[chatpb.ChatChunk]) error {
+	ctx := stream.Context()
+	rc, alias, req, err := s.prepare(ctx, in)
+	if err != nil {
+		return err
+	}
+	req.Stream = true
+	streamDone := s.streams.Start()
+	defer streamDone()
+	_, err = s.executor.ChatStream(ctx, rc, alias, req, traceID(ctx), firstMetadata(ctx, "idempotency-key"), func(chunk models.ChatChunk) error {
+		return stream.Send(toProtoChunk(chunk, alias))
+	})
+	if err != nil {
+		return executorError(err)
+	}
+	return nil
+}
+
+// prepare validates the request the same way the HTTP chat handler does and
+// converts it to the executor's model.
+func (s *chatService) prepare(ctx context.Context, in *chatpb.ChatRequest) (*requestctx.Context, string, models.ChatRequest, error) {
+	alias := strings.TrimSpace(in.GetModel())
+	if alias == "" {
+		return nil, "", models.ChatRequest{}, status.Error(codes.InvalidArgument, "model is required")
+	}
+	if len(in.GetMessages()) == 0 {
+		return nil, "", models.ChatRequest{}, status.Error(codes.InvalidArgument, "messages are required")
+	}
+	rc, ok := requestctx.FromContext(ctx)
+	if !ok || rc == nil {
+		return nil, "", models.ChatRequest{}, status.Error(codes.Internal, "request context missing")
+	}
+	if !s.modelAllowed(rc.TenantID, alias) {
+		return nil, "", models.ChatRequest{}, status.Error(codes.PermissionDenied, "model not enabled for tenant")
+	}
+	req, err := fromProtoRequest(in)
+	if err != nil {
+		return nil, "", models.ChatRequest{}, err
+	}
+	return rc, alias, req, nil
+}
+
+func fromProtoRequest(in *chatpb.ChatRequest) (models.ChatRequest, error) {
+	messages := make([]models.ChatMessage, 0, len(in.GetMessages()))
+	for _, m := range in.GetMessages() {
+		role := strings.ToLower(m.GetRole())
+		if role == "" {
+			role = "user"
+		}
+		messages = append(messages, models.ChatMessage{
+			Role:    role,
+			Content: m.GetContent(),
+			Name:    m.GetName(),
+		})
+	}
+
+	req := models.ChatRequest{
+		Messages:    messages,
+		Temperature: in.Temperature,
+		TopP:        in.TopP,
+		MaxTokens:   in.MaxTokens,
+		Stop:        in.GetStop(),
+	}
+	if format := in.GetResponseFormat(); format != nil && format.GetType() != "" {
+		req.ResponseFormat = &models.ChatResponseFormat{Type: format.GetType()}
+		if raw := strings.TrimSpace(format.GetJsonSchema()); raw != "" {
+			if !json.Valid([]byte(raw)) {
+				return models.ChatRequest{}, status.Error(codes.InvalidArgument, "response_format.json_schema must be valid JSON")
+			}
+			schema := json.RawMessage(raw)
+			req.ResponseFormat.JSONSchema = &schema
+		}
+	}
+	return req, nil
+}
+
+func toProtoResponse(resp models.ChatResponse, alias string) *chatpb.ChatResponse {
+	choices := make([]*chatpb.ChatChoice, 0, len(resp.Choices))
+	for _, choice := range resp.Choices {
+		choices = append(choices, &chatpb.ChatChoice{
+			Index:        int32(choice.Index),
+			Message:      toProtoMessage(choice.Message),
+			FinishReason: choice.FinishReason,
+		})
+	}
+	return &chatpb.ChatResponse{
+		Id:      resp.ID,
+		Created: timestamppb.New(resp.Created),
+		Model:   alias,
+		Choices: choices,
+		Usage: &chatpb.Usage{
+			PromptTokens:     resp.Usage.PromptTokens,
+			CompletionTokens: resp.Usage.CompletionTokens,
+			TotalTokens:      resp.Usage.TotalTokens,
+		},
+	}
+}
+
+func toProtoChunk(chunk models.ChatChunk, alias string) *chatpb.ChatChunk {
+	choices := make([]*chatpb.ChunkDelta, 0, len(chunk.Choices))
+	for _, choice := range chunk.Choices {
+		choices = append(choices, &chatpb.ChunkDelta{
+			Index:        int32(choice.Index),
+			Delta:        toProtoMessage(choice.Delta),
+			FinishReason: choice.FinishReason,
+		})
+	}
+	return &chatpb.ChatChunk{
+		Id:      chunk.ID,
+		Model:   alias,
+		Created: timestamppb.New(chunk.Created),
+		Choices: choices,
+	}
+}
+
+func toProtoMessage(m models.ChatMessage) *chatpb.ChatMessage {
+	return &chatpb.ChatMessage{Role: m.Role, Content: m.Content, Name: m.Name}
+}
+
+// executorError converts executor failures into gRPC statuses. Errors raised
+// by the stream itself (for example a cancelled client) pass through.
+func executorError(err error) error {
+	if httpStatus, msg, ok := executor.AsAPIError(err); ok {
+		return status.Error(codeForHTTPStatus(httpStatus), msg)
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// traceID uses the caller's x-request-id when present, like the HTTP
+// request ID middleware, and generates one otherwise.
+func traceID(ctx context.Context) string {
+	if id := strings.TrimSpace(firstMetadata(ctx, "x-request-id")); id != "" {
+		return id
+	}
+	return uuid.NewString()
+}
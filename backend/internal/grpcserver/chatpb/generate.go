@@ -0,0 +1,5 @@
+// Package chatpb holds the generated protobuf and gRPC bindings for the chat
+// service. Regenerate after editing chat.proto.
+package chatpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative chat.proto
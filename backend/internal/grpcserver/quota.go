@@ -0,0 +1,94 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/limits"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+)
+
+type tenantQuotaFunc func(tenantID uuid.UUID) (limits.QuotaConfig, bool)
+
+type quotaCounter interface {
+	Reserve(ctx context.Context, tenantID uuid.UUID, cfg limits.QuotaConfig) (limits.QuotaStatus, error)
+	Refund(ctx context.Context, status limits.QuotaStatus)
+}
+
+// quotaGate enforces the tenant request quota the way the HTTP tenantQuota
+// middleware does: a slot is reserved before the call runs and refunded
+// when it fails. It must run after the authenticator.
+type quotaGate struct {
+	lookup  tenantQuotaFunc
+	counter quotaCounter
+}
+
+func (q *quotaGate) unary(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	reserved, err := q.reserve(ctx, func(md metadata.MD) error { return grpc.SetHeader(ctx, md) })
+	if err != nil {
+		return nil, err
+	}
+	resp, err := handler(ctx, req)
+	if err != nil {
+		q.refund(reserved)
+	}
+	return resp, err
+}
+
+func (q *quotaGate) stream(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	reserved, err := q.reserve(ss.Context(), ss.SetHeader)
+	if err != nil {
+		return err
+	}
+	if err := handler(srv, ss); err != nil {
+		q.refund(reserved)
+		return err
+	}
+	return nil
+}
+
+// reserve takes a quota slot for the authenticated tenant and reports the
+// counter as x-quota-* header metadata. It returns nil when the tenant has
+// no quota configured.
+func (q *quotaGate) reserve(ctx context.Context, setHeader func(metadata.MD) error) (*limits.QuotaStatus, error) {
+	rc, ok := requestctx.FromContext(ctx)
+	if !ok || rc == nil || q.lookup == nil || q.counter == nil {
+		return nil, nil
+	}
+	cfg, ok := q.lookup(rc.TenantID)
+	if !ok {
+		return nil, nil
+	}
+
+	quota, err := q.counter.Reserve(ctx, rc.TenantID, cfg)
+	if err != nil {
+		if errors.Is(err, limits.ErrQuotaExceeded) {
+			_ = setHeader(quotaMetadata(quota))
+			return nil, status.Error(codes.ResourceExhausted, "quota_exceeded")
+		}
+		return nil, status.Error(codes.Internal, "failed to evaluate quota")
+	}
+	_ = setHeader(quotaMetadata(quota))
+	return &quota, nil
+}
+
+func (q *quotaGate) refund(reserved *limits.QuotaStatus) {
+	if reserved != nil {
+		q.counter.Refund(context.Background(), *reserved)
+	}
+}
+
+func quotaMetadata(quota limits.QuotaStatus) metadata.MD {
+	return metadata.Pairs(
+		"x-quota-limit", strconv.FormatInt(quota.Limit, 10),
+		"x-quota-remaining", strconv.FormatInt(quota.Remaining(), 10),
+		"x-quota-reset", strconv.FormatInt(quota.ResetAt.Unix(), 10),
+	)
+}
@@ -0,0 +1,85 @@
+// Package grpcserver serves the chat API over gRPC next to the Fiber HTTP
+// server. Requests go through the same executor as /v1/chat/completions, so
+// routing, rate limits, budgets, request quotas and usage logging are shared.
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/executor"
+	"github.com/ncecere/open_model_gateway/backend/internal/grpcserver/chatpb"
+)
+
+// Server wraps the gRPC server and its listen address.
+type Server struct {
+	grpc            *grpc.Server
+	addr            string
+	shutdownTimeout time.Duration
+}
+
+// New builds the gRPC server for the container's configured listen address.
+func New(container *app.Container, exec *executor.Executor) (*Server, error) {
+	if container == nil || container.Config == nil {
+		return nil, errors.New("container required")
+	}
+	cfg := container.Config.Server
+	// Streams get the same drain window as on the HTTP server.
+	timeout := max(cfg.GracefulShutdownDelay, cfg.GracefulStreamDrainTimeout)
+	return &Server{
+		grpc:            newGRPCServer(container.AuthenticateAPIKey, container.IsModelAllowed, &quotaGate{lookup: container.TenantQuota, counter: container.Quotas}, &container.Streams, exec),
+		addr:            cfg.GRPCListenAddr,
+		shutdownTimeout: timeout,
+	}, nil
+}
+
+func newGRPCServer(authenticate authenticateFunc, allowed modelAllowedFunc, quota *quotaGate, streams *app.StreamTracker, exec chatExecutor) *grpc.Server {
+	a := &authenticator{authenticate: authenticate}
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(a.unary, quota.unary),
+		grpc.ChainStreamInterceptor(a.stream, quota.stream),
+	)
+	chatpb.RegisterChatServiceServer(srv, &chatService{executor: exec, modelAllowed: allowed, streams: streams})
+	return srv
+}
+
+// Listen serves until ctx is cancelled, then drains in-flight calls for up
+// to the longer of the graceful shutdown delay and the stream drain timeout
+// before forcing the server closed.
+func (s *Server) Listen(ctx context.Context) error {
+	lis, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.grpc.Serve(lis)
+	}()
+
+	select {
+	case <-ctx.Done():
+		timeout := s.shutdownTimeout
+		if timeout <= 0 {
+			timeout = 5 * time.Second
+		}
+		stopped := make(chan struct{})
+		go func() {
+			s.grpc.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(timeout):
+			s.grpc.Stop()
+		}
+		return <-errCh
+	case err := <-errCh:
+		return err
+	}
+}
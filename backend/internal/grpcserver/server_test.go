@@ -0,0 +1,257 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/executor"
+	"github.com/ncecere/open_model_gateway/backend/internal/grpcserver/chatpb"
+	"github.com/ncecere/open_model_gateway/backend/internal/limits"
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+	usagepipeline "github.com/ncecere/open_model_gateway/backend/internal/services/usagepipeline"
+)
+
+const testKey = "sk-test.secret"
+
+type fakeExecutor struct {
+	tenantID uuid.UUID
+	lastReq  models.ChatRequest
+	err      error
+	// streams is the tracker the test server registers streams with;
+	// activeStreams is its count while ChatStream runs.
+	streams       app.StreamTracker
+	activeStreams int64
+}
+
+func (f *fakeExecutor) Chat(_ context.Context, rc *requestctx.Context, alias string, req models.ChatRequest, _ string, _ string) (executor.ChatResult, error) {
+	if f.err != nil {
+		return executor.ChatResult{}, f.err
+	}
+	f.tenantID = rc.TenantID
+	f.lastReq = req
+	return executor.ChatResult{Response: models.ChatResponse{
+		ID:      "chatcmpl-1",
+		Created: time.Unix(1700000000, 0),
+		Model:   "provider-model",
+		Choices: []models.ChatChoice{{Message: models.ChatMessage{Role: "assistant", Content: "hello " + req.Messages[0].Content}, FinishReason: "stop"}},
+		Usage:   models.Usage{PromptTokens: 3, CompletionTokens: 2, TotalTokens: 5},
+	}}, nil
+}
+
+func (f *fakeExecutor) ChatStream(_ context.Context, rc *requestctx.Context, _ string, req models.ChatRequest, _ string, _ string, emit func(models.ChatChunk) error) (usagepipeline.BudgetStatus, error) {
+	f.tenantID = rc.TenantID
+	f.lastReq = req
+	f.activeStreams = f.streams.Active()
+	for _, word := range []string{"one", "two", "three"} {
+		if err := emit(models.ChatChunk{ID: "chunk", Choices: []models.ChunkDelta{{Delta: models.ChatMessage{Content: word}}}}); err != nil {
+			return usagepipeline.BudgetStatus{}, err
+		}
+	}
+	return usagepipeline.BudgetStatus{}, nil
+}
+
+func startTestServer(t *testing.T, exec *fakeExecutor, tenantID uuid.UUID) chatpb.ChatServiceClient {
+	t.Helper()
+	return startQuotaTestServer(t, exec, tenantID, &quotaGate{})
+}
+
+func startQuotaTestServer(t *testing.T, exec *fakeExecutor, tenantID uuid.UUID, quota *quotaGate) chatpb.ChatServiceClient {
+	t.Helper()
+	authenticate := func(_ context.Context, key string) (*requestctx.Context, error) {
+		if key != testKey {
+			return nil, &app.APIKeyAuthError{Status: http.StatusUnauthorized, Message: "invalid api key"}
+		}
+		return &requestctx.Context{TenantID: tenantID}, nil
+	}
+	allowed := func(_ uuid.UUID, alias string) bool { return alias != "blocked" }
+
+	lis := bufconn.Listen(1 << 20)
+	srv := newGRPCServer(authenticate, allowed, quota, &exec.streams, exec)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return chatpb.NewChatServiceClient(conn)
+}
+
+func withKey(key string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+key)
+}
+
+func TestChatRequiresAPIKey(t *testing.T) {
+	client := startTestServer(t, &fakeExecutor{}, uuid.New())
+	req := &chatpb.ChatRequest{Model: "gpt", Messages: []*chatpb.ChatMessage{{Content: "hi"}}}
+
+	if _, err := client.Chat(context.Background(), req); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated without metadata, got %v", err)
+	}
+	if _, err := client.Chat(withKey("sk-wrong.key"), req); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated for a bad key, got %v", err)
+	}
+	if _, err := client.Chat(withKey(testKey), &chatpb.ChatRequest{Model: "blocked", Messages: req.Messages}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied for a disabled model, got %v", err)
+	}
+}
+
+func TestChatUnary(t *testing.T) {
+	tenantID := uuid.New()
+	exec := &fakeExecutor{}
+	client := startTestServer(t, exec, tenantID)
+
+	temperature := float32(0.5)
+	resp, err := client.Chat(withKey(testKey), &chatpb.ChatRequest{
+		Model:       "gpt",
+		Messages:    []*chatpb.ChatMessage{{Content: "world"}},
+		Temperature: &temperature,
+		Stop:        []string{"END"},
+	})
+	if err != nil {
+		t.Fatalf("chat: %v", err)
+	}
+	if exec.tenantID != tenantID {
+		t.Fatalf("executor ran with tenant %s, want %s", exec.tenantID, tenantID)
+	}
+	if exec.lastReq.Messages[0].Role != "user" || *exec.lastReq.Temperature != 0.5 || exec.lastReq.Stop[0] != "END" {
+		t.Fatalf("request not mapped: %+v", exec.lastReq)
+	}
+	if resp.GetModel() != "gpt" || resp.GetChoices()[0].GetMessage().GetContent() != "hello world" || resp.GetUsage().GetTotalTokens() != 5 {
+		t.Fatalf("unexpected response: %v", resp)
+	}
+	if !resp.GetCreated().AsTime().Equal(time.Unix(1700000000, 0)) {
+		t.Fatalf("unexpected created time %v", resp.GetCreated().AsTime())
+	}
+
+	exec.err = executor.NewAPIError(http.StatusTooManyRequests, "rate limit exceeded")
+	if _, err := client.Chat(withKey(testKey), &chatpb.ChatRequest{Model: "gpt", Messages: []*chatpb.ChatMessage{{Content: "x"}}}); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+}
+
+func TestChatStream(t *testing.T) {
+	exec := &fakeExecutor{}
+	client := startTestServer(t, exec, uuid.New())
+
+	stream, err := client.ChatStream(withKey(testKey), &chatpb.ChatRequest{Model: "gpt", Messages: []*chatpb.ChatMessage{{Content: "count"}}})
+	if err != nil {
+		t.Fatalf("chat stream: %v", err)
+	}
+	var got []string
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("recv: %v", err)
+		}
+		got = append(got, chunk.GetChoices()[0].GetDelta().GetContent())
+	}
+	if len(got) != 3 || got[0] != "one" || got[2] != "three" {
+		t.Fatalf("unexpected chunks %v", got)
+	}
+	if !exec.lastReq.Stream {
+		t.Fatal("expected stream flag to be set on the executor request")
+	}
+	if exec.activeStreams != 1 {
+		t.Fatalf("expected the stream to be tracked while running, got %d active", exec.activeStreams)
+	}
+	if active := exec.streams.Active(); active != 0 {
+		t.Fatalf("expected the stream to be released when done, got %d active", active)
+	}
+}
+
+type fakeQuotaCounter struct {
+	limit    int64
+	used     int64
+	refunded int
+}
+
+func (f *fakeQuotaCounter) Reserve(_ context.Context, _ uuid.UUID, _ limits.QuotaConfig) (limits.QuotaStatus, error) {
+	if f.used >= f.limit {
+		return limits.QuotaStatus{Limit: f.limit, Used: f.used}, limits.ErrQuotaExceeded
+	}
+	f.used++
+	return limits.QuotaStatus{Limit: f.limit, Used: f.used}, nil
+}
+
+func (f *fakeQuotaCounter) Refund(_ context.Context, _ limits.QuotaStatus) {
+	f.used--
+	f.refunded++
+}
+
+func TestChatEnforcesTenantQuota(t *testing.T) {
+	tenantID := uuid.New()
+	exec := &fakeExecutor{}
+	counter := &fakeQuotaCounter{limit: 2}
+	lookup := func(id uuid.UUID) (limits.QuotaConfig, bool) {
+		return limits.QuotaConfig{MaxRequests: 2}, id == tenantID
+	}
+	client := startQuotaTestServer(t, exec, tenantID, &quotaGate{lookup: lookup, counter: counter})
+	req := &chatpb.ChatRequest{Model: "gpt", Messages: []*chatpb.ChatMessage{{Content: "hi"}}}
+
+	var header metadata.MD
+	if _, err := client.Chat(withKey(testKey), req, grpc.Header(&header)); err != nil {
+		t.Fatalf("chat: %v", err)
+	}
+	if got := header.Get("x-quota-remaining"); len(got) != 1 || got[0] != "1" {
+		t.Fatalf("unexpected x-quota-remaining %v", got)
+	}
+
+	exec.err = executor.NewAPIError(http.StatusBadGateway, "upstream failed")
+	if _, err := client.Chat(withKey(testKey), req); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected Unavailable, got %v", err)
+	}
+	if counter.refunded != 1 || counter.used != 1 {
+		t.Fatalf("failed call should be refunded: used=%d refunded=%d", counter.used, counter.refunded)
+	}
+	exec.err = nil
+
+	stream, err := client.ChatStream(withKey(testKey), req)
+	if err != nil {
+		t.Fatalf("chat stream: %v", err)
+	}
+	for {
+		if _, err := stream.Recv(); err != nil {
+			if !errors.Is(err, io.EOF) {
+				t.Fatalf("recv: %v", err)
+			}
+			break
+		}
+	}
+	if counter.used != 2 {
+		t.Fatalf("stream should take a quota slot, used=%d", counter.used)
+	}
+
+	if _, err := client.Chat(withKey(testKey), req); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted once the quota is used, got %v", err)
+	}
+	stream, err = client.ChatStream(withKey(testKey), req)
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted for the stream, got %v", err)
+	}
+}
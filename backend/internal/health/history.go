@@ -0,0 +1,221 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	historyKeyPrefix = "health_history:"
+	// MaxHistoryWindow is how far back success-rate history is kept.
+	MaxHistoryWindow     = 24 * time.Hour
+	historyQueueSize     = 1024
+	historyFlushInterval = time.Second
+	historyFlushTimeout  = 2 * time.Second
+)
+
+// HistoryBucket is one minute of call outcomes for a model alias.
+// SuccessRate is nil for minutes without traffic.
+type HistoryBucket struct {
+	TS          time.Time `json:"ts"`
+	Successes   int64     `json:"successes"`
+	Failures    int64     `json:"failures"`
+	SuccessRate *float64  `json:"success_rate"`
+}
+
+// History keeps per-minute success and failure counts for each model alias in
+// Redis: a sorted set per alias indexes the minutes by timestamp, and a hash
+// per minute holds the counts. Entries expire after MaxHistoryWindow.
+// Outcomes reported through Observe are queued and written by Run, so the
+// request path never waits on Redis.
+type History struct {
+	client     *redis.Client
+	now        func() time.Time
+	outcomes   chan historyCount
+	flushEvery time.Duration
+}
+
+// historyCount identifies one counter: an alias's successes or failures in
+// one minute.
+type historyCount struct {
+	alias  string
+	minute time.Time
+	field  string
+}
+
+// NewHistory returns a history store; a nil client disables it.
+func NewHistory(client *redis.Client) *History {
+	return &History{
+		client:     client,
+		now:        time.Now,
+		outcomes:   make(chan historyCount, historyQueueSize),
+		flushEvery: historyFlushInterval,
+	}
+}
+
+// Observe queues one outcome for alias without blocking; outcomes are dropped
+// when the queue is full. It matches router.OutcomeObserver.
+func (h *History) Observe(alias string, success bool) {
+	if h == nil || h.client == nil || alias == "" {
+		return
+	}
+	select {
+	case h.outcomes <- h.count(alias, success):
+	default:
+		slog.Debug("health history queue full; dropping outcome", slog.String("alias", alias))
+	}
+}
+
+// Run writes queued outcomes to Redis until ctx is cancelled. Outcomes are
+// summed per counter and written in one pipeline per flush interval; outcomes
+// still queued when ctx is cancelled are flushed before Run returns.
+func (h *History) Run(ctx context.Context) {
+	if h == nil || h.client == nil {
+		return
+	}
+	ticker := time.NewTicker(h.flushEvery)
+	defer ticker.Stop()
+	pending := make(map[historyCount]int64)
+	for {
+		select {
+		case <-ctx.Done():
+			for len(h.outcomes) > 0 {
+				pending[<-h.outcomes]++
+			}
+			h.flush(context.WithoutCancel(ctx), pending)
+			return
+		case count := <-h.outcomes:
+			pending[count]++
+		case <-ticker.C:
+			h.flush(ctx, pending)
+			clear(pending)
+		}
+	}
+}
+
+func (h *History) flush(ctx context.Context, pending map[historyCount]int64) {
+	if len(pending) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, historyFlushTimeout)
+	defer cancel()
+	if err := h.write(ctx, pending); err != nil {
+		slog.Debug("record health history failed", slog.Int("counters", len(pending)), slog.String("error", err.Error()))
+	}
+}
+
+// Record counts one call outcome for alias in the current minute, writing it
+// to Redis immediately.
+func (h *History) Record(ctx context.Context, alias string, success bool) error {
+	if h == nil || h.client == nil || alias == "" {
+		return nil
+	}
+	return h.write(ctx, map[historyCount]int64{h.count(alias, success): 1})
+}
+
+func (h *History) count(alias string, success bool) historyCount {
+	field := "failures"
+	if success {
+		field = "successes"
+	}
+	return historyCount{alias: alias, minute: h.now().UTC().Truncate(time.Minute), field: field}
+}
+
+// write adds counts to their minute buckets and trims each alias's index to
+// MaxHistoryWindow.
+func (h *History) write(ctx context.Context, counts map[historyCount]int64) error {
+	cutoff := "(" + strconv.FormatInt(h.now().UTC().Add(-MaxHistoryWindow).Unix(), 10)
+	aliases := make(map[string]struct{})
+	pipe := h.client.TxPipeline()
+	for count, n := range counts {
+		bucket := historyBucketKey(count.alias, count.minute)
+		pipe.HIncrBy(ctx, bucket, count.field, n)
+		pipe.Expire(ctx, bucket, MaxHistoryWindow)
+		pipe.ZAdd(ctx, historyIndexKey(count.alias), redis.Z{Score: float64(count.minute.Unix()), Member: count.minute.Unix()})
+		aliases[count.alias] = struct{}{}
+	}
+	for alias := range aliases {
+		index := historyIndexKey(alias)
+		pipe.ZRemRangeByScore(ctx, index, "-inf", cutoff)
+		pipe.Expire(ctx, index, MaxHistoryWindow)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Buckets returns one bucket per minute covering the last window, oldest
+// first, including the current minute.
+func (h *History) Buckets(ctx context.Context, alias string, window time.Duration) ([]HistoryBucket, error) {
+	if window > MaxHistoryWindow {
+		window = MaxHistoryWindow
+	}
+	minutes := int(window / time.Minute)
+	if minutes < 1 {
+		minutes = 1
+	}
+	now := time.Now
+	if h != nil && h.now != nil {
+		now = h.now
+	}
+	end := now().UTC().Truncate(time.Minute)
+	start := end.Add(-time.Duration(minutes-1) * time.Minute)
+
+	buckets := make([]HistoryBucket, minutes)
+	for i := range buckets {
+		buckets[i].TS = start.Add(time.Duration(i) * time.Minute)
+	}
+	if h == nil || h.client == nil {
+		return buckets, nil
+	}
+
+	recorded, err := h.client.ZRangeByScore(ctx, historyIndexKey(alias), &redis.ZRangeBy{
+		Min: strconv.FormatInt(start.Unix(), 10),
+		Max: strconv.FormatInt(end.Unix(), 10),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(recorded) > 0 {
+		pipe := h.client.Pipeline()
+		counts := make(map[int64]*redis.MapStringStringCmd, len(recorded))
+		for _, member := range recorded {
+			ts, err := strconv.ParseInt(member, 10, 64)
+			if err != nil {
+				continue
+			}
+			counts[ts] = pipe.HGetAll(ctx, historyBucketKey(alias, time.Unix(ts, 0)))
+		}
+		if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+			return nil, err
+		}
+		for i := range buckets {
+			cmd, ok := counts[buckets[i].TS.Unix()]
+			if !ok {
+				continue
+			}
+			values := cmd.Val()
+			buckets[i].Successes, _ = strconv.ParseInt(values["successes"], 10, 64)
+			buckets[i].Failures, _ = strconv.ParseInt(values["failures"], 10, 64)
+		}
+	}
+	for i := range buckets {
+		if total := buckets[i].Successes + buckets[i].Failures; total > 0 {
+			rate := float64(buckets[i].Successes) / float64(total)
+			buckets[i].SuccessRate = &rate
+		}
+	}
+	return buckets, nil
+}
+
+func historyIndexKey(alias string) string {
+	return historyKeyPrefix + alias
+}
+
+func historyBucketKey(alias string, minute time.Time) string {
+	return fmt.Sprintf("%s%s:%d", historyKeyPrefix, alias, minute.Unix())
+}
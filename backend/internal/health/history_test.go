@@ -0,0 +1,130 @@
+package health
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/providers"
+	"github.com/ncecere/open_model_gateway/backend/internal/router"
+)
+
+func TestHistoryBucketsPerMinuteSuccessRate(t *testing.T) {
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() {
+		client.Close()
+		server.Close()
+	})
+
+	now := time.Date(2025, 11, 17, 12, 30, 20, 0, time.UTC)
+	history := NewHistory(client)
+	history.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// Two minutes ago: 3 successes, 1 failure. Current minute: 1 failure.
+	now = now.Add(-2 * time.Minute)
+	for _, success := range []bool{true, true, false, true} {
+		if err := history.Record(ctx, "gpt-4", success); err != nil {
+			t.Fatalf("record: %v", err)
+		}
+	}
+	now = now.Add(2 * time.Minute)
+	if err := history.Record(ctx, "gpt-4", false); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	buckets, err := history.Buckets(ctx, "gpt-4", 5*time.Minute)
+	if err != nil {
+		t.Fatalf("buckets: %v", err)
+	}
+	if len(buckets) != 5 {
+		t.Fatalf("expected 5 buckets, got %d", len(buckets))
+	}
+	if want := time.Date(2025, 11, 17, 12, 26, 0, 0, time.UTC); !buckets[0].TS.Equal(want) {
+		t.Fatalf("expected first bucket at %s, got %s", want, buckets[0].TS)
+	}
+	older := buckets[2]
+	if older.Successes != 3 || older.Failures != 1 || older.SuccessRate == nil || *older.SuccessRate != 0.75 {
+		t.Fatalf("unexpected bucket %+v", older)
+	}
+	if buckets[3].SuccessRate != nil {
+		t.Fatalf("expected no rate for an idle minute, got %v", *buckets[3].SuccessRate)
+	}
+	current := buckets[4]
+	if current.Failures != 1 || current.SuccessRate == nil || *current.SuccessRate != 0 {
+		t.Fatalf("unexpected current bucket %+v", current)
+	}
+}
+
+func TestMonitorSuccessRateReflectsInjectedFailures(t *testing.T) {
+	engine := router.NewEngine()
+	monitor := NewMonitor(engine, config.HealthConfig{RollingWindow: 10})
+	route := providers.Route{Alias: "gpt-4", Model: "gpt-4"}
+
+	for i := 0; i < 8; i++ {
+		engine.ReportSuccess("gpt-4", route)
+	}
+	if rate := monitor.SuccessRate("gpt-4", route); rate != 1 {
+		t.Fatalf("expected 1, got %v", rate)
+	}
+	engine.ReportFailure("gpt-4", route)
+	engine.ReportFailure("gpt-4", route)
+	if rate := monitor.SuccessRate("gpt-4", route); rate != 0.8 {
+		t.Fatalf("expected 0.8 after two failures, got %v", rate)
+	}
+}
+
+func TestHistoryObserveIsFlushedInTheBackground(t *testing.T) {
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() {
+		client.Close()
+		server.Close()
+	})
+
+	now := time.Date(2025, 11, 17, 12, 30, 20, 0, time.UTC)
+	history := NewHistory(client)
+	history.now = func() time.Time { return now }
+	history.flushEvery = time.Hour
+	ctx := context.Background()
+
+	// Observe only queues; nothing reaches Redis until Run flushes.
+	for _, success := range []bool{true, true, false} {
+		history.Observe("gpt-4", success)
+	}
+	buckets, err := history.Buckets(ctx, "gpt-4", time.Minute)
+	if err != nil {
+		t.Fatalf("buckets: %v", err)
+	}
+	if buckets[0].Successes != 0 || buckets[0].Failures != 0 {
+		t.Fatalf("expected nothing written before a flush, got %+v", buckets[0])
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		history.Run(runCtx)
+		close(done)
+	}()
+	cancel()
+	<-done
+
+	buckets, err = history.Buckets(ctx, "gpt-4", time.Minute)
+	if err != nil {
+		t.Fatalf("buckets: %v", err)
+	}
+	if buckets[0].Successes != 2 || buckets[0].Failures != 1 {
+		t.Fatalf("expected queued outcomes to be flushed on shutdown, got %+v", buckets[0])
+	}
+}
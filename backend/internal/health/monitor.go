@@ -2,14 +2,37 @@ package health
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/ncecere/open_model_gateway/backend/internal/config"
 	"github.com/ncecere/open_model_gateway/backend/internal/providers"
 	"github.com/ncecere/open_model_gateway/backend/internal/router"
+	"github.com/ncecere/open_model_gateway/backend/internal/services/notifications"
 )
 
+// HealthState is the coarse status reported for one dependency.
+type HealthState string
+
+const (
+	StateOK       HealthState = "ok"
+	StateDegraded HealthState = "degraded"
+	StateDown     HealthState = "down"
+)
+
+// Keys used in Status for the critical dependencies. Model aliases appear
+// under ProviderKeyPrefix.
+const (
+	DBKey             = "db"
+	RedisKey          = "redis"
+	ProviderKeyPrefix = "provider:"
+)
+
+// PingFunc checks one dependency, returning an error when it is unreachable.
+type PingFunc func(ctx context.Context) error
+
 // Monitor periodically pings provider routes and updates the router engine health state.
 type Monitor struct {
 	engine    *router.Engine
@@ -17,6 +40,11 @@ type Monitor struct {
 	timeout   time.Duration
 	getRoutes func() map[string][]providers.Route
 	startOnce sync.Once
+	pingDB    PingFunc
+	pingRedis PingFunc
+	notifier  notifications.Notifier
+	// down holds the aliases whose last sweep found no healthy route.
+	down map[string]bool
 }
 
 // NewMonitor constructs a monitor using the health configuration.
@@ -30,10 +58,104 @@ func NewMonitor(engine *router.Engine, cfg config.HealthConfig) *Monitor {
 		timeout = 5 * time.Second
 	}
 
+	if engine != nil {
+		engine.SetRollingWindow(cfg.RollingWindow)
+	}
+
 	return &Monitor{
 		engine:   engine,
 		interval: interval,
 		timeout:  timeout,
+		down:     make(map[string]bool),
+	}
+}
+
+// SetNotifier sends circuit-breaker trips and provider outages to the admin
+// inbox. An outage is reported when a sweep finds no healthy route for an
+// alias, and again as info once one recovers.
+func (m *Monitor) SetNotifier(n notifications.Notifier) {
+	m.notifier = n
+	if m.engine == nil || n == nil {
+		return
+	}
+	m.engine.SetBreakerObserver(func(alias string, route providers.Route) {
+		notifications.Send(n, notifications.Notification{
+			Severity:   notifications.SeverityWarning,
+			EntityType: notifications.EntityRoute,
+			EntityID:   alias,
+			Message:    fmt.Sprintf("circuit breaker opened for %s route %s of %s after repeated failures", route.Provider, routeName(route), alias),
+		})
+	})
+}
+
+// SetPingers registers the database and Redis checks used by Status.
+func (m *Monitor) SetPingers(db, redis PingFunc) {
+	m.pingDB = db
+	m.pingRedis = redis
+}
+
+// Status pings the database and Redis and reports each model alias's route
+// health: ok when every route is healthy, degraded when some are, down when
+// none are. Dependencies without a pinger are omitted.
+func (m *Monitor) Status(ctx context.Context) map[string]HealthState {
+	status := make(map[string]HealthState)
+	if m == nil {
+		return status
+	}
+	ping := func(key string, fn PingFunc) {
+		if fn == nil {
+			return
+		}
+		pingCtx, cancel := context.WithTimeout(ctx, m.timeout)
+		defer cancel()
+		if err := fn(pingCtx); err != nil {
+			status[key] = StateDown
+			return
+		}
+		status[key] = StateOK
+	}
+	ping(DBKey, m.pingDB)
+	ping(RedisKey, m.pingRedis)
+
+	if m.engine != nil {
+		for alias, routes := range m.engine.HealthStatus() {
+			status[ProviderKeyPrefix+alias] = routeState(routes)
+		}
+	}
+	return status
+}
+
+// SuccessRate returns the route's success ratio over the last
+// health.rolling_window reported outcomes, or 1 when none are recorded.
+func (m *Monitor) SuccessRate(alias string, route providers.Route) float64 {
+	if m == nil || m.engine == nil {
+		return 1
+	}
+	return m.engine.SuccessRate(alias, route)
+}
+
+// Ready reports whether every critical dependency in status is up. Provider
+// health never blocks readiness.
+func Ready(status map[string]HealthState) bool {
+	for key, state := range status {
+		if strings.HasPrefix(key, ProviderKeyPrefix) {
+			continue
+		}
+		if state != StateOK {
+			return false
+		}
+	}
+	return true
+}
+
+func routeState(health router.RouteHealth) HealthState {
+	switch {
+	case health.TotalRoutes == 0 || health.HealthyRoutes == 0:
+		return StateDown
+	case health.HealthyRoutes < health.TotalRoutes:
+		return StateDegraded
+	default:
+		return StateOK
 	}
 }
 
@@ -94,4 +216,43 @@ func (m *Monitor) checkRoutes(ctx context.Context) {
 		}
 	}
 	wg.Wait()
+	m.reportOutages(m.engine.HealthStatus())
+}
+
+// reportOutages notifies when an alias loses its last healthy route or gets
+// one back.
+func (m *Monitor) reportOutages(status map[string]router.RouteHealth) {
+	if m.notifier == nil {
+		return
+	}
+	for alias, health := range status {
+		down := health.TotalRoutes > 0 && health.HealthyRoutes == 0
+		switch {
+		case down && !m.down[alias]:
+			m.down[alias] = true
+			notifications.Send(m.notifier, notifications.Notification{
+				Severity:   notifications.SeverityCritical,
+				EntityType: notifications.EntityModel,
+				EntityID:   alias,
+				Message:    fmt.Sprintf("provider outage: none of the %d routes for %s are healthy", health.TotalRoutes, alias),
+			})
+		case !down && m.down[alias]:
+			delete(m.down, alias)
+			notifications.Send(m.notifier, notifications.Notification{
+				Severity:   notifications.SeverityInfo,
+				EntityType: notifications.EntityModel,
+				EntityID:   alias,
+				Message:    fmt.Sprintf("%s recovered: %d of %d routes healthy", alias, health.HealthyRoutes, health.TotalRoutes),
+			})
+		}
+	}
+}
+
+// routeName identifies a route in messages by its deployment, falling back
+// to the provider model.
+func routeName(route providers.Route) string {
+	if deployment := route.Metadata["deployment"]; deployment != "" {
+		return deployment
+	}
+	return route.Model
 }
@@ -0,0 +1,109 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/providers"
+	"github.com/ncecere/open_model_gateway/backend/internal/router"
+	"github.com/ncecere/open_model_gateway/backend/internal/services/notifications"
+)
+
+func TestMonitorStatusPingsDependencies(t *testing.T) {
+	monitor := NewMonitor(router.NewEngine(), config.HealthConfig{})
+	ok := func(context.Context) error { return nil }
+	monitor.SetPingers(ok, func(context.Context) error { return errors.New("connection refused") })
+
+	status := monitor.Status(context.Background())
+	if status[DBKey] != StateOK || status[RedisKey] != StateDown {
+		t.Fatalf("unexpected status %v", status)
+	}
+	if Ready(status) {
+		t.Fatal("redis down should block readiness")
+	}
+
+	monitor.SetPingers(ok, ok)
+	if status := monitor.Status(context.Background()); !Ready(status) {
+		t.Fatalf("expected ready, got %v", status)
+	}
+}
+
+func TestReadyIgnoresProviders(t *testing.T) {
+	status := map[string]HealthState{
+		DBKey:                          StateOK,
+		RedisKey:                       StateOK,
+		ProviderKeyPrefix + "claude-3": StateDegraded,
+		ProviderKeyPrefix + "gpt-4":    StateDown,
+	}
+	if !Ready(status) {
+		t.Fatal("provider health must not block readiness")
+	}
+}
+
+func TestRouteState(t *testing.T) {
+	cases := []struct {
+		health router.RouteHealth
+		want   HealthState
+	}{
+		{router.RouteHealth{HealthyRoutes: 2, TotalRoutes: 2}, StateOK},
+		{router.RouteHealth{HealthyRoutes: 1, TotalRoutes: 2}, StateDegraded},
+		{router.RouteHealth{HealthyRoutes: 0, TotalRoutes: 2}, StateDown},
+	}
+	for _, tc := range cases {
+		if got := routeState(tc.health); got != tc.want {
+			t.Fatalf("routeState(%+v) = %s, want %s", tc.health, got, tc.want)
+		}
+	}
+}
+
+type channelNotifier chan notifications.Notification
+
+func (c channelNotifier) Notify(_ context.Context, n notifications.Notification) error {
+	c <- n
+	return nil
+}
+
+func (c channelNotifier) next(t *testing.T) notifications.Notification {
+	t.Helper()
+	select {
+	case n := <-c:
+		return n
+	case <-time.After(time.Second):
+		t.Fatal("expected a notification")
+		return notifications.Notification{}
+	}
+}
+
+func TestMonitorNotifiesBreakerTripsAndOutages(t *testing.T) {
+	engine := router.NewEngine()
+	monitor := NewMonitor(engine, config.HealthConfig{})
+	notifier := make(channelNotifier, 4)
+	monitor.SetNotifier(notifier)
+
+	route := providers.Route{Alias: "gpt-4", Model: "gpt-4", Provider: "openai"}
+	for i := 0; i < 3; i++ {
+		engine.ReportFailure("gpt-4", route)
+	}
+	if n := notifier.next(t); n.Severity != notifications.SeverityWarning || n.EntityType != notifications.EntityRoute || n.EntityID != "gpt-4" {
+		t.Fatalf("unexpected breaker notification %+v", n)
+	}
+
+	monitor.reportOutages(map[string]router.RouteHealth{"gpt-4": {HealthyRoutes: 0, TotalRoutes: 2}})
+	if n := notifier.next(t); n.Severity != notifications.SeverityCritical || n.EntityID != "gpt-4" {
+		t.Fatalf("unexpected outage notification %+v", n)
+	}
+	// A later sweep that still finds the alias down stays quiet.
+	monitor.reportOutages(map[string]router.RouteHealth{"gpt-4": {HealthyRoutes: 0, TotalRoutes: 2}})
+	monitor.reportOutages(map[string]router.RouteHealth{"gpt-4": {HealthyRoutes: 1, TotalRoutes: 2}})
+	if n := notifier.next(t); n.Severity != notifications.SeverityInfo {
+		t.Fatalf("expected a recovery notification, got %+v", n)
+	}
+	select {
+	case n := <-notifier:
+		t.Fatalf("unexpected extra notification %+v", n)
+	default:
+	}
+}
@@ -0,0 +1,90 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/providers"
+)
+
+const modelHealthCacheTTL = 30 * time.Second
+
+// ModelHealth is the per-route probe result for one alias.
+type ModelHealth struct {
+	Alias  string                   `json:"alias"`
+	Routes []providers.HealthResult `json:"routes"`
+}
+
+// Prober runs on-demand health checks against every route of an alias.
+// Results are cached in Redis briefly so operators checking the same model
+// at once do not all hit the provider.
+type Prober struct {
+	client  *redis.Client
+	timeout time.Duration
+	ttl     time.Duration
+}
+
+// NewProber constructs a prober; a nil client disables caching.
+func NewProber(client *redis.Client, timeout time.Duration) *Prober {
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	return &Prober{client: client, timeout: timeout, ttl: modelHealthCacheTTL}
+}
+
+// Check probes routes concurrently, or returns the cached result for alias.
+func (p *Prober) Check(ctx context.Context, alias string, routes []providers.Route) ModelHealth {
+	if cached, ok := p.cached(ctx, alias); ok {
+		return cached
+	}
+
+	result := ModelHealth{Alias: alias, Routes: make([]providers.HealthResult, len(routes))}
+	var wg sync.WaitGroup
+	for i, route := range routes {
+		wg.Add(1)
+		go func(i int, route providers.Route) {
+			defer wg.Done()
+			probeCtx, cancel := context.WithTimeout(ctx, p.timeout)
+			defer cancel()
+			result.Routes[i] = route.HealthCheck(probeCtx)
+		}(i, route)
+	}
+	wg.Wait()
+
+	p.store(ctx, result)
+	return result
+}
+
+func (p *Prober) cached(ctx context.Context, alias string) (ModelHealth, bool) {
+	if p == nil || p.client == nil {
+		return ModelHealth{}, false
+	}
+	data, err := p.client.Get(ctx, p.key(alias)).Bytes()
+	if err != nil {
+		return ModelHealth{}, false
+	}
+	var result ModelHealth
+	if err := json.Unmarshal(data, &result); err != nil {
+		return ModelHealth{}, false
+	}
+	return result, true
+}
+
+func (p *Prober) store(ctx context.Context, result ModelHealth) {
+	if p == nil || p.client == nil {
+		return
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return
+	}
+	p.client.Set(ctx, p.key(result.Alias), data, p.ttl)
+}
+
+func (p *Prober) key(alias string) string {
+	return "model_health:" + alias
+}
@@ -0,0 +1,74 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/providers"
+)
+
+func TestProberReportsRoutesAndCaches(t *testing.T) {
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() {
+		client.Close()
+		server.Close()
+	})
+
+	var calls atomic.Int64
+	routes := []providers.Route{
+		{
+			Provider: "bedrock",
+			Metadata: map[string]string{"region": "us-east-1"},
+			Health: func(context.Context) error {
+				calls.Add(1)
+				return nil
+			},
+		},
+		{
+			Provider: "openai",
+			Metadata: map[string]string{"base_url": "https://api.example.com/v1"},
+			Health: func(context.Context) error {
+				calls.Add(1)
+				return errors.New("401 unauthorized")
+			},
+		},
+		{Provider: "vertex", Model: "gemini-pro"},
+	}
+
+	prober := NewProber(client, time.Second)
+	ctx := context.Background()
+	result := prober.Check(ctx, "chat", routes)
+	if result.Alias != "chat" || len(result.Routes) != 3 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if got := result.Routes[0]; !got.Healthy || got.RegionOrEndpoint != "us-east-1" || got.Error != "" {
+		t.Fatalf("unexpected healthy route %+v", got)
+	}
+	if got := result.Routes[1]; got.Healthy || got.Error != "401 unauthorized" || got.RegionOrEndpoint != "https://api.example.com/v1" {
+		t.Fatalf("unexpected failing route %+v", got)
+	}
+	if got := result.Routes[2]; got.Healthy || got.Error == "" || got.RegionOrEndpoint != "gemini-pro" {
+		t.Fatalf("unexpected unsupported route %+v", got)
+	}
+
+	cached := prober.Check(ctx, "chat", routes)
+	if calls.Load() != 2 || len(cached.Routes) != 3 || cached.Routes[1].Error != "401 unauthorized" {
+		t.Fatalf("expected cached result without new probes, calls=%d result=%+v", calls.Load(), cached)
+	}
+
+	server.FastForward(31 * time.Second)
+	prober.Check(ctx, "chat", routes)
+	if calls.Load() != 4 {
+		t.Fatalf("expected probes after cache expiry, calls=%d", calls.Load())
+	}
+}
@@ -0,0 +1,43 @@
+package httpserver
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// accessLog writes one structured record per request using OpenTelemetry
+// HTTP attribute names, so aggregators can parse it without regex.
+func accessLog() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+		if err != nil {
+			// Let the error handler set the final status before logging.
+			if handlerErr := c.App().ErrorHandler(c, err); handlerErr != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+			err = nil
+		}
+		status := c.Response().StatusCode()
+		level := slog.LevelInfo
+		if status >= fiber.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		route := c.Path()
+		if r := c.Route(); r != nil && r.Path != "" {
+			route = r.Path
+		}
+		slog.Log(c.UserContext(), level, "http request",
+			slog.String("http.method", c.Method()),
+			slog.String("http.route", route),
+			slog.String("url.path", c.Path()),
+			slog.Int("http.status_code", status),
+			slog.Float64("http.duration_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("client.address", c.IP()),
+			slog.String("http.request_id", c.GetRespHeader(fiber.HeaderXRequestID)),
+		)
+		return err
+	}
+}
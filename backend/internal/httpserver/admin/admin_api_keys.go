@@ -6,6 +6,7 @@ import (
     "github.com/ncecere/open_model_gateway/backend/internal/app"
     "github.com/ncecere/open_model_gateway/backend/internal/db"
     "github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+    "github.com/ncecere/open_model_gateway/backend/internal/rbac"
 )
 
 func registerAdminAPIKeyRoutes(router fiber.Router, container *app.Container) {
@@ -19,7 +20,7 @@ type apiKeyHandler struct {
 }
 
 func (h *apiKeyHandler) list(c *fiber.Ctx) error {
-    if err := requireAnyRole(c, h.container, db.MembershipRoleAdmin); err != nil {
+    if err := requireAnyPermission(c, h.container, rbac.PermAPIKeysReadAll); err != nil {
         return err
     }
     if h.container == nil || h.container.Queries == nil {
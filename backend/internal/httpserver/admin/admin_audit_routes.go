@@ -1,8 +1,10 @@
 package admin
 
 import (
+	"bufio"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -12,8 +14,8 @@ import (
 	"github.com/jackc/pgx/v5"
 
 	"github.com/ncecere/open_model_gateway/backend/internal/app"
-	"github.com/ncecere/open_model_gateway/backend/internal/db"
 	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	"github.com/ncecere/open_model_gateway/backend/internal/rbac"
 	auditservice "github.com/ncecere/open_model_gateway/backend/internal/services/audit"
 )
 
@@ -36,10 +38,11 @@ func registerAdminAuditRoutes(router fiber.Router, container *app.Container) {
 	handler := &auditRoutes{container: container, service: auditservice.NewService(container.Queries)}
 	group := router.Group("/audit")
 	group.Get("/logs", handler.list)
+	router.Get("/audit-log/export", handler.export)
 }
 
 func (h *auditRoutes) list(c *fiber.Ctx) error {
-	if err := requireAnyRole(c, h.container, db.MembershipRoleViewer); err != nil {
+	if err := requireAnyPermission(c, h.container, rbac.PermAuditRead); err != nil {
 		return err
 	}
 	filter := auditservice.Filter{Limit: 50}
@@ -94,3 +97,59 @@ func (h *auditRoutes) list(c *fiber.Ctx) error {
 		"offset": filter.Offset,
 	})
 }
+
+// export streams audit entries as CSV or JSONL. Filters are validated before
+// the body starts streaming so bad requests still get a JSON error.
+func (h *auditRoutes) export(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	req := auditservice.ExportRequest{
+		Format:       c.Query("format"),
+		Action:       strings.TrimSpace(c.Query("action")),
+		ResourceType: strings.TrimSpace(c.Query("entity_type")),
+	}
+	if val := strings.TrimSpace(c.Query("actor_id")); val != "" {
+		id, err := uuid.Parse(val)
+		if err != nil {
+			return httputil.WriteError(c, fiber.StatusBadRequest, "invalid actor_id")
+		}
+		req.UserID = id
+	}
+	for _, bound := range []struct {
+		name string
+		dst  *time.Time
+	}{{"start", &req.Start}, {"end", &req.End}} {
+		val := strings.TrimSpace(c.Query(bound.name))
+		if val == "" {
+			continue
+		}
+		ts, err := time.Parse(time.RFC3339, val)
+		if err != nil {
+			return httputil.WriteError(c, fiber.StatusBadRequest, fmt.Sprintf("invalid %s timestamp", bound.name))
+		}
+		*bound.dst = ts
+	}
+	if err := req.Normalize(time.Now().UTC()); err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	contentType := "text/csv"
+	if req.Format == auditservice.ExportFormatJSONL {
+		contentType = "application/x-ndjson"
+	}
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="audit-log-%s.%s"`, req.End.Format("20060102"), req.Format))
+
+	ctx := c.UserContext()
+	service := h.service
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := service.Export(ctx, req, w); err != nil {
+			// Headers are already sent; record the failure in-band so a
+			// truncated file is not mistaken for a complete export.
+			fmt.Fprintf(w, "\nexport failed: %v\n", err)
+		}
+		w.Flush()
+	})
+	return nil
+}
@@ -1,6 +1,7 @@
 package admin
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -11,12 +12,15 @@ import (
 	"github.com/ncecere/open_model_gateway/backend/internal/app"
 	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/batchdto"
 	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	batchsvc "github.com/ncecere/open_model_gateway/backend/internal/services/batches"
+	"github.com/ncecere/open_model_gateway/backend/internal/timeutil"
 )
 
 func registerAdminBatchRoutes(router fiber.Router, container *app.Container) {
 	handler := &batchHandler{container: container}
 	group := router.Group("/batches")
 	group.Get("/", handler.list)
+	group.Get("/analytics", handler.analytics)
 	group.Post("/:batchID/cancel", handler.cancel)
 	group.Get("/:batchID/output", handler.downloadOutput)
 	group.Get("/:batchID/errors", handler.downloadErrors)
@@ -76,6 +80,23 @@ func (h *batchHandler) list(c *fiber.Ctx) error {
 	})
 }
 
+func (h *batchHandler) analytics(c *fiber.Ctx) error {
+	if h.container == nil || h.container.Batches == nil {
+		return httputil.WriteError(c, fiber.StatusNotImplemented, "batches service unavailable")
+	}
+	result, err := h.container.Batches.Analytics(c.UserContext(), batchsvc.AnalyticsParams{
+		Period:  c.Query("period"),
+		GroupBy: c.Query("group_by"),
+	})
+	if err != nil {
+		if errors.Is(err, batchsvc.ErrInvalidAnalyticsGroup) || errors.Is(err, timeutil.ErrInvalidPeriod) {
+			return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+		}
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(result)
+}
+
 func (h *batchHandler) cancel(c *fiber.Ctx) error {
 	batchID, err := parseBatchID(c)
 	if err != nil {
@@ -15,7 +15,9 @@ import (
 	"github.com/ncecere/open_model_gateway/backend/internal/config"
 	"github.com/ncecere/open_model_gateway/backend/internal/db"
 	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	"github.com/ncecere/open_model_gateway/backend/internal/rbac"
 	adminbudgetsvc "github.com/ncecere/open_model_gateway/backend/internal/services/adminbudget"
+	"github.com/ncecere/open_model_gateway/backend/internal/services/usagepipeline"
 )
 
 type budgetHandler struct {
@@ -38,7 +40,7 @@ func registerAdminBudgetRoutes(router fiber.Router, container *app.Container) {
 }
 
 func (h *budgetHandler) getDefault(c *fiber.Ctx) error {
-	if err := requireAnyRole(c, h.container, db.MembershipRoleAdmin); err != nil {
+	if err := requireAnyPermission(c, h.container, rbac.PermSettingsRead); err != nil {
 		return err
 	}
 
@@ -70,7 +72,7 @@ type budgetDefaultsRequest struct {
 }
 
 func (h *budgetHandler) updateDefault(c *fiber.Ctx) error {
-	if err := requireAnyRole(c, h.container, db.MembershipRoleAdmin); err != nil {
+	if err := requireAnyPermission(c, h.container, rbac.PermSettingsWrite); err != nil {
 		return err
 	}
 	if h.service == nil {
@@ -121,7 +123,7 @@ func (h *budgetHandler) updateDefault(c *fiber.Ctx) error {
 }
 
 func (h *budgetHandler) listOverrides(c *fiber.Ctx) error {
-	if err := requireAnyRole(c, h.container, db.MembershipRoleViewer); err != nil {
+	if err := requireAnyPermission(c, h.container, rbac.PermBudgetsRead); err != nil {
 		return err
 	}
 	if h.service == nil {
@@ -134,7 +136,7 @@ func (h *budgetHandler) listOverrides(c *fiber.Ctx) error {
 		if err != nil {
 			return httputil.WriteError(c, fiber.StatusBadRequest, "invalid tenant_id")
 		}
-		if err := requireTenantRole(c, h.container, tenantID, db.MembershipRoleViewer); err != nil {
+		if err := requireTenantPermission(c, h.container, tenantID, rbac.PermBudgetsRead); err != nil {
 			return err
 		}
 		override, err := h.service.GetOverride(c.Context(), tenantID)
@@ -166,6 +168,15 @@ type budgetOverrideRequest struct {
 	AlertEmails          []string `json:"alert_emails"`
 	AlertWebhooks        []string `json:"alert_webhooks"`
 	AlertCooldownSeconds *int32   `json:"alert_cooldown_seconds"`
+	// UsageAlert subscribes the tenant to usage spike alerts; omit it to
+	// keep the current subscription.
+	UsageAlert *usagepipeline.TenantUsageAlertConfig `json:"usage_alert"`
+	// EnforcementMode is "hard" (reject once spent) or "soft" (allow and
+	// flag); omit it to keep the current mode.
+	EnforcementMode string `json:"enforcement_mode"`
+	// AlertWebhookSecret signs the tenant's alert webhooks. It is write-only;
+	// omit it to keep the current secret or send "" to remove it.
+	AlertWebhookSecret *string `json:"alert_webhook_secret"`
 }
 
 func (h *budgetHandler) upsertOverride(c *fiber.Ctx) error {
@@ -173,7 +184,7 @@ func (h *budgetHandler) upsertOverride(c *fiber.Ctx) error {
 	if err != nil {
 		return err
 	}
-	if err := requireTenantRole(c, h.container, tenantUUID, db.MembershipRoleOwner); err != nil {
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermBudgetsWrite); err != nil {
 		return err
 	}
 
@@ -192,6 +203,9 @@ func (h *budgetHandler) upsertOverride(c *fiber.Ctx) error {
 		AlertEmails:          req.AlertEmails,
 		AlertWebhooks:        req.AlertWebhooks,
 		AlertCooldownSeconds: req.AlertCooldownSeconds,
+		UsageAlert:           req.UsageAlert,
+		EnforcementMode:      req.EnforcementMode,
+		AlertWebhookSecret:   req.AlertWebhookSecret,
 	})
 	if err != nil {
 		return writeBudgetError(c, err)
@@ -203,6 +217,9 @@ func (h *budgetHandler) upsertOverride(c *fiber.Ctx) error {
 		"refresh_schedule":  req.RefreshSchedule,
 		"alert_emails":      req.AlertEmails,
 		"alert_webhooks":    req.AlertWebhooks,
+		"usage_alert":       req.UsageAlert,
+		"enforcement_mode":  override.EnforcementMode,
+		"secret_updated":    req.AlertWebhookSecret != nil,
 	}); err != nil {
 		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
 	}
@@ -215,7 +232,7 @@ func (h *budgetHandler) deleteOverride(c *fiber.Ctx) error {
 	if err != nil {
 		return err
 	}
-	if err := requireTenantRole(c, h.container, tenantUUID, db.MembershipRoleOwner); err != nil {
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermBudgetsWrite); err != nil {
 		return err
 	}
 
@@ -257,18 +274,25 @@ func mapBudgetOverride(ov db.TenantBudgetOverride) fiber.Map {
 	if ov.LastAlertAt.Valid {
 		lastAlert = lastAlertAt.Format(time.RFC3339)
 	}
+	var usageAlert *usagepipeline.TenantUsageAlertConfig
+	if sub, ok, err := usagepipeline.ParseTenantUsageAlertConfig(ov.UsageAlertConfig); err == nil && ok {
+		usageAlert = &sub
+	}
 	return fiber.Map{
-		"tenant_id":              tenantID.String(),
-		"budget_usd":             budget,
-		"warning_threshold":      warn,
-		"refresh_schedule":       ov.RefreshSchedule,
-		"alert_emails":           ov.AlertEmails,
-		"alert_webhooks":         ov.AlertWebhooks,
-		"alert_cooldown_seconds": ov.AlertCooldownSeconds,
-		"last_alert_at":          lastAlert,
-		"last_alert_level":       ov.LastAlertLevel.String,
-		"created_at":             created.Format(time.RFC3339),
-		"updated_at":             updated.Format(time.RFC3339),
+		"tenant_id":                tenantID.String(),
+		"budget_usd":               budget,
+		"warning_threshold":        warn,
+		"refresh_schedule":         ov.RefreshSchedule,
+		"alert_emails":             ov.AlertEmails,
+		"alert_webhooks":           ov.AlertWebhooks,
+		"alert_cooldown_seconds":   ov.AlertCooldownSeconds,
+		"last_alert_at":            lastAlert,
+		"last_alert_level":         ov.LastAlertLevel.String,
+		"created_at":               created.Format(time.RFC3339),
+		"updated_at":               updated.Format(time.RFC3339),
+		"usage_alert":              usageAlert,
+		"enforcement_mode":         ov.EnforcementMode,
+		"alert_webhook_secret_set": ov.AlertWebhookSecret != "",
 	}
 }
 
@@ -383,7 +407,9 @@ func writeBudgetError(c *fiber.Ctx, err error) error {
 	switch {
 	case errors.Is(err, adminbudgetsvc.ErrInvalidDefault),
 		errors.Is(err, adminbudgetsvc.ErrInvalidThreshold),
-		errors.Is(err, adminbudgetsvc.ErrInvalidOverride):
+		errors.Is(err, adminbudgetsvc.ErrInvalidOverride),
+		errors.Is(err, adminbudgetsvc.ErrInvalidUsageAlert),
+		errors.Is(err, adminbudgetsvc.ErrInvalidEnforcement):
 		status = fiber.StatusBadRequest
 	case errors.Is(err, adminbudgetsvc.ErrServiceUnavailable):
 		status = fiber.StatusInternalServerError
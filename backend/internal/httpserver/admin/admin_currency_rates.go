@@ -0,0 +1,88 @@
+package admin
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/currency"
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	"github.com/ncecere/open_model_gateway/backend/internal/rbac"
+	usageservice "github.com/ncecere/open_model_gateway/backend/internal/services/usage"
+)
+
+func registerAdminCurrencyRateRoutes(router fiber.Router, container *app.Container) {
+	handler := &currencyRateHandler{container: container}
+	group := router.Group("/config/currency-rates")
+	group.Get("/", handler.list)
+	group.Post("/", handler.upsert)
+}
+
+type currencyRateHandler struct {
+	container *app.Container
+}
+
+type currencyRateRequest struct {
+	FromCurrency  string  `json:"from_currency"`
+	ToCurrency    string  `json:"to_currency"`
+	Rate          float64 `json:"rate"`
+	EffectiveDate string  `json:"effective_date"`
+}
+
+func (h *currencyRateHandler) list(c *fiber.Ctx) error {
+	if err := requireAnyPermission(c, h.container, rbac.PermCurrencyRatesRead); err != nil {
+		return err
+	}
+	if h.container.UsageService == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "usage service unavailable")
+	}
+	rates, err := h.container.UsageService.ListCurrencyRates(c.Context())
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(fiber.Map{"rates": rates})
+}
+
+func (h *currencyRateHandler) upsert(c *fiber.Ctx) error {
+	if err := requireAnyPermission(c, h.container, rbac.PermCurrencyRatesWrite); err != nil {
+		return err
+	}
+	if h.container.UsageService == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "usage service unavailable")
+	}
+	var req currencyRateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
+	}
+	if strings.TrimSpace(req.FromCurrency) == "" || strings.TrimSpace(req.ToCurrency) == "" {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "from_currency and to_currency are required")
+	}
+	effective := time.Now().UTC()
+	if raw := strings.TrimSpace(req.EffectiveDate); raw != "" {
+		parsed, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			return httputil.WriteError(c, fiber.StatusBadRequest, "effective_date must be YYYY-MM-DD")
+		}
+		effective = parsed
+	}
+
+	record, err := h.container.UsageService.UpsertCurrencyRate(c.Context(), req.FromCurrency, req.ToCurrency, req.Rate, effective)
+	if err != nil {
+		switch {
+		case errors.Is(err, currency.ErrUnknownCurrency), errors.Is(err, usageservice.ErrInvalidCurrencyRate):
+			return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+		default:
+			return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+		}
+	}
+	if err := recordAudit(c, h.container, "config.currency_rate.upsert", "currency_rate", record.FromCurrency+"/"+record.ToCurrency, fiber.Map{
+		"rate":           record.Rate,
+		"effective_date": record.EffectiveDate,
+	}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(record)
+}
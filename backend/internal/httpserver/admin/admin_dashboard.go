@@ -0,0 +1,42 @@
+package admin
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	"github.com/ncecere/open_model_gateway/backend/internal/rbac"
+	admindashboardsvc "github.com/ncecere/open_model_gateway/backend/internal/services/admindashboard"
+)
+
+type dashboardHandler struct {
+	container *app.Container
+	service   *admindashboardsvc.Service
+}
+
+func registerAdminDashboardRoutes(router fiber.Router, container *app.Container) {
+	handler := &dashboardHandler{
+		container: container,
+		service:   container.AdminDashboard,
+	}
+	router.Get("/dashboard", handler.get)
+}
+
+// get returns the overview snapshot behind the admin landing page. Results are
+// cached for a minute, so as_of may trail the request time.
+func (h *dashboardHandler) get(c *fiber.Ctx) error {
+	if err := requireAnyPermission(c, h.container, rbac.PermUsageRead); err != nil {
+		return err
+	}
+	snapshot, err := h.service.Get(c.UserContext(), time.Now())
+	if err != nil {
+		if errors.Is(err, admindashboardsvc.ErrServiceUnavailable) {
+			return httputil.WriteError(c, fiber.StatusInternalServerError, "dashboard service unavailable")
+		}
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(snapshot)
+}
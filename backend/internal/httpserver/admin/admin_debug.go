@@ -0,0 +1,49 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/executor"
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+)
+
+func registerAdminDebugRoutes(router fiber.Router, container *app.Container) {
+	handler := &debugHandler{container: container, executor: executor.New(container)}
+	group := router.Group("/debug")
+	group.Get("/samples", handler.samples)
+	group.Post("/compare-models", handler.compareModels)
+}
+
+// chatExecutor runs one chat completion through the gateway pipeline;
+// *executor.Executor implements it.
+type chatExecutor interface {
+	Chat(ctx context.Context, rc *requestctx.Context, alias string, req models.ChatRequest, traceID string, idempotencyKey string) (executor.ChatResult, error)
+}
+
+type debugHandler struct {
+	container *app.Container
+	executor  chatExecutor
+}
+
+// samples returns the sampled /v1 traffic held in memory, newest first.
+func (h *debugHandler) samples(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	buffer := h.container.DebugSamples
+	if buffer == nil {
+		return httputil.WriteError(c, fiber.StatusNotFound, "debug sampling is disabled; set DEBUG_SAMPLING_ENABLED=true and restart")
+	}
+	samples := buffer.Snapshot()
+	return c.JSON(fiber.Map{
+		"sample_rate": h.container.Config.Debug.SampleRate,
+		"capacity":    buffer.Capacity(),
+		"count":       len(samples),
+		"samples":     samples,
+	})
+}
@@ -0,0 +1,162 @@
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/executor"
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+	usageservice "github.com/ncecere/open_model_gateway/backend/internal/services/usage"
+)
+
+// compareKeyPrefix stands in for the API key prefix on comparison requests so
+// their rate limits and logs stay apart from the tenant's real keys.
+const compareKeyPrefix = "debug-compare"
+
+type compareModelsRequest struct {
+	TenantID    string               `json:"tenant_id"`
+	Models      []string             `json:"models"`
+	Messages    []models.ChatMessage `json:"messages"`
+	Temperature *float32             `json:"temperature"`
+	MaxTokens   *int32               `json:"max_tokens"`
+}
+
+type modelComparison struct {
+	Model     string               `json:"model"`
+	Response  *models.ChatResponse `json:"response,omitempty"`
+	LatencyMs int64                `json:"latency_ms"`
+	CostCents int64                `json:"cost_cents"`
+	Tokens    models.Usage         `json:"tokens"`
+	Status    int                  `json:"status"`
+	Error     string               `json:"error,omitempty"`
+}
+
+// compareModels sends one chat request to every listed model concurrently on
+// behalf of a tenant and returns the responses side by side, in request
+// order. Each sub-request goes through the executor, so budgets, rate limits,
+// and usage logging apply to it as to a live call. The calls carry a zero API
+// key ID under compareKeyPrefix, since no real key is involved.
+func (h *debugHandler) compareModels(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	var req compareModelsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
+	}
+	tenantID, err := h.compareTenantID(c, req.TenantID)
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+	}
+	aliases := make([]string, 0, len(req.Models))
+	for _, alias := range req.Models {
+		if alias = strings.TrimSpace(alias); alias != "" {
+			aliases = append(aliases, alias)
+		}
+	}
+	if len(aliases) == 0 {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "models must include at least one alias")
+	}
+	if len(aliases) > usageservice.MaxUsageCompareSeries {
+		return httputil.WriteError(c, fiber.StatusBadRequest, fmt.Sprintf("at most %d models may be compared", usageservice.MaxUsageCompareSeries))
+	}
+	if len(req.Messages) == 0 {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "messages are required")
+	}
+
+	ctx := c.UserContext()
+	tenant, err := h.container.Queries.GetTenantByID(ctx, pgtype.UUID{Bytes: tenantID, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return httputil.WriteError(c, fiber.StatusNotFound, "tenant not found")
+		}
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	if tenant.Status != db.TenantStatusActive {
+		return httputil.WriteError(c, fiber.StatusConflict, "tenant is not active")
+	}
+	rc, err := app.BuildRequestContext(ctx, h.container, db.ApiKey{
+		ID:       pgtype.UUID{Valid: true},
+		TenantID: tenant.ID,
+		Prefix:   compareKeyPrefix,
+	})
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	callCtx := requestctx.WithContext(ctx, rc)
+
+	comparisons := make([]modelComparison, len(aliases))
+	var g errgroup.Group
+	for i, alias := range aliases {
+		g.Go(func() error {
+			out := modelComparison{Model: alias}
+			if !h.container.IsModelAllowed(rc.TenantID, alias) {
+				out.Status = fiber.StatusForbidden
+				out.Error = "model not enabled for tenant"
+				comparisons[i] = out
+				return nil
+			}
+			chatReq := models.ChatRequest{
+				Model:       alias,
+				Messages:    req.Messages,
+				Temperature: req.Temperature,
+				MaxTokens:   req.MaxTokens,
+			}
+			start := time.Now()
+			result, err := h.executor.Chat(callCtx, rc, alias, chatReq, uuid.NewString(), "")
+			out.LatencyMs = time.Since(start).Milliseconds()
+			if err != nil {
+				out.Status = fiber.StatusBadGateway
+				out.Error = err.Error()
+				if status, msg, ok := executor.AsAPIError(err); ok {
+					out.Status, out.Error = status, msg
+				}
+				comparisons[i] = out
+				return nil
+			}
+			out.Status = fiber.StatusOK
+			out.Response = &result.Response
+			out.Tokens = result.Response.Usage
+			out.CostCents = h.container.UsageLogger.EstimateCostCents(alias, result.Response.Usage)
+			comparisons[i] = out
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	if err := recordAudit(c, h.container, "debug.compare_models", "tenant", tenantID.String(), fiber.Map{
+		"models": aliases,
+	}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(fiber.Map{"comparisons": comparisons})
+}
+
+// compareTenantID resolves the tenant a comparison runs in. Without a
+// tenant_id it defaults to the calling admin's personal tenant.
+func (h *debugHandler) compareTenantID(c *fiber.Ctx, raw string) (uuid.UUID, error) {
+	if raw = strings.TrimSpace(raw); raw != "" {
+		tenantID, err := uuid.Parse(raw)
+		if err != nil {
+			return uuid.Nil, errors.New("invalid tenant_id")
+		}
+		return tenantID, nil
+	}
+	user, ok := adminUserFromContext(c.UserContext())
+	if !ok || !user.PersonalTenantID.Valid {
+		return uuid.Nil, errors.New("tenant_id is required when the caller has no personal tenant")
+	}
+	return uuid.UUID(user.PersonalTenantID.Bytes), nil
+}
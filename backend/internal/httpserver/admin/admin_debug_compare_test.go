@@ -0,0 +1,258 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/executor"
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+	adminauditsvc "github.com/ncecere/open_model_gateway/backend/internal/services/adminaudit"
+	usageservice "github.com/ncecere/open_model_gateway/backend/internal/services/usage"
+	"github.com/ncecere/open_model_gateway/backend/internal/services/usagepipeline"
+)
+
+// compareTenantDB serves GetTenantByID for active tenants and reports every
+// other lookup as empty, which is all BuildRequestContext needs.
+type compareTenantDB struct{}
+
+func (compareTenantDB) Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, nil
+}
+
+func (compareTenantDB) Query(context.Context, string, ...interface{}) (pgx.Rows, error) {
+	return &emptyRows{}, nil
+}
+
+func (compareTenantDB) QueryRow(_ context.Context, sql string, args ...interface{}) pgx.Row {
+	if strings.Contains(sql, "name: GetTenantByID") {
+		return tenantRow{id: args[0].(pgtype.UUID)}
+	}
+	return tenantRow{err: pgx.ErrNoRows}
+}
+
+type tenantRow struct {
+	id  pgtype.UUID
+	err error
+}
+
+func (r tenantRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	*dest[0].(*pgtype.UUID) = r.id
+	*dest[2].(*db.TenantStatus) = db.TenantStatusActive
+	return nil
+}
+
+type emptyRows struct{}
+
+func (*emptyRows) Close()                                       {}
+func (*emptyRows) Err() error                                   { return nil }
+func (*emptyRows) CommandTag() pgconn.CommandTag                { return pgconn.CommandTag{} }
+func (*emptyRows) FieldDescriptions() []pgconn.FieldDescription { return nil }
+func (*emptyRows) Next() bool                                   { return false }
+func (*emptyRows) Scan(...any) error                            { return nil }
+func (*emptyRows) Values() ([]any, error)                       { return nil, nil }
+func (*emptyRows) RawValues() [][]byte                          { return nil }
+func (*emptyRows) Conn() *pgx.Conn                              { return nil }
+
+type stubChatCall struct {
+	tenantID uuid.UUID
+	keyID    uuid.UUID
+	prefix   string
+}
+
+// stubChatExecutor answers each alias after its configured delay, or fails
+// it when the alias is listed in errs.
+type stubChatExecutor struct {
+	delays map[string]time.Duration
+	errs   map[string]error
+
+	mu    sync.Mutex
+	calls []stubChatCall
+}
+
+func (s *stubChatExecutor) Chat(_ context.Context, rc *requestctx.Context, alias string, _ models.ChatRequest, _ string, _ string) (executor.ChatResult, error) {
+	s.mu.Lock()
+	s.calls = append(s.calls, stubChatCall{tenantID: rc.TenantID, keyID: rc.APIKeyID, prefix: rc.APIKeyPrefix})
+	s.mu.Unlock()
+	time.Sleep(s.delays[alias])
+	if err := s.errs[alias]; err != nil {
+		return executor.ChatResult{}, err
+	}
+	return executor.ChatResult{Response: models.ChatResponse{
+		Model: alias,
+		Usage: models.Usage{PromptTokens: 1000, CompletionTokens: 1000, TotalTokens: 2000},
+	}}, nil
+}
+
+func newCompareTestApp(chat *stubChatExecutor, audit *stubAuditRecorder, user db.User) *fiber.App {
+	logger := usagepipeline.NewLogger(nil, nil, config.BudgetConfig{}, nil, nil, nil, nil, nil, nil)
+	logger.LoadCatalog([]config.ModelCatalogEntry{{Alias: "fast", PriceInput: 1, PriceOutput: 2}})
+	container := &app.Container{
+		Config:      &config.Config{},
+		Queries:     db.New(compareTenantDB{}),
+		UsageLogger: logger,
+		AdminAudit:  adminauditsvc.NewService(audit),
+	}
+	handler := &debugHandler{container: container, executor: chat}
+	fiberApp := fiber.New()
+	fiberApp.Use(respondedErrors(), func(c *fiber.Ctx) error {
+		ctx := context.WithValue(c.UserContext(), adminContextUserKey, user)
+		ctx = context.WithValue(ctx, adminContextUserIDKey, uuid.New())
+		c.SetUserContext(ctx)
+		return c.Next()
+	})
+	fiberApp.Post("/debug/compare-models", handler.compareModels)
+	return fiberApp
+}
+
+func postCompare(t *testing.T, fiberApp *fiber.App, body fiber.Map) (int, []modelComparison) {
+	t.Helper()
+	raw, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	req := httptest.NewRequest("POST", "/debug/compare-models", strings.NewReader(string(raw)))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	resp, err := fiberApp.Test(req)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	var out struct {
+		Comparisons []modelComparison `json:"comparisons"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&out)
+	return resp.StatusCode, out.Comparisons
+}
+
+var compareMessages = []models.ChatMessage{{Role: "user", Content: "hi"}}
+
+func TestCompareModelsRequiresSuperAdmin(t *testing.T) {
+	chat := &stubChatExecutor{}
+	audit := &stubAuditRecorder{}
+	fiberApp := newCompareTestApp(chat, audit, db.User{})
+
+	status, _ := postCompare(t, fiberApp, fiber.Map{
+		"tenant_id": uuid.NewString(),
+		"models":    []string{"fast"},
+		"messages":  compareMessages,
+	})
+	if status != fiber.StatusForbidden {
+		t.Fatalf("expected 403, got %d", status)
+	}
+	if len(chat.calls) != 0 || len(audit.entries) != 0 {
+		t.Fatalf("expected no calls or audit entries, got %d calls and %d entries", len(chat.calls), len(audit.entries))
+	}
+}
+
+func TestCompareModelsKeepsRequestOrderAcrossPartialFailures(t *testing.T) {
+	chat := &stubChatExecutor{
+		delays: map[string]time.Duration{"slow": 50 * time.Millisecond},
+		errs:   map[string]error{"broken": errors.New("upstream unavailable")},
+	}
+	audit := &stubAuditRecorder{}
+	fiberApp := newCompareTestApp(chat, audit, db.User{IsSuperAdmin: true})
+	tenantID := uuid.New()
+
+	status, comparisons := postCompare(t, fiberApp, fiber.Map{
+		"tenant_id": tenantID.String(),
+		"models":    []string{"slow", "broken", " ", "fast"},
+		"messages":  compareMessages,
+	})
+	if status != fiber.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if len(comparisons) != 3 {
+		t.Fatalf("expected 3 comparisons, got %d", len(comparisons))
+	}
+	for i, want := range []string{"slow", "broken", "fast"} {
+		if comparisons[i].Model != want {
+			t.Fatalf("comparison %d: expected %q, got %q", i, want, comparisons[i].Model)
+		}
+	}
+	if comparisons[0].Status != fiber.StatusOK || comparisons[2].Status != fiber.StatusOK {
+		t.Fatalf("expected the healthy models to succeed, got %+v", comparisons)
+	}
+	if comparisons[1].Status != fiber.StatusBadGateway || comparisons[1].Error != "upstream unavailable" {
+		t.Fatalf("expected the failed model to report a 502, got %+v", comparisons[1])
+	}
+	// 1000 tokens each way at $1 and $2 per million tokens rounds up to 1 cent.
+	if comparisons[2].CostCents != 1 || comparisons[2].Tokens.TotalTokens != 2000 {
+		t.Fatalf("expected priced usage for the fast model, got %+v", comparisons[2])
+	}
+	for _, call := range chat.calls {
+		if call.tenantID != tenantID || call.keyID != uuid.Nil || call.prefix != compareKeyPrefix {
+			t.Fatalf("expected calls under the debug key in the tenant, got %+v", call)
+		}
+	}
+	if len(audit.entries) != 1 || audit.entries[0].Action != "debug.compare_models" {
+		t.Fatalf("expected one compare audit entry, got %+v", audit.entries)
+	}
+}
+
+func TestCompareModelsCapsTheModelCount(t *testing.T) {
+	chat := &stubChatExecutor{}
+	fiberApp := newCompareTestApp(chat, &stubAuditRecorder{}, db.User{IsSuperAdmin: true})
+
+	aliases := make([]string, usageservice.MaxUsageCompareSeries+1)
+	for i := range aliases {
+		aliases[i] = fmt.Sprintf("model-%d", i)
+	}
+	status, _ := postCompare(t, fiberApp, fiber.Map{
+		"tenant_id": uuid.NewString(),
+		"models":    aliases,
+		"messages":  compareMessages,
+	})
+	if status != fiber.StatusBadRequest {
+		t.Fatalf("expected 400 above the cap, got %d", status)
+	}
+
+	status, comparisons := postCompare(t, fiberApp, fiber.Map{
+		"tenant_id": uuid.NewString(),
+		"models":    aliases[:usageservice.MaxUsageCompareSeries],
+		"messages":  compareMessages,
+	})
+	if status != fiber.StatusOK || len(comparisons) != usageservice.MaxUsageCompareSeries {
+		t.Fatalf("expected the cap itself to be allowed, got %d with %d comparisons", status, len(comparisons))
+	}
+}
+
+func TestCompareModelsDefaultsToPersonalTenant(t *testing.T) {
+	personal := uuid.New()
+	chat := &stubChatExecutor{}
+	fiberApp := newCompareTestApp(chat, &stubAuditRecorder{}, db.User{
+		IsSuperAdmin:     true,
+		PersonalTenantID: pgtype.UUID{Bytes: personal, Valid: true},
+	})
+
+	status, _ := postCompare(t, fiberApp, fiber.Map{"models": []string{"fast"}, "messages": compareMessages})
+	if status != fiber.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if len(chat.calls) != 1 || chat.calls[0].tenantID != personal {
+		t.Fatalf("expected the call to run in the personal tenant, got %+v", chat.calls)
+	}
+
+	fiberApp = newCompareTestApp(chat, &stubAuditRecorder{}, db.User{IsSuperAdmin: true})
+	if status, _ := postCompare(t, fiberApp, fiber.Map{"models": []string{"fast"}, "messages": compareMessages}); status != fiber.StatusBadRequest {
+		t.Fatalf("expected 400 without a tenant to default to, got %d", status)
+	}
+}
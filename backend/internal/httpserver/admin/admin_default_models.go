@@ -8,8 +8,8 @@ import (
 
 	"github.com/ncecere/open_model_gateway/backend/internal/app"
 	"github.com/ncecere/open_model_gateway/backend/internal/catalog"
-	"github.com/ncecere/open_model_gateway/backend/internal/db"
 	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	"github.com/ncecere/open_model_gateway/backend/internal/rbac"
 )
 
 func registerAdminDefaultModelRoutes(router fiber.Router, container *app.Container) {
@@ -29,7 +29,7 @@ type defaultModelRequest struct {
 }
 
 func (h *defaultModelHandler) list(c *fiber.Ctx) error {
-	if err := requireAnyRole(c, h.container, db.MembershipRoleViewer); err != nil {
+	if err := requireAnyPermission(c, h.container, rbac.PermModelsRead); err != nil {
 		return err
 	}
 	models, err := h.container.DefaultModels.List(c.Context())
@@ -40,7 +40,7 @@ func (h *defaultModelHandler) list(c *fiber.Ctx) error {
 }
 
 func (h *defaultModelHandler) create(c *fiber.Ctx) error {
-	if err := requireAnyRole(c, h.container, db.MembershipRoleAdmin); err != nil {
+	if err := requireAnyPermission(c, h.container, rbac.PermModelsWrite); err != nil {
 		return err
 	}
 	var req defaultModelRequest
@@ -58,7 +58,7 @@ func (h *defaultModelHandler) create(c *fiber.Ctx) error {
 }
 
 func (h *defaultModelHandler) delete(c *fiber.Ctx) error {
-	if err := requireAnyRole(c, h.container, db.MembershipRoleAdmin); err != nil {
+	if err := requireAnyPermission(c, h.container, rbac.PermModelsWrite); err != nil {
 		return err
 	}
 	alias := strings.TrimSpace(c.Params("alias"))
@@ -0,0 +1,90 @@
+package admin
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+)
+
+func registerAdminImpersonationRoutes(router fiber.Router, container *app.Container) {
+	handler := &impersonationHandler{container: container}
+	router.Post("/impersonate", handler.impersonate)
+}
+
+type impersonationHandler struct {
+	container *app.Container
+}
+
+type impersonateRequest struct {
+	TenantID string `json:"tenant_id"`
+	Reason   string `json:"reason"`
+}
+
+type impersonateResponse struct {
+	Token     string    `json:"token"`
+	TenantID  string    `json:"tenant_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// impersonate issues a short-lived token that calls the public API as the
+// tenant. Each issuance is audited with the admin and their reason.
+func (h *impersonationHandler) impersonate(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	adminID, ok := adminUserIDFromContext(c.UserContext())
+	if !ok {
+		return httputil.WriteError(c, fiber.StatusUnauthorized, "missing admin context")
+	}
+
+	var req impersonateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
+	}
+	tenantID, err := uuid.Parse(strings.TrimSpace(req.TenantID))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid tenant_id")
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "reason is required")
+	}
+
+	tenant, err := h.container.Queries.GetTenantByID(c.Context(), pgtype.UUID{Bytes: tenantID, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return httputil.WriteError(c, fiber.StatusNotFound, "tenant not found")
+		}
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	if tenant.Status != db.TenantStatusActive {
+		return httputil.WriteError(c, fiber.StatusConflict, "tenant is not active")
+	}
+
+	token, expiresAt, err := h.container.AdminAuth.IssueImpersonationToken(adminID, tenantID, reason)
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	if err := recordAudit(c, h.container, "tenant.impersonate", "tenant", tenantID.String(), fiber.Map{
+		"reason":     reason,
+		"expires_at": expiresAt.UTC(),
+	}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(impersonateResponse{
+		Token:     token,
+		TenantID:  tenantID.String(),
+		ExpiresAt: expiresAt.UTC(),
+	})
+}
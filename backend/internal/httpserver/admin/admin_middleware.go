@@ -2,12 +2,15 @@ package admin
 
 import (
 	"context"
+	"errors"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/auth"
 	"github.com/ncecere/open_model_gateway/backend/internal/db"
 	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
 )
@@ -18,6 +21,7 @@ const (
 	adminAuthHeaderPrefix  = "bearer "
 	adminContextUserKey    = adminContextKey("open-model-gateway/admin-user")
 	adminContextUserIDKey  = adminContextKey("open-model-gateway/admin-user-id")
+	adminContextTokenKey   = adminContextKey("open-model-gateway/admin-token-id")
 	adminAuthorizationName = "Authorization"
 )
 
@@ -35,7 +39,21 @@ func adminAuthMiddleware(container *app.Container) fiber.Handler {
 			return httputil.WriteError(c, fiber.StatusUnauthorized, "admin authorization required")
 		}
 
-		user, err := container.AdminAuth.AuthorizeAccessToken(userContext(c), token)
+		var (
+			user       db.User
+			adminToken *db.AdminToken
+			err        error
+		)
+		if auth.IsAdminToken(token) {
+			var record db.AdminToken
+			user, record, err = container.AdminAuth.AuthorizeAdminToken(userContext(c), token, c.IP())
+			if errors.Is(err, auth.ErrAdminTokenIPDenied) {
+				return httputil.WriteError(c, fiber.StatusForbidden, err.Error())
+			}
+			adminToken = &record
+		} else {
+			user, err = container.AdminAuth.AuthorizeAccessToken(userContext(c), token)
+		}
 		if err != nil {
 			return httputil.WriteError(c, fiber.StatusUnauthorized, "invalid or expired token")
 		}
@@ -47,6 +65,9 @@ func adminAuthMiddleware(container *app.Container) fiber.Handler {
 
 		ctx := context.WithValue(userContext(c), adminContextUserKey, user)
 		ctx = context.WithValue(ctx, adminContextUserIDKey, userID)
+		if adminToken != nil {
+			ctx = context.WithValue(ctx, adminContextTokenKey, adminToken.ID)
+		}
 		c.SetUserContext(ctx)
 		c.Locals("adminUserID", userID.String())
 		c.Locals("adminUser", user)
@@ -77,3 +98,13 @@ func adminUserIDFromContext(ctx context.Context) (uuid.UUID, bool) {
 	id, ok := val.(uuid.UUID)
 	return id, ok
 }
+
+// adminAuthenticatedByToken reports whether the request was authorized with a
+// long-lived admin token instead of an interactive session.
+func adminAuthenticatedByToken(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	_, ok := ctx.Value(adminContextTokenKey).(pgtype.UUID)
+	return ok
+}
@@ -2,14 +2,19 @@ package admin
 
 import (
 	"errors"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 
 	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/health"
 	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	"github.com/ncecere/open_model_gateway/backend/internal/rbac"
 	"github.com/ncecere/open_model_gateway/backend/internal/router"
 	admincatalogsvc "github.com/ncecere/open_model_gateway/backend/internal/services/admincatalog"
+	usageservice "github.com/ncecere/open_model_gateway/backend/internal/services/usage"
 )
 
 func registerAdminModelCatalogRoutes(router fiber.Router, container *app.Container) {
@@ -23,6 +28,16 @@ func registerAdminModelCatalogRoutes(router fiber.Router, container *app.Contain
 	group.Get("/status", handler.status)
 	group.Post("/", handler.upsert)
 	group.Delete("/:alias", handler.remove)
+
+	router.Get("/catalog/deprecated", handler.deprecated)
+	router.Put("/catalog/:alias/deprecation", handler.setDeprecation)
+	router.Get("/catalog/:alias/price-history", handler.priceHistory)
+
+	router.Get("/models/cost-comparison", handler.costComparison)
+	router.Get("/models/:alias/ab-stats", handler.abStats)
+	router.Get("/models/:alias/health", handler.health)
+	router.Get("/models/:alias/health/history", handler.healthHistory)
+	router.Get("/models/:alias/latency-stream", handler.latencyStream)
 }
 
 type modelCatalogHandler struct {
@@ -69,7 +84,8 @@ func (h *modelCatalogHandler) upsert(c *fiber.Ctx) error {
 	if err := c.BodyParser(&payload); err != nil {
 		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
 	}
-	entry, err := h.service.Upsert(c.Context(), payload)
+	actorID, _ := adminUserIDFromContext(c.UserContext())
+	entry, err := h.service.Upsert(c.Context(), payload, actorID)
 	if err != nil {
 		return writeCatalogError(c, err)
 	}
@@ -100,14 +116,208 @@ func (h *modelCatalogHandler) remove(c *fiber.Ctx) error {
 	return c.SendStatus(fiber.StatusNoContent)
 }
 
+// deprecated lists every catalog entry with a scheduled removal date.
+func (h *modelCatalogHandler) deprecated(c *fiber.Ctx) error {
+	if err := requireAnyPermission(c, h.container, rbac.PermModelsRead); err != nil {
+		return err
+	}
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "model catalog service unavailable")
+	}
+	items, err := h.service.ListDeprecated(c.UserContext())
+	if err != nil {
+		return writeCatalogError(c, err)
+	}
+	return c.JSON(fiber.Map{"models": items})
+}
+
+type deprecationRequest struct {
+	DeprecatedAt *time.Time `json:"deprecated_at"`
+	Message      string     `json:"message"`
+}
+
+// setDeprecation schedules or, with a null deprecated_at, clears an alias's
+// deprecation.
+func (h *modelCatalogHandler) setDeprecation(c *fiber.Ctx) error {
+	if err := requireAnyPermission(c, h.container, rbac.PermModelsWrite); err != nil {
+		return err
+	}
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "model catalog service unavailable")
+	}
+	var req deprecationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
+	}
+	alias := strings.TrimSpace(c.Params("alias"))
+	if err := h.service.SetDeprecation(c.UserContext(), alias, req.DeprecatedAt, req.Message); err != nil {
+		return writeCatalogError(c, err)
+	}
+	metadata := fiber.Map{"deprecated_at": req.DeprecatedAt, "message": strings.TrimSpace(req.Message)}
+	if err := recordAudit(c, h.container, "model_catalog.deprecation", "model", alias, metadata); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// priceHistory lists the recorded price changes for an alias, newest first.
+func (h *modelCatalogHandler) priceHistory(c *fiber.Ctx) error {
+	if err := requireAnyPermission(c, h.container, rbac.PermModelsRead); err != nil {
+		return err
+	}
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "model catalog service unavailable")
+	}
+	alias := strings.TrimSpace(c.Params("alias"))
+	changes, err := h.service.PriceHistory(c.UserContext(), alias)
+	if err != nil {
+		return writeCatalogError(c, err)
+	}
+	return c.JSON(fiber.Map{"alias": alias, "changes": changes})
+}
+
+// abStats compares the branches of an alias's traffic split. Usage spans every
+// tenant, so only super admins may read it.
+func (h *modelCatalogHandler) abStats(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	if h.container == nil || h.container.UsageService == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "usage service unavailable")
+	}
+	alias := strings.TrimSpace(c.Params("alias"))
+	if alias == "" {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "alias is required")
+	}
+	period := strings.TrimSpace(c.Query("period"))
+	if period == "" {
+		period = "7d"
+	}
+
+	stats, err := h.container.UsageService.SummarizeABVariants(c.Context(), alias, period, strings.TrimSpace(c.Query("timezone")))
+	if err != nil {
+		switch {
+		case errors.Is(err, usageservice.ErrInvalidPeriod):
+			return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+		case errors.Is(err, usageservice.ErrInvalidTimezone):
+			return httputil.WriteError(c, fiber.StatusBadRequest, "invalid timezone")
+		}
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(stats)
+}
+
+// health probes every route behind alias and reports per-route latency and
+// errors. Results are cached for 30 seconds.
+func (h *modelCatalogHandler) health(c *fiber.Ctx) error {
+	if err := requireAnyPermission(c, h.container, rbac.PermModelsHealth); err != nil {
+		return err
+	}
+	if h.container == nil || h.container.Engine == nil || h.container.HealthProbe == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "health checks unavailable")
+	}
+	alias := strings.TrimSpace(c.Params("alias"))
+	if alias == "" {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "alias is required")
+	}
+	routes, ok := h.container.Engine.ListAliases()[alias]
+	if !ok || len(routes) == 0 {
+		return httputil.WriteError(c, fiber.StatusNotFound, "model not found")
+	}
+	return c.JSON(h.container.HealthProbe.Check(c.UserContext(), alias, routes))
+}
+
+// healthHistory reports per-minute success rates for alias over the last
+// window (default 1h, at most 24h).
+func (h *modelCatalogHandler) healthHistory(c *fiber.Ctx) error {
+	if err := requireAnyPermission(c, h.container, rbac.PermModelsHealth); err != nil {
+		return err
+	}
+	if h.container == nil || h.container.Engine == nil || h.container.HealthHistory == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "health history unavailable")
+	}
+	alias := strings.TrimSpace(c.Params("alias"))
+	if alias == "" {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "alias is required")
+	}
+	window := time.Hour
+	if raw := strings.TrimSpace(c.Query("window")); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed < time.Minute || parsed > health.MaxHistoryWindow {
+			return httputil.WriteError(c, fiber.StatusBadRequest, "window must be a duration between 1m and 24h")
+		}
+		window = parsed
+	}
+	if _, ok := h.container.Engine.ListAliases()[alias]; !ok {
+		return httputil.WriteError(c, fiber.StatusNotFound, "model not found")
+	}
+	buckets, err := h.container.HealthHistory.Buckets(c.UserContext(), alias, window)
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(fiber.Map{
+		"alias":        alias,
+		"window":       window.String(),
+		"availability": h.container.Engine.Availability(alias),
+		"buckets":      buckets,
+	})
+}
+
+func (h *modelCatalogHandler) costComparison(c *fiber.Ctx) error {
+	if err := requireAnyPermission(c, h.container, rbac.PermModelsRead); err != nil {
+		return err
+	}
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "model catalog service unavailable")
+	}
+	promptTokens, err := parseTokenCount(c.Query("prompt_tokens"))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid prompt_tokens")
+	}
+	completionTokens, err := parseTokenCount(c.Query("completion_tokens"))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid completion_tokens")
+	}
+	costs, err := h.service.CostComparison(c.UserContext(), admincatalogsvc.CostComparisonParams{
+		PromptTokens:     promptTokens,
+		CompletionTokens: completionTokens,
+		Currency:         c.Query("currency"),
+	})
+	if err != nil {
+		if errors.Is(err, admincatalogsvc.ErrInvalidTokenMix) {
+			return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+		}
+		return writeCatalogError(c, err)
+	}
+	return c.JSON(costs)
+}
+
+func parseTokenCount(raw string) (int64, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(raw, 10, 64)
+}
+
 func writeCatalogError(c *fiber.Ctx, err error) error {
 	status := fiber.StatusInternalServerError
 	switch {
 	case errors.Is(err, admincatalogsvc.ErrAliasRequired),
 		errors.Is(err, admincatalogsvc.ErrProviderRequired),
 		errors.Is(err, admincatalogsvc.ErrModelRequired),
-		errors.Is(err, admincatalogsvc.ErrDeploymentRequired):
+		errors.Is(err, admincatalogsvc.ErrDeploymentRequired),
+		errors.Is(err, admincatalogsvc.ErrRoutingPolicy),
+		errors.Is(err, admincatalogsvc.ErrTrafficSplit),
+		errors.Is(err, admincatalogsvc.ErrMaxDimensions),
+		errors.Is(err, admincatalogsvc.ErrMirror),
+		errors.Is(err, admincatalogsvc.ErrFallbackVision),
+		errors.Is(err, admincatalogsvc.ErrCachedPriceRatio),
+		errors.Is(err, admincatalogsvc.ErrErrorMapping),
+		errors.Is(err, admincatalogsvc.ErrCurrency):
 		status = fiber.StatusBadRequest
+	case errors.Is(err, admincatalogsvc.ErrModelNotFound):
+		status = fiber.StatusNotFound
 	case errors.Is(err, admincatalogsvc.ErrServiceUnavailable):
 		status = fiber.StatusInternalServerError
 	}
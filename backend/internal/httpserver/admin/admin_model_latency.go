@@ -0,0 +1,76 @@
+package admin
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	"github.com/ncecere/open_model_gateway/backend/internal/rbac"
+	"github.com/ncecere/open_model_gateway/backend/internal/services/usagepipeline"
+)
+
+// latencyKeepAlive is how often an idle latency stream sends a comment, which
+// also surfaces a disconnected client as a write error.
+const latencyKeepAlive = 15 * time.Second
+
+// latencyStream relays a model's per-request latency events as server-sent
+// events until the client disconnects.
+func (h *modelCatalogHandler) latencyStream(c *fiber.Ctx) error {
+	if err := requireAnyPermission(c, h.container, rbac.PermModelsHealth); err != nil {
+		return err
+	}
+	if h.container == nil || h.container.Latency == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "latency stream unavailable")
+	}
+	alias := strings.TrimSpace(c.Params("alias"))
+	if alias == "" {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "alias is required")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sub, release, err := h.container.Latency.Subscribe(ctx, alias)
+	if err != nil {
+		cancel()
+		if errors.Is(err, usagepipeline.ErrLatencySubscribersFull) {
+			return httputil.WriteError(c, fiber.StatusTooManyRequests, err.Error())
+		}
+		return httputil.WriteError(c, fiber.StatusServiceUnavailable, err.Error())
+	}
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+
+	done := c.Context().Done()
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+		defer release()
+
+		messages := sub.Channel()
+		keepAlive := time.NewTicker(latencyKeepAlive)
+		defer keepAlive.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				fmt.Fprintf(w, "data: %s\n\n", msg.Payload)
+			case <-keepAlive.C:
+				fmt.Fprint(w, ": keep-alive\n\n")
+			}
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+	return nil
+}
@@ -0,0 +1,207 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	notificationsvc "github.com/ncecere/open_model_gateway/backend/internal/services/notifications"
+)
+
+const (
+	notificationPingInterval = 15 * time.Second
+	notificationWriteTimeout = 10 * time.Second
+)
+
+func registerAdminNotificationRoutes(router fiber.Router, container *app.Container) {
+	handler := &notificationHandler{container: container, service: container.Notifications}
+	group := router.Group("/notifications")
+	group.Get("/", handler.list)
+	group.Get("/stream", handler.upgradeStream, websocket.New(handler.stream))
+	group.Post("/read-all", handler.markAllRead)
+	group.Post("/:id/read", handler.markRead)
+}
+
+type notificationHandler struct {
+	container *app.Container
+	service   *notificationsvc.Service
+}
+
+// Notifications cover every tenant's budgets, routes, and batches, so the
+// inbox is limited to super admins.
+func (h *notificationHandler) list(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "notification service unavailable")
+	}
+
+	limit := int32(50)
+	offset := int32(0)
+	if v := strings.TrimSpace(c.Query("limit")); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			limit = int32(n)
+		}
+	}
+	if v := strings.TrimSpace(c.Query("offset")); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			offset = int32(n)
+		}
+	}
+	unreadOnly := false
+	if v := strings.TrimSpace(c.Query("unread")); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			return httputil.WriteError(c, fiber.StatusBadRequest, "unread must be true or false")
+		}
+		unreadOnly = parsed
+	}
+
+	items, err := h.service.List(c.Context(), notificationsvc.Filter{
+		UnreadOnly: unreadOnly,
+		Limit:      limit,
+		Offset:     offset,
+	})
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	unread, err := h.service.UnreadCount(c.Context())
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(fiber.Map{
+		"notifications": items,
+		"unread_count":  unread,
+		"limit":         limit,
+		"offset":        offset,
+	})
+}
+
+func (h *notificationHandler) markRead(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "notification service unavailable")
+	}
+	id, err := uuid.Parse(strings.TrimSpace(c.Params("id")))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid notification id")
+	}
+	notification, err := h.service.MarkRead(c.Context(), id)
+	if err != nil {
+		if errors.Is(err, notificationsvc.ErrNotFound) {
+			return httputil.WriteError(c, fiber.StatusNotFound, err.Error())
+		}
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(notification)
+}
+
+func (h *notificationHandler) markAllRead(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "notification service unavailable")
+	}
+	updated, err := h.service.MarkAllRead(c.Context())
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(fiber.Map{"updated": updated})
+}
+
+// upgradeStream admits super admins to the notification WebSocket. Browsers
+// authenticate the upgrade with the admin session cookie.
+func (h *notificationHandler) upgradeStream(c *fiber.Ctx) error {
+	user, ok := adminUserFromContext(c.UserContext())
+	if !ok || !user.IsSuperAdmin {
+		return requireSuperAdmin(c)
+	}
+	if !websocket.IsWebSocketUpgrade(c) {
+		return httputil.WriteError(c, fiber.StatusUpgradeRequired, "websocket upgrade required")
+	}
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "notification service unavailable")
+	}
+	return c.Next()
+}
+
+// stream pushes each new notification to the client as a JSON text frame
+// until the client disconnects.
+func (h *notificationHandler) stream(conn *websocket.Conn) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sub, err := h.service.Subscribe(ctx)
+	if err != nil {
+		closeNotificationStream(conn, websocket.CloseInternalServerErr, err.Error())
+		return
+	}
+	defer sub.Close()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	_ = conn.SetReadDeadline(time.Now().Add(2 * notificationPingInterval))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * notificationPingInterval))
+	})
+	// The read loop consumes pongs and the client's close frame, and ends
+	// the stream once the client goes away.
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	messages := sub.Channel()
+	ping := time.NewTicker(notificationPingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			closeNotificationStream(conn, websocket.CloseNormalClosure, "")
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				closeNotificationStream(conn, websocket.CloseGoingAway, "")
+				return
+			}
+			_ = conn.SetWriteDeadline(time.Now().Add(notificationWriteTimeout))
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(msg.Payload)); err != nil {
+				slog.Debug("notification stream write", slog.String("error", err.Error()))
+				closeNotificationStream(conn, websocket.CloseGoingAway, "")
+				return
+			}
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(notificationWriteTimeout)); err != nil {
+				closeNotificationStream(conn, websocket.CloseGoingAway, "")
+				return
+			}
+		}
+	}
+}
+
+// closeNotificationStream sends a close frame and unblocks the read loop
+// shortly after, since the connection is recycled once the handler returns.
+func closeNotificationStream(conn *websocket.Conn, code int, reason string) {
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(notificationWriteTimeout))
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+}
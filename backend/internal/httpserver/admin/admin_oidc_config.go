@@ -0,0 +1,93 @@
+package admin
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	adminconfigsvc "github.com/ncecere/open_model_gateway/backend/internal/services/adminconfig"
+)
+
+func registerAdminOIDCConfigRoutes(router fiber.Router, container *app.Container) {
+	handler := &oidcConfigHandler{container: container}
+	group := router.Group("/config/oidc")
+	group.Get("/", handler.get)
+	group.Put("/", handler.update)
+	group.Post("/test", handler.test)
+}
+
+type oidcConfigHandler struct {
+	container *app.Container
+}
+
+func (h *oidcConfigHandler) get(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	if h.container.AdminConfig == nil {
+		return httputil.WriteError(c, fiber.StatusNotImplemented, "settings service unavailable")
+	}
+	return c.JSON(h.container.AdminConfig.GetOIDCConfig())
+}
+
+func (h *oidcConfigHandler) update(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	if h.container.AdminConfig == nil {
+		return httputil.WriteError(c, fiber.StatusNotImplemented, "settings service unavailable")
+	}
+	adminID, ok := adminUserIDFromContext(c.UserContext())
+	if !ok {
+		return httputil.WriteError(c, fiber.StatusUnauthorized, "admin identity missing")
+	}
+	var req adminconfigsvc.OIDCSettings
+	if err := c.BodyParser(&req); err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
+	}
+
+	updated, err := h.container.AdminConfig.UpdateOIDCConfig(c.Context(), req, adminID)
+	if err != nil {
+		switch {
+		case errors.Is(err, adminconfigsvc.ErrInvalidOIDCConfig):
+			return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+		case errors.Is(err, adminconfigsvc.ErrOIDCDiscovery):
+			return httputil.WriteError(c, fiber.StatusBadGateway, err.Error())
+		default:
+			return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+		}
+	}
+	if err := recordAudit(c, h.container, "oidc_config.update", "oidc_config", "oidc", fiber.Map{
+		"enabled":   updated.Enabled,
+		"issuer":    updated.Issuer,
+		"client_id": updated.ClientID,
+	}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(updated)
+}
+
+func (h *oidcConfigHandler) test(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	if h.container.AdminConfig == nil {
+		return httputil.WriteError(c, fiber.StatusNotImplemented, "settings service unavailable")
+	}
+	req := h.container.AdminConfig.GetOIDCConfig()
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
+		}
+	}
+
+	if err := h.container.AdminConfig.TestOIDCConfig(c.Context(), req); err != nil {
+		if errors.Is(err, adminconfigsvc.ErrInvalidOIDCConfig) {
+			return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+		}
+		return c.JSON(fiber.Map{"success": false, "error": err.Error()})
+	}
+	return c.JSON(fiber.Map{"success": true})
+}
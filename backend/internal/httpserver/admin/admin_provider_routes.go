@@ -1,26 +1,39 @@
 package admin
 
 import (
+	"context"
+	"errors"
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
 
 	"github.com/ncecere/open_model_gateway/backend/internal/app"
-	"github.com/ncecere/open_model_gateway/backend/internal/db"
 	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	"github.com/ncecere/open_model_gateway/backend/internal/providers"
+	"github.com/ncecere/open_model_gateway/backend/internal/rbac"
 	adminprovidersvc "github.com/ncecere/open_model_gateway/backend/internal/services/adminprovider"
 )
 
+// credentialRotator rotates a provider instance's API key; *app.Container
+// implements it.
+type credentialRotator interface {
+	RotateProviderCredential(ctx context.Context, scope providers.CredentialScope, key string) (app.ProviderCredentialRotation, error)
+}
+
 type providerHandler struct {
 	container *app.Container
 	service   *adminprovidersvc.Service
+	rotator   credentialRotator
 }
 
 func registerAdminProviderRoutes(router fiber.Router, container *app.Container) {
-	handler := &providerHandler{container: container, service: container.AdminProviders}
+	handler := &providerHandler{container: container, service: container.AdminProviders, rotator: container}
 	router.Get("/providers", handler.list)
+	router.Post("/providers/:provider/credentials", handler.rotateCredential)
 }
 
 func (h *providerHandler) list(c *fiber.Ctx) error {
-	if err := requireAnyRole(c, h.container, db.MembershipRoleViewer); err != nil {
+	if err := requireAnyPermission(c, h.container, rbac.PermProvidersRead); err != nil {
 		return err
 	}
 	if h.service == nil {
@@ -32,3 +45,49 @@ func (h *providerHandler) list(c *fiber.Ctx) error {
 	}
 	return c.JSON(fiber.Map{"providers": defs})
 }
+
+type rotateCredentialRequest struct {
+	APIKey string `json:"api_key"`
+	// Endpoint selects the provider instance: catalog entries whose endpoint
+	// matches it. Empty selects the entries using the provider default.
+	Endpoint string `json:"endpoint"`
+}
+
+// rotateCredential swaps the API key of one provider endpoint in place. The
+// old key keeps serving until a test inference with the new one succeeds.
+func (h *providerHandler) rotateCredential(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	var req rotateCredentialRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
+	}
+	key := strings.TrimSpace(req.APIKey)
+	if key == "" {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "api_key is required")
+	}
+	scope := providers.NewCredentialScope(c.Params("provider"), req.Endpoint)
+
+	rotation, err := h.rotator.RotateProviderCredential(c.Context(), scope, key)
+	if err != nil {
+		switch {
+		case errors.Is(err, providers.ErrCredentialUnsupported), errors.Is(err, app.ErrNoProviderRoutes):
+			return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+		case errors.Is(err, app.ErrCredentialTestFailed):
+			return httputil.WriteError(c, fiber.StatusUnprocessableEntity, err.Error())
+		case errors.Is(err, app.ErrCredentialEncryptionDisabled):
+			return httputil.WriteError(c, fiber.StatusNotImplemented, err.Error())
+		}
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	if err := recordAudit(c, h.container, "provider.credential_rotated", "provider", scope.Provider, fiber.Map{
+		"endpoint": scope.Endpoint,
+		"key_hash": rotation.KeyHash,
+		"routes":   rotation.Routes,
+	}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(rotation)
+}
@@ -0,0 +1,127 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/providers"
+	adminauditsvc "github.com/ncecere/open_model_gateway/backend/internal/services/adminaudit"
+)
+
+type stubRotator struct {
+	scope providers.CredentialScope
+	key   string
+	err   error
+}
+
+func (s *stubRotator) RotateProviderCredential(_ context.Context, scope providers.CredentialScope, key string) (app.ProviderCredentialRotation, error) {
+	s.scope, s.key = scope, key
+	if s.err != nil {
+		return app.ProviderCredentialRotation{}, s.err
+	}
+	return app.ProviderCredentialRotation{Provider: scope.Provider, Endpoint: scope.Endpoint, KeyHash: providers.CredentialHash(key), Routes: 2}, nil
+}
+
+type stubAuditRecorder struct {
+	entries []db.InsertAuditLogParams
+}
+
+func (s *stubAuditRecorder) Record(_ context.Context, params db.InsertAuditLogParams) error {
+	s.entries = append(s.entries, params)
+	return nil
+}
+
+func newProviderTestApp(rotator credentialRotator, audit *stubAuditRecorder, superAdmin bool) *fiber.App {
+	container := &app.Container{AdminAudit: adminauditsvc.NewService(audit)}
+	handler := &providerHandler{container: container, rotator: rotator}
+	fiberApp := fiber.New()
+	fiberApp.Use(respondedErrors(), func(c *fiber.Ctx) error {
+		ctx := context.WithValue(c.UserContext(), adminContextUserKey, db.User{IsSuperAdmin: superAdmin})
+		ctx = context.WithValue(ctx, adminContextUserIDKey, uuid.New())
+		c.SetUserContext(ctx)
+		return c.Next()
+	})
+	fiberApp.Post("/providers/:provider/credentials", handler.rotateCredential)
+	return fiberApp
+}
+
+func postCredential(t *testing.T, fiberApp *fiber.App, body string) (int, string) {
+	t.Helper()
+	req := httptest.NewRequest("POST", "/providers/openai/credentials", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := fiberApp.Test(req)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	data, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(data)
+}
+
+func TestRotateCredentialScopesToEndpointAndAudits(t *testing.T) {
+	rotator := &stubRotator{}
+	audit := &stubAuditRecorder{}
+	fiberApp := newProviderTestApp(rotator, audit, true)
+
+	status, body := postCredential(t, fiberApp, `{"api_key":" sk-new ","endpoint":"https://proxy.example.com/v1/"}`)
+	if status != fiber.StatusOK {
+		t.Fatalf("expected 200, got %d %s", status, body)
+	}
+	if rotator.scope != (providers.CredentialScope{Provider: "openai", Endpoint: "https://proxy.example.com/v1"}) || rotator.key != "sk-new" {
+		t.Fatalf("unexpected rotation %+v %q", rotator.scope, rotator.key)
+	}
+	if strings.Contains(body, "sk-new") {
+		t.Fatalf("response must not echo the key: %s", body)
+	}
+	var rotation app.ProviderCredentialRotation
+	if err := json.Unmarshal([]byte(body), &rotation); err != nil || rotation.Endpoint != "https://proxy.example.com/v1" {
+		t.Fatalf("unexpected response %s: %v", body, err)
+	}
+	if len(audit.entries) != 1 || audit.entries[0].Action != "provider.credential_rotated" {
+		t.Fatalf("expected one audit entry, got %+v", audit.entries)
+	}
+	meta := string(audit.entries[0].Metadata)
+	if !strings.Contains(meta, `"endpoint":"https://proxy.example.com/v1"`) || strings.Contains(meta, "sk-new") {
+		t.Fatalf("unexpected audit metadata %s", meta)
+	}
+}
+
+func TestRotateCredentialErrors(t *testing.T) {
+	cases := []struct {
+		name   string
+		body   string
+		err    error
+		super  bool
+		status int
+	}{
+		{"not super admin", `{"api_key":"sk"}`, nil, false, fiber.StatusForbidden},
+		{"invalid body", `{`, nil, true, fiber.StatusBadRequest},
+		{"missing key", `{"api_key":" "}`, nil, true, fiber.StatusBadRequest},
+		{"unsupported", `{"api_key":"sk"}`, providers.ErrCredentialUnsupported, true, fiber.StatusBadRequest},
+		{"no routes", `{"api_key":"sk"}`, app.ErrNoProviderRoutes, true, fiber.StatusBadRequest},
+		{"test failed", `{"api_key":"sk"}`, fmt.Errorf("%w: gpt: 401", app.ErrCredentialTestFailed), true, fiber.StatusUnprocessableEntity},
+		{"encryption disabled", `{"api_key":"sk"}`, app.ErrCredentialEncryptionDisabled, true, fiber.StatusNotImplemented},
+		{"store failure", `{"api_key":"sk"}`, errors.New("db down"), true, fiber.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		audit := &stubAuditRecorder{}
+		fiberApp := newProviderTestApp(&stubRotator{err: tc.err}, audit, tc.super)
+		status, body := postCredential(t, fiberApp, tc.body)
+		if status != tc.status {
+			t.Errorf("%s: expected %d, got %d %s", tc.name, tc.status, status, body)
+		}
+		if len(audit.entries) != 0 {
+			t.Errorf("%s: failed rotations must not be audited", tc.name)
+		}
+	}
+}
@@ -4,8 +4,8 @@ import (
 	"github.com/gofiber/fiber/v2"
 
 	"github.com/ncecere/open_model_gateway/backend/internal/app"
-	"github.com/ncecere/open_model_gateway/backend/internal/db"
 	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	"github.com/ncecere/open_model_gateway/backend/internal/rbac"
 	adminratelimitsvc "github.com/ncecere/open_model_gateway/backend/internal/services/adminratelimit"
 )
 
@@ -25,7 +25,7 @@ func registerAdminRateLimitRoutes(router fiber.Router, container *app.Container)
 }
 
 func (h *rateLimitHandler) getDefaults(c *fiber.Ctx) error {
-	if err := requireAnyRole(c, h.container, db.MembershipRoleViewer); err != nil {
+	if err := requireAnyPermission(c, h.container, rbac.PermRateLimitsRead); err != nil {
 		return err
 	}
 	cfg := h.container.Config.RateLimits
@@ -45,7 +45,7 @@ type rateLimitDefaultsRequest struct {
 }
 
 func (h *rateLimitHandler) updateDefaults(c *fiber.Ctx) error {
-	if err := requireAnyRole(c, h.container, db.MembershipRoleAdmin); err != nil {
+	if err := requireAnyPermission(c, h.container, rbac.PermRateLimitsWrite); err != nil {
 		return err
 	}
 	if h.service == nil {
@@ -1,19 +1,46 @@
 package admin
 
 import (
+	"errors"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 
 	"github.com/ncecere/open_model_gateway/backend/internal/app"
-	"github.com/ncecere/open_model_gateway/backend/internal/db"
 	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	"github.com/ncecere/open_model_gateway/backend/internal/rbac"
 	adminrbacsvc "github.com/ncecere/open_model_gateway/backend/internal/services/adminrbac"
 )
 
-func requireTenantRole(c *fiber.Ctx, container *app.Container, tenantID uuid.UUID, role db.MembershipRole) error {
+// errResponseWritten is returned by the permission guards after they have
+// written an error response, so a handler's `return err` stops it there.
+// respondedErrors turns it back into a nil error.
+var errResponseWritten = errors.New("admin: error response already written")
+
+// deny writes an error response and returns errResponseWritten.
+func deny(c *fiber.Ctx, status int, msg string) error {
+	if err := httputil.WriteError(c, status, msg); err != nil {
+		return err
+	}
+	return errResponseWritten
+}
+
+// respondedErrors swallows errResponseWritten so the app error handler does
+// not replace the response a guard already wrote.
+func respondedErrors() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if err := c.Next(); !errors.Is(err, errResponseWritten) {
+			return err
+		}
+		return nil
+	}
+}
+
+// requireTenantPermission ensures the admin's role on the tenant grants perm.
+func requireTenantPermission(c *fiber.Ctx, container *app.Container, tenantID uuid.UUID, perm rbac.Permission) error {
 	userID, ok := adminUserIDFromContext(c.UserContext())
 	if !ok {
-		return httputil.WriteError(c, fiber.StatusUnauthorized, "missing admin context")
+		return deny(c, fiber.StatusUnauthorized, "missing admin context")
 	}
 
 	superAdmin := false
@@ -22,18 +49,20 @@ func requireTenantRole(c *fiber.Ctx, container *app.Container, tenantID uuid.UUI
 	}
 
 	if container.AdminRBAC == nil {
-		return httputil.WriteError(c, fiber.StatusInternalServerError, "rbac service unavailable")
+		return deny(c, fiber.StatusInternalServerError, "rbac service unavailable")
 	}
-	if err := container.AdminRBAC.RequireTenantRole(c.UserContext(), tenantID, userID, role, superAdmin); err != nil {
+	if err := container.AdminRBAC.RequireTenantPermission(c.UserContext(), tenantID, userID, perm, superAdmin); err != nil {
 		return mapRBACError(c, err)
 	}
 	return nil
 }
 
-func requireAnyRole(c *fiber.Ctx, container *app.Container, role db.MembershipRole) error {
+// requireAnyPermission ensures the admin's role on at least one tenant grants
+// perm.
+func requireAnyPermission(c *fiber.Ctx, container *app.Container, perm rbac.Permission) error {
 	userID, ok := adminUserIDFromContext(c.UserContext())
 	if !ok {
-		return httputil.WriteError(c, fiber.StatusUnauthorized, "missing admin context")
+		return deny(c, fiber.StatusUnauthorized, "missing admin context")
 	}
 
 	superAdmin := false
@@ -42,21 +71,32 @@ func requireAnyRole(c *fiber.Ctx, container *app.Container, role db.MembershipRo
 	}
 
 	if container.AdminRBAC == nil {
-		return httputil.WriteError(c, fiber.StatusInternalServerError, "rbac service unavailable")
+		return deny(c, fiber.StatusInternalServerError, "rbac service unavailable")
 	}
-	if err := container.AdminRBAC.RequireAnyRole(c.UserContext(), userID, role, superAdmin); err != nil {
+	if err := container.AdminRBAC.RequireAnyPermission(c.UserContext(), userID, perm, superAdmin); err != nil {
 		return mapRBACError(c, err)
 	}
 	return nil
 }
 
+func requireSuperAdmin(c *fiber.Ctx) error {
+	user, ok := adminUserFromContext(c.UserContext())
+	if !ok {
+		return deny(c, fiber.StatusUnauthorized, "missing admin context")
+	}
+	if !user.IsSuperAdmin {
+		return deny(c, fiber.StatusForbidden, adminrbacsvc.ErrForbidden.Error())
+	}
+	return nil
+}
+
 func mapRBACError(c *fiber.Ctx, err error) error {
 	switch {
 	case err == adminrbacsvc.ErrUnauthorized:
-		return httputil.WriteError(c, fiber.StatusUnauthorized, err.Error())
+		return deny(c, fiber.StatusUnauthorized, err.Error())
 	case err == adminrbacsvc.ErrForbidden:
-		return httputil.WriteError(c, fiber.StatusForbidden, err.Error())
+		return deny(c, fiber.StatusForbidden, err.Error())
 	default:
-		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+		return deny(c, fiber.StatusInternalServerError, err.Error())
 	}
 }
@@ -0,0 +1,233 @@
+package admin
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	"github.com/ncecere/open_model_gateway/backend/internal/rbac"
+	adminrbacsvc "github.com/ncecere/open_model_gateway/backend/internal/services/adminrbac"
+)
+
+func registerAdminRBACRoutes(router fiber.Router, container *app.Container) {
+	handler := &rbacRoleHandler{container: container}
+	group := router.Group("/rbac")
+	group.Get("/roles", handler.list)
+	group.Post("/roles", handler.create)
+	group.Put("/roles/:name", handler.update)
+	group.Delete("/roles/:name", handler.delete)
+	group.Put("/tenants/:tenantID/members/:userID/role", handler.assign)
+	group.Delete("/tenants/:tenantID/members/:userID/role", handler.unassign)
+}
+
+type rbacRoleHandler struct {
+	container *app.Container
+}
+
+type rbacRoleRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+type rbacRoleResponse struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Permissions []rbac.Permission `json:"permissions"`
+	Builtin     bool              `json:"builtin"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+type rbacPermissionResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func (h *rbacRoleHandler) list(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	if h.container.AdminRBAC == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "rbac service unavailable")
+	}
+	roles, err := h.container.AdminRBAC.ListRoles(c.Context())
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	perms, err := h.container.AdminRBAC.ListPermissions(c.Context())
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	roleResp := make([]rbacRoleResponse, 0, len(roles))
+	for _, role := range roles {
+		roleResp = append(roleResp, toRBACRoleResponse(role))
+	}
+	permResp := make([]rbacPermissionResponse, 0, len(perms))
+	for _, perm := range perms {
+		permResp = append(permResp, rbacPermissionResponse{Name: perm.Name, Description: perm.Description})
+	}
+	return c.JSON(fiber.Map{"roles": roleResp, "permissions": permResp})
+}
+
+func (h *rbacRoleHandler) create(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	if h.container.AdminRBAC == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "rbac service unavailable")
+	}
+	var req rbacRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
+	}
+	role, err := h.container.AdminRBAC.CreateRole(c.Context(), adminrbacsvc.RoleInput{
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: req.Permissions,
+	})
+	if err != nil {
+		return writeRBACRoleError(c, err)
+	}
+	if err := recordAudit(c, h.container, "rbac_role.create", "rbac_role", role.Name, fiber.Map{
+		"permissions": role.Permissions,
+	}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.Status(fiber.StatusCreated).JSON(toRBACRoleResponse(role))
+}
+
+func (h *rbacRoleHandler) update(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	if h.container.AdminRBAC == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "rbac service unavailable")
+	}
+	var req rbacRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
+	}
+	role, err := h.container.AdminRBAC.UpdateRole(c.Context(), c.Params("name"), adminrbacsvc.RoleInput{
+		Description: req.Description,
+		Permissions: req.Permissions,
+	})
+	if err != nil {
+		return writeRBACRoleError(c, err)
+	}
+	if err := recordAudit(c, h.container, "rbac_role.update", "rbac_role", role.Name, fiber.Map{
+		"permissions": role.Permissions,
+	}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(toRBACRoleResponse(role))
+}
+
+func (h *rbacRoleHandler) delete(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	if h.container.AdminRBAC == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "rbac service unavailable")
+	}
+	name := strings.ToLower(strings.TrimSpace(c.Params("name")))
+	if err := h.container.AdminRBAC.DeleteRole(c.Context(), name); err != nil {
+		return writeRBACRoleError(c, err)
+	}
+	if err := recordAudit(c, h.container, "rbac_role.delete", "rbac_role", name, nil); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *rbacRoleHandler) assign(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	if h.container.AdminRBAC == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "rbac service unavailable")
+	}
+	tenantID, userID, err := parseRBACMember(c)
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+	}
+	var req struct {
+		Role string `json:"role"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
+	}
+	if err := h.container.AdminRBAC.AssignTenantRole(c.Context(), tenantID, userID, req.Role); err != nil {
+		return writeRBACRoleError(c, err)
+	}
+	if err := recordAudit(c, h.container, "rbac_role.assign", "tenant_membership", userID.String(), fiber.Map{
+		"tenant_id": tenantID.String(),
+		"role":      strings.ToLower(strings.TrimSpace(req.Role)),
+	}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *rbacRoleHandler) unassign(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	if h.container.AdminRBAC == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "rbac service unavailable")
+	}
+	tenantID, userID, err := parseRBACMember(c)
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+	}
+	if err := h.container.AdminRBAC.ClearTenantRole(c.Context(), tenantID, userID); err != nil {
+		return writeRBACRoleError(c, err)
+	}
+	if err := recordAudit(c, h.container, "rbac_role.unassign", "tenant_membership", userID.String(), fiber.Map{
+		"tenant_id": tenantID.String(),
+	}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func parseRBACMember(c *fiber.Ctx) (uuid.UUID, uuid.UUID, error) {
+	tenantID, err := uuid.Parse(strings.TrimSpace(c.Params("tenantID")))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errors.New("invalid tenant id")
+	}
+	userID, err := uuid.Parse(strings.TrimSpace(c.Params("userID")))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errors.New("invalid user id")
+	}
+	return tenantID, userID, nil
+}
+
+func writeRBACRoleError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, adminrbacsvc.ErrInvalidRole), errors.Is(err, adminrbacsvc.ErrBuiltinRole):
+		return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+	case errors.Is(err, adminrbacsvc.ErrRoleNotFound), errors.Is(err, adminrbacsvc.ErrMembershipNotFound):
+		return httputil.WriteError(c, fiber.StatusNotFound, err.Error())
+	case errors.Is(err, adminrbacsvc.ErrRoleExists), errors.Is(err, adminrbacsvc.ErrRoleInUse):
+		return httputil.WriteError(c, fiber.StatusConflict, err.Error())
+	default:
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+}
+
+func toRBACRoleResponse(role adminrbacsvc.Role) rbacRoleResponse {
+	return rbacRoleResponse{
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: role.Permissions,
+		Builtin:     role.Builtin,
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
+	}
+}
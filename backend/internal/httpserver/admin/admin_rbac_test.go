@@ -0,0 +1,160 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/auth"
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/rbac"
+	adminauditsvc "github.com/ncecere/open_model_gateway/backend/internal/services/adminaudit"
+)
+
+func TestPermissionGuardsStopTheHandler(t *testing.T) {
+	cases := []struct {
+		name   string
+		user   *db.User
+		guard  func(c *fiber.Ctx) error
+		status int
+	}{
+		{"super admin allowed", &db.User{IsSuperAdmin: true}, requireSuperAdmin, fiber.StatusOK},
+		{"not super admin", &db.User{}, requireSuperAdmin, fiber.StatusForbidden},
+		{"missing admin context", nil, requireSuperAdmin, fiber.StatusUnauthorized},
+		{"rbac unavailable", &db.User{}, func(c *fiber.Ctx) error {
+			return requireAnyPermission(c, &app.Container{}, rbac.PermProvidersRead)
+		}, fiber.StatusInternalServerError},
+		{"tenant rbac unavailable", &db.User{}, func(c *fiber.Ctx) error {
+			return requireTenantPermission(c, &app.Container{}, uuid.New(), rbac.PermProvidersRead)
+		}, fiber.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		ran := false
+		fiberApp := fiber.New()
+		fiberApp.Use(respondedErrors(), func(c *fiber.Ctx) error {
+			if tc.user != nil {
+				ctx := context.WithValue(c.UserContext(), adminContextUserKey, *tc.user)
+				ctx = context.WithValue(ctx, adminContextUserIDKey, uuid.New())
+				c.SetUserContext(ctx)
+			}
+			return c.Next()
+		})
+		fiberApp.Get("/", func(c *fiber.Ctx) error {
+			if err := tc.guard(c); err != nil {
+				return err
+			}
+			ran = true
+			return c.SendStatus(fiber.StatusOK)
+		})
+
+		resp, err := fiberApp.Test(httptest.NewRequest("GET", "/", nil))
+		if err != nil {
+			t.Fatalf("%s: request: %v", tc.name, err)
+		}
+		if resp.StatusCode != tc.status {
+			t.Errorf("%s: expected %d, got %d", tc.name, tc.status, resp.StatusCode)
+		}
+		if ran != (tc.status == fiber.StatusOK) {
+			t.Errorf("%s: handler ran = %v", tc.name, ran)
+		}
+		if tc.status != fiber.StatusOK && resp.Header.Get(fiber.HeaderContentType) != fiber.MIMEApplicationJSON {
+			t.Errorf("%s: expected the guard's JSON error to be kept, got %q", tc.name, resp.Header.Get(fiber.HeaderContentType))
+		}
+	}
+}
+
+// adminUserDB answers the admin middleware's user lookup and records every
+// other statement so a test can assert that a denied request wrote nothing.
+type adminUserDB struct {
+	userID     uuid.UUID
+	statements []string
+}
+
+func (d *adminUserDB) Exec(_ context.Context, sql string, _ ...interface{}) (pgconn.CommandTag, error) {
+	d.statements = append(d.statements, sql)
+	return pgconn.CommandTag{}, nil
+}
+
+func (d *adminUserDB) Query(_ context.Context, sql string, _ ...interface{}) (pgx.Rows, error) {
+	d.statements = append(d.statements, sql)
+	return nil, errors.New("unexpected query")
+}
+
+func (d *adminUserDB) QueryRow(_ context.Context, sql string, _ ...interface{}) pgx.Row {
+	if strings.Contains(sql, "name: GetUserByID") {
+		return adminUserRow{id: d.userID}
+	}
+	d.statements = append(d.statements, sql)
+	return adminUserRow{err: errors.New("unexpected query")}
+}
+
+type adminUserRow struct {
+	id  uuid.UUID
+	err error
+}
+
+func (r adminUserRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	*dest[0].(*pgtype.UUID) = pgtype.UUID{Bytes: r.id, Valid: true}
+	return nil
+}
+
+func TestAdminRoutesRejectUnderPrivilegedUsers(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Admin.Session = config.AdminSessionConfig{
+		JWTSecret:       "test-secret",
+		AccessTokenTTL:  time.Minute,
+		RefreshTokenTTL: time.Hour,
+		CookieName:      "admin_session",
+	}
+	store := &adminUserDB{userID: uuid.New()}
+	queries := db.New(store)
+	adminAuth, err := auth.NewAdminAuthService(context.Background(), cfg.Admin, queries, nil)
+	if err != nil {
+		t.Fatalf("admin auth: %v", err)
+	}
+	audit := &stubAuditRecorder{}
+	container := &app.Container{
+		Config:     cfg,
+		Queries:    queries,
+		AdminAuth:  adminAuth,
+		AdminAudit: adminauditsvc.NewService(audit),
+	}
+	fiberApp := fiber.New()
+	Register(fiberApp, container)
+
+	tokens, err := adminAuth.IssueTokenPair(db.User{ID: pgtype.UUID{Bytes: store.userID, Valid: true}, Email: "viewer@example.com"})
+	if err != nil {
+		t.Fatalf("issue token: %v", err)
+	}
+	req := httptest.NewRequest("POST", "/admin/providers/openai/credentials", strings.NewReader(`{"api_key":"sk-new"}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	req.Header.Set(fiber.HeaderAuthorization, "Bearer "+tokens.AccessToken)
+
+	resp, err := fiberApp.Test(req)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusForbidden {
+		t.Fatalf("expected 403, got %d", resp.StatusCode)
+	}
+	if len(audit.entries) != 0 {
+		t.Fatalf("expected no audit entries, got %d", len(audit.entries))
+	}
+	if len(store.statements) != 0 {
+		t.Fatalf("expected no statements after the denial, got %v", store.statements)
+	}
+}
@@ -0,0 +1,150 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/executor"
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	"github.com/ncecere/open_model_gateway/backend/internal/rbac"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+	usagepipeline "github.com/ncecere/open_model_gateway/backend/internal/services/usagepipeline"
+)
+
+func registerAdminRequestRoutes(router fiber.Router, container *app.Container) {
+	handler := &requestHandler{container: container, executor: executor.New(container)}
+	group := router.Group("/requests")
+	group.Get("/:requestID/payload", handler.payload)
+	group.Post("/:requestID/replay", handler.replay)
+}
+
+type requestHandler struct {
+	container *app.Container
+	executor  *executor.Executor
+}
+
+type requestPayloadResponse struct {
+	RequestID string    `json:"request_id"`
+	TenantID  string    `json:"tenant_id"`
+	Request   any       `json:"request"`
+	Response  any       `json:"response"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (h *requestHandler) payload(c *fiber.Ctx) error {
+	requestID, err := uuid.Parse(strings.TrimSpace(c.Params("requestID")))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request id")
+	}
+	payload, err := h.container.UsageLogger.GetPayload(c.Context(), requestID)
+	if err != nil {
+		if errors.Is(err, usagepipeline.ErrPayloadNotFound) {
+			return httputil.WriteError(c, fiber.StatusNotFound, err.Error())
+		}
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	if err := requireTenantPermission(c, h.container, payload.TenantID, rbac.PermRequestsRead); err != nil {
+		return err
+	}
+	return c.JSON(requestPayloadResponse{
+		RequestID: payload.RequestID.String(),
+		TenantID:  payload.TenantID.String(),
+		Request:   payloadBody(payload.RequestBody),
+		Response:  payloadBody(payload.ResponseBody),
+		CreatedAt: payload.CreatedAt,
+	})
+}
+
+// replay re-executes a stored chat request through the executor with the
+// original API key, so budgets, rate limits, and usage apply as they would for
+// a live call. Idempotency caching is skipped and a fresh trace id is issued.
+func (h *requestHandler) replay(c *fiber.Ctx) error {
+	requestID, err := uuid.Parse(strings.TrimSpace(c.Params("requestID")))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request id")
+	}
+	ctx := c.UserContext()
+	original, err := h.container.UsageLogger.GetRequest(ctx, requestID)
+	if err != nil {
+		switch {
+		case errors.Is(err, usagepipeline.ErrRequestNotFound), errors.Is(err, usagepipeline.ErrPayloadNotFound):
+			return httputil.WriteError(c, fiber.StatusNotFound, err.Error())
+		case errors.Is(err, usagepipeline.ErrRequestNotReplayable):
+			return httputil.WriteError(c, fiber.StatusUnprocessableEntity, err.Error())
+		default:
+			return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+		}
+	}
+	if err := requireTenantPermission(c, h.container, original.TenantID, rbac.PermRequestsReplay); err != nil {
+		return err
+	}
+
+	if original.APIKeyID == uuid.Nil {
+		return httputil.WriteError(c, fiber.StatusConflict, "original api key no longer exists")
+	}
+	keyRow, err := h.container.Queries.GetAPIKeyByID(ctx, toPgUUID(original.APIKeyID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return httputil.WriteError(c, fiber.StatusConflict, "original api key no longer exists")
+		}
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	if keyRow.RevokedAt.Valid {
+		return httputil.WriteError(c, fiber.StatusConflict, "original api key has been revoked")
+	}
+	if keyRow.SuspendedAt.Valid {
+		return httputil.WriteError(c, fiber.StatusConflict, "original api key is suspended")
+	}
+	if len(h.container.Engine.SelectRoutes(original.Alias)) == 0 {
+		return httputil.WriteError(c, fiber.StatusConflict, "model alias is no longer available")
+	}
+	if !h.container.IsModelAllowed(original.TenantID, original.Alias) {
+		return httputil.WriteError(c, fiber.StatusForbidden, "model not enabled for tenant")
+	}
+
+	rc, err := app.BuildRequestContext(ctx, h.container, keyRow)
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	traceID := uuid.NewString()
+	req := original.Request
+	req.Stream = false
+
+	c.Set("X-Replay-Original-ID", requestID.String())
+	result, err := h.executor.Chat(requestctx.WithContext(ctx, rc), rc, original.Alias, req, traceID, "")
+	if err != nil {
+		if status, msg, ok := executor.AsAPIError(err); ok {
+			return httputil.WriteError(c, status, msg)
+		}
+		return httputil.WriteError(c, fiber.StatusBadGateway, err.Error())
+	}
+
+	if err := recordAudit(c, h.container, "request.replay", "request", requestID.String(), fiber.Map{
+		"alias":    original.Alias,
+		"trace_id": traceID,
+	}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(fiber.Map{
+		"trace_id": traceID,
+		"response": result.Response,
+	})
+}
+
+// payloadBody embeds JSON bodies as-is and falls back to a string otherwise.
+func payloadBody(body []byte) any {
+	if len(body) == 0 {
+		return nil
+	}
+	if json.Valid(body) {
+		return json.RawMessage(body)
+	}
+	return string(body)
+}
@@ -25,6 +25,7 @@ const (
 	oidcStatePrefix       = "oidc:state:"
 	oidcStateTTL          = 10 * time.Minute
 	defaultOIDCReturnPath = "/admin/ui/auth/oidc/callback"
+	samlStatePrefix       = "saml:state:"
 )
 
 type oidcStateData struct {
@@ -32,6 +33,11 @@ type oidcStateData struct {
 	ReturnTo string `json:"return_to"`
 }
 
+type samlStateData struct {
+	RequestID string `json:"request_id"`
+	ReturnTo  string `json:"return_to"`
+}
+
 func registerAdminAuthRoutes(router fiber.Router, container *app.Container) {
 	handler := &adminAuthHandler{
 		authService: container.AdminAuth,
@@ -46,6 +52,8 @@ func registerAdminAuthRoutes(router fiber.Router, container *app.Container) {
 	router.Post("/logout", handler.logout)
 	router.Get("/oidc/start", handler.oidcStart)
 	router.Get("/oidc/callback", handler.oidcCallback)
+	router.Post("/saml/initiate", handler.samlInitiate)
+	router.Post("/saml/callback", handler.samlCallback)
 }
 
 type adminAuthHandler struct {
@@ -256,6 +264,86 @@ func (h *adminAuthHandler) oidcCallback(c *fiber.Ctx) error {
 	return redirectOIDC(c, stateData.ReturnTo, nil)
 }
 
+func (h *adminAuthHandler) samlInitiate(c *fiber.Ctx) error {
+	if !h.cfg.SAML.Enabled {
+		return httputil.WriteError(c, fiber.StatusNotFound, "saml disabled")
+	}
+
+	returnTo := c.FormValue("return_to")
+	if returnTo == "" {
+		returnTo = c.Query("return_to")
+	}
+	returnTo = sanitizeReturnPath(returnTo)
+
+	relayState, err := auth.GenerateState(32)
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	authReq, err := h.authService.StartSAMLAuth(relayState)
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	ctx := userContext(c)
+	payload, err := json.Marshal(samlStateData{
+		RequestID: authReq.ID,
+		ReturnTo:  returnTo,
+	})
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "failed to encode saml state")
+	}
+	if err := h.redis.Set(ctx, samlStateKey(relayState), payload, oidcStateTTL).Err(); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "failed to persist saml state")
+	}
+
+	return c.Redirect(authReq.RedirectURL, fiber.StatusSeeOther)
+}
+
+func (h *adminAuthHandler) samlCallback(c *fiber.Ctx) error {
+	if !h.cfg.SAML.Enabled {
+		return httputil.WriteError(c, fiber.StatusNotFound, "saml disabled")
+	}
+
+	relayState := c.FormValue("RelayState")
+	samlResponse := c.FormValue("SAMLResponse")
+	if relayState == "" || samlResponse == "" {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "SAMLResponse and RelayState required")
+	}
+
+	ctx := userContext(c)
+	key := samlStateKey(relayState)
+	rawState, err := h.redis.Get(ctx, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return redirectSAML(c, "", fmt.Errorf("invalid or expired state"))
+		}
+		return redirectSAML(c, "", fmt.Errorf("failed to validate saml state: %w", err))
+	}
+	_ = h.redis.Del(ctx, key).Err()
+
+	stateData := samlStateData{ReturnTo: defaultOIDCReturnPath}
+	if err := json.Unmarshal(rawState, &stateData); err != nil {
+		return redirectSAML(c, "", fmt.Errorf("invalid saml state payload"))
+	}
+
+	assertion, err := h.authService.ParseSAMLResponse(samlResponse, []string{stateData.RequestID})
+	if err != nil {
+		return redirectSAML(c, stateData.ReturnTo, err)
+	}
+	user, err := h.authService.LoginSAML(ctx, assertion)
+	if err != nil {
+		return redirectSAML(c, stateData.ReturnTo, err)
+	}
+	pair, err := h.authService.IssueTokenPair(user)
+	if err != nil {
+		return redirectSAML(c, stateData.ReturnTo, err)
+	}
+
+	h.setRefreshCookie(c, pair)
+
+	return redirectSAML(c, stateData.ReturnTo, nil)
+}
+
 func (h *adminAuthHandler) setRefreshCookie(c *fiber.Ctx, pair *auth.TokenPair) {
 	secure := strings.EqualFold(c.Protocol(), "https")
 
@@ -362,6 +450,10 @@ func oidcStateKey(state string) string {
 	return fmt.Sprintf("%s%s", oidcStatePrefix, state)
 }
 
+func samlStateKey(state string) string {
+	return fmt.Sprintf("%s%s", samlStatePrefix, state)
+}
+
 func sanitizeReturnPath(path string) string {
 	path = strings.TrimSpace(path)
 	if path == "" {
@@ -383,13 +475,23 @@ func sanitizeReturnPath(path string) string {
 }
 
 func redirectOIDC(c *fiber.Ctx, path string, err error) error {
+	return c.Redirect(authResultTarget(path, err), fiber.StatusTemporaryRedirect)
+}
+
+// redirectSAML answers the IdP's form POST with 303 so the browser follows up
+// with a GET instead of replaying the assertion against the UI route.
+func redirectSAML(c *fiber.Ctx, path string, err error) error {
+	return c.Redirect(authResultTarget(path, err), fiber.StatusSeeOther)
+}
+
+func authResultTarget(path string, err error) string {
 	target := sanitizeReturnPath(path)
 	if err != nil {
 		target = appendQueryParam(target, "error", err.Error())
 	} else {
 		target = appendQueryParam(target, "status", "success")
 	}
-	return c.Redirect(target, fiber.StatusTemporaryRedirect)
+	return target
 }
 
 func appendQueryParam(path string, key, value string) string {
@@ -9,8 +9,8 @@ import (
 
 	"github.com/ncecere/open_model_gateway/backend/internal/app"
 	"github.com/ncecere/open_model_gateway/backend/internal/config"
-	"github.com/ncecere/open_model_gateway/backend/internal/db"
 	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	"github.com/ncecere/open_model_gateway/backend/internal/rbac"
 	adminconfigsvc "github.com/ncecere/open_model_gateway/backend/internal/services/adminconfig"
 )
 
@@ -31,7 +31,7 @@ type settingsHandler struct {
 }
 
 func (h *settingsHandler) getFileSettings(c *fiber.Ctx) error {
-	if err := requireAnyRole(c, h.container, db.MembershipRoleAdmin); err != nil {
+	if err := requireAnyPermission(c, h.container, rbac.PermSettingsRead); err != nil {
 		return err
 	}
 	svc := h.service()
@@ -43,7 +43,7 @@ func (h *settingsHandler) getFileSettings(c *fiber.Ctx) error {
 }
 
 func (h *settingsHandler) updateFileSettings(c *fiber.Ctx) error {
-	if err := requireAnyRole(c, h.container, db.MembershipRoleAdmin); err != nil {
+	if err := requireAnyPermission(c, h.container, rbac.PermSettingsWrite); err != nil {
 		return err
 	}
 	svc := h.service()
@@ -80,7 +80,7 @@ func (h *settingsHandler) updateFileSettings(c *fiber.Ctx) error {
 }
 
 func (h *settingsHandler) getBatchSettings(c *fiber.Ctx) error {
-	if err := requireAnyRole(c, h.container, db.MembershipRoleAdmin); err != nil {
+	if err := requireAnyPermission(c, h.container, rbac.PermSettingsRead); err != nil {
 		return err
 	}
 	svc := h.service()
@@ -92,7 +92,7 @@ func (h *settingsHandler) getBatchSettings(c *fiber.Ctx) error {
 }
 
 func (h *settingsHandler) updateBatchSettings(c *fiber.Ctx) error {
-	if err := requireAnyRole(c, h.container, db.MembershipRoleAdmin); err != nil {
+	if err := requireAnyPermission(c, h.container, rbac.PermSettingsWrite); err != nil {
 		return err
 	}
 	svc := h.service()
@@ -131,7 +131,7 @@ func (h *settingsHandler) updateBatchSettings(c *fiber.Ctx) error {
 }
 
 func (h *settingsHandler) getAlertSettings(c *fiber.Ctx) error {
-	if err := requireAnyRole(c, h.container, db.MembershipRoleAdmin); err != nil {
+	if err := requireAnyPermission(c, h.container, rbac.PermSettingsRead); err != nil {
 		return err
 	}
 	svc := h.service()
@@ -143,7 +143,7 @@ func (h *settingsHandler) getAlertSettings(c *fiber.Ctx) error {
 }
 
 func (h *settingsHandler) updateAlertSettings(c *fiber.Ctx) error {
-	if err := requireAnyRole(c, h.container, db.MembershipRoleAdmin); err != nil {
+	if err := requireAnyPermission(c, h.container, rbac.PermSettingsWrite); err != nil {
 		return err
 	}
 	svc := h.service()
@@ -179,7 +179,7 @@ func (h *settingsHandler) updateAlertSettings(c *fiber.Ctx) error {
 }
 
 func (h *settingsHandler) sendTestAlertEmail(c *fiber.Ctx) error {
-	if err := requireAnyRole(c, h.container, db.MembershipRoleAdmin); err != nil {
+	if err := requireAnyPermission(c, h.container, rbac.PermSettingsWrite); err != nil {
 		return err
 	}
 	svc := h.service()
@@ -0,0 +1,64 @@
+package admin
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	"github.com/ncecere/open_model_gateway/backend/internal/rbac"
+)
+
+type cloneTenantRequest struct {
+	Name string `json:"name"`
+}
+
+// cloneTenant copies a tenant's configuration into a new active tenant, for
+// promoting a staging tenant's setup to production. API keys are not copied.
+func (h *tenantHandler) cloneTenant(c *fiber.Ctx) error {
+	sourceID, err := uuid.Parse(strings.TrimSpace(c.Params("tenantID")))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid tenant id")
+	}
+	if err := requireAnyPermission(c, h.container, rbac.PermTenantsCreate); err != nil {
+		return err
+	}
+	if err := requireTenantPermission(c, h.container, sourceID, rbac.PermTenantsRead); err != nil {
+		return err
+	}
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
+	}
+
+	var req cloneTenantRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
+	}
+	record, err := h.service.Clone(c.Context(), sourceID, req.Name)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return httputil.WriteError(c, fiber.StatusBadRequest, "tenant name already exists")
+		}
+		return writeTenantServiceError(c, err)
+	}
+
+	tenantID := uuid.UUID(record.ID.Bytes)
+	if err := recordAudit(c, h.container, "tenant.cloned", "tenant", tenantID.String(), fiber.Map{
+		"source_tenant_id": sourceID.String(),
+		"name":             record.Name,
+	}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"id":               tenantID.String(),
+		"name":             record.Name,
+		"status":           string(record.Status),
+		"cost_center":      record.CostCenter,
+		"created_at":       record.CreatedAt.Time,
+		"source_tenant_id": sourceID.String(),
+	})
+}
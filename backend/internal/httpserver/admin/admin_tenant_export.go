@@ -0,0 +1,141 @@
+package admin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	admintenantsvc "github.com/ncecere/open_model_gateway/backend/internal/services/admintenant"
+)
+
+type tenantExportResponse struct {
+	JobID             string     `json:"job_id"`
+	TenantID          string     `json:"tenant_id"`
+	Status            string     `json:"status"`
+	SectionsCompleted int32      `json:"sections_completed"`
+	SectionsTotal     int32      `json:"sections_total"`
+	RowsExported      int64      `json:"rows_exported"`
+	Bytes             int64      `json:"bytes,omitempty"`
+	DownloadURL       *string    `json:"download_url,omitempty"`
+	Error             string     `json:"error,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	StartedAt         *time.Time `json:"started_at,omitempty"`
+	CompletedAt       *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"`
+}
+
+// exportTenant queues a ZIP export of the tenant's data. Poll getTenantExport
+// for progress; the finished archive is served by downloadTenantExport.
+func (h *tenantHandler) exportTenant(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	tenantID, err := uuid.Parse(strings.TrimSpace(c.Params("tenantID")))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid tenant id")
+	}
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
+	}
+	jobID, err := h.service.ExportTenant(c.Context(), tenantID)
+	if err != nil {
+		return writeTenantServiceError(c, err)
+	}
+	if err := recordAudit(c, h.container, "tenant.export", "tenant", tenantID.String(), fiber.Map{
+		"job_id": jobID.String(),
+	}); err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"job_id":    jobID.String(),
+		"tenant_id": tenantID.String(),
+		"status":    admintenantsvc.ExportStatusPending,
+	})
+}
+
+func (h *tenantHandler) getTenantExport(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	tenantID, err := uuid.Parse(strings.TrimSpace(c.Params("tenantID")))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid tenant id")
+	}
+	jobID, err := uuid.Parse(strings.TrimSpace(c.Params("jobID")))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid job id")
+	}
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
+	}
+	job, err := h.service.GetExportJob(c.Context(), tenantID, jobID)
+	if err != nil {
+		return writeTenantServiceError(c, err)
+	}
+	return c.JSON(toTenantExportResponse(job))
+}
+
+// downloadTenantExport streams a completed export archive. Exports are kept
+// outside the tenant's files, so this super-admin route is the only way to
+// fetch one.
+func (h *tenantHandler) downloadTenantExport(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	tenantID, err := uuid.Parse(strings.TrimSpace(c.Params("tenantID")))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid tenant id")
+	}
+	jobID, err := uuid.Parse(strings.TrimSpace(c.Params("jobID")))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid job id")
+	}
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
+	}
+	reader, job, err := h.service.OpenExport(c.UserContext(), tenantID, jobID)
+	if err != nil {
+		return writeTenantServiceError(c, err)
+	}
+	if err := recordAudit(c, h.container, "tenant.export_download", "tenant", tenantID.String(), fiber.Map{
+		"job_id": jobID.String(),
+	}); err != nil {
+		reader.Close()
+		return err
+	}
+	filename := fmt.Sprintf("tenant-export-%s-%s.zip", tenantID, job.CreatedAt.UTC().Format("20060102"))
+	c.Set(fiber.HeaderContentType, "application/zip")
+	if job.Bytes > 0 {
+		c.Set(fiber.HeaderContentLength, strconv.FormatInt(job.Bytes, 10))
+	}
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.SendStream(reader)
+}
+
+func toTenantExportResponse(job admintenantsvc.ExportJob) tenantExportResponse {
+	resp := tenantExportResponse{
+		JobID:             job.ID.String(),
+		TenantID:          job.TenantID.String(),
+		Status:            job.Status,
+		SectionsCompleted: job.SectionsCompleted,
+		SectionsTotal:     job.SectionsTotal,
+		RowsExported:      job.RowsExported,
+		Bytes:             job.Bytes,
+		Error:             job.Error,
+		CreatedAt:         job.CreatedAt,
+		StartedAt:         job.StartedAt,
+		CompletedAt:       job.CompletedAt,
+		ExpiresAt:         job.ExpiresAt,
+	}
+	if job.Status == admintenantsvc.ExportStatusCompleted && job.Downloadable {
+		url := "/admin/tenants/" + job.TenantID.String() + "/export/" + job.ID.String() + "/download"
+		resp.DownloadURL = &url
+	}
+	return resp
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
 	"github.com/ncecere/open_model_gateway/backend/internal/limits"
 	"github.com/ncecere/open_model_gateway/backend/internal/rbac"
+	"github.com/ncecere/open_model_gateway/backend/internal/schemavalidation"
 	adminbudgetsvc "github.com/ncecere/open_model_gateway/backend/internal/services/adminbudget"
 	admintenantsvc "github.com/ncecere/open_model_gateway/backend/internal/services/admintenant"
 )
@@ -31,28 +32,53 @@ func registerAdminTenantRoutes(router fiber.Router, container *app.Container) {
 	group.Get("/", handler.list)
 	group.Get("/personal", handler.listPersonal)
 	group.Post("/", handler.create)
+	group.Post("/bulk/suspend", handler.bulkSuspend)
+	group.Post("/bulk/activate", handler.bulkActivate)
 	group.Patch("/:tenantID", handler.updateDetails)
 	group.Patch("/:tenantID/status", handler.updateStatus)
 	group.Get("/:tenantID/budget", handler.getBudget)
 	group.Put("/:tenantID/budget", handler.upsertBudget)
+	group.Patch("/:tenantID/budget", handler.patchBudget)
 	group.Delete("/:tenantID/budget", handler.deleteBudget)
 	group.Get("/:tenantID/rate-limits", handler.getRateLimits)
 	group.Put("/:tenantID/rate-limits", handler.upsertRateLimits)
 	group.Delete("/:tenantID/rate-limits", handler.deleteRateLimits)
+	group.Get("/:tenantID/quota", handler.getQuota)
+	group.Put("/:tenantID/quota", handler.upsertQuota)
+	group.Delete("/:tenantID/quota", handler.deleteQuota)
+	group.Get("/:tenantID/settings", handler.getSettings)
+	group.Put("/:tenantID/settings", handler.updateSettings)
+	group.Get("/:tenantID/system-prompt", handler.getSystemPrompt)
+	group.Put("/:tenantID/system-prompt", handler.upsertSystemPrompt)
+	group.Delete("/:tenantID/system-prompt", handler.deleteSystemPrompt)
+	group.Get("/:tenantID/parent", handler.getParent)
+	group.Put("/:tenantID/parent", handler.setParent)
+	group.Delete("/:tenantID/parent", handler.deleteParent)
+	group.Get("/:tenantID/model-overrides", handler.listModelOverrides)
+	group.Put("/:tenantID/model-overrides/:alias", handler.upsertModelOverride)
+	group.Delete("/:tenantID/model-overrides/:alias", handler.deleteModelOverride)
 	group.Get("/:tenantID/models", handler.getTenantModels)
 	group.Put("/:tenantID/models", handler.upsertTenantModels)
 	group.Delete("/:tenantID/models", handler.deleteTenantModels)
 	group.Get("/:tenantID/api-keys", handler.listAPIKeys)
 	group.Post("/:tenantID/api-keys", handler.createAPIKey)
 	group.Delete("/:tenantID/api-keys/:apiKeyID", handler.revokeAPIKey)
+	group.Post("/:tenantID/api-keys/:apiKeyID/reinstate", handler.reinstateAPIKey)
 	group.Get("/:tenantID/memberships", handler.listMemberships)
 	group.Post("/:tenantID/memberships", handler.upsertMembership)
 	group.Delete("/:tenantID/memberships/:userID", handler.removeMembership)
+	group.Post("/:tenantID/memberships/invite", handler.createInvitation)
+	group.Get("/:tenantID/memberships/invitations", handler.listInvitations)
+	group.Delete("/:tenantID/memberships/invitations/:invitationID", handler.revokeInvitation)
 	group.Get("/:tenantID/batches", handler.listBatches)
 	group.Get("/:tenantID/batches/:batchID", handler.getBatch)
 	group.Post("/:tenantID/batches/:batchID/cancel", handler.cancelBatch)
 	group.Get("/:tenantID/batches/:batchID/output", handler.downloadBatchOutput)
 	group.Get("/:tenantID/batches/:batchID/errors", handler.downloadBatchErrors)
+	group.Post("/:tenantID/export", handler.exportTenant)
+	group.Get("/:tenantID/export/:jobID", handler.getTenantExport)
+	group.Get("/:tenantID/export/:jobID/download", handler.downloadTenantExport)
+	group.Post("/:tenantID/clone", handler.cloneTenant)
 }
 
 type tenantHandler struct {
@@ -68,6 +94,7 @@ type listTenantResponse struct {
 	BudgetLimitUSD   float64   `json:"budget_limit_usd"`
 	BudgetUsedUSD    float64   `json:"budget_used_usd"`
 	WarningThreshold *float64  `json:"warning_threshold,omitempty"`
+	CostCenter       string    `json:"cost_center"`
 }
 
 type listPersonalTenantResponse struct {
@@ -90,10 +117,30 @@ type createTenantRequest struct {
 
 type updateTenantStatusRequest struct {
 	Status string `json:"status"`
+	Reason string `json:"reason"`
+}
+
+type bulkTenantStatusRequest struct {
+	TenantIDs []string `json:"tenant_ids"`
+	Reason    string   `json:"reason"`
+}
+
+type bulkTenantFailure struct {
+	TenantID string `json:"tenant_id"`
+	Reason   string `json:"reason"`
+}
+
+type bulkTenantStatusResponse struct {
+	Status    string              `json:"status"`
+	Succeeded []string            `json:"succeeded"`
+	Failed    []bulkTenantFailure `json:"failed"`
 }
 
 type updateTenantDetailsRequest struct {
 	Name string `json:"name"`
+	// CostCenter groups the tenant in chargeback reports. Only super admins
+	// may change it; an empty string clears it.
+	CostCenter *string `json:"cost_center"`
 }
 
 type createAPIKeyRequest struct {
@@ -101,6 +148,8 @@ type createAPIKeyRequest struct {
 	Scopes     []string                `json:"scopes"`
 	Quota      *quotaPayload           `json:"quota"`
 	RateLimits *apiKeyRateLimitRequest `json:"rate_limits,omitempty"`
+	// ZeroRetention stores only counts and costs for the key's requests.
+	ZeroRetention bool `json:"zero_retention"`
 }
 
 type quotaPayload struct {
@@ -113,18 +162,86 @@ type tenantRateLimitRequest struct {
 	RequestsPerMinute int `json:"requests_per_minute"`
 	TokensPerMinute   int `json:"tokens_per_minute"`
 	ParallelRequests  int `json:"parallel_requests"`
+	// ParallelRequestTTLSeconds overrides rate_limits.parallel_request_ttl;
+	// 0 keeps the default.
+	ParallelRequestTTLSeconds int `json:"parallel_request_ttl_seconds"`
+}
+
+type tenantQuotaRequest struct {
+	MaxRequestsPerPeriod int64  `json:"max_requests_per_period"`
+	RefreshSchedule      string `json:"refresh_schedule"`
+	MaxRequestBodyMB     int32  `json:"max_request_body_mb"`
+	StreamIdleTimeoutSec int32  `json:"stream_idle_timeout_sec"`
+}
+
+type tenantQuotaResponse struct {
+	MaxRequestsPerPeriod int64     `json:"max_requests_per_period"`
+	RefreshSchedule      string    `json:"refresh_schedule"`
+	MaxRequestBodyMB     int32     `json:"max_request_body_mb"`
+	StreamIdleTimeoutSec int32     `json:"stream_idle_timeout_sec"`
+	Used                 int64     `json:"used"`
+	Remaining            int64     `json:"remaining"`
+	ResetAt              time.Time `json:"reset_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+type tenantModelOverrideRequest struct {
+	ContextWindowOverride   int32 `json:"context_window_override"`
+	MaxOutputTokensOverride int32 `json:"max_output_tokens_override"`
+}
+
+type tenantModelOverrideResponse struct {
+	ModelAlias              string    `json:"model_alias"`
+	ContextWindowOverride   int32     `json:"context_window_override"`
+	MaxOutputTokensOverride int32     `json:"max_output_tokens_override"`
+	UpdatedAt               time.Time `json:"updated_at"`
+}
+
+type tenantSettingsRequest struct {
+	SchemaValidationMode      string    `json:"schema_validation_mode"`
+	AbuseFingerprintThreshold *int32    `json:"abuse_fingerprint_threshold"`
+	DataResidency             *[]string `json:"data_residency"`
+}
+
+type tenantSettingsResponse struct {
+	SchemaValidationMode      string   `json:"schema_validation_mode"`
+	AbuseFingerprintThreshold int32    `json:"abuse_fingerprint_threshold"`
+	DataResidency             []string `json:"data_residency"`
+}
+
+type tenantSystemPromptRequest struct {
+	Content string `json:"content"`
+	Mode    string `json:"mode"`
+}
+
+type tenantSystemPromptResponse struct {
+	Content   string    `json:"content"`
+	Mode      string    `json:"mode"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type tenantParentRequest struct {
+	ParentTenantID string `json:"parent_tenant_id"`
+}
+
+type tenantParentResponse struct {
+	TenantID       string    `json:"tenant_id"`
+	ParentTenantID string    `json:"parent_tenant_id"`
+	CreatedAt      time.Time `json:"created_at"`
 }
 
 type tenantRateLimitResponse struct {
-	RequestsPerMinute int `json:"requests_per_minute"`
-	TokensPerMinute   int `json:"tokens_per_minute"`
-	ParallelRequests  int `json:"parallel_requests"`
+	RequestsPerMinute         int `json:"requests_per_minute"`
+	TokensPerMinute           int `json:"tokens_per_minute"`
+	ParallelRequests          int `json:"parallel_requests"`
+	ParallelRequestTTLSeconds int `json:"parallel_request_ttl_seconds"`
 }
 
 type apiKeyRateLimitRequest struct {
-	RequestsPerMinute int `json:"requests_per_minute"`
-	TokensPerMinute   int `json:"tokens_per_minute"`
-	ParallelRequests  int `json:"parallel_requests"`
+	RequestsPerMinute         int `json:"requests_per_minute"`
+	TokensPerMinute           int `json:"tokens_per_minute"`
+	ParallelRequests          int `json:"parallel_requests"`
+	ParallelRequestTTLSeconds int `json:"parallel_request_ttl_seconds"`
 }
 
 type membershipRequest struct {
@@ -133,6 +250,21 @@ type membershipRequest struct {
 	Password string `json:"password"`
 }
 
+type invitationRequest struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+type invitationResponse struct {
+	ID        string    `json:"id"`
+	TenantID  string    `json:"tenant_id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	InvitedBy *string   `json:"invited_by,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type tenantModelsRequest struct {
 	Models []string `json:"models"`
 }
@@ -178,6 +310,7 @@ func (h *tenantHandler) list(c *fiber.Ctx) error {
 			BudgetLimitUSD:   item.BudgetLimitUSD,
 			BudgetUsedUSD:    item.BudgetUsedUSD,
 			WarningThreshold: item.WarningThresh,
+			CostCenter:       item.CostCenter,
 		})
 	}
 
@@ -235,7 +368,7 @@ func (h *tenantHandler) listPersonal(c *fiber.Ctx) error {
 }
 
 func (h *tenantHandler) create(c *fiber.Ctx) error {
-	if err := requireAnyRole(c, h.container, db.MembershipRoleAdmin); err != nil {
+	if err := requireAnyPermission(c, h.container, rbac.PermTenantsCreate); err != nil {
 		return err
 	}
 	if h.service == nil {
@@ -299,7 +432,7 @@ func (h *tenantHandler) updateDetails(c *fiber.Ctx) error {
 		return err
 	}
 
-	if err := requireTenantRole(c, h.container, tenantUUID, db.MembershipRoleOwner); err != nil {
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermTenantsWrite); err != nil {
 		return err
 	}
 
@@ -309,23 +442,52 @@ func (h *tenantHandler) updateDetails(c *fiber.Ctx) error {
 	}
 
 	name := strings.TrimSpace(req.Name)
-	if name == "" {
+	if name == "" && req.CostCenter == nil {
 		return httputil.WriteError(c, fiber.StatusBadRequest, "name is required")
 	}
 	if len(name) > 128 {
 		return httputil.WriteError(c, fiber.StatusBadRequest, "name must be <= 128 characters")
 	}
+	var costCenter string
+	if req.CostCenter != nil {
+		if err := requireSuperAdmin(c); err != nil {
+			return err
+		}
+		costCenter = strings.TrimSpace(*req.CostCenter)
+		if len(costCenter) > 128 {
+			return httputil.WriteError(c, fiber.StatusBadRequest, "cost_center must be <= 128 characters")
+		}
+	}
 
 	if h.service == nil {
 		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
 	}
-	record, err := h.service.UpdateTenantName(c.Context(), tenantUUID, name)
-	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
-			return httputil.WriteError(c, fiber.StatusBadRequest, "tenant name already exists")
+	var record db.Tenant
+	if name != "" {
+		record, err = h.service.UpdateTenantName(c.Context(), tenantUUID, name)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				return httputil.WriteError(c, fiber.StatusBadRequest, "tenant name already exists")
+			}
+			return writeTenantServiceError(c, err)
+		}
+		if err := recordAudit(c, h.container, "tenant.update_name", "tenant", tenantUUID.String(), fiber.Map{
+			"name": record.Name,
+		}); err != nil {
+			return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+		}
+	}
+	if req.CostCenter != nil {
+		record, err = h.service.UpdateTenantCostCenter(c.Context(), tenantUUID, costCenter)
+		if err != nil {
+			return writeTenantServiceError(c, err)
+		}
+		if err := recordAudit(c, h.container, "tenant.update_cost_center", "tenant", tenantUUID.String(), fiber.Map{
+			"cost_center": record.CostCenter,
+		}); err != nil {
+			return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
 		}
-		return writeTenantServiceError(c, err)
 	}
 
 	created, err := timeFromPg(record.CreatedAt)
@@ -345,12 +507,7 @@ func (h *tenantHandler) updateDetails(c *fiber.Ctx) error {
 		CreatedAt:      created,
 		BudgetLimitUSD: h.container.Config.Budgets.DefaultUSD,
 		BudgetUsedUSD:  0,
-	}
-
-	if err := recordAudit(c, h.container, "tenant.update_name", "tenant", tenantIDOut.String(), fiber.Map{
-		"name": record.Name,
-	}); err != nil {
-		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+		CostCenter:     record.CostCenter,
 	}
 
 	return c.JSON(resp)
@@ -362,7 +519,7 @@ func (h *tenantHandler) updateStatus(c *fiber.Ctx) error {
 		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid tenant id")
 	}
 
-	if err := requireTenantRole(c, h.container, id, db.MembershipRoleOwner); err != nil {
+	if err := requireTenantPermission(c, h.container, id, rbac.PermTenantsWrite); err != nil {
 		return err
 	}
 
@@ -379,7 +536,8 @@ func (h *tenantHandler) updateStatus(c *fiber.Ctx) error {
 	if h.service == nil {
 		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
 	}
-	record, err := h.service.UpdateTenantStatus(c.Context(), id, db.TenantStatus(status))
+	reason := strings.TrimSpace(req.Reason)
+	record, err := h.service.UpdateTenantStatus(c.Context(), id, db.TenantStatus(status), reason)
 	if err != nil {
 		return writeTenantServiceError(c, err)
 	}
@@ -403,6 +561,7 @@ func (h *tenantHandler) updateStatus(c *fiber.Ctx) error {
 
 	if err := recordAudit(c, h.container, "tenant.update_status", "tenant", response.ID, fiber.Map{
 		"status": response.Status,
+		"reason": reason,
 	}); err != nil {
 		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
 	}
@@ -410,13 +569,117 @@ func (h *tenantHandler) updateStatus(c *fiber.Ctx) error {
 	return c.JSON(response)
 }
 
+func (h *tenantHandler) bulkSuspend(c *fiber.Ctx) error {
+	return h.bulkUpdateStatus(c, db.TenantStatusSuspended)
+}
+
+func (h *tenantHandler) bulkActivate(c *fiber.Ctx) error {
+	return h.bulkUpdateStatus(c, db.TenantStatusActive)
+}
+
+// bulkUpdateStatus applies status to up to MaxBulkTenantIDs tenants and
+// reports per-tenant success. Super admins cannot suspend tenants they
+// belong to, including their personal tenant.
+func (h *tenantHandler) bulkUpdateStatus(c *fiber.Ctx, status db.TenantStatus) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
+	}
+	var req bulkTenantStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
+	}
+	if len(req.TenantIDs) == 0 {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "tenant_ids required")
+	}
+	if len(req.TenantIDs) > admintenantsvc.MaxBulkTenantIDs {
+		return httputil.WriteError(c, fiber.StatusBadRequest, admintenantsvc.ErrBulkTooLarge.Error())
+	}
+
+	response := bulkTenantStatusResponse{
+		Status:    string(status),
+		Succeeded: []string{},
+		Failed:    []bulkTenantFailure{},
+	}
+	var own map[uuid.UUID]struct{}
+	if status == db.TenantStatusSuspended {
+		var err error
+		own, err = h.callerTenantIDs(c)
+		if err != nil {
+			return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+		}
+	}
+	ids := make([]uuid.UUID, 0, len(req.TenantIDs))
+	seen := make(map[uuid.UUID]struct{}, len(req.TenantIDs))
+	for _, raw := range req.TenantIDs {
+		id, err := uuid.Parse(strings.TrimSpace(raw))
+		if err != nil {
+			response.Failed = append(response.Failed, bulkTenantFailure{TenantID: raw, Reason: "invalid tenant id"})
+			continue
+		}
+		if _, dup := seen[id]; dup {
+			continue
+		}
+		seen[id] = struct{}{}
+		if _, mine := own[id]; mine {
+			response.Failed = append(response.Failed, bulkTenantFailure{TenantID: id.String(), Reason: "cannot suspend your own tenant"})
+			continue
+		}
+		ids = append(ids, id)
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	succeeded, failed, err := h.service.BulkUpdateStatus(c.Context(), ids, status, reason)
+	if err != nil {
+		return writeTenantServiceError(c, err)
+	}
+	for _, id := range failed {
+		response.Failed = append(response.Failed, bulkTenantFailure{TenantID: id.String(), Reason: "tenant not found"})
+	}
+	for _, id := range succeeded {
+		response.Succeeded = append(response.Succeeded, id.String())
+		if err := recordAudit(c, h.container, "tenant.bulk_update_status", "tenant", id.String(), fiber.Map{
+			"status": string(status),
+			"reason": reason,
+		}); err != nil {
+			return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+		}
+	}
+	return c.JSON(response)
+}
+
+// callerTenantIDs returns the admin's personal tenant and every tenant they
+// are a member of.
+func (h *tenantHandler) callerTenantIDs(c *fiber.Ctx) (map[uuid.UUID]struct{}, error) {
+	user, ok := adminUserFromContext(c.UserContext())
+	if !ok {
+		return nil, errors.New("missing admin context")
+	}
+	own := make(map[uuid.UUID]struct{})
+	if id, err := fromPgUUID(user.PersonalTenantID); err == nil {
+		own[id] = struct{}{}
+	}
+	memberships, err := h.container.Queries.ListUserTenants(c.Context(), user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range memberships {
+		if id, err := fromPgUUID(m.TenantID); err == nil {
+			own[id] = struct{}{}
+		}
+	}
+	return own, nil
+}
+
 func (h *tenantHandler) getBudget(c *fiber.Ctx) error {
 	tenantUUID, err := parseTenantParam(c)
 	if err != nil {
 		return err
 	}
 
-	if err := requireTenantRole(c, h.container, tenantUUID, db.MembershipRoleViewer); err != nil {
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermBudgetsRead); err != nil {
 		return err
 	}
 
@@ -439,7 +702,7 @@ func (h *tenantHandler) upsertBudget(c *fiber.Ctx) error {
 	if err != nil {
 		return err
 	}
-	if err := requireTenantRole(c, h.container, tenantUUID, db.MembershipRoleOwner); err != nil {
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermBudgetsWrite); err != nil {
 		return err
 	}
 
@@ -458,9 +721,12 @@ func (h *tenantHandler) upsertBudget(c *fiber.Ctx) error {
 		AlertEmails:          req.AlertEmails,
 		AlertWebhooks:        req.AlertWebhooks,
 		AlertCooldownSeconds: req.AlertCooldownSeconds,
+		UsageAlert:           req.UsageAlert,
+		EnforcementMode:      req.EnforcementMode,
+		AlertWebhookSecret:   req.AlertWebhookSecret,
 	})
 	if err != nil {
-		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+		return writeBudgetError(c, err)
 	}
 
 	if err := recordAudit(c, h.container, "tenant.budget.upsert", "tenant", tenantUUID.String(), fiber.Map{
@@ -470,6 +736,48 @@ func (h *tenantHandler) upsertBudget(c *fiber.Ctx) error {
 		"alert_emails":           override.AlertEmails,
 		"alert_webhooks":         override.AlertWebhooks,
 		"alert_cooldown_seconds": override.AlertCooldownSeconds,
+		"enforcement_mode":       override.EnforcementMode,
+		"secret_updated":         req.AlertWebhookSecret != nil,
+	}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(mapBudgetOverride(override))
+}
+
+type budgetPatchRequest struct {
+	EnforcementMode string `json:"enforcement_mode"`
+}
+
+// patchBudget switches an existing budget override between hard and soft
+// enforcement.
+func (h *tenantHandler) patchBudget(c *fiber.Ctx) error {
+	tenantUUID, err := parseTenantParam(c)
+	if err != nil {
+		return err
+	}
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermBudgetsWrite); err != nil {
+		return err
+	}
+
+	if h.container.AdminBudgets == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "budget service unavailable")
+	}
+
+	var req budgetPatchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
+	}
+	override, err := h.container.AdminBudgets.SetEnforcementMode(c.Context(), tenantUUID, req.EnforcementMode)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return httputil.WriteError(c, fiber.StatusNotFound, "budget override not set")
+		}
+		return writeBudgetError(c, err)
+	}
+
+	if err := recordAudit(c, h.container, "tenant.budget.enforcement_mode", "tenant", tenantUUID.String(), fiber.Map{
+		"enforcement_mode": override.EnforcementMode,
 	}); err != nil {
 		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
 	}
@@ -482,7 +790,7 @@ func (h *tenantHandler) deleteBudget(c *fiber.Ctx) error {
 	if err != nil {
 		return err
 	}
-	if err := requireTenantRole(c, h.container, tenantUUID, db.MembershipRoleOwner); err != nil {
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermBudgetsWrite); err != nil {
 		return err
 	}
 
@@ -505,7 +813,7 @@ func (h *tenantHandler) getRateLimits(c *fiber.Ctx) error {
 	if err != nil {
 		return err
 	}
-	if err := requireTenantRole(c, h.container, tenantUUID, db.MembershipRoleViewer); err != nil {
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermRateLimitsRead); err != nil {
 		return err
 	}
 	if h.service == nil {
@@ -526,7 +834,7 @@ func (h *tenantHandler) upsertRateLimits(c *fiber.Ctx) error {
 	if err != nil {
 		return err
 	}
-	if err := requireTenantRole(c, h.container, tenantUUID, db.MembershipRoleOwner); err != nil {
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermTenantsWrite); err != nil {
 		return err
 	}
 	if h.service == nil {
@@ -537,9 +845,10 @@ func (h *tenantHandler) upsertRateLimits(c *fiber.Ctx) error {
 		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
 	}
 	cfg, err := h.service.UpsertTenantRateLimitOverride(c.Context(), tenantUUID, limits.LimitConfig{
-		RequestsPerMinute: req.RequestsPerMinute,
-		TokensPerMinute:   req.TokensPerMinute,
-		ParallelRequests:  req.ParallelRequests,
+		RequestsPerMinute:  req.RequestsPerMinute,
+		TokensPerMinute:    req.TokensPerMinute,
+		ParallelRequests:   req.ParallelRequests,
+		ParallelRequestTTL: time.Duration(req.ParallelRequestTTLSeconds) * time.Second,
 	})
 	if err != nil {
 		if errors.Is(err, admintenantsvc.ErrInvalidRateLimit) {
@@ -548,9 +857,10 @@ func (h *tenantHandler) upsertRateLimits(c *fiber.Ctx) error {
 		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
 	}
 	if err := recordAudit(c, h.container, "tenant.rate_limit.upsert", "tenant", tenantUUID.String(), fiber.Map{
-		"requests_per_minute": cfg.RequestsPerMinute,
-		"tokens_per_minute":   cfg.TokensPerMinute,
-		"parallel_requests":   cfg.ParallelRequests,
+		"requests_per_minute":          cfg.RequestsPerMinute,
+		"tokens_per_minute":            cfg.TokensPerMinute,
+		"parallel_requests":            cfg.ParallelRequests,
+		"parallel_request_ttl_seconds": int(cfg.ParallelRequestTTL / time.Second),
 	}); err != nil {
 		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
 	}
@@ -562,7 +872,7 @@ func (h *tenantHandler) deleteRateLimits(c *fiber.Ctx) error {
 	if err != nil {
 		return err
 	}
-	if err := requireTenantRole(c, h.container, tenantUUID, db.MembershipRoleOwner); err != nil {
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermTenantsWrite); err != nil {
 		return err
 	}
 	if h.service == nil {
@@ -577,158 +887,597 @@ func (h *tenantHandler) deleteRateLimits(c *fiber.Ctx) error {
 	return c.SendStatus(fiber.StatusNoContent)
 }
 
-func mapTenantRateLimit(cfg limits.LimitConfig) tenantRateLimitResponse {
-	return tenantRateLimitResponse{
-		RequestsPerMinute: cfg.RequestsPerMinute,
-		TokensPerMinute:   cfg.TokensPerMinute,
-		ParallelRequests:  cfg.ParallelRequests,
+func (h *tenantHandler) getQuota(c *fiber.Ctx) error {
+	tenantUUID, err := parseTenantParam(c)
+	if err != nil {
+		return err
 	}
-}
-
-func validateQuotaAgainstBudget(quota *quotaPayload, limit float64) error {
-	if quota == nil {
-		return nil
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermTenantsRead); err != nil {
+		return err
 	}
-	if quota.BudgetUSD > 0 && limit > 0 && quota.BudgetUSD > limit {
-		return fmt.Errorf("budget cannot exceed tenant limit (%.2f)", limit)
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
 	}
-	if quota.BudgetUSD < 0 {
-		return fmt.Errorf("budget must be positive")
+	record, exists, err := h.service.GetTenantQuota(c.Context(), tenantUUID)
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
 	}
-	if quota.WarningThreshold > 1 || quota.WarningThreshold < 0 {
-		return fmt.Errorf("warning threshold must be between 0 and 1")
+	if !exists {
+		return httputil.WriteError(c, fiber.StatusNotFound, "quota not set")
 	}
-	return nil
+	resp, err := h.mapTenantQuota(c.Context(), tenantUUID, record)
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(resp)
 }
 
-func validateAPIKeyRateLimitRequest(container *app.Container, tenantID uuid.UUID, payload *apiKeyRateLimitRequest) (*limits.LimitConfig, error) {
-	if container == nil || payload == nil {
-		return nil, nil
-	}
-	rpm := payload.RequestsPerMinute
-	tpm := payload.TokensPerMinute
-	parallel := payload.ParallelRequests
-	if rpm == 0 && tpm == 0 && parallel == 0 {
-		return nil, nil
-	}
-	if rpm <= 0 || tpm <= 0 || parallel <= 0 {
-		return nil, fmt.Errorf("rate limits must be positive integers")
+func (h *tenantHandler) upsertQuota(c *fiber.Ctx) error {
+	tenantUUID, err := parseTenantParam(c)
+	if err != nil {
+		return err
 	}
-	keyCfg, tenantCfg := container.EffectiveRateLimits("", tenantID)
-	if tenantCfg.RequestsPerMinute > 0 && rpm > tenantCfg.RequestsPerMinute {
-		return nil, fmt.Errorf("requests_per_minute cannot exceed tenant limit (%d)", tenantCfg.RequestsPerMinute)
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermTenantsWrite); err != nil {
+		return err
 	}
-	if keyCfg.RequestsPerMinute > 0 && rpm > keyCfg.RequestsPerMinute {
-		return nil, fmt.Errorf("requests_per_minute cannot exceed default limit (%d)", keyCfg.RequestsPerMinute)
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
 	}
-	if tenantCfg.TokensPerMinute > 0 && tpm > tenantCfg.TokensPerMinute {
-		return nil, fmt.Errorf("tokens_per_minute cannot exceed tenant limit (%d)", tenantCfg.TokensPerMinute)
+	var req tenantQuotaRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
 	}
-	if keyCfg.TokensPerMinute > 0 && tpm > keyCfg.TokensPerMinute {
-		return nil, fmt.Errorf("tokens_per_minute cannot exceed default limit (%d)", keyCfg.TokensPerMinute)
+	record, err := h.service.UpsertTenantQuota(c.Context(), tenantUUID, admintenantsvc.TenantQuotaInput{
+		MaxRequestsPerPeriod: req.MaxRequestsPerPeriod,
+		RefreshSchedule:      req.RefreshSchedule,
+		MaxRequestBodyMB:     req.MaxRequestBodyMB,
+		StreamIdleTimeoutSec: req.StreamIdleTimeoutSec,
+	})
+	if err != nil {
+		if errors.Is(err, admintenantsvc.ErrInvalidQuota) || errors.Is(err, admintenantsvc.ErrInvalidBodyLimit) || errors.Is(err, admintenantsvc.ErrInvalidStreamIdle) {
+			return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+		}
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
 	}
-	if tenantCfg.ParallelRequests > 0 && parallel > tenantCfg.ParallelRequests {
-		return nil, fmt.Errorf("parallel_requests cannot exceed tenant limit (%d)", tenantCfg.ParallelRequests)
+	if err := recordAudit(c, h.container, "tenant.quota.upsert", "tenant", tenantUUID.String(), fiber.Map{
+		"max_requests_per_period": record.MaxRequestsPerPeriod,
+		"refresh_schedule":        record.RefreshSchedule,
+		"max_request_body_mb":     record.MaxRequestBodyMb,
+		"stream_idle_timeout_sec": record.StreamIdleTimeoutSec,
+	}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
 	}
-	if keyCfg.ParallelRequests > 0 && parallel > keyCfg.ParallelRequests {
-		return nil, fmt.Errorf("parallel_requests cannot exceed default limit (%d)", keyCfg.ParallelRequests)
+	resp, err := h.mapTenantQuota(c.Context(), tenantUUID, record)
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
 	}
-	return &limits.LimitConfig{
-		RequestsPerMinute: rpm,
-		TokensPerMinute:   tpm,
-		ParallelRequests:  parallel,
-	}, nil
+	return c.JSON(resp)
 }
 
-func (h *tenantHandler) getTenantModels(c *fiber.Ctx) error {
+func (h *tenantHandler) deleteQuota(c *fiber.Ctx) error {
 	tenantUUID, err := parseTenantParam(c)
 	if err != nil {
 		return err
 	}
-
-	if err := requireTenantRole(c, h.container, tenantUUID, db.MembershipRoleViewer); err != nil {
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermTenantsWrite); err != nil {
 		return err
 	}
-
 	if h.service == nil {
 		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
 	}
-	models, err := h.service.ListModels(c.Context(), tenantUUID)
-	if err != nil {
-		return writeTenantServiceError(c, err)
+	if err := h.service.DeleteTenantQuota(c.Context(), tenantUUID); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
 	}
+	if err := recordAudit(c, h.container, "tenant.quota.delete", "tenant", tenantUUID.String(), fiber.Map{}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
 
-	return c.JSON(fiber.Map{"models": models})
+// mapTenantQuota pairs the stored quota with the live Redis counter.
+func (h *tenantHandler) mapTenantQuota(ctx context.Context, tenantID uuid.UUID, record db.TenantQuotaOverride) (tenantQuotaResponse, error) {
+	status, err := h.container.Quotas.Usage(ctx, tenantID, limits.QuotaConfig{
+		MaxRequests:     record.MaxRequestsPerPeriod,
+		RefreshSchedule: record.RefreshSchedule,
+	})
+	if err != nil {
+		return tenantQuotaResponse{}, err
+	}
+	return tenantQuotaResponse{
+		MaxRequestsPerPeriod: record.MaxRequestsPerPeriod,
+		RefreshSchedule:      record.RefreshSchedule,
+		MaxRequestBodyMB:     record.MaxRequestBodyMb,
+		StreamIdleTimeoutSec: record.StreamIdleTimeoutSec,
+		Used:                 status.Used,
+		Remaining:            status.Remaining(),
+		ResetAt:              status.ResetAt,
+		UpdatedAt:            record.UpdatedAt.Time,
+	}, nil
 }
 
-func (h *tenantHandler) upsertTenantModels(c *fiber.Ctx) error {
+func (h *tenantHandler) listModelOverrides(c *fiber.Ctx) error {
 	tenantUUID, err := parseTenantParam(c)
 	if err != nil {
 		return err
 	}
-	if err := requireTenantRole(c, h.container, tenantUUID, db.MembershipRoleOwner); err != nil {
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermModelsRead); err != nil {
 		return err
 	}
-
-	var req tenantModelsRequest
-	if err := c.BodyParser(&req); err != nil {
-		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
-	}
 	if h.service == nil {
 		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
 	}
-	finalList, err := h.service.SetModels(c.Context(), tenantUUID, req.Models)
+	records, err := h.service.ListTenantModelOverrides(c.Context(), tenantUUID)
 	if err != nil {
 		return writeTenantServiceError(c, err)
 	}
-
-	if err := recordAudit(c, h.container, "tenant.models.set", "tenant", tenantUUID.String(), fiber.Map{
-		"models": finalList,
-	}); err != nil {
-		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	data := make([]tenantModelOverrideResponse, 0, len(records))
+	for _, record := range records {
+		data = append(data, mapTenantModelOverride(record))
 	}
-
-	return c.JSON(fiber.Map{"models": finalList})
+	return c.JSON(fiber.Map{"overrides": data})
 }
 
-func (h *tenantHandler) deleteTenantModels(c *fiber.Ctx) error {
+func (h *tenantHandler) upsertModelOverride(c *fiber.Ctx) error {
 	tenantUUID, err := parseTenantParam(c)
 	if err != nil {
 		return err
 	}
-	if err := requireTenantRole(c, h.container, tenantUUID, db.MembershipRoleOwner); err != nil {
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermTenantsWrite); err != nil {
 		return err
 	}
-
 	if h.service == nil {
 		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
 	}
-	if err := h.service.DeleteModels(c.Context(), tenantUUID); err != nil {
+	var req tenantModelOverrideRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
+	}
+	alias := strings.TrimSpace(c.Params("alias"))
+	record, err := h.service.UpsertTenantModelOverride(c.Context(), tenantUUID, alias, req.ContextWindowOverride, req.MaxOutputTokensOverride)
+	if err != nil {
 		return writeTenantServiceError(c, err)
 	}
-
-	if err := recordAudit(c, h.container, "tenant.models.clear", "tenant", tenantUUID.String(), fiber.Map{}); err != nil {
+	if err := recordAudit(c, h.container, "tenant.model_override.upsert", "tenant", tenantUUID.String(), fiber.Map{
+		"model_alias":                record.ModelAlias,
+		"context_window_override":    record.ContextWindowOverride,
+		"max_output_tokens_override": record.MaxOutputTokensOverride,
+	}); err != nil {
 		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
 	}
-
-	return c.SendStatus(fiber.StatusNoContent)
+	return c.JSON(mapTenantModelOverride(record))
 }
 
-func (h *tenantHandler) listAPIKeys(c *fiber.Ctx) error {
-	id, err := uuid.Parse(strings.TrimSpace(c.Params("tenantID")))
+func (h *tenantHandler) deleteModelOverride(c *fiber.Ctx) error {
+	tenantUUID, err := parseTenantParam(c)
 	if err != nil {
-		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid tenant id")
+		return err
 	}
-
-	if err := requireTenantRole(c, h.container, id, db.MembershipRoleViewer); err != nil {
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermTenantsWrite); err != nil {
 		return err
 	}
-
 	if h.service == nil {
 		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
 	}
-	keys, err := h.service.ListAPIKeys(c.Context(), id)
-	if err != nil {
+	alias := strings.TrimSpace(c.Params("alias"))
+	if err := h.service.DeleteTenantModelOverride(c.Context(), tenantUUID, alias); err != nil {
+		return writeTenantServiceError(c, err)
+	}
+	if err := recordAudit(c, h.container, "tenant.model_override.delete", "tenant", tenantUUID.String(), fiber.Map{
+		"model_alias": alias,
+	}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func mapTenantModelOverride(record db.TenantModelOverride) tenantModelOverrideResponse {
+	return tenantModelOverrideResponse{
+		ModelAlias:              record.ModelAlias,
+		ContextWindowOverride:   record.ContextWindowOverride,
+		MaxOutputTokensOverride: record.MaxOutputTokensOverride,
+		UpdatedAt:               record.UpdatedAt.Time,
+	}
+}
+
+func (h *tenantHandler) getSettings(c *fiber.Ctx) error {
+	tenantUUID, err := parseTenantParam(c)
+	if err != nil {
+		return err
+	}
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermTenantsRead); err != nil {
+		return err
+	}
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
+	}
+	settings, err := h.service.GetTenantSettings(c.Context(), tenantUUID)
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(toTenantSettingsResponse(settings))
+}
+
+func (h *tenantHandler) updateSettings(c *fiber.Ctx) error {
+	tenantUUID, err := parseTenantParam(c)
+	if err != nil {
+		return err
+	}
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermTenantsWrite); err != nil {
+		return err
+	}
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
+	}
+	var req tenantSettingsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
+	}
+	update := admintenantsvc.TenantSettings{SchemaValidationMode: schemavalidation.Mode(req.SchemaValidationMode)}
+	// Omitting the threshold or the residency keeps the stored value.
+	current, err := h.service.GetTenantSettings(c.Context(), tenantUUID)
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	update.AbuseFingerprintThreshold = current.AbuseFingerprintThreshold
+	if req.AbuseFingerprintThreshold != nil {
+		update.AbuseFingerprintThreshold = *req.AbuseFingerprintThreshold
+	}
+	update.DataResidency = current.DataResidency
+	if req.DataResidency != nil {
+		update.DataResidency = *req.DataResidency
+	}
+	settings, err := h.service.UpdateTenantSettings(c.Context(), tenantUUID, update)
+	if err != nil {
+		if errors.Is(err, schemavalidation.ErrInvalidMode) ||
+			errors.Is(err, admintenantsvc.ErrInvalidAbuseLimit) ||
+			errors.Is(err, admintenantsvc.ErrInvalidResidency) {
+			return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+		}
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	if err := recordAudit(c, h.container, "tenant.settings.update", "tenant", tenantUUID.String(), fiber.Map{
+		"schema_validation_mode":      settings.SchemaValidationMode,
+		"abuse_fingerprint_threshold": settings.AbuseFingerprintThreshold,
+		"data_residency":              settings.DataResidency,
+	}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(toTenantSettingsResponse(settings))
+}
+
+func toTenantSettingsResponse(settings admintenantsvc.TenantSettings) tenantSettingsResponse {
+	return tenantSettingsResponse{
+		SchemaValidationMode:      string(settings.SchemaValidationMode),
+		AbuseFingerprintThreshold: settings.AbuseFingerprintThreshold,
+		DataResidency:             settings.DataResidency,
+	}
+}
+
+func (h *tenantHandler) getSystemPrompt(c *fiber.Ctx) error {
+	tenantUUID, err := parseTenantParam(c)
+	if err != nil {
+		return err
+	}
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermTenantsRead); err != nil {
+		return err
+	}
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
+	}
+	record, exists, err := h.service.GetTenantSystemPrompt(c.Context(), tenantUUID)
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	if !exists {
+		return httputil.WriteError(c, fiber.StatusNotFound, "system prompt not set")
+	}
+	return c.JSON(mapTenantSystemPrompt(record))
+}
+
+func (h *tenantHandler) upsertSystemPrompt(c *fiber.Ctx) error {
+	tenantUUID, err := parseTenantParam(c)
+	if err != nil {
+		return err
+	}
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermTenantsWrite); err != nil {
+		return err
+	}
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
+	}
+	var req tenantSystemPromptRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
+	}
+	record, err := h.service.UpsertTenantSystemPrompt(c.Context(), tenantUUID, req.Content, req.Mode)
+	if err != nil {
+		if errors.Is(err, admintenantsvc.ErrInvalidSystemPrompt) || errors.Is(err, admintenantsvc.ErrInvalidPromptMode) {
+			return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+		}
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	if err := recordAudit(c, h.container, "tenant.system_prompt.upsert", "tenant", tenantUUID.String(), fiber.Map{
+		"mode":           record.Mode,
+		"content_length": len(record.Content),
+	}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(mapTenantSystemPrompt(record))
+}
+
+func (h *tenantHandler) deleteSystemPrompt(c *fiber.Ctx) error {
+	tenantUUID, err := parseTenantParam(c)
+	if err != nil {
+		return err
+	}
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermTenantsWrite); err != nil {
+		return err
+	}
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
+	}
+	if err := h.service.DeleteTenantSystemPrompt(c.Context(), tenantUUID); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	if err := recordAudit(c, h.container, "tenant.system_prompt.delete", "tenant", tenantUUID.String(), fiber.Map{}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *tenantHandler) getParent(c *fiber.Ctx) error {
+	tenantUUID, err := parseTenantParam(c)
+	if err != nil {
+		return err
+	}
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermTenantsRead); err != nil {
+		return err
+	}
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
+	}
+	record, exists, err := h.service.GetTenantParent(c.Context(), tenantUUID)
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	if !exists {
+		return httputil.WriteError(c, fiber.StatusNotFound, "parent tenant not set")
+	}
+	return c.JSON(mapTenantParent(record))
+}
+
+func (h *tenantHandler) setParent(c *fiber.Ctx) error {
+	tenantUUID, err := parseTenantParam(c)
+	if err != nil {
+		return err
+	}
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermTenantsWrite); err != nil {
+		return err
+	}
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
+	}
+	var req tenantParentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
+	}
+	parentUUID, err := uuid.Parse(strings.TrimSpace(req.ParentTenantID))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid parent_tenant_id")
+	}
+	// Sub-tenants may spend on the parent's behalf in batches, so the caller
+	// must manage both sides of the relationship.
+	if err := requireTenantPermission(c, h.container, parentUUID, rbac.PermTenantsWrite); err != nil {
+		return err
+	}
+	record, err := h.service.SetTenantParent(c.Context(), tenantUUID, parentUUID)
+	if err != nil {
+		switch {
+		case errors.Is(err, admintenantsvc.ErrInvalidParent), errors.Is(err, admintenantsvc.ErrParentCycle):
+			return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+		case errors.Is(err, pgx.ErrNoRows):
+			return httputil.WriteError(c, fiber.StatusNotFound, "parent tenant not found")
+		}
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	if err := recordAudit(c, h.container, "tenant.parent.set", "tenant", tenantUUID.String(), fiber.Map{
+		"parent_tenant_id": parentUUID.String(),
+	}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(mapTenantParent(record))
+}
+
+func (h *tenantHandler) deleteParent(c *fiber.Ctx) error {
+	tenantUUID, err := parseTenantParam(c)
+	if err != nil {
+		return err
+	}
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermTenantsWrite); err != nil {
+		return err
+	}
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
+	}
+	if err := h.service.DeleteTenantParent(c.Context(), tenantUUID); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	if err := recordAudit(c, h.container, "tenant.parent.delete", "tenant", tenantUUID.String(), fiber.Map{}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func mapTenantParent(record db.TenantParent) tenantParentResponse {
+	return tenantParentResponse{
+		TenantID:       uuid.UUID(record.TenantID.Bytes).String(),
+		ParentTenantID: uuid.UUID(record.ParentTenantID.Bytes).String(),
+		CreatedAt:      record.CreatedAt.Time,
+	}
+}
+
+func mapTenantSystemPrompt(record db.TenantSystemPrompt) tenantSystemPromptResponse {
+	return tenantSystemPromptResponse{
+		Content:   record.Content,
+		Mode:      string(record.Mode),
+		UpdatedAt: record.UpdatedAt.Time,
+	}
+}
+
+func mapTenantRateLimit(cfg limits.LimitConfig) tenantRateLimitResponse {
+	return tenantRateLimitResponse{
+		RequestsPerMinute:         cfg.RequestsPerMinute,
+		TokensPerMinute:           cfg.TokensPerMinute,
+		ParallelRequests:          cfg.ParallelRequests,
+		ParallelRequestTTLSeconds: int(cfg.ParallelRequestTTL / time.Second),
+	}
+}
+
+func validateQuotaAgainstBudget(quota *quotaPayload, limit float64) error {
+	if quota == nil {
+		return nil
+	}
+	if quota.BudgetUSD > 0 && limit > 0 && quota.BudgetUSD > limit {
+		return fmt.Errorf("budget cannot exceed tenant limit (%.2f)", limit)
+	}
+	if quota.BudgetUSD < 0 {
+		return fmt.Errorf("budget must be positive")
+	}
+	if quota.WarningThreshold > 1 || quota.WarningThreshold < 0 {
+		return fmt.Errorf("warning threshold must be between 0 and 1")
+	}
+	return nil
+}
+
+func validateAPIKeyRateLimitRequest(container *app.Container, tenantID uuid.UUID, payload *apiKeyRateLimitRequest) (*limits.LimitConfig, error) {
+	if container == nil || payload == nil {
+		return nil, nil
+	}
+	rpm := payload.RequestsPerMinute
+	tpm := payload.TokensPerMinute
+	parallel := payload.ParallelRequests
+	if rpm == 0 && tpm == 0 && parallel == 0 {
+		return nil, nil
+	}
+	if rpm <= 0 || tpm <= 0 || parallel <= 0 || payload.ParallelRequestTTLSeconds < 0 {
+		return nil, fmt.Errorf("rate limits must be positive integers")
+	}
+	keyCfg, tenantCfg := container.EffectiveRateLimits("", tenantID)
+	if tenantCfg.RequestsPerMinute > 0 && rpm > tenantCfg.RequestsPerMinute {
+		return nil, fmt.Errorf("requests_per_minute cannot exceed tenant limit (%d)", tenantCfg.RequestsPerMinute)
+	}
+	if keyCfg.RequestsPerMinute > 0 && rpm > keyCfg.RequestsPerMinute {
+		return nil, fmt.Errorf("requests_per_minute cannot exceed default limit (%d)", keyCfg.RequestsPerMinute)
+	}
+	if tenantCfg.TokensPerMinute > 0 && tpm > tenantCfg.TokensPerMinute {
+		return nil, fmt.Errorf("tokens_per_minute cannot exceed tenant limit (%d)", tenantCfg.TokensPerMinute)
+	}
+	if keyCfg.TokensPerMinute > 0 && tpm > keyCfg.TokensPerMinute {
+		return nil, fmt.Errorf("tokens_per_minute cannot exceed default limit (%d)", keyCfg.TokensPerMinute)
+	}
+	if tenantCfg.ParallelRequests > 0 && parallel > tenantCfg.ParallelRequests {
+		return nil, fmt.Errorf("parallel_requests cannot exceed tenant limit (%d)", tenantCfg.ParallelRequests)
+	}
+	if keyCfg.ParallelRequests > 0 && parallel > keyCfg.ParallelRequests {
+		return nil, fmt.Errorf("parallel_requests cannot exceed default limit (%d)", keyCfg.ParallelRequests)
+	}
+	return &limits.LimitConfig{
+		RequestsPerMinute:  rpm,
+		TokensPerMinute:    tpm,
+		ParallelRequests:   parallel,
+		ParallelRequestTTL: time.Duration(payload.ParallelRequestTTLSeconds) * time.Second,
+	}, nil
+}
+
+func (h *tenantHandler) getTenantModels(c *fiber.Ctx) error {
+	tenantUUID, err := parseTenantParam(c)
+	if err != nil {
+		return err
+	}
+
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermModelsRead); err != nil {
+		return err
+	}
+
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
+	}
+	models, err := h.service.ListModels(c.Context(), tenantUUID)
+	if err != nil {
+		return writeTenantServiceError(c, err)
+	}
+
+	return c.JSON(fiber.Map{"models": models})
+}
+
+func (h *tenantHandler) upsertTenantModels(c *fiber.Ctx) error {
+	tenantUUID, err := parseTenantParam(c)
+	if err != nil {
+		return err
+	}
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermTenantsWrite); err != nil {
+		return err
+	}
+
+	var req tenantModelsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
+	}
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
+	}
+	finalList, err := h.service.SetModels(c.Context(), tenantUUID, req.Models)
+	if err != nil {
+		return writeTenantServiceError(c, err)
+	}
+
+	if err := recordAudit(c, h.container, "tenant.models.set", "tenant", tenantUUID.String(), fiber.Map{
+		"models": finalList,
+	}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(fiber.Map{"models": finalList})
+}
+
+func (h *tenantHandler) deleteTenantModels(c *fiber.Ctx) error {
+	tenantUUID, err := parseTenantParam(c)
+	if err != nil {
+		return err
+	}
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermTenantsWrite); err != nil {
+		return err
+	}
+
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
+	}
+	if err := h.service.DeleteModels(c.Context(), tenantUUID); err != nil {
+		return writeTenantServiceError(c, err)
+	}
+
+	if err := recordAudit(c, h.container, "tenant.models.clear", "tenant", tenantUUID.String(), fiber.Map{}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *tenantHandler) listAPIKeys(c *fiber.Ctx) error {
+	id, err := uuid.Parse(strings.TrimSpace(c.Params("tenantID")))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid tenant id")
+	}
+
+	if err := requireTenantPermission(c, h.container, id, rbac.PermAPIKeysRead); err != nil {
+		return err
+	}
+
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
+	}
+	keys, err := h.service.ListAPIKeys(c.Context(), id)
+	if err != nil {
 		return writeTenantServiceError(c, err)
 	}
 
@@ -754,7 +1503,7 @@ func (h *tenantHandler) createAPIKey(c *fiber.Ctx) error {
 		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid tenant id")
 	}
 
-	if err := requireTenantRole(c, h.container, tenantID, db.MembershipRoleAdmin); err != nil {
+	if err := requireTenantPermission(c, h.container, tenantID, rbac.PermAPIKeysCreate); err != nil {
 		return err
 	}
 
@@ -805,7 +1554,7 @@ func (h *tenantHandler) createAPIKey(c *fiber.Ctx) error {
 	if h.service == nil {
 		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
 	}
-	result, err := h.service.CreateAPIKey(c.Context(), tenantID, req.Name, scopesJSON, quotaJSON, rateLimitCfg)
+	result, err := h.service.CreateAPIKey(c.Context(), tenantID, req.Name, scopesJSON, quotaJSON, rateLimitCfg, req.ZeroRetention)
 	if err != nil {
 		return writeTenantServiceError(c, err)
 	}
@@ -840,7 +1589,7 @@ func (h *tenantHandler) revokeAPIKey(c *fiber.Ctx) error {
 		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid api key id")
 	}
 
-	if err := requireTenantRole(c, h.container, tenantID, db.MembershipRoleAdmin); err != nil {
+	if err := requireTenantPermission(c, h.container, tenantID, rbac.PermAPIKeysRevoke); err != nil {
 		return err
 	}
 
@@ -870,13 +1619,54 @@ func (h *tenantHandler) revokeAPIKey(c *fiber.Ctx) error {
 	return c.JSON(response)
 }
 
+// reinstateAPIKey lifts a suspension, such as one applied by the fingerprint
+// abuse suspender.
+func (h *tenantHandler) reinstateAPIKey(c *fiber.Ctx) error {
+	tenantID, err := uuid.Parse(strings.TrimSpace(c.Params("tenantID")))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid tenant id")
+	}
+	apiKeyID, err := uuid.Parse(strings.TrimSpace(c.Params("apiKeyID")))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid api key id")
+	}
+
+	if err := requireTenantPermission(c, h.container, tenantID, rbac.PermAPIKeysRevoke); err != nil {
+		return err
+	}
+
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
+	}
+
+	record, err := h.service.ReinstateAPIKey(c.Context(), tenantID, apiKeyID)
+	if err != nil {
+		return writeTenantServiceError(c, err)
+	}
+
+	tenantName := h.lookupTenantName(c.Context(), tenantID)
+	issuer := resolveAPIKeyIssuer(record, tenantName, "", "")
+	response, err := buildAPIKeyResponse(c.Context(), h.container, record, tenantID, tenantName, issuer)
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	if err := recordAudit(c, h.container, "api_key.reinstate", "api_key", response.ID, fiber.Map{
+		"tenant_id": tenantID.String(),
+	}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(response)
+}
+
 func (h *tenantHandler) listMemberships(c *fiber.Ctx) error {
 	tenantID, err := uuid.Parse(strings.TrimSpace(c.Params("tenantID")))
 	if err != nil {
 		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid tenant id")
 	}
 
-	if err := requireTenantRole(c, h.container, tenantID, db.MembershipRoleAdmin); err != nil {
+	if err := requireTenantPermission(c, h.container, tenantID, rbac.PermMembershipsRead); err != nil {
 		return err
 	}
 
@@ -908,7 +1698,7 @@ func (h *tenantHandler) upsertMembership(c *fiber.Ctx) error {
 		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid tenant id")
 	}
 
-	if err := requireTenantRole(c, h.container, tenantID, db.MembershipRoleOwner); err != nil {
+	if err := requireTenantPermission(c, h.container, tenantID, rbac.PermMembershipsWrite); err != nil {
 		return err
 	}
 
@@ -961,7 +1751,7 @@ func (h *tenantHandler) removeMembership(c *fiber.Ctx) error {
 		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid user id")
 	}
 
-	if err := requireTenantRole(c, h.container, tenantID, db.MembershipRoleOwner); err != nil {
+	if err := requireTenantPermission(c, h.container, tenantID, rbac.PermMembershipsWrite); err != nil {
 		return err
 	}
 
@@ -981,6 +1771,123 @@ func (h *tenantHandler) removeMembership(c *fiber.Ctx) error {
 	return c.SendStatus(fiber.StatusNoContent)
 }
 
+func (h *tenantHandler) createInvitation(c *fiber.Ctx) error {
+	tenantID, err := uuid.Parse(strings.TrimSpace(c.Params("tenantID")))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid tenant id")
+	}
+
+	if err := requireTenantPermission(c, h.container, tenantID, rbac.PermMembershipsWrite); err != nil {
+		return err
+	}
+
+	var req invitationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
+	}
+	req.Email = strings.TrimSpace(req.Email)
+	if req.Email == "" {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "email is required")
+	}
+	role, ok := rbac.ParseRole(req.Role)
+	if !ok {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "role must be owner, admin, viewer, or user")
+	}
+
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
+	}
+	inviter, _ := adminUserIDFromContext(c.UserContext())
+	invitation, err := h.service.CreateInvitation(c.Context(), tenantID, req.Email, role, inviter)
+	if err != nil {
+		return writeTenantServiceError(c, err)
+	}
+
+	resp := toInvitationResponse(invitation)
+
+	if err := recordAudit(c, h.container, "membership.invite", "tenant", tenantID.String(), fiber.Map{
+		"invitation_id": resp.ID,
+		"email":         resp.Email,
+		"role":          resp.Role,
+	}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+func (h *tenantHandler) listInvitations(c *fiber.Ctx) error {
+	tenantID, err := uuid.Parse(strings.TrimSpace(c.Params("tenantID")))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid tenant id")
+	}
+
+	if err := requireTenantPermission(c, h.container, tenantID, rbac.PermMembershipsRead); err != nil {
+		return err
+	}
+
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
+	}
+	invitations, err := h.service.ListPendingInvitations(c.Context(), tenantID)
+	if err != nil {
+		return writeTenantServiceError(c, err)
+	}
+
+	out := make([]invitationResponse, 0, len(invitations))
+	for _, invitation := range invitations {
+		out = append(out, toInvitationResponse(invitation))
+	}
+
+	return c.JSON(fiber.Map{"invitations": out})
+}
+
+func (h *tenantHandler) revokeInvitation(c *fiber.Ctx) error {
+	tenantID, err := uuid.Parse(strings.TrimSpace(c.Params("tenantID")))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid tenant id")
+	}
+	invitationID, err := uuid.Parse(strings.TrimSpace(c.Params("invitationID")))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid invitation id")
+	}
+
+	if err := requireTenantPermission(c, h.container, tenantID, rbac.PermMembershipsWrite); err != nil {
+		return err
+	}
+
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant service unavailable")
+	}
+	if err := h.service.RevokeInvitation(c.Context(), tenantID, invitationID); err != nil {
+		return writeTenantServiceError(c, err)
+	}
+
+	if err := recordAudit(c, h.container, "membership.invite_revoke", "tenant", tenantID.String(), fiber.Map{
+		"invitation_id": invitationID.String(),
+	}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func toInvitationResponse(invitation admintenantsvc.Invitation) invitationResponse {
+	resp := invitationResponse{
+		ID:        invitation.ID.String(),
+		TenantID:  invitation.TenantID.String(),
+		Email:     invitation.Email,
+		Role:      string(invitation.Role),
+		ExpiresAt: invitation.ExpiresAt,
+		CreatedAt: invitation.CreatedAt,
+	}
+	if invitation.InvitedBy != nil {
+		inviter := invitation.InvitedBy.String()
+		resp.InvitedBy = &inviter
+	}
+	return resp
+}
+
 func (h *tenantHandler) lookupTenantName(ctx context.Context, tenantID uuid.UUID) string {
 	if h.container == nil || h.container.Queries == nil {
 		return ""
@@ -997,7 +1904,7 @@ func (h *tenantHandler) listBatches(c *fiber.Ctx) error {
 	if err != nil {
 		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid tenant id")
 	}
-	if err := requireTenantRole(c, h.container, tenantUUID, db.MembershipRoleAdmin); err != nil {
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermBatchesRead); err != nil {
 		return err
 	}
 	if h.container.Batches == nil {
@@ -1025,7 +1932,7 @@ func (h *tenantHandler) getBatch(c *fiber.Ctx) error {
 	if !ok {
 		return nil
 	}
-	if err := requireTenantRole(c, h.container, tenantUUID, db.MembershipRoleAdmin); err != nil {
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermBatchesRead); err != nil {
 		return err
 	}
 	if h.container.Batches == nil {
@@ -1046,7 +1953,7 @@ func (h *tenantHandler) cancelBatch(c *fiber.Ctx) error {
 	if !ok {
 		return nil
 	}
-	if err := requireTenantRole(c, h.container, tenantUUID, db.MembershipRoleAdmin); err != nil {
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermBatchesCancel); err != nil {
 		return err
 	}
 	if h.container.Batches == nil {
@@ -1075,7 +1982,7 @@ func (h *tenantHandler) streamBatchFile(c *fiber.Ctx, output bool) error {
 	if !ok {
 		return nil
 	}
-	if err := requireTenantRole(c, h.container, tenantUUID, db.MembershipRoleAdmin); err != nil {
+	if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermBatchesRead); err != nil {
 		return err
 	}
 	if h.container.Batches == nil || h.container.Files == nil {
@@ -1163,10 +2070,27 @@ func writeTenantServiceError(c *fiber.Ctx, err error) error {
 	switch {
 	case errors.Is(err, admintenantsvc.ErrInvalidModelList),
 		errors.Is(err, admintenantsvc.ErrModelNotFound),
-		errors.Is(err, admintenantsvc.ErrLocalAuthDisabled):
+		errors.Is(err, admintenantsvc.ErrInvalidModelPattern),
+		errors.Is(err, admintenantsvc.ErrLocalAuthDisabled),
+		errors.Is(err, admintenantsvc.ErrInvitationEmailMissing),
+		errors.Is(err, admintenantsvc.ErrMailerUnavailable),
+		errors.Is(err, admintenantsvc.ErrInvalidModelOverride),
+		errors.Is(err, admintenantsvc.ErrBulkTooLarge),
+		errors.Is(err, admintenantsvc.ErrTenantNameRequired):
 		status = fiber.StatusBadRequest
-	case errors.Is(err, admintenantsvc.ErrAPIKeyTenantMismatch):
+	case errors.Is(err, admintenantsvc.ErrAPIKeyTenantMismatch),
+		errors.Is(err, admintenantsvc.ErrTenantNotFound),
+		errors.Is(err, admintenantsvc.ErrInvitationNotFound),
+		errors.Is(err, admintenantsvc.ErrModelOverrideMissing),
+		errors.Is(err, admintenantsvc.ErrExportNotFound):
 		status = fiber.StatusNotFound
+	case errors.Is(err, admintenantsvc.ErrExportNotReady),
+		errors.Is(err, admintenantsvc.ErrAPIKeyNotSuspended):
+		status = fiber.StatusConflict
+	case errors.Is(err, admintenantsvc.ErrExportExpired):
+		status = fiber.StatusGone
+	case errors.Is(err, admintenantsvc.ErrExportUnavailable):
+		status = fiber.StatusNotImplemented
 	case errors.Is(err, admintenantsvc.ErrServiceUnavailable):
 		status = fiber.StatusInternalServerError
 	}
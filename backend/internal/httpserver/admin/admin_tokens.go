@@ -0,0 +1,191 @@
+package admin
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/auth"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	"github.com/ncecere/open_model_gateway/backend/internal/rbac"
+)
+
+func registerAdminTokenRoutes(router fiber.Router, container *app.Container) {
+	handler := &adminTokenHandler{container: container}
+	group := router.Group("/auth/tokens")
+	group.Get("/", handler.list)
+	group.Post("/", handler.create)
+	group.Delete("/:tokenID", handler.revoke)
+}
+
+type adminTokenHandler struct {
+	container *app.Container
+}
+
+type createAdminTokenRequest struct {
+	Name       string     `json:"name"`
+	Role       string     `json:"role"`
+	AllowedIPs []string   `json:"allowed_ips"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+}
+
+type adminTokenResponse struct {
+	ID         string     `json:"id"`
+	UserID     string     `json:"user_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Role       string     `json:"role"`
+	AllowedIPs []string   `json:"allowed_ips"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func (h *adminTokenHandler) list(c *fiber.Ctx) error {
+	if err := requireAnyPermission(c, h.container, rbac.PermTokensRead); err != nil {
+		return err
+	}
+	user, ok := adminUserFromContext(c.UserContext())
+	if !ok {
+		return httputil.WriteError(c, fiber.StatusUnauthorized, "missing admin context")
+	}
+
+	records, err := h.container.AdminAuth.ListAdminTokens(c.Context(), user)
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	tokens := make([]adminTokenResponse, 0, len(records))
+	for _, record := range records {
+		resp, err := toAdminTokenResponse(record)
+		if err != nil {
+			return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+		}
+		tokens = append(tokens, resp)
+	}
+	return c.JSON(fiber.Map{"tokens": tokens})
+}
+
+func (h *adminTokenHandler) create(c *fiber.Ctx) error {
+	if adminAuthenticatedByToken(c.UserContext()) {
+		return httputil.WriteError(c, fiber.StatusForbidden, "admin tokens cannot create other tokens")
+	}
+	if err := requireAnyPermission(c, h.container, rbac.PermTokensWrite); err != nil {
+		return err
+	}
+	user, ok := adminUserFromContext(c.UserContext())
+	if !ok {
+		return httputil.WriteError(c, fiber.StatusUnauthorized, "missing admin context")
+	}
+
+	var req createAdminTokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
+	}
+
+	record, token, err := h.container.AdminAuth.CreateAdminToken(c.Context(), user, auth.CreateAdminTokenInput{
+		Name:       req.Name,
+		Role:       req.Role,
+		AllowedIPs: req.AllowedIPs,
+		ExpiresAt:  req.ExpiresAt,
+	})
+	if err != nil {
+		if errors.Is(err, auth.ErrAdminTokenForbidden) {
+			return httputil.WriteError(c, fiber.StatusForbidden, err.Error())
+		}
+		return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	resp, err := toAdminTokenResponse(record)
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	if err := recordAudit(c, h.container, "admin_token.create", "admin_token", resp.ID, fiber.Map{
+		"name":        resp.Name,
+		"prefix":      resp.Prefix,
+		"role":        resp.Role,
+		"allowed_ips": resp.AllowedIPs,
+		"expires_at":  resp.ExpiresAt,
+	}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"token":       token,
+		"admin_token": resp,
+	})
+}
+
+func (h *adminTokenHandler) revoke(c *fiber.Ctx) error {
+	if err := requireAnyPermission(c, h.container, rbac.PermTokensWrite); err != nil {
+		return err
+	}
+	user, ok := adminUserFromContext(c.UserContext())
+	if !ok {
+		return httputil.WriteError(c, fiber.StatusUnauthorized, "missing admin context")
+	}
+	tokenID, err := uuid.Parse(strings.TrimSpace(c.Params("tokenID")))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid token id")
+	}
+
+	record, err := h.container.AdminAuth.RevokeAdminToken(c.Context(), user, tokenID)
+	if err != nil {
+		if errors.Is(err, auth.ErrAdminTokenNotFound) {
+			return httputil.WriteError(c, fiber.StatusNotFound, err.Error())
+		}
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	if err := recordAudit(c, h.container, "admin_token.revoke", "admin_token", tokenID.String(), fiber.Map{
+		"name":   record.Name,
+		"prefix": record.Prefix,
+	}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func toAdminTokenResponse(record db.AdminToken) (adminTokenResponse, error) {
+	id, err := uuidFromPg(record.ID)
+	if err != nil {
+		return adminTokenResponse{}, err
+	}
+	userID, err := uuidFromPg(record.UserID)
+	if err != nil {
+		return adminTokenResponse{}, err
+	}
+	created, err := timeFromPg(record.CreatedAt)
+	if err != nil {
+		return adminTokenResponse{}, err
+	}
+	allowed := record.AllowedIps
+	if allowed == nil {
+		allowed = []string{}
+	}
+	return adminTokenResponse{
+		ID:         id,
+		UserID:     userID,
+		Name:       record.Name,
+		Prefix:     record.Prefix,
+		Role:       record.Role,
+		AllowedIPs: allowed,
+		ExpiresAt:  optionalTime(record.ExpiresAt),
+		LastUsedAt: optionalTime(record.LastUsedAt),
+		RevokedAt:  optionalTime(record.RevokedAt),
+		CreatedAt:  created,
+	}, nil
+}
+
+func optionalTime(ts pgtype.Timestamptz) *time.Time {
+	if !ts.Valid {
+		return nil
+	}
+	t := ts.Time
+	return &t
+}
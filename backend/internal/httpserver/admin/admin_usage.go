@@ -1,6 +1,8 @@
 package admin
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
@@ -11,14 +13,23 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/ncecere/open_model_gateway/backend/internal/app"
-	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/currency"
 	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	"github.com/ncecere/open_model_gateway/backend/internal/rbac"
 	usageservice "github.com/ncecere/open_model_gateway/backend/internal/services/usage"
+	"github.com/ncecere/open_model_gateway/backend/internal/services/usagepipeline"
 )
 
+// usagePurger deletes old request log rows; *usagepipeline.Logger
+// implements it.
+type usagePurger interface {
+	PurgeUsage(ctx context.Context, tenantID *uuid.UUID, before time.Time) (int64, error)
+}
+
 type usageHandler struct {
 	container *app.Container
 	service   *usageservice.Service
+	purger    usagePurger
 }
 
 func registerAdminUsageRoutes(router fiber.Router, container *app.Container) {
@@ -26,18 +37,79 @@ func registerAdminUsageRoutes(router fiber.Router, container *app.Container) {
 		container: container,
 		service:   container.UsageService,
 	}
+	if container.UsageLogger != nil {
+		handler.purger = container.UsageLogger
+	}
 
 	group := router.Group("/usage")
 	group.Get("/summary", handler.summary)
 	group.Get("/breakdown", handler.breakdown)
+	group.Get("/suspicious-patterns", handler.suspiciousPatterns)
 	group.Get("/compare", handler.compare)
+	group.Get("/chargeback", handler.chargeback)
+	group.Get("/archive/list", handler.archiveList)
+	group.Get("/archive/:name/download", handler.archiveDownload)
 	group.Get("/tenant/daily", handler.tenantDaily)
 	group.Get("/user/daily", handler.userDaily)
 	group.Get("/model/daily", handler.modelDaily)
+	group.Delete("", handler.purge)
+}
+
+// purge deletes request log rows older than before, optionally for a single
+// tenant. The last seven days are off limits so billing stays intact.
+func (h *usageHandler) purge(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	if h.purger == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "usage logger unavailable")
+	}
+	beforeRaw := strings.TrimSpace(c.Query("before"))
+	if beforeRaw == "" {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "before is required")
+	}
+	before, err := time.Parse(time.RFC3339, beforeRaw)
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid before timestamp")
+	}
+
+	var tenantPtr *uuid.UUID
+	resourceID := "all"
+	if raw := strings.TrimSpace(c.Query("tenant_id")); raw != "" {
+		tenantID, err := uuid.Parse(raw)
+		if err != nil {
+			return httputil.WriteError(c, fiber.StatusBadRequest, "invalid tenant_id")
+		}
+		tenantPtr = &tenantID
+		resourceID = tenantID.String()
+	}
+
+	deleted, purgeErr := h.purger.PurgeUsage(c.Context(), tenantPtr, before)
+	if errors.Is(purgeErr, usagepipeline.ErrPurgeTooRecent) {
+		return httputil.WriteError(c, fiber.StatusBadRequest, purgeErr.Error())
+	}
+
+	// Batches deleted before a failure stay deleted, so a failed purge is
+	// audited too, with the rows it did remove.
+	metadata := fiber.Map{
+		"tenant_id": resourceID,
+		"before":    before.UTC().Format(time.RFC3339),
+		"deleted":   deleted,
+	}
+	if purgeErr != nil {
+		metadata["error"] = purgeErr.Error()
+	}
+	if err := recordAudit(c, h.container, "usage.purge", "usage", resourceID, metadata); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	if purgeErr != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, purgeErr.Error())
+	}
+	return c.JSON(fiber.Map{"deleted": deleted})
 }
 
 func (h *usageHandler) summary(c *fiber.Ctx) error {
-	if err := requireAnyRole(c, h.container, db.MembershipRoleViewer); err != nil {
+	if err := requireAnyPermission(c, h.container, rbac.PermUsageRead); err != nil {
 		return err
 	}
 	if h.service == nil {
@@ -61,13 +133,22 @@ func (h *usageHandler) summary(c *fiber.Ctx) error {
 		if err != nil {
 			return httputil.WriteError(c, fiber.StatusBadRequest, "invalid tenant_id")
 		}
-		if err := requireTenantRole(c, h.container, tenantUUID, db.MembershipRoleViewer); err != nil {
+		if err := requireTenantPermission(c, h.container, tenantUUID, rbac.PermUsageRead); err != nil {
 			return err
 		}
 		tenantPtr = &tenantUUID
 	}
 
-	summary, err := h.service.SummarizeAdminUsage(c.Context(), period, tenantPtr, timezone, startPtr, endPtr)
+	filter, err := parseUsageFilter(c.Query("tags_filter"), c.Query("metadata_filter"), c.Query("top_tags"))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+	}
+	rate, ok := h.currencyRate(c)
+	if !ok {
+		return nil
+	}
+
+	summary, err := h.service.SummarizeAdminUsage(c.Context(), period, tenantPtr, timezone, startPtr, endPtr, filter)
 	if err != nil {
 		switch {
 		case errors.Is(err, usageservice.ErrInvalidPeriod):
@@ -79,11 +160,12 @@ func (h *usageHandler) summary(c *fiber.Ctx) error {
 		}
 		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
 	}
+	rate.ApplySummary(&summary)
 	return c.JSON(summary)
 }
 
 func (h *usageHandler) breakdown(c *fiber.Ctx) error {
-	if err := requireAnyRole(c, h.container, db.MembershipRoleViewer); err != nil {
+	if err := requireAnyPermission(c, h.container, rbac.PermUsageRead); err != nil {
 		return err
 	}
 	if h.service == nil {
@@ -105,6 +187,14 @@ func (h *usageHandler) breakdown(c *fiber.Ctx) error {
 	if err != nil {
 		return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
 	}
+	filter, err := parseUsageFilter(c.Query("tags_filter"), c.Query("metadata_filter"), c.Query("top_tags"))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+	}
+	rate, ok := h.currencyRate(c)
+	if !ok {
+		return nil
+	}
 
 	result, err := h.service.BreakdownAdminUsage(c.Context(), usageservice.AdminBreakdownParams{
 		Group:         group,
@@ -114,6 +204,7 @@ func (h *usageHandler) breakdown(c *fiber.Ctx) error {
 		Timezone:      timezone,
 		StartOverride: startPtr,
 		EndOverride:   endPtr,
+		Filter:        filter,
 	})
 	if err != nil {
 		switch {
@@ -125,15 +216,51 @@ func (h *usageHandler) breakdown(c *fiber.Ctx) error {
 			return httputil.WriteError(c, fiber.StatusBadRequest, "invalid date range")
 		case errors.Is(err, usageservice.ErrInvalidBreakdownType):
 			return httputil.WriteError(c, fiber.StatusBadRequest, "group must be tenant, model, or user")
+		case errors.Is(err, usageservice.ErrTagFilterUnsupported):
+			return httputil.WriteError(c, fiber.StatusBadRequest, "tags_filter and metadata_filter support tenant and model groups only")
 		default:
 			return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
 		}
 	}
+	rate.ApplyBreakdown(&result)
 	return c.JSON(result)
 }
 
+// suspiciousPatterns lists API keys repeating one request fingerprint more
+// than threshold times within period (default 100 in 1h).
+func (h *usageHandler) suspiciousPatterns(c *fiber.Ctx) error {
+	if err := requireAnyPermission(c, h.container, rbac.PermUsageRead); err != nil {
+		return err
+	}
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "usage service unavailable")
+	}
+	threshold := int64(parsePositiveInt(c.Query("threshold"), 100))
+	period := time.Hour
+	if raw := strings.TrimSpace(c.Query("period")); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			return httputil.WriteError(c, fiber.StatusBadRequest, "invalid period")
+		}
+		period = parsed
+	}
+	patterns, err := h.service.SuspiciousPatterns(c.Context(), threshold, period, time.Now())
+	if err != nil {
+		if errors.Is(err, usageservice.ErrInvalidSuspiciousQuery) {
+			return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+		}
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(fiber.Map{
+		"object":    "list",
+		"threshold": threshold,
+		"period":    period.String(),
+		"data":      patterns,
+	})
+}
+
 func (h *usageHandler) compare(c *fiber.Ctx) error {
-	if err := requireAnyRole(c, h.container, db.MembershipRoleViewer); err != nil {
+	if err := requireAnyPermission(c, h.container, rbac.PermUsageRead); err != nil {
 		return err
 	}
 	if h.service == nil {
@@ -153,7 +280,7 @@ func (h *usageHandler) compare(c *fiber.Ctx) error {
 		return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
 	}
 	for _, id := range tenantIDs {
-		if err := requireTenantRole(c, h.container, id, db.MembershipRoleViewer); err != nil {
+		if err := requireTenantPermission(c, h.container, id, rbac.PermUsageRead); err != nil {
 			return err
 		}
 	}
@@ -162,8 +289,19 @@ func (h *usageHandler) compare(c *fiber.Ctx) error {
 		return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
 	}
 	aliases := parseAliasList(c.Query("model_aliases"))
-	if len(tenantIDs) == 0 && len(aliases) == 0 && len(userIDs) == 0 {
-		return httputil.WriteError(c, fiber.StatusBadRequest, "tenant_ids, user_ids, or model_aliases required")
+	topN := 0
+	if raw := strings.TrimSpace(c.Query("top_n")); raw != "" {
+		topN, err = strconv.Atoi(raw)
+		if err != nil || topN <= 0 {
+			return httputil.WriteError(c, fiber.StatusBadRequest, "top_n must be a positive integer")
+		}
+	}
+	if len(tenantIDs) == 0 && len(aliases) == 0 && len(userIDs) == 0 && topN == 0 {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "tenant_ids, user_ids, model_aliases, or top_n required")
+	}
+	rate, ok := h.currencyRate(c)
+	if !ok {
+		return nil
 	}
 	result, err := h.service.CompareUsage(c.Context(), usageservice.CompareUsageParams{
 		Period:       period,
@@ -173,6 +311,7 @@ func (h *usageHandler) compare(c *fiber.Ctx) error {
 		TenantIDs:    tenantIDs,
 		ModelAliases: aliases,
 		UserIDs:      userIDs,
+		TopN:         topN,
 	})
 	if err != nil {
 		switch {
@@ -183,18 +322,19 @@ func (h *usageHandler) compare(c *fiber.Ctx) error {
 		case errors.Is(err, usageservice.ErrInvalidTimezone):
 			return httputil.WriteError(c, fiber.StatusBadRequest, "invalid timezone")
 		case errors.Is(err, usageservice.ErrNoEntitiesSelected):
-			return httputil.WriteError(c, fiber.StatusBadRequest, "tenant_ids, user_ids, or model_aliases required")
+			return httputil.WriteError(c, fiber.StatusBadRequest, "tenant_ids, user_ids, model_aliases, or top_n required")
 		case errors.Is(err, usageservice.ErrEntityLimitExceeded):
 			return httputil.WriteError(c, fiber.StatusBadRequest, "too many entities requested")
 		default:
 			return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
 		}
 	}
+	rate.ApplyCompare(&result)
 	return c.JSON(result)
 }
 
 func (h *usageHandler) tenantDaily(c *fiber.Ctx) error {
-	if err := requireAnyRole(c, h.container, db.MembershipRoleViewer); err != nil {
+	if err := requireAnyPermission(c, h.container, rbac.PermUsageRead); err != nil {
 		return err
 	}
 	if h.service == nil {
@@ -208,7 +348,7 @@ func (h *usageHandler) tenantDaily(c *fiber.Ctx) error {
 	if err != nil {
 		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid tenant_id")
 	}
-	if err := requireTenantRole(c, h.container, tenantID, db.MembershipRoleViewer); err != nil {
+	if err := requireTenantPermission(c, h.container, tenantID, rbac.PermUsageRead); err != nil {
 		return err
 	}
 	startPtr, endPtr, err := parseRangeParams(c.Query("start"), c.Query("end"))
@@ -234,7 +374,7 @@ func (h *usageHandler) tenantDaily(c *fiber.Ctx) error {
 }
 
 func (h *usageHandler) userDaily(c *fiber.Ctx) error {
-	if err := requireAnyRole(c, h.container, db.MembershipRoleViewer); err != nil {
+	if err := requireAnyPermission(c, h.container, rbac.PermUsageRead); err != nil {
 		return err
 	}
 	if h.service == nil {
@@ -271,7 +411,7 @@ func (h *usageHandler) userDaily(c *fiber.Ctx) error {
 }
 
 func (h *usageHandler) modelDaily(c *fiber.Ctx) error {
-	if err := requireAnyRole(c, h.container, db.MembershipRoleViewer); err != nil {
+	if err := requireAnyPermission(c, h.container, rbac.PermUsageRead); err != nil {
 		return err
 	}
 	if h.service == nil {
@@ -303,6 +443,25 @@ func (h *usageHandler) modelDaily(c *fiber.Ctx) error {
 	return c.JSON(result)
 }
 
+// currencyRate resolves the currency query parameter, defaulting to the
+// configured budget currency, and writes a 400 when it cannot be converted.
+func (h *usageHandler) currencyRate(c *fiber.Ctx) (usageservice.CurrencyRate, bool) {
+	code := strings.TrimSpace(c.Query("currency"))
+	if code == "" && h.container.Config != nil {
+		code = h.container.Config.Budgets.Currency
+	}
+	rate, err := h.service.ResolveCurrency(c.Context(), code, time.Now().UTC())
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		if errors.Is(err, currency.ErrUnknownCurrency) || errors.Is(err, usageservice.ErrCurrencyRateMissing) {
+			status = fiber.StatusBadRequest
+		}
+		_ = httputil.WriteError(c, status, err.Error())
+		return rate, false
+	}
+	return rate, true
+}
+
 func parsePositiveInt(raw string, fallback int) int {
 	if raw == "" {
 		return fallback
@@ -370,3 +529,30 @@ func parseRangeParams(startRaw, endRaw string) (*time.Time, *time.Time, error) {
 	}
 	return &start, &end, nil
 }
+
+const maxTopTags = 50
+
+// parseUsageFilter reads tags_filter (a JSON object of tag values),
+// metadata_filter (a JSON object matched against X-Request-Metadata), and
+// top_tags (how many top tag values to return) from the query string.
+func parseUsageFilter(tagsRaw, metadataRaw, topRaw string) (usageservice.AdminUsageFilter, error) {
+	var filter usageservice.AdminUsageFilter
+	if clean := strings.TrimSpace(tagsRaw); clean != "" {
+		if err := json.Unmarshal([]byte(clean), &filter.Tags); err != nil {
+			return usageservice.AdminUsageFilter{}, fmt.Errorf("tags_filter must be a JSON object of string values")
+		}
+	}
+	if clean := strings.TrimSpace(metadataRaw); clean != "" {
+		if err := json.Unmarshal([]byte(clean), &filter.Metadata); err != nil || filter.Metadata == nil {
+			return usageservice.AdminUsageFilter{}, fmt.Errorf("metadata_filter must be a JSON object")
+		}
+	}
+	if clean := strings.TrimSpace(topRaw); clean != "" {
+		value, err := strconv.Atoi(clean)
+		if err != nil || value < 0 || value > maxTopTags {
+			return usageservice.AdminUsageFilter{}, fmt.Errorf("top_tags must be between 0 and %d", maxTopTags)
+		}
+		filter.TopTags = value
+	}
+	return filter, nil
+}
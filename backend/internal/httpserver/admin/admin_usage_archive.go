@@ -0,0 +1,90 @@
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	usagearchivesvc "github.com/ncecere/open_model_gateway/backend/internal/services/usagearchive"
+)
+
+type usageArchiveResponse struct {
+	Name        string    `json:"name"`
+	Table       string    `json:"table"`
+	Period      string    `json:"period"`
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+	RowCount    int64     `json:"row_count"`
+	SizeBytes   int64     `json:"size_bytes"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// archiveList returns the request log archives written by the archival
+// worker. Archives span every tenant, so only super admins may read them.
+func (h *usageHandler) archiveList(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	if h.container.UsageArchive == nil {
+		return httputil.WriteError(c, fiber.StatusNotImplemented, "usage archival disabled")
+	}
+	records, err := h.container.UsageArchive.List(c.Context())
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	archives := make([]usageArchiveResponse, 0, len(records))
+	for _, record := range records {
+		archives = append(archives, toUsageArchiveResponse(record))
+	}
+	return c.JSON(fiber.Map{"archives": archives})
+}
+
+// archiveDownload streams one gzipped NDJSON archive.
+func (h *usageHandler) archiveDownload(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	if h.container.UsageArchive == nil {
+		return httputil.WriteError(c, fiber.StatusNotImplemented, "usage archival disabled")
+	}
+	name := strings.TrimSpace(c.Params("name"))
+	if name == "" {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "archive name required")
+	}
+	reader, record, err := h.container.UsageArchive.Open(c.UserContext(), name)
+	if err != nil {
+		if errors.Is(err, usagearchivesvc.ErrArchiveNotFound) {
+			return httputil.WriteError(c, fiber.StatusNotFound, "archive not found")
+		}
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	if err := recordAudit(c, h.container, "usage.archive_download", "usage_archive", record.Name, fiber.Map{
+		"period_start": record.PeriodStart.Time,
+		"rows":         record.RowCount,
+	}); err != nil {
+		reader.Close()
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	c.Set(fiber.HeaderContentType, "application/gzip")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", record.Name))
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.SendStream(reader)
+}
+
+func toUsageArchiveResponse(record db.ArchivedPartition) usageArchiveResponse {
+	return usageArchiveResponse{
+		Name:        record.Name,
+		Table:       record.TableName,
+		Period:      record.Period,
+		PeriodStart: record.PeriodStart.Time,
+		PeriodEnd:   record.PeriodEnd.Time,
+		RowCount:    record.RowCount,
+		SizeBytes:   record.SizeBytes,
+		CreatedAt:   record.CreatedAt.Time,
+	}
+}
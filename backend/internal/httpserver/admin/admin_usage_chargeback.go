@@ -0,0 +1,105 @@
+package admin
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	filesvc "github.com/ncecere/open_model_gateway/backend/internal/services/files"
+	usageservice "github.com/ncecere/open_model_gateway/backend/internal/services/usage"
+)
+
+// chargeback returns per-tenant, per-model spend for chargeback reports.
+// format=jsonl streams a header line followed by one line per tenant, and
+// snapshot=true also stores each tenant's slice of the report in that
+// tenant's files so it can be downloaded later.
+func (h *usageHandler) chargeback(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "usage service unavailable")
+	}
+	format := strings.ToLower(strings.TrimSpace(c.Query("format", "json")))
+	if format != "json" && format != "jsonl" {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "format must be json or jsonl")
+	}
+	period := strings.TrimSpace(c.Query("period"))
+	if period == "" {
+		period = "30d"
+	}
+
+	rate, ok := h.currencyRate(c)
+	if !ok {
+		return nil
+	}
+
+	report, err := h.service.Chargeback(c.Context(), period)
+	if err != nil {
+		if errors.Is(err, usageservice.ErrInvalidPeriod) {
+			return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+		}
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	rate.ApplyChargeback(&report)
+
+	if c.QueryBool("snapshot") {
+		if h.container.Files == nil {
+			return httputil.WriteError(c, fiber.StatusNotImplemented, "files service unavailable")
+		}
+		if err := h.storeChargebackSnapshots(c, &report); err != nil {
+			return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+		}
+	}
+
+	if format == "json" {
+		return c.JSON(report)
+	}
+	c.Set(fiber.HeaderContentType, "application/x-ndjson")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="chargeback-%s-%s.jsonl"`, report.Period, time.Now().UTC().Format("20060102")))
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := report.WriteJSONL(w); err != nil {
+			fmt.Fprintf(w, "\nexport failed: %v\n", err)
+		}
+		w.Flush()
+	})
+	return nil
+}
+
+func (h *usageHandler) storeChargebackSnapshots(c *fiber.Ctx, report *usageservice.ChargebackReport) error {
+	filename := fmt.Sprintf("chargeback_%s_%s.jsonl", report.Period, time.Now().UTC().Format("20060102"))
+	for i := range report.Tenants {
+		tenantID, err := uuid.Parse(report.Tenants[i].TenantID)
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		if err := report.ForTenant(i).WriteJSONL(&buf); err != nil {
+			return err
+		}
+		record, err := h.container.Files.Upload(c.UserContext(), filesvc.UploadParams{
+			TenantID:    tenantID,
+			Filename:    filename,
+			Purpose:     filesvc.PurposeChargeback,
+			ContentType: "application/x-ndjson",
+			ContentLen:  int64(buf.Len()),
+			Reader:      &buf,
+		})
+		if err != nil {
+			return fmt.Errorf("store chargeback snapshot for tenant %s: %w", tenantID, err)
+		}
+		report.Tenants[i].SnapshotFileID = record.ID.String()
+	}
+	return recordAudit(c, h.container, "usage.chargeback_snapshot", "usage", "chargeback", fiber.Map{
+		"period":   report.Period,
+		"currency": report.Currency,
+		"tenants":  len(report.Tenants),
+	})
+}
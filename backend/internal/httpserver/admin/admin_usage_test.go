@@ -0,0 +1,77 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	adminauditsvc "github.com/ncecere/open_model_gateway/backend/internal/services/adminaudit"
+)
+
+func TestParseUsageFilterReadsMetadataFilter(t *testing.T) {
+	filter, err := parseUsageFilter(`{"team":"search"}`, `{"project":"llm-ops"}`, "5")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if filter.Tags["team"] != "search" || filter.Metadata["project"] != "llm-ops" || filter.TopTags != 5 {
+		t.Fatalf("unexpected filter %+v", filter)
+	}
+
+	for _, raw := range []string{"project=llm-ops", "[1]", "null"} {
+		if _, err := parseUsageFilter("", raw, ""); err == nil {
+			t.Fatalf("expected metadata_filter %q to be rejected", raw)
+		}
+	}
+}
+
+type stubPurger struct {
+	deleted int64
+	err     error
+}
+
+func (s *stubPurger) PurgeUsage(context.Context, *uuid.UUID, time.Time) (int64, error) {
+	return s.deleted, s.err
+}
+
+func TestPurgeAuditsPartialDeletesOnFailure(t *testing.T) {
+	audit := &stubAuditRecorder{}
+	handler := &usageHandler{
+		container: &app.Container{AdminAudit: adminauditsvc.NewService(audit)},
+		purger:    &stubPurger{deleted: 3000, err: errors.New("connection reset")},
+	}
+	fiberApp := fiber.New()
+	fiberApp.Use(respondedErrors(), func(c *fiber.Ctx) error {
+		ctx := context.WithValue(c.UserContext(), adminContextUserKey, db.User{IsSuperAdmin: true})
+		ctx = context.WithValue(ctx, adminContextUserIDKey, uuid.New())
+		c.SetUserContext(ctx)
+		return c.Next()
+	})
+	fiberApp.Delete("/usage", handler.purge)
+
+	before := time.Now().Add(-30 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	resp, err := fiberApp.Test(httptest.NewRequest(fiber.MethodDelete, "/usage?before="+before, nil))
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", resp.StatusCode)
+	}
+	if len(audit.entries) != 1 {
+		t.Fatalf("expected the failed purge to be audited, got %d entries", len(audit.entries))
+	}
+	var metadata map[string]any
+	if err := json.Unmarshal(audit.entries[0].Metadata, &metadata); err != nil {
+		t.Fatalf("decode metadata: %v", err)
+	}
+	if metadata["deleted"] != float64(3000) || metadata["error"] != "connection reset" {
+		t.Fatalf("unexpected audit metadata %v", metadata)
+	}
+}
@@ -10,8 +10,8 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/ncecere/open_model_gateway/backend/internal/app"
-	"github.com/ncecere/open_model_gateway/backend/internal/db"
 	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	"github.com/ncecere/open_model_gateway/backend/internal/rbac"
 	adminusersvc "github.com/ncecere/open_model_gateway/backend/internal/services/adminuser"
 )
 
@@ -24,6 +24,9 @@ func registerAdminUserRoutes(router fiber.Router, container *app.Container) {
 	group.Get("/", handler.list)
 	group.Post("/", handler.create)
 	group.Get("/:userID/tenants", handler.listUserTenants)
+	group.Delete("/:userID/data", handler.eraseUserData)
+	group.Post("/:userID/tenant-scopes", handler.addTenantScope)
+	group.Delete("/:userID/tenant-scopes/:tenantID", handler.removeTenantScope)
 }
 
 type adminUserHandler struct {
@@ -48,6 +51,10 @@ type userTenantMembershipResponse struct {
 	JoinedAt   time.Time `json:"joined_at"`
 }
 
+type tenantScopeRequest struct {
+	TenantID string `json:"tenant_id"`
+}
+
 type createUserRequest struct {
 	Email    string `json:"email"`
 	Name     string `json:"name"`
@@ -55,7 +62,7 @@ type createUserRequest struct {
 }
 
 func (h *adminUserHandler) list(c *fiber.Ctx) error {
-	if err := requireAnyRole(c, h.container, db.MembershipRoleAdmin); err != nil {
+	if err := requireAnyPermission(c, h.container, rbac.PermUsersRead); err != nil {
 		return err
 	}
 	if h.service == nil {
@@ -93,7 +100,7 @@ func (h *adminUserHandler) list(c *fiber.Ctx) error {
 }
 
 func (h *adminUserHandler) create(c *fiber.Ctx) error {
-	if err := requireAnyRole(c, h.container, db.MembershipRoleAdmin); err != nil {
+	if err := requireAnyPermission(c, h.container, rbac.PermUsersWrite); err != nil {
 		return err
 	}
 	if h.service == nil {
@@ -140,7 +147,7 @@ func mapAdminUser(user adminusersvc.User) adminUserResponse {
 }
 
 func (h *adminUserHandler) listUserTenants(c *fiber.Ctx) error {
-	if err := requireAnyRole(c, h.container, db.MembershipRoleAdmin); err != nil {
+	if err := requireAnyPermission(c, h.container, rbac.PermUsersRead); err != nil {
 		return err
 	}
 	if h.container == nil || h.container.Queries == nil {
@@ -176,3 +183,104 @@ func (h *adminUserHandler) listUserTenants(c *fiber.Ctx) error {
 		"tenants": resp,
 	})
 }
+
+func (h *adminUserHandler) eraseUserData(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "user service unavailable")
+	}
+	userID, err := uuid.Parse(strings.TrimSpace(c.Params("userID")))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid user id")
+	}
+	if actorID, ok := adminUserIDFromContext(c.UserContext()); ok && actorID == userID {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "cannot erase your own account")
+	}
+
+	if err := h.service.EraseUser(c.Context(), userID); err != nil {
+		if errors.Is(err, adminusersvc.ErrUserNotFound) {
+			return httputil.WriteError(c, fiber.StatusNotFound, err.Error())
+		}
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	if err := recordAudit(c, h.container, "admin_user.erase", "user", userID.String(), fiber.Map{}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// addTenantScope grants a user admin access to one tenant. Only super admins
+// may grant scopes so tenant admins cannot elevate other users.
+func (h *adminUserHandler) addTenantScope(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "user service unavailable")
+	}
+	userID, err := uuid.Parse(strings.TrimSpace(c.Params("userID")))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid user id")
+	}
+	var req tenantScopeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
+	}
+	tenantID, err := uuid.Parse(strings.TrimSpace(req.TenantID))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid tenant id")
+	}
+
+	if err := h.service.AddTenantScope(c.Context(), userID, tenantID); err != nil {
+		switch {
+		case errors.Is(err, adminusersvc.ErrUserNotFound), errors.Is(err, adminusersvc.ErrTenantNotFound):
+			return httputil.WriteError(c, fiber.StatusNotFound, err.Error())
+		default:
+			return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+		}
+	}
+
+	if err := recordAudit(c, h.container, "admin_user.scope_add", "user", userID.String(), fiber.Map{
+		"tenant_id": tenantID.String(),
+	}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"user_id":   userID.String(),
+		"tenant_id": tenantID.String(),
+	})
+}
+
+func (h *adminUserHandler) removeTenantScope(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "user service unavailable")
+	}
+	userID, err := uuid.Parse(strings.TrimSpace(c.Params("userID")))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid user id")
+	}
+	tenantID, err := uuid.Parse(strings.TrimSpace(c.Params("tenantID")))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid tenant id")
+	}
+
+	if err := h.service.RemoveTenantScope(c.Context(), userID, tenantID); err != nil {
+		if errors.Is(err, adminusersvc.ErrScopeNotFound) {
+			return httputil.WriteError(c, fiber.StatusNotFound, err.Error())
+		}
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	if err := recordAudit(c, h.container, "admin_user.scope_remove", "user", userID.String(), fiber.Map{
+		"tenant_id": tenantID.String(),
+	}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
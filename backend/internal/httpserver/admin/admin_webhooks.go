@@ -0,0 +1,160 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	webhooksvc "github.com/ncecere/open_model_gateway/backend/internal/services/webhooks"
+)
+
+func registerAdminWebhookRoutes(router fiber.Router, container *app.Container) {
+	handler := &webhookHandler{container: container, service: container.Webhooks}
+	group := router.Group("/webhooks")
+	group.Get("/deliveries", handler.listDeliveries)
+	group.Post("/deliveries/:deliveryID/retry", handler.retryDelivery)
+	group.Post("/verify-signature", handler.verifySignature)
+}
+
+type webhookHandler struct {
+	container *app.Container
+	service   *webhooksvc.Service
+}
+
+type webhookDeliveryResponse struct {
+	ID          string          `json:"id"`
+	Event       string          `json:"event"`
+	WebhookURL  string          `json:"webhook_url"`
+	Payload     json.RawMessage `json:"payload"`
+	Status      string          `json:"status"`
+	Attempts    int             `json:"attempts"`
+	LastError   string          `json:"last_error,omitempty"`
+	NextRetryAt *time.Time      `json:"next_retry_at,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// Deliveries span every tenant's alert channels, so inspection and retries
+// are limited to super admins.
+func (h *webhookHandler) listDeliveries(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "webhook service unavailable")
+	}
+
+	limit := int32(50)
+	offset := int32(0)
+	if v := strings.TrimSpace(c.Query("limit")); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			limit = int32(n)
+		}
+	}
+	if v := strings.TrimSpace(c.Query("offset")); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			offset = int32(n)
+		}
+	}
+
+	records, err := h.service.List(c.Context(), c.Query("status"), limit, offset)
+	if err != nil {
+		if errors.Is(err, webhooksvc.ErrInvalidStatus) {
+			return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+		}
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	deliveries := make([]webhookDeliveryResponse, 0, len(records))
+	for _, record := range records {
+		deliveries = append(deliveries, mapWebhookDelivery(record))
+	}
+	return c.JSON(fiber.Map{
+		"deliveries": deliveries,
+		"limit":      limit,
+		"offset":     offset,
+	})
+}
+
+func (h *webhookHandler) retryDelivery(c *fiber.Ctx) error {
+	if err := requireSuperAdmin(c); err != nil {
+		return err
+	}
+	if h.service == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "webhook service unavailable")
+	}
+	deliveryID, err := uuid.Parse(strings.TrimSpace(c.Params("deliveryID")))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid delivery id")
+	}
+
+	delivery, err := h.service.Retry(c.Context(), deliveryID)
+	if err != nil {
+		if errors.Is(err, webhooksvc.ErrDeliveryNotFound) {
+			return httputil.WriteError(c, fiber.StatusNotFound, err.Error())
+		}
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	if err := recordAudit(c, h.container, "webhook_delivery.retry", "webhook_delivery", deliveryID.String(), fiber.Map{
+		"event":       delivery.Event,
+		"webhook_url": delivery.URL,
+	}); err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.Status(fiber.StatusAccepted).JSON(mapWebhookDelivery(delivery))
+}
+
+type verifySignatureRequest struct {
+	Secret    string `json:"secret"`
+	Timestamp string `json:"timestamp"`
+	Signature string `json:"signature"`
+	// Payload is the raw request body exactly as delivered. A JSON string is
+	// used verbatim; any other JSON value is taken as its raw bytes.
+	Payload json.RawMessage `json:"payload"`
+}
+
+// verifySignature is a debugging aid for webhook receivers. Deliveries carry
+// X-Gateway-Timestamp (unix seconds) and X-Gateway-Signature
+// ("sha256=" + hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the
+// tenant's alert_webhook_secret, or budgets.alert.webhook.signing_secret).
+// The endpoint recomputes the signature and reports whether it matches.
+func (h *webhookHandler) verifySignature(c *fiber.Ctx) error {
+	var req verifySignatureRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
+	}
+	if req.Secret == "" || strings.TrimSpace(req.Timestamp) == "" || strings.TrimSpace(req.Signature) == "" {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "secret, timestamp, and signature are required")
+	}
+	body := []byte(req.Payload)
+	var text string
+	if err := json.Unmarshal(req.Payload, &text); err == nil {
+		body = []byte(text)
+	}
+	return c.JSON(fiber.Map{
+		"valid":     webhooksvc.Verify(req.Secret, req.Timestamp, body, req.Signature),
+		"algorithm": `sha256=hex(HMAC-SHA256(secret, timestamp + "." + payload))`,
+	})
+}
+
+func mapWebhookDelivery(delivery webhooksvc.Delivery) webhookDeliveryResponse {
+	return webhookDeliveryResponse{
+		ID:          delivery.ID.String(),
+		Event:       delivery.Event,
+		WebhookURL:  delivery.URL,
+		Payload:     delivery.Payload,
+		Status:      delivery.Status,
+		Attempts:    delivery.Attempts,
+		LastError:   delivery.LastError,
+		NextRetryAt: delivery.NextRetryAt,
+		CreatedAt:   delivery.CreatedAt,
+		UpdatedAt:   delivery.UpdatedAt,
+	}
+}
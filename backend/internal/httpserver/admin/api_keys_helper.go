@@ -21,9 +21,10 @@ type apiKeyIssuer struct {
 }
 
 type rateLimitDetails struct {
-    RequestsPerMinute int `json:"requests_per_minute"`
-    TokensPerMinute   int `json:"tokens_per_minute"`
-    ParallelRequests  int `json:"parallel_requests"`
+    RequestsPerMinute         int `json:"requests_per_minute"`
+    TokensPerMinute           int `json:"tokens_per_minute"`
+    ParallelRequests          int `json:"parallel_requests"`
+    ParallelRequestTTLSeconds int `json:"parallel_request_ttl_seconds"`
 }
 
 type rateLimitPayload struct {
@@ -46,6 +47,9 @@ type apiKeyResponse struct {
     RevokedAt             *time.Time        `json:"revoked_at,omitempty"`
     LastUsedAt            *time.Time        `json:"last_used_at,omitempty"`
     Revoked               bool              `json:"revoked"`
+    SuspendedAt           *time.Time        `json:"suspended_at,omitempty"`
+    SuspensionReason      string            `json:"suspension_reason,omitempty"`
+    ZeroRetention         bool              `json:"zero_retention"`
 }
 
 type createAPIKeyResponse struct {
@@ -112,6 +116,9 @@ func buildAPIKeyResponse(ctx context.Context, container *app.Container, key db.A
         RevokedAt:             revokedAt,
         LastUsedAt:            lastUsed,
         Revoked:               revokedAt != nil,
+        SuspendedAt:           optionalTime(key.SuspendedAt),
+        SuspensionReason:      key.SuspensionReason,
+        ZeroRetention:         key.ZeroRetention,
     }, nil
 }
 
@@ -143,14 +150,16 @@ func buildRateLimitPayload(container *app.Container, prefix string, tenantID uui
     keyCfg, tenantCfg := container.EffectiveRateLimits(prefix, tenantID)
     return &rateLimitPayload{
         Key: rateLimitDetails{
-            RequestsPerMinute: keyCfg.RequestsPerMinute,
-            TokensPerMinute:   keyCfg.TokensPerMinute,
-            ParallelRequests:  keyCfg.ParallelRequests,
+            RequestsPerMinute:         keyCfg.RequestsPerMinute,
+            TokensPerMinute:           keyCfg.TokensPerMinute,
+            ParallelRequests:          keyCfg.ParallelRequests,
+            ParallelRequestTTLSeconds: int(keyCfg.ParallelRequestTTL / time.Second),
         },
         Tenant: rateLimitDetails{
-            RequestsPerMinute: tenantCfg.RequestsPerMinute,
-            TokensPerMinute:   tenantCfg.TokensPerMinute,
-            ParallelRequests:  tenantCfg.ParallelRequests,
+            RequestsPerMinute:         tenantCfg.RequestsPerMinute,
+            TokensPerMinute:           tenantCfg.TokensPerMinute,
+            ParallelRequests:          tenantCfg.ParallelRequests,
+            ParallelRequestTTLSeconds: int(tenantCfg.ParallelRequestTTL / time.Second),
         },
     }
 }
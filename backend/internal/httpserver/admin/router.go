@@ -11,7 +11,7 @@ func Register(app *fiber.App, container *app.Container) {
 	authGroup := app.Group("/admin/auth")
 	registerAdminAuthRoutes(authGroup, container)
 
-	protected := app.Group("/admin", adminAuthMiddleware(container))
+	protected := app.Group("/admin", respondedErrors(), adminAuthMiddleware(container))
 	registerAdminModelCatalogRoutes(protected, container)
 	registerAdminAuditRoutes(protected, container)
 	registerAdminDefaultModelRoutes(protected, container)
@@ -21,8 +21,18 @@ func Register(app *fiber.App, container *app.Container) {
 	registerAdminUserRoutes(protected, container)
 	registerAdminAPIKeyRoutes(protected, container)
 	registerAdminUsageRoutes(protected, container)
+	registerAdminDashboardRoutes(protected, container)
 	registerAdminSettingsRoutes(protected, container)
+	registerAdminCurrencyRateRoutes(protected, container)
+	registerAdminOIDCConfigRoutes(protected, container)
+	registerAdminRBACRoutes(protected, container)
+	registerAdminDebugRoutes(protected, container)
 	registerAdminBudgetRoutes(protected, container)
 	registerAdminRateLimitRoutes(protected, container)
 	registerAdminProviderRoutes(protected, container)
+	registerAdminTokenRoutes(protected, container)
+	registerAdminRequestRoutes(protected, container)
+	registerAdminWebhookRoutes(protected, container)
+	registerAdminImpersonationRoutes(protected, container)
+	registerAdminNotificationRoutes(protected, container)
 }
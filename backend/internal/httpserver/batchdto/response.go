@@ -38,6 +38,7 @@ type Batch struct {
 	FailedAt         *time.Time            `json:"failed_at,omitempty"`
 	ExpiresAt        *time.Time            `json:"expires_at,omitempty"`
 	ExpiredAt        *time.Time            `json:"expired_at,omitempty"`
+	ScheduledAt      *time.Time            `json:"scheduled_at,omitempty"`
 	Errors           []batchsvc.BatchError `json:"errors,omitempty"`
 	Counts           Counts                `json:"counts"`
 }
@@ -103,6 +104,9 @@ func FromBatch(batch batchsvc.Batch) Batch {
 	if batch.ExpiredAt != nil {
 		resp.ExpiredAt = batch.ExpiredAt
 	}
+	if batch.ScheduledAt != nil {
+		resp.ScheduledAt = batch.ScheduledAt
+	}
 	if len(batch.Errors) > 0 {
 		resp.Errors = make([]batchsvc.BatchError, len(batch.Errors))
 		copy(resp.Errors, batch.Errors)
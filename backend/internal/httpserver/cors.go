@@ -0,0 +1,35 @@
+package httpserver
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+)
+
+// registerCORS mounts Fiber's CORS middleware ahead of the route groups so
+// pre-flight OPTIONS requests are answered before authentication runs. The
+// admin and user portal APIs share AdminCORS and allow credentials, since the
+// admin UI authenticates with a session cookie.
+func registerCORS(app *fiber.App, cfg config.ServerConfig) {
+	if cfg.CORS.Enabled {
+		app.Use("/v1", corsMiddleware(cfg.CORS, false))
+	}
+	if cfg.AdminCORS.Enabled {
+		admin := corsMiddleware(cfg.AdminCORS, true)
+		app.Use("/admin", admin)
+		app.Use("/user", admin)
+	}
+}
+
+func corsMiddleware(cfg config.CORSConfig, credentials bool) fiber.Handler {
+	return cors.New(cors.Config{
+		AllowOrigins:     strings.Join(cfg.AllowedOrigins, ","),
+		AllowMethods:     strings.Join(cfg.AllowedMethods, ","),
+		AllowHeaders:     strings.Join(cfg.AllowedHeaders, ","),
+		AllowCredentials: credentials,
+		MaxAge:           cfg.MaxAgeSec,
+	})
+}
@@ -0,0 +1,93 @@
+package httpserver
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+)
+
+func TestCORSPreflight(t *testing.T) {
+	fiberApp := fiber.New(fiber.Config{DisableStartupMessage: true})
+	registerCORS(fiberApp, config.ServerConfig{
+		CORS: config.CORSConfig{
+			Enabled:        true,
+			AllowedOrigins: []string{"https://app.example.com"},
+			AllowedMethods: []string{"GET", "POST"},
+			AllowedHeaders: []string{"Authorization", "Content-Type"},
+			MaxAgeSec:      600,
+		},
+		AdminCORS: config.CORSConfig{
+			Enabled:        true,
+			AllowedOrigins: []string{"https://admin.example.com"},
+			AllowedMethods: []string{"GET", "PUT"},
+		},
+	})
+	unauthorized := func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusUnauthorized) }
+	fiberApp.Group("/v1", unauthorized).Post("/chat/completions", unauthorized)
+	fiberApp.Group("/admin", unauthorized).Get("/tenants", unauthorized)
+
+	preflight := func(path, origin string) (int, func(string) string) {
+		req := httptest.NewRequest(fiber.MethodOptions, path, nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", "POST")
+		resp, err := fiberApp.Test(req)
+		if err != nil {
+			t.Fatalf("preflight %s: %v", path, err)
+		}
+		return resp.StatusCode, resp.Header.Get
+	}
+
+	status, header := preflight("/v1/chat/completions", "https://app.example.com")
+	if status != fiber.StatusNoContent {
+		t.Fatalf("expected 204 before auth runs, got %d", status)
+	}
+	if got := header("Access-Control-Allow-Origin"); got != "https://app.example.com" {
+		t.Fatalf("Access-Control-Allow-Origin = %q", got)
+	}
+	if got := header("Access-Control-Allow-Methods"); got != "GET,POST" {
+		t.Fatalf("Access-Control-Allow-Methods = %q", got)
+	}
+	if got := header("Access-Control-Allow-Headers"); got != "Authorization,Content-Type" {
+		t.Fatalf("Access-Control-Allow-Headers = %q", got)
+	}
+	if got := header("Access-Control-Max-Age"); got != "600" {
+		t.Fatalf("Access-Control-Max-Age = %q", got)
+	}
+	if got := header("Access-Control-Allow-Credentials"); got != "" {
+		t.Fatalf("public API must not allow credentials, got %q", got)
+	}
+
+	_, header = preflight("/v1/chat/completions", "https://evil.example.com")
+	if got := header("Access-Control-Allow-Origin"); got != "" {
+		t.Fatalf("unlisted origin should not be allowed, got %q", got)
+	}
+
+	_, header = preflight("/admin/tenants", "https://app.example.com")
+	if got := header("Access-Control-Allow-Origin"); got != "" {
+		t.Fatalf("public origin should not reach the admin API, got %q", got)
+	}
+	_, header = preflight("/admin/tenants", "https://admin.example.com")
+	if header("Access-Control-Allow-Origin") != "https://admin.example.com" || header("Access-Control-Allow-Credentials") != "true" {
+		t.Fatalf("admin preflight: origin=%q credentials=%q", header("Access-Control-Allow-Origin"), header("Access-Control-Allow-Credentials"))
+	}
+}
+
+func TestCORSDisabledByDefault(t *testing.T) {
+	fiberApp := fiber.New(fiber.Config{DisableStartupMessage: true})
+	registerCORS(fiberApp, config.ServerConfig{})
+	fiberApp.Post("/v1/chat/completions", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+	req := httptest.NewRequest(fiber.MethodOptions, "/v1/chat/completions", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	resp, err := fiberApp.Test(req)
+	if err != nil {
+		t.Fatalf("preflight: %v", err)
+	}
+	if got := resp.Header.Get("Access-Control-Allow-Origin"); got != "" {
+		t.Fatalf("CORS should be off unless enabled, got %q", got)
+	}
+}
@@ -4,22 +4,34 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/jackc/pgx/v5"
+	"github.com/google/uuid"
 
 	"github.com/ncecere/open_model_gateway/backend/internal/app"
 	"github.com/ncecere/open_model_gateway/backend/internal/auth"
-	"github.com/ncecere/open_model_gateway/backend/internal/db"
 	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
 	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
 )
 
 const authBearerPrefix = "bearer "
 
-// apiKeyAuth validates the Authorization bearer token and injects request metadata.
+// apiKeyAuth validates the Authorization bearer token and injects request
+// metadata. With server.proxy_auth enabled, requests from a trusted proxy that
+// carry the user ID header are authenticated as that user instead.
 func apiKeyAuth(container *app.Container) fiber.Handler {
+	var proxy *trustedProxyAuth
+	if container != nil && container.Config != nil {
+		proxy = newTrustedProxyAuth(container.Config.Server.ProxyAuth)
+	}
 	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		if userID, ok := proxy.userID(c); ok {
+			rc, err := container.AuthenticateProxyUser(userContext(c), userID)
+			return continueAuthenticated(c, rc, err, start)
+		}
+
 		raw := strings.TrimSpace(c.Get(fiber.HeaderAuthorization))
 		if raw == "" {
 			return httputil.WriteError(c, fiber.StatusUnauthorized, "authorization header required")
@@ -30,88 +42,57 @@ func apiKeyAuth(container *app.Container) fiber.Handler {
 		}
 
 		key := strings.TrimSpace(raw[len(authBearerPrefix):])
-		prefix, secret, err := splitAPIKey(key)
-		if err != nil {
-			return httputil.WriteError(c, fiber.StatusUnauthorized, err.Error())
-		}
-
 		ctx := userContext(c)
-		record, err := container.Queries.GetAPIKeyByPrefix(ctx, prefix)
-		if err != nil {
-			if errors.Is(err, pgx.ErrNoRows) {
-				return httputil.WriteError(c, fiber.StatusUnauthorized, "invalid api key")
-			}
-			return httputil.WriteError(c, fiber.StatusInternalServerError, "api key lookup failed")
+		var (
+			rc  *requestctx.Context
+			err error
+		)
+		if auth.LooksLikeImpersonationToken(key) {
+			rc, err = container.AuthenticateImpersonation(ctx, key)
+		} else {
+			rc, err = container.AuthenticateAPIKey(ctx, key)
 		}
+		return continueAuthenticated(c, rc, err, start)
+	}
+}
 
-		if record.RevokedAt.Valid {
-			return httputil.WriteError(c, fiber.StatusUnauthorized, "api key revoked")
+// continueAuthenticated reports an authentication failure or attaches rc and
+// runs the next handler, charging the time since start to the auth stage of a
+// traced request.
+func continueAuthenticated(c *fiber.Ctx, rc *requestctx.Context, err error, start time.Time) error {
+	if err != nil {
+		var authErr *app.APIKeyAuthError
+		if errors.As(err, &authErr) {
+			return httputil.WriteError(c, authErr.Status, authErr.Message)
 		}
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
 
-		if record.SecretHash == "" {
-			return httputil.WriteError(c, fiber.StatusUnauthorized, "api key invalid")
-		}
+	requestctx.TimingsFromContext(userContext(c)).Track(requestctx.StageAuth, start)
+	c.Locals(requestctx.FiberLocalsKey(), rc)
+	newCtx := requestctx.WithContext(userContext(c), rc)
+	c.SetUserContext(newCtx)
 
-		match, err := auth.VerifyPassword(secret, record.SecretHash)
-		if err != nil {
-			return httputil.WriteError(c, fiber.StatusInternalServerError, "api key verification failed")
-		}
-		if !match {
-			return httputil.WriteError(c, fiber.StatusUnauthorized, "invalid api key")
-		}
+	return c.Next()
+}
 
-		tenant, err := container.Queries.GetTenantByID(ctx, record.TenantID)
-		if err != nil {
-			if errors.Is(err, pgx.ErrNoRows) {
-				return httputil.WriteError(c, fiber.StatusUnauthorized, "tenant not found")
+// requireAPIKey rejects impersonation tokens and proxy-authenticated users on
+// routes whose work outlives the request and is attributed to a stored API
+// key.
+func requireAPIKey() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if rc, ok := requestctx.FromContext(c.UserContext()); ok && rc != nil {
+			if rc.ImpersonatedBy != uuid.Nil {
+				return httputil.WriteError(c, fiber.StatusForbidden, "impersonation tokens cannot use this endpoint")
+			}
+			if rc.APIKeyID == uuid.Nil {
+				return httputil.WriteError(c, fiber.StatusForbidden, "this endpoint requires an api key")
 			}
-			return httputil.WriteError(c, fiber.StatusInternalServerError, "tenant lookup failed")
-		}
-		if tenant.Status != db.TenantStatusActive {
-			return httputil.WriteError(c, fiber.StatusForbidden, "tenant is not active")
-		}
-
-		rc, err := app.BuildRequestContext(ctx, container, record)
-		if err != nil {
-			return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
-		}
-
-		if err := container.Queries.UpdateAPIKeyLastUsed(ctx, record.ID); err != nil {
-			return httputil.WriteError(c, fiber.StatusInternalServerError, "failed to update key usage")
 		}
-
-		c.Locals(requestctx.FiberLocalsKey(), rc)
-		newCtx := requestctx.WithContext(ctx, rc)
-		c.SetUserContext(newCtx)
-
 		return c.Next()
 	}
 }
 
-func splitAPIKey(token string) (string, string, error) {
-	if token == "" {
-		return "", "", errors.New("api key required")
-	}
-
-	withoutPrefix := strings.TrimPrefix(token, "sk-")
-	if withoutPrefix == token {
-		return "", "", errors.New("api key must start with sk-")
-	}
-
-	parts := strings.SplitN(withoutPrefix, ".", 2)
-	if len(parts) != 2 {
-		return "", "", errors.New("api key format invalid")
-	}
-
-	prefix := parts[0]
-	secret := strings.TrimSpace(parts[1])
-	if prefix == "" || secret == "" {
-		return "", "", errors.New("api key format invalid")
-	}
-
-	return prefix, secret, nil
-}
-
 func userContext(c *fiber.Ctx) context.Context {
 	if c == nil {
 		return context.Background()
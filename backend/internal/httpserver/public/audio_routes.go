@@ -10,6 +10,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 
+	"github.com/ncecere/open_model_gateway/backend/internal/executor"
 	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
 	"github.com/ncecere/open_model_gateway/backend/internal/limits"
 	"github.com/ncecere/open_model_gateway/backend/internal/models"
@@ -92,9 +93,11 @@ func (h *openAIHandler) invokeAudioTranscription(c *fiber.Ctx, inv audioInvocati
 	if !h.container.IsModelAllowed(rc.TenantID, inv.Model) {
 		return httputil.WriteError(c, fiber.StatusForbidden, "model not enabled for tenant")
 	}
-	routes := h.container.Engine.SelectRoutes(inv.Model)
-	if len(routes) == 0 {
-		return httputil.WriteError(c, fiber.StatusServiceUnavailable, "no backend available for model")
+	h.warnIfDeprecated(c, inv.Model)
+	routes, err := h.executor.SelectRoutes(rc, inv.Model)
+	if err != nil {
+		status, msg, _ := executor.AsAPIError(err)
+		return httputil.WriteError(c, status, msg)
 	}
 
 	traceID := traceIDFromContext(c)
@@ -104,7 +107,7 @@ func (h *openAIHandler) invokeAudioTranscription(c *fiber.Ctx, inv audioInvocati
 	if err != nil {
 		return httputil.WriteError(c, fiber.StatusInternalServerError, "failed to evaluate budget")
 	}
-	if budget.Exceeded {
+	if budget.Blocked() {
 		setBudgetHeaders(c, budget)
 		return httputil.WriteError(c, fiber.StatusForbidden, "tenant budget exceeded")
 	}
@@ -176,6 +179,7 @@ func (h *openAIHandler) invokeAudioTranscription(c *fiber.Ctx, inv audioInvocati
 			Context:   rc,
 			Alias:     alias,
 			Provider:  route.Provider,
+			ABVariant: route.ABVariant,
 			Usage:     resp.Usage,
 			Latency:   elapsed,
 			Status:    fiber.StatusOK,
@@ -197,6 +201,7 @@ func (h *openAIHandler) invokeAudioTranscription(c *fiber.Ctx, inv audioInvocati
 			Context:   rc,
 			Alias:     alias,
 			Provider:  lastRoute.Provider,
+			ABVariant: lastRoute.ABVariant,
 			Latency:   lastLatency,
 			Status:    fiber.StatusBadGateway,
 			ErrorCode: lastErr.Error(),
@@ -262,16 +267,18 @@ func (h *openAIHandler) invokeAudioSpeech(c *fiber.Ctx, req models.AudioSpeechRe
 	if !h.container.IsModelAllowed(rc.TenantID, alias) {
 		return httputil.WriteError(c, fiber.StatusForbidden, "model not enabled for tenant")
 	}
-	routes := h.container.Engine.SelectRoutes(alias)
-	if len(routes) == 0 {
-		return httputil.WriteError(c, fiber.StatusServiceUnavailable, "no backend available for model")
+	h.warnIfDeprecated(c, alias)
+	routes, err := h.executor.SelectRoutes(rc, alias)
+	if err != nil {
+		status, msg, _ := executor.AsAPIError(err)
+		return httputil.WriteError(c, status, msg)
 	}
 	traceID := traceIDFromContext(c)
 	budget, err := h.container.UsageLogger.CheckBudget(ctx, rc, time.Now().UTC())
 	if err != nil {
 		return httputil.WriteError(c, fiber.StatusInternalServerError, "failed to evaluate budget")
 	}
-	if budget.Exceeded {
+	if budget.Blocked() {
 		setBudgetHeaders(c, budget)
 		return httputil.WriteError(c, fiber.StatusForbidden, "tenant budget exceeded")
 	}
@@ -336,6 +343,7 @@ func (h *openAIHandler) invokeAudioSpeech(c *fiber.Ctx, req models.AudioSpeechRe
 			Context:   rc,
 			Alias:     alias,
 			Provider:  route.Provider,
+			ABVariant: route.ABVariant,
 			Usage:     resp.Usage,
 			Latency:   elapsed,
 			Status:    fiber.StatusOK,
@@ -363,6 +371,7 @@ func (h *openAIHandler) invokeAudioSpeech(c *fiber.Ctx, req models.AudioSpeechRe
 			Context:   rc,
 			Alias:     alias,
 			Provider:  lastRoute.Provider,
+			ABVariant: lastRoute.ABVariant,
 			Latency:   lastLatency,
 			Status:    fiber.StatusBadGateway,
 			ErrorCode: lastErr.Error(),
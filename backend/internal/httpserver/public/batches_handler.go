@@ -1,6 +1,8 @@
 package public
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -8,6 +10,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -29,29 +32,36 @@ type createBatchRequest struct {
 	CompletionWindow string            `json:"completion_window"`
 	Metadata         map[string]string `json:"metadata"`
 	MaxConcurrency   *int              `json:"max_concurrency"`
+	// ScheduledAt is a Unix timestamp; the batch waits in the scheduled
+	// status until then.
+	ScheduledAt *int64 `json:"scheduled_at"`
 }
 
 type openAIBatchResponse struct {
-	ID               string                `json:"id"`
-	Object           string                `json:"object"`
-	Endpoint         string                `json:"endpoint"`
-	Status           string                `json:"status"`
-	CompletionWindow string                `json:"completion_window"`
-	CreatedAt        int64                 `json:"created_at"`
-	InProgressAt     *int64                `json:"in_progress_at"`
-	CompletedAt      *int64                `json:"completed_at"`
-	CancelledAt      *int64                `json:"cancelled_at"`
-	FailedAt         *int64                `json:"failed_at"`
-	FinalizingAt     *int64                `json:"finalizing_at"`
-	CancellingAt     *int64                `json:"cancelling_at"`
-	ExpiresAt        *int64                `json:"expires_at"`
-	ExpiredAt        *int64                `json:"expired_at"`
-	InputFileID      string                `json:"input_file_id"`
-	OutputFileID     *string               `json:"output_file_id"`
-	ErrorFileID      *string               `json:"error_file_id"`
-	RequestCounts    openAIBatchCounts     `json:"request_counts"`
-	Metadata         map[string]string     `json:"metadata,omitempty"`
-	Errors           *openAIBatchErrorList `json:"errors,omitempty"`
+	ID               string            `json:"id"`
+	Object           string            `json:"object"`
+	Endpoint         string            `json:"endpoint"`
+	Status           string            `json:"status"`
+	CompletionWindow string            `json:"completion_window"`
+	CreatedAt        int64             `json:"created_at"`
+	InProgressAt     *int64            `json:"in_progress_at"`
+	CompletedAt      *int64            `json:"completed_at"`
+	CancelledAt      *int64            `json:"cancelled_at"`
+	FailedAt         *int64            `json:"failed_at"`
+	FinalizingAt     *int64            `json:"finalizing_at"`
+	CancellingAt     *int64            `json:"cancelling_at"`
+	ExpiresAt        *int64            `json:"expires_at"`
+	ExpiredAt        *int64            `json:"expired_at"`
+	ScheduledAt      *int64            `json:"scheduled_at,omitempty"`
+	InputFileID      string            `json:"input_file_id"`
+	OutputFileID     *string           `json:"output_file_id"`
+	ErrorFileID      *string           `json:"error_file_id"`
+	RequestCounts    openAIBatchCounts `json:"request_counts"`
+	// CompletedRequestCounts is bumped as each item finishes, so it tracks
+	// how many results /items/next can already return.
+	CompletedRequestCounts int                   `json:"completed_request_counts"`
+	Metadata               map[string]string     `json:"metadata,omitempty"`
+	Errors                 *openAIBatchErrorList `json:"errors,omitempty"`
 }
 
 type openAIBatchCounts struct {
@@ -61,6 +71,21 @@ type openAIBatchCounts struct {
 	Cancelled int `json:"cancelled"`
 }
 
+type batchItemLine struct {
+	ID          string          `json:"id"`
+	ItemIndex   int64           `json:"item_index"`
+	CustomID    string          `json:"custom_id,omitempty"`
+	Status      string          `json:"status"`
+	Response    json.RawMessage `json:"response"`
+	Error       json.RawMessage `json:"error"`
+	CompletedAt *int64          `json:"completed_at,omitempty"`
+}
+
+const (
+	defaultBatchItemsLimit = 50
+	maxBatchItemsLimit     = 1000
+)
+
 type openAIBatchList struct {
 	Object  string                `json:"object"`
 	Data    []openAIBatchResponse `json:"data"`
@@ -104,6 +129,11 @@ func (h *batchHandler) create(c *fiber.Ctx) error {
 	if err := validateBatchMetadata(req.Metadata); err != nil {
 		return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
 	}
+	var scheduledAt *time.Time
+	if req.ScheduledAt != nil {
+		ts := time.Unix(*req.ScheduledAt, 0).UTC()
+		scheduledAt = &ts
+	}
 
 	if alias := rc.TenantID; alias == (uuid.UUID{}) {
 		slog.Error("batch create missing tenant id", slog.String("api_key", rc.APIKeyPrefix))
@@ -117,6 +147,7 @@ func (h *batchHandler) create(c *fiber.Ctx) error {
 		InputFileID:      inputID,
 		Metadata:         req.Metadata,
 		MaxConcurrency:   maxConc,
+		ScheduledAt:      scheduledAt,
 	})
 	if err != nil {
 		return h.translateBatchError(c, err)
@@ -307,6 +338,87 @@ func (h *batchHandler) errors(c *fiber.Ctx) error {
 	return nil
 }
 
+// nextItems streams finished items after the ?after index (default -1) as
+// NDJSON so clients can consume results before the batch finishes.
+func (h *batchHandler) nextItems(c *fiber.Ctx) error {
+	rc, err := h.requireContext(c)
+	if err != nil {
+		return err
+	}
+	if h.container.Batches == nil {
+		return httputil.WriteError(c, fiber.StatusNotImplemented, "batches not enabled")
+	}
+	batchID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid batch id")
+	}
+	limit, after, err := parseBatchItemsQuery(c.Query("limit"), c.Query("after"))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+	}
+	items, err := h.container.Batches.ListResultItems(c.UserContext(), rc.TenantID, batchID, after, limit)
+	if err != nil {
+		return h.translateBatchError(c, err)
+	}
+
+	body, err := encodeBatchItems(items)
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	c.Set("Content-Type", "application/x-ndjson")
+	c.Set("Cache-Control", "no-store")
+	return c.Send(body)
+}
+
+func parseBatchItemsQuery(limitRaw, afterRaw string) (int32, int64, error) {
+	limit := int64(defaultBatchItemsLimit)
+	if strings.TrimSpace(limitRaw) != "" {
+		parsed, err := strconv.ParseInt(strings.TrimSpace(limitRaw), 10, 32)
+		if err != nil || parsed <= 0 {
+			return 0, 0, fmt.Errorf("limit must be a positive integer")
+		}
+		limit = min(parsed, maxBatchItemsLimit)
+	}
+	after := int64(-1)
+	if strings.TrimSpace(afterRaw) != "" {
+		parsed, err := strconv.ParseInt(strings.TrimSpace(afterRaw), 10, 64)
+		if err != nil || parsed < -1 {
+			return 0, 0, fmt.Errorf("after must be an item index")
+		}
+		after = parsed
+	}
+	return int32(limit), after, nil
+}
+
+func encodeBatchItems(items []batchsvc.ResultItem) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, item := range items {
+		line := batchItemLine{
+			ID:        item.ID.String(),
+			ItemIndex: item.Index,
+			CustomID:  item.CustomID,
+			Status:    item.Status,
+			Response:  item.Response,
+			Error:     item.Error,
+		}
+		if len(line.Response) == 0 {
+			line.Response = json.RawMessage("null")
+		}
+		if len(line.Error) == 0 {
+			line.Error = json.RawMessage("null")
+		}
+		if item.CompletedAt != nil {
+			ts := item.CompletedAt.Unix()
+			line.CompletedAt = &ts
+		}
+		if err := enc.Encode(line); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
 func (h *batchHandler) requireContext(c *fiber.Ctx) (*requestctx.Context, error) {
 	rc, ok := requestctx.FromContext(c.UserContext())
 	if !ok || rc == nil {
@@ -319,7 +431,7 @@ func (h *batchHandler) translateBatchError(c *fiber.Ctx, err error) error {
 	switch {
 	case err == nil:
 		return nil
-	case errors.Is(err, batchsvc.ErrUnsupportedEndpoint), errors.Is(err, batchsvc.ErrFilePurposeMismatch):
+	case errors.Is(err, batchsvc.ErrUnsupportedEndpoint), errors.Is(err, batchsvc.ErrFilePurposeMismatch), errors.Is(err, batchsvc.ErrScheduleInPast):
 		return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
 	case errors.Is(err, pgx.ErrNoRows):
 		return httputil.WriteError(c, fiber.StatusNotFound, "batch not found or cannot transition")
@@ -363,6 +475,7 @@ func toOpenAIBatch(batch batchsvc.Batch) openAIBatchResponse {
 			Failed:    batch.RequestCountFailed,
 			Cancelled: batch.RequestCountCancelled,
 		},
+		CompletedRequestCounts: batch.RequestCountCompleted,
 	}
 	if len(batch.Errors) > 0 {
 		errList := openAIBatchErrorList{
@@ -411,6 +524,10 @@ func toOpenAIBatch(batch batchsvc.Batch) openAIBatchResponse {
 		ts := batch.ExpiredAt.Unix()
 		resp.ExpiredAt = &ts
 	}
+	if batch.ScheduledAt != nil {
+		ts := batch.ScheduledAt.Unix()
+		resp.ScheduledAt = &ts
+	}
 	if batch.ResultFileID != nil {
 		id := batch.ResultFileID.String()
 		resp.OutputFileID = &id
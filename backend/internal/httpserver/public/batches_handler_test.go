@@ -95,3 +95,39 @@ func TestToOpenAIBatchIncludesErrorsAndTimestamps(t *testing.T) {
 	require.NotNil(t, resp.ExpiredAt)
 	require.Equal(t, expired.Unix(), *resp.ExpiredAt)
 }
+
+func TestParseBatchItemsQuery(t *testing.T) {
+	limit, after, err := parseBatchItemsQuery("", "")
+	require.NoError(t, err)
+	require.Equal(t, int32(defaultBatchItemsLimit), limit)
+	require.Equal(t, int64(-1), after)
+
+	limit, after, err = parseBatchItemsQuery("5000", "41")
+	require.NoError(t, err)
+	require.Equal(t, int32(maxBatchItemsLimit), limit)
+	require.Equal(t, int64(41), after)
+
+	_, _, err = parseBatchItemsQuery("0", "")
+	require.Error(t, err)
+	_, _, err = parseBatchItemsQuery("", "-2")
+	require.Error(t, err)
+}
+
+func TestEncodeBatchItemsWritesNDJSON(t *testing.T) {
+	completed := time.Unix(1700000000, 0)
+	body, err := encodeBatchItems([]batchsvc.ResultItem{
+		{ID: uuid.New(), Index: 0, CustomID: "a", Status: "completed", Response: []byte(`{"status_code":200}`), CompletedAt: &completed},
+		{ID: uuid.New(), Index: 1, Status: "failed", Error: []byte(`{"message":"boom"}`)},
+	})
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(body)), "\n")
+	require.Len(t, lines, 2)
+	require.Contains(t, lines[0], `"item_index":0`)
+	require.Contains(t, lines[0], `"response":{"status_code":200}`)
+	require.Contains(t, lines[0], `"completed_at":1700000000`)
+	require.Contains(t, lines[0], `"status":"completed"`)
+	require.Contains(t, lines[0], `"error":null`)
+	require.Contains(t, lines[1], `"status":"failed"`)
+	require.Contains(t, lines[1], `"response":null`)
+	require.Contains(t, lines[1], `"error":{"message":"boom"}`)
+}
@@ -0,0 +1,24 @@
+package public
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+)
+
+// tenantBodyLimit rejects requests whose declared Content-Length exceeds the
+// tenant's max_request_body_mb override. The server-wide BodyLimitMB still
+// applies to every tenant.
+func tenantBodyLimit() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		rc, ok := c.Locals(requestctx.FiberLocalsKey()).(*requestctx.Context)
+		if !ok || rc == nil || rc.RequestBodyLimit <= 0 {
+			return c.Next()
+		}
+		if int64(c.Request().Header.ContentLength()) > rc.RequestBodyLimit {
+			return httputil.WriteError(c, fiber.StatusRequestEntityTooLarge, "request_too_large")
+		}
+		return c.Next()
+	}
+}
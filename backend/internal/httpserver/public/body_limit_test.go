@@ -0,0 +1,57 @@
+package public
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+)
+
+func newBodyLimitTestApp(limit int64) *fiber.App {
+	fiberApp := fiber.New(fiber.Config{BodyLimit: 8 * 1024 * 1024})
+	fiberApp.Post("/v1/chat/completions", func(c *fiber.Ctx) error {
+		c.Locals(requestctx.FiberLocalsKey(), &requestctx.Context{RequestBodyLimit: limit})
+		return c.Next()
+	}, tenantBodyLimit(), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	return fiberApp
+}
+
+func TestTenantBodyLimitRejectsLargeBody(t *testing.T) {
+	const mb = 1024 * 1024
+	fiberApp := newBodyLimitTestApp(1 * mb)
+
+	resp, err := fiberApp.Test(httptest.NewRequest(fiber.MethodPost, "/v1/chat/completions", bytes.NewReader(make([]byte, 2*mb))), -1)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != fiber.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413 for a 2MB body, got %d", resp.StatusCode)
+	}
+
+	resp, err = fiberApp.Test(httptest.NewRequest(fiber.MethodPost, "/v1/chat/completions", bytes.NewReader(make([]byte, mb/2))), -1)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected 200 under the cap, got %d", resp.StatusCode)
+	}
+}
+
+func TestTenantBodyLimitUnsetAllowsBody(t *testing.T) {
+	fiberApp := newBodyLimitTestApp(0)
+	resp, err := fiberApp.Test(httptest.NewRequest(fiber.MethodPost, "/v1/chat/completions", bytes.NewReader(make([]byte, 2*1024*1024))), -1)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected 200 without a tenant cap, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,19 @@
+package public
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	usagepipeline "github.com/ncecere/open_model_gateway/backend/internal/services/usagepipeline"
+)
+
+// writeHoldError answers a failed budget pre-authorization. Requests that
+// would overrun the remaining budget, counting other in-flight holds, get 403.
+func writeHoldError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, usagepipeline.ErrInsufficientBudget) {
+		return httputil.WriteError(c, fiber.StatusForbidden, "insufficient budget for request")
+	}
+	return httputil.WriteError(c, fiber.StatusInternalServerError, "failed to evaluate budget")
+}
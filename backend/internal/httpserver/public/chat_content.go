@@ -0,0 +1,73 @@
+package public
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+)
+
+// UnmarshalJSON accepts OpenAI's two content forms: a plain string, or an
+// array of {"type":"text"} and {"type":"image_url"} parts. Text parts are
+// joined into Content; when any image is present the full part list is kept
+// in Parts so vision-capable routes can forward it.
+func (m *openAIChatMessage) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Role    string          `json:"role"`
+		Content json.RawMessage `json:"content"`
+		Name    string          `json:"name"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	m.Role, m.Name, m.Content, m.Parts = raw.Role, raw.Name, "", nil
+
+	content := bytes.TrimSpace(raw.Content)
+	if len(content) == 0 || bytes.Equal(content, []byte("null")) {
+		return nil
+	}
+	if content[0] != '[' {
+		return json.Unmarshal(content, &m.Content)
+	}
+
+	var parts []struct {
+		Type     string `json:"type"`
+		Text     string `json:"text"`
+		ImageURL *struct {
+			URL    string `json:"url"`
+			Detail string `json:"detail"`
+		} `json:"image_url"`
+	}
+	if err := json.Unmarshal(content, &parts); err != nil {
+		return err
+	}
+	texts := make([]string, 0, len(parts))
+	converted := make([]models.ChatContentPart, 0, len(parts))
+	hasImage := false
+	for _, part := range parts {
+		switch part.Type {
+		case "text":
+			texts = append(texts, part.Text)
+			converted = append(converted, models.ChatContentPart{Type: "text", Text: part.Text})
+		case "image_url":
+			if part.ImageURL == nil || strings.TrimSpace(part.ImageURL.URL) == "" {
+				return fmt.Errorf("image_url part requires a url")
+			}
+			hasImage = true
+			converted = append(converted, models.ChatContentPart{
+				Type:        "image_url",
+				ImageURL:    strings.TrimSpace(part.ImageURL.URL),
+				ImageDetail: part.ImageURL.Detail,
+			})
+		default:
+			return fmt.Errorf("unsupported content part type %q", part.Type)
+		}
+	}
+	m.Content = strings.Join(texts, "\n")
+	if hasImage {
+		m.Parts = converted
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package public
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestChatMessageArrayContent(t *testing.T) {
+	var msg openAIChatMessage
+	raw := `{"role":"user","content":[{"type":"text","text":"what is this?"},{"type":"image_url","image_url":{"url":"https://example.com/cat.png","detail":"low"}}]}`
+	if err := json.Unmarshal([]byte(raw), &msg); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if msg.Content != "what is this?" {
+		t.Fatalf("expected joined text content, got %q", msg.Content)
+	}
+	if len(msg.Parts) != 2 || msg.Parts[1].ImageURL != "https://example.com/cat.png" || msg.Parts[1].ImageDetail != "low" {
+		t.Fatalf("unexpected parts %+v", msg.Parts)
+	}
+}
+
+func TestChatMessageTextContent(t *testing.T) {
+	var msg openAIChatMessage
+	if err := json.Unmarshal([]byte(`{"role":"user","content":"hi"}`), &msg); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if msg.Content != "hi" || msg.Parts != nil {
+		t.Fatalf("unexpected message %+v", msg)
+	}
+
+	if err := json.Unmarshal([]byte(`{"role":"user","content":[{"type":"text","text":"a"},{"type":"text","text":"b"}]}`), &msg); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if msg.Content != "a\nb" || msg.Parts != nil {
+		t.Fatalf("text-only parts should flatten without Parts, got %+v", msg)
+	}
+
+	if err := json.Unmarshal([]byte(`{"role":"user","content":[{"type":"image_url","image_url":{}}]}`), &msg); err == nil {
+		t.Fatal("expected an error for an image part without a url")
+	}
+}
@@ -0,0 +1,133 @@
+package public
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/executor"
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+	"github.com/ncecere/open_model_gateway/backend/internal/providers"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+)
+
+const (
+	headerEstimateCost         = "X-Estimate-Cost"
+	headerEstimatedCostCents   = "X-Estimated-Cost-Cents"
+	headerEstimatedTokens      = "X-Estimated-Tokens"
+	headerCostEstimateAccuracy = "X-Cost-Estimate-Accuracy"
+)
+
+var errUnknownModel = errors.New("unknown model")
+
+// wantsCostEstimate reports whether the caller asked for a dry run. Dry runs
+// return estimate headers only: no provider call, budget check, rate-limit
+// slot, or usage record.
+func wantsCostEstimate(c *fiber.Ctx) bool {
+	return strings.EqualFold(strings.TrimSpace(c.Get(headerEstimateCost)), "true")
+}
+
+func (h *openAIHandler) catalogEntry(ctx context.Context, alias string) (db.ModelCatalog, error) {
+	entry, err := h.container.Queries.GetModelByAlias(ctx, alias)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.ModelCatalog{}, errUnknownModel
+	}
+	return entry, err
+}
+
+func writeCatalogError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, errUnknownModel) {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "unknown model")
+	}
+	return httputil.WriteError(c, fiber.StatusInternalServerError, "failed to load model")
+}
+
+func writeCostEstimate(c *fiber.Ctx, tokens int, cents int64) error {
+	c.Set(headerEstimatedCostCents, strconv.FormatInt(cents, 10))
+	c.Set(headerEstimatedTokens, strconv.Itoa(tokens))
+	c.Set(headerCostEstimateAccuracy, "approximate")
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// estimateChatCost prices the estimated prompt plus a completion buffer of
+// max_tokens, or budgets.estimate_completion_buffer_perc of the context window
+// when max_tokens is unset.
+func (h *openAIHandler) estimateChatCost(c *fiber.Ctx, alias string, req models.ChatRequest) error {
+	entry, err := h.catalogEntry(c.UserContext(), alias)
+	if err != nil {
+		return writeCatalogError(c, err)
+	}
+	tokens, cents := h.chatEstimate(alias, entry.Provider, entry.ContextWindow, req)
+	return writeCostEstimate(c, tokens, cents)
+}
+
+// chatEstimate returns the estimated token count and cost of req on alias.
+func (h *openAIHandler) chatEstimate(alias, provider string, contextWindow int32, req models.ChatRequest) (int, int64) {
+	prompt := h.container.TokenEstimators.For(provider).EstimateMessages(req.Messages)
+	completion := 0
+	if req.MaxTokens != nil && *req.MaxTokens > 0 {
+		completion = int(*req.MaxTokens)
+	} else if contextWindow > 0 {
+		completion = int(float64(contextWindow) * h.container.Config.Budgets.EstimateCompletionBufferPerc)
+	}
+	cents := h.container.UsageLogger.EstimateCostCents(alias, models.Usage{
+		PromptTokens:     int32(prompt),
+		CompletionTokens: int32(completion),
+		TotalTokens:      int32(prompt + completion),
+	})
+	return prompt + completion, cents
+}
+
+func (h *openAIHandler) estimateEmbeddingCost(c *fiber.Ctx, alias string, inputs []string) error {
+	entry, err := h.catalogEntry(c.UserContext(), alias)
+	if err != nil {
+		return writeCatalogError(c, err)
+	}
+	estimator := h.container.TokenEstimators.For(entry.Provider)
+	tokens := 0
+	for _, input := range inputs {
+		tokens += estimator.EstimateText(input)
+	}
+	cents := h.container.UsageLogger.EstimateCostCents(alias, models.Usage{
+		PromptTokens: int32(tokens),
+		TotalTokens:  int32(tokens),
+	})
+	return writeCostEstimate(c, tokens, cents)
+}
+
+// estimateImageCost mirrors image billing: the route's price_image_cents
+// override when configured, token pricing of the prompt otherwise.
+func (h *openAIHandler) estimateImageCost(c *fiber.Ctx, alias, prompt string) error {
+	entry, err := h.catalogEntry(c.UserContext(), alias)
+	if err != nil {
+		return writeCatalogError(c, err)
+	}
+	rc, _ := requestctx.FromContext(c.UserContext())
+	routes, err := h.executor.SelectRoutes(rc, alias)
+	if err != nil {
+		status, msg, _ := executor.AsAPIError(err)
+		return httputil.WriteError(c, status, msg)
+	}
+	tokens, cents := h.imageEstimate(alias, entry.Provider, prompt, routes[0])
+	return writeCostEstimate(c, tokens, cents)
+}
+
+// imageEstimate returns the prompt's token count and the cost image billing
+// would charge on route.
+func (h *openAIHandler) imageEstimate(alias, provider, prompt string, route providers.Route) (int, int64) {
+	tokens := h.container.TokenEstimators.For(provider).EstimateText(prompt)
+	if override := parseImageOverrideCost(route.Metadata); override != nil {
+		return tokens, *override
+	}
+	cents := h.container.UsageLogger.EstimateCostCents(alias, models.Usage{
+		PromptTokens: int32(tokens),
+		TotalTokens:  int32(tokens),
+	})
+	return tokens, cents
+}
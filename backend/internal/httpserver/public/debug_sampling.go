@@ -0,0 +1,73 @@
+package public
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/debug"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+)
+
+// debugSampling copies a random fraction of /v1 traffic into the container's
+// debug sample buffer. It is a pass-through unless DEBUG_SAMPLING_ENABLED was
+// set at startup. Streamed responses are recorded without their body. It runs
+// after authentication so zero-retention keys, and deployments with
+// retention.zero_retention, never have their bodies captured.
+func debugSampling(container *app.Container) fiber.Handler {
+	buffer := container.DebugSamples
+	if buffer == nil || container.Config == nil {
+		return func(c *fiber.Ctx) error { return c.Next() }
+	}
+	rate := container.Config.Debug.SampleRate
+	maxBody := container.Config.Debug.MaxBodyBytes
+	zeroRetention := container.Config.Retention.ZeroRetention
+	return func(c *fiber.Ctx) error {
+		if zeroRetention || rand.Float64() >= rate {
+			return c.Next()
+		}
+		rc, ok := c.Locals(requestctx.FiberLocalsKey()).(*requestctx.Context)
+		if !ok || rc == nil || rc.ZeroRetention {
+			return c.Next()
+		}
+
+		start := time.Now()
+		headers := make(map[string]string)
+		c.Request().Header.VisitAll(func(key, value []byte) {
+			headers[string(key)] = string(value)
+		})
+		sample := debug.DebugSample{
+			TraceID:     traceIDFromContext(c),
+			Method:      c.Method(),
+			Path:        c.Path(),
+			TenantID:    rc.TenantID.String(),
+			Headers:     debug.SanitizeHeaders(headers),
+			RequestBody: truncateBody(c.Body(), maxBody),
+		}
+
+		err := c.Next()
+
+		sample.DurationMS = time.Since(start).Milliseconds()
+		sample.CapturedAt = time.Now().UTC()
+		sample.Status = c.Response().StatusCode()
+		if err != nil {
+			if fe, ok := err.(*fiber.Error); ok {
+				sample.Status = fe.Code
+			}
+		}
+		if !c.Response().IsBodyStream() {
+			sample.ResponseBody = truncateBody(c.Response().Body(), maxBody)
+		}
+		buffer.Add(sample)
+		return err
+	}
+}
+
+func truncateBody(body []byte, limit int) string {
+	if limit > 0 && len(body) > limit {
+		return string(body[:limit])
+	}
+	return string(body)
+}
@@ -0,0 +1,106 @@
+package public
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/debug"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+)
+
+func TestDebugSamplingCapturesBodiesWithoutCredentials(t *testing.T) {
+	buffer := debug.NewSampleBuffer(10)
+	container := &app.Container{
+		Config:       &config.Config{Debug: config.DebugConfig{SampleRate: 1, MaxBodyBytes: 16}},
+		DebugSamples: buffer,
+	}
+	tenantID := uuid.New()
+
+	server := fiber.New()
+	server.Post("/v1/echo", withRequestContext(&requestctx.Context{TenantID: tenantID}), debugSampling(container), func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusCreated).SendString(`{"reply":"hello there, world"}`)
+	})
+
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/echo", strings.NewReader(`{"prompt":"hi"}`))
+	req.Header.Set("Authorization", "Bearer sk-live-secret")
+	req.Header.Set("Content-Type", "application/json")
+	if _, err := server.Test(req); err != nil {
+		t.Fatalf("request: %v", err)
+	}
+
+	samples := buffer.Snapshot()
+	if len(samples) != 1 {
+		t.Fatalf("expected one sample, got %d", len(samples))
+	}
+	sample := samples[0]
+	if sample.Method != fiber.MethodPost || sample.Path != "/v1/echo" || sample.Status != fiber.StatusCreated {
+		t.Fatalf("unexpected sample %+v", sample)
+	}
+	if sample.RequestBody != `{"prompt":"hi"}` || sample.ResponseBody != `{"reply":"hello ` {
+		t.Fatalf("unexpected bodies %q / %q", sample.RequestBody, sample.ResponseBody)
+	}
+	if sample.TenantID != tenantID.String() {
+		t.Fatalf("expected tenant %s, got %s", tenantID, sample.TenantID)
+	}
+	if _, ok := sample.Headers["Authorization"]; ok {
+		t.Fatal("authorization header must not be stored")
+	}
+	if sample.Headers["Content-Type"] != "application/json" {
+		t.Fatalf("expected content type to be kept, got %v", sample.Headers)
+	}
+}
+
+func TestDebugSamplingSkipsZeroRetention(t *testing.T) {
+	cases := []struct {
+		name      string
+		retention config.RetentionConfig
+		rc        *requestctx.Context
+	}{
+		{name: "zero-retention key", rc: &requestctx.Context{TenantID: uuid.New(), ZeroRetention: true}},
+		{name: "zero-retention deployment", retention: config.RetentionConfig{ZeroRetention: true}, rc: &requestctx.Context{TenantID: uuid.New()}},
+		{name: "unauthenticated"},
+	}
+	for _, tc := range cases {
+		buffer := debug.NewSampleBuffer(10)
+		container := &app.Container{
+			Config:       &config.Config{Debug: config.DebugConfig{SampleRate: 1}, Retention: tc.retention},
+			DebugSamples: buffer,
+		}
+		server := fiber.New()
+		server.Post("/v1/echo", withRequestContext(tc.rc), debugSampling(container), func(c *fiber.Ctx) error {
+			return c.SendString("secret reply")
+		})
+		if _, err := server.Test(httptest.NewRequest(fiber.MethodPost, "/v1/echo", strings.NewReader("secret prompt"))); err != nil {
+			t.Fatalf("%s: request: %v", tc.name, err)
+		}
+		if samples := buffer.Snapshot(); len(samples) != 0 {
+			t.Fatalf("%s: expected no samples, got %+v", tc.name, samples)
+		}
+	}
+}
+
+func withRequestContext(rc *requestctx.Context) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if rc != nil {
+			c.Locals(requestctx.FiberLocalsKey(), rc)
+		}
+		return c.Next()
+	}
+}
+
+func TestDebugSamplingDisabledWithoutBuffer(t *testing.T) {
+	server := fiber.New()
+	server.Get("/v1/ping", debugSampling(&app.Container{}), func(c *fiber.Ctx) error {
+		return c.SendString("pong")
+	})
+	resp, err := server.Test(httptest.NewRequest(fiber.MethodGet, "/v1/ping", nil))
+	if err != nil || resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected pass-through, got %v %v", resp, err)
+	}
+}
@@ -0,0 +1,29 @@
+package public
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// warnIfDeprecated adds an RFC 7234 Warning header when alias is deprecated.
+func (h *openAIHandler) warnIfDeprecated(c *fiber.Ctx, alias string) {
+	if h.container == nil || h.container.Engine == nil {
+		return
+	}
+	if at, message, ok := h.container.Engine.Deprecation(alias); ok {
+		c.Set(fiber.HeaderWarning, deprecationWarning(at, message))
+	}
+}
+
+// deprecationWarning formats a 299 (miscellaneous persistent) warning such as
+// `299 - "model deprecated; scheduled removal: 2026-01-31; use gpt-4o instead"`.
+func deprecationWarning(at time.Time, message string) string {
+	text := "model deprecated; scheduled removal: " + at.UTC().Format(time.DateOnly)
+	if message = strings.TrimSpace(message); message != "" {
+		text += "; " + message
+	}
+	text = strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(text)
+	return `299 - "` + text + `"`
+}
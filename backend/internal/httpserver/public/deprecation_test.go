@@ -0,0 +1,26 @@
+package public
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDeprecationWarning(t *testing.T) {
+	removal := time.Date(2026, 1, 31, 18, 0, 0, 0, time.FixedZone("PST", -8*3600))
+
+	got := deprecationWarning(removal, "use gpt-4o instead")
+	want := `299 - "model deprecated; scheduled removal: 2026-02-01; use gpt-4o instead"`
+	if got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+
+	got = deprecationWarning(removal, `  see "migration" notes `)
+	want = `299 - "model deprecated; scheduled removal: 2026-02-01; see \"migration\" notes"`
+	if got != want {
+		t.Fatalf("expected quotes escaped, got %s", got)
+	}
+
+	if got := deprecationWarning(removal, ""); got != `299 - "model deprecated; scheduled removal: 2026-02-01"` {
+		t.Fatalf("unexpected warning without message: %s", got)
+	}
+}
@@ -156,16 +156,41 @@ func (h *filesHandler) upload(c *fiber.Ctx) error {
 	}
 	file := fileHeaders[0]
 	purpose := form.Value["purpose"]
+	contentType := filesvc.ResolveContentType(file.Header.Get("Content-Type"), file.Filename)
+	if err := filesvc.ValidatePurposeMIME(firstValue(purpose), contentType, h.container.Config.Files.PurposeRules); err != nil {
+		return httputil.WriteError(c, fiber.StatusUnsupportedMediaType, err.Error())
+	}
 	reader, err := file.Open()
 	if err != nil {
 		return httputil.WriteError(c, fiber.StatusBadRequest, "failed to open file")
 	}
 	defer reader.Close()
+	if err := filesvc.VerifyMagicBytes(contentType, reader); err != nil {
+		if errors.Is(err, filesvc.ErrUnsupportedMediaType) {
+			return httputil.WriteError(c, fiber.StatusUnsupportedMediaType, err.Error())
+		}
+		return httputil.WriteError(c, fiber.StatusBadRequest, "failed to read file")
+	}
+	if firstValue(purpose) == filesvc.PurposeBatch {
+		if err := filesvc.ValidateBatchFile(c.UserContext(), reader, h.container.Config.Batches.MaxRequests, h.batchModelAliases(rc)); err != nil {
+			var verr *filesvc.BatchValidationError
+			if errors.As(err, &verr) {
+				return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+					"error":  "invalid batch file",
+					"errors": verr.Errors,
+				})
+			}
+			return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+		}
+		if _, err := reader.Seek(0, io.SeekStart); err != nil {
+			return httputil.WriteError(c, fiber.StatusInternalServerError, "failed to rewind file")
+		}
+	}
 	params := filesvc.UploadParams{
 		TenantID:    rc.TenantID,
 		Filename:    file.Filename,
 		Purpose:     firstValue(purpose),
-		ContentType: file.Header.Get("Content-Type"),
+		ContentType: contentType,
 		ContentLen:  file.Size,
 		Reader:      reader,
 	}
@@ -182,6 +207,22 @@ func (h *filesHandler) upload(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(toOpenAIFile(record))
 }
 
+// batchModelAliases lists the aliases the caller's tenant may target in a
+// batch file.
+func (h *filesHandler) batchModelAliases(rc *requestctx.Context) []string {
+	if h.container.Engine == nil {
+		return nil
+	}
+	aliases := make([]string, 0)
+	for alias, routes := range h.container.Engine.ListAliases() {
+		if len(routes) == 0 || !h.container.IsModelAllowed(rc.TenantID, alias) {
+			continue
+		}
+		aliases = append(aliases, alias)
+	}
+	return aliases
+}
+
 func (h *filesHandler) createUpload(c *fiber.Ctx) error {
 	return httputil.WriteError(c, fiber.StatusNotImplemented, "uploads not yet implemented")
 }
@@ -0,0 +1,186 @@
+package public
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/executor"
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	"github.com/ncecere/open_model_gateway/backend/internal/limits"
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+	imagejobsvc "github.com/ncecere/open_model_gateway/backend/internal/services/imagejobs"
+	usagepipeline "github.com/ncecere/open_model_gateway/backend/internal/services/usagepipeline"
+)
+
+type openAIImageJob struct {
+	JobID       string               `json:"job_id"`
+	Object      string               `json:"object"`
+	Status      string               `json:"status"`
+	Model       string               `json:"model"`
+	CreatedAt   int64                `json:"created_at"`
+	CompletedAt *int64               `json:"completed_at,omitempty"`
+	ExpiresAt   int64                `json:"expires_at"`
+	Result      *openAIImageResponse `json:"result,omitempty"`
+	Error       *openAIImageJobError `json:"error,omitempty"`
+}
+
+type openAIImageJobError struct {
+	Message string `json:"message"`
+}
+
+// imageGenerationsAsync queues an image generation and answers 202 with the
+// job ID to poll. Model access, budget, and rate limits are checked here so
+// a rejected request fails before it is queued.
+func (h *openAIHandler) imageGenerationsAsync(c *fiber.Ctx) error {
+	if h.container.ImageJobs == nil {
+		return httputil.WriteError(c, fiber.StatusServiceUnavailable, "image queue unavailable")
+	}
+	var req openAIImageRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
+	}
+	req.Model = strings.TrimSpace(req.Model)
+	req.Prompt = strings.TrimSpace(req.Prompt)
+	if req.Model == "" {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "model is required")
+	}
+	if req.Prompt == "" {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "prompt is required")
+	}
+	n := req.N
+	if n <= 0 {
+		n = 1
+	}
+	if n > 10 {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "n must be between 1 and 10")
+	}
+
+	ctx := c.UserContext()
+	rc, ok := requestctx.FromContext(ctx)
+	if !ok || rc == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "request context missing")
+	}
+	if !h.container.IsModelAllowed(rc.TenantID, req.Model) {
+		return httputil.WriteError(c, fiber.StatusForbidden, "model not enabled for tenant")
+	}
+	h.warnIfDeprecated(c, req.Model)
+	routes, err := h.executor.SelectRoutes(rc, req.Model)
+	if err != nil {
+		status, msg, _ := executor.AsAPIError(err)
+		return httputil.WriteError(c, status, msg)
+	}
+	hasImageRoute := false
+	for _, route := range routes {
+		if route.Image != nil {
+			hasImageRoute = true
+			break
+		}
+	}
+	if !hasImageRoute {
+		return httputil.WriteError(c, fiber.StatusServiceUnavailable, "no backend available for model")
+	}
+
+	traceID := traceIDFromContext(c)
+	budget, err := h.container.UsageLogger.CheckBudget(ctx, rc, time.Now().UTC())
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "failed to evaluate budget")
+	}
+	setBudgetHeaders(c, budget)
+	if budget.Blocked() {
+		_, _ = h.container.UsageLogger.Record(ctx, usagepipeline.Record{
+			Context:   rc,
+			Alias:     req.Model,
+			Provider:  "budget",
+			Status:    fiber.StatusForbidden,
+			ErrorCode: "budget_exceeded",
+			TraceID:   traceID,
+			Timestamp: time.Now().UTC(),
+			Success:   false,
+		})
+		return httputil.WriteError(c, fiber.StatusForbidden, "tenant budget exceeded")
+	}
+
+	// The submission counts against the request rate limit; the slot is
+	// released once the job is queued rather than held while it runs.
+	_, _, _, _, release, err := h.container.AcquireRateLimits(ctx, req.Model)
+	if err != nil {
+		if errors.Is(err, limits.ErrLimitExceeded) {
+			return httputil.WriteError(c, fiber.StatusTooManyRequests, "rate limit exceeded")
+		}
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	defer release()
+
+	job, err := h.container.ImageJobs.Submit(ctx, imagejobsvc.Job{
+		TenantID: rc.TenantID,
+		APIKeyID: rc.APIKeyID,
+		Alias:    req.Model,
+		TraceID:  traceID,
+		Request: models.ImageRequest{
+			Prompt:         req.Prompt,
+			Size:           req.Size,
+			ResponseFormat: req.ResponseFormat,
+			Quality:        req.Quality,
+			N:              n,
+			User:           req.User,
+			Background:     req.Background,
+			Style:          req.Style,
+		},
+	})
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "failed to queue image job")
+	}
+	return c.Status(fiber.StatusAccepted).JSON(toOpenAIImageJob(job))
+}
+
+// imageJob reports a queued image job's status, including the images once
+// it has completed.
+func (h *openAIHandler) imageJob(c *fiber.Ctx) error {
+	if h.container.ImageJobs == nil {
+		return httputil.WriteError(c, fiber.StatusServiceUnavailable, "image queue unavailable")
+	}
+	rc, ok := requestctx.FromContext(c.UserContext())
+	if !ok || rc == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "request context missing")
+	}
+	id, err := uuid.Parse(c.Params("jobID"))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusNotFound, "image job not found")
+	}
+	job, err := h.container.ImageJobs.Get(c.UserContext(), rc.TenantID, id)
+	if err != nil {
+		if errors.Is(err, imagejobsvc.ErrNotFound) {
+			return httputil.WriteError(c, fiber.StatusNotFound, "image job not found")
+		}
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(toOpenAIImageJob(job))
+}
+
+func toOpenAIImageJob(job imagejobsvc.Job) openAIImageJob {
+	out := openAIImageJob{
+		JobID:     job.ID.String(),
+		Object:    "image.job",
+		Status:    string(job.Status),
+		Model:     job.Alias,
+		CreatedAt: job.CreatedAt.Unix(),
+		ExpiresAt: job.ExpiresAt.Unix(),
+	}
+	if job.CompletedAt != nil {
+		completed := job.CompletedAt.Unix()
+		out.CompletedAt = &completed
+	}
+	if job.Result != nil {
+		result := convertImageResponse(*job.Result)
+		out.Result = &result
+	}
+	if job.Error != "" {
+		out.Error = &openAIImageJobError{Message: job.Error}
+	}
+	return out
+}
@@ -0,0 +1,187 @@
+package public
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+	imagejobsvc "github.com/ncecere/open_model_gateway/backend/internal/services/imagejobs"
+)
+
+type memoryImageJobs struct {
+	mu   sync.Mutex
+	jobs []db.ImageJob
+}
+
+func (m *memoryImageJobs) InsertImageJob(_ context.Context, arg db.InsertImageJobParams) (db.ImageJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job := db.ImageJob{
+		ID:         pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		TenantID:   arg.TenantID,
+		ApiKeyID:   arg.ApiKeyID,
+		ModelAlias: arg.ModelAlias,
+		Request:    arg.Request,
+		Status:     db.ImageJobStatusPending,
+		TraceID:    arg.TraceID,
+		CreatedAt:  pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		ExpiresAt:  arg.ExpiresAt,
+	}
+	m.jobs = append(m.jobs, job)
+	return job, nil
+}
+
+func (m *memoryImageJobs) GetImageJob(_ context.Context, arg db.GetImageJobParams) (db.ImageJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, job := range m.jobs {
+		if job.ID == arg.ID && job.TenantID == arg.TenantID {
+			return job, nil
+		}
+	}
+	return db.ImageJob{}, pgx.ErrNoRows
+}
+
+func (m *memoryImageJobs) ClaimNextImageJob(context.Context) (db.ImageJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.jobs {
+		if m.jobs[i].Status == db.ImageJobStatusPending {
+			m.jobs[i].Status = db.ImageJobStatusProcessing
+			return m.jobs[i], nil
+		}
+	}
+	return db.ImageJob{}, pgx.ErrNoRows
+}
+
+func (m *memoryImageJobs) CompleteImageJob(_ context.Context, arg db.CompleteImageJobParams) error {
+	return m.finish(arg.ID, func(job *db.ImageJob) {
+		job.Status = db.ImageJobStatusCompleted
+		job.Result = arg.Result
+	})
+}
+
+func (m *memoryImageJobs) FailImageJob(_ context.Context, arg db.FailImageJobParams) error {
+	return m.finish(arg.ID, func(job *db.ImageJob) {
+		job.Status = db.ImageJobStatusFailed
+		job.ErrorMessage = arg.ErrorMessage
+	})
+}
+
+func (m *memoryImageJobs) DeleteExpiredImageJobs(context.Context, pgtype.Timestamptz) (int64, error) {
+	return 0, nil
+}
+
+func (m *memoryImageJobs) finish(id pgtype.UUID, apply func(*db.ImageJob)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.jobs {
+		if m.jobs[i].ID == id {
+			apply(&m.jobs[i])
+			m.jobs[i].CompletedAt = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+		}
+	}
+	return nil
+}
+
+func TestImageJobPollReportsStatusProgression(t *testing.T) {
+	jobs := imagejobsvc.NewService(&memoryImageJobs{}, &config.FilesConfig{DefaultTTL: time.Hour}, nil)
+	handler := &openAIHandler{container: &app.Container{ImageJobs: jobs}}
+	rc := &requestctx.Context{TenantID: uuid.New(), APIKeyID: uuid.New()}
+
+	server := fiber.New()
+	server.Get("/v1/images/jobs/:jobID", func(c *fiber.Ctx) error {
+		c.SetUserContext(requestctx.WithContext(c.UserContext(), rc))
+		return c.Next()
+	}, handler.imageJob)
+
+	poll := func(id string) openAIImageJob {
+		t.Helper()
+		resp, err := server.Test(httptest.NewRequest(fiber.MethodGet, "/v1/images/jobs/"+id, nil))
+		if err != nil {
+			t.Fatalf("poll: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("poll status %d", resp.StatusCode)
+		}
+		var job openAIImageJob
+		if err := json.NewDecoder(resp.Body).Decode(&job); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		return job
+	}
+	waitFor := func(id, status string) openAIImageJob {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for {
+			job := poll(id)
+			if job.Status == status {
+				return job
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("job stayed %q, expected %q", job.Status, status)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	job, err := jobs.Submit(context.Background(), imagejobsvc.Job{
+		TenantID: rc.TenantID,
+		APIKeyID: rc.APIKeyID,
+		Alias:    "dall-e",
+		Request:  models.ImageRequest{Prompt: "a lighthouse", N: 1},
+	})
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	id := job.ID.String()
+	if got := poll(id); got.Status != "pending" || got.Result != nil {
+		t.Fatalf("expected pending job without result, got %+v", got)
+	}
+
+	// The slow backend holds the job in processing until released.
+	release := make(chan struct{})
+	slowBackend := func(ctx context.Context, job imagejobsvc.Job) (models.ImageResponse, error) {
+		select {
+		case <-release:
+		case <-ctx.Done():
+			return models.ImageResponse{}, ctx.Err()
+		}
+		return models.ImageResponse{Created: time.Now(), Data: []models.ImageData{{B64JSON: "aW1n", RevisedPrompt: job.Request.Prompt}}}, nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go jobs.Run(ctx, 1, slowBackend)
+
+	waitFor(id, "processing")
+	close(release)
+	done := waitFor(id, "completed")
+	if done.Result == nil || len(done.Result.Data) != 1 || done.Result.Data[0].B64JSON != "aW1n" || done.Result.Data[0].RevisedPrompt != "a lighthouse" {
+		t.Fatalf("unexpected result %+v", done.Result)
+	}
+	if done.CompletedAt == nil || done.Error != nil {
+		t.Fatalf("expected completion time and no error, got %+v", done)
+	}
+
+	resp, err := server.Test(httptest.NewRequest(fiber.MethodGet, "/v1/images/jobs/"+uuid.NewString(), nil))
+	if err != nil {
+		t.Fatalf("poll unknown: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Fatalf("expected 404 for unknown job, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,84 @@
+package public
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	admintenantsvc "github.com/ncecere/open_model_gateway/backend/internal/services/admintenant"
+)
+
+type acceptInvitationRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+type acceptInvitationResponse struct {
+	AccessToken      string    `json:"access_token"`
+	AccessExpiresAt  time.Time `json:"access_expires_at"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+	TenantID         string    `json:"tenant_id"`
+	UserID           string    `json:"user_id"`
+	Email            string    `json:"email"`
+	Role             string    `json:"role"`
+}
+
+// acceptInvitation redeems a tenant invitation token for an invitee without
+// an account. The token is the only credential: it creates the user, adds the
+// membership, and signs the new user in with the same session cookie the
+// portal login sets. Invitations for existing accounts are refused here; those
+// users sign in and accept at POST /user/invitations/accept.
+func acceptInvitation(container *app.Container) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var req acceptInvitationRequest
+		if err := c.BodyParser(&req); err != nil {
+			return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
+		}
+		if strings.TrimSpace(req.Token) == "" {
+			return httputil.WriteError(c, fiber.StatusBadRequest, "token is required")
+		}
+		if container.AdminTenants == nil || container.AdminAuth == nil {
+			return httputil.WriteError(c, fiber.StatusServiceUnavailable, "invitations unavailable")
+		}
+
+		accepted, err := container.AdminTenants.AcceptInvitation(c.UserContext(), req.Token, req.Password)
+		if err != nil {
+			if errors.Is(err, admintenantsvc.ErrInvitationInvalid) || errors.Is(err, admintenantsvc.ErrLocalAuthDisabled) {
+				return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+			}
+			if errors.Is(err, admintenantsvc.ErrInvitationLoginRequired) {
+				return httputil.WriteError(c, fiber.StatusConflict, err.Error())
+			}
+			return httputil.WriteError(c, fiber.StatusInternalServerError, "failed to accept invitation")
+		}
+
+		pair, err := container.AdminAuth.IssueTokenPair(accepted.User)
+		if err != nil {
+			return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+		}
+		c.Cookie(&fiber.Cookie{
+			Name:     container.Config.Admin.Session.CookieName,
+			Value:    pair.RefreshToken,
+			HTTPOnly: true,
+			Secure:   strings.EqualFold(c.Protocol(), "https"),
+			Path:     "/",
+			Expires:  pair.RefreshExpiresAt,
+			SameSite: fiber.CookieSameSiteLaxMode,
+		})
+
+		membership := accepted.Membership
+		return c.JSON(acceptInvitationResponse{
+			AccessToken:      pair.AccessToken,
+			AccessExpiresAt:  pair.AccessExpiresAt,
+			RefreshExpiresAt: pair.RefreshExpiresAt,
+			TenantID:         membership.TenantID.String(),
+			UserID:           membership.UserID.String(),
+			Email:            membership.Email,
+			Role:             string(membership.Role),
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package public
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	admintenantsvc "github.com/ncecere/open_model_gateway/backend/internal/services/admintenant"
+)
+
+type createPersonalAPIKeyRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+type personalAPIKey struct {
+	ID          string     `json:"id"`
+	TenantID    string     `json:"tenant_id"`
+	Prefix      string     `json:"prefix"`
+	Name        string     `json:"name"`
+	Scopes      []string   `json:"scopes"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	Revoked     bool       `json:"revoked"`
+	SuspendedAt *time.Time `json:"suspended_at,omitempty"`
+}
+
+type personalAPIKeyList struct {
+	Data []personalAPIKey `json:"data"`
+}
+
+type createPersonalAPIKeyResponse struct {
+	APIKey personalAPIKey `json:"api_key"`
+	Secret string         `json:"secret"`
+	Token  string         `json:"token"`
+}
+
+var errAdminTenantServiceMissing = errors.New("api key service unavailable")
+
+// listPersonalAPIKeys lists the caller's personal keys.
+func listPersonalAPIKeys(container *app.Container) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, err := personalKeyOwner(c, container)
+		if err != nil {
+			return writePersonalAPIKeyError(c, err)
+		}
+		keys, err := container.AdminTenants.ListPersonalAPIKeys(c.UserContext(), userID)
+		if err != nil {
+			return writePersonalAPIKeyError(c, err)
+		}
+		out := personalAPIKeyList{Data: make([]personalAPIKey, 0, len(keys))}
+		for _, key := range keys {
+			out.Data = append(out.Data, toPersonalAPIKey(key))
+		}
+		return c.JSON(out)
+	}
+}
+
+// createPersonalAPIKey issues a personal key on the caller's personal tenant.
+// The key is subject to the same rate limits and budgets as any other key.
+func createPersonalAPIKey(container *app.Container) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, err := personalKeyOwner(c, container)
+		if err != nil {
+			return writePersonalAPIKeyError(c, err)
+		}
+		var req createPersonalAPIKeyRequest
+		if err := c.BodyParser(&req); err != nil {
+			return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
+		}
+		req.Name = strings.TrimSpace(req.Name)
+		if req.Name == "" {
+			return httputil.WriteError(c, fiber.StatusBadRequest, "name is required")
+		}
+		scopes := make([]string, 0, len(req.Scopes))
+		for _, scope := range req.Scopes {
+			if trimmed := strings.TrimSpace(scope); trimmed != "" {
+				scopes = append(scopes, trimmed)
+			}
+		}
+		scopesJSON, err := json.Marshal(scopes)
+		if err != nil {
+			return httputil.WriteError(c, fiber.StatusBadRequest, "invalid scopes")
+		}
+		result, err := container.AdminTenants.CreatePersonalAPIKey(c.UserContext(), userID, req.Name, scopesJSON)
+		if err != nil {
+			return writePersonalAPIKeyError(c, err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(createPersonalAPIKeyResponse{
+			APIKey: toPersonalAPIKey(result.Key),
+			Secret: result.Secret,
+			Token:  result.Token,
+		})
+	}
+}
+
+// revokePersonalAPIKey revokes one of the caller's personal keys.
+func revokePersonalAPIKey(container *app.Container) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, err := personalKeyOwner(c, container)
+		if err != nil {
+			return writePersonalAPIKeyError(c, err)
+		}
+		keyID, err := uuid.Parse(strings.TrimSpace(c.Params("keyID")))
+		if err != nil {
+			return httputil.WriteError(c, fiber.StatusBadRequest, "invalid api key id")
+		}
+		revoked, err := container.AdminTenants.RevokePersonalAPIKey(c.UserContext(), userID, keyID)
+		if err != nil {
+			return writePersonalAPIKeyError(c, err)
+		}
+		return c.JSON(toPersonalAPIKey(revoked))
+	}
+}
+
+func personalKeyOwner(c *fiber.Ctx, container *app.Container) (uuid.UUID, error) {
+	if container.AdminTenants == nil {
+		return uuid.Nil, errAdminTenantServiceMissing
+	}
+	return apiKeyOwner(c)
+}
+
+func toPersonalAPIKey(key db.ApiKey) personalAPIKey {
+	out := personalAPIKey{
+		ID:        uuid.UUID(key.ID.Bytes).String(),
+		TenantID:  uuid.UUID(key.TenantID.Bytes).String(),
+		Prefix:    key.Prefix,
+		Name:      key.Name,
+		Scopes:    []string{},
+		CreatedAt: key.CreatedAt.Time,
+		Revoked:   key.RevokedAt.Valid,
+	}
+	if len(key.ScopesJson) > 0 {
+		_ = json.Unmarshal(key.ScopesJson, &out.Scopes)
+	}
+	if key.LastUsedAt.Valid {
+		ts := key.LastUsedAt.Time
+		out.LastUsedAt = &ts
+	}
+	if key.RevokedAt.Valid {
+		ts := key.RevokedAt.Time
+		out.RevokedAt = &ts
+	}
+	if key.SuspendedAt.Valid {
+		ts := key.SuspendedAt.Time
+		out.SuspendedAt = &ts
+	}
+	return out
+}
+
+func writePersonalAPIKeyError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, errAPIKeyWithoutOwner), errors.Is(err, admintenantsvc.ErrAPIKeyNotOwned):
+		return httputil.WriteError(c, fiber.StatusForbidden, err.Error())
+	case errors.Is(err, admintenantsvc.ErrPersonalAPIKeyLimit):
+		return httputil.WriteError(c, fiber.StatusConflict, err.Error())
+	case errors.Is(err, admintenantsvc.ErrNoPersonalTenant), errors.Is(err, pgx.ErrNoRows):
+		return httputil.WriteError(c, fiber.StatusNotFound, "api key not found")
+	}
+	return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+}
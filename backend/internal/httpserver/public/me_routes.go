@@ -0,0 +1,237 @@
+package public
+
+import (
+	"errors"
+	"math"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+	tenantservice "github.com/ncecere/open_model_gateway/backend/internal/services/tenant"
+	usageservice "github.com/ncecere/open_model_gateway/backend/internal/services/usage"
+	"github.com/ncecere/open_model_gateway/backend/internal/services/usagepipeline"
+)
+
+type personalBudgetRequest struct {
+	BudgetUSD        float64 `json:"budget_usd"`
+	WarningThreshold float64 `json:"warning_threshold"`
+}
+
+type personalBudgetResponse struct {
+	TenantID         string  `json:"tenant_id"`
+	BudgetUSD        float64 `json:"budget_usd"`
+	UsedUSD          float64 `json:"used_usd"`
+	RemainingUSD     float64 `json:"remaining_usd"`
+	WarningThreshold float64 `json:"warning_threshold"`
+	RefreshSchedule  string  `json:"refresh_schedule"`
+	MaxBudgetUSD     float64 `json:"max_budget_usd"`
+}
+
+type personalBudgetUsageResponse struct {
+	TenantID string                     `json:"tenant_id"`
+	Period   string                     `json:"period"`
+	Start    string                     `json:"start"`
+	End      string                     `json:"end"`
+	Timezone string                     `json:"timezone"`
+	Usage    usageservice.UsageTotals   `json:"usage"`
+	Budget   *usageservice.BudgetStatus `json:"budget,omitempty"`
+}
+
+// getPersonalBudget reports the budget of the API key owner's personal tenant.
+func getPersonalBudget(container *app.Container) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, err := personalBudgetOwner(c, container)
+		if err != nil {
+			return writePersonalBudgetError(c, err)
+		}
+		budget, err := container.TenantService.PersonalBudget(c.UserContext(), userID)
+		if err != nil {
+			return writePersonalBudgetError(c, err)
+		}
+		return writePersonalBudget(c, container, budget)
+	}
+}
+
+// putPersonalBudget lets the API key owner set their personal tenant's
+// budget, bounded by budgets.max_personal_budget_usd and the period's spend.
+func putPersonalBudget(container *app.Container) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, err := personalBudgetOwner(c, container)
+		if err != nil {
+			return writePersonalBudgetError(c, err)
+		}
+		var req personalBudgetRequest
+		if err := c.BodyParser(&req); err != nil {
+			return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
+		}
+		budget, err := container.TenantService.SetPersonalBudget(c.UserContext(), userID, tenantservice.PersonalBudgetRequest{
+			BudgetUSD:        req.BudgetUSD,
+			WarningThreshold: req.WarningThreshold,
+		})
+		if err != nil {
+			return writePersonalBudgetError(c, err)
+		}
+		return writePersonalBudget(c, container, budget)
+	}
+}
+
+// getPersonalBudgetUsage reports the API key owner's personal tenant spend
+// for ?period (default 30d) alongside its current budget status.
+func getPersonalBudgetUsage(container *app.Container) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, err := apiKeyOwner(c)
+		if err != nil {
+			return writePersonalBudgetError(c, err)
+		}
+		if container.UsageService == nil || container.Queries == nil {
+			return httputil.WriteError(c, fiber.StatusInternalServerError, "usage service unavailable")
+		}
+		ctx := c.UserContext()
+		user, err := container.Queries.GetUserByID(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return writePersonalBudgetError(c, tenantservice.ErrNoPersonalTenant)
+			}
+			return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+		}
+		if !user.PersonalTenantID.Valid {
+			return writePersonalBudgetError(c, tenantservice.ErrNoPersonalTenant)
+		}
+		period := strings.TrimSpace(c.Query("period"))
+		if period == "" {
+			period = "30d"
+		}
+		summary, err := container.UsageService.SummarizeUserUsage(ctx, user, period, nil, strings.TrimSpace(c.Query("timezone")), nil, nil)
+		if err != nil {
+			switch {
+			case errors.Is(err, usageservice.ErrInvalidPeriod):
+				return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+			case errors.Is(err, usageservice.ErrInvalidTimezone):
+				return httputil.WriteError(c, fiber.StatusBadRequest, "invalid timezone")
+			}
+			return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+		}
+		return writePersonalBudgetUsage(c, uuid.UUID(user.PersonalTenantID.Bytes), summary)
+	}
+}
+
+func writePersonalBudgetUsage(c *fiber.Ctx, tenantID uuid.UUID, summary usageservice.UserSummary) error {
+	resp := personalBudgetUsageResponse{
+		TenantID: tenantID.String(),
+		Period:   summary.Period,
+		Start:    summary.Start,
+		End:      summary.End,
+		Timezone: summary.Timezone,
+		Budget:   summary.PersonalBudget,
+	}
+	if personal := summary.Personal; personal != nil {
+		resp.Usage = usageservice.UsageTotals{
+			Requests:  personal.Requests,
+			Tokens:    personal.Tokens,
+			CostCents: personal.CostCents,
+			CostUSD:   personal.CostUSD,
+		}
+	}
+	if budget := summary.PersonalBudget; budget != nil {
+		setBudgetHeaders(c, usagepipeline.BudgetStatus{
+			TotalCostCents: int64(math.Round(budget.UsedUSD * 100)),
+			LimitCents:     int64(math.Round(budget.BudgetUSD * 100)),
+			Warning:        budget.Warning,
+			Exceeded:       budget.Exceeded,
+		})
+	}
+	return c.JSON(resp)
+}
+
+var (
+	errRequestContextMissing = errors.New("request context missing")
+	errTenantServiceMissing  = errors.New("tenant service unavailable")
+	errAPIKeyWithoutOwner    = errors.New("api key has no user owner")
+)
+
+func personalBudgetOwner(c *fiber.Ctx, container *app.Container) (uuid.UUID, error) {
+	if container.TenantService == nil {
+		return uuid.Nil, errTenantServiceMissing
+	}
+	return apiKeyOwner(c)
+}
+
+// apiKeyOwner returns the user that owns the calling API key. Keys without an
+// owner (bootstrap and admin-issued service keys) cannot use /v1/me routes.
+func apiKeyOwner(c *fiber.Ctx) (uuid.UUID, error) {
+	rc, ok := requestctx.FromContext(c.UserContext())
+	if !ok || rc == nil {
+		return uuid.Nil, errRequestContextMissing
+	}
+	if rc.OwnerUserID == uuid.Nil {
+		return uuid.Nil, errAPIKeyWithoutOwner
+	}
+	return rc.OwnerUserID, nil
+}
+
+func writePersonalBudget(c *fiber.Ctx, container *app.Container, status tenantservice.PersonalBudgetStatus) error {
+	summary := status.Budget
+	limitCents := int64(math.Round(summary.LimitUSD * 100))
+	usedCents := int64(math.Round(summary.UsedUSD * 100))
+	setBudgetHeaders(c, usagepipeline.BudgetStatus{
+		TotalCostCents: usedCents,
+		LimitCents:     limitCents,
+		Warning:        limitCents > 0 && float64(usedCents) >= float64(limitCents)*summary.WarningThreshold,
+		Exceeded:       limitCents > 0 && usedCents >= limitCents,
+	})
+	return c.JSON(personalBudgetResponse{
+		TenantID:         status.TenantID.String(),
+		BudgetUSD:        summary.LimitUSD,
+		UsedUSD:          summary.UsedUSD,
+		RemainingUSD:     summary.RemainingUSD,
+		WarningThreshold: summary.WarningThreshold,
+		RefreshSchedule:  summary.RefreshSchedule,
+		MaxBudgetUSD:     container.Config.Budgets.MaxPersonalBudgetUSD,
+	})
+}
+
+func writePersonalBudgetError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, errAPIKeyWithoutOwner):
+		return httputil.WriteError(c, fiber.StatusForbidden, err.Error())
+	case errors.Is(err, tenantservice.ErrNoPersonalTenant):
+		return httputil.WriteError(c, fiber.StatusNotFound, err.Error())
+	case errors.Is(err, tenantservice.ErrInvalidPersonalBudget),
+		errors.Is(err, tenantservice.ErrInvalidBudgetThreshold),
+		errors.Is(err, tenantservice.ErrPersonalBudgetTooHigh),
+		errors.Is(err, tenantservice.ErrPersonalBudgetTooLow):
+		return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+	}
+	return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+}
+
+type impersonationInfoResponse struct {
+	Active      bool   `json:"active"`
+	AdminUserID string `json:"admin_user_id,omitempty"`
+	TenantID    string `json:"tenant_id,omitempty"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+// getImpersonationInfo reports the super admin behind the calling
+// impersonation token, or active=false for ordinary API keys.
+func getImpersonationInfo(c *fiber.Ctx) error {
+	rc, ok := requestctx.FromContext(c.UserContext())
+	if !ok || rc == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "request context missing")
+	}
+	if rc.ImpersonatedBy == uuid.Nil {
+		return c.JSON(impersonationInfoResponse{})
+	}
+	return c.JSON(impersonationInfoResponse{
+		Active:      true,
+		AdminUserID: rc.ImpersonatedBy.String(),
+		TenantID:    rc.TenantID.String(),
+		Reason:      rc.ImpersonationReason,
+	})
+}
@@ -0,0 +1,219 @@
+package public
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/providers"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+	"github.com/ncecere/open_model_gateway/backend/internal/router"
+)
+
+const maxModelListLimit = 1000
+
+// modelFilter holds the GET /v1/models query parameters. Enabled is nil when
+// the caller did not ask, which lists enabled models only.
+type modelFilter struct {
+	Provider      string
+	Modality      string
+	SupportsTools *bool
+	Enabled       *bool
+	Search        string
+	Limit         int
+	After         string
+}
+
+func parseModelFilter(c *fiber.Ctx) (modelFilter, error) {
+	filter := modelFilter{
+		Provider: strings.ToLower(strings.TrimSpace(c.Query("provider"))),
+		Modality: strings.ToLower(strings.TrimSpace(c.Query("modality"))),
+		Search:   strings.ToLower(strings.TrimSpace(c.Query("search"))),
+		After:    strings.TrimSpace(c.Query("after")),
+	}
+	var err error
+	if filter.SupportsTools, err = parseOptionalBool(c.Query("supports_tools")); err != nil {
+		return modelFilter{}, fmt.Errorf("supports_tools must be true or false")
+	}
+	if filter.Enabled, err = parseOptionalBool(c.Query("enabled")); err != nil {
+		return modelFilter{}, fmt.Errorf("enabled must be true or false")
+	}
+	if raw := strings.TrimSpace(c.Query("limit")); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 || limit > maxModelListLimit {
+			return modelFilter{}, fmt.Errorf("limit must be between 1 and %d", maxModelListLimit)
+		}
+		filter.Limit = limit
+	}
+	return filter, nil
+}
+
+func parseOptionalBool(raw string) (*bool, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// cacheKey scopes the cached listing to the caller's tenant, since model
+// access differs per tenant.
+func (f modelFilter) cacheKey(rc *requestctx.Context) string {
+	tenant := "-"
+	if rc != nil {
+		tenant = rc.TenantID.String()
+	}
+	return fmt.Sprintf("%s:p=%s:m=%s:t=%s:e=%s:s=%s:l=%d:a=%s",
+		tenant, f.Provider, f.Modality, formatOptionalBool(f.SupportsTools), formatOptionalBool(f.Enabled), f.Search, f.Limit, f.After)
+}
+
+func formatOptionalBool(value *bool) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatBool(*value)
+}
+
+func (f modelFilter) matches(model openAIModel) bool {
+	if f.Provider != "" && !strings.EqualFold(model.OwnedBy, f.Provider) {
+		return false
+	}
+	if f.Modality != "" && !hasModality(model, f.Modality) {
+		return false
+	}
+	if f.SupportsTools != nil && model.SupportsTools != *f.SupportsTools {
+		return false
+	}
+	if f.Search != "" && !strings.HasPrefix(strings.ToLower(model.ID), f.Search) {
+		return false
+	}
+	return true
+}
+
+// hasModality matches the catalog modalities, falling back to the model type
+// so embedding models match modality=embedding and llm models match text.
+func hasModality(model openAIModel, modality string) bool {
+	for _, m := range model.Modalities {
+		if strings.EqualFold(m, modality) {
+			return true
+		}
+	}
+	modelType := strings.ToLower(model.ModelType)
+	return modelType == modality || (modality == "text" && modelType == "llm")
+}
+
+// paginate sorts models by id and returns the page after f.After.
+func (f modelFilter) paginate(models []openAIModel) openAIModelList {
+	sort.Slice(models, func(i, j int) bool { return models[i].ID < models[j].ID })
+	if f.After != "" {
+		start := sort.Search(len(models), func(i int) bool { return models[i].ID > f.After })
+		models = models[start:]
+	}
+	list := openAIModelList{Object: "list", Data: models}
+	if f.Limit > 0 && len(models) > f.Limit {
+		list.Data = models[:f.Limit]
+		list.HasMore = true
+	}
+	if len(list.Data) > 0 {
+		list.LastID = list.Data[len(list.Data)-1].ID
+	}
+	return list
+}
+
+// modelCandidates lists the routable aliases, or the disabled catalog
+// entries when the filter asks for enabled=false.
+func (h *openAIHandler) modelCandidates(ctx context.Context, filter modelFilter, now int64) ([]openAIModel, error) {
+	if filter.Enabled != nil && !*filter.Enabled {
+		return h.disabledModels(ctx, now)
+	}
+	aliases := h.container.Engine.ListAliases()
+	out := make([]openAIModel, 0, len(aliases))
+	for alias, routes := range aliases {
+		if len(routes) == 0 {
+			continue
+		}
+		out = append(out, modelFromRoutes(alias, routes, now))
+	}
+	return out, nil
+}
+
+func (h *openAIHandler) disabledModels(ctx context.Context, now int64) ([]openAIModel, error) {
+	if h.container.Queries == nil {
+		return nil, nil
+	}
+	rows, err := h.container.Queries.ListModelCatalog(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := router.MergeEntries(h.container.ConfigCatalog(), rows)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]openAIModel, 0)
+	for _, entry := range entries {
+		if entry.IsEnabled() {
+			continue
+		}
+		out = append(out, modelFromEntry(entry, now))
+	}
+	return out, nil
+}
+
+func modelFromRoutes(alias string, routes []providers.Route, now int64) openAIModel {
+	route := routes[0]
+	model := openAIModel{
+		ID:               alias,
+		Object:           "model",
+		OwnedBy:          route.Provider,
+		Created:          now,
+		Deployment:       route.Metadata["deployment"],
+		TrafficSplit:     route.TrafficSplit,
+		Enabled:          true,
+		ModelType:        route.ModelType,
+		ContextWindow:    route.ContextWindow,
+		MaxOutputTokens:  route.MaxOutputTokens,
+		PriceInputPer1K:  route.PriceInput / 1000,
+		PriceOutputPer1K: route.PriceOutput / 1000,
+		Modalities:       slices.Clone(route.Modalities),
+		SupportsTools:    route.SupportsTools,
+	}
+	for _, r := range routes {
+		if r.DeprecatedAt != nil {
+			model.Deprecated = true
+			model.DeprecationMessage = r.DeprecationMessage
+			break
+		}
+	}
+	return model
+}
+
+func modelFromEntry(entry config.ModelCatalogEntry, now int64) openAIModel {
+	return openAIModel{
+		ID:                 entry.Alias,
+		Object:             "model",
+		OwnedBy:            entry.Provider,
+		Created:            now,
+		Deployment:         entry.Deployment,
+		TrafficSplit:       entry.TrafficSplit,
+		Deprecated:         entry.DeprecatedAt != nil,
+		DeprecationMessage: entry.DeprecationMessage,
+		Enabled:            entry.IsEnabled(),
+		ModelType:          entry.ModelType,
+		ContextWindow:      entry.ContextWindow,
+		MaxOutputTokens:    entry.MaxOutputTokens,
+		PriceInputPer1K:    entry.PriceInput / 1000,
+		PriceOutputPer1K:   entry.PriceOutput / 1000,
+		Modalities:         slices.Clone(entry.Modalities),
+		SupportsTools:      entry.SupportsTools,
+	}
+}
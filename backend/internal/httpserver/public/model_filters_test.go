@@ -0,0 +1,99 @@
+package public
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/providers"
+)
+
+func TestParseModelFilter(t *testing.T) {
+	app := fiber.New()
+	var got modelFilter
+	var parseErr error
+	app.Get("/", func(c *fiber.Ctx) error {
+		got, parseErr = parseModelFilter(c)
+		return nil
+	})
+
+	if _, err := app.Test(httptest.NewRequest("GET", "/?provider=OpenAI&modality=text&supports_tools=true&search=GPT&limit=5&after=gpt-4", nil)); err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	if parseErr != nil {
+		t.Fatalf("parse: %v", parseErr)
+	}
+	if got.Provider != "openai" || got.Modality != "text" || got.Search != "gpt" || got.Limit != 5 || got.After != "gpt-4" {
+		t.Fatalf("unexpected filter %+v", got)
+	}
+	if got.SupportsTools == nil || !*got.SupportsTools || got.Enabled != nil {
+		t.Fatalf("unexpected bool filters %+v", got)
+	}
+
+	for _, query := range []string{"/?limit=0", "/?limit=abc", "/?enabled=maybe"} {
+		if _, err := app.Test(httptest.NewRequest("GET", query, nil)); err != nil {
+			t.Fatalf("request: %v", err)
+		}
+		if parseErr == nil {
+			t.Fatalf("expected %s to be rejected", query)
+		}
+	}
+}
+
+func TestModelFilterMatches(t *testing.T) {
+	chat := modelFromRoutes("gpt-4o", []providers.Route{{
+		Provider:      "openai",
+		ModelType:     "llm",
+		Modalities:    []string{"text", "image"},
+		SupportsTools: true,
+		PriceInput:    5,
+	}}, 0)
+	embed := modelFromRoutes("text-embedding-3", []providers.Route{{Provider: "azure", ModelType: "embedding"}}, 0)
+
+	if chat.PriceInputPer1K != 0.005 {
+		t.Fatalf("expected per-1K price, got %v", chat.PriceInputPer1K)
+	}
+	yes := true
+	cases := []struct {
+		filter modelFilter
+		model  openAIModel
+		want   bool
+	}{
+		{modelFilter{Provider: "openai"}, chat, true},
+		{modelFilter{Provider: "openai"}, embed, false},
+		{modelFilter{Modality: "image"}, chat, true},
+		{modelFilter{Modality: "embedding"}, embed, true},
+		{modelFilter{Modality: "text"}, embed, false},
+		{modelFilter{SupportsTools: &yes}, embed, false},
+		{modelFilter{Search: "text-"}, embed, true},
+		{modelFilter{Search: "4o"}, chat, false},
+	}
+	for i, tc := range cases {
+		if got := tc.filter.matches(tc.model); got != tc.want {
+			t.Fatalf("case %d: matches = %v, want %v", i, got, tc.want)
+		}
+	}
+}
+
+func TestModelFilterPaginate(t *testing.T) {
+	models := []openAIModel{{ID: "c"}, {ID: "a"}, {ID: "d"}, {ID: "b"}}
+
+	page := modelFilter{Limit: 2}.paginate(models)
+	if len(page.Data) != 2 || page.Data[0].ID != "a" || !page.HasMore || page.LastID != "b" {
+		t.Fatalf("unexpected first page %+v", page)
+	}
+	page = modelFilter{Limit: 2, After: page.LastID}.paginate(models)
+	if len(page.Data) != 2 || page.Data[0].ID != "c" || page.HasMore || page.LastID != "d" {
+		t.Fatalf("unexpected second page %+v", page)
+	}
+}
+
+func TestModelFilterCacheKeyVariesByFilter(t *testing.T) {
+	yes, no := true, false
+	a := modelFilter{Enabled: &yes}.cacheKey(nil)
+	b := modelFilter{Enabled: &no}.cacheKey(nil)
+	if a == b || a == (modelFilter{}).cacheKey(nil) {
+		t.Fatalf("cache keys must differ per filter: %s %s", a, b)
+	}
+}
@@ -2,6 +2,7 @@ package public
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -16,12 +17,14 @@ import (
 	"github.com/gofiber/fiber/v2"
 
 	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
 	"github.com/ncecere/open_model_gateway/backend/internal/executor"
 	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
 	"github.com/ncecere/open_model_gateway/backend/internal/limits"
 	"github.com/ncecere/open_model_gateway/backend/internal/models"
 	"github.com/ncecere/open_model_gateway/backend/internal/providers"
 	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+	"github.com/ncecere/open_model_gateway/backend/internal/schemavalidation"
 	usagepipeline "github.com/ncecere/open_model_gateway/backend/internal/services/usagepipeline"
 )
 
@@ -40,38 +43,51 @@ func (h *openAIHandler) listModels(c *fiber.Ctx) error {
 		}
 	}
 
-	aliases := h.container.Engine.ListAliases()
-	models := make([]openAIModel, 0, len(aliases))
-	now := time.Now().Unix()
+	filter, err := parseModelFilter(c)
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+	}
+	cacheKey := filter.cacheKey(rc)
+	if data, ok := h.container.ModelLists.Get(ctx, cacheKey); ok {
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		return c.Send(data)
+	}
 
-	for alias, routes := range aliases {
-		if len(routes) == 0 {
+	candidates, err := h.modelCandidates(ctx, filter, time.Now().Unix())
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "failed to list models")
+	}
+	models := make([]openAIModel, 0, len(candidates))
+	for _, model := range candidates {
+		if rc != nil && !h.container.IsModelAllowed(rc.TenantID, model.ID) {
 			continue
 		}
-		if rc != nil && !h.container.IsModelAllowed(rc.TenantID, alias) {
-			continue
+		model.Availability = 1
+		if h.container.Engine != nil {
+			model.Availability = h.container.Engine.Availability(model.ID)
+		}
+		if filter.matches(model) {
+			models = append(models, model)
 		}
-		route := routes[0]
-		deployment := route.Metadata["deployment"]
-		models = append(models, openAIModel{
-			ID:         alias,
-			Object:     "model",
-			OwnedBy:    route.Provider,
-			Created:    now,
-			Deployment: deployment,
-		})
 	}
 
-	return c.JSON(openAIModelList{
-		Object: "list",
-		Data:   models,
-	})
+	data, err := json.Marshal(filter.paginate(models))
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "failed to encode models")
+	}
+	h.container.ModelLists.Set(ctx, cacheKey, data)
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return c.Send(data)
 }
 
 type imageOperationConfig struct {
 	Alias          string
 	IdempotencyKey string
-	Builder        func(route providers.Route) (models.ImageResponse, error)
+	// Prompt sizes the budget hold taken before the provider is called.
+	Prompt string
+	// Builder runs the provider call for one route; ctx carries the route's
+	// provider timeout.
+	Builder func(ctx context.Context, route providers.Route) (models.ImageResponse, error)
 }
 
 func (h *openAIHandler) runImageOperation(c *fiber.Ctx, cfg imageOperationConfig) error {
@@ -87,10 +103,12 @@ func (h *openAIHandler) runImageOperation(c *fiber.Ctx, cfg imageOperationConfig
 	if !h.container.IsModelAllowed(rc.TenantID, alias) {
 		return httputil.WriteError(c, fiber.StatusForbidden, "model not enabled for tenant")
 	}
+	h.warnIfDeprecated(c, alias)
 
-	routes := h.container.Engine.SelectRoutes(alias)
-	if len(routes) == 0 {
-		return httputil.WriteError(c, fiber.StatusServiceUnavailable, "no backend available for model")
+	routes, err := h.executor.SelectRoutes(rc, alias)
+	if err != nil {
+		status, msg, _ := executor.AsAPIError(err)
+		return httputil.WriteError(c, status, msg)
 	}
 
 	traceID := traceIDFromContext(c)
@@ -98,7 +116,7 @@ func (h *openAIHandler) runImageOperation(c *fiber.Ctx, cfg imageOperationConfig
 	if err != nil {
 		return httputil.WriteError(c, fiber.StatusInternalServerError, "failed to evaluate budget")
 	}
-	if initialBudget.Exceeded {
+	if initialBudget.Blocked() {
 		setBudgetHeaders(c, initialBudget)
 		_, _ = h.container.UsageLogger.Record(ctx, usagepipeline.Record{
 			Context:   rc,
@@ -122,6 +140,15 @@ func (h *openAIHandler) runImageOperation(c *fiber.Ctx, cfg imageOperationConfig
 		}
 	}
 
+	_, holdCents := h.imageEstimate(alias, routes[0].Provider, cfg.Prompt, routes[0])
+	releaseHold, err := h.container.UsageLogger.PreAuthorize(ctx, rc, holdCents)
+	if err != nil {
+		return writeHoldError(c, err)
+	}
+	// Released with the actual cost once usage is recorded; this covers the
+	// failure paths.
+	defer releaseHold(0)
+
 	keyKey, keyCfg, tenantKey, tenantCfg, release, err := h.container.AcquireRateLimits(ctx, alias)
 	if err != nil {
 		if errors.Is(err, limits.ErrLimitExceeded) {
@@ -139,7 +166,10 @@ func (h *openAIHandler) runImageOperation(c *fiber.Ctx, cfg imageOperationConfig
 		}
 		lastRoute = route
 		start := time.Now()
-		resp, err := cfg.Builder(route)
+		routeCtx, cancel, timeout := h.executor.RouteContext(ctx, route)
+		resp, err := cfg.Builder(routeCtx, route)
+		executor.LogRouteTimeout(routeCtx, alias, route, timeout, err)
+		cancel()
 		if err != nil {
 			if errors.Is(err, models.ErrImageOperationUnsupported) {
 				continue
@@ -172,6 +202,7 @@ func (h *openAIHandler) runImageOperation(c *fiber.Ctx, cfg imageOperationConfig
 			Context:           rc,
 			Alias:             alias,
 			Provider:          route.Provider,
+			ABVariant:         route.ABVariant,
 			Usage:             resp.Usage,
 			Latency:           time.Since(start),
 			Status:            fiber.StatusOK,
@@ -185,6 +216,11 @@ func (h *openAIHandler) runImageOperation(c *fiber.Ctx, cfg imageOperationConfig
 		if err != nil {
 			return httputil.WriteError(c, fiber.StatusInternalServerError, "failed to persist usage")
 		}
+		if record.OverrideCostCents != nil {
+			releaseHold(*record.OverrideCostCents)
+		} else {
+			releaseHold(h.container.UsageLogger.EstimateCostCents(alias, resp.Usage))
+		}
 		setBudgetHeaders(c, budgetStatus)
 
 		payload, err := json.Marshal(convertImageResponse(resp))
@@ -207,6 +243,7 @@ func (h *openAIHandler) runImageOperation(c *fiber.Ctx, cfg imageOperationConfig
 		Context:   rc,
 		Alias:     alias,
 		Provider:  lastRoute.Provider,
+		ABVariant: lastRoute.ABVariant,
 		Status:    fiber.StatusBadGateway,
 		ErrorCode: errMessage(lastErr),
 		TraceID:   traceID,
@@ -222,17 +259,43 @@ type openAIModel struct {
 	OwnedBy    string `json:"owned_by"`
 	Created    int64  `json:"created"`
 	Deployment string `json:"deployment"`
+	// TrafficSplit lists the aliases that share this alias's traffic when an
+	// A/B experiment is configured.
+	TrafficSplit []config.TrafficSplitEntry `json:"traffic_split,omitempty"`
+	// Deprecated is set when the alias has a scheduled removal date.
+	Deprecated         bool   `json:"deprecated"`
+	DeprecationMessage string `json:"deprecation_message,omitempty"`
+	// Catalog metadata for client-side routing decisions. Prices are USD
+	// per 1K tokens.
+	Enabled          bool     `json:"enabled"`
+	ModelType        string   `json:"model_type,omitempty"`
+	ContextWindow    int32    `json:"context_window"`
+	MaxOutputTokens  int32    `json:"max_output_tokens"`
+	PriceInputPer1K  float64  `json:"price_input_per_1k"`
+	PriceOutputPer1K float64  `json:"price_output_per_1k"`
+	Modalities       []string `json:"modalities"`
+	SupportsTools    bool     `json:"supports_tools"`
+	// Availability is the alias's rolling success rate across its routes,
+	// from 0 to 1.
+	Availability float64 `json:"availability"`
 }
 
 type openAIModelList struct {
 	Object string        `json:"object"`
 	Data   []openAIModel `json:"data"`
+	// HasMore and LastID page the listing; pass LastID as ?after= for the
+	// next page.
+	HasMore bool   `json:"has_more"`
+	LastID  string `json:"last_id,omitempty"`
 }
 
 type openAIChatMessage struct {
 	Role    string `json:"role"`
 	Content string `json:"content"`
 	Name    string `json:"name,omitempty"`
+	// Parts is set when the request sent array-form content with images;
+	// see UnmarshalJSON.
+	Parts []models.ChatContentPart `json:"-"`
 }
 
 type openAIChatRequest struct {
@@ -243,6 +306,11 @@ type openAIChatRequest struct {
 	MaxTokens   *int32              `json:"max_tokens,omitempty"`
 	Stream      bool                `json:"stream,omitempty"`
 	StopRaw     json.RawMessage     `json:"stop,omitempty"`
+
+	ResponseFormat *models.ChatResponseFormat `json:"response_format,omitempty"`
+	// Metadata tags the request for cost allocation; merged with the
+	// X-Request-Tags header.
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 type openAIChatChoice struct {
@@ -271,29 +339,17 @@ func (h *openAIHandler) chatCompletions(c *fiber.Ctx) error {
 	if err := c.BodyParser(&req); err != nil {
 		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
 	}
-	req.Model = strings.TrimSpace(req.Model)
-	if req.Model == "" {
-		return httputil.WriteError(c, fiber.StatusBadRequest, "model is required")
-	}
-	if len(req.Messages) == 0 {
-		return httputil.WriteError(c, fiber.StatusBadRequest, "messages are required")
+	modelReq, err := parseChatRequest(&req)
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
 	}
-	stop, err := parseStop(req.StopRaw)
+	responseSchema, err := compileResponseFormat(req.ResponseFormat)
 	if err != nil {
-		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid stop field")
+		return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
 	}
-
-	messages := make([]models.ChatMessage, 0, len(req.Messages))
-	for _, m := range req.Messages {
-		role := strings.ToLower(m.Role)
-		if role == "" {
-			role = "user"
-		}
-		messages = append(messages, models.ChatMessage{
-			Role:    role,
-			Content: m.Content,
-			Name:    m.Name,
-		})
+	tags, err := parseRequestTags(c.Get("X-Request-Tags"), req.Metadata)
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
 	}
 
 	ctx := c.UserContext()
@@ -301,20 +357,20 @@ func (h *openAIHandler) chatCompletions(c *fiber.Ctx) error {
 	if !ok || rc == nil {
 		return httputil.WriteError(c, fiber.StatusInternalServerError, "request context missing")
 	}
+	rc.Tags = tags
+	rc.Fingerprint = modelReq.Fingerprint()
 	if !h.container.IsModelAllowed(rc.TenantID, req.Model) {
 		return httputil.WriteError(c, fiber.StatusForbidden, "model not enabled for tenant")
 	}
+	h.warnIfDeprecated(c, req.Model)
 
 	traceID := traceIDFromContext(c)
 	alias := req.Model
 	idempotencyKey := strings.TrimSpace(c.Get("Idempotency-Key"))
 
-	modelReq := models.ChatRequest{
-		Messages:    messages,
-		Temperature: req.Temperature,
-		TopP:        req.TopP,
-		MaxTokens:   req.MaxTokens,
-		Stop:        stop,
+	if wantsCostEstimate(c) {
+		estimateReq, _ := executor.ApplySystemPrompt(rc, modelReq)
+		return h.estimateChatCost(c, alias, estimateReq)
 	}
 
 	if req.Stream {
@@ -336,6 +392,31 @@ func (h *openAIHandler) chatCompletions(c *fiber.Ctx) error {
 		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
 	}
 	setBudgetHeaders(c, chatResult.BudgetStatus)
+	if chatResult.SystemPromptApplied {
+		c.Set("X-System-Prompt-Applied", "true")
+	}
+	mirrorReq, _ := executor.ApplySystemPrompt(rc, modelReq)
+	h.executor.MirrorChat(ctx, rc, alias, mirrorReq, traceID)
+
+	if responseSchema != nil {
+		mode, err := h.container.TenantService.SchemaValidationMode(ctx, rc.TenantID)
+		if err != nil {
+			return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+		}
+		if mode != schemavalidation.ModeDisabled {
+			if verr := validateChatOutput(responseSchema, chatResult.Response); verr != nil {
+				if mode == schemavalidation.ModeStrict {
+					return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+						"error":   "schema_validation_failed",
+						"details": verr.Details,
+					})
+				}
+				c.Set("X-Schema-Valid", "false")
+			} else {
+				c.Set("X-Schema-Valid", "true")
+			}
+		}
+	}
 
 	resp := convertChatResponse(chatResult.Response, alias)
 	if idempotencyKey != "" {
@@ -347,6 +428,46 @@ func (h *openAIHandler) chatCompletions(c *fiber.Ctx) error {
 	return c.JSON(resp)
 }
 
+// parseChatRequest validates an OpenAI chat payload, trimming req.Model in
+// place, and converts it to the executor's request model.
+func parseChatRequest(req *openAIChatRequest) (models.ChatRequest, error) {
+	req.Model = strings.TrimSpace(req.Model)
+	if req.Model == "" {
+		return models.ChatRequest{}, errors.New("model is required")
+	}
+	if len(req.Messages) == 0 {
+		return models.ChatRequest{}, errors.New("messages are required")
+	}
+	stop, err := parseStop(req.StopRaw)
+	if err != nil {
+		return models.ChatRequest{}, errors.New("invalid stop field")
+	}
+
+	messages := make([]models.ChatMessage, 0, len(req.Messages))
+	for _, m := range req.Messages {
+		role := strings.ToLower(m.Role)
+		if role == "" {
+			role = "user"
+		}
+		messages = append(messages, models.ChatMessage{
+			Role:    role,
+			Content: m.Content,
+			Name:    m.Name,
+			Parts:   m.Parts,
+		})
+	}
+
+	return models.ChatRequest{
+		Messages:    messages,
+		Temperature: req.Temperature,
+		TopP:        req.TopP,
+		MaxTokens:   req.MaxTokens,
+		Stop:        stop,
+
+		ResponseFormat: req.ResponseFormat,
+	}, nil
+}
+
 func (h *openAIHandler) handleStreamChat(
 	c *fiber.Ctx,
 	rc *requestctx.Context,
@@ -356,16 +477,30 @@ func (h *openAIHandler) handleStreamChat(
 	req models.ChatRequest,
 ) error {
 	ctx := c.UserContext()
-	routes := h.container.Engine.SelectRoutes(alias)
-	if len(routes) == 0 {
-		return httputil.WriteError(c, fiber.StatusServiceUnavailable, "no backend available for model")
+	if idempotencyKey != "" {
+		if transcript, ok := h.container.StreamIdempotency.Get(ctx, rc.TenantID, rc.APIKeyID, idempotencyKey); ok {
+			return replayChatStream(c, transcript)
+		}
+	}
+
+	alias, err := h.executor.ResolveVisionAlias(ctx, rc, alias, req)
+	if err != nil {
+		if status, msg, ok := executor.AsAPIError(err); ok {
+			return httputil.WriteError(c, status, msg)
+		}
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	routes, err := h.executor.SelectRoutes(rc, alias)
+	if err != nil {
+		status, msg, _ := executor.AsAPIError(err)
+		return httputil.WriteError(c, status, msg)
 	}
 
 	initialBudget, err := h.container.UsageLogger.CheckBudget(ctx, rc, time.Now().UTC())
 	if err != nil {
 		return httputil.WriteError(c, fiber.StatusInternalServerError, "failed to evaluate budget")
 	}
-	if initialBudget.Exceeded {
+	if initialBudget.Blocked() {
 		setBudgetHeaders(c, initialBudget)
 		_, _ = h.container.UsageLogger.Record(ctx, usagepipeline.Record{
 			Context:   rc,
@@ -381,8 +516,22 @@ func (h *openAIHandler) handleStreamChat(
 	}
 	setBudgetHeaders(c, initialBudget)
 
+	req, promptApplied := executor.ApplySystemPrompt(rc, req)
+	req, err = h.executor.EnforceContextWindow(rc, alias, routes[0], req)
+	if err != nil {
+		status, msg, _ := executor.AsAPIError(err)
+		return httputil.WriteError(c, status, msg)
+	}
+
+	_, holdCents := h.chatEstimate(alias, routes[0].Provider, routes[0].ContextWindow, req)
+	releaseHold, err := h.container.UsageLogger.PreAuthorize(ctx, rc, holdCents)
+	if err != nil {
+		return writeHoldError(c, err)
+	}
+
 	keyKey, keyCfg, tenantKey, tenantCfg, release, err := h.container.AcquireRateLimits(ctx, alias)
 	if err != nil {
+		releaseHold(0)
 		if errors.Is(err, limits.ErrLimitExceeded) {
 			return httputil.WriteError(c, fiber.StatusTooManyRequests, "rate limit exceeded")
 		}
@@ -391,7 +540,11 @@ func (h *openAIHandler) handleStreamChat(
 	var once sync.Once
 	releaseOnce := func() { once.Do(release) }
 
-	return h.streamChat(c, alias, rc, traceID, idempotencyKey, req, routes, keyKey, keyCfg, tenantKey, tenantCfg, releaseOnce)
+	if promptApplied {
+		c.Set("X-System-Prompt-Applied", "true")
+	}
+
+	return h.streamChat(c, alias, rc, traceID, idempotencyKey, req, routes, keyKey, keyCfg, tenantKey, tenantCfg, releaseOnce, releaseHold)
 }
 
 func (h *openAIHandler) streamChat(
@@ -406,6 +559,7 @@ func (h *openAIHandler) streamChat(
 	tenantKey string,
 	tenantCfg limits.LimitConfig,
 	release func(),
+	releaseHold usagepipeline.ReleaseFunc,
 ) error {
 	ctx := c.UserContext()
 
@@ -417,8 +571,11 @@ func (h *openAIHandler) streamChat(
 		}
 		lastRoute = route
 		req.Model = route.ResolveDeployment()
-		chunks, cancel, err := route.ChatStream.ChatStream(ctx, req)
+		streamCtx, cancelStream, timeout := h.executor.RouteContext(ctx, route)
+		chunks, cancel, err := route.ChatStream.ChatStream(streamCtx, req)
 		if err != nil {
+			executor.LogRouteTimeout(streamCtx, alias, route, timeout, err)
+			cancelStream()
 			h.container.Engine.ReportFailure(alias, route)
 			lastErr = err
 			continue
@@ -433,10 +590,24 @@ func (h *openAIHandler) streamChat(
 		var firstTokenLatency time.Duration
 		var firstTokenMeasured bool
 
+		streamDone := h.container.Streams.Start()
 		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			defer streamDone()
+			defer cancelStream()
 			defer cancel()
 			defer release()
 
+			idle := newStreamIdleWatchdog(rc.StreamIdleTimeout, func() {
+				cancelStream()
+				_ = cancel()
+			})
+			defer idle.Stop()
+
+			sse := &chatStreamWriter{w: w}
+			if idempotencyKey != "" && h.container.StreamIdempotency != nil {
+				sse.transcript = &bytes.Buffer{}
+			}
+
 			recordStatus := fiber.StatusOK
 			recordSuccess := false
 			reported := false
@@ -472,6 +643,7 @@ func (h *openAIHandler) streamChat(
 					Context:        rc,
 					Alias:          alias,
 					Provider:       route.Provider,
+					ABVariant:      route.ABVariant,
 					Usage:          streamUsage,
 					Latency:        latency,
 					Status:         recordStatus,
@@ -484,6 +656,11 @@ func (h *openAIHandler) streamChat(
 				if _, err := h.container.UsageLogger.Record(ctx, record); err != nil {
 					slog.Error("record stream usage", slog.String("alias", alias), slog.String("error", err.Error()))
 				}
+				priceAlias := alias
+				if route.ABVariant != "" {
+					priceAlias = route.ABVariant
+				}
+				releaseHold(h.container.UsageLogger.EstimateCostCents(priceAlias, streamUsage))
 			}
 
 			defer recordUsage()
@@ -493,7 +670,11 @@ func (h *openAIHandler) streamChat(
 				}
 			}()
 
-			for chunk := range chunks {
+			for {
+				chunk, ok := idle.next(chunks)
+				if !ok {
+					break
+				}
 				if chunk.IsUsageOnly() {
 					if chunk.Usage != nil {
 						streamUsage = *chunk.Usage
@@ -513,22 +694,11 @@ func (h *openAIHandler) streamChat(
 					recordStatus = fiber.StatusInternalServerError
 					return
 				}
-				if _, err = w.WriteString("data: "); err != nil {
-					recordStatus = fiber.StatusInternalServerError
-					return
-				}
-				if _, err = w.Write(data); err != nil {
-					recordStatus = fiber.StatusInternalServerError
-					return
-				}
-				if _, err = w.WriteString("\n\n"); err != nil {
-					recordStatus = fiber.StatusInternalServerError
-					return
-				}
-				if err = w.Flush(); err != nil {
+				if err = sse.data(data); err != nil {
 					recordStatus = fiber.StatusInternalServerError
 					return
 				}
+				idle.Reset()
 
 				if chunk.Usage != nil {
 					streamUsage = *chunk.Usage
@@ -538,17 +708,29 @@ func (h *openAIHandler) streamChat(
 				recordSuccess = true
 			}
 
-			if _, err := w.WriteString("data: [DONE]\n\n"); err != nil {
+			if err := sse.done(); err != nil {
 				recordStatus = fiber.StatusInternalServerError
 				return
 			}
-			if err := w.Flush(); err != nil {
-				recordStatus = fiber.StatusInternalServerError
+			if idle.Expired() {
+				// The provider stalled past the tenant's idle timeout; the
+				// client got a clean [DONE] but the route is not credited.
+				recordStatus = fiber.StatusGatewayTimeout
+				return
+			}
+			if errors.Is(streamCtx.Err(), context.DeadlineExceeded) {
+				// The stream outlived the route's provider timeout.
+				executor.LogRouteTimeout(streamCtx, alias, route, timeout, streamCtx.Err())
+				recordStatus = fiber.StatusGatewayTimeout
 				return
 			}
 
 			h.container.Engine.ReportSuccess(alias, route)
 			reported = true
+			if sse.transcript != nil && recordSuccess {
+				h.container.StreamIdempotency.Set(ctx, rc.TenantID, rc.APIKeyID, idempotencyKey, sse.transcript.Bytes())
+			}
+			h.executor.MirrorChat(ctx, rc, alias, req, traceID)
 		})
 
 		return nil
@@ -562,6 +744,7 @@ func (h *openAIHandler) streamChat(
 			Context:   rc,
 			Alias:     alias,
 			Provider:  lastRoute.Provider,
+			ABVariant: lastRoute.ABVariant,
 			Status:    fiber.StatusBadGateway,
 			ErrorCode: lastErr.Error(),
 			TraceID:   traceID,
@@ -570,12 +753,14 @@ func (h *openAIHandler) streamChat(
 		})
 	}
 	release()
+	releaseHold(0)
 	return httputil.WriteError(c, fiber.StatusBadGateway, lastErr.Error())
 }
 
 type openAIEmbeddingRequest struct {
-	Model string          `json:"model"`
-	Input json.RawMessage `json:"input"`
+	Model      string          `json:"model"`
+	Input      json.RawMessage `json:"input"`
+	Dimensions *int32          `json:"dimensions,omitempty"`
 }
 
 type openAIEmbedding struct {
@@ -636,21 +821,35 @@ func (h *openAIHandler) embeddings(c *fiber.Ctx) error {
 	if !h.container.IsModelAllowed(rc.TenantID, req.Model) {
 		return httputil.WriteError(c, fiber.StatusForbidden, "model not enabled for tenant")
 	}
+	h.warnIfDeprecated(c, req.Model)
+	if wantsCostEstimate(c) {
+		return h.estimateEmbeddingCost(c, req.Model, inputs)
+	}
 
-	routes := h.container.Engine.SelectRoutes(req.Model)
-	if len(routes) == 0 {
-		return httputil.WriteError(c, fiber.StatusServiceUnavailable, "no backend available for model")
+	timings := requestctx.TimingsFromContext(ctx)
+	stageStart := time.Now()
+	routes, err := h.executor.SelectRoutes(rc, req.Model)
+	timings.Track(requestctx.StageRouteSelect, stageStart)
+	if err != nil {
+		status, msg, _ := executor.AsAPIError(err)
+		return httputil.WriteError(c, status, msg)
+	}
+	if err := executor.ValidateEmbeddingDimensions(req.Model, routes, req.Dimensions); err != nil {
+		status, msg, _ := executor.AsAPIError(err)
+		return httputil.WriteError(c, status, msg)
 	}
 
 	alias := req.Model
 
 	traceID := traceIDFromContext(c)
 
+	stageStart = time.Now()
 	initialBudget, err := h.container.UsageLogger.CheckBudget(ctx, rc, time.Now().UTC())
+	timings.Track(requestctx.StageBudgetCheck, stageStart)
 	if err != nil {
 		return httputil.WriteError(c, fiber.StatusInternalServerError, "failed to evaluate budget")
 	}
-	if initialBudget.Exceeded {
+	if initialBudget.Blocked() {
 		setBudgetHeaders(c, initialBudget)
 		_, _ = h.container.UsageLogger.Record(ctx, usagepipeline.Record{
 			Context:   rc,
@@ -666,7 +865,9 @@ func (h *openAIHandler) embeddings(c *fiber.Ctx) error {
 	}
 	setBudgetHeaders(c, initialBudget)
 
+	stageStart = time.Now()
 	keyKey, keyCfg, tenantKey, tenantCfg, release, err := h.container.AcquireRateLimits(ctx, alias)
+	timings.Track(requestctx.StageRateLimit, stageStart)
 	if err != nil {
 		if errors.Is(err, limits.ErrLimitExceeded) {
 			return httputil.WriteError(c, fiber.StatusTooManyRequests, "rate limit exceeded")
@@ -675,8 +876,28 @@ func (h *openAIHandler) embeddings(c *fiber.Ctx) error {
 	}
 	defer release()
 
+	if cached, ok := h.container.CachedEmbeddings(ctx, alias, req.Dimensions, inputs); ok {
+		openaiResp := convertEmbeddingResponse(cached, alias)
+		status, err := h.container.UsageLogger.Record(ctx, usagepipeline.Record{
+			Context:   rc,
+			Alias:     alias,
+			Provider:  "cache",
+			Status:    fiber.StatusOK,
+			TraceID:   traceID,
+			Timestamp: time.Now().UTC(),
+			Success:   true,
+		})
+		if err != nil {
+			return httputil.WriteError(c, fiber.StatusInternalServerError, "failed to persist usage")
+		}
+		setBudgetHeaders(c, status)
+		c.Set("X-Embedding-Cache-Hit", "true")
+		return c.JSON(openaiResp)
+	}
+
 	modelReq := models.EmbeddingsRequest{
-		Input: inputs,
+		Input:      inputs,
+		Dimensions: req.Dimensions,
 	}
 
 	var lastErr error
@@ -686,7 +907,11 @@ func (h *openAIHandler) embeddings(c *fiber.Ctx) error {
 		lastRoute = route
 		modelReq.Model = route.ResolveDeployment()
 		start := time.Now()
-		resp, err := route.Embedding.Embed(ctx, modelReq)
+		routeCtx, cancel, timeout := h.executor.RouteContext(ctx, route)
+		resp, err := executor.Embed(routeCtx, route.Embedding, modelReq)
+		executor.LogRouteTimeout(routeCtx, alias, route, timeout, err)
+		cancel()
+		timings.Track(requestctx.StageProvider, start)
 		if err != nil {
 			h.container.Engine.ReportFailure(req.Model, route)
 			lastLatency = time.Since(start)
@@ -714,11 +939,16 @@ func (h *openAIHandler) embeddings(c *fiber.Ctx) error {
 				return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
 			}
 		}
+		h.container.StoreEmbeddings(ctx, alias, req.Dimensions, inputs, resp)
+		if h.container.EmbeddingCache != nil {
+			c.Set("X-Embedding-Cache-Hit", "false")
+		}
 		openaiResp := convertEmbeddingResponse(resp, alias)
 		record := usagepipeline.Record{
 			Context:   rc,
 			Alias:     alias,
 			Provider:  route.Provider,
+			ABVariant: route.ABVariant,
 			Usage:     resp.Usage,
 			Latency:   elapsed,
 			Status:    fiber.StatusOK,
@@ -726,11 +956,17 @@ func (h *openAIHandler) embeddings(c *fiber.Ctx) error {
 			Timestamp: time.Now().UTC(),
 			Success:   true,
 		}
-		if status, err := h.container.UsageLogger.Record(ctx, record); err == nil {
-			setBudgetHeaders(c, status)
-		} else {
+		if h.container.UsageLogger.PayloadLoggingEnabled() {
+			record.RequestPayload, _ = json.Marshal(models.EmbeddingsRequest{Model: alias, Input: inputs, Dimensions: req.Dimensions})
+			record.ResponsePayload, _ = json.Marshal(openaiResp)
+		}
+		stageStart = time.Now()
+		status, err := h.container.UsageLogger.Record(ctx, record)
+		timings.Track(requestctx.StageUsageRecord, stageStart)
+		if err != nil {
 			return httputil.WriteError(c, fiber.StatusInternalServerError, "failed to persist usage")
 		}
+		setBudgetHeaders(c, status)
 		return c.JSON(openaiResp)
 	}
 
@@ -742,6 +978,7 @@ func (h *openAIHandler) embeddings(c *fiber.Ctx) error {
 			Context:   rc,
 			Alias:     alias,
 			Provider:  lastRoute.Provider,
+			ABVariant: lastRoute.ABVariant,
 			Latency:   lastLatency,
 			Status:    fiber.StatusBadGateway,
 			ErrorCode: lastErr.Error(),
@@ -776,12 +1013,24 @@ func (h *openAIHandler) imageGenerations(c *fiber.Ctx) error {
 	}
 
 	ctx := c.UserContext()
+	if wantsCostEstimate(c) {
+		rc, ok := requestctx.FromContext(ctx)
+		if !ok || rc == nil {
+			return httputil.WriteError(c, fiber.StatusInternalServerError, "request context missing")
+		}
+		if !h.container.IsModelAllowed(rc.TenantID, req.Model) {
+			return httputil.WriteError(c, fiber.StatusForbidden, "model not enabled for tenant")
+		}
+		return h.estimateImageCost(c, req.Model, req.Prompt)
+	}
+
 	idempotencyKey := strings.TrimSpace(c.Get("Idempotency-Key"))
 	baseReq := req
 	return h.runImageOperation(c, imageOperationConfig{
 		Alias:          req.Model,
 		IdempotencyKey: idempotencyKey,
-		Builder: func(route providers.Route) (models.ImageResponse, error) {
+		Prompt:         req.Prompt,
+		Builder: func(ctx context.Context, route providers.Route) (models.ImageResponse, error) {
 			modelReq := models.ImageRequest{
 				Model:          route.ResolveDeployment(),
 				Prompt:         baseReq.Prompt,
@@ -851,10 +1100,10 @@ func (h *openAIHandler) imageEdits(c *fiber.Ctx) error {
 		N:              n,
 		User:           strings.TrimSpace(c.FormValue("user")),
 	}
-	ctx := c.UserContext()
 	return h.runImageOperation(c, imageOperationConfig{
-		Alias: model,
-		Builder: func(route providers.Route) (models.ImageResponse, error) {
+		Alias:  model,
+		Prompt: prompt,
+		Builder: func(ctx context.Context, route providers.Route) (models.ImageResponse, error) {
 			req := baseReq
 			req.Model = route.ResolveDeployment()
 			req.Images = cloneImageInputs(baseReq.Images)
@@ -899,10 +1148,9 @@ func (h *openAIHandler) imageVariations(c *fiber.Ctx) error {
 		N:              n,
 		User:           strings.TrimSpace(c.FormValue("user")),
 	}
-	ctx := c.UserContext()
 	return h.runImageOperation(c, imageOperationConfig{
 		Alias: model,
-		Builder: func(route providers.Route) (models.ImageResponse, error) {
+		Builder: func(ctx context.Context, route providers.Route) (models.ImageResponse, error) {
 			req := baseReq
 			req.Model = route.ResolveDeployment()
 			req.Image = baseReq.Image
@@ -1028,6 +1276,33 @@ func setBudgetHeaders(c *fiber.Ctx, status usagepipeline.BudgetStatus) {
 	}
 }
 
+// compileResponseFormat prepares the schema for json_schema response formats so
+// malformed schemas are rejected before the provider is called.
+func compileResponseFormat(format *models.ChatResponseFormat) (*schemavalidation.Schema, error) {
+	if format == nil || !strings.EqualFold(strings.TrimSpace(format.Type), "json_schema") {
+		return nil, nil
+	}
+	if format.JSONSchema == nil {
+		return nil, errors.New("response_format.json_schema is required")
+	}
+	return schemavalidation.Compile(*format.JSONSchema)
+}
+
+func validateChatOutput(schema *schemavalidation.Schema, resp models.ChatResponse) *schemavalidation.ValidationError {
+	for _, choice := range resp.Choices {
+		err := schema.Validate(choice.Message.Content)
+		if err == nil {
+			continue
+		}
+		var verr *schemavalidation.ValidationError
+		if errors.As(err, &verr) {
+			return verr
+		}
+		return &schemavalidation.ValidationError{Details: []schemavalidation.Detail{{Message: err.Error()}}}
+	}
+	return nil
+}
+
 func convertChatResponse(resp models.ChatResponse, alias string) openAIChatResponse {
 	choices := make([]openAIChatChoice, 0, len(resp.Choices))
 	for _, choice := range resp.Choices {
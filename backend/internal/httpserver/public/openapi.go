@@ -0,0 +1,109 @@
+package public
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/spec"
+)
+
+const openAPIDocVersion = "1.0.0"
+
+type audioTranscriptionResponse struct {
+	Text string `json:"text"`
+}
+
+// publicEndpoints describes the /v1 surface for the OpenAPI document. Routes
+// registered in Register but missing here still appear with generic metadata.
+var publicEndpoints = []spec.Endpoint{
+	{Method: fiber.MethodGet, Path: "/v1/models", Summary: "List models available to the caller", Tag: "models", Response: openAIModelList{}},
+	{Method: fiber.MethodPost, Path: "/v1/chat/completions", Summary: "Create a chat completion (set stream=true for server-sent events)", Tag: "chat", Request: openAIChatRequest{}, Response: openAIChatResponse{}},
+	{Method: fiber.MethodPost, Path: "/v1/responses", Summary: "Create a model response with the Responses API (stream=true emits response.* server-sent events; previous_response_id continues a stored conversation)", Tag: "chat", Request: openAIResponsesRequest{}, Response: openAIResponse{}},
+	{Method: fiber.MethodPost, Path: "/v1/embeddings", Summary: "Create embeddings", Tag: "embeddings", Request: openAIEmbeddingRequest{}, Response: openAIEmbeddingResponse{}},
+	{Method: fiber.MethodPost, Path: "/v1/ws/auth", Summary: "Issue a one-time token (valid 60s) for opening /v1/ws/chat/completions", Tag: "chat", Response: wsAuthResponse{}},
+	{Method: fiber.MethodGet, Path: "/v1/ws/chat/completions", Summary: "Stream a chat completion over WebSocket (authenticated by ?token= from /v1/ws/auth, not an API key)", Tag: "chat", Query: []spec.Parameter{spec.QueryString("token")}},
+	{Method: fiber.MethodGet, Path: "/v1/me/impersonation-info", Summary: "Show the admin behind the calling impersonation token, if any", Tag: "me", Response: impersonationInfoResponse{}},
+	{Method: fiber.MethodGet, Path: "/v1/me/budget", Summary: "Show the budget of the API key owner's personal tenant", Tag: "budget", Response: personalBudgetResponse{}},
+	{Method: fiber.MethodPut, Path: "/v1/me/budget", Summary: "Set the budget of the API key owner's personal tenant (capped by budgets.max_personal_budget_usd)", Tag: "budget", Request: personalBudgetRequest{}, Response: personalBudgetResponse{}},
+	{Method: fiber.MethodGet, Path: "/v1/me/budget/usage", Summary: "Show the API key owner's personal tenant spend for a period with its budget status", Tag: "budget", Response: personalBudgetUsageResponse{}},
+	{Method: fiber.MethodGet, Path: "/v1/me/api-keys", Summary: "List the API key owner's personal keys", Tag: "api-keys", Response: personalAPIKeyList{}},
+	{Method: fiber.MethodPost, Path: "/v1/me/api-keys", Summary: "Create a personal key (limited by api_keys.max_personal_api_keys)", Tag: "api-keys", Request: createPersonalAPIKeyRequest{}, Response: createPersonalAPIKeyResponse{}},
+	{Method: fiber.MethodDelete, Path: "/v1/me/api-keys/:keyID", Summary: "Revoke one of the API key owner's personal keys", Tag: "api-keys", Response: personalAPIKey{}},
+	{Method: fiber.MethodPost, Path: "/v1/tokens/count", Summary: "Estimate prompt tokens for a chat request without dispatching it", Tag: "chat", Request: tokenCountRequest{}, Response: tokenCountResponse{}},
+	{Method: fiber.MethodPost, Path: "/v1/images/generations", Summary: "Generate images", Tag: "images", Request: openAIImageRequest{}, Response: openAIImageResponse{}},
+	{Method: fiber.MethodPost, Path: "/v1/images/generations/async", Summary: "Queue an image generation and return a job to poll", Tag: "images", Request: openAIImageRequest{}, Response: openAIImageJob{}},
+	{Method: fiber.MethodGet, Path: "/v1/images/jobs/:jobID", Summary: "Retrieve a queued image job and its result", Tag: "images", Response: openAIImageJob{}},
+	{
+		Method: fiber.MethodPost, Path: "/v1/images/edits", Summary: "Edit images", Tag: "images",
+		Request: spec.Multipart(
+			spec.Text("model", true), spec.Text("prompt", true), spec.File("image", true), spec.File("mask", false),
+			spec.Text("n", false), spec.Text("size", false), spec.Text("response_format", false),
+			spec.Text("quality", false), spec.Text("background", false), spec.Text("style", false), spec.Text("user", false),
+		),
+		Response: openAIImageResponse{},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/v1/images/variations", Summary: "Create image variations", Tag: "images",
+		Request: spec.Multipart(
+			spec.Text("model", true), spec.File("image", true), spec.Text("n", false), spec.Text("size", false),
+			spec.Text("response_format", false), spec.Text("quality", false), spec.Text("background", false),
+			spec.Text("style", false), spec.Text("user", false),
+		),
+		Response: openAIImageResponse{},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/v1/audio/transcriptions", Summary: "Transcribe audio", Tag: "audio",
+		Request:  spec.Multipart(spec.Text("model", true), spec.File("file", true), spec.Text("prompt", false), spec.Text("language", false), spec.Text("temperature", false)),
+		Response: audioTranscriptionResponse{},
+	},
+	{
+		Method: fiber.MethodPost, Path: "/v1/audio/translations", Summary: "Translate audio to English", Tag: "audio",
+		Request:  spec.Multipart(spec.Text("model", true), spec.File("file", true), spec.Text("prompt", false), spec.Text("temperature", false)),
+		Response: audioTranscriptionResponse{},
+	},
+	{Method: fiber.MethodPost, Path: "/v1/audio/speech", Summary: "Synthesize speech", Tag: "audio", Request: audioSpeechRequest{}, Response: spec.Binary("application/octet-stream", "Synthesized audio")},
+	{Method: fiber.MethodGet, Path: "/v1/files", Summary: "List files", Tag: "files", Query: []spec.Parameter{spec.QueryString("purpose"), spec.QueryString("after"), spec.QueryInt("limit")}, Response: openAIFileList{}},
+	{
+		Method: fiber.MethodPost, Path: "/v1/files", Summary: "Upload a file", Tag: "files",
+		Request:  spec.Multipart(spec.File("file", true), spec.Text("purpose", true), spec.Text("expires_in", false)),
+		Response: openAIFile{},
+	},
+	{Method: fiber.MethodGet, Path: "/v1/files/:id", Summary: "Retrieve file metadata", Tag: "files", Response: openAIFile{}},
+	{Method: fiber.MethodDelete, Path: "/v1/files/:id", Summary: "Delete a file", Tag: "files", Response: openAIDeleteFile{}},
+	{Method: fiber.MethodGet, Path: "/v1/files/:id/content", Summary: "Download file content", Tag: "files", Response: spec.Binary("application/octet-stream", "File content")},
+	{Method: fiber.MethodPost, Path: "/v1/uploads", Summary: "Create a multipart upload (not yet implemented)", Tag: "files"},
+	{Method: fiber.MethodGet, Path: "/v1/batches", Summary: "List batches", Tag: "batches", Query: []spec.Parameter{spec.QueryString("after"), spec.QueryInt("limit")}, Response: openAIBatchList{}},
+	{Method: fiber.MethodPost, Path: "/v1/batches", Summary: "Create a batch", Tag: "batches", Request: createBatchRequest{}, Response: openAIBatchResponse{}},
+	{Method: fiber.MethodGet, Path: "/v1/batches/:id", Summary: "Retrieve a batch", Tag: "batches", Response: openAIBatchResponse{}},
+	{Method: fiber.MethodPost, Path: "/v1/batches/:id/cancel", Summary: "Cancel a batch", Tag: "batches", Response: openAIBatchResponse{}},
+	{Method: fiber.MethodGet, Path: "/v1/batches/:id/output", Summary: "Download batch results", Tag: "batches", Response: spec.Binary("application/jsonl", "Batch results as JSON lines")},
+	{Method: fiber.MethodGet, Path: "/v1/batches/:id/errors", Summary: "Download batch errors", Tag: "batches", Response: spec.Binary("application/jsonl", "Batch errors as JSON lines")},
+	{Method: fiber.MethodPost, Path: "/v1/batches/:id/items/next", Summary: "Read completed batch items while the batch runs", Tag: "batches", Query: []spec.Parameter{spec.QueryInt("after"), spec.QueryInt("limit")}, Response: spec.Binary("application/x-ndjson", "Completed items as JSON lines")},
+	{Method: fiber.MethodPost, Path: "/v1/invitations/accept", Summary: "Accept a tenant invitation as a new user (authenticated by the invitation token, not an API key)", Tag: "invitations", Request: acceptInvitationRequest{}, Response: acceptInvitationResponse{}},
+}
+
+func registerOpenAPIRoute(router fiber.Router, container *app.Container) {
+	router.Get("/openapi.json", func(c *fiber.Ctx) error {
+		registered := c.App().GetRoutes(true)
+		routes := make([]spec.Route, 0, len(registered))
+		for _, r := range registered {
+			routes = append(routes, spec.Route{Method: r.Method, Path: r.Path})
+		}
+
+		var aliases []string
+		if container.Engine != nil {
+			for alias, candidates := range container.Engine.ListAliases() {
+				if len(candidates) > 0 {
+					aliases = append(aliases, alias)
+				}
+			}
+		}
+
+		return c.JSON(spec.Build(routes, publicEndpoints, spec.Options{
+			Title:   "Open Model Gateway API",
+			Version: openAPIDocVersion,
+			Prefix:  "/v1/",
+			Models:  aliases,
+		}))
+	})
+}
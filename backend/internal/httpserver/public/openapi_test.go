@@ -0,0 +1,27 @@
+package public
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+)
+
+func TestPublicEndpointsCoverRegisteredRoutes(t *testing.T) {
+	server := fiber.New()
+	Register(server, &app.Container{})
+
+	documented := make(map[string]bool, len(publicEndpoints))
+	for _, ep := range publicEndpoints {
+		documented[ep.Method+" "+ep.Path] = true
+	}
+	for _, route := range server.GetRoutes(true) {
+		if !strings.HasPrefix(route.Path, "/v1/") || route.Method == fiber.MethodHead {
+			continue
+		}
+		require.Truef(t, documented[route.Method+" "+route.Path], "route %s %s missing from publicEndpoints", route.Method, route.Path)
+	}
+}
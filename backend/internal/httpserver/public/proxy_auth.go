@@ -0,0 +1,54 @@
+package public
+
+import (
+	"net/netip"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+)
+
+// trustedProxyAuth reads the caller's user ID from a header set by a reverse
+// proxy. The header is honored only when the TCP peer, never a forwarded
+// address, is inside one of the trusted CIDRs, so clients cannot inject it.
+type trustedProxyAuth struct {
+	header   string
+	prefixes []netip.Prefix
+}
+
+// newTrustedProxyAuth returns nil when proxy auth is disabled or trusts no
+// network.
+func newTrustedProxyAuth(cfg config.ProxyAuthConfig) *trustedProxyAuth {
+	if !cfg.Enabled || strings.TrimSpace(cfg.UserIDHeader) == "" {
+		return nil
+	}
+	prefixes := cfg.TrustedPrefixes()
+	if len(prefixes) == 0 {
+		return nil
+	}
+	return &trustedProxyAuth{header: strings.TrimSpace(cfg.UserIDHeader), prefixes: prefixes}
+}
+
+// userID returns the forwarded user ID when the request carries one and
+// arrived from a trusted proxy.
+func (p *trustedProxyAuth) userID(c *fiber.Ctx) (string, bool) {
+	if p == nil {
+		return "", false
+	}
+	value := strings.TrimSpace(c.Get(p.header))
+	if value == "" {
+		return "", false
+	}
+	addr, ok := netip.AddrFromSlice(c.Context().RemoteIP())
+	if !ok {
+		return "", false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range p.prefixes {
+		if prefix.Contains(addr) {
+			return value, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,50 @@
+package public
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+)
+
+func proxyUserID(t *testing.T, proxy *trustedProxyAuth, header string) string {
+	t.Helper()
+	fiberApp := fiber.New()
+	fiberApp.Get("/v1/models", func(c *fiber.Ctx) error {
+		userID, _ := proxy.userID(c)
+		return c.SendString(userID)
+	})
+	req := httptest.NewRequest(fiber.MethodGet, "/v1/models", nil)
+	if header != "" {
+		req.Header.Set("X-Forwarded-User", header)
+	}
+	resp, err := fiberApp.Test(req, -1)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return string(body)
+}
+
+func TestTrustedProxyAuthRequiresTrustedPeer(t *testing.T) {
+	// fiber's test connection reports 0.0.0.0 as the peer address.
+	trusted := newTrustedProxyAuth(config.ProxyAuthConfig{Enabled: true, UserIDHeader: "X-Forwarded-User", TrustedProxies: []string{"0.0.0.0/32"}})
+	if got := proxyUserID(t, trusted, " user-1 "); got != "user-1" {
+		t.Fatalf("expected the header from a trusted peer, got %q", got)
+	}
+	if got := proxyUserID(t, trusted, ""); got != "" {
+		t.Fatalf("expected no user without the header, got %q", got)
+	}
+
+	untrusted := newTrustedProxyAuth(config.ProxyAuthConfig{Enabled: true, UserIDHeader: "X-Forwarded-User", TrustedProxies: []string{"10.0.0.0/8"}})
+	if got := proxyUserID(t, untrusted, "user-1"); got != "" {
+		t.Fatalf("header from an untrusted peer must be ignored, got %q", got)
+	}
+	if newTrustedProxyAuth(config.ProxyAuthConfig{UserIDHeader: "X-Forwarded-User", TrustedProxies: []string{"0.0.0.0/32"}}) != nil {
+		t.Fatal("disabled proxy auth should not trust any peer")
+	}
+}
@@ -0,0 +1,53 @@
+package public
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	"github.com/ncecere/open_model_gateway/backend/internal/limits"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+)
+
+// tenantQuota enforces the tenant's request quota on model-dispatching routes.
+// A slot is reserved before the handler runs and refunded when the request
+// fails, so only successful requests count against the quota. Cost-estimate
+// dry runs are not counted.
+func tenantQuota(container *app.Container) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		rc, ok := c.Locals(requestctx.FiberLocalsKey()).(*requestctx.Context)
+		if !ok || rc == nil || wantsCostEstimate(c) {
+			return c.Next()
+		}
+		cfg, ok := container.TenantQuota(rc.TenantID)
+		if !ok {
+			return c.Next()
+		}
+
+		status, err := container.Quotas.Reserve(userContext(c), rc.TenantID, cfg)
+		if err != nil {
+			if errors.Is(err, limits.ErrQuotaExceeded) {
+				setQuotaHeaders(c, status)
+				return httputil.WriteError(c, fiber.StatusTooManyRequests, "quota_exceeded")
+			}
+			return httputil.WriteError(c, fiber.StatusInternalServerError, "failed to evaluate quota")
+		}
+		setQuotaHeaders(c, status)
+
+		err = c.Next()
+		if err != nil || c.Response().StatusCode() >= fiber.StatusBadRequest {
+			container.Quotas.Refund(context.Background(), status)
+		}
+		return err
+	}
+}
+
+func setQuotaHeaders(c *fiber.Ctx, status limits.QuotaStatus) {
+	c.Set("X-Quota-Limit", strconv.FormatInt(status.Limit, 10))
+	c.Set("X-Quota-Remaining", strconv.FormatInt(status.Remaining(), 10))
+	c.Set("X-Quota-Reset", strconv.FormatInt(status.ResetAt.Unix(), 10))
+}
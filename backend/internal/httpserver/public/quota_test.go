@@ -0,0 +1,111 @@
+package public
+
+import (
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/limits"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+)
+
+func newQuotaTestApp(t *testing.T, maxRequests int64, handler fiber.Handler) *fiber.App {
+	t.Helper()
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	rdb := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() {
+		rdb.Close()
+		server.Close()
+	})
+
+	tenantID := uuid.New()
+	container := &app.Container{Quotas: limits.NewQuotaCounter(rdb)}
+	container.UpdateTenantQuota(tenantID, &limits.QuotaConfig{MaxRequests: maxRequests, RefreshSchedule: "calendar_month"})
+
+	fiberApp := fiber.New()
+	fiberApp.Post("/v1/chat/completions", func(c *fiber.Ctx) error {
+		c.Locals(requestctx.FiberLocalsKey(), &requestctx.Context{TenantID: tenantID})
+		return c.Next()
+	}, tenantQuota(container), handler)
+	return fiberApp
+}
+
+func TestTenantQuotaConcurrentRequests(t *testing.T) {
+	fiberApp := newQuotaTestApp(t, 5, func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	const total = 25
+	statuses := make(chan int, total)
+	var wg sync.WaitGroup
+	for i := 0; i < total; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := fiberApp.Test(httptest.NewRequest(fiber.MethodPost, "/v1/chat/completions", nil), -1)
+			if err != nil {
+				t.Errorf("request: %v", err)
+				return
+			}
+			resp.Body.Close()
+			if resp.Header.Get("X-Quota-Limit") != "5" || resp.Header.Get("X-Quota-Reset") == "" {
+				t.Errorf("missing quota headers: %v", resp.Header)
+			}
+			statuses <- resp.StatusCode
+		}()
+	}
+	wg.Wait()
+	close(statuses)
+
+	counts := map[int]int{}
+	for status := range statuses {
+		counts[status]++
+	}
+	if counts[fiber.StatusOK] != 5 || counts[fiber.StatusTooManyRequests] != total-5 {
+		t.Fatalf("expected 5 successes and %d quota rejections, got %v", total-5, counts)
+	}
+}
+
+func TestTenantQuotaRefundsFailedRequests(t *testing.T) {
+	fail := true
+	fiberApp := newQuotaTestApp(t, 1, func(c *fiber.Ctx) error {
+		if fail {
+			return c.SendStatus(fiber.StatusBadGateway)
+		}
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	resp, err := fiberApp.Test(httptest.NewRequest(fiber.MethodPost, "/v1/chat/completions", nil), -1)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusBadGateway {
+		t.Fatalf("expected upstream failure, got %d", resp.StatusCode)
+	}
+
+	fail = false
+	resp, err = fiberApp.Test(httptest.NewRequest(fiber.MethodPost, "/v1/chat/completions", nil), -1)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK || resp.Header.Get("X-Quota-Remaining") != "0" {
+		t.Fatalf("expected failed request to be refunded, got %d remaining=%s", resp.StatusCode, resp.Header.Get("X-Quota-Remaining"))
+	}
+
+	resp, err = fiberApp.Test(httptest.NewRequest(fiber.MethodPost, "/v1/chat/completions", nil), -1)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusTooManyRequests {
+		t.Fatalf("expected quota_exceeded, got %d", resp.StatusCode)
+	}
+}
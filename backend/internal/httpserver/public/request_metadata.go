@@ -0,0 +1,54 @@
+package public
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+)
+
+const (
+	requestMetadataHeader   = "X-Request-Metadata"
+	maxRequestMetadataBytes = 1024
+)
+
+// parseRequestMetadata decodes the X-Request-Metadata header, a JSON object
+// of at most maxRequestMetadataBytes. An empty header yields nil.
+func parseRequestMetadata(header string) (map[string]any, error) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return nil, nil
+	}
+	if len(header) > maxRequestMetadataBytes {
+		return nil, fmt.Errorf("%s exceeds %d bytes", requestMetadataHeader, maxRequestMetadataBytes)
+	}
+	var metadata map[string]any
+	if err := json.Unmarshal([]byte(header), &metadata); err != nil || metadata == nil {
+		return nil, fmt.Errorf("%s must be a JSON object", requestMetadataHeader)
+	}
+	if len(metadata) == 0 {
+		return nil, nil
+	}
+	return metadata, nil
+}
+
+// requestMetadata attaches the X-Request-Metadata header to the request
+// context so it is stored with the request log.
+func requestMetadata() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		rc, ok := c.Locals(requestctx.FiberLocalsKey()).(*requestctx.Context)
+		if !ok || rc == nil {
+			return c.Next()
+		}
+		metadata, err := parseRequestMetadata(c.Get(requestMetadataHeader))
+		if err != nil {
+			return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+		}
+		rc.Metadata = metadata
+		return c.Next()
+	}
+}
@@ -0,0 +1,36 @@
+package public
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseRequestMetadata(t *testing.T) {
+	metadata, err := parseRequestMetadata(` {"project":"llm-ops","run":3} `)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if metadata["project"] != "llm-ops" || metadata["run"] != float64(3) {
+		t.Fatalf("unexpected metadata %v", metadata)
+	}
+
+	for _, header := range []string{"", "{}"} {
+		if metadata, err := parseRequestMetadata(header); err != nil || metadata != nil {
+			t.Fatalf("%q: expected no metadata, got %v (%v)", header, metadata, err)
+		}
+	}
+}
+
+func TestParseRequestMetadataRejectsInvalid(t *testing.T) {
+	cases := map[string]string{
+		"not json":  "project=llm-ops",
+		"array":     `["llm-ops"]`,
+		"null":      "null",
+		"too large": `{"note":"` + strings.Repeat("x", maxRequestMetadataBytes) + `"}`,
+	}
+	for name, header := range cases {
+		if _, err := parseRequestMetadata(header); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
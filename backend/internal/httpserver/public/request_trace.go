@@ -0,0 +1,43 @@
+package public
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+)
+
+const (
+	requestTraceHeader  = "X-Request-Trace"
+	requestTimingHeader = "X-Request-Timing"
+)
+
+// requestTrace collects a per-stage timing breakdown for requests sent with
+// X-Request-Trace: true and returns it in the X-Request-Timing header once
+// the caller has authenticated. Streamed responses are skipped: their headers
+// are sent before the provider stage finishes.
+func requestTrace() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !strings.EqualFold(strings.TrimSpace(c.Get(requestTraceHeader)), "true") {
+			return c.Next()
+		}
+		timings := requestctx.NewRequestTimings()
+		c.Locals(requestctx.TimingsLocalsKey(), timings)
+		c.SetUserContext(requestctx.WithTimings(userContext(c), timings))
+
+		err := c.Next()
+
+		if rc, ok := c.Locals(requestctx.FiberLocalsKey()).(*requestctx.Context); !ok || rc == nil {
+			return err
+		}
+		if c.Response().IsBodyStream() {
+			return err
+		}
+		if encoded, marshalErr := json.Marshal(timings); marshalErr == nil {
+			c.Set(requestTimingHeader, string(encoded))
+		}
+		return err
+	}
+}
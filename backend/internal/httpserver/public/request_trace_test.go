@@ -0,0 +1,96 @@
+package public
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+)
+
+func newRequestTraceApp() *fiber.App {
+	app := fiber.New()
+	authenticate := func(c *fiber.Ctx) error {
+		if c.Get(fiber.HeaderAuthorization) == "" {
+			return httputil.WriteError(c, fiber.StatusUnauthorized, "authorization header required")
+		}
+		return continueAuthenticated(c, &requestctx.Context{}, nil, time.Now().Add(-3*time.Millisecond))
+	}
+	app.Use(requestTrace(), authenticate)
+	app.Post("/v1/chat/completions", func(c *fiber.Ctx) error {
+		requestctx.TimingsFromContext(c.UserContext()).Add(requestctx.StageProvider, 42*time.Millisecond)
+		return c.JSON(fiber.Map{"ok": true})
+	})
+	app.Post("/v1/stream", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, "text/event-stream")
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			_, _ = w.WriteString("data: [DONE]\n\n")
+		})
+		return nil
+	})
+	return app
+}
+
+func TestRequestTraceReturnsTimingBreakdown(t *testing.T) {
+	app := newRequestTraceApp()
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/chat/completions", nil)
+	req.Header.Set(fiber.HeaderAuthorization, "Bearer sk-test")
+	req.Header.Set(requestTraceHeader, "true")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	header := resp.Header.Get(requestTimingHeader)
+	if header == "" {
+		t.Fatal("expected X-Request-Timing header")
+	}
+	var timings map[string]int64
+	if err := json.Unmarshal([]byte(header), &timings); err != nil {
+		t.Fatalf("decode %q: %v", header, err)
+	}
+	for _, key := range []string{"auth_ms", "budget_check_ms", "rate_limit_ms", "route_select_ms", "provider_ms", "usage_record_ms"} {
+		if _, ok := timings[key]; !ok {
+			t.Fatalf("missing %s in %q", key, header)
+		}
+	}
+	if timings["provider_ms"] != 42 {
+		t.Fatalf("expected provider_ms 42, got %d", timings["provider_ms"])
+	}
+	if timings["auth_ms"] < 3 {
+		t.Fatalf("expected auth time to be recorded, got %d", timings["auth_ms"])
+	}
+}
+
+func TestRequestTraceOmitsTimingHeader(t *testing.T) {
+	app := newRequestTraceApp()
+	cases := map[string]struct {
+		path    string
+		auth    bool
+		tracing bool
+	}{
+		"not requested":   {path: "/v1/chat/completions", auth: true},
+		"unauthenticated": {path: "/v1/chat/completions", tracing: true},
+		"streaming":       {path: "/v1/stream", auth: true, tracing: true},
+	}
+	for name, tc := range cases {
+		req := httptest.NewRequest(fiber.MethodPost, tc.path, nil)
+		if tc.auth {
+			req.Header.Set(fiber.HeaderAuthorization, "Bearer sk-test")
+		}
+		if tc.tracing {
+			req.Header.Set(requestTraceHeader, "true")
+		}
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("%s: request: %v", name, err)
+		}
+		if got := resp.Header.Get(requestTimingHeader); got != "" {
+			t.Fatalf("%s: expected no timing header, got %q", name, got)
+		}
+	}
+}
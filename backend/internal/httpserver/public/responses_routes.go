@@ -0,0 +1,418 @@
+package public
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/executor"
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+	responsesvc "github.com/ncecere/open_model_gateway/backend/internal/services/responses"
+)
+
+// openAIResponsesRequest is the subset of the Responses API request the
+// gateway translates to a chat completion. Input is either a string or an
+// array of message items.
+type openAIResponsesRequest struct {
+	Model              string          `json:"model"`
+	Input              json.RawMessage `json:"input"`
+	Instructions       string          `json:"instructions,omitempty"`
+	PreviousResponseID string          `json:"previous_response_id,omitempty"`
+	Temperature        *float32        `json:"temperature,omitempty"`
+	TopP               *float32        `json:"top_p,omitempty"`
+	MaxOutputTokens    *int32          `json:"max_output_tokens,omitempty"`
+	Stream             bool            `json:"stream,omitempty"`
+	// Store defaults to true; false skips persisting the turn, so the
+	// response cannot be used as a previous_response_id.
+	Store    *bool             `json:"store,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+type responseInputItem struct {
+	Type    string          `json:"type,omitempty"`
+	Role    string          `json:"role"`
+	Content json.RawMessage `json:"content"`
+}
+
+type responseContentPart struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type openAIResponse struct {
+	ID                 string                     `json:"id"`
+	Object             string                     `json:"object"`
+	CreatedAt          int64                      `json:"created_at"`
+	Status             string                     `json:"status"`
+	Model              string                     `json:"model"`
+	Instructions       string                     `json:"instructions,omitempty"`
+	PreviousResponseID string                     `json:"previous_response_id,omitempty"`
+	Output             []openAIResponseOutputItem `json:"output"`
+	Usage              *openAIResponseUsage       `json:"usage,omitempty"`
+	Error              *openAIResponseError       `json:"error,omitempty"`
+	Metadata           map[string]string          `json:"metadata,omitempty"`
+}
+
+type openAIResponseOutputItem struct {
+	Type    string                     `json:"type"`
+	ID      string                     `json:"id"`
+	Status  string                     `json:"status"`
+	Role    string                     `json:"role"`
+	Content []openAIResponseOutputText `json:"content"`
+}
+
+type openAIResponseOutputText struct {
+	Type        string            `json:"type"`
+	Text        string            `json:"text"`
+	Annotations []json.RawMessage `json:"annotations"`
+}
+
+type openAIResponseUsage struct {
+	InputTokens  int32 `json:"input_tokens"`
+	OutputTokens int32 `json:"output_tokens"`
+	TotalTokens  int32 `json:"total_tokens"`
+}
+
+type openAIResponseError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// responses serves POST /v1/responses by translating the request into a chat
+// completion. Budgets, rate limits, routing, and usage recording go through
+// the executor exactly as for /v1/chat/completions.
+func (h *openAIHandler) responses(c *fiber.Ctx) error {
+	var req openAIResponsesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
+	}
+	req.Model = strings.TrimSpace(req.Model)
+	if req.Model == "" {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "model is required")
+	}
+	input, err := parseResponsesInput(req.Input)
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+	}
+	tags, err := parseRequestTags(c.Get("X-Request-Tags"), req.Metadata)
+	if err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	ctx := c.UserContext()
+	rc, ok := requestctx.FromContext(ctx)
+	if !ok || rc == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "request context missing")
+	}
+	rc.Tags = tags
+	if !h.container.IsModelAllowed(rc.TenantID, req.Model) {
+		return httputil.WriteError(c, fiber.StatusForbidden, "model not enabled for tenant")
+	}
+	h.warnIfDeprecated(c, req.Model)
+
+	var history []models.ChatMessage
+	if req.PreviousResponseID != "" {
+		history, err = h.container.Responses.History(ctx, rc.TenantID, req.PreviousResponseID)
+		if err != nil {
+			if errors.Is(err, responsesvc.ErrNotFound) {
+				return httputil.WriteError(c, fiber.StatusNotFound, err.Error())
+			}
+			return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+		}
+	}
+	conversation := append(history, input...)
+	modelReq := models.ChatRequest{
+		Messages:    withInstructions(req.Instructions, conversation),
+		Temperature: req.Temperature,
+		TopP:        req.TopP,
+		MaxTokens:   req.MaxOutputTokens,
+	}
+
+	resp := openAIResponse{
+		ID:                 responsesvc.NewID(),
+		Object:             "response",
+		CreatedAt:          time.Now().Unix(),
+		Status:             "in_progress",
+		Model:              req.Model,
+		Instructions:       req.Instructions,
+		PreviousResponseID: req.PreviousResponseID,
+		Output:             []openAIResponseOutputItem{},
+		Metadata:           req.Metadata,
+	}
+	traceID := traceIDFromContext(c)
+	idempotencyKey := strings.TrimSpace(c.Get("Idempotency-Key"))
+
+	if req.Stream {
+		modelReq.Stream = true
+		return h.streamResponse(c, rc, req, modelReq, conversation, resp, traceID, idempotencyKey)
+	}
+
+	chatResult, err := h.executor.Chat(ctx, rc, req.Model, modelReq, traceID, idempotencyKey)
+	if err != nil {
+		if status, msg, ok := executor.AsAPIError(err); ok {
+			return httputil.WriteError(c, status, msg)
+		}
+		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	setBudgetHeaders(c, chatResult.BudgetStatus)
+	if chatResult.SystemPromptApplied {
+		c.Set("X-System-Prompt-Applied", "true")
+	}
+
+	var text string
+	if len(chatResult.Response.Choices) > 0 {
+		text = chatResult.Response.Choices[0].Message.Content
+	}
+	resp.Status = "completed"
+	resp.Output = []openAIResponseOutputItem{responseMessage(resp.ID, "completed", text)}
+	resp.Usage = &openAIResponseUsage{
+		InputTokens:  chatResult.Response.Usage.PromptTokens,
+		OutputTokens: chatResult.Response.Usage.CompletionTokens,
+		TotalTokens:  chatResult.Response.Usage.TotalTokens,
+	}
+	h.saveResponse(ctx, rc, req, resp.ID, conversation, text)
+	return c.JSON(resp)
+}
+
+// streamResponse relays the chat stream as Responses API server-sent events:
+// response.created, the output item and content part lifecycle with
+// response.output_text.delta for each chunk, and finally response.completed
+// (or response.failed).
+func (h *openAIHandler) streamResponse(
+	c *fiber.Ctx,
+	rc *requestctx.Context,
+	req openAIResponsesRequest,
+	modelReq models.ChatRequest,
+	conversation []models.ChatMessage,
+	resp openAIResponse,
+	traceID, idempotencyKey string,
+) error {
+	ctx := c.UserContext()
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+
+	streamDone := h.container.Streams.Start()
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer streamDone()
+		events := &responseEventWriter{w: w}
+		if err := events.emit("response.created", fiber.Map{"response": resp}); err != nil {
+			return
+		}
+
+		item := responseMessage(resp.ID, "in_progress", "")
+		var text strings.Builder
+		opened := false
+		open := func() error {
+			if opened {
+				return nil
+			}
+			opened = true
+			if err := events.emit("response.output_item.added", fiber.Map{"output_index": 0, "item": item}); err != nil {
+				return err
+			}
+			return events.emit("response.content_part.added", fiber.Map{
+				"item_id": item.ID, "output_index": 0, "content_index": 0, "part": item.Content[0],
+			})
+		}
+
+		_, err := h.executor.ChatStream(ctx, rc, req.Model, modelReq, traceID, idempotencyKey, func(chunk models.ChatChunk) error {
+			for _, choice := range chunk.Choices {
+				if choice.Delta.Content == "" {
+					continue
+				}
+				if err := open(); err != nil {
+					return err
+				}
+				text.WriteString(choice.Delta.Content)
+				if err := events.emit("response.output_text.delta", fiber.Map{
+					"item_id": item.ID, "output_index": 0, "content_index": 0, "delta": choice.Delta.Content,
+				}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			resp.Status = "failed"
+			resp.Error = responseError(err)
+			_ = events.emit("response.failed", fiber.Map{"response": resp})
+			return
+		}
+		if err := open(); err != nil {
+			return
+		}
+
+		done := responseMessage(resp.ID, "completed", text.String())
+		_ = events.emit("response.output_text.done", fiber.Map{
+			"item_id": item.ID, "output_index": 0, "content_index": 0, "text": text.String(),
+		})
+		_ = events.emit("response.content_part.done", fiber.Map{
+			"item_id": item.ID, "output_index": 0, "content_index": 0, "part": done.Content[0],
+		})
+		_ = events.emit("response.output_item.done", fiber.Map{"output_index": 0, "item": done})
+		resp.Status = "completed"
+		resp.Output = []openAIResponseOutputItem{done}
+		_ = events.emit("response.completed", fiber.Map{"response": resp})
+
+		h.saveResponse(context.Background(), rc, req, resp.ID, conversation, text.String())
+	})
+	return nil
+}
+
+// saveResponse stores the turn for previous_response_id continuations unless
+// the caller set store=false or the key is zero-retention.
+func (h *openAIHandler) saveResponse(ctx context.Context, rc *requestctx.Context, req openAIResponsesRequest, id string, conversation []models.ChatMessage, text string) {
+	if (req.Store != nil && !*req.Store) || rc.ZeroRetention || h.container.Responses == nil {
+		return
+	}
+	messages := append(append([]models.ChatMessage{}, conversation...), models.ChatMessage{Role: "assistant", Content: text})
+	if err := h.container.Responses.Save(ctx, responsesvc.Turn{
+		ID:                 id,
+		TenantID:           rc.TenantID,
+		APIKeyID:           rc.APIKeyID,
+		Alias:              req.Model,
+		PreviousResponseID: req.PreviousResponseID,
+		Messages:           messages,
+	}); err != nil {
+		slog.Error("store response", slog.String("response_id", id), slog.String("error", err.Error()))
+	}
+}
+
+// responseEventWriter writes Responses API events, numbering them in order.
+type responseEventWriter struct {
+	w   *bufio.Writer
+	seq int
+}
+
+func (e *responseEventWriter) emit(event string, payload fiber.Map) error {
+	payload["type"] = event
+	payload["sequence_number"] = e.seq
+	e.seq++
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(e.w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+		return err
+	}
+	return e.w.Flush()
+}
+
+// parseResponsesInput converts the Responses API input (a string or an array
+// of message items with string or text-part content) into chat messages.
+func parseResponsesInput(raw json.RawMessage) ([]models.ChatMessage, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil, errors.New("input is required")
+	}
+	if raw[0] == '"' {
+		var text string
+		if err := json.Unmarshal(raw, &text); err != nil {
+			return nil, errors.New("invalid input")
+		}
+		return []models.ChatMessage{{Role: "user", Content: text}}, nil
+	}
+
+	var items []responseInputItem
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, errors.New("input must be a string or an array of messages")
+	}
+	if len(items) == 0 {
+		return nil, errors.New("input is required")
+	}
+	messages := make([]models.ChatMessage, 0, len(items))
+	for _, item := range items {
+		if item.Type != "" && item.Type != "message" {
+			return nil, fmt.Errorf("unsupported input item type %q", item.Type)
+		}
+		role := strings.ToLower(strings.TrimSpace(item.Role))
+		switch role {
+		case "":
+			role = "user"
+		case "developer":
+			role = "system"
+		}
+		content, err := responseContentText(item.Content)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, models.ChatMessage{Role: role, Content: content})
+	}
+	return messages, nil
+}
+
+func responseContentText(raw json.RawMessage) (string, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) > 0 && raw[0] == '"' {
+		var text string
+		if err := json.Unmarshal(raw, &text); err != nil {
+			return "", errors.New("invalid message content")
+		}
+		return text, nil
+	}
+	var parts []responseContentPart
+	if err := json.Unmarshal(raw, &parts); err != nil {
+		return "", errors.New("invalid message content")
+	}
+	var text strings.Builder
+	for _, part := range parts {
+		switch part.Type {
+		case "input_text", "output_text", "text":
+			text.WriteString(part.Text)
+		default:
+			return "", fmt.Errorf("unsupported content part type %q; only text input is supported", part.Type)
+		}
+	}
+	return text.String(), nil
+}
+
+// withInstructions prepends instructions as a system message. Instructions
+// apply to one request only and are never stored with the conversation.
+func withInstructions(instructions string, conversation []models.ChatMessage) []models.ChatMessage {
+	if strings.TrimSpace(instructions) == "" {
+		return conversation
+	}
+	messages := make([]models.ChatMessage, 0, len(conversation)+1)
+	messages = append(messages, models.ChatMessage{Role: "system", Content: instructions})
+	return append(messages, conversation...)
+}
+
+func responseMessage(responseID, status, text string) openAIResponseOutputItem {
+	return openAIResponseOutputItem{
+		Type:   "message",
+		ID:     "msg_" + strings.TrimPrefix(responseID, "resp_"),
+		Status: status,
+		Role:   "assistant",
+		Content: []openAIResponseOutputText{{
+			Type:        "output_text",
+			Text:        text,
+			Annotations: []json.RawMessage{},
+		}},
+	}
+}
+
+func responseError(err error) *openAIResponseError {
+	status, msg, ok := executor.AsAPIError(err)
+	if !ok {
+		return &openAIResponseError{Code: "server_error", Message: err.Error()}
+	}
+	switch {
+	case status == fiber.StatusTooManyRequests:
+		return &openAIResponseError{Code: "rate_limit_exceeded", Message: msg}
+	case status >= fiber.StatusInternalServerError:
+		return &openAIResponseError{Code: "server_error", Message: msg}
+	default:
+		return &openAIResponseError{Code: "invalid_request", Message: msg}
+	}
+}
@@ -0,0 +1,82 @@
+package public
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+)
+
+func TestParseResponsesInput(t *testing.T) {
+	messages, err := parseResponsesInput(json.RawMessage(`"hello"`))
+	if err != nil || len(messages) != 1 || messages[0].Role != "user" || messages[0].Content != "hello" {
+		t.Fatalf("unexpected string input %+v (%v)", messages, err)
+	}
+
+	messages, err = parseResponsesInput(json.RawMessage(`[
+		{"role": "developer", "content": "be brief"},
+		{"type": "message", "role": "user", "content": [{"type": "input_text", "text": "hi "}, {"type": "input_text", "text": "there"}]},
+		{"role": "assistant", "content": [{"type": "output_text", "text": "hello"}]}
+	]`))
+	if err != nil {
+		t.Fatalf("parse items: %v", err)
+	}
+	want := []models.ChatMessage{{Role: "system", Content: "be brief"}, {Role: "user", Content: "hi there"}, {Role: "assistant", Content: "hello"}}
+	if len(messages) != len(want) {
+		t.Fatalf("expected %d messages, got %+v", len(want), messages)
+	}
+	for i := range want {
+		if !reflect.DeepEqual(messages[i], want[i]) {
+			t.Fatalf("message %d: expected %+v, got %+v", i, want[i], messages[i])
+		}
+	}
+
+	for _, raw := range []string{``, `null`, `[]`, `42`, `[{"role": "user", "content": [{"type": "input_image", "image_url": "x"}]}]`, `[{"type": "function_call_output"}]`} {
+		if _, err := parseResponsesInput(json.RawMessage(raw)); err == nil {
+			t.Fatalf("expected %q to be rejected", raw)
+		}
+	}
+}
+
+func TestWithInstructionsPrependsSystemMessage(t *testing.T) {
+	conversation := []models.ChatMessage{{Role: "user", Content: "hi"}}
+	messages := withInstructions("be brief", conversation)
+	if len(messages) != 2 || messages[0].Role != "system" || messages[0].Content != "be brief" {
+		t.Fatalf("unexpected messages %+v", messages)
+	}
+	if len(conversation) != 1 {
+		t.Fatal("conversation must not be modified")
+	}
+	if got := withInstructions("  ", conversation); len(got) != 1 {
+		t.Fatalf("expected blank instructions to be ignored, got %+v", got)
+	}
+}
+
+func TestResponseEventWriterFormatsSSE(t *testing.T) {
+	var buf bytes.Buffer
+	events := &responseEventWriter{w: bufio.NewWriter(&buf)}
+	if err := events.emit("response.created", fiber.Map{"response": openAIResponse{ID: "resp_1", Object: "response", Status: "in_progress"}}); err != nil {
+		t.Fatalf("emit: %v", err)
+	}
+	if err := events.emit("response.output_text.delta", fiber.Map{"delta": "hi"}); err != nil {
+		t.Fatalf("emit: %v", err)
+	}
+
+	frames := strings.Split(strings.TrimSpace(buf.String()), "\n\n")
+	if len(frames) != 2 || !strings.HasPrefix(frames[1], "event: response.output_text.delta\ndata: ") {
+		t.Fatalf("unexpected frames %q", frames)
+	}
+	var delta map[string]any
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(strings.SplitN(frames[1], "\n", 2)[1], "data: ")), &delta); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if delta["type"] != "response.output_text.delta" || delta["sequence_number"] != float64(1) || delta["delta"] != "hi" {
+		t.Fatalf("unexpected delta event %v", delta)
+	}
+}
@@ -1,6 +1,7 @@
 package public
 
 import (
+	"github.com/gofiber/contrib/websocket"
 	"github.com/gofiber/fiber/v2"
 
 	"github.com/ncecere/open_model_gateway/backend/internal/app"
@@ -9,17 +10,39 @@ import (
 
 // Register wires up the OpenAI-compatible public API routes.
 func Register(app *fiber.App, container *app.Container) {
-	group := app.Group("/v1", apiKeyAuth(container))
+	registerOpenAPIRoute(app, container)
+	// Registered ahead of the /v1 group so the API key middleware never runs;
+	// the invitation token is the credential.
+	app.Post("/v1/invitations/accept", acceptInvitation(container))
+
 	handler := &openAIHandler{container: container, executor: executor.New(container)}
+	quota := tenantQuota(container)
+	// WebSocket clients authenticate with a one-time token from /v1/ws/auth
+	// because browsers cannot send an Authorization header on the upgrade.
+	app.Get("/v1/ws/chat/completions", wsTokenAuth(container), quota, websocket.New(handler.chatWebSocket))
+
+	group := app.Group("/v1", requestTrace(), apiKeyAuth(container), debugSampling(container), requestMetadata(), tenantBodyLimit())
 	group.Get("/models", handler.listModels)
-	group.Post("/chat/completions", handler.chatCompletions)
-	group.Post("/embeddings", handler.embeddings)
-	group.Post("/images/generations", handler.imageGenerations)
-	group.Post("/images/edits", handler.imageEdits)
-	group.Post("/images/variations", handler.imageVariations)
-	group.Post("/audio/transcriptions", handler.audioTranscriptions)
-	group.Post("/audio/translations", handler.audioTranslations)
-	group.Post("/audio/speech", handler.audioSpeech)
+	group.Post("/chat/completions", quota, handler.chatCompletions)
+	group.Post("/responses", quota, handler.responses)
+	group.Post("/embeddings", quota, handler.embeddings)
+	group.Post("/tokens/count", handler.tokensCount)
+	group.Post("/ws/auth", requireAPIKey(), issueWSAuth(container))
+	group.Get("/me/impersonation-info", getImpersonationInfo)
+	group.Get("/me/budget", getPersonalBudget(container))
+	group.Put("/me/budget", putPersonalBudget(container))
+	group.Get("/me/budget/usage", getPersonalBudgetUsage(container))
+	group.Get("/me/api-keys", listPersonalAPIKeys(container))
+	group.Post("/me/api-keys", createPersonalAPIKey(container))
+	group.Delete("/me/api-keys/:keyID", revokePersonalAPIKey(container))
+	group.Post("/images/generations", quota, handler.imageGenerations)
+	group.Post("/images/generations/async", requireAPIKey(), quota, handler.imageGenerationsAsync)
+	group.Get("/images/jobs/:jobID", handler.imageJob)
+	group.Post("/images/edits", quota, handler.imageEdits)
+	group.Post("/images/variations", quota, handler.imageVariations)
+	group.Post("/audio/transcriptions", quota, handler.audioTranscriptions)
+	group.Post("/audio/translations", quota, handler.audioTranslations)
+	group.Post("/audio/speech", quota, handler.audioSpeech)
 
 	filesHandler := &filesHandler{container: container}
 	group.Get("/files", filesHandler.list)
@@ -31,9 +54,10 @@ func Register(app *fiber.App, container *app.Container) {
 
 	batchHandler := &batchHandler{container: container}
 	group.Get("/batches", batchHandler.list)
-	group.Post("/batches", batchHandler.create)
+	group.Post("/batches", requireAPIKey(), batchHandler.create)
 	group.Get("/batches/:id", batchHandler.get)
 	group.Post("/batches/:id/cancel", batchHandler.cancel)
 	group.Get("/batches/:id/output", batchHandler.output)
 	group.Get("/batches/:id/errors", batchHandler.errors)
+	group.Post("/batches/:id/items/next", batchHandler.nextItems)
 }
@@ -0,0 +1,45 @@
+package public
+
+import (
+	"bufio"
+	"bytes"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// chatStreamWriter writes chat completion SSE frames. When transcript is set
+// every byte sent to the client is also kept there so a completed stream can
+// be stored for Idempotency-Key replays.
+type chatStreamWriter struct {
+	w          *bufio.Writer
+	transcript *bytes.Buffer
+}
+
+// data writes one "data:" frame and flushes it to the client.
+func (s *chatStreamWriter) data(payload []byte) error {
+	frame := make([]byte, 0, len(payload)+8)
+	frame = append(frame, "data: "...)
+	frame = append(frame, payload...)
+	frame = append(frame, "\n\n"...)
+	if _, err := s.w.Write(frame); err != nil {
+		return err
+	}
+	if s.transcript != nil {
+		s.transcript.Write(frame)
+	}
+	return s.w.Flush()
+}
+
+// done terminates the stream with the [DONE] sentinel.
+func (s *chatStreamWriter) done() error {
+	return s.data([]byte("[DONE]"))
+}
+
+// replayChatStream answers a repeated streaming request from its stored
+// transcript without contacting a provider.
+func replayChatStream(c *fiber.Ctx, transcript []byte) error {
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+	return c.Send(transcript)
+}
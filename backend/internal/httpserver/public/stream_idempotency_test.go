@@ -0,0 +1,120 @@
+package public
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/cache"
+)
+
+func TestStreamIdempotencyReplayMatchesOriginalChunks(t *testing.T) {
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer server.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer rdb.Close()
+	streams := cache.NewStreamIdempotencyCache(rdb, time.Minute)
+	ctx := context.Background()
+	tenantID, apiKeyID := uuid.New(), uuid.New()
+
+	// Stream the original response, keeping the transcript as the handler does.
+	var client bytes.Buffer
+	sse := &chatStreamWriter{w: bufio.NewWriter(&client), transcript: &bytes.Buffer{}}
+	for _, chunk := range []string{
+		`{"id":"chatcmpl-1","object":"chat.completion.chunk","choices":[{"index":0,"delta":{"role":"assistant"}}]}`,
+		`{"id":"chatcmpl-1","object":"chat.completion.chunk","choices":[{"index":0,"delta":{"content":"Hel"}}]}`,
+		`{"id":"chatcmpl-1","object":"chat.completion.chunk","choices":[{"index":0,"delta":{"content":"lo"},"finish_reason":"stop"}]}`,
+	} {
+		if err := sse.data([]byte(chunk)); err != nil {
+			t.Fatalf("write chunk: %v", err)
+		}
+	}
+	if err := sse.done(); err != nil {
+		t.Fatalf("write done: %v", err)
+	}
+	streams.Set(ctx, tenantID, apiKeyID, "retry-1", sse.transcript.Bytes())
+
+	if _, ok := cache.NewIdempotencyCache(rdb, time.Minute).Get(ctx, "retry-1"); ok {
+		t.Fatal("streaming transcripts must not be visible to the sync cache")
+	}
+
+	app := fiber.New()
+	app.Post("/v1/chat/completions", func(c *fiber.Ctx) error {
+		transcript, ok := streams.Get(c.UserContext(), tenantID, apiKeyID, c.Get("Idempotency-Key"))
+		if !ok {
+			t.Fatal("expected cached transcript")
+		}
+		return replayChatStream(c, transcript)
+	})
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/chat/completions", nil)
+	req.Header.Set("Idempotency-Key", "retry-1")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("expected event stream, got %q", ct)
+	}
+	replayed, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read replay: %v", err)
+	}
+
+	original := strings.Split(strings.TrimSpace(client.String()), "\n\n")
+	chunks := strings.Split(strings.TrimSpace(string(replayed)), "\n\n")
+	if len(chunks) != 4 || len(chunks) != len(original) {
+		t.Fatalf("expected 4 frames, got original %d replay %d", len(original), len(chunks))
+	}
+	for i := range original {
+		if chunks[i] != original[i] {
+			t.Fatalf("frame %d differs:\noriginal %q\nreplay   %q", i, original[i], chunks[i])
+		}
+	}
+	if chunks[3] != "data: [DONE]" {
+		t.Fatalf("expected replay to end with [DONE], got %q", chunks[3])
+	}
+
+	server.FastForward(2 * time.Minute)
+	if _, ok := streams.Get(ctx, tenantID, apiKeyID, "retry-1"); ok {
+		t.Fatal("expected transcript to expire after the stream TTL")
+	}
+}
+
+func TestStreamIdempotencyIsScopedToTenantAndKey(t *testing.T) {
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer server.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer rdb.Close()
+	streams := cache.NewStreamIdempotencyCache(rdb, time.Minute)
+	ctx := context.Background()
+
+	tenantA, keyA := uuid.New(), uuid.New()
+	streams.Set(ctx, tenantA, keyA, "shared-key", []byte("data: {\"secret\":true}\n\n"))
+
+	if _, ok := streams.Get(ctx, uuid.New(), keyA, "shared-key"); ok {
+		t.Fatal("another tenant must not replay the transcript")
+	}
+	if _, ok := streams.Get(ctx, tenantA, uuid.New(), "shared-key"); ok {
+		t.Fatal("another API key in the same tenant must not replay the transcript")
+	}
+	if _, ok := streams.Get(ctx, tenantA, keyA, "shared-key"); !ok {
+		t.Fatal("expected the original caller to replay the transcript")
+	}
+}
@@ -0,0 +1,73 @@
+package public
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+)
+
+// streamIdleWatchdog cancels an upstream stream once no chunk has been flushed
+// to the client for the tenant's idle timeout. A zero timeout disables it.
+type streamIdleWatchdog struct {
+	timeout time.Duration
+	timer   *time.Timer
+	expired chan struct{}
+	once    sync.Once
+}
+
+func newStreamIdleWatchdog(timeout time.Duration, cancel func()) *streamIdleWatchdog {
+	w := &streamIdleWatchdog{timeout: timeout}
+	if timeout <= 0 {
+		return w
+	}
+	w.expired = make(chan struct{})
+	w.timer = time.AfterFunc(timeout, func() {
+		w.once.Do(func() { close(w.expired) })
+		cancel()
+	})
+	return w
+}
+
+// Reset restarts the idle period; call it after every successful flush.
+func (w *streamIdleWatchdog) Reset() {
+	if w.timer != nil {
+		w.timer.Reset(w.timeout)
+	}
+}
+
+// Stop disarms the watchdog without cancelling the stream.
+func (w *streamIdleWatchdog) Stop() {
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+}
+
+// Done is closed when the idle timeout fires. It is nil (never ready) when
+// the watchdog is disabled.
+func (w *streamIdleWatchdog) Done() <-chan struct{} {
+	return w.expired
+}
+
+// Expired reports whether the idle timeout fired.
+func (w *streamIdleWatchdog) Expired() bool {
+	if w.expired == nil {
+		return false
+	}
+	select {
+	case <-w.expired:
+		return true
+	default:
+		return false
+	}
+}
+
+// next waits for the upstream chunk, giving up when the watchdog fires.
+func (w *streamIdleWatchdog) next(chunks <-chan models.ChatChunk) (models.ChatChunk, bool) {
+	select {
+	case chunk, ok := <-chunks:
+		return chunk, ok
+	case <-w.Done():
+		return models.ChatChunk{}, false
+	}
+}
@@ -0,0 +1,70 @@
+package public
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+)
+
+func TestStreamIdleWatchdogTerminatesStalledStream(t *testing.T) {
+	upstream, cancelUpstream := context.WithCancel(context.Background())
+	defer cancelUpstream()
+	chunks := make(chan models.ChatChunk)
+	go func() {
+		defer close(chunks)
+		for i := 0; i < 3; i++ {
+			select {
+			case chunks <- models.ChatChunk{ID: "chunk"}:
+			case <-upstream.Done():
+				return
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+		// Pause delivery until the gateway gives up.
+		<-upstream.Done()
+	}()
+
+	const timeout = 100 * time.Millisecond
+	idle := newStreamIdleWatchdog(timeout, cancelUpstream)
+	defer idle.Stop()
+
+	start := time.Now()
+	var delivered int
+	for {
+		if _, ok := idle.next(chunks); !ok {
+			break
+		}
+		delivered++
+		idle.Reset()
+	}
+	elapsed := time.Since(start)
+
+	if delivered != 3 {
+		t.Fatalf("expected 3 chunks before the pause, got %d", delivered)
+	}
+	if !idle.Expired() {
+		t.Fatal("expected the idle timeout to fire")
+	}
+	if upstream.Err() == nil {
+		t.Fatal("expected the upstream context to be cancelled")
+	}
+	if elapsed < timeout || elapsed > timeout+time.Second {
+		t.Fatalf("expected termination about %s after the last chunk, took %s", timeout, elapsed)
+	}
+}
+
+func TestStreamIdleWatchdogDisabled(t *testing.T) {
+	idle := newStreamIdleWatchdog(0, func() { t.Fatal("disabled watchdog must not cancel") })
+	chunks := make(chan models.ChatChunk, 1)
+	chunks <- models.ChatChunk{ID: "chunk"}
+	close(chunks)
+	if _, ok := idle.next(chunks); !ok {
+		t.Fatal("expected the buffered chunk")
+	}
+	idle.Reset()
+	if _, ok := idle.next(chunks); ok || idle.Expired() {
+		t.Fatal("expected a closed stream without expiry")
+	}
+}
@@ -0,0 +1,54 @@
+package public
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	maxRequestTags      = 10
+	maxRequestTagLength = 64
+)
+
+// parseRequestTags merges the X-Request-Tags header ("key=value" pairs
+// separated by commas) with the chat body's metadata field; metadata wins
+// when both set the same key. Tags are limited to maxRequestTags keys with
+// keys and values of at most maxRequestTagLength characters.
+func parseRequestTags(header string, metadata map[string]string) (map[string]string, error) {
+	tags := make(map[string]string)
+	if header = strings.TrimSpace(header); header != "" {
+		for _, pair := range strings.Split(header, ",") {
+			pair = strings.TrimSpace(pair)
+			if pair == "" {
+				continue
+			}
+			key, value, ok := strings.Cut(pair, "=")
+			if !ok {
+				return nil, fmt.Errorf("X-Request-Tags entry %q must be key=value", pair)
+			}
+			tags[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	for key, value := range metadata {
+		tags[strings.TrimSpace(key)] = value
+	}
+
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	if len(tags) > maxRequestTags {
+		return nil, fmt.Errorf("at most %d request tags are allowed", maxRequestTags)
+	}
+	for key, value := range tags {
+		if key == "" {
+			return nil, fmt.Errorf("request tag keys must not be empty")
+		}
+		if len(key) > maxRequestTagLength {
+			return nil, fmt.Errorf("request tag key %q exceeds %d characters", key, maxRequestTagLength)
+		}
+		if len(value) > maxRequestTagLength {
+			return nil, fmt.Errorf("request tag %q value exceeds %d characters", key, maxRequestTagLength)
+		}
+	}
+	return tags, nil
+}
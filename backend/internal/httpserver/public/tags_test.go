@@ -0,0 +1,44 @@
+package public
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseRequestTagsMergesHeaderAndMetadata(t *testing.T) {
+	tags, err := parseRequestTags(" project=analytics, env = staging ", map[string]string{"env": "production"})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(tags) != 2 || tags["project"] != "analytics" || tags["env"] != "production" {
+		t.Fatalf("unexpected tags %v", tags)
+	}
+
+	tags, err = parseRequestTags("", nil)
+	if err != nil || tags != nil {
+		t.Fatalf("expected no tags, got %v (%v)", tags, err)
+	}
+}
+
+func TestParseRequestTagsLimits(t *testing.T) {
+	pairs := make([]string, 0, maxRequestTags+1)
+	for i := 0; i <= maxRequestTags; i++ {
+		pairs = append(pairs, fmt.Sprintf("k%d=v", i))
+	}
+	cases := map[string]struct {
+		header   string
+		metadata map[string]string
+	}{
+		"too many keys": {header: strings.Join(pairs, ",")},
+		"long value":    {metadata: map[string]string{"team": strings.Repeat("x", maxRequestTagLength+1)}},
+		"long key":      {metadata: map[string]string{strings.Repeat("k", maxRequestTagLength+1): "v"}},
+		"missing value": {header: "project"},
+		"empty key":     {header: "=value"},
+	}
+	for name, tc := range cases {
+		if _, err := parseRequestTags(tc.header, tc.metadata); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
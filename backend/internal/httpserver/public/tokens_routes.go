@@ -0,0 +1,77 @@
+package public
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/executor"
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+	"github.com/ncecere/open_model_gateway/backend/internal/providers"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+)
+
+type tokenCountRequest struct {
+	Model    string              `json:"model"`
+	Messages []openAIChatMessage `json:"messages"`
+}
+
+type tokenCountResponse struct {
+	PromptTokens  int    `json:"prompt_tokens"`
+	Model         string `json:"model"`
+	ContextWindow int32  `json:"context_window"`
+	Remaining     int    `json:"remaining"`
+}
+
+// tokensCount estimates prompt size for a chat request without dispatching it.
+// It never touches budgets or rate limits so clients can call it freely
+// before deciding whether to trim a conversation.
+func (h *openAIHandler) tokensCount(c *fiber.Ctx) error {
+	var req tokenCountRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
+	}
+	alias := strings.TrimSpace(req.Model)
+	if alias == "" {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "model is required")
+	}
+
+	ctx := c.UserContext()
+	rc, ok := requestctx.FromContext(ctx)
+	if !ok || rc == nil {
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "request context missing")
+	}
+
+	entry, err := h.catalogEntry(ctx, alias)
+	if err != nil {
+		return writeCatalogError(c, err)
+	}
+	if !h.container.IsModelAllowed(rc.TenantID, alias) {
+		return httputil.WriteError(c, fiber.StatusForbidden, "model not enabled for tenant")
+	}
+
+	messages := make([]models.ChatMessage, 0, len(req.Messages))
+	for _, m := range req.Messages {
+		role := strings.ToLower(m.Role)
+		if role == "" {
+			role = "user"
+		}
+		messages = append(messages, models.ChatMessage{Role: role, Content: m.Content, Name: m.Name})
+	}
+	chatReq, _ := executor.ApplySystemPrompt(rc, models.ChatRequest{Model: alias, Messages: messages})
+
+	tokens := h.container.TokenEstimators.For(entry.Provider).EstimateMessages(chatReq.Messages)
+	window := executor.EffectiveModelLimits(rc, alias, providers.Route{ContextWindow: entry.ContextWindow}).ContextWindow
+	remaining := 0
+	if window > 0 {
+		remaining = max(int(window)-tokens, 0)
+	}
+
+	return c.JSON(tokenCountResponse{
+		PromptTokens:  tokens,
+		Model:         alias,
+		ContextWindow: window,
+		Remaining:     remaining,
+	})
+}
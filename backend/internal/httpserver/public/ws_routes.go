@@ -0,0 +1,270 @@
+package public
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/executor"
+	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+	"github.com/ncecere/open_model_gateway/backend/internal/observability"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+)
+
+const (
+	wsTokenTTL       = time.Minute
+	wsTokenKeyPrefix = "ws_token:"
+	wsPingInterval   = 15 * time.Second
+	wsWriteTimeout   = 10 * time.Second
+)
+
+var errWSTokenInvalid = errors.New("invalid or expired websocket token")
+
+type wsAuthResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type wsDoneFrame struct {
+	Done bool `json:"done"`
+}
+
+type wsErrorFrame struct {
+	Error wsError `json:"error"`
+}
+
+type wsError struct {
+	Message string `json:"message"`
+	Status  int    `json:"status"`
+}
+
+// issueWSToken stores a random one-time token bound to the API key prefix.
+// Only its hash is kept in Redis.
+func issueWSToken(ctx context.Context, rdb *redis.Client, apiKeyPrefix string, ttl time.Duration) (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	token := "wst_" + base64.RawURLEncoding.EncodeToString(buf)
+	if err := rdb.Set(ctx, wsTokenKey(token), apiKeyPrefix, ttl).Err(); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// consumeWSToken atomically redeems a token and returns the API key prefix
+// it was issued for. A token can be redeemed once.
+func consumeWSToken(ctx context.Context, rdb *redis.Client, token string) (string, error) {
+	if token == "" {
+		return "", errWSTokenInvalid
+	}
+	prefix, err := rdb.GetDel(ctx, wsTokenKey(token)).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", errWSTokenInvalid
+	}
+	return prefix, err
+}
+
+func wsTokenKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return wsTokenKeyPrefix + hex.EncodeToString(sum[:])
+}
+
+// issueWSAuth exchanges the caller's API key for a short-lived token that
+// browsers can pass as ?token= when opening the WebSocket, since they cannot
+// set an Authorization header on the upgrade request.
+func issueWSAuth(container *app.Container) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		rc, ok := requestctx.FromContext(c.UserContext())
+		if !ok || rc == nil {
+			return httputil.WriteError(c, fiber.StatusInternalServerError, "request context missing")
+		}
+		if container.Redis == nil {
+			return httputil.WriteError(c, fiber.StatusServiceUnavailable, "websocket auth unavailable")
+		}
+		token, err := issueWSToken(c.UserContext(), container.Redis, rc.APIKeyPrefix, wsTokenTTL)
+		if err != nil {
+			return httputil.WriteError(c, fiber.StatusInternalServerError, "failed to issue websocket token")
+		}
+		return c.JSON(wsAuthResponse{Token: token, ExpiresAt: time.Now().Add(wsTokenTTL).UTC()})
+	}
+}
+
+// wsTokenAuth redeems the one-time token on the upgrade request and stores
+// the request context for the WebSocket handler.
+func wsTokenAuth(container *app.Container) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !websocket.IsWebSocketUpgrade(c) {
+			return httputil.WriteError(c, fiber.StatusUpgradeRequired, "websocket upgrade required")
+		}
+		if container.Redis == nil {
+			return httputil.WriteError(c, fiber.StatusServiceUnavailable, "websocket auth unavailable")
+		}
+		ctx := userContext(c)
+		prefix, err := consumeWSToken(ctx, container.Redis, c.Query("token"))
+		if err != nil {
+			if errors.Is(err, errWSTokenInvalid) {
+				return httputil.WriteError(c, fiber.StatusUnauthorized, err.Error())
+			}
+			return httputil.WriteError(c, fiber.StatusInternalServerError, "websocket token lookup failed")
+		}
+		rc, err := container.AuthenticateAPIKeyPrefix(ctx, prefix)
+		if err != nil {
+			var authErr *app.APIKeyAuthError
+			if errors.As(err, &authErr) {
+				return httputil.WriteError(c, authErr.Status, authErr.Message)
+			}
+			return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
+		}
+		c.Locals(requestctx.FiberLocalsKey(), rc)
+		return c.Next()
+	}
+}
+
+// chatWebSocket serves one streaming chat completion per connection: it
+// reads an openAIChatRequest frame, relays openAIStreamChunk frames and ends
+// with {"done":true}. Pings every 15s detect clients that went away; a
+// closed or unresponsive client cancels the provider call.
+func (h *openAIHandler) chatWebSocket(conn *websocket.Conn) {
+	rc, ok := conn.Locals(requestctx.FiberLocalsKey()).(*requestctx.Context)
+	if !ok || rc == nil {
+		writeWSError(conn, fiber.StatusInternalServerError, "request context missing")
+		return
+	}
+
+	var req openAIChatRequest
+	if err := conn.ReadJSON(&req); err != nil {
+		writeWSError(conn, fiber.StatusBadRequest, "invalid request body")
+		return
+	}
+	modelReq, err := parseChatRequest(&req)
+	if err != nil {
+		writeWSError(conn, fiber.StatusBadRequest, err.Error())
+		return
+	}
+	tags, err := parseRequestTags(conn.Headers("X-Request-Tags"), req.Metadata)
+	if err != nil {
+		writeWSError(conn, fiber.StatusBadRequest, err.Error())
+		return
+	}
+	rc.Tags = tags
+	metadata, err := parseRequestMetadata(conn.Headers(requestMetadataHeader))
+	if err != nil {
+		writeWSError(conn, fiber.StatusBadRequest, err.Error())
+		return
+	}
+	rc.Metadata = metadata
+	rc.Fingerprint = modelReq.Fingerprint()
+	if !h.container.IsModelAllowed(rc.TenantID, req.Model) {
+		writeWSError(conn, fiber.StatusForbidden, "model not enabled for tenant")
+		return
+	}
+	modelReq.Stream = true
+	alias := req.Model
+
+	// The upgrade request's user context is gone by now; keep its trace from
+	// the span context the tracing middleware left in Locals.
+	baseCtx := requestctx.WithContext(context.Background(), rc)
+	if sc, ok := conn.Locals(observability.SpanContextLocalsKey).(trace.SpanContext); ok {
+		baseCtx = trace.ContextWithSpanContext(baseCtx, sc)
+	}
+	ctx, cancel := context.WithCancel(baseCtx)
+	defer cancel()
+	// Count the socket as an in-flight stream so shutdown drains it like an
+	// SSE response.
+	streamDone := h.container.Streams.Start()
+	defer streamDone()
+	stopWatch := watchWSClient(ctx, cancel, conn)
+	defer stopWatch()
+
+	traceID, _ := conn.Locals("requestid").(string)
+	_, err = h.executor.ChatStream(ctx, rc, alias, modelReq, traceID, "", func(chunk models.ChatChunk) error {
+		_ = conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+		return conn.WriteJSON(convertStreamChunk(chunk, alias))
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		if status, msg, ok := executor.AsAPIError(err); ok {
+			writeWSError(conn, status, msg)
+			return
+		}
+		writeWSError(conn, fiber.StatusInternalServerError, err.Error())
+		return
+	}
+
+	_ = conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	if err := conn.WriteJSON(wsDoneFrame{Done: true}); err != nil {
+		slog.Debug("websocket done frame", slog.String("error", err.Error()))
+		return
+	}
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(wsWriteTimeout))
+}
+
+// watchWSClient pings the client every wsPingInterval and cancels ctx once it
+// disconnects or misses a pong. The read loop also consumes the client's
+// close frame. The returned stop func must run before the handler returns,
+// because the connection is recycled afterwards.
+func watchWSClient(ctx context.Context, cancel context.CancelFunc, conn *websocket.Conn) func() {
+	_ = conn.SetReadDeadline(time.Now().Add(2 * wsPingInterval))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * wsPingInterval))
+	})
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(wsPingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout)); err != nil {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		// Give the client a moment to answer our close frame, then unblock
+		// the reader.
+		_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+		wg.Wait()
+	}
+}
+
+func writeWSError(conn *websocket.Conn, status int, msg string) {
+	_ = conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	_ = conn.WriteJSON(wsErrorFrame{Error: wsError{Message: msg, Status: status}})
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, msg), time.Now().Add(wsWriteTimeout))
+}
@@ -0,0 +1,60 @@
+package public
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+)
+
+func TestWSTokenIsSingleUseAndExpires(t *testing.T) {
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer server.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer rdb.Close()
+	ctx := context.Background()
+
+	token, err := issueWSToken(ctx, rdb, "abc123", time.Minute)
+	if err != nil {
+		t.Fatalf("issue: %v", err)
+	}
+	prefix, err := consumeWSToken(ctx, rdb, token)
+	if err != nil || prefix != "abc123" {
+		t.Fatalf("consume = %q, %v; want abc123", prefix, err)
+	}
+	if _, err := consumeWSToken(ctx, rdb, token); !errors.Is(err, errWSTokenInvalid) {
+		t.Fatalf("expected replayed token to be rejected, got %v", err)
+	}
+
+	expiring, err := issueWSToken(ctx, rdb, "abc123", time.Minute)
+	if err != nil {
+		t.Fatalf("issue: %v", err)
+	}
+	server.FastForward(2 * time.Minute)
+	if _, err := consumeWSToken(ctx, rdb, expiring); !errors.Is(err, errWSTokenInvalid) {
+		t.Fatalf("expected expired token to be rejected, got %v", err)
+	}
+}
+
+func TestWSChatRequiresUpgrade(t *testing.T) {
+	server := fiber.New()
+	Register(server, &app.Container{})
+
+	resp, err := server.Test(httptest.NewRequest(fiber.MethodGet, "/v1/ws/chat/completions?token=x", nil))
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusUpgradeRequired {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, fiber.StatusUpgradeRequired)
+	}
+}
@@ -3,22 +3,24 @@ package httpserver
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"net"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/adaptor"
-	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/gofiber/fiber/v2/middleware/requestid"
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/codes"
 
 	"github.com/ncecere/open_model_gateway/backend/internal/app"
 	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/health"
 	adminroutes "github.com/ncecere/open_model_gateway/backend/internal/httpserver/admin"
 	publicroutes "github.com/ncecere/open_model_gateway/backend/internal/httpserver/public"
 	userroutes "github.com/ncecere/open_model_gateway/backend/internal/httpserver/user"
+	"github.com/ncecere/open_model_gateway/backend/internal/version"
 )
 
 // Server wraps the Fiber app and configuration.
@@ -51,7 +53,7 @@ func New(container *app.Container) (*Server, error) {
 	})
 
 	app.Use(requestid.New())
-	app.Use(logger.New())
+	app.Use(accessLog())
 	app.Use(recover.New())
 
 	if container.Observability != nil {
@@ -70,33 +72,7 @@ func New(container *app.Container) (*Server, error) {
 		})
 	}
 
-	if container.Observability != nil && container.Observability.TracerProvider() != nil {
-		tracer := otel.Tracer("open-model-gateway/http")
-		app.Use(func(c *fiber.Ctx) error {
-			spanCtx, span := tracer.Start(c.UserContext(), c.Method()+" "+c.Path())
-			c.SetUserContext(spanCtx)
-			err := c.Next()
-			route := ""
-			if r := c.Route(); r != nil {
-				route = r.Path
-			}
-			span.SetAttributes(
-				attribute.String("http.method", c.Method()),
-				attribute.String("http.route", route),
-				attribute.Int("http.status_code", c.Response().StatusCode()),
-			)
-			if err != nil {
-				span.RecordError(err)
-				span.SetStatus(codes.Error, err.Error())
-			} else if status := c.Response().StatusCode(); status >= 500 {
-				span.SetStatus(codes.Error, fmt.Sprintf("status %d", status))
-			} else {
-				span.SetStatus(codes.Ok, "OK")
-			}
-			span.End()
-			return err
-		})
-	}
+	app.Use(tracing(otel.Tracer("open-model-gateway/http")))
 
 	if container.Observability != nil {
 		if handler := container.Observability.PrometheusHandler(); handler != nil {
@@ -104,6 +80,7 @@ func New(container *app.Container) (*Server, error) {
 		}
 	}
 
+	registerCORS(app, cfg.Server)
 	registerHealthRoutes(app, container)
 	mountAdminUISubpath(app)
 	adminroutes.Register(app, container)
@@ -120,27 +97,59 @@ func New(container *app.Container) (*Server, error) {
 
 // Listen blocks until context cancellation or a fatal listen error occurs.
 func (s *Server) Listen(ctx context.Context) error {
+	ln, err := net.Listen("tcp", s.cfg.Server.ListenAddr)
+	if err != nil {
+		return err
+	}
+	return serve(ctx, s.app, ln, &s.container.Streams, s.cfg.Server)
+}
+
+// serve runs app on ln until ctx is cancelled. Shutdown stops accepting
+// connections first, then gives in-flight streams up to
+// GracefulStreamDrainTimeout to finish before returning; other requests get
+// GracefulShutdownDelay.
+func serve(ctx context.Context, fiberApp *fiber.App, ln net.Listener, streams *app.StreamTracker, cfg config.ServerConfig) error {
 	errCh := make(chan error, 1)
 	go func() {
-		errCh <- s.app.Listen(s.cfg.Server.ListenAddr)
+		errCh <- fiberApp.Listener(ln)
 	}()
 
 	select {
 	case <-ctx.Done():
-		timeout := s.cfg.Server.GracefulShutdownDelay
-		if timeout <= 0 {
-			timeout = 5 * time.Second
-		}
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
-		defer cancel()
-		err := s.app.ShutdownWithContext(shutdownCtx)
-		if err == nil {
-			err = <-errCh
-		}
-		return err
 	case err := <-errCh:
 		return err
 	}
+
+	timeout := cfg.GracefulShutdownDelay
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	drain := cfg.GracefulStreamDrainTimeout
+	if drain <= 0 {
+		drain = 30 * time.Second
+	}
+	if drain > timeout {
+		timeout = drain
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	shutdownErr := make(chan error, 1)
+	go func() {
+		shutdownErr <- fiberApp.ShutdownWithContext(shutdownCtx)
+	}()
+
+	drainCtx, cancelDrain := context.WithTimeout(shutdownCtx, drain)
+	defer cancelDrain()
+	if err := streams.Wait(drainCtx); err != nil {
+		slog.Warn("stream drain timed out; closing remaining streams",
+			slog.Int64("active_streams", streams.Active()),
+			slog.Duration("timeout", drain))
+		return err
+	}
+	if err := <-shutdownErr; err != nil {
+		return err
+	}
+	return <-errCh
 }
 
 func registerHealthRoutes(app *fiber.App, container *app.Container) {
@@ -189,4 +198,57 @@ func registerHealthRoutes(app *fiber.App, container *app.Container) {
 			"checks": checks,
 		})
 	})
+
+	// /health is the liveness probe: it always answers 200 while the process
+	// serves requests. /health/ready answers 503 until Postgres and Redis
+	// are reachable; degraded providers do not affect readiness.
+	app.Get("/health", func(c *fiber.Ctx) error {
+		status := dependencyStatus(c, container)
+		return c.JSON(healthResponse(status, health.Ready(status)))
+	})
+	app.Get("/health/ready", func(c *fiber.Ctx) error {
+		status := dependencyStatus(c, container)
+		ready := health.Ready(status)
+		code := fiber.StatusOK
+		if !ready {
+			code = fiber.StatusServiceUnavailable
+		}
+		return c.Status(code).JSON(healthResponse(status, ready))
+	})
+}
+
+var startedAt = time.Now()
+
+func dependencyStatus(c *fiber.Ctx, container *app.Container) map[string]health.HealthState {
+	if container == nil || container.HealthMon == nil {
+		return map[string]health.HealthState{}
+	}
+	ctx, cancel := context.WithTimeout(c.Context(), 2*time.Second)
+	defer cancel()
+	return container.HealthMon.Status(ctx)
+}
+
+// healthResponse nests model aliases under deps.providers.
+func healthResponse(status map[string]health.HealthState, ready bool) fiber.Map {
+	deps := fiber.Map{}
+	providerStates := map[string]health.HealthState{}
+	for key, state := range status {
+		if alias, ok := strings.CutPrefix(key, health.ProviderKeyPrefix); ok {
+			providerStates[alias] = state
+			continue
+		}
+		deps[key] = state
+	}
+	deps["providers"] = providerStates
+
+	overall := "ok"
+	if !ready {
+		overall = "degraded"
+	}
+	return fiber.Map{
+		"status":     overall,
+		"deps":       deps,
+		"uptime_sec": int64(time.Since(startedAt).Seconds()),
+		"version":    version.Version,
+	}
 }
@@ -0,0 +1,114 @@
+package httpserver
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"os/signal"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/app"
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/health"
+	"github.com/ncecere/open_model_gateway/backend/internal/version"
+)
+
+func TestServeDrainsStreamsOnSIGTERM(t *testing.T) {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM)
+	defer stop()
+
+	var streams app.StreamTracker
+	fiberApp := fiber.New(fiber.Config{DisableStartupMessage: true})
+	fiberApp.Get("/stream", func(c *fiber.Ctx) error {
+		done := streams.Start()
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			defer done()
+			for i := 0; i < 5; i++ {
+				w.WriteString("data: chunk\n\n")
+				w.Flush()
+				time.Sleep(100 * time.Millisecond)
+			}
+			w.WriteString("data: [DONE]\n\n")
+			w.Flush()
+		})
+		return nil
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- serve(ctx, fiberApp, ln, &streams, config.ServerConfig{
+			GracefulShutdownDelay:      time.Second,
+			GracefulStreamDrainTimeout: 5 * time.Second,
+		})
+	}()
+
+	bodyCh := make(chan string, 1)
+	go func() {
+		resp, err := http.Get("http://" + ln.Addr().String() + "/stream")
+		if err != nil {
+			bodyCh <- "error: " + err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		bodyCh <- string(body)
+	}()
+
+	time.Sleep(250 * time.Millisecond)
+	if streams.Active() != 1 {
+		t.Fatalf("expected one active stream, got %d", streams.Active())
+	}
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGTERM); err != nil {
+		t.Fatalf("send SIGTERM: %v", err)
+	}
+
+	select {
+	case err := <-serveErr:
+		if err != nil {
+			t.Fatalf("serve returned %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not exit")
+	}
+	if streams.Active() != 0 {
+		t.Fatalf("server exited with %d active streams", streams.Active())
+	}
+	body := <-bodyCh
+	if !strings.HasSuffix(body, "data: [DONE]\n\n") {
+		t.Fatalf("stream was cut off: %q", body)
+	}
+}
+
+func TestHealthResponseNestsProviders(t *testing.T) {
+	status := map[string]health.HealthState{
+		health.DBKey:                          health.StateOK,
+		health.RedisKey:                       health.StateOK,
+		health.ProviderKeyPrefix + "gpt-4":    health.StateOK,
+		health.ProviderKeyPrefix + "claude-3": health.StateDegraded,
+	}
+	resp := healthResponse(status, health.Ready(status))
+	if resp["status"] != "ok" || resp["version"] != version.Version {
+		t.Fatalf("unexpected response %v", resp)
+	}
+	deps := resp["deps"].(fiber.Map)
+	providers := deps["providers"].(map[string]health.HealthState)
+	if deps["db"] != health.StateOK || providers["claude-3"] != health.StateDegraded || len(providers) != 2 {
+		t.Fatalf("unexpected deps %v", deps)
+	}
+
+	status[health.RedisKey] = health.StateDown
+	if resp := healthResponse(status, health.Ready(status)); resp["status"] != "degraded" {
+		t.Fatalf("expected degraded when redis is down, got %v", resp["status"])
+	}
+}
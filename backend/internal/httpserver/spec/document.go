@@ -0,0 +1,275 @@
+// Package spec builds the OpenAPI 3.0 document served at /openapi.json. Paths
+// come from the live Fiber route registry, request and response schemas are
+// derived from the handler types, and the pieces reflection cannot express
+// (multipart uploads, binary downloads, the error envelope) are hand-authored
+// in fragments.go.
+package spec
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const (
+	openAPIVersion = "3.0.3"
+	errorSchemaRef = "#/components/schemas/Error"
+	securityBearer = "bearerAuth"
+)
+
+// Document is the root OpenAPI object.
+type Document struct {
+	OpenAPI    string                `json:"openapi"`
+	Info       Info                  `json:"info"`
+	Paths      map[string]PathItem   `json:"paths"`
+	Components Components            `json:"components"`
+	Security   []map[string][]string `json:"security,omitempty"`
+}
+
+// Info describes the API.
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// PathItem maps lower-case HTTP methods to operations.
+type PathItem map[string]*Operation
+
+// Operation describes a single route.
+type Operation struct {
+	OperationID string               `json:"operationId,omitempty"`
+	Summary     string               `json:"summary,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter describes a path or query parameter.
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody describes an operation payload keyed by content type.
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response describes an operation response keyed by content type.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType wraps a schema for a content type.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components holds shared schemas and security schemes.
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes"`
+}
+
+// SecurityScheme describes how callers authenticate.
+type SecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme"`
+}
+
+// Route is a registered method/path pair, typically taken from Fiber's
+// route registry.
+type Route struct {
+	Method string
+	Path   string
+}
+
+// Endpoint carries the hand-authored details for a route.
+type Endpoint struct {
+	Method  string
+	Path    string
+	Summary string
+	Tag     string
+	// Query lists supported query parameters.
+	Query []Parameter
+	// Request is either a Go value whose type describes the JSON body, or a
+	// *RequestBody for non-JSON payloads. Nil means no body.
+	Request any
+	// Response is either a Go value whose type describes the JSON response,
+	// or a *Response for non-JSON payloads.
+	Response any
+}
+
+// Options configures Build.
+type Options struct {
+	Title   string
+	Version string
+	// Prefix limits the document to routes under this path.
+	Prefix string
+	// Models populates the enum of every "model" request field.
+	Models []string
+}
+
+// Build assembles a document for the routes under opts.Prefix. Routes without
+// a matching endpoint still appear with the shared error responses so the
+// spec never drifts from what the server actually serves.
+func Build(routes []Route, endpoints []Endpoint, opts Options) *Document {
+	lookup := make(map[string]Endpoint, len(endpoints))
+	for _, ep := range endpoints {
+		lookup[routeKey(ep.Method, ep.Path)] = ep
+	}
+	models := append([]string(nil), opts.Models...)
+	sort.Strings(models)
+
+	doc := &Document{
+		OpenAPI: openAPIVersion,
+		Info:    Info{Title: opts.Title, Version: opts.Version},
+		Paths:   make(map[string]PathItem),
+		Components: Components{
+			Schemas: map[string]*Schema{"Error": ErrorSchema()},
+			SecuritySchemes: map[string]SecurityScheme{
+				securityBearer: {Type: "http", Scheme: "bearer"},
+			},
+		},
+		Security: []map[string][]string{{securityBearer: {}}},
+	}
+
+	seen := make(map[string]bool)
+	for _, route := range routes {
+		method := strings.ToUpper(route.Method)
+		if method == http.MethodHead || method == http.MethodOptions {
+			continue
+		}
+		if opts.Prefix != "" && !strings.HasPrefix(route.Path, opts.Prefix) {
+			continue
+		}
+		key := routeKey(method, route.Path)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		ep, ok := lookup[key]
+		if !ok {
+			ep = Endpoint{Method: method, Path: route.Path}
+		}
+		path, params := convertPath(route.Path)
+		item := doc.Paths[path]
+		if item == nil {
+			item = PathItem{}
+			doc.Paths[path] = item
+		}
+		item[strings.ToLower(method)] = buildOperation(ep, params, models)
+	}
+	return doc
+}
+
+func buildOperation(ep Endpoint, pathParams []Parameter, models []string) *Operation {
+	op := &Operation{
+		OperationID: operationID(ep.Method, ep.Path),
+		Summary:     ep.Summary,
+		Parameters:  append(pathParams, ep.Query...),
+		Responses:   errorResponses(),
+	}
+	if ep.Tag != "" {
+		op.Tags = []string{ep.Tag}
+	}
+
+	switch req := ep.Request.(type) {
+	case nil:
+	case *RequestBody:
+		op.RequestBody = req
+	default:
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"application/json": {Schema: SchemaOf(req)}},
+		}
+	}
+	if op.RequestBody != nil {
+		for _, media := range op.RequestBody.Content {
+			applyModelEnum(media.Schema, models)
+		}
+	}
+
+	switch resp := ep.Response.(type) {
+	case nil:
+		op.Responses["200"] = &Response{Description: "Successful response"}
+	case *Response:
+		op.Responses["200"] = resp
+	default:
+		op.Responses["200"] = &Response{
+			Description: "Successful response",
+			Content:     map[string]MediaType{"application/json": {Schema: SchemaOf(resp)}},
+		}
+	}
+	return op
+}
+
+func applyModelEnum(schema *Schema, models []string) {
+	if schema == nil || len(models) == 0 {
+		return
+	}
+	if prop, ok := schema.Properties["model"]; ok && prop.Type == "string" {
+		prop.Enum = models
+	}
+}
+
+func errorResponses() map[string]*Response {
+	responses := make(map[string]*Response)
+	for code, desc := range map[string]string{
+		"400": "Invalid request",
+		"401": "Missing or invalid API key",
+		"403": "Forbidden (scope, model access, or budget)",
+		"429": "Rate limit exceeded",
+		"500": "Internal error",
+		"502": "Provider error",
+	} {
+		responses[code] = &Response{
+			Description: desc,
+			Content:     map[string]MediaType{"application/json": {Schema: &Schema{Ref: errorSchemaRef}}},
+		}
+	}
+	return responses
+}
+
+// convertPath rewrites Fiber's ":param" segments to OpenAPI "{param}" form
+// and returns the matching path parameters.
+func convertPath(path string) (string, []Parameter) {
+	segments := strings.Split(path, "/")
+	var params []Parameter
+	for i, seg := range segments {
+		if !strings.HasPrefix(seg, ":") {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(seg, ":"), "?")
+		segments[i] = "{" + name + "}"
+		params = append(params, Parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+	return strings.Join(segments, "/"), params
+}
+
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, seg := range strings.Split(path, "/") {
+		seg = strings.TrimPrefix(seg, ":")
+		for _, part := range strings.FieldsFunc(seg, func(r rune) bool { return r == '-' || r == '_' || r == '.' }) {
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return b.String()
+}
+
+func routeKey(method, path string) string {
+	return strings.ToUpper(method) + " " + path
+}
@@ -0,0 +1,78 @@
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type testChatRequest struct {
+	Model       string   `json:"model"`
+	Messages    []string `json:"messages"`
+	Temperature *float32 `json:"temperature,omitempty"`
+}
+
+type testChatResponse struct {
+	ID string `json:"id"`
+}
+
+func TestBuildDocument(t *testing.T) {
+	routes := []Route{
+		{Method: "GET", Path: "/v1/files/:id"},
+		{Method: "HEAD", Path: "/v1/files/:id"},
+		{Method: "POST", Path: "/v1/chat/completions"},
+		{Method: "GET", Path: "/v1/unknown"},
+		{Method: "GET", Path: "/admin/tenants"},
+	}
+	endpoints := []Endpoint{
+		{Method: "POST", Path: "/v1/chat/completions", Summary: "chat", Tag: "chat", Request: testChatRequest{}, Response: testChatResponse{}},
+		{Method: "GET", Path: "/v1/files/:id", Response: Binary("application/octet-stream", "file")},
+	}
+
+	doc := Build(routes, endpoints, Options{Title: "test", Version: "1", Prefix: "/v1/", Models: []string{"b", "a"}})
+
+	if _, ok := doc.Paths["/admin/tenants"]; ok {
+		t.Fatal("expected routes outside the prefix to be skipped")
+	}
+	if len(doc.Paths) != 3 {
+		t.Fatalf("expected 3 paths, got %d", len(doc.Paths))
+	}
+
+	file := doc.Paths["/v1/files/{id}"]
+	if file == nil || file["get"] == nil || file["head"] != nil {
+		t.Fatalf("expected only GET for file path, got %+v", file)
+	}
+	if params := file["get"].Parameters; len(params) != 1 || params[0].Name != "id" || params[0].In != "path" {
+		t.Fatalf("unexpected path params: %+v", params)
+	}
+
+	chat := doc.Paths["/v1/chat/completions"]["post"]
+	if chat == nil || chat.RequestBody == nil {
+		t.Fatal("expected chat operation with request body")
+	}
+	schema := chat.RequestBody.Content["application/json"].Schema
+	if got := schema.Properties["model"].Enum; len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Fatalf("expected sorted model enum, got %v", got)
+	}
+	if len(schema.Required) != 2 || schema.Required[0] != "model" || schema.Required[1] != "messages" {
+		t.Fatalf("unexpected required fields: %v", schema.Required)
+	}
+	if !schema.Properties["temperature"].Nullable {
+		t.Fatal("expected pointer field to be nullable")
+	}
+	if chat.Responses["429"] == nil || chat.Responses["429"].Content["application/json"].Schema.Ref != errorSchemaRef {
+		t.Fatal("expected shared error responses")
+	}
+
+	if doc.Paths["/v1/unknown"]["get"] == nil {
+		t.Fatal("expected unlisted route to be documented generically")
+	}
+	if _, err := json.Marshal(doc); err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+}
+
+func TestOperationID(t *testing.T) {
+	if got := operationID("POST", "/v1/batches/:id/cancel"); got != "postV1BatchesIdCancel" {
+		t.Fatalf("unexpected operation id %q", got)
+	}
+}
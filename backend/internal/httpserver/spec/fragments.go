@@ -0,0 +1,62 @@
+package spec
+
+// ErrorSchema matches the envelope written by httputil.WriteError.
+func ErrorSchema() *Schema {
+	return &Schema{
+		Type:       "object",
+		Properties: map[string]*Schema{"error": {Type: "string"}},
+		Required:   []string{"error"},
+	}
+}
+
+// FormField describes a multipart form field.
+type FormField struct {
+	Name     string
+	Schema   *Schema
+	Required bool
+}
+
+// File returns a required or optional binary form field.
+func File(name string, required bool) FormField {
+	return FormField{Name: name, Schema: &Schema{Type: "string", Format: "binary"}, Required: required}
+}
+
+// Text returns a string form field.
+func Text(name string, required bool) FormField {
+	return FormField{Name: name, Schema: &Schema{Type: "string"}, Required: required}
+}
+
+// Multipart builds a multipart/form-data request body.
+func Multipart(fields ...FormField) *RequestBody {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema, len(fields))}
+	for _, field := range fields {
+		schema.Properties[field.Name] = field.Schema
+		if field.Required {
+			schema.Required = append(schema.Required, field.Name)
+		}
+	}
+	return &RequestBody{
+		Required: true,
+		Content:  map[string]MediaType{"multipart/form-data": {Schema: schema}},
+	}
+}
+
+// Binary describes a raw download response.
+func Binary(contentType, description string) *Response {
+	return &Response{
+		Description: description,
+		Content: map[string]MediaType{
+			contentType: {Schema: &Schema{Type: "string", Format: "binary"}},
+		},
+	}
+}
+
+// QueryString returns an optional string query parameter.
+func QueryString(name string) Parameter {
+	return Parameter{Name: name, In: "query", Schema: &Schema{Type: "string"}}
+}
+
+// QueryInt returns an optional integer query parameter.
+func QueryInt(name string) Parameter {
+	return Parameter{Name: name, In: "query", Schema: &Schema{Type: "integer"}}
+}
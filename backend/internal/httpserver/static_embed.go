@@ -3,7 +3,7 @@ package httpserver
 import (
 	"embed"
 	"io/fs"
-	"log"
+	"log/slog"
 	"net/http"
 
 	"github.com/gofiber/fiber/v2"
@@ -24,7 +24,7 @@ func embeddedUI() (fs.FS, error) {
 func mountEmbeddedUI(app *fiber.App) {
 	dist, err := embeddedUI()
 	if err != nil {
-		log.Printf("ui assets not embedded: %v", err)
+		slog.Warn("ui assets not embedded", slog.String("error", err.Error()))
 		return
 	}
 
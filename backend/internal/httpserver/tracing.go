@@ -0,0 +1,67 @@
+package httpserver
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/observability"
+)
+
+// tracing starts a server span per request, continuing the caller's trace
+// when the request carries W3C traceparent and tracestate headers. The span
+// context goes into the user context, which the executor hands to provider
+// adapters, and into fiber.Locals under observability.SpanContextLocalsKey.
+// With no tracer provider configured the span is not recorded, but the
+// caller's trace context is still forwarded upstream.
+func tracing(tracer trace.Tracer) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		parent := observability.TraceContext.Extract(c.UserContext(), requestHeaderCarrier{c})
+		spanCtx, span := tracer.Start(parent, c.Method()+" "+c.Path(), trace.WithSpanKind(trace.SpanKindServer))
+		c.SetUserContext(spanCtx)
+		c.Locals(observability.SpanContextLocalsKey, span.SpanContext())
+		err := c.Next()
+		route := ""
+		if r := c.Route(); r != nil {
+			route = r.Path
+		}
+		span.SetAttributes(
+			attribute.String("http.method", c.Method()),
+			attribute.String("http.route", route),
+			attribute.Int("http.status_code", c.Response().StatusCode()),
+		)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		} else if status := c.Response().StatusCode(); status >= 500 {
+			span.SetStatus(codes.Error, fmt.Sprintf("status %d", status))
+		} else {
+			span.SetStatus(codes.Ok, "OK")
+		}
+		span.End()
+		return err
+	}
+}
+
+// requestHeaderCarrier reads propagation headers from the incoming request.
+type requestHeaderCarrier struct {
+	c *fiber.Ctx
+}
+
+func (r requestHeaderCarrier) Get(key string) string {
+	return r.c.Get(key)
+}
+
+// Set is a no-op; the carrier is only used for extraction.
+func (r requestHeaderCarrier) Set(string, string) {}
+
+func (r requestHeaderCarrier) Keys() []string {
+	var keys []string
+	r.c.Request().Header.VisitAll(func(key, _ []byte) {
+		keys = append(keys, string(key))
+	})
+	return keys
+}
@@ -0,0 +1,62 @@
+package httpserver
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/observability"
+)
+
+func TestTracingContinuesIncomingTraceParent(t *testing.T) {
+	const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	var local trace.SpanContext
+	var forwarded string
+	fiberApp := fiber.New()
+	fiberApp.Use(tracing(noop.NewTracerProvider().Tracer("test")))
+	fiberApp.Get("/", func(c *fiber.Ctx) error {
+		local, _ = c.Locals(observability.SpanContextLocalsKey).(trace.SpanContext)
+		forwarded = observability.TraceParent(c.UserContext())
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("traceparent", traceParent)
+	req.Header.Set("tracestate", "vendor=value")
+	if _, err := fiberApp.Test(req); err != nil {
+		t.Fatalf("request: %v", err)
+	}
+
+	if got := local.TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Fatalf("expected the caller's trace id in Locals, got %q", got)
+	}
+	if got := local.TraceState().Get("vendor"); got != "value" {
+		t.Fatalf("expected tracestate to be kept, got %q", got)
+	}
+	if forwarded != traceParent {
+		t.Fatalf("expected traceparent %q to be forwarded, got %q", traceParent, forwarded)
+	}
+}
+
+func TestTracingWithoutTraceParent(t *testing.T) {
+	var forwarded string
+	fiberApp := fiber.New()
+	fiberApp.Use(tracing(noop.NewTracerProvider().Tracer("test")))
+	fiberApp.Get("/", func(c *fiber.Ctx) error {
+		forwarded = observability.TraceParent(c.UserContext())
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("traceparent", "not-a-traceparent")
+	if _, err := fiberApp.Test(req); err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	if forwarded != "" {
+		t.Fatalf("expected no traceparent for an invalid header, got %q", forwarded)
+	}
+}
@@ -34,6 +34,8 @@ type userAPIKeyResponse struct {
 	RevokedAt             *time.Time        `json:"revoked_at,omitempty"`
 	LastUsedAt            *time.Time        `json:"last_used_at,omitempty"`
 	Revoked               bool              `json:"revoked"`
+	SuspendedAt           *time.Time        `json:"suspended_at,omitempty"`
+	ZeroRetention         bool              `json:"zero_retention"`
 }
 
 type quotaPayload struct {
@@ -43,9 +45,10 @@ type quotaPayload struct {
 }
 
 type rateLimitDetails struct {
-	RequestsPerMinute int `json:"requests_per_minute"`
-	TokensPerMinute   int `json:"tokens_per_minute"`
-	ParallelRequests  int `json:"parallel_requests"`
+	RequestsPerMinute         int `json:"requests_per_minute"`
+	TokensPerMinute           int `json:"tokens_per_minute"`
+	ParallelRequests          int `json:"parallel_requests"`
+	ParallelRequestTTLSeconds int `json:"parallel_request_ttl_seconds"`
 }
 
 type rateLimitPayload struct {
@@ -54,9 +57,10 @@ type rateLimitPayload struct {
 }
 
 type apiKeyRateLimitRequest struct {
-	RequestsPerMinute int `json:"requests_per_minute"`
-	TokensPerMinute   int `json:"tokens_per_minute"`
-	ParallelRequests  int `json:"parallel_requests"`
+	RequestsPerMinute         int `json:"requests_per_minute"`
+	TokensPerMinute           int `json:"tokens_per_minute"`
+	ParallelRequests          int `json:"parallel_requests"`
+	ParallelRequestTTLSeconds int `json:"parallel_request_ttl_seconds"`
 }
 
 type createUserAPIKeyRequest struct {
@@ -64,6 +68,8 @@ type createUserAPIKeyRequest struct {
 	Scopes     []string                `json:"scopes"`
 	Quota      *quotaPayload           `json:"quota"`
 	RateLimits *apiKeyRateLimitRequest `json:"rate_limits,omitempty"`
+	// ZeroRetention stores only counts and costs for the key's requests.
+	ZeroRetention bool `json:"zero_retention"`
 }
 
 type createUserAPIKeyResponse struct {
@@ -168,14 +174,15 @@ func (h *userHandler) createAPIKey(c *fiber.Ctx) error {
 	}
 
 	record, err := h.container.Queries.CreateAPIKey(c.Context(), db.CreateAPIKeyParams{
-		TenantID:    tenantID,
-		Prefix:      prefix,
-		SecretHash:  hash,
-		Name:        req.Name,
-		ScopesJson:  scopesJSON,
-		QuotaJson:   quotaJSON,
-		Kind:        db.ApiKeyKindPersonal,
-		OwnerUserID: user.ID,
+		TenantID:      tenantID,
+		Prefix:        prefix,
+		SecretHash:    hash,
+		Name:          req.Name,
+		ScopesJson:    scopesJSON,
+		QuotaJson:     quotaJSON,
+		Kind:          db.ApiKeyKindPersonal,
+		OwnerUserID:   user.ID,
+		ZeroRetention: req.ZeroRetention,
 	})
 	if err != nil {
 		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
@@ -371,14 +378,15 @@ func (h *userHandler) createTenantAPIKey(c *fiber.Ctx) error {
 		return httputil.WriteError(c, fiber.StatusInternalServerError, "failed to hash api key")
 	}
 	record, err := h.container.Queries.CreateAPIKey(c.Context(), db.CreateAPIKeyParams{
-		TenantID:    toPgUUID(tenantUUID),
-		Prefix:      prefix,
-		SecretHash:  hash,
-		Name:        req.Name,
-		ScopesJson:  scopesJSON,
-		QuotaJson:   quotaJSON,
-		Kind:        db.ApiKeyKindService,
-		OwnerUserID: user.ID,
+		TenantID:      toPgUUID(tenantUUID),
+		Prefix:        prefix,
+		SecretHash:    hash,
+		Name:          req.Name,
+		ScopesJson:    scopesJSON,
+		QuotaJson:     quotaJSON,
+		Kind:          db.ApiKeyKindService,
+		OwnerUserID:   user.ID,
+		ZeroRetention: req.ZeroRetention,
 	})
 	if err != nil {
 		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
@@ -579,6 +587,11 @@ func (h *userHandler) buildUserAPIKeyResponse(ctx context.Context, record db.Api
 		RevokedAt:             revokedAt,
 		LastUsedAt:            lastUsed,
 		Revoked:               record.RevokedAt.Valid,
+		ZeroRetention:         record.ZeroRetention,
+	}
+	if record.SuspendedAt.Valid {
+		ts := record.SuspendedAt.Time
+		resp.SuspendedAt = &ts
 	}
 	return resp, nil
 }
@@ -611,14 +624,16 @@ func (h *userHandler) buildRateLimitPayload(prefix string, tenantID uuid.UUID) *
 	keyCfg, tenantCfg := h.container.EffectiveRateLimits(prefix, tenantID)
 	return &rateLimitPayload{
 		Key: rateLimitDetails{
-			RequestsPerMinute: keyCfg.RequestsPerMinute,
-			TokensPerMinute:   keyCfg.TokensPerMinute,
-			ParallelRequests:  keyCfg.ParallelRequests,
+			RequestsPerMinute:         keyCfg.RequestsPerMinute,
+			TokensPerMinute:           keyCfg.TokensPerMinute,
+			ParallelRequests:          keyCfg.ParallelRequests,
+			ParallelRequestTTLSeconds: int(keyCfg.ParallelRequestTTL / time.Second),
 		},
 		Tenant: rateLimitDetails{
-			RequestsPerMinute: tenantCfg.RequestsPerMinute,
-			TokensPerMinute:   tenantCfg.TokensPerMinute,
-			ParallelRequests:  tenantCfg.ParallelRequests,
+			RequestsPerMinute:         tenantCfg.RequestsPerMinute,
+			TokensPerMinute:           tenantCfg.TokensPerMinute,
+			ParallelRequests:          tenantCfg.ParallelRequests,
+			ParallelRequestTTLSeconds: int(tenantCfg.ParallelRequestTTL / time.Second),
 		},
 	}
 }
@@ -686,7 +701,7 @@ func validateAPIKeyRateLimit(container *app.Container, tenantID uuid.UUID, paylo
 	if rpm == 0 && tpm == 0 && parallel == 0 {
 		return nil, nil
 	}
-	if rpm <= 0 || tpm <= 0 || parallel <= 0 {
+	if rpm <= 0 || tpm <= 0 || parallel <= 0 || payload.ParallelRequestTTLSeconds < 0 {
 		return nil, fmt.Errorf("rate limits must be positive integers")
 	}
 	keyCfg, tenantCfg := container.EffectiveRateLimits("", tenantID)
@@ -709,9 +724,10 @@ func validateAPIKeyRateLimit(container *app.Container, tenantID uuid.UUID, paylo
 		return nil, fmt.Errorf("parallel_requests cannot exceed default limit (%d)", keyCfg.ParallelRequests)
 	}
 	return &limits.LimitConfig{
-		RequestsPerMinute: rpm,
-		TokensPerMinute:   tpm,
-		ParallelRequests:  parallel,
+		RequestsPerMinute:  rpm,
+		TokensPerMinute:    tpm,
+		ParallelRequests:   parallel,
+		ParallelRequestTTL: time.Duration(payload.ParallelRequestTTLSeconds) * time.Second,
 	}, nil
 }
 
@@ -727,10 +743,11 @@ func applyAPIKeyRateLimit(ctx context.Context, container *app.Container, record
 		return nil
 	}
 	if _, err := container.Queries.UpsertAPIKeyRateLimit(ctx, db.UpsertAPIKeyRateLimitParams{
-		ApiKeyID:          record.ID,
-		RequestsPerMinute: int32(cfg.RequestsPerMinute),
-		TokensPerMinute:   int32(cfg.TokensPerMinute),
-		ParallelRequests:  int32(cfg.ParallelRequests),
+		ApiKeyID:                  record.ID,
+		RequestsPerMinute:         int32(cfg.RequestsPerMinute),
+		TokensPerMinute:           int32(cfg.TokensPerMinute),
+		ParallelRequests:          int32(cfg.ParallelRequests),
+		ParallelRequestTtlSeconds: int32(cfg.ParallelRequestTTL / time.Second),
 	}); err != nil {
 		return err
 	}
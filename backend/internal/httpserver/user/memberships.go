@@ -12,12 +12,55 @@ import (
 	"github.com/ncecere/open_model_gateway/backend/internal/db"
 	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
 	"github.com/ncecere/open_model_gateway/backend/internal/rbac"
+	admintenantsvc "github.com/ncecere/open_model_gateway/backend/internal/services/admintenant"
 )
 
 func (h *userHandler) registerTenantManagementRoutes(group fiber.Router) {
 	group.Get("/tenants/:tenantID/memberships", h.listTenantMemberships)
 	group.Post("/tenants/:tenantID/memberships", h.inviteTenantMembership)
 	group.Delete("/tenants/:tenantID/memberships/:userID", h.removeTenantMembership)
+	group.Post("/invitations/accept", h.acceptInvitation)
+}
+
+type acceptInvitationRequest struct {
+	Token string `json:"token"`
+}
+
+// acceptInvitation redeems an invitation addressed to the signed-in user's
+// email. Existing accounts must accept here rather than at the public
+// endpoint, so an invitation token alone never grants access to an account.
+func (h *userHandler) acceptInvitation(c *fiber.Ctx) error {
+	userID, ok := userIDFromContext(c.UserContext())
+	if !ok {
+		return httputil.WriteError(c, fiber.StatusUnauthorized, "authentication required")
+	}
+	var req acceptInvitationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "invalid request body")
+	}
+	if strings.TrimSpace(req.Token) == "" {
+		return httputil.WriteError(c, fiber.StatusBadRequest, "token is required")
+	}
+	if h.container.AdminTenants == nil {
+		return httputil.WriteError(c, fiber.StatusServiceUnavailable, "invitations unavailable")
+	}
+
+	accepted, err := h.container.AdminTenants.AcceptInvitationAsUser(c.UserContext(), req.Token, userID)
+	if err != nil {
+		if errors.Is(err, admintenantsvc.ErrInvitationInvalid) {
+			return httputil.WriteError(c, fiber.StatusBadRequest, err.Error())
+		}
+		return httputil.WriteError(c, fiber.StatusInternalServerError, "failed to accept invitation")
+	}
+	membership := accepted.Membership
+	return c.JSON(userMembershipResponse{
+		TenantID:  membership.TenantID.String(),
+		UserID:    membership.UserID.String(),
+		Email:     membership.Email,
+		Role:      string(membership.Role),
+		CreatedAt: membership.Created,
+		Self:      true,
+	})
 }
 
 type userMembershipRequest struct {
@@ -10,6 +10,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 
+	"github.com/ncecere/open_model_gateway/backend/internal/currency"
 	"github.com/ncecere/open_model_gateway/backend/internal/db"
 	"github.com/ncecere/open_model_gateway/backend/internal/httpserver/httputil"
 	usageservice "github.com/ncecere/open_model_gateway/backend/internal/services/usage"
@@ -56,6 +57,10 @@ func (h *userHandler) handleUsageRequest(c *fiber.Ctx, period, fallback string)
 	if h.usage == nil {
 		return httputil.WriteError(c, fiber.StatusInternalServerError, "usage service unavailable")
 	}
+	rate, ok := h.currencyRate(c)
+	if !ok {
+		return nil
+	}
 	summary, err := h.usage.SummarizeUserUsage(c.Context(), user, period, tenantFilter, timezone, startPtr, endPtr)
 	if err != nil {
 		switch {
@@ -71,6 +76,7 @@ func (h *userHandler) handleUsageRequest(c *fiber.Ctx, period, fallback string)
 		}
 		return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
 	}
+	rate.ApplyUserSummary(&summary)
 	return c.JSON(summary)
 }
 
@@ -116,6 +122,10 @@ func (h *userHandler) userUsageCompare(c *fiber.Ctx) error {
 	if len(tenantIDs) == 0 && len(aliases) == 0 {
 		return httputil.WriteError(c, fiber.StatusBadRequest, "tenant_ids or model_aliases required")
 	}
+	rate, ok := h.currencyRate(c)
+	if !ok {
+		return nil
+	}
 	result, err := h.usage.CompareUsage(c.Context(), usageservice.CompareUsageParams{
 		Period:       period,
 		Timezone:     timezone,
@@ -141,6 +151,7 @@ func (h *userHandler) userUsageCompare(c *fiber.Ctx) error {
 			return httputil.WriteError(c, fiber.StatusInternalServerError, err.Error())
 		}
 	}
+	rate.ApplyCompare(&result)
 	return c.JSON(result)
 }
 
@@ -288,6 +299,25 @@ func parseRangeParams(startRaw, endRaw string) (*time.Time, *time.Time, error) {
 	return &start, &end, nil
 }
 
+// currencyRate resolves the currency query parameter, defaulting to the
+// configured budget currency, and writes a 400 when it cannot be converted.
+func (h *userHandler) currencyRate(c *fiber.Ctx) (usageservice.CurrencyRate, bool) {
+	code := strings.TrimSpace(c.Query("currency"))
+	if code == "" && h.container.Config != nil {
+		code = h.container.Config.Budgets.Currency
+	}
+	rate, err := h.usage.ResolveCurrency(c.Context(), code, time.Now().UTC())
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		if errors.Is(err, currency.ErrUnknownCurrency) || errors.Is(err, usageservice.ErrCurrencyRateMissing) {
+			status = fiber.StatusBadRequest
+		}
+		_ = httputil.WriteError(c, status, err.Error())
+		return rate, false
+	}
+	return rate, true
+}
+
 func (h *userHandler) loadTenantScope(ctx context.Context, user db.User) ([]uuid.UUID, map[uuid.UUID]struct{}, error) {
 	addAllowed := func(id uuid.UUID, allowed map[uuid.UUID]struct{}, ordered *[]uuid.UUID) {
 		if id == uuid.Nil {
@@ -0,0 +1,183 @@
+package keysweeper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+// AbuseSuspendedEvent labels fingerprint abuse suspensions in the webhook
+// delivery queue.
+const AbuseSuspendedEvent = "api_key.abuse_suspended"
+
+// WebhookQueue persists outbound webhooks. webhooks.Service satisfies it.
+type WebhookQueue interface {
+	Enqueue(ctx context.Context, tenantID uuid.UUID, event, url string, payload []byte) error
+}
+
+// AbuseSuspensionReason is stored in api_keys.suspension_reason for keys
+// suspended by the AbuseSuspender.
+const AbuseSuspensionReason = "fingerprint_abuse"
+
+type abuseQueries interface {
+	ListFingerprintAbuseCandidates(ctx context.Context, arg db.ListFingerprintAbuseCandidatesParams) ([]db.ListFingerprintAbuseCandidatesRow, error)
+	SuspendAPIKey(ctx context.Context, arg db.SuspendAPIKeyParams) (db.ApiKey, error)
+	InsertAuditLog(ctx context.Context, arg db.InsertAuditLogParams) (db.AdminAuditLog, error)
+}
+
+// AbuseSuspender suspends API keys that sent more structurally identical
+// requests within a window than their tenant's threshold allows, which
+// usually means a client stuck in a loop. Suspension is reversible: an admin
+// reinstates the key once the client is fixed. Bootstrap keys are never
+// touched.
+type AbuseSuspender struct {
+	queries  abuseQueries
+	webhooks WebhookQueue
+	urls     []string
+	logger   *slog.Logger
+	now      func() time.Time
+}
+
+// NewAbuseSuspender builds a suspender that posts each suspension to urls
+// through webhooks. webhooks may be nil to only log suspensions.
+func NewAbuseSuspender(queries abuseQueries, webhooks WebhookQueue, urls []string, logger *slog.Logger) *AbuseSuspender {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &AbuseSuspender{
+		queries:  queries,
+		webhooks: webhooks,
+		urls:     urls,
+		logger:   logger,
+		now:      func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// abuseSuspendedPayload is the webhook body for one suspended key.
+type abuseSuspendedPayload struct {
+	Event        string    `json:"event"`
+	TenantID     string    `json:"tenant_id"`
+	APIKeyID     string    `json:"api_key_id"`
+	APIKeyPrefix string    `json:"api_key_prefix"`
+	APIKeyName   string    `json:"api_key_name"`
+	Fingerprint  string    `json:"fingerprint"`
+	Requests     int64     `json:"requests"`
+	Threshold    int64     `json:"threshold"`
+	Window       string    `json:"window"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// SuspendFingerprintAbuse suspends every active key that repeated a
+// fingerprint more than its threshold within window, recording an
+// api_key.suspend audit entry for each. threshold applies to tenants without
+// an abuse_fingerprint_threshold setting. It returns the number of keys
+// suspended.
+func (s *AbuseSuspender) SuspendFingerprintAbuse(ctx context.Context, threshold int32, window time.Duration) (int, error) {
+	if s == nil || s.queries == nil || threshold <= 0 || window <= 0 {
+		return 0, nil
+	}
+	now := s.now()
+	candidates, err := s.queries.ListFingerprintAbuseCandidates(ctx, db.ListFingerprintAbuseCandidatesParams{
+		DefaultThreshold: threshold,
+		Since:            pgtype.Timestamptz{Time: now.Add(-window), Valid: true},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("list abuse candidates: %w", err)
+	}
+
+	suspended := 0
+	seen := make(map[pgtype.UUID]struct{}, len(candidates))
+	for _, row := range candidates {
+		// Rows are busiest first, so a key over the limit on several
+		// fingerprints is reported with its worst one.
+		if _, ok := seen[row.ApiKeyID]; ok {
+			continue
+		}
+		seen[row.ApiKeyID] = struct{}{}
+		if _, err := s.queries.SuspendAPIKey(ctx, db.SuspendAPIKeyParams{
+			ID:               row.ApiKeyID,
+			SuspensionReason: AbuseSuspensionReason,
+		}); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			return suspended, fmt.Errorf("suspend key %s: %w", row.Prefix, err)
+		}
+		suspended++
+		s.audit(ctx, row, window)
+		s.logger.WarnContext(ctx, "suspended api key for repeated request fingerprint",
+			slog.String("prefix", row.Prefix),
+			slog.String("tenant_id", uuid.UUID(row.TenantID.Bytes).String()),
+			slog.String("fingerprint", row.Fingerprint),
+			slog.Int64("requests", row.Requests),
+			slog.Int64("threshold", row.Threshold),
+		)
+		s.notify(ctx, row, window, now)
+	}
+	return suspended, nil
+}
+
+// audit records the suspension without a user, since no admin acted.
+func (s *AbuseSuspender) audit(ctx context.Context, row db.ListFingerprintAbuseCandidatesRow, window time.Duration) {
+	metadata, err := json.Marshal(map[string]any{
+		"tenant_id":   uuid.UUID(row.TenantID.Bytes).String(),
+		"reason":      AbuseSuspensionReason,
+		"fingerprint": row.Fingerprint,
+		"requests":    row.Requests,
+		"threshold":   row.Threshold,
+		"window":      window.String(),
+	})
+	if err != nil {
+		return
+	}
+	if _, err := s.queries.InsertAuditLog(ctx, db.InsertAuditLogParams{
+		Action:       "api_key.suspend",
+		ResourceType: "api_key",
+		ResourceID:   uuid.UUID(row.ApiKeyID.Bytes).String(),
+		Metadata:     metadata,
+	}); err != nil {
+		s.logger.WarnContext(ctx, "record abuse suspension audit failed",
+			slog.String("prefix", row.Prefix),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+func (s *AbuseSuspender) notify(ctx context.Context, row db.ListFingerprintAbuseCandidatesRow, window time.Duration, now time.Time) {
+	if s.webhooks == nil || len(s.urls) == 0 {
+		return
+	}
+	body, err := json.Marshal(abuseSuspendedPayload{
+		Event:        AbuseSuspendedEvent,
+		TenantID:     uuid.UUID(row.TenantID.Bytes).String(),
+		APIKeyID:     uuid.UUID(row.ApiKeyID.Bytes).String(),
+		APIKeyPrefix: row.Prefix,
+		APIKeyName:   row.Name,
+		Fingerprint:  row.Fingerprint,
+		Requests:     row.Requests,
+		Threshold:    row.Threshold,
+		Window:       window.String(),
+		Timestamp:    now,
+	})
+	if err != nil {
+		return
+	}
+	for _, url := range s.urls {
+		// The URLs are gateway-wide settings, so the gateway secret signs them.
+		if err := s.webhooks.Enqueue(ctx, uuid.Nil, AbuseSuspendedEvent, url, body); err != nil {
+			s.logger.WarnContext(ctx, "queue abuse suspension webhook failed",
+				slog.String("prefix", row.Prefix),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+}
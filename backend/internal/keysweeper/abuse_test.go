@@ -0,0 +1,104 @@
+package keysweeper
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+type fakeAbuseQueries struct {
+	rows      []db.ListFingerprintAbuseCandidatesRow
+	params    db.ListFingerprintAbuseCandidatesParams
+	suspended []db.SuspendAPIKeyParams
+	audits    []db.InsertAuditLogParams
+}
+
+func (f *fakeAbuseQueries) ListFingerprintAbuseCandidates(_ context.Context, arg db.ListFingerprintAbuseCandidatesParams) ([]db.ListFingerprintAbuseCandidatesRow, error) {
+	f.params = arg
+	return f.rows, nil
+}
+
+func (f *fakeAbuseQueries) SuspendAPIKey(_ context.Context, arg db.SuspendAPIKeyParams) (db.ApiKey, error) {
+	f.suspended = append(f.suspended, arg)
+	return db.ApiKey{ID: arg.ID}, nil
+}
+
+func (f *fakeAbuseQueries) InsertAuditLog(_ context.Context, arg db.InsertAuditLogParams) (db.AdminAuditLog, error) {
+	f.audits = append(f.audits, arg)
+	return db.AdminAuditLog{}, nil
+}
+
+type queuedWebhook struct {
+	event, url string
+	payload    []byte
+}
+
+type fakeWebhookQueue struct {
+	queued []queuedWebhook
+}
+
+func (f *fakeWebhookQueue) Enqueue(_ context.Context, _ uuid.UUID, event, url string, payload []byte) error {
+	f.queued = append(f.queued, queuedWebhook{event: event, url: url, payload: payload})
+	return nil
+}
+
+func TestSuspendFingerprintAbuseSuspendsEachKeyOnce(t *testing.T) {
+	loopKey := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	otherKey := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	queries := &fakeAbuseQueries{rows: []db.ListFingerprintAbuseCandidatesRow{
+		{ApiKeyID: loopKey, Prefix: "sk-loop", Fingerprint: "aaa", Requests: 5000, Threshold: 1000},
+		{ApiKeyID: loopKey, Prefix: "sk-loop", Fingerprint: "bbb", Requests: 1200, Threshold: 1000},
+		{ApiKeyID: otherKey, Prefix: "sk-other", Fingerprint: "ccc", Requests: 60, Threshold: 50},
+	}}
+	hooks := &fakeWebhookQueue{}
+	now := time.Date(2025, 11, 17, 12, 0, 0, 0, time.UTC)
+	suspender := NewAbuseSuspender(queries, hooks, []string{"https://hooks.example.com/abuse"}, nil)
+	suspender.now = func() time.Time { return now }
+
+	suspended, err := suspender.SuspendFingerprintAbuse(context.Background(), 1000, time.Hour)
+	if err != nil {
+		t.Fatalf("suspend: %v", err)
+	}
+	if suspended != 2 || len(queries.suspended) != 2 {
+		t.Fatalf("expected 2 keys suspended, got %d (%v)", suspended, queries.suspended)
+	}
+	if queries.suspended[0].ID != loopKey || queries.suspended[0].SuspensionReason != AbuseSuspensionReason {
+		t.Fatalf("unexpected suspension %+v", queries.suspended[0])
+	}
+	if len(queries.audits) != 2 || queries.audits[0].Action != "api_key.suspend" || queries.audits[0].UserID.Valid {
+		t.Fatalf("expected a system audit entry per suspension, got %+v", queries.audits)
+	}
+	if queries.audits[0].ResourceID != uuid.UUID(loopKey.Bytes).String() {
+		t.Fatalf("audit entry should name the key, got %q", queries.audits[0].ResourceID)
+	}
+	if queries.params.DefaultThreshold != 1000 || !queries.params.Since.Time.Equal(now.Add(-time.Hour)) {
+		t.Fatalf("unexpected query params %+v", queries.params)
+	}
+	if len(hooks.queued) != 2 || hooks.queued[0].event != AbuseSuspendedEvent {
+		t.Fatalf("expected one webhook per suspended key, got %+v", hooks.queued)
+	}
+	var payload abuseSuspendedPayload
+	if err := json.Unmarshal(hooks.queued[0].payload, &payload); err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
+	if payload.APIKeyPrefix != "sk-loop" || payload.Fingerprint != "aaa" || payload.Requests != 5000 {
+		t.Fatalf("expected the busiest fingerprint in the payload, got %+v", payload)
+	}
+}
+
+func TestSuspendFingerprintAbuseDisabledWithoutThreshold(t *testing.T) {
+	queries := &fakeAbuseQueries{rows: []db.ListFingerprintAbuseCandidatesRow{{Prefix: "sk-loop"}}}
+	suspender := NewAbuseSuspender(queries, nil, nil, nil)
+	if suspended, err := suspender.SuspendFingerprintAbuse(context.Background(), 0, time.Hour); err != nil || suspended != 0 {
+		t.Fatalf("expected no-op, got %d, %v", suspended, err)
+	}
+	if len(queries.suspended) != 0 {
+		t.Fatal("expected no suspensions")
+	}
+}
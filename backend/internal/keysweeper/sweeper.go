@@ -0,0 +1,142 @@
+package keysweeper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+// Mailer sends plain-text email. usagepipeline.SMTPSink satisfies it.
+type Mailer interface {
+	SendMail(ctx context.Context, to []string, subject, body string) error
+}
+
+type keyQueries interface {
+	ListAPIKeysIdleSince(ctx context.Context, arg db.ListAPIKeysIdleSinceParams) ([]db.ListAPIKeysIdleSinceRow, error)
+	MarkAPIKeyInactiveWarningSent(ctx context.Context, id pgtype.UUID) error
+	RevokeAPIKey(ctx context.Context, id pgtype.UUID) (db.ApiKey, error)
+}
+
+// Sweeper revokes API keys that have not been used within a TTL. Owners are
+// warned by email once a key has been idle for ttl-warning, and a key is only
+// revoked after its warning period has fully elapsed, even if it crossed the
+// TTL while the sweeper was not running. Using a key clears its warning.
+type Sweeper struct {
+	queries keyQueries
+	mailer  Mailer
+	warning time.Duration
+	logger  *slog.Logger
+	now     func() time.Time
+}
+
+// New builds a sweeper. mailer may be nil, in which case warnings are only
+// recorded and logged.
+func New(queries keyQueries, mailer Mailer, warning time.Duration, logger *slog.Logger) *Sweeper {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if warning < 0 {
+		warning = 0
+	}
+	return &Sweeper{
+		queries: queries,
+		mailer:  mailer,
+		warning: warning,
+		logger:  logger,
+		now:     func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// SweepInactiveAPIKeys warns owners of keys approaching ttl and revokes keys
+// whose warning period has elapsed, handling up to batchSize keys per phase.
+// It returns the number of keys revoked. Bootstrap keys are never touched.
+func (s *Sweeper) SweepInactiveAPIKeys(ctx context.Context, ttl time.Duration, batchSize int32) (int, error) {
+	if s == nil || s.queries == nil || ttl <= 0 {
+		return 0, nil
+	}
+	if batchSize <= 0 {
+		batchSize = 200
+	}
+	now := s.now()
+	warnAfter := max(ttl-s.warning, 0)
+
+	if err := s.warnIdle(ctx, now.Add(-warnAfter), ttl, batchSize); err != nil {
+		return 0, err
+	}
+
+	expired, err := s.queries.ListAPIKeysIdleSince(ctx, db.ListAPIKeysIdleSinceParams{
+		IdleBefore:   pgtype.Timestamptz{Time: now.Add(-ttl), Valid: true},
+		WarnedBefore: pgtype.Timestamptz{Time: now.Add(-s.warning), Valid: true},
+		BatchSize:    batchSize,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("list expired keys: %w", err)
+	}
+	revoked := 0
+	for _, key := range expired {
+		if _, err := s.queries.RevokeAPIKey(ctx, key.ID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			return revoked, fmt.Errorf("revoke key %s: %w", key.Prefix, err)
+		}
+		revoked++
+		s.logger.InfoContext(ctx, "revoked inactive api key",
+			slog.String("prefix", key.Prefix),
+			slog.String("tenant_id", key.TenantID.String()),
+		)
+	}
+	return revoked, nil
+}
+
+func (s *Sweeper) warnIdle(ctx context.Context, idleBefore time.Time, ttl time.Duration, batchSize int32) error {
+	keys, err := s.queries.ListAPIKeysIdleSince(ctx, db.ListAPIKeysIdleSinceParams{
+		IdleBefore: pgtype.Timestamptz{Time: idleBefore, Valid: true},
+		BatchSize:  batchSize,
+	})
+	if err != nil {
+		return fmt.Errorf("list idle keys: %w", err)
+	}
+	for _, key := range keys {
+		email := strings.TrimSpace(key.OwnerEmail.String)
+		if s.mailer != nil && email != "" {
+			subject, body := warningMessage(key, ttl, s.warning)
+			if err := s.mailer.SendMail(ctx, []string{email}, subject, body); err != nil {
+				// Leave the key unwarned so the next sweep retries the email
+				// rather than revoking a key whose owner was never told.
+				s.logger.WarnContext(ctx, "inactive api key warning failed",
+					slog.String("prefix", key.Prefix),
+					slog.String("error", err.Error()),
+				)
+				continue
+			}
+		}
+		if err := s.queries.MarkAPIKeyInactiveWarningSent(ctx, key.ID); err != nil {
+			return fmt.Errorf("mark key %s warned: %w", key.Prefix, err)
+		}
+	}
+	return nil
+}
+
+func warningMessage(key db.ListAPIKeysIdleSinceRow, ttl, warning time.Duration) (string, string) {
+	lastUsed := "never"
+	if key.LastUsedAt.Valid {
+		lastUsed = key.LastUsedAt.Time.UTC().Format(time.RFC3339)
+	}
+	subject := fmt.Sprintf("[API Key] %s will be revoked for inactivity", key.Prefix)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "API Key: %s (%s)\n", key.Name, key.Prefix)
+	fmt.Fprintf(&b, "Last Used: %s\n", lastUsed)
+	fmt.Fprintf(&b, "Keys unused for %s are revoked automatically.\n", ttl)
+	fmt.Fprintf(&b, "This key will be revoked in %s unless it is used before then.\n", warning)
+	return subject, b.String()
+}
@@ -0,0 +1,131 @@
+package keysweeper
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+type fakeKey struct {
+	row       db.ListAPIKeysIdleSinceRow
+	bootstrap bool
+	revoked   bool
+}
+
+type fakeKeyQueries struct {
+	keys []*fakeKey
+	now  time.Time
+}
+
+// ListAPIKeysIdleSince mirrors the SQL filter: active, non-bootstrap keys idle
+// since before IdleBefore that are unwarned (no WarnedBefore) or were warned
+// at or before WarnedBefore.
+func (f *fakeKeyQueries) ListAPIKeysIdleSince(_ context.Context, arg db.ListAPIKeysIdleSinceParams) ([]db.ListAPIKeysIdleSinceRow, error) {
+	var out []db.ListAPIKeysIdleSinceRow
+	for _, key := range f.keys {
+		if key.revoked || key.bootstrap {
+			continue
+		}
+		idleSince := key.row.CreatedAt.Time
+		if key.row.LastUsedAt.Valid {
+			idleSince = key.row.LastUsedAt.Time
+		}
+		if !idleSince.Before(arg.IdleBefore.Time) {
+			continue
+		}
+		warned := key.row.InactiveWarningSentAt
+		switch {
+		case !arg.WarnedBefore.Valid && !warned.Valid:
+		case arg.WarnedBefore.Valid && warned.Valid && !warned.Time.After(arg.WarnedBefore.Time):
+		default:
+			continue
+		}
+		out = append(out, key.row)
+	}
+	return out, nil
+}
+
+func (f *fakeKeyQueries) MarkAPIKeyInactiveWarningSent(_ context.Context, id pgtype.UUID) error {
+	for _, key := range f.keys {
+		if key.row.ID == id {
+			key.row.InactiveWarningSentAt = pgtype.Timestamptz{Time: f.now, Valid: true}
+		}
+	}
+	return nil
+}
+
+func (f *fakeKeyQueries) RevokeAPIKey(_ context.Context, id pgtype.UUID) (db.ApiKey, error) {
+	for _, key := range f.keys {
+		if key.row.ID == id {
+			key.revoked = true
+		}
+	}
+	return db.ApiKey{ID: id}, nil
+}
+
+type recordingMailer struct {
+	sent []string
+}
+
+func (m *recordingMailer) SendMail(_ context.Context, to []string, _, _ string) error {
+	m.sent = append(m.sent, to...)
+	return nil
+}
+
+func newKey(id byte, lastUsed time.Time, email string) *fakeKey {
+	return &fakeKey{row: db.ListAPIKeysIdleSinceRow{
+		ID:         pgtype.UUID{Bytes: [16]byte{id}, Valid: true},
+		Prefix:     "sk-" + string('a'+rune(id)),
+		CreatedAt:  pgtype.Timestamptz{Time: lastUsed.Add(-time.Hour), Valid: true},
+		LastUsedAt: pgtype.Timestamptz{Time: lastUsed, Valid: true},
+		OwnerEmail: pgtype.Text{String: email, Valid: email != ""},
+	}}
+}
+
+func TestSweepWarnsThenRevokesAfterWarningPeriod(t *testing.T) {
+	const (
+		ttl     = 30 * 24 * time.Hour
+		warning = 7 * 24 * time.Hour
+	)
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	stale := newKey(1, start.Add(-40*24*time.Hour), "owner@example.com")
+	fresh := newKey(2, start.Add(-time.Hour), "other@example.com")
+	bootstrap := newKey(3, start.Add(-400*24*time.Hour), "")
+	bootstrap.bootstrap = true
+
+	queries := &fakeKeyQueries{keys: []*fakeKey{stale, fresh, bootstrap}, now: start}
+	mailer := &recordingMailer{}
+	sweeper := New(queries, mailer, warning, nil)
+	sweeper.now = func() time.Time { return queries.now }
+
+	revoked, err := sweeper.SweepInactiveAPIKeys(context.Background(), ttl, 100)
+	if err != nil {
+		t.Fatalf("first sweep: %v", err)
+	}
+	if revoked != 0 || stale.revoked {
+		t.Fatal("key revoked before its owner had the full warning period")
+	}
+	if len(mailer.sent) != 1 || mailer.sent[0] != "owner@example.com" {
+		t.Fatalf("expected one warning to the stale key owner, got %v", mailer.sent)
+	}
+
+	queries.now = start.Add(warning + time.Minute)
+	revoked, err = sweeper.SweepInactiveAPIKeys(context.Background(), ttl, 100)
+	if err != nil {
+		t.Fatalf("second sweep: %v", err)
+	}
+	if revoked != 1 || !stale.revoked {
+		t.Fatalf("expected stale key revoked after warning period, revoked=%d", revoked)
+	}
+	if fresh.revoked || bootstrap.revoked {
+		t.Fatal("recently used or bootstrap key was revoked")
+	}
+	if len(mailer.sent) != 1 {
+		t.Fatalf("expected no repeat warnings, got %v", mailer.sent)
+	}
+}
@@ -5,19 +5,70 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 )
 
 var ErrLimitExceeded = errors.New("rate limit exceeded")
 
+// DefaultParallelRequestTTL bounds how long a parallel-request slot survives
+// once its holder stops refreshing it.
+const DefaultParallelRequestTTL = 60 * time.Second
+
 type LimitConfig struct {
 	RequestsPerMinute int
 	TokensPerMinute   int
 	ParallelRequests  int
+	// ParallelRequestTTL expires a parallel slot whose holder stops refreshing
+	// it, so a process that dies before Release cannot leak it. Live holders
+	// refresh the slot, so long requests keep it. Zero uses
+	// DefaultParallelRequestTTL.
+	ParallelRequestTTL time.Duration
+}
+
+// Permit is the parallel-request slot taken by Allow. A nil Permit holds no
+// slot and is safe to release.
+type Permit struct {
+	slot  string
+	token string
+	stop  chan struct{}
+	once  sync.Once
 }
 
+// semaphoreAcquireScript claims the first free slot with SET NX PX. Each slot
+// is its own key holding the owner's token, so slots held by a crashed
+// process expire on their own instead of leaking a counter. The slot keys
+// share a hash tag so the script runs on a single Redis Cluster node.
+var semaphoreAcquireScript = redis.NewScript(`
+for i, slot in ipairs(KEYS) do
+  if redis.call('SET', slot, ARGV[1], 'NX', 'PX', ARGV[2]) then
+    return i
+  end
+end
+return 0
+`)
+
+// semaphoreRefreshScript extends a slot's lease while the caller still owns
+// it. It returns 0 once the slot has expired or passed to another request.
+var semaphoreRefreshScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+  return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// semaphoreReleaseScript frees a slot only while the caller still owns it, so
+// a release after expiry never frees a slot another request has since taken.
+var semaphoreReleaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+  return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
 type RateLimiter struct {
 	client *redis.Client
 }
@@ -26,33 +77,34 @@ func NewRateLimiter(client *redis.Client) *RateLimiter {
 	return &RateLimiter{client: client}
 }
 
-func (l *RateLimiter) Allow(ctx context.Context, key string, overrides LimitConfig) error {
+// Allow checks the RPM window and takes a parallel-request slot. The slot's
+// lease is refreshed until the returned Permit is passed to Release, which
+// must happen once the request finishes.
+func (l *RateLimiter) Allow(ctx context.Context, key string, overrides LimitConfig) (*Permit, error) {
 	if l == nil || l.client == nil {
-		return nil
+		return nil, nil
 	}
 
 	cfg := overrides
 	if cfg.RequestsPerMinute > 0 {
 		if err := l.countCheck(ctx, fmt.Sprintf("rpm:%s", key), time.Minute, cfg.RequestsPerMinute); err != nil {
-			return err
+			return nil, err
 		}
 	}
 	if cfg.ParallelRequests > 0 {
-		if err := l.semaphoreAcquire(ctx, fmt.Sprintf("sem:%s", key), cfg.ParallelRequests); err != nil {
-			return err
-		}
+		return l.semaphoreAcquire(ctx, fmt.Sprintf("sem:%s", key), cfg.ParallelRequests, cfg.ParallelRequestTTL)
 	}
 
-	return nil
+	return nil, nil
 }
 
-func (l *RateLimiter) Release(ctx context.Context, key string, cfg LimitConfig) {
-	if l == nil || l.client == nil {
+// Release frees the slot held by permit.
+func (l *RateLimiter) Release(ctx context.Context, permit *Permit) {
+	if l == nil || l.client == nil || permit == nil {
 		return
 	}
-	if cfg.ParallelRequests > 0 {
-		l.semaphoreRelease(ctx, fmt.Sprintf("sem:%s", key))
-	}
+	permit.once.Do(func() { close(permit.stop) })
+	semaphoreReleaseScript.Run(ctx, l.client, []string{permit.slot}, permit.token)
 }
 
 func (l *RateLimiter) countCheck(ctx context.Context, key string, ttl time.Duration, limit int) error {
@@ -72,25 +124,43 @@ func (l *RateLimiter) countCheck(ctx context.Context, key string, ttl time.Durat
 	return nil
 }
 
-func (l *RateLimiter) semaphoreAcquire(ctx context.Context, key string, max int) error {
-	ttl := 5 * time.Minute
-	redisKey := key
-	cnt, err := l.client.Incr(ctx, redisKey).Result()
-	if err != nil {
-		return err
+func (l *RateLimiter) semaphoreAcquire(ctx context.Context, key string, max int, ttl time.Duration) (*Permit, error) {
+	if ttl <= 0 {
+		ttl = DefaultParallelRequestTTL
 	}
-	if cnt == 1 {
-		l.client.Expire(ctx, redisKey, ttl)
+	slots := make([]string, max)
+	for i := range slots {
+		slots[i] = fmt.Sprintf("{%s}:%d", key, i)
 	}
-	if int(cnt) > max {
-		l.client.Decr(ctx, redisKey)
-		return ErrLimitExceeded
+	token := uuid.NewString()
+	idx, err := semaphoreAcquireScript.Run(ctx, l.client, slots, token, ttl.Milliseconds()).Int()
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	if idx == 0 {
+		return nil, ErrLimitExceeded
+	}
+	permit := &Permit{slot: slots[idx-1], token: token, stop: make(chan struct{})}
+	go l.refreshLease(context.WithoutCancel(ctx), permit, ttl)
+	return permit, nil
 }
 
-func (l *RateLimiter) semaphoreRelease(ctx context.Context, key string) {
-	l.client.Decr(ctx, key)
+// refreshLease renews the permit's slot every third of its TTL until the
+// permit is released or the slot is lost.
+func (l *RateLimiter) refreshLease(ctx context.Context, permit *Permit, ttl time.Duration) {
+	ticker := time.NewTicker(ttl / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-permit.stop:
+			return
+		case <-ticker.C:
+			held, err := semaphoreRefreshScript.Run(ctx, l.client, []string{permit.slot}, permit.token, ttl.Milliseconds()).Int()
+			if err == nil && held == 0 {
+				return
+			}
+		}
+	}
 }
 
 func (l *RateLimiter) TokenAllowance(ctx context.Context, key string, tokens int, cfg LimitConfig) error {
@@ -3,6 +3,7 @@ package limits
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -11,6 +12,12 @@ import (
 )
 
 func newTestLimiter(t *testing.T) (*RateLimiter, func()) {
+	t.Helper()
+	limiter, _, cleanup := newTestLimiterWithServer(t)
+	return limiter, cleanup
+}
+
+func newTestLimiterWithServer(t *testing.T) (*RateLimiter, *miniredis.Miniredis, func()) {
 	t.Helper()
 	server, err := miniredis.Run()
 	if err != nil {
@@ -22,7 +29,7 @@ func newTestLimiter(t *testing.T) (*RateLimiter, func()) {
 		client.Close()
 		server.Close()
 	}
-	return limiter, cleanup
+	return limiter, server, cleanup
 }
 
 func TestRateLimiterAllowEnforcesParallel(t *testing.T) {
@@ -33,18 +40,132 @@ func TestRateLimiterAllowEnforcesParallel(t *testing.T) {
 	cfg := LimitConfig{ParallelRequests: 1}
 	key := "parallel:test"
 
-	if err := limiter.Allow(ctx, key, cfg); err != nil {
+	permit, err := limiter.Allow(ctx, key, cfg)
+	if err != nil {
 		t.Fatalf("first request should pass: %v", err)
 	}
-	if err := limiter.Allow(ctx, key, cfg); err != ErrLimitExceeded {
+	if _, err := limiter.Allow(ctx, key, cfg); err != ErrLimitExceeded {
 		t.Fatalf("expected parallel limit error, got %v", err)
 	}
-	limiter.Release(ctx, key, cfg)
-	if err := limiter.Allow(ctx, key, cfg); err != nil {
+	limiter.Release(ctx, permit)
+	if _, err := limiter.Allow(ctx, key, cfg); err != nil {
 		t.Fatalf("request after release should pass: %v", err)
 	}
 }
 
+func TestRateLimiterParallelConcurrentAcquire(t *testing.T) {
+	limiter, cleanup := newTestLimiter(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	cfg := LimitConfig{ParallelRequests: 3}
+	key := "parallel:concurrent"
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		permits []*Permit
+	)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			permit, err := limiter.Allow(ctx, key, cfg)
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			permits = append(permits, permit)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	if len(permits) != 3 {
+		t.Fatalf("expected 3 concurrent slots, got %d", len(permits))
+	}
+	for _, permit := range permits {
+		limiter.Release(ctx, permit)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := limiter.Allow(ctx, key, cfg); err != nil {
+			t.Fatalf("slot %d should be free after release: %v", i, err)
+		}
+	}
+}
+
+func TestRateLimiterParallelSlotExpiresWithoutRelease(t *testing.T) {
+	limiter, server, cleanup := newTestLimiterWithServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	cfg := LimitConfig{ParallelRequests: 1, ParallelRequestTTL: 10 * time.Second}
+	key := "parallel:abort"
+
+	// Take the slot from a second client that goes away without releasing,
+	// as a crashed gateway instance would.
+	crashed := NewRateLimiter(redis.NewClient(&redis.Options{Addr: server.Addr()}))
+	permit, err := crashed.Allow(ctx, key, cfg)
+	if err != nil {
+		t.Fatalf("first request should pass: %v", err)
+	}
+	crashed.client.Close()
+	crashed.Release(ctx, permit)
+
+	if _, err := limiter.Allow(ctx, key, cfg); err != ErrLimitExceeded {
+		t.Fatalf("expected slot to stay held until it expires, got %v", err)
+	}
+	server.FastForward(11 * time.Second)
+	second, err := limiter.Allow(ctx, key, cfg)
+	if err != nil {
+		t.Fatalf("expected expired slot to be reclaimed: %v", err)
+	}
+
+	// A late release from the original holder must not free the new owner's slot.
+	limiter.Release(ctx, permit)
+	if _, err := limiter.Allow(ctx, key, cfg); err != ErrLimitExceeded {
+		t.Fatalf("stale release freed a slot it no longer owned: %v", err)
+	}
+	limiter.Release(ctx, second)
+	if _, err := limiter.Allow(ctx, key, cfg); err != nil {
+		t.Fatalf("slot should be free after owner release: %v", err)
+	}
+}
+
+func TestRateLimiterParallelLeaseRefreshedWhileHeld(t *testing.T) {
+	limiter, server, cleanup := newTestLimiterWithServer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	ttl := 300 * time.Millisecond
+	cfg := LimitConfig{ParallelRequests: 1, ParallelRequestTTL: ttl}
+	key := "parallel:lease"
+
+	permit, err := limiter.Allow(ctx, key, cfg)
+	if err != nil {
+		t.Fatalf("first request should pass: %v", err)
+	}
+	if permit.slot != "{sem:parallel:lease}:0" {
+		t.Fatalf("expected the slot key to carry the shared hash tag, got %q", permit.slot)
+	}
+
+	// Hold the permit for three TTLs; miniredis only expires keys when the
+	// clock is advanced, so advance it alongside the wall clock.
+	for range 6 {
+		time.Sleep(ttl / 2)
+		server.FastForward(ttl / 2)
+	}
+	if _, err := limiter.Allow(ctx, key, cfg); err != ErrLimitExceeded {
+		t.Fatalf("expected the refreshed slot to stay held past its TTL, got %v", err)
+	}
+
+	limiter.Release(ctx, permit)
+	next, err := limiter.Allow(ctx, key, cfg)
+	if err != nil {
+		t.Fatalf("slot should be free after release: %v", err)
+	}
+	limiter.Release(ctx, next)
+}
+
 func TestRateLimiterAllowEnforcesRPM(t *testing.T) {
 	limiter, cleanup := newTestLimiter(t)
 	defer cleanup()
@@ -53,13 +174,13 @@ func TestRateLimiterAllowEnforcesRPM(t *testing.T) {
 	cfg := LimitConfig{RequestsPerMinute: 2}
 	key := "rpm:test"
 
-	if err := limiter.Allow(ctx, key, cfg); err != nil {
+	if _, err := limiter.Allow(ctx, key, cfg); err != nil {
 		t.Fatalf("first request should pass: %v", err)
 	}
-	if err := limiter.Allow(ctx, key, cfg); err != nil {
+	if _, err := limiter.Allow(ctx, key, cfg); err != nil {
 		t.Fatalf("second request should pass: %v", err)
 	}
-	if err := limiter.Allow(ctx, key, cfg); err != ErrLimitExceeded {
+	if _, err := limiter.Allow(ctx, key, cfg); err != ErrLimitExceeded {
 		t.Fatalf("expected rpm limit error, got %v", err)
 	}
 }
@@ -0,0 +1,154 @@
+package limits
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+)
+
+var ErrQuotaExceeded = errors.New("request quota exceeded")
+
+// QuotaConfig caps the number of requests a tenant may make per refresh period.
+type QuotaConfig struct {
+	MaxRequests     int64
+	RefreshSchedule string
+	// MaxRequestBodyMB caps the request body size for the tenant's API
+	// calls; zero leaves only the server-wide limit.
+	MaxRequestBodyMB int32
+	// StreamIdleTimeout ends a streaming response once no chunk has been
+	// flushed for this long; zero disables the check.
+	StreamIdleTimeout time.Duration
+}
+
+// QuotaStatus describes the tenant's counter for the current period.
+type QuotaStatus struct {
+	Limit   int64
+	Used    int64
+	ResetAt time.Time
+	key     string
+}
+
+// Remaining returns how many requests are left in the period.
+func (s QuotaStatus) Remaining() int64 {
+	if s.Used >= s.Limit {
+		return 0
+	}
+	return s.Limit - s.Used
+}
+
+// quotaReserveScript increments the counter only while it is below the limit so
+// concurrent callers can never push it past max_requests.
+var quotaReserveScript = redis.NewScript(`
+local used = tonumber(redis.call('GET', KEYS[1]) or '0')
+local limit = tonumber(ARGV[1])
+if used >= limit then
+  return {0, used}
+end
+used = redis.call('INCR', KEYS[1])
+redis.call('EXPIREAT', KEYS[1], ARGV[2])
+return {1, used}
+`)
+
+var quotaRefundScript = redis.NewScript(`
+local used = tonumber(redis.call('GET', KEYS[1]) or '0')
+if used > 0 then
+  return redis.call('DECR', KEYS[1])
+end
+return 0
+`)
+
+type QuotaCounter struct {
+	client *redis.Client
+	now    func() time.Time
+}
+
+func NewQuotaCounter(client *redis.Client) *QuotaCounter {
+	return &QuotaCounter{client: client, now: time.Now}
+}
+
+// Reserve counts one request against the tenant's quota. When the quota is
+// already used up it returns ErrQuotaExceeded alongside the current status.
+func (q *QuotaCounter) Reserve(ctx context.Context, tenantID uuid.UUID, cfg QuotaConfig) (QuotaStatus, error) {
+	status := q.status(tenantID, cfg)
+	if q == nil || q.client == nil || cfg.MaxRequests <= 0 {
+		return status, nil
+	}
+	res, err := quotaReserveScript.Run(ctx, q.client, []string{status.key}, cfg.MaxRequests, status.ResetAt.Unix()).Int64Slice()
+	if err != nil {
+		return status, err
+	}
+	if len(res) != 2 {
+		return status, fmt.Errorf("unexpected quota script result %v", res)
+	}
+	status.Used = res[1]
+	if res[0] == 0 {
+		return status, ErrQuotaExceeded
+	}
+	return status, nil
+}
+
+// Refund returns a reserved request to the quota, e.g. when dispatch failed.
+func (q *QuotaCounter) Refund(ctx context.Context, status QuotaStatus) {
+	if q == nil || q.client == nil || status.key == "" {
+		return
+	}
+	quotaRefundScript.Run(ctx, q.client, []string{status.key})
+}
+
+// Usage reports the tenant's counter without consuming from it.
+func (q *QuotaCounter) Usage(ctx context.Context, tenantID uuid.UUID, cfg QuotaConfig) (QuotaStatus, error) {
+	status := q.status(tenantID, cfg)
+	if q == nil || q.client == nil {
+		return status, nil
+	}
+	used, err := q.client.Get(ctx, status.key).Int64()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return status, nil
+		}
+		return status, err
+	}
+	status.Used = used
+	return status, nil
+}
+
+func (q *QuotaCounter) status(tenantID uuid.UUID, cfg QuotaConfig) QuotaStatus {
+	now := time.Now()
+	if q != nil && q.now != nil {
+		now = q.now()
+	}
+	start, end := QuotaPeriod(now, cfg.RefreshSchedule)
+	return QuotaStatus{
+		Limit:   cfg.MaxRequests,
+		ResetAt: end,
+		key:     fmt.Sprintf("quota:%s:%s", tenantID, start.Format("2006-01-02")),
+	}
+}
+
+// QuotaPeriod returns the counting window containing now. Calendar months and
+// ISO weeks match the budget windows; rolling_Nd schedules use fixed N-day
+// windows counted from the Unix epoch because a counter needs a stable start.
+func QuotaPeriod(now time.Time, schedule string) (time.Time, time.Time) {
+	nowUTC := now.UTC()
+	year, month, day := nowUTC.Date()
+	switch normalized := config.NormalizeBudgetRefreshSchedule(schedule); normalized {
+	case "weekly":
+		delta := (int(nowUTC.Weekday()) + 6) % 7 // Monday = 0
+		start := time.Date(year, month, day, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -delta)
+		return start, start.AddDate(0, 0, 7)
+	default:
+		if days, ok := config.BudgetRollingWindowDays(normalized); ok && days > 0 {
+			window := int64(days) * 24 * 60 * 60
+			start := time.Unix(nowUTC.Unix()/window*window, 0).UTC()
+			return start, start.AddDate(0, 0, days)
+		}
+	}
+	start := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
@@ -0,0 +1,107 @@
+package limits
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestQuotaCounter(t *testing.T) (*QuotaCounter, *miniredis.Miniredis) {
+	t.Helper()
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() {
+		client.Close()
+		server.Close()
+	})
+	return NewQuotaCounter(client), server
+}
+
+func TestQuotaReserveConcurrentAllowsExactlyMax(t *testing.T) {
+	counter, _ := newTestQuotaCounter(t)
+	ctx := context.Background()
+	tenantID := uuid.New()
+	cfg := QuotaConfig{MaxRequests: 10, RefreshSchedule: "calendar_month"}
+
+	var allowed, rejected atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := counter.Reserve(ctx, tenantID, cfg)
+			switch {
+			case err == nil:
+				allowed.Add(1)
+			case errors.Is(err, ErrQuotaExceeded):
+				rejected.Add(1)
+			default:
+				t.Errorf("reserve: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if allowed.Load() != 10 || rejected.Load() != 40 {
+		t.Fatalf("expected 10 allowed / 40 rejected, got %d / %d", allowed.Load(), rejected.Load())
+	}
+	status, err := counter.Usage(ctx, tenantID, cfg)
+	if err != nil {
+		t.Fatalf("usage: %v", err)
+	}
+	if status.Used != 10 || status.Remaining() != 0 {
+		t.Fatalf("expected counter to stop at 10, got used=%d remaining=%d", status.Used, status.Remaining())
+	}
+}
+
+func TestQuotaRefundAndExpiry(t *testing.T) {
+	counter, server := newTestQuotaCounter(t)
+	now := time.Date(2025, 3, 31, 23, 59, 0, 0, time.UTC)
+	counter.now = func() time.Time { return now }
+	server.SetTime(now)
+	ctx := context.Background()
+	tenantID := uuid.New()
+	cfg := QuotaConfig{MaxRequests: 1}
+
+	status, err := counter.Reserve(ctx, tenantID, cfg)
+	if err != nil {
+		t.Fatalf("first reserve: %v", err)
+	}
+	if !status.ResetAt.Equal(time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected reset %s", status.ResetAt)
+	}
+	if _, err := counter.Reserve(ctx, tenantID, cfg); !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("expected quota exceeded, got %v", err)
+	}
+	counter.Refund(ctx, status)
+	if _, err := counter.Reserve(ctx, tenantID, cfg); err != nil {
+		t.Fatalf("reserve after refund: %v", err)
+	}
+
+	key := "quota:" + tenantID.String() + ":2025-03-01"
+	if ttl := server.TTL(key); ttl <= 0 || ttl > time.Hour {
+		t.Fatalf("expected counter to expire at period end, ttl=%s", ttl)
+	}
+}
+
+func TestQuotaPeriodRollingWindows(t *testing.T) {
+	now := time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC)
+	start, end := QuotaPeriod(now, "rolling_7d")
+	if start.After(now) || !end.After(now) || end.Sub(start) != 7*24*time.Hour {
+		t.Fatalf("unexpected rolling window %s - %s", start, end)
+	}
+	start, end = QuotaPeriod(now, "weekly")
+	if !start.Equal(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)) || !end.Equal(time.Date(2025, 3, 17, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected weekly window %s - %s", start, end)
+	}
+}
@@ -0,0 +1,47 @@
+// Package logging configures the process-wide slog logger from
+// config.LoggingConfig.
+package logging
+
+import (
+	"io"
+	"log/slog"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+)
+
+// ServiceName is reported as service.name on every record, matching the
+// OpenTelemetry resource.
+const ServiceName = "open-model-gateway"
+
+// New builds a logger writing to w in the configured format and level.
+func New(cfg config.LoggingConfig, w io.Writer) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: level(cfg.Level), AddSource: cfg.AddSource}
+	var handler slog.Handler
+	if cfg.Format == "json" {
+		handler = slog.NewJSONHandler(w, opts)
+	} else {
+		handler = slog.NewTextHandler(w, opts)
+	}
+	return slog.New(handler).With(slog.String("service.name", ServiceName))
+}
+
+// Setup installs the logger as slog's default. Output from the standard log
+// package is routed through it at info level.
+func Setup(cfg config.LoggingConfig, w io.Writer) *slog.Logger {
+	logger := New(cfg, w)
+	slog.SetDefault(logger)
+	return logger
+}
+
+func level(name string) slog.Level {
+	switch name {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
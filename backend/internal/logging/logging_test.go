@@ -0,0 +1,41 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+)
+
+func TestNewJSONLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(config.LoggingConfig{Format: "json", Level: "warn"}, &buf)
+
+	logger.Info("dropped")
+	logger.Warn("kept", slog.Int("http.status_code", 503))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected only the warn record, got %q", buf.String())
+	}
+	var record map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+		t.Fatalf("record is not JSON: %v", err)
+	}
+	if record["msg"] != "kept" || record["service.name"] != ServiceName || record["http.status_code"] != float64(503) {
+		t.Fatalf("unexpected record %v", record)
+	}
+}
+
+func TestNewTextLoggerAddsSource(t *testing.T) {
+	var buf bytes.Buffer
+	New(config.LoggingConfig{Format: "text", Level: "debug", AddSource: true}, &buf).Debug("hello")
+
+	out := buf.String()
+	if !strings.Contains(out, "msg=hello") || !strings.Contains(out, "source=") {
+		t.Fatalf("unexpected text output %q", out)
+	}
+}
@@ -1,11 +1,88 @@
 package models
 
-import "time"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
 
 type ChatMessage struct {
 	Role    string `json:"role"`
 	Content string `json:"content"`
 	Name    string `json:"name,omitempty"`
+	// Parts holds array-form content when the message carries images, in the
+	// order the client sent them. Content still holds the joined text parts
+	// for adapters that only understand text.
+	Parts []ChatContentPart `json:"parts,omitempty"`
+}
+
+// ChatContentPart is one element of array-form message content. Type is
+// "text" or "image_url".
+type ChatContentPart struct {
+	Type        string `json:"type"`
+	Text        string `json:"text,omitempty"`
+	ImageURL    string `json:"image_url,omitempty"`
+	ImageDetail string `json:"image_detail,omitempty"`
+}
+
+// HasImageContent reports whether any message carries an image part.
+func (r ChatRequest) HasImageContent() bool {
+	for _, msg := range r.Messages {
+		for _, part := range msg.Parts {
+			if part.Type == "image_url" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ErrUnsupportedContent marks request content a provider adapter cannot
+// forward, such as an image URL form the provider does not accept. The
+// gateway reports it to the client as 400 instead of a provider failure.
+var ErrUnsupportedContent = errors.New("unsupported content")
+
+// ParseImageDataURL splits a "data:<media type>;base64,<data>" image URL into
+// its media type and base64 payload. ok is false for any other URL.
+func ParseImageDataURL(raw string) (mediaType, data string, ok bool) {
+	rest, found := strings.CutPrefix(raw, "data:")
+	if !found {
+		return "", "", false
+	}
+	meta, data, found := strings.Cut(rest, ",")
+	if !found || data == "" {
+		return "", "", false
+	}
+	mediaType, found = strings.CutSuffix(meta, ";base64")
+	if !found || !strings.HasPrefix(mediaType, "image/") {
+		return "", "", false
+	}
+	return mediaType, data, true
+}
+
+// fingerprintTokenBucket rounds the approximate token count so requests that
+// differ only by a timestamp or counter still share a fingerprint.
+const fingerprintTokenBucket = 16
+
+// Fingerprint hashes the request's shape (model, message count, first
+// message role, and approximate token count) without its content. Identical
+// fingerprints from one key in a short window indicate a runaway loop.
+func (r ChatRequest) Fingerprint() string {
+	chars := 0
+	for _, msg := range r.Messages {
+		chars += len(msg.Content)
+	}
+	tokens := (chars / 4) / fingerprintTokenBucket * fingerprintTokenBucket
+	firstRole := ""
+	if len(r.Messages) > 0 {
+		firstRole = r.Messages[0].Role
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%d|%s|%d", r.Model, len(r.Messages), firstRole, tokens)))
+	return hex.EncodeToString(sum[:16])
 }
 
 type ChatRequest struct {
@@ -16,6 +93,16 @@ type ChatRequest struct {
 	MaxTokens   *int32        `json:"max_tokens,omitempty"`
 	Stream      bool          `json:"stream,omitempty"`
 	Stop        []string      `json:"stop,omitempty"`
+
+	ResponseFormat *ChatResponseFormat `json:"response_format,omitempty"`
+}
+
+// ChatResponseFormat mirrors OpenAI's response_format. JSONSchema carries the
+// raw json_schema object ({"name", "schema", "strict"}) when Type is
+// "json_schema".
+type ChatResponseFormat struct {
+	Type       string           `json:"type"`
+	JSONSchema *json.RawMessage `json:"json_schema,omitempty"`
 }
 
 type ChatChoice struct {
@@ -28,6 +115,9 @@ type Usage struct {
 	PromptTokens     int32 `json:"prompt_tokens"`
 	CompletionTokens int32 `json:"completion_tokens"`
 	TotalTokens      int32 `json:"total_tokens"`
+	// CachedInputTokens is the part of PromptTokens the provider served from
+	// its context cache; those tokens are billed at a reduced rate.
+	CachedInputTokens int32 `json:"cached_input_tokens,omitempty"`
 }
 
 type ChatResponse struct {
@@ -0,0 +1,40 @@
+package models
+
+import "testing"
+
+func TestChatRequestFingerprintIgnoresContent(t *testing.T) {
+	base := ChatRequest{Model: "gpt-4o", Messages: []ChatMessage{
+		{Role: "system", Content: "You are a helpful agent."},
+		{Role: "user", Content: "Run step 1 of the plan now."},
+	}}
+	same := ChatRequest{Model: "gpt-4o", Messages: []ChatMessage{
+		{Role: "system", Content: "You are a helpful agent."},
+		{Role: "user", Content: "Run step 2 of the plan now."},
+	}}
+	if base.Fingerprint() != same.Fingerprint() {
+		t.Fatal("requests with the same shape should share a fingerprint")
+	}
+
+	for name, other := range map[string]ChatRequest{
+		"model":      {Model: "gpt-4o-mini", Messages: base.Messages},
+		"count":      {Model: "gpt-4o", Messages: base.Messages[:1]},
+		"first role": {Model: "gpt-4o", Messages: []ChatMessage{{Role: "user", Content: base.Messages[0].Content}, base.Messages[1]}},
+		"tokens":     {Model: "gpt-4o", Messages: []ChatMessage{base.Messages[0], {Role: "user", Content: string(make([]byte, 4000))}}},
+	} {
+		if other.Fingerprint() == base.Fingerprint() {
+			t.Fatalf("changing the %s should change the fingerprint", name)
+		}
+	}
+}
+
+func TestParseImageDataURL(t *testing.T) {
+	mediaType, data, ok := ParseImageDataURL("data:image/webp;base64,UklGR")
+	if !ok || mediaType != "image/webp" || data != "UklGR" {
+		t.Fatalf("unexpected parse %q %q %v", mediaType, data, ok)
+	}
+	for _, raw := range []string{"https://example.com/a.png", "data:image/png,raw", "data:text/plain;base64,aGk=", "data:image/png;base64,"} {
+		if _, _, ok := ParseImageDataURL(raw); ok {
+			t.Fatalf("expected %q to be rejected", raw)
+		}
+	}
+}
@@ -3,6 +3,9 @@ package models
 type EmbeddingsRequest struct {
 	Model string   `json:"model"`
 	Input []string `json:"input"`
+	// Dimensions asks providers that support it for shorter vectors; nil
+	// keeps the model's native size.
+	Dimensions *int32 `json:"dimensions,omitempty"`
 }
 
 type Embedding struct {
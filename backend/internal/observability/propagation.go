@@ -0,0 +1,32 @@
+package observability
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// TraceContext is the W3C Trace Context propagator shared by the HTTP
+// middleware, which reads traceparent and tracestate from callers, and the
+// provider adapters, which forward them upstream.
+var TraceContext propagation.TextMapPropagator = propagation.TraceContext{}
+
+// SpanContextLocalsKey is the fiber.Locals key the HTTP tracing middleware
+// stores the request's trace.SpanContext under, for handlers such as
+// WebSocket streams that cannot reach the request's user context.
+const SpanContextLocalsKey = "trace_span_context"
+
+// InjectTraceContext sets traceparent and tracestate on header from the span
+// in ctx. It leaves header untouched when ctx carries no valid span context.
+func InjectTraceContext(ctx context.Context, header http.Header) {
+	TraceContext.Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// TraceParent returns the traceparent value for the span in ctx, or "" when
+// ctx carries no valid span context.
+func TraceParent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	TraceContext.Inject(ctx, carrier)
+	return carrier.Get("traceparent")
+}
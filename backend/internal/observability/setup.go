@@ -26,6 +26,7 @@ type Provider struct {
 	meterProvider  *metric.MeterProvider
 	promExporter   *prometheus.Exporter
 	promHandler    http.Handler
+	registry       *promreg.Registry
 	shutdownFuncs  []func(context.Context) error
 
 	httpRequestCounter *promreg.CounterVec
@@ -78,6 +79,7 @@ func Setup(ctx context.Context, cfg config.ObservabilityConfig) (*Provider, erro
 			sdktrace.WithResource(res),
 		)
 		otel.SetTracerProvider(tp)
+		otel.SetTextMapPropagator(TraceContext)
 		provider.tracerProvider = tp
 		provider.shutdownFuncs = append(provider.shutdownFuncs, tp.Shutdown)
 	}
@@ -96,6 +98,7 @@ func Setup(ctx context.Context, cfg config.ObservabilityConfig) (*Provider, erro
 		provider.meterProvider = mp
 		provider.promExporter = promExporter
 		provider.promHandler = promhttp.HandlerFor(registry, promhttp.HandlerOpts{EnableOpenMetrics: true})
+		provider.registry = registry
 		provider.shutdownFuncs = append(provider.shutdownFuncs, mp.Shutdown)
 
 		httpRequests := promreg.NewCounterVec(
@@ -161,6 +164,15 @@ func (p *Provider) PrometheusHandler() http.Handler {
 	return p.promHandler
 }
 
+// Registerer returns the registry behind /metrics, or nil when metrics are
+// disabled. Callers use it to expose their own collectors.
+func (p *Provider) Registerer() promreg.Registerer {
+	if p == nil || p.registry == nil {
+		return nil
+	}
+	return p.registry
+}
+
 func (p *Provider) Shutdown(ctx context.Context) error {
 	if p == nil {
 		return nil
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/ncecere/open_model_gateway/backend/internal/adapters/anthropic"
 	"github.com/ncecere/open_model_gateway/backend/internal/config"
@@ -34,7 +35,12 @@ func buildAnthropicRoute(ctx context.Context, cfg *config.Config, entry config.M
 			apiKey = strings.TrimSpace(cfg.Providers.AnthropicKey)
 		}
 	}
-	if apiKey == "" {
+	var pooled []string
+	if override != nil {
+		pooled = override.APIKeys
+	}
+	keys := NewKeyPool(append([]string{apiKey}, pooled...)...)
+	if keys == nil {
 		return Route{}, fmt.Errorf("anthropic provider requires api key")
 	}
 
@@ -53,11 +59,14 @@ func buildAnthropicRoute(ctx context.Context, cfg *config.Config, entry config.M
 
 	defaultMax := entry.MaxOutputTokens
 	opts := anthropic.Options{
-		APIKey:           apiKey,
+		APIKey:           keys.Primary(),
 		BaseURL:          baseURL,
 		Version:          version,
 		DefaultMaxTokens: defaultMax,
 	}
+	if keys.Size() > 1 {
+		opts.HTTPClient = keys.Client("x-api-key", "", 60*time.Second)
+	}
 
 	adapter, err := anthropic.New(opts)
 	if err != nil {
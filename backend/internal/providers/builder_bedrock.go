@@ -14,7 +14,7 @@ import (
 func init() {
 	RegisterDefinition(Definition{
 		Name:         "bedrock",
-		Description:  "AWS Bedrock (Anthropic Claude, Titan embeddings/images)",
+		Description:  "AWS Bedrock (Anthropic Claude, Amazon Nova, Titan embeddings/images)",
 		Capabilities: []string{"chat", "chat_stream", "embeddings", "images"},
 		Builder:      buildBedrockRoute,
 	})
@@ -46,8 +46,13 @@ func buildBedrockRoute(ctx context.Context, cfg *config.Config, entry config.Mod
 	if override != nil && strings.TrimSpace(override.ChatFormat) != "" {
 		chatFormat = strings.TrimSpace(override.ChatFormat)
 	}
-	if chatFormat == "" && strings.Contains(entry.ProviderModel, ".anthropic.") {
-		chatFormat = bedrock.ChatFormatAnthropicMessages
+	if chatFormat == "" {
+		switch {
+		case strings.Contains(entry.ProviderModel, ".anthropic."):
+			chatFormat = bedrock.ChatFormatAnthropicMessages
+		case strings.Contains(entry.ProviderModel, "amazon.nova-"):
+			chatFormat = bedrock.ChatFormatNovaMessages
+		}
 	}
 	if chatFormat != "" {
 		metadata["bedrock_chat_format"] = chatFormat
@@ -57,8 +62,13 @@ func buildBedrockRoute(ctx context.Context, cfg *config.Config, entry config.Mod
 	if override != nil && strings.TrimSpace(override.EmbeddingFormat) != "" {
 		embeddingFormat = strings.TrimSpace(override.EmbeddingFormat)
 	}
-	if embeddingFormat == "" && strings.Contains(entry.ProviderModel, "titan-embed") {
-		embeddingFormat = bedrock.EmbeddingFormatTitanText
+	if embeddingFormat == "" {
+		switch {
+		case strings.Contains(entry.ProviderModel, "titan-embed-text-v2"):
+			embeddingFormat = bedrock.EmbeddingFormatTitanV2
+		case strings.Contains(entry.ProviderModel, "titan-embed"):
+			embeddingFormat = bedrock.EmbeddingFormatTitanText
+		}
 	}
 	if embeddingFormat != "" {
 		metadata["bedrock_embedding_format"] = embeddingFormat
@@ -102,6 +112,9 @@ func buildBedrockRoute(ctx context.Context, cfg *config.Config, entry config.Mod
 	}
 	if imageTask == "" && supportsModality(entry.Modalities, "image") {
 		imageTask = "TEXT_IMAGE"
+		if strings.Contains(entry.ProviderModel, "titan-image-generator-v2") {
+			imageTask = bedrock.ImageTaskTypeTitanV2
+		}
 	}
 	if imageTask != "" {
 		metadata["bedrock_image_task_type"] = imageTask
@@ -39,7 +39,12 @@ func buildOpenAIRoute(ctx context.Context, cfg *config.Config, entry config.Mode
 			apiKey = strings.TrimSpace(cfg.Providers.OpenAIKey)
 		}
 	}
-	if apiKey == "" {
+	var pooled []string
+	if override != nil {
+		pooled = override.APIKeys
+	}
+	keys := NewKeyPool(append([]string{apiKey}, pooled...)...)
+	if keys == nil {
 		return Route{}, fmt.Errorf("openai provider requires api key (providers.openai_key or catalog entry api_key)")
 	}
 
@@ -53,10 +58,13 @@ func buildOpenAIRoute(ctx context.Context, cfg *config.Config, entry config.Mode
 		}
 	}
 	opts := native.Options{
-		APIKey:       apiKey,
+		APIKey:       keys.Primary(),
 		BaseURL:      strings.TrimSpace(entry.Endpoint),
 		Organization: strings.TrimSpace(md["openai_organization"]),
 	}
+	if keys.Size() > 1 {
+		opts.HTTPClient = keys.Client("Authorization", "Bearer ", 0)
+	}
 	adapter, err := native.New(opts)
 	if err != nil {
 		return Route{}, err
@@ -114,14 +122,22 @@ func buildOpenAICompatibleRoute(ctx context.Context, cfg *config.Config, entry c
 			apiKey = strings.TrimSpace(cfg.Providers.OpenAIKey)
 		}
 	}
-	if apiKey == "" {
+	var pooled []string
+	if override != nil {
+		pooled = override.APIKeys
+	}
+	keys := NewKeyPool(append([]string{apiKey}, pooled...)...)
+	if keys == nil {
 		return Route{}, fmt.Errorf("openai-compatible provider requires api key")
 	}
 	opts := native.Options{
-		APIKey:       apiKey,
+		APIKey:       keys.Primary(),
 		BaseURL:      baseURL,
 		Organization: strings.TrimSpace(md["openai_organization"]),
 	}
+	if keys.Size() > 1 {
+		opts.HTTPClient = keys.Client("Authorization", "Bearer ", 0)
+	}
 	adapter, err := native.New(opts)
 	if err != nil {
 		return Route{}, err
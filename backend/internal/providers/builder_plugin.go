@@ -0,0 +1,62 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/adapters/plugin"
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+)
+
+// PluginProviderPrefix prefixes the provider slug of plugin adapters; a
+// plugin configured as providers.plugins.acme is referenced as "plugin:acme".
+const PluginProviderPrefix = "plugin:"
+
+// pluginLoader opens Go plugins; tests swap it out.
+var pluginLoader = plugin.Load
+
+func buildPluginRoute(name string, pc config.PluginConfig) Builder {
+	return func(ctx context.Context, cfg *config.Config, entry config.ModelCatalogEntry) (Route, error) {
+		var adapter any
+		switch strings.ToLower(strings.TrimSpace(pc.Type)) {
+		case "rpc":
+			rpc, err := plugin.New(plugin.Options{Name: name, Endpoint: pc.Endpoint})
+			if err != nil {
+				return Route{}, err
+			}
+			adapter = rpc
+		case "go_plugin":
+			loaded, err := pluginLoader(strings.TrimSpace(pc.Path))
+			if err != nil {
+				return Route{}, err
+			}
+			adapter = loaded
+		default:
+			return Route{}, fmt.Errorf("plugin %q: unsupported type %q", name, pc.Type)
+		}
+
+		weight := entry.Weight
+		if weight == 0 {
+			weight = 100
+		}
+		md := cloneMetadata(entry.Metadata)
+		md["plugin"] = name
+		route := Route{
+			Alias:    entry.Alias,
+			Provider: entry.Provider,
+			Model:    entry.ProviderModel,
+			Weight:   weight,
+			Metadata: md,
+		}
+		route.Chat, _ = adapter.(ChatCompletions)
+		route.ChatStream, _ = adapter.(ChatStreaming)
+		route.Embedding, _ = adapter.(EmbeddingsProvider)
+		route.Image, _ = adapter.(ImagesProvider)
+		route.Models, _ = adapter.(ModelLister)
+		if route.Chat == nil && route.Embedding == nil && route.Image == nil {
+			return Route{}, fmt.Errorf("plugin %q implements no chat, embeddings, or images interface", name)
+		}
+		return route, nil
+	}
+}
@@ -0,0 +1,158 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/adapters/plugin"
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+)
+
+func TestFactoryBuildsRPCPluginRoute(t *testing.T) {
+	var ops []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var env struct {
+			Plugin    string          `json:"plugin"`
+			Operation string          `json:"operation"`
+			Request   json.RawMessage `json:"request"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&env); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if env.Plugin != "acme" {
+			http.Error(w, "unknown plugin "+env.Plugin, http.StatusNotFound)
+			return
+		}
+		ops = append(ops, env.Operation)
+		switch env.Operation {
+		case plugin.OperationChat:
+			var req models.ChatRequest
+			_ = json.Unmarshal(env.Request, &req)
+			_ = json.NewEncoder(w).Encode(models.ChatResponse{
+				ID:    "chat-1",
+				Model: req.Model,
+				Choices: []models.ChatChoice{{
+					Message:      models.ChatMessage{Role: "assistant", Content: "echo: " + req.Messages[0].Content},
+					FinishReason: "stop",
+				}},
+				Usage: models.Usage{PromptTokens: 3, CompletionTokens: 2, TotalTokens: 5},
+			})
+		case plugin.OperationEmbeddings:
+			_ = json.NewEncoder(w).Encode(models.EmbeddingsResponse{
+				Model:      "acme-embed",
+				Embeddings: []models.Embedding{{Index: 0, Vector: []float32{0.5, 0.25}}},
+			})
+		case plugin.OperationImageGeneration:
+			_ = json.NewEncoder(w).Encode(models.ImageResponse{Data: []models.ImageData{{URL: "https://img.example/1.png"}}})
+		default:
+			http.Error(w, "unsupported operation", http.StatusNotImplemented)
+		}
+	}))
+	defer srv.Close()
+
+	cfg := &config.Config{
+		Providers: config.ProviderConfig{Plugins: map[string]config.PluginConfig{
+			"acme": {Type: "rpc", Endpoint: srv.URL},
+		}},
+		ModelCatalog: []config.ModelCatalogEntry{{
+			Alias:         "acme-chat",
+			Provider:      "plugin:acme",
+			ProviderModel: "acme-large",
+			Deployment:    "acme",
+		}},
+	}
+	routes, err := NewFactory(cfg).Build(context.Background())
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	if len(routes["acme-chat"]) != 1 {
+		t.Fatalf("expected one acme-chat route, got %v", routes)
+	}
+	route := routes["acme-chat"][0]
+	if route.Chat == nil || route.Embedding == nil || route.Image == nil {
+		t.Fatalf("rpc plugin should serve chat, embeddings, and images: %+v", route)
+	}
+	if route.ChatStream != nil {
+		t.Fatal("rpc plugins do not stream")
+	}
+	ctx := context.Background()
+
+	chat, err := route.Chat.Chat(ctx, models.ChatRequest{
+		Model:    "acme-large",
+		Messages: []models.ChatMessage{{Role: "user", Content: "hi"}},
+	})
+	if err != nil {
+		t.Fatalf("chat: %v", err)
+	}
+	if chat.Model != "acme-large" || chat.Choices[0].Message.Content != "echo: hi" || chat.Usage.TotalTokens != 5 {
+		t.Fatalf("unexpected chat response: %+v", chat)
+	}
+
+	emb, err := route.Embedding.Embed(ctx, models.EmbeddingsRequest{Model: "acme-large", Input: []string{"x"}})
+	if err != nil {
+		t.Fatalf("embed: %v", err)
+	}
+	if len(emb.Embeddings) != 1 || emb.Embeddings[0].Vector[1] != 0.25 {
+		t.Fatalf("unexpected embeddings: %+v", emb)
+	}
+
+	img, err := route.Image.Generate(ctx, models.ImageRequest{Model: "acme-large", Prompt: "a cat"})
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if len(img.Data) != 1 || img.Data[0].URL != "https://img.example/1.png" {
+		t.Fatalf("unexpected image response: %+v", img)
+	}
+
+	_, err = route.Image.Variation(ctx, models.ImageVariationRequest{Model: "acme-large"})
+	var coded interface{ HTTPStatusCode() int }
+	if !errors.As(err, &coded) || coded.HTTPStatusCode() != http.StatusNotImplemented {
+		t.Fatalf("expected plugin status to surface, got %v", err)
+	}
+
+	want := []string{plugin.OperationChat, plugin.OperationEmbeddings, plugin.OperationImageGeneration, plugin.OperationImageVariation}
+	if len(ops) != len(want) {
+		t.Fatalf("plugin saw operations %v, want %v", ops, want)
+	}
+}
+
+type chatOnlyPlugin struct{}
+
+func (chatOnlyPlugin) Chat(context.Context, models.ChatRequest) (models.ChatResponse, error) {
+	return models.ChatResponse{ID: "local"}, nil
+}
+
+func TestFactoryBuildsGoPluginRoute(t *testing.T) {
+	orig := pluginLoader
+	t.Cleanup(func() { pluginLoader = orig })
+	pluginLoader = func(path string) (any, error) {
+		if path != "/opt/plugins/local.so" {
+			t.Fatalf("unexpected plugin path %q", path)
+		}
+		return chatOnlyPlugin{}, nil
+	}
+
+	cfg := &config.Config{
+		Providers: config.ProviderConfig{Plugins: map[string]config.PluginConfig{
+			"local": {Type: "go_plugin", Path: "/opt/plugins/local.so"},
+		}},
+		ModelCatalog: []config.ModelCatalogEntry{{Alias: "local", Provider: "plugin:local", ProviderModel: "m", Deployment: "d"}},
+	}
+	routes, err := NewFactory(cfg).Build(context.Background())
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	route := routes["local"][0]
+	if route.Chat == nil || route.Embedding != nil || route.Image != nil {
+		t.Fatalf("go plugin route should only serve chat: %+v", route)
+	}
+	if route.Metadata["plugin"] != "local" {
+		t.Fatalf("expected plugin metadata, got %v", route.Metadata)
+	}
+}
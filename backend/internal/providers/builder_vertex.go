@@ -63,52 +63,17 @@ func buildVertexRoute(ctx context.Context, cfg *config.Config, entry config.Mode
 		location = "us-central1"
 	}
 
-	credSource := pickFirst(
-		func() string {
-			if override != nil {
-				return override.CredentialsJSON
-			}
-			return ""
-		}(),
-		md["gcp_credentials_json"],
-		cfg.Providers.GCPJSONCredentials,
-	)
-	if credSource == "" {
-		return Route{}, fmt.Errorf("vertex provider requires gcp credentials json")
+	var keys *KeyPool
+	if override != nil {
+		keys = NewKeyPool(override.APIKeys...)
 	}
-	credSource = strings.TrimSpace(credSource)
-
-	format := pickFirst(
-		func() string {
-			if override != nil {
-				return override.CredentialsFormat
-			}
-			return ""
-		}(),
-		md["gcp_credentials_format"],
-	)
-	credBytes := []byte(credSource)
-	switch strings.ToLower(format) {
-	case "base64":
-		decoded, err := base64.StdEncoding.DecodeString(credSource)
+	var credBytes []byte
+	if keys == nil {
+		var err error
+		credBytes, err = vertexCredentials(cfg, override, md)
 		if err != nil {
-			return Route{}, fmt.Errorf("vertex credentials base64 decode: %w", err)
+			return Route{}, err
 		}
-		if !json.Valid(decoded) {
-			return Route{}, fmt.Errorf("vertex credentials base64 decode produced invalid JSON")
-		}
-		credBytes = decoded
-	case "json", "":
-		if !json.Valid(credBytes) {
-			if decoded, err := base64.StdEncoding.DecodeString(credSource); err == nil && json.Valid(decoded) {
-				credBytes = decoded
-				format = "base64"
-			} else {
-				return Route{}, fmt.Errorf("vertex credentials json invalid or truncated")
-			}
-		}
-	default:
-		return Route{}, fmt.Errorf("vertex credentials format %q not supported", format)
 	}
 
 	opts := vertex.Options{
@@ -125,6 +90,9 @@ func buildVertexRoute(ctx context.Context, cfg *config.Config, entry config.Mode
 		CredentialsJSON: credBytes,
 		Metadata:        md,
 	}
+	if keys != nil {
+		opts.HTTPClient = keys.Client("x-goog-api-key", "", 0)
+	}
 
 	md["gcp_project_id"] = projectID
 	md["vertex_location"] = location
@@ -165,3 +133,56 @@ func buildVertexRoute(ctx context.Context, cfg *config.Config, entry config.Mode
 
 	return route, nil
 }
+
+// vertexCredentials resolves the service-account JSON for an entry, accepting
+// raw or base64-encoded input.
+func vertexCredentials(cfg *config.Config, override *config.VertexProviderConfig, md map[string]string) ([]byte, error) {
+	credSource := pickFirst(
+		func() string {
+			if override != nil {
+				return override.CredentialsJSON
+			}
+			return ""
+		}(),
+		md["gcp_credentials_json"],
+		cfg.Providers.GCPJSONCredentials,
+	)
+	if credSource == "" {
+		return nil, fmt.Errorf("vertex provider requires gcp credentials json")
+	}
+	credSource = strings.TrimSpace(credSource)
+
+	format := pickFirst(
+		func() string {
+			if override != nil {
+				return override.CredentialsFormat
+			}
+			return ""
+		}(),
+		md["gcp_credentials_format"],
+	)
+	credBytes := []byte(credSource)
+	switch strings.ToLower(format) {
+	case "base64":
+		decoded, err := base64.StdEncoding.DecodeString(credSource)
+		if err != nil {
+			return nil, fmt.Errorf("vertex credentials base64 decode: %w", err)
+		}
+		if !json.Valid(decoded) {
+			return nil, fmt.Errorf("vertex credentials base64 decode produced invalid JSON")
+		}
+		credBytes = decoded
+	case "json", "":
+		if !json.Valid(credBytes) {
+			if decoded, err := base64.StdEncoding.DecodeString(credSource); err == nil && json.Valid(decoded) {
+				credBytes = decoded
+				format = "base64"
+			} else {
+				return nil, fmt.Errorf("vertex credentials json invalid or truncated")
+			}
+		}
+	default:
+		return nil, fmt.Errorf("vertex credentials format %q not supported", format)
+	}
+	return credBytes, nil
+}
@@ -0,0 +1,255 @@
+package providers
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+)
+
+// ErrCredentialUnsupported reports a provider whose builder does not read a
+// catalog entry's api_key, so its credential cannot be rotated.
+var ErrCredentialUnsupported = errors.New("provider does not support credential rotation")
+
+// credentialProviders lists the providers whose builders take the catalog
+// entry's api_key in preference to configured keys.
+var credentialProviders = map[string]struct{}{
+	"openai":            {},
+	"openai-compatible": {},
+	"anthropic":         {},
+	"azure":             {},
+}
+
+// SupportsCredentialRotation reports whether provider accepts a rotated API
+// key.
+func SupportsCredentialRotation(provider string) bool {
+	_, ok := credentialProviders[provider]
+	return ok
+}
+
+// CredentialScope names the provider instance a rotated key belongs to: the
+// provider plus the catalog endpoint it serves. An empty Endpoint is the
+// provider's default endpoint, used by entries that set none.
+type CredentialScope struct {
+	Provider string
+	Endpoint string
+}
+
+// NewCredentialScope trims provider and normalizes endpoint.
+func NewCredentialScope(provider, endpoint string) CredentialScope {
+	return CredentialScope{Provider: strings.TrimSpace(provider), Endpoint: NormalizeEndpoint(endpoint)}
+}
+
+// NormalizeEndpoint trims whitespace and trailing slashes so equivalent
+// endpoint spellings name the same credential scope.
+func NormalizeEndpoint(endpoint string) string {
+	return strings.TrimRight(strings.TrimSpace(endpoint), "/")
+}
+
+func entryScope(entry config.ModelCatalogEntry) CredentialScope {
+	return NewCredentialScope(entry.Provider, entryEndpoint(entry))
+}
+
+// entryEndpoint resolves the endpoint an entry's builder calls, including
+// provider override blocks, but not provider-wide config defaults.
+func entryEndpoint(entry config.ModelCatalogEntry) string {
+	endpoint := strings.TrimSpace(entry.Endpoint)
+	overrides := entry.ProviderOverrides
+	switch entry.Provider {
+	case "openai":
+		if overrides.OpenAI != nil && strings.TrimSpace(overrides.OpenAI.BaseURL) != "" {
+			endpoint = overrides.OpenAI.BaseURL
+		}
+	case "openai-compatible":
+		if overrides.OpenAICompatible != nil && strings.TrimSpace(overrides.OpenAICompatible.BaseURL) != "" {
+			endpoint = overrides.OpenAICompatible.BaseURL
+		}
+	case "anthropic":
+		if overrides.Anthropic != nil && strings.TrimSpace(overrides.Anthropic.BaseURL) != "" {
+			endpoint = overrides.Anthropic.BaseURL
+		}
+		if endpoint == "" {
+			endpoint = entry.Metadata["anthropic_base_url"]
+		}
+	case "azure":
+		if endpoint == "" && overrides.Azure != nil {
+			endpoint = overrides.Azure.Endpoint
+		}
+	}
+	return NormalizeEndpoint(endpoint)
+}
+
+// CredentialHash fingerprints a provider API key for audit logs.
+func CredentialHash(key string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(key)))
+	return hex.EncodeToString(sum[:])
+}
+
+// CredentialCipher encrypts rotated provider keys at rest with AES-GCM. The
+// scope is bound as additional data, so a ciphertext cannot be moved to
+// another provider instance.
+type CredentialCipher struct {
+	aead cipher.AEAD
+}
+
+// NewCredentialCipher parses a base64 AES key of 16, 24, or 32 bytes. It
+// returns nil when raw is empty.
+func NewCredentialCipher(raw string) (*CredentialCipher, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(trimmed)
+	if err != nil {
+		return nil, fmt.Errorf("providers.credential_encryption_key must be base64: %w", err)
+	}
+	switch len(key) {
+	case 16, 24, 32:
+	default:
+		return nil, fmt.Errorf("providers.credential_encryption_key must be 16/24/32 bytes after decoding")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &CredentialCipher{aead: aead}, nil
+}
+
+// Seal encrypts key for scope and returns base64(nonce || ciphertext).
+func (c *CredentialCipher) Seal(scope CredentialScope, key string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := c.aead.Seal(nonce, nonce, []byte(key), scope.additionalData())
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Open decrypts a value produced by Seal for the same scope.
+func (c *CredentialCipher) Open(scope CredentialScope, sealed string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil {
+		return "", fmt.Errorf("decode credential: %w", err)
+	}
+	nonceSize := c.aead.NonceSize()
+	if len(data) < nonceSize {
+		return "", errors.New("encrypted credential too short")
+	}
+	plain, err := c.aead.Open(nil, data[:nonceSize], data[nonceSize:], scope.additionalData())
+	if err != nil {
+		return "", fmt.Errorf("decrypt credential: %w", err)
+	}
+	return string(plain), nil
+}
+
+func (s CredentialScope) additionalData() []byte {
+	return []byte(s.Provider + "\x00" + s.Endpoint)
+}
+
+// RotateCredential makes key the API key of every catalog entry in scope in
+// later builds, taking precedence over entry and configured keys. It does
+// not touch routes already built; callers verify and install the routes from
+// BuildProvider first.
+func (f *Factory) RotateCredential(scope CredentialScope, key string) error {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return fmt.Errorf("api key is required")
+	}
+	if _, ok := f.builders[scope.Provider]; !ok {
+		return fmt.Errorf("provider %q unsupported", scope.Provider)
+	}
+	if !SupportsCredentialRotation(scope.Provider) {
+		return ErrCredentialUnsupported
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.credentials == nil {
+		f.credentials = make(map[CredentialScope]string)
+	}
+	f.credentials[scope] = key
+	return nil
+}
+
+// SetCredentials replaces the rotated credentials. It is used to restore the
+// keys persisted in provider_credentials.
+func (f *Factory) SetCredentials(credentials map[CredentialScope]string) {
+	next := make(map[CredentialScope]string, len(credentials))
+	for scope, key := range credentials {
+		if key = strings.TrimSpace(key); key != "" && SupportsCredentialRotation(scope.Provider) {
+			next[scope] = key
+		}
+	}
+	f.mu.Lock()
+	f.credentials = next
+	f.mu.Unlock()
+}
+
+// BuildProvider builds the enabled routes in scope with key as their API
+// key, leaving the factory's credentials unchanged.
+func (f *Factory) BuildProvider(ctx context.Context, scope CredentialScope, key string) (map[string][]Route, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil, fmt.Errorf("api key is required")
+	}
+	if !SupportsCredentialRotation(scope.Provider) {
+		return nil, ErrCredentialUnsupported
+	}
+	routes := make(map[string][]Route)
+	for _, entry := range f.cfg.ModelCatalog {
+		if !entry.IsEnabled() || entryScope(entry) != scope {
+			continue
+		}
+		entry.APIKey = key
+		route, err := f.buildEntry(ctx, entry)
+		if err != nil {
+			return nil, err
+		}
+		routes[entry.Alias] = append(routes[entry.Alias], route)
+	}
+	return routes, nil
+}
+
+func (f *Factory) withCredential(entry config.ModelCatalogEntry) config.ModelCatalogEntry {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if key := f.credentials[entryScope(entry)]; key != "" {
+		entry.APIKey = key
+	}
+	return entry
+}
+
+// TestInference sends the cheapest real request the route supports: a
+// one-token chat completion, a single-input embedding, or else the health
+// probe.
+func (r Route) TestInference(ctx context.Context) error {
+	switch {
+	case r.Chat != nil:
+		maxTokens := int32(1)
+		_, err := r.Chat.Chat(ctx, models.ChatRequest{
+			Model:     r.ResolveDeployment(),
+			Messages:  []models.ChatMessage{{Role: "user", Content: "ping"}},
+			MaxTokens: &maxTokens,
+		})
+		return err
+	case r.Embedding != nil:
+		_, err := r.Embedding.Embed(ctx, models.EmbeddingsRequest{Model: r.ResolveDeployment(), Input: []string{"ping"}})
+		return err
+	case r.Health != nil:
+		return r.Health(ctx)
+	default:
+		return fmt.Errorf("provider %s has no test request", r.Provider)
+	}
+}
@@ -0,0 +1,71 @@
+package providers
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+)
+
+func TestCredentialCipherRoundTrip(t *testing.T) {
+	if c, err := NewCredentialCipher(" "); c != nil || err != nil {
+		t.Fatalf("empty key should disable the cipher, got %v %v", c, err)
+	}
+	if _, err := NewCredentialCipher(base64.StdEncoding.EncodeToString([]byte("short"))); err == nil {
+		t.Fatal("expected a short key to be rejected")
+	}
+
+	cipher, err := NewCredentialCipher(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32))))
+	if err != nil {
+		t.Fatalf("new cipher: %v", err)
+	}
+	scope := NewCredentialScope("openai", "https://proxy.example.com/v1/")
+	sealed, err := cipher.Seal(scope, "sk-secret")
+	if err != nil {
+		t.Fatalf("seal: %v", err)
+	}
+	if strings.Contains(sealed, "sk-secret") {
+		t.Fatal("sealed credential must not contain the key")
+	}
+	got, err := cipher.Open(NewCredentialScope("openai", "https://proxy.example.com/v1"), sealed)
+	if err != nil || got != "sk-secret" {
+		t.Fatalf("open = %q, %v", got, err)
+	}
+	if _, err := cipher.Open(NewCredentialScope("openai", ""), sealed); err == nil {
+		t.Fatal("expected a ciphertext sealed for another endpoint to be rejected")
+	}
+}
+
+func TestEntryEndpointFollowsOverrides(t *testing.T) {
+	cases := []struct {
+		name  string
+		entry config.ModelCatalogEntry
+		want  string
+	}{
+		{"default", config.ModelCatalogEntry{Provider: "openai"}, ""},
+		{"entry", config.ModelCatalogEntry{Provider: "openai", Endpoint: " https://a.example.com/v1/ "}, "https://a.example.com/v1"},
+		{"openai override", config.ModelCatalogEntry{
+			Provider:          "openai",
+			Endpoint:          "https://a.example.com",
+			ProviderOverrides: config.ProviderOverrides{OpenAI: &config.OpenAIProviderConfig{BaseURL: "https://b.example.com/"}},
+		}, "https://b.example.com"},
+		{"compatible override", config.ModelCatalogEntry{
+			Provider:          "openai-compatible",
+			ProviderOverrides: config.ProviderOverrides{OpenAICompatible: &config.OpenAICompatibleProviderConfig{BaseURL: "http://vllm:8000/v1"}},
+		}, "http://vllm:8000/v1"},
+		{"anthropic metadata", config.ModelCatalogEntry{
+			Provider: "anthropic",
+			Metadata: map[string]string{"anthropic_base_url": "https://claude.example.com"},
+		}, "https://claude.example.com"},
+		{"azure override", config.ModelCatalogEntry{
+			Provider:          "azure",
+			ProviderOverrides: config.ProviderOverrides{Azure: &config.AzureProviderConfig{Endpoint: "https://res.openai.azure.com"}},
+		}, "https://res.openai.azure.com"},
+	}
+	for _, tc := range cases {
+		if got := entryEndpoint(tc.entry); got != tc.want {
+			t.Errorf("%s: entryEndpoint = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
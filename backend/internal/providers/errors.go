@@ -0,0 +1,115 @@
+package providers
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openai/openai-go/v3"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+)
+
+// defaultErrorMappings canonicalizes provider statuses that have no standard
+// meaning. Catalog error_mapping entries take precedence.
+var defaultErrorMappings = map[string]map[string]int{
+	// Anthropic signals overload with the non-standard 529.
+	"anthropic": {"529": http.StatusServiceUnavailable},
+}
+
+// MapProviderError returns the status and message the gateway reports for a
+// failed provider call. Each overrides key is matched, case-insensitively,
+// against the provider's error code or type (e.g. the AWS exception name
+// "ThrottlingException" or OpenAI's "rate_limit_exceeded"), its HTTP status
+// ("529"), and finally as a substring of the error message; longer keys are
+// tried first. Unmapped errors become 502 Bad Gateway, except content the
+// adapter could not forward (models.ErrUnsupportedContent), which is 400.
+func MapProviderError(providerSlug string, raw error, overrides map[string]int) (int, string) {
+	if raw == nil {
+		return http.StatusOK, ""
+	}
+	msg := raw.Error()
+	if errors.Is(raw, models.ErrUnsupportedContent) {
+		return http.StatusBadRequest, msg
+	}
+	if status, ok := MappedErrorStatus(providerSlug, raw, overrides); ok {
+		return status, msg
+	}
+	return http.StatusBadGateway, msg
+}
+
+// MappedErrorStatus returns the status an error_mapping entry (or the
+// provider's default mapping) assigns to raw, and false when none matches.
+func MappedErrorStatus(providerSlug string, raw error, overrides map[string]int) (int, bool) {
+	if status, ok := matchErrorMapping(raw, overrides); ok {
+		return status, true
+	}
+	return matchErrorMapping(raw, defaultErrorMappings[strings.ToLower(providerSlug)])
+}
+
+func matchErrorMapping(raw error, mapping map[string]int) (int, bool) {
+	if len(mapping) == 0 {
+		return 0, false
+	}
+	codes := providerErrorCodes(raw)
+	message := strings.ToLower(raw.Error())
+
+	keys := make([]string, 0, len(mapping))
+	for key := range mapping {
+		if strings.TrimSpace(key) != "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+
+	for _, key := range keys {
+		want := strings.ToLower(strings.TrimSpace(key))
+		for _, code := range codes {
+			if code == want {
+				return mapping[key], true
+			}
+		}
+	}
+	for _, key := range keys {
+		if strings.Contains(message, strings.ToLower(strings.TrimSpace(key))) {
+			return mapping[key], true
+		}
+	}
+	return 0, false
+}
+
+// providerErrorCodes collects the lower-cased error code, type, and HTTP
+// status carried by err.
+func providerErrorCodes(err error) []string {
+	var codes []string
+	add := func(code string) {
+		if code = strings.ToLower(strings.TrimSpace(code)); code != "" {
+			codes = append(codes, code)
+		}
+	}
+	var sdkErr *openai.Error
+	if errors.As(err, &sdkErr) {
+		add(sdkErr.Code)
+		add(sdkErr.Type)
+		if sdkErr.StatusCode > 0 {
+			add(strconv.Itoa(sdkErr.StatusCode))
+		}
+	}
+	// smithy.APIError, returned by the AWS SDK.
+	var apiErr interface{ ErrorCode() string }
+	if errors.As(err, &apiErr) {
+		add(apiErr.ErrorCode())
+	}
+	var coded interface{ HTTPStatusCode() int }
+	if errors.As(err, &coded) && coded.HTTPStatusCode() > 0 {
+		add(strconv.Itoa(coded.HTTPStatusCode()))
+	}
+	return codes
+}
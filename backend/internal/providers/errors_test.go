@@ -0,0 +1,78 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	brtypes "github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+	"github.com/openai/openai-go/v3"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+)
+
+func TestMapProviderErrorBedrockThrottling(t *testing.T) {
+	raw := fmt.Errorf("operation error Bedrock Runtime: Converse, %w", &brtypes.ThrottlingException{Message: aws.String("Too many requests")})
+
+	if status, _ := MapProviderError("bedrock", raw, nil); status != http.StatusBadGateway {
+		t.Fatalf("unmapped bedrock throttling should stay 502, got %d", status)
+	}
+	status, msg := MapProviderError("bedrock", raw, map[string]int{"ThrottlingException": http.StatusTooManyRequests})
+	if status != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", status)
+	}
+	if msg != raw.Error() {
+		t.Fatalf("message should be the provider error, got %q", msg)
+	}
+}
+
+func TestMapProviderErrorOpenAI(t *testing.T) {
+	reqURL, _ := url.Parse("https://api.openai.com/v1/chat/completions")
+	raw := &openai.Error{
+		Code:       "insufficient_quota",
+		Type:       "insufficient_quota",
+		StatusCode: http.StatusTooManyRequests,
+		Request:    &http.Request{Method: http.MethodPost, URL: reqURL},
+		Response:   &http.Response{StatusCode: http.StatusTooManyRequests},
+	}
+
+	if status, _ := MapProviderError("openai", raw, map[string]int{"insufficient_quota": http.StatusPaymentRequired}); status != http.StatusPaymentRequired {
+		t.Fatalf("expected the error code mapping to win, got %d", status)
+	}
+	if status, _ := MapProviderError("openai", raw, map[string]int{"429": http.StatusServiceUnavailable}); status != http.StatusServiceUnavailable {
+		t.Fatalf("expected the status mapping to apply, got %d", status)
+	}
+}
+
+func TestMapProviderErrorMessageAndDefaults(t *testing.T) {
+	raw := errors.New("upstream: model is currently OVERLOADED, try later")
+	if status, _ := MapProviderError("openai-compatible", raw, map[string]int{"overloaded": http.StatusServiceUnavailable}); status != http.StatusServiceUnavailable {
+		t.Fatalf("expected message match, got %d", status)
+	}
+	if status, _ := MapProviderError("openai-compatible", raw, map[string]int{"quota": http.StatusTooManyRequests}); status != http.StatusBadGateway {
+		t.Fatalf("expected 502 without a match, got %d", status)
+	}
+
+	overloaded := statusErr(529)
+	if status, _ := MapProviderError("anthropic", overloaded, nil); status != http.StatusServiceUnavailable {
+		t.Fatalf("anthropic 529 should default to 503, got %d", status)
+	}
+	if status, _ := MapProviderError("anthropic", overloaded, map[string]int{"529": http.StatusTooManyRequests}); status != http.StatusTooManyRequests {
+		t.Fatalf("catalog mapping should override the default, got %d", status)
+	}
+}
+
+type statusErr int
+
+func (e statusErr) Error() string       { return fmt.Sprintf("status %d", int(e)) }
+func (e statusErr) HTTPStatusCode() int { return int(e) }
+
+func TestMapProviderErrorUnsupportedContent(t *testing.T) {
+	raw := fmt.Errorf("bedrock: image_url must be a base64 data URL: %w", models.ErrUnsupportedContent)
+	if status, _ := MapProviderError("bedrock", raw, map[string]int{"image_url": http.StatusTeapot}); status != http.StatusBadRequest {
+		t.Fatalf("unsupported content should be 400, got %d", status)
+	}
+}
@@ -3,8 +3,11 @@ package providers
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
 
 	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/region"
 )
 
 // Builder constructs a provider Route for a catalog entry.
@@ -14,11 +17,21 @@ type Builder func(ctx context.Context, cfg *config.Config, entry config.ModelCat
 type Factory struct {
 	cfg      *config.Config
 	builders map[string]Builder
+
+	mu          sync.RWMutex
+	credentials map[CredentialScope]string
 }
 
-// NewFactory creates a factory with the default provider registry.
+// NewFactory creates a factory with the default provider registry plus one
+// "plugin:<name>" builder per configured provider plugin.
 func NewFactory(cfg *config.Config) *Factory {
-	return &Factory{cfg: cfg, builders: cloneDefaultBuilders()}
+	f := &Factory{cfg: cfg, builders: cloneDefaultBuilders()}
+	if cfg != nil {
+		for name, pc := range cfg.Providers.Plugins {
+			f.Register(PluginProviderPrefix+name, buildPluginRoute(name, pc))
+		}
+	}
+	return f
 }
 
 // Register allows tests or callers to override provider builders.
@@ -36,15 +49,61 @@ func (f *Factory) Build(ctx context.Context) (map[string][]Route, error) {
 		if !entry.IsEnabled() {
 			continue
 		}
-		builder, ok := f.builders[entry.Provider]
-		if !ok {
-			return nil, fmt.Errorf("alias %q: provider %q unsupported", entry.Alias, entry.Provider)
-		}
-		route, err := builder(ctx, f.cfg, entry)
+		route, err := f.buildEntry(ctx, f.withCredential(entry))
 		if err != nil {
-			return nil, fmt.Errorf("alias %q: %w", entry.Alias, err)
+			return nil, err
 		}
 		routes[entry.Alias] = append(routes[entry.Alias], route)
 	}
 	return routes, nil
 }
+
+// buildEntry instantiates the adapter for a single catalog entry.
+func (f *Factory) buildEntry(ctx context.Context, entry config.ModelCatalogEntry) (Route, error) {
+	builder, ok := f.builders[entry.Provider]
+	if !ok {
+		return Route{}, fmt.Errorf("alias %q: provider %q unsupported", entry.Alias, entry.Provider)
+	}
+	route, err := builder(ctx, f.cfg, entry)
+	if err != nil {
+		return Route{}, fmt.Errorf("alias %q: %w", entry.Alias, err)
+	}
+	route.RoutingPolicy = entry.RoutingPolicy
+	route.TrafficSplit = entry.TrafficSplit
+	route.ContextWindow = entry.ContextWindow
+	route.MaxOutputTokens = entry.MaxOutputTokens
+	route.MaxDimensions = entry.MaxDimensions
+	route.MirrorAlias = entry.MirrorAlias
+	route.MirrorSampleRate = entry.MirrorSampleRate
+	route.DeprecatedAt = entry.DeprecatedAt
+	route.DeprecationMessage = entry.DeprecationMessage
+	route.SupportsVision = entry.SupportsVision
+	route.FallbackVisionAlias = entry.FallbackVisionAlias
+	route.ModelType = entry.ModelType
+	route.Modalities = entry.Modalities
+	route.SupportsTools = entry.SupportsTools
+	route.PriceInput = entry.PriceInput
+	route.PriceOutput = entry.PriceOutput
+	route.DataResidency = dataResidency(entry)
+	route.ErrorMapping = entry.ErrorMapping
+	route.Endpoint = entryEndpoint(entry)
+	return route, nil
+}
+
+// dataResidency returns the entry's explicit residency codes, falling back to
+// the countries of its provider region.
+func dataResidency(entry config.ModelCatalogEntry) []string {
+	if codes := region.NormalizeCodes(entry.DataResidency); len(codes) > 0 {
+		return codes
+	}
+	name := entry.Region
+	switch {
+	case entry.Azure != nil && strings.TrimSpace(entry.Azure.Region) != "":
+		name = entry.Azure.Region
+	case entry.Bedrock != nil && strings.TrimSpace(entry.Bedrock.Region) != "":
+		name = entry.Bedrock.Region
+	case entry.Vertex != nil && strings.TrimSpace(entry.Vertex.Location) != "":
+		name = entry.Vertex.Location
+	}
+	return region.Countries(name)
+}
@@ -0,0 +1,87 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+)
+
+func TestDataResidencyFromCatalogEntry(t *testing.T) {
+	cases := []struct {
+		name  string
+		entry config.ModelCatalogEntry
+		want  []string
+	}{
+		{name: "explicit", entry: config.ModelCatalogEntry{Region: "us-east-1", DataResidency: []string{" de ", "eu"}}, want: []string{"DE", "EU"}},
+		{name: "entry region", entry: config.ModelCatalogEntry{Region: "eu-west-1"}, want: []string{"EU", "IE"}},
+		{name: "bedrock override", entry: config.ModelCatalogEntry{Region: "eu-west-1", ProviderOverrides: config.ProviderOverrides{Bedrock: &config.BedrockProviderConfig{Region: "us-west-2"}}}, want: []string{"US"}},
+		{name: "vertex location", entry: config.ModelCatalogEntry{ProviderOverrides: config.ProviderOverrides{Vertex: &config.VertexProviderConfig{Location: "europe-west4"}}}, want: []string{"EU", "NL"}},
+		{name: "unknown", entry: config.ModelCatalogEntry{}, want: nil},
+	}
+	for _, tc := range cases {
+		if got := dataResidency(tc.entry); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: dataResidency = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestFactoryRotateCredential(t *testing.T) {
+	cfg := &config.Config{ModelCatalog: []config.ModelCatalogEntry{
+		{Alias: "gpt", Provider: "openai", ProviderModel: "gpt-4o", APIKey: "sk-entry"},
+		{Alias: "gpt-proxy", Provider: "openai", ProviderModel: "gpt-4o", Endpoint: "https://proxy.example.com/v1/", APIKey: "sk-proxy"},
+		{Alias: "claude", Provider: "anthropic", ProviderModel: "claude", APIKey: "sk-ant"},
+	}}
+	factory := NewFactory(cfg)
+	stub := func(_ context.Context, _ *config.Config, entry config.ModelCatalogEntry) (Route, error) {
+		return Route{Alias: entry.Alias, Provider: entry.Provider, Metadata: map[string]string{"key": entry.APIKey}}, nil
+	}
+	factory.Register("openai", stub)
+	factory.Register("anthropic", stub)
+
+	openai := NewCredentialScope("openai", "")
+	candidate, err := factory.BuildProvider(context.Background(), openai, " sk-new ")
+	if err != nil {
+		t.Fatalf("build provider: %v", err)
+	}
+	if len(candidate) != 1 || candidate["gpt"][0].Metadata["key"] != "sk-new" {
+		t.Fatalf("unexpected candidate routes %+v", candidate)
+	}
+	routes, _ := factory.Build(context.Background())
+	if routes["gpt"][0].Metadata["key"] != "sk-entry" {
+		t.Fatal("BuildProvider must not change the active credential")
+	}
+
+	if err := factory.RotateCredential(openai, "sk-new"); err != nil {
+		t.Fatalf("rotate: %v", err)
+	}
+	routes, _ = factory.Build(context.Background())
+	if routes["gpt"][0].Metadata["key"] != "sk-new" || routes["claude"][0].Metadata["key"] != "sk-ant" {
+		t.Fatalf("rotation should only affect openai routes: %+v", routes)
+	}
+	if routes["gpt-proxy"][0].Metadata["key"] != "sk-proxy" {
+		t.Fatalf("rotation should not affect other openai endpoints: %+v", routes["gpt-proxy"])
+	}
+
+	proxy := NewCredentialScope("openai", " https://proxy.example.com/v1 ")
+	candidate, err = factory.BuildProvider(context.Background(), proxy, "sk-proxy-2")
+	if err != nil || len(candidate) != 1 || candidate["gpt-proxy"][0].Endpoint != "https://proxy.example.com/v1" {
+		t.Fatalf("expected only the proxy route, got %+v %v", candidate, err)
+	}
+	if err := factory.RotateCredential(proxy, "sk-proxy-2"); err != nil {
+		t.Fatalf("rotate proxy: %v", err)
+	}
+	routes, _ = factory.Build(context.Background())
+	if routes["gpt-proxy"][0].Metadata["key"] != "sk-proxy-2" || routes["gpt"][0].Metadata["key"] != "sk-new" {
+		t.Fatalf("unexpected routes after proxy rotation: %+v", routes)
+	}
+
+	if err := factory.RotateCredential(NewCredentialScope("bedrock", ""), "key"); !errors.Is(err, ErrCredentialUnsupported) {
+		t.Fatalf("expected ErrCredentialUnsupported, got %v", err)
+	}
+	if err := factory.RotateCredential(openai, " "); err == nil {
+		t.Fatal("expected empty key to be rejected")
+	}
+}
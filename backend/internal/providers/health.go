@@ -0,0 +1,48 @@
+package providers
+
+import (
+	"context"
+	"time"
+)
+
+// HealthResult reports the outcome of probing a single route.
+type HealthResult struct {
+	Provider         string `json:"provider"`
+	RegionOrEndpoint string `json:"region_or_endpoint"`
+	Healthy          bool   `json:"healthy"`
+	LatencyMs        int64  `json:"latency_ms"`
+	Error            string `json:"error"`
+}
+
+// HealthCheck runs the adapter's lightweight readiness probe (a models list,
+// or STS GetCallerIdentity for Bedrock) and times it.
+func (r Route) HealthCheck(ctx context.Context) HealthResult {
+	result := HealthResult{
+		Provider:         r.Provider,
+		RegionOrEndpoint: r.RegionOrEndpoint(),
+	}
+	if r.Health == nil {
+		result.Error = "provider does not support health checks"
+		return result
+	}
+	started := time.Now()
+	err := r.Health(ctx)
+	result.LatencyMs = time.Since(started).Milliseconds()
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Healthy = true
+	return result
+}
+
+// RegionOrEndpoint describes where the route sends traffic: the cloud region
+// when the provider has one, otherwise the configured base URL or deployment.
+func (r Route) RegionOrEndpoint() string {
+	for _, key := range []string{"region", "vertex_location", "base_url", "deployment"} {
+		if value := r.Metadata[key]; value != "" {
+			return value
+		}
+	}
+	return r.Model
+}
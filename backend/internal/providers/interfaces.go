@@ -20,6 +20,9 @@ type ModelLister interface {
 
 type EmbeddingsProvider interface {
 	Embed(ctx context.Context, req models.EmbeddingsRequest) (models.EmbeddingsResponse, error)
+	// BatchSize is the most inputs a single Embed call accepts; callers split
+	// larger requests. Values below one mean no limit.
+	BatchSize() int
 }
 
 type ImagesProvider interface {
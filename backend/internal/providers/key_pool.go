@@ -0,0 +1,127 @@
+package providers
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const keyCooldown = time.Minute
+
+// KeyPool hands out provider API keys round-robin so per-key rate limits are
+// spread across every configured key. Each key carries its own breaker: a key
+// marked failed is skipped until its cooldown expires, so a revoked or
+// throttled key drops out without affecting the rest of the pool.
+type KeyPool struct {
+	keys    []string
+	counter atomic.Uint64
+
+	mu        sync.Mutex
+	openUntil map[string]time.Time
+}
+
+// NewKeyPool returns a pool over the distinct non-empty keys, or nil when
+// none remain.
+func NewKeyPool(keys ...string) *KeyPool {
+	seen := make(map[string]struct{}, len(keys))
+	unique := make([]string, 0, len(keys))
+	for _, key := range keys {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		unique = append(unique, key)
+	}
+	if len(unique) == 0 {
+		return nil
+	}
+	return &KeyPool{keys: unique, openUntil: make(map[string]time.Time)}
+}
+
+// Size reports how many distinct keys the pool rotates through.
+func (p *KeyPool) Size() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.keys)
+}
+
+// Primary returns the first configured key, used where an adapter requires a
+// static key up front.
+func (p *KeyPool) Primary() string {
+	if p == nil {
+		return ""
+	}
+	return p.keys[0]
+}
+
+// Next returns the next healthy key. When every key is cooling down it still
+// returns one so requests fail with the provider's error instead of stalling.
+func (p *KeyPool) Next() string {
+	now := time.Now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var fallback string
+	for range p.keys {
+		key := p.keys[(p.counter.Add(1)-1)%uint64(len(p.keys))]
+		until, open := p.openUntil[key]
+		if !open || !until.After(now) {
+			delete(p.openUntil, key)
+			return key
+		}
+		if fallback == "" {
+			fallback = key
+		}
+	}
+	return fallback
+}
+
+// MarkFailed takes key out of rotation for the cooldown period.
+func (p *KeyPool) MarkFailed(key string) {
+	p.mu.Lock()
+	p.openUntil[key] = time.Now().Add(keyCooldown)
+	p.mu.Unlock()
+}
+
+// Client returns an HTTP client that sets header to prefix+key from the pool
+// on every request and marks keys failed when the provider rejects them.
+func (p *KeyPool) Client(header, prefix string, timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &keyPoolTransport{
+			pool:   p,
+			base:   http.DefaultTransport,
+			header: header,
+			prefix: prefix,
+		},
+	}
+}
+
+type keyPoolTransport struct {
+	pool   *KeyPool
+	base   http.RoundTripper
+	header string
+	prefix string
+}
+
+func (t *keyPoolTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := t.pool.Next()
+	clone := req.Clone(req.Context())
+	clone.Header.Set(t.header, t.prefix+key)
+	resp, err := t.base.RoundTrip(clone)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests:
+		t.pool.MarkFailed(key)
+	}
+	return resp, nil
+}
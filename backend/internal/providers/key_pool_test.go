@@ -0,0 +1,67 @@
+package providers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestKeyPoolRoundRobinSkipsFailedKeys(t *testing.T) {
+	pool := NewKeyPool("a", " b ", "", "a", "c")
+	if pool.Size() != 3 {
+		t.Fatalf("expected 3 distinct keys, got %d", pool.Size())
+	}
+	var got []string
+	for i := 0; i < 4; i++ {
+		got = append(got, pool.Next())
+	}
+	if want := []string{"a", "b", "c", "a"}; !slices.Equal(got, want) {
+		t.Fatalf("rotation = %v, want %v", got, want)
+	}
+
+	pool.MarkFailed("b")
+	for i := 0; i < 6; i++ {
+		if key := pool.Next(); key == "b" {
+			t.Fatal("failed key handed out during cooldown")
+		}
+	}
+
+	pool.MarkFailed("a")
+	pool.MarkFailed("c")
+	if key := pool.Next(); key == "" {
+		t.Fatal("expected a fallback key when every key is cooling down")
+	}
+}
+
+func TestKeyPoolClientMarksRejectedKeys(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-api-key") == "revoked" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	pool := NewKeyPool("revoked", "good")
+	client := pool.Client("x-api-key", "", 0)
+
+	statuses := make([]int, 0, 4)
+	for i := 0; i < 4; i++ {
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+		resp.Body.Close()
+		statuses = append(statuses, resp.StatusCode)
+	}
+	if statuses[0] != http.StatusUnauthorized {
+		t.Fatalf("expected first request to use the revoked key, got %d", statuses[0])
+	}
+	for i, status := range statuses[1:] {
+		if status != http.StatusOK {
+			t.Fatalf("request %d used the revoked key after it was marked failed", i+1)
+		}
+	}
+}
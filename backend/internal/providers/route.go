@@ -2,7 +2,9 @@ package providers
 
 import (
 	"context"
+	"time"
 
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
 	"github.com/ncecere/open_model_gateway/backend/internal/models"
 )
 
@@ -23,6 +25,48 @@ type Route struct {
 	TextToSpeechStream TextToSpeechStreaming
 	Models     ModelLister
 	Health     func(ctx context.Context) error
+	// RoutingPolicy is copied from the catalog entry; see config.RoutingPolicyFastest.
+	RoutingPolicy string
+	// TrafficSplit is copied from the catalog entry; see config.TrafficSplitEntry.
+	TrafficSplit []config.TrafficSplitEntry
+	// ContextWindow and MaxOutputTokens are copied from the catalog entry; zero
+	// means unknown.
+	ContextWindow   int32
+	MaxOutputTokens int32
+	// MaxDimensions is the native embedding size from the catalog entry; zero
+	// means unknown.
+	MaxDimensions int32
+	// MirrorAlias and MirrorSampleRate are copied from the catalog entry; see
+	// config.ModelCatalogEntry.
+	MirrorAlias      string
+	MirrorSampleRate float64
+	// DeprecatedAt and DeprecationMessage are copied from the catalog entry;
+	// a non-nil DeprecatedAt makes responses carry a Warning header.
+	DeprecatedAt       *time.Time
+	DeprecationMessage string
+	// SupportsVision and FallbackVisionAlias are copied from the catalog
+	// entry; see config.ModelCatalogEntry.
+	SupportsVision      bool
+	FallbackVisionAlias string
+	// ModelType, Modalities, SupportsTools, and the per-1M-token prices are
+	// copied from the catalog entry for model listings.
+	ModelType     string
+	Modalities    []string
+	SupportsTools bool
+	PriceInput    float64
+	PriceOutput   float64
+	// ErrorMapping is copied from the catalog entry; see MapProviderError.
+	ErrorMapping map[string]int
+	// DataResidency is the set of country codes the route keeps data in,
+	// from the catalog entry or its region; empty means unknown.
+	DataResidency []string
+	// Endpoint is the catalog entry's normalized endpoint, including provider
+	// override blocks, and empty for the provider default. With Provider it
+	// names the route's credential scope.
+	Endpoint string
+	// ABVariant names the split branch that produced this route. It is set by
+	// router.Engine.SelectRoutes only when the requested alias has a split.
+	ABVariant string
 }
 
 // ResolveDeployment extracts deployment identifier from route metadata.
@@ -41,7 +85,7 @@ func (r Route) ToModel() models.Model {
 		Alias:           r.Alias,
 		Provider:        r.Provider,
 		ProviderModel:   r.Model,
-		ContextWindow:   0,
-		MaxOutputTokens: 0,
+		ContextWindow:   r.ContextWindow,
+		MaxOutputTokens: r.MaxOutputTokens,
 	}
 }
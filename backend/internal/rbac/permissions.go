@@ -0,0 +1,199 @@
+package rbac
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+// Permission names a single admin capability. The permissions table holds
+// the catalog; roles grant a subset of it.
+type Permission string
+
+const (
+	PermAPIKeysCreate      Permission = "api_keys:create"
+	PermAPIKeysRead        Permission = "api_keys:read"
+	PermAPIKeysReadAll     Permission = "api_keys:read_all"
+	PermAPIKeysRevoke      Permission = "api_keys:revoke"
+	PermAuditRead          Permission = "audit:read"
+	PermBatchesCancel      Permission = "batches:cancel"
+	PermBatchesRead        Permission = "batches:read"
+	PermBudgetsRead        Permission = "budgets:read"
+	PermBudgetsWrite       Permission = "budgets:write"
+	PermCurrencyRatesRead  Permission = "currency_rates:read"
+	PermCurrencyRatesWrite Permission = "currency_rates:write"
+	PermMembershipsRead    Permission = "memberships:read"
+	PermMembershipsWrite   Permission = "memberships:write"
+	PermModelsHealth       Permission = "models:health"
+	PermModelsRead         Permission = "models:read"
+	PermModelsWrite        Permission = "models:write"
+	PermProvidersRead      Permission = "providers:read"
+	PermRateLimitsRead     Permission = "rate_limits:read"
+	PermRateLimitsWrite    Permission = "rate_limits:write"
+	PermRequestsRead       Permission = "requests:read"
+	PermRequestsReplay     Permission = "requests:replay"
+	PermSettingsRead       Permission = "settings:read"
+	PermSettingsWrite      Permission = "settings:write"
+	PermTenantsCreate      Permission = "tenants:create"
+	PermTenantsRead        Permission = "tenants:read"
+	PermTenantsWrite       Permission = "tenants:write"
+	PermTokensRead         Permission = "tokens:read"
+	PermTokensWrite        Permission = "tokens:write"
+	PermUsageRead          Permission = "usage:read"
+	PermUsersRead          Permission = "users:read"
+	PermUsersWrite         Permission = "users:write"
+)
+
+// PermissionQuerier is the subset of db.Queries permission checks depend on.
+type PermissionQuerier interface {
+	Querier
+	GetRole(ctx context.Context, name string) (db.Role, error)
+	GetTenantMembershipRole(ctx context.Context, arg db.GetTenantMembershipRoleParams) (db.TenantMembershipRole, error)
+	ListUserMembershipRoles(ctx context.Context, userID pgtype.UUID) ([]db.TenantMembershipRole, error)
+}
+
+// RolePermissions returns the permissions granted by the named role. A role
+// that no longer exists grants nothing.
+func RolePermissions(ctx context.Context, queries PermissionQuerier, name string) ([]Permission, error) {
+	role, err := queries.GetRole(ctx, name)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return DecodePermissions(role.Permissions)
+}
+
+// DecodePermissions parses a roles.permissions JSON array.
+func DecodePermissions(raw []byte) ([]Permission, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var perms []Permission
+	if err := json.Unmarshal(raw, &perms); err != nil {
+		return nil, err
+	}
+	return perms, nil
+}
+
+// EnsurePermission enforces that the user's role on the tenant grants perm.
+// A custom role assigned to the membership replaces its built-in role; users
+// without a membership granting perm fall back to their admin tenant scopes,
+// which carry the admin role's permissions.
+func EnsurePermission(ctx context.Context, queries PermissionQuerier, tenantID, userID uuid.UUID, perm Permission) error {
+	tenant := pgtype.UUID{Bytes: tenantID, Valid: true}
+	user := pgtype.UUID{Bytes: userID, Valid: true}
+	membership, err := queries.GetTenantMembership(ctx, db.GetTenantMembershipParams{
+		TenantID: tenant,
+		UserID:   user,
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+	if err == nil {
+		roleName := string(membership.Role)
+		custom, err := queries.GetTenantMembershipRole(ctx, db.GetTenantMembershipRoleParams{
+			TenantID: tenant,
+			UserID:   user,
+		})
+		switch {
+		case err == nil:
+			roleName = custom.Role
+		case !errors.Is(err, pgx.ErrNoRows):
+			return err
+		}
+		granted, err := roleGrants(ctx, queries, roleName, perm)
+		if err != nil || granted {
+			return err
+		}
+	}
+
+	granted, err := roleGrants(ctx, queries, string(db.MembershipRoleAdmin), perm)
+	if err != nil {
+		return err
+	}
+	if !granted {
+		return ErrForbidden
+	}
+	scoped, err := queries.HasAdminTenantScope(ctx, db.HasAdminTenantScopeParams{
+		AdminUserID: user,
+		TenantID:    tenant,
+	})
+	if err != nil {
+		return err
+	}
+	if !scoped {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// EnsureAnyPermission verifies that the role on at least one of the user's
+// memberships grants perm, or that perm is an admin permission and the user
+// has at least one admin tenant scope.
+func EnsureAnyPermission(ctx context.Context, queries PermissionQuerier, userID uuid.UUID, perm Permission) error {
+	user := pgtype.UUID{Bytes: userID, Valid: true}
+	memberships, err := queries.ListUserTenants(ctx, user)
+	if err != nil {
+		return err
+	}
+	custom, err := queries.ListUserMembershipRoles(ctx, user)
+	if err != nil {
+		return err
+	}
+	customRoles := make(map[pgtype.UUID]string, len(custom))
+	for _, assignment := range custom {
+		customRoles[assignment.TenantID] = assignment.Role
+	}
+
+	checked := make(map[string]bool)
+	for _, membership := range memberships {
+		roleName := string(membership.Role)
+		if name, ok := customRoles[membership.TenantID]; ok {
+			roleName = name
+		}
+		if checked[roleName] {
+			continue
+		}
+		checked[roleName] = true
+		granted, err := roleGrants(ctx, queries, roleName, perm)
+		if err != nil {
+			return err
+		}
+		if granted {
+			return nil
+		}
+	}
+
+	granted, err := roleGrants(ctx, queries, string(db.MembershipRoleAdmin), perm)
+	if err != nil {
+		return err
+	}
+	if !granted {
+		return ErrForbidden
+	}
+	scoped, err := queries.HasAnyAdminTenantScope(ctx, user)
+	if err != nil {
+		return err
+	}
+	if !scoped {
+		return ErrForbidden
+	}
+	return nil
+}
+
+func roleGrants(ctx context.Context, queries PermissionQuerier, name string, perm Permission) (bool, error) {
+	perms, err := RolePermissions(ctx, queries, name)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(perms, perm), nil
+}
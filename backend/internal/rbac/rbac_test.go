@@ -0,0 +1,78 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+type scopeQuerier struct {
+	scopes map[uuid.UUID][]uuid.UUID
+}
+
+func (q *scopeQuerier) GetTenantMembership(context.Context, db.GetTenantMembershipParams) (db.TenantMembership, error) {
+	return db.TenantMembership{}, pgx.ErrNoRows
+}
+
+func (q *scopeQuerier) ListUserTenants(context.Context, pgtype.UUID) ([]db.ListUserTenantsRow, error) {
+	return nil, nil
+}
+
+func (q *scopeQuerier) HasAdminTenantScope(_ context.Context, arg db.HasAdminTenantScopeParams) (bool, error) {
+	for _, tenant := range q.scopes[arg.AdminUserID.Bytes] {
+		if tenant == arg.TenantID.Bytes {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (q *scopeQuerier) HasAnyAdminTenantScope(_ context.Context, adminUserID pgtype.UUID) (bool, error) {
+	return len(q.scopes[adminUserID.Bytes]) > 0, nil
+}
+
+func (q *scopeQuerier) GetRole(_ context.Context, name string) (db.Role, error) {
+	if name != string(db.MembershipRoleAdmin) {
+		return db.Role{}, pgx.ErrNoRows
+	}
+	return db.Role{Name: name, Permissions: []byte(`["tenants:write","usage:read"]`)}, nil
+}
+
+func (q *scopeQuerier) GetTenantMembershipRole(context.Context, db.GetTenantMembershipRoleParams) (db.TenantMembershipRole, error) {
+	return db.TenantMembershipRole{}, pgx.ErrNoRows
+}
+
+func (q *scopeQuerier) ListUserMembershipRoles(context.Context, pgtype.UUID) ([]db.TenantMembershipRole, error) {
+	return nil, nil
+}
+
+func TestEnsurePermissionScopedAdminLimitedToScopedTenants(t *testing.T) {
+	admin := uuid.New()
+	scoped := uuid.New()
+	other := uuid.New()
+	q := &scopeQuerier{scopes: map[uuid.UUID][]uuid.UUID{admin: {scoped}}}
+	ctx := context.Background()
+
+	if err := EnsurePermission(ctx, q, scoped, admin, PermTenantsWrite); err != nil {
+		t.Fatalf("expected scoped tenant access, got %v", err)
+	}
+	if err := EnsurePermission(ctx, q, other, admin, PermUsageRead); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("expected ErrForbidden outside scope, got %v", err)
+	}
+	if err := EnsurePermission(ctx, q, scoped, admin, PermTenantsCreate); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("expected scoped admin to be denied permissions the admin role lacks, got %v", err)
+	}
+
+	if err := EnsureAnyPermission(ctx, q, admin, PermTenantsWrite); err != nil {
+		t.Fatalf("expected scoped admin to pass any-tenant check, got %v", err)
+	}
+	if err := EnsureAnyPermission(ctx, q, uuid.New(), PermTenantsWrite); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("expected ErrForbidden for unscoped user, got %v", err)
+	}
+}
@@ -0,0 +1,122 @@
+// Package redact masks fields of JSON documents before they are stored.
+package redact
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// Placeholder replaces every redacted string value.
+const Placeholder = "[REDACTED]"
+
+// Wildcard is a path segment matching every element of an array or every
+// member of an object.
+const Wildcard = "*"
+
+var (
+	// ErrInvalidPath reports a redaction path that is not a JSON pointer.
+	ErrInvalidPath = errors.New("invalid redaction path")
+	// ErrInvalidJSON reports a document that could not be parsed.
+	ErrInvalidJSON = errors.New("invalid json")
+)
+
+var placeholderJSON = strconv.Quote(Placeholder)
+
+// Apply replaces the string values at paths with Placeholder and returns the
+// redacted document. Paths are JSON pointers (RFC 6901), e.g.
+// "/messages/*/content", where a "*" segment is a wildcard. Values that are
+// not strings and paths that match nothing are left alone, as is the rest of
+// the document's formatting. data itself is never modified.
+func Apply(data json.RawMessage, paths []string) (json.RawMessage, error) {
+	if len(paths) == 0 || len(data) == 0 {
+		return data, nil
+	}
+	if !gjson.ValidBytes(data) {
+		return nil, ErrInvalidJSON
+	}
+	root := gjson.ParseBytes(data)
+	spans := make(map[int]int)
+	for _, path := range paths {
+		segments, err := parsePointer(path)
+		if err != nil {
+			return nil, err
+		}
+		collect(root, segments, spans)
+	}
+	if len(spans) == 0 {
+		return data, nil
+	}
+
+	starts := make([]int, 0, len(spans))
+	for start := range spans {
+		starts = append(starts, start)
+	}
+	sort.Ints(starts)
+	out := make([]byte, 0, len(data))
+	last := 0
+	for _, start := range starts {
+		out = append(out, data[last:start]...)
+		out = append(out, placeholderJSON...)
+		last = start + spans[start]
+	}
+	out = append(out, data[last:]...)
+	return out, nil
+}
+
+// ValidatePath reports whether path is a usable redaction path.
+func ValidatePath(path string) error {
+	_, err := parsePointer(path)
+	return err
+}
+
+// collect records the byte offset and length of every string value under
+// value that segments match.
+func collect(value gjson.Result, segments []string, spans map[int]int) {
+	if len(segments) == 0 {
+		if value.Type == gjson.String {
+			spans[value.Index] = len(value.Raw)
+		}
+		return
+	}
+	segment, rest := segments[0], segments[1:]
+	switch {
+	case value.IsArray():
+		i := 0
+		value.ForEach(func(_, elem gjson.Result) bool {
+			if segment == Wildcard || segment == strconv.Itoa(i) {
+				collect(elem, rest, spans)
+			}
+			i++
+			return true
+		})
+	case value.IsObject():
+		value.ForEach(func(key, member gjson.Result) bool {
+			if segment == Wildcard || key.Str == segment {
+				collect(member, rest, spans)
+			}
+			return true
+		})
+	}
+}
+
+// parsePointer splits a JSON pointer into unescaped segments. The root
+// pointer "" is rejected since it would redact the whole document.
+func parsePointer(path string) ([]string, error) {
+	if path == "" || path == "/" {
+		return nil, fmt.Errorf("%w: path must name a field", ErrInvalidPath)
+	}
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("%w: %q must start with /", ErrInvalidPath, path)
+	}
+	segments := strings.Split(path[1:], "/")
+	for i, segment := range segments {
+		segments[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)
+	}
+	return segments, nil
+}
@@ -0,0 +1,67 @@
+package redact
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestApplyRedactsNestedMessageArrays(t *testing.T) {
+	data := json.RawMessage(`{"model":"gpt-4o","messages":[` +
+		`{"role":"system","content":"be brief"},` +
+		`{"role":"user","content":[{"type":"text","text":"my ssn is 123"},{"type":"image_url","image_url":{"url":"data:image/png;base64,AAA"}}]},` +
+		`{"role":"assistant","content":null,"tool_calls":[{"function":{"name":"lookup","arguments":"{\"ssn\":\"123\"}"}}]}` +
+		`],"temperature":0.2}`)
+	original := string(data)
+
+	got, err := Apply(data, []string{
+		"/messages/*/content",
+		"/messages/*/content/*/text",
+		"/messages/*/content/*/image_url/url",
+		"/messages/*/tool_calls/*/function/arguments",
+	})
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	want := `{"model":"gpt-4o","messages":[` +
+		`{"role":"system","content":"[REDACTED]"},` +
+		`{"role":"user","content":[{"type":"text","text":"[REDACTED]"},{"type":"image_url","image_url":{"url":"[REDACTED]"}}]},` +
+		`{"role":"assistant","content":null,"tool_calls":[{"function":{"name":"lookup","arguments":"[REDACTED]"}}]}` +
+		`],"temperature":0.2}`
+	if string(got) != want {
+		t.Fatalf("unexpected redaction:\n got %s\nwant %s", got, want)
+	}
+	if string(data) != original {
+		t.Fatal("expected the input to be left untouched")
+	}
+}
+
+func TestApplyIndexesAndEscapedKeys(t *testing.T) {
+	data := json.RawMessage(`{
+  "input": ["first", "second"],
+  "metadata": {"a/b": "secret", "count": 3}
+}`)
+	got, err := Apply(data, []string{"/input/1", "/metadata/a~1b", "/metadata/count", "/missing/field"})
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	want := `{
+  "input": ["first", "[REDACTED]"],
+  "metadata": {"a/b": "[REDACTED]", "count": 3}
+}`
+	if string(got) != want {
+		t.Fatalf("unexpected redaction:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestApplyRejectsBadInput(t *testing.T) {
+	if _, err := Apply(json.RawMessage(`{"a":"b"}`), []string{"messages/content"}); !errors.Is(err, ErrInvalidPath) {
+		t.Fatalf("expected ErrInvalidPath, got %v", err)
+	}
+	if _, err := Apply(json.RawMessage(`{"a":`), []string{"/a"}); !errors.Is(err, ErrInvalidJSON) {
+		t.Fatalf("expected ErrInvalidJSON, got %v", err)
+	}
+	if err := ValidatePath("/"); !errors.Is(err, ErrInvalidPath) {
+		t.Fatalf("expected the root path to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+// Package region maps cloud provider regions to the jurisdictions they serve
+// so requests can be held to a tenant's data residency requirements.
+package region
+
+import "strings"
+
+// EU is the pseudo country code covering every EU member state.
+const EU = "EU"
+
+// RegionToCountries lists the ISO 3166-1 alpha-2 codes (plus EU for regions
+// inside the European Union) that each AWS, Azure, and GCP region stores data
+// in. Keys are lower case.
+var RegionToCountries = map[string][]string{
+	// AWS
+	"us-east-1":      {"US"},
+	"us-east-2":      {"US"},
+	"us-west-1":      {"US"},
+	"us-west-2":      {"US"},
+	"ca-central-1":   {"CA"},
+	"sa-east-1":      {"BR"},
+	"eu-west-1":      {EU, "IE"},
+	"eu-west-2":      {"GB"},
+	"eu-west-3":      {EU, "FR"},
+	"eu-central-1":   {EU, "DE"},
+	"eu-central-2":   {"CH"},
+	"eu-north-1":     {EU, "SE"},
+	"eu-south-1":     {EU, "IT"},
+	"eu-south-2":     {EU, "ES"},
+	"ap-northeast-1": {"JP"},
+	"ap-northeast-2": {"KR"},
+	"ap-northeast-3": {"JP"},
+	"ap-south-1":     {"IN"},
+	"ap-southeast-1": {"SG"},
+	"ap-southeast-2": {"AU"},
+
+	// Azure
+	"eastus":             {"US"},
+	"eastus2":            {"US"},
+	"westus":             {"US"},
+	"westus2":            {"US"},
+	"westus3":            {"US"},
+	"centralus":          {"US"},
+	"northcentralus":     {"US"},
+	"southcentralus":     {"US"},
+	"canadaeast":         {"CA"},
+	"canadacentral":      {"CA"},
+	"brazilsouth":        {"BR"},
+	"northeurope":        {EU, "IE"},
+	"westeurope":         {EU, "NL"},
+	"francecentral":      {EU, "FR"},
+	"germanywestcentral": {EU, "DE"},
+	"swedencentral":      {EU, "SE"},
+	"italynorth":         {EU, "IT"},
+	"spaincentral":       {EU, "ES"},
+	"polandcentral":      {EU, "PL"},
+	"switzerlandnorth":   {"CH"},
+	"norwayeast":         {"NO"},
+	"uksouth":            {"GB"},
+	"ukwest":             {"GB"},
+	"japaneast":          {"JP"},
+	"koreacentral":       {"KR"},
+	"centralindia":       {"IN"},
+	"southindia":         {"IN"},
+	"southeastasia":      {"SG"},
+	"australiaeast":      {"AU"},
+
+	// GCP
+	"us-central1":             {"US"},
+	"us-east1":                {"US"},
+	"us-east4":                {"US"},
+	"us-east5":                {"US"},
+	"us-south1":               {"US"},
+	"us-west1":                {"US"},
+	"us-west4":                {"US"},
+	"northamerica-northeast1": {"CA"},
+	"southamerica-east1":      {"BR"},
+	"europe-west1":            {EU, "BE"},
+	"europe-west2":            {"GB"},
+	"europe-west3":            {EU, "DE"},
+	"europe-west4":            {EU, "NL"},
+	"europe-west6":            {"CH"},
+	"europe-west8":            {EU, "IT"},
+	"europe-west9":            {EU, "FR"},
+	"europe-north1":           {EU, "FI"},
+	"europe-central2":         {EU, "PL"},
+	"europe-southwest1":       {EU, "ES"},
+	"asia-northeast1":         {"JP"},
+	"asia-northeast3":         {"KR"},
+	"asia-south1":             {"IN"},
+	"asia-southeast1":         {"SG"},
+	"australia-southeast1":    {"AU"},
+}
+
+// Countries returns the codes served by a provider region, or nil when the
+// region is unknown.
+func Countries(name string) []string {
+	return RegionToCountries[strings.ToLower(strings.TrimSpace(name))]
+}
+
+// NormalizeCodes upper-cases and trims country codes, dropping blanks and
+// duplicates.
+func NormalizeCodes(codes []string) []string {
+	if len(codes) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(codes))
+	seen := make(map[string]struct{}, len(codes))
+	for _, code := range codes {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if code == "" {
+			continue
+		}
+		if _, ok := seen[code]; ok {
+			continue
+		}
+		seen[code] = struct{}{}
+		out = append(out, code)
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// ValidCode reports whether code is a two-letter upper-case country code;
+// EU passes as well.
+func ValidCode(code string) bool {
+	if len(code) != 2 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Satisfies reports whether a route covering the given codes may serve a
+// caller restricted to required. An empty requirement allows every route; a
+// route with no known coverage never satisfies a requirement.
+func Satisfies(coverage, required []string) bool {
+	if len(required) == 0 {
+		return true
+	}
+	for _, want := range required {
+		for _, have := range coverage {
+			if strings.EqualFold(want, have) {
+				return true
+			}
+		}
+	}
+	return false
+}
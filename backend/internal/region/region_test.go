@@ -0,0 +1,40 @@
+package region
+
+import "testing"
+
+func TestCountries(t *testing.T) {
+	got := Countries(" EU-West-1 ")
+	if len(got) != 2 || got[0] != EU || got[1] != "IE" {
+		t.Fatalf("eu-west-1 = %v, want [EU IE]", got)
+	}
+	if Countries("mars-north-1") != nil {
+		t.Fatal("unknown regions should map to nil")
+	}
+}
+
+func TestSatisfies(t *testing.T) {
+	cases := []struct {
+		coverage, required []string
+		want               bool
+	}{
+		{coverage: []string{"US"}, required: nil, want: true},
+		{coverage: nil, required: nil, want: true},
+		{coverage: []string{"US"}, required: []string{"EU"}, want: false},
+		{coverage: []string{"EU", "IE"}, required: []string{"EU"}, want: true},
+		{coverage: []string{"EU", "IE"}, required: []string{"DE"}, want: false},
+		{coverage: []string{"GB"}, required: []string{"EU", "GB"}, want: true},
+		{coverage: nil, required: []string{"EU"}, want: false},
+	}
+	for _, tc := range cases {
+		if got := Satisfies(tc.coverage, tc.required); got != tc.want {
+			t.Errorf("Satisfies(%v, %v) = %v, want %v", tc.coverage, tc.required, got, tc.want)
+		}
+	}
+}
+
+func TestNormalizeCodes(t *testing.T) {
+	got := NormalizeCodes([]string{" eu", "", "EU", "de"})
+	if len(got) != 2 || got[0] != "EU" || got[1] != "DE" {
+		t.Fatalf("NormalizeCodes = %v", got)
+	}
+}
@@ -19,6 +19,7 @@ type Context struct {
 	TenantID              uuid.UUID
 	APIKeyID              uuid.UUID
 	APIKeyPrefix          string
+	OwnerUserID           uuid.UUID
 	Scopes                []string
 	BudgetLimitCents      int64
 	WarningThreshold      float64
@@ -30,6 +31,45 @@ type Context struct {
 	AlertLastLevel        string
 	AlertLastSent         time.Time
 	HasBudgetOverride     bool
+	// BudgetSoftLimit lets requests through once the budget is exceeded
+	// (enforcement_mode "soft"); they are flagged and audited instead.
+	BudgetSoftLimit       bool
+	SystemPrompt          string
+	SystemPromptMode      string
+	// ModelOverrides holds the tenant's per-alias limits; see ModelLimits.
+	ModelOverrides map[string]ModelLimits
+	// Tags are the caller-supplied cost-allocation labels recorded with the
+	// request (X-Request-Tags header or the chat body's metadata field).
+	Tags map[string]string
+	// Metadata is the caller's X-Request-Metadata object (correlation IDs,
+	// project names, custom dimensions) recorded with the request log.
+	Metadata map[string]any
+	// Fingerprint is the structural hash of a chat request (see
+	// models.ChatRequest.Fingerprint) recorded for abuse detection.
+	Fingerprint string
+	// ZeroRetention marks requests from a zero-retention API key: only
+	// counts and costs are persisted, never the model, provider, or content.
+	ZeroRetention bool
+	// RequestBodyLimit is the tenant's request body cap in bytes; zero
+	// leaves only the server-wide limit.
+	RequestBodyLimit int64
+	// StreamIdleTimeout ends a streaming chat response once no chunk has
+	// been flushed for this long; zero disables the check.
+	StreamIdleTimeout time.Duration
+	// DataResidency limits routing to providers that keep data in one of
+	// these country codes (or EU); empty allows every route.
+	DataResidency []string
+	// ImpersonatedBy is the super admin acting as this tenant through an
+	// impersonation token, with the reason they gave; zero for API keys.
+	ImpersonatedBy      uuid.UUID
+	ImpersonationReason string
+}
+
+// ModelLimits narrows or widens a catalog model's limits for one tenant. Zero
+// fields inherit the catalog value.
+type ModelLimits struct {
+	ContextWindow   int32
+	MaxOutputTokens int32
 }
 
 // WithContext embeds the request context into the parent context.
@@ -0,0 +1,109 @@
+package requestctx
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+const timingsLocalsKey = "request_timings"
+
+// timingsKey is the context key for the request's RequestTimings.
+var timingsKey contextKey = "open-model-gateway/request-timings"
+
+// TimingStage names one processing stage reported by RequestTimings.
+type TimingStage int
+
+const (
+	StageAuth TimingStage = iota
+	StageBudgetCheck
+	StageRateLimit
+	StageRouteSelect
+	StageProvider
+	StageUsageRecord
+	timingStageCount
+)
+
+// RequestTimings accumulates per-stage processing time for a traced request
+// (X-Request-Trace: true). A nil *RequestTimings ignores every call, so
+// stages can be timed unconditionally.
+type RequestTimings struct {
+	mu     sync.Mutex
+	stages [timingStageCount]time.Duration
+}
+
+// NewRequestTimings returns an empty timing breakdown.
+func NewRequestTimings() *RequestTimings {
+	return &RequestTimings{}
+}
+
+// Add charges d to stage. Stages that run more than once (such as usage
+// recording after a fallback) accumulate.
+func (t *RequestTimings) Add(stage TimingStage, d time.Duration) {
+	if t == nil || stage < 0 || stage >= timingStageCount {
+		return
+	}
+	t.mu.Lock()
+	t.stages[stage] += d
+	t.mu.Unlock()
+}
+
+// Track charges the time elapsed since start to stage.
+func (t *RequestTimings) Track(stage TimingStage, start time.Time) {
+	t.Add(stage, time.Since(start))
+}
+
+// Get returns the time charged to stage so far.
+func (t *RequestTimings) Get(stage TimingStage) time.Duration {
+	if t == nil || stage < 0 || stage >= timingStageCount {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.stages[stage]
+}
+
+// MarshalJSON encodes the breakdown in whole milliseconds, the format of the
+// X-Request-Timing response header.
+func (t *RequestTimings) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		AuthMS        int64 `json:"auth_ms"`
+		BudgetCheckMS int64 `json:"budget_check_ms"`
+		RateLimitMS   int64 `json:"rate_limit_ms"`
+		RouteSelectMS int64 `json:"route_select_ms"`
+		ProviderMS    int64 `json:"provider_ms"`
+		UsageRecordMS int64 `json:"usage_record_ms"`
+	}{
+		AuthMS:        t.Get(StageAuth).Milliseconds(),
+		BudgetCheckMS: t.Get(StageBudgetCheck).Milliseconds(),
+		RateLimitMS:   t.Get(StageRateLimit).Milliseconds(),
+		RouteSelectMS: t.Get(StageRouteSelect).Milliseconds(),
+		ProviderMS:    t.Get(StageProvider).Milliseconds(),
+		UsageRecordMS: t.Get(StageUsageRecord).Milliseconds(),
+	})
+}
+
+// WithTimings embeds the request's timing breakdown into the parent context.
+func WithTimings(parent context.Context, t *RequestTimings) context.Context {
+	if parent == nil {
+		parent = context.Background()
+	}
+	return context.WithValue(parent, timingsKey, t)
+}
+
+// TimingsFromContext returns the request's timing breakdown, or nil when the
+// request is not traced.
+func TimingsFromContext(ctx context.Context) *RequestTimings {
+	if ctx == nil {
+		return nil
+	}
+	t, _ := ctx.Value(timingsKey).(*RequestTimings)
+	return t
+}
+
+// TimingsLocalsKey returns the key used in fiber.Locals for the timing
+// breakdown.
+func TimingsLocalsKey() string {
+	return timingsLocalsKey
+}
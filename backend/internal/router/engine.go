@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"math/rand"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -13,14 +14,27 @@ import (
 	"github.com/ncecere/open_model_gateway/backend/internal/config"
 	"github.com/ncecere/open_model_gateway/backend/internal/db"
 	"github.com/ncecere/open_model_gateway/backend/internal/providers"
+	"github.com/ncecere/open_model_gateway/backend/internal/region"
 )
 
 type Engine struct {
-	mu     sync.RWMutex
-	routes map[string][]providers.Route
-	state  map[string]*routeState
+	mu       sync.RWMutex
+	routes   map[string][]providers.Route
+	state    map[string]*routeState
+	window   int
+	observer OutcomeObserver
+	breaker  BreakerObserver
 }
 
+// OutcomeObserver is told about every reported call outcome, keyed by the
+// route's own alias.
+type OutcomeObserver func(alias string, success bool)
+
+// BreakerObserver is told when a route's circuit breaker opens after
+// consecutive failures. It is not called again for the route until a
+// success closes the breaker.
+type BreakerObserver func(alias string, route providers.Route)
+
 // RouteHealth describes the current health for an alias.
 type RouteHealth struct {
 	Alias         string `json:"alias"`
@@ -31,20 +45,87 @@ type RouteHealth struct {
 type routeState struct {
 	consecutiveFailures int
 	openUntil           time.Time
+	// outcomes is a ring of the most recent call results (true for success),
+	// sized to the engine's rolling window.
+	outcomes []bool
+	next     int
+	filled   int
+	// latency is an exponentially weighted average of successful calls.
+	latency time.Duration
 }
 
 const (
-	failureThreshold = 3
-	openDuration     = time.Minute
+	failureThreshold     = 3
+	openDuration         = time.Minute
+	defaultRollingWindow = 5
+	// similarLatencyRatio is how much slower a route may be than another and
+	// still count as similar, letting availability decide between them.
+	similarLatencyRatio = 1.2
 )
 
 func NewEngine() *Engine {
 	return &Engine{
 		routes: make(map[string][]providers.Route),
 		state:  make(map[string]*routeState),
+		window: defaultRollingWindow,
+	}
+}
+
+// SetRollingWindow sets how many recent outcomes per route feed SuccessRate
+// (health.rolling_window). Values below one keep the default of 5.
+func (e *Engine) SetRollingWindow(n int) {
+	if n <= 0 {
+		n = defaultRollingWindow
+	}
+	e.mu.Lock()
+	e.window = n
+	e.mu.Unlock()
+}
+
+// SetOutcomeObserver registers fn to receive every ReportSuccess and
+// ReportFailure. It is called outside the engine lock.
+func (e *Engine) SetOutcomeObserver(fn OutcomeObserver) {
+	e.mu.Lock()
+	e.observer = fn
+	e.mu.Unlock()
+}
+
+// SetBreakerObserver registers fn to be told when a route's breaker opens. It
+// is called outside the engine lock.
+func (e *Engine) SetBreakerObserver(fn BreakerObserver) {
+	e.mu.Lock()
+	e.breaker = fn
+	e.mu.Unlock()
+}
+
+// record adds one call outcome to the rolling window.
+func (st *routeState) record(success bool, window int) {
+	if len(st.outcomes) != window {
+		st.outcomes = make([]bool, window)
+		st.next, st.filled = 0, 0
+	}
+	st.outcomes[st.next] = success
+	st.next = (st.next + 1) % window
+	if st.filled < window {
+		st.filled++
 	}
 }
 
+// counts returns the successes and failures in the rolling window.
+func (st *routeState) counts() (successes, failures int) {
+	if st == nil {
+		return 0, 0
+	}
+	for i := 0; i < st.filled; i++ {
+		if st.outcomes[i] {
+			successes++
+		} else {
+			failures++
+		}
+	}
+	return successes, failures
+}
+
 func (e *Engine) Reload(ctx context.Context, factory *providers.Factory) error {
 	routes, err := factory.Build(ctx)
 	if err != nil {
@@ -71,10 +152,93 @@ func (e *Engine) Reload(ctx context.Context, factory *providers.Factory) error {
 	return nil
 }
 
+// ReplaceProviderRoutes swaps every route in scope, that is served by the
+// scope's provider and endpoint, for the given routes, leaving other routes
+// and breaker state untouched. Aliases left without routes are dropped.
+func (e *Engine) ReplaceProviderRoutes(scope providers.CredentialScope, routes map[string][]providers.Route) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	next := make(map[string][]providers.Route, len(e.routes))
+	for alias, rts := range e.routes {
+		kept := make([]providers.Route, 0, len(rts))
+		for _, route := range rts {
+			if route.Provider != scope.Provider || route.Endpoint != scope.Endpoint {
+				kept = append(kept, route)
+			}
+		}
+		kept = append(kept, routes[alias]...)
+		if len(kept) > 0 {
+			next[alias] = kept
+		}
+	}
+	for alias, rts := range routes {
+		if _, ok := e.routes[alias]; !ok && len(rts) > 0 {
+			next[alias] = append([]providers.Route(nil), rts...)
+		}
+		for _, route := range rts {
+			if key := routeKey(alias, route); e.state[key] == nil {
+				e.state[key] = &routeState{}
+			}
+		}
+	}
+	e.routes = next
+}
+
+// SelectRoutes returns the healthy routes for alias, weighted pick first. When
+// the alias has a traffic split, the routes of the chosen branch are returned
+// instead, each tagged with ABVariant; a branch with no healthy routes falls
+// back to the alias's own routes.
 func (e *Engine) SelectRoutes(alias string) []providers.Route {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
 
+	configured := e.routes[alias]
+	if len(configured) == 0 || len(configured[0].TrafficSplit) == 0 {
+		return e.healthyRoutes(alias)
+	}
+
+	variant := pickVariant(configured[0].TrafficSplit)
+	var routes []providers.Route
+	if variant != alias {
+		routes = e.healthyRoutes(variant)
+	}
+	if len(routes) == 0 {
+		variant = alias
+		routes = e.healthyRoutes(alias)
+	}
+	for i := range routes {
+		routes[i].ABVariant = variant
+	}
+	return routes
+}
+
+// ErrDataResidency reports that an alias has routes but none of them keeps
+// data inside the caller's permitted jurisdictions.
+var ErrDataResidency = errors.New("no route satisfies the data residency requirement")
+
+// SelectResidentRoutes is SelectRoutes restricted to routes whose
+// DataResidency covers one of the residency codes. It returns
+// ErrDataResidency when the filter removed every route; an empty residency
+// applies no filter.
+func (e *Engine) SelectResidentRoutes(alias string, residency []string) ([]providers.Route, error) {
+	routes := e.SelectRoutes(alias)
+	if len(residency) == 0 || len(routes) == 0 {
+		return routes, nil
+	}
+	allowed := routes[:0]
+	for _, route := range routes {
+		if region.Satisfies(route.DataResidency, residency) {
+			allowed = append(allowed, route)
+		}
+	}
+	if len(allowed) == 0 {
+		return nil, ErrDataResidency
+	}
+	return allowed, nil
+}
+
+func (e *Engine) healthyRoutes(alias string) []providers.Route {
 	healthy := make([]providers.Route, 0)
 	now := time.Now()
 	for _, route := range e.routes[alias] {
@@ -87,6 +251,10 @@ func (e *Engine) SelectRoutes(alias string) []providers.Route {
 	if len(healthy) <= 1 {
 		return healthy
 	}
+	if healthy[0].RoutingPolicy == config.RoutingPolicyFastest {
+		e.orderByLatency(alias, healthy)
+		return healthy
+	}
 
 	idx := weightedSelect(healthy)
 	if idx != 0 {
@@ -98,33 +266,142 @@ func (e *Engine) SelectRoutes(alias string) []providers.Route {
 	return healthy
 }
 
-func (e *Engine) ReportSuccess(alias string, route providers.Route) {
-	e.mu.Lock()
-	defer e.mu.Unlock()
+// orderByLatency sorts routes fastest first by their average latency. Routes
+// within similarLatencyRatio of each other are ordered by availability
+// instead, and routes with no latency samples go last. The caller holds
+// e.mu.
+func (e *Engine) orderByLatency(alias string, routes []providers.Route) {
+	type rank struct {
+		latency      time.Duration
+		availability float64
+	}
+	ranks := make(map[string]rank, len(routes))
+	for _, route := range routes {
+		st := e.state[routeKey(alias, route)]
+		rk := rank{availability: successRate(st)}
+		if st != nil {
+			rk.latency = st.latency
+		}
+		ranks[routeKey(alias, route)] = rk
+	}
+	sort.SliceStable(routes, func(i, j int) bool {
+		a, b := ranks[routeKey(alias, routes[i])], ranks[routeKey(alias, routes[j])]
+		switch {
+		case a.latency == 0 || b.latency == 0:
+			return a.latency != 0 && b.latency == 0
+		case similarLatency(a.latency, b.latency) && a.availability != b.availability:
+			return a.availability > b.availability
+		default:
+			return a.latency < b.latency
+		}
+	})
+}
 
-	st := e.state[routeKey(alias, route)]
-	if st == nil {
-		st = &routeState{}
-		e.state[routeKey(alias, route)] = st
+func similarLatency(a, b time.Duration) bool {
+	if a > b {
+		a, b = b, a
 	}
+	return float64(b) <= float64(a)*similarLatencyRatio
+}
+
+func (e *Engine) ReportSuccess(alias string, route providers.Route) {
+	e.mu.Lock()
+	st := e.stateFor(alias, route)
 	st.consecutiveFailures = 0
 	st.openUntil = time.Time{}
+	st.record(true, e.window)
+	observer := e.observer
+	e.mu.Unlock()
+
+	if observer != nil {
+		observer(routeAlias(alias, route), true)
+	}
 }
 
 func (e *Engine) ReportFailure(alias string, route providers.Route) {
+	e.mu.Lock()
+	st := e.stateFor(alias, route)
+	st.consecutiveFailures++
+	if st.consecutiveFailures >= failureThreshold {
+		st.openUntil = time.Now().Add(openDuration)
+	}
+	st.record(false, e.window)
+	observer := e.observer
+	var breaker BreakerObserver
+	if st.consecutiveFailures == failureThreshold {
+		breaker = e.breaker
+	}
+	e.mu.Unlock()
+
+	if observer != nil {
+		observer(routeAlias(alias, route), false)
+	}
+	if breaker != nil {
+		breaker(routeAlias(alias, route), route)
+	}
+}
+
+// ReportLatency folds a successful call's latency into the route's average,
+// which orders routes under the fastest routing policy.
+func (e *Engine) ReportLatency(alias string, route providers.Route, latency time.Duration) {
+	if latency <= 0 {
+		return
+	}
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
-	st := e.state[routeKey(alias, route)]
-	if st == nil {
-		st = &routeState{}
-		e.state[routeKey(alias, route)] = st
+	st := e.stateFor(alias, route)
+	if st.latency == 0 {
+		st.latency = latency
+		return
 	}
+	st.latency += (latency - st.latency) / 5
+}
 
-	st.consecutiveFailures++
-	if st.consecutiveFailures >= failureThreshold {
-		st.openUntil = time.Now().Add(openDuration)
+// SuccessRate returns successes / (successes + failures) over the route's
+// rolling window, or 1 when the route has no outcomes yet.
+func (e *Engine) SuccessRate(alias string, route providers.Route) float64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return successRate(e.state[routeKey(alias, route)])
+}
+
+// Availability pools the rolling outcomes of every route behind alias into
+// one success rate between 0 and 1; it is 1 when nothing has been reported.
+func (e *Engine) Availability(alias string) float64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	var successes, failures int
+	for _, route := range e.routes[alias] {
+		s, f := e.state[routeKey(alias, route)].counts()
+		successes += s
+		failures += f
+	}
+	if successes+failures == 0 {
+		return 1
+	}
+	return float64(successes) / float64(successes+failures)
+}
+
+func successRate(st *routeState) float64 {
+	successes, failures := st.counts()
+	if successes+failures == 0 {
+		return 1
+	}
+	return float64(successes) / float64(successes+failures)
+}
+
+// stateFor returns the route's state, creating it if needed. The caller holds
+// e.mu for writing.
+func (e *Engine) stateFor(alias string, route providers.Route) *routeState {
+	key := routeKey(alias, route)
+	st := e.state[key]
+	if st == nil {
+		st = &routeState{}
+		e.state[key] = st
 	}
+	return st
 }
 
 func weightedSelect(routes []providers.Route) int {
@@ -152,12 +429,55 @@ func weightedSelect(routes []providers.Route) int {
 	return 0
 }
 
+// pickVariant draws a split branch with probability proportional to its weight.
+func pickVariant(split []config.TrafficSplitEntry) string {
+	total := 0
+	for _, entry := range split {
+		total += entry.Weight
+	}
+	if total <= 0 {
+		return split[0].ModelAlias
+	}
+	draw := rand.Intn(total)
+	for _, entry := range split {
+		if draw < entry.Weight {
+			return entry.ModelAlias
+		}
+		draw -= entry.Weight
+	}
+	return split[len(split)-1].ModelAlias
+}
+
+// routeKey identifies a route's breaker state. Routes served through a
+// traffic split are reported under the requested alias, so the route's own
+// alias takes precedence.
 func routeKey(alias string, route providers.Route) string {
 	deployment := route.Metadata["deployment"]
 	if deployment == "" {
 		deployment = route.Model
 	}
-	return alias + "::" + deployment
+	return routeAlias(alias, route) + "::" + deployment
+}
+
+// routeAlias is the alias a route's state is kept under; see routeKey.
+func routeAlias(alias string, route providers.Route) string {
+	if route.Alias != "" {
+		return route.Alias
+	}
+	return alias
+}
+
+// MirrorTarget returns the alias configured to shadow alias and the share of
+// requests to mirror, or an empty alias when mirroring is off.
+func (e *Engine) MirrorTarget(alias string) (string, float64) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	routes := e.routes[alias]
+	if len(routes) == 0 {
+		return "", 0
+	}
+	return routes[0].MirrorAlias, routes[0].MirrorSampleRate
 }
 
 // ListAliases returns the set of configured aliases and their routes.
@@ -174,6 +494,32 @@ func (e *Engine) ListAliases() map[string][]providers.Route {
 	return copyMap
 }
 
+// Deprecation reports the scheduled removal date and message of the first
+// deprecated route behind alias.
+func (e *Engine) Deprecation(alias string) (time.Time, string, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	for _, route := range e.routes[alias] {
+		if route.DeprecatedAt != nil {
+			return *route.DeprecatedAt, route.DeprecationMessage, true
+		}
+	}
+	return time.Time{}, "", false
+}
+
+// VisionSupport reports whether alias accepts image content and, when it
+// does not, the alias configured to serve such requests instead.
+func (e *Engine) VisionSupport(alias string) (bool, string) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	routes := e.routes[alias]
+	if len(routes) == 0 {
+		return false, ""
+	}
+	return routes[0].SupportsVision, routes[0].FallbackVisionAlias
+}
+
 // HealthStatus returns a snapshot of healthy vs total routes per alias.
 func (e *Engine) HealthStatus() map[string]RouteHealth {
 	e.mu.RLock()
@@ -217,6 +563,8 @@ func MergeEntries(cfgEntries []config.ModelCatalogEntry, dbEntries []db.ModelCat
 			ModelType:       row.ModelType,
 			ContextWindow:   row.ContextWindow,
 			MaxOutputTokens: row.MaxOutputTokens,
+			MaxDimensions:   row.MaxDimensions,
+			MirrorAlias:     row.MirrorAlias,
 			SupportsTools:   row.SupportsTools,
 			PriceInput:      row.PriceInput.InexactFloat64(),
 			PriceOutput:     row.PriceOutput.InexactFloat64(),
@@ -229,6 +577,21 @@ func MergeEntries(cfgEntries []config.ModelCatalogEntry, dbEntries []db.ModelCat
 			Region:          row.Region,
 			Weight:          int(row.Weight),
 			Metadata:        map[string]string{},
+			RoutingPolicy:   row.RoutingPolicy,
+		}
+		entry.MirrorSampleRate = row.MirrorSampleRate.InexactFloat64()
+		if row.DeprecatedAt.Valid {
+			deprecatedAt := row.DeprecatedAt.Time
+			entry.DeprecatedAt = &deprecatedAt
+		}
+		entry.DeprecationMessage = row.DeprecationMessage
+		entry.SupportsVision = row.SupportsVision
+		entry.FallbackVisionAlias = row.FallbackVisionAlias
+		entry.CachedTokenPriceRatio = row.CachedPriceRatio.InexactFloat64()
+		if len(row.TrafficSplitJson) > 0 {
+			if err := json.Unmarshal(row.TrafficSplitJson, &entry.TrafficSplit); err != nil {
+				return nil, err
+			}
 		}
 
 		if len(row.ModalitiesJson) > 0 {
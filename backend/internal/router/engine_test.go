@@ -1,9 +1,12 @@
 package router
 
 import (
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgtype"
+
 	"github.com/ncecere/open_model_gateway/backend/internal/config"
 	"github.com/ncecere/open_model_gateway/backend/internal/db"
 	"github.com/ncecere/open_model_gateway/backend/internal/providers"
@@ -56,6 +59,30 @@ func TestEngineCircuitBreakerTransitions(t *testing.T) {
 	}
 }
 
+func TestEngineBreakerObserverFiresOncePerTrip(t *testing.T) {
+	engine := NewEngine()
+	route := providers.Route{Alias: "gpt-breaker", Model: "m1", Provider: "openai"}
+	var trips []string
+	engine.SetBreakerObserver(func(alias string, route providers.Route) {
+		trips = append(trips, alias+"/"+route.Provider)
+	})
+
+	for i := 0; i < failureThreshold+2; i++ {
+		engine.ReportFailure("gpt-breaker", route)
+	}
+	if len(trips) != 1 || trips[0] != "gpt-breaker/openai" {
+		t.Fatalf("expected one trip while the breaker stays open, got %v", trips)
+	}
+
+	engine.ReportSuccess("gpt-breaker", route)
+	for i := 0; i < failureThreshold; i++ {
+		engine.ReportFailure("gpt-breaker", route)
+	}
+	if len(trips) != 2 {
+		t.Fatalf("expected a second trip after recovery, got %v", trips)
+	}
+}
+
 func TestMergeEntriesPrioritizesSources(t *testing.T) {
 	enabled := true
 	cfgEntries := []config.ModelCatalogEntry{{
@@ -93,3 +120,209 @@ func TestMergeEntriesPrioritizesSources(t *testing.T) {
 		t.Fatalf("merged aliases missing: %v", flags)
 	}
 }
+
+func TestMergeEntriesCarriesDeprecation(t *testing.T) {
+	removal := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	dbEntries := []db.ModelCatalog{
+		{
+			Alias:              "gpt-4",
+			Provider:           "openai",
+			Enabled:            true,
+			DeprecatedAt:       pgtype.Timestamptz{Time: removal, Valid: true},
+			DeprecationMessage: "use gpt-4o instead",
+		},
+		{Alias: "gpt-4o", Provider: "openai", Enabled: true},
+	}
+	merged, err := MergeEntries(nil, dbEntries)
+	if err != nil {
+		t.Fatalf("merge failed: %v", err)
+	}
+
+	engine := NewEngine()
+	for _, entry := range merged {
+		engine.routes[entry.Alias] = []providers.Route{{
+			Alias:              entry.Alias,
+			DeprecatedAt:       entry.DeprecatedAt,
+			DeprecationMessage: entry.DeprecationMessage,
+		}}
+	}
+	at, message, ok := engine.Deprecation("gpt-4")
+	if !ok || !at.Equal(removal) || message != "use gpt-4o instead" {
+		t.Fatalf("expected gpt-4 deprecation, got %v %q %v", at, message, ok)
+	}
+	if _, _, ok := engine.Deprecation("gpt-4o"); ok {
+		t.Fatal("gpt-4o should not be deprecated")
+	}
+}
+
+func TestEngineTrafficSplitDistribution(t *testing.T) {
+	engine := NewEngine()
+	split := []config.TrafficSplitEntry{{ModelAlias: "gpt-4-turbo", Weight: 90}, {ModelAlias: "claude-3-opus", Weight: 10}}
+	control := providers.Route{Alias: "gpt-4-turbo", Model: "gpt", Metadata: map[string]string{"deployment": "gpt"}, TrafficSplit: split}
+	variant := providers.Route{Alias: "claude-3-opus", Model: "claude", Metadata: map[string]string{"deployment": "claude"}}
+	engine.routes["gpt-4-turbo"] = []providers.Route{control}
+	engine.routes["claude-3-opus"] = []providers.Route{variant}
+
+	const calls = 10000
+	counts := map[string]int{}
+	for i := 0; i < calls; i++ {
+		routes := engine.SelectRoutes("gpt-4-turbo")
+		if len(routes) != 1 {
+			t.Fatalf("expected 1 route, got %d", len(routes))
+		}
+		if routes[0].ABVariant != routes[0].Alias {
+			t.Fatalf("variant %q does not match serving alias %q", routes[0].ABVariant, routes[0].Alias)
+		}
+		counts[routes[0].ABVariant]++
+	}
+
+	// Binomial sd for p=0.1 over 10k draws is 30; allow five of them.
+	if got := counts["claude-3-opus"]; got < 850 || got > 1150 {
+		t.Fatalf("claude-3-opus served %d of %d calls, want ~1000", got, calls)
+	}
+	if counts["gpt-4-turbo"]+counts["claude-3-opus"] != calls {
+		t.Fatalf("unexpected variants: %v", counts)
+	}
+
+	engine.state[routeKey("claude-3-opus", variant)] = &routeState{openUntil: time.Now().Add(time.Minute)}
+	for i := 0; i < 200; i++ {
+		if routes := engine.SelectRoutes("gpt-4-turbo"); len(routes) != 1 || routes[0].ABVariant != "gpt-4-turbo" {
+			t.Fatalf("expected fallback to control when the variant is unhealthy, got %v", routes)
+		}
+	}
+}
+
+func TestEngineVisionSupport(t *testing.T) {
+	engine := NewEngine()
+	engine.routes["gpt-3.5"] = []providers.Route{{Alias: "gpt-3.5", FallbackVisionAlias: "gpt-4o"}}
+	engine.routes["gpt-4o"] = []providers.Route{{Alias: "gpt-4o", SupportsVision: true}}
+
+	if supports, fallback := engine.VisionSupport("gpt-3.5"); supports || fallback != "gpt-4o" {
+		t.Fatalf("gpt-3.5: supports=%v fallback=%q", supports, fallback)
+	}
+	if supports, _ := engine.VisionSupport("gpt-4o"); !supports {
+		t.Fatal("gpt-4o should support vision")
+	}
+	if supports, fallback := engine.VisionSupport("missing"); supports || fallback != "" {
+		t.Fatal("unknown alias should report no vision support")
+	}
+}
+
+func TestEngineSelectResidentRoutesFiltersByResidency(t *testing.T) {
+	engine := NewEngine()
+	us := providers.Route{Alias: "us-only", Model: "m1", Weight: 1, DataResidency: []string{"US"}}
+	engine.routes["us-only"] = []providers.Route{us}
+	eu := providers.Route{Alias: "mixed", Model: "eu", Weight: 1, DataResidency: []string{"EU", "IE"}}
+	engine.routes["mixed"] = []providers.Route{{Alias: "mixed", Model: "us", Weight: 1, DataResidency: []string{"US"}}, eu}
+
+	if _, err := engine.SelectResidentRoutes("us-only", []string{"EU"}); !errors.Is(err, ErrDataResidency) {
+		t.Fatalf("expected ErrDataResidency for a US-only model, got %v", err)
+	}
+	routes, err := engine.SelectResidentRoutes("us-only", nil)
+	if err != nil || len(routes) != 1 {
+		t.Fatalf("unrestricted tenants should keep the US route: %v %v", routes, err)
+	}
+	routes, err = engine.SelectResidentRoutes("mixed", []string{"EU"})
+	if err != nil || len(routes) != 1 || routes[0].Model != "eu" {
+		t.Fatalf("expected only the EU route, got %v %v", routes, err)
+	}
+	routes, err = engine.SelectResidentRoutes("missing", []string{"EU"})
+	if err != nil || len(routes) != 0 {
+		t.Fatalf("unknown aliases should return no routes without a residency error: %v %v", routes, err)
+	}
+}
+
+func TestEngineReplaceProviderRoutes(t *testing.T) {
+	engine := NewEngine()
+	alias := "gpt-rotate"
+	old := providers.Route{Alias: alias, Provider: "openai", Model: "m1", Metadata: map[string]string{"deployment": "m1", "key": "old"}}
+	other := providers.Route{Alias: alias, Provider: "azure", Model: "m2", Metadata: map[string]string{"deployment": "m2"}}
+	proxy := providers.Route{Alias: alias, Provider: "openai", Endpoint: "https://proxy.example.com/v1", Model: "m4", Metadata: map[string]string{"deployment": "m4", "key": "proxy"}}
+	engine.routes[alias] = []providers.Route{old, other, proxy}
+	engine.routes["openai-only"] = []providers.Route{{Alias: "openai-only", Provider: "openai", Model: "m3"}}
+	engine.state[routeKey(alias, old)] = &routeState{consecutiveFailures: 2}
+
+	rotated := old
+	rotated.Metadata = map[string]string{"deployment": "m1", "key": "new"}
+	engine.ReplaceProviderRoutes(providers.NewCredentialScope("openai", ""), map[string][]providers.Route{alias: {rotated}})
+
+	routes := engine.ListAliases()
+	if _, ok := routes["openai-only"]; ok {
+		t.Fatalf("alias without rebuilt routes should be dropped: %+v", routes)
+	}
+	got := routes[alias]
+	if len(got) != 3 || got[0].Provider != "azure" || got[1].Metadata["key"] != "proxy" || got[2].Metadata["key"] != "new" {
+		t.Fatalf("unexpected routes %+v", got)
+	}
+	if st := engine.state[routeKey(alias, rotated)]; st == nil || st.consecutiveFailures != 2 {
+		t.Fatalf("breaker state should carry over, got %+v", st)
+	}
+}
+
+func TestEngineSuccessRateTracksRollingWindow(t *testing.T) {
+	engine := NewEngine()
+	engine.SetRollingWindow(4)
+	alias := "gpt-rate"
+	route := providers.Route{Alias: alias, Model: "m1", Metadata: map[string]string{"deployment": "m1"}}
+	engine.routes[alias] = []providers.Route{route}
+
+	var observed []bool
+	engine.SetOutcomeObserver(func(got string, success bool) {
+		if got != alias {
+			t.Errorf("observer got alias %q", got)
+		}
+		observed = append(observed, success)
+	})
+
+	if rate := engine.SuccessRate(alias, route); rate != 1 {
+		t.Fatalf("expected 1 with no samples, got %v", rate)
+	}
+	engine.ReportSuccess(alias, route)
+	engine.ReportSuccess(alias, route)
+	engine.ReportFailure(alias, route)
+	engine.ReportFailure(alias, route)
+	if rate := engine.SuccessRate(alias, route); rate != 0.5 {
+		t.Fatalf("expected 0.5, got %v", rate)
+	}
+	// Older outcomes fall out of the window.
+	engine.ReportFailure(alias, route)
+	engine.ReportFailure(alias, route)
+	if rate := engine.SuccessRate(alias, route); rate != 0 {
+		t.Fatalf("expected 0 after failures filled the window, got %v", rate)
+	}
+	if availability := engine.Availability(alias); availability != 0 {
+		t.Fatalf("expected availability 0, got %v", availability)
+	}
+	if len(observed) != 6 {
+		t.Fatalf("expected 6 observed outcomes, got %d", len(observed))
+	}
+}
+
+func TestEngineFastestPolicyPrefersAvailableRouteAtSimilarLatency(t *testing.T) {
+	engine := NewEngine()
+	alias := "gpt-fast"
+	flaky := providers.Route{Alias: alias, Model: "m1", Metadata: map[string]string{"deployment": "m1"}, RoutingPolicy: config.RoutingPolicyFastest}
+	steady := providers.Route{Alias: alias, Model: "m2", Metadata: map[string]string{"deployment": "m2"}, RoutingPolicy: config.RoutingPolicyFastest}
+	slow := providers.Route{Alias: alias, Model: "m3", Metadata: map[string]string{"deployment": "m3"}, RoutingPolicy: config.RoutingPolicyFastest}
+	engine.routes[alias] = []providers.Route{slow, flaky, steady}
+
+	engine.ReportLatency(alias, flaky, 100*time.Millisecond)
+	engine.ReportLatency(alias, steady, 110*time.Millisecond)
+	engine.ReportLatency(alias, slow, 400*time.Millisecond)
+	engine.ReportSuccess(alias, flaky)
+	engine.ReportFailure(alias, flaky)
+	engine.ReportSuccess(alias, steady)
+
+	selected := engine.SelectRoutes(alias)
+	order := []string{selected[0].Model, selected[1].Model, selected[2].Model}
+	if order[0] != "m2" || order[1] != "m1" || order[2] != "m3" {
+		t.Fatalf("expected steady, flaky, slow; got %v", order)
+	}
+
+	// A clearly faster route wins despite lower availability.
+	engine.ReportLatency(alias, steady, 2*time.Second)
+	selected = engine.SelectRoutes(alias)
+	if selected[0].Model != "m1" {
+		t.Fatalf("expected faster route first, got %s", selected[0].Model)
+	}
+}
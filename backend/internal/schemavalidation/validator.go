@@ -0,0 +1,156 @@
+package schemavalidation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// Mode controls how the gateway reacts when a completion does not match the
+// schema requested via response_format.
+type Mode string
+
+const (
+	ModeStrict   Mode = "strict"
+	ModeWarnOnly Mode = "warn_only"
+	ModeDisabled Mode = "disabled"
+)
+
+const schemaResourceURL = "response_format.json"
+
+// ErrInvalidMode is returned when a mode string is not recognised.
+var ErrInvalidMode = errors.New("schema_validation_mode must be strict, warn_only, or disabled")
+
+// ParseMode normalises a stored or user-supplied mode. Empty values fall back
+// to strict.
+func ParseMode(value string) (Mode, error) {
+	switch Mode(strings.ToLower(strings.TrimSpace(value))) {
+	case "", ModeStrict:
+		return ModeStrict, nil
+	case ModeWarnOnly:
+		return ModeWarnOnly, nil
+	case ModeDisabled:
+		return ModeDisabled, nil
+	default:
+		return "", ErrInvalidMode
+	}
+}
+
+// Detail describes a single schema violation.
+type Detail struct {
+	InstanceLocation string `json:"instance_location"`
+	KeywordLocation  string `json:"keyword_location"`
+	Message          string `json:"message"`
+}
+
+// ValidationError is returned when the model output does not satisfy the schema.
+type ValidationError struct {
+	Details []Detail
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Details) == 0 {
+		return "output does not match schema"
+	}
+	first := e.Details[0]
+	return fmt.Sprintf("output does not match schema at %q: %s", first.InstanceLocation, first.Message)
+}
+
+// Schema is a compiled response_format schema.
+type Schema struct {
+	schema *jsonschema.Schema
+}
+
+// Compile prepares the json_schema object supplied in an OpenAI-style
+// response_format. Both the wrapped form ({"name", "schema"}) and a bare JSON
+// Schema document are accepted.
+func Compile(jsonSchema json.RawMessage) (*Schema, error) {
+	schemaDoc, err := extractSchema(jsonSchema)
+	if err != nil {
+		return nil, err
+	}
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft2020
+	if err := compiler.AddResource(schemaResourceURL, bytes.NewReader(schemaDoc)); err != nil {
+		return nil, fmt.Errorf("invalid json schema: %w", err)
+	}
+	compiled, err := compiler.Compile(schemaResourceURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid json schema: %w", err)
+	}
+	return &Schema{schema: compiled}, nil
+}
+
+// Validate checks output against the schema, returning *ValidationError when
+// it is not valid JSON or does not match.
+func (s *Schema) Validate(output string) error {
+	decoder := json.NewDecoder(strings.NewReader(output))
+	decoder.UseNumber()
+	var instance any
+	if err := decoder.Decode(&instance); err != nil {
+		return &ValidationError{Details: []Detail{{
+			Message: fmt.Sprintf("output is not valid JSON: %v", err),
+		}}}
+	}
+	if decoder.More() {
+		return &ValidationError{Details: []Detail{{
+			Message: "output contains trailing data after JSON value",
+		}}}
+	}
+
+	if err := s.schema.Validate(instance); err != nil {
+		var verr *jsonschema.ValidationError
+		if !errors.As(err, &verr) {
+			return err
+		}
+		return &ValidationError{Details: detailsFrom(verr)}
+	}
+	return nil
+}
+
+// Validate compiles jsonSchema and checks output against it in one step.
+func Validate(jsonSchema json.RawMessage, output string) error {
+	schema, err := Compile(jsonSchema)
+	if err != nil {
+		return err
+	}
+	return schema.Validate(output)
+}
+
+func extractSchema(raw json.RawMessage) ([]byte, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return nil, errors.New("json_schema is required")
+	}
+	var wrapper struct {
+		Schema json.RawMessage `json:"schema"`
+	}
+	if err := json.Unmarshal(trimmed, &wrapper); err != nil {
+		return nil, fmt.Errorf("invalid json_schema: %w", err)
+	}
+	if len(bytes.TrimSpace(wrapper.Schema)) > 0 {
+		return wrapper.Schema, nil
+	}
+	return trimmed, nil
+}
+
+func detailsFrom(verr *jsonschema.ValidationError) []Detail {
+	basic := verr.BasicOutput()
+	details := make([]Detail, 0, len(basic.Errors))
+	for _, item := range basic.Errors {
+		// The root entry only restates that validation failed.
+		if item.KeywordLocation == "" && len(basic.Errors) > 1 {
+			continue
+		}
+		details = append(details, Detail{
+			InstanceLocation: item.InstanceLocation,
+			KeywordLocation:  item.KeywordLocation,
+			Message:          item.Error,
+		})
+	}
+	return details
+}
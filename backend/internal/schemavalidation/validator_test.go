@@ -0,0 +1,82 @@
+package schemavalidation
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+const personSchema = `{
+	"name": "person",
+	"strict": true,
+	"schema": {
+		"type": "object",
+		"properties": {
+			"name": {"type": "string"},
+			"age": {"type": "integer", "minimum": 0}
+		},
+		"required": ["name", "age"],
+		"additionalProperties": false
+	}
+}`
+
+func TestValidateAcceptsMatchingOutput(t *testing.T) {
+	if err := Validate(json.RawMessage(personSchema), `{"name":"Ada","age":36}`); err != nil {
+		t.Fatalf("expected valid output, got %v", err)
+	}
+}
+
+func TestValidateReportsViolations(t *testing.T) {
+	err := Validate(json.RawMessage(personSchema), `{"name":"Ada","age":-1,"extra":true}`)
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	if len(verr.Details) < 2 {
+		t.Fatalf("expected details for each violation, got %+v", verr.Details)
+	}
+	for _, detail := range verr.Details {
+		if detail.KeywordLocation == "" || detail.Message == "" {
+			t.Fatalf("incomplete detail %+v", detail)
+		}
+	}
+}
+
+func TestValidateRejectsNonJSONOutput(t *testing.T) {
+	err := Validate(json.RawMessage(personSchema), "Sure! Here is the JSON you asked for")
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+}
+
+func TestValidateAcceptsBareSchema(t *testing.T) {
+	bare := `{"type": "array", "items": {"type": "number"}}`
+	if err := Validate(json.RawMessage(bare), `[1, 2.5, 3]`); err != nil {
+		t.Fatalf("expected valid output, got %v", err)
+	}
+	if err := Validate(json.RawMessage(bare), `["x"]`); err == nil {
+		t.Fatalf("expected violation for string item")
+	}
+}
+
+func TestValidateInvalidSchema(t *testing.T) {
+	err := Validate(json.RawMessage(`{"schema": {"type": 12}}`), `{}`)
+	var verr *ValidationError
+	if err == nil || errors.As(err, &verr) {
+		t.Fatalf("expected schema compile error, got %v", err)
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	cases := map[string]Mode{"": ModeStrict, "STRICT": ModeStrict, " warn_only ": ModeWarnOnly, "disabled": ModeDisabled}
+	for input, want := range cases {
+		got, err := ParseMode(input)
+		if err != nil || got != want {
+			t.Fatalf("ParseMode(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseMode("lenient"); !errors.Is(err, ErrInvalidMode) {
+		t.Fatalf("expected ErrInvalidMode, got %v", err)
+	}
+}
@@ -39,7 +39,7 @@ func (s *Service) Record(ctx context.Context, userID uuid.UUID, action, resource
 		metaBytes = data
 	}
 	return s.audit.Record(ctx, db.InsertAuditLogParams{
-		UserID:       pgtype.UUID{Bytes: userID, Valid: true},
+		UserID:       pgtype.UUID{Bytes: userID, Valid: userID != uuid.Nil},
 		Action:       action,
 		ResourceType: resourceType,
 		ResourceID:   resourceID,
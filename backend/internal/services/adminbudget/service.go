@@ -2,6 +2,7 @@ package adminbudget
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"strings"
 	"time"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/ncecere/open_model_gateway/backend/internal/config"
 	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/services/usagepipeline"
 )
 
 var (
@@ -19,6 +21,8 @@ var (
 	ErrInvalidDefault     = errors.New("default_usd must be positive")
 	ErrInvalidThreshold   = errors.New("warning_threshold must be between 0 and 1")
 	ErrInvalidOverride    = errors.New("budget_usd must be positive")
+	ErrInvalidUsageAlert  = errors.New("usage_alert thresholds and lookback_hours must be >= 0")
+	ErrInvalidEnforcement = errors.New("enforcement_mode must be hard or soft")
 )
 
 // Service wraps DB + config helpers for admin budget operations.
@@ -48,6 +52,15 @@ type OverrideRequest struct {
 	AlertEmails          []string
 	AlertWebhooks        []string
 	AlertCooldownSeconds *int32
+	// UsageAlert replaces the tenant's spike alert subscription; nil keeps
+	// the stored one.
+	UsageAlert *usagepipeline.TenantUsageAlertConfig
+	// EnforcementMode is "hard" or "soft"; empty keeps the stored mode (hard
+	// for new overrides).
+	EnforcementMode string
+	// AlertWebhookSecret signs the tenant's alert webhooks; nil keeps the
+	// stored secret and "" removes it.
+	AlertWebhookSecret *string
 }
 
 func (s *Service) UpdateDefaults(ctx context.Context, req DefaultUpdate) (db.BudgetDefault, error) {
@@ -111,9 +124,45 @@ func (s *Service) UpsertOverride(ctx context.Context, tenantID uuid.UUID, req Ov
 		return db.TenantBudgetOverride{}, ErrInvalidThreshold
 	}
 	params := s.buildOverrideParams(tenantID, req)
+	if strings.TrimSpace(req.EnforcementMode) != "" {
+		mode, ok := config.NormalizeBudgetEnforcementMode(req.EnforcementMode)
+		if !ok {
+			return db.TenantBudgetOverride{}, ErrInvalidEnforcement
+		}
+		params.EnforcementMode = pgtype.Text{String: mode, Valid: true}
+	}
+	if req.AlertWebhookSecret != nil {
+		params.AlertWebhookSecret = pgtype.Text{String: strings.TrimSpace(*req.AlertWebhookSecret), Valid: true}
+	}
+	if req.UsageAlert != nil {
+		if err := req.UsageAlert.Validate(); err != nil {
+			return db.TenantBudgetOverride{}, ErrInvalidUsageAlert
+		}
+		raw, err := json.Marshal(req.UsageAlert)
+		if err != nil {
+			return db.TenantBudgetOverride{}, err
+		}
+		params.UsageAlertConfig = raw
+	}
 	return s.queries.UpsertTenantBudgetOverride(ctx, params)
 }
 
+// SetEnforcementMode switches an existing override between hard and soft
+// enforcement. It returns pgx.ErrNoRows when the tenant has no override.
+func (s *Service) SetEnforcementMode(ctx context.Context, tenantID uuid.UUID, mode string) (db.TenantBudgetOverride, error) {
+	if s == nil || s.queries == nil {
+		return db.TenantBudgetOverride{}, ErrServiceUnavailable
+	}
+	normalized, ok := config.NormalizeBudgetEnforcementMode(mode)
+	if !ok || strings.TrimSpace(mode) == "" {
+		return db.TenantBudgetOverride{}, ErrInvalidEnforcement
+	}
+	return s.queries.UpdateTenantBudgetEnforcementMode(ctx, db.UpdateTenantBudgetEnforcementModeParams{
+		TenantID:        toPgUUID(tenantID),
+		EnforcementMode: normalized,
+	})
+}
+
 func (s *Service) DeleteOverride(ctx context.Context, tenantID uuid.UUID) error {
 	if s == nil || s.queries == nil {
 		return ErrServiceUnavailable
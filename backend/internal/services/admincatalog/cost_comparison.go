@@ -0,0 +1,84 @@
+package admincatalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	decimal "github.com/shopspring/decimal"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/currency"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+var ErrInvalidTokenMix = errors.New("prompt_tokens and completion_tokens must be >= 0 and not both zero")
+
+// CostComparisonParams is the token mix to price across the catalog.
+type CostComparisonParams struct {
+	PromptTokens     int64
+	CompletionTokens int64
+	// Currency limits the comparison to models priced in that ISO 4217
+	// currency. Empty means USD.
+	Currency string
+}
+
+// ModelCost is the projected cost of the token mix on one model.
+type ModelCost struct {
+	Alias            string  `json:"alias"`
+	Provider         string  `json:"provider"`
+	PriceInputPer1K  float64 `json:"price_input_per_1k"`
+	PriceOutputPer1K float64 `json:"price_output_per_1k"`
+	EstimatedCostUSD float64 `json:"estimated_cost_usd"`
+	Currency         string  `json:"currency"`
+	Enabled          bool    `json:"enabled"`
+}
+
+// CostComparison prices the token mix on every catalog model, cheapest first.
+func (s *Service) CostComparison(ctx context.Context, params CostComparisonParams) ([]ModelCost, error) {
+	if params.PromptTokens < 0 || params.CompletionTokens < 0 || params.PromptTokens+params.CompletionTokens == 0 {
+		return nil, ErrInvalidTokenMix
+	}
+	items, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return compareModelCosts(items, params)
+}
+
+// compareModelCosts applies the same per-million-token pricing the usage
+// pipeline charges with.
+func compareModelCosts(items []db.ModelCatalog, params CostComparisonParams) ([]ModelCost, error) {
+	code, err := currency.Normalize(params.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCurrency, err)
+	}
+	million := decimal.NewFromInt(1_000_000)
+	thousand := decimal.NewFromInt(1_000)
+	prompt := decimal.NewFromInt(params.PromptTokens)
+	completion := decimal.NewFromInt(params.CompletionTokens)
+
+	out := make([]ModelCost, 0, len(items))
+	for _, item := range items {
+		if itemCode, err := currency.Normalize(item.Currency); err != nil || itemCode != code {
+			continue
+		}
+		cost := item.PriceInput.Mul(prompt).Add(item.PriceOutput.Mul(completion)).Div(million)
+		out = append(out, ModelCost{
+			Alias:            item.Alias,
+			Provider:         item.Provider,
+			PriceInputPer1K:  item.PriceInput.Div(thousand).InexactFloat64(),
+			PriceOutputPer1K: item.PriceOutput.Div(thousand).InexactFloat64(),
+			EstimatedCostUSD: cost.InexactFloat64(),
+			Currency:         code,
+			Enabled:          item.Enabled,
+		})
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].EstimatedCostUSD != out[j].EstimatedCostUSD {
+			return out[i].EstimatedCostUSD < out[j].EstimatedCostUSD
+		}
+		return out[i].Alias < out[j].Alias
+	})
+	return out, nil
+}
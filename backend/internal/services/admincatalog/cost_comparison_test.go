@@ -0,0 +1,43 @@
+package admincatalog
+
+import (
+	"errors"
+	"testing"
+
+	decimal "github.com/shopspring/decimal"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+func TestCompareModelCosts(t *testing.T) {
+	items := []db.ModelCatalog{
+		{Alias: "gpt-4o", Provider: "openai", PriceInput: decimal.NewFromFloat(2.5), PriceOutput: decimal.NewFromInt(10), Enabled: true},
+		{Alias: "mini", Provider: "openai", PriceInput: decimal.NewFromFloat(0.15), PriceOutput: decimal.NewFromFloat(0.6), Currency: "usd", Enabled: true},
+		{Alias: "claude", Provider: "bedrock", PriceInput: decimal.NewFromInt(3), PriceOutput: decimal.NewFromInt(15)},
+		{Alias: "euro-model", Provider: "vertex", PriceInput: decimal.NewFromInt(1), PriceOutput: decimal.NewFromInt(1), Currency: "EUR"},
+	}
+
+	got, err := compareModelCosts(items, CostComparisonParams{PromptTokens: 1000, CompletionTokens: 500})
+	if err != nil {
+		t.Fatalf("compare: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected EUR model to be excluded, got %+v", got)
+	}
+	if got[0].Alias != "mini" || got[1].Alias != "gpt-4o" || got[2].Alias != "claude" {
+		t.Fatalf("expected cheapest first, got %+v", got)
+	}
+	// 1000 * 2.5/1M + 500 * 10/1M
+	if got[1].EstimatedCostUSD != 0.0075 || got[1].PriceInputPer1K != 0.0025 || got[1].PriceOutputPer1K != 0.01 {
+		t.Fatalf("unexpected gpt-4o pricing %+v", got[1])
+	}
+
+	eur, err := compareModelCosts(items, CostComparisonParams{PromptTokens: 1000, Currency: "eur"})
+	if err != nil || len(eur) != 1 || eur[0].Alias != "euro-model" || eur[0].Currency != "EUR" {
+		t.Fatalf("expected only EUR model, got %+v (%v)", eur, err)
+	}
+
+	if _, err := compareModelCosts(items, CostComparisonParams{PromptTokens: 1000, Currency: "EURO"}); !errors.Is(err, ErrCurrency) {
+		t.Fatalf("expected ErrCurrency for an unknown code, got %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package admincatalog
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/catalog"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+var ErrModelNotFound = errors.New("model not found")
+
+// SetDeprecation schedules alias for removal at the given time, or clears the
+// deprecation when at is nil. message is appended to the Warning header sent
+// with every response for the alias, e.g. "use gpt-4o instead".
+func (s *Service) SetDeprecation(ctx context.Context, alias string, at *time.Time, message string) error {
+	if s == nil || s.queries == nil {
+		return ErrServiceUnavailable
+	}
+	alias = strings.TrimSpace(alias)
+	if alias == "" {
+		return ErrAliasRequired
+	}
+	params := db.SetModelDeprecationParams{Alias: alias}
+	if at != nil {
+		params.DeprecatedAt = pgtype.Timestamptz{Time: at.UTC(), Valid: true}
+		params.DeprecationMessage = strings.TrimSpace(message)
+	}
+	if _, err := s.queries.SetModelDeprecation(ctx, params); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrModelNotFound
+		}
+		return err
+	}
+	if s.reload != nil {
+		return s.reload(ctx)
+	}
+	return nil
+}
+
+// ListDeprecated returns every deprecated catalog entry, soonest removal first.
+func (s *Service) ListDeprecated(ctx context.Context) ([]db.ModelCatalog, error) {
+	if s == nil || s.queries == nil {
+		return nil, ErrServiceUnavailable
+	}
+	items, err := s.queries.ListDeprecatedModels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		items[i].Provider = catalog.NormalizeProviderSlug(items[i].Provider)
+	}
+	return items, nil
+}
+
+// DisableExpired disables enabled models whose deprecation date is at or
+// before now and reloads the router when any were changed.
+func (s *Service) DisableExpired(ctx context.Context, now time.Time) ([]string, error) {
+	if s == nil || s.queries == nil {
+		return nil, ErrServiceUnavailable
+	}
+	aliases, err := s.queries.DisableDeprecatedModels(ctx, pgtype.Timestamptz{Time: now.UTC(), Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	if len(aliases) > 0 && s.reload != nil {
+		if err := s.reload(ctx); err != nil {
+			return aliases, err
+		}
+	}
+	return aliases, nil
+}
@@ -0,0 +1,118 @@
+package admincatalog
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	decimal "github.com/shopspring/decimal"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+// PriceChange is one recorded change to a catalog entry's token prices.
+// ChangedByUserID is nil when the change was not made by an admin user.
+type PriceChange struct {
+	Alias           string          `json:"alias"`
+	OldPriceInput   decimal.Decimal `json:"old_price_input"`
+	NewPriceInput   decimal.Decimal `json:"new_price_input"`
+	OldPriceOutput  decimal.Decimal `json:"old_price_output"`
+	NewPriceOutput  decimal.Decimal `json:"new_price_output"`
+	ChangedAt       time.Time       `json:"changed_at"`
+	ChangedByUserID *uuid.UUID      `json:"changed_by_user_id"`
+}
+
+// PriceHistory returns the recorded price changes for alias, newest first.
+func (s *Service) PriceHistory(ctx context.Context, alias string) ([]PriceChange, error) {
+	if s == nil || s.queries == nil {
+		return nil, ErrServiceUnavailable
+	}
+	alias = strings.TrimSpace(alias)
+	if alias == "" {
+		return nil, ErrAliasRequired
+	}
+	rows, err := s.queries.ListModelPriceHistory(ctx, alias)
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]PriceChange, 0, len(rows))
+	for _, row := range rows {
+		change := PriceChange{
+			Alias:          row.Alias,
+			OldPriceInput:  row.OldPriceInput,
+			NewPriceInput:  row.NewPriceInput,
+			OldPriceOutput: row.OldPriceOutput,
+			NewPriceOutput: row.NewPriceOutput,
+			ChangedAt:      row.ChangedAt.Time.UTC(),
+		}
+		if row.ChangedByUserID.Valid {
+			id := uuid.UUID(row.ChangedByUserID.Bytes)
+			change.ChangedByUserID = &id
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// priceHistoryEntry builds the history row for an upsert of existing, reporting
+// false when neither the input nor the output price changes.
+func priceHistoryEntry(existing db.ModelCatalog, params db.UpsertModelCatalogEntryParams, actorID uuid.UUID) (db.InsertModelPriceHistoryParams, bool) {
+	if existing.PriceInput.Equal(params.PriceInput) && existing.PriceOutput.Equal(params.PriceOutput) {
+		return db.InsertModelPriceHistoryParams{}, false
+	}
+	entry := db.InsertModelPriceHistoryParams{
+		Alias:          params.Alias,
+		OldPriceInput:  existing.PriceInput,
+		NewPriceInput:  params.PriceInput,
+		OldPriceOutput: existing.PriceOutput,
+		NewPriceOutput: params.PriceOutput,
+	}
+	if actorID != uuid.Nil {
+		entry.ChangedByUserID = pgtype.UUID{Bytes: actorID, Valid: true}
+	}
+	return entry, true
+}
+
+// SaveEntry upserts a catalog entry and records a price change to an existing
+// entry in the same transaction, so the history cannot miss or invent a
+// change. merge, when set, copies fields from the existing entry into params
+// before they are saved. actorID is the change's author; uuid.Nil leaves it
+// unset.
+func SaveEntry(ctx context.Context, pool *pgxpool.Pool, queries *db.Queries, params db.UpsertModelCatalogEntryParams, actorID uuid.UUID, merge func(existing db.ModelCatalog, params *db.UpsertModelCatalogEntryParams)) (db.ModelCatalog, error) {
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return db.ModelCatalog{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := queries.WithTx(tx)
+	var priceChange db.InsertModelPriceHistoryParams
+	changed := false
+	if existing, err := qtx.GetModelByAliasForUpdate(ctx, params.Alias); err == nil {
+		if merge != nil {
+			merge(existing, &params)
+		}
+		priceChange, changed = priceHistoryEntry(existing, params, actorID)
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return db.ModelCatalog{}, err
+	}
+
+	entry, err := qtx.UpsertModelCatalogEntry(ctx, params)
+	if err != nil {
+		return db.ModelCatalog{}, err
+	}
+	if changed {
+		if _, err := qtx.InsertModelPriceHistory(ctx, priceChange); err != nil {
+			return db.ModelCatalog{}, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return db.ModelCatalog{}, err
+	}
+	return entry, nil
+}
@@ -0,0 +1,68 @@
+package admincatalog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	decimal "github.com/shopspring/decimal"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/database/dbtest"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+func TestSaveEntryRecordsPriceChangesWithTheUpsert(t *testing.T) {
+	pool := dbtest.Open(t)
+	queries := db.New(pool)
+	ctx := context.Background()
+
+	alias := "price-history-" + uuid.NewString()
+	t.Cleanup(func() { _ = queries.DeleteModelCatalogEntry(context.Background(), alias) })
+	params := db.UpsertModelCatalogEntryParams{
+		Alias:              alias,
+		Provider:           "openai",
+		ProviderModel:      "gpt-4o",
+		ModelType:          "llm",
+		ModalitiesJson:     []byte(`["text"]`),
+		PriceInput:         decimal.NewFromFloat(2.5),
+		PriceOutput:        decimal.NewFromFloat(10),
+		Currency:           "USD",
+		Enabled:            true,
+		MetadataJson:       []byte("{}"),
+		ProviderConfigJson: []byte("{}"),
+		TrafficSplitJson:   []byte("[]"),
+		Weight:             1,
+	}
+
+	// A new entry has no previous price to record.
+	if _, err := SaveEntry(ctx, pool, queries, params, uuid.Nil, nil); err != nil {
+		t.Fatalf("create entry: %v", err)
+	}
+	// Saving the same prices again is not a change.
+	if _, err := SaveEntry(ctx, pool, queries, params, uuid.Nil, nil); err != nil {
+		t.Fatalf("resave entry: %v", err)
+	}
+	params.PriceOutput = decimal.NewFromFloat(8)
+	saved, err := SaveEntry(ctx, pool, queries, params, uuid.Nil, nil)
+	if err != nil {
+		t.Fatalf("update entry: %v", err)
+	}
+	if !saved.PriceOutput.Equal(decimal.NewFromFloat(8)) {
+		t.Fatalf("expected saved output price 8, got %s", saved.PriceOutput)
+	}
+
+	history, err := queries.ListModelPriceHistory(ctx, alias)
+	if err != nil {
+		t.Fatalf("list history: %v", err)
+	}
+	if len(history) != 1 {
+		t.Fatalf("expected one price change, got %d", len(history))
+	}
+	change := history[0]
+	if !change.OldPriceOutput.Equal(decimal.NewFromFloat(10)) || !change.NewPriceOutput.Equal(decimal.NewFromFloat(8)) {
+		t.Fatalf("unexpected output prices %s -> %s", change.OldPriceOutput, change.NewPriceOutput)
+	}
+	if change.ChangedByUserID.Valid {
+		t.Fatalf("expected no author for a config sync, got %v", change.ChangedByUserID)
+	}
+}
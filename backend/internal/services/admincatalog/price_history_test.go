@@ -0,0 +1,49 @@
+package admincatalog
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	decimal "github.com/shopspring/decimal"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+func TestPriceHistoryEntryRecordsChanges(t *testing.T) {
+	existing := db.ModelCatalog{
+		Alias:       "gpt-4o",
+		PriceInput:  decimal.RequireFromString("2.500000"),
+		PriceOutput: decimal.RequireFromString("10.000000"),
+	}
+	actor := uuid.New()
+
+	// Same prices with a different scale are not a change.
+	params := db.UpsertModelCatalogEntryParams{
+		Alias:       "gpt-4o",
+		PriceInput:  decimal.NewFromFloat(2.5),
+		PriceOutput: decimal.NewFromFloat(10),
+	}
+	if _, changed := priceHistoryEntry(existing, params, actor); changed {
+		t.Fatalf("expected no history entry for unchanged prices")
+	}
+
+	params.PriceOutput = decimal.NewFromFloat(8)
+	entry, changed := priceHistoryEntry(existing, params, actor)
+	if !changed {
+		t.Fatalf("expected a history entry for an output price change")
+	}
+	if !entry.OldPriceOutput.Equal(decimal.NewFromInt(10)) || !entry.NewPriceOutput.Equal(decimal.NewFromInt(8)) {
+		t.Fatalf("unexpected output prices %s -> %s", entry.OldPriceOutput, entry.NewPriceOutput)
+	}
+	if !entry.OldPriceInput.Equal(entry.NewPriceInput) {
+		t.Fatalf("expected unchanged input price on both sides, got %s -> %s", entry.OldPriceInput, entry.NewPriceInput)
+	}
+	if !entry.ChangedByUserID.Valid || uuid.UUID(entry.ChangedByUserID.Bytes) != actor {
+		t.Fatalf("expected actor %s, got %+v", actor, entry.ChangedByUserID)
+	}
+
+	entry, _ = priceHistoryEntry(existing, params, uuid.Nil)
+	if entry.ChangedByUserID.Valid {
+		t.Fatalf("expected no actor for uuid.Nil")
+	}
+}
@@ -4,12 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"strings"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
 	decimal "github.com/shopspring/decimal"
 
 	"github.com/ncecere/open_model_gateway/backend/internal/catalog"
 	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/currency"
 	"github.com/ncecere/open_model_gateway/backend/internal/db"
 )
 
@@ -19,6 +23,14 @@ var (
 	ErrProviderRequired   = errors.New("provider is required")
 	ErrModelRequired      = errors.New("provider_model is required")
 	ErrDeploymentRequired = errors.New("deployment is required")
+	ErrRoutingPolicy      = errors.New("routing_policy must be empty or \"fastest\"")
+	ErrTrafficSplit       = errors.New("invalid traffic_split")
+	ErrMaxDimensions      = errors.New("max_dimensions must be zero or positive")
+	ErrMirror             = errors.New("invalid mirror")
+	ErrFallbackVision     = errors.New("invalid fallback_vision_alias")
+	ErrCachedPriceRatio   = errors.New("invalid cached_token_price_ratio")
+	ErrErrorMapping       = errors.New("invalid error_mapping")
+	ErrCurrency           = errors.New("invalid currency")
 )
 
 // ReloadFunc triggers a router reload after catalog changes.
@@ -26,36 +38,51 @@ type ReloadFunc func(ctx context.Context) error
 
 // Service wraps admin model catalog operations.
 type Service struct {
+	pool    *pgxpool.Pool
 	queries *db.Queries
 	reload  ReloadFunc
 }
 
 // NewService constructs a catalog service.
-func NewService(queries *db.Queries, reload ReloadFunc) *Service {
-	return &Service{queries: queries, reload: reload}
+func NewService(pool *pgxpool.Pool, queries *db.Queries, reload ReloadFunc) *Service {
+	return &Service{pool: pool, queries: queries, reload: reload}
 }
 
 // ModelPayload represents the upsert request body.
 type ModelPayload struct {
-	Alias           string            `json:"alias"`
-	Provider        string            `json:"provider"`
-	ProviderModel   string            `json:"provider_model"`
-	ModelType       string            `json:"model_type"`
-	ContextWindow   int32             `json:"context_window"`
-	MaxOutputTokens int32             `json:"max_output_tokens"`
-	Modalities      []string          `json:"modalities"`
-	SupportsTools   bool              `json:"supports_tools"`
-	PriceInput      float64           `json:"price_input"`
-	PriceOutput     float64           `json:"price_output"`
-	Currency        string            `json:"currency"`
-	Deployment      string            `json:"deployment"`
-	Endpoint        string            `json:"endpoint"`
-	APIKey          string            `json:"api_key"`
-	APIVersion      string            `json:"api_version"`
-	Region          string            `json:"region"`
-	Weight          int32             `json:"weight"`
-	Enabled         bool              `json:"enabled"`
-	Metadata        map[string]string `json:"metadata"`
+	Alias           string                     `json:"alias"`
+	Provider        string                     `json:"provider"`
+	ProviderModel   string                     `json:"provider_model"`
+	ModelType       string                     `json:"model_type"`
+	ContextWindow   int32                      `json:"context_window"`
+	MaxOutputTokens int32                      `json:"max_output_tokens"`
+	MaxDimensions   int32                      `json:"max_dimensions"`
+	Modalities      []string                   `json:"modalities"`
+	SupportsTools   bool                       `json:"supports_tools"`
+	PriceInput      float64                    `json:"price_input"`
+	PriceOutput     float64                    `json:"price_output"`
+	Currency        string                     `json:"currency"`
+	Deployment      string                     `json:"deployment"`
+	Endpoint        string                     `json:"endpoint"`
+	APIKey          string                     `json:"api_key"`
+	APIVersion      string                     `json:"api_version"`
+	Region          string                     `json:"region"`
+	Weight          int32                      `json:"weight"`
+	Enabled         bool                       `json:"enabled"`
+	Metadata        map[string]string          `json:"metadata"`
+	RoutingPolicy   string                     `json:"routing_policy"`
+	TrafficSplit    []config.TrafficSplitEntry `json:"traffic_split"`
+	// MirrorAlias and MirrorSampleRate shadow traffic to a second model; see
+	// config.ModelCatalogEntry.
+	MirrorAlias      string  `json:"mirror_alias"`
+	MirrorSampleRate float64 `json:"mirror_sample_rate"`
+	// SupportsVision and FallbackVisionAlias route image requests; see
+	// config.ModelCatalogEntry.
+	SupportsVision      bool   `json:"supports_vision"`
+	FallbackVisionAlias string `json:"fallback_vision_alias"`
+	// CachedTokenPriceRatio discounts cached prompt tokens; zero means the
+	// default. See config.ModelCatalogEntry.
+	CachedTokenPriceRatio float64 `json:"cached_token_price_ratio"`
 	config.ProviderOverrides
 }
 
@@ -75,8 +102,10 @@ func (s *Service) List(ctx context.Context) ([]db.ModelCatalog, error) {
 }
 
 // Upsert validates and saves a catalog entry, reloading the router afterwards.
-func (s *Service) Upsert(ctx context.Context, payload ModelPayload) (db.ModelCatalog, error) {
-	if s == nil || s.queries == nil {
+// Price changes to an existing entry are recorded in the price history with
+// actorID as the author; uuid.Nil leaves the author unset.
+func (s *Service) Upsert(ctx context.Context, payload ModelPayload, actorID uuid.UUID) (db.ModelCatalog, error) {
+	if s == nil || s.queries == nil || s.pool == nil {
 		return db.ModelCatalog{}, ErrServiceUnavailable
 	}
 	alias := strings.TrimSpace(payload.Alias)
@@ -102,6 +131,39 @@ func (s *Service) Upsert(ctx context.Context, payload ModelPayload) (db.ModelCat
 	if payload.Metadata == nil {
 		payload.Metadata = map[string]string{}
 	}
+	routingPolicy, err := config.NormalizeRoutingPolicy(payload.RoutingPolicy)
+	if err != nil {
+		return db.ModelCatalog{}, ErrRoutingPolicy
+	}
+	trafficSplit, err := config.NormalizeTrafficSplit(payload.TrafficSplit)
+	if err != nil {
+		return db.ModelCatalog{}, fmt.Errorf("%w: %v", ErrTrafficSplit, err)
+	}
+	if trafficSplit == nil {
+		trafficSplit = []config.TrafficSplitEntry{}
+	}
+	if payload.MaxDimensions < 0 {
+		return db.ModelCatalog{}, ErrMaxDimensions
+	}
+	mirrorAlias, err := config.NormalizeMirror(alias, payload.MirrorAlias, payload.MirrorSampleRate)
+	if err != nil {
+		return db.ModelCatalog{}, fmt.Errorf("%w: %v", ErrMirror, err)
+	}
+	fallbackVision, err := config.NormalizeFallbackVision(alias, payload.FallbackVisionAlias)
+	if err != nil {
+		return db.ModelCatalog{}, fmt.Errorf("%w: %v", ErrFallbackVision, err)
+	}
+	cachedRatio, err := config.NormalizeCachedTokenPriceRatio(payload.CachedTokenPriceRatio)
+	if err != nil {
+		return db.ModelCatalog{}, fmt.Errorf("%w: %v", ErrCachedPriceRatio, err)
+	}
+	catalogCurrency, err := currency.Normalize(payload.Currency)
+	if err != nil {
+		return db.ModelCatalog{}, fmt.Errorf("%w: %v", ErrCurrency, err)
+	}
+	if err := config.ValidateErrorMapping(payload.ProviderOverrides.ErrorMapping); err != nil {
+		return db.ModelCatalog{}, fmt.Errorf("%w: %v", ErrErrorMapping, err)
+	}
 
 	switch provider {
 	case "azure":
@@ -171,34 +233,47 @@ func (s *Service) Upsert(ctx context.Context, payload ModelPayload) (db.ModelCat
 	if err != nil {
 		return db.ModelCatalog{}, err
 	}
+	trafficSplitJSON, err := json.Marshal(trafficSplit)
+	if err != nil {
+		return db.ModelCatalog{}, err
+	}
 
 	params := db.UpsertModelCatalogEntryParams{
-		Alias:              alias,
-		Provider:           provider,
-		ProviderModel:      model,
-		ModelType:          modelType,
-		ContextWindow:      payload.ContextWindow,
-		MaxOutputTokens:    payload.MaxOutputTokens,
-		ModalitiesJson:     modalitiesJSON,
-		SupportsTools:      payload.SupportsTools,
-		PriceInput:         decimal.NewFromFloat(payload.PriceInput),
-		PriceOutput:        decimal.NewFromFloat(payload.PriceOutput),
-		Currency:           strings.ToUpper(strings.TrimSpace(payload.Currency)),
-		Enabled:            payload.Enabled,
-		Deployment:         deployment,
-		Endpoint:           endpoint,
-		ApiKey:             apiKey,
-		ApiVersion:         apiVersion,
-		Region:             region,
-		MetadataJson:       metadataJSON,
-		Weight:             payload.Weight,
-		ProviderConfigJson: providerConfigJSON,
-	}
-	if params.Currency == "" {
-		params.Currency = "USD"
-	}
-
-	entry, err := s.queries.UpsertModelCatalogEntry(ctx, params)
+		Alias:               alias,
+		Provider:            provider,
+		ProviderModel:       model,
+		ModelType:           modelType,
+		ContextWindow:       payload.ContextWindow,
+		MaxOutputTokens:     payload.MaxOutputTokens,
+		ModalitiesJson:      modalitiesJSON,
+		SupportsTools:       payload.SupportsTools,
+		PriceInput:          decimal.NewFromFloat(payload.PriceInput),
+		PriceOutput:         decimal.NewFromFloat(payload.PriceOutput),
+		Currency:            catalogCurrency,
+		Enabled:             payload.Enabled,
+		Deployment:          deployment,
+		Endpoint:            endpoint,
+		ApiKey:              apiKey,
+		ApiVersion:          apiVersion,
+		Region:              region,
+		MetadataJson:        metadataJSON,
+		Weight:              payload.Weight,
+		ProviderConfigJson:  providerConfigJSON,
+		RoutingPolicy:       routingPolicy,
+		TrafficSplitJson:    trafficSplitJSON,
+		MaxDimensions:       payload.MaxDimensions,
+		MirrorAlias:         mirrorAlias,
+		MirrorSampleRate:    decimal.NewFromFloat(payload.MirrorSampleRate),
+		SupportsVision:      payload.SupportsVision,
+		FallbackVisionAlias: fallbackVision,
+		CachedPriceRatio:    decimal.NewFromFloat(cachedRatio),
+	}
+	// Deprecation is managed through SetDeprecation; keep whatever the
+	// existing entry has so edits do not clear a scheduled removal.
+	entry, err := SaveEntry(ctx, s.pool, s.queries, params, actorID, func(existing db.ModelCatalog, params *db.UpsertModelCatalogEntryParams) {
+		params.DeprecatedAt = existing.DeprecatedAt
+		params.DeprecationMessage = existing.DeprecationMessage
+	})
 	if err != nil {
 		return db.ModelCatalog{}, err
 	}
@@ -0,0 +1,163 @@
+package adminconfig
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/auth"
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+// maskedSecret replaces the client secret in responses. Sending it back
+// unchanged in an update keeps the stored secret.
+const maskedSecret = "********"
+
+var (
+	ErrInvalidOIDCConfig = errors.New("invalid oidc config")
+	ErrOIDCDiscovery     = errors.New("oidc discovery failed")
+)
+
+// OIDCSettings is the runtime-editable view of config.OIDCConfig.
+type OIDCSettings struct {
+	Enabled            bool     `json:"enabled"`
+	Issuer             string   `json:"issuer"`
+	ClientID           string   `json:"client_id"`
+	ClientSecret       string   `json:"client_secret"`
+	RedirectURL        string   `json:"redirect_url"`
+	Scopes             []string `json:"scopes"`
+	AllowedDomains     []string `json:"allowed_domains"`
+	HTTPTimeoutSeconds int      `json:"http_timeout_seconds"`
+	RolesClaim         string   `json:"roles_claim"`
+	AllowedRoles       []string `json:"allowed_roles"`
+	AdminRoles         []string `json:"admin_roles"`
+}
+
+// GetOIDCConfig returns the active OIDC config with the client secret masked.
+func (s *Service) GetOIDCConfig() OIDCSettings {
+	return oidcSettingsFromConfig(s.cfg.Admin.OIDC).masked()
+}
+
+// UpdateOIDCConfig validates req, re-initialises the OIDC provider with it
+// and stores it as an override of the file/env config. An empty or masked
+// client secret keeps the current one.
+func (s *Service) UpdateOIDCConfig(ctx context.Context, req OIDCSettings, updatedBy uuid.UUID) (OIDCSettings, error) {
+	if s.adminAuth == nil {
+		return OIDCSettings{}, errors.New("admin auth unavailable")
+	}
+	next := req.apply(s.cfg.Admin.OIDC)
+	if err := validateOIDCConfig(s.cfg.Admin, next); err != nil {
+		return OIDCSettings{}, err
+	}
+	if err := s.adminAuth.ReloadOIDC(ctx, next); err != nil {
+		return OIDCSettings{}, fmt.Errorf("%w: %v", ErrOIDCDiscovery, err)
+	}
+	s.cfg.Admin.OIDC = next
+
+	stored := oidcSettingsFromConfig(next)
+	payload, _ := json.Marshal(stored)
+	if _, err := s.queries.UpsertSystemSetting(ctx, db.UpsertSystemSettingParams{
+		Key:       oidcSettingKey,
+		Value:     payload,
+		UpdatedBy: toPgUUID(updatedBy),
+	}); err != nil {
+		return OIDCSettings{}, err
+	}
+	return stored.masked(), nil
+}
+
+// TestOIDCConfig fetches the discovery document for req (merged over the
+// active config) without applying it.
+func (s *Service) TestOIDCConfig(ctx context.Context, req OIDCSettings) error {
+	cfg := req.apply(s.cfg.Admin.OIDC)
+	if cfg.Issuer == "" {
+		return fmt.Errorf("%w: issuer is required", ErrInvalidOIDCConfig)
+	}
+	if cfg.HTTPTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.HTTPTimeout)
+		defer cancel()
+	}
+	if _, err := auth.NewOIDCProvider(ctx, cfg); err != nil {
+		return fmt.Errorf("%w: %v", ErrOIDCDiscovery, err)
+	}
+	return nil
+}
+
+func oidcSettingsFromConfig(cfg config.OIDCConfig) OIDCSettings {
+	return OIDCSettings{
+		Enabled:            cfg.Enabled,
+		Issuer:             cfg.Issuer,
+		ClientID:           cfg.ClientID,
+		ClientSecret:       cfg.ClientSecret,
+		RedirectURL:        cfg.RedirectURL,
+		Scopes:             cfg.Scopes,
+		AllowedDomains:     cfg.AllowedDomains,
+		HTTPTimeoutSeconds: int(cfg.HTTPTimeout / time.Second),
+		RolesClaim:         cfg.RolesClaim,
+		AllowedRoles:       cfg.AllowedRoles,
+		AdminRoles:         cfg.AdminRoles,
+	}
+}
+
+func (o OIDCSettings) masked() OIDCSettings {
+	if o.ClientSecret != "" {
+		o.ClientSecret = maskedSecret
+	}
+	return o
+}
+
+// apply returns base overridden by o. The client secret and HTTP timeout
+// fall back to base when unset.
+func (o OIDCSettings) apply(base config.OIDCConfig) config.OIDCConfig {
+	out := config.OIDCConfig{
+		Enabled:        o.Enabled,
+		Issuer:         strings.TrimSpace(o.Issuer),
+		ClientID:       strings.TrimSpace(o.ClientID),
+		ClientSecret:   o.ClientSecret,
+		RedirectURL:    strings.TrimSpace(o.RedirectURL),
+		Scopes:         o.Scopes,
+		AllowedDomains: o.AllowedDomains,
+		HTTPTimeout:    time.Duration(o.HTTPTimeoutSeconds) * time.Second,
+		RolesClaim:     strings.TrimSpace(o.RolesClaim),
+		AllowedRoles:   o.AllowedRoles,
+		AdminRoles:     o.AdminRoles,
+	}
+	if out.ClientSecret == "" || out.ClientSecret == maskedSecret {
+		out.ClientSecret = base.ClientSecret
+	}
+	if out.HTTPTimeout <= 0 {
+		out.HTTPTimeout = base.HTTPTimeout
+	}
+	return out
+}
+
+// validateOIDCConfig mirrors the startup checks in config.AdminConfig and
+// refuses to disable the last enabled login method.
+func validateOIDCConfig(admin config.AdminConfig, cfg config.OIDCConfig) error {
+	if !cfg.Enabled {
+		if !admin.Local.Enabled && !admin.SAML.Enabled {
+			return fmt.Errorf("%w: at least one admin authentication method must stay enabled", ErrInvalidOIDCConfig)
+		}
+		return nil
+	}
+	switch {
+	case cfg.Issuer == "":
+		return fmt.Errorf("%w: issuer is required", ErrInvalidOIDCConfig)
+	case cfg.ClientID == "":
+		return fmt.Errorf("%w: client_id is required", ErrInvalidOIDCConfig)
+	case cfg.ClientSecret == "":
+		return fmt.Errorf("%w: client_secret is required", ErrInvalidOIDCConfig)
+	case cfg.RedirectURL == "":
+		return fmt.Errorf("%w: redirect_url is required", ErrInvalidOIDCConfig)
+	case cfg.HTTPTimeout <= 0:
+		return fmt.Errorf("%w: http_timeout_seconds must be > 0", ErrInvalidOIDCConfig)
+	}
+	return nil
+}
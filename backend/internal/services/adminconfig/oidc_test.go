@@ -0,0 +1,66 @@
+package adminconfig
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+)
+
+func TestOIDCSettingsApplyKeepsSecret(t *testing.T) {
+	base := config.OIDCConfig{ClientSecret: "s3cret", HTTPTimeout: 5 * time.Second}
+
+	for _, secret := range []string{"", maskedSecret} {
+		got := OIDCSettings{Enabled: true, Issuer: " https://idp.example.com ", ClientSecret: secret}.apply(base)
+		if got.ClientSecret != "s3cret" {
+			t.Fatalf("secret %q: expected stored secret, got %q", secret, got.ClientSecret)
+		}
+		if got.Issuer != "https://idp.example.com" {
+			t.Fatalf("expected trimmed issuer, got %q", got.Issuer)
+		}
+		if got.HTTPTimeout != 5*time.Second {
+			t.Fatalf("expected base timeout, got %s", got.HTTPTimeout)
+		}
+	}
+
+	got := OIDCSettings{ClientSecret: "rotated", HTTPTimeoutSeconds: 10}.apply(base)
+	if got.ClientSecret != "rotated" || got.HTTPTimeout != 10*time.Second {
+		t.Fatalf("expected overrides to apply, got %+v", got)
+	}
+}
+
+func TestOIDCSettingsMasked(t *testing.T) {
+	settings := oidcSettingsFromConfig(config.OIDCConfig{ClientSecret: "s3cret"}).masked()
+	if settings.ClientSecret != maskedSecret {
+		t.Fatalf("expected masked secret, got %q", settings.ClientSecret)
+	}
+	if empty := (OIDCSettings{}).masked(); empty.ClientSecret != "" {
+		t.Fatalf("expected unset secret to stay empty, got %q", empty.ClientSecret)
+	}
+}
+
+func TestValidateOIDCConfig(t *testing.T) {
+	valid := config.OIDCConfig{
+		Enabled:      true,
+		Issuer:       "https://idp.example.com",
+		ClientID:     "gateway",
+		ClientSecret: "s3cret",
+		RedirectURL:  "https://gateway.example.com/callback",
+		HTTPTimeout:  5 * time.Second,
+	}
+	admin := config.AdminConfig{Local: config.LocalAuthConfig{Enabled: true}}
+	if err := validateOIDCConfig(admin, valid); err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+
+	missing := valid
+	missing.ClientID = ""
+	if err := validateOIDCConfig(admin, missing); !errors.Is(err, ErrInvalidOIDCConfig) {
+		t.Fatalf("expected ErrInvalidOIDCConfig, got %v", err)
+	}
+
+	if err := validateOIDCConfig(config.AdminConfig{}, config.OIDCConfig{}); !errors.Is(err, ErrInvalidOIDCConfig) {
+		t.Fatalf("expected disabling the only login method to fail, got %v", err)
+	}
+}
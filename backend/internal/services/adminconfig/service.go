@@ -12,6 +12,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
+	"github.com/ncecere/open_model_gateway/backend/internal/auth"
 	"github.com/ncecere/open_model_gateway/backend/internal/config"
 	"github.com/ncecere/open_model_gateway/backend/internal/db"
 	batchsvc "github.com/ncecere/open_model_gateway/backend/internal/services/batches"
@@ -23,17 +24,19 @@ const (
 	filesSettingKey   = "files_config"
 	batchesSettingKey = "batches_config"
 	alertsSettingKey  = "alert_transport_config"
+	oidcSettingKey    = "oidc"
 )
 
 type Service struct {
-	queries *db.Queries
-	cfg     *config.Config
-	files   *filesvc.Service
-	batches *batchsvc.Service
+	queries   *db.Queries
+	cfg       *config.Config
+	files     *filesvc.Service
+	batches   *batchsvc.Service
+	adminAuth *auth.AdminAuthService
 }
 
-func NewService(queries *db.Queries, cfg *config.Config, files *filesvc.Service, batches *batchsvc.Service) *Service {
-	return &Service{queries: queries, cfg: cfg, files: files, batches: batches}
+func NewService(queries *db.Queries, cfg *config.Config, files *filesvc.Service, batches *batchsvc.Service, adminAuth *auth.AdminAuthService) *Service {
+	return &Service{queries: queries, cfg: cfg, files: files, batches: batches, adminAuth: adminAuth}
 }
 
 type FileSettings struct {
@@ -191,7 +194,8 @@ func toPgUUID(id uuid.UUID) pgtype.UUID {
 	return out
 }
 
-// ApplyOverrides loads stored file/batch settings and mutates cfg accordingly.
+// ApplyOverrides loads stored file/batch/alert/OIDC settings and mutates cfg
+// accordingly.
 func ApplyOverrides(ctx context.Context, queries *db.Queries, cfg *config.Config) {
 	if cfg == nil || queries == nil {
 		return
@@ -236,4 +240,10 @@ func ApplyOverrides(ctx context.Context, queries *db.Queries, cfg *config.Config
 			cfg.Budgets.Alert.Webhook = payload.Webhook
 		}
 	}
+	if setting, err := queries.GetSystemSetting(ctx, oidcSettingKey); err == nil {
+		var payload OIDCSettings
+		if err := json.Unmarshal(setting.Value, &payload); err == nil {
+			cfg.Admin.OIDC = payload.apply(cfg.Admin.OIDC)
+		}
+	}
 }
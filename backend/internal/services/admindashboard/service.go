@@ -0,0 +1,231 @@
+// Package admindashboard assembles the admin UI's overview widgets into a
+// single cached snapshot.
+package admindashboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/services/usagepipeline"
+)
+
+const (
+	cacheKey       = "admin_dashboard"
+	cacheTTL       = 60 * time.Second
+	window         = 24 * time.Hour
+	topModelErrors = 5
+	maxBudgetRows  = 10
+)
+
+var ErrServiceUnavailable = errors.New("admin dashboard service not initialized")
+
+type dashboardQueries interface {
+	CountActiveAPIKeys(context.Context) (int64, error)
+	CountActiveTenants(context.Context) (int64, error)
+	ListModelErrorRates(context.Context, db.ListModelErrorRatesParams) ([]db.ListModelErrorRatesRow, error)
+	ListTenantBudgetSpend(context.Context, db.ListTenantBudgetSpendParams) ([]db.ListTenantBudgetSpendRow, error)
+	SummarizeRequestsSince(context.Context, pgtype.Timestamptz) (db.SummarizeRequestsSinceRow, error)
+}
+
+// Service builds dashboard snapshots. Budget defaults are read from cfg on
+// every build so admin edits apply without a restart.
+type Service struct {
+	queries dashboardQueries
+	redis   *redis.Client
+	cfg     *config.Config
+}
+
+func NewService(queries dashboardQueries, redisClient *redis.Client, cfg *config.Config) *Service {
+	return &Service{queries: queries, redis: redisClient, cfg: cfg}
+}
+
+// Snapshot is the payload served by GET /admin/dashboard.
+type Snapshot struct {
+	AsOf                   time.Time           `json:"as_of"`
+	ActiveTenantsCount     int64               `json:"active_tenants_count"`
+	ActiveAPIKeysCount     int64               `json:"active_api_keys_count"`
+	RequestsLast24h        int64               `json:"requests_last_24h"`
+	TokensLast24h          int64               `json:"tokens_last_24h"`
+	CostLast24hUSD         float64             `json:"cost_last_24h_usd"`
+	P95LatencyMs           float64             `json:"p95_latency_ms"`
+	ModelErrorRateTop5     []ModelError        `json:"model_error_rate_top5"`
+	BudgetNearLimitTenants []TenantBudgetAlert `json:"budget_near_limit_tenants"`
+}
+
+// ModelError is a model's error rate over the last 24 hours.
+type ModelError struct {
+	ModelAlias string  `json:"model_alias"`
+	Requests   int64   `json:"requests"`
+	Errors     int64   `json:"errors"`
+	ErrorRate  float64 `json:"error_rate"`
+}
+
+// TenantBudgetAlert is a tenant whose spend this budget period has reached its
+// warning threshold.
+type TenantBudgetAlert struct {
+	TenantID   uuid.UUID `json:"tenant_id"`
+	TenantName string    `json:"tenant_name"`
+	SpentUSD   float64   `json:"spent_usd"`
+	BudgetUSD  float64   `json:"budget_usd"`
+	UsedPerc   float64   `json:"used_perc"`
+	Exceeded   bool      `json:"exceeded"`
+}
+
+// Get returns the cached snapshot, rebuilding it when the cache is empty or
+// Redis is unavailable.
+func (s *Service) Get(ctx context.Context, now time.Time) (Snapshot, error) {
+	if s == nil || s.queries == nil {
+		return Snapshot{}, ErrServiceUnavailable
+	}
+	if s.redis != nil {
+		if raw, err := s.redis.Get(ctx, cacheKey).Bytes(); err == nil {
+			var cached Snapshot
+			if err := json.Unmarshal(raw, &cached); err == nil {
+				return cached, nil
+			}
+		}
+	}
+
+	snapshot, err := s.Build(ctx, now)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	if s.redis != nil {
+		if raw, err := json.Marshal(snapshot); err == nil {
+			if err := s.redis.Set(ctx, cacheKey, raw, cacheTTL).Err(); err != nil {
+				slog.Warn("cache admin dashboard failed", slog.String("error", err.Error()))
+			}
+		}
+	}
+	return snapshot, nil
+}
+
+// Build runs every dashboard query concurrently and assembles the snapshot.
+func (s *Service) Build(ctx context.Context, now time.Time) (Snapshot, error) {
+	if s == nil || s.queries == nil {
+		return Snapshot{}, ErrServiceUnavailable
+	}
+	now = now.UTC()
+	since := pgtype.Timestamptz{Time: now.Add(-window), Valid: true}
+	snapshot := Snapshot{AsOf: now}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		count, err := s.queries.CountActiveTenants(gctx)
+		snapshot.ActiveTenantsCount = count
+		return err
+	})
+	g.Go(func() error {
+		count, err := s.queries.CountActiveAPIKeys(gctx)
+		snapshot.ActiveAPIKeysCount = count
+		return err
+	})
+	g.Go(func() error {
+		summary, err := s.queries.SummarizeRequestsSince(gctx, since)
+		if err != nil {
+			return err
+		}
+		snapshot.RequestsLast24h = summary.Requests
+		snapshot.TokensLast24h = summary.Tokens
+		snapshot.CostLast24hUSD = float64(summary.CostUsdMicros) / 1_000_000
+		snapshot.P95LatencyMs = summary.P95LatencyMs
+		return nil
+	})
+	g.Go(func() error {
+		rows, err := s.queries.ListModelErrorRates(gctx, db.ListModelErrorRatesParams{Ts: since, Limit: topModelErrors})
+		if err != nil {
+			return err
+		}
+		snapshot.ModelErrorRateTop5 = toModelErrors(rows)
+		return nil
+	})
+	g.Go(func() error {
+		alerts, err := s.budgetAlerts(gctx, now)
+		snapshot.BudgetNearLimitTenants = alerts
+		return err
+	})
+	if err := g.Wait(); err != nil {
+		return Snapshot{}, err
+	}
+	return snapshot, nil
+}
+
+func (s *Service) budgetAlerts(ctx context.Context, now time.Time) ([]TenantBudgetAlert, error) {
+	var defaults config.BudgetConfig
+	if s.cfg != nil {
+		defaults = s.cfg.Budgets
+	}
+	start, end := usagepipeline.BudgetPeriod(now, defaults.RefreshSchedule)
+	rows, err := s.queries.ListTenantBudgetSpend(ctx, db.ListTenantBudgetSpendParams{
+		Ts:   pgtype.Timestamptz{Time: start, Valid: true},
+		Ts_2: pgtype.Timestamptz{Time: end, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nearLimit(rows, defaults), nil
+}
+
+// nearLimit keeps the tenants at or past their warning threshold, most
+// consumed first. Tenants without an override use the default budget.
+func nearLimit(rows []db.ListTenantBudgetSpendRow, defaults config.BudgetConfig) []TenantBudgetAlert {
+	alerts := []TenantBudgetAlert{}
+	for _, row := range rows {
+		budget, _ := row.BudgetUsd.Float64()
+		if budget <= 0 {
+			budget = defaults.DefaultUSD
+		}
+		if budget <= 0 {
+			continue
+		}
+		warn, _ := row.WarningThreshold.Float64()
+		if warn <= 0 {
+			warn = defaults.WarningThresholdPerc
+		}
+		spent := float64(row.SpentCents) / 100
+		used := spent / budget
+		if used < warn {
+			continue
+		}
+		alerts = append(alerts, TenantBudgetAlert{
+			TenantID:   uuid.UUID(row.TenantID.Bytes),
+			TenantName: row.Name,
+			SpentUSD:   spent,
+			BudgetUSD:  budget,
+			UsedPerc:   used,
+			Exceeded:   used >= 1,
+		})
+	}
+	sort.Slice(alerts, func(i, j int) bool { return alerts[i].UsedPerc > alerts[j].UsedPerc })
+	if len(alerts) > maxBudgetRows {
+		alerts = alerts[:maxBudgetRows]
+	}
+	return alerts
+}
+
+func toModelErrors(rows []db.ListModelErrorRatesRow) []ModelError {
+	out := make([]ModelError, 0, len(rows))
+	for _, row := range rows {
+		if row.Requests == 0 {
+			continue
+		}
+		out = append(out, ModelError{
+			ModelAlias: row.ModelAlias,
+			Requests:   row.Requests,
+			Errors:     row.Errors,
+			ErrorRate:  float64(row.Errors) / float64(row.Requests),
+		})
+	}
+	return out
+}
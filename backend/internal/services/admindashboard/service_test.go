@@ -0,0 +1,114 @@
+package admindashboard
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/redis/go-redis/v9"
+	decimal "github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+func TestBuildAssemblesSnapshot(t *testing.T) {
+	near := uuid.New()
+	over := uuid.New()
+	queries := &fakeQueries{
+		summary: db.SummarizeRequestsSinceRow{Requests: 120, Tokens: 5400, CostUsdMicros: 2_500_000, P95LatencyMs: 830},
+		errors: []db.ListModelErrorRatesRow{
+			{ModelAlias: "gpt-4o", Requests: 40, Errors: 10},
+			{ModelAlias: "claude", Requests: 80, Errors: 4},
+		},
+		spend: []db.ListTenantBudgetSpendRow{
+			// Default budget of $100 with a 0.8 threshold.
+			{TenantID: pgtype.UUID{Bytes: near, Valid: true}, Name: "near", SpentCents: 8_500},
+			{TenantID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, Name: "quiet", SpentCents: 1_000},
+			{TenantID: pgtype.UUID{Bytes: over, Valid: true}, Name: "over", BudgetUsd: decimal.NewFromInt(20), WarningThreshold: decimal.NewFromFloat(0.5), SpentCents: 2_400},
+		},
+	}
+	cfg := &config.Config{Budgets: config.BudgetConfig{DefaultUSD: 100, WarningThresholdPerc: 0.8, RefreshSchedule: "calendar_month"}}
+	svc := NewService(queries, nil, cfg)
+	now := time.Date(2025, 11, 17, 12, 0, 0, 0, time.UTC)
+
+	snapshot, err := svc.Build(context.Background(), now)
+	require.NoError(t, err)
+	require.Equal(t, now, snapshot.AsOf)
+	require.Equal(t, int64(7), snapshot.ActiveTenantsCount)
+	require.Equal(t, int64(31), snapshot.ActiveAPIKeysCount)
+	require.Equal(t, int64(120), snapshot.RequestsLast24h)
+	require.Equal(t, int64(5400), snapshot.TokensLast24h)
+	require.InDelta(t, 2.5, snapshot.CostLast24hUSD, 1e-9)
+	require.Equal(t, 830.0, snapshot.P95LatencyMs)
+	require.Equal(t, now.Add(-24*time.Hour), queries.since)
+
+	require.Len(t, snapshot.ModelErrorRateTop5, 2)
+	require.InDelta(t, 0.25, snapshot.ModelErrorRateTop5[0].ErrorRate, 1e-9)
+
+	require.Len(t, snapshot.BudgetNearLimitTenants, 2)
+	require.Equal(t, over, snapshot.BudgetNearLimitTenants[0].TenantID)
+	require.True(t, snapshot.BudgetNearLimitTenants[0].Exceeded)
+	require.Equal(t, near, snapshot.BudgetNearLimitTenants[1].TenantID)
+	require.False(t, snapshot.BudgetNearLimitTenants[1].Exceeded)
+	require.Equal(t, time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC), queries.periodStart)
+}
+
+func TestGetCachesSnapshot(t *testing.T) {
+	server, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+	rdb := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+
+	queries := &fakeQueries{}
+	svc := NewService(queries, rdb, &config.Config{})
+	now := time.Date(2025, 11, 17, 12, 0, 0, 0, time.UTC)
+
+	first, err := svc.Get(context.Background(), now)
+	require.NoError(t, err)
+	second, err := svc.Get(context.Background(), now.Add(30*time.Second))
+	require.NoError(t, err)
+	require.Equal(t, first.AsOf, second.AsOf)
+	require.Equal(t, int32(1), queries.builds.Load())
+
+	server.FastForward(61 * time.Second)
+	_, err = svc.Get(context.Background(), now.Add(61*time.Second))
+	require.NoError(t, err)
+	require.Equal(t, int32(2), queries.builds.Load())
+}
+
+type fakeQueries struct {
+	summary     db.SummarizeRequestsSinceRow
+	errors      []db.ListModelErrorRatesRow
+	spend       []db.ListTenantBudgetSpendRow
+	since       time.Time
+	periodStart time.Time
+	builds      atomic.Int32
+}
+
+func (f *fakeQueries) CountActiveAPIKeys(context.Context) (int64, error) { return 31, nil }
+
+func (f *fakeQueries) CountActiveTenants(context.Context) (int64, error) {
+	f.builds.Add(1)
+	return 7, nil
+}
+
+func (f *fakeQueries) ListModelErrorRates(_ context.Context, arg db.ListModelErrorRatesParams) ([]db.ListModelErrorRatesRow, error) {
+	return f.errors, nil
+}
+
+func (f *fakeQueries) ListTenantBudgetSpend(_ context.Context, arg db.ListTenantBudgetSpendParams) ([]db.ListTenantBudgetSpendRow, error) {
+	f.periodStart = arg.Ts.Time
+	return f.spend, nil
+}
+
+func (f *fakeQueries) SummarizeRequestsSince(_ context.Context, ts pgtype.Timestamptz) (db.SummarizeRequestsSinceRow, error) {
+	f.since = ts.Time
+	return f.summary, nil
+}
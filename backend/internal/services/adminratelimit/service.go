@@ -49,12 +49,13 @@ func (s *Service) UpdateDefaults(ctx context.Context, req DefaultUpdate) (config
 		return config.RateLimitConfig{}, err
 	}
 
-	updated := config.RateLimitConfig{
-		DefaultRequestsPerMinute:      req.RequestsPerMinute,
-		DefaultTokensPerMinute:        req.TokensPerMinute,
-		DefaultParallelRequestsKey:    req.ParallelRequestsKey,
-		DefaultParallelRequestsTenant: req.ParallelRequestsTenant,
-	}
+	// Settings outside the stored defaults, such as the parallel request
+	// TTL, keep their configured values.
+	updated := s.cfg.RateLimits
+	updated.DefaultRequestsPerMinute = req.RequestsPerMinute
+	updated.DefaultTokensPerMinute = req.TokensPerMinute
+	updated.DefaultParallelRequestsKey = req.ParallelRequestsKey
+	updated.DefaultParallelRequestsTenant = req.ParallelRequestsTenant
 	s.cfg.RateLimits = updated
 	return updated, nil
 }
@@ -2,61 +2,271 @@ package adminrbac
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/ncecere/open_model_gateway/backend/internal/db"
 	"github.com/ncecere/open_model_gateway/backend/internal/rbac"
 )
 
+type rbacQueries interface {
+	rbac.PermissionQuerier
+	ListPermissions(ctx context.Context) ([]db.Permission, error)
+	ListRoles(ctx context.Context) ([]db.Role, error)
+	InsertRole(ctx context.Context, arg db.InsertRoleParams) (db.Role, error)
+	UpdateRole(ctx context.Context, arg db.UpdateRoleParams) (db.Role, error)
+	DeleteRole(ctx context.Context, name string) (int64, error)
+	UpsertTenantMembershipRole(ctx context.Context, arg db.UpsertTenantMembershipRoleParams) (db.TenantMembershipRole, error)
+	DeleteTenantMembershipRole(ctx context.Context, arg db.DeleteTenantMembershipRoleParams) (int64, error)
+}
+
 // Service provides RBAC helpers for admin surfaces.
 type Service struct {
-	queries *db.Queries
+	queries rbacQueries
 }
 
-func NewService(queries *db.Queries) *Service {
+func NewService(queries rbacQueries) *Service {
 	return &Service{queries: queries}
 }
 
 var (
-	ErrUnauthorized = errors.New("missing admin context")
-	ErrForbidden    = errors.New("insufficient permissions")
+	ErrUnauthorized       = errors.New("missing admin context")
+	ErrForbidden          = errors.New("insufficient permissions")
+	ErrInvalidRole        = errors.New("invalid role")
+	ErrRoleNotFound       = errors.New("role not found")
+	ErrRoleExists         = errors.New("role already exists")
+	ErrRoleInUse          = errors.New("role is assigned to tenant members")
+	ErrBuiltinRole        = errors.New("built-in roles cannot be deleted or assigned as custom roles")
+	ErrMembershipNotFound = errors.New("membership not found")
 )
 
-// RequireTenantRole ensures the user has the specified role on the tenant.
-func (s *Service) RequireTenantRole(ctx context.Context, tenantID, userID uuid.UUID, role db.MembershipRole, superAdmin bool) error {
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,62}$`)
+
+// Role is a named permission set. Built-in roles back the membership roles
+// and cannot be deleted.
+type Role struct {
+	Name        string
+	Description string
+	Permissions []rbac.Permission
+	Builtin     bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// RoleInput carries the editable fields of a role.
+type RoleInput struct {
+	Name        string
+	Description string
+	Permissions []string
+}
+
+// RequireTenantPermission ensures the user's role on the tenant grants perm.
+func (s *Service) RequireTenantPermission(ctx context.Context, tenantID, userID uuid.UUID, perm rbac.Permission, superAdmin bool) error {
 	if superAdmin {
 		return nil
 	}
 	if s == nil || s.queries == nil {
 		return errors.New("rbac service not initialized")
 	}
-	_, err := rbac.Ensure(ctx, s.queries, tenantID, userID, role)
-	if err != nil {
-		if err == rbac.ErrForbidden || err == pgx.ErrNoRows {
-			return ErrForbidden
-		}
-		return err
-	}
-	return nil
+	return mapEnsureError(rbac.EnsurePermission(ctx, s.queries, tenantID, userID, perm))
 }
 
-// RequireAnyRole checks user has at least given role in any tenant.
-func (s *Service) RequireAnyRole(ctx context.Context, userID uuid.UUID, role db.MembershipRole, superAdmin bool) error {
+// RequireAnyPermission checks that the user's role on any tenant grants perm.
+func (s *Service) RequireAnyPermission(ctx context.Context, userID uuid.UUID, perm rbac.Permission, superAdmin bool) error {
 	if superAdmin {
 		return nil
 	}
 	if s == nil || s.queries == nil {
 		return errors.New("rbac service not initialized")
 	}
-	_, err := rbac.EnsureAny(ctx, s.queries, userID, role)
+	return mapEnsureError(rbac.EnsureAnyPermission(ctx, s.queries, userID, perm))
+}
+
+func mapEnsureError(err error) error {
+	if errors.Is(err, rbac.ErrForbidden) || errors.Is(err, pgx.ErrNoRows) {
+		return ErrForbidden
+	}
+	return err
+}
+
+// ListPermissions returns the permission catalog.
+func (s *Service) ListPermissions(ctx context.Context) ([]db.Permission, error) {
+	return s.queries.ListPermissions(ctx)
+}
+
+// ListRoles returns built-in roles followed by custom roles.
+func (s *Service) ListRoles(ctx context.Context) ([]Role, error) {
+	rows, err := s.queries.ListRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	roles := make([]Role, 0, len(rows))
+	for _, row := range rows {
+		role, err := toRole(row)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+// CreateRole adds a custom role.
+func (s *Service) CreateRole(ctx context.Context, input RoleInput) (Role, error) {
+	name := strings.ToLower(strings.TrimSpace(input.Name))
+	if !roleNamePattern.MatchString(name) {
+		return Role{}, fmt.Errorf("%w: name must be 2-63 lowercase letters, digits, or underscores", ErrInvalidRole)
+	}
+	perms, err := s.normalizePermissions(ctx, input.Permissions)
+	if err != nil {
+		return Role{}, err
+	}
+	row, err := s.queries.InsertRole(ctx, db.InsertRoleParams{
+		Name:        name,
+		Description: strings.TrimSpace(input.Description),
+		Permissions: perms,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return Role{}, ErrRoleExists
+		}
+		return Role{}, err
+	}
+	return toRole(row)
+}
+
+// UpdateRole replaces a role's description and permissions. Built-in roles
+// may be edited to change what each membership role grants.
+func (s *Service) UpdateRole(ctx context.Context, name string, input RoleInput) (Role, error) {
+	perms, err := s.normalizePermissions(ctx, input.Permissions)
+	if err != nil {
+		return Role{}, err
+	}
+	row, err := s.queries.UpdateRole(ctx, db.UpdateRoleParams{
+		Name:        strings.ToLower(strings.TrimSpace(name)),
+		Description: strings.TrimSpace(input.Description),
+		Permissions: perms,
+	})
 	if err != nil {
-		if err == rbac.ErrForbidden || err == pgx.ErrNoRows {
-			return ErrForbidden
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Role{}, ErrRoleNotFound
+		}
+		return Role{}, err
+	}
+	return toRole(row)
+}
+
+// DeleteRole removes a custom role that is not assigned to any membership.
+func (s *Service) DeleteRole(ctx context.Context, name string) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	role, err := s.queries.GetRole(ctx, name)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrRoleNotFound
+		}
+		return err
+	}
+	if role.Builtin {
+		return ErrBuiltinRole
+	}
+	if _, err := s.queries.DeleteRole(ctx, name); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return ErrRoleInUse
 		}
 		return err
 	}
 	return nil
 }
+
+// AssignTenantRole gives a tenant member a custom role, which replaces the
+// permissions of their membership role on that tenant.
+func (s *Service) AssignTenantRole(ctx context.Context, tenantID, userID uuid.UUID, name string) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	role, err := s.queries.GetRole(ctx, name)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrRoleNotFound
+		}
+		return err
+	}
+	if role.Builtin {
+		return ErrBuiltinRole
+	}
+	tenant := pgtype.UUID{Bytes: tenantID, Valid: true}
+	user := pgtype.UUID{Bytes: userID, Valid: true}
+	if _, err := s.queries.GetTenantMembership(ctx, db.GetTenantMembershipParams{TenantID: tenant, UserID: user}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrMembershipNotFound
+		}
+		return err
+	}
+	_, err = s.queries.UpsertTenantMembershipRole(ctx, db.UpsertTenantMembershipRoleParams{
+		TenantID: tenant,
+		UserID:   user,
+		Role:     name,
+	})
+	return err
+}
+
+// ClearTenantRole removes a member's custom role, if any, so their
+// membership role applies again.
+func (s *Service) ClearTenantRole(ctx context.Context, tenantID, userID uuid.UUID) error {
+	_, err := s.queries.DeleteTenantMembershipRole(ctx, db.DeleteTenantMembershipRoleParams{
+		TenantID: pgtype.UUID{Bytes: tenantID, Valid: true},
+		UserID:   pgtype.UUID{Bytes: userID, Valid: true},
+	})
+	return err
+}
+
+// normalizePermissions checks each permission against the catalog and
+// returns the sorted, de-duplicated set encoded for storage.
+func (s *Service) normalizePermissions(ctx context.Context, requested []string) ([]byte, error) {
+	catalog, err := s.queries.ListPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(catalog))
+	for _, perm := range catalog {
+		known[perm.Name] = true
+	}
+	perms := make([]string, 0, len(requested))
+	for _, perm := range requested {
+		perm = strings.ToLower(strings.TrimSpace(perm))
+		if !known[perm] {
+			return nil, fmt.Errorf("%w: unknown permission %q", ErrInvalidRole, perm)
+		}
+		perms = append(perms, perm)
+	}
+	slices.Sort(perms)
+	return json.Marshal(slices.Compact(perms))
+}
+
+func toRole(row db.Role) (Role, error) {
+	perms, err := rbac.DecodePermissions(row.Permissions)
+	if err != nil {
+		return Role{}, err
+	}
+	if perms == nil {
+		perms = []rbac.Permission{}
+	}
+	return Role{
+		Name:        row.Name,
+		Description: row.Description,
+		Permissions: perms,
+		Builtin:     row.Builtin,
+		CreatedAt:   row.CreatedAt.Time,
+		UpdatedAt:   row.UpdatedAt.Time,
+	}, nil
+}
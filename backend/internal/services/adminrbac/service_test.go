@@ -0,0 +1,224 @@
+package adminrbac
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/rbac"
+)
+
+type memoryRBAC struct {
+	permissions []db.Permission
+	roles       map[string]db.Role
+	memberships []db.TenantMembership
+	custom      map[[2]pgtype.UUID]string
+}
+
+func newMemoryRBAC(t *testing.T) *memoryRBAC {
+	t.Helper()
+	q := &memoryRBAC{roles: map[string]db.Role{}, custom: map[[2]pgtype.UUID]string{}}
+	for _, perm := range []rbac.Permission{rbac.PermTenantsRead, rbac.PermTenantsWrite, rbac.PermUsageRead, rbac.PermTenantsCreate} {
+		q.permissions = append(q.permissions, db.Permission{Name: string(perm)})
+	}
+	builtin := map[string][]rbac.Permission{
+		"user":   {},
+		"viewer": {rbac.PermTenantsRead, rbac.PermUsageRead},
+		"admin":  {rbac.PermTenantsCreate, rbac.PermTenantsRead, rbac.PermUsageRead},
+		"owner":  {rbac.PermTenantsCreate, rbac.PermTenantsRead, rbac.PermTenantsWrite, rbac.PermUsageRead},
+	}
+	for name, perms := range builtin {
+		raw, err := json.Marshal(perms)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		q.roles[name] = db.Role{Name: name, Permissions: raw, Builtin: true}
+	}
+	return q
+}
+
+func (q *memoryRBAC) addMember(tenantID, userID uuid.UUID, role db.MembershipRole) {
+	q.memberships = append(q.memberships, db.TenantMembership{
+		TenantID: pgtype.UUID{Bytes: tenantID, Valid: true},
+		UserID:   pgtype.UUID{Bytes: userID, Valid: true},
+		Role:     role,
+	})
+}
+
+func (q *memoryRBAC) GetTenantMembership(_ context.Context, arg db.GetTenantMembershipParams) (db.TenantMembership, error) {
+	for _, m := range q.memberships {
+		if m.TenantID == arg.TenantID && m.UserID == arg.UserID {
+			return m, nil
+		}
+	}
+	return db.TenantMembership{}, pgx.ErrNoRows
+}
+
+func (q *memoryRBAC) ListUserTenants(_ context.Context, userID pgtype.UUID) ([]db.ListUserTenantsRow, error) {
+	var rows []db.ListUserTenantsRow
+	for _, m := range q.memberships {
+		if m.UserID == userID {
+			rows = append(rows, db.ListUserTenantsRow{TenantID: m.TenantID, UserID: m.UserID, Role: m.Role})
+		}
+	}
+	return rows, nil
+}
+
+func (q *memoryRBAC) HasAdminTenantScope(context.Context, db.HasAdminTenantScopeParams) (bool, error) {
+	return false, nil
+}
+
+func (q *memoryRBAC) HasAnyAdminTenantScope(context.Context, pgtype.UUID) (bool, error) {
+	return false, nil
+}
+
+func (q *memoryRBAC) GetRole(_ context.Context, name string) (db.Role, error) {
+	role, ok := q.roles[name]
+	if !ok {
+		return db.Role{}, pgx.ErrNoRows
+	}
+	return role, nil
+}
+
+func (q *memoryRBAC) GetTenantMembershipRole(_ context.Context, arg db.GetTenantMembershipRoleParams) (db.TenantMembershipRole, error) {
+	role, ok := q.custom[[2]pgtype.UUID{arg.TenantID, arg.UserID}]
+	if !ok {
+		return db.TenantMembershipRole{}, pgx.ErrNoRows
+	}
+	return db.TenantMembershipRole{TenantID: arg.TenantID, UserID: arg.UserID, Role: role}, nil
+}
+
+func (q *memoryRBAC) ListUserMembershipRoles(_ context.Context, userID pgtype.UUID) ([]db.TenantMembershipRole, error) {
+	var rows []db.TenantMembershipRole
+	for key, role := range q.custom {
+		if key[1] == userID {
+			rows = append(rows, db.TenantMembershipRole{TenantID: key[0], UserID: key[1], Role: role})
+		}
+	}
+	return rows, nil
+}
+
+func (q *memoryRBAC) ListPermissions(context.Context) ([]db.Permission, error) {
+	return q.permissions, nil
+}
+
+func (q *memoryRBAC) ListRoles(context.Context) ([]db.Role, error) {
+	var rows []db.Role
+	for _, role := range q.roles {
+		rows = append(rows, role)
+	}
+	return rows, nil
+}
+
+func (q *memoryRBAC) InsertRole(_ context.Context, arg db.InsertRoleParams) (db.Role, error) {
+	role := db.Role{Name: arg.Name, Description: arg.Description, Permissions: arg.Permissions}
+	q.roles[arg.Name] = role
+	return role, nil
+}
+
+func (q *memoryRBAC) UpdateRole(_ context.Context, arg db.UpdateRoleParams) (db.Role, error) {
+	role, ok := q.roles[arg.Name]
+	if !ok {
+		return db.Role{}, pgx.ErrNoRows
+	}
+	role.Description = arg.Description
+	role.Permissions = arg.Permissions
+	q.roles[arg.Name] = role
+	return role, nil
+}
+
+func (q *memoryRBAC) DeleteRole(_ context.Context, name string) (int64, error) {
+	if role, ok := q.roles[name]; !ok || role.Builtin {
+		return 0, nil
+	}
+	delete(q.roles, name)
+	return 1, nil
+}
+
+func (q *memoryRBAC) UpsertTenantMembershipRole(_ context.Context, arg db.UpsertTenantMembershipRoleParams) (db.TenantMembershipRole, error) {
+	q.custom[[2]pgtype.UUID{arg.TenantID, arg.UserID}] = arg.Role
+	return db.TenantMembershipRole{TenantID: arg.TenantID, UserID: arg.UserID, Role: arg.Role}, nil
+}
+
+func (q *memoryRBAC) DeleteTenantMembershipRole(_ context.Context, arg db.DeleteTenantMembershipRoleParams) (int64, error) {
+	key := [2]pgtype.UUID{arg.TenantID, arg.UserID}
+	if _, ok := q.custom[key]; !ok {
+		return 0, nil
+	}
+	delete(q.custom, key)
+	return 1, nil
+}
+
+func TestCustomRoleGrantsOnlyItsPermissions(t *testing.T) {
+	ctx := context.Background()
+	queries := newMemoryRBAC(t)
+	svc := NewService(queries)
+	tenantID, userID := uuid.New(), uuid.New()
+	queries.addMember(tenantID, userID, db.MembershipRoleViewer)
+
+	if _, err := svc.CreateRole(ctx, RoleInput{Name: "billing_viewer", Permissions: []string{"usage:read"}}); err != nil {
+		t.Fatalf("create role: %v", err)
+	}
+	if err := svc.AssignTenantRole(ctx, tenantID, userID, "billing_viewer"); err != nil {
+		t.Fatalf("assign role: %v", err)
+	}
+
+	if err := svc.RequireAnyPermission(ctx, userID, rbac.PermUsageRead, false); err != nil {
+		t.Fatalf("expected usage access, got %v", err)
+	}
+	if err := svc.RequireTenantPermission(ctx, tenantID, userID, rbac.PermUsageRead, false); err != nil {
+		t.Fatalf("expected tenant usage access, got %v", err)
+	}
+	// The custom role replaces the viewer membership role, so tenant reads
+	// the viewer role would grant are denied too.
+	for _, perm := range []rbac.Permission{rbac.PermTenantsRead, rbac.PermTenantsWrite} {
+		if err := svc.RequireTenantPermission(ctx, tenantID, userID, perm, false); !errors.Is(err, ErrForbidden) {
+			t.Fatalf("expected %s to be forbidden, got %v", perm, err)
+		}
+	}
+	if err := svc.RequireAnyPermission(ctx, userID, rbac.PermTenantsCreate, false); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("expected tenant creation to be forbidden, got %v", err)
+	}
+
+	if err := svc.ClearTenantRole(ctx, tenantID, userID); err != nil {
+		t.Fatalf("clear role: %v", err)
+	}
+	if err := svc.RequireTenantPermission(ctx, tenantID, userID, rbac.PermTenantsRead, false); err != nil {
+		t.Fatalf("expected viewer access after clearing custom role, got %v", err)
+	}
+}
+
+func TestRoleValidation(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(newMemoryRBAC(t))
+
+	if _, err := svc.CreateRole(ctx, RoleInput{Name: "auditor", Permissions: []string{"audit:everything"}}); !errors.Is(err, ErrInvalidRole) {
+		t.Fatalf("expected unknown permission to be rejected, got %v", err)
+	}
+	if _, err := svc.CreateRole(ctx, RoleInput{Name: "Billing Viewer", Permissions: []string{"usage:read"}}); !errors.Is(err, ErrInvalidRole) {
+		t.Fatalf("expected invalid name to be rejected, got %v", err)
+	}
+	if err := svc.DeleteRole(ctx, "viewer"); !errors.Is(err, ErrBuiltinRole) {
+		t.Fatalf("expected built-in role deletion to fail, got %v", err)
+	}
+	if err := svc.AssignTenantRole(ctx, uuid.New(), uuid.New(), "owner"); !errors.Is(err, ErrBuiltinRole) {
+		t.Fatalf("expected built-in role assignment to fail, got %v", err)
+	}
+
+	role, err := svc.CreateRole(ctx, RoleInput{Name: "billing_viewer", Permissions: []string{"usage:read", "usage:read"}})
+	if err != nil {
+		t.Fatalf("create role: %v", err)
+	}
+	if len(role.Permissions) != 1 || role.Permissions[0] != rbac.PermUsageRead {
+		t.Fatalf("expected de-duplicated permissions, got %v", role.Permissions)
+	}
+	if err := svc.DeleteRole(ctx, "billing_viewer"); err != nil {
+		t.Fatalf("delete role: %v", err)
+	}
+}
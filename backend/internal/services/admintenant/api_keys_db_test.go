@@ -0,0 +1,69 @@
+package admintenant
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/database/dbtest"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+func TestReinstateAPIKeyLiftsSuspension(t *testing.T) {
+	pool := dbtest.Open(t)
+	queries := db.New(pool)
+	ctx := context.Background()
+
+	newTenant := func(name string) db.Tenant {
+		tenant, err := queries.CreateTenant(ctx, db.CreateTenantParams{
+			Name:   name + "-" + uuid.NewString(),
+			Status: db.TenantStatusActive,
+			Kind:   db.TenantKindOrganization,
+		})
+		if err != nil {
+			t.Fatalf("create tenant: %v", err)
+		}
+		t.Cleanup(func() { _ = queries.DeleteTenant(context.Background(), tenant.ID) })
+		return tenant
+	}
+	tenant := newTenant("reinstate")
+	other := newTenant("reinstate-other")
+
+	key, err := queries.CreateAPIKey(ctx, db.CreateAPIKeyParams{
+		TenantID:   tenant.ID,
+		Prefix:     "rs" + uuid.NewString()[:8],
+		SecretHash: "hash",
+		Name:       "looping client",
+		ScopesJson: []byte("[]"),
+		QuotaJson:  []byte("{}"),
+		Kind:       db.ApiKeyKindService,
+	})
+	if err != nil {
+		t.Fatalf("create key: %v", err)
+	}
+	suspended, err := queries.SuspendAPIKey(ctx, db.SuspendAPIKeyParams{ID: key.ID, SuspensionReason: "fingerprint_abuse"})
+	if err != nil || !suspended.SuspendedAt.Valid || suspended.SuspensionReason != "fingerprint_abuse" {
+		t.Fatalf("suspend: %+v %v", suspended, err)
+	}
+
+	svc := NewService(&config.Config{}, queries, time.UTC, pool, nil, nil, nil, nil, nil, nil, nil, nil)
+	keyID := uuid.UUID(key.ID.Bytes)
+	if _, err := svc.ReinstateAPIKey(ctx, uuid.UUID(other.ID.Bytes), keyID); !errors.Is(err, ErrAPIKeyTenantMismatch) {
+		t.Fatalf("expected ErrAPIKeyTenantMismatch for another tenant, got %v", err)
+	}
+
+	reinstated, err := svc.ReinstateAPIKey(ctx, uuid.UUID(tenant.ID.Bytes), keyID)
+	if err != nil {
+		t.Fatalf("reinstate: %v", err)
+	}
+	if reinstated.SuspendedAt.Valid || reinstated.SuspensionReason != "" || !reinstated.ReinstatedAt.Valid || reinstated.RevokedAt.Valid {
+		t.Fatalf("unexpected reinstated key %+v", reinstated)
+	}
+	if _, err := svc.ReinstateAPIKey(ctx, uuid.UUID(tenant.ID.Bytes), keyID); !errors.Is(err, ErrAPIKeyNotSuspended) {
+		t.Fatalf("expected ErrAPIKeyNotSuspended, got %v", err)
+	}
+}
@@ -0,0 +1,192 @@
+package admintenant
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/limits"
+)
+
+// ErrTenantNameRequired reports a clone request without a name for the copy.
+var ErrTenantNameRequired = errors.New("name is required")
+
+// cloneQueries lists the statements a tenant clone reads from the source and
+// writes for the copy.
+type cloneQueries interface {
+	GetTenantByID(ctx context.Context, id pgtype.UUID) (db.Tenant, error)
+	CreateTenant(ctx context.Context, arg db.CreateTenantParams) (db.Tenant, error)
+	UpdateTenantCostCenter(ctx context.Context, arg db.UpdateTenantCostCenterParams) (db.Tenant, error)
+	ListTenantModels(ctx context.Context, tenantID pgtype.UUID) ([]string, error)
+	InsertTenantModel(ctx context.Context, arg db.InsertTenantModelParams) error
+	GetTenantRateLimit(ctx context.Context, tenantID pgtype.UUID) (db.TenantRateLimit, error)
+	UpsertTenantRateLimit(ctx context.Context, arg db.UpsertTenantRateLimitParams) (db.TenantRateLimit, error)
+	GetTenantBudgetOverride(ctx context.Context, tenantID pgtype.UUID) (db.TenantBudgetOverride, error)
+	UpsertTenantBudgetOverride(ctx context.Context, arg db.UpsertTenantBudgetOverrideParams) (db.TenantBudgetOverride, error)
+	ListTenantMembers(ctx context.Context, tenantID pgtype.UUID) ([]db.ListTenantMembersRow, error)
+	AddTenantMembership(ctx context.Context, arg db.AddTenantMembershipParams) (db.TenantMembership, error)
+	ListTenantMembershipRoles(ctx context.Context, tenantID pgtype.UUID) ([]db.TenantMembershipRole, error)
+	UpsertTenantMembershipRole(ctx context.Context, arg db.UpsertTenantMembershipRoleParams) (db.TenantMembershipRole, error)
+}
+
+// clonedTenant is what a clone wrote, so caches can be primed after commit.
+type clonedTenant struct {
+	tenant    db.Tenant
+	models    []string
+	rateLimit *limits.LimitConfig
+}
+
+// Clone creates an active tenant named newName with the source tenant's
+// model access list, rate-limit override, budget configuration, and
+// memberships with their custom roles, all in one transaction. API keys are never copied, and the
+// copy starts without usage or budget alert state. Members must already
+// exist as users; none are created.
+func (s *Service) Clone(ctx context.Context, srcTenantID uuid.UUID, newName string) (db.Tenant, error) {
+	if s == nil || s.queries == nil || s.dbPool == nil {
+		return db.Tenant{}, ErrServiceUnavailable
+	}
+	newName = strings.TrimSpace(newName)
+	if newName == "" {
+		return db.Tenant{}, ErrTenantNameRequired
+	}
+	tx, err := s.dbPool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return db.Tenant{}, err
+	}
+	defer tx.Rollback(ctx)
+	cloned, err := cloneTenant(ctx, s.queries.WithTx(tx), toPgUUID(srcTenantID), newName)
+	if err != nil {
+		return db.Tenant{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return db.Tenant{}, err
+	}
+
+	tenantID := uuid.UUID(cloned.tenant.ID.Bytes)
+	if s.setTenantModels != nil && len(cloned.models) > 0 {
+		s.setTenantModels(tenantID, cloned.models)
+	}
+	if s.setTenantRate != nil && cloned.rateLimit != nil {
+		s.setTenantRate(tenantID, cloned.rateLimit)
+	}
+	return cloned.tenant, nil
+}
+
+func cloneTenant(ctx context.Context, q cloneQueries, srcID pgtype.UUID, name string) (clonedTenant, error) {
+	src, err := q.GetTenantByID(ctx, srcID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return clonedTenant{}, ErrTenantNotFound
+		}
+		return clonedTenant{}, err
+	}
+	tenant, err := q.CreateTenant(ctx, db.CreateTenantParams{
+		Name:   name,
+		Status: db.TenantStatusActive,
+		Kind:   db.TenantKindOrganization,
+	})
+	if err != nil {
+		return clonedTenant{}, err
+	}
+	if src.CostCenter != "" {
+		if tenant, err = q.UpdateTenantCostCenter(ctx, db.UpdateTenantCostCenterParams{
+			ID:         tenant.ID,
+			CostCenter: src.CostCenter,
+		}); err != nil {
+			return clonedTenant{}, err
+		}
+	}
+	out := clonedTenant{tenant: tenant}
+
+	if out.models, err = q.ListTenantModels(ctx, srcID); err != nil {
+		return clonedTenant{}, err
+	}
+	for _, alias := range out.models {
+		if err := q.InsertTenantModel(ctx, db.InsertTenantModelParams{
+			TenantID: tenant.ID,
+			Alias:    alias,
+		}); err != nil {
+			return clonedTenant{}, err
+		}
+	}
+
+	rate, err := q.GetTenantRateLimit(ctx, srcID)
+	switch {
+	case err == nil:
+		if _, err := q.UpsertTenantRateLimit(ctx, db.UpsertTenantRateLimitParams{
+			TenantID:                  tenant.ID,
+			RequestsPerMinute:         rate.RequestsPerMinute,
+			TokensPerMinute:           rate.TokensPerMinute,
+			ParallelRequests:          rate.ParallelRequests,
+			ParallelRequestTtlSeconds: rate.ParallelRequestTtlSeconds,
+		}); err != nil {
+			return clonedTenant{}, err
+		}
+		out.rateLimit = &limits.LimitConfig{
+			RequestsPerMinute:  int(rate.RequestsPerMinute),
+			TokensPerMinute:    int(rate.TokensPerMinute),
+			ParallelRequests:   int(rate.ParallelRequests),
+			ParallelRequestTTL: time.Duration(rate.ParallelRequestTtlSeconds) * time.Second,
+		}
+	case !errors.Is(err, pgx.ErrNoRows):
+		return clonedTenant{}, err
+	}
+
+	// The alert webhook signing secret stays with the source tenant, and
+	// alert state starts fresh with the copy's zero usage.
+	budget, err := q.GetTenantBudgetOverride(ctx, srcID)
+	switch {
+	case err == nil:
+		if _, err := q.UpsertTenantBudgetOverride(ctx, db.UpsertTenantBudgetOverrideParams{
+			TenantID:             tenant.ID,
+			BudgetUsd:            budget.BudgetUsd,
+			WarningThreshold:     budget.WarningThreshold,
+			RefreshSchedule:      budget.RefreshSchedule,
+			AlertEmails:          budget.AlertEmails,
+			AlertWebhooks:        budget.AlertWebhooks,
+			AlertCooldownSeconds: budget.AlertCooldownSeconds,
+			UsageAlertConfig:     budget.UsageAlertConfig,
+			EnforcementMode:      pgtype.Text{String: budget.EnforcementMode, Valid: budget.EnforcementMode != ""},
+		}); err != nil {
+			return clonedTenant{}, err
+		}
+	case !errors.Is(err, pgx.ErrNoRows):
+		return clonedTenant{}, err
+	}
+
+	members, err := q.ListTenantMembers(ctx, srcID)
+	if err != nil {
+		return clonedTenant{}, err
+	}
+	for _, member := range members {
+		if _, err := q.AddTenantMembership(ctx, db.AddTenantMembershipParams{
+			TenantID: tenant.ID,
+			UserID:   member.UserID,
+			Role:     member.Role,
+		}); err != nil {
+			return clonedTenant{}, err
+		}
+	}
+
+	// Custom roles reference the memberships just copied, so they go last.
+	roles, err := q.ListTenantMembershipRoles(ctx, srcID)
+	if err != nil {
+		return clonedTenant{}, err
+	}
+	for _, role := range roles {
+		if _, err := q.UpsertTenantMembershipRole(ctx, db.UpsertTenantMembershipRoleParams{
+			TenantID: tenant.ID,
+			UserID:   role.UserID,
+			Role:     role.Role,
+		}); err != nil {
+			return clonedTenant{}, err
+		}
+	}
+	return out, nil
+}
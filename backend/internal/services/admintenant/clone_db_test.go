@@ -0,0 +1,73 @@
+package admintenant
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/database/dbtest"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+func TestCloneCopiesCustomMembershipRoles(t *testing.T) {
+	pool := dbtest.Open(t)
+	queries := db.New(pool)
+	ctx := context.Background()
+
+	user, err := queries.CreateUser(ctx, db.CreateUserParams{Email: "clone-" + uuid.NewString() + "@example.com", Name: "Clone Member"})
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	t.Cleanup(func() { _, _ = pool.Exec(context.Background(), "DELETE FROM users WHERE id = $1", user.ID) })
+	role, err := queries.InsertRole(ctx, db.InsertRoleParams{
+		Name:        "clone-role-" + uuid.NewString(),
+		Description: "custom role copied by clone",
+		Permissions: []byte(`["usage:read"]`),
+	})
+	if err != nil {
+		t.Fatalf("insert role: %v", err)
+	}
+	t.Cleanup(func() { _, _ = queries.DeleteRole(context.Background(), role.Name) })
+
+	src, err := queries.CreateTenant(ctx, db.CreateTenantParams{
+		Name:   "clone-src-" + uuid.NewString(),
+		Status: db.TenantStatusActive,
+		Kind:   db.TenantKindOrganization,
+	})
+	if err != nil {
+		t.Fatalf("create tenant: %v", err)
+	}
+	t.Cleanup(func() { _ = queries.DeleteTenant(context.Background(), src.ID) })
+	if _, err := queries.AddTenantMembership(ctx, db.AddTenantMembershipParams{
+		TenantID: src.ID,
+		UserID:   user.ID,
+		Role:     db.MembershipRoleViewer,
+	}); err != nil {
+		t.Fatalf("add membership: %v", err)
+	}
+	if _, err := queries.UpsertTenantMembershipRole(ctx, db.UpsertTenantMembershipRoleParams{
+		TenantID: src.ID,
+		UserID:   user.ID,
+		Role:     role.Name,
+	}); err != nil {
+		t.Fatalf("assign custom role: %v", err)
+	}
+
+	svc := NewService(&config.Config{}, queries, time.UTC, pool, nil, nil, nil, nil, nil, nil, nil, nil)
+	clone, err := svc.Clone(ctx, uuid.UUID(src.ID.Bytes), "clone-dst-"+uuid.NewString())
+	if err != nil {
+		t.Fatalf("clone: %v", err)
+	}
+	t.Cleanup(func() { _ = queries.DeleteTenant(context.Background(), clone.ID) })
+
+	got, err := queries.GetTenantMembershipRole(ctx, db.GetTenantMembershipRoleParams{TenantID: clone.ID, UserID: user.ID})
+	if err != nil {
+		t.Fatalf("custom role not copied: %v", err)
+	}
+	if got.Role != role.Name {
+		t.Fatalf("cloned role = %q, want %q", got.Role, role.Name)
+	}
+}
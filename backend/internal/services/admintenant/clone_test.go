@@ -0,0 +1,238 @@
+package admintenant
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	decimal "github.com/shopspring/decimal"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+// memCloneQueries keeps tenant configuration in maps keyed by tenant id.
+type memCloneQueries struct {
+	tenants     map[pgtype.UUID]db.Tenant
+	models      map[pgtype.UUID][]string
+	rateLimits  map[pgtype.UUID]db.TenantRateLimit
+	budgets     map[pgtype.UUID]db.TenantBudgetOverride
+	memberships map[pgtype.UUID][]db.ListTenantMembersRow
+	roles       map[pgtype.UUID][]db.TenantMembershipRole
+}
+
+func newMemCloneQueries() *memCloneQueries {
+	return &memCloneQueries{
+		tenants:     map[pgtype.UUID]db.Tenant{},
+		models:      map[pgtype.UUID][]string{},
+		rateLimits:  map[pgtype.UUID]db.TenantRateLimit{},
+		budgets:     map[pgtype.UUID]db.TenantBudgetOverride{},
+		memberships: map[pgtype.UUID][]db.ListTenantMembersRow{},
+		roles:       map[pgtype.UUID][]db.TenantMembershipRole{},
+	}
+}
+
+func (q *memCloneQueries) GetTenantByID(_ context.Context, id pgtype.UUID) (db.Tenant, error) {
+	tenant, ok := q.tenants[id]
+	if !ok {
+		return db.Tenant{}, pgx.ErrNoRows
+	}
+	return tenant, nil
+}
+
+func (q *memCloneQueries) CreateTenant(_ context.Context, arg db.CreateTenantParams) (db.Tenant, error) {
+	tenant := db.Tenant{ID: toPgUUID(uuid.New()), Name: arg.Name, Status: arg.Status, Kind: arg.Kind}
+	q.tenants[tenant.ID] = tenant
+	return tenant, nil
+}
+
+func (q *memCloneQueries) UpdateTenantCostCenter(_ context.Context, arg db.UpdateTenantCostCenterParams) (db.Tenant, error) {
+	tenant := q.tenants[arg.ID]
+	tenant.CostCenter = arg.CostCenter
+	q.tenants[arg.ID] = tenant
+	return tenant, nil
+}
+
+func (q *memCloneQueries) ListTenantModels(_ context.Context, tenantID pgtype.UUID) ([]string, error) {
+	return q.models[tenantID], nil
+}
+
+func (q *memCloneQueries) InsertTenantModel(_ context.Context, arg db.InsertTenantModelParams) error {
+	q.models[arg.TenantID] = append(q.models[arg.TenantID], arg.Alias)
+	return nil
+}
+
+func (q *memCloneQueries) GetTenantRateLimit(_ context.Context, tenantID pgtype.UUID) (db.TenantRateLimit, error) {
+	rate, ok := q.rateLimits[tenantID]
+	if !ok {
+		return db.TenantRateLimit{}, pgx.ErrNoRows
+	}
+	return rate, nil
+}
+
+func (q *memCloneQueries) UpsertTenantRateLimit(_ context.Context, arg db.UpsertTenantRateLimitParams) (db.TenantRateLimit, error) {
+	rate := db.TenantRateLimit{
+		TenantID:          arg.TenantID,
+		RequestsPerMinute: arg.RequestsPerMinute,
+		TokensPerMinute:   arg.TokensPerMinute,
+		ParallelRequests:  arg.ParallelRequests,
+	}
+	q.rateLimits[arg.TenantID] = rate
+	return rate, nil
+}
+
+func (q *memCloneQueries) GetTenantBudgetOverride(_ context.Context, tenantID pgtype.UUID) (db.TenantBudgetOverride, error) {
+	budget, ok := q.budgets[tenantID]
+	if !ok {
+		return db.TenantBudgetOverride{}, pgx.ErrNoRows
+	}
+	return budget, nil
+}
+
+func (q *memCloneQueries) UpsertTenantBudgetOverride(_ context.Context, arg db.UpsertTenantBudgetOverrideParams) (db.TenantBudgetOverride, error) {
+	budget := db.TenantBudgetOverride{
+		TenantID:             arg.TenantID,
+		BudgetUsd:            arg.BudgetUsd,
+		WarningThreshold:     arg.WarningThreshold,
+		RefreshSchedule:      arg.RefreshSchedule,
+		AlertEmails:          arg.AlertEmails,
+		AlertWebhooks:        arg.AlertWebhooks,
+		AlertCooldownSeconds: arg.AlertCooldownSeconds,
+		UsageAlertConfig:     arg.UsageAlertConfig,
+		EnforcementMode:      "hard",
+		AlertWebhookSecret:   arg.AlertWebhookSecret.String,
+	}
+	if arg.EnforcementMode.Valid {
+		budget.EnforcementMode = arg.EnforcementMode.String
+	}
+	q.budgets[arg.TenantID] = budget
+	return budget, nil
+}
+
+func (q *memCloneQueries) ListTenantMembers(_ context.Context, tenantID pgtype.UUID) ([]db.ListTenantMembersRow, error) {
+	return q.memberships[tenantID], nil
+}
+
+func (q *memCloneQueries) AddTenantMembership(_ context.Context, arg db.AddTenantMembershipParams) (db.TenantMembership, error) {
+	q.memberships[arg.TenantID] = append(q.memberships[arg.TenantID], db.ListTenantMembersRow{
+		TenantID: arg.TenantID,
+		UserID:   arg.UserID,
+		Role:     arg.Role,
+	})
+	return db.TenantMembership{TenantID: arg.TenantID, UserID: arg.UserID, Role: arg.Role}, nil
+}
+
+func (q *memCloneQueries) ListTenantMembershipRoles(_ context.Context, tenantID pgtype.UUID) ([]db.TenantMembershipRole, error) {
+	return q.roles[tenantID], nil
+}
+
+func (q *memCloneQueries) UpsertTenantMembershipRole(_ context.Context, arg db.UpsertTenantMembershipRoleParams) (db.TenantMembershipRole, error) {
+	for _, member := range q.memberships[arg.TenantID] {
+		if member.UserID == arg.UserID {
+			role := db.TenantMembershipRole{TenantID: arg.TenantID, UserID: arg.UserID, Role: arg.Role}
+			q.roles[arg.TenantID] = append(q.roles[arg.TenantID], role)
+			return role, nil
+		}
+	}
+	return db.TenantMembershipRole{}, errors.New("membership does not exist")
+}
+
+func TestCloneTenantCopiesConfiguration(t *testing.T) {
+	q := newMemCloneQueries()
+	srcID := toPgUUID(uuid.New())
+	owner, viewer := toPgUUID(uuid.New()), toPgUUID(uuid.New())
+	q.tenants[srcID] = db.Tenant{ID: srcID, Name: "acme-staging", Status: db.TenantStatusSuspended, Kind: db.TenantKindOrganization, CostCenter: "eng-42"}
+	q.models[srcID] = []string{"gpt-4o", "text-embedding-3-small"}
+	q.rateLimits[srcID] = db.TenantRateLimit{TenantID: srcID, RequestsPerMinute: 120, TokensPerMinute: 50000, ParallelRequests: 8}
+	q.budgets[srcID] = db.TenantBudgetOverride{
+		TenantID:             srcID,
+		BudgetUsd:            decimal.NewFromInt(250),
+		WarningThreshold:     decimal.RequireFromString("0.8"),
+		RefreshSchedule:      "weekly",
+		AlertEmails:          []string{"ops@example.com"},
+		AlertWebhooks:        []string{"https://hooks.example.com/budget"},
+		AlertCooldownSeconds: 600,
+		UsageAlertConfig:     []byte(`{"enabled":true}`),
+		EnforcementMode:      "soft",
+		AlertWebhookSecret:   "whsec_source",
+		LastAlertAt:          pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		LastAlertLevel:       pgtype.Text{String: "warning", Valid: true},
+	}
+	q.memberships[srcID] = []db.ListTenantMembersRow{
+		{TenantID: srcID, UserID: owner, Role: db.MembershipRoleOwner},
+		{TenantID: srcID, UserID: viewer, Role: db.MembershipRoleViewer},
+	}
+	q.roles[srcID] = []db.TenantMembershipRole{{TenantID: srcID, UserID: viewer, Role: "billing-auditor"}}
+
+	cloned, err := cloneTenant(context.Background(), q, srcID, "acme-prod")
+	if err != nil {
+		t.Fatalf("clone: %v", err)
+	}
+	dst := cloned.tenant
+	if dst.ID == srcID || dst.Name != "acme-prod" || dst.Status != db.TenantStatusActive || dst.CostCenter != "eng-42" {
+		t.Fatalf("unexpected cloned tenant %+v", dst)
+	}
+	if !reflect.DeepEqual(q.models[dst.ID], q.models[srcID]) || !reflect.DeepEqual(cloned.models, q.models[srcID]) {
+		t.Fatalf("expected models %v, got %v", q.models[srcID], q.models[dst.ID])
+	}
+	rate := q.rateLimits[dst.ID]
+	if rate.RequestsPerMinute != 120 || rate.TokensPerMinute != 50000 || rate.ParallelRequests != 8 {
+		t.Fatalf("unexpected rate limit %+v", rate)
+	}
+	if cloned.rateLimit == nil || cloned.rateLimit.RequestsPerMinute != 120 {
+		t.Fatalf("expected rate limit to prime the cache, got %+v", cloned.rateLimit)
+	}
+
+	src, budget := q.budgets[srcID], q.budgets[dst.ID]
+	if !budget.BudgetUsd.Equal(src.BudgetUsd) || !budget.WarningThreshold.Equal(src.WarningThreshold) ||
+		budget.RefreshSchedule != "weekly" || budget.AlertCooldownSeconds != 600 || budget.EnforcementMode != "soft" ||
+		!reflect.DeepEqual(budget.AlertEmails, src.AlertEmails) || !reflect.DeepEqual(budget.AlertWebhooks, src.AlertWebhooks) ||
+		string(budget.UsageAlertConfig) != `{"enabled":true}` {
+		t.Fatalf("budget not copied: %+v", budget)
+	}
+	// Usage and alert state start from zero, and secrets stay behind.
+	if budget.LastAlertAt.Valid || budget.LastAlertLevel.Valid || budget.AlertWebhookSecret != "" {
+		t.Fatalf("expected fresh alert state without secret, got %+v", budget)
+	}
+
+	members := q.memberships[dst.ID]
+	if len(members) != 2 || members[0].UserID != owner || members[0].Role != db.MembershipRoleOwner ||
+		members[1].UserID != viewer || members[1].Role != db.MembershipRoleViewer {
+		t.Fatalf("unexpected memberships %+v", members)
+	}
+	roles := q.roles[dst.ID]
+	if len(roles) != 1 || roles[0].UserID != viewer || roles[0].Role != "billing-auditor" {
+		t.Fatalf("expected the custom role to be copied, got %+v", roles)
+	}
+}
+
+func TestCloneTenantWithoutOverrides(t *testing.T) {
+	q := newMemCloneQueries()
+	srcID := toPgUUID(uuid.New())
+	q.tenants[srcID] = db.Tenant{ID: srcID, Name: "bare"}
+
+	cloned, err := cloneTenant(context.Background(), q, srcID, "bare-copy")
+	if err != nil {
+		t.Fatalf("clone: %v", err)
+	}
+	if _, ok := q.rateLimits[cloned.tenant.ID]; ok || cloned.rateLimit != nil {
+		t.Fatal("expected no rate limit override")
+	}
+	if _, ok := q.budgets[cloned.tenant.ID]; ok {
+		t.Fatal("expected no budget override")
+	}
+	if len(q.memberships[cloned.tenant.ID]) != 0 || len(q.roles[cloned.tenant.ID]) != 0 || len(q.models[cloned.tenant.ID]) != 0 {
+		t.Fatal("expected no models, members, or roles")
+	}
+}
+
+func TestCloneTenantMissingSource(t *testing.T) {
+	_, err := cloneTenant(context.Background(), newMemCloneQueries(), toPgUUID(uuid.New()), "copy")
+	if !errors.Is(err, ErrTenantNotFound) {
+		t.Fatalf("expected ErrTenantNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,611 @@
+package admintenant
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/storage/blob"
+)
+
+const (
+	ExportStatusPending   = "pending"
+	ExportStatusRunning   = "running"
+	ExportStatusCompleted = "completed"
+	ExportStatusFailed    = "failed"
+
+	// ExportTTL is how long a finished export stays downloadable.
+	ExportTTL = 7 * 24 * time.Hour
+
+	// exportStaleAfter is how long a pending or running job may go without a
+	// heartbeat before SweepExports fails it; jobs only stall that long when
+	// the process running them has stopped.
+	exportStaleAfter = 10 * time.Minute
+	// exportHeartbeatEvery throttles progress writes within a section.
+	exportHeartbeatEvery = 30 * time.Second
+
+	exportPageSize = 1000
+)
+
+var (
+	ErrExportUnavailable = errors.New("tenant export requires blob storage")
+	ErrExportNotFound    = errors.New("export job not found")
+	ErrExportNotReady    = errors.New("export has not completed")
+	ErrExportExpired     = errors.New("export archive has expired")
+)
+
+// exportSections are the archive entries, written in this order.
+var exportSections = []string{
+	"tenant.json",
+	"members.jsonl",
+	"api_keys.jsonl",
+	"usage.jsonl",
+	"batches.jsonl",
+	"batch_items.jsonl",
+	"files.jsonl",
+}
+
+// exportQueries lists the statements a tenant export reads and the job
+// progress updates it writes.
+type exportQueries interface {
+	GetTenantByID(ctx context.Context, id pgtype.UUID) (db.Tenant, error)
+	ListTenantMembersForExport(ctx context.Context, arg db.ListTenantMembersForExportParams) ([]db.ListTenantMembersForExportRow, error)
+	ListAPIKeysByTenantForExport(ctx context.Context, arg db.ListAPIKeysByTenantForExportParams) ([]db.ApiKey, error)
+	ListTenantRequestsForExport(ctx context.Context, arg db.ListTenantRequestsForExportParams) ([]db.Request, error)
+	ListBatchesCursor(ctx context.Context, arg db.ListBatchesCursorParams) ([]db.Batch, error)
+	ListBatchItemsPage(ctx context.Context, arg db.ListBatchItemsPageParams) ([]db.BatchItem, error)
+	ListFiles(ctx context.Context, arg db.ListFilesParams) ([]db.File, error)
+	StartTenantExportJob(ctx context.Context, id pgtype.UUID) error
+	UpdateTenantExportJobProgress(ctx context.Context, arg db.UpdateTenantExportJobProgressParams) error
+	CompleteTenantExportJob(ctx context.Context, arg db.CompleteTenantExportJobParams) error
+	FailTenantExportJob(ctx context.Context, arg db.FailTenantExportJobParams) error
+}
+
+// exportStore receives the finished archive.
+type exportStore interface {
+	Put(ctx context.Context, key string, body io.Reader, opts blob.PutOptions) (blob.ObjectInfo, error)
+}
+
+// SetExportStore registers the blob store that holds finished tenant exports.
+// Archives live under their own keys rather than as tenant files, so they are
+// only reachable through the admin download endpoint.
+func (s *Service) SetExportStore(store blob.Store) {
+	s.exports = store
+}
+
+// ExportJob reports the progress of a tenant export.
+type ExportJob struct {
+	ID                uuid.UUID
+	TenantID          uuid.UUID
+	Status            string
+	SectionsCompleted int32
+	SectionsTotal     int32
+	RowsExported      int64
+	Bytes             int64
+	Error             string
+	CreatedAt         time.Time
+	StartedAt         *time.Time
+	CompletedAt       *time.Time
+	ExpiresAt         *time.Time
+	// Downloadable reports whether the archive can still be fetched with
+	// OpenExport.
+	Downloadable bool
+}
+
+// ExportTenant queues a background export of the tenant's members, API keys
+// (prefix only), usage history, batches with their items, and file metadata.
+// The archive is kept in the export blob store for ExportTTL; poll
+// GetExportJob for progress and fetch it with OpenExport.
+func (s *Service) ExportTenant(ctx context.Context, tenantID uuid.UUID) (uuid.UUID, error) {
+	if s == nil || s.queries == nil {
+		return uuid.Nil, ErrServiceUnavailable
+	}
+	if s.exports == nil {
+		return uuid.Nil, ErrExportUnavailable
+	}
+	pgID := toPgUUID(tenantID)
+	if _, err := s.queries.GetTenantByID(ctx, pgID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, ErrTenantNotFound
+		}
+		return uuid.Nil, err
+	}
+	job, err := s.queries.CreateTenantExportJob(ctx, db.CreateTenantExportJobParams{
+		TenantID:      pgID,
+		SectionsTotal: int32(len(exportSections)),
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("create export job: %w", err)
+	}
+
+	exp := &tenantExporter{queries: s.queries, store: s.exports, now: time.Now}
+	go exp.run(context.WithoutCancel(ctx), job.ID, tenantID)
+	return uuid.UUID(job.ID.Bytes), nil
+}
+
+// GetExportJob returns an export job belonging to the tenant.
+func (s *Service) GetExportJob(ctx context.Context, tenantID, jobID uuid.UUID) (ExportJob, error) {
+	if s == nil || s.queries == nil {
+		return ExportJob{}, ErrServiceUnavailable
+	}
+	job, err := s.queries.GetTenantExportJob(ctx, db.GetTenantExportJobParams{
+		ID:       toPgUUID(jobID),
+		TenantID: toPgUUID(tenantID),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ExportJob{}, ErrExportNotFound
+		}
+		return ExportJob{}, err
+	}
+	return toExportJob(job, time.Now()), nil
+}
+
+// OpenExport returns a reader for a completed export's archive. The caller
+// closes it.
+func (s *Service) OpenExport(ctx context.Context, tenantID, jobID uuid.UUID) (io.ReadCloser, ExportJob, error) {
+	if s == nil || s.queries == nil {
+		return nil, ExportJob{}, ErrServiceUnavailable
+	}
+	if s.exports == nil {
+		return nil, ExportJob{}, ErrExportUnavailable
+	}
+	record, err := s.queries.GetTenantExportJob(ctx, db.GetTenantExportJobParams{
+		ID:       toPgUUID(jobID),
+		TenantID: toPgUUID(tenantID),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ExportJob{}, ErrExportNotFound
+		}
+		return nil, ExportJob{}, err
+	}
+	job := toExportJob(record, time.Now())
+	if job.Status != ExportStatusCompleted {
+		return nil, ExportJob{}, ErrExportNotReady
+	}
+	if !job.Downloadable {
+		return nil, ExportJob{}, ErrExportExpired
+	}
+	reader, _, err := s.exports.Get(ctx, record.StorageKey.String)
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			return nil, ExportJob{}, ErrExportExpired
+		}
+		return nil, ExportJob{}, err
+	}
+	return reader, job, nil
+}
+
+// SweepExports fails export jobs whose worker stopped heartbeating, such as
+// jobs left running when the process restarted, and deletes archives past
+// their expiry.
+func (s *Service) SweepExports(ctx context.Context, batchSize int32) error {
+	if s == nil || s.queries == nil {
+		return ErrServiceUnavailable
+	}
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	now := time.Now().UTC()
+	failed, err := s.queries.FailStaleTenantExportJobs(ctx, db.FailStaleTenantExportJobsParams{
+		Error:       pgtype.Text{String: "export interrupted before completion", Valid: true},
+		StaleBefore: pgtype.Timestamptz{Time: now.Add(-exportStaleAfter), Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("fail stale exports: %w", err)
+	}
+	if failed > 0 {
+		slog.Warn("failed interrupted tenant exports", slog.Int64("jobs", failed))
+	}
+	if s.exports == nil {
+		return nil
+	}
+	expired, err := s.queries.ListExpiredTenantExports(ctx, db.ListExpiredTenantExportsParams{
+		ExpiresAt: pgtype.Timestamptz{Time: now, Valid: true},
+		Limit:     batchSize,
+	})
+	if err != nil {
+		return fmt.Errorf("list expired exports: %w", err)
+	}
+	for _, job := range expired {
+		if err := s.exports.Delete(ctx, job.StorageKey.String); err != nil && !errors.Is(err, blob.ErrNotFound) {
+			slog.Warn("delete expired tenant export",
+				slog.String("job_id", uuid.UUID(job.ID.Bytes).String()),
+				slog.String("error", err.Error()))
+			continue
+		}
+		if err := s.queries.ClearTenantExportArchive(ctx, job.ID); err != nil {
+			return fmt.Errorf("clear export archive: %w", err)
+		}
+	}
+	return nil
+}
+
+// exportKey is where a job's archive is stored, outside the tenant/ prefix
+// the files service uses.
+func exportKey(tenantID uuid.UUID, jobID pgtype.UUID) string {
+	return fmt.Sprintf("tenant-exports/%s/%s.zip", tenantID, uuid.UUID(jobID.Bytes))
+}
+
+func toExportJob(job db.TenantExportJob, now time.Time) ExportJob {
+	out := ExportJob{
+		ID:                uuid.UUID(job.ID.Bytes),
+		TenantID:          uuid.UUID(job.TenantID.Bytes),
+		Status:            job.Status,
+		SectionsCompleted: job.SectionsCompleted,
+		SectionsTotal:     job.SectionsTotal,
+		RowsExported:      job.RowsExported,
+		Bytes:             job.Bytes,
+		Error:             job.Error.String,
+		CreatedAt:         job.CreatedAt.Time,
+		Downloadable:      job.StorageKey.Valid && job.ExpiresAt.Valid && now.Before(job.ExpiresAt.Time),
+	}
+	if job.StartedAt.Valid {
+		ts := job.StartedAt.Time
+		out.StartedAt = &ts
+	}
+	if job.CompletedAt.Valid {
+		ts := job.CompletedAt.Time
+		out.CompletedAt = &ts
+	}
+	if job.ExpiresAt.Valid {
+		ts := job.ExpiresAt.Time
+		out.ExpiresAt = &ts
+	}
+	return out
+}
+
+// tenantExporter writes one export job. Every section is streamed page by
+// page into a temporary ZIP so large usage histories never sit in memory.
+type tenantExporter struct {
+	queries exportQueries
+	store   exportStore
+	now     func() time.Time
+
+	jobID     pgtype.UUID
+	tenantID  pgtype.UUID
+	sections  int32
+	rows      int64
+	heartbeat time.Time
+}
+
+func (e *tenantExporter) run(ctx context.Context, jobID pgtype.UUID, tenantID uuid.UUID) {
+	if err := e.export(ctx, jobID, tenantID); err != nil {
+		slog.Error("tenant export failed",
+			slog.String("tenant_id", tenantID.String()),
+			slog.String("job_id", uuid.UUID(jobID.Bytes).String()),
+			slog.String("error", err.Error()))
+		if markErr := e.queries.FailTenantExportJob(ctx, db.FailTenantExportJobParams{
+			ID:    jobID,
+			Error: pgtype.Text{String: err.Error(), Valid: true},
+		}); markErr != nil {
+			slog.Error("mark tenant export failed", slog.String("error", markErr.Error()))
+		}
+	}
+}
+
+func (e *tenantExporter) export(ctx context.Context, jobID pgtype.UUID, tenantID uuid.UUID) error {
+	e.jobID, e.tenantID = jobID, toPgUUID(tenantID)
+	if err := e.queries.StartTenantExportJob(ctx, jobID); err != nil {
+		return fmt.Errorf("start export job: %w", err)
+	}
+	e.heartbeat = e.now()
+
+	tmp, err := os.CreateTemp("", "tenant-export-*.zip")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		tmp.Close()
+		os.Remove(tmp.Name())
+	}()
+
+	archive := zip.NewWriter(tmp)
+	writers := []func(context.Context, *json.Encoder) error{
+		e.writeTenant,
+		e.writeMembers,
+		e.writeAPIKeys,
+		e.writeUsage,
+		e.writeBatches,
+		e.writeBatchItems,
+		e.writeFiles,
+	}
+	for i, name := range exportSections {
+		entry, err := archive.Create(name)
+		if err != nil {
+			return err
+		}
+		if err := writers[i](ctx, json.NewEncoder(entry)); err != nil {
+			return fmt.Errorf("export %s: %w", name, err)
+		}
+		e.sections++
+		if err := e.recordProgress(ctx); err != nil {
+			return err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return err
+	}
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	key := exportKey(tenantID, jobID)
+	if _, err := e.store.Put(ctx, key, tmp, blob.PutOptions{
+		ContentType: "application/zip",
+		Metadata:    map[string]string{"tenant_id": tenantID.String()},
+	}); err != nil {
+		return fmt.Errorf("store export: %w", err)
+	}
+	return e.queries.CompleteTenantExportJob(ctx, db.CompleteTenantExportJobParams{
+		ID:         jobID,
+		StorageKey: pgtype.Text{String: key, Valid: true},
+		Bytes:      size,
+		ExpiresAt:  pgtype.Timestamptz{Time: e.now().Add(ExportTTL), Valid: true},
+	})
+}
+
+// recordProgress writes the job's progress, which doubles as its heartbeat.
+func (e *tenantExporter) recordProgress(ctx context.Context) error {
+	if err := e.queries.UpdateTenantExportJobProgress(ctx, db.UpdateTenantExportJobProgressParams{
+		ID:                e.jobID,
+		SectionsCompleted: e.sections,
+		RowsExported:      e.rows,
+	}); err != nil {
+		return fmt.Errorf("record export progress: %w", err)
+	}
+	e.heartbeat = e.now()
+	return nil
+}
+
+// pageDone heartbeats between pages of a long section so SweepExports does
+// not mistake a slow export for an abandoned one.
+func (e *tenantExporter) pageDone(ctx context.Context) error {
+	if e.now().Sub(e.heartbeat) < exportHeartbeatEvery {
+		return nil
+	}
+	return e.recordProgress(ctx)
+}
+
+func (e *tenantExporter) encode(enc *json.Encoder, v any) error {
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	e.rows++
+	return nil
+}
+
+func (e *tenantExporter) writeTenant(ctx context.Context, enc *json.Encoder) error {
+	tenant, err := e.queries.GetTenantByID(ctx, e.tenantID)
+	if err != nil {
+		return err
+	}
+	return e.encode(enc, tenant)
+}
+
+func (e *tenantExporter) writeMembers(ctx context.Context, enc *json.Encoder) error {
+	after := toPgUUID(uuid.Nil)
+	for {
+		members, err := e.queries.ListTenantMembersForExport(ctx, db.ListTenantMembersForExportParams{
+			TenantID: e.tenantID,
+			AfterID:  after,
+			RowLimit: exportPageSize,
+		})
+		if err != nil {
+			return err
+		}
+		for _, member := range members {
+			if err := e.encode(enc, member); err != nil {
+				return err
+			}
+		}
+		if len(members) < exportPageSize {
+			return nil
+		}
+		after = members[len(members)-1].ID
+		if err := e.pageDone(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// exportAPIKey omits the secret hash, scopes, and quota of a key.
+type exportAPIKey struct {
+	ID          pgtype.UUID        `json:"id"`
+	Prefix      string             `json:"prefix"`
+	Name        string             `json:"name"`
+	Kind        db.ApiKeyKind      `json:"kind"`
+	OwnerUserID pgtype.UUID        `json:"owner_user_id"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	RevokedAt   pgtype.Timestamptz `json:"revoked_at"`
+	LastUsedAt  pgtype.Timestamptz `json:"last_used_at"`
+}
+
+func (e *tenantExporter) writeAPIKeys(ctx context.Context, enc *json.Encoder) error {
+	after := toPgUUID(uuid.Nil)
+	for {
+		keys, err := e.queries.ListAPIKeysByTenantForExport(ctx, db.ListAPIKeysByTenantForExportParams{
+			TenantID: e.tenantID,
+			AfterID:  after,
+			RowLimit: exportPageSize,
+		})
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if err := e.encode(enc, exportAPIKey{
+				ID:          key.ID,
+				Prefix:      key.Prefix,
+				Name:        key.Name,
+				Kind:        key.Kind,
+				OwnerUserID: key.OwnerUserID,
+				CreatedAt:   key.CreatedAt,
+				RevokedAt:   key.RevokedAt,
+				LastUsedAt:  key.LastUsedAt,
+			}); err != nil {
+				return err
+			}
+		}
+		if len(keys) < exportPageSize {
+			return nil
+		}
+		after = keys[len(keys)-1].ID
+		if err := e.pageDone(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+func (e *tenantExporter) writeUsage(ctx context.Context, enc *json.Encoder) error {
+	afterTs := pgtype.Timestamptz{InfinityModifier: pgtype.NegativeInfinity, Valid: true}
+	afterID := toPgUUID(uuid.Nil)
+	for {
+		rows, err := e.queries.ListTenantRequestsForExport(ctx, db.ListTenantRequestsForExportParams{
+			TenantID: e.tenantID,
+			AfterTs:  afterTs,
+			AfterID:  afterID,
+			RowLimit: exportPageSize,
+		})
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if err := e.encode(enc, row); err != nil {
+				return err
+			}
+		}
+		if len(rows) < exportPageSize {
+			return nil
+		}
+		last := rows[len(rows)-1]
+		afterTs, afterID = last.Ts, last.ID
+		if err := e.pageDone(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// eachBatch pages through the tenant's batches newest first.
+func (e *tenantExporter) eachBatch(ctx context.Context, fn func(db.Batch) error) error {
+	var after pgtype.UUID
+	for {
+		batches, err := e.queries.ListBatchesCursor(ctx, db.ListBatchesCursorParams{
+			TenantID: e.tenantID,
+			Limit:    exportPageSize,
+			AfterID:  after,
+		})
+		if err != nil {
+			return err
+		}
+		for _, batch := range batches {
+			if err := fn(batch); err != nil {
+				return err
+			}
+		}
+		if len(batches) < exportPageSize {
+			return nil
+		}
+		after = batches[len(batches)-1].ID
+		if err := e.pageDone(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+func (e *tenantExporter) writeBatches(ctx context.Context, enc *json.Encoder) error {
+	return e.eachBatch(ctx, func(batch db.Batch) error {
+		return e.encode(enc, batch)
+	})
+}
+
+func (e *tenantExporter) writeBatchItems(ctx context.Context, enc *json.Encoder) error {
+	return e.eachBatch(ctx, func(batch db.Batch) error {
+		after := int64(-1)
+		for {
+			items, err := e.queries.ListBatchItemsPage(ctx, db.ListBatchItemsPageParams{
+				BatchID:    batch.ID,
+				AfterIndex: after,
+				RowLimit:   exportPageSize,
+			})
+			if err != nil {
+				return err
+			}
+			for _, item := range items {
+				if err := e.encode(enc, item); err != nil {
+					return err
+				}
+			}
+			if len(items) < exportPageSize {
+				return nil
+			}
+			after = items[len(items)-1].ItemIndex
+			if err := e.pageDone(ctx); err != nil {
+				return err
+			}
+		}
+	})
+}
+
+// exportFile omits where a file is stored.
+type exportFile struct {
+	ID            pgtype.UUID        `json:"id"`
+	Filename      string             `json:"filename"`
+	Purpose       string             `json:"purpose"`
+	ContentType   string             `json:"content_type"`
+	Bytes         int64              `json:"bytes"`
+	Checksum      pgtype.Text        `json:"checksum"`
+	Status        string             `json:"status"`
+	StatusDetails pgtype.Text        `json:"status_details"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	ExpiresAt     pgtype.Timestamptz `json:"expires_at"`
+}
+
+func (e *tenantExporter) writeFiles(ctx context.Context, enc *json.Encoder) error {
+	params := db.ListFilesParams{TenantID: e.tenantID, Limit: exportPageSize}
+	for {
+		files, err := e.queries.ListFiles(ctx, params)
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			if err := e.encode(enc, exportFile{
+				ID:            file.ID,
+				Filename:      file.Filename,
+				Purpose:       file.Purpose,
+				ContentType:   file.ContentType,
+				Bytes:         file.Bytes,
+				Checksum:      file.Checksum,
+				Status:        file.Status,
+				StatusDetails: file.StatusDetails,
+				CreatedAt:     file.CreatedAt,
+				ExpiresAt:     file.ExpiresAt,
+			}); err != nil {
+				return err
+			}
+		}
+		if len(files) < exportPageSize {
+			return nil
+		}
+		last := files[len(files)-1]
+		params.AfterCreatedAt, params.AfterID = last.CreatedAt, last.ID
+		if err := e.pageDone(ctx); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package admintenant
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/storage/blob"
+)
+
+type stubExportQueries struct {
+	requests  []db.Request
+	members   []db.ListTenantMembersForExportRow
+	progress  []db.UpdateTenantExportJobProgressParams
+	completed *db.CompleteTenantExportJobParams
+	failed    *db.FailTenantExportJobParams
+}
+
+func (q *stubExportQueries) GetTenantByID(_ context.Context, id pgtype.UUID) (db.Tenant, error) {
+	return db.Tenant{ID: id, Name: "acme"}, nil
+}
+
+func (q *stubExportQueries) ListTenantMembersForExport(_ context.Context, arg db.ListTenantMembersForExportParams) ([]db.ListTenantMembersForExportRow, error) {
+	start := 0
+	for i, member := range q.members {
+		if member.ID == arg.AfterID {
+			start = i + 1
+		}
+	}
+	end := min(start+int(arg.RowLimit), len(q.members))
+	return q.members[start:end], nil
+}
+
+func (q *stubExportQueries) ListAPIKeysByTenantForExport(_ context.Context, arg db.ListAPIKeysByTenantForExportParams) ([]db.ApiKey, error) {
+	if arg.AfterID != toPgUUID(uuid.Nil) {
+		return nil, nil
+	}
+	return []db.ApiKey{{Prefix: "sk-abc", SecretHash: "super-secret-hash", Name: "ci"}}, nil
+}
+
+func (q *stubExportQueries) ListTenantRequestsForExport(_ context.Context, arg db.ListTenantRequestsForExportParams) ([]db.Request, error) {
+	start := 0
+	if arg.AfterTs.InfinityModifier != pgtype.NegativeInfinity {
+		for i, req := range q.requests {
+			if req.ID == arg.AfterID {
+				start = i + 1
+			}
+		}
+	}
+	end := min(start+int(arg.RowLimit), len(q.requests))
+	return q.requests[start:end], nil
+}
+
+func (q *stubExportQueries) ListBatchesCursor(context.Context, db.ListBatchesCursorParams) ([]db.Batch, error) {
+	return []db.Batch{{ID: toPgUUID(uuid.New()), Status: "completed"}}, nil
+}
+
+func (q *stubExportQueries) ListBatchItemsPage(_ context.Context, arg db.ListBatchItemsPageParams) ([]db.BatchItem, error) {
+	if arg.AfterIndex >= 0 {
+		return nil, nil
+	}
+	return []db.BatchItem{{BatchID: arg.BatchID, ItemIndex: 0}, {BatchID: arg.BatchID, ItemIndex: 1}}, nil
+}
+
+func (q *stubExportQueries) ListFiles(context.Context, db.ListFilesParams) ([]db.File, error) {
+	return []db.File{{Filename: "input.jsonl", StorageKey: "tenant/secret/key"}}, nil
+}
+
+func (q *stubExportQueries) StartTenantExportJob(context.Context, pgtype.UUID) error { return nil }
+
+func (q *stubExportQueries) UpdateTenantExportJobProgress(_ context.Context, arg db.UpdateTenantExportJobProgressParams) error {
+	q.progress = append(q.progress, arg)
+	return nil
+}
+
+func (q *stubExportQueries) CompleteTenantExportJob(_ context.Context, arg db.CompleteTenantExportJobParams) error {
+	q.completed = &arg
+	return nil
+}
+
+func (q *stubExportQueries) FailTenantExportJob(_ context.Context, arg db.FailTenantExportJobParams) error {
+	q.failed = &arg
+	return nil
+}
+
+type stubExportStore struct {
+	key  string
+	opts blob.PutOptions
+	data []byte
+}
+
+func (f *stubExportStore) Put(_ context.Context, key string, body io.Reader, opts blob.PutOptions) (blob.ObjectInfo, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return blob.ObjectInfo{}, err
+	}
+	f.key, f.opts, f.data = key, opts, data
+	return blob.ObjectInfo{Key: key, Size: int64(len(data))}, nil
+}
+
+func TestTenantExportWritesEverySection(t *testing.T) {
+	queries := &stubExportQueries{}
+	for i := 0; i < exportPageSize+5; i++ {
+		queries.requests = append(queries.requests, db.Request{ID: toPgUUID(uuid.New()), ModelAlias: "gpt-4o"})
+	}
+	for i := 0; i < exportPageSize+2; i++ {
+		queries.members = append(queries.members, db.ListTenantMembersForExportRow{ID: toPgUUID(uuid.New()), UserEmail: "a@example.com", Role: db.MembershipRoleOwner})
+	}
+	store := &stubExportStore{}
+	now := time.Date(2025, 11, 17, 0, 0, 0, 0, time.UTC)
+	exporter := &tenantExporter{queries: queries, store: store, now: func() time.Time { return now }}
+	tenantID, jobID := uuid.New(), toPgUUID(uuid.New())
+
+	exporter.run(context.Background(), jobID, tenantID)
+
+	if queries.failed != nil {
+		t.Fatalf("export failed: %s", queries.failed.Error.String)
+	}
+	completed := queries.completed
+	if completed == nil || completed.StorageKey.String != store.key || completed.Bytes != int64(len(store.data)) {
+		t.Fatalf("expected job completed with the stored archive, got %+v", completed)
+	}
+	if !completed.ExpiresAt.Time.Equal(now.Add(ExportTTL)) {
+		t.Fatalf("expires_at = %v, want %v", completed.ExpiresAt.Time, now.Add(ExportTTL))
+	}
+	if want := exportKey(tenantID, jobID); store.key != want || strings.HasPrefix(store.key, "tenant/") {
+		t.Fatalf("archive key = %q, want %q outside the files namespace", store.key, want)
+	}
+	if len(queries.progress) != len(exportSections) {
+		t.Fatalf("expected progress after each section, got %d updates", len(queries.progress))
+	}
+	// tenant + members + key + requests + batch + 2 items + file
+	wantRows := int64(1 + exportPageSize + 2 + 1 + exportPageSize + 5 + 1 + 2 + 1)
+	if last := queries.progress[len(queries.progress)-1]; last.RowsExported != wantRows {
+		t.Fatalf("rows exported = %d, want %d", last.RowsExported, wantRows)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(store.data), int64(len(store.data)))
+	if err != nil {
+		t.Fatalf("open archive: %v", err)
+	}
+	contents := map[string]string{}
+	for _, entry := range archive.File {
+		rc, err := entry.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", entry.Name, err)
+		}
+		raw, _ := io.ReadAll(rc)
+		rc.Close()
+		contents[entry.Name] = string(raw)
+	}
+	for _, name := range exportSections {
+		if _, ok := contents[name]; !ok {
+			t.Fatalf("archive missing %s", name)
+		}
+	}
+	if got := strings.Count(contents["usage.jsonl"], "\n"); got != exportPageSize+5 {
+		t.Fatalf("usage.jsonl has %d rows, want %d", got, exportPageSize+5)
+	}
+	if got := strings.Count(contents["members.jsonl"], "\n"); got != exportPageSize+2 {
+		t.Fatalf("members.jsonl has %d rows, want %d", got, exportPageSize+2)
+	}
+	if strings.Contains(contents["api_keys.jsonl"], "super-secret-hash") || strings.Contains(contents["api_keys.jsonl"], "secret_hash") {
+		t.Fatalf("api key secret leaked into export: %s", contents["api_keys.jsonl"])
+	}
+	if strings.Contains(contents["files.jsonl"], "storage_key") {
+		t.Fatalf("storage key leaked into export: %s", contents["files.jsonl"])
+	}
+	var key map[string]any
+	if err := json.Unmarshal([]byte(contents["api_keys.jsonl"]), &key); err != nil || key["prefix"] != "sk-abc" {
+		t.Fatalf("unexpected api key row %q: %v", contents["api_keys.jsonl"], err)
+	}
+}
+
+func TestTenantExportHeartbeatsBetweenPages(t *testing.T) {
+	queries := &stubExportQueries{}
+	for i := 0; i < 3*exportPageSize; i++ {
+		queries.requests = append(queries.requests, db.Request{ID: toPgUUID(uuid.New())})
+	}
+	now := time.Date(2025, 11, 17, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time {
+		now = now.Add(exportHeartbeatEvery)
+		return now
+	}
+	exporter := &tenantExporter{queries: queries, store: &stubExportStore{}, now: clock}
+
+	exporter.run(context.Background(), toPgUUID(uuid.New()), uuid.New())
+
+	if queries.failed != nil {
+		t.Fatalf("export failed: %s", queries.failed.Error.String)
+	}
+	// One heartbeat after each of the first three usage pages, on top of the
+	// per-section progress writes.
+	if got, want := len(queries.progress), len(exportSections)+3; got != want {
+		t.Fatalf("progress writes = %d, want %d", got, want)
+	}
+}
+
+func TestToExportJobDownloadable(t *testing.T) {
+	now := time.Now()
+	job := db.TenantExportJob{
+		Status:     ExportStatusCompleted,
+		StorageKey: pgtype.Text{String: "tenant-exports/a/b.zip", Valid: true},
+		ExpiresAt:  pgtype.Timestamptz{Time: now.Add(time.Hour), Valid: true},
+	}
+	if !toExportJob(job, now).Downloadable {
+		t.Fatal("expected unexpired archive to be downloadable")
+	}
+	if toExportJob(job, now.Add(2*time.Hour)).Downloadable {
+		t.Fatal("expected expired archive not to be downloadable")
+	}
+	job.StorageKey = pgtype.Text{}
+	if toExportJob(job, now).Downloadable {
+		t.Fatal("expected swept archive not to be downloadable")
+	}
+}
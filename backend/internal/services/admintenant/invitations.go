@@ -0,0 +1,453 @@
+package admintenant
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/auth"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+// Mailer sends plain-text email. usagepipeline.SMTPSink satisfies it.
+type Mailer interface {
+	SendMail(ctx context.Context, to []string, subject, body string) error
+}
+
+var (
+	ErrTenantNotFound         = errors.New("tenant not found")
+	ErrInvitationNotFound     = errors.New("invitation not found")
+	ErrInvitationInvalid      = errors.New("invitation is invalid or expired")
+	ErrInvitationEmailMissing = errors.New("email is required")
+	ErrMailerUnavailable      = errors.New("smtp is not configured")
+	// ErrInvitationLoginRequired reports an invitation for an email that
+	// already has an account; the user must sign in and accept it as
+	// themselves.
+	ErrInvitationLoginRequired = errors.New("an account already exists for this email; sign in to accept the invitation")
+)
+
+// Invitation is a pending offer of tenant membership.
+type Invitation struct {
+	ID        uuid.UUID
+	TenantID  uuid.UUID
+	Email     string
+	Role      db.MembershipRole
+	InvitedBy *uuid.UUID
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// AcceptedInvitation is the membership created by accepting an invitation.
+type AcceptedInvitation struct {
+	Membership Membership
+	User       db.User
+}
+
+// CreateInvitation records a signed invitation for email to join tenantID
+// with role, replacing any pending invitation for the same address, and
+// mails the one-time token to the invitee. The token is only ever sent to
+// the invitee's address: it is not stored (only its hash is) and not returned
+// to the inviter. When the email cannot be sent the invitation is revoked.
+func (s *Service) CreateInvitation(ctx context.Context, tenantID uuid.UUID, email string, role db.MembershipRole, invitedBy uuid.UUID) (Invitation, error) {
+	if s == nil || s.queries == nil || s.cfg == nil {
+		return Invitation{}, ErrServiceUnavailable
+	}
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return Invitation{}, ErrInvitationEmailMissing
+	}
+	if s.mailer == nil {
+		return Invitation{}, ErrMailerUnavailable
+	}
+	tenant, err := s.queries.GetTenantByID(ctx, toPgUUID(tenantID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Invitation{}, ErrTenantNotFound
+		}
+		return Invitation{}, err
+	}
+
+	expiresAt := time.Now().UTC().Add(s.cfg.Admin.InvitationTTL).Truncate(time.Second)
+	token := signInvitationToken(s.invitationSecret(), tenantID, email, role, expiresAt)
+
+	if err := s.queries.RevokePendingTenantInvitationsForEmail(ctx, db.RevokePendingTenantInvitationsForEmailParams{
+		TenantID: toPgUUID(tenantID),
+		Email:    email,
+	}); err != nil {
+		return Invitation{}, err
+	}
+	var inviter pgtype.UUID
+	if invitedBy != uuid.Nil {
+		inviter = toPgUUID(invitedBy)
+	}
+	record, err := s.queries.CreateTenantInvitation(ctx, db.CreateTenantInvitationParams{
+		TenantID:  toPgUUID(tenantID),
+		Email:     email,
+		Role:      role,
+		TokenHash: hashInvitationToken(token),
+		InvitedBy: inviter,
+		ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+	})
+	if err != nil {
+		return Invitation{}, err
+	}
+	invitation, err := invitationFromRecord(record)
+	if err != nil {
+		return Invitation{}, err
+	}
+
+	subject := fmt.Sprintf("You're invited to join %s", tenant.Name)
+	body := fmt.Sprintf("You have been invited to join the tenant %q as %s.\n\nIf you are new, accept the invitation by sending this token to POST /v1/invitations/accept. If you already have an account, sign in and send it to POST /user/invitations/accept:\n\n%s\n\nThe invitation expires at %s.\n",
+		tenant.Name, role, token, expiresAt.Format(time.RFC1123))
+	if err := s.mailer.SendMail(ctx, []string{email}, subject, body); err != nil {
+		if _, revokeErr := s.queries.RevokeTenantInvitation(ctx, db.RevokeTenantInvitationParams{
+			ID:       record.ID,
+			TenantID: record.TenantID,
+		}); revokeErr != nil {
+			slog.Warn("revoke unsent invitation failed", slog.String("tenant_id", tenantID.String()), slog.String("error", revokeErr.Error()))
+		}
+		return Invitation{}, fmt.Errorf("send invitation email: %w", err)
+	}
+	return invitation, nil
+}
+
+// ListPendingInvitations returns unexpired invitations that have been neither
+// accepted nor revoked.
+func (s *Service) ListPendingInvitations(ctx context.Context, tenantID uuid.UUID) ([]Invitation, error) {
+	if s == nil || s.queries == nil {
+		return nil, ErrServiceUnavailable
+	}
+	rows, err := s.queries.ListPendingTenantInvitations(ctx, toPgUUID(tenantID))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Invitation, 0, len(rows))
+	for _, row := range rows {
+		invitation, err := invitationFromRecord(row)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, invitation)
+	}
+	return out, nil
+}
+
+// RevokeInvitation cancels a pending invitation so its token can no longer be
+// accepted.
+func (s *Service) RevokeInvitation(ctx context.Context, tenantID, invitationID uuid.UUID) error {
+	if s == nil || s.queries == nil {
+		return ErrServiceUnavailable
+	}
+	rows, err := s.queries.RevokeTenantInvitation(ctx, db.RevokeTenantInvitationParams{
+		ID:       toPgUUID(invitationID),
+		TenantID: toPgUUID(tenantID),
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrInvitationNotFound
+	}
+	return nil
+}
+
+// AcceptInvitation redeems token for an invitee without an account: it
+// creates the user, adds the membership, and optionally sets a local
+// password, all in one transaction. When the invited email already belongs to
+// a user it returns ErrInvitationLoginRequired; that user accepts through
+// AcceptInvitationAsUser once signed in, so a token never grants access to an
+// existing account.
+func (s *Service) AcceptInvitation(ctx context.Context, token, password string) (AcceptedInvitation, error) {
+	if s == nil || s.queries == nil || s.cfg == nil || s.dbPool == nil {
+		return AcceptedInvitation{}, ErrServiceUnavailable
+	}
+	record, claims, err := s.verifyInvitation(ctx, token)
+	if err != nil {
+		return AcceptedInvitation{}, err
+	}
+	if _, err := s.queries.GetUserByEmail(ctx, record.Email); err == nil {
+		return AcceptedInvitation{}, ErrInvitationLoginRequired
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return AcceptedInvitation{}, err
+	}
+	if password != "" && (s.adminAuth == nil || !s.cfg.Admin.Local.Enabled) {
+		return AcceptedInvitation{}, ErrLocalAuthDisabled
+	}
+
+	tx, err := s.dbPool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return AcceptedInvitation{}, err
+	}
+	defer tx.Rollback(ctx)
+	qtx := s.queries.WithTx(tx)
+	if err := markInvitationAccepted(ctx, qtx, record.ID); err != nil {
+		return AcceptedInvitation{}, err
+	}
+	user, err := qtx.CreateUser(ctx, db.CreateUserParams{
+		Email: record.Email,
+		Name:  record.Email,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return AcceptedInvitation{}, ErrInvitationLoginRequired
+		}
+		return AcceptedInvitation{}, err
+	}
+	membership, err := upsertMembershipRecord(ctx, qtx, record.TenantID, user.ID, record.Role)
+	if err != nil {
+		return AcceptedInvitation{}, err
+	}
+	if password != "" {
+		if err := upsertLocalPassword(ctx, qtx, user, password); err != nil {
+			return AcceptedInvitation{}, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return AcceptedInvitation{}, err
+	}
+
+	if s.accounts != nil {
+		if updated, _, err := s.accounts.EnsurePersonalTenant(ctx, user); err != nil {
+			slog.Warn("invitation: personal tenant", slog.String("email", user.Email), slog.String("error", err.Error()))
+		} else {
+			user = updated
+		}
+	}
+	return acceptedInvitation(claims.TenantID, user, membership)
+}
+
+// AcceptInvitationAsUser redeems token for the signed-in user userID. The
+// invitation must have been addressed to that user's email.
+func (s *Service) AcceptInvitationAsUser(ctx context.Context, token string, userID uuid.UUID) (AcceptedInvitation, error) {
+	if s == nil || s.queries == nil || s.cfg == nil || s.dbPool == nil {
+		return AcceptedInvitation{}, ErrServiceUnavailable
+	}
+	record, claims, err := s.verifyInvitation(ctx, token)
+	if err != nil {
+		return AcceptedInvitation{}, err
+	}
+	user, err := s.queries.GetUserByID(ctx, toPgUUID(userID))
+	if err != nil {
+		return AcceptedInvitation{}, err
+	}
+	if !strings.EqualFold(strings.TrimSpace(user.Email), record.Email) {
+		return AcceptedInvitation{}, ErrInvitationInvalid
+	}
+
+	tx, err := s.dbPool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return AcceptedInvitation{}, err
+	}
+	defer tx.Rollback(ctx)
+	qtx := s.queries.WithTx(tx)
+	if err := markInvitationAccepted(ctx, qtx, record.ID); err != nil {
+		return AcceptedInvitation{}, err
+	}
+	membership, err := upsertMembershipRecord(ctx, qtx, record.TenantID, user.ID, record.Role)
+	if err != nil {
+		return AcceptedInvitation{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return AcceptedInvitation{}, err
+	}
+	return acceptedInvitation(claims.TenantID, user, membership)
+}
+
+// verifyInvitation checks token's signature and expiry, then that it matches
+// a pending invitation.
+func (s *Service) verifyInvitation(ctx context.Context, token string) (db.TenantInvitation, invitationClaims, error) {
+	token = strings.TrimSpace(token)
+	claims, err := parseInvitationToken(s.invitationSecret(), token, time.Now().UTC())
+	if err != nil {
+		return db.TenantInvitation{}, invitationClaims{}, err
+	}
+	record, err := s.queries.GetTenantInvitationByTokenHash(ctx, hashInvitationToken(token))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.TenantInvitation{}, invitationClaims{}, ErrInvitationInvalid
+		}
+		return db.TenantInvitation{}, invitationClaims{}, err
+	}
+	if record.AcceptedAt.Valid || record.RevokedAt.Valid ||
+		record.TenantID != toPgUUID(claims.TenantID) || record.Email != claims.Email || record.Role != claims.Role {
+		return db.TenantInvitation{}, invitationClaims{}, ErrInvitationInvalid
+	}
+	return record, claims, nil
+}
+
+// markInvitationAccepted claims the invitation inside the caller's
+// transaction, so a token is redeemed at most once.
+func markInvitationAccepted(ctx context.Context, q *db.Queries, id pgtype.UUID) error {
+	accepted, err := q.MarkTenantInvitationAccepted(ctx, id)
+	if err != nil {
+		return err
+	}
+	if accepted == 0 {
+		return ErrInvitationInvalid
+	}
+	return nil
+}
+
+// upsertLocalPassword stores a local-login password for user within q's
+// transaction.
+func upsertLocalPassword(ctx context.Context, q *db.Queries, user db.User, password string) error {
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return err
+	}
+	if _, err := q.UpsertCredential(ctx, db.UpsertCredentialParams{
+		UserID:       user.ID,
+		Provider:     auth.ProviderLocal,
+		Issuer:       auth.ProviderLocal,
+		Subject:      user.Email,
+		PasswordHash: pgtype.Text{String: hash, Valid: true},
+		Metadata:     json.RawMessage(`{}`),
+	}); err != nil {
+		return fmt.Errorf("upsert credential: %w", err)
+	}
+	return nil
+}
+
+func acceptedInvitation(tenantID uuid.UUID, user db.User, membership db.TenantMembership) (AcceptedInvitation, error) {
+	userUUID, err := uuidFromPg(user.ID)
+	if err != nil {
+		return AcceptedInvitation{}, err
+	}
+	created, err := timeFromPg(membership.CreatedAt)
+	if err != nil {
+		return AcceptedInvitation{}, err
+	}
+	return AcceptedInvitation{
+		Membership: Membership{
+			TenantID: tenantID,
+			UserID:   userUUID,
+			Email:    user.Email,
+			Role:     membership.Role,
+			Created:  created,
+		},
+		User: user,
+	}, nil
+}
+
+func (s *Service) invitationSecret() []byte {
+	return []byte(s.cfg.Admin.Session.JWTSecret)
+}
+
+type invitationClaims struct {
+	TenantID  uuid.UUID
+	Email     string
+	Role      db.MembershipRole
+	ExpiresAt time.Time
+}
+
+// signInvitationToken encodes tenant_id|email|role|expires_at alongside its
+// HMAC-SHA256 so acceptance can be checked before touching the database.
+func signInvitationToken(secret []byte, tenantID uuid.UUID, email string, role db.MembershipRole, expiresAt time.Time) string {
+	payload := strings.Join([]string{
+		tenantID.String(),
+		email,
+		string(role),
+		strconv.FormatInt(expiresAt.Unix(), 10),
+	}, "|")
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." +
+		base64.RawURLEncoding.EncodeToString(invitationMAC(secret, payload))
+}
+
+func parseInvitationToken(secret []byte, token string, now time.Time) (invitationClaims, error) {
+	encodedPayload, encodedMAC, ok := strings.Cut(token, ".")
+	if !ok {
+		return invitationClaims{}, ErrInvitationInvalid
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return invitationClaims{}, ErrInvitationInvalid
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(encodedMAC)
+	if err != nil || !hmac.Equal(mac, invitationMAC(secret, string(payload))) {
+		return invitationClaims{}, ErrInvitationInvalid
+	}
+
+	parts := strings.Split(string(payload), "|")
+	if len(parts) < 4 {
+		return invitationClaims{}, ErrInvitationInvalid
+	}
+	tenantID, err := uuid.Parse(parts[0])
+	if err != nil {
+		return invitationClaims{}, ErrInvitationInvalid
+	}
+	expiresUnix, err := strconv.ParseInt(parts[len(parts)-1], 10, 64)
+	if err != nil {
+		return invitationClaims{}, ErrInvitationInvalid
+	}
+	expiresAt := time.Unix(expiresUnix, 0).UTC()
+	if !now.Before(expiresAt) {
+		return invitationClaims{}, ErrInvitationInvalid
+	}
+	return invitationClaims{
+		TenantID:  tenantID,
+		Email:     strings.Join(parts[1:len(parts)-2], "|"),
+		Role:      db.MembershipRole(parts[len(parts)-2]),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func invitationMAC(secret []byte, payload string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+func hashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func invitationFromRecord(record db.TenantInvitation) (Invitation, error) {
+	id, err := uuidFromPg(record.ID)
+	if err != nil {
+		return Invitation{}, err
+	}
+	tenantID, err := uuidFromPg(record.TenantID)
+	if err != nil {
+		return Invitation{}, err
+	}
+	expiresAt, err := timeFromPg(record.ExpiresAt)
+	if err != nil {
+		return Invitation{}, err
+	}
+	createdAt, err := timeFromPg(record.CreatedAt)
+	if err != nil {
+		return Invitation{}, err
+	}
+	invitation := Invitation{
+		ID:        id,
+		TenantID:  tenantID,
+		Email:     record.Email,
+		Role:      record.Role,
+		ExpiresAt: expiresAt,
+		CreatedAt: createdAt,
+	}
+	if record.InvitedBy.Valid {
+		inviter, err := uuidFromPg(record.InvitedBy)
+		if err != nil {
+			return Invitation{}, err
+		}
+		invitation.InvitedBy = &inviter
+	}
+	return invitation, nil
+}
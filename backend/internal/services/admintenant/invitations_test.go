@@ -0,0 +1,42 @@
+package admintenant
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+func TestInvitationTokenRoundTrip(t *testing.T) {
+	secret := []byte("secret")
+	tenantID := uuid.New()
+	now := time.Date(2025, 11, 16, 12, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(time.Hour)
+
+	token := signInvitationToken(secret, tenantID, "a|b@example.com", db.MembershipRoleAdmin, expiresAt)
+	claims, err := parseInvitationToken(secret, token, now)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if claims.TenantID != tenantID || claims.Email != "a|b@example.com" || claims.Role != db.MembershipRoleAdmin || !claims.ExpiresAt.Equal(expiresAt) {
+		t.Fatalf("unexpected claims %+v", claims)
+	}
+
+	if _, err := parseInvitationToken(secret, token, expiresAt); !errors.Is(err, ErrInvitationInvalid) {
+		t.Fatalf("expected expired token to be rejected, got %v", err)
+	}
+	if _, err := parseInvitationToken([]byte("other"), token, now); !errors.Is(err, ErrInvitationInvalid) {
+		t.Fatalf("expected token signed with another secret to be rejected, got %v", err)
+	}
+
+	forged := signInvitationToken(secret, tenantID, "a|b@example.com", db.MembershipRoleOwner, expiresAt)
+	payload, _, _ := strings.Cut(forged, ".")
+	_, mac, _ := strings.Cut(token, ".")
+	if _, err := parseInvitationToken(secret, payload+"."+mac, now); !errors.Is(err, ErrInvitationInvalid) {
+		t.Fatalf("expected tampered role to be rejected, got %v", err)
+	}
+}
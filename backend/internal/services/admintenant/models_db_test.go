@@ -0,0 +1,50 @@
+package admintenant
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/database/dbtest"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+func TestSetModelsPersistsPatterns(t *testing.T) {
+	pool := dbtest.Open(t)
+	queries := db.New(pool)
+	ctx := context.Background()
+
+	tenant, err := queries.CreateTenant(ctx, db.CreateTenantParams{
+		Name:   "model-patterns-" + uuid.NewString(),
+		Status: db.TenantStatusActive,
+		Kind:   db.TenantKindOrganization,
+	})
+	if err != nil {
+		t.Fatalf("create tenant: %v", err)
+	}
+	t.Cleanup(func() { _ = queries.DeleteTenant(context.Background(), tenant.ID) })
+	tenantID := uuid.UUID(tenant.ID.Bytes)
+
+	var cached []string
+	svc := NewService(&config.Config{}, queries, time.UTC, pool, nil, nil, nil,
+		func(_ uuid.UUID, aliases []string) { cached = aliases }, nil, nil, nil, nil)
+
+	patterns := []string{"claude-*", "gpt-4*"}
+	if _, err := svc.SetModels(ctx, tenantID, patterns); err != nil {
+		t.Fatalf("set pattern allowlist: %v", err)
+	}
+	stored, err := svc.ListModels(ctx, tenantID)
+	if err != nil {
+		t.Fatalf("list models: %v", err)
+	}
+	if !reflect.DeepEqual(stored, patterns) {
+		t.Fatalf("stored models = %v, want %v", stored, patterns)
+	}
+	if !reflect.DeepEqual(cached, patterns) {
+		t.Fatalf("cached models = %v, want %v", cached, patterns)
+	}
+}
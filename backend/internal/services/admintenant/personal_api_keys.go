@@ -0,0 +1,111 @@
+package admintenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+var (
+	ErrNoPersonalTenant    = errors.New("user has no personal tenant")
+	ErrPersonalAPIKeyLimit = errors.New("personal api key limit reached")
+	ErrAPIKeyNotOwned      = errors.New("api key does not belong to user")
+)
+
+// CreatePersonalAPIKey issues a personal key on the user's personal tenant,
+// creating the tenant if needed. Users may hold at most
+// api_keys.max_personal_api_keys active personal keys.
+func (s *Service) CreatePersonalAPIKey(ctx context.Context, userID uuid.UUID, name string, scopesJSON []byte) (APIKeyCreateResult, error) {
+	if s == nil || s.queries == nil || s.cfg == nil {
+		return APIKeyCreateResult{}, ErrServiceUnavailable
+	}
+	tenantID, err := s.ensurePersonalTenant(ctx, userID)
+	if err != nil {
+		return APIKeyCreateResult{}, err
+	}
+	keys, err := s.queries.ListPersonalAPIKeysByUser(ctx, toPgUUID(userID))
+	if err != nil {
+		return APIKeyCreateResult{}, err
+	}
+	if limit := s.cfg.APIKeys.MaxPersonalAPIKeys; countActivePersonalKeys(keys) >= limit {
+		return APIKeyCreateResult{}, fmt.Errorf("%w (%d)", ErrPersonalAPIKeyLimit, limit)
+	}
+	return s.issueAPIKey(ctx, apiKeySpec{
+		TenantID:    tenantID,
+		OwnerUserID: userID,
+		Kind:        db.ApiKeyKindPersonal,
+		Name:        name,
+		ScopesJSON:  scopesJSON,
+		QuotaJSON:   []byte("{}"),
+	}, db.ApiKeyKindPersonal)
+}
+
+// ListPersonalAPIKeys returns the user's personal keys, newest first.
+func (s *Service) ListPersonalAPIKeys(ctx context.Context, userID uuid.UUID) ([]db.ApiKey, error) {
+	if s == nil || s.queries == nil {
+		return nil, ErrServiceUnavailable
+	}
+	keys, err := s.queries.ListPersonalAPIKeysByUser(ctx, toPgUUID(userID))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]db.ApiKey, 0, len(keys))
+	for _, key := range keys {
+		if key.Kind == db.ApiKeyKindPersonal {
+			out = append(out, key)
+		}
+	}
+	return out, nil
+}
+
+// RevokePersonalAPIKey revokes one of the user's personal keys.
+func (s *Service) RevokePersonalAPIKey(ctx context.Context, userID, apiKeyID uuid.UUID) (db.ApiKey, error) {
+	if s == nil || s.queries == nil {
+		return db.ApiKey{}, ErrServiceUnavailable
+	}
+	record, err := s.queries.GetAPIKeyByID(ctx, toPgUUID(apiKeyID))
+	if err != nil {
+		return db.ApiKey{}, err
+	}
+	owner, err := uuidFromPg(record.OwnerUserID)
+	if err != nil || owner != userID || record.Kind != db.ApiKeyKindPersonal {
+		return db.ApiKey{}, ErrAPIKeyNotOwned
+	}
+	return s.queries.RevokeAPIKey(ctx, record.ID)
+}
+
+func (s *Service) ensurePersonalTenant(ctx context.Context, userID uuid.UUID) (uuid.UUID, error) {
+	user, err := s.queries.GetUserByID(ctx, toPgUUID(userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, ErrNoPersonalTenant
+		}
+		return uuid.Nil, err
+	}
+	if user.PersonalTenantID.Valid {
+		return uuidFromPg(user.PersonalTenantID)
+	}
+	if s.accounts == nil {
+		return uuid.Nil, ErrNoPersonalTenant
+	}
+	_, tenant, err := s.accounts.EnsurePersonalTenant(ctx, user)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return uuidFromPg(tenant.ID)
+}
+
+func countActivePersonalKeys(keys []db.ApiKey) int {
+	count := 0
+	for _, key := range keys {
+		if key.Kind == db.ApiKeyKindPersonal && !key.RevokedAt.Valid {
+			count++
+		}
+	}
+	return count
+}
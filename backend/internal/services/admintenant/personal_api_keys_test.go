@@ -0,0 +1,41 @@
+package admintenant
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+func TestCountActivePersonalKeys(t *testing.T) {
+	revoked := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+	keys := []db.ApiKey{
+		{Kind: db.ApiKeyKindPersonal},
+		{Kind: db.ApiKeyKindPersonal},
+		{Kind: db.ApiKeyKindPersonal, RevokedAt: revoked},
+		{Kind: db.ApiKeyKindService},
+	}
+	if got := countActivePersonalKeys(keys); got != 2 {
+		t.Fatalf("expected 2 active personal keys, got %d", got)
+	}
+}
+
+func TestCheckAPIKeyKind(t *testing.T) {
+	owner := uuid.New()
+	if err := checkAPIKeyKind(apiKeySpec{Kind: db.ApiKeyKindService}, db.ApiKeyKindService); err != nil {
+		t.Fatalf("service key rejected: %v", err)
+	}
+	if err := checkAPIKeyKind(apiKeySpec{Kind: db.ApiKeyKindPersonal, OwnerUserID: owner}, db.ApiKeyKindPersonal); err != nil {
+		t.Fatalf("personal key rejected: %v", err)
+	}
+	if err := checkAPIKeyKind(apiKeySpec{Kind: db.ApiKeyKindPersonal, OwnerUserID: owner}, db.ApiKeyKindService); !errors.Is(err, ErrAPIKeyKindNotAllowed) {
+		t.Fatalf("expected kind mismatch to be rejected, got %v", err)
+	}
+	if err := checkAPIKeyKind(apiKeySpec{Kind: db.ApiKeyKindPersonal}, db.ApiKeyKindPersonal); !errors.Is(err, ErrAPIKeyKindNotAllowed) {
+		t.Fatalf("expected ownerless personal key to be rejected, got %v", err)
+	}
+}
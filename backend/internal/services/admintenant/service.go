@@ -18,36 +18,47 @@ import (
 	"github.com/ncecere/open_model_gateway/backend/internal/config"
 	"github.com/ncecere/open_model_gateway/backend/internal/db"
 	"github.com/ncecere/open_model_gateway/backend/internal/limits"
+	"github.com/ncecere/open_model_gateway/backend/internal/region"
+	"github.com/ncecere/open_model_gateway/backend/internal/schemavalidation"
+	"github.com/ncecere/open_model_gateway/backend/internal/storage/blob"
+	"github.com/ncecere/open_model_gateway/backend/internal/wildcard"
 )
 
 // Service centralizes admin-facing tenant operations.
 type Service struct {
-	queries         *db.Queries
-	cfg             *config.Config
-	timezone        *time.Location
-	dbPool          *pgxpool.Pool
-	accounts        *accounts.PersonalService
-	adminAuth       *auth.AdminAuthService
-	setTenantModels func(uuid.UUID, []string)
-	setTenantRate   func(uuid.UUID, *limits.LimitConfig)
-	setAPIKeyRate   func(string, *limits.LimitConfig)
+	queries          *db.Queries
+	cfg              *config.Config
+	timezone         *time.Location
+	dbPool           *pgxpool.Pool
+	accounts         *accounts.PersonalService
+	adminAuth        *auth.AdminAuthService
+	mailer           Mailer
+	setTenantModels  func(uuid.UUID, []string)
+	setTenantRate    func(uuid.UUID, *limits.LimitConfig)
+	setAPIKeyRate    func(string, *limits.LimitConfig)
+	setTenantQuota   func(uuid.UUID, *limits.QuotaConfig)
+	invalidatePrompt func(uuid.UUID)
+	exports          blob.Store
 }
 
 // NewService builds an admin tenant service.
-func NewService(cfg *config.Config, queries *db.Queries, tz *time.Location, pool *pgxpool.Pool, accounts *accounts.PersonalService, adminAuth *auth.AdminAuthService, setTenantModels func(uuid.UUID, []string), setTenantRate func(uuid.UUID, *limits.LimitConfig), setAPIKeyRate func(string, *limits.LimitConfig)) *Service {
+func NewService(cfg *config.Config, queries *db.Queries, tz *time.Location, pool *pgxpool.Pool, accounts *accounts.PersonalService, adminAuth *auth.AdminAuthService, mailer Mailer, setTenantModels func(uuid.UUID, []string), setTenantRate func(uuid.UUID, *limits.LimitConfig), setAPIKeyRate func(string, *limits.LimitConfig), setTenantQuota func(uuid.UUID, *limits.QuotaConfig), invalidatePrompt func(uuid.UUID)) *Service {
 	if tz == nil {
 		tz = time.UTC
 	}
 	return &Service{
-		cfg:             cfg,
-		queries:         queries,
-		timezone:        tz,
-		dbPool:          pool,
-		accounts:        accounts,
-		adminAuth:       adminAuth,
-		setTenantModels: setTenantModels,
-		setTenantRate:   setTenantRate,
-		setAPIKeyRate:   setAPIKeyRate,
+		cfg:              cfg,
+		queries:          queries,
+		timezone:         tz,
+		dbPool:           pool,
+		accounts:         accounts,
+		adminAuth:        adminAuth,
+		mailer:           mailer,
+		setTenantModels:  setTenantModels,
+		setTenantRate:    setTenantRate,
+		setAPIKeyRate:    setAPIKeyRate,
+		setTenantQuota:   setTenantQuota,
+		invalidatePrompt: invalidatePrompt,
 	}
 }
 
@@ -55,11 +66,29 @@ var (
 	ErrServiceUnavailable   = errors.New("admin tenant service not initialized")
 	ErrInvalidModelList     = errors.New("models must include at least one alias")
 	ErrModelNotFound        = errors.New("model not found")
+	ErrInvalidModelPattern  = errors.New("invalid model pattern")
 	ErrAPIKeyTenantMismatch = errors.New("api key does not belong to tenant")
+	ErrAPIKeyNotSuspended   = errors.New("api key is not suspended")
 	ErrLocalAuthDisabled    = errors.New("local authentication disabled")
 	ErrInvalidRateLimit     = errors.New("rate limits must be positive")
+	ErrInvalidQuota         = errors.New("max_requests_per_period must be positive")
+	ErrInvalidBodyLimit     = errors.New("max_request_body_mb must be zero or positive")
+	ErrInvalidStreamIdle    = errors.New("stream_idle_timeout_sec must be zero or positive and within the server stream_max_duration")
+	ErrInvalidAbuseLimit    = errors.New("abuse_fingerprint_threshold must be zero or positive")
+	ErrInvalidResidency     = errors.New("data_residency entries must be two-letter country codes or EU")
+	ErrInvalidModelOverride = errors.New("overrides must be >= 0 and at least one must be set")
+	ErrModelOverrideMissing = errors.New("model override not found")
+	ErrInvalidSystemPrompt  = errors.New("system prompt content is required")
+	ErrInvalidPromptMode    = errors.New("mode must be prepend, append, or replace")
+	ErrAPIKeyKindNotAllowed = errors.New("api key kind not allowed")
+	ErrBulkTooLarge         = fmt.Errorf("at most %d tenant ids per call", MaxBulkTenantIDs)
+	ErrInvalidParent        = errors.New("a tenant cannot be its own parent")
+	ErrParentCycle          = errors.New("parent tenant is a descendant of the tenant")
 )
 
+// MaxBulkTenantIDs caps how many tenants one bulk status change may touch.
+const MaxBulkTenantIDs = 100
+
 // ListItem represents a tenant row plus budget summary.
 type ListItem struct {
 	ID             uuid.UUID
@@ -69,6 +98,19 @@ type ListItem struct {
 	BudgetLimitUSD float64
 	BudgetUsedUSD  float64
 	WarningThresh  *float64
+	CostCenter     string
+}
+
+// TenantSettings holds per-tenant gateway behaviour toggles.
+type TenantSettings struct {
+	SchemaValidationMode schemavalidation.Mode
+	// AbuseFingerprintThreshold overrides
+	// api_keys.fingerprint_abuse_suspension.threshold for the tenant's keys;
+	// zero inherits it.
+	AbuseFingerprintThreshold int32
+	// DataResidency restricts the tenant to routes keeping data in one of
+	// these country codes (or EU); empty allows every route.
+	DataResidency []string
 }
 
 // PersonalListItem represents a personal tenant linked to a specific user.
@@ -150,6 +192,7 @@ func (s *Service) List(ctx context.Context, limit, offset int32) ([]ListItem, er
 			BudgetLimitUSD: limitUSD,
 			BudgetUsedUSD:  used,
 			WarningThresh:  warnPtr,
+			CostCenter:     rec.CostCenter,
 		})
 	}
 	return items, nil
@@ -252,15 +295,87 @@ func (s *Service) UpdateTenantName(ctx context.Context, tenantID uuid.UUID, name
 	})
 }
 
-// UpdateTenantStatus updates tenant status.
-func (s *Service) UpdateTenantStatus(ctx context.Context, tenantID uuid.UUID, status db.TenantStatus) (db.Tenant, error) {
+// UpdateTenantCostCenter sets the cost center used to group the tenant in
+// chargeback reports. An empty value clears it.
+func (s *Service) UpdateTenantCostCenter(ctx context.Context, tenantID uuid.UUID, costCenter string) (db.Tenant, error) {
+	if s == nil || s.queries == nil {
+		return db.Tenant{}, ErrServiceUnavailable
+	}
+	return s.queries.UpdateTenantCostCenter(ctx, db.UpdateTenantCostCenterParams{
+		ID:         toPgUUID(tenantID),
+		CostCenter: costCenter,
+	})
+}
+
+// UpdateTenantStatus updates tenant status. Suspending a tenant emails its
+// owners; see NotifySuspension.
+func (s *Service) UpdateTenantStatus(ctx context.Context, tenantID uuid.UUID, status db.TenantStatus, reason string) (db.Tenant, error) {
 	if s == nil || s.queries == nil {
 		return db.Tenant{}, ErrServiceUnavailable
 	}
-	return s.queries.UpdateTenantStatus(ctx, db.UpdateTenantStatusParams{
+	tenant, err := s.queries.UpdateTenantStatus(ctx, db.UpdateTenantStatusParams{
 		ID:     toPgUUID(tenantID),
 		Status: status,
 	})
+	if err != nil {
+		return db.Tenant{}, err
+	}
+	if status == db.TenantStatusSuspended {
+		s.notifySuspension(ctx, tenantID, reason)
+	}
+	return tenant, nil
+}
+
+// BulkUpdateStatus sets status on every tenant in ids with one statement.
+// IDs that matched no tenant are returned in failed. Suspended tenants'
+// owners are emailed as with UpdateTenantStatus.
+func (s *Service) BulkUpdateStatus(ctx context.Context, ids []uuid.UUID, status db.TenantStatus, reason string) (succeeded, failed []uuid.UUID, err error) {
+	if s == nil || s.queries == nil {
+		return nil, nil, ErrServiceUnavailable
+	}
+	if len(ids) > MaxBulkTenantIDs {
+		return nil, nil, ErrBulkTooLarge
+	}
+	if len(ids) == 0 {
+		return []uuid.UUID{}, []uuid.UUID{}, nil
+	}
+	pgIDs := make([]pgtype.UUID, 0, len(ids))
+	for _, id := range ids {
+		pgIDs = append(pgIDs, toPgUUID(id))
+	}
+	updated, err := s.queries.BulkUpdateTenantStatus(ctx, db.BulkUpdateTenantStatusParams{
+		Status: status,
+		Ids:    pgIDs,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	succeeded, failed = partitionBulkResult(ids, updated)
+	if status == db.TenantStatusSuspended {
+		for _, id := range succeeded {
+			s.notifySuspension(ctx, id, reason)
+		}
+	}
+	return succeeded, failed, nil
+}
+
+func partitionBulkResult(requested []uuid.UUID, updated []pgtype.UUID) (succeeded, failed []uuid.UUID) {
+	done := make(map[uuid.UUID]struct{}, len(updated))
+	for _, pgID := range updated {
+		if id, err := uuidFromPg(pgID); err == nil {
+			done[id] = struct{}{}
+		}
+	}
+	succeeded = make([]uuid.UUID, 0, len(updated))
+	failed = make([]uuid.UUID, 0)
+	for _, id := range requested {
+		if _, ok := done[id]; ok {
+			succeeded = append(succeeded, id)
+		} else {
+			failed = append(failed, id)
+		}
+	}
+	return succeeded, failed
 }
 
 // ListModels returns tenant model aliases.
@@ -327,11 +442,42 @@ type APIKeyCreateResult struct {
 	Token  string
 }
 
-// CreateAPIKey issues a new service key.
-func (s *Service) CreateAPIKey(ctx context.Context, tenantID uuid.UUID, name string, scopesJSON, quotaJSON []byte, rateLimit *limits.LimitConfig) (APIKeyCreateResult, error) {
+// CreateAPIKey issues a new service key. Zero-retention keys only have counts
+// and costs recorded for their requests.
+func (s *Service) CreateAPIKey(ctx context.Context, tenantID uuid.UUID, name string, scopesJSON, quotaJSON []byte, rateLimit *limits.LimitConfig, zeroRetention bool) (APIKeyCreateResult, error) {
+	return s.issueAPIKey(ctx, apiKeySpec{
+		TenantID:      tenantID,
+		Kind:          db.ApiKeyKindService,
+		Name:          name,
+		ScopesJSON:    scopesJSON,
+		QuotaJSON:     quotaJSON,
+		RateLimit:     rateLimit,
+		ZeroRetention: zeroRetention,
+	}, db.ApiKeyKindService)
+}
+
+// apiKeySpec describes a key to issue. OwnerUserID is required for personal
+// keys and optional for service keys.
+type apiKeySpec struct {
+	TenantID      uuid.UUID
+	OwnerUserID   uuid.UUID
+	Kind          db.ApiKeyKind
+	Name          string
+	ScopesJSON    []byte
+	QuotaJSON     []byte
+	RateLimit     *limits.LimitConfig
+	ZeroRetention bool
+}
+
+// issueAPIKey creates the key after checking spec.Kind against allowedKind,
+// so each caller can only mint the kind of key it is meant to.
+func (s *Service) issueAPIKey(ctx context.Context, spec apiKeySpec, allowedKind db.ApiKeyKind) (APIKeyCreateResult, error) {
 	if s == nil || s.queries == nil {
 		return APIKeyCreateResult{}, ErrServiceUnavailable
 	}
+	if err := checkAPIKeyKind(spec, allowedKind); err != nil {
+		return APIKeyCreateResult{}, err
+	}
 	prefix, secret, token, err := auth.GenerateAPIKey()
 	if err != nil {
 		return APIKeyCreateResult{}, err
@@ -340,25 +486,31 @@ func (s *Service) CreateAPIKey(ctx context.Context, tenantID uuid.UUID, name str
 	if err != nil {
 		return APIKeyCreateResult{}, err
 	}
+	owner := pgtype.UUID{Valid: false}
+	if spec.OwnerUserID != uuid.Nil {
+		owner = toPgUUID(spec.OwnerUserID)
+	}
 	key, err := s.queries.CreateAPIKey(ctx, db.CreateAPIKeyParams{
-		TenantID:    toPgUUID(tenantID),
-		Prefix:      prefix,
-		SecretHash:  hash,
-		Name:        name,
-		ScopesJson:  scopesJSON,
-		QuotaJson:   quotaJSON,
-		Kind:        db.ApiKeyKindService,
-		OwnerUserID: pgtype.UUID{Valid: false},
+		TenantID:      toPgUUID(spec.TenantID),
+		Prefix:        prefix,
+		SecretHash:    hash,
+		Name:          spec.Name,
+		ScopesJson:    spec.ScopesJSON,
+		QuotaJson:     spec.QuotaJSON,
+		Kind:          spec.Kind,
+		OwnerUserID:   owner,
+		ZeroRetention: spec.ZeroRetention,
 	})
 	if err != nil {
 		return APIKeyCreateResult{}, err
 	}
-	if rateLimit != nil {
+	if rateLimit := spec.RateLimit; rateLimit != nil {
 		if _, err := s.queries.UpsertAPIKeyRateLimit(ctx, db.UpsertAPIKeyRateLimitParams{
-			ApiKeyID:          key.ID,
-			RequestsPerMinute: int32(rateLimit.RequestsPerMinute),
-			TokensPerMinute:   int32(rateLimit.TokensPerMinute),
-			ParallelRequests:  int32(rateLimit.ParallelRequests),
+			ApiKeyID:                  key.ID,
+			RequestsPerMinute:         int32(rateLimit.RequestsPerMinute),
+			TokensPerMinute:           int32(rateLimit.TokensPerMinute),
+			ParallelRequests:          int32(rateLimit.ParallelRequests),
+			ParallelRequestTtlSeconds: int32(rateLimit.ParallelRequestTTL / time.Second),
 		}); err != nil {
 			return APIKeyCreateResult{}, err
 		}
@@ -369,6 +521,16 @@ func (s *Service) CreateAPIKey(ctx context.Context, tenantID uuid.UUID, name str
 	return APIKeyCreateResult{Key: key, Secret: secret, Token: token}, nil
 }
 
+func checkAPIKeyKind(spec apiKeySpec, allowedKind db.ApiKeyKind) error {
+	if spec.Kind != allowedKind {
+		return ErrAPIKeyKindNotAllowed
+	}
+	if spec.Kind == db.ApiKeyKindPersonal && spec.OwnerUserID == uuid.Nil {
+		return ErrAPIKeyKindNotAllowed
+	}
+	return nil
+}
+
 // ListAPIKeys returns keys for tenant.
 func (s *Service) ListAPIKeys(ctx context.Context, tenantID uuid.UUID) ([]db.ApiKey, error) {
 	if s == nil || s.queries == nil {
@@ -399,6 +561,32 @@ func (s *Service) RevokeAPIKey(ctx context.Context, tenantID, apiKeyID uuid.UUID
 	return record, nil
 }
 
+// ReinstateAPIKey lifts the suspension of a tenant API key. Requests it sent
+// before now no longer count towards fingerprint abuse.
+func (s *Service) ReinstateAPIKey(ctx context.Context, tenantID, apiKeyID uuid.UUID) (db.ApiKey, error) {
+	if s == nil || s.queries == nil {
+		return db.ApiKey{}, ErrServiceUnavailable
+	}
+	key, err := s.queries.GetAPIKeyByID(ctx, toPgUUID(apiKeyID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.ApiKey{}, ErrAPIKeyTenantMismatch
+		}
+		return db.ApiKey{}, err
+	}
+	if uuid.UUID(key.TenantID.Bytes) != tenantID {
+		return db.ApiKey{}, ErrAPIKeyTenantMismatch
+	}
+	record, err := s.queries.ReinstateAPIKey(ctx, key.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.ApiKey{}, ErrAPIKeyNotSuspended
+		}
+		return db.ApiKey{}, err
+	}
+	return record, nil
+}
+
 // Membership represents membership details.
 type Membership struct {
 	TenantID uuid.UUID
@@ -464,7 +652,7 @@ func (s *Service) UpsertMembership(ctx context.Context, tenantID uuid.UUID, emai
 		}
 	}
 
-	membership, err := s.upsertMembershipRecord(ctx, toPgUUID(tenantID), user.ID, role)
+	membership, err := upsertMembershipRecord(ctx, s.queries, toPgUUID(tenantID), user.ID, role)
 	if err != nil {
 		return Membership{}, err
 	}
@@ -509,9 +697,10 @@ func (s *Service) GetTenantRateLimitOverride(ctx context.Context, tenantID uuid.
 		return limits.LimitConfig{}, false, err
 	}
 	cfg := limits.LimitConfig{
-		RequestsPerMinute: int(record.RequestsPerMinute),
-		TokensPerMinute:   int(record.TokensPerMinute),
-		ParallelRequests:  int(record.ParallelRequests),
+		RequestsPerMinute:  int(record.RequestsPerMinute),
+		TokensPerMinute:    int(record.TokensPerMinute),
+		ParallelRequests:   int(record.ParallelRequests),
+		ParallelRequestTTL: time.Duration(record.ParallelRequestTtlSeconds) * time.Second,
 	}
 	return cfg, true, nil
 }
@@ -521,22 +710,24 @@ func (s *Service) UpsertTenantRateLimitOverride(ctx context.Context, tenantID uu
 	if s == nil || s.queries == nil {
 		return limits.LimitConfig{}, ErrServiceUnavailable
 	}
-	if req.RequestsPerMinute <= 0 || req.TokensPerMinute <= 0 || req.ParallelRequests <= 0 {
+	if req.RequestsPerMinute <= 0 || req.TokensPerMinute <= 0 || req.ParallelRequests <= 0 || req.ParallelRequestTTL < 0 {
 		return limits.LimitConfig{}, ErrInvalidRateLimit
 	}
 	record, err := s.queries.UpsertTenantRateLimit(ctx, db.UpsertTenantRateLimitParams{
-		TenantID:          toPgUUID(tenantID),
-		RequestsPerMinute: int32(req.RequestsPerMinute),
-		TokensPerMinute:   int32(req.TokensPerMinute),
-		ParallelRequests:  int32(req.ParallelRequests),
+		TenantID:                  toPgUUID(tenantID),
+		RequestsPerMinute:         int32(req.RequestsPerMinute),
+		TokensPerMinute:           int32(req.TokensPerMinute),
+		ParallelRequests:          int32(req.ParallelRequests),
+		ParallelRequestTtlSeconds: int32(req.ParallelRequestTTL / time.Second),
 	})
 	if err != nil {
 		return limits.LimitConfig{}, err
 	}
 	cfg := limits.LimitConfig{
-		RequestsPerMinute: int(record.RequestsPerMinute),
-		TokensPerMinute:   int(record.TokensPerMinute),
-		ParallelRequests:  int(record.ParallelRequests),
+		RequestsPerMinute:  int(record.RequestsPerMinute),
+		TokensPerMinute:    int(record.TokensPerMinute),
+		ParallelRequests:   int(record.ParallelRequests),
+		ParallelRequestTTL: time.Duration(record.ParallelRequestTtlSeconds) * time.Second,
 	}
 	if s.setTenantRate != nil {
 		s.setTenantRate(tenantID, &cfg)
@@ -561,6 +752,320 @@ func (s *Service) DeleteTenantRateLimitOverride(ctx context.Context, tenantID uu
 	return nil
 }
 
+// GetTenantSettings returns the tenant's settings, falling back to defaults
+// when none have been stored.
+func (s *Service) GetTenantSettings(ctx context.Context, tenantID uuid.UUID) (TenantSettings, error) {
+	if s == nil || s.queries == nil {
+		return TenantSettings{}, ErrServiceUnavailable
+	}
+	record, err := s.queries.GetTenantSettings(ctx, toPgUUID(tenantID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return TenantSettings{SchemaValidationMode: schemavalidation.ModeStrict}, nil
+		}
+		return TenantSettings{}, err
+	}
+	mode, err := schemavalidation.ParseMode(record.SchemaValidationMode)
+	if err != nil {
+		return TenantSettings{}, err
+	}
+	return TenantSettings{
+		SchemaValidationMode:      mode,
+		AbuseFingerprintThreshold: record.AbuseFingerprintThreshold,
+		DataResidency:             record.DataResidency,
+	}, nil
+}
+
+// UpdateTenantSettings stores the tenant's settings.
+func (s *Service) UpdateTenantSettings(ctx context.Context, tenantID uuid.UUID, settings TenantSettings) (TenantSettings, error) {
+	if s == nil || s.queries == nil {
+		return TenantSettings{}, ErrServiceUnavailable
+	}
+	mode, err := schemavalidation.ParseMode(string(settings.SchemaValidationMode))
+	if err != nil {
+		return TenantSettings{}, err
+	}
+	if settings.AbuseFingerprintThreshold < 0 {
+		return TenantSettings{}, ErrInvalidAbuseLimit
+	}
+	residency := region.NormalizeCodes(settings.DataResidency)
+	if residency == nil {
+		residency = []string{}
+	}
+	for _, code := range residency {
+		if !region.ValidCode(code) {
+			return TenantSettings{}, ErrInvalidResidency
+		}
+	}
+	record, err := s.queries.UpsertTenantSettings(ctx, db.UpsertTenantSettingsParams{
+		TenantID:                  toPgUUID(tenantID),
+		SchemaValidationMode:      string(mode),
+		AbuseFingerprintThreshold: settings.AbuseFingerprintThreshold,
+		DataResidency:             residency,
+	})
+	if err != nil {
+		return TenantSettings{}, err
+	}
+	return TenantSettings{
+		SchemaValidationMode:      schemavalidation.Mode(record.SchemaValidationMode),
+		AbuseFingerprintThreshold: record.AbuseFingerprintThreshold,
+		DataResidency:             record.DataResidency,
+	}, nil
+}
+
+// GetTenantQuota returns the tenant's request quota override (if any).
+func (s *Service) GetTenantQuota(ctx context.Context, tenantID uuid.UUID) (db.TenantQuotaOverride, bool, error) {
+	if s == nil || s.queries == nil {
+		return db.TenantQuotaOverride{}, false, ErrServiceUnavailable
+	}
+	record, err := s.queries.GetTenantQuotaOverride(ctx, toPgUUID(tenantID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.TenantQuotaOverride{}, false, nil
+		}
+		return db.TenantQuotaOverride{}, false, err
+	}
+	return record, true, nil
+}
+
+// TenantQuotaInput describes a tenant quota override. Zero MaxRequestBodyMB
+// and StreamIdleTimeoutSec leave the server-wide behaviour in place.
+type TenantQuotaInput struct {
+	MaxRequestsPerPeriod int64
+	RefreshSchedule      string
+	MaxRequestBodyMB     int32
+	StreamIdleTimeoutSec int32
+}
+
+// UpsertTenantQuota caps the number of requests the tenant may make per period
+// and optionally the request body size and streaming idle time.
+func (s *Service) UpsertTenantQuota(ctx context.Context, tenantID uuid.UUID, input TenantQuotaInput) (db.TenantQuotaOverride, error) {
+	if s == nil || s.queries == nil {
+		return db.TenantQuotaOverride{}, ErrServiceUnavailable
+	}
+	if input.MaxRequestsPerPeriod <= 0 {
+		return db.TenantQuotaOverride{}, ErrInvalidQuota
+	}
+	if input.MaxRequestBodyMB < 0 {
+		return db.TenantQuotaOverride{}, ErrInvalidBodyLimit
+	}
+	if err := s.validateStreamIdleTimeout(input.StreamIdleTimeoutSec); err != nil {
+		return db.TenantQuotaOverride{}, err
+	}
+	record, err := s.queries.UpsertTenantQuotaOverride(ctx, db.UpsertTenantQuotaOverrideParams{
+		TenantID:             toPgUUID(tenantID),
+		MaxRequestsPerPeriod: input.MaxRequestsPerPeriod,
+		RefreshSchedule:      config.NormalizeBudgetRefreshSchedule(input.RefreshSchedule),
+		MaxRequestBodyMb:     input.MaxRequestBodyMB,
+		StreamIdleTimeoutSec: input.StreamIdleTimeoutSec,
+	})
+	if err != nil {
+		return db.TenantQuotaOverride{}, err
+	}
+	if s.setTenantQuota != nil {
+		s.setTenantQuota(tenantID, &limits.QuotaConfig{
+			MaxRequests:       record.MaxRequestsPerPeriod,
+			RefreshSchedule:   record.RefreshSchedule,
+			MaxRequestBodyMB:  record.MaxRequestBodyMb,
+			StreamIdleTimeout: time.Duration(record.StreamIdleTimeoutSec) * time.Second,
+		})
+	}
+	return record, nil
+}
+
+// validateStreamIdleTimeout rejects negative timeouts and any timeout longer
+// than the server's stream_max_duration, which would never fire.
+func (s *Service) validateStreamIdleTimeout(seconds int32) error {
+	if seconds < 0 {
+		return ErrInvalidStreamIdle
+	}
+	if s.cfg != nil && s.cfg.Server.StreamMaxDuration > 0 && time.Duration(seconds)*time.Second > s.cfg.Server.StreamMaxDuration {
+		return ErrInvalidStreamIdle
+	}
+	return nil
+}
+
+// DeleteTenantQuota removes the tenant's request quota.
+func (s *Service) DeleteTenantQuota(ctx context.Context, tenantID uuid.UUID) error {
+	if s == nil || s.queries == nil {
+		return ErrServiceUnavailable
+	}
+	if err := s.queries.DeleteTenantQuotaOverride(ctx, toPgUUID(tenantID)); err != nil {
+		return err
+	}
+	if s.setTenantQuota != nil {
+		s.setTenantQuota(tenantID, nil)
+	}
+	return nil
+}
+
+// ListTenantModelOverrides returns the tenant's per-model limit overrides.
+func (s *Service) ListTenantModelOverrides(ctx context.Context, tenantID uuid.UUID) ([]db.TenantModelOverride, error) {
+	if s == nil || s.queries == nil {
+		return nil, ErrServiceUnavailable
+	}
+	return s.queries.ListTenantModelOverrides(ctx, toPgUUID(tenantID))
+}
+
+// UpsertTenantModelOverride sets the tenant's context window and output cap
+// for alias. Zero leaves the catalog value in effect.
+func (s *Service) UpsertTenantModelOverride(ctx context.Context, tenantID uuid.UUID, alias string, contextWindow, maxOutputTokens int32) (db.TenantModelOverride, error) {
+	if s == nil || s.queries == nil {
+		return db.TenantModelOverride{}, ErrServiceUnavailable
+	}
+	alias = strings.TrimSpace(alias)
+	if contextWindow < 0 || maxOutputTokens < 0 || (contextWindow == 0 && maxOutputTokens == 0) {
+		return db.TenantModelOverride{}, ErrInvalidModelOverride
+	}
+	if _, err := s.queries.GetModelByAlias(ctx, alias); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.TenantModelOverride{}, fmt.Errorf("%w: %s", ErrModelNotFound, alias)
+		}
+		return db.TenantModelOverride{}, err
+	}
+	return s.queries.UpsertTenantModelOverride(ctx, db.UpsertTenantModelOverrideParams{
+		TenantID:                toPgUUID(tenantID),
+		ModelAlias:              alias,
+		ContextWindowOverride:   contextWindow,
+		MaxOutputTokensOverride: maxOutputTokens,
+	})
+}
+
+// DeleteTenantModelOverride restores the catalog limits for alias.
+func (s *Service) DeleteTenantModelOverride(ctx context.Context, tenantID uuid.UUID, alias string) error {
+	if s == nil || s.queries == nil {
+		return ErrServiceUnavailable
+	}
+	rows, err := s.queries.DeleteTenantModelOverride(ctx, db.DeleteTenantModelOverrideParams{
+		TenantID:   toPgUUID(tenantID),
+		ModelAlias: strings.TrimSpace(alias),
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrModelOverrideMissing
+	}
+	return nil
+}
+
+// GetTenantSystemPrompt returns the tenant's injected system prompt (if any).
+func (s *Service) GetTenantSystemPrompt(ctx context.Context, tenantID uuid.UUID) (db.TenantSystemPrompt, bool, error) {
+	if s == nil || s.queries == nil {
+		return db.TenantSystemPrompt{}, false, ErrServiceUnavailable
+	}
+	record, err := s.queries.GetTenantSystemPrompt(ctx, toPgUUID(tenantID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.TenantSystemPrompt{}, false, nil
+		}
+		return db.TenantSystemPrompt{}, false, err
+	}
+	return record, true, nil
+}
+
+// UpsertTenantSystemPrompt stores the prompt injected into the tenant's chat requests.
+func (s *Service) UpsertTenantSystemPrompt(ctx context.Context, tenantID uuid.UUID, content string, mode string) (db.TenantSystemPrompt, error) {
+	if s == nil || s.queries == nil {
+		return db.TenantSystemPrompt{}, ErrServiceUnavailable
+	}
+	if strings.TrimSpace(content) == "" {
+		return db.TenantSystemPrompt{}, ErrInvalidSystemPrompt
+	}
+	promptMode, err := parseSystemPromptMode(mode)
+	if err != nil {
+		return db.TenantSystemPrompt{}, err
+	}
+	record, err := s.queries.UpsertTenantSystemPrompt(ctx, db.UpsertTenantSystemPromptParams{
+		TenantID: toPgUUID(tenantID),
+		Content:  content,
+		Mode:     promptMode,
+	})
+	if err != nil {
+		return db.TenantSystemPrompt{}, err
+	}
+	if s.invalidatePrompt != nil {
+		s.invalidatePrompt(tenantID)
+	}
+	return record, nil
+}
+
+// DeleteTenantSystemPrompt stops injecting a prompt for the tenant.
+func (s *Service) DeleteTenantSystemPrompt(ctx context.Context, tenantID uuid.UUID) error {
+	if s == nil || s.queries == nil {
+		return ErrServiceUnavailable
+	}
+	if err := s.queries.DeleteTenantSystemPrompt(ctx, toPgUUID(tenantID)); err != nil {
+		return err
+	}
+	if s.invalidatePrompt != nil {
+		s.invalidatePrompt(tenantID)
+	}
+	return nil
+}
+
+// GetTenantParent returns the tenant's parent in the org-unit hierarchy (if any).
+func (s *Service) GetTenantParent(ctx context.Context, tenantID uuid.UUID) (db.TenantParent, bool, error) {
+	if s == nil || s.queries == nil {
+		return db.TenantParent{}, false, ErrServiceUnavailable
+	}
+	record, err := s.queries.GetTenantParent(ctx, toPgUUID(tenantID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.TenantParent{}, false, nil
+		}
+		return db.TenantParent{}, false, err
+	}
+	return record, true, nil
+}
+
+// SetTenantParent places the tenant under parentID, rejecting assignments
+// that would make the hierarchy cyclic.
+func (s *Service) SetTenantParent(ctx context.Context, tenantID, parentID uuid.UUID) (db.TenantParent, error) {
+	if s == nil || s.queries == nil {
+		return db.TenantParent{}, ErrServiceUnavailable
+	}
+	if parentID == uuid.Nil || parentID == tenantID {
+		return db.TenantParent{}, ErrInvalidParent
+	}
+	if _, err := s.queries.GetTenantByID(ctx, toPgUUID(parentID)); err != nil {
+		return db.TenantParent{}, err
+	}
+	cyclic, err := s.queries.IsTenantDescendant(ctx, db.IsTenantDescendantParams{
+		TenantID:       toPgUUID(parentID),
+		ParentTenantID: toPgUUID(tenantID),
+	})
+	if err != nil {
+		return db.TenantParent{}, err
+	}
+	if cyclic {
+		return db.TenantParent{}, ErrParentCycle
+	}
+	return s.queries.UpsertTenantParent(ctx, db.UpsertTenantParentParams{
+		TenantID:       toPgUUID(tenantID),
+		ParentTenantID: toPgUUID(parentID),
+	})
+}
+
+// DeleteTenantParent makes the tenant a top-level org unit again.
+func (s *Service) DeleteTenantParent(ctx context.Context, tenantID uuid.UUID) error {
+	if s == nil || s.queries == nil {
+		return ErrServiceUnavailable
+	}
+	return s.queries.DeleteTenantParent(ctx, toPgUUID(tenantID))
+}
+
+func parseSystemPromptMode(value string) (db.SystemPromptMode, error) {
+	switch mode := db.SystemPromptMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return db.SystemPromptModePrepend, nil
+	case db.SystemPromptModePrepend, db.SystemPromptModeAppend, db.SystemPromptModeReplace:
+		return mode, nil
+	default:
+		return "", ErrInvalidPromptMode
+	}
+}
+
 func (s *Service) normalizeModelAliases(ctx context.Context, aliases []string) ([]string, error) {
 	if len(aliases) == 0 {
 		return nil, ErrInvalidModelList
@@ -576,6 +1081,16 @@ func (s *Service) normalizeModelAliases(ctx context.Context, aliases []string) (
 		if _, exists := unique[norm]; exists {
 			continue
 		}
+		// Patterns such as "gpt-4*" may match models added later, so they
+		// are only checked for syntax.
+		if wildcard.IsPattern(trimmed) {
+			if err := wildcard.Validate(trimmed); err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidModelPattern, trimmed)
+			}
+			unique[norm] = trimmed
+			finalList = append(finalList, trimmed)
+			continue
+		}
 		if _, err := s.queries.GetModelByAlias(ctx, trimmed); err != nil {
 			if errors.Is(err, pgx.ErrNoRows) {
 				return nil, fmt.Errorf("%w: %s", ErrModelNotFound, trimmed)
@@ -617,14 +1132,14 @@ func (s *Service) ensureUser(ctx context.Context, email string) (db.User, error)
 	return user, nil
 }
 
-func (s *Service) upsertMembershipRecord(ctx context.Context, tenantID pgtype.UUID, userID pgtype.UUID, role db.MembershipRole) (db.TenantMembership, error) {
-	existing, err := s.queries.GetTenantMembership(ctx, db.GetTenantMembershipParams{
+func upsertMembershipRecord(ctx context.Context, q *db.Queries, tenantID pgtype.UUID, userID pgtype.UUID, role db.MembershipRole) (db.TenantMembership, error) {
+	existing, err := q.GetTenantMembership(ctx, db.GetTenantMembershipParams{
 		TenantID: tenantID,
 		UserID:   userID,
 	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return s.queries.AddTenantMembership(ctx, db.AddTenantMembershipParams{
+			return q.AddTenantMembership(ctx, db.AddTenantMembershipParams{
 				TenantID: tenantID,
 				UserID:   userID,
 				Role:     role,
@@ -635,7 +1150,7 @@ func (s *Service) upsertMembershipRecord(ctx context.Context, tenantID pgtype.UU
 	if existing.Role == role {
 		return existing, nil
 	}
-	return s.queries.UpdateTenantMembershipRole(ctx, db.UpdateTenantMembershipRoleParams{
+	return q.UpdateTenantMembershipRole(ctx, db.UpdateTenantMembershipRoleParams{
 		TenantID: tenantID,
 		UserID:   userID,
 		Role:     role,
@@ -0,0 +1,56 @@
+package admintenant
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+)
+
+func TestPartitionBulkResult(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	succeeded, failed := partitionBulkResult([]uuid.UUID{a, b, c}, []pgtype.UUID{toPgUUID(c), toPgUUID(a)})
+	if len(succeeded) != 2 || succeeded[0] != a || succeeded[1] != c {
+		t.Fatalf("expected a and c in request order, got %v", succeeded)
+	}
+	if len(failed) != 1 || failed[0] != b {
+		t.Fatalf("expected b to fail, got %v", failed)
+	}
+}
+
+func TestValidateStreamIdleTimeoutCappedByMaxDuration(t *testing.T) {
+	svc := &Service{cfg: &config.Config{Server: config.ServerConfig{StreamMaxDuration: 300 * time.Second}}}
+	for _, seconds := range []int32{0, 15, 300} {
+		if err := svc.validateStreamIdleTimeout(seconds); err != nil {
+			t.Fatalf("expected %ds to be accepted, got %v", seconds, err)
+		}
+	}
+	for _, seconds := range []int32{-1, 301} {
+		if err := svc.validateStreamIdleTimeout(seconds); !errors.Is(err, ErrInvalidStreamIdle) {
+			t.Fatalf("expected %ds to be rejected, got %v", seconds, err)
+		}
+	}
+}
+
+func TestNormalizeModelAliasesValidatesPatterns(t *testing.T) {
+	svc := &Service{}
+	got, err := svc.normalizeModelAliases(context.Background(), []string{"gpt-4*", " claude-3-[hs]* ", "GPT-4*", "gpt-4[^o]*"})
+	if err != nil {
+		t.Fatalf("normalize: %v", err)
+	}
+	if want := []string{"claude-3-[hs]*", "gpt-4*", "gpt-4[^o]*"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	for _, pattern := range []string{"gpt-4[", "claude-[a-"} {
+		if _, err := svc.normalizeModelAliases(context.Background(), []string{"gpt-4*", pattern}); !errors.Is(err, ErrInvalidModelPattern) {
+			t.Fatalf("%q: expected ErrInvalidModelPattern, got %v", pattern, err)
+		}
+	}
+}
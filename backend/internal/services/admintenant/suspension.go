@@ -0,0 +1,130 @@
+package admintenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+// suspensionSendTimeout bounds the background delivery of one suspension
+// email.
+const suspensionSendTimeout = 30 * time.Second
+
+// fromMailer is a Mailer that can send as an address other than smtp.from.
+// usagepipeline.SMTPSink satisfies it.
+type fromMailer interface {
+	SendMailFrom(ctx context.Context, from string, to []string, subject, body string) error
+}
+
+// suspensionQueries lists the statements a suspension notice reads.
+type suspensionQueries interface {
+	GetTenantByID(ctx context.Context, id pgtype.UUID) (db.Tenant, error)
+	ListTenantMembers(ctx context.Context, tenantID pgtype.UUID) ([]db.ListTenantMembersRow, error)
+}
+
+// suspensionEmail is one rendered suspension notice.
+type suspensionEmail struct {
+	From    string
+	To      []string
+	Subject string
+	Body    string
+}
+
+// NotifySuspension emails every owner of tenantID that the tenant was
+// suspended. Owners are looked up before returning; the email itself is sent
+// in the background and failures are only logged. It is a no-op unless
+// admin.suspension_notification is enabled and SMTP is configured.
+func (s *Service) NotifySuspension(ctx context.Context, tenantID uuid.UUID, reason string) error {
+	if s == nil || s.queries == nil || s.cfg == nil {
+		return ErrServiceUnavailable
+	}
+	settings := s.cfg.Admin.SuspensionNotification
+	if !settings.Enabled || s.mailer == nil {
+		return nil
+	}
+	email, err := buildSuspensionEmail(ctx, s.queries, settings, s.cfg.Budgets.Alert.SMTP.From, tenantID, reason, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if len(email.To) == 0 {
+		return nil
+	}
+	go func() {
+		sendCtx, cancel := context.WithTimeout(context.Background(), suspensionSendTimeout)
+		defer cancel()
+		if err := sendSuspensionEmail(sendCtx, s.mailer, email); err != nil {
+			slog.Warn("suspension email failed", slog.String("tenant_id", tenantID.String()), slog.String("error", err.Error()))
+		}
+	}()
+	return nil
+}
+
+// notifySuspension runs NotifySuspension for a status change that has
+// already been committed, so a lookup failure is logged rather than returned.
+func (s *Service) notifySuspension(ctx context.Context, tenantID uuid.UUID, reason string) {
+	if err := s.NotifySuspension(ctx, tenantID, reason); err != nil {
+		slog.Warn("suspension notification failed", slog.String("tenant_id", tenantID.String()), slog.String("error", err.Error()))
+	}
+}
+
+// buildSuspensionEmail renders the notice for tenantID's owners. defaultFrom
+// is used as the sender and appeal contact when settings leave it unset.
+func buildSuspensionEmail(ctx context.Context, queries suspensionQueries, settings config.SuspensionNotificationConfig, defaultFrom string, tenantID uuid.UUID, reason string, suspendedAt time.Time) (suspensionEmail, error) {
+	tenant, err := queries.GetTenantByID(ctx, toPgUUID(tenantID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return suspensionEmail{}, ErrTenantNotFound
+		}
+		return suspensionEmail{}, err
+	}
+	members, err := queries.ListTenantMembers(ctx, toPgUUID(tenantID))
+	if err != nil {
+		return suspensionEmail{}, err
+	}
+	var owners []string
+	for _, member := range members {
+		if member.Role != db.MembershipRoleOwner || strings.TrimSpace(member.UserEmail) == "" {
+			continue
+		}
+		owners = append(owners, member.UserEmail)
+	}
+
+	from := strings.TrimSpace(settings.FromAddress)
+	if from == "" {
+		from = defaultFrom
+	}
+	subject := strings.ReplaceAll(settings.TemplateSubject, "{tenant}", tenant.Name)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "The tenant %q has been suspended. Its API keys are rejected until it is reactivated.\n\n", tenant.Name)
+	fmt.Fprintf(&b, "Tenant: %s\n", tenant.Name)
+	fmt.Fprintf(&b, "Tenant ID: %s\n", tenantID)
+	fmt.Fprintf(&b, "Suspended At: %s\n", suspendedAt.UTC().Format(time.RFC3339))
+	if reason = strings.TrimSpace(reason); reason != "" {
+		fmt.Fprintf(&b, "Reason: %s\n", reason)
+	}
+	if from != "" {
+		fmt.Fprintf(&b, "\nTo appeal, contact %s.\n", from)
+	}
+
+	return suspensionEmail{From: from, To: owners, Subject: subject, Body: b.String()}, nil
+}
+
+// sendSuspensionEmail delivers email, using its sender when mailer supports
+// one.
+func sendSuspensionEmail(ctx context.Context, mailer Mailer, email suspensionEmail) error {
+	if sender, ok := mailer.(fromMailer); ok {
+		return sender.SendMailFrom(ctx, email.From, email.To, email.Subject, email.Body)
+	}
+	return mailer.SendMail(ctx, email.To, email.Subject, email.Body)
+}
@@ -0,0 +1,106 @@
+package admintenant
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+type stubSuspensionQueries struct {
+	members []db.ListTenantMembersRow
+}
+
+func (q *stubSuspensionQueries) GetTenantByID(_ context.Context, id pgtype.UUID) (db.Tenant, error) {
+	return db.Tenant{ID: id, Name: "acme"}, nil
+}
+
+func (q *stubSuspensionQueries) ListTenantMembers(context.Context, pgtype.UUID) ([]db.ListTenantMembersRow, error) {
+	return q.members, nil
+}
+
+type sentMail struct {
+	from    string
+	to      []string
+	subject string
+	body    string
+}
+
+// recordingMailer stands in for usagepipeline.SMTPSink.
+type recordingMailer struct {
+	sent []sentMail
+}
+
+func (m *recordingMailer) SendMail(_ context.Context, to []string, subject, body string) error {
+	m.sent = append(m.sent, sentMail{to: to, subject: subject, body: body})
+	return nil
+}
+
+func (m *recordingMailer) SendMailFrom(_ context.Context, from string, to []string, subject, body string) error {
+	m.sent = append(m.sent, sentMail{from: from, to: to, subject: subject, body: body})
+	return nil
+}
+
+func TestSuspensionEmailGoesToOwners(t *testing.T) {
+	queries := &stubSuspensionQueries{members: []db.ListTenantMembersRow{
+		{Role: db.MembershipRoleOwner, UserEmail: "owner@example.com"},
+		{Role: db.MembershipRoleAdmin, UserEmail: "admin@example.com"},
+		{Role: db.MembershipRoleOwner, UserEmail: "second-owner@example.com"},
+		{Role: db.MembershipRoleViewer, UserEmail: "viewer@example.com"},
+	}}
+	settings := config.SuspensionNotificationConfig{
+		Enabled:         true,
+		FromAddress:     "trust@example.com",
+		TemplateSubject: "Tenant {tenant} has been suspended",
+	}
+	suspendedAt := time.Date(2025, 11, 17, 9, 30, 0, 0, time.UTC)
+
+	email, err := buildSuspensionEmail(context.Background(), queries, settings, "alerts@example.com", uuid.New(), "unpaid invoice", suspendedAt)
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	mailer := &recordingMailer{}
+	if err := sendSuspensionEmail(context.Background(), mailer, email); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	if len(mailer.sent) != 1 {
+		t.Fatalf("expected one email, got %d", len(mailer.sent))
+	}
+	sent := mailer.sent[0]
+	if want := []string{"owner@example.com", "second-owner@example.com"}; !reflect.DeepEqual(sent.to, want) {
+		t.Fatalf("expected recipients %v, got %v", want, sent.to)
+	}
+	if sent.from != "trust@example.com" {
+		t.Fatalf("expected configured sender, got %q", sent.from)
+	}
+	if sent.subject != "Tenant acme has been suspended" {
+		t.Fatalf("unexpected subject %q", sent.subject)
+	}
+	for _, want := range []string{"acme", "2025-11-17T09:30:00Z", "unpaid invoice", "To appeal, contact trust@example.com"} {
+		if !strings.Contains(sent.body, want) {
+			t.Fatalf("body missing %q:\n%s", want, sent.body)
+		}
+	}
+}
+
+func TestSuspensionEmailFallsBackToSMTPFrom(t *testing.T) {
+	queries := &stubSuspensionQueries{}
+	email, err := buildSuspensionEmail(context.Background(), queries, config.SuspensionNotificationConfig{Enabled: true}, "alerts@example.com", uuid.New(), "", time.Now())
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	if email.From != "alerts@example.com" || !strings.Contains(email.Body, "contact alerts@example.com") {
+		t.Fatalf("expected smtp.from as sender and contact, got %+v", email)
+	}
+	if len(email.To) != 0 {
+		t.Fatalf("expected no recipients without owners, got %v", email.To)
+	}
+}
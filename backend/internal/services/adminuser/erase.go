@@ -0,0 +1,163 @@
+package adminuser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+const (
+	erasureStatusCompleted = "completed"
+	erasureStatusFailed    = "failed"
+)
+
+// ErrUserNotFound indicates the requested user does not exist.
+var ErrUserNotFound = errors.New("user not found")
+
+// erasureQueries lists the statements EraseUser runs inside its transaction.
+type erasureQueries interface {
+	GetUserByID(ctx context.Context, id pgtype.UUID) (db.User, error)
+	AnonymizeUser(ctx context.Context, arg db.AnonymizeUserParams) (db.User, error)
+	DeleteBatchesForUser(ctx context.Context, arg db.DeleteBatchesForUserParams) error
+	DeleteFilesByTenant(ctx context.Context, tenantID pgtype.UUID) ([]string, error)
+	DeleteAPIKeysByOwner(ctx context.Context, ownerUserID pgtype.UUID) (int64, error)
+	DeleteUserMemberships(ctx context.Context, userID pgtype.UUID) (int64, error)
+	DeleteCredentialsForUser(ctx context.Context, userID pgtype.UUID) error
+	DeleteAdminTokensByUser(ctx context.Context, userID pgtype.UUID) error
+	DeleteAdminTenantScopesByUser(ctx context.Context, adminUserID pgtype.UUID) error
+	DeleteTenant(ctx context.Context, id pgtype.UUID) error
+	UpdateGDPRErasureRequestStatus(ctx context.Context, arg db.UpdateGDPRErasureRequestStatusParams) error
+}
+
+// EraseUser removes personally identifiable data for a user. The user row is
+// kept (with email and name replaced by random UUIDs) so organization usage
+// totals stay intact, while API keys, memberships, credentials, and the
+// personal tenant with its usage history are deleted in a single transaction.
+// Each call is tracked in gdpr_erasure_requests.
+func (s *Service) EraseUser(ctx context.Context, userID uuid.UUID) error {
+	if s == nil || s.pool == nil || s.queries == nil {
+		return ErrServiceUnavailable
+	}
+	pgID := pgtype.UUID{Bytes: userID, Valid: true}
+	if _, err := s.queries.GetUserByID(ctx, pgID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	request, err := s.queries.CreateGDPRErasureRequest(ctx, pgID)
+	if err != nil {
+		return fmt.Errorf("record erasure request: %w", err)
+	}
+
+	storageKeys, err := s.eraseInTx(ctx, pgID, request.ID)
+	if err != nil {
+		if markErr := s.queries.UpdateGDPRErasureRequestStatus(ctx, db.UpdateGDPRErasureRequestStatusParams{
+			ID:     request.ID,
+			Status: erasureStatusFailed,
+			Error:  pgtype.Text{String: err.Error(), Valid: true},
+		}); markErr != nil {
+			slog.Error("mark erasure request failed", slog.String("user_id", userID.String()), slog.String("error", markErr.Error()))
+		}
+		return err
+	}
+
+	if s.blobs != nil {
+		for _, key := range storageKeys {
+			if err := s.blobs.Delete(ctx, key); err != nil {
+				slog.Warn("delete erased user file", slog.String("key", key), slog.String("error", err.Error()))
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Service) eraseInTx(ctx context.Context, userID pgtype.UUID, requestID pgtype.UUID) ([]string, error) {
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	storageKeys, err := eraseUser(ctx, s.queries.WithTx(tx), userID, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return storageKeys, nil
+}
+
+// eraseUser runs the erasure statements and returns the blob storage keys of
+// deleted personal files so the caller can remove them once committed.
+func eraseUser(ctx context.Context, q erasureQueries, userID pgtype.UUID, requestID pgtype.UUID) ([]string, error) {
+	user, err := q.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	personalTenant := user.PersonalTenantID
+
+	// Batches and files reference tenants and API keys without cascading, so
+	// they have to go before the keys and personal tenant.
+	if err := q.DeleteBatchesForUser(ctx, db.DeleteBatchesForUserParams{
+		TenantID:    personalTenant,
+		OwnerUserID: userID,
+	}); err != nil {
+		return nil, fmt.Errorf("delete batches: %w", err)
+	}
+	var storageKeys []string
+	if personalTenant.Valid {
+		storageKeys, err = q.DeleteFilesByTenant(ctx, personalTenant)
+		if err != nil {
+			return nil, fmt.Errorf("delete files: %w", err)
+		}
+	}
+
+	if _, err := q.DeleteAPIKeysByOwner(ctx, userID); err != nil {
+		return nil, fmt.Errorf("delete api keys: %w", err)
+	}
+	if _, err := q.DeleteUserMemberships(ctx, userID); err != nil {
+		return nil, fmt.Errorf("delete memberships: %w", err)
+	}
+	if err := q.DeleteCredentialsForUser(ctx, userID); err != nil {
+		return nil, fmt.Errorf("delete credentials: %w", err)
+	}
+	if err := q.DeleteAdminTokensByUser(ctx, userID); err != nil {
+		return nil, fmt.Errorf("delete admin tokens: %w", err)
+	}
+	if err := q.DeleteAdminTenantScopesByUser(ctx, userID); err != nil {
+		return nil, fmt.Errorf("delete admin tenant scopes: %w", err)
+	}
+	if _, err := q.AnonymizeUser(ctx, db.AnonymizeUserParams{
+		ID:    userID,
+		Email: uuid.NewString(),
+		Name:  uuid.NewString(),
+	}); err != nil {
+		return nil, fmt.Errorf("anonymize user: %w", err)
+	}
+	if personalTenant.Valid {
+		if err := q.DeleteTenant(ctx, personalTenant); err != nil {
+			return nil, fmt.Errorf("delete personal tenant: %w", err)
+		}
+	}
+
+	if err := q.UpdateGDPRErasureRequestStatus(ctx, db.UpdateGDPRErasureRequestStatusParams{
+		ID:     requestID,
+		Status: erasureStatusCompleted,
+	}); err != nil {
+		return nil, fmt.Errorf("complete erasure request: %w", err)
+	}
+	return storageKeys, nil
+}
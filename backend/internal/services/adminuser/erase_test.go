@@ -0,0 +1,186 @@
+package adminuser
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+func TestEraseUserRemovesIdentifiableData(t *testing.T) {
+	userID := pgUUID(uuid.New())
+	personalTenant := pgUUID(uuid.New())
+	orgTenant := pgUUID(uuid.New())
+	requestID := pgUUID(uuid.New())
+
+	store := newErasureStore()
+	store.users[userID] = db.User{
+		ID:               userID,
+		Email:            "jane@example.com",
+		Name:             "Jane Doe",
+		IsSuperAdmin:     true,
+		PersonalTenantID: personalTenant,
+	}
+	store.tenants[personalTenant] = true
+	store.tenants[orgTenant] = true
+	store.apiKeys[pgUUID(uuid.New())] = userID
+	store.apiKeys[pgUUID(uuid.New())] = pgtype.UUID{}
+	store.memberships[orgTenant] = userID
+	store.files[personalTenant] = []string{"personal/file-1"}
+	store.credentials[userID] = true
+
+	keys, err := eraseUser(context.Background(), store, userID, requestID)
+	if err != nil {
+		t.Fatalf("erase user: %v", err)
+	}
+
+	user := store.users[userID]
+	for _, value := range []string{user.Email, user.Name} {
+		if strings.Contains(value, "jane") || strings.Contains(value, "Jane") {
+			t.Fatalf("expected identifiable data to be removed, got %q", value)
+		}
+		if _, err := uuid.Parse(value); err != nil {
+			t.Fatalf("expected anonymized value to be a uuid, got %q", value)
+		}
+	}
+	if user.IsSuperAdmin || user.PersonalTenantID.Valid {
+		t.Fatalf("expected privileges and personal tenant to be cleared, got %+v", user)
+	}
+	for _, owner := range store.apiKeys {
+		if owner == userID {
+			t.Fatal("expected user api keys to be deleted")
+		}
+	}
+	if len(store.apiKeys) != 1 {
+		t.Fatalf("expected service key to remain, got %d keys", len(store.apiKeys))
+	}
+	if len(store.memberships) != 0 {
+		t.Fatalf("expected memberships to be removed, got %d", len(store.memberships))
+	}
+	if store.tenants[personalTenant] {
+		t.Fatal("expected personal tenant to be deleted")
+	}
+	if !store.tenants[orgTenant] {
+		t.Fatal("expected organization tenant to remain")
+	}
+	if store.credentials[userID] {
+		t.Fatal("expected credentials to be deleted")
+	}
+	if len(keys) != 1 || keys[0] != "personal/file-1" {
+		t.Fatalf("expected personal file keys to be returned, got %v", keys)
+	}
+	if store.requestStatus[requestID] != erasureStatusCompleted {
+		t.Fatalf("expected erasure request to be completed, got %q", store.requestStatus[requestID])
+	}
+}
+
+func TestEraseUserUnknownUser(t *testing.T) {
+	store := newErasureStore()
+	if _, err := eraseUser(context.Background(), store, pgUUID(uuid.New()), pgUUID(uuid.New())); err != ErrUserNotFound {
+		t.Fatalf("expected ErrUserNotFound, got %v", err)
+	}
+}
+
+func pgUUID(id uuid.UUID) pgtype.UUID {
+	return pgtype.UUID{Bytes: id, Valid: true}
+}
+
+type erasureStore struct {
+	users         map[pgtype.UUID]db.User
+	tenants       map[pgtype.UUID]bool
+	apiKeys       map[pgtype.UUID]pgtype.UUID
+	memberships   map[pgtype.UUID]pgtype.UUID
+	files         map[pgtype.UUID][]string
+	credentials   map[pgtype.UUID]bool
+	requestStatus map[pgtype.UUID]string
+}
+
+func newErasureStore() *erasureStore {
+	return &erasureStore{
+		users:         make(map[pgtype.UUID]db.User),
+		tenants:       make(map[pgtype.UUID]bool),
+		apiKeys:       make(map[pgtype.UUID]pgtype.UUID),
+		memberships:   make(map[pgtype.UUID]pgtype.UUID),
+		files:         make(map[pgtype.UUID][]string),
+		credentials:   make(map[pgtype.UUID]bool),
+		requestStatus: make(map[pgtype.UUID]string),
+	}
+}
+
+func (s *erasureStore) GetUserByID(_ context.Context, id pgtype.UUID) (db.User, error) {
+	user, ok := s.users[id]
+	if !ok {
+		return db.User{}, pgx.ErrNoRows
+	}
+	return user, nil
+}
+
+func (s *erasureStore) AnonymizeUser(_ context.Context, arg db.AnonymizeUserParams) (db.User, error) {
+	user := s.users[arg.ID]
+	user.Email = arg.Email
+	user.Name = arg.Name
+	user.IsSuperAdmin = false
+	user.PersonalTenantID = pgtype.UUID{}
+	s.users[arg.ID] = user
+	return user, nil
+}
+
+func (s *erasureStore) DeleteBatchesForUser(context.Context, db.DeleteBatchesForUserParams) error {
+	return nil
+}
+
+func (s *erasureStore) DeleteFilesByTenant(_ context.Context, tenantID pgtype.UUID) ([]string, error) {
+	keys := s.files[tenantID]
+	delete(s.files, tenantID)
+	return keys, nil
+}
+
+func (s *erasureStore) DeleteAPIKeysByOwner(_ context.Context, ownerUserID pgtype.UUID) (int64, error) {
+	var removed int64
+	for id, owner := range s.apiKeys {
+		if owner == ownerUserID {
+			delete(s.apiKeys, id)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+func (s *erasureStore) DeleteUserMemberships(_ context.Context, userID pgtype.UUID) (int64, error) {
+	var removed int64
+	for tenantID, member := range s.memberships {
+		if member == userID {
+			delete(s.memberships, tenantID)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+func (s *erasureStore) DeleteCredentialsForUser(_ context.Context, userID pgtype.UUID) error {
+	delete(s.credentials, userID)
+	return nil
+}
+
+func (s *erasureStore) DeleteAdminTokensByUser(context.Context, pgtype.UUID) error {
+	return nil
+}
+
+func (s *erasureStore) DeleteAdminTenantScopesByUser(context.Context, pgtype.UUID) error {
+	return nil
+}
+
+func (s *erasureStore) DeleteTenant(_ context.Context, id pgtype.UUID) error {
+	delete(s.tenants, id)
+	return nil
+}
+
+func (s *erasureStore) UpdateGDPRErasureRequestStatus(_ context.Context, arg db.UpdateGDPRErasureRequestStatusParams) error {
+	s.requestStatus[arg.ID] = arg.Status
+	return nil
+}
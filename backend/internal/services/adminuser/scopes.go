@@ -0,0 +1,71 @@
+package adminuser
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+var (
+	// ErrTenantNotFound indicates the tenant for a scope grant does not exist.
+	ErrTenantNotFound = errors.New("tenant not found")
+	// ErrScopeNotFound indicates the admin has no scope for the tenant.
+	ErrScopeNotFound = errors.New("tenant scope not found")
+)
+
+// TenantScopes lists the tenants an admin user is scoped to.
+func (s *Service) TenantScopes(ctx context.Context, userID uuid.UUID) ([]db.AdminTenantScope, error) {
+	if s == nil || s.queries == nil {
+		return nil, ErrServiceUnavailable
+	}
+	return s.queries.ListAdminTenantScopes(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+}
+
+// AddTenantScope grants the user admin-level access to a single tenant
+// without adding a membership. Granting an existing scope is a no-op.
+func (s *Service) AddTenantScope(ctx context.Context, userID, tenantID uuid.UUID) error {
+	if s == nil || s.queries == nil {
+		return ErrServiceUnavailable
+	}
+	pgUser := pgtype.UUID{Bytes: userID, Valid: true}
+	pgTenant := pgtype.UUID{Bytes: tenantID, Valid: true}
+	if _, err := s.queries.GetUserByID(ctx, pgUser); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	if _, err := s.queries.GetTenantByID(ctx, pgTenant); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrTenantNotFound
+		}
+		return err
+	}
+	return s.queries.AddAdminTenantScope(ctx, db.AddAdminTenantScopeParams{
+		AdminUserID: pgUser,
+		TenantID:    pgTenant,
+	})
+}
+
+// RemoveTenantScope revokes a tenant scope from the user.
+func (s *Service) RemoveTenantScope(ctx context.Context, userID, tenantID uuid.UUID) error {
+	if s == nil || s.queries == nil {
+		return ErrServiceUnavailable
+	}
+	rows, err := s.queries.DeleteAdminTenantScope(ctx, db.DeleteAdminTenantScopeParams{
+		AdminUserID: pgtype.UUID{Bytes: userID, Valid: true},
+		TenantID:    pgtype.UUID{Bytes: tenantID, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrScopeNotFound
+	}
+	return nil
+}
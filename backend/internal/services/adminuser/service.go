@@ -9,10 +9,12 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/ncecere/open_model_gateway/backend/internal/accounts"
 	"github.com/ncecere/open_model_gateway/backend/internal/auth"
 	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/storage/blob"
 )
 
 var (
@@ -22,14 +24,22 @@ var (
 
 // Service manages admin-facing user operations.
 type Service struct {
+	pool      *pgxpool.Pool
 	queries   *db.Queries
 	accounts  *accounts.PersonalService
 	adminAuth *auth.AdminAuthService
+	blobs     blob.Store
 }
 
 // NewService wires dependencies for the admin user service.
-func NewService(queries *db.Queries, accounts *accounts.PersonalService, adminAuth *auth.AdminAuthService) *Service {
-	return &Service{queries: queries, accounts: accounts, adminAuth: adminAuth}
+func NewService(pool *pgxpool.Pool, queries *db.Queries, accounts *accounts.PersonalService, adminAuth *auth.AdminAuthService) *Service {
+	return &Service{pool: pool, queries: queries, accounts: accounts, adminAuth: adminAuth}
+}
+
+// SetBlobStore registers the file store used to remove stored objects when a
+// user's personal tenant is erased.
+func (s *Service) SetBlobStore(store blob.Store) {
+	s.blobs = store
 }
 
 // User represents an admin-facing user record.
@@ -0,0 +1,179 @@
+package audit
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+const (
+	// ExportFormatCSV writes one CSV row per audit entry with changes_json
+	// holding the metadata document.
+	ExportFormatCSV = "csv"
+	// ExportFormatJSONL writes one JSON object per line.
+	ExportFormatJSONL = "jsonl"
+
+	// MaxExportWindow bounds a single export request.
+	MaxExportWindow = 365 * 24 * time.Hour
+
+	defaultExportWindow = 30 * 24 * time.Hour
+	exportPageSize      = 500
+)
+
+var (
+	ErrExportFormat = errors.New("format must be csv or jsonl")
+	ErrExportWindow = errors.New("export window must be between 0 and 365 days")
+)
+
+var exportCSVHeader = []string{"id", "created_at", "actor_id", "actor_email", "action", "entity_type", "entity_id", "changes_json"}
+
+// ExportRequest selects the audit entries to export.
+type ExportRequest struct {
+	Format       string
+	Start        time.Time
+	End          time.Time
+	UserID       uuid.UUID
+	Action       string
+	ResourceType string
+}
+
+// Normalize applies defaults (csv, the 30 days before now) and validates the
+// format and window. Call it before committing to a streamed response so
+// invalid requests can still be rejected with an error status.
+func (r *ExportRequest) Normalize(now time.Time) error {
+	r.Format = strings.ToLower(strings.TrimSpace(r.Format))
+	if r.Format == "" {
+		r.Format = ExportFormatCSV
+	}
+	if r.Format != ExportFormatCSV && r.Format != ExportFormatJSONL {
+		return ErrExportFormat
+	}
+	if r.End.IsZero() {
+		r.End = now
+	}
+	if r.Start.IsZero() {
+		r.Start = r.End.Add(-defaultExportWindow)
+	}
+	if !r.End.After(r.Start) || r.End.Sub(r.Start) > MaxExportWindow {
+		return ErrExportWindow
+	}
+	return nil
+}
+
+type exportQueries interface {
+	ListAuditLogsForExport(ctx context.Context, arg db.ListAuditLogsForExportParams) ([]db.ListAuditLogsForExportRow, error)
+}
+
+type exportRecord struct {
+	ID         string          `json:"id"`
+	CreatedAt  time.Time       `json:"created_at"`
+	ActorID    string          `json:"actor_id,omitempty"`
+	ActorEmail string          `json:"actor_email,omitempty"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   string          `json:"entity_id"`
+	Changes    json.RawMessage `json:"changes"`
+}
+
+// Export streams matching audit entries to w, oldest first. Rows are read in
+// keyset-paginated pages and written as they arrive, so memory use does not
+// grow with the size of the export.
+func (s *Service) Export(ctx context.Context, req ExportRequest, w io.Writer) error {
+	if s == nil || s.queries == nil {
+		return ErrServiceUnavailable
+	}
+	return exportLogs(ctx, s.queries, req, w)
+}
+
+func exportLogs(ctx context.Context, queries exportQueries, req ExportRequest, w io.Writer) error {
+	params := db.ListAuditLogsForExportParams{
+		StartTime:      pgtype.Timestamptz{Time: req.Start, Valid: true},
+		EndTime:        pgtype.Timestamptz{Time: req.End, Valid: true},
+		UserIDFilter:   toNullableUUID(req.UserID),
+		ActionFilter:   toNullableText(req.Action),
+		ResourceFilter: toNullableText(req.ResourceType),
+		PageLimit:      exportPageSize,
+	}
+
+	var (
+		csvWriter *csv.Writer
+		encoder   *json.Encoder
+		record    []string
+	)
+	if req.Format == ExportFormatJSONL {
+		encoder = json.NewEncoder(w)
+	} else {
+		csvWriter = csv.NewWriter(w)
+		if err := csvWriter.Write(exportCSVHeader); err != nil {
+			return err
+		}
+		record = make([]string, len(exportCSVHeader))
+	}
+
+	for {
+		rows, err := queries.ListAuditLogsForExport(ctx, params)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			entry := toExportRecord(row)
+			if encoder != nil {
+				if err := encoder.Encode(entry); err != nil {
+					return err
+				}
+				continue
+			}
+			record[0] = entry.ID
+			record[1] = entry.CreatedAt.Format(time.RFC3339Nano)
+			record[2] = entry.ActorID
+			record[3] = entry.ActorEmail
+			record[4] = entry.Action
+			record[5] = entry.EntityType
+			record[6] = entry.EntityID
+			record[7] = string(entry.Changes)
+			if err := csvWriter.Write(record); err != nil {
+				return err
+			}
+		}
+		if csvWriter != nil {
+			csvWriter.Flush()
+			if err := csvWriter.Error(); err != nil {
+				return err
+			}
+		}
+		if len(rows) < exportPageSize {
+			return nil
+		}
+		last := rows[len(rows)-1]
+		params.AfterCreatedAt = last.CreatedAt
+		params.AfterID = last.ID
+	}
+}
+
+func toExportRecord(row db.ListAuditLogsForExportRow) exportRecord {
+	entry := exportRecord{
+		ID:         uuid.UUID(row.ID.Bytes).String(),
+		CreatedAt:  row.CreatedAt.Time.UTC(),
+		ActorEmail: row.ActorEmail.String,
+		Action:     row.Action,
+		EntityType: row.ResourceType,
+		EntityID:   row.ResourceID,
+		Changes:    json.RawMessage(row.Metadata),
+	}
+	if row.UserID.Valid {
+		entry.ActorID = uuid.UUID(row.UserID.Bytes).String()
+	}
+	if len(entry.Changes) == 0 {
+		entry.Changes = json.RawMessage("{}")
+	}
+	return entry
+}
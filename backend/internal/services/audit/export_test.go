@@ -0,0 +1,165 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+// pagedAuditLogs serves pre-built rows page by page and records how much of
+// the export had been written before each page was requested.
+type pagedAuditLogs struct {
+	rows         []db.ListAuditLogsForExportRow
+	out          *countingWriter
+	maxPage      int32
+	writtenAtEnd int
+}
+
+func (p *pagedAuditLogs) ListAuditLogsForExport(_ context.Context, arg db.ListAuditLogsForExportParams) ([]db.ListAuditLogsForExportRow, error) {
+	if arg.PageLimit > p.maxPage {
+		p.maxPage = arg.PageLimit
+	}
+	start := 0
+	if arg.AfterID.Valid {
+		for i, row := range p.rows {
+			if row.ID == arg.AfterID {
+				start = i + 1
+				break
+			}
+		}
+	}
+	end := min(start+int(arg.PageLimit), len(p.rows))
+	if end == len(p.rows) {
+		p.writtenAtEnd = p.out.n
+	}
+	return p.rows[start:end], nil
+}
+
+type countingWriter struct{ n int }
+
+func (w *countingWriter) Write(b []byte) (int, error) {
+	w.n += len(b)
+	return len(b), nil
+}
+
+func buildAuditRows(n int) []db.ListAuditLogsForExportRow {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	actor := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	rows := make([]db.ListAuditLogsForExportRow, n)
+	for i := range rows {
+		rows[i] = db.ListAuditLogsForExportRow{
+			ID:           pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			UserID:       actor,
+			ActorEmail:   pgtype.Text{String: "admin@example.com", Valid: true},
+			Action:       "tenant.update",
+			ResourceType: "tenant",
+			ResourceID:   "t-1",
+			Metadata:     []byte(`{"name":"acme"}`),
+			CreatedAt:    pgtype.Timestamptz{Time: base.Add(time.Duration(i) * time.Second), Valid: true},
+		}
+	}
+	return rows
+}
+
+func TestExportStreamsWithoutBuffering(t *testing.T) {
+	const total = 10000
+	rows := buildAuditRows(total)
+	req := ExportRequest{Format: ExportFormatCSV}
+	if err := req.Normalize(time.Now()); err != nil {
+		t.Fatalf("normalize: %v", err)
+	}
+
+	for _, format := range []string{ExportFormatCSV, ExportFormatJSONL} {
+		req.Format = format
+		out := &countingWriter{}
+		source := &pagedAuditLogs{rows: rows, out: out}
+		allocs := testing.AllocsPerRun(1, func() {
+			out.n = 0
+			if err := exportLogs(context.Background(), source, req, out); err != nil {
+				t.Fatalf("export %s: %v", format, err)
+			}
+		})
+		if source.maxPage > exportPageSize {
+			t.Fatalf("%s: requested %d rows in one page", format, source.maxPage)
+		}
+		if source.writtenAtEnd == 0 {
+			t.Fatalf("%s: nothing was written before the last page was fetched", format)
+		}
+		if perRow := allocs / total; perRow > 20 {
+			t.Fatalf("%s: %.1f allocations per row suggests rows are being buffered", format, perRow)
+		}
+	}
+}
+
+func TestExportCSVFlattensChanges(t *testing.T) {
+	rows := buildAuditRows(2)
+	rows[1].UserID = pgtype.UUID{}
+	rows[1].ActorEmail = pgtype.Text{}
+	var buf bytes.Buffer
+	req := ExportRequest{Format: ExportFormatCSV, Start: time.Unix(0, 0), End: time.Unix(3600, 0)}
+	if err := exportLogs(context.Background(), &pagedAuditLogs{rows: rows, out: &countingWriter{}}, req, &buf); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("parse csv: %v", err)
+	}
+	if len(records) != 3 || strings.Join(records[0], ",") != strings.Join(exportCSVHeader, ",") {
+		t.Fatalf("unexpected csv layout: %v", records)
+	}
+	if records[1][7] != `{"name":"acme"}` {
+		t.Fatalf("changes_json = %q", records[1][7])
+	}
+	if records[2][2] != "" || records[2][3] != "" {
+		t.Fatalf("expected empty actor columns for system entries, got %q/%q", records[2][2], records[2][3])
+	}
+}
+
+func TestExportJSONLKeepsChangesObject(t *testing.T) {
+	var buf bytes.Buffer
+	req := ExportRequest{Format: ExportFormatJSONL, Start: time.Unix(0, 0), End: time.Unix(3600, 0)}
+	if err := exportLogs(context.Background(), &pagedAuditLogs{rows: buildAuditRows(1), out: &countingWriter{}}, req, &buf); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("decode jsonl: %v", err)
+	}
+	changes, ok := entry["changes"].(map[string]any)
+	if !ok || changes["name"] != "acme" {
+		t.Fatalf("expected changes object, got %#v", entry["changes"])
+	}
+	if entry["entity_type"] != "tenant" || entry["actor_email"] != "admin@example.com" {
+		t.Fatalf("unexpected entry %#v", entry)
+	}
+}
+
+func TestExportRequestNormalize(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	req := ExportRequest{}
+	if err := req.Normalize(now); err != nil {
+		t.Fatalf("normalize defaults: %v", err)
+	}
+	if req.Format != ExportFormatCSV || !req.End.Equal(now) || !req.Start.Equal(now.Add(-defaultExportWindow)) {
+		t.Fatalf("unexpected defaults %+v", req)
+	}
+
+	tooLong := ExportRequest{Start: now.Add(-366 * 24 * time.Hour), End: now}
+	if err := tooLong.Normalize(now); !errors.Is(err, ErrExportWindow) {
+		t.Fatalf("expected ErrExportWindow, got %v", err)
+	}
+	badFormat := ExportRequest{Format: "xml"}
+	if err := badFormat.Normalize(now); !errors.Is(err, ErrExportFormat) {
+		t.Fatalf("expected ErrExportFormat, got %v", err)
+	}
+}
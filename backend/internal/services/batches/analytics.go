@@ -0,0 +1,172 @@
+package batches
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/timeutil"
+)
+
+// mixedModelAlias marks batches whose input lines target different models.
+const mixedModelAlias = "mixed"
+
+var ErrInvalidAnalyticsGroup = errors.New("group_by must be model, status, or tenant")
+
+// Analytics groupings supported by Service.Analytics.
+const (
+	AnalyticsGroupModel  = "model"
+	AnalyticsGroupStatus = "status"
+	AnalyticsGroupTenant = "tenant"
+)
+
+// AnalyticsParams selects the window and grouping for batch analytics.
+type AnalyticsParams struct {
+	Period  string
+	GroupBy string
+}
+
+// BatchAnalytics aggregates batches created in a window.
+type BatchAnalytics struct {
+	Period  string                `json:"period"`
+	Start   time.Time             `json:"start"`
+	End     time.Time             `json:"end"`
+	GroupBy string                `json:"group_by"`
+	Groups  []BatchAnalyticsGroup `json:"groups"`
+}
+
+// BatchAnalyticsGroup holds the totals for one model, status, or tenant.
+// Label is the tenant name when grouping by tenant and the key otherwise.
+type BatchAnalyticsGroup struct {
+	Key                 string  `json:"key"`
+	Label               string  `json:"label"`
+	TotalBatches        int64   `json:"total_batches"`
+	TotalItems          int64   `json:"total_items"`
+	CompletedItems      int64   `json:"completed_items"`
+	FailedItems         int64   `json:"failed_items"`
+	AvgProcessingTimeMs float64 `json:"avg_processing_time_ms"`
+	CostUSD             float64 `json:"cost_usd"`
+}
+
+// Analytics reports item counts, average item processing time, and cost for
+// batches created within params.Period, grouped by params.GroupBy.
+func (s *Service) Analytics(ctx context.Context, params AnalyticsParams) (BatchAnalytics, error) {
+	groupBy := strings.ToLower(strings.TrimSpace(params.GroupBy))
+	if groupBy == "" {
+		groupBy = AnalyticsGroupModel
+	}
+	if groupBy != AnalyticsGroupModel && groupBy != AnalyticsGroupStatus && groupBy != AnalyticsGroupTenant {
+		return BatchAnalytics{}, ErrInvalidAnalyticsGroup
+	}
+	period := strings.TrimSpace(params.Period)
+	if period == "" {
+		period = "30d"
+	}
+	window, err := timeutil.NewWindow(period, time.Now().UTC(), time.UTC)
+	if err != nil {
+		return BatchAnalytics{}, err
+	}
+	start, end := window.Bounds()
+	rows, err := s.queries.ListBatchAnalytics(ctx, db.ListBatchAnalyticsParams{
+		StartTs: toPgTime(start),
+		EndTs:   toPgTime(end),
+	})
+	if err != nil {
+		return BatchAnalytics{}, err
+	}
+	return BatchAnalytics{
+		Period:  window.Period(),
+		Start:   start,
+		End:     end,
+		GroupBy: groupBy,
+		Groups:  aggregateBatchAnalytics(rows, groupBy),
+	}, nil
+}
+
+func aggregateBatchAnalytics(rows []db.ListBatchAnalyticsRow, groupBy string) []BatchAnalyticsGroup {
+	type accumulator struct {
+		group        BatchAnalyticsGroup
+		timedItems   int64
+		processingMs float64
+		costCents    int64
+	}
+	byKey := make(map[string]*accumulator)
+	for _, row := range rows {
+		key, label := analyticsGroupKey(row, groupBy)
+		acc, ok := byKey[key]
+		if !ok {
+			acc = &accumulator{group: BatchAnalyticsGroup{Key: key, Label: label}}
+			byKey[key] = acc
+		}
+		acc.group.TotalBatches++
+		acc.group.TotalItems += row.TotalItems
+		acc.group.CompletedItems += row.CompletedItems
+		acc.group.FailedItems += row.FailedItems
+		acc.timedItems += row.TimedItems
+		acc.processingMs += row.ProcessingMs
+		acc.costCents += row.CostCents
+	}
+
+	out := make([]BatchAnalyticsGroup, 0, len(byKey))
+	for _, acc := range byKey {
+		group := acc.group
+		if acc.timedItems > 0 {
+			group.AvgProcessingTimeMs = acc.processingMs / float64(acc.timedItems)
+		}
+		group.CostUSD = float64(acc.costCents) / 100
+		out = append(out, group)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].TotalBatches != out[j].TotalBatches {
+			return out[i].TotalBatches > out[j].TotalBatches
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
+
+func analyticsGroupKey(row db.ListBatchAnalyticsRow, groupBy string) (string, string) {
+	switch groupBy {
+	case AnalyticsGroupStatus:
+		return row.Status, row.Status
+	case AnalyticsGroupTenant:
+		id, err := fromPgUUID(row.TenantID)
+		if err != nil {
+			return row.TenantName, row.TenantName
+		}
+		return id.String(), row.TenantName
+	}
+	alias := row.ModelAlias
+	if alias == "" {
+		alias = "unknown"
+	}
+	return alias, alias
+}
+
+// batchModelAlias returns the model shared by every entry, or "mixed" when
+// entries target different models.
+func batchModelAlias(entries []batchInput) string {
+	alias := ""
+	for _, entry := range entries {
+		var body struct {
+			Model string `json:"model"`
+		}
+		if err := json.Unmarshal(entry.Body, &body); err != nil {
+			continue
+		}
+		model := strings.TrimSpace(body.Model)
+		if model == "" {
+			continue
+		}
+		if alias == "" {
+			alias = model
+		} else if alias != model {
+			return mixedModelAlias
+		}
+	}
+	return alias
+}
@@ -0,0 +1,72 @@
+package batches
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+func TestAggregateBatchAnalytics(t *testing.T) {
+	acme, globex := uuid.New(), uuid.New()
+	rows := []db.ListBatchAnalyticsRow{
+		{TenantID: toPgUUID(acme), TenantName: "acme", Status: "completed", ModelAlias: "gpt-4o", TotalItems: 10, CompletedItems: 10, TimedItems: 10, ProcessingMs: 5000, CostCents: 120},
+		{TenantID: toPgUUID(acme), TenantName: "acme", Status: "failed", ModelAlias: "gpt-4o", TotalItems: 4, CompletedItems: 1, FailedItems: 3, TimedItems: 4, ProcessingMs: 1000, CostCents: 5},
+		{TenantID: toPgUUID(globex), TenantName: "globex", Status: "completed", ModelAlias: "embed-small", TotalItems: 6, CompletedItems: 6, TimedItems: 6, ProcessingMs: 600, CostCents: 2},
+		{TenantID: toPgUUID(globex), TenantName: "globex", Status: "scheduled", ModelAlias: "gpt-4o", TotalItems: 3},
+	}
+
+	byModel := aggregateBatchAnalytics(rows, AnalyticsGroupModel)
+	if len(byModel) != 2 {
+		t.Fatalf("expected 2 model groups, got %+v", byModel)
+	}
+	gpt := byModel[0]
+	if gpt.Key != "gpt-4o" || gpt.TotalBatches != 3 || gpt.TotalItems != 17 || gpt.CompletedItems != 11 || gpt.FailedItems != 3 {
+		t.Fatalf("unexpected gpt-4o totals %+v", gpt)
+	}
+	if gpt.AvgProcessingTimeMs != 6000.0/14 {
+		t.Fatalf("expected average over timed items only, got %v", gpt.AvgProcessingTimeMs)
+	}
+	if gpt.CostUSD != 1.25 {
+		t.Fatalf("expected cost 1.25, got %v", gpt.CostUSD)
+	}
+
+	byStatus := aggregateBatchAnalytics(rows, AnalyticsGroupStatus)
+	if len(byStatus) != 3 || byStatus[0].Key != "completed" || byStatus[0].TotalBatches != 2 || byStatus[0].TotalItems != 16 {
+		t.Fatalf("unexpected status groups %+v", byStatus)
+	}
+
+	byTenant := aggregateBatchAnalytics(rows, AnalyticsGroupTenant)
+	if len(byTenant) != 2 {
+		t.Fatalf("expected 2 tenant groups, got %+v", byTenant)
+	}
+	for _, group := range byTenant {
+		switch group.Key {
+		case acme.String():
+			if group.Label != "acme" || group.TotalBatches != 2 || group.FailedItems != 3 || group.CostUSD != 1.25 {
+				t.Fatalf("unexpected acme totals %+v", group)
+			}
+		case globex.String():
+			if group.Label != "globex" || group.TotalBatches != 2 || group.TotalItems != 9 || group.AvgProcessingTimeMs != 100 {
+				t.Fatalf("unexpected globex totals %+v", group)
+			}
+		default:
+			t.Fatalf("unexpected tenant group %+v", group)
+		}
+	}
+}
+
+func TestBatchModelAlias(t *testing.T) {
+	line := func(model string) batchInput {
+		body, _ := json.Marshal(map[string]string{"model": model})
+		return batchInput{Body: body}
+	}
+	if got := batchModelAlias([]batchInput{line("gpt-4o"), line("gpt-4o")}); got != "gpt-4o" {
+		t.Fatalf("expected shared alias, got %q", got)
+	}
+	if got := batchModelAlias([]batchInput{line("gpt-4o"), line("embed-small")}); got != mixedModelAlias {
+		t.Fatalf("expected mixed, got %q", got)
+	}
+}
@@ -0,0 +1,98 @@
+package batches
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultLockTTL     = 5 * time.Minute
+	claimLockKeyPrefix = "batch_claim:"
+)
+
+// ErrBatchLockLost reports that a batch's claim lock expired or is now held
+// by another worker, so the caller must stop processing the batch.
+var ErrBatchLockLost = errors.New("batch claim lock lost")
+
+// extendLockScript refreshes the lock TTL only while the caller still holds
+// it.
+var extendLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// releaseLockScript deletes the lock only while the caller still holds it.
+var releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// ClaimLock is a per-batch Redis lock that lets several worker instances
+// share the batch queue. Each instance holds a random token, so it can only
+// extend or release the locks it acquired.
+type ClaimLock struct {
+	client *redis.Client
+	ttl    time.Duration
+	token  string
+}
+
+// NewClaimLock returns a lock that expires after ttl unless extended.
+func NewClaimLock(client *redis.Client, ttl time.Duration) *ClaimLock {
+	if ttl <= 0 {
+		ttl = defaultLockTTL
+	}
+	return &ClaimLock{client: client, ttl: ttl, token: uuid.NewString()}
+}
+
+// TTL returns how long a lock lives without a heartbeat.
+func (l *ClaimLock) TTL() time.Duration {
+	if l == nil {
+		return defaultLockTTL
+	}
+	return l.ttl
+}
+
+// Acquire takes the lock for batchID with SET NX PX and reports whether it
+// was free.
+func (l *ClaimLock) Acquire(ctx context.Context, batchID uuid.UUID) (bool, error) {
+	if l == nil || l.client == nil {
+		return true, nil
+	}
+	return l.client.SetNX(ctx, claimLockKey(batchID), l.token, l.ttl).Result()
+}
+
+// Extend resets the lock TTL, returning ErrBatchLockLost when the lock is no
+// longer held by this instance.
+func (l *ClaimLock) Extend(ctx context.Context, batchID uuid.UUID) error {
+	if l == nil || l.client == nil {
+		return nil
+	}
+	n, err := extendLockScript.Run(ctx, l.client, []string{claimLockKey(batchID)}, l.token, l.ttl.Milliseconds()).Int64()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrBatchLockLost
+	}
+	return nil
+}
+
+// Release drops the lock if this instance still holds it.
+func (l *ClaimLock) Release(ctx context.Context, batchID uuid.UUID) error {
+	if l == nil || l.client == nil {
+		return nil
+	}
+	return releaseLockScript.Run(ctx, l.client, []string{claimLockKey(batchID)}, l.token).Err()
+}
+
+func claimLockKey(batchID uuid.UUID) string {
+	return claimLockKeyPrefix + batchID.String()
+}
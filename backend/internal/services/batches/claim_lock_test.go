@@ -0,0 +1,123 @@
+package batches
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestRedis(t *testing.T) (*redis.Client, *miniredis.Miniredis) {
+	t.Helper()
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() {
+		client.Close()
+		server.Close()
+	})
+	return client, server
+}
+
+func TestClaimLockTwoWorkersClaimEachBatchOnce(t *testing.T) {
+	client, _ := newTestRedis(t)
+	ctx := context.Background()
+
+	batches := make([]uuid.UUID, 50)
+	for i := range batches {
+		batches[i] = uuid.New()
+	}
+
+	var mu sync.Mutex
+	processed := make(map[uuid.UUID]int)
+	perWorker := make([]int, 2)
+	var wg sync.WaitGroup
+	for worker := 0; worker < 2; worker++ {
+		lock := NewClaimLock(client, time.Minute)
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for _, id := range batches {
+				acquired, err := lock.Acquire(ctx, id)
+				if err != nil {
+					t.Errorf("worker %d acquire: %v", worker, err)
+					return
+				}
+				if !acquired {
+					continue
+				}
+				mu.Lock()
+				processed[id]++
+				perWorker[worker]++
+				mu.Unlock()
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	for _, id := range batches {
+		if processed[id] != 1 {
+			t.Fatalf("batch %s processed %d times", id, processed[id])
+		}
+	}
+	if perWorker[0]+perWorker[1] != len(batches) {
+		t.Fatalf("expected %d claims, got %v", len(batches), perWorker)
+	}
+}
+
+func TestClaimLockExpiresWithoutHeartbeat(t *testing.T) {
+	client, server := newTestRedis(t)
+	ctx := context.Background()
+	ttl := 30 * time.Second
+	first := NewClaimLock(client, ttl)
+	second := NewClaimLock(client, ttl)
+	batchID := uuid.New()
+
+	if ok, err := first.Acquire(ctx, batchID); err != nil || !ok {
+		t.Fatalf("first acquire: ok=%v err=%v", ok, err)
+	}
+	if ok, _ := second.Acquire(ctx, batchID); ok {
+		t.Fatal("second worker claimed a locked batch")
+	}
+	if err := second.Extend(ctx, batchID); !errors.Is(err, ErrBatchLockLost) {
+		t.Fatalf("expected ErrBatchLockLost extending another worker's lock, got %v", err)
+	}
+
+	// Heartbeats keep the lock alive past its original TTL.
+	server.FastForward(20 * time.Second)
+	if err := first.Extend(ctx, batchID); err != nil {
+		t.Fatalf("heartbeat: %v", err)
+	}
+	server.FastForward(20 * time.Second)
+	if ok, _ := second.Acquire(ctx, batchID); ok {
+		t.Fatal("lock expired despite heartbeat")
+	}
+
+	// Without heartbeats the lock expires and another worker takes over.
+	server.FastForward(ttl)
+	if ok, err := second.Acquire(ctx, batchID); err != nil || !ok {
+		t.Fatalf("expected reclaim after expiry: ok=%v err=%v", ok, err)
+	}
+	if err := first.Extend(ctx, batchID); !errors.Is(err, ErrBatchLockLost) {
+		t.Fatalf("expected original worker to lose the lock, got %v", err)
+	}
+	if err := first.Release(ctx, batchID); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	if !server.Exists(claimLockKey(batchID)) {
+		t.Fatal("stale worker released the new owner's lock")
+	}
+	if err := second.Release(ctx, batchID); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	if server.Exists(claimLockKey(batchID)) {
+		t.Fatal("owner release left the lock in place")
+	}
+}
@@ -0,0 +1,45 @@
+package batches
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+type tenantHierarchyQueries interface {
+	IsTenantDescendant(ctx context.Context, arg db.IsTenantDescendantParams) (bool, error)
+}
+
+// ValidateItemTenant reports whether a batch owned by ownerTenantID may run an
+// item on behalf of itemTenantID: the owner itself or any tenant below it in
+// the org-unit hierarchy (tenant_parents).
+func (s *Service) ValidateItemTenant(ctx context.Context, ownerTenantID, itemTenantID uuid.UUID) bool {
+	if s == nil || s.queries == nil {
+		return ownerTenantID != uuid.Nil && ownerTenantID == itemTenantID
+	}
+	return validateItemTenant(ctx, s.queries, ownerTenantID, itemTenantID)
+}
+
+func validateItemTenant(ctx context.Context, queries tenantHierarchyQueries, ownerTenantID, itemTenantID uuid.UUID) bool {
+	if ownerTenantID == uuid.Nil || itemTenantID == uuid.Nil {
+		return false
+	}
+	if ownerTenantID == itemTenantID {
+		return true
+	}
+	ok, err := queries.IsTenantDescendant(ctx, db.IsTenantDescendantParams{
+		TenantID:       toPgUUID(itemTenantID),
+		ParentTenantID: toPgUUID(ownerTenantID),
+	})
+	if err != nil {
+		slog.Warn("batch item tenant lookup failed",
+			slog.String("owner_tenant_id", ownerTenantID.String()),
+			slog.String("item_tenant_id", itemTenantID.String()),
+			slog.String("error", err.Error()))
+		return false
+	}
+	return ok
+}
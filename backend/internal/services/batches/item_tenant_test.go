@@ -0,0 +1,64 @@
+package batches
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+// fakeHierarchy maps each tenant to its parent.
+type fakeHierarchy struct {
+	parents map[uuid.UUID]uuid.UUID
+	err     error
+}
+
+func (f *fakeHierarchy) IsTenantDescendant(_ context.Context, arg db.IsTenantDescendantParams) (bool, error) {
+	if f.err != nil {
+		return false, f.err
+	}
+	want := uuid.UUID(arg.ParentTenantID.Bytes)
+	current := uuid.UUID(arg.TenantID.Bytes)
+	for {
+		parent, ok := f.parents[current]
+		if !ok {
+			return false, nil
+		}
+		if parent == want {
+			return true, nil
+		}
+		current = parent
+	}
+}
+
+func TestValidateItemTenantWalksHierarchy(t *testing.T) {
+	org, team, squad, other := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	queries := &fakeHierarchy{parents: map[uuid.UUID]uuid.UUID{team: org, squad: team}}
+	ctx := context.Background()
+
+	cases := []struct {
+		name        string
+		owner, item uuid.UUID
+		want        bool
+	}{
+		{"owner", org, org, true},
+		{"child", org, team, true},
+		{"grandchild", org, squad, true},
+		{"parent of owner", team, org, false},
+		{"unrelated", org, other, false},
+		{"nil item", org, uuid.Nil, false},
+	}
+	for _, tc := range cases {
+		if got := validateItemTenant(ctx, queries, tc.owner, tc.item); got != tc.want {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+
+	queries.err = errors.New("db down")
+	if validateItemTenant(ctx, queries, org, team) {
+		t.Fatal("lookup errors must deny the override")
+	}
+}
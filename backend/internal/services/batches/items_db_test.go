@@ -0,0 +1,103 @@
+package batches
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/database/dbtest"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+func TestListResultItemsStopsAtFirstPendingItem(t *testing.T) {
+	pool := dbtest.Open(t)
+	queries := db.New(pool)
+	ctx := context.Background()
+
+	tenant, err := queries.CreateTenant(ctx, db.CreateTenantParams{
+		Name:   "batch-items-" + uuid.NewString(),
+		Status: db.TenantStatusActive,
+		Kind:   db.TenantKindOrganization,
+	})
+	if err != nil {
+		t.Fatalf("create tenant: %v", err)
+	}
+	t.Cleanup(func() { _ = queries.DeleteTenant(context.Background(), tenant.ID) })
+	key, err := queries.CreateAPIKey(ctx, db.CreateAPIKeyParams{
+		TenantID:   tenant.ID,
+		Prefix:     "bi" + uuid.NewString()[:8],
+		SecretHash: "hash",
+		Name:       "batch client",
+		ScopesJson: []byte("[]"),
+		QuotaJson:  []byte("{}"),
+		Kind:       db.ApiKeyKindService,
+	})
+	if err != nil {
+		t.Fatalf("create key: %v", err)
+	}
+	batch, err := queries.CreateBatch(ctx, db.CreateBatchParams{
+		TenantID:          tenant.ID,
+		ApiKeyID:          key.ID,
+		Status:            "in_progress",
+		Endpoint:          "/v1/chat/completions",
+		MaxConcurrency:    4,
+		Metadata:          []byte("{}"),
+		RequestCountTotal: 4,
+	})
+	if err != nil {
+		t.Fatalf("create batch: %v", err)
+	}
+	t.Cleanup(func() { _, _ = pool.Exec(context.Background(), "DELETE FROM batches WHERE id = $1", batch.ID) })
+
+	items := make([]db.BatchItem, 4)
+	for i := range items {
+		items[i], err = queries.InsertBatchItem(ctx, db.InsertBatchItemParams{
+			BatchID:   batch.ID,
+			ItemIndex: int64(i),
+			Status:    "queued",
+			Input:     []byte("{}"),
+		})
+		if err != nil {
+			t.Fatalf("insert item %d: %v", i, err)
+		}
+	}
+
+	svc := NewService(pool, queries, nil, nil, &config.BatchesConfig{})
+	tenantID := uuid.UUID(tenant.ID.Bytes)
+	batchID := uuid.UUID(batch.ID.Bytes)
+	indexes := func(after int64) []int64 {
+		t.Helper()
+		got, err := svc.ListResultItems(ctx, tenantID, batchID, after, 10)
+		if err != nil {
+			t.Fatalf("list finished items: %v", err)
+		}
+		out := make([]int64, 0, len(got))
+		for _, item := range got {
+			out = append(out, item.Index)
+		}
+		return out
+	}
+
+	// Items 0 and 2 finish before item 1; the page must not skip past 1.
+	if err := queries.CompleteBatchItem(ctx, db.CompleteBatchItemParams{ID: items[0].ID, Response: []byte(`{"ok":true}`)}); err != nil {
+		t.Fatalf("complete item 0: %v", err)
+	}
+	if err := queries.CompleteBatchItem(ctx, db.CompleteBatchItemParams{ID: items[2].ID, Response: []byte(`{"ok":true}`)}); err != nil {
+		t.Fatalf("complete item 2: %v", err)
+	}
+	if got := indexes(-1); len(got) != 1 || got[0] != 0 {
+		t.Fatalf("expected only item 0 before item 1 finishes, got %v", got)
+	}
+
+	if err := queries.FailBatchItem(ctx, db.FailBatchItemParams{ID: items[1].ID, Error: []byte(`{"message":"boom"}`)}); err != nil {
+		t.Fatalf("fail item 1: %v", err)
+	}
+	if got := indexes(0); len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Fatalf("expected failed item 1 and item 2 after cursor 0, got %v", got)
+	}
+	if got := indexes(2); len(got) != 0 {
+		t.Fatalf("expected nothing while item 3 is queued, got %v", got)
+	}
+}
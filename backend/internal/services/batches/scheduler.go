@@ -0,0 +1,37 @@
+package batches
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type scheduleQueries interface {
+	ActivateScheduledBatches(ctx context.Context, now pgtype.Timestamptz) (int64, error)
+}
+
+// Scheduler releases scheduled batches to the worker queue once their
+// scheduled_at time has passed. Cancelled batches are never released.
+type Scheduler struct {
+	queries scheduleQueries
+	now     func() time.Time
+}
+
+// NewScheduler builds a scheduler backed by the batch queries.
+func NewScheduler(queries scheduleQueries) *Scheduler {
+	return &Scheduler{
+		queries: queries,
+		now:     func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// ActivateDue moves every scheduled batch whose scheduled_at is at or before
+// the current time into the validating queue. It returns the number of
+// batches released.
+func (s *Scheduler) ActivateDue(ctx context.Context) (int64, error) {
+	if s == nil || s.queries == nil {
+		return 0, nil
+	}
+	return s.queries.ActivateScheduledBatches(ctx, toPgTime(s.now()))
+}
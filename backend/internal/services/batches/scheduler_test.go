@@ -0,0 +1,63 @@
+package batches
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type fakeScheduledBatch struct {
+	status      string
+	scheduledAt time.Time
+}
+
+type fakeScheduleQueries struct {
+	batches []*fakeScheduledBatch
+}
+
+// ActivateScheduledBatches mirrors the SQL filter: only scheduled batches
+// whose scheduled_at is at or before now move to validating.
+func (f *fakeScheduleQueries) ActivateScheduledBatches(_ context.Context, now pgtype.Timestamptz) (int64, error) {
+	var activated int64
+	for _, batch := range f.batches {
+		if batch.status == "scheduled" && !batch.scheduledAt.After(now.Time) {
+			batch.status = "validating"
+			activated++
+		}
+	}
+	return activated, nil
+}
+
+func TestSchedulerActivatesOnlyDueBatches(t *testing.T) {
+	start := time.Date(2025, 11, 16, 12, 0, 0, 0, time.UTC)
+	clock := start
+	due := &fakeScheduledBatch{status: "scheduled", scheduledAt: start.Add(time.Minute)}
+	later := &fakeScheduledBatch{status: "scheduled", scheduledAt: start.Add(time.Hour)}
+	cancelled := &fakeScheduledBatch{status: "cancelled", scheduledAt: start.Add(time.Minute)}
+
+	scheduler := NewScheduler(&fakeScheduleQueries{batches: []*fakeScheduledBatch{due, later, cancelled}})
+	scheduler.now = func() time.Time { return clock }
+	ctx := context.Background()
+
+	if n, err := scheduler.ActivateDue(ctx); err != nil || n != 0 {
+		t.Fatalf("expected no activations before the scheduled time, got %d (%v)", n, err)
+	}
+
+	clock = start.Add(time.Minute)
+	if n, err := scheduler.ActivateDue(ctx); err != nil || n != 1 {
+		t.Fatalf("expected one activation at the scheduled time, got %d (%v)", n, err)
+	}
+	if due.status != "validating" || later.status != "scheduled" || cancelled.status != "cancelled" {
+		t.Fatalf("unexpected statuses: due=%s later=%s cancelled=%s", due.status, later.status, cancelled.status)
+	}
+
+	clock = start.Add(2 * time.Hour)
+	if n, err := scheduler.ActivateDue(ctx); err != nil || n != 1 {
+		t.Fatalf("expected the later batch to activate, got %d (%v)", n, err)
+	}
+	if later.status != "validating" {
+		t.Fatalf("expected later batch to be validating, got %s", later.status)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
 
 	"github.com/ncecere/open_model_gateway/backend/internal/config"
 	"github.com/ncecere/open_model_gateway/backend/internal/db"
@@ -24,20 +25,36 @@ import (
 var (
 	ErrUnsupportedEndpoint = errors.New("unsupported batch endpoint")
 	ErrFilePurposeMismatch = errors.New("file purpose must be batch")
+	ErrScheduleInPast      = errors.New("scheduled_at must be in the future")
 )
 
 const defaultCompletionWindow = "24h"
 
+// reclaimScanLimit caps how many running batches one claim checks for an
+// expired lock.
+const reclaimScanLimit = 20
+
 // Service orchestrates batch metadata and ingestion.
 type Service struct {
 	pool    *pgxpool.Pool
 	queries *db.Queries
 	files   *filesvc.Service
 	cfg     *config.BatchesConfig
+	lock    *ClaimLock
 }
 
-func NewService(pool *pgxpool.Pool, queries *db.Queries, files *filesvc.Service, cfg *config.BatchesConfig) *Service {
-	return &Service{pool: pool, queries: queries, files: files, cfg: cfg}
+// NewService wires the batch service. With a Redis client, claimed batches
+// are locked under batch_claim:<id> so several workers can share the queue.
+func NewService(pool *pgxpool.Pool, queries *db.Queries, files *filesvc.Service, redisClient *redis.Client, cfg *config.BatchesConfig) *Service {
+	svc := &Service{pool: pool, queries: queries, files: files, cfg: cfg}
+	if redisClient != nil {
+		var ttl time.Duration
+		if cfg != nil {
+			ttl = cfg.LockTTL
+		}
+		svc.lock = NewClaimLock(redisClient, ttl)
+	}
+	return svc
 }
 
 type CreateParams struct {
@@ -48,6 +65,9 @@ type CreateParams struct {
 	InputFileID      uuid.UUID
 	Metadata         map[string]string
 	MaxConcurrency   int
+	// ScheduledAt defers processing until the given time. Nil submits the
+	// batch immediately.
+	ScheduledAt *time.Time
 }
 
 type Batch struct {
@@ -76,7 +96,11 @@ type Batch struct {
 	FailedAt              *time.Time
 	ExpiresAt             *time.Time
 	ExpiredAt             *time.Time
-	Errors                []BatchError
+	ScheduledAt           *time.Time
+	// ModelAlias is the model every line of the input file targets, or
+	// "mixed" when lines name different models.
+	ModelAlias string
+	Errors     []BatchError
 }
 
 // BatchWithTenant augments Batch records with tenant metadata for admin views.
@@ -93,6 +117,18 @@ type batchInput struct {
 	Headers  json.RawMessage `json:"headers"`
 }
 
+// ResultItem is one batch item that completed or failed. Response is set
+// for completed items and Error for failed ones.
+type ResultItem struct {
+	ID          uuid.UUID
+	Index       int64
+	CustomID    string
+	Status      string
+	Response    json.RawMessage
+	Error       json.RawMessage
+	CompletedAt *time.Time
+}
+
 // BatchError aligns with the OpenAI/Azure error payload.
 type BatchError struct {
 	Code    string `json:"code"`
@@ -131,6 +167,19 @@ func (s *Service) Create(ctx context.Context, params CreateParams) (Batch, error
 		return Batch{}, fmt.Errorf("batch exceeds max of %d requests", s.cfg.MaxRequests)
 	}
 
+	now := time.Now()
+	status := "validating"
+	startAt := now
+	var scheduledAt pgtype.Timestamptz
+	if params.ScheduledAt != nil {
+		if !params.ScheduledAt.After(now) {
+			return Batch{}, ErrScheduleInPast
+		}
+		status = "scheduled"
+		startAt = *params.ScheduledAt
+		scheduledAt = toPgTime(startAt)
+	}
+
 	ttl := s.cfg.DefaultTTL
 	if ttl <= 0 {
 		ttl = 168 * time.Hour
@@ -138,7 +187,7 @@ func (s *Service) Create(ctx context.Context, params CreateParams) (Batch, error
 	if s.cfg.MaxTTL > 0 && ttl > s.cfg.MaxTTL {
 		ttl = s.cfg.MaxTTL
 	}
-	expiresAt := time.Now().Add(ttl)
+	expiresAt := startAt.Add(ttl)
 
 	completionWindow := strings.TrimSpace(params.CompletionWindow)
 	if completionWindow == "" {
@@ -173,7 +222,7 @@ func (s *Service) Create(ctx context.Context, params CreateParams) (Batch, error
 	batchRow, err := qtx.CreateBatch(ctx, db.CreateBatchParams{
 		TenantID:          toPgUUID(params.TenantID),
 		ApiKeyID:          toPgUUID(params.APIKeyID),
-		Status:            "validating",
+		Status:            status,
 		Endpoint:          endpoint,
 		InputFileID:       toNullableUUID(params.InputFileID),
 		CompletionWindow:  pgtype.Text{String: completionWindow, Valid: true},
@@ -181,6 +230,8 @@ func (s *Service) Create(ctx context.Context, params CreateParams) (Batch, error
 		Metadata:          metadataJSON,
 		RequestCountTotal: int32(len(entries)),
 		ExpiresAt:         toPgTime(expiresAt),
+		ScheduledAt:       scheduledAt,
+		ModelAlias:        batchModelAlias(entries),
 	})
 	if err != nil {
 		return Batch{}, err
@@ -419,6 +470,7 @@ func toBatch(row db.Batch) (Batch, error) {
 		RequestCountCancelled: int(row.RequestCountCancelled),
 		CreatedAt:             row.CreatedAt.Time,
 		UpdatedAt:             row.UpdatedAt.Time,
+		ModelAlias:            row.ModelAlias,
 	}
 
 	if len(row.Metadata) > 0 {
@@ -474,6 +526,10 @@ func toBatch(row db.Batch) (Batch, error) {
 		t := row.ExpiredAt.Time
 		batch.ExpiredAt = &t
 	}
+	if row.ScheduledAt.Valid {
+		t := row.ScheduledAt.Time
+		batch.ScheduledAt = &t
+	}
 
 	return batch, nil
 }
@@ -506,11 +562,24 @@ func toBatchFromAdminRow(row db.ListBatchesAdminRow) (Batch, error) {
 		FailedAt:              row.FailedAt,
 		ExpiresAt:             row.ExpiresAt,
 		ExpiredAt:             row.ExpiredAt,
+		ScheduledAt:           row.ScheduledAt,
+		ModelAlias:            row.ModelAlias,
 	})
 }
 
-// ClaimNextBatch finds the oldest queued batch and marks it in progress atomically.
+// ClaimNextBatch finds the oldest queued batch, takes its claim lock and marks
+// it in progress atomically. With no queued batch it reclaims a running batch
+// whose lock expired because its worker stopped heartbeating. It returns
+// pgx.ErrNoRows when there is nothing to claim.
 func (s *Service) ClaimNextBatch(ctx context.Context) (Batch, error) {
+	batch, err := s.claimQueuedBatch(ctx)
+	if errors.Is(err, pgx.ErrNoRows) && s.lock != nil {
+		return s.reclaimAbandonedBatch(ctx)
+	}
+	return batch, err
+}
+
+func (s *Service) claimQueuedBatch(ctx context.Context) (Batch, error) {
 	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
 		return Batch{}, err
@@ -522,21 +591,124 @@ func (s *Service) ClaimNextBatch(ctx context.Context) (Batch, error) {
 	if err != nil {
 		return Batch{}, err
 	}
+	batchID, err := fromPgUUID(row.ID)
+	if err != nil {
+		return Batch{}, err
+	}
+	acquired, err := s.lock.Acquire(ctx, batchID)
+	if err != nil {
+		return Batch{}, err
+	}
+	if !acquired {
+		return Batch{}, pgx.ErrNoRows
+	}
 	claimed, err := qtx.MarkBatchInProgress(ctx, row.ID)
 	if err != nil {
+		s.releaseLock(batchID)
 		return Batch{}, err
 	}
 	if err := tx.Commit(ctx); err != nil {
+		s.releaseLock(batchID)
 		return Batch{}, err
 	}
 	return toBatch(claimed)
 }
 
+// reclaimAbandonedBatch takes over the oldest running batch without a claim
+// lock. Items the previous worker left running are queued again.
+func (s *Service) reclaimAbandonedBatch(ctx context.Context) (Batch, error) {
+	ids, err := s.queries.ListActiveBatchIDs(ctx, reclaimScanLimit)
+	if err != nil {
+		return Batch{}, err
+	}
+	for _, id := range ids {
+		batchID, err := fromPgUUID(id)
+		if err != nil {
+			return Batch{}, err
+		}
+		acquired, err := s.lock.Acquire(ctx, batchID)
+		if err != nil {
+			return Batch{}, err
+		}
+		if !acquired {
+			continue
+		}
+		// The batch may have finished between listing and locking.
+		row, err := s.queries.GetBatchByID(ctx, id)
+		if err != nil {
+			s.releaseLock(batchID)
+			return Batch{}, err
+		}
+		if row.Status != "in_progress" && row.Status != "cancelling" {
+			s.releaseLock(batchID)
+			continue
+		}
+		requeued, err := s.queries.RequeueRunningBatchItems(ctx, id)
+		if err != nil {
+			s.releaseLock(batchID)
+			return Batch{}, err
+		}
+		slog.Warn("batch reclaimed after claim lock expired",
+			slog.String("batch_id", batchID.String()),
+			slog.Int64("requeued_items", requeued),
+		)
+		return toBatch(row)
+	}
+	return Batch{}, pgx.ErrNoRows
+}
+
+// HeartbeatBatch extends the claim lock on batchID. It returns
+// ErrBatchLockLost when another worker may have taken the batch over.
+func (s *Service) HeartbeatBatch(ctx context.Context, batchID uuid.UUID) error {
+	return s.lock.Extend(ctx, batchID)
+}
+
+// ReleaseBatch drops the claim lock once the worker is done with batchID.
+func (s *Service) ReleaseBatch(ctx context.Context, batchID uuid.UUID) error {
+	return s.lock.Release(ctx, batchID)
+}
+
+// LockTTL returns how long a claim lock lives without a heartbeat.
+func (s *Service) LockTTL() time.Duration {
+	return s.lock.TTL()
+}
+
+func (s *Service) releaseLock(batchID uuid.UUID) {
+	if err := s.lock.Release(context.Background(), batchID); err != nil {
+		slog.Warn("release batch claim lock", slog.String("batch_id", batchID.String()), slog.String("error", err.Error()))
+	}
+}
+
+// ListFinishedItems returns the completed and failed items of batchID in
+// index order, so a worker resuming a reclaimed batch can carry their results
+// into the output files.
+func (s *Service) ListFinishedItems(ctx context.Context, batchID uuid.UUID) ([]db.BatchItem, error) {
+	rows, err := s.queries.ListBatchItemsForOutput(ctx, toPgUUID(batchID))
+	if err != nil {
+		return nil, err
+	}
+	items := make([]db.BatchItem, 0, len(rows))
+	for _, row := range rows {
+		if row.Status == "completed" || row.Status == "failed" {
+			items = append(items, row)
+		}
+	}
+	return items, nil
+}
+
 // ClaimNextItem locks and transitions the next queued batch item to running state.
 func (s *Service) ClaimNextItem(ctx context.Context, batchID uuid.UUID) (db.BatchItem, error) {
 	return s.queries.ClaimNextBatchItem(ctx, toPgUUID(batchID))
 }
 
+// ReleaseItem puts a running item back in the queue so another worker can
+// run it. Counters are untouched because they only move when an item
+// finishes. Releasing an item that is no longer running is a no-op.
+func (s *Service) ReleaseItem(ctx context.Context, itemID uuid.UUID) error {
+	_, err := s.queries.ReleaseBatchItem(ctx, toPgUUID(itemID))
+	return err
+}
+
 // CompleteItem marks the specified batch item as completed and stores the response payload.
 func (s *Service) CompleteItem(ctx context.Context, itemID uuid.UUID, payload []byte) error {
 	return s.queries.CompleteBatchItem(ctx, db.CompleteBatchItemParams{
@@ -553,6 +725,47 @@ func (s *Service) FailItem(ctx context.Context, itemID uuid.UUID, payload []byte
 	})
 }
 
+// ListResultItems returns up to limit completed or failed items of the
+// tenant's batch with an item index above afterIndex, in index order, so
+// results can be read while the batch is still running. Items finish out of
+// order, so the page stops before the first item that is still queued or
+// running; the last returned index is always a safe cursor. Pass -1 to start
+// from the first item.
+func (s *Service) ListResultItems(ctx context.Context, tenantID, batchID uuid.UUID, afterIndex int64, limit int32) ([]ResultItem, error) {
+	if _, err := s.Get(ctx, tenantID, batchID); err != nil {
+		return nil, err
+	}
+	rows, err := s.queries.ListFinishedBatchItems(ctx, db.ListFinishedBatchItemsParams{
+		BatchID:    toPgUUID(batchID),
+		AfterIndex: afterIndex,
+		RowLimit:   limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]ResultItem, 0, len(rows))
+	for _, row := range rows {
+		id, err := fromPgUUID(row.ID)
+		if err != nil {
+			return nil, err
+		}
+		item := ResultItem{
+			ID:       id,
+			Index:    row.ItemIndex,
+			CustomID: row.CustomID.String,
+			Status:   row.Status,
+			Response: json.RawMessage(row.Response),
+			Error:    json.RawMessage(row.Error),
+		}
+		if row.CompletedAt.Valid {
+			completedAt := row.CompletedAt.Time
+			item.CompletedAt = &completedAt
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
 // IncrementCounts adjusts the aggregate batch counters.
 func (s *Service) IncrementCounts(ctx context.Context, batchID uuid.UUID, completed, failed, cancelled int) error {
 	return s.queries.IncrementBatchCounts(ctx, db.IncrementBatchCountsParams{
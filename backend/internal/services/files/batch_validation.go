@@ -0,0 +1,132 @@
+package files
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// maxBatchLineErrors bounds the errors reported for one file so a wholly
+// malformed upload does not produce an enormous response.
+const maxBatchLineErrors = 100
+
+// batchEndpoints mirrors the endpoints the batch worker can execute.
+var batchEndpoints = map[string]struct{}{
+	"/v1/chat/completions":   {},
+	"/v1/embeddings":         {},
+	"/v1/images/generations": {},
+}
+
+// BatchLineError describes why one line of a batch input file was rejected.
+type BatchLineError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// BatchValidationError lists every rejected line of a batch input file.
+type BatchValidationError struct {
+	Errors []BatchLineError
+}
+
+func (e *BatchValidationError) Error() string {
+	if len(e.Errors) == 0 {
+		return "invalid batch file"
+	}
+	first := e.Errors[0]
+	return fmt.Sprintf("invalid batch file: line %d: %s (%d errors)", first.Line, first.Error, len(e.Errors))
+}
+
+// ValidateBatchFile checks every line of a batch input file before it is
+// stored: each line must be a JSON object with custom_id, method POST, a
+// supported url, and a body whose model is one of knownAliases. A nil
+// knownAliases skips the model check. Files longer than maxLines requests are
+// rejected when maxLines > 0. Failures are returned as *BatchValidationError.
+func ValidateBatchFile(ctx context.Context, reader io.Reader, maxLines int, knownAliases []string) error {
+	var aliases map[string]struct{}
+	if knownAliases != nil {
+		aliases = make(map[string]struct{}, len(knownAliases))
+		for _, alias := range knownAliases {
+			aliases[strings.TrimSpace(alias)] = struct{}{}
+		}
+	}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	var errs []BatchLineError
+	line, requests := 0, 0
+	for scanner.Scan() {
+		line++
+		if line%1000 == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		raw := strings.TrimSpace(scanner.Text())
+		if raw == "" {
+			continue
+		}
+		requests++
+		if maxLines > 0 && requests > maxLines {
+			errs = append(errs, BatchLineError{Line: line, Error: fmt.Sprintf("batch file exceeds max of %d requests", maxLines)})
+			break
+		}
+		if msg := validateBatchLine(raw, aliases); msg != "" {
+			errs = append(errs, BatchLineError{Line: line, Error: msg})
+			if len(errs) >= maxBatchLineErrors {
+				break
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, BatchLineError{Line: line + 1, Error: err.Error()})
+	}
+	if len(errs) == 0 && requests == 0 {
+		errs = append(errs, BatchLineError{Line: 1, Error: "batch file contained no requests"})
+	}
+	if len(errs) > 0 {
+		return &BatchValidationError{Errors: errs}
+	}
+	return nil
+}
+
+func validateBatchLine(raw string, aliases map[string]struct{}) string {
+	var entry struct {
+		CustomID *string        `json:"custom_id"`
+		Method   string         `json:"method"`
+		URL      string         `json:"url"`
+		Body     map[string]any `json:"body"`
+	}
+	if err := json.Unmarshal([]byte(raw), &entry); err != nil {
+		return "invalid JSON: " + err.Error()
+	}
+	if entry.CustomID == nil || strings.TrimSpace(*entry.CustomID) == "" {
+		return "custom_id is required"
+	}
+	if !strings.EqualFold(strings.TrimSpace(entry.Method), "POST") {
+		return "method must be POST"
+	}
+	url := strings.TrimSpace(entry.URL)
+	if url != "" && !strings.HasPrefix(url, "/") {
+		url = "/" + url
+	}
+	if _, ok := batchEndpoints[url]; !ok {
+		return fmt.Sprintf("url %q is not a supported batch endpoint", entry.URL)
+	}
+	if entry.Body == nil {
+		return "body is required"
+	}
+	model, _ := entry.Body["model"].(string)
+	model = strings.TrimSpace(model)
+	if model == "" {
+		return "body.model is required"
+	}
+	if aliases != nil {
+		if _, ok := aliases[model]; !ok {
+			return fmt.Sprintf("body.model %q is not a known model", model)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,60 @@
+package files
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateBatchFileAcceptsValidLines(t *testing.T) {
+	t.Parallel()
+
+	input := strings.Join([]string{
+		`{"custom_id":"a","method":"POST","url":"/v1/chat/completions","body":{"model":"gpt-4o","messages":[]}}`,
+		``,
+		`{"custom_id":"b","method":"post","url":"v1/embeddings","body":{"model":"embed","input":"hi"}}`,
+	}, "\n")
+	err := ValidateBatchFile(context.Background(), strings.NewReader(input), 10, []string{"gpt-4o", "embed"})
+	require.NoError(t, err)
+}
+
+func TestValidateBatchFileReportsEveryBadLine(t *testing.T) {
+	t.Parallel()
+
+	input := strings.Join([]string{
+		`{"custom_id":"ok","method":"POST","url":"/v1/chat/completions","body":{"model":"gpt-4o"}}`,
+		`not json`,
+		`{"method":"POST","url":"/v1/chat/completions","body":{"model":"gpt-4o"}}`,
+		`{"custom_id":"c","method":"GET","url":"/v1/chat/completions","body":{"model":"gpt-4o"}}`,
+		`{"custom_id":"d","method":"POST","url":"/v1/audio/speech","body":{"model":"gpt-4o"}}`,
+		`{"custom_id":"e","method":"POST","url":"/v1/chat/completions"}`,
+		`{"custom_id":"f","method":"POST","url":"/v1/chat/completions","body":{"model":"unknown"}}`,
+	}, "\n")
+	err := ValidateBatchFile(context.Background(), strings.NewReader(input), 0, []string{"gpt-4o"})
+
+	var verr *BatchValidationError
+	require.ErrorAs(t, err, &verr)
+	lines := make([]int, 0, len(verr.Errors))
+	for _, e := range verr.Errors {
+		lines = append(lines, e.Line)
+	}
+	require.Equal(t, []int{2, 3, 4, 5, 6, 7}, lines)
+	require.Contains(t, verr.Errors[1].Error, "custom_id")
+	require.Contains(t, verr.Errors[5].Error, `"unknown"`)
+}
+
+func TestValidateBatchFileLimitsAndEmptyFiles(t *testing.T) {
+	t.Parallel()
+
+	line := `{"custom_id":"x","method":"POST","url":"/v1/embeddings","body":{"model":"embed"}}`
+	err := ValidateBatchFile(context.Background(), strings.NewReader(strings.Repeat(line+"\n", 3)), 2, nil)
+	var verr *BatchValidationError
+	require.ErrorAs(t, err, &verr)
+	require.Equal(t, 3, verr.Errors[0].Line)
+
+	err = ValidateBatchFile(context.Background(), strings.NewReader("\n\n"), 2, nil)
+	require.ErrorAs(t, err, &verr)
+	require.Contains(t, verr.Errors[0].Error, "no requests")
+}
@@ -0,0 +1,149 @@
+package files
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path/filepath"
+	"strings"
+)
+
+// sniffLen is how much of an upload is read to check its magic bytes.
+const sniffLen = 512
+
+// ErrUnsupportedMediaType reports an upload whose content type is not allowed
+// for its purpose or does not match the file's contents.
+var ErrUnsupportedMediaType = errors.New("unsupported media type")
+
+// extensionTypes covers upload extensions the mime package may not know.
+var extensionTypes = map[string]string{
+	".jsonl":  "application/x-ndjson",
+	".ndjson": "application/x-ndjson",
+	".json":   "application/json",
+	".txt":    "text/plain",
+}
+
+// magicSignature identifies a binary format by its leading bytes.
+type magicSignature struct {
+	contentType string
+	matches     func(head []byte) bool
+}
+
+func signaturePrefix(sig string) func([]byte) bool {
+	return func(head []byte) bool { return bytes.HasPrefix(head, []byte(sig)) }
+}
+
+var magicSignatures = []magicSignature{
+	{contentType: "image/png", matches: signaturePrefix("\x89PNG\r\n\x1a\n")},
+	{contentType: "image/jpeg", matches: signaturePrefix("\xff\xd8\xff")},
+	{contentType: "image/gif", matches: func(head []byte) bool {
+		return bytes.HasPrefix(head, []byte("GIF87a")) || bytes.HasPrefix(head, []byte("GIF89a"))
+	}},
+	{contentType: "image/webp", matches: func(head []byte) bool {
+		return len(head) >= 12 && bytes.HasPrefix(head, []byte("RIFF")) && string(head[8:12]) == "WEBP"
+	}},
+	{contentType: "application/pdf", matches: signaturePrefix("%PDF-")},
+	{contentType: "application/zip", matches: signaturePrefix("PK\x03\x04")},
+	{contentType: "application/gzip", matches: signaturePrefix("\x1f\x8b")},
+}
+
+// ResolveContentType returns the media type of an upload without parameters.
+// When the client sent none, or the generic application/octet-stream, it is
+// inferred from the filename's extension.
+func ResolveContentType(declared, filename string) string {
+	mediaType := normalizeMediaType(declared)
+	if mediaType != "" && mediaType != "application/octet-stream" {
+		return mediaType
+	}
+	ext := strings.ToLower(filepath.Ext(filename))
+	if inferred, ok := extensionTypes[ext]; ok {
+		return inferred
+	}
+	if inferred := normalizeMediaType(mime.TypeByExtension(ext)); inferred != "" {
+		return inferred
+	}
+	return mediaType
+}
+
+// ValidatePurposeMIME checks contentType against the media types rules allow
+// for purpose. Purposes without rules accept any type. Failures wrap
+// ErrUnsupportedMediaType.
+func ValidatePurposeMIME(purpose, contentType string, rules map[string][]string) error {
+	allowed := rules[strings.ToLower(strings.TrimSpace(purpose))]
+	if len(allowed) == 0 {
+		return nil
+	}
+	mediaType := normalizeMediaType(contentType)
+	for _, candidate := range allowed {
+		if normalizeMediaType(candidate) == mediaType {
+			return nil
+		}
+	}
+	if mediaType == "" {
+		mediaType = "no content type"
+	}
+	return fmt.Errorf("%w: %s files must be one of %s, got %s", ErrUnsupportedMediaType, purpose, strings.Join(allowed, ", "), mediaType)
+}
+
+// VerifyMagicBytes checks that an upload's leading bytes match contentType so
+// a declared type cannot disguise another format: a file declared as a known
+// binary format must carry its signature, and a text or JSON file must not
+// start with one. The reader is rewound afterwards. Failures wrap ErrUnsupportedMediaType.
+func VerifyMagicBytes(contentType string, reader io.ReadSeeker) error {
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(reader, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return err
+	}
+	if _, err := reader.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	return checkMagicBytes(normalizeMediaType(contentType), head[:n])
+}
+
+func checkMagicBytes(mediaType string, head []byte) error {
+	detected := ""
+	for _, sig := range magicSignatures {
+		if sig.matches(head) {
+			detected = sig.contentType
+			break
+		}
+	}
+	for _, sig := range magicSignatures {
+		if sig.contentType != mediaType {
+			continue
+		}
+		if detected != mediaType {
+			return fmt.Errorf("%w: file content is not %s", ErrUnsupportedMediaType, mediaType)
+		}
+		return nil
+	}
+	if detected != "" && isTextMediaType(mediaType) {
+		return fmt.Errorf("%w: file content is %s, not %s", ErrUnsupportedMediaType, detected, mediaType)
+	}
+	return nil
+}
+
+func isTextMediaType(mediaType string) bool {
+	switch {
+	case strings.HasPrefix(mediaType, "text/"), strings.HasSuffix(mediaType, "+json"):
+		return true
+	case mediaType == "application/json", mediaType == "application/x-ndjson", mediaType == "application/jsonl":
+		return true
+	default:
+		return false
+	}
+}
+
+func normalizeMediaType(contentType string) string {
+	contentType = strings.TrimSpace(contentType)
+	if contentType == "" {
+		return ""
+	}
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mediaType
+	}
+	return strings.ToLower(contentType)
+}
@@ -0,0 +1,109 @@
+package files
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+var testPurposeRules = map[string][]string{
+	PurposeBatch:  {"application/json", "application/x-ndjson", "text/plain"},
+	PurposeVision: {"image/png", "image/jpeg", "image/webp"},
+}
+
+func TestValidatePurposeMIMEAllowsListedTypes(t *testing.T) {
+	cases := []struct{ purpose, contentType string }{
+		{PurposeBatch, "application/x-ndjson"},
+		{PurposeBatch, "text/plain; charset=utf-8"},
+		{PurposeVision, "IMAGE/PNG"},
+		{PurposeVision, "image/webp"},
+		// Purposes without rules accept anything.
+		{PurposeAssist, "application/pdf"},
+	}
+	for _, tc := range cases {
+		if err := ValidatePurposeMIME(tc.purpose, tc.contentType, testPurposeRules); err != nil {
+			t.Fatalf("%s %s: unexpected error %v", tc.purpose, tc.contentType, err)
+		}
+	}
+}
+
+func TestValidatePurposeMIMERejectsOtherTypes(t *testing.T) {
+	cases := []struct{ purpose, contentType string }{
+		{PurposeBatch, "image/png"},
+		{PurposeBatch, ""},
+		{PurposeVision, "application/json"},
+		{PurposeVision, "image/gif"},
+	}
+	for _, tc := range cases {
+		err := ValidatePurposeMIME(tc.purpose, tc.contentType, testPurposeRules)
+		if !errors.Is(err, ErrUnsupportedMediaType) {
+			t.Fatalf("%s %q: expected ErrUnsupportedMediaType, got %v", tc.purpose, tc.contentType, err)
+		}
+	}
+}
+
+func TestVerifyMagicBytes(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	jpeg := []byte("\xff\xd8\xff\xe0\x00\x10JFIF")
+	webp := []byte("RIFF\x24\x00\x00\x00WEBPVP8 ")
+	jsonl := []byte(`{"custom_id":"1","method":"POST"}` + "\n")
+
+	valid := []struct {
+		contentType string
+		data        []byte
+	}{
+		{"image/png", png},
+		{"image/jpeg", jpeg},
+		{"image/webp", webp},
+		{"application/x-ndjson", jsonl},
+		{"application/octet-stream", png},
+	}
+	for _, tc := range valid {
+		if err := VerifyMagicBytes(tc.contentType, bytes.NewReader(tc.data)); err != nil {
+			t.Fatalf("%s: unexpected error %v", tc.contentType, err)
+		}
+	}
+
+	mismatched := []struct {
+		contentType string
+		data        []byte
+	}{
+		{"image/png", jpeg},
+		{"image/png", jsonl},
+		{"image/webp", []byte("RIFF\x24\x00\x00\x00WAVEfmt ")},
+		{"application/json", png},
+		{"text/plain", []byte("%PDF-1.7")},
+	}
+	for _, tc := range mismatched {
+		err := VerifyMagicBytes(tc.contentType, bytes.NewReader(tc.data))
+		if !errors.Is(err, ErrUnsupportedMediaType) {
+			t.Fatalf("%s with %q: expected ErrUnsupportedMediaType, got %v", tc.contentType, tc.data[:4], err)
+		}
+	}
+}
+
+func TestVerifyMagicBytesRewindsReader(t *testing.T) {
+	reader := bytes.NewReader([]byte("\x89PNG\r\n\x1a\nrest"))
+	if err := VerifyMagicBytes("image/png", reader); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	data, _ := io.ReadAll(reader)
+	if string(data) != "\x89PNG\r\n\x1a\nrest" {
+		t.Fatalf("reader not rewound, read %q", data)
+	}
+}
+
+func TestResolveContentType(t *testing.T) {
+	cases := []struct{ declared, filename, want string }{
+		{"text/plain; charset=utf-8", "input.jsonl", "text/plain"},
+		{"application/octet-stream", "input.jsonl", "application/x-ndjson"},
+		{"", "photo.png", "image/png"},
+		{"application/octet-stream", "blob", "application/octet-stream"},
+	}
+	for _, tc := range cases {
+		if got := ResolveContentType(tc.declared, tc.filename); got != tc.want {
+			t.Fatalf("ResolveContentType(%q, %q) = %q, want %q", tc.declared, tc.filename, got, tc.want)
+		}
+	}
+}
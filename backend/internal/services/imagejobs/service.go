@@ -0,0 +1,249 @@
+// Package imagejobs queues image generation requests submitted to
+// /v1/images/generations/async and runs them on a background worker pool.
+package imagejobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+)
+
+const (
+	pollInterval = time.Second
+	maxErrorLen  = 1024
+	// defaultTTL applies when files.default_ttl is unset.
+	defaultTTL = 24 * time.Hour
+)
+
+var ErrNotFound = errors.New("image job not found")
+
+// Job is a queued image generation. Request holds the normalized
+// models.ImageRequest; Result holds the models.ImageResponse once completed.
+type Job struct {
+	ID          uuid.UUID
+	TenantID    uuid.UUID
+	APIKeyID    uuid.UUID
+	Alias       string
+	Request     models.ImageRequest
+	Status      db.ImageJobStatus
+	Result      *models.ImageResponse
+	Error       string
+	TraceID     string
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+	ExpiresAt   time.Time
+}
+
+// Processor generates the images for a claimed job.
+type Processor func(ctx context.Context, job Job) (models.ImageResponse, error)
+
+type jobQueries interface {
+	InsertImageJob(ctx context.Context, arg db.InsertImageJobParams) (db.ImageJob, error)
+	GetImageJob(ctx context.Context, arg db.GetImageJobParams) (db.ImageJob, error)
+	ClaimNextImageJob(ctx context.Context) (db.ImageJob, error)
+	CompleteImageJob(ctx context.Context, arg db.CompleteImageJobParams) error
+	FailImageJob(ctx context.Context, arg db.FailImageJobParams) error
+	DeleteExpiredImageJobs(ctx context.Context, expiresAt pgtype.Timestamptz) (int64, error)
+}
+
+// Service persists image jobs and drains them with a pool of workers. Jobs
+// and their results are kept for files.default_ttl.
+type Service struct {
+	queries      jobQueries
+	files        *config.FilesConfig
+	logger       *slog.Logger
+	pollInterval time.Duration
+}
+
+func NewService(queries jobQueries, files *config.FilesConfig, logger *slog.Logger) *Service {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Service{queries: queries, files: files, logger: logger, pollInterval: pollInterval}
+}
+
+// Submit queues job and returns it with its assigned ID and pending status.
+func (s *Service) Submit(ctx context.Context, job Job) (Job, error) {
+	if s == nil || s.queries == nil {
+		return Job{}, errors.New("image job service not initialized")
+	}
+	request, err := json.Marshal(job.Request)
+	if err != nil {
+		return Job{}, err
+	}
+	row, err := s.queries.InsertImageJob(ctx, db.InsertImageJobParams{
+		TenantID:   toPgUUID(job.TenantID),
+		ApiKeyID:   toPgUUID(job.APIKeyID),
+		ModelAlias: job.Alias,
+		Request:    request,
+		TraceID:    job.TraceID,
+		ExpiresAt:  s.expiry(),
+	})
+	if err != nil {
+		return Job{}, err
+	}
+	return toJob(row)
+}
+
+// Get returns the tenant's job, or ErrNotFound once it has expired.
+func (s *Service) Get(ctx context.Context, tenantID, id uuid.UUID) (Job, error) {
+	if s == nil || s.queries == nil {
+		return Job{}, errors.New("image job service not initialized")
+	}
+	row, err := s.queries.GetImageJob(ctx, db.GetImageJobParams{
+		ID:       toPgUUID(id),
+		TenantID: toPgUUID(tenantID),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Job{}, ErrNotFound
+		}
+		return Job{}, err
+	}
+	return toJob(row)
+}
+
+// Run starts workers goroutines that process queued jobs until ctx is
+// cancelled, and waits for them to finish.
+func (s *Service) Run(ctx context.Context, workers int, process Processor) {
+	if s == nil || s.queries == nil || process == nil {
+		return
+	}
+	workers = max(workers, 1)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.work(ctx, process)
+		}()
+	}
+	wg.Wait()
+}
+
+func (s *Service) work(ctx context.Context, process Processor) {
+	for {
+		handled, err := s.ProcessNext(ctx, process)
+		if err != nil && ctx.Err() == nil {
+			s.logger.Error("image job worker", slog.String("error", err.Error()))
+		}
+		if handled && err == nil {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.pollInterval):
+		}
+	}
+}
+
+// ProcessNext claims the oldest queued job and runs it, reporting whether a
+// job was claimed. A processing error marks the job failed and is not
+// returned; only storage errors and cancellation are.
+func (s *Service) ProcessNext(ctx context.Context, process Processor) (bool, error) {
+	row, err := s.queries.ClaimNextImageJob(ctx)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	job, err := toJob(row)
+	if err != nil {
+		return true, s.queries.FailImageJob(ctx, db.FailImageJobParams{
+			ID:           row.ID,
+			ErrorMessage: pgtype.Text{String: "invalid job request", Valid: true},
+			ExpiresAt:    s.expiry(),
+		})
+	}
+
+	resp, err := process(ctx, job)
+	if err != nil {
+		if ctx.Err() != nil {
+			// Shutting down: leave the job processing so it is reclaimed.
+			return true, ctx.Err()
+		}
+		return true, s.queries.FailImageJob(ctx, db.FailImageJobParams{
+			ID:           row.ID,
+			ErrorMessage: pgtype.Text{String: truncate(err.Error(), maxErrorLen), Valid: true},
+			ExpiresAt:    s.expiry(),
+		})
+	}
+	result, err := json.Marshal(resp)
+	if err != nil {
+		return true, err
+	}
+	return true, s.queries.CompleteImageJob(ctx, db.CompleteImageJobParams{
+		ID:        row.ID,
+		Result:    result,
+		ExpiresAt: s.expiry(),
+	})
+}
+
+// PurgeExpired deletes jobs whose retention has lapsed.
+func (s *Service) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	if s == nil || s.queries == nil {
+		return 0, nil
+	}
+	return s.queries.DeleteExpiredImageJobs(ctx, pgtype.Timestamptz{Time: now, Valid: true})
+}
+
+func (s *Service) expiry() pgtype.Timestamptz {
+	ttl := defaultTTL
+	if s.files != nil && s.files.DefaultTTL > 0 {
+		ttl = s.files.DefaultTTL
+	}
+	return pgtype.Timestamptz{Time: time.Now().UTC().Add(ttl), Valid: true}
+}
+
+func toJob(row db.ImageJob) (Job, error) {
+	job := Job{
+		ID:        uuid.UUID(row.ID.Bytes),
+		TenantID:  uuid.UUID(row.TenantID.Bytes),
+		APIKeyID:  uuid.UUID(row.ApiKeyID.Bytes),
+		Alias:     row.ModelAlias,
+		Status:    row.Status,
+		Error:     row.ErrorMessage.String,
+		TraceID:   row.TraceID,
+		CreatedAt: row.CreatedAt.Time,
+		ExpiresAt: row.ExpiresAt.Time,
+	}
+	if err := json.Unmarshal(row.Request, &job.Request); err != nil {
+		return Job{}, err
+	}
+	if len(row.Result) > 0 {
+		var result models.ImageResponse
+		if err := json.Unmarshal(row.Result, &result); err != nil {
+			return Job{}, err
+		}
+		job.Result = &result
+	}
+	if row.CompletedAt.Valid {
+		completed := row.CompletedAt.Time
+		job.CompletedAt = &completed
+	}
+	return job, nil
+}
+
+func toPgUUID(id uuid.UUID) pgtype.UUID {
+	return pgtype.UUID{Bytes: id, Valid: id != uuid.Nil}
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
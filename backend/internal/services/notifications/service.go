@@ -0,0 +1,262 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+const (
+	// Channel is the Redis pub/sub channel new notifications are published
+	// on, so streams on every instance see them.
+	Channel = "gateway:notifications"
+
+	defaultListLimit = 50
+	maxListLimit     = 200
+	sendTimeout      = 5 * time.Second
+)
+
+var (
+	ErrServiceUnavailable = errors.New("notification service not initialized")
+	ErrNotFound           = errors.New("notification not found")
+	ErrMessageRequired    = errors.New("message is required")
+	ErrInvalidSeverity    = errors.New("severity must be info, warning, or critical")
+	ErrStreamUnavailable  = errors.New("notification stream unavailable")
+)
+
+// Severity ranks a notification.
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+// Entity types the built-in event sources attach notifications to.
+const (
+	EntityTenant = "tenant"
+	EntityRoute  = "route"
+	EntityModel  = "model"
+	EntityBatch  = "batch"
+)
+
+// Notification is one system event in the admin inbox. ReadAt is nil until an
+// admin marks it read.
+type Notification struct {
+	ID         uuid.UUID  `json:"id"`
+	Severity   Severity   `json:"severity"`
+	EntityType string     `json:"entity_type"`
+	EntityID   string     `json:"entity_id"`
+	Message    string     `json:"message"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ReadAt     *time.Time `json:"read_at"`
+}
+
+// Notifier records notifications; *Service implements it.
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// Send records n in the background so event sources on the request path
+// never wait on the write. Failures are logged.
+func Send(notifier Notifier, n Notification) {
+	if notifier == nil {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+		defer cancel()
+		if err := notifier.Notify(ctx, n); err != nil {
+			slog.Warn("record admin notification",
+				slog.String("entity_type", n.EntityType),
+				slog.String("entity_id", n.EntityID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}()
+}
+
+// Filter controls notification listing.
+type Filter struct {
+	UnreadOnly bool
+	Limit      int32
+	Offset     int32
+}
+
+type notificationQueries interface {
+	InsertNotification(ctx context.Context, arg db.InsertNotificationParams) (db.Notification, error)
+	ListNotifications(ctx context.Context, arg db.ListNotificationsParams) ([]db.Notification, error)
+	CountUnreadNotifications(ctx context.Context) (int64, error)
+	MarkNotificationRead(ctx context.Context, id pgtype.UUID) (db.Notification, error)
+	MarkAllNotificationsRead(ctx context.Context) (int64, error)
+}
+
+// Service stores admin notifications and fans new ones out over Redis.
+type Service struct {
+	queries notificationQueries
+	client  *redis.Client
+}
+
+// NewService returns a notification service. A nil client disables the live
+// stream; notifications are still stored.
+func NewService(queries notificationQueries, client *redis.Client) *Service {
+	return &Service{queries: queries, client: client}
+}
+
+// Notify stores n and publishes it to live streams. Severity defaults to
+// info.
+func (s *Service) Notify(ctx context.Context, n Notification) error {
+	if s == nil || s.queries == nil {
+		return ErrServiceUnavailable
+	}
+	severity, err := normalizeSeverity(n.Severity)
+	if err != nil {
+		return err
+	}
+	message := strings.TrimSpace(n.Message)
+	if message == "" {
+		return ErrMessageRequired
+	}
+	row, err := s.queries.InsertNotification(ctx, db.InsertNotificationParams{
+		Severity:   db.NotificationSeverity(severity),
+		EntityType: strings.TrimSpace(n.EntityType),
+		EntityID:   strings.TrimSpace(n.EntityID),
+		Message:    message,
+	})
+	if err != nil {
+		return err
+	}
+	if s.client == nil {
+		return nil
+	}
+	data, err := json.Marshal(fromRow(row))
+	if err != nil {
+		return err
+	}
+	// Live delivery is best effort; the stored row is the record.
+	if err := s.client.Publish(ctx, Channel, data).Err(); err != nil {
+		slog.Debug("publish admin notification", slog.String("error", err.Error()))
+	}
+	return nil
+}
+
+// List returns notifications newest first.
+func (s *Service) List(ctx context.Context, filter Filter) ([]Notification, error) {
+	if s == nil || s.queries == nil {
+		return nil, ErrServiceUnavailable
+	}
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+	offset := filter.Offset
+	if offset < 0 {
+		offset = 0
+	}
+	rows, err := s.queries.ListNotifications(ctx, db.ListNotificationsParams{
+		UnreadOnly: filter.UnreadOnly,
+		ListLimit:  limit,
+		ListOffset: offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]Notification, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, fromRow(row))
+	}
+	return items, nil
+}
+
+// UnreadCount returns how many notifications are unread.
+func (s *Service) UnreadCount(ctx context.Context) (int64, error) {
+	if s == nil || s.queries == nil {
+		return 0, ErrServiceUnavailable
+	}
+	return s.queries.CountUnreadNotifications(ctx)
+}
+
+// MarkRead marks one notification read. Marking an already read
+// notification keeps its original read time.
+func (s *Service) MarkRead(ctx context.Context, id uuid.UUID) (Notification, error) {
+	if s == nil || s.queries == nil {
+		return Notification{}, ErrServiceUnavailable
+	}
+	row, err := s.queries.MarkNotificationRead(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Notification{}, ErrNotFound
+		}
+		return Notification{}, err
+	}
+	return fromRow(row), nil
+}
+
+// MarkAllRead marks every unread notification read and returns how many
+// changed.
+func (s *Service) MarkAllRead(ctx context.Context) (int64, error) {
+	if s == nil || s.queries == nil {
+		return 0, ErrServiceUnavailable
+	}
+	return s.queries.MarkAllNotificationsRead(ctx)
+}
+
+// Subscribe opens a subscription to newly recorded notifications. Each
+// message payload is a JSON Notification. The caller must close the
+// subscription.
+func (s *Service) Subscribe(ctx context.Context) (*redis.PubSub, error) {
+	if s == nil || s.client == nil {
+		return nil, ErrStreamUnavailable
+	}
+	sub := s.client.Subscribe(ctx, Channel)
+	// Wait for the subscription to be confirmed so nothing published right
+	// after Subscribe returns is missed.
+	if _, err := sub.Receive(ctx); err != nil {
+		sub.Close()
+		return nil, err
+	}
+	return sub, nil
+}
+
+func normalizeSeverity(severity Severity) (Severity, error) {
+	switch Severity(strings.ToLower(strings.TrimSpace(string(severity)))) {
+	case "", SeverityInfo:
+		return SeverityInfo, nil
+	case SeverityWarning:
+		return SeverityWarning, nil
+	case SeverityCritical:
+		return SeverityCritical, nil
+	default:
+		return "", ErrInvalidSeverity
+	}
+}
+
+func fromRow(row db.Notification) Notification {
+	n := Notification{
+		ID:         uuid.UUID(row.ID.Bytes),
+		Severity:   Severity(row.Severity),
+		EntityType: row.EntityType,
+		EntityID:   row.EntityID,
+		Message:    row.Message,
+		CreatedAt:  row.CreatedAt.Time.UTC(),
+	}
+	if row.ReadAt.Valid {
+		readAt := row.ReadAt.Time.UTC()
+		n.ReadAt = &readAt
+	}
+	return n
+}
@@ -0,0 +1,122 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+type stubQueries struct {
+	inserted []db.InsertNotificationParams
+	listArgs db.ListNotificationsParams
+}
+
+func (q *stubQueries) InsertNotification(_ context.Context, arg db.InsertNotificationParams) (db.Notification, error) {
+	q.inserted = append(q.inserted, arg)
+	return db.Notification{
+		ID:         pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		Severity:   arg.Severity,
+		EntityType: arg.EntityType,
+		EntityID:   arg.EntityID,
+		Message:    arg.Message,
+		CreatedAt:  pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}, nil
+}
+
+func (q *stubQueries) ListNotifications(_ context.Context, arg db.ListNotificationsParams) ([]db.Notification, error) {
+	q.listArgs = arg
+	return nil, nil
+}
+
+func (q *stubQueries) CountUnreadNotifications(context.Context) (int64, error) {
+	return 0, nil
+}
+
+func (q *stubQueries) MarkNotificationRead(context.Context, pgtype.UUID) (db.Notification, error) {
+	return db.Notification{}, pgx.ErrNoRows
+}
+
+func (q *stubQueries) MarkAllNotificationsRead(context.Context) (int64, error) {
+	return 0, nil
+}
+
+func TestNotifyStoresAndPublishes(t *testing.T) {
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() {
+		client.Close()
+		server.Close()
+	})
+
+	queries := &stubQueries{}
+	service := NewService(queries, client)
+	ctx := context.Background()
+	sub, err := service.Subscribe(ctx)
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	defer sub.Close()
+
+	err = service.Notify(ctx, Notification{EntityType: EntityBatch, EntityID: "batch_1", Message: " batch failed "})
+	if err != nil {
+		t.Fatalf("notify: %v", err)
+	}
+	if len(queries.inserted) != 1 || queries.inserted[0].Severity != db.NotificationSeverityInfo || queries.inserted[0].Message != "batch failed" {
+		t.Fatalf("unexpected insert %+v", queries.inserted)
+	}
+
+	select {
+	case msg := <-sub.Channel():
+		var got Notification
+		if err := json.Unmarshal([]byte(msg.Payload), &got); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		if got.Message != "batch failed" || got.EntityID != "batch_1" || got.ReadAt != nil {
+			t.Fatalf("unexpected published notification %+v", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a published notification")
+	}
+}
+
+func TestNotifyRejectsInvalidInput(t *testing.T) {
+	service := NewService(&stubQueries{}, nil)
+	ctx := context.Background()
+	if err := service.Notify(ctx, Notification{Message: "  "}); !errors.Is(err, ErrMessageRequired) {
+		t.Fatalf("expected ErrMessageRequired, got %v", err)
+	}
+	if err := service.Notify(ctx, Notification{Severity: "fatal", Message: "x"}); !errors.Is(err, ErrInvalidSeverity) {
+		t.Fatalf("expected ErrInvalidSeverity, got %v", err)
+	}
+}
+
+func TestListClampsLimit(t *testing.T) {
+	queries := &stubQueries{}
+	service := NewService(queries, nil)
+	if _, err := service.List(context.Background(), Filter{UnreadOnly: true, Limit: 1000, Offset: -5}); err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if queries.listArgs.ListLimit != maxListLimit || queries.listArgs.ListOffset != 0 || !queries.listArgs.UnreadOnly {
+		t.Fatalf("unexpected list args %+v", queries.listArgs)
+	}
+}
+
+func TestMarkReadMissing(t *testing.T) {
+	service := NewService(&stubQueries{}, nil)
+	if _, err := service.MarkRead(context.Background(), uuid.New()); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+// Package responses stores Responses API turns so that requests carrying
+// previous_response_id can continue a conversation.
+package responses
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+)
+
+const idPrefix = "resp_"
+
+var ErrNotFound = errors.New("previous response not found")
+
+type responseQueries interface {
+	GetResponse(context.Context, db.GetResponseParams) (db.Response, error)
+	InsertResponse(context.Context, db.InsertResponseParams) error
+}
+
+// Service persists conversation transcripts keyed by response ID.
+type Service struct {
+	queries responseQueries
+}
+
+func NewService(queries responseQueries) *Service {
+	return &Service{queries: queries}
+}
+
+// NewID returns a fresh response identifier ("resp_" followed by 48 hex
+// characters).
+func NewID() string {
+	buf := make([]byte, 24)
+	_, _ = rand.Read(buf)
+	return idPrefix + hex.EncodeToString(buf)
+}
+
+// Turn is one completed response. Messages hold the whole conversation up to
+// and including the assistant reply, without the request's instructions,
+// which are not carried over to continuations.
+type Turn struct {
+	ID                 string
+	TenantID           uuid.UUID
+	APIKeyID           uuid.UUID
+	Alias              string
+	PreviousResponseID string
+	Messages           []models.ChatMessage
+}
+
+// Save stores the turn so later requests can continue from it.
+func (s *Service) Save(ctx context.Context, turn Turn) error {
+	if s == nil || s.queries == nil {
+		return errors.New("responses service not initialized")
+	}
+	payload, err := json.Marshal(turn.Messages)
+	if err != nil {
+		return err
+	}
+	params := db.InsertResponseParams{
+		ID:         turn.ID,
+		TenantID:   pgtype.UUID{Bytes: turn.TenantID, Valid: true},
+		ModelAlias: turn.Alias,
+		Messages:   payload,
+	}
+	if turn.APIKeyID != uuid.Nil {
+		params.ApiKeyID = pgtype.UUID{Bytes: turn.APIKeyID, Valid: true}
+	}
+	if turn.PreviousResponseID != "" {
+		params.PreviousResponseID = pgtype.Text{String: turn.PreviousResponseID, Valid: true}
+	}
+	return s.queries.InsertResponse(ctx, params)
+}
+
+// History returns the conversation stored for the tenant's response id.
+func (s *Service) History(ctx context.Context, tenantID uuid.UUID, id string) ([]models.ChatMessage, error) {
+	if s == nil || s.queries == nil {
+		return nil, errors.New("responses service not initialized")
+	}
+	id = strings.TrimSpace(id)
+	if !strings.HasPrefix(id, idPrefix) {
+		return nil, ErrNotFound
+	}
+	row, err := s.queries.GetResponse(ctx, db.GetResponseParams{
+		ID:       id,
+		TenantID: pgtype.UUID{Bytes: tenantID, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	var messages []models.ChatMessage
+	if err := json.Unmarshal(row.Messages, &messages); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
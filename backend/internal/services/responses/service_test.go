@@ -0,0 +1,67 @@
+package responses
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+)
+
+type memoryQueries struct {
+	rows map[string]db.Response
+}
+
+func (m *memoryQueries) GetResponse(_ context.Context, arg db.GetResponseParams) (db.Response, error) {
+	row, ok := m.rows[arg.ID]
+	if !ok || row.TenantID != arg.TenantID {
+		return db.Response{}, pgx.ErrNoRows
+	}
+	return row, nil
+}
+
+func (m *memoryQueries) InsertResponse(_ context.Context, arg db.InsertResponseParams) error {
+	m.rows[arg.ID] = db.Response{
+		ID:                 arg.ID,
+		TenantID:           arg.TenantID,
+		ApiKeyID:           arg.ApiKeyID,
+		ModelAlias:         arg.ModelAlias,
+		PreviousResponseID: arg.PreviousResponseID,
+		Messages:           arg.Messages,
+	}
+	return nil
+}
+
+func TestSaveAndHistoryScopedToTenant(t *testing.T) {
+	svc := NewService(&memoryQueries{rows: map[string]db.Response{}})
+	tenantID := uuid.New()
+	id := NewID()
+	if !strings.HasPrefix(id, "resp_") || len(id) != len("resp_")+48 {
+		t.Fatalf("unexpected id %q", id)
+	}
+
+	messages := []models.ChatMessage{{Role: "user", Content: "hi"}, {Role: "assistant", Content: "hello"}}
+	if err := svc.Save(context.Background(), Turn{ID: id, TenantID: tenantID, Alias: "gpt-4o", Messages: messages}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	history, err := svc.History(context.Background(), tenantID, id)
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	if len(history) != 2 || history[1].Content != "hello" {
+		t.Fatalf("unexpected history %+v", history)
+	}
+
+	if _, err := svc.History(context.Background(), uuid.New(), id); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected other tenants to get ErrNotFound, got %v", err)
+	}
+	if _, err := svc.History(context.Background(), tenantID, "chatcmpl-1"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected malformed ids to get ErrNotFound, got %v", err)
+	}
+}
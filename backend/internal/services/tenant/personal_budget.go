@@ -0,0 +1,139 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	decimal "github.com/shopspring/decimal"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+var (
+	ErrNoPersonalTenant       = errors.New("user has no personal tenant")
+	ErrInvalidPersonalBudget  = errors.New("budget_usd must be positive")
+	ErrInvalidBudgetThreshold = errors.New("warning_threshold must be between 0 and 1")
+	ErrPersonalBudgetTooHigh  = errors.New("budget_usd exceeds the personal budget cap")
+	ErrPersonalBudgetTooLow   = errors.New("budget_usd is below the current period's spend")
+)
+
+// PersonalBudgetRequest is the self-service budget update for a user's
+// personal tenant.
+type PersonalBudgetRequest struct {
+	BudgetUSD        float64
+	WarningThreshold float64
+}
+
+// PersonalBudgetStatus pairs a user's personal tenant with its budget.
+type PersonalBudgetStatus struct {
+	TenantID uuid.UUID
+	Budget   BudgetSummary
+}
+
+// PersonalBudget returns the budget summary for the user's personal tenant.
+func (s *Service) PersonalBudget(ctx context.Context, userID uuid.UUID) (PersonalBudgetStatus, error) {
+	if s == nil || s.queries == nil || s.cfg == nil {
+		return PersonalBudgetStatus{}, errors.New("tenant service not initialized")
+	}
+	tenantID, err := s.personalTenantID(ctx, userID)
+	if err != nil {
+		return PersonalBudgetStatus{}, err
+	}
+	return s.personalBudgetStatus(ctx, tenantID)
+}
+
+// SetPersonalBudget stores a budget override on the user's personal tenant.
+// The budget may not exceed budgets.max_personal_budget_usd or fall below
+// what the tenant has already spent this period. Existing schedule and alert
+// settings on the override are kept.
+func (s *Service) SetPersonalBudget(ctx context.Context, userID uuid.UUID, req PersonalBudgetRequest) (PersonalBudgetStatus, error) {
+	if s == nil || s.queries == nil || s.cfg == nil {
+		return PersonalBudgetStatus{}, errors.New("tenant service not initialized")
+	}
+	tenantID, err := s.personalTenantID(ctx, userID)
+	if err != nil {
+		return PersonalBudgetStatus{}, err
+	}
+	current, err := s.buildBudgetSummary(ctx, tenantID)
+	if err != nil {
+		return PersonalBudgetStatus{}, err
+	}
+	if err := validatePersonalBudget(req, s.cfg.Budgets.MaxPersonalBudgetUSD, current.UsedUSD); err != nil {
+		return PersonalBudgetStatus{}, err
+	}
+
+	params := db.UpsertTenantBudgetOverrideParams{
+		TenantID:             toPgUUID(tenantID),
+		BudgetUsd:            decimal.NewFromFloat(req.BudgetUSD).Round(2),
+		WarningThreshold:     decimal.NewFromFloat(req.WarningThreshold),
+		RefreshSchedule:      current.RefreshSchedule,
+		AlertCooldownSeconds: int32(s.cfg.Budgets.Alert.Cooldown / time.Second),
+	}
+	existing, err := s.queries.GetTenantBudgetOverride(ctx, toPgUUID(tenantID))
+	switch {
+	case err == nil:
+		params.AlertEmails = existing.AlertEmails
+		params.AlertWebhooks = existing.AlertWebhooks
+		params.AlertCooldownSeconds = existing.AlertCooldownSeconds
+	case errors.Is(err, pgx.ErrNoRows):
+		if s.cfg.Budgets.Alert.Enabled {
+			params.AlertEmails = s.cfg.Budgets.Alert.Emails
+			params.AlertWebhooks = s.cfg.Budgets.Alert.Webhooks
+		}
+	default:
+		return PersonalBudgetStatus{}, err
+	}
+	if params.AlertCooldownSeconds <= 0 {
+		params.AlertCooldownSeconds = int32(time.Hour / time.Second)
+	}
+	if _, err := s.queries.UpsertTenantBudgetOverride(ctx, params); err != nil {
+		return PersonalBudgetStatus{}, err
+	}
+	return s.personalBudgetStatus(ctx, tenantID)
+}
+
+func (s *Service) personalBudgetStatus(ctx context.Context, tenantID uuid.UUID) (PersonalBudgetStatus, error) {
+	budget, err := s.buildBudgetSummary(ctx, tenantID)
+	if err != nil {
+		return PersonalBudgetStatus{}, err
+	}
+	return PersonalBudgetStatus{TenantID: tenantID, Budget: budget}, nil
+}
+
+func (s *Service) personalTenantID(ctx context.Context, userID uuid.UUID) (uuid.UUID, error) {
+	if userID == uuid.Nil {
+		return uuid.Nil, ErrNoPersonalTenant
+	}
+	user, err := s.queries.GetUserByID(ctx, toPgUUID(userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, ErrNoPersonalTenant
+		}
+		return uuid.Nil, err
+	}
+	tenantID, err := uuidFromPg(user.PersonalTenantID)
+	if err != nil || tenantID == uuid.Nil {
+		return uuid.Nil, ErrNoPersonalTenant
+	}
+	return tenantID, nil
+}
+
+func validatePersonalBudget(req PersonalBudgetRequest, maxUSD, spentUSD float64) error {
+	if req.BudgetUSD <= 0 {
+		return ErrInvalidPersonalBudget
+	}
+	if req.WarningThreshold <= 0 || req.WarningThreshold > 1 {
+		return ErrInvalidBudgetThreshold
+	}
+	if maxUSD > 0 && req.BudgetUSD > maxUSD {
+		return fmt.Errorf("%w of $%.2f", ErrPersonalBudgetTooHigh, maxUSD)
+	}
+	if req.BudgetUSD < spentUSD {
+		return fmt.Errorf("%w ($%.2f)", ErrPersonalBudgetTooLow, spentUSD)
+	}
+	return nil
+}
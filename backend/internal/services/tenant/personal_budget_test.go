@@ -0,0 +1,32 @@
+package tenant
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidatePersonalBudget(t *testing.T) {
+	cases := []struct {
+		name  string
+		req   PersonalBudgetRequest
+		spent float64
+		want  error
+	}{
+		{name: "within cap", req: PersonalBudgetRequest{BudgetUSD: 50, WarningThreshold: 0.8}, spent: 10},
+		{name: "at cap", req: PersonalBudgetRequest{BudgetUSD: 100, WarningThreshold: 1}, spent: 0},
+		{name: "above cap", req: PersonalBudgetRequest{BudgetUSD: 150, WarningThreshold: 0.8}, want: ErrPersonalBudgetTooHigh},
+		{name: "below spend", req: PersonalBudgetRequest{BudgetUSD: 5, WarningThreshold: 0.8}, spent: 7.5, want: ErrPersonalBudgetTooLow},
+		{name: "zero budget", req: PersonalBudgetRequest{WarningThreshold: 0.8}, want: ErrInvalidPersonalBudget},
+		{name: "threshold above one", req: PersonalBudgetRequest{BudgetUSD: 50, WarningThreshold: 1.5}, want: ErrInvalidBudgetThreshold},
+		{name: "missing threshold", req: PersonalBudgetRequest{BudgetUSD: 50}, want: ErrInvalidBudgetThreshold},
+	}
+	for _, tc := range cases {
+		err := validatePersonalBudget(tc.req, 100, tc.spent)
+		if tc.want == nil && err != nil {
+			t.Errorf("%s: unexpected error %v", tc.name, err)
+		}
+		if tc.want != nil && !errors.Is(err, tc.want) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, err)
+		}
+	}
+}
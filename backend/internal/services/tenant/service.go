@@ -12,6 +12,7 @@ import (
 
 	"github.com/ncecere/open_model_gateway/backend/internal/config"
 	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/schemavalidation"
 )
 
 // Service centralizes tenant-related read operations consumed by HTTP handlers.
@@ -132,6 +133,30 @@ func (s *Service) GetTenantSummary(ctx context.Context, user db.User, tenantID u
 	}, nil
 }
 
+// TenantBudget returns the current period's budget and spend for a tenant.
+func (s *Service) TenantBudget(ctx context.Context, tenantID uuid.UUID) (BudgetSummary, error) {
+	if s == nil || s.queries == nil || s.cfg == nil {
+		return BudgetSummary{}, errors.New("tenant service not initialized")
+	}
+	return s.buildBudgetSummary(ctx, tenantID)
+}
+
+// SchemaValidationMode returns how response_format JSON schemas are enforced
+// for the tenant. Tenants without stored settings default to strict.
+func (s *Service) SchemaValidationMode(ctx context.Context, tenantID uuid.UUID) (schemavalidation.Mode, error) {
+	if s == nil || s.queries == nil {
+		return schemavalidation.ModeStrict, errors.New("tenant service not initialized")
+	}
+	settings, err := s.queries.GetTenantSettings(ctx, toPgUUID(tenantID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return schemavalidation.ModeStrict, nil
+		}
+		return schemavalidation.ModeStrict, err
+	}
+	return schemavalidation.ParseMode(settings.SchemaValidationMode)
+}
+
 func (s *Service) buildBudgetSummary(ctx context.Context, tenantID uuid.UUID) (BudgetSummary, error) {
 	limit := s.cfg.Budgets.DefaultUSD
 	warn := s.cfg.Budgets.WarningThresholdPerc
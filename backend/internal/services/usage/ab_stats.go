@@ -0,0 +1,83 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+// ABVariantStats aggregates the requests one traffic-split branch served.
+type ABVariantStats struct {
+	Variant      string  `json:"variant"`
+	Requests     int64   `json:"requests"`
+	Successes    int64   `json:"successes"`
+	SuccessRate  float64 `json:"success_rate"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	CostUSD      float64 `json:"cost_usd"`
+	AvgLatencyMs float64 `json:"avg_latency_ms"`
+}
+
+// ABStats compares the branches of an alias's traffic split over a period.
+type ABStats struct {
+	Alias    string           `json:"alias"`
+	Period   string           `json:"period"`
+	Start    string           `json:"start"`
+	End      string           `json:"end"`
+	Timezone string           `json:"timezone"`
+	Variants []ABVariantStats `json:"variants"`
+}
+
+// SummarizeABVariants groups requests made to alias by the split branch that
+// served them. Requests recorded before a split was configured are excluded.
+func (s *Service) SummarizeABVariants(ctx context.Context, alias, period, timezone string) (ABStats, error) {
+	if s == nil || s.queries == nil {
+		return ABStats{}, errors.New("usage service not initialized")
+	}
+	window, err := s.newWindow(period, timezone)
+	if err != nil {
+		if errors.Is(err, ErrInvalidTimezone) {
+			return ABStats{}, ErrInvalidTimezone
+		}
+		return ABStats{}, ErrInvalidPeriod
+	}
+	start, end := window.Bounds()
+
+	rows, err := s.queries.AggregateRequestsByABVariant(ctx, db.AggregateRequestsByABVariantParams{
+		ModelAlias: alias,
+		Ts:         toPgTime(start),
+		Ts_2:       toPgTime(end),
+	})
+	if err != nil {
+		return ABStats{}, err
+	}
+
+	variants := make([]ABVariantStats, 0, len(rows))
+	for _, row := range rows {
+		stats := ABVariantStats{
+			Variant:      row.AbVariant.String,
+			Requests:     row.Requests,
+			Successes:    row.Successes,
+			InputTokens:  row.InputTokens,
+			OutputTokens: row.OutputTokens,
+			CostUSD:      microsToUSD(row.CostUsdMicros),
+			AvgLatencyMs: row.AvgLatencyMs,
+		}
+		if row.Requests > 0 {
+			stats.SuccessRate = float64(row.Successes) / float64(row.Requests)
+		}
+		variants = append(variants, stats)
+	}
+
+	loc := window.Location()
+	return ABStats{
+		Alias:    alias,
+		Period:   window.Period(),
+		Start:    start.In(loc).Format(time.RFC3339),
+		End:      end.In(loc).Format(time.RFC3339),
+		Timezone: window.Timezone(),
+		Variants: variants,
+	}, nil
+}
@@ -0,0 +1,204 @@
+package usage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+// ChargebackModelLine is one tenant's spend on one model and provider.
+type ChargebackModelLine struct {
+	ModelAlias string  `json:"model_alias"`
+	Provider   string  `json:"provider"`
+	Requests   int64   `json:"requests"`
+	Tokens     int64   `json:"tokens"`
+	CostUSD    float64 `json:"cost_usd"`
+	// Cost restates CostUSD in the report's currency.
+	Cost float64 `json:"cost"`
+}
+
+// TenantChargeback totals one tenant's spend. SnapshotFileID is set when the
+// report was stored to the tenant's files.
+type TenantChargeback struct {
+	TenantID       string                `json:"tenant_id"`
+	TenantName     string                `json:"tenant_name"`
+	CostCenters    []string              `json:"cost_centers"`
+	TotalUSD       float64               `json:"total_usd"`
+	Total          float64               `json:"total"`
+	ByModel        []ChargebackModelLine `json:"by_model"`
+	SnapshotFileID string                `json:"snapshot_file_id,omitempty"`
+}
+
+// CostCenterChargeback rolls up the tenants sharing a cost center.
+type CostCenterChargeback struct {
+	CostCenter  string  `json:"cost_center"`
+	TenantCount int     `json:"tenant_count"`
+	TotalUSD    float64 `json:"total_usd"`
+	Total       float64 `json:"total"`
+}
+
+// ChargebackReport breaks successful request spend down by tenant and model.
+// Costs are recorded in USD; Currency names the currency the Cost and Total
+// fields are restated in (see CurrencyRate.ApplyChargeback).
+type ChargebackReport struct {
+	Period      string                 `json:"period"`
+	Start       string                 `json:"start"`
+	End         string                 `json:"end"`
+	Currency    string                 `json:"currency"`
+	CostCenters []CostCenterChargeback `json:"cost_centers"`
+	Tenants     []TenantChargeback     `json:"tenants"`
+}
+
+// Chargeback builds the chargeback report for period. Tenants with a cost
+// center are listed first, grouped by cost center.
+func (s *Service) Chargeback(ctx context.Context, period string) (ChargebackReport, error) {
+	if s == nil || s.queries == nil {
+		return ChargebackReport{}, errors.New("usage service not initialized")
+	}
+	window, err := s.newWindow(period, "")
+	if err != nil {
+		return ChargebackReport{}, ErrInvalidPeriod
+	}
+	start, end := window.Bounds()
+
+	rows, err := s.queries.ListChargebackUsage(ctx, db.ListChargebackUsageParams{
+		StartTs: toPgTime(start),
+		EndTs:   toPgTime(end),
+	})
+	if err != nil {
+		return ChargebackReport{}, err
+	}
+	tenants, costCenters := buildChargeback(rows)
+
+	loc := window.Location()
+	return ChargebackReport{
+		Period:      window.Period(),
+		Start:       start.In(loc).Format(time.RFC3339),
+		End:         end.In(loc).Format(time.RFC3339),
+		CostCenters: costCenters,
+		Tenants:     tenants,
+	}, nil
+}
+
+// WriteJSONL writes the report header (everything but tenants) on the first
+// line followed by one line per tenant.
+func (r ChargebackReport) WriteJSONL(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(struct {
+		Period      string                 `json:"period"`
+		Start       string                 `json:"start"`
+		End         string                 `json:"end"`
+		Currency    string                 `json:"currency"`
+		CostCenters []CostCenterChargeback `json:"cost_centers"`
+	}{r.Period, r.Start, r.End, r.Currency, r.CostCenters}); err != nil {
+		return err
+	}
+	for _, tenant := range r.Tenants {
+		if err := enc.Encode(tenant); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ForTenant narrows the report to the tenant at index i and its cost center.
+func (r ChargebackReport) ForTenant(i int) ChargebackReport {
+	tenant := r.Tenants[i]
+	out := r
+	out.Tenants = []TenantChargeback{tenant}
+	out.CostCenters = []CostCenterChargeback{}
+	for _, cc := range r.CostCenters {
+		for _, name := range tenant.CostCenters {
+			if cc.CostCenter == name {
+				out.CostCenters = append(out.CostCenters, cc)
+			}
+		}
+	}
+	return out
+}
+
+func buildChargeback(rows []db.ListChargebackUsageRow) ([]TenantChargeback, []CostCenterChargeback) {
+	byTenant := make(map[uuid.UUID]*TenantChargeback)
+	order := make([]uuid.UUID, 0)
+	micros := make(map[uuid.UUID]int64)
+	for _, row := range rows {
+		id, err := uuidFromPg(row.TenantID)
+		if err != nil {
+			continue
+		}
+		tenant, ok := byTenant[id]
+		if !ok {
+			tenant = &TenantChargeback{
+				TenantID:    id.String(),
+				TenantName:  row.TenantName,
+				CostCenters: []string{},
+				ByModel:     []ChargebackModelLine{},
+			}
+			if cc := strings.TrimSpace(row.CostCenter); cc != "" {
+				tenant.CostCenters = append(tenant.CostCenters, cc)
+			}
+			byTenant[id] = tenant
+			order = append(order, id)
+		}
+		tenant.ByModel = append(tenant.ByModel, ChargebackModelLine{
+			ModelAlias: row.ModelAlias,
+			Provider:   row.Provider,
+			Requests:   row.Requests,
+			Tokens:     row.Tokens,
+			CostUSD:    microsToUSD(row.CostUsdMicros),
+		})
+		micros[id] += row.CostUsdMicros
+	}
+
+	tenants := make([]TenantChargeback, 0, len(order))
+	centerMicros := make(map[string]int64)
+	centerTenants := make(map[string]int)
+	for _, id := range order {
+		tenant := *byTenant[id]
+		tenant.TotalUSD = microsToUSD(micros[id])
+		sort.SliceStable(tenant.ByModel, func(i, j int) bool {
+			return tenant.ByModel[i].CostUSD > tenant.ByModel[j].CostUSD
+		})
+		for _, cc := range tenant.CostCenters {
+			centerMicros[cc] += micros[id]
+			centerTenants[cc]++
+		}
+		tenants = append(tenants, tenant)
+	}
+	sort.SliceStable(tenants, func(i, j int) bool {
+		ci, cj := costCenterOf(tenants[i]), costCenterOf(tenants[j])
+		if ci != cj {
+			if ci == "" || cj == "" {
+				return cj == ""
+			}
+			return ci < cj
+		}
+		return tenants[i].TotalUSD > tenants[j].TotalUSD
+	})
+
+	centers := make([]CostCenterChargeback, 0, len(centerMicros))
+	for name, total := range centerMicros {
+		centers = append(centers, CostCenterChargeback{
+			CostCenter:  name,
+			TenantCount: centerTenants[name],
+			TotalUSD:    microsToUSD(total),
+		})
+	}
+	sort.Slice(centers, func(i, j int) bool { return centers[i].CostCenter < centers[j].CostCenter })
+	return tenants, centers
+}
+
+func costCenterOf(tenant TenantChargeback) string {
+	if len(tenant.CostCenters) == 0 {
+		return ""
+	}
+	return tenant.CostCenters[0]
+}
@@ -0,0 +1,69 @@
+package usage
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+func TestBuildChargebackGroupsByCostCenter(t *testing.T) {
+	acme, globex, initech := uuid.New(), uuid.New(), uuid.New()
+	rows := []db.ListChargebackUsageRow{
+		{TenantID: toPgUUID(initech), TenantName: "initech", ModelAlias: "gpt-4o", Provider: "openai", Requests: 9, Tokens: 900, CostUsdMicros: 9_000_000},
+		{TenantID: toPgUUID(acme), TenantName: "acme", CostCenter: "CC-200", ModelAlias: "embed", Provider: "openai", Requests: 4, Tokens: 40, CostUsdMicros: 250_000},
+		{TenantID: toPgUUID(acme), TenantName: "acme", CostCenter: "CC-200", ModelAlias: "gpt-4o", Provider: "azure", Requests: 2, Tokens: 200, CostUsdMicros: 1_500_000},
+		{TenantID: toPgUUID(globex), TenantName: "globex", CostCenter: "CC-100", ModelAlias: "gpt-4o", Provider: "openai", Requests: 1, Tokens: 10, CostUsdMicros: 100_000},
+	}
+
+	tenants, centers := buildChargeback(rows)
+	if len(tenants) != 3 {
+		t.Fatalf("expected 3 tenants, got %+v", tenants)
+	}
+	if tenants[0].TenantName != "globex" || tenants[1].TenantName != "acme" || tenants[2].TenantName != "initech" {
+		t.Fatalf("expected cost-center tenants first in cost-center order, got %s, %s, %s", tenants[0].TenantName, tenants[1].TenantName, tenants[2].TenantName)
+	}
+	acmeReport := tenants[1]
+	if acmeReport.TotalUSD != 1.75 || len(acmeReport.ByModel) != 2 || acmeReport.ByModel[0].ModelAlias != "gpt-4o" {
+		t.Fatalf("unexpected acme totals %+v", acmeReport)
+	}
+	if len(tenants[2].CostCenters) != 0 {
+		t.Fatalf("expected no cost center for initech, got %v", tenants[2].CostCenters)
+	}
+	if len(centers) != 2 || centers[0].CostCenter != "CC-100" || centers[1].TotalUSD != 1.75 || centers[1].TenantCount != 1 {
+		t.Fatalf("unexpected cost centers %+v", centers)
+	}
+}
+
+func TestChargebackReportWriteJSONL(t *testing.T) {
+	tenants, centers := buildChargeback([]db.ListChargebackUsageRow{
+		{TenantID: toPgUUID(uuid.New()), TenantName: "acme", CostCenter: "CC-1", ModelAlias: "gpt-4o", Provider: "openai", Requests: 1, CostUsdMicros: 1_000_000},
+		{TenantID: toPgUUID(uuid.New()), TenantName: "globex", ModelAlias: "gpt-4o", Provider: "openai", Requests: 1, CostUsdMicros: 2_000_000},
+	})
+	report := ChargebackReport{Period: "30d", Currency: "USD", CostCenters: centers, Tenants: tenants}
+
+	var buf bytes.Buffer
+	if err := report.WriteJSONL(&buf); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header plus 2 tenant lines, got %d", len(lines))
+	}
+	var header map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &header); err != nil {
+		t.Fatalf("decode header: %v", err)
+	}
+	if _, ok := header["tenants"]; ok || header["currency"] != "USD" {
+		t.Fatalf("unexpected header %v", header)
+	}
+
+	single := report.ForTenant(1)
+	if len(single.Tenants) != 1 || single.Tenants[0].TenantName != "globex" || len(single.CostCenters) != 0 {
+		t.Fatalf("unexpected single-tenant report %+v", single)
+	}
+}
@@ -0,0 +1,220 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	decimal "github.com/shopspring/decimal"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/currency"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+var (
+	ErrCurrencyRateMissing = errors.New("no exchange rate configured for currency")
+	ErrInvalidCurrencyRate = errors.New("rate must be > 0 and currencies must differ")
+)
+
+// CurrencyRate converts USD costs into Currency. Rate is units of Currency
+// per US dollar.
+type CurrencyRate struct {
+	Currency string
+	Rate     float64
+}
+
+// usdRate leaves costs in USD.
+var usdRate = CurrencyRate{Currency: currency.Base, Rate: 1}
+
+// CurrencyRateRecord is a stored exchange rate.
+type CurrencyRateRecord struct {
+	FromCurrency  string  `json:"from_currency"`
+	ToCurrency    string  `json:"to_currency"`
+	Rate          float64 `json:"rate"`
+	EffectiveDate string  `json:"effective_date"`
+}
+
+// ResolveCurrency returns the latest USD rate for code effective on asOf.
+// A rate stored in the opposite direction (code to USD) is inverted.
+func (s *Service) ResolveCurrency(ctx context.Context, code string, asOf time.Time) (CurrencyRate, error) {
+	code, err := currency.Normalize(code)
+	if err != nil {
+		return CurrencyRate{}, err
+	}
+	if code == currency.Base {
+		return usdRate, nil
+	}
+	if s == nil || s.queries == nil {
+		return CurrencyRate{}, errors.New("usage service not initialized")
+	}
+	row, err := s.queries.GetCurrencyRate(ctx, db.GetCurrencyRateParams{
+		FromCurrency: currency.Base,
+		ToCurrency:   code,
+		AsOf:         pgtype.Date{Time: asOf, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return CurrencyRate{}, fmt.Errorf("%w: %s", ErrCurrencyRateMissing, code)
+		}
+		return CurrencyRate{}, err
+	}
+	return rateFromRow(row, code), nil
+}
+
+// UpsertCurrencyRate stores the rate from one currency to another effective
+// from the given day.
+func (s *Service) UpsertCurrencyRate(ctx context.Context, from, to string, rate float64, effective time.Time) (CurrencyRateRecord, error) {
+	if s == nil || s.queries == nil {
+		return CurrencyRateRecord{}, errors.New("usage service not initialized")
+	}
+	from, err := currency.Normalize(from)
+	if err != nil {
+		return CurrencyRateRecord{}, err
+	}
+	to, err = currency.Normalize(to)
+	if err != nil {
+		return CurrencyRateRecord{}, err
+	}
+	if rate <= 0 || from == to {
+		return CurrencyRateRecord{}, ErrInvalidCurrencyRate
+	}
+	row, err := s.queries.UpsertCurrencyRate(ctx, db.UpsertCurrencyRateParams{
+		FromCurrency:  from,
+		ToCurrency:    to,
+		EffectiveDate: pgtype.Date{Time: effective, Valid: true},
+		Rate:          decimal.NewFromFloat(rate),
+	})
+	if err != nil {
+		return CurrencyRateRecord{}, err
+	}
+	return toCurrencyRateRecord(row), nil
+}
+
+// ListCurrencyRates returns every stored rate, newest first per pair.
+func (s *Service) ListCurrencyRates(ctx context.Context) ([]CurrencyRateRecord, error) {
+	if s == nil || s.queries == nil {
+		return nil, errors.New("usage service not initialized")
+	}
+	rows, err := s.queries.ListCurrencyRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]CurrencyRateRecord, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, toCurrencyRateRecord(row))
+	}
+	return out, nil
+}
+
+func toCurrencyRateRecord(row db.CurrencyRate) CurrencyRateRecord {
+	return CurrencyRateRecord{
+		FromCurrency:  row.FromCurrency,
+		ToCurrency:    row.ToCurrency,
+		Rate:          row.Rate.InexactFloat64(),
+		EffectiveDate: row.EffectiveDate.Time.Format(time.DateOnly),
+	}
+}
+
+func rateFromRow(row db.CurrencyRate, code string) CurrencyRate {
+	rate := row.Rate.InexactFloat64()
+	if row.FromCurrency != currency.Base {
+		rate = 1 / rate
+	}
+	return CurrencyRate{Currency: code, Rate: rate}
+}
+
+// microsToCurrency is the converted counterpart of microsToUSD.
+func (r CurrencyRate) microsToCurrency(micros int64) float64 {
+	return r.fromUSD(microsToUSD(micros))
+}
+
+func (r CurrencyRate) fromUSD(usd float64) float64 {
+	if r.Rate == 0 {
+		return usd
+	}
+	return usd * r.Rate
+}
+
+func (r CurrencyRate) applyTotals(t *UsageTotals) {
+	t.Currency = r.Currency
+	t.Cost = r.fromUSD(t.CostUSD)
+}
+
+func (r CurrencyRate) applyPoints(points []UsagePoint) {
+	for i := range points {
+		points[i].Currency = r.Currency
+		points[i].Cost = r.fromUSD(points[i].CostUSD)
+	}
+}
+
+// ApplySummary fills the currency and converted cost fields of summary.
+func (r CurrencyRate) ApplySummary(summary *AdminUsageSummary) {
+	summary.Currency = r.Currency
+	summary.TotalCost = r.fromUSD(summary.TotalCostUSD)
+	r.applyPoints(summary.Points)
+}
+
+// ApplyBreakdown fills the currency and converted cost fields of breakdown.
+func (r CurrencyRate) ApplyBreakdown(breakdown *AdminBreakdown) {
+	breakdown.Currency = r.Currency
+	for i := range breakdown.Items {
+		breakdown.Items[i].Currency = r.Currency
+		breakdown.Items[i].Cost = r.fromUSD(breakdown.Items[i].CostUSD)
+	}
+	r.applyPoints(breakdown.Series.Points)
+	if breakdown.Totals != nil {
+		r.applyTotals(breakdown.Totals)
+	}
+}
+
+// ApplyCompare fills the currency and converted cost fields of every series.
+func (r CurrencyRate) ApplyCompare(usage *MultiEntityUsage) {
+	usage.Currency = r.Currency
+	for i := range usage.Series {
+		r.applyTotals(&usage.Series[i].Totals)
+		r.applyPoints(usage.Series[i].Points)
+	}
+}
+
+// ApplyChargeback fills the currency and converted cost fields of report.
+func (r CurrencyRate) ApplyChargeback(report *ChargebackReport) {
+	report.Currency = r.Currency
+	for i := range report.CostCenters {
+		report.CostCenters[i].Total = r.fromUSD(report.CostCenters[i].TotalUSD)
+	}
+	for i := range report.Tenants {
+		tenant := &report.Tenants[i]
+		tenant.Total = r.fromUSD(tenant.TotalUSD)
+		for j := range tenant.ByModel {
+			tenant.ByModel[j].Cost = r.fromUSD(tenant.ByModel[j].CostUSD)
+		}
+	}
+}
+
+// ApplyUserSummary fills the currency and converted cost fields of a user's
+// usage summary.
+func (r CurrencyRate) ApplyUserSummary(summary *UserSummary) {
+	r.applyTotals(&summary.Totals)
+	r.applyPoints(summary.PersonalSeries)
+	if summary.Personal != nil {
+		r.applyTenantUsage(summary.Personal)
+	}
+	for i := range summary.Memberships {
+		r.applyTenantUsage(&summary.Memberships[i])
+	}
+	for i := range summary.Scopes {
+		r.applyTotals(&summary.Scopes[i].Totals)
+	}
+	if summary.SelectedScope != nil {
+		r.applyTotals(&summary.SelectedScope.Scope.Totals)
+		r.applyPoints(summary.SelectedScope.Series)
+	}
+}
+
+func (r CurrencyRate) applyTenantUsage(usage *UserTenantUsage) {
+	usage.Currency = r.Currency
+	usage.Cost = r.fromUSD(usage.CostUSD)
+}
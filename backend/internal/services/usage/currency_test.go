@@ -0,0 +1,92 @@
+package usage
+
+import (
+	"math"
+	"testing"
+
+	decimal "github.com/shopspring/decimal"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+func TestCurrencyRoundtrip(t *testing.T) {
+	for _, row := range []db.CurrencyRate{
+		{FromCurrency: "USD", ToCurrency: "EUR", Rate: decimal.RequireFromString("0.9213")},
+		{FromCurrency: "GBP", ToCurrency: "USD", Rate: decimal.RequireFromString("1.2674")},
+	} {
+		code := row.ToCurrency
+		if code == "USD" {
+			code = row.FromCurrency
+		}
+		rate := rateFromRow(row, code)
+		converted := rate.microsToCurrency(12_345_678)
+		back := converted / rate.Rate
+		if math.Abs(back-microsToUSD(12_345_678)) > 1e-9 {
+			t.Fatalf("%s roundtrip drifted: %f -> %f -> %f", code, microsToUSD(12_345_678), converted, back)
+		}
+	}
+
+	gbp := rateFromRow(db.CurrencyRate{FromCurrency: "GBP", ToCurrency: "USD", Rate: decimal.NewFromInt(2)}, "GBP")
+	if gbp.microsToCurrency(3_000_000) != 1.5 {
+		t.Fatalf("expected inverted GBP rate to halve USD costs, got %f", gbp.microsToCurrency(3_000_000))
+	}
+}
+
+func TestCurrencyRateApplyBreakdown(t *testing.T) {
+	eur := CurrencyRate{Currency: "EUR", Rate: 0.5}
+	breakdown := AdminBreakdown{
+		Items:  []AdminBreakdownItem{{ID: "a", CostUSD: 4}},
+		Series: AdminBreakdownSeries{Points: []UsagePoint{{Date: "2025-01-01", CostUSD: 2}}},
+		Totals: &UsageTotals{CostUSD: 6},
+	}
+	eur.ApplyBreakdown(&breakdown)
+	if breakdown.Currency != "EUR" || breakdown.Items[0].Cost != 2 || breakdown.Items[0].Currency != "EUR" {
+		t.Fatalf("unexpected items %+v", breakdown.Items)
+	}
+	if breakdown.Series.Points[0].Cost != 1 || breakdown.Totals.Cost != 3 || breakdown.Items[0].CostUSD != 4 {
+		t.Fatalf("unexpected series/totals %+v %+v", breakdown.Series.Points, breakdown.Totals)
+	}
+}
+
+func TestCurrencyRateApplyChargeback(t *testing.T) {
+	eur := CurrencyRate{Currency: "EUR", Rate: 0.5}
+	report := ChargebackReport{
+		CostCenters: []CostCenterChargeback{{CostCenter: "CC-100", TotalUSD: 8}},
+		Tenants: []TenantChargeback{{
+			TotalUSD: 8,
+			ByModel:  []ChargebackModelLine{{ModelAlias: "gpt-4o", CostUSD: 6}, {ModelAlias: "embed", CostUSD: 2}},
+		}},
+	}
+	eur.ApplyChargeback(&report)
+	if report.Currency != "EUR" || report.CostCenters[0].Total != 4 || report.Tenants[0].Total != 4 {
+		t.Fatalf("unexpected totals %+v", report)
+	}
+	if report.Tenants[0].ByModel[0].Cost != 3 || report.Tenants[0].ByModel[1].Cost != 1 || report.Tenants[0].TotalUSD != 8 {
+		t.Fatalf("unexpected model lines %+v", report.Tenants[0].ByModel)
+	}
+}
+
+func TestCurrencyRateApplyUserSummary(t *testing.T) {
+	eur := CurrencyRate{Currency: "EUR", Rate: 0.5}
+	summary := UserSummary{
+		Totals:         UsageTotals{CostUSD: 10},
+		Personal:       &UserTenantUsage{CostUSD: 4},
+		PersonalSeries: []UsagePoint{{Date: "2025-01-01", CostUSD: 4}},
+		Memberships:    []UserTenantUsage{{CostUSD: 6}},
+		Scopes:         []UsageScope{{ID: "personal", Totals: UsageTotals{CostUSD: 4}}},
+		SelectedScope: &UserScopeDetail{
+			Scope:  UsageScope{Totals: UsageTotals{CostUSD: 4}},
+			Series: []UsagePoint{{Date: "2025-01-01", CostUSD: 4}},
+		},
+	}
+	eur.ApplyUserSummary(&summary)
+	if summary.Totals.Currency != "EUR" || summary.Totals.Cost != 5 || summary.Personal.Cost != 2 || summary.Memberships[0].Cost != 3 {
+		t.Fatalf("unexpected totals %+v", summary)
+	}
+	if summary.PersonalSeries[0].Cost != 2 || summary.Scopes[0].Totals.Cost != 2 {
+		t.Fatalf("unexpected personal series or scopes %+v %+v", summary.PersonalSeries, summary.Scopes)
+	}
+	if summary.SelectedScope.Scope.Totals.Cost != 2 || summary.SelectedScope.Series[0].Cost != 2 {
+		t.Fatalf("unexpected selected scope %+v", summary.SelectedScope)
+	}
+}
@@ -30,8 +30,43 @@ var (
 
 // Service exposes usage aggregation helpers shared across admin and user surfaces.
 type Service struct {
-	queries  *db.Queries
-	timezone *time.Location
+	queries      *db.Queries
+	timezone     *time.Location
+	budgetLookup BudgetLookup
+}
+
+// BudgetLookup loads a tenant's budget status for the current budget period.
+type BudgetLookup func(ctx context.Context, tenantID uuid.UUID) (BudgetStatus, error)
+
+// BudgetStatus reports a tenant's spend against its budget for the current
+// budget period.
+type BudgetStatus struct {
+	BudgetUSD        float64 `json:"budget_usd"`
+	UsedUSD          float64 `json:"used_usd"`
+	RemainingUSD     float64 `json:"remaining_usd"`
+	WarningThreshold float64 `json:"warning_threshold"`
+	RefreshSchedule  string  `json:"refresh_schedule"`
+	Warning          bool    `json:"warning"`
+	Exceeded         bool    `json:"exceeded"`
+}
+
+// NewBudgetStatus derives the remaining spend and warning/exceeded flags from
+// a budget and the period's spend.
+func NewBudgetStatus(budgetUSD, usedUSD, warningThreshold float64, schedule string) BudgetStatus {
+	remaining := budgetUSD - usedUSD
+	if remaining < 0 {
+		remaining = 0
+	}
+	exceeded := budgetUSD > 0 && usedUSD >= budgetUSD
+	return BudgetStatus{
+		BudgetUSD:        budgetUSD,
+		UsedUSD:          usedUSD,
+		RemainingUSD:     remaining,
+		WarningThreshold: warningThreshold,
+		RefreshSchedule:  schedule,
+		Warning:          !exceeded && budgetUSD > 0 && usedUSD >= budgetUSD*warningThreshold,
+		Exceeded:         exceeded,
+	}
 }
 
 // ModelPerformanceStats captures recent performance data for a model alias.
@@ -45,8 +80,11 @@ type ModelPerformanceStats struct {
 	LatencySampleCount     int64   `json:"latency_sample_count"`
 }
 
+// MaxUsageCompareSeries caps how many series one usage comparison returns.
+// The admin model comparison fans out to at most as many models.
+const MaxUsageCompareSeries = 10
+
 const (
-	maxUsageCompareSeries  = 10
 	maxCustomCompareDays   = 180
 	maxCustomCompareWindow = time.Duration(maxCustomCompareDays) * 24 * time.Hour
 )
@@ -55,6 +93,12 @@ func NewService(queries *db.Queries, timezone *time.Location) *Service {
 	return &Service{queries: queries, timezone: timezone}
 }
 
+// SetBudgetLookup registers how SummarizeUserUsage loads the personal
+// tenant's budget status.
+func (s *Service) SetBudgetLookup(lookup BudgetLookup) {
+	s.budgetLookup = lookup
+}
+
 func (s *Service) location() *time.Location {
 	if s == nil || s.timezone == nil {
 		return time.UTC
@@ -83,6 +127,7 @@ type UserSummary struct {
 	Timezone       string              `json:"timezone"`
 	Totals         UsageTotals         `json:"totals"`
 	Personal       *UserTenantUsage    `json:"personal,omitempty"`
+	PersonalBudget *BudgetStatus       `json:"personal_budget,omitempty"`
 	PersonalSeries []UsagePoint        `json:"personal_series,omitempty"`
 	Memberships    []UserTenantUsage   `json:"memberships"`
 	PersonalKeys   []APIKeyUsageDigest `json:"personal_api_keys,omitempty"`
@@ -97,6 +142,25 @@ type UsageTotals struct {
 	Tokens    int64   `json:"tokens"`
 	CostCents int64   `json:"cost_cents"`
 	CostUSD   float64 `json:"cost_usd"`
+	// CachedTokens counts the prompt tokens, already included in Tokens,
+	// that providers served from their context caches.
+	CachedTokens int64 `json:"cached_tokens"`
+	// PromptTokens and CompletionTokens split Tokens into input and output,
+	// which are priced separately.
+	PromptTokens     int64 `json:"prompt_tokens"`
+	CompletionTokens int64 `json:"completion_tokens"`
+	// PromptCostUSD and CompletionCostUSD split CostUSD the same way. Only
+	// per-model usage reports them; usage recorded before the split was
+	// stored counts toward neither.
+	PromptCostUSD     float64 `json:"prompt_cost_usd,omitempty"`
+	CompletionCostUSD float64 `json:"completion_cost_usd,omitempty"`
+	// Currency and Cost restate CostUSD in the currency the caller asked for.
+	// Usage endpoints that take a currency parameter set them; elsewhere they
+	// are omitted.
+	Currency string  `json:"currency,omitempty"`
+	Cost     float64 `json:"cost,omitempty"`
+	// TopTags lists the highest-spend request tags when the caller asks for them.
+	TopTags []TagUsage `json:"top_tags,omitempty"`
 }
 
 // UserTenantUsage represents usage for a tenant the user belongs to.
@@ -109,6 +173,8 @@ type UserTenantUsage struct {
 	Tokens     int64   `json:"tokens"`
 	CostCents  int64   `json:"cost_cents"`
 	CostUSD    float64 `json:"cost_usd"`
+	Currency   string  `json:"currency,omitempty"`
+	Cost       float64 `json:"cost,omitempty"`
 	IsPersonal bool    `json:"is_personal"`
 }
 
@@ -141,13 +207,20 @@ type scopeEntry struct {
 	tenant uuid.UUID
 }
 
-// UsagePoint is a daily time-series datapoint.
+// UsagePoint is a daily time-series datapoint. The prompt and completion
+// split is filled in for per-model series only.
 type UsagePoint struct {
-	Date      string  `json:"date"`
-	Requests  int64   `json:"requests"`
-	Tokens    int64   `json:"tokens"`
-	CostCents int64   `json:"cost_cents"`
-	CostUSD   float64 `json:"cost_usd"`
+	Date              string  `json:"date"`
+	Requests          int64   `json:"requests"`
+	Tokens            int64   `json:"tokens"`
+	CostCents         int64   `json:"cost_cents"`
+	CostUSD           float64 `json:"cost_usd"`
+	PromptTokens      int64   `json:"prompt_tokens,omitempty"`
+	CompletionTokens  int64   `json:"completion_tokens,omitempty"`
+	PromptCostUSD     float64 `json:"prompt_cost_usd,omitempty"`
+	CompletionCostUSD float64 `json:"completion_cost_usd,omitempty"`
+	Currency          string  `json:"currency,omitempty"`
+	Cost              float64 `json:"cost,omitempty"`
 }
 
 type UsageCompareSeriesKind string
@@ -180,6 +253,7 @@ type MultiEntityUsage struct {
 	Start    string               `json:"start"`
 	End      string               `json:"end"`
 	Timezone string               `json:"timezone"`
+	Currency string               `json:"currency,omitempty"`
 	Series   []UsageCompareSeries `json:"series"`
 }
 
@@ -245,12 +319,16 @@ type ModelDailyUsageResponse struct {
 }
 
 type ModelDailyUsageDay struct {
-	Date      string                  `json:"date"`
-	Requests  int64                   `json:"requests"`
-	Tokens    int64                   `json:"tokens"`
-	CostCents int64                   `json:"cost_cents"`
-	CostUSD   float64                 `json:"cost_usd"`
-	Tenants   []ModelDailyTenantUsage `json:"tenants"`
+	Date              string                  `json:"date"`
+	Requests          int64                   `json:"requests"`
+	Tokens            int64                   `json:"tokens"`
+	CostCents         int64                   `json:"cost_cents"`
+	CostUSD           float64                 `json:"cost_usd"`
+	PromptTokens      int64                   `json:"prompt_tokens"`
+	CompletionTokens  int64                   `json:"completion_tokens"`
+	PromptCostUSD     float64                 `json:"prompt_cost_usd"`
+	CompletionCostUSD float64                 `json:"completion_cost_usd"`
+	Tenants           []ModelDailyTenantUsage `json:"tenants"`
 }
 
 type ModelDailyTenantUsage struct {
@@ -271,20 +349,41 @@ type CompareUsageParams struct {
 	ModelAliases []string
 	UserIDs      []uuid.UUID
 	TenantScope  []uuid.UUID
+	// TopN adds the N tenants with the highest spend in the window to
+	// TenantIDs, skipping any already listed.
+	TopN int
+}
+
+// TenantUsageSummary is a tenant's usage totals over a window.
+type TenantUsageSummary struct {
+	TenantID   uuid.UUID `json:"tenant_id"`
+	TenantName string    `json:"tenant_name"`
+	Requests   int64     `json:"requests"`
+	Tokens     int64     `json:"tokens"`
+	CostCents  int64     `json:"cost_cents"`
+	CostUSD    float64   `json:"cost_usd"`
 }
 
 // AdminUsageSummary mirrors the admin usage summary payload.
 type AdminUsageSummary struct {
-	Period         string       `json:"period"`
-	Start          string       `json:"start"`
-	End            string       `json:"end"`
-	Timezone       string       `json:"timezone"`
-	TotalRequests  int64        `json:"total_requests"`
-	TotalTokens    int64        `json:"total_tokens"`
-	TotalCostCents int64        `json:"total_cost_cents"`
-	TotalCostUSD   float64      `json:"total_cost_usd"`
-	Points         []UsagePoint `json:"points"`
-	TenantID       *string      `json:"tenant_id,omitempty"`
+	Period                string            `json:"period"`
+	Start                 string            `json:"start"`
+	End                   string            `json:"end"`
+	Timezone              string            `json:"timezone"`
+	TotalRequests         int64             `json:"total_requests"`
+	TotalTokens           int64             `json:"total_tokens"`
+	TotalCostCents        int64             `json:"total_cost_cents"`
+	TotalCostUSD          float64           `json:"total_cost_usd"`
+	TotalCachedTokens     int64             `json:"total_cached_tokens"`
+	TotalPromptTokens     int64             `json:"total_prompt_tokens"`
+	TotalCompletionTokens int64             `json:"total_completion_tokens"`
+	Currency              string            `json:"currency,omitempty"`
+	TotalCost             float64           `json:"total_cost,omitempty"`
+	Points                []UsagePoint      `json:"points"`
+	TenantID              *string           `json:"tenant_id,omitempty"`
+	TagsFilter            map[string]string `json:"tags_filter,omitempty"`
+	MetadataFilter        map[string]any    `json:"metadata_filter,omitempty"`
+	TopTags               []TagUsage        `json:"top_tags,omitempty"`
 }
 
 // AdminBreakdownParams configures the admin usage breakdown query.
@@ -296,16 +395,24 @@ type AdminBreakdownParams struct {
 	Timezone      string
 	StartOverride *time.Time
 	EndOverride   *time.Time
+	Filter        AdminUsageFilter
 }
 
-// AdminBreakdownItem represents an item row in the breakdown response.
+// AdminBreakdownItem represents an item row in the breakdown response. The
+// prompt and completion split is filled in when grouping by model.
 type AdminBreakdownItem struct {
-	ID        string  `json:"id"`
-	Label     string  `json:"label"`
-	Requests  int64   `json:"requests"`
-	Tokens    int64   `json:"tokens"`
-	CostCents int64   `json:"cost_cents"`
-	CostUSD   float64 `json:"cost_usd"`
+	ID                string  `json:"id"`
+	Label             string  `json:"label"`
+	Requests          int64   `json:"requests"`
+	Tokens            int64   `json:"tokens"`
+	CostCents         int64   `json:"cost_cents"`
+	CostUSD           float64 `json:"cost_usd"`
+	PromptTokens      int64   `json:"prompt_tokens,omitempty"`
+	CompletionTokens  int64   `json:"completion_tokens,omitempty"`
+	PromptCostUSD     float64 `json:"prompt_cost_usd,omitempty"`
+	CompletionCostUSD float64 `json:"completion_cost_usd,omitempty"`
+	Currency          string  `json:"currency,omitempty"`
+	Cost              float64 `json:"cost,omitempty"`
 }
 
 // AdminBreakdownSeries captures the time-series for the selected entity.
@@ -323,8 +430,12 @@ type AdminBreakdown struct {
 	Start    string               `json:"start"`
 	End      string               `json:"end"`
 	Timezone string               `json:"timezone"`
+	Currency string               `json:"currency,omitempty"`
 	Items    []AdminBreakdownItem `json:"items"`
 	Series   AdminBreakdownSeries `json:"series"`
+	// Totals covers every request matching the tag filter; set only when
+	// the breakdown was filtered by tags or top tags were requested.
+	Totals *UsageTotals `json:"totals,omitempty"`
 }
 
 // APIKeyUsageSummary aggregates usage for a single API key over a time window.
@@ -343,6 +454,11 @@ func (t *UsageTotals) addTotals(other UsageTotals) {
 	t.Tokens += other.Tokens
 	t.CostCents += other.CostCents
 	t.CostUSD += other.CostUSD
+	t.CachedTokens += other.CachedTokens
+	t.PromptTokens += other.PromptTokens
+	t.CompletionTokens += other.CompletionTokens
+	t.PromptCostUSD += other.PromptCostUSD
+	t.CompletionCostUSD += other.CompletionCostUSD
 }
 
 // SummarizeUserUsage returns usage aggregates for the provided user and period (e.g., "7d", "30d") or a custom range when start/end overrides are supplied.
@@ -427,6 +543,13 @@ func (s *Service) SummarizeUserUsage(ctx context.Context, user db.User, period s
 			IsPersonal: true,
 		}
 		summary.Personal = &personalUsage
+		if s.budgetLookup != nil {
+			budget, err := s.budgetLookup(ctx, personalTenant)
+			if err != nil {
+				return UserSummary{}, err
+			}
+			summary.PersonalBudget = &budget
+		}
 		scope := UsageScope{
 			ID:     "personal",
 			Kind:   UsageScopePersonal,
@@ -575,10 +698,13 @@ func (s *Service) SummarizeAPIKeyUsage(ctx context.Context, key db.ApiKey, perio
 		End:      window.EndString(),
 		Timezone: zone,
 		Totals: UsageTotals{
-			Requests:  sum.TotalRequests,
-			Tokens:    sum.TotalTokens,
-			CostCents: sum.TotalCostCents,
-			CostUSD:   microsToUSD(sum.TotalCostUsdMicros),
+			Requests:         sum.TotalRequests,
+			Tokens:           sum.TotalTokens,
+			CostCents:        sum.TotalCostCents,
+			CostUSD:          microsToUSD(sum.TotalCostUsdMicros),
+			CachedTokens:     sum.TotalCachedTokens,
+			PromptTokens:     sum.TotalInputTokens,
+			CompletionTokens: sum.TotalOutputTokens,
 		},
 		Series: series,
 	}, nil
@@ -609,10 +735,13 @@ func (s *Service) buildTenantCompareSeries(ctx context.Context, tenantIDs []uuid
 	for _, row := range totalRows {
 		id := pgUUIDString(row.TenantID)
 		totalMap[id] = UsageTotals{
-			Requests:  row.TotalRequests,
-			Tokens:    row.TotalTokens,
-			CostCents: row.TotalCostCents,
-			CostUSD:   microsToUSD(row.TotalCostUsdMicros),
+			Requests:         row.TotalRequests,
+			Tokens:           row.TotalTokens,
+			CostCents:        row.TotalCostCents,
+			CostUSD:          microsToUSD(row.TotalCostUsdMicros),
+			CachedTokens:     row.TotalCachedTokens,
+			PromptTokens:     row.TotalInputTokens,
+			CompletionTokens: row.TotalOutputTokens,
 		}
 	}
 	dailyRows, err := s.queries.AggregateUsageDailyByTenants(ctx, db.AggregateUsageDailyByTenantsParams{
@@ -704,10 +833,15 @@ func (s *Service) buildModelCompareSeries(ctx context.Context, aliases []string,
 	totalMap := make(map[string]UsageTotals, len(totalRows))
 	for _, row := range totalRows {
 		totalMap[row.ModelAlias] = UsageTotals{
-			Requests:  row.TotalRequests,
-			Tokens:    row.TotalTokens,
-			CostCents: row.TotalCostCents,
-			CostUSD:   microsToUSD(row.TotalCostUsdMicros),
+			Requests:          row.TotalRequests,
+			Tokens:            row.TotalTokens,
+			CostCents:         row.TotalCostCents,
+			CostUSD:           microsToUSD(row.TotalCostUsdMicros),
+			CachedTokens:      row.TotalCachedTokens,
+			PromptTokens:      row.TotalInputTokens,
+			CompletionTokens:  row.TotalOutputTokens,
+			PromptCostUSD:     microsToUSD(row.TotalInputCostUsdMicros),
+			CompletionCostUSD: microsToUSD(row.TotalOutputCostUsdMicros),
 		}
 	}
 	dailyRows, err := s.queries.AggregateUsageDailyByModels(ctx, db.AggregateUsageDailyByModelsParams{
@@ -720,26 +854,7 @@ func (s *Service) buildModelCompareSeries(ctx context.Context, aliases []string,
 	if err != nil {
 		return nil, err
 	}
-	daily := make(map[string]map[int64]dailyAggregate, len(aliases))
-	for _, row := range dailyRows {
-		alias := row.ModelAlias
-		dayTime, err := timeFromPg(row.Day)
-		if err != nil {
-			continue
-		}
-		day := timeutil.TruncateToDay(dayTime, loc)
-		bucket := daily[alias]
-		if bucket == nil {
-			bucket = make(map[int64]dailyAggregate)
-			daily[alias] = bucket
-		}
-		bucket[day.Unix()] = dailyAggregate{
-			Requests:      row.Requests,
-			Tokens:        row.Tokens,
-			CostCents:     row.CostCents,
-			CostUsdMicros: row.CostUsdMicros,
-		}
-	}
+	daily := groupModelDailyAggregates(dailyRows, loc)
 	series := make([]UsageCompareSeries, 0, len(aliases))
 	for _, alias := range aliases {
 		label := alias
@@ -836,10 +951,13 @@ func (s *Service) buildUserCompareSeries(ctx context.Context, userIDs []uuid.UUI
 	for _, row := range totalRows {
 		id := pgUUIDString(row.UserID)
 		totalMap[id] = UsageTotals{
-			Requests:  row.TotalRequests,
-			Tokens:    row.TotalTokens,
-			CostCents: row.TotalCostCents,
-			CostUSD:   microsToUSD(row.TotalCostUsdMicros),
+			Requests:         row.TotalRequests,
+			Tokens:           row.TotalTokens,
+			CostCents:        row.TotalCostCents,
+			CostUSD:          microsToUSD(row.TotalCostUsdMicros),
+			CachedTokens:     row.TotalCachedTokens,
+			PromptTokens:     row.TotalInputTokens,
+			CompletionTokens: row.TotalOutputTokens,
 		}
 	}
 	dailyRows, err := s.queries.AggregateUsageDailyByUsers(ctx, db.AggregateUsageDailyByUsersParams{
@@ -897,10 +1015,10 @@ func (s *Service) CompareUsage(ctx context.Context, params CompareUsageParams) (
 	aliases := dedupStrings(params.ModelAliases)
 	users := dedupUUIDs(params.UserIDs)
 	totalRequested := len(tenants) + len(aliases) + len(users)
-	if totalRequested == 0 {
+	if totalRequested == 0 && params.TopN <= 0 {
 		return MultiEntityUsage{}, ErrNoEntitiesSelected
 	}
-	if totalRequested > maxUsageCompareSeries {
+	if totalRequested+max(params.TopN, 0) > MaxUsageCompareSeries {
 		return MultiEntityUsage{}, ErrEntityLimitExceeded
 	}
 	var (
@@ -948,6 +1066,14 @@ func (s *Service) CompareUsage(ctx context.Context, params CompareUsageParams) (
 		start, end = window.Bounds()
 		periodLabel = window.Period()
 	}
+	if params.TopN > 0 {
+		top, err := s.TopTenantsByUsage(ctx, start, end, params.TopN)
+		if err != nil {
+			return MultiEntityUsage{}, err
+		}
+		tenants = mergeTopTenants(tenants, top)
+		totalRequested = len(tenants) + len(aliases) + len(users)
+	}
 	scopeIDs := dedupUUIDs(params.TenantScope)
 	scopeParam := toPgUUIDArray(scopeIDs)
 	result := MultiEntityUsage{
@@ -981,6 +1107,51 @@ func (s *Service) CompareUsage(ctx context.Context, params CompareUsageParams) (
 	return result, nil
 }
 
+// TopTenantsByUsage returns up to limit tenants with the highest spend
+// between start and end, biggest first.
+func (s *Service) TopTenantsByUsage(ctx context.Context, start, end time.Time, limit int) ([]TenantUsageSummary, error) {
+	if s == nil || s.queries == nil {
+		return nil, errors.New("usage service not initialized")
+	}
+	if limit <= 0 {
+		return nil, nil
+	}
+	rows, err := s.queries.AggregateUsageByTenant(ctx, db.AggregateUsageByTenantParams{
+		Ts:    toPgTime(start),
+		Ts_2:  toPgTime(end),
+		Limit: int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]TenantUsageSummary, 0, len(rows))
+	for _, row := range rows {
+		tenantID, err := uuidFromPg(row.TenantID)
+		if err != nil {
+			continue
+		}
+		out = append(out, TenantUsageSummary{
+			TenantID:   tenantID,
+			TenantName: row.Name,
+			Requests:   row.Requests,
+			Tokens:     row.Tokens,
+			CostCents:  row.CostCents,
+			CostUSD:    microsToUSD(row.CostUsdMicros),
+		})
+	}
+	return out, nil
+}
+
+// mergeTopTenants appends the top spenders to the explicitly requested
+// tenants, keeping the explicit order and dropping duplicates.
+func mergeTopTenants(explicit []uuid.UUID, top []TenantUsageSummary) []uuid.UUID {
+	merged := append([]uuid.UUID(nil), explicit...)
+	for _, summary := range top {
+		merged = append(merged, summary.TenantID)
+	}
+	return dedupUUIDs(merged)
+}
+
 // TenantDailyUsage aggregates per-day totals and per-key breakdowns for a tenant between start and end.
 func (s *Service) TenantDailyUsage(ctx context.Context, tenantID uuid.UUID, start, end time.Time, timezone string) (TenantDailyUsageResponse, error) {
 	if s == nil || s.queries == nil {
@@ -1316,12 +1487,16 @@ func (s *Service) ModelDailyUsage(ctx context.Context, alias string, start, end
 			aggregate = sumModelTenantUsage(tenants)
 		}
 		days = append(days, ModelDailyUsageDay{
-			Date:      day.Format(time.RFC3339),
-			Requests:  aggregate.Requests,
-			Tokens:    aggregate.Tokens,
-			CostCents: aggregate.CostCents,
-			CostUSD:   microsToUSD(aggregate.CostUsdMicros),
-			Tenants:   tenants,
+			Date:              day.Format(time.RFC3339),
+			Requests:          aggregate.Requests,
+			Tokens:            aggregate.Tokens,
+			CostCents:         aggregate.CostCents,
+			CostUSD:           microsToUSD(aggregate.CostUsdMicros),
+			PromptTokens:      aggregate.PromptTokens,
+			CompletionTokens:  aggregate.CompletionTokens,
+			PromptCostUSD:     microsToUSD(aggregate.PromptCostUsdMicros),
+			CompletionCostUSD: microsToUSD(aggregate.CompletionCostUsdMicros),
+			Tenants:           tenants,
 		})
 	}
 
@@ -1335,7 +1510,8 @@ func (s *Service) ModelDailyUsage(ctx context.Context, alias string, start, end
 }
 
 // SummarizeAdminUsage aggregates system-wide or tenant-scoped usage for admin dashboards.
-func (s *Service) SummarizeAdminUsage(ctx context.Context, period string, tenantID *uuid.UUID, timezone string, startOverride, endOverride *time.Time) (AdminUsageSummary, error) {
+// A tag filter switches the aggregation to the tagged request log.
+func (s *Service) SummarizeAdminUsage(ctx context.Context, period string, tenantID *uuid.UUID, timezone string, startOverride, endOverride *time.Time, filter AdminUsageFilter) (AdminUsageSummary, error) {
 	if s == nil || s.queries == nil {
 		return AdminUsageSummary{}, errors.New("usage service not initialized")
 	}
@@ -1390,6 +1566,35 @@ func (s *Service) SummarizeAdminUsage(ctx context.Context, period string, tenant
 		tenantRef = &idCopy
 	}
 
+	if filter.active() {
+		totals, err := s.taggedTotals(ctx, tenantParam, start, end, filter)
+		if err != nil {
+			return AdminUsageSummary{}, err
+		}
+		dailyRows, err := s.taggedDailyRows(ctx, tenantParam, "", start, end, zone, filter)
+		if err != nil {
+			return AdminUsageSummary{}, err
+		}
+		return AdminUsageSummary{
+			Period:                periodLabel,
+			Start:                 start.In(loc).Format(time.RFC3339),
+			End:                   end.In(loc).Format(time.RFC3339),
+			Timezone:              zone,
+			TotalRequests:         totals.Requests,
+			TotalTokens:           totals.Tokens,
+			TotalCostCents:        totals.CostCents,
+			TotalCostUSD:          totals.CostUSD,
+			TotalCachedTokens:     totals.CachedTokens,
+			TotalPromptTokens:     totals.PromptTokens,
+			TotalCompletionTokens: totals.CompletionTokens,
+			Points:                buildAggregateUsagePoints(start, end, dailyRows, loc),
+			TenantID:              tenantRef,
+			TagsFilter:            filter.Tags,
+			MetadataFilter:        filter.Metadata,
+			TopTags:               totals.TopTags,
+		}, nil
+	}
+
 	sum, err := s.queries.SumUsage(ctx, db.SumUsageParams{
 		Column1: tenantParam,
 		Ts:      toPgTime(start),
@@ -1409,18 +1614,27 @@ func (s *Service) SummarizeAdminUsage(ctx context.Context, period string, tenant
 		return AdminUsageSummary{}, err
 	}
 
+	topTags, err := s.topTags(ctx, tenantParam, start, end, filter)
+	if err != nil {
+		return AdminUsageSummary{}, err
+	}
+
 	points := buildAggregateUsagePoints(start, end, dailyRows, loc)
 	return AdminUsageSummary{
-		Period:         periodLabel,
-		Start:          start.In(loc).Format(time.RFC3339),
-		End:            end.In(loc).Format(time.RFC3339),
-		Timezone:       zone,
-		TotalRequests:  sum.TotalRequests,
-		TotalTokens:    sum.TotalTokens,
-		TotalCostCents: sum.TotalCostCents,
-		TotalCostUSD:   microsToUSD(sum.TotalCostUsdMicros),
-		Points:         points,
-		TenantID:       tenantRef,
+		Period:                periodLabel,
+		Start:                 start.In(loc).Format(time.RFC3339),
+		End:                   end.In(loc).Format(time.RFC3339),
+		Timezone:              zone,
+		TotalRequests:         sum.TotalRequests,
+		TotalTokens:           sum.TotalTokens,
+		TotalCostCents:        sum.TotalCostCents,
+		TotalCostUSD:          microsToUSD(sum.TotalCostUsdMicros),
+		TotalCachedTokens:     sum.TotalCachedTokens,
+		TotalPromptTokens:     sum.TotalInputTokens,
+		TotalCompletionTokens: sum.TotalOutputTokens,
+		Points:                points,
+		TenantID:              tenantRef,
+		TopTags:               topTags,
 	}, nil
 }
 
@@ -1494,6 +1708,20 @@ func (s *Service) BreakdownAdminUsage(ctx context.Context, params AdminBreakdown
 
 	selected := strings.TrimSpace(params.EntityID)
 
+	if params.Filter.active() || params.Filter.TopTags > 0 {
+		totals, err := s.taggedTotals(ctx, pgtype.UUID{}, start, end, params.Filter)
+		if err != nil {
+			return AdminBreakdown{}, err
+		}
+		result.Totals = &totals
+	}
+	if params.Filter.active() {
+		if err := s.breakdownTagged(ctx, &result, group, selected, limit, start, end, loc, params.Filter); err != nil {
+			return AdminBreakdown{}, err
+		}
+		return result, nil
+	}
+
 	switch group {
 	case "tenant":
 		rows, err := s.queries.AggregateUsageByTenant(ctx, db.AggregateUsageByTenantParams{
@@ -1561,12 +1789,16 @@ func (s *Service) BreakdownAdminUsage(ctx context.Context, params AdminBreakdown
 			}
 			labelMap[label] = label
 			result.Items = append(result.Items, AdminBreakdownItem{
-				ID:        label,
-				Label:     label,
-				Requests:  row.Requests,
-				Tokens:    row.Tokens,
-				CostCents: row.CostCents,
-				CostUSD:   microsToUSD(row.CostUsdMicros),
+				ID:                label,
+				Label:             label,
+				Requests:          row.Requests,
+				Tokens:            row.Tokens,
+				CostCents:         row.CostCents,
+				CostUSD:           microsToUSD(row.CostUsdMicros),
+				PromptTokens:      row.InputTokens,
+				CompletionTokens:  row.OutputTokens,
+				PromptCostUSD:     microsToUSD(row.InputCostUsdMicros),
+				CompletionCostUSD: microsToUSD(row.OutputCostUsdMicros),
 			})
 		}
 		if selected == "" && len(result.Items) > 0 {
@@ -1670,10 +1902,13 @@ func (s *Service) sumUsageForUserTenant(ctx context.Context, user db.User, tenan
 		return UsageTotals{}, err
 	}
 	return UsageTotals{
-		Requests:  sum.TotalRequests,
-		Tokens:    sum.TotalTokens,
-		CostCents: sum.TotalCostCents,
-		CostUSD:   microsToUSD(sum.TotalCostUsdMicros),
+		Requests:         sum.TotalRequests,
+		Tokens:           sum.TotalTokens,
+		CostCents:        sum.TotalCostCents,
+		CostUSD:          microsToUSD(sum.TotalCostUsdMicros),
+		CachedTokens:     sum.TotalCachedTokens,
+		PromptTokens:     sum.TotalInputTokens,
+		CompletionTokens: sum.TotalOutputTokens,
 	}, nil
 }
 
@@ -1832,21 +2067,17 @@ func buildModelUsagePoints(start, end time.Time, rows []db.AggregateModelUsageDa
 	}
 	points := make([]UsagePoint, 0, int(endDay.Sub(startDay).Hours()/24)+1)
 	for day := startDay; !day.After(endDay); day = day.AddDate(0, 0, 1) {
-		key := day.Unix()
-		var requests, tokens, cost int64
-		var costUSD float64
-		if row, ok := daily[key]; ok {
-			requests = row.Requests
-			tokens = row.Tokens
-			cost = row.CostCents
-			costUSD = microsToUSD(row.CostUsdMicros)
-		}
+		row := daily[day.Unix()]
 		points = append(points, UsagePoint{
-			Date:      day.Format(time.RFC3339),
-			Requests:  requests,
-			Tokens:    tokens,
-			CostCents: cost,
-			CostUSD:   costUSD,
+			Date:              day.Format(time.RFC3339),
+			Requests:          row.Requests,
+			Tokens:            row.Tokens,
+			CostCents:         row.CostCents,
+			CostUSD:           microsToUSD(row.CostUsdMicros),
+			PromptTokens:      row.InputTokens,
+			CompletionTokens:  row.OutputTokens,
+			PromptCostUSD:     microsToUSD(row.InputCostUsdMicros),
+			CompletionCostUSD: microsToUSD(row.OutputCostUsdMicros),
 		})
 	}
 	return points
@@ -1888,10 +2119,14 @@ func buildAPIKeyUsagePoints(start, end time.Time, rows []db.AggregateAPIKeyUsage
 }
 
 type dailyAggregate struct {
-	Requests      int64
-	Tokens        int64
-	CostCents     int64
-	CostUsdMicros int64
+	Requests                int64
+	Tokens                  int64
+	CostCents               int64
+	CostUsdMicros           int64
+	PromptTokens            int64
+	CompletionTokens        int64
+	PromptCostUsdMicros     int64
+	CompletionCostUsdMicros int64
 }
 
 func buildUsagePointsFromDailyMap(start, end time.Time, loc *time.Location, daily map[int64]dailyAggregate) []UsagePoint {
@@ -1912,11 +2147,15 @@ func buildUsagePointsFromDailyMap(start, end time.Time, loc *time.Location, dail
 			record = row
 		}
 		points = append(points, UsagePoint{
-			Date:      day.Format(time.RFC3339),
-			Requests:  record.Requests,
-			Tokens:    record.Tokens,
-			CostCents: record.CostCents,
-			CostUSD:   microsToUSD(record.CostUsdMicros),
+			Date:              day.Format(time.RFC3339),
+			Requests:          record.Requests,
+			Tokens:            record.Tokens,
+			CostCents:         record.CostCents,
+			CostUSD:           microsToUSD(record.CostUsdMicros),
+			PromptTokens:      record.PromptTokens,
+			CompletionTokens:  record.CompletionTokens,
+			PromptCostUSD:     microsToUSD(record.PromptCostUsdMicros),
+			CompletionCostUSD: microsToUSD(record.CompletionCostUsdMicros),
 		})
 	}
 	return points
@@ -1994,10 +2233,14 @@ func groupModelDailyAggregates(rows []db.AggregateUsageDailyByModelsRow, loc *ti
 			data[alias] = bucket
 		}
 		bucket[day.Unix()] = dailyAggregate{
-			Requests:      row.Requests,
-			Tokens:        row.Tokens,
-			CostCents:     row.CostCents,
-			CostUsdMicros: row.CostUsdMicros,
+			Requests:                row.Requests,
+			Tokens:                  row.Tokens,
+			CostCents:               row.CostCents,
+			CostUsdMicros:           row.CostUsdMicros,
+			PromptTokens:            row.InputTokens,
+			CompletionTokens:        row.OutputTokens,
+			PromptCostUsdMicros:     row.InputCostUsdMicros,
+			CompletionCostUsdMicros: row.OutputCostUsdMicros,
 		}
 	}
 	return data
@@ -2069,6 +2312,10 @@ func (s *Service) summarizeKeysForTenant(ctx context.Context, user db.User, tena
 			}
 		}
 		digests = append(digests, digest)
+		// Zero-retention keys keep no per-request detail worth listing.
+		if key.ZeroRetention {
+			continue
+		}
 		keyIDs = append(keyIDs, key.ID)
 		nameMap[pgUUIDString(key.ID)] = key.Name
 	}
@@ -6,6 +6,9 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
 )
 
 func TestBuildUsagePointsFromDailyMap_FillsMissingDaysAndFormatsTimezone(t *testing.T) {
@@ -107,3 +110,79 @@ func TestDedupStrings(t *testing.T) {
 		}
 	}
 }
+
+func TestUsageTotalsAddCarriesCachedTokens(t *testing.T) {
+	totals := UsageTotals{Requests: 1, Tokens: 1000, CachedTokens: 600}
+	totals.addTotals(UsageTotals{Requests: 2, Tokens: 500, CachedTokens: 100})
+	if totals.Requests != 3 || totals.Tokens != 1500 || totals.CachedTokens != 700 {
+		t.Fatalf("unexpected totals: %+v", totals)
+	}
+}
+
+func TestUsageTotalsAddCarriesTokenSplit(t *testing.T) {
+	totals := UsageTotals{Tokens: 1000, PromptTokens: 900, CompletionTokens: 100}
+	totals.addTotals(UsageTotals{Tokens: 500, PromptTokens: 50, CompletionTokens: 450})
+	if totals.PromptTokens != 950 || totals.CompletionTokens != 550 {
+		t.Fatalf("unexpected token split: %+v", totals)
+	}
+	if totals.PromptTokens+totals.CompletionTokens != totals.Tokens {
+		t.Fatalf("split does not add up to tokens: %+v", totals)
+	}
+}
+
+func TestModelDailyPointsCarryPromptAndCompletionSplit(t *testing.T) {
+	day := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	rows := []db.AggregateUsageDailyByModelsRow{{
+		ModelAlias:          "gpt-4o",
+		Day:                 pgtype.Timestamptz{Time: day, Valid: true},
+		Requests:            3,
+		Tokens:              1200,
+		CostCents:           1,
+		CostUsdMicros:       7_500,
+		InputTokens:         1000,
+		OutputTokens:        200,
+		InputCostUsdMicros:  2_500,
+		OutputCostUsdMicros: 5_000,
+	}}
+	daily := groupModelDailyAggregates(rows, time.UTC)
+	points := buildUsagePointsFromDailyMap(day, day.Add(24*time.Hour), time.UTC, daily["gpt-4o"])
+	if len(points) != 1 {
+		t.Fatalf("expected 1 point, got %d", len(points))
+	}
+	point := points[0]
+	if point.PromptTokens != 1000 || point.CompletionTokens != 200 ||
+		point.PromptCostUSD != 0.0025 || point.CompletionCostUSD != 0.005 {
+		t.Fatalf("unexpected split %+v", point)
+	}
+
+	totals := UsageTotals{PromptCostUSD: 0.0025, CompletionCostUSD: 0.005}
+	totals.addTotals(UsageTotals{PromptCostUSD: 0.001, CompletionCostUSD: 0.002})
+	if totals.PromptCostUSD != 0.0035 || totals.CompletionCostUSD != 0.007 {
+		t.Fatalf("unexpected cost split totals %+v", totals)
+	}
+}
+
+func TestMergeTopTenantsKeepsExplicitOrderAndDedups(t *testing.T) {
+	explicit := uuid.New()
+	top1 := uuid.New()
+	top2 := uuid.New()
+	merged := mergeTopTenants([]uuid.UUID{explicit}, []TenantUsageSummary{
+		{TenantID: top1},
+		{TenantID: explicit},
+		{TenantID: top2},
+	})
+	if len(merged) != 3 || merged[0] != explicit || merged[1] != top1 || merged[2] != top2 {
+		t.Fatalf("unexpected merge %v", merged)
+	}
+}
+
+func TestNewBudgetStatus(t *testing.T) {
+	status := NewBudgetStatus(10, 8.5, 0.8, "calendar_month")
+	if !status.Warning || status.Exceeded || status.RemainingUSD != 1.5 {
+		t.Fatalf("expected warning with $1.50 remaining, got %+v", status)
+	}
+	status = NewBudgetStatus(10, 12, 0.8, "calendar_month")
+	if status.Warning || !status.Exceeded || status.RemainingUSD != 0 {
+		t.Fatalf("expected exceeded with nothing remaining, got %+v", status)
+	}
+}
@@ -0,0 +1,66 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+const (
+	// MaxSuspiciousPeriod bounds how far back a fingerprint scan may look.
+	MaxSuspiciousPeriod = 7 * 24 * time.Hour
+	maxSuspiciousRows   = 500
+)
+
+var ErrInvalidSuspiciousQuery = errors.New("threshold must be positive and period between 1m and 168h")
+
+// SuspiciousPattern is an API key that repeated one request fingerprint more
+// than the threshold within the period.
+type SuspiciousPattern struct {
+	APIKeyID     uuid.UUID `json:"api_key_id"`
+	APIKeyPrefix string    `json:"api_key_prefix"`
+	APIKeyName   string    `json:"api_key_name"`
+	TenantID     uuid.UUID `json:"tenant_id"`
+	Fingerprint  string    `json:"fingerprint"`
+	Requests     int64     `json:"requests"`
+	FirstSeen    time.Time `json:"first_seen"`
+	LastSeen     time.Time `json:"last_seen"`
+}
+
+// SuspiciousPatterns lists keys that sent more than threshold structurally
+// identical requests since now-period, busiest first. Such bursts usually
+// mean a client stuck in a retry or agent loop.
+func (s *Service) SuspiciousPatterns(ctx context.Context, threshold int64, period time.Duration, now time.Time) ([]SuspiciousPattern, error) {
+	if s == nil || s.queries == nil {
+		return nil, errors.New("usage service not initialized")
+	}
+	if threshold <= 0 || period < time.Minute || period > MaxSuspiciousPeriod {
+		return nil, ErrInvalidSuspiciousQuery
+	}
+	rows, err := s.queries.ListSuspiciousRequestPatterns(ctx, db.ListSuspiciousRequestPatternsParams{
+		Since:     toPgTime(now.Add(-period)),
+		Threshold: threshold,
+		RowLimit:  maxSuspiciousRows,
+	})
+	if err != nil {
+		return nil, err
+	}
+	patterns := make([]SuspiciousPattern, 0, len(rows))
+	for _, row := range rows {
+		patterns = append(patterns, SuspiciousPattern{
+			APIKeyID:     uuid.UUID(row.ApiKeyID.Bytes),
+			APIKeyPrefix: row.Prefix,
+			APIKeyName:   row.Name,
+			TenantID:     uuid.UUID(row.TenantID.Bytes),
+			Fingerprint:  row.Fingerprint,
+			Requests:     row.Requests,
+			FirstSeen:    row.FirstSeen.Time.UTC(),
+			LastSeen:     row.LastSeen.Time.UTC(),
+		})
+	}
+	return patterns, nil
+}
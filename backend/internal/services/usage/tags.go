@@ -0,0 +1,241 @@
+package usage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+// ErrTagFilterUnsupported is returned when a tag or metadata filter is
+// combined with a breakdown that cannot be computed from request logs.
+var ErrTagFilterUnsupported = errors.New("tags_filter is not supported for this group")
+
+// AdminUsageFilter narrows admin usage queries to requests carrying every
+// tag in Tags and every key/value pair in Metadata, and optionally asks for
+// the TopTags highest-spend tag values.
+type AdminUsageFilter struct {
+	Tags     map[string]string
+	Metadata map[string]any
+	TopTags  int
+}
+
+func (f AdminUsageFilter) active() bool {
+	return len(f.Tags) > 0 || len(f.Metadata) > 0
+}
+
+func (f AdminUsageFilter) tagsJSON() []byte {
+	if len(f.Tags) == 0 {
+		return []byte("{}")
+	}
+	data, err := json.Marshal(f.Tags)
+	if err != nil {
+		return []byte("{}")
+	}
+	return data
+}
+
+// metadataJSON is the jsonb containment operand for Metadata; "{}" matches
+// every request.
+func (f AdminUsageFilter) metadataJSON() []byte {
+	if len(f.Metadata) == 0 {
+		return []byte("{}")
+	}
+	data, err := json.Marshal(f.Metadata)
+	if err != nil {
+		return []byte("{}")
+	}
+	return data
+}
+
+// TagUsage aggregates spend for one tag key/value pair.
+type TagUsage struct {
+	Key       string  `json:"key"`
+	Value     string  `json:"value"`
+	Requests  int64   `json:"requests"`
+	CostCents int64   `json:"cost_cents"`
+	CostUSD   float64 `json:"cost_usd"`
+}
+
+// taggedTotals sums the request log rows matching filter, attaching the top
+// tag values when filter.TopTags is set.
+func (s *Service) taggedTotals(ctx context.Context, tenantParam pgtype.UUID, start, end time.Time, filter AdminUsageFilter) (UsageTotals, error) {
+	sum, err := s.queries.SumTaggedRequests(ctx, db.SumTaggedRequestsParams{
+		Column1: tenantParam,
+		Ts:      toPgTime(start),
+		Ts_2:    toPgTime(end),
+		Column4: filter.tagsJSON(),
+		Column5: filter.metadataJSON(),
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return UsageTotals{}, err
+	}
+	totals := UsageTotals{
+		Requests:         sum.TotalRequests,
+		Tokens:           sum.TotalTokens,
+		CostCents:        sum.TotalCostCents,
+		CostUSD:          microsToUSD(sum.TotalCostUsdMicros),
+		CachedTokens:     sum.TotalCachedTokens,
+		PromptTokens:     sum.TotalInputTokens,
+		CompletionTokens: sum.TotalOutputTokens,
+	}
+	totals.TopTags, err = s.topTags(ctx, tenantParam, start, end, filter)
+	if err != nil {
+		return UsageTotals{}, err
+	}
+	return totals, nil
+}
+
+func (s *Service) topTags(ctx context.Context, tenantParam pgtype.UUID, start, end time.Time, filter AdminUsageFilter) ([]TagUsage, error) {
+	if filter.TopTags <= 0 {
+		return nil, nil
+	}
+	rows, err := s.queries.ListTopRequestTags(ctx, db.ListTopRequestTagsParams{
+		Column1: tenantParam,
+		Ts:      toPgTime(start),
+		Ts_2:    toPgTime(end),
+		Column4: filter.tagsJSON(),
+		Limit:   int32(filter.TopTags),
+		Column6: filter.metadataJSON(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]TagUsage, 0, len(rows))
+	for _, row := range rows {
+		tags = append(tags, TagUsage{
+			Key:       row.TagKey,
+			Value:     row.TagValue,
+			Requests:  row.Requests,
+			CostCents: row.CostCents,
+			CostUSD:   microsToUSD(row.CostUsdMicros),
+		})
+	}
+	return tags, nil
+}
+
+// taggedDailyRows returns per-day totals for tagged requests in the shape of
+// AggregateUsageDaily so the usual point builder can fill gaps.
+func (s *Service) taggedDailyRows(ctx context.Context, tenantParam pgtype.UUID, modelAlias string, start, end time.Time, zone string, filter AdminUsageFilter) ([]db.AggregateUsageDailyRow, error) {
+	rows, err := s.queries.AggregateTaggedRequestsDaily(ctx, db.AggregateTaggedRequestsDailyParams{
+		Column1: tenantParam,
+		Column2: strings.TrimSpace(modelAlias),
+		Ts:      toPgTime(start),
+		Ts_2:    toPgTime(end),
+		Column5: zone,
+		Column6: filter.tagsJSON(),
+		Column7: filter.metadataJSON(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	daily := make([]db.AggregateUsageDailyRow, 0, len(rows))
+	for _, row := range rows {
+		daily = append(daily, db.AggregateUsageDailyRow(row))
+	}
+	return daily, nil
+}
+
+// breakdownTagged fills result with the tenant or model breakdown of the
+// requests matching filter.
+func (s *Service) breakdownTagged(ctx context.Context, result *AdminBreakdown, group, selected string, limit int, start, end time.Time, loc *time.Location, filter AdminUsageFilter) error {
+	labelMap := make(map[string]string)
+	switch group {
+	case "tenant":
+		rows, err := s.queries.AggregateTaggedRequestsByTenant(ctx, db.AggregateTaggedRequestsByTenantParams{
+			Ts:      toPgTime(start),
+			Ts_2:    toPgTime(end),
+			Column3: filter.tagsJSON(),
+			Limit:   int32(limit),
+			Column5: filter.metadataJSON(),
+		})
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			tenantID, err := uuidFromPg(row.TenantID)
+			if err != nil {
+				continue
+			}
+			id := tenantID.String()
+			labelMap[id] = row.Name
+			result.Items = append(result.Items, AdminBreakdownItem{
+				ID:        id,
+				Label:     row.Name,
+				Requests:  row.Requests,
+				Tokens:    row.Tokens,
+				CostCents: row.CostCents,
+				CostUSD:   microsToUSD(row.CostUsdMicros),
+			})
+		}
+	case "model":
+		rows, err := s.queries.AggregateTaggedRequestsByModel(ctx, db.AggregateTaggedRequestsByModelParams{
+			Ts:      toPgTime(start),
+			Ts_2:    toPgTime(end),
+			Column3: filter.tagsJSON(),
+			Limit:   int32(limit),
+			Column5: filter.metadataJSON(),
+		})
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			label := strings.TrimSpace(row.ModelAlias)
+			if label == "" {
+				label = "unknown"
+			}
+			labelMap[label] = label
+			result.Items = append(result.Items, AdminBreakdownItem{
+				ID:        label,
+				Label:     label,
+				Requests:  row.Requests,
+				Tokens:    row.Tokens,
+				CostCents: row.CostCents,
+				CostUSD:   microsToUSD(row.CostUsdMicros),
+			})
+		}
+	case "user":
+		return ErrTagFilterUnsupported
+	default:
+		return ErrInvalidBreakdownType
+	}
+
+	if selected == "" && len(result.Items) > 0 {
+		selected = result.Items[0].ID
+	}
+	if selected == "" {
+		return nil
+	}
+	var (
+		tenantParam pgtype.UUID
+		modelAlias  string
+	)
+	if group == "tenant" {
+		tenantUUID, err := uuid.Parse(selected)
+		if err != nil {
+			return nil
+		}
+		tenantParam = toPgUUID(tenantUUID)
+	} else {
+		modelAlias = selected
+	}
+	dailyRows, err := s.taggedDailyRows(ctx, tenantParam, modelAlias, start, end, result.Timezone, filter)
+	if err != nil {
+		return err
+	}
+	label := labelMap[selected]
+	if label == "" {
+		label = selected
+	}
+	result.Series.ID = selected
+	result.Series.Label = label
+	result.Series.Points = buildAggregateUsagePoints(start, end, dailyRows, loc)
+	return nil
+}
@@ -0,0 +1,21 @@
+package usage
+
+import "testing"
+
+func TestAdminUsageFilterMatchesMetadata(t *testing.T) {
+	filter := AdminUsageFilter{Metadata: map[string]any{"project": "llm-ops"}}
+	if !filter.active() {
+		t.Fatal("a metadata filter should route breakdowns through the request log")
+	}
+	if got := string(filter.metadataJSON()); got != `{"project":"llm-ops"}` {
+		t.Fatalf("unexpected metadata operand %s", got)
+	}
+	if got := string(filter.tagsJSON()); got != "{}" {
+		t.Fatalf("expected tags operand to match everything, got %s", got)
+	}
+
+	var empty AdminUsageFilter
+	if empty.active() || string(empty.metadataJSON()) != "{}" {
+		t.Fatal("an empty filter should match every request")
+	}
+}
@@ -0,0 +1,321 @@
+// Package usagearchive exports request log rows that have passed retention
+// to blob storage before deleting them.
+package usagearchive
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/storage/blob"
+)
+
+const (
+	archivedTable   = "requests"
+	archivePageSize = 1000
+)
+
+var (
+	ErrArchiveNotFound    = errors.New("archive not found")
+	ErrServiceUnavailable = errors.New("usage archive unavailable")
+)
+
+type archiveQueries interface {
+	DeleteRequestsBetween(context.Context, db.DeleteRequestsBetweenParams) (int64, error)
+	GetArchivedPartitionByName(context.Context, string) (db.ArchivedPartition, error)
+	GetOldestRequestBefore(context.Context, pgtype.Timestamptz) (pgtype.Timestamptz, error)
+	InsertArchivedPartition(context.Context, db.InsertArchivedPartitionParams) (db.ArchivedPartition, error)
+	ListArchivedPartitions(context.Context) ([]db.ArchivedPartition, error)
+	ListRequestsForArchive(context.Context, db.ListRequestsForArchiveParams) ([]db.Request, error)
+}
+
+// Service archives expired request rows as gzipped NDJSON, one object per
+// month or day, and serves the archives back to administrators.
+type Service struct {
+	queries     archiveQueries
+	store       blob.Store
+	partitionBy string
+	retention   time.Duration
+}
+
+// NewService builds an archive service. metadataDays <= 0 disables archival
+// because nothing ever passes retention.
+func NewService(queries archiveQueries, store blob.Store, cfg config.ArchiveConfig, metadataDays int) *Service {
+	var retention time.Duration
+	if metadataDays > 0 {
+		retention = time.Duration(metadataDays) * 24 * time.Hour
+	}
+	partitionBy := cfg.PartitionBy
+	if partitionBy != "day" {
+		partitionBy = "month"
+	}
+	return &Service{
+		queries:     queries,
+		store:       store,
+		partitionBy: partitionBy,
+		retention:   retention,
+	}
+}
+
+// Result summarises one archival run.
+type Result struct {
+	Archived    []string
+	RowsDeleted int64
+}
+
+// PeriodBounds returns the UTC month or day containing t.
+func PeriodBounds(t time.Time, partitionBy string) (time.Time, time.Time) {
+	t = t.UTC()
+	if partitionBy == "day" {
+		start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 0, 1)
+	}
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// ArchiveName is the file name for the period starting at start, e.g.
+// requests-2025-09.ndjson.gz or requests-2025-09-14.ndjson.gz.
+func ArchiveName(start time.Time, partitionBy string) string {
+	layout := "2006-01"
+	if partitionBy == "day" {
+		layout = "2006-01-02"
+	}
+	return fmt.Sprintf("%s-%s.ndjson.gz", archivedTable, start.UTC().Format(layout))
+}
+
+// ArchiveExpired archives every whole period older than the retention window
+// and deletes its rows. Periods already recorded in archived_partitions are
+// not uploaded again; any rows left behind by an interrupted run are deleted.
+func (s *Service) ArchiveExpired(ctx context.Context, now time.Time) (Result, error) {
+	var result Result
+	if s == nil || s.queries == nil || s.store == nil {
+		return result, ErrServiceUnavailable
+	}
+	if s.retention <= 0 {
+		return result, nil
+	}
+	threshold := pgtype.Timestamptz{Time: now.UTC().Add(-s.retention), Valid: true}
+	for {
+		// Each pass deletes the period holding the oldest expired row, so the
+		// next lookup moves forward until only rows inside retention remain.
+		oldest, err := s.queries.GetOldestRequestBefore(ctx, threshold)
+		if err != nil {
+			return result, err
+		}
+		if !oldest.Valid {
+			return result, nil
+		}
+		start, end := PeriodBounds(oldest.Time, s.partitionBy)
+		if end.After(threshold.Time) {
+			return result, nil
+		}
+
+		name := ArchiveName(start, s.partitionBy)
+		_, err = s.queries.GetArchivedPartitionByName(ctx, name)
+		switch {
+		case err == nil:
+		case errors.Is(err, pgx.ErrNoRows):
+			archived, err := s.archivePeriod(ctx, name, start, end)
+			if err != nil {
+				return result, fmt.Errorf("archive %s: %w", name, err)
+			}
+			if archived {
+				result.Archived = append(result.Archived, name)
+			}
+		default:
+			return result, err
+		}
+		deleted, err := s.queries.DeleteRequestsBetween(ctx, db.DeleteRequestsBetweenParams{
+			Ts:   pgtype.Timestamptz{Time: start, Valid: true},
+			Ts_2: pgtype.Timestamptz{Time: end, Valid: true},
+		})
+		if err != nil {
+			return result, fmt.Errorf("delete archived rows for %s: %w", name, err)
+		}
+		if deleted == 0 {
+			return result, nil
+		}
+		result.RowsDeleted += deleted
+	}
+}
+
+// archivePeriod uploads the rows in [start, end) and records the archive. It
+// reports false when the period has no rows.
+func (s *Service) archivePeriod(ctx context.Context, name string, start, end time.Time) (bool, error) {
+	tmp, err := os.CreateTemp("", "usage-archive-*.ndjson.gz")
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	rows, err := s.writeRows(ctx, tmp, start, end)
+	if err != nil || rows == 0 {
+		return false, err
+	}
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return false, err
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return false, err
+	}
+
+	key := s.objectKey(name)
+	if _, err := s.store.Put(ctx, key, tmp, blob.PutOptions{
+		ContentType: "application/gzip",
+		Metadata:    map[string]string{"table": archivedTable, "period": s.partitionBy},
+	}); err != nil {
+		return false, err
+	}
+	_, err = s.queries.InsertArchivedPartition(ctx, db.InsertArchivedPartitionParams{
+		Name:        name,
+		TableName:   archivedTable,
+		Period:      s.partitionBy,
+		PeriodStart: pgtype.Timestamptz{Time: start, Valid: true},
+		PeriodEnd:   pgtype.Timestamptz{Time: end, Valid: true},
+		ObjectKey:   key,
+		RowCount:    rows,
+		SizeBytes:   size,
+	})
+	return err == nil, err
+}
+
+func (s *Service) writeRows(ctx context.Context, w io.Writer, start, end time.Time) (int64, error) {
+	gz := gzip.NewWriter(w)
+	enc := json.NewEncoder(gz)
+	params := db.ListRequestsForArchiveParams{
+		StartTs:  pgtype.Timestamptz{Time: start, Valid: true},
+		EndTs:    pgtype.Timestamptz{Time: end, Valid: true},
+		AfterTs:  pgtype.Timestamptz{Time: start, Valid: true},
+		AfterID:  pgtype.UUID{Valid: true},
+		RowLimit: archivePageSize,
+	}
+	var count int64
+	for {
+		page, err := s.queries.ListRequestsForArchive(ctx, params)
+		if err != nil {
+			return 0, err
+		}
+		for _, row := range page {
+			if err := enc.Encode(toArchiveRecord(row)); err != nil {
+				return 0, err
+			}
+		}
+		count += int64(len(page))
+		if len(page) < archivePageSize {
+			break
+		}
+		last := page[len(page)-1]
+		params.AfterTs = last.Ts
+		params.AfterID = last.ID
+	}
+	if err := gz.Close(); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (s *Service) objectKey(name string) string {
+	return path.Join("archives", archivedTable, name)
+}
+
+// List returns every recorded archive, newest period first.
+func (s *Service) List(ctx context.Context) ([]db.ArchivedPartition, error) {
+	if s == nil || s.queries == nil {
+		return nil, ErrServiceUnavailable
+	}
+	return s.queries.ListArchivedPartitions(ctx)
+}
+
+// Open returns a reader for the named archive along with its record.
+func (s *Service) Open(ctx context.Context, name string) (io.ReadCloser, db.ArchivedPartition, error) {
+	if s == nil || s.queries == nil || s.store == nil {
+		return nil, db.ArchivedPartition{}, ErrServiceUnavailable
+	}
+	record, err := s.queries.GetArchivedPartitionByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, db.ArchivedPartition{}, ErrArchiveNotFound
+		}
+		return nil, db.ArchivedPartition{}, err
+	}
+	reader, _, err := s.store.Get(ctx, record.ObjectKey)
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			return nil, db.ArchivedPartition{}, ErrArchiveNotFound
+		}
+		return nil, db.ArchivedPartition{}, err
+	}
+	return reader, record, nil
+}
+
+// archiveRecord is one NDJSON line: a requests row with plain JSON types.
+type archiveRecord struct {
+	ID             string          `json:"id"`
+	TenantID       string          `json:"tenant_id"`
+	APIKeyID       string          `json:"api_key_id,omitempty"`
+	Timestamp      time.Time       `json:"ts"`
+	ModelAlias     string          `json:"model_alias"`
+	Provider       string          `json:"provider"`
+	LatencyMs      int32           `json:"latency_ms"`
+	Status         int32           `json:"status"`
+	ErrorCode      string          `json:"error_code,omitempty"`
+	InputTokens    int64           `json:"input_tokens"`
+	OutputTokens   int64           `json:"output_tokens"`
+	CostCents      int64           `json:"cost_cents"`
+	CostUSDMicros  int64           `json:"cost_usd_micros"`
+	IdempotencyKey string          `json:"idempotency_key,omitempty"`
+	TraceID        string          `json:"trace_id,omitempty"`
+	ABVariant      string          `json:"ab_variant,omitempty"`
+	Tags           json.RawMessage `json:"tags,omitempty"`
+	Metadata       json.RawMessage `json:"metadata,omitempty"`
+}
+
+func toArchiveRecord(row db.Request) archiveRecord {
+	rec := archiveRecord{
+		ID:             uuidString(row.ID),
+		TenantID:       uuidString(row.TenantID),
+		APIKeyID:       uuidString(row.ApiKeyID),
+		Timestamp:      row.Ts.Time.UTC(),
+		ModelAlias:     row.ModelAlias,
+		Provider:       row.Provider,
+		LatencyMs:      row.LatencyMs,
+		Status:         row.Status,
+		ErrorCode:      row.ErrorCode.String,
+		InputTokens:    row.InputTokens,
+		OutputTokens:   row.OutputTokens,
+		CostCents:      row.CostCents,
+		CostUSDMicros:  row.CostUsdMicros,
+		IdempotencyKey: row.IdempotencyKey.String,
+		TraceID:        row.TraceID.String,
+		ABVariant:      row.AbVariant.String,
+	}
+	if len(row.TagsJson) > 0 && string(row.TagsJson) != "{}" {
+		rec.Tags = json.RawMessage(row.TagsJson)
+	}
+	if len(row.RequestMetadata) > 0 && string(row.RequestMetadata) != "{}" {
+		rec.Metadata = json.RawMessage(row.RequestMetadata)
+	}
+	return rec
+}
+
+func uuidString(id pgtype.UUID) string {
+	if !id.Valid {
+		return ""
+	}
+	return uuid.UUID(id.Bytes).String()
+}
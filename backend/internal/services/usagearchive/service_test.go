@@ -0,0 +1,224 @@
+package usagearchive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/storage/blob"
+)
+
+func TestArchiveNameAndBounds(t *testing.T) {
+	ts := time.Date(2025, 9, 14, 18, 30, 0, 0, time.UTC)
+
+	start, end := PeriodBounds(ts, "month")
+	require.Equal(t, time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), start)
+	require.Equal(t, time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), end)
+	require.Equal(t, "requests-2025-09.ndjson.gz", ArchiveName(start, "month"))
+
+	start, end = PeriodBounds(ts, "day")
+	require.Equal(t, time.Date(2025, 9, 14, 0, 0, 0, 0, time.UTC), start)
+	require.Equal(t, time.Date(2025, 9, 15, 0, 0, 0, 0, time.UTC), end)
+	require.Equal(t, "requests-2025-09-14.ndjson.gz", ArchiveName(start, "day"))
+}
+
+func TestArchiveExpiredUploadsDeletesAndIsIdempotent(t *testing.T) {
+	now := time.Date(2025, 11, 17, 12, 0, 0, 0, time.UTC)
+	tenant := uuid.New()
+	queries := &fakeQueries{archives: map[string]db.ArchivedPartition{}}
+	queries.add(tenant, time.Date(2025, 9, 3, 10, 0, 0, 0, time.UTC))
+	queries.add(tenant, time.Date(2025, 9, 28, 10, 0, 0, 0, time.UTC))
+	queries.add(tenant, time.Date(2025, 10, 2, 10, 0, 0, 0, time.UTC))
+	// Inside the 30 day window; must survive.
+	queries.add(tenant, time.Date(2025, 11, 1, 10, 0, 0, 0, time.UTC))
+
+	store := &memoryStore{objects: map[string][]byte{}}
+	svc := NewService(queries, store, config.ArchiveConfig{PartitionBy: "month"}, 30)
+
+	result, err := svc.ArchiveExpired(context.Background(), now)
+	require.NoError(t, err)
+	// October ends after the threshold (Oct 18), so only September is whole.
+	require.Equal(t, []string{"requests-2025-09.ndjson.gz"}, result.Archived)
+	require.Equal(t, int64(2), result.RowsDeleted)
+	require.Len(t, queries.rows, 2)
+
+	record := queries.archives["requests-2025-09.ndjson.gz"]
+	require.Equal(t, int64(2), record.RowCount)
+	lines := readArchive(t, store.objects[record.ObjectKey])
+	require.Len(t, lines, 2)
+	require.Equal(t, tenant.String(), lines[0]["tenant_id"])
+	require.Equal(t, map[string]any{"team": "search"}, lines[0]["tags"])
+
+	puts := store.puts
+	result, err = svc.ArchiveExpired(context.Background(), now)
+	require.NoError(t, err)
+	require.Empty(t, result.Archived)
+	require.Equal(t, puts, store.puts, "archived periods must not be uploaded again")
+
+	reader, got, err := svc.Open(context.Background(), "requests-2025-09.ndjson.gz")
+	require.NoError(t, err)
+	reader.Close()
+	require.Equal(t, record.ObjectKey, got.ObjectKey)
+
+	_, _, err = svc.Open(context.Background(), "requests-2020-01.ndjson.gz")
+	require.ErrorIs(t, err, ErrArchiveNotFound)
+}
+
+func TestArchiveExpiredDeletesRowsLeftByInterruptedRun(t *testing.T) {
+	now := time.Date(2025, 11, 17, 12, 0, 0, 0, time.UTC)
+	queries := &fakeQueries{archives: map[string]db.ArchivedPartition{
+		"requests-2025-09-03.ndjson.gz": {Name: "requests-2025-09-03.ndjson.gz"},
+	}}
+	queries.add(uuid.New(), time.Date(2025, 9, 3, 10, 0, 0, 0, time.UTC))
+	store := &memoryStore{objects: map[string][]byte{}}
+	svc := NewService(queries, store, config.ArchiveConfig{PartitionBy: "day"}, 30)
+
+	result, err := svc.ArchiveExpired(context.Background(), now)
+	require.NoError(t, err)
+	require.Empty(t, result.Archived)
+	require.Equal(t, int64(1), result.RowsDeleted)
+	require.Zero(t, store.puts)
+}
+
+func readArchive(t *testing.T, data []byte) []map[string]any {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	var out []map[string]any
+	scanner := bufio.NewScanner(gz)
+	for scanner.Scan() {
+		var line map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		out = append(out, line)
+	}
+	require.NoError(t, scanner.Err())
+	return out
+}
+
+type fakeQueries struct {
+	rows     []db.Request
+	archives map[string]db.ArchivedPartition
+}
+
+func (f *fakeQueries) add(tenant uuid.UUID, ts time.Time) {
+	f.rows = append(f.rows, db.Request{
+		ID:         pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		TenantID:   pgtype.UUID{Bytes: tenant, Valid: true},
+		Ts:         pgtype.Timestamptz{Time: ts, Valid: true},
+		ModelAlias: "gpt-4o",
+		Provider:   "openai",
+		Status:     200,
+		TagsJson:   []byte(`{"team":"search"}`),
+	})
+	sort.Slice(f.rows, func(i, j int) bool { return f.rows[i].Ts.Time.Before(f.rows[j].Ts.Time) })
+}
+
+func (f *fakeQueries) DeleteRequestsBetween(_ context.Context, arg db.DeleteRequestsBetweenParams) (int64, error) {
+	kept := f.rows[:0]
+	var deleted int64
+	for _, row := range f.rows {
+		if !row.Ts.Time.Before(arg.Ts.Time) && row.Ts.Time.Before(arg.Ts_2.Time) {
+			deleted++
+			continue
+		}
+		kept = append(kept, row)
+	}
+	f.rows = kept
+	return deleted, nil
+}
+
+func (f *fakeQueries) GetArchivedPartitionByName(_ context.Context, name string) (db.ArchivedPartition, error) {
+	record, ok := f.archives[name]
+	if !ok {
+		return db.ArchivedPartition{}, pgx.ErrNoRows
+	}
+	return record, nil
+}
+
+func (f *fakeQueries) GetOldestRequestBefore(_ context.Context, ts pgtype.Timestamptz) (pgtype.Timestamptz, error) {
+	if len(f.rows) == 0 || !f.rows[0].Ts.Time.Before(ts.Time) {
+		return pgtype.Timestamptz{}, nil
+	}
+	return f.rows[0].Ts, nil
+}
+
+func (f *fakeQueries) InsertArchivedPartition(_ context.Context, arg db.InsertArchivedPartitionParams) (db.ArchivedPartition, error) {
+	record := db.ArchivedPartition{
+		Name:        arg.Name,
+		TableName:   arg.TableName,
+		Period:      arg.Period,
+		PeriodStart: arg.PeriodStart,
+		PeriodEnd:   arg.PeriodEnd,
+		ObjectKey:   arg.ObjectKey,
+		RowCount:    arg.RowCount,
+		SizeBytes:   arg.SizeBytes,
+	}
+	f.archives[arg.Name] = record
+	return record, nil
+}
+
+func (f *fakeQueries) ListArchivedPartitions(context.Context) ([]db.ArchivedPartition, error) {
+	items := []db.ArchivedPartition{}
+	for _, record := range f.archives {
+		items = append(items, record)
+	}
+	return items, nil
+}
+
+func (f *fakeQueries) ListRequestsForArchive(_ context.Context, arg db.ListRequestsForArchiveParams) ([]db.Request, error) {
+	items := []db.Request{}
+	for _, row := range f.rows {
+		if row.Ts.Time.Before(arg.StartTs.Time) || !row.Ts.Time.Before(arg.EndTs.Time) {
+			continue
+		}
+		if !row.Ts.Time.After(arg.AfterTs.Time) && (row.Ts.Time.Before(arg.AfterTs.Time) || bytes.Compare(row.ID.Bytes[:], arg.AfterID.Bytes[:]) <= 0) {
+			continue
+		}
+		items = append(items, row)
+		if int32(len(items)) == arg.RowLimit {
+			break
+		}
+	}
+	return items, nil
+}
+
+type memoryStore struct {
+	objects map[string][]byte
+	puts    int
+}
+
+func (m *memoryStore) Put(_ context.Context, key string, body io.Reader, opts blob.PutOptions) (blob.ObjectInfo, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return blob.ObjectInfo{}, err
+	}
+	m.objects[key] = data
+	m.puts++
+	return blob.ObjectInfo{Key: key, Size: int64(len(data)), ContentType: opts.ContentType}, nil
+}
+
+func (m *memoryStore) Get(_ context.Context, key string) (io.ReadCloser, blob.ObjectInfo, error) {
+	data, ok := m.objects[key]
+	if !ok {
+		return nil, blob.ObjectInfo{}, blob.ErrNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), blob.ObjectInfo{Key: key, Size: int64(len(data))}, nil
+}
+
+func (m *memoryStore) Delete(_ context.Context, key string) error {
+	delete(m.objects, key)
+	return nil
+}
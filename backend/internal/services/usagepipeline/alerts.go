@@ -14,6 +14,9 @@ const (
 	AlertLevelNone     AlertLevel = "none"
 	AlertLevelWarning  AlertLevel = "warning"
 	AlertLevelExceeded AlertLevel = "exceeded"
+	// AlertLevelUsageSpike marks SpikeDetector alerts; Spike carries the
+	// details and Status is empty.
+	AlertLevelUsageSpike AlertLevel = "usage_spike"
 )
 
 type AlertChannels struct {
@@ -22,13 +25,17 @@ type AlertChannels struct {
 }
 
 type AlertPayload struct {
-	TenantID     uuid.UUID
-	Level        AlertLevel
+	TenantID uuid.UUID
+	Level    AlertLevel
+	// Escalation is the 1-based budgets.alert_escalation_levels tier that
+	// produced the alert, or 0 for the regular warning/exceeded alert.
+	Escalation   int
 	Status       BudgetStatus
 	Channels     AlertChannels
 	Timestamp    time.Time
 	APIKeyPrefix string
 	ModelAlias   string
+	Spike        *UsageSpikeAlert
 }
 
 type AlertSink interface {
@@ -51,13 +58,30 @@ func (s *LogAlertSink) Notify(ctx context.Context, payload AlertPayload) error {
 		return nil
 	}
 
+	if spike := payload.Spike; spike != nil {
+		s.logger.WarnContext(ctx, "usage spike alert",
+			slog.String("tenant_id", payload.TenantID.String()),
+			slog.String("metric", spike.Metric),
+			slog.Time("hour", spike.Hour),
+			slog.Int64("count", spike.Count),
+			slog.Float64("average", spike.Average),
+			slog.Float64("increase_perc", spike.IncreasePerc),
+			slog.Int("threshold_perc", spike.ThresholdPerc),
+			slog.Any("emails", payload.Channels.Emails),
+			slog.Any("webhooks", payload.Channels.Webhooks),
+		)
+		return nil
+	}
+
 	s.logger.WarnContext(ctx, "budget alert",
 		slog.String("tenant_id", payload.TenantID.String()),
 		slog.String("level", string(payload.Level)),
+		slog.Int("escalation", payload.Escalation),
 		slog.Int64("total_cost_cents", payload.Status.TotalCostCents),
 		slog.Int64("limit_cents", payload.Status.LimitCents),
 		slog.Bool("warning", payload.Status.Warning),
 		slog.Bool("exceeded", payload.Status.Exceeded),
+		slog.Bool("soft_limit", payload.Status.SoftLimit),
 		slog.String("api_key_prefix", payload.APIKeyPrefix),
 		slog.String("model_alias", payload.ModelAlias),
 		slog.Any("emails", payload.Channels.Emails),
@@ -15,9 +15,14 @@ import (
 	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
 )
 
+// usageSummer is the query the evaluator uses to total a tenant's spend.
+type usageSummer interface {
+	SumUsageForTenant(ctx context.Context, arg db.SumUsageForTenantParams) (db.SumUsageForTenantRow, error)
+}
+
 // BudgetEvaluator centralizes budget config, window math, and usage aggregation.
 type BudgetEvaluator struct {
-	queries *db.Queries
+	queries usageSummer
 	cfgMu   sync.RWMutex
 	cfg     config.BudgetConfig
 }
@@ -91,5 +96,6 @@ func (b *BudgetEvaluator) Check(ctx context.Context, rc *requestctx.Context, now
 		LimitCents:     limit,
 		Warning:        warning,
 		Exceeded:       exceeded,
+		SoftLimit:      rc.BudgetSoftLimit,
 	}, nil
 }
@@ -0,0 +1,59 @@
+package usagepipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+)
+
+// stubUsage reports a fixed spend per tenant for the budget period.
+type stubUsage struct {
+	spentCents map[uuid.UUID]int64
+}
+
+func (s *stubUsage) SumUsageForTenant(_ context.Context, arg db.SumUsageForTenantParams) (db.SumUsageForTenantRow, error) {
+	return db.SumUsageForTenantRow{TotalCostCents: s.spentCents[uuid.UUID(arg.TenantID.Bytes)]}, nil
+}
+
+func TestPersonalBudgetBlocksOnceExhausted(t *testing.T) {
+	personalTenant := uuid.New()
+	usage := &stubUsage{spentCents: map[uuid.UUID]int64{}}
+	evaluator := &BudgetEvaluator{queries: usage, cfg: config.BudgetConfig{DefaultUSD: 100, RefreshSchedule: "calendar_month"}}
+	logger := &Logger{budgets: evaluator}
+
+	// A personal-tenant API key carries the seeded $5 override, not the
+	// $100 default.
+	rc := &requestctx.Context{
+		TenantID:          personalTenant,
+		OwnerUserID:       uuid.New(),
+		BudgetLimitCents:  500,
+		WarningThreshold:  0.8,
+		HasBudgetOverride: true,
+	}
+	now := time.Now().UTC()
+
+	usage.spentCents[personalTenant] = 450
+	status, err := logger.CheckBudget(context.Background(), rc, now)
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if status.Blocked() || !status.Warning || status.LimitCents != 500 {
+		t.Fatalf("expected a warning under the personal budget, got %+v", status)
+	}
+
+	// Once spend reaches the personal budget, handlers answer 403.
+	usage.spentCents[personalTenant] = 500
+	status, err = logger.CheckBudget(context.Background(), rc, now)
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if !status.Blocked() {
+		t.Fatalf("expected requests to be blocked after exhausting the personal budget, got %+v", status)
+	}
+}
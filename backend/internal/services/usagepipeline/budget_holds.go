@@ -0,0 +1,139 @@
+package usagepipeline
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+)
+
+// ErrInsufficientBudget is returned by PreAuthorize when the tenant's
+// remaining budget, less outstanding holds, cannot cover the estimate.
+var ErrInsufficientBudget = errors.New("insufficient budget headroom")
+
+// ReleaseFunc frees a budget hold once the request's usage has been recorded.
+// actualCostCents is compared with the held estimate so underestimates show
+// up in the logs. It is safe to call more than once.
+type ReleaseFunc func(actualCostCents int64)
+
+// holdReserveScript drops expired holds, sums the rest, and adds a new hold
+// only when it fits in the remaining headroom. Members are "<id>:<cents>"
+// scored by their expiry in unix milliseconds.
+var holdReserveScript = redis.NewScript(`
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', ARGV[1])
+local held = 0
+for _, member in ipairs(redis.call('ZRANGE', KEYS[1], 0, -1)) do
+  held = held + tonumber(string.match(member, ':(%d+)$'))
+end
+local headroom = tonumber(ARGV[4]) - held
+local estimate = tonumber(ARGV[3])
+if headroom <= 0 or estimate > headroom then
+  return 0
+end
+redis.call('ZADD', KEYS[1], ARGV[2], ARGV[5])
+redis.call('PEXPIRE', KEYS[1], ARGV[6])
+return 1
+`)
+
+// BudgetHolds reserves estimated spend in Redis so concurrent long-running
+// requests cannot all claim the same remaining budget.
+type BudgetHolds struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewBudgetHolds builds a hold store. Holds expire after ttl so a request
+// that never releases cannot pin budget forever.
+func NewBudgetHolds(client *redis.Client, ttl time.Duration) *BudgetHolds {
+	if ttl <= 0 {
+		ttl = 5 * time.Minute
+	}
+	return &BudgetHolds{client: client, ttl: ttl}
+}
+
+// Reserve places a hold of estimateCents against tenantID when headroomCents
+// (limit minus recorded spend) still covers it after existing holds. It
+// returns the hold member to pass to Release.
+func (h *BudgetHolds) Reserve(ctx context.Context, tenantID uuid.UUID, headroomCents, estimateCents int64) (string, error) {
+	if estimateCents < 0 {
+		estimateCents = 0
+	}
+	now := time.Now()
+	member := uuid.NewString() + ":" + strconv.FormatInt(estimateCents, 10)
+	ok, err := holdReserveScript.Run(ctx, h.client, []string{holdKey(tenantID)},
+		now.UnixMilli(),
+		now.Add(h.ttl).UnixMilli(),
+		estimateCents,
+		headroomCents,
+		member,
+		h.ttl.Milliseconds(),
+	).Int()
+	if err != nil {
+		return "", err
+	}
+	if ok == 0 {
+		return "", ErrInsufficientBudget
+	}
+	return member, nil
+}
+
+// Release removes a hold placed by Reserve.
+func (h *BudgetHolds) Release(ctx context.Context, tenantID uuid.UUID, member string) error {
+	return h.client.ZRem(ctx, holdKey(tenantID), member).Err()
+}
+
+func holdKey(tenantID uuid.UUID) string {
+	return "budget_holds:" + tenantID.String()
+}
+
+// PreAuthorize reserves estimatedCostCents of the tenant's budget before a
+// long request starts. It fails with ErrInsufficientBudget when recorded
+// spend plus outstanding holds leave no room for the estimate. Call the
+// returned ReleaseFunc after Record so the spend is never uncounted.
+func (l *Logger) PreAuthorize(ctx context.Context, rc *requestctx.Context, estimatedCostCents int64) (ReleaseFunc, error) {
+	if rc == nil {
+		return nil, errors.New("request context missing")
+	}
+	if rc.BudgetSoftLimit {
+		// Soft budgets never reject, so there is nothing to reserve.
+		return func(int64) {}, nil
+	}
+	now := time.Now().UTC()
+	limit := l.budgets.EffectiveLimit(rc)
+	total, err := l.budgets.SumUsage(ctx, rc.TenantID, now, l.budgets.Schedule(rc))
+	if err != nil {
+		return nil, err
+	}
+	if l.holds == nil {
+		if total >= limit {
+			return nil, ErrInsufficientBudget
+		}
+		return func(int64) {}, nil
+	}
+
+	member, err := l.holds.Reserve(ctx, rc.TenantID, limit-total, estimatedCostCents)
+	if err != nil {
+		return nil, err
+	}
+	var once sync.Once
+	return func(actualCostCents int64) {
+		once.Do(func() {
+			if actualCostCents > estimatedCostCents {
+				slog.Warn("budget hold underestimated request cost",
+					slog.String("tenant_id", rc.TenantID.String()),
+					slog.Int64("held_cents", estimatedCostCents),
+					slog.Int64("actual_cents", actualCostCents))
+			}
+			if err := l.holds.Release(context.Background(), rc.TenantID, member); err != nil {
+				slog.Error("release budget hold", slog.String("tenant_id", rc.TenantID.String()), slog.String("error", err.Error()))
+			}
+		})
+	}, nil
+}
@@ -0,0 +1,96 @@
+package usagepipeline
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestHolds(t *testing.T, ttl time.Duration) *BudgetHolds {
+	t.Helper()
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	t.Cleanup(server.Close)
+	rdb := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	return NewBudgetHolds(rdb, ttl)
+}
+
+func TestBudgetHoldsConcurrentRequestsRaceForLastBudget(t *testing.T) {
+	holds := newTestHolds(t, time.Minute)
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	// Five cents of headroom left; every request wants three of them.
+	const racers = 20
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		granted []string
+		denied  int
+	)
+	start := make(chan struct{})
+	for i := 0; i < racers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			member, err := holds.Reserve(ctx, tenantID, 5, 3)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				granted = append(granted, member)
+			case errors.Is(err, ErrInsufficientBudget):
+				denied++
+			default:
+				t.Errorf("reserve: %v", err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if len(granted) != 1 || denied != racers-1 {
+		t.Fatalf("expected exactly one hold, got %d granted and %d denied", len(granted), denied)
+	}
+
+	if err := holds.Release(ctx, tenantID, granted[0]); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	if _, err := holds.Reserve(ctx, tenantID, 5, 3); err != nil {
+		t.Fatalf("expected headroom after release, got %v", err)
+	}
+}
+
+func TestBudgetHoldsRejectWithoutHeadroomAndExpire(t *testing.T) {
+	holds := newTestHolds(t, 50*time.Millisecond)
+	ctx := context.Background()
+	tenantID := uuid.New()
+
+	if _, err := holds.Reserve(ctx, tenantID, 0, 0); !errors.Is(err, ErrInsufficientBudget) {
+		t.Fatalf("expected exhausted budget to be rejected, got %v", err)
+	}
+	if _, err := holds.Reserve(ctx, tenantID, 10, 10); err != nil {
+		t.Fatalf("reserve: %v", err)
+	}
+	if _, err := holds.Reserve(ctx, tenantID, 10, 1); !errors.Is(err, ErrInsufficientBudget) {
+		t.Fatalf("expected outstanding hold to block, got %v", err)
+	}
+	if _, err := holds.Reserve(ctx, uuid.New(), 10, 1); err != nil {
+		t.Fatalf("holds must be per tenant, got %v", err)
+	}
+
+	time.Sleep(80 * time.Millisecond)
+	if _, err := holds.Reserve(ctx, tenantID, 10, 10); err != nil {
+		t.Fatalf("expected expired hold to be dropped, got %v", err)
+	}
+}
@@ -9,43 +9,57 @@ import (
 	"github.com/ncecere/open_model_gateway/backend/internal/db"
 )
 
-func insertRequest(ctx context.Context, q *db.Queries, rec Record, ts time.Time, costCents int64, costMicros int64) error {
+func insertRequest(ctx context.Context, q *db.Queries, rec Record, ts time.Time, costCents int64, costMicros int64) (db.Request, error) {
+	return q.InsertRequestRecord(ctx, requestRecordParams(rec, ts, costCents, costMicros))
+}
+
+func requestRecordParams(rec Record, ts time.Time, costCents int64, costMicros int64) db.InsertRequestRecordParams {
 	latency := rec.Latency.Milliseconds()
 	if latency < 0 {
 		latency = 0
 	}
 
-	_, err := q.InsertRequestRecord(ctx, db.InsertRequestRecordParams{
-		TenantID:       toPgUUID(rec.Context.TenantID),
-		ApiKeyID:       toPgNullableUUID(rec.Context.APIKeyID),
-		Ts:             pgtype.Timestamptz{Time: ts, Valid: true},
-		ModelAlias:     rec.Alias,
-		Provider:       rec.Provider,
-		LatencyMs:      int32(latency),
-		Status:         int32(rec.Status),
-		ErrorCode:      toPgText(rec.ErrorCode),
-		InputTokens:    int64(rec.Usage.PromptTokens),
-		OutputTokens:   int64(rec.Usage.CompletionTokens),
-		CostCents:      costCents,
-		CostUsdMicros:  costMicros,
-		IdempotencyKey: toPgText(rec.IdempotencyKey),
-		TraceID:        toPgText(rec.TraceID),
-	})
-	return err
+	return db.InsertRequestRecordParams{
+		TenantID:            toPgUUID(rec.Context.TenantID),
+		ApiKeyID:            toPgNullableUUID(rec.Context.APIKeyID),
+		Ts:                  pgtype.Timestamptz{Time: ts, Valid: true},
+		ModelAlias:          rec.Alias,
+		Provider:            rec.Provider,
+		LatencyMs:           int32(latency),
+		Status:              int32(rec.Status),
+		ErrorCode:           toPgText(rec.ErrorCode),
+		InputTokens:         int64(rec.Usage.PromptTokens),
+		OutputTokens:        int64(rec.Usage.CompletionTokens),
+		CostCents:           costCents,
+		CostUsdMicros:       costMicros,
+		IdempotencyKey:      toPgText(rec.IdempotencyKey),
+		TraceID:             toPgText(rec.TraceID),
+		TraceParent:         toPgText(rec.TraceParent),
+		AbVariant:           toPgText(rec.ABVariant),
+		TagsJson:            tagsJSON(rec.Context.Tags),
+		RequestMetadata:     metadataJSON(rec),
+		Fingerprint:         toPgText(rec.Context.Fingerprint),
+		CachedTokens:        int64(rec.Usage.CachedInputTokens),
+		PriceInputSnapshot:  rec.price.Input,
+		PriceOutputSnapshot: rec.price.Output,
+	}
 }
 
 func insertUsage(ctx context.Context, q *db.Queries, rec Record, ts time.Time, costCents int64, costMicros int64) error {
 	_, err := q.InsertUsageRecord(ctx, db.InsertUsageRecordParams{
-		TenantID:      toPgUUID(rec.Context.TenantID),
-		ApiKeyID:      toPgNullableUUID(rec.Context.APIKeyID),
-		Ts:            pgtype.Timestamptz{Time: ts, Valid: true},
-		ModelAlias:    rec.Alias,
-		Provider:      rec.Provider,
-		InputTokens:   int64(rec.Usage.PromptTokens),
-		OutputTokens:  int64(rec.Usage.CompletionTokens),
-		Requests:      1,
-		CostCents:     costCents,
-		CostUsdMicros: costMicros,
+		TenantID:            toPgUUID(rec.Context.TenantID),
+		ApiKeyID:            toPgNullableUUID(rec.Context.APIKeyID),
+		Ts:                  pgtype.Timestamptz{Time: ts, Valid: true},
+		ModelAlias:          rec.Alias,
+		Provider:            rec.Provider,
+		InputTokens:         int64(rec.Usage.PromptTokens),
+		OutputTokens:        int64(rec.Usage.CompletionTokens),
+		Requests:            1,
+		CostCents:           costCents,
+		CostUsdMicros:       costMicros,
+		CachedTokens:        int64(rec.Usage.CachedInputTokens),
+		InputCostUsdMicros:  rec.inputCostMicros,
+		OutputCostUsdMicros: rec.outputCostMicros,
 	})
 	return err
 }
@@ -0,0 +1,95 @@
+package usagepipeline
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+)
+
+// EscalationEvaluator notifies tiered contacts as a tenant's spend crosses
+// budgets.alert_escalation_levels. Fired levels are tracked in a Redis set
+// per tenant and budget period, so each level notifies exactly once per
+// period no matter how many instances record usage.
+type EscalationEvaluator struct {
+	client *redis.Client
+	sink   AlertSink
+}
+
+func NewEscalationEvaluator(client *redis.Client, sink AlertSink) *EscalationEvaluator {
+	if sink == nil {
+		sink = NewLogAlertSink(nil)
+	}
+	return &EscalationEvaluator{client: client, sink: sink}
+}
+
+// Evaluate fires every level whose threshold the status has reached and that
+// has not fired yet this period. Levels crossed together each fire once.
+func (e *EscalationEvaluator) Evaluate(ctx context.Context, rec Record, status BudgetStatus, levels []config.EscalationLevel, schedule string, now time.Time) error {
+	if e == nil || e.client == nil || rec.Context == nil || len(levels) == 0 || status.LimitCents <= 0 {
+		return nil
+	}
+	used := float64(status.TotalCostCents) / float64(status.LimitCents)
+	key, ttl := escalationKey(rec.Context.TenantID, now, schedule)
+
+	var firstErr error
+	for i, level := range levels {
+		if used < level.ThresholdPerc {
+			continue
+		}
+		member := strconv.Itoa(i + 1)
+		added, err := e.client.SAdd(ctx, key, member).Result()
+		if err != nil {
+			return err
+		}
+		if added == 0 {
+			continue
+		}
+		e.client.Expire(ctx, key, ttl)
+
+		alertLevel := AlertLevelWarning
+		if status.Exceeded {
+			alertLevel = AlertLevelExceeded
+		}
+		payload := AlertPayload{
+			TenantID:     rec.Context.TenantID,
+			Level:        alertLevel,
+			Escalation:   i + 1,
+			Status:       status,
+			Channels:     AlertChannels{Emails: level.Emails, Webhooks: level.Webhooks},
+			Timestamp:    now,
+			APIKeyPrefix: rec.Context.APIKeyPrefix,
+			ModelAlias:   rec.Alias,
+		}
+		if err := e.sink.Notify(ctx, payload); err != nil {
+			// Re-arm the level so the next request retries the notification.
+			e.client.SRem(ctx, key, member)
+			slog.Error("budget escalation notify failed",
+				slog.String("tenant_id", rec.Context.TenantID.String()),
+				slog.Int("escalation", i+1),
+				slog.String("error", err.Error()))
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// escalationKey names the fired-level set for the tenant's current budget
+// period. Calendar periods expire a day after they end; rolling windows keep
+// the set for one window length.
+func escalationKey(tenantID uuid.UUID, now time.Time, schedule string) (string, time.Duration) {
+	normalized := config.NormalizeBudgetRefreshSchedule(schedule)
+	if days, ok := config.BudgetRollingWindowDays(normalized); ok && days > 0 {
+		return fmt.Sprintf("budget_escalation:%s:%s", tenantID, normalized), time.Duration(days) * 24 * time.Hour
+	}
+	start, end := periodBounds(now, schedule)
+	return fmt.Sprintf("budget_escalation:%s:%s", tenantID, start.Format("20060102")), end.Sub(now) + 24*time.Hour
+}
@@ -0,0 +1,110 @@
+package usagepipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+)
+
+var testEscalationLevels = []config.EscalationLevel{
+	{ThresholdPerc: 0.8, Emails: []string{"tier1@example.com"}},
+	{ThresholdPerc: 0.95, Emails: []string{"tier2@example.com"}},
+	{ThresholdPerc: 1, Emails: []string{"tier3@example.com"}, Webhooks: []string{"https://pager.example.com/hook"}},
+}
+
+func newTestEscalation(t *testing.T, sink AlertSink) *EscalationEvaluator {
+	t.Helper()
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	t.Cleanup(server.Close)
+	rdb := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	return NewEscalationEvaluator(rdb, sink)
+}
+
+func escalationStatus(spent int64) BudgetStatus {
+	return BudgetStatus{TotalCostCents: spent, LimitCents: 1000, Exceeded: spent >= 1000}
+}
+
+func TestEscalationFiresEachLevelOncePerPeriod(t *testing.T) {
+	sink := &stubSink{}
+	evaluator := newTestEscalation(t, sink)
+	ctx := context.Background()
+	rec := Record{Context: &requestctx.Context{TenantID: uuid.New()}, Alias: "gpt-4o"}
+	now := time.Date(2025, 11, 10, 12, 0, 0, 0, time.UTC)
+
+	for _, spent := range []int64{500, 800, 850, 950, 960, 1000, 1200, 1300} {
+		if err := evaluator.Evaluate(ctx, rec, escalationStatus(spent), testEscalationLevels, "calendar_month", now); err != nil {
+			t.Fatalf("evaluate at %d: %v", spent, err)
+		}
+	}
+
+	if sink.calls != 3 {
+		t.Fatalf("expected one notification per level, got %d", sink.calls)
+	}
+	for i, payload := range sink.payloads {
+		if payload.Escalation != i+1 {
+			t.Fatalf("notification %d escalation = %d, want %d", i, payload.Escalation, i+1)
+		}
+		if payload.Channels.Emails[0] != testEscalationLevels[i].Emails[0] {
+			t.Fatalf("notification %d went to %v", i, payload.Channels.Emails)
+		}
+	}
+	if sink.payloads[2].Level != AlertLevelExceeded || len(sink.payloads[2].Channels.Webhooks) != 1 {
+		t.Fatalf("tier 3 should be an exceeded alert with the webhook, got %+v", sink.payloads[2])
+	}
+
+	// A new budget period re-arms every level.
+	next := time.Date(2025, 12, 1, 0, 0, 1, 0, time.UTC)
+	if err := evaluator.Evaluate(ctx, rec, escalationStatus(850), testEscalationLevels, "calendar_month", next); err != nil {
+		t.Fatalf("evaluate next period: %v", err)
+	}
+	if sink.calls != 4 || sink.payloads[3].Escalation != 1 {
+		t.Fatalf("expected tier 1 to fire again in the new period, calls=%d", sink.calls)
+	}
+}
+
+func TestEscalationFiresAllCrossedLevelsAtOnce(t *testing.T) {
+	sink := &stubSink{}
+	evaluator := newTestEscalation(t, sink)
+	rec := Record{Context: &requestctx.Context{TenantID: uuid.New()}}
+
+	if err := evaluator.Evaluate(context.Background(), rec, escalationStatus(1000), testEscalationLevels, "calendar_month", time.Now()); err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	if sink.calls != 3 {
+		t.Fatalf("jumping straight to 100%% should fire all three levels, got %d", sink.calls)
+	}
+}
+
+func TestEscalationRetriesAfterNotifyFailure(t *testing.T) {
+	sink := &stubSink{err: errors.New("smtp down")}
+	evaluator := newTestEscalation(t, sink)
+	ctx := context.Background()
+	rec := Record{Context: &requestctx.Context{TenantID: uuid.New()}}
+	levels := testEscalationLevels[:1]
+
+	if err := evaluator.Evaluate(ctx, rec, escalationStatus(800), levels, "calendar_month", time.Now()); err == nil {
+		t.Fatal("expected notify error")
+	}
+	sink.err = nil
+	if err := evaluator.Evaluate(ctx, rec, escalationStatus(810), levels, "calendar_month", time.Now()); err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	if err := evaluator.Evaluate(ctx, rec, escalationStatus(820), levels, "calendar_month", time.Now()); err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	if sink.calls != 2 {
+		t.Fatalf("expected the failed level to fire once more, got %d calls", sink.calls)
+	}
+}
@@ -0,0 +1,105 @@
+package usagepipeline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	latencyChannelPrefix = "gateway:latency:"
+	// MaxLatencySubscribers caps the live latency streams per model on one
+	// instance, since each holds a Redis subscription open.
+	MaxLatencySubscribers = 10
+)
+
+// ErrLatencySubscribersFull reports that a model already has
+// MaxLatencySubscribers live latency streams.
+var ErrLatencySubscribersFull = errors.New("too many latency subscribers for model")
+
+// LatencyEvent is the compact per-request event published for live latency
+// dashboards.
+type LatencyEvent struct {
+	TS        time.Time `json:"ts"`
+	LatencyMS int       `json:"latency_ms"`
+	Provider  string    `json:"provider"`
+	Status    int       `json:"status"`
+}
+
+// LatencyPublisher fans request latencies out over Redis pub/sub on
+// gateway:latency:<model>.
+type LatencyPublisher struct {
+	client *redis.Client
+
+	mu          sync.Mutex
+	subscribers map[string]int
+}
+
+// NewLatencyPublisher returns a publisher bound to client. A nil client
+// disables publishing and subscribing.
+func NewLatencyPublisher(client *redis.Client) *LatencyPublisher {
+	return &LatencyPublisher{client: client, subscribers: make(map[string]int)}
+}
+
+// LatencyChannel returns the pub/sub channel carrying model's latency events.
+func LatencyChannel(model string) string {
+	return latencyChannelPrefix + model
+}
+
+// Publish sends one latency event for model. Events are fire-and-forget:
+// nobody may be listening, and a missed event only thins out a live chart.
+func (p *LatencyPublisher) Publish(ctx context.Context, model string, latencyMS int, provider string, status int) error {
+	if p == nil || p.client == nil || model == "" {
+		return nil
+	}
+	data, err := json.Marshal(LatencyEvent{
+		TS:        time.Now().UTC(),
+		LatencyMS: latencyMS,
+		Provider:  provider,
+		Status:    status,
+	})
+	if err != nil {
+		return err
+	}
+	return p.client.Publish(ctx, LatencyChannel(model), data).Err()
+}
+
+// Subscribe opens a subscription to model's latency events. The caller must
+// call the returned release func, which closes the subscription and frees
+// the subscriber slot.
+func (p *LatencyPublisher) Subscribe(ctx context.Context, model string) (*redis.PubSub, func(), error) {
+	if p == nil || p.client == nil {
+		return nil, nil, errors.New("latency stream unavailable")
+	}
+	p.mu.Lock()
+	if p.subscribers[model] >= MaxLatencySubscribers {
+		p.mu.Unlock()
+		return nil, nil, ErrLatencySubscribersFull
+	}
+	p.subscribers[model]++
+	p.mu.Unlock()
+
+	sub := p.client.Subscribe(ctx, LatencyChannel(model))
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			sub.Close()
+			p.mu.Lock()
+			if p.subscribers[model]--; p.subscribers[model] <= 0 {
+				delete(p.subscribers, model)
+			}
+			p.mu.Unlock()
+		})
+	}
+	// Wait for the subscription to be confirmed so no event published right
+	// after Subscribe returns is missed.
+	if _, err := sub.Receive(ctx); err != nil {
+		release()
+		return nil, nil, err
+	}
+	return sub, release, nil
+}
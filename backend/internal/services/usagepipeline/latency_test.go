@@ -0,0 +1,90 @@
+package usagepipeline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestLatencyPublisher(t *testing.T) *LatencyPublisher {
+	t.Helper()
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	t.Cleanup(server.Close)
+	rdb := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	return NewLatencyPublisher(rdb)
+}
+
+func TestLatencyPublisherDeliversEventsForModel(t *testing.T) {
+	publisher := newTestLatencyPublisher(t)
+	ctx := context.Background()
+
+	sub, release, err := publisher.Subscribe(ctx, "gpt-4o")
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	defer release()
+
+	if err := publisher.Publish(ctx, "other-model", 5, "openai", 200); err != nil {
+		t.Fatalf("publish other: %v", err)
+	}
+	if err := publisher.Publish(ctx, "gpt-4o", 120, "azure", 429); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	select {
+	case msg := <-sub.Channel():
+		if msg.Channel != "gateway:latency:gpt-4o" {
+			t.Fatalf("unexpected channel %q", msg.Channel)
+		}
+		var event LatencyEvent
+		if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+			t.Fatalf("decode event: %v", err)
+		}
+		if event.LatencyMS != 120 || event.Provider != "azure" || event.Status != 429 || event.TS.IsZero() {
+			t.Fatalf("unexpected event %+v", event)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for latency event")
+	}
+}
+
+func TestLatencyPublisherCapsSubscribersPerModel(t *testing.T) {
+	publisher := newTestLatencyPublisher(t)
+	ctx := context.Background()
+
+	releases := make([]func(), 0, MaxLatencySubscribers)
+	for i := 0; i < MaxLatencySubscribers; i++ {
+		_, release, err := publisher.Subscribe(ctx, "gpt-4o")
+		if err != nil {
+			t.Fatalf("subscribe %d: %v", i, err)
+		}
+		releases = append(releases, release)
+	}
+	if _, _, err := publisher.Subscribe(ctx, "gpt-4o"); !errors.Is(err, ErrLatencySubscribersFull) {
+		t.Fatalf("expected ErrLatencySubscribersFull, got %v", err)
+	}
+	if _, release, err := publisher.Subscribe(ctx, "claude"); err != nil {
+		t.Fatalf("other model should have its own cap: %v", err)
+	} else {
+		release()
+	}
+
+	// Releasing twice frees a single slot.
+	releases[0]()
+	releases[0]()
+	if _, _, err := publisher.Subscribe(ctx, "gpt-4o"); err != nil {
+		t.Fatalf("expected a freed slot after release: %v", err)
+	}
+	if _, _, err := publisher.Subscribe(ctx, "gpt-4o"); !errors.Is(err, ErrLatencySubscribersFull) {
+		t.Fatalf("expected the cap to hold after a double release, got %v", err)
+	}
+}
@@ -2,7 +2,9 @@ package usagepipeline
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"math"
 	"strings"
@@ -19,6 +21,7 @@ import (
 	"github.com/ncecere/open_model_gateway/backend/internal/models"
 	"github.com/ncecere/open_model_gateway/backend/internal/observability"
 	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+	"github.com/ncecere/open_model_gateway/backend/internal/services/notifications"
 )
 
 // Logger persists request and usage records while enforcing configured budgets.
@@ -27,6 +30,14 @@ type Logger struct {
 	budgets  *BudgetEvaluator
 	alerts   *AlertDispatcher
 	metrics  *observability.Provider
+	payloads *PayloadStore
+	holds    *BudgetHolds
+	escalate *EscalationEvaluator
+	latency  *LatencyPublisher
+	requests requestQueries
+	audit    auditQueries
+	purge    purgeQueries
+	notifier notifications.Notifier
 
 	priceMu          sync.RWMutex
 	prices           map[string]priceInfo
@@ -43,6 +54,9 @@ type priceInfo struct {
 	Input    decimal.Decimal
 	Output   decimal.Decimal
 	Currency string
+	// CachedRatio scales Input for prompt tokens served from the provider's
+	// context cache.
+	CachedRatio decimal.Decimal
 }
 
 func dollarsToCents(value float64) int64 {
@@ -60,9 +74,28 @@ type Record struct {
 	ErrorCode         string
 	IdempotencyKey    string
 	TraceID           string
+	TraceParent       string
 	Timestamp         time.Time
 	Success           bool
 	OverrideCostCents *int64
+	// ABVariant is the traffic-split branch that served the request, empty
+	// when the alias has no split. Cost is priced from the variant's catalog
+	// entry since that is the model that ran.
+	ABVariant string
+	// RequestPayload and ResponsePayload are stored asynchronously when
+	// retention.log_payloads is enabled.
+	RequestPayload  []byte
+	ResponsePayload []byte
+	// Metadata is the caller's X-Request-Metadata object, stored with the
+	// request log for filtering. Nil falls back to Context.Metadata.
+	Metadata map[string]any
+	// price is the catalog price the request was billed at, stored on the
+	// request row so later price changes do not rewrite history.
+	price priceInfo
+	// inputCostMicros and outputCostMicros split the priced cost between
+	// prompt and completion tokens. Override costs are not split.
+	inputCostMicros  int64
+	outputCostMicros int64
 }
 
 // BudgetStatus reflects the tenant's budget posture after a request.
@@ -71,15 +104,24 @@ type BudgetStatus struct {
 	LimitCents     int64
 	Warning        bool
 	Exceeded       bool
+	// SoftLimit is set for tenants whose budget only warns; see Blocked.
+	SoftLimit bool
 }
 
 // NewLogger constructs a usage logger using the shared pool and queries.
-func NewLogger(pool *pgxpool.Pool, queries *db.Queries, cfg config.BudgetConfig, sink AlertSink, metrics *observability.Provider) *Logger {
+func NewLogger(pool *pgxpool.Pool, queries *db.Queries, cfg config.BudgetConfig, sink AlertSink, metrics *observability.Provider, payloads *PayloadStore, holds *BudgetHolds, escalation *EscalationEvaluator, latency *LatencyPublisher) *Logger {
 	return &Logger{
 		recorder:         NewUsageRecorder(pool, queries),
 		budgets:          NewBudgetEvaluator(cfg, queries),
 		alerts:           NewAlertDispatcher(queries, sink),
 		metrics:          metrics,
+		payloads:         payloads,
+		holds:            holds,
+		escalate:         escalation,
+		latency:          latency,
+		requests:         queries,
+		audit:            queries,
+		purge:            queries,
 		prices:           make(map[string]priceInfo),
 		tenantRemainders: make(map[uuid.UUID]decimal.Decimal),
 	}
@@ -91,10 +133,15 @@ func (l *Logger) LoadCatalog(entries []config.ModelCatalogEntry) {
 	defer l.priceMu.Unlock()
 
 	for _, entry := range entries {
+		ratio, err := config.NormalizeCachedTokenPriceRatio(entry.CachedTokenPriceRatio)
+		if err != nil {
+			ratio = config.DefaultCachedTokenPriceRatio
+		}
 		l.prices[entry.Alias] = priceInfo{
-			Input:    decimal.NewFromFloat(entry.PriceInput),
-			Output:   decimal.NewFromFloat(entry.PriceOutput),
-			Currency: entry.Currency,
+			Input:       decimal.NewFromFloat(entry.PriceInput),
+			Output:      decimal.NewFromFloat(entry.PriceOutput),
+			Currency:    entry.Currency,
+			CachedRatio: decimal.NewFromFloat(ratio),
 		}
 	}
 }
@@ -117,9 +164,20 @@ func (l *Logger) Record(ctx context.Context, rec Record) (BudgetStatus, error) {
 	if ts.IsZero() {
 		ts = time.Now().UTC()
 	}
+	// Handlers pass their request context, so the W3C traceparent comes from
+	// the span the tracing middleware started.
+	if rec.TraceParent == "" {
+		rec.TraceParent = observability.TraceParent(ctx)
+	}
 
 	limit := l.budgets.EffectiveLimit(rec.Context)
 
+	priceAlias := rec.Alias
+	if rec.ABVariant != "" {
+		priceAlias = rec.ABVariant
+	}
+	rec.price = l.priceFor(priceAlias)
+
 	var costCents int64
 	var costMicros int64
 	if rec.Success {
@@ -127,15 +185,19 @@ func (l *Logger) Record(ctx context.Context, rec Record) (BudgetStatus, error) {
 			costCents = *rec.OverrideCostCents
 			costMicros = *rec.OverrideCostCents * 10000 // convert cents to micros (1 cent = 10,000 micros)
 		} else {
-			costUSD := l.costFor(rec.Alias, rec.Usage)
+			costUSD := l.costFor(priceAlias, rec.Usage)
 			costCents = l.allocateCostCents(rec.Context.TenantID, costUSD)
 			costMicros = usdToMicros(costUSD)
+			rec.inputCostMicros, rec.outputCostMicros = l.splitCostMicros(priceAlias, rec.Usage, costMicros)
 		}
 	}
 
-	if err := l.recorder.Persist(ctx, rec, ts, costCents, costMicros); err != nil {
+	stored := retainedRecord(rec)
+	request, err := l.recorder.Persist(ctx, stored, ts, costCents, costMicros)
+	if err != nil {
 		return BudgetStatus{}, err
 	}
+	l.payloads.enqueue(request.ID, request.TenantID, stored.RequestPayload, stored.ResponsePayload, ts)
 	if l.metrics != nil {
 		tenantLabel := rec.Context.TenantID.String()
 		l.metrics.RecordAPILatency(tenantLabel, rec.Alias, rec.Provider, rec.Status, rec.Latency)
@@ -143,6 +205,9 @@ func (l *Logger) Record(ctx context.Context, rec Record) (BudgetStatus, error) {
 			l.metrics.RecordTokens(tenantLabel, rec.Alias, rec.Provider, int64(rec.Usage.PromptTokens), int64(rec.Usage.CompletionTokens))
 		}
 	}
+	if err := l.latency.Publish(ctx, rec.Alias, int(rec.Latency.Milliseconds()), rec.Provider, rec.Status); err != nil {
+		slog.Debug("publish latency event", slog.String("model", rec.Alias), slog.String("error", err.Error()))
+	}
 
 	schedule := l.budgets.Schedule(rec.Context)
 	total, err := l.budgets.SumUsage(ctx, rec.Context.TenantID, ts, schedule)
@@ -158,15 +223,50 @@ func (l *Logger) Record(ctx context.Context, rec Record) (BudgetStatus, error) {
 		LimitCents:     limit,
 		Warning:        warning,
 		Exceeded:       exceeded,
+		SoftLimit:      rec.Context.BudgetSoftLimit,
+	}
+	if rec.Context.BudgetSoftLimit && total-costCents >= limit {
+		l.auditSoftLimit(ctx, rec, status)
+	}
+
+	if notification, ok := budgetNotification(rec.Context, status, costCents); ok {
+		notifications.Send(l.notifier, notification)
 	}
 
 	if err := l.alerts.Dispatch(ctx, rec, status, ts); err != nil {
 		slog.Error("dispatch budget alert", slog.String("tenant_id", rec.Context.TenantID.String()), slog.String("error", err.Error()))
 	}
+	if err := l.escalate.Evaluate(ctx, rec, status, l.budgets.Config().AlertEscalationLevels, schedule, ts); err != nil {
+		slog.Error("evaluate budget escalation", slog.String("tenant_id", rec.Context.TenantID.String()), slog.String("error", err.Error()))
+	}
 
 	return status, nil
 }
 
+// retainedRecord strips everything but counts, status, and cost from records
+// made with a zero-retention API key. Pricing happens before this so the
+// stored cost still reflects the model that ran.
+func retainedRecord(rec Record) Record {
+	if rec.Context == nil || !rec.Context.ZeroRetention {
+		return rec
+	}
+	rc := *rec.Context
+	rc.Tags = nil
+	rc.Metadata = nil
+	rc.Fingerprint = ""
+	rec.Context = &rc
+	rec.Metadata = nil
+	rec.Alias = ""
+	rec.Provider = ""
+	rec.ABVariant = ""
+	rec.IdempotencyKey = ""
+	rec.TraceID = ""
+	rec.TraceParent = ""
+	rec.RequestPayload = nil
+	rec.ResponsePayload = nil
+	return rec
+}
+
 func (l *Logger) insertRequest(ctx context.Context, q *db.Queries, rec Record, ts time.Time, costCents int64, costMicros int64) error {
 	latency := rec.Latency.Milliseconds()
 	if latency < 0 {
@@ -174,62 +274,146 @@ func (l *Logger) insertRequest(ctx context.Context, q *db.Queries, rec Record, t
 	}
 
 	_, err := q.InsertRequestRecord(ctx, db.InsertRequestRecordParams{
-		TenantID:       toPgUUID(rec.Context.TenantID),
-		ApiKeyID:       toPgNullableUUID(rec.Context.APIKeyID),
-		Ts:             pgtype.Timestamptz{Time: ts, Valid: true},
-		ModelAlias:     rec.Alias,
-		Provider:       rec.Provider,
-		LatencyMs:      int32(latency),
-		Status:         int32(rec.Status),
-		ErrorCode:      toPgText(rec.ErrorCode),
-		InputTokens:    int64(rec.Usage.PromptTokens),
-		OutputTokens:   int64(rec.Usage.CompletionTokens),
-		CostCents:      costCents,
-		CostUsdMicros:  costMicros,
-		IdempotencyKey: toPgText(rec.IdempotencyKey),
-		TraceID:        toPgText(rec.TraceID),
+		TenantID:        toPgUUID(rec.Context.TenantID),
+		ApiKeyID:        toPgNullableUUID(rec.Context.APIKeyID),
+		Ts:              pgtype.Timestamptz{Time: ts, Valid: true},
+		ModelAlias:      rec.Alias,
+		Provider:        rec.Provider,
+		LatencyMs:       int32(latency),
+		Status:          int32(rec.Status),
+		ErrorCode:       toPgText(rec.ErrorCode),
+		InputTokens:     int64(rec.Usage.PromptTokens),
+		OutputTokens:    int64(rec.Usage.CompletionTokens),
+		CostCents:       costCents,
+		CostUsdMicros:   costMicros,
+		IdempotencyKey:  toPgText(rec.IdempotencyKey),
+		TraceID:         toPgText(rec.TraceID),
+		TraceParent:     toPgText(rec.TraceParent),
+		AbVariant:       toPgText(rec.ABVariant),
+		TagsJson:        tagsJSON(rec.Context.Tags),
+		RequestMetadata: metadataJSON(rec),
+		Fingerprint:     toPgText(rec.Context.Fingerprint),
+		CachedTokens:    int64(rec.Usage.CachedInputTokens),
 	})
 	return err
 }
 
 func (l *Logger) insertUsage(ctx context.Context, q *db.Queries, rec Record, ts time.Time, costCents int64, costMicros int64) error {
 	_, err := q.InsertUsageRecord(ctx, db.InsertUsageRecordParams{
-		TenantID:      toPgUUID(rec.Context.TenantID),
-		ApiKeyID:      toPgNullableUUID(rec.Context.APIKeyID),
-		Ts:            pgtype.Timestamptz{Time: ts, Valid: true},
-		ModelAlias:    rec.Alias,
-		Provider:      rec.Provider,
-		InputTokens:   int64(rec.Usage.PromptTokens),
-		OutputTokens:  int64(rec.Usage.CompletionTokens),
-		Requests:      1,
-		CostCents:     costCents,
-		CostUsdMicros: costMicros,
+		TenantID:            toPgUUID(rec.Context.TenantID),
+		ApiKeyID:            toPgNullableUUID(rec.Context.APIKeyID),
+		Ts:                  pgtype.Timestamptz{Time: ts, Valid: true},
+		ModelAlias:          rec.Alias,
+		Provider:            rec.Provider,
+		InputTokens:         int64(rec.Usage.PromptTokens),
+		OutputTokens:        int64(rec.Usage.CompletionTokens),
+		Requests:            1,
+		CostCents:           costCents,
+		CostUsdMicros:       costMicros,
+		CachedTokens:        int64(rec.Usage.CachedInputTokens),
+		InputCostUsdMicros:  rec.inputCostMicros,
+		OutputCostUsdMicros: rec.outputCostMicros,
 	})
 	return err
 }
 
+// PayloadLoggingEnabled reports whether callers should attach request and
+// response bodies to their records.
+func (l *Logger) PayloadLoggingEnabled() bool {
+	return l != nil && l.payloads.Enabled()
+}
+
+// GetPayload returns the stored request/response bodies for a request.
+func (l *Logger) GetPayload(ctx context.Context, requestID uuid.UUID) (Payload, error) {
+	if l == nil {
+		return Payload{}, ErrPayloadNotFound
+	}
+	return l.payloads.Get(ctx, requestID)
+}
+
+// SetNotifier sends budget warnings and overruns to the admin inbox.
+func (l *Logger) SetNotifier(n notifications.Notifier) {
+	l.notifier = n
+}
+
+// budgetNotification returns the admin notification for the request that
+// moved a tenant past its warning threshold or budget, so each crossing is
+// reported once rather than on every later request.
+func budgetNotification(rc *requestctx.Context, status BudgetStatus, costCents int64) (notifications.Notification, bool) {
+	if rc == nil || status.LimitCents <= 0 || costCents <= 0 {
+		return notifications.Notification{}, false
+	}
+	before := status.TotalCostCents - costCents
+	spent := fmt.Sprintf("$%.2f of $%.2f", float64(status.TotalCostCents)/100, float64(status.LimitCents)/100)
+	switch {
+	case status.Exceeded && before < status.LimitCents:
+		return notifications.Notification{
+			Severity:   notifications.SeverityCritical,
+			EntityType: notifications.EntityTenant,
+			EntityID:   rc.TenantID.String(),
+			Message:    fmt.Sprintf("tenant exceeded its budget: %s spent", spent),
+		}, true
+	case status.Warning && !overThreshold(before, status.LimitCents, rc.WarningThreshold):
+		return notifications.Notification{
+			Severity:   notifications.SeverityWarning,
+			EntityType: notifications.EntityTenant,
+			EntityID:   rc.TenantID.String(),
+			Message:    fmt.Sprintf("tenant passed its budget warning threshold: %s spent", spent),
+		}, true
+	}
+	return notifications.Notification{}, false
+}
+
 // SetConfig swaps the budget configuration at runtime.
 func (l *Logger) SetConfig(cfg config.BudgetConfig) {
 	l.budgets.SetConfig(cfg)
 }
 
 func (l *Logger) costFor(alias string, usage models.Usage) decimal.Decimal {
+	promptCost, completionCost := l.costSplit(alias, usage)
+	totalUSD := promptCost.Add(completionCost)
+	if totalUSD.IsNegative() {
+		return decimal.Zero
+	}
+	return totalUSD
+}
+
+// splitCostMicros divides costMicros, the priced total of usage, into its
+// prompt and completion shares so the two always add up to the total.
+func (l *Logger) splitCostMicros(alias string, usage models.Usage, costMicros int64) (int64, int64) {
+	promptCost, _ := l.costSplit(alias, usage)
+	input := min(max(usdToMicros(promptCost), 0), costMicros)
+	return input, costMicros - input
+}
+
+// costSplit prices the prompt and completion tokens of usage separately.
+// Cached prompt tokens are billed at the catalog's cached ratio.
+func (l *Logger) costSplit(alias string, usage models.Usage) (decimal.Decimal, decimal.Decimal) {
 	price := l.priceFor(alias)
 	if price.Input.IsZero() && price.Output.IsZero() {
-		return decimal.Zero
+		return decimal.Zero, decimal.Zero
 	}
 
-	prompt := decimal.NewFromInt(int64(usage.PromptTokens))
+	cached := min(max(usage.CachedInputTokens, 0), usage.PromptTokens)
+	prompt := decimal.NewFromInt(int64(usage.PromptTokens - cached))
 	completion := decimal.NewFromInt(int64(usage.CompletionTokens))
+	if cached > 0 {
+		prompt = prompt.Add(decimal.NewFromInt(int64(cached)).Mul(price.CachedRatio))
+	}
 
 	million := decimal.NewFromInt(1_000_000)
-	promptCost := price.Input.Mul(prompt).Div(million)
-	completionCost := price.Output.Mul(completion).Div(million)
-	totalUSD := promptCost.Add(completionCost)
-	if totalUSD.IsNegative() {
-		return decimal.Zero
+	return price.Input.Mul(prompt).Div(million), price.Output.Mul(completion).Div(million)
+}
+
+// EstimateCostCents prices usage against the catalog without recording it or
+// touching the tenant's fractional-cent remainder. Partial cents round up so
+// dry-run estimates never understate the charge.
+func (l *Logger) EstimateCostCents(alias string, usage models.Usage) int64 {
+	usd := l.costFor(alias, usage)
+	if usd.IsZero() {
+		return 0
 	}
-	return totalUSD
+	return usd.Mul(decimal.NewFromInt(100)).Ceil().IntPart()
 }
 
 func (l *Logger) priceFor(alias string) priceInfo {
@@ -318,3 +502,32 @@ func toPgText(value string) pgtype.Text {
 	}
 	return pgtype.Text{String: value, Valid: true}
 }
+
+// tagsJSON serialises the caller's cost-allocation tags for requests.tags_json.
+// metadataJSON encodes the record's request metadata, defaulting to the
+// metadata captured on the request context.
+func metadataJSON(rec Record) []byte {
+	metadata := rec.Metadata
+	if metadata == nil && rec.Context != nil {
+		metadata = rec.Context.Metadata
+	}
+	if len(metadata) == 0 {
+		return []byte("{}")
+	}
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return []byte("{}")
+	}
+	return data
+}
+
+func tagsJSON(tags map[string]string) []byte {
+	if len(tags) == 0 {
+		return []byte("{}")
+	}
+	data, err := json.Marshal(tags)
+	if err != nil {
+		return []byte("{}")
+	}
+	return data
+}
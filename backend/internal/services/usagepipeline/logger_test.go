@@ -0,0 +1,175 @@
+package usagepipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+	"github.com/ncecere/open_model_gateway/backend/internal/services/notifications"
+)
+
+func TestEstimateCostCentsRoundsUp(t *testing.T) {
+	l := &Logger{prices: make(map[string]priceInfo)}
+	l.LoadCatalog([]config.ModelCatalogEntry{{Alias: "gpt", PriceInput: 2.5, PriceOutput: 10}})
+
+	// 1000 prompt tokens at $2.50/M plus 500 completion tokens at $10/M = $0.0075.
+	got := l.EstimateCostCents("gpt", models.Usage{PromptTokens: 1000, CompletionTokens: 500})
+	if got != 1 {
+		t.Fatalf("expected 1 cent, got %d", got)
+	}
+	if got := l.EstimateCostCents("unknown", models.Usage{PromptTokens: 1000}); got != 0 {
+		t.Fatalf("expected unpriced alias to estimate 0, got %d", got)
+	}
+}
+
+func TestSplitCostMicrosSeparatesPromptAndCompletion(t *testing.T) {
+	l := &Logger{prices: make(map[string]priceInfo)}
+	l.LoadCatalog([]config.ModelCatalogEntry{{Alias: "gpt", PriceInput: 2.5, PriceOutput: 10}})
+
+	// 1000 prompt tokens at $2.50/M is $0.0025; 500 completion tokens at
+	// $10/M is $0.005.
+	usage := models.Usage{PromptTokens: 1000, CompletionTokens: 500}
+	total := usdToMicros(l.costFor("gpt", usage))
+	input, output := l.splitCostMicros("gpt", usage, total)
+	if input != 2500 || output != 5000 || input+output != total {
+		t.Fatalf("expected 2500/5000 micros of %d, got %d/%d", total, input, output)
+	}
+	if input, output := l.splitCostMicros("unknown", usage, 0); input != 0 || output != 0 {
+		t.Fatalf("expected unpriced alias to split nothing, got %d/%d", input, output)
+	}
+}
+
+func TestCachedTokensBilledAtReducedRate(t *testing.T) {
+	l := &Logger{prices: make(map[string]priceInfo)}
+	l.LoadCatalog([]config.ModelCatalogEntry{
+		{Alias: "claude", PriceInput: 3, PriceOutput: 15},
+		{Alias: "gpt", PriceInput: 2, PriceOutput: 8, CachedTokenPriceRatio: 0.5},
+	})
+
+	// 1M prompt tokens at $3/M, 800k of them cached at the default 10%:
+	// 200k * $3/M + 800k * $0.30/M = $0.84.
+	cached := models.Usage{PromptTokens: 1_000_000, CachedInputTokens: 800_000}
+	if got := l.EstimateCostCents("claude", cached); got != 84 {
+		t.Fatalf("expected 84 cents with cached tokens, got %d", got)
+	}
+	if got := l.EstimateCostCents("claude", models.Usage{PromptTokens: 1_000_000}); got != 300 {
+		t.Fatalf("expected 300 cents without cached tokens, got %d", got)
+	}
+	// A catalog ratio of 0.5: 200k * $2/M + 800k * $1/M = $1.20.
+	if got := l.EstimateCostCents("gpt", cached); got != 120 {
+		t.Fatalf("expected 120 cents at a 0.5 ratio, got %d", got)
+	}
+	// Cached counts above the prompt total are clamped.
+	if got := l.EstimateCostCents("claude", models.Usage{PromptTokens: 1_000_000, CachedInputTokens: 2_000_000}); got != 30 {
+		t.Fatalf("expected 30 cents when everything is cached, got %d", got)
+	}
+
+	rec := Record{Context: &requestctx.Context{TenantID: uuid.New()}, Alias: "claude", Provider: "anthropic", Usage: cached}
+	if params := requestRecordParams(rec, time.Now(), 84, 840_000); params.CachedTokens != 800_000 || params.InputTokens != 1_000_000 {
+		t.Fatalf("expected cached tokens on the request row, got %+v", params)
+	}
+}
+
+func TestZeroRetentionRequestRowHasOnlyCountsAndCost(t *testing.T) {
+	rc := &requestctx.Context{
+		TenantID:      uuid.New(),
+		APIKeyID:      uuid.New(),
+		Tags:          map[string]string{"project": "secret"},
+		Metadata:      map[string]any{"project": "secret"},
+		Fingerprint:   "abc123",
+		ZeroRetention: true,
+	}
+	rec := Record{
+		Context:         rc,
+		Alias:           "gpt-4o",
+		Provider:        "openai",
+		ABVariant:       "gpt-4o-mini",
+		Usage:           models.Usage{PromptTokens: 120, CompletionTokens: 30},
+		Latency:         250 * time.Millisecond,
+		Status:          200,
+		IdempotencyKey:  "idem-1",
+		TraceID:         "trace-1",
+		Success:         true,
+		RequestPayload:  []byte(`{"messages":[{"role":"user","content":"hi"}]}`),
+		ResponsePayload: []byte(`{"choices":[]}`),
+	}
+
+	stored := retainedRecord(rec)
+	params := requestRecordParams(stored, time.Now(), 3, 25_000)
+	if params.ModelAlias != "" || params.Provider != "" {
+		t.Fatalf("expected model and provider to be blank, got %q/%q", params.ModelAlias, params.Provider)
+	}
+	if params.AbVariant.Valid || params.IdempotencyKey.Valid || params.TraceID.Valid || params.Fingerprint.Valid || string(params.TagsJson) != "{}" || string(params.RequestMetadata) != "{}" {
+		t.Fatalf("expected identifying fields to be empty, got %+v", params)
+	}
+	if stored.RequestPayload != nil || stored.ResponsePayload != nil {
+		t.Fatal("expected payloads to be dropped")
+	}
+	if params.InputTokens != 120 || params.OutputTokens != 30 || params.CostCents != 3 || params.CostUsdMicros != 25_000 || params.Status != 200 {
+		t.Fatalf("expected counts and cost to be kept, got %+v", params)
+	}
+	if rec.Alias != "gpt-4o" || rc.Tags["project"] != "secret" {
+		t.Fatal("expected the caller's record and context to be left untouched")
+	}
+}
+
+func TestRequestRowStoresMetadata(t *testing.T) {
+	rc := &requestctx.Context{TenantID: uuid.New(), Metadata: map[string]any{"project": "llm-ops"}}
+	rec := Record{Context: rc, Alias: "gpt-4o", Provider: "openai", Status: 200}
+
+	params := requestRecordParams(rec, time.Now(), 0, 0)
+	if string(params.RequestMetadata) != `{"project":"llm-ops"}` {
+		t.Fatalf("expected context metadata to be stored, got %s", params.RequestMetadata)
+	}
+
+	rec.Metadata = map[string]any{"run": 7}
+	params = requestRecordParams(rec, time.Now(), 0, 0)
+	if string(params.RequestMetadata) != `{"run":7}` {
+		t.Fatalf("expected record metadata to win, got %s", params.RequestMetadata)
+	}
+
+	params = requestRecordParams(Record{Context: &requestctx.Context{}}, time.Now(), 0, 0)
+	if string(params.RequestMetadata) != "{}" {
+		t.Fatalf("expected empty metadata object, got %s", params.RequestMetadata)
+	}
+}
+
+func TestRequestRowSnapshotsPrice(t *testing.T) {
+	l := &Logger{prices: make(map[string]priceInfo)}
+	l.LoadCatalog([]config.ModelCatalogEntry{{Alias: "gpt", PriceInput: 2.5, PriceOutput: 10}})
+
+	rec := Record{Context: &requestctx.Context{TenantID: uuid.New(), ZeroRetention: true}, Alias: "gpt", Status: 200, price: l.priceFor("gpt")}
+	// A later price change must not alter what the request was billed at.
+	l.LoadCatalog([]config.ModelCatalogEntry{{Alias: "gpt", PriceInput: 5, PriceOutput: 20}})
+
+	params := requestRecordParams(retainedRecord(rec), time.Now(), 1, 7_500)
+	if params.PriceInputSnapshot.String() != "2.5" || params.PriceOutputSnapshot.String() != "10" {
+		t.Fatalf("expected price snapshot 2.5/10, got %s/%s", params.PriceInputSnapshot, params.PriceOutputSnapshot)
+	}
+}
+
+func TestBudgetNotificationOnlyOnCrossing(t *testing.T) {
+	rc := &requestctx.Context{TenantID: uuid.New(), WarningThreshold: 0.8}
+
+	// 75 -> 85 cents of a $1 budget crosses the warning threshold.
+	n, ok := budgetNotification(rc, BudgetStatus{TotalCostCents: 85, LimitCents: 100, Warning: true}, 10)
+	if !ok || n.Severity != notifications.SeverityWarning || n.EntityID != rc.TenantID.String() {
+		t.Fatalf("expected a warning notification, got %+v (%v)", n, ok)
+	}
+	// 85 -> 90 was already past the threshold.
+	if _, ok := budgetNotification(rc, BudgetStatus{TotalCostCents: 90, LimitCents: 100, Warning: true}, 5); ok {
+		t.Fatal("expected no notification once past the threshold")
+	}
+	// 95 -> 102 crosses the budget itself.
+	n, ok = budgetNotification(rc, BudgetStatus{TotalCostCents: 102, LimitCents: 100, Exceeded: true}, 7)
+	if !ok || n.Severity != notifications.SeverityCritical || n.Message != "tenant exceeded its budget: $1.02 of $1.00 spent" {
+		t.Fatalf("expected an overrun notification, got %+v (%v)", n, ok)
+	}
+	if _, ok := budgetNotification(rc, BudgetStatus{TotalCostCents: 110, LimitCents: 100, Exceeded: true}, 8); ok {
+		t.Fatal("expected no notification for requests after the overrun")
+	}
+}
@@ -0,0 +1,160 @@
+package usagepipeline
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/redact"
+)
+
+const payloadQueueSize = 256
+
+// ErrPayloadNotFound indicates no payload was stored for the request (logging
+// disabled at the time, dropped, or already purged).
+var ErrPayloadNotFound = errors.New("request payload not found")
+
+// Payload is a stored request/response body pair.
+type Payload struct {
+	RequestID    uuid.UUID
+	TenantID     uuid.UUID
+	RequestBody  []byte
+	ResponseBody []byte
+	CreatedAt    time.Time
+}
+
+type payloadQueries interface {
+	InsertRequestPayload(ctx context.Context, arg db.InsertRequestPayloadParams) error
+	GetRequestPayload(ctx context.Context, requestID pgtype.UUID) (db.RequestPayload, error)
+	DeleteRequestPayloadsBefore(ctx context.Context, createdAt pgtype.Timestamptz) (int64, error)
+}
+
+// PayloadStore persists request/response bodies from a buffered queue so the
+// request path never waits on the extra write.
+type PayloadStore struct {
+	queries   payloadQueries
+	jobs      chan db.InsertRequestPayloadParams
+	retention time.Duration
+	enabled   bool
+	// redactPaths are masked in both bodies before they are queued.
+	redactPaths []string
+}
+
+// NewPayloadStore builds a payload store. Logging stays disabled unless
+// retention.log_payloads is set and zero_retention is off.
+func NewPayloadStore(queries payloadQueries, cfg config.RetentionConfig) *PayloadStore {
+	days := cfg.PayloadRetentionDays
+	if days <= 0 {
+		days = 7
+	}
+	return &PayloadStore{
+		queries:     queries,
+		jobs:        make(chan db.InsertRequestPayloadParams, payloadQueueSize),
+		retention:   time.Duration(days) * 24 * time.Hour,
+		enabled:     cfg.LogPayloads && !cfg.ZeroRetention && queries != nil,
+		redactPaths: cfg.Redaction.RedactPaths,
+	}
+}
+
+// Enabled reports whether callers should bother serializing payloads.
+func (s *PayloadStore) Enabled() bool {
+	return s != nil && s.enabled
+}
+
+// Run drains the payload queue until ctx is cancelled.
+func (s *PayloadStore) Run(ctx context.Context) {
+	if !s.Enabled() {
+		return
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-s.jobs:
+			s.write(ctx, job)
+		}
+	}
+}
+
+func (s *PayloadStore) write(ctx context.Context, job db.InsertRequestPayloadParams) {
+	if err := s.queries.InsertRequestPayload(ctx, job); err != nil {
+		slog.Error("persist request payload", slog.String("error", err.Error()))
+	}
+}
+
+// enqueue hands a payload to the writer without blocking; payloads are dropped
+// when the queue is full. Redaction runs here, before the body reaches the
+// writer goroutine.
+func (s *PayloadStore) enqueue(requestID, tenantID pgtype.UUID, request, response []byte, ts time.Time) {
+	if !s.Enabled() {
+		return
+	}
+	request = s.redact(request)
+	response = s.redact(response)
+	if len(request) == 0 && len(response) == 0 {
+		return
+	}
+	job := db.InsertRequestPayloadParams{
+		RequestID:    requestID,
+		TenantID:     tenantID,
+		RequestBody:  request,
+		ResponseBody: response,
+		CreatedAt:    pgtype.Timestamptz{Time: ts, Valid: true},
+	}
+	select {
+	case s.jobs <- job:
+	default:
+		slog.Warn("request payload queue full; dropping payload")
+	}
+}
+
+// redact masks the configured paths in body. A body that cannot be redacted,
+// such as one that is not JSON, is dropped rather than stored unmasked.
+func (s *PayloadStore) redact(body []byte) []byte {
+	if len(s.redactPaths) == 0 || len(body) == 0 {
+		return body
+	}
+	redacted, err := redact.Apply(body, s.redactPaths)
+	if err != nil {
+		slog.Warn("redact request payload; dropping body", slog.String("error", err.Error()))
+		return nil
+	}
+	return redacted
+}
+
+// Get returns the stored payload for a request.
+func (s *PayloadStore) Get(ctx context.Context, requestID uuid.UUID) (Payload, error) {
+	if s == nil || s.queries == nil {
+		return Payload{}, ErrPayloadNotFound
+	}
+	record, err := s.queries.GetRequestPayload(ctx, toPgUUID(requestID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Payload{}, ErrPayloadNotFound
+		}
+		return Payload{}, err
+	}
+	return Payload{
+		RequestID:    uuid.UUID(record.RequestID.Bytes),
+		TenantID:     uuid.UUID(record.TenantID.Bytes),
+		RequestBody:  record.RequestBody,
+		ResponseBody: record.ResponseBody,
+		CreatedAt:    record.CreatedAt.Time,
+	}, nil
+}
+
+// PurgeExpired deletes payloads older than the retention window.
+func (s *PayloadStore) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	if s == nil || s.queries == nil {
+		return 0, nil
+	}
+	cutoff := now.Add(-s.retention)
+	return s.queries.DeleteRequestPayloadsBefore(ctx, pgtype.Timestamptz{Time: cutoff, Valid: true})
+}
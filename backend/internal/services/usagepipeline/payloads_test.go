@@ -0,0 +1,151 @@
+package usagepipeline
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+type memoryPayloadQueries struct {
+	mu   sync.Mutex
+	rows map[pgtype.UUID]db.RequestPayload
+}
+
+func newMemoryPayloadQueries() *memoryPayloadQueries {
+	return &memoryPayloadQueries{rows: make(map[pgtype.UUID]db.RequestPayload)}
+}
+
+func (m *memoryPayloadQueries) InsertRequestPayload(_ context.Context, arg db.InsertRequestPayloadParams) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rows[arg.RequestID] = db.RequestPayload{
+		RequestID:    arg.RequestID,
+		TenantID:     arg.TenantID,
+		RequestBody:  arg.RequestBody,
+		ResponseBody: arg.ResponseBody,
+		CreatedAt:    arg.CreatedAt,
+	}
+	return nil
+}
+
+func (m *memoryPayloadQueries) GetRequestPayload(_ context.Context, requestID pgtype.UUID) (db.RequestPayload, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	row, ok := m.rows[requestID]
+	if !ok {
+		return db.RequestPayload{}, pgx.ErrNoRows
+	}
+	return row, nil
+}
+
+func (m *memoryPayloadQueries) DeleteRequestPayloadsBefore(_ context.Context, createdAt pgtype.Timestamptz) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var deleted int64
+	for id, row := range m.rows {
+		if row.CreatedAt.Time.Before(createdAt.Time) {
+			delete(m.rows, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (m *memoryPayloadQueries) count() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.rows)
+}
+
+func TestPayloadStorePurgesAfterRetention(t *testing.T) {
+	queries := newMemoryPayloadQueries()
+	store := NewPayloadStore(queries, config.RetentionConfig{LogPayloads: true, PayloadRetentionDays: 1})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		store.Run(ctx)
+		close(done)
+	}()
+
+	now := time.Now().UTC()
+	tenant := toPgUUID(uuid.New())
+	oldID, freshID := uuid.New(), uuid.New()
+	store.enqueue(toPgUUID(oldID), tenant, []byte(`{"old":true}`), []byte(`{}`), now.Add(-48*time.Hour))
+	store.enqueue(toPgUUID(freshID), tenant, []byte(`{"fresh":true}`), []byte(`{}`), now.Add(-time.Hour))
+
+	deadline := time.Now().Add(time.Second)
+	for queries.count() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("payloads were not persisted")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	deleted, err := store.PurgeExpired(context.Background(), now)
+	if err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	if deleted != 1 {
+		t.Fatalf("expected 1 purged payload, got %d", deleted)
+	}
+	if _, err := store.Get(context.Background(), oldID); !errors.Is(err, ErrPayloadNotFound) {
+		t.Fatalf("expected expired payload to be gone, got %v", err)
+	}
+	payload, err := store.Get(context.Background(), freshID)
+	if err != nil {
+		t.Fatalf("get fresh payload: %v", err)
+	}
+	if string(payload.RequestBody) != `{"fresh":true}` {
+		t.Fatalf("unexpected request body %q", payload.RequestBody)
+	}
+}
+
+func TestPayloadStoreRespectsZeroRetention(t *testing.T) {
+	queries := newMemoryPayloadQueries()
+	store := NewPayloadStore(queries, config.RetentionConfig{LogPayloads: true, ZeroRetention: true})
+	if store.Enabled() {
+		t.Fatalf("zero retention must disable payload logging")
+	}
+	store.enqueue(toPgUUID(uuid.New()), toPgUUID(uuid.New()), []byte(`{}`), nil, time.Now())
+	if len(store.jobs) != 0 {
+		t.Fatalf("expected payload to be skipped")
+	}
+}
+
+func TestPayloadStoreRedactsBeforeQueueing(t *testing.T) {
+	store := NewPayloadStore(newMemoryPayloadQueries(), config.RetentionConfig{
+		LogPayloads: true,
+		Redaction:   config.RedactionConfig{RedactPaths: []string{"/messages/*/content", "/choices/*/message/content"}},
+	})
+	request := []byte(`{"model":"gpt-4o","messages":[{"role":"user","content":"my card is 4242"}]}`)
+	response := []byte(`{"choices":[{"message":{"role":"assistant","content":"noted 4242"}}]}`)
+	store.enqueue(toPgUUID(uuid.New()), toPgUUID(uuid.New()), request, response, time.Now())
+
+	// The queued job is what the writer goroutine persists.
+	job := <-store.jobs
+	if string(job.RequestBody) != `{"model":"gpt-4o","messages":[{"role":"user","content":"[REDACTED]"}]}` {
+		t.Fatalf("unexpected request body %s", job.RequestBody)
+	}
+	if string(job.ResponseBody) != `{"choices":[{"message":{"role":"assistant","content":"[REDACTED]"}}]}` {
+		t.Fatalf("unexpected response body %s", job.ResponseBody)
+	}
+
+	// Bodies that cannot be parsed are dropped instead of stored unmasked.
+	store.enqueue(toPgUUID(uuid.New()), toPgUUID(uuid.New()), request, []byte("data: {\"content\":\"4242\"}\n\n"), time.Now())
+	job = <-store.jobs
+	if job.ResponseBody != nil || len(job.RequestBody) == 0 {
+		t.Fatalf("expected only the unparseable body to be dropped, got %+v", job)
+	}
+}
@@ -0,0 +1,58 @@
+package usagepipeline
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+const (
+	// MinPurgeAge keeps the last week of the request log, which billing
+	// still reads, out of reach of PurgeUsage.
+	MinPurgeAge    = 7 * 24 * time.Hour
+	purgeBatchSize = 1000
+)
+
+// ErrPurgeTooRecent rejects purges whose cutoff falls within MinPurgeAge.
+var ErrPurgeTooRecent = errors.New("before must be at least 7 days in the past")
+
+type purgeQueries interface {
+	DeleteRequestsBeforeBatch(ctx context.Context, arg db.DeleteRequestsBeforeBatchParams) (int64, error)
+}
+
+// PurgeUsage deletes request log rows (and their stored payloads) recorded
+// before the cutoff, limited to one tenant when tenantID is set, and returns
+// how many were removed. Rows go 1000 per statement so no transaction holds
+// locks for long. Aggregated usage_records are kept for billing.
+func (l *Logger) PurgeUsage(ctx context.Context, tenantID *uuid.UUID, before time.Time) (int64, error) {
+	if before.After(time.Now().Add(-MinPurgeAge)) {
+		return 0, ErrPurgeTooRecent
+	}
+	params := db.DeleteRequestsBeforeBatchParams{
+		BeforeTs: pgtype.Timestamptz{Time: before, Valid: true},
+		RowLimit: purgeBatchSize,
+	}
+	if tenantID != nil {
+		params.TenantID = toPgUUID(*tenantID)
+	}
+
+	var total int64
+	for {
+		deleted, err := l.purge.DeleteRequestsBeforeBatch(ctx, params)
+		total += deleted
+		if err != nil {
+			return total, err
+		}
+		if deleted < purgeBatchSize {
+			return total, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package usagepipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+type batchedPurgeQueries struct {
+	remaining int64
+	calls     []db.DeleteRequestsBeforeBatchParams
+}
+
+func (f *batchedPurgeQueries) DeleteRequestsBeforeBatch(_ context.Context, arg db.DeleteRequestsBeforeBatchParams) (int64, error) {
+	f.calls = append(f.calls, arg)
+	deleted := min(f.remaining, int64(arg.RowLimit))
+	f.remaining -= deleted
+	return deleted, nil
+}
+
+func TestPurgeUsageDeletesInBatches(t *testing.T) {
+	queries := &batchedPurgeQueries{remaining: 2500}
+	logger := &Logger{purge: queries}
+	tenantID := uuid.New()
+	before := time.Now().Add(-30 * 24 * time.Hour)
+
+	deleted, err := logger.PurgeUsage(context.Background(), &tenantID, before)
+	if err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	if deleted != 2500 || len(queries.calls) != 3 {
+		t.Fatalf("expected 2500 rows over 3 batches, got %d over %d", deleted, len(queries.calls))
+	}
+	call := queries.calls[0]
+	if call.RowLimit != purgeBatchSize || uuid.UUID(call.TenantID.Bytes) != tenantID || !call.BeforeTs.Time.Equal(before) {
+		t.Fatalf("unexpected params %+v", call)
+	}
+
+	queries = &batchedPurgeQueries{remaining: 10}
+	logger.purge = queries
+	if _, err := logger.PurgeUsage(context.Background(), nil, before); err != nil || queries.calls[0].TenantID.Valid {
+		t.Fatalf("expected an unscoped purge, got %+v (%v)", queries.calls, err)
+	}
+}
+
+func TestPurgeUsageRejectsRecentCutoff(t *testing.T) {
+	queries := &batchedPurgeQueries{remaining: 10}
+	logger := &Logger{purge: queries}
+	_, err := logger.PurgeUsage(context.Background(), nil, time.Now().Add(-6*24*time.Hour))
+	if !errors.Is(err, ErrPurgeTooRecent) || len(queries.calls) != 0 {
+		t.Fatalf("expected ErrPurgeTooRecent without deleting, got %v (%d calls)", err, len(queries.calls))
+	}
+}
@@ -21,28 +21,33 @@ func NewUsageRecorder(pool *pgxpool.Pool, queries *db.Queries) *UsageRecorder {
 	return &UsageRecorder{pool: pool, queries: queries}
 }
 
-// Persist writes the request + usage rows with the provided cost in cents/micros.
-func (r *UsageRecorder) Persist(ctx context.Context, rec Record, ts time.Time, costCents int64, costMicros int64) error {
+// Persist writes the request + usage rows with the provided cost in cents/micros
+// and returns the stored request row.
+func (r *UsageRecorder) Persist(ctx context.Context, rec Record, ts time.Time, costCents int64, costMicros int64) (db.Request, error) {
 	if r == nil {
-		return ErrRecorderUnavailable
+		return db.Request{}, ErrRecorderUnavailable
 	}
 
 	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
-		return err
+		return db.Request{}, err
 	}
 	defer tx.Rollback(ctx)
 
 	qtx := r.queries.WithTx(tx)
-	if err := insertRequest(ctx, qtx, rec, ts, costCents, costMicros); err != nil {
-		return err
+	request, err := insertRequest(ctx, qtx, rec, ts, costCents, costMicros)
+	if err != nil {
+		return db.Request{}, err
 	}
 	if rec.Success {
 		if err := insertUsage(ctx, qtx, rec, ts, costCents, costMicros); err != nil {
-			return err
+			return db.Request{}, err
 		}
 	}
-	return tx.Commit(ctx)
+	if err := tx.Commit(ctx); err != nil {
+		return db.Request{}, err
+	}
+	return request, nil
 }
 
 var ErrRecorderUnavailable = errors.New("usage recorder unavailable")
@@ -0,0 +1,81 @@
+package usagepipeline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/models"
+)
+
+var (
+	// ErrRequestNotFound indicates no request row exists for the id.
+	ErrRequestNotFound = errors.New("request not found")
+	// ErrRequestNotReplayable indicates the stored payload is not a chat request.
+	ErrRequestNotReplayable = errors.New("only chat requests can be replayed")
+)
+
+type requestQueries interface {
+	GetRequestByID(ctx context.Context, id pgtype.UUID) (db.Request, error)
+}
+
+// ReplayableRequest is a previously served chat request reconstructed from
+// its request row and stored payload.
+type ReplayableRequest struct {
+	ID        uuid.UUID
+	TenantID  uuid.UUID
+	APIKeyID  uuid.UUID
+	Alias     string
+	Timestamp time.Time
+	Request   models.ChatRequest
+}
+
+// GetRequest loads a request and its stored payload for replay. It returns
+// ErrRequestNotFound or ErrPayloadNotFound when either is missing.
+func (l *Logger) GetRequest(ctx context.Context, id uuid.UUID) (ReplayableRequest, error) {
+	if l == nil || l.requests == nil {
+		return ReplayableRequest{}, ErrRequestNotFound
+	}
+	row, err := l.requests.GetRequestByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ReplayableRequest{}, ErrRequestNotFound
+		}
+		return ReplayableRequest{}, err
+	}
+	payload, err := l.payloads.Get(ctx, id)
+	if err != nil {
+		return ReplayableRequest{}, err
+	}
+	if len(payload.RequestBody) == 0 {
+		return ReplayableRequest{}, ErrPayloadNotFound
+	}
+
+	var req models.ChatRequest
+	if err := json.Unmarshal(payload.RequestBody, &req); err != nil || len(req.Messages) == 0 {
+		return ReplayableRequest{}, ErrRequestNotReplayable
+	}
+
+	alias := strings.TrimSpace(req.Model)
+	if alias == "" {
+		alias = row.ModelAlias
+	}
+	replay := ReplayableRequest{
+		ID:        id,
+		TenantID:  uuid.UUID(row.TenantID.Bytes),
+		Alias:     alias,
+		Timestamp: row.Ts.Time,
+		Request:   req,
+	}
+	if row.ApiKeyID.Valid {
+		replay.APIKeyID = uuid.UUID(row.ApiKeyID.Bytes)
+	}
+	return replay, nil
+}
@@ -0,0 +1,87 @@
+package usagepipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+type memoryRequestQueries map[pgtype.UUID]db.Request
+
+func (m memoryRequestQueries) GetRequestByID(_ context.Context, id pgtype.UUID) (db.Request, error) {
+	row, ok := m[id]
+	if !ok {
+		return db.Request{}, pgx.ErrNoRows
+	}
+	return row, nil
+}
+
+func TestLoggerGetRequest(t *testing.T) {
+	ctx := context.Background()
+	requestID := uuid.New()
+	tenantID := uuid.New()
+	keyID := uuid.New()
+
+	payloads := newMemoryPayloadQueries()
+	requests := memoryRequestQueries{
+		toPgUUID(requestID): {
+			ID:         toPgUUID(requestID),
+			TenantID:   toPgUUID(tenantID),
+			ApiKeyID:   toPgUUID(keyID),
+			ModelAlias: "gpt-4o",
+			Ts:         pgtype.Timestamptz{Time: time.Now().UTC(), Valid: true},
+		},
+	}
+	logger := &Logger{
+		requests: requests,
+		payloads: NewPayloadStore(payloads, config.RetentionConfig{LogPayloads: true}),
+	}
+
+	if _, err := logger.GetRequest(ctx, requestID); !errors.Is(err, ErrPayloadNotFound) {
+		t.Fatalf("expected ErrPayloadNotFound, got %v", err)
+	}
+	if _, err := logger.GetRequest(ctx, uuid.New()); !errors.Is(err, ErrRequestNotFound) {
+		t.Fatalf("expected ErrRequestNotFound, got %v", err)
+	}
+
+	_ = payloads.InsertRequestPayload(ctx, db.InsertRequestPayloadParams{
+		RequestID:   toPgUUID(requestID),
+		TenantID:    toPgUUID(tenantID),
+		RequestBody: []byte(`{"model":"gpt-4o","messages":[{"role":"user","content":"hi"}]}`),
+	})
+	replay, err := logger.GetRequest(ctx, requestID)
+	if err != nil {
+		t.Fatalf("get request: %v", err)
+	}
+	if replay.Alias != "gpt-4o" || replay.TenantID != tenantID || replay.APIKeyID != keyID {
+		t.Fatalf("unexpected replay metadata: %+v", replay)
+	}
+	if len(replay.Request.Messages) != 1 || replay.Request.Messages[0].Content != "hi" {
+		t.Fatalf("unexpected replay request: %+v", replay.Request)
+	}
+}
+
+func TestLoggerGetRequestRejectsEmbeddings(t *testing.T) {
+	ctx := context.Background()
+	requestID := uuid.New()
+	payloads := newMemoryPayloadQueries()
+	_ = payloads.InsertRequestPayload(ctx, db.InsertRequestPayloadParams{
+		RequestID:   toPgUUID(requestID),
+		RequestBody: []byte(`{"model":"text-embedding-3-small","input":["hi"]}`),
+	})
+	logger := &Logger{
+		requests: memoryRequestQueries{toPgUUID(requestID): {ID: toPgUUID(requestID)}},
+		payloads: NewPayloadStore(payloads, config.RetentionConfig{}),
+	}
+	if _, err := logger.GetRequest(ctx, requestID); !errors.Is(err, ErrRequestNotReplayable) {
+		t.Fatalf("expected ErrRequestNotReplayable, got %v", err)
+	}
+}
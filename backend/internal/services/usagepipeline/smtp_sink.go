@@ -20,10 +20,10 @@ type SMTPSink struct {
 }
 
 func NewSMTPSink(cfg config.SMTPConfig, _ *slog.Logger) AlertSink {
-	if strings.TrimSpace(cfg.Host) == "" || cfg.Port == 0 || strings.TrimSpace(cfg.From) == "" {
-		return nil
+	if sink := NewSMTPMailer(cfg); sink != nil {
+		return sink
 	}
-	return &SMTPSink{cfg: cfg}
+	return nil
 }
 
 func (s *SMTPSink) Notify(ctx context.Context, payload AlertPayload) error {
@@ -36,6 +36,39 @@ func (s *SMTPSink) Notify(ctx context.Context, payload AlertPayload) error {
 	}
 
 	msg := buildEmailMessage(s.cfg.From, recipients, payload)
+	return s.send(ctx, s.cfg.From, recipients, msg)
+}
+
+// NewSMTPMailer returns an SMTP sender for non-alert notifications, or nil
+// when SMTP is not configured.
+func NewSMTPMailer(cfg config.SMTPConfig) *SMTPSink {
+	if strings.TrimSpace(cfg.Host) == "" || cfg.Port == 0 || strings.TrimSpace(cfg.From) == "" {
+		return nil
+	}
+	return &SMTPSink{cfg: cfg}
+}
+
+// SendMail delivers a plain-text email to the recipients.
+func (s *SMTPSink) SendMail(ctx context.Context, to []string, subject, body string) error {
+	if s == nil || len(to) == 0 {
+		return nil
+	}
+	return s.send(ctx, s.cfg.From, to, buildMessage(s.cfg.From, to, subject, body))
+}
+
+// SendMailFrom is SendMail with a sender other than smtp.from; an empty from
+// falls back to it.
+func (s *SMTPSink) SendMailFrom(ctx context.Context, from string, to []string, subject, body string) error {
+	if s == nil || len(to) == 0 {
+		return nil
+	}
+	if strings.TrimSpace(from) == "" {
+		from = s.cfg.From
+	}
+	return s.send(ctx, from, to, buildMessage(from, to, subject, body))
+}
+
+func (s *SMTPSink) send(ctx context.Context, from string, recipients []string, msg []byte) error {
 	addr := fmt.Sprintf("%s:%d", s.cfg.Host, s.cfg.Port)
 	client, err := s.newClient(ctx, addr)
 	if err != nil {
@@ -43,7 +76,7 @@ func (s *SMTPSink) Notify(ctx context.Context, payload AlertPayload) error {
 	}
 	defer client.Close()
 
-	if err := client.Mail(s.cfg.From); err != nil {
+	if err := client.Mail(from); err != nil {
 		client.Quit()
 		return err
 	}
@@ -108,9 +141,18 @@ func (s *SMTPSink) newClient(ctx context.Context, addr string) (*smtp.Client, er
 }
 
 func buildEmailMessage(from string, to []string, payload AlertPayload) []byte {
+	if payload.Spike != nil {
+		subject := fmt.Sprintf("[Usage Spike] %s for tenant %s", payload.Spike.Metric, payload.TenantID)
+		return buildMessage(from, to, subject, formatSpikeEmailBody(payload))
+	}
 	subject := fmt.Sprintf("[Budget %s] Tenant %s", strings.ToUpper(string(payload.Level)), payload.TenantID)
-	body := formatEmailBody(payload)
+	if payload.Escalation > 0 {
+		subject = fmt.Sprintf("[Budget %s - Escalation %d] Tenant %s", strings.ToUpper(string(payload.Level)), payload.Escalation, payload.TenantID)
+	}
+	return buildMessage(from, to, subject, formatEmailBody(payload))
+}
 
+func buildMessage(from string, to []string, subject, body string) []byte {
 	var buf bytes.Buffer
 	buf.WriteString(fmt.Sprintf("From: %s\r\n", from))
 	buf.WriteString(fmt.Sprintf("To: %s\r\n", strings.Join(to, ",")))
@@ -131,9 +173,15 @@ func formatEmailBody(payload AlertPayload) string {
 	var b strings.Builder
 	fmt.Fprintf(&b, "Tenant ID: %s\n", payload.TenantID)
 	fmt.Fprintf(&b, "Level: %s\n", strings.ToUpper(string(payload.Level)))
+	if payload.Escalation > 0 {
+		fmt.Fprintf(&b, "Escalation Tier: %d\n", payload.Escalation)
+	}
 	fmt.Fprintf(&b, "Spend: %s / %s\n", spend, limit)
 	fmt.Fprintf(&b, "Exceeded: %t\n", payload.Status.Exceeded)
 	fmt.Fprintf(&b, "Warning: %t\n", payload.Status.Warning)
+	if payload.Status.SoftLimit {
+		b.WriteString("Enforcement: soft (requests are still allowed)\n")
+	}
 	if payload.APIKeyPrefix != "" {
 		fmt.Fprintf(&b, "API Key Prefix: %s\n", payload.APIKeyPrefix)
 	}
@@ -144,6 +192,18 @@ func formatEmailBody(payload AlertPayload) string {
 	return b.String()
 }
 
+func formatSpikeEmailBody(payload AlertPayload) string {
+	spike := payload.Spike
+	var b strings.Builder
+	fmt.Fprintf(&b, "Tenant ID: %s\n", payload.TenantID)
+	fmt.Fprintf(&b, "Metric: %s\n", spike.Metric)
+	fmt.Fprintf(&b, "Hour: %s\n", spike.Hour.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&b, "Count: %d\n", spike.Count)
+	fmt.Fprintf(&b, "Hourly Average (%dh): %.1f\n", spike.LookbackHours, spike.Average)
+	fmt.Fprintf(&b, "Increase: %.0f%% (threshold %d%%)\n", spike.IncreasePerc, spike.ThresholdPerc)
+	return b.String()
+}
+
 func formatCurrency(cents int64) string {
 	return fmt.Sprintf("$%.2f", float64(cents)/100)
 }
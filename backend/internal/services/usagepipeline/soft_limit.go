@@ -0,0 +1,49 @@
+package usagepipeline
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+// auditSoftLimitExceeded is the audit action logged for every request a soft
+// budget lets through after the budget is spent.
+const auditSoftLimitExceeded = "budget.soft_limit_exceeded"
+
+type auditQueries interface {
+	InsertAuditLog(ctx context.Context, arg db.InsertAuditLogParams) (db.AdminAuditLog, error)
+}
+
+// Blocked reports whether the request must be rejected: the budget is spent
+// and the tenant enforces it as a hard limit.
+func (s BudgetStatus) Blocked() bool {
+	return s.Exceeded && !s.SoftLimit
+}
+
+func (l *Logger) auditSoftLimit(ctx context.Context, rec Record, status BudgetStatus) {
+	if l.audit == nil || rec.Context == nil {
+		return
+	}
+	meta, err := json.Marshal(map[string]any{
+		"model_alias":      rec.Alias,
+		"provider":         rec.Provider,
+		"api_key_prefix":   rec.Context.APIKeyPrefix,
+		"trace_id":         rec.TraceID,
+		"total_cost_cents": status.TotalCostCents,
+		"limit_cents":      status.LimitCents,
+	})
+	if err != nil {
+		return
+	}
+	tenantID := rec.Context.TenantID.String()
+	if _, err := l.audit.InsertAuditLog(ctx, db.InsertAuditLogParams{
+		Action:       auditSoftLimitExceeded,
+		ResourceType: "tenant",
+		ResourceID:   tenantID,
+		Metadata:     meta,
+	}); err != nil {
+		slog.Error("audit soft budget overage", slog.String("tenant_id", tenantID), slog.String("error", err.Error()))
+	}
+}
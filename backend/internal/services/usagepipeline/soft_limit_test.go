@@ -0,0 +1,66 @@
+package usagepipeline
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+	"github.com/ncecere/open_model_gateway/backend/internal/requestctx"
+)
+
+type recordingAudit struct {
+	entries []db.InsertAuditLogParams
+}
+
+func (r *recordingAudit) InsertAuditLog(_ context.Context, arg db.InsertAuditLogParams) (db.AdminAuditLog, error) {
+	r.entries = append(r.entries, arg)
+	return db.AdminAuditLog{}, nil
+}
+
+func TestBudgetStatusBlocked(t *testing.T) {
+	cases := []struct {
+		status BudgetStatus
+		want   bool
+	}{
+		{status: BudgetStatus{}, want: false},
+		{status: BudgetStatus{Exceeded: true}, want: true},
+		{status: BudgetStatus{Exceeded: true, SoftLimit: true}, want: false},
+		{status: BudgetStatus{Warning: true, SoftLimit: true}, want: false},
+	}
+	for _, tc := range cases {
+		if got := tc.status.Blocked(); got != tc.want {
+			t.Errorf("Blocked(%+v) = %v, want %v", tc.status, got, tc.want)
+		}
+	}
+}
+
+func TestAuditSoftLimitRecordsOverage(t *testing.T) {
+	audit := &recordingAudit{}
+	logger := &Logger{audit: audit}
+	tenantID := uuid.New()
+	rec := Record{
+		Context:  &requestctx.Context{TenantID: tenantID, APIKeyPrefix: "sk-abc", BudgetSoftLimit: true},
+		Alias:    "gpt-4o",
+		Provider: "openai",
+	}
+
+	logger.auditSoftLimit(context.Background(), rec, BudgetStatus{TotalCostCents: 1200, LimitCents: 1000, Exceeded: true, SoftLimit: true})
+
+	if len(audit.entries) != 1 {
+		t.Fatalf("expected one audit entry, got %d", len(audit.entries))
+	}
+	entry := audit.entries[0]
+	if entry.Action != auditSoftLimitExceeded || entry.ResourceType != "tenant" || entry.ResourceID != tenantID.String() {
+		t.Fatalf("unexpected audit entry %+v", entry)
+	}
+	var meta map[string]any
+	if err := json.Unmarshal(entry.Metadata, &meta); err != nil {
+		t.Fatalf("decode metadata: %v", err)
+	}
+	if meta["model_alias"] != "gpt-4o" || meta["total_cost_cents"] != float64(1200) {
+		t.Fatalf("unexpected metadata %v", meta)
+	}
+}
@@ -0,0 +1,279 @@
+package usagepipeline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+const (
+	SpikeMetricRequests = "requests"
+	SpikeMetricTokens   = "tokens"
+)
+
+// UsageSpikeAlert describes an hour whose volume exceeded the rolling hourly
+// average by at least the configured percentage.
+type UsageSpikeAlert struct {
+	Metric        string    `json:"metric"`
+	Hour          time.Time `json:"hour"`
+	Count         int64     `json:"count"`
+	Average       float64   `json:"average"`
+	IncreasePerc  float64   `json:"increase_perc"`
+	ThresholdPerc int       `json:"threshold_perc"`
+	LookbackHours int       `json:"lookback_hours"`
+}
+
+// TenantUsageAlertConfig is a tenant's spike alert subscription, stored in
+// tenant_budget_overrides.usage_alert_config. Zero fields fall back to
+// budgets.alert.usage; alerts go to the override's alert channels.
+type TenantUsageAlertConfig struct {
+	Enabled               bool `json:"enabled"`
+	RequestSpikeThreshold int  `json:"request_spike_threshold,omitempty"`
+	TokenSpikeThreshold   int  `json:"token_spike_threshold,omitempty"`
+	LookbackHours         int  `json:"lookback_hours,omitempty"`
+}
+
+// ParseTenantUsageAlertConfig decodes a stored subscription; ok is false
+// when none is stored.
+func ParseTenantUsageAlertConfig(raw []byte) (TenantUsageAlertConfig, bool, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return TenantUsageAlertConfig{}, false, nil
+	}
+	var cfg TenantUsageAlertConfig
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return TenantUsageAlertConfig{}, false, err
+	}
+	return cfg, true, nil
+}
+
+// Validate rejects negative thresholds and lookback windows.
+func (c TenantUsageAlertConfig) Validate() error {
+	if c.RequestSpikeThreshold < 0 || c.TokenSpikeThreshold < 0 {
+		return fmt.Errorf("usage alert spike thresholds must be >= 0")
+	}
+	if c.LookbackHours < 0 {
+		return fmt.Errorf("usage alert lookback_hours must be >= 0")
+	}
+	return nil
+}
+
+// Merge overlays the tenant's settings on the gateway-wide defaults.
+func (c TenantUsageAlertConfig) Merge(base config.UsageAlertConfig) config.UsageAlertConfig {
+	out := base
+	if c.RequestSpikeThreshold > 0 {
+		out.RequestSpikeThreshold = c.RequestSpikeThreshold
+	}
+	if c.TokenSpikeThreshold > 0 {
+		out.TokenSpikeThreshold = c.TokenSpikeThreshold
+	}
+	if c.LookbackHours > 0 {
+		out.LookbackWindow = time.Duration(c.LookbackHours) * time.Hour
+	}
+	return out
+}
+
+type spikeQueries interface {
+	AggregateUsageHourly(ctx context.Context, arg db.AggregateUsageHourlyParams) ([]db.AggregateUsageHourlyRow, error)
+	ListTenantBudgetOverrides(ctx context.Context) ([]db.TenantBudgetOverride, error)
+}
+
+// SpikeDetector compares the last complete hour of usage with the rolling
+// hourly average and notifies the alert sinks when it spikes. Gateway-wide
+// totals are checked against budgets.alert.usage and reported to the global
+// alert channels (with a nil tenant ID); subscribed tenants are checked
+// individually. Fired alerts are claimed in Redis so each spike notifies once
+// across instances.
+type SpikeDetector struct {
+	queries  spikeQueries
+	client   *redis.Client
+	sink     AlertSink
+	cfg      config.UsageAlertConfig
+	channels AlertChannels
+}
+
+func NewSpikeDetector(queries spikeQueries, client *redis.Client, sink AlertSink, alertCfg config.BudgetAlertConfig) *SpikeDetector {
+	if sink == nil {
+		sink = NewLogAlertSink(nil)
+	}
+	var channels AlertChannels
+	if alertCfg.Enabled {
+		channels = AlertChannels{Emails: alertCfg.Emails, Webhooks: alertCfg.Webhooks}
+	}
+	return &SpikeDetector{
+		queries:  queries,
+		client:   client,
+		sink:     sink,
+		cfg:      alertCfg.Usage,
+		channels: channels,
+	}
+}
+
+// Run checks the hour before now and returns how many alerts fired.
+func (d *SpikeDetector) Run(ctx context.Context, now time.Time) (int, error) {
+	if d == nil || d.queries == nil {
+		return 0, nil
+	}
+	hour := now.UTC().Truncate(time.Hour).Add(-time.Hour)
+
+	var errs []error
+	fired := 0
+	if d.cfg.Enabled() && hasChannels(d.channels) {
+		n, err := d.check(ctx, uuid.Nil, d.cfg, d.channels, hour)
+		fired += n
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	overrides, err := d.queries.ListTenantBudgetOverrides(ctx)
+	if err != nil {
+		return fired, errors.Join(append(errs, err)...)
+	}
+	for _, override := range overrides {
+		sub, ok, err := ParseTenantUsageAlertConfig(override.UsageAlertConfig)
+		if err != nil || !ok || !sub.Enabled {
+			continue
+		}
+		cfg := sub.Merge(d.cfg)
+		channels := AlertChannels{Emails: override.AlertEmails, Webhooks: override.AlertWebhooks}
+		if !cfg.Enabled() || !hasChannels(channels) {
+			continue
+		}
+		n, err := d.check(ctx, uuid.UUID(override.TenantID.Bytes), cfg, channels, hour)
+		fired += n
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return fired, errors.Join(errs...)
+}
+
+func (d *SpikeDetector) check(ctx context.Context, tenantID uuid.UUID, cfg config.UsageAlertConfig, channels AlertChannels, hour time.Time) (int, error) {
+	params := db.AggregateUsageHourlyParams{
+		Ts:   pgtype.Timestamptz{Time: hour.Add(-lookbackWindow(cfg)), Valid: true},
+		Ts_2: pgtype.Timestamptz{Time: hour.Add(time.Hour), Valid: true},
+	}
+	if tenantID != uuid.Nil {
+		params.Column1 = toPgUUID(tenantID)
+	}
+	rows, err := d.queries.AggregateUsageHourly(ctx, params)
+	if err != nil {
+		return 0, err
+	}
+
+	fired := 0
+	var firstErr error
+	for _, spike := range DetectSpikes(rows, hour, cfg) {
+		key, claimed, err := d.claim(ctx, tenantID, spike)
+		if err != nil {
+			return fired, err
+		}
+		if !claimed {
+			continue
+		}
+		payload := AlertPayload{
+			TenantID:  tenantID,
+			Level:     AlertLevelUsageSpike,
+			Channels:  channels,
+			Timestamp: hour.Add(time.Hour),
+			Spike:     &spike,
+		}
+		if err := d.sink.Notify(ctx, payload); err != nil {
+			// Release the claim so the next run retries the notification.
+			if d.client != nil {
+				d.client.Del(ctx, key)
+			}
+			slog.Error("usage spike notify failed",
+				slog.String("tenant_id", tenantID.String()),
+				slog.String("metric", spike.Metric),
+				slog.String("error", err.Error()))
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		fired++
+	}
+	return fired, firstErr
+}
+
+func (d *SpikeDetector) claim(ctx context.Context, tenantID uuid.UUID, spike UsageSpikeAlert) (string, bool, error) {
+	key := fmt.Sprintf("usage_spike:%s:%s:%s", tenantID, spike.Metric, spike.Hour.UTC().Format("2006010215"))
+	if d.client == nil {
+		return key, true, nil
+	}
+	ok, err := d.client.SetNX(ctx, key, 1, 2*time.Hour).Result()
+	return key, ok, err
+}
+
+// DetectSpikes compares hour's totals with the average of the preceding
+// lookback window. Hours without usage count as zero; a metric with no
+// baseline usage never spikes.
+func DetectSpikes(rows []db.AggregateUsageHourlyRow, hour time.Time, cfg config.UsageAlertConfig) []UsageSpikeAlert {
+	hour = hour.UTC().Truncate(time.Hour)
+	window := lookbackWindow(cfg)
+	hours := int(window / time.Hour)
+	start := hour.Add(-window)
+
+	var currentRequests, currentTokens, baselineRequests, baselineTokens int64
+	for _, row := range rows {
+		if !row.Hour.Valid {
+			continue
+		}
+		ts := row.Hour.Time.UTC().Truncate(time.Hour)
+		switch {
+		case ts.Equal(hour):
+			currentRequests += row.Requests
+			currentTokens += row.Tokens
+		case !ts.Before(start) && ts.Before(hour):
+			baselineRequests += row.Requests
+			baselineTokens += row.Tokens
+		}
+	}
+
+	var spikes []UsageSpikeAlert
+	check := func(metric string, current, baseline int64, threshold int) {
+		if threshold <= 0 || baseline <= 0 {
+			return
+		}
+		avg := float64(baseline) / float64(hours)
+		increase := (float64(current) - avg) / avg * 100
+		if increase < float64(threshold) {
+			return
+		}
+		spikes = append(spikes, UsageSpikeAlert{
+			Metric:        metric,
+			Hour:          hour,
+			Count:         current,
+			Average:       avg,
+			IncreasePerc:  increase,
+			ThresholdPerc: threshold,
+			LookbackHours: hours,
+		})
+	}
+	check(SpikeMetricRequests, currentRequests, baselineRequests, cfg.RequestSpikeThreshold)
+	check(SpikeMetricTokens, currentTokens, baselineTokens, cfg.TokenSpikeThreshold)
+	return spikes
+}
+
+func lookbackWindow(cfg config.UsageAlertConfig) time.Duration {
+	window := cfg.LookbackWindow.Truncate(time.Hour)
+	if window < time.Hour {
+		window = 24 * time.Hour
+	}
+	return window
+}
+
+func hasChannels(channels AlertChannels) bool {
+	return len(channels.Emails) > 0 || len(channels.Webhooks) > 0
+}
@@ -0,0 +1,168 @@
+package usagepipeline
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+type fakeSpikeQueries struct {
+	hourly    map[uuid.UUID][]db.AggregateUsageHourlyRow
+	overrides []db.TenantBudgetOverride
+}
+
+func (f *fakeSpikeQueries) AggregateUsageHourly(_ context.Context, arg db.AggregateUsageHourlyParams) ([]db.AggregateUsageHourlyRow, error) {
+	var tenantID uuid.UUID
+	if arg.Column1.Valid {
+		tenantID = uuid.UUID(arg.Column1.Bytes)
+	}
+	var out []db.AggregateUsageHourlyRow
+	for _, row := range f.hourly[tenantID] {
+		if !row.Hour.Time.Before(arg.Ts.Time) && row.Hour.Time.Before(arg.Ts_2.Time) {
+			out = append(out, row)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeSpikeQueries) ListTenantBudgetOverrides(context.Context) ([]db.TenantBudgetOverride, error) {
+	return f.overrides, nil
+}
+
+// seedHourly returns lookback hours of steady usage before hour followed by
+// the given latest-hour totals.
+func seedHourly(hour time.Time, lookback int, requests, tokens, latestRequests, latestTokens int64) []db.AggregateUsageHourlyRow {
+	rows := make([]db.AggregateUsageHourlyRow, 0, lookback+1)
+	for i := lookback; i > 0; i-- {
+		rows = append(rows, db.AggregateUsageHourlyRow{
+			Hour:     pgtype.Timestamptz{Time: hour.Add(-time.Duration(i) * time.Hour), Valid: true},
+			Requests: requests,
+			Tokens:   tokens,
+		})
+	}
+	return append(rows, db.AggregateUsageHourlyRow{
+		Hour:     pgtype.Timestamptz{Time: hour, Valid: true},
+		Requests: latestRequests,
+		Tokens:   latestTokens,
+	})
+}
+
+func TestDetectSpikesAtThreshold(t *testing.T) {
+	hour := time.Date(2025, 11, 17, 10, 0, 0, 0, time.UTC)
+	cfg := config.UsageAlertConfig{RequestSpikeThreshold: 100, TokenSpikeThreshold: 100, LookbackWindow: 24 * time.Hour}
+
+	// Exactly double the average trips a 100% threshold.
+	spikes := DetectSpikes(seedHourly(hour, 24, 50, 1000, 100, 1999), hour, cfg)
+	if len(spikes) != 1 {
+		t.Fatalf("expected one spike, got %+v", spikes)
+	}
+	got := spikes[0]
+	if got.Metric != SpikeMetricRequests || got.Count != 100 || got.Average != 50 || got.IncreasePerc != 100 || got.LookbackHours != 24 {
+		t.Fatalf("unexpected spike %+v", got)
+	}
+
+	if spikes := DetectSpikes(seedHourly(hour, 24, 50, 1000, 99, 1000), hour, cfg); len(spikes) != 0 {
+		t.Fatalf("expected no spike below threshold, got %+v", spikes)
+	}
+}
+
+func TestDetectSpikesCountsMissingHoursAsZero(t *testing.T) {
+	hour := time.Date(2025, 11, 17, 10, 0, 0, 0, time.UTC)
+	cfg := config.UsageAlertConfig{RequestSpikeThreshold: 300, LookbackWindow: 4 * time.Hour}
+	rows := []db.AggregateUsageHourlyRow{
+		{Hour: pgtype.Timestamptz{Time: hour.Add(-2 * time.Hour), Valid: true}, Requests: 40},
+		{Hour: pgtype.Timestamptz{Time: hour, Valid: true}, Requests: 40},
+	}
+	spikes := DetectSpikes(rows, hour, cfg)
+	if len(spikes) != 1 || spikes[0].Average != 10 {
+		t.Fatalf("expected spike against a zero-filled average of 10, got %+v", spikes)
+	}
+
+	if spikes := DetectSpikes(rows[1:], hour, cfg); len(spikes) != 0 {
+		t.Fatalf("no baseline should never spike, got %+v", spikes)
+	}
+}
+
+func TestSpikeDetectorNotifiesSubscribedTenantsOnce(t *testing.T) {
+	now := time.Date(2025, 11, 17, 11, 5, 0, 0, time.UTC)
+	hour := time.Date(2025, 11, 17, 10, 0, 0, 0, time.UTC)
+	subscribed := uuid.New()
+	unsubscribed := uuid.New()
+	subscription, _ := json.Marshal(TenantUsageAlertConfig{Enabled: true, TokenSpikeThreshold: 50, LookbackHours: 6})
+
+	queries := &fakeSpikeQueries{
+		hourly: map[uuid.UUID][]db.AggregateUsageHourlyRow{
+			subscribed:   seedHourly(hour, 6, 10, 1000, 10, 1500),
+			unsubscribed: seedHourly(hour, 6, 10, 1000, 100, 9000),
+		},
+		overrides: []db.TenantBudgetOverride{
+			{TenantID: toPgUUID(subscribed), AlertWebhooks: []string{"https://hooks.example.com/spike"}, UsageAlertConfig: subscription},
+			{TenantID: toPgUUID(unsubscribed), AlertEmails: []string{"ops@example.com"}},
+		},
+	}
+
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	t.Cleanup(server.Close)
+	rdb := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+
+	sink := &stubSink{}
+	detector := NewSpikeDetector(queries, rdb, sink, config.BudgetAlertConfig{})
+
+	fired, err := detector.Run(context.Background(), now)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if fired != 1 || len(sink.payloads) != 1 {
+		t.Fatalf("expected one alert, got %d (%+v)", fired, sink.payloads)
+	}
+	payload := sink.payloads[0]
+	if payload.TenantID != subscribed || payload.Level != AlertLevelUsageSpike || payload.Spike == nil {
+		t.Fatalf("unexpected payload %+v", payload)
+	}
+	if payload.Spike.Metric != SpikeMetricTokens || !payload.Spike.Hour.Equal(hour) || payload.Spike.ThresholdPerc != 50 {
+		t.Fatalf("unexpected spike %+v", payload.Spike)
+	}
+	if len(payload.Channels.Webhooks) != 1 {
+		t.Fatalf("expected the override's webhook channel, got %+v", payload.Channels)
+	}
+
+	if fired, err := detector.Run(context.Background(), now.Add(10*time.Minute)); err != nil || fired != 0 {
+		t.Fatalf("expected the spike to fire once, got %d (%v)", fired, err)
+	}
+}
+
+func TestSpikeDetectorChecksGatewayTotals(t *testing.T) {
+	now := time.Date(2025, 11, 17, 11, 0, 0, 0, time.UTC)
+	hour := now.Add(-time.Hour)
+	queries := &fakeSpikeQueries{
+		hourly: map[uuid.UUID][]db.AggregateUsageHourlyRow{
+			uuid.Nil: seedHourly(hour, 24, 100, 0, 400, 0),
+		},
+	}
+	sink := &stubSink{}
+	detector := NewSpikeDetector(queries, nil, sink, config.BudgetAlertConfig{
+		Enabled: true,
+		Emails:  []string{"ops@example.com"},
+		Usage:   config.UsageAlertConfig{RequestSpikeThreshold: 200, LookbackWindow: 24 * time.Hour},
+	})
+	fired, err := detector.Run(context.Background(), now)
+	if err != nil || fired != 1 {
+		t.Fatalf("expected gateway spike, got %d (%v)", fired, err)
+	}
+	if sink.payloads[0].TenantID != uuid.Nil || sink.payloads[0].Spike.IncreasePerc != 300 {
+		t.Fatalf("unexpected payload %+v", sink.payloads[0])
+	}
+}
@@ -6,6 +6,11 @@ import (
 	"github.com/ncecere/open_model_gateway/backend/internal/config"
 )
 
+// BudgetPeriod returns the budget window containing now for schedule.
+func BudgetPeriod(now time.Time, schedule string) (time.Time, time.Time) {
+	return periodBounds(now, schedule)
+}
+
 func periodBounds(now time.Time, schedule string) (time.Time, time.Time) {
 	nowUTC := now.UTC()
 	normalized := config.NormalizeBudgetRefreshSchedule(schedule)
@@ -1,45 +1,47 @@
 package usagepipeline
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
-	"net/http"
 	"strings"
 	"time"
 
-	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/google/uuid"
 )
 
-// WebhookSink delivers alerts to arbitrary HTTP endpoints.
+// Event names that label alerts in the webhook delivery queue.
+const (
+	webhookAlertEvent      = "budget.alert"
+	webhookUsageSpikeEvent = "usage.spike"
+)
+
+// WebhookQueue persists outbound webhooks for background delivery.
+type WebhookQueue interface {
+	Enqueue(ctx context.Context, tenantID uuid.UUID, event, url string, payload []byte) error
+}
+
+// WebhookSink hands alerts to a WebhookQueue, which signs each delivery
+// with the alert tenant's secret and retries failed ones.
 type WebhookSink struct {
-	client     *http.Client
-	maxRetries int
-	logger     *slog.Logger
+	queue  WebhookQueue
+	logger *slog.Logger
 }
 
-func NewWebhookSink(cfg config.WebhookConfig, logger *slog.Logger) AlertSink {
+// NewQueuedWebhookSink hands alerts to queue instead of posting them inline,
+// so a failing endpoint is retried with backoff and never delays the usage
+// pipeline. The queue signs each delivery for the alert's tenant.
+func NewQueuedWebhookSink(queue WebhookQueue, logger *slog.Logger) AlertSink {
 	if logger == nil {
 		logger = slog.Default()
 	}
-	if cfg.Timeout <= 0 {
-		cfg.Timeout = 5 * time.Second
-	}
-	if cfg.MaxRetries <= 0 {
-		cfg.MaxRetries = 1
-	}
-	return &WebhookSink{
-		client:     &http.Client{Timeout: cfg.Timeout},
-		maxRetries: cfg.MaxRetries,
-		logger:     logger,
-	}
+	return &WebhookSink{queue: queue, logger: logger}
 }
 
 func (s *WebhookSink) Notify(ctx context.Context, payload AlertPayload) error {
-	if s == nil {
+	if s == nil || s.queue == nil {
 		return nil
 	}
 	urls := payload.Channels.Webhooks
@@ -50,24 +52,31 @@ func (s *WebhookSink) Notify(ctx context.Context, payload AlertPayload) error {
 	body, err := json.Marshal(webhookPayload{
 		TenantID:       payload.TenantID.String(),
 		Level:          string(payload.Level),
+		EscalationTier: payload.Escalation,
 		LimitCents:     payload.Status.LimitCents,
 		TotalCostCents: payload.Status.TotalCostCents,
 		Warning:        payload.Status.Warning,
 		Exceeded:       payload.Status.Exceeded,
+		SoftLimit:      payload.Status.SoftLimit,
 		APIKeyPrefix:   payload.APIKeyPrefix,
 		ModelAlias:     payload.ModelAlias,
 		Timestamp:      payload.Timestamp.UTC(),
+		UsageSpike:     payload.Spike,
 	})
 	if err != nil {
 		return err
 	}
+	event := webhookAlertEvent
+	if payload.Spike != nil {
+		event = webhookUsageSpikeEvent
+	}
 
 	var errs []error
 	for _, target := range urls {
 		if strings.TrimSpace(target) == "" {
 			continue
 		}
-		if err := s.postWithRetries(ctx, target, body); err != nil {
+		if err := s.queue.Enqueue(ctx, payload.TenantID, event, target, body); err != nil {
 			errs = append(errs, fmt.Errorf("%s: %w", target, err))
 		}
 	}
@@ -77,50 +86,19 @@ func (s *WebhookSink) Notify(ctx context.Context, payload AlertPayload) error {
 	return nil
 }
 
-func (s *WebhookSink) postWithRetries(ctx context.Context, url string, body []byte) error {
-	var lastErr error
-	for attempt := 1; attempt <= s.maxRetries; attempt++ {
-		if err := s.post(ctx, url, body); err != nil {
-			lastErr = err
-			delay := time.Duration(attempt) * 250 * time.Millisecond
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			case <-time.After(delay):
-			}
-			continue
-		}
-		return nil
-	}
-	return lastErr
-}
-
-func (s *WebhookSink) post(ctx context.Context, url string, body []byte) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
-	if err != nil {
-		return err
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := s.client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode >= 400 {
-		return fmt.Errorf("status %d", resp.StatusCode)
-	}
-	return nil
-}
-
 type webhookPayload struct {
-	TenantID       string    `json:"tenant_id"`
-	Level          string    `json:"level"`
-	LimitCents     int64     `json:"limit_cents"`
-	TotalCostCents int64     `json:"total_cost_cents"`
-	Warning        bool      `json:"warning"`
-	Exceeded       bool      `json:"exceeded"`
-	APIKeyPrefix   string    `json:"api_key_prefix"`
-	ModelAlias     string    `json:"model_alias"`
-	Timestamp      time.Time `json:"timestamp"`
+	TenantID       string `json:"tenant_id"`
+	Level          string `json:"level"`
+	EscalationTier int    `json:"escalation_tier,omitempty"`
+	LimitCents     int64  `json:"limit_cents"`
+	TotalCostCents int64  `json:"total_cost_cents"`
+	Warning        bool   `json:"warning"`
+	Exceeded       bool   `json:"exceeded"`
+	// SoftLimit marks tenants whose requests keep flowing past the budget.
+	SoftLimit    bool      `json:"soft_limit,omitempty"`
+	APIKeyPrefix string    `json:"api_key_prefix"`
+	ModelAlias   string    `json:"model_alias"`
+	Timestamp    time.Time `json:"timestamp"`
+	// UsageSpike is set on usage.spike events.
+	UsageSpike *UsageSpikeAlert `json:"usage_spike,omitempty"`
 }
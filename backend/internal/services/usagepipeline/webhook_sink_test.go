@@ -3,39 +3,52 @@ package usagepipeline
 import (
 	"context"
 	"encoding/json"
-	"net/http"
-	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
-
-	"github.com/ncecere/open_model_gateway/backend/internal/config"
 )
 
-func TestWebhookSinkNotify(t *testing.T) {
-	var received webhookPayload
-	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		defer r.Body.Close()
-		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			return
-		}
-		w.WriteHeader(http.StatusOK)
-	}))
-	defer ts.Close()
+type queuedWebhook struct {
+	tenantID uuid.UUID
+	event    string
+	url      string
+	payload  []byte
+}
 
-	sink := NewWebhookSink(config.WebhookConfig{Timeout: time.Second, MaxRetries: 1}, nil)
+type fakeWebhookQueue struct {
+	queued []queuedWebhook
+}
+
+func (q *fakeWebhookQueue) Enqueue(_ context.Context, tenantID uuid.UUID, event, url string, payload []byte) error {
+	q.queued = append(q.queued, queuedWebhook{tenantID: tenantID, event: event, url: url, payload: payload})
+	return nil
+}
+
+func TestWebhookSinkNotify(t *testing.T) {
+	queue := &fakeWebhookQueue{}
+	sink := NewQueuedWebhookSink(queue, nil)
 	payload := AlertPayload{
 		TenantID:  uuid.New(),
 		Level:     AlertLevelWarning,
 		Status:    BudgetStatus{LimitCents: 10000, TotalCostCents: 8000, Warning: true},
-		Channels:  AlertChannels{Webhooks: []string{ts.URL}},
+		Channels:  AlertChannels{Webhooks: []string{"https://hooks.example.com/budget", " "}},
 		Timestamp: time.Now(),
 	}
 	if err := sink.Notify(context.Background(), payload); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
+	if len(queue.queued) != 1 {
+		t.Fatalf("expected one queued delivery, got %d", len(queue.queued))
+	}
+	delivery := queue.queued[0]
+	if delivery.tenantID != payload.TenantID || delivery.event != webhookAlertEvent || delivery.url != "https://hooks.example.com/budget" {
+		t.Fatalf("unexpected delivery %+v", delivery)
+	}
+	var received webhookPayload
+	if err := json.Unmarshal(delivery.payload, &received); err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
 	if received.TenantID != payload.TenantID.String() {
 		t.Fatalf("tenant mismatch")
 	}
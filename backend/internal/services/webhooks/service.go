@@ -0,0 +1,324 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+const (
+	pollInterval = time.Second
+	claimBatch   = 50
+	maxErrorLen  = 1024
+)
+
+// maxFailures is how many failed attempts a delivery gets. The last one marks
+// it dead and only an admin retry will send it again.
+const maxFailures = 5
+
+// retryBackoff is the wait before each retry of a failed delivery, indexed by
+// the number of failures so far.
+var retryBackoff = []time.Duration{
+	time.Second,
+	5 * time.Second,
+	30 * time.Second,
+	5 * time.Minute,
+}
+
+var (
+	// ErrDeliveryNotFound indicates no failed or dead delivery has the given id.
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+	// ErrInvalidStatus indicates an unknown delivery status filter.
+	ErrInvalidStatus = errors.New("invalid webhook delivery status")
+)
+
+// Delivery is an outbound webhook tracked in webhook_deliveries.
+type Delivery struct {
+	ID          uuid.UUID
+	Event       string
+	URL         string
+	Payload     json.RawMessage
+	Status      string
+	Attempts    int
+	LastError   string
+	NextRetryAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type deliveryQueries interface {
+	InsertWebhookDelivery(ctx context.Context, arg db.InsertWebhookDeliveryParams) (db.WebhookDelivery, error)
+	ClaimDueWebhookDeliveries(ctx context.Context, limit int32) ([]db.WebhookDelivery, error)
+	MarkWebhookDeliveryDelivered(ctx context.Context, id pgtype.UUID) error
+	MarkWebhookDeliveryFailed(ctx context.Context, arg db.MarkWebhookDeliveryFailedParams) error
+	ListWebhookDeliveries(ctx context.Context, arg db.ListWebhookDeliveriesParams) ([]db.WebhookDelivery, error)
+	RetryWebhookDelivery(ctx context.Context, id pgtype.UUID) (db.WebhookDelivery, error)
+	DeleteWebhookDeliveriesBefore(ctx context.Context, updatedAt pgtype.Timestamptz) (int64, error)
+	GetTenantBudgetOverride(ctx context.Context, tenantID pgtype.UUID) (db.TenantBudgetOverride, error)
+}
+
+// Service persists outbound webhooks and delivers them from a background
+// worker so callers never block on, or lose, a slow or failing endpoint.
+// Deliveries are signed (see Sign) with the tenant's alert_webhook_secret, or
+// the gateway-wide signing secret when the tenant has none.
+type Service struct {
+	queries   deliveryQueries
+	client    *http.Client
+	retention time.Duration
+	secret    string
+	logger    *slog.Logger
+}
+
+// NewService builds the delivery queue. cfg supplies the per-request timeout
+// and signing secret, and retention.webhook_retention_days bounds how long
+// finished deliveries are kept.
+func NewService(queries deliveryQueries, cfg config.WebhookConfig, retention config.RetentionConfig, logger *slog.Logger) *Service {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	days := retention.WebhookRetentionDays
+	if days <= 0 {
+		days = 30
+	}
+	return &Service{
+		queries:   queries,
+		client:    &http.Client{Timeout: timeout},
+		retention: time.Duration(days) * 24 * time.Hour,
+		secret:    cfg.SigningSecret,
+		logger:    logger,
+	}
+}
+
+// Enqueue records a delivery for the worker to send. tenantID selects the
+// signing secret; uuid.Nil marks gateway-wide notifications.
+func (s *Service) Enqueue(ctx context.Context, tenantID uuid.UUID, event, url string, payload []byte) error {
+	if s == nil || s.queries == nil {
+		return errors.New("webhook service not initialized")
+	}
+	params := db.InsertWebhookDeliveryParams{
+		Event:      event,
+		WebhookUrl: url,
+		Payload:    payload,
+	}
+	if tenantID != uuid.Nil {
+		params.TenantID = pgtype.UUID{Bytes: tenantID, Valid: true}
+	}
+	_, err := s.queries.InsertWebhookDelivery(ctx, params)
+	return err
+}
+
+// Run delivers due webhooks until ctx is cancelled.
+func (s *Service) Run(ctx context.Context) {
+	if s == nil || s.queries == nil {
+		return
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.ProcessDue(ctx); err != nil && ctx.Err() == nil {
+			s.logger.Error("webhook delivery worker", slog.String("error", err.Error()))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessDue claims due deliveries and attempts each once, returning how many
+// were attempted.
+func (s *Service) ProcessDue(ctx context.Context) (int, error) {
+	deliveries, err := s.queries.ClaimDueWebhookDeliveries(ctx, claimBatch)
+	if err != nil {
+		return 0, err
+	}
+	var errs []error
+	for _, delivery := range deliveries {
+		if err := s.attempt(ctx, delivery); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return len(deliveries), errors.Join(errs...)
+}
+
+func (s *Service) attempt(ctx context.Context, delivery db.WebhookDelivery) error {
+	secret, err := s.signingSecret(ctx, delivery.TenantID)
+	if err != nil {
+		return err
+	}
+	sendErr := s.post(ctx, delivery.WebhookUrl, delivery.Payload, secret)
+	if sendErr == nil {
+		return s.queries.MarkWebhookDeliveryDelivered(ctx, delivery.ID)
+	}
+
+	status, next := nextAttempt(int(delivery.Attempts)+1, time.Now().UTC())
+	params := db.MarkWebhookDeliveryFailedParams{
+		ID:        delivery.ID,
+		Status:    status,
+		LastError: pgtype.Text{String: truncate(sendErr.Error(), maxErrorLen), Valid: true},
+	}
+	if !next.IsZero() {
+		params.NextRetryAt = pgtype.Timestamptz{Time: next, Valid: true}
+	}
+	if status == db.WebhookDeliveryStatusDead {
+		s.logger.Warn("webhook delivery dead",
+			slog.String("event", delivery.Event),
+			slog.String("url", delivery.WebhookUrl),
+			slog.String("error", sendErr.Error()),
+		)
+	}
+	return s.queries.MarkWebhookDeliveryFailed(ctx, params)
+}
+
+// nextAttempt returns the status and retry time after the given number of
+// failed attempts. The zero time means no further automatic retry.
+func nextAttempt(failures int, now time.Time) (db.WebhookDeliveryStatus, time.Time) {
+	if failures >= maxFailures {
+		return db.WebhookDeliveryStatusDead, time.Time{}
+	}
+	return db.WebhookDeliveryStatusFailed, now.Add(retryBackoff[failures-1])
+}
+
+// signingSecret looks the secret up on every attempt so retries pick up a
+// rotated secret.
+func (s *Service) signingSecret(ctx context.Context, tenantID pgtype.UUID) (string, error) {
+	if !tenantID.Valid {
+		return s.secret, nil
+	}
+	override, err := s.queries.GetTenantBudgetOverride(ctx, tenantID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return s.secret, nil
+		}
+		return "", err
+	}
+	if override.AlertWebhookSecret != "" {
+		return override.AlertWebhookSecret, nil
+	}
+	return s.secret, nil
+}
+
+func (s *Service) post(ctx context.Context, url string, body []byte, secret string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range signatureHeaders(secret, body, time.Now()) {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// List returns deliveries newest first, optionally filtered by status.
+func (s *Service) List(ctx context.Context, status string, limit, offset int32) ([]Delivery, error) {
+	if s == nil || s.queries == nil {
+		return nil, errors.New("webhook service not initialized")
+	}
+	params := db.ListWebhookDeliveriesParams{ListLimit: limit, ListOffset: offset}
+	if status = strings.ToLower(strings.TrimSpace(status)); status != "" {
+		parsed, ok := parseStatus(status)
+		if !ok {
+			return nil, ErrInvalidStatus
+		}
+		params.StatusFilter = db.NullWebhookDeliveryStatus{WebhookDeliveryStatus: parsed, Valid: true}
+	}
+	rows, err := s.queries.ListWebhookDeliveries(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Delivery, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, toDelivery(row))
+	}
+	return out, nil
+}
+
+// Retry resets a failed or dead delivery so the worker sends it again with a
+// fresh retry budget.
+func (s *Service) Retry(ctx context.Context, id uuid.UUID) (Delivery, error) {
+	if s == nil || s.queries == nil {
+		return Delivery{}, errors.New("webhook service not initialized")
+	}
+	row, err := s.queries.RetryWebhookDelivery(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Delivery{}, ErrDeliveryNotFound
+		}
+		return Delivery{}, err
+	}
+	return toDelivery(row), nil
+}
+
+// PurgeExpired deletes delivered and dead deliveries older than the retention
+// window. Pending and failed deliveries are kept until they finish.
+func (s *Service) PurgeExpired(ctx context.Context, now time.Time) (int64, error) {
+	if s == nil || s.queries == nil {
+		return 0, nil
+	}
+	cutoff := now.Add(-s.retention)
+	return s.queries.DeleteWebhookDeliveriesBefore(ctx, pgtype.Timestamptz{Time: cutoff, Valid: true})
+}
+
+func parseStatus(value string) (db.WebhookDeliveryStatus, bool) {
+	switch status := db.WebhookDeliveryStatus(value); status {
+	case db.WebhookDeliveryStatusPending, db.WebhookDeliveryStatusDelivered,
+		db.WebhookDeliveryStatusFailed, db.WebhookDeliveryStatusDead:
+		return status, true
+	default:
+		return "", false
+	}
+}
+
+func toDelivery(row db.WebhookDelivery) Delivery {
+	delivery := Delivery{
+		ID:        uuid.UUID(row.ID.Bytes),
+		Event:     row.Event,
+		URL:       row.WebhookUrl,
+		Payload:   json.RawMessage(row.Payload),
+		Status:    string(row.Status),
+		Attempts:  int(row.Attempts),
+		LastError: row.LastError.String,
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+	if row.NextRetryAt.Valid {
+		next := row.NextRetryAt.Time
+		delivery.NextRetryAt = &next
+	}
+	return delivery
+}
+
+func truncate(value string, limit int) string {
+	if len(value) <= limit {
+		return value
+	}
+	return value[:limit]
+}
@@ -0,0 +1,226 @@
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ncecere/open_model_gateway/backend/internal/config"
+	"github.com/ncecere/open_model_gateway/backend/internal/db"
+)
+
+type fakeDeliveryQueries struct {
+	deliveries []db.WebhookDelivery
+	secrets    map[uuid.UUID]string
+}
+
+func (f *fakeDeliveryQueries) InsertWebhookDelivery(_ context.Context, arg db.InsertWebhookDeliveryParams) (db.WebhookDelivery, error) {
+	row := db.WebhookDelivery{
+		ID:         pgtype.UUID{Bytes: [16]byte{byte(len(f.deliveries) + 1)}, Valid: true},
+		Event:      arg.Event,
+		WebhookUrl: arg.WebhookUrl,
+		Payload:    arg.Payload,
+		Status:     db.WebhookDeliveryStatusPending,
+		TenantID:   arg.TenantID,
+	}
+	f.deliveries = append(f.deliveries, row)
+	return row, nil
+}
+
+// ClaimDueWebhookDeliveries ignores next_retry_at so tests can drive retries
+// without waiting out the backoff.
+func (f *fakeDeliveryQueries) ClaimDueWebhookDeliveries(context.Context, int32) ([]db.WebhookDelivery, error) {
+	var due []db.WebhookDelivery
+	for _, d := range f.deliveries {
+		if d.Status == db.WebhookDeliveryStatusPending || d.Status == db.WebhookDeliveryStatusFailed {
+			due = append(due, d)
+		}
+	}
+	return due, nil
+}
+
+func (f *fakeDeliveryQueries) MarkWebhookDeliveryDelivered(_ context.Context, id pgtype.UUID) error {
+	d := f.find(id)
+	d.Status = db.WebhookDeliveryStatusDelivered
+	d.Attempts++
+	return nil
+}
+
+func (f *fakeDeliveryQueries) MarkWebhookDeliveryFailed(_ context.Context, arg db.MarkWebhookDeliveryFailedParams) error {
+	d := f.find(arg.ID)
+	d.Status = arg.Status
+	d.Attempts++
+	d.LastError = arg.LastError
+	d.NextRetryAt = arg.NextRetryAt
+	return nil
+}
+
+func (f *fakeDeliveryQueries) ListWebhookDeliveries(context.Context, db.ListWebhookDeliveriesParams) ([]db.WebhookDelivery, error) {
+	return f.deliveries, nil
+}
+
+func (f *fakeDeliveryQueries) RetryWebhookDelivery(_ context.Context, id pgtype.UUID) (db.WebhookDelivery, error) {
+	d := f.find(id)
+	d.Status = db.WebhookDeliveryStatusPending
+	d.Attempts = 0
+	return *d, nil
+}
+
+func (f *fakeDeliveryQueries) DeleteWebhookDeliveriesBefore(context.Context, pgtype.Timestamptz) (int64, error) {
+	return 0, nil
+}
+
+func (f *fakeDeliveryQueries) GetTenantBudgetOverride(_ context.Context, tenantID pgtype.UUID) (db.TenantBudgetOverride, error) {
+	secret, ok := f.secrets[uuid.UUID(tenantID.Bytes)]
+	if !ok {
+		return db.TenantBudgetOverride{}, pgx.ErrNoRows
+	}
+	return db.TenantBudgetOverride{TenantID: tenantID, AlertWebhookSecret: secret}, nil
+}
+
+func (f *fakeDeliveryQueries) find(id pgtype.UUID) *db.WebhookDelivery {
+	for i := range f.deliveries {
+		if f.deliveries[i].ID == id {
+			return &f.deliveries[i]
+		}
+	}
+	return nil
+}
+
+func TestNextAttemptBackoff(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	want := []time.Duration{time.Second, 5 * time.Second, 30 * time.Second, 5 * time.Minute}
+	for i, delay := range want {
+		status, next := nextAttempt(i+1, now)
+		if status != db.WebhookDeliveryStatusFailed || !next.Equal(now.Add(delay)) {
+			t.Fatalf("failure %d: got %s at %s, want failed after %s", i+1, status, next, delay)
+		}
+	}
+	// The fifth failure is the last: the delivery is dead, not retried again.
+	if status, next := nextAttempt(5, now); status != db.WebhookDeliveryStatusDead || !next.IsZero() {
+		t.Fatalf("expected the fifth failure to mark the delivery dead, got %s at %s", status, next)
+	}
+}
+
+func TestProcessDueMarksDeadAndRetryRedelivers(t *testing.T) {
+	var healthy atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	queries := &fakeDeliveryQueries{}
+	svc := NewService(queries, config.WebhookConfig{Timeout: time.Second}, config.RetentionConfig{}, nil)
+	ctx := context.Background()
+	if err := svc.Enqueue(ctx, uuid.Nil, "budget.alert", srv.URL, []byte(`{"level":"warning"}`)); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+
+	for i := 0; i < maxFailures; i++ {
+		if _, err := svc.ProcessDue(ctx); err != nil {
+			t.Fatalf("process attempt %d: %v", i+1, err)
+		}
+	}
+	delivery := queries.deliveries[0]
+	if delivery.Status != db.WebhookDeliveryStatusDead {
+		t.Fatalf("expected dead delivery, got %s after %d attempts", delivery.Status, delivery.Attempts)
+	}
+	if delivery.LastError.String != "status 502" {
+		t.Fatalf("unexpected last error %q", delivery.LastError.String)
+	}
+	if n, _ := svc.ProcessDue(ctx); n != 0 {
+		t.Fatalf("dead delivery was attempted again automatically")
+	}
+
+	healthy.Store(true)
+	if _, err := svc.Retry(ctx, delivery.ID.Bytes); err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if _, err := svc.ProcessDue(ctx); err != nil {
+		t.Fatalf("process retry: %v", err)
+	}
+	if got := queries.deliveries[0]; got.Status != db.WebhookDeliveryStatusDelivered || got.Attempts != 1 {
+		t.Fatalf("expected delivered on first retried attempt, got %s/%d", got.Status, got.Attempts)
+	}
+}
+
+func TestListRejectsUnknownStatus(t *testing.T) {
+	svc := NewService(&fakeDeliveryQueries{}, config.WebhookConfig{}, config.RetentionConfig{}, nil)
+	if _, err := svc.List(context.Background(), "lost", 10, 0); err != ErrInvalidStatus {
+		t.Fatalf("expected ErrInvalidStatus, got %v", err)
+	}
+}
+
+func TestProcessDueSignsWithTenantSecret(t *testing.T) {
+	type received struct {
+		signature, timestamp string
+		body                 []byte
+	}
+	got := make(chan received, 4)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got <- received{r.Header.Get(SignatureHeader), r.Header.Get(TimestampHeader), body}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	tenant, bare := uuid.New(), uuid.New()
+	queries := &fakeDeliveryQueries{secrets: map[uuid.UUID]string{tenant: "tenant-secret"}}
+	svc := NewService(queries, config.WebhookConfig{Timeout: time.Second, SigningSecret: "gateway-secret"}, config.RetentionConfig{}, nil)
+	ctx := context.Background()
+	payload := []byte(`{"level":"exceeded"}`)
+	for _, id := range []uuid.UUID{tenant, bare, uuid.Nil} {
+		if err := svc.Enqueue(ctx, id, "budget.alert", srv.URL, payload); err != nil {
+			t.Fatalf("enqueue: %v", err)
+		}
+	}
+	if _, err := svc.ProcessDue(ctx); err != nil {
+		t.Fatalf("process: %v", err)
+	}
+
+	for i, secret := range []string{"tenant-secret", "gateway-secret", "gateway-secret"} {
+		r := <-got
+		if !strings.HasPrefix(r.signature, "sha256=") || r.timestamp == "" {
+			t.Fatalf("delivery %d missing signature headers: %+v", i, r)
+		}
+		if !Verify(secret, r.timestamp, r.body, r.signature) {
+			t.Fatalf("delivery %d not signed with %s", i, secret)
+		}
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"ok":true}`)
+	sig := Sign("s3cret", 1700000000, body)
+	if !Verify("s3cret", "1700000000", body, sig) {
+		t.Fatal("expected a valid signature")
+	}
+	cases := []struct {
+		name, secret, ts string
+		body             []byte
+	}{
+		{"wrong secret", "other", "1700000000", body},
+		{"wrong timestamp", "s3cret", "1700000001", body},
+		{"tampered body", "s3cret", "1700000000", []byte(`{"ok":false}`)},
+		{"empty secret", "", "1700000000", body},
+		{"bad timestamp", "s3cret", "soon", body},
+	}
+	for _, tc := range cases {
+		if Verify(tc.secret, tc.ts, tc.body, sig) {
+			t.Errorf("%s: expected invalid signature", tc.name)
+		}
+	}
+}
@@ -0,0 +1,7 @@
+// Package version holds build metadata injected by the linker, e.g.
+//
+//	go build -ldflags "-X github.com/ncecere/open_model_gateway/backend/internal/version.Version=v1.4.0" ./cmd/routerd
+package version
+
+// Version is the release the binary was built from; "dev" for local builds.
+var Version = "dev"
@@ -0,0 +1,37 @@
+// Package wildcard matches model aliases against shell-style patterns such
+// as "gpt-4*", using filepath.Match semantics: '*' matches any run of
+// characters, '?' matches one, and "[...]" is a character class that "[^...]"
+// negates.
+package wildcard
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// ErrInvalidPattern reports a pattern filepath.Match cannot parse.
+var ErrInvalidPattern = errors.New("invalid wildcard pattern")
+
+// IsPattern reports whether s contains wildcard syntax and should be matched
+// rather than compared.
+func IsPattern(s string) bool {
+	return strings.ContainsAny(s, "*?[")
+}
+
+// Validate checks that pattern is well formed. Failures wrap
+// ErrInvalidPattern.
+func Validate(pattern string) error {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return fmt.Errorf("%w: %q", ErrInvalidPattern, pattern)
+	}
+	return nil
+}
+
+// Match reports whether alias matches pattern. A malformed pattern matches
+// nothing.
+func Match(pattern, alias string) bool {
+	matched, err := filepath.Match(pattern, alias)
+	return err == nil && matched
+}
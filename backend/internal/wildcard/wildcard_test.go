@@ -0,0 +1,63 @@
+package wildcard
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	cases := []struct {
+		pattern, alias string
+		want           bool
+	}{
+		{"gpt-4*", "gpt-4-turbo", true},
+		{"gpt-4*", "gpt-4o", true},
+		{"gpt-4*", "gpt-4", true},
+		{"gpt-4*", "gpt-3.5-turbo", false},
+		{"*-mini", "gpt-4o-mini", true},
+		{"*-mini", "gpt-4o", false},
+		{"gpt-4?", "gpt-4o", true},
+		{"gpt-4?", "gpt-4", false},
+		{"gpt-4?", "gpt-4-turbo", false},
+		{"claude-3-[hs]*", "claude-3-haiku", true},
+		{"claude-3-[hs]*", "claude-3-sonnet", true},
+		{"claude-3-[hs]*", "claude-3-opus", false},
+		{"llama-[0-9]*", "llama-3-70b", true},
+		{"llama-[0-9]*", "llama-guard", false},
+		{"gpt-4[^o]*", "gpt-4-turbo", true},
+		{"gpt-4[^o]*", "gpt-4o", false},
+		{"gpt-4[^o]*", "gpt-4o-mini", false},
+		{"gpt-4o", "gpt-4o", true},
+		{"gpt-4o", "gpt-4o-mini", false},
+		{"gpt-4[", "gpt-4[", false},
+	}
+	for _, tc := range cases {
+		if got := Match(tc.pattern, tc.alias); got != tc.want {
+			t.Fatalf("Match(%q, %q) = %v, want %v", tc.pattern, tc.alias, got, tc.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, pattern := range []string{"gpt-4*", "gpt-4?", "claude-[a-z]*", "gpt-4[^o]*", "text-embedding-3-small"} {
+		if err := Validate(pattern); err != nil {
+			t.Fatalf("Validate(%q): unexpected error %v", pattern, err)
+		}
+	}
+	for _, pattern := range []string{"gpt-4[", "gpt-[a-", "claude-[]", "gpt-4\\"} {
+		if err := Validate(pattern); !errors.Is(err, ErrInvalidPattern) {
+			t.Fatalf("Validate(%q): expected ErrInvalidPattern, got %v", pattern, err)
+		}
+	}
+}
+
+func TestIsPattern(t *testing.T) {
+	for _, s := range []string{"gpt-4*", "gpt-4?", "claude-[hs]*"} {
+		if !IsPattern(s) {
+			t.Fatalf("expected %q to be a pattern", s)
+		}
+	}
+	if IsPattern("gpt-4o-mini") {
+		t.Fatal("expected a plain alias not to be a pattern")
+	}
+}
@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TYPE system_prompt_mode AS ENUM ('prepend', 'append', 'replace');
+
+CREATE TABLE IF NOT EXISTS tenant_system_prompts (
+    tenant_id UUID PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    mode system_prompt_mode NOT NULL DEFAULT 'prepend',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER tenant_system_prompts_updated_at
+    BEFORE UPDATE ON tenant_system_prompts
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_timestamp();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS tenant_system_prompts_updated_at ON tenant_system_prompts;
+DROP TABLE IF EXISTS tenant_system_prompts;
+DROP TYPE IF EXISTS system_prompt_mode;
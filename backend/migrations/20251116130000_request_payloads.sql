@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS request_payloads (
+    request_id UUID PRIMARY KEY REFERENCES requests(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    request_body BYTEA,
+    response_body BYTEA,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_request_payloads_created_at ON request_payloads(created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_request_payloads_created_at;
+DROP TABLE IF EXISTS request_payloads;
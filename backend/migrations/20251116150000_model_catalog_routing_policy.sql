@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE model_catalog
+    ADD COLUMN routing_policy TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE model_catalog
+    DROP COLUMN IF EXISTS routing_policy;
@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS admin_tenant_scopes (
+    admin_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (admin_user_id, tenant_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_admin_tenant_scopes_tenant ON admin_tenant_scopes(tenant_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_admin_tenant_scopes_tenant;
+DROP TABLE IF EXISTS admin_tenant_scopes;
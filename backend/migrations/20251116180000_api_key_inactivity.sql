@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE api_keys
+    ADD COLUMN is_bootstrap BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN inactive_warning_sent_at TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE api_keys
+    DROP COLUMN IF EXISTS inactive_warning_sent_at,
+    DROP COLUMN IF EXISTS is_bootstrap;
@@ -0,0 +1,19 @@
+-- +goose Up
+ALTER TABLE model_catalog
+    ADD COLUMN traffic_split_json JSONB NOT NULL DEFAULT '[]'::jsonb;
+
+ALTER TABLE requests
+    ADD COLUMN ab_variant TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_requests_ab_variant
+    ON requests (model_alias, ts)
+    WHERE ab_variant IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_requests_ab_variant;
+
+ALTER TABLE requests
+    DROP COLUMN IF EXISTS ab_variant;
+
+ALTER TABLE model_catalog
+    DROP COLUMN IF EXISTS traffic_split_json;
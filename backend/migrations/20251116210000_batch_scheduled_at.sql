@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE batches
+    ADD COLUMN scheduled_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_batches_scheduled
+    ON batches (scheduled_at)
+    WHERE status = 'scheduled';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_batches_scheduled;
+
+ALTER TABLE batches
+    DROP COLUMN IF EXISTS scheduled_at;
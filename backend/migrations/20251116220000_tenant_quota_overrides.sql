@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS tenant_quota_overrides (
+    tenant_id UUID PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+    max_requests_per_period BIGINT NOT NULL CHECK (max_requests_per_period > 0),
+    refresh_schedule TEXT NOT NULL DEFAULT 'calendar_month',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER tenant_quota_overrides_updated_at
+    BEFORE UPDATE ON tenant_quota_overrides
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_timestamp();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS tenant_quota_overrides_updated_at ON tenant_quota_overrides;
+DROP TABLE IF EXISTS tenant_quota_overrides;
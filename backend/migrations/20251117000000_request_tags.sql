@@ -0,0 +1,11 @@
+-- +goose Up
+ALTER TABLE requests
+    ADD COLUMN IF NOT EXISTS tags_json JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+CREATE INDEX IF NOT EXISTS idx_requests_tags ON requests USING GIN (tags_json);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_requests_tags;
+
+ALTER TABLE requests
+    DROP COLUMN IF EXISTS tags_json;
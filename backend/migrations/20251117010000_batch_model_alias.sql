@@ -0,0 +1,12 @@
+-- +goose Up
+ALTER TABLE batches
+    ADD COLUMN model_alias TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_batches_created_model
+    ON batches (created_at, model_alias);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_batches_created_model;
+
+ALTER TABLE batches
+    DROP COLUMN IF EXISTS model_alias;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE model_catalog
+    ADD COLUMN max_dimensions INT NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE model_catalog
+    DROP COLUMN IF EXISTS max_dimensions;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE tenants
+    ADD COLUMN cost_center TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE tenants
+    DROP COLUMN IF EXISTS cost_center;
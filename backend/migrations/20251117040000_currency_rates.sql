@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS currency_rates (
+    from_currency TEXT NOT NULL,
+    to_currency TEXT NOT NULL,
+    effective_date DATE NOT NULL,
+    rate NUMERIC(20,10) NOT NULL CHECK (rate > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (from_currency, to_currency, effective_date)
+);
+
+CREATE TRIGGER currency_rates_updated_at
+    BEFORE UPDATE ON currency_rates
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_timestamp();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS currency_rates_updated_at ON currency_rates;
+DROP TABLE IF EXISTS currency_rates;
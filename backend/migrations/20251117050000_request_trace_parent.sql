@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE requests
+    ADD COLUMN IF NOT EXISTS trace_parent TEXT;
+
+-- +goose Down
+ALTER TABLE requests
+    DROP COLUMN IF EXISTS trace_parent;
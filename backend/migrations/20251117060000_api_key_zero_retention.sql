@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE api_keys
+    ADD COLUMN zero_retention BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+ALTER TABLE api_keys
+    DROP COLUMN IF EXISTS zero_retention;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE tenant_quota_overrides
+    ADD COLUMN max_request_body_mb INTEGER NOT NULL DEFAULT 0 CHECK (max_request_body_mb >= 0);
+
+-- +goose Down
+ALTER TABLE tenant_quota_overrides
+    DROP COLUMN IF EXISTS max_request_body_mb;
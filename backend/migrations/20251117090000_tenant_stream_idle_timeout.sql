@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE tenant_quota_overrides
+    ADD COLUMN stream_idle_timeout_sec INTEGER NOT NULL DEFAULT 0 CHECK (stream_idle_timeout_sec >= 0);
+
+-- +goose Down
+ALTER TABLE tenant_quota_overrides
+    DROP COLUMN IF EXISTS stream_idle_timeout_sec;
@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS responses (
+    id TEXT PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    api_key_id UUID REFERENCES api_keys(id) ON DELETE SET NULL,
+    model_alias TEXT NOT NULL,
+    previous_response_id TEXT,
+    messages JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS responses_tenant_created_idx ON responses (tenant_id, created_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS responses;
@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE model_catalog
+    ADD COLUMN deprecated_at TIMESTAMPTZ,
+    ADD COLUMN deprecation_message TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE model_catalog
+    DROP COLUMN IF EXISTS deprecation_message,
+    DROP COLUMN IF EXISTS deprecated_at;
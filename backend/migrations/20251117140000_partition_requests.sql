@@ -0,0 +1,147 @@
+-- +goose Up
+-- Partitioned tables cannot be the target of a foreign key on id alone, so
+-- payloads are tied to requests by retention instead of ON DELETE CASCADE.
+ALTER TABLE request_payloads
+    DROP CONSTRAINT IF EXISTS request_payloads_request_id_fkey;
+
+ALTER TABLE requests RENAME TO requests_unpartitioned;
+ALTER TABLE requests_unpartitioned DROP CONSTRAINT IF EXISTS requests_pkey;
+DROP INDEX IF EXISTS idx_requests_tenant_ts;
+DROP INDEX IF EXISTS idx_requests_model_alias;
+DROP INDEX IF EXISTS idx_requests_tenant_idempotency;
+DROP INDEX IF EXISTS idx_requests_ab_variant;
+DROP INDEX IF EXISTS idx_requests_tags;
+
+CREATE TABLE requests (
+    id               UUID NOT NULL DEFAULT gen_random_uuid(),
+    tenant_id        UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    api_key_id       UUID REFERENCES api_keys(id) ON DELETE SET NULL,
+    ts               TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    model_alias      TEXT NOT NULL,
+    provider         TEXT NOT NULL,
+    latency_ms       INT NOT NULL,
+    status           INT NOT NULL,
+    error_code       TEXT,
+    input_tokens     BIGINT NOT NULL DEFAULT 0,
+    output_tokens    BIGINT NOT NULL DEFAULT 0,
+    cost_cents       BIGINT NOT NULL DEFAULT 0,
+    cost_usd_micros  BIGINT NOT NULL DEFAULT 0,
+    idempotency_key  TEXT,
+    trace_id         TEXT,
+    ab_variant       TEXT,
+    tags_json        JSONB NOT NULL DEFAULT '{}'::jsonb,
+    trace_parent     TEXT,
+    PRIMARY KEY (id, ts)
+) PARTITION BY RANGE (ts);
+
+-- Rows outside every monthly partition land here; the partition manager
+-- keeps it empty by creating each month ahead of time.
+CREATE TABLE requests_default PARTITION OF requests DEFAULT;
+
+-- +goose StatementBegin
+DO $$
+DECLARE
+    month_start TIMESTAMPTZ;
+    last_month  TIMESTAMPTZ;
+BEGIN
+    SELECT date_trunc('month', COALESCE(MIN(ts), NOW()) AT TIME ZONE 'UTC') AT TIME ZONE 'UTC'
+      INTO month_start
+      FROM requests_unpartitioned;
+    last_month := date_trunc('month', (NOW() + INTERVAL '1 month') AT TIME ZONE 'UTC') AT TIME ZONE 'UTC';
+    WHILE month_start <= last_month LOOP
+        EXECUTE format(
+            'CREATE TABLE IF NOT EXISTS %I PARTITION OF requests FOR VALUES FROM (%L) TO (%L)',
+            'requests_y' || to_char(month_start AT TIME ZONE 'UTC', 'YYYY') || 'm' || to_char(month_start AT TIME ZONE 'UTC', 'MM'),
+            month_start,
+            month_start + INTERVAL '1 month'
+        );
+        month_start := month_start + INTERVAL '1 month';
+    END LOOP;
+END
+$$;
+-- +goose StatementEnd
+
+INSERT INTO requests (
+    id, tenant_id, api_key_id, ts, model_alias, provider, latency_ms, status,
+    error_code, input_tokens, output_tokens, cost_cents, cost_usd_micros,
+    idempotency_key, trace_id, ab_variant, tags_json, trace_parent
+)
+SELECT
+    id, tenant_id, api_key_id, ts, model_alias, provider, latency_ms, status,
+    error_code, input_tokens, output_tokens, cost_cents, cost_usd_micros,
+    idempotency_key, trace_id, ab_variant, tags_json, trace_parent
+FROM requests_unpartitioned;
+
+DROP TABLE requests_unpartitioned;
+
+CREATE INDEX idx_requests_tenant_ts ON requests(tenant_id, ts DESC);
+CREATE INDEX idx_requests_model_alias ON requests(model_alias);
+-- Unique indexes on a partitioned table must include the partition key, so
+-- idempotency keys are indexed for lookup only; replay is enforced in Redis.
+CREATE INDEX idx_requests_tenant_idempotency
+    ON requests(tenant_id, idempotency_key)
+    WHERE idempotency_key IS NOT NULL;
+CREATE INDEX idx_requests_ab_variant
+    ON requests (model_alias, ts)
+    WHERE ab_variant IS NOT NULL;
+CREATE INDEX idx_requests_tags ON requests USING GIN (tags_json);
+
+-- +goose Down
+ALTER TABLE requests RENAME TO requests_partitioned;
+DROP INDEX IF EXISTS idx_requests_tenant_ts;
+DROP INDEX IF EXISTS idx_requests_model_alias;
+DROP INDEX IF EXISTS idx_requests_tenant_idempotency;
+DROP INDEX IF EXISTS idx_requests_ab_variant;
+DROP INDEX IF EXISTS idx_requests_tags;
+ALTER TABLE requests_partitioned DROP CONSTRAINT IF EXISTS requests_pkey;
+
+CREATE TABLE requests (
+    id               UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id        UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    api_key_id       UUID REFERENCES api_keys(id) ON DELETE SET NULL,
+    ts               TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    model_alias      TEXT NOT NULL,
+    provider         TEXT NOT NULL,
+    latency_ms       INT NOT NULL,
+    status           INT NOT NULL,
+    error_code       TEXT,
+    input_tokens     BIGINT NOT NULL DEFAULT 0,
+    output_tokens    BIGINT NOT NULL DEFAULT 0,
+    cost_cents       BIGINT NOT NULL DEFAULT 0,
+    cost_usd_micros  BIGINT NOT NULL DEFAULT 0,
+    idempotency_key  TEXT,
+    trace_id         TEXT,
+    ab_variant       TEXT,
+    tags_json        JSONB NOT NULL DEFAULT '{}'::jsonb,
+    trace_parent     TEXT
+);
+
+INSERT INTO requests (
+    id, tenant_id, api_key_id, ts, model_alias, provider, latency_ms, status,
+    error_code, input_tokens, output_tokens, cost_cents, cost_usd_micros,
+    idempotency_key, trace_id, ab_variant, tags_json, trace_parent
+)
+SELECT
+    id, tenant_id, api_key_id, ts, model_alias, provider, latency_ms, status,
+    error_code, input_tokens, output_tokens, cost_cents, cost_usd_micros,
+    idempotency_key, trace_id, ab_variant, tags_json, trace_parent
+FROM requests_partitioned;
+
+DROP TABLE requests_partitioned;
+
+CREATE INDEX idx_requests_tenant_ts ON requests(tenant_id, ts DESC);
+CREATE INDEX idx_requests_model_alias ON requests(model_alias);
+CREATE UNIQUE INDEX idx_requests_tenant_idempotency
+    ON requests(tenant_id, idempotency_key)
+    WHERE idempotency_key IS NOT NULL;
+CREATE INDEX idx_requests_ab_variant
+    ON requests (model_alias, ts)
+    WHERE ab_variant IS NOT NULL;
+CREATE INDEX idx_requests_tags ON requests USING GIN (tags_json);
+
+DELETE FROM request_payloads
+WHERE request_id NOT IN (SELECT id FROM requests);
+
+ALTER TABLE request_payloads
+    ADD CONSTRAINT request_payloads_request_id_fkey
+    FOREIGN KEY (request_id) REFERENCES requests(id) ON DELETE CASCADE;
@@ -0,0 +1,11 @@
+-- +goose Up
+ALTER TABLE requests
+    ADD COLUMN IF NOT EXISTS request_metadata JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+CREATE INDEX IF NOT EXISTS idx_requests_metadata ON requests USING GIN (request_metadata);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_requests_metadata;
+
+ALTER TABLE requests
+    DROP COLUMN IF EXISTS request_metadata;
@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE model_catalog
+    ADD COLUMN supports_vision BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN fallback_vision_alias TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE model_catalog
+    DROP COLUMN IF EXISTS fallback_vision_alias,
+    DROP COLUMN IF EXISTS supports_vision;
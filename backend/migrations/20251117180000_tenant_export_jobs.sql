@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS tenant_export_jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+    sections_completed INT NOT NULL DEFAULT 0,
+    sections_total INT NOT NULL DEFAULT 0,
+    rows_exported BIGINT NOT NULL DEFAULT 0,
+    file_id UUID REFERENCES files(id) ON DELETE SET NULL,
+    error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_tenant_export_jobs_tenant ON tenant_export_jobs(tenant_id, created_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS tenant_export_jobs;
@@ -0,0 +1,20 @@
+-- +goose Up
+ALTER TABLE requests
+    ADD COLUMN IF NOT EXISTS fingerprint TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_requests_fingerprint
+    ON requests (ts, api_key_id, fingerprint)
+    WHERE fingerprint IS NOT NULL;
+
+ALTER TABLE tenant_settings
+    ADD COLUMN IF NOT EXISTS abuse_fingerprint_threshold INTEGER NOT NULL DEFAULT 0
+        CHECK (abuse_fingerprint_threshold >= 0);
+
+-- +goose Down
+ALTER TABLE tenant_settings
+    DROP COLUMN IF EXISTS abuse_fingerprint_threshold;
+
+DROP INDEX IF EXISTS idx_requests_fingerprint;
+
+ALTER TABLE requests
+    DROP COLUMN IF EXISTS fingerprint;
@@ -0,0 +1,20 @@
+-- +goose Up
+ALTER TABLE requests
+    ADD COLUMN IF NOT EXISTS cached_tokens BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE usage_records
+    ADD COLUMN IF NOT EXISTS cached_tokens BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE model_catalog
+    ADD COLUMN IF NOT EXISTS cached_price_ratio NUMERIC(5,4) NOT NULL DEFAULT 0.1
+        CHECK (cached_price_ratio >= 0 AND cached_price_ratio <= 1);
+
+-- +goose Down
+ALTER TABLE model_catalog
+    DROP COLUMN IF EXISTS cached_price_ratio;
+
+ALTER TABLE usage_records
+    DROP COLUMN IF EXISTS cached_tokens;
+
+ALTER TABLE requests
+    DROP COLUMN IF EXISTS cached_tokens;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE tenant_settings
+    ADD COLUMN IF NOT EXISTS data_residency TEXT[] NOT NULL DEFAULT '{}';
+
+-- +goose Down
+ALTER TABLE tenant_settings
+    DROP COLUMN IF EXISTS data_residency;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE tenant_budget_overrides
+    ADD COLUMN IF NOT EXISTS usage_alert_config JSONB;
+
+-- +goose Down
+ALTER TABLE tenant_budget_overrides
+    DROP COLUMN IF EXISTS usage_alert_config;
@@ -0,0 +1,8 @@
+-- +goose Up
+ALTER TABLE tenant_budget_overrides
+    ADD COLUMN IF NOT EXISTS enforcement_mode TEXT NOT NULL DEFAULT 'hard'
+        CHECK (enforcement_mode IN ('hard', 'soft'));
+
+-- +goose Down
+ALTER TABLE tenant_budget_overrides
+    DROP COLUMN IF EXISTS enforcement_mode;
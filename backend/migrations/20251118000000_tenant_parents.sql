@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS tenant_parents (
+    tenant_id UUID PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+    parent_tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (tenant_id <> parent_tenant_id)
+);
+
+CREATE INDEX IF NOT EXISTS tenant_parents_parent_idx ON tenant_parents (parent_tenant_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS tenant_parents;
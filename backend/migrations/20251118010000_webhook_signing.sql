@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE tenant_budget_overrides
+    ADD COLUMN IF NOT EXISTS alert_webhook_secret TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE webhook_deliveries
+    ADD COLUMN IF NOT EXISTS tenant_id UUID REFERENCES tenants(id) ON DELETE CASCADE;
+
+-- +goose Down
+ALTER TABLE webhook_deliveries
+    DROP COLUMN IF EXISTS tenant_id;
+
+ALTER TABLE tenant_budget_overrides
+    DROP COLUMN IF EXISTS alert_webhook_secret;
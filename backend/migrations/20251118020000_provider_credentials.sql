@@ -0,0 +1,10 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS provider_credentials (
+    provider TEXT PRIMARY KEY,
+    api_key TEXT NOT NULL,
+    key_hash TEXT NOT NULL,
+    rotated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS provider_credentials;
@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS model_price_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    alias TEXT NOT NULL,
+    old_price_input NUMERIC(12,6) NOT NULL,
+    new_price_input NUMERIC(12,6) NOT NULL,
+    old_price_output NUMERIC(12,6) NOT NULL,
+    new_price_output NUMERIC(12,6) NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    changed_by_user_id UUID REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_model_price_history_alias_changed_at ON model_price_history (alias, changed_at DESC);
+
+ALTER TABLE requests
+    ADD COLUMN IF NOT EXISTS price_input_snapshot NUMERIC(12,6) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS price_output_snapshot NUMERIC(12,6) NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE requests
+    DROP COLUMN IF EXISTS price_output_snapshot,
+    DROP COLUMN IF EXISTS price_input_snapshot;
+
+DROP TABLE IF EXISTS model_price_history;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Tenant exports move out of the files table into their own blob keys, so the
+-- archive can only be downloaded through the admin export endpoint.
+ALTER TABLE tenant_export_jobs
+    DROP COLUMN IF EXISTS file_id,
+    ADD COLUMN storage_key TEXT,
+    ADD COLUMN bytes BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN expires_at TIMESTAMPTZ,
+    ADD COLUMN heartbeat_at TIMESTAMPTZ;
+
+-- Exports already stored as tenant files expire on the next files sweep.
+UPDATE files
+SET expires_at = NOW()
+WHERE purpose = 'tenant_export'
+  AND deleted_at IS NULL;
+
+-- +goose Down
+ALTER TABLE tenant_export_jobs
+    DROP COLUMN IF EXISTS heartbeat_at,
+    DROP COLUMN IF EXISTS expires_at,
+    DROP COLUMN IF EXISTS bytes,
+    DROP COLUMN IF EXISTS storage_key,
+    ADD COLUMN file_id UUID REFERENCES files(id) ON DELETE SET NULL;
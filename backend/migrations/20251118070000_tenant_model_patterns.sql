@@ -0,0 +1,15 @@
+-- +goose Up
+-- Tenant allowlists may hold wildcard patterns such as "gpt-4*", which are
+-- not catalog aliases, so the alias column can no longer reference
+-- model_catalog.
+ALTER TABLE tenant_models
+    DROP CONSTRAINT IF EXISTS tenant_models_alias_fkey;
+
+-- +goose Down
+DELETE FROM tenant_models tm
+WHERE NOT EXISTS (
+    SELECT 1 FROM model_catalog mc WHERE mc.alias = tm.alias
+);
+
+ALTER TABLE tenant_models
+    ADD CONSTRAINT tenant_models_alias_fkey FOREIGN KEY (alias) REFERENCES model_catalog(alias);
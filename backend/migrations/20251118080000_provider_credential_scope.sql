@@ -0,0 +1,30 @@
+-- +goose Up
+-- Rotated keys are scoped to a provider endpoint and stored encrypted.
+-- Existing plaintext rows keep api_key until the router seals them at
+-- startup with providers.credential_encryption_key.
+ALTER TABLE provider_credentials
+    ADD COLUMN endpoint TEXT NOT NULL DEFAULT '',
+    ADD COLUMN api_key_ciphertext TEXT,
+    ALTER COLUMN api_key DROP NOT NULL;
+
+ALTER TABLE provider_credentials
+    DROP CONSTRAINT IF EXISTS provider_credentials_pkey;
+
+ALTER TABLE provider_credentials
+    ADD PRIMARY KEY (provider, endpoint);
+
+-- +goose Down
+-- Encrypted rows cannot be restored to plaintext; they must be rotated again.
+DELETE FROM provider_credentials
+WHERE endpoint <> '' OR api_key IS NULL;
+
+ALTER TABLE provider_credentials
+    DROP CONSTRAINT IF EXISTS provider_credentials_pkey;
+
+ALTER TABLE provider_credentials
+    ADD PRIMARY KEY (provider);
+
+ALTER TABLE provider_credentials
+    ALTER COLUMN api_key SET NOT NULL,
+    DROP COLUMN api_key_ciphertext,
+    DROP COLUMN endpoint;
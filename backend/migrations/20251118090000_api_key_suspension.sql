@@ -0,0 +1,14 @@
+-- +goose Up
+-- Abuse suspension is reversible: suspended keys are refused at auth until
+-- an admin reinstates them. reinstated_at keeps requests sent before the
+-- reinstatement from suspending the key again.
+ALTER TABLE api_keys
+    ADD COLUMN suspended_at TIMESTAMPTZ,
+    ADD COLUMN suspension_reason TEXT NOT NULL DEFAULT '',
+    ADD COLUMN reinstated_at TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE api_keys
+    DROP COLUMN reinstated_at,
+    DROP COLUMN suspension_reason,
+    DROP COLUMN suspended_at;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- A mirror_sample_rate of 0 used to mean "mirror everything"; it now mirrors
+-- nothing. Keep existing mirrors running at the rate they had.
+UPDATE model_catalog
+SET mirror_sample_rate = 1
+WHERE mirror_alias <> ''
+  AND mirror_sample_rate = 0;
+
+-- +goose Down
+SELECT 1;
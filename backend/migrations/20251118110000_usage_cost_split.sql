@@ -0,0 +1,12 @@
+-- +goose Up
+-- Prompt and completion tokens are priced separately; keep both halves of
+-- cost_usd_micros so per-model reports can show which side dominates. Rows
+-- recorded before this migration have no split and stay at 0.
+ALTER TABLE usage_records
+    ADD COLUMN input_cost_usd_micros BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN output_cost_usd_micros BIGINT NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE usage_records
+    DROP COLUMN output_cost_usd_micros,
+    DROP COLUMN input_cost_usd_micros;
@@ -0,0 +1,13 @@
+-- +goose Up
+-- A parallel-request slot expires once its holder stops refreshing it. Tenant
+-- and key overrides may set their own TTL; 0 keeps rate_limits.parallel_request_ttl.
+ALTER TABLE tenant_rate_limits
+    ADD COLUMN parallel_request_ttl_seconds INTEGER NOT NULL DEFAULT 0 CHECK (parallel_request_ttl_seconds >= 0);
+ALTER TABLE api_key_rate_limits
+    ADD COLUMN parallel_request_ttl_seconds INTEGER NOT NULL DEFAULT 0 CHECK (parallel_request_ttl_seconds >= 0);
+
+-- +goose Down
+ALTER TABLE api_key_rate_limits
+    DROP COLUMN parallel_request_ttl_seconds;
+ALTER TABLE tenant_rate_limits
+    DROP COLUMN parallel_request_ttl_seconds;
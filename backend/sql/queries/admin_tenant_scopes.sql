@@ -0,0 +1,32 @@
+-- name: AddAdminTenantScope :exec
+INSERT INTO admin_tenant_scopes (admin_user_id, tenant_id)
+VALUES ($1, $2)
+ON CONFLICT (admin_user_id, tenant_id) DO NOTHING;
+
+-- name: DeleteAdminTenantScope :execrows
+DELETE FROM admin_tenant_scopes
+WHERE admin_user_id = $1 AND tenant_id = $2;
+
+-- name: ListAdminTenantScopes :many
+SELECT *
+FROM admin_tenant_scopes
+WHERE admin_user_id = $1
+ORDER BY created_at;
+
+-- name: HasAdminTenantScope :one
+SELECT EXISTS (
+    SELECT 1
+    FROM admin_tenant_scopes
+    WHERE admin_user_id = $1 AND tenant_id = $2
+);
+
+-- name: HasAnyAdminTenantScope :one
+SELECT EXISTS (
+    SELECT 1
+    FROM admin_tenant_scopes
+    WHERE admin_user_id = $1
+);
+
+-- name: DeleteAdminTenantScopesByUser :exec
+DELETE FROM admin_tenant_scopes
+WHERE admin_user_id = $1;
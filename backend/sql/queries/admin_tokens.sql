@@ -0,0 +1,47 @@
+-- name: CreateAdminToken :one
+INSERT INTO admin_tokens (
+    user_id,
+    name,
+    prefix,
+    secret_hash,
+    role,
+    allowed_ips,
+    expires_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetAdminTokenByPrefix :one
+SELECT *
+FROM admin_tokens
+WHERE prefix = $1;
+
+-- name: GetAdminTokenByID :one
+SELECT *
+FROM admin_tokens
+WHERE id = $1;
+
+-- name: ListAdminTokens :many
+SELECT *
+FROM admin_tokens
+ORDER BY created_at DESC;
+
+-- name: ListAdminTokensByUser :many
+SELECT *
+FROM admin_tokens
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: RevokeAdminToken :one
+UPDATE admin_tokens
+SET revoked_at = NOW()
+WHERE id = $1 AND revoked_at IS NULL
+RETURNING *;
+
+-- name: UpdateAdminTokenLastUsed :exec
+UPDATE admin_tokens
+SET last_used_at = NOW()
+WHERE id = $1;
+
+-- name: DeleteAdminTokensByUser :exec
+DELETE FROM admin_tokens
+WHERE user_id = $1;
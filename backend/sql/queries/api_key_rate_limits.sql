@@ -3,7 +3,8 @@ SELECT ak.prefix AS prefix,
        r.api_key_id,
        r.requests_per_minute,
        r.tokens_per_minute,
-       r.parallel_requests
+       r.parallel_requests,
+       r.parallel_request_ttl_seconds
 FROM api_key_rate_limits r
 JOIN api_keys ak ON ak.id = r.api_key_id;
 
@@ -11,7 +12,8 @@ JOIN api_keys ak ON ak.id = r.api_key_id;
 SELECT api_key_id,
        requests_per_minute,
        tokens_per_minute,
-       parallel_requests
+       parallel_requests,
+       parallel_request_ttl_seconds
 FROM api_key_rate_limits
 WHERE api_key_id = $1;
 
@@ -20,17 +22,20 @@ INSERT INTO api_key_rate_limits (
     api_key_id,
     requests_per_minute,
     tokens_per_minute,
-    parallel_requests
-) VALUES ($1, $2, $3, $4)
+    parallel_requests,
+    parallel_request_ttl_seconds
+) VALUES ($1, $2, $3, $4, $5)
 ON CONFLICT (api_key_id) DO UPDATE
 SET requests_per_minute = EXCLUDED.requests_per_minute,
     tokens_per_minute = EXCLUDED.tokens_per_minute,
     parallel_requests = EXCLUDED.parallel_requests,
+    parallel_request_ttl_seconds = EXCLUDED.parallel_request_ttl_seconds,
     updated_at = NOW()
 RETURNING api_key_id,
           requests_per_minute,
           tokens_per_minute,
-          parallel_requests;
+          parallel_requests,
+          parallel_request_ttl_seconds;
 
 -- name: DeleteAPIKeyRateLimit :execrows
 DELETE FROM api_key_rate_limits
@@ -7,9 +7,10 @@ INSERT INTO api_keys (
     scopes_json,
     quota_json,
     kind,
-    owner_user_id
+    owner_user_id,
+    zero_retention
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 RETURNING *;
 
 -- name: GetAPIKeyByPrefix :one
@@ -28,6 +29,14 @@ FROM api_keys
 WHERE tenant_id = $1
 ORDER BY created_at DESC;
 
+-- name: ListAPIKeysByTenantForExport :many
+SELECT *
+FROM api_keys
+WHERE tenant_id = sqlc.arg(tenant_id)
+  AND id > sqlc.arg(after_id)::uuid
+ORDER BY id
+LIMIT sqlc.arg(row_limit);
+
 -- name: ListPersonalAPIKeysByUser :many
 SELECT *
 FROM api_keys
@@ -52,9 +61,60 @@ SET revoked_at = NOW()
 WHERE id = $1 AND revoked_at IS NULL
 RETURNING *;
 
+-- name: SuspendAPIKey :one
+UPDATE api_keys
+SET suspended_at = NOW(),
+    suspension_reason = $2
+WHERE id = $1
+  AND revoked_at IS NULL
+  AND suspended_at IS NULL
+RETURNING *;
+
+-- name: ReinstateAPIKey :one
+UPDATE api_keys
+SET suspended_at = NULL,
+    suspension_reason = '',
+    reinstated_at = NOW()
+WHERE id = $1 AND suspended_at IS NOT NULL
+RETURNING *;
+
 -- name: UpdateAPIKeyLastUsed :exec
 UPDATE api_keys
-SET last_used_at = NOW()
+SET last_used_at = NOW(),
+    inactive_warning_sent_at = NULL
+WHERE id = $1;
+
+-- name: MarkAPIKeyBootstrap :exec
+UPDATE api_keys
+SET is_bootstrap = TRUE
+WHERE id = $1 AND NOT is_bootstrap;
+
+-- name: ListAPIKeysIdleSince :many
+SELECT
+    k.id,
+    k.tenant_id,
+    k.prefix,
+    k.name,
+    k.owner_user_id,
+    k.created_at,
+    k.last_used_at,
+    k.inactive_warning_sent_at,
+    u.email AS owner_email
+FROM api_keys k
+LEFT JOIN users u ON u.id = k.owner_user_id
+WHERE k.revoked_at IS NULL
+  AND NOT k.is_bootstrap
+  AND COALESCE(k.last_used_at, k.created_at) < sqlc.arg(idle_before)
+  AND (
+    (sqlc.narg(warned_before)::timestamptz IS NULL AND k.inactive_warning_sent_at IS NULL)
+    OR k.inactive_warning_sent_at <= sqlc.narg(warned_before)::timestamptz
+)
+ORDER BY COALESCE(k.last_used_at, k.created_at)
+LIMIT sqlc.arg(batch_size);
+
+-- name: MarkAPIKeyInactiveWarningSent :exec
+UPDATE api_keys
+SET inactive_warning_sent_at = NOW()
 WHERE id = $1;
 
 -- name: UpdateAPIKeyTenant :one
@@ -84,3 +144,14 @@ FROM api_keys k
 JOIN tenants t ON t.id = k.tenant_id
 LEFT JOIN users u ON u.id = k.owner_user_id
 ORDER BY k.created_at DESC;
+
+-- name: DeleteAPIKeysByOwner :execrows
+DELETE FROM api_keys
+WHERE owner_user_id = $1;
+
+-- name: CountActiveAPIKeys :one
+SELECT COUNT(*)::bigint
+FROM api_keys k
+JOIN tenants t ON t.id = k.tenant_id
+WHERE k.revoked_at IS NULL
+  AND t.status = 'active';
@@ -0,0 +1,22 @@
+-- name: InsertArchivedPartition :one
+INSERT INTO archived_partitions (
+    name,
+    table_name,
+    period,
+    period_start,
+    period_end,
+    object_key,
+    row_count,
+    size_bytes
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetArchivedPartitionByName :one
+SELECT *
+FROM archived_partitions
+WHERE name = $1;
+
+-- name: ListArchivedPartitions :many
+SELECT *
+FROM archived_partitions
+ORDER BY period_start DESC, name;
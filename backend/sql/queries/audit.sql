@@ -25,3 +25,28 @@ WHERE (
 )
 ORDER BY created_at DESC
 LIMIT sqlc.arg(list_limit) OFFSET sqlc.arg(list_offset);
+
+-- name: ListAuditLogsForExport :many
+SELECT l.id, l.user_id, u.email AS actor_email, l.action, l.resource_type, l.resource_id, l.metadata, l.created_at
+FROM admin_audit_logs l
+LEFT JOIN users u ON u.id = l.user_id
+WHERE l.created_at >= sqlc.arg(start_time)
+  AND l.created_at < sqlc.arg(end_time)
+  AND (
+    sqlc.narg(user_id_filter)::uuid IS NULL
+    OR l.user_id = sqlc.narg(user_id_filter)::uuid
+)
+  AND (
+    sqlc.narg(action_filter)::text IS NULL
+    OR l.action = sqlc.narg(action_filter)::text
+)
+  AND (
+    sqlc.narg(resource_filter)::text IS NULL
+    OR l.resource_type = sqlc.narg(resource_filter)::text
+)
+  AND (
+    sqlc.narg(after_created_at)::timestamptz IS NULL
+    OR (l.created_at, l.id) > (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id)::uuid)
+)
+ORDER BY l.created_at, l.id
+LIMIT sqlc.arg(page_limit);
@@ -9,9 +9,11 @@ INSERT INTO batches (
     max_concurrency,
     metadata,
     request_count_total,
-    expires_at
+    expires_at,
+    scheduled_at,
+    model_alias
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
 ) RETURNING *;
 
 -- name: InsertBatchItem :one
@@ -83,10 +85,25 @@ LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
 SELECT *
 FROM batches
 WHERE status = 'validating'
+  AND (scheduled_at IS NULL OR scheduled_at <= NOW())
 ORDER BY created_at
 LIMIT 1
 FOR UPDATE SKIP LOCKED;
 
+-- name: ActivateScheduledBatches :execrows
+UPDATE batches
+SET status = 'validating',
+    updated_at = NOW()
+WHERE status = 'scheduled'
+  AND scheduled_at <= sqlc.arg(now)::timestamptz;
+
+-- name: ListActiveBatchIDs :many
+SELECT id
+FROM batches
+WHERE status IN ('in_progress', 'cancelling')
+ORDER BY in_progress_at
+LIMIT $1;
+
 -- name: MarkBatchInProgress :one
 UPDATE batches
 SET status = 'in_progress',
@@ -98,13 +115,13 @@ RETURNING *;
 -- name: CancelBatch :one
 UPDATE batches
 SET status = CASE
-        WHEN status = 'validating' THEN 'cancelled'
+        WHEN status IN ('scheduled', 'validating') THEN 'cancelled'
         ELSE 'cancelling'
     END,
-    cancelled_at = CASE WHEN status = 'validating' THEN NOW() ELSE cancelled_at END,
-    cancelling_at = CASE WHEN status <> 'validating' THEN NOW() ELSE cancelling_at END,
+    cancelled_at = CASE WHEN status IN ('scheduled', 'validating') THEN NOW() ELSE cancelled_at END,
+    cancelling_at = CASE WHEN status NOT IN ('scheduled', 'validating') THEN NOW() ELSE cancelling_at END,
     updated_at = NOW()
-WHERE tenant_id = $1 AND id = $2 AND status IN ('validating', 'in_progress', 'finalizing')
+WHERE tenant_id = $1 AND id = $2 AND status IN ('scheduled', 'validating', 'in_progress', 'finalizing')
 RETURNING *;
 
 -- name: UpdateBatchCounts :one
@@ -170,8 +187,89 @@ SET status = $2,
 WHERE id = $1
 RETURNING *;
 
+-- name: ListBatchAnalytics :many
+-- Per-batch item counts, processing time, and cost for batches created in
+-- the window. Cost comes from the requests logged with the batch's trace IDs.
+SELECT b.id,
+       b.tenant_id,
+       t.name AS tenant_name,
+       b.status,
+       b.model_alias,
+       COALESCE(items.total_items, 0)::bigint AS total_items,
+       COALESCE(items.completed_items, 0)::bigint AS completed_items,
+       COALESCE(items.failed_items, 0)::bigint AS failed_items,
+       COALESCE(items.timed_items, 0)::bigint AS timed_items,
+       COALESCE(items.processing_ms, 0)::double precision AS processing_ms,
+       COALESCE(costs.cost_cents, 0)::bigint AS cost_cents
+FROM batches b
+JOIN tenants t ON t.id = b.tenant_id
+LEFT JOIN LATERAL (
+    SELECT COUNT(*) AS total_items,
+           COUNT(*) FILTER (WHERE bi.status = 'completed') AS completed_items,
+           COUNT(*) FILTER (WHERE bi.status = 'failed') AS failed_items,
+           COUNT(*) FILTER (WHERE bi.started_at IS NOT NULL AND bi.completed_at IS NOT NULL) AS timed_items,
+           SUM(EXTRACT(EPOCH FROM (bi.completed_at - bi.started_at)) * 1000)
+               FILTER (WHERE bi.started_at IS NOT NULL AND bi.completed_at IS NOT NULL) AS processing_ms
+    FROM batch_items bi
+    WHERE bi.batch_id = b.id
+) items ON TRUE
+LEFT JOIN LATERAL (
+    SELECT SUM(r.cost_cents) AS cost_cents
+    FROM requests r
+    WHERE r.tenant_id = b.tenant_id
+      AND r.ts >= b.created_at
+      AND r.trace_id LIKE 'batch_' || b.id::text || '_%'
+) costs ON TRUE
+WHERE b.created_at >= sqlc.arg(start_ts)
+  AND b.created_at < sqlc.arg(end_ts);
+
 -- name: ListBatchItemsForOutput :many
 SELECT *
 FROM batch_items
 WHERE batch_id = $1
 ORDER BY item_index;
+
+-- name: ListBatchItemsPage :many
+SELECT *
+FROM batch_items
+WHERE batch_id = sqlc.arg(batch_id)
+  AND item_index > sqlc.arg(after_index)
+ORDER BY item_index
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListFinishedBatchItems :many
+SELECT *
+FROM batch_items
+WHERE batch_id = sqlc.arg(batch_id)
+  AND status IN ('completed', 'failed')
+  AND item_index > sqlc.arg(after_index)
+  AND item_index < COALESCE((
+      SELECT MIN(pending.item_index)
+      FROM batch_items pending
+      WHERE pending.batch_id = sqlc.arg(batch_id)
+        AND pending.status NOT IN ('completed', 'failed')
+        AND pending.item_index > sqlc.arg(after_index)
+  ), 9223372036854775807)
+ORDER BY item_index
+LIMIT sqlc.arg(row_limit);
+
+-- name: ReleaseBatchItem :execrows
+UPDATE batch_items
+SET status = 'queued',
+    started_at = NULL
+WHERE id = $1
+  AND status = 'running';
+
+-- name: RequeueRunningBatchItems :execrows
+UPDATE batch_items
+SET status = 'queued',
+    started_at = NULL
+WHERE batch_id = $1
+  AND status = 'running';
+
+-- name: DeleteBatchesForUser :exec
+DELETE FROM batches
+WHERE tenant_id = sqlc.arg(tenant_id)
+   OR api_key_id IN (
+        SELECT id FROM api_keys WHERE owner_user_id = sqlc.arg(owner_user_id)
+   );
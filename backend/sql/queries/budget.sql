@@ -9,7 +9,10 @@ SELECT tenant_id,
        last_alert_at,
        last_alert_level,
        created_at,
-       updated_at
+       updated_at,
+       usage_alert_config,
+       enforcement_mode,
+       alert_webhook_secret
 FROM tenant_budget_overrides
 ORDER BY created_at DESC;
 
@@ -24,7 +27,10 @@ SELECT tenant_id,
        last_alert_at,
        last_alert_level,
        created_at,
-       updated_at
+       updated_at,
+       usage_alert_config,
+       enforcement_mode,
+       alert_webhook_secret
 FROM tenant_budget_overrides
 WHERE tenant_id = $1;
 
@@ -36,9 +42,12 @@ INSERT INTO tenant_budget_overrides (
     refresh_schedule,
     alert_emails,
     alert_webhooks,
-    alert_cooldown_seconds
+    alert_cooldown_seconds,
+    usage_alert_config,
+    enforcement_mode,
+    alert_webhook_secret
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, COALESCE($9::text, 'hard'), COALESCE($10::text, ''))
 ON CONFLICT (tenant_id) DO UPDATE
 SET budget_usd = EXCLUDED.budget_usd,
     warning_threshold = EXCLUDED.warning_threshold,
@@ -46,6 +55,9 @@ SET budget_usd = EXCLUDED.budget_usd,
     alert_emails = EXCLUDED.alert_emails,
     alert_webhooks = EXCLUDED.alert_webhooks,
     alert_cooldown_seconds = EXCLUDED.alert_cooldown_seconds,
+    usage_alert_config = COALESCE(EXCLUDED.usage_alert_config, tenant_budget_overrides.usage_alert_config),
+    enforcement_mode = COALESCE($9::text, tenant_budget_overrides.enforcement_mode),
+    alert_webhook_secret = COALESCE($10::text, tenant_budget_overrides.alert_webhook_secret),
     updated_at = NOW()
 RETURNING tenant_id,
           budget_usd,
@@ -57,12 +69,35 @@ RETURNING tenant_id,
           last_alert_at,
           last_alert_level,
           created_at,
-          updated_at;
+          updated_at,
+          usage_alert_config,
+          enforcement_mode,
+          alert_webhook_secret;
 
 -- name: DeleteTenantBudgetOverride :exec
 DELETE FROM tenant_budget_overrides
 WHERE tenant_id = $1;
 
+-- name: UpdateTenantBudgetEnforcementMode :one
+UPDATE tenant_budget_overrides
+SET enforcement_mode = $2,
+    updated_at = NOW()
+WHERE tenant_id = $1
+RETURNING tenant_id,
+          budget_usd,
+          warning_threshold,
+          refresh_schedule,
+          alert_emails,
+          alert_webhooks,
+          alert_cooldown_seconds,
+          last_alert_at,
+          last_alert_level,
+          created_at,
+          updated_at,
+          usage_alert_config,
+          enforcement_mode,
+          alert_webhook_secret;
+
 -- name: UpdateTenantBudgetAlertState :exec
 UPDATE tenant_budget_overrides
 SET last_alert_at = $2,
@@ -130,3 +165,18 @@ INSERT INTO budget_alert_events (
     error
 )
 VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: ListTenantBudgetSpend :many
+SELECT
+    t.id AS tenant_id,
+    t.name,
+    COALESCE(o.budget_usd, 0)::numeric AS budget_usd,
+    COALESCE(o.warning_threshold, 0)::numeric AS warning_threshold,
+    COALESCE(SUM(u.cost_cents), 0)::bigint AS spent_cents
+FROM tenants t
+JOIN usage_records u ON u.tenant_id = t.id
+LEFT JOIN tenant_budget_overrides o ON o.tenant_id = t.id
+WHERE t.status = 'active'
+  AND u.ts >= $1
+  AND u.ts < $2
+GROUP BY t.id, t.name, o.budget_usd, o.warning_threshold;
@@ -0,0 +1,27 @@
+-- name: UpsertCurrencyRate :one
+INSERT INTO currency_rates (
+    from_currency,
+    to_currency,
+    effective_date,
+    rate
+) VALUES ($1, $2, $3, $4)
+ON CONFLICT (from_currency, to_currency, effective_date) DO UPDATE
+SET rate = EXCLUDED.rate,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: ListCurrencyRates :many
+SELECT *
+FROM currency_rates
+ORDER BY from_currency, to_currency, effective_date DESC;
+
+-- Latest rate in either direction effective on or before as_of; callers
+-- invert the rate when the stored pair is reversed.
+-- name: GetCurrencyRate :one
+SELECT *
+FROM currency_rates
+WHERE ((from_currency = sqlc.arg(from_currency) AND to_currency = sqlc.arg(to_currency))
+    OR (from_currency = sqlc.arg(to_currency) AND to_currency = sqlc.arg(from_currency)))
+  AND effective_date <= sqlc.arg(as_of)
+ORDER BY effective_date DESC, (from_currency = sqlc.arg(from_currency)) DESC
+LIMIT 1;
@@ -76,3 +76,8 @@ SELECT *
 FROM files
 WHERE deleted_at IS NULL AND expires_at <= $1
 LIMIT $2;
+
+-- name: DeleteFilesByTenant :many
+DELETE FROM files
+WHERE tenant_id = $1
+RETURNING storage_key;
@@ -0,0 +1,11 @@
+-- name: CreateGDPRErasureRequest :one
+INSERT INTO gdpr_erasure_requests (user_id)
+VALUES ($1)
+RETURNING *;
+
+-- name: UpdateGDPRErasureRequestStatus :exec
+UPDATE gdpr_erasure_requests
+SET status = $2,
+    error = sqlc.narg(error),
+    completed_at = NOW()
+WHERE id = $1;
@@ -0,0 +1,20 @@
+-- name: GetIdempotencyKey :one
+SELECT response
+FROM idempotency_keys
+WHERE key = $1
+  AND expires_at > NOW();
+
+-- name: UpsertIdempotencyKey :exec
+INSERT INTO idempotency_keys (
+    key,
+    response,
+    expires_at
+) VALUES ($1, $2, $3)
+ON CONFLICT (key) DO UPDATE
+SET response = EXCLUDED.response,
+    created_at = NOW(),
+    expires_at = EXCLUDED.expires_at;
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys
+WHERE expires_at < $1;
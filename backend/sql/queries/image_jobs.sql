@@ -0,0 +1,47 @@
+-- name: InsertImageJob :one
+INSERT INTO image_jobs (tenant_id, api_key_id, model_alias, request, trace_id, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetImageJob :one
+SELECT *
+FROM image_jobs
+WHERE id = $1
+  AND tenant_id = $2
+  AND expires_at > NOW();
+
+-- name: ClaimNextImageJob :one
+-- Jobs left processing for ten minutes belonged to a worker that died and are
+-- picked up again.
+UPDATE image_jobs
+SET status = 'processing'
+WHERE id = (
+    SELECT id
+    FROM image_jobs
+    WHERE status = 'pending'
+       OR (status = 'processing' AND updated_at < NOW() - INTERVAL '10 minutes')
+    ORDER BY created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: CompleteImageJob :exec
+UPDATE image_jobs
+SET status = 'completed',
+    result = $2,
+    completed_at = NOW(),
+    expires_at = $3
+WHERE id = $1;
+
+-- name: FailImageJob :exec
+UPDATE image_jobs
+SET status = 'failed',
+    error_message = $2,
+    completed_at = NOW(),
+    expires_at = $3
+WHERE id = $1;
+
+-- name: DeleteExpiredImageJobs :execrows
+DELETE FROM image_jobs
+WHERE expires_at < $1;
@@ -28,6 +28,23 @@ JOIN users u ON u.id = tm.user_id
 WHERE tm.tenant_id = $1
 ORDER BY u.email;
 
+-- name: ListTenantMembersForExport :many
+SELECT
+    tm.id,
+    tm.tenant_id,
+    tm.user_id,
+    tm.role,
+    tm.created_at,
+    u.email AS user_email,
+    u.name AS user_name,
+    u.created_at AS user_created_at
+FROM tenant_memberships tm
+JOIN users u ON u.id = tm.user_id
+WHERE tm.tenant_id = sqlc.arg(tenant_id)
+  AND tm.id > sqlc.arg(after_id)::uuid
+ORDER BY tm.id
+LIMIT sqlc.arg(row_limit);
+
 -- name: ListUserTenants :many
 SELECT
     tm.id,
@@ -47,3 +64,7 @@ ORDER BY t.name;
 SELECT *
 FROM tenant_memberships
 WHERE tenant_id = $1 AND user_id = $2;
+
+-- name: DeleteUserMemberships :execrows
+DELETE FROM tenant_memberships
+WHERE user_id = $1;
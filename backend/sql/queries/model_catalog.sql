@@ -19,9 +19,19 @@ INSERT INTO model_catalog (
     region,
     metadata_json,
     weight,
-    provider_config_json
+    provider_config_json,
+    routing_policy,
+    traffic_split_json,
+    max_dimensions,
+    mirror_alias,
+    mirror_sample_rate,
+    deprecated_at,
+    deprecation_message,
+    supports_vision,
+    fallback_vision_alias,
+    cached_price_ratio
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30)
 ON CONFLICT (alias)
 DO UPDATE SET
     provider = EXCLUDED.provider,
@@ -43,6 +53,16 @@ DO UPDATE SET
     metadata_json = EXCLUDED.metadata_json,
     weight = EXCLUDED.weight,
     provider_config_json = EXCLUDED.provider_config_json,
+    routing_policy = EXCLUDED.routing_policy,
+    traffic_split_json = EXCLUDED.traffic_split_json,
+    max_dimensions = EXCLUDED.max_dimensions,
+    mirror_alias = EXCLUDED.mirror_alias,
+    mirror_sample_rate = EXCLUDED.mirror_sample_rate,
+    deprecated_at = EXCLUDED.deprecated_at,
+    deprecation_message = EXCLUDED.deprecation_message,
+    supports_vision = EXCLUDED.supports_vision,
+    fallback_vision_alias = EXCLUDED.fallback_vision_alias,
+    cached_price_ratio = EXCLUDED.cached_price_ratio,
     updated_at = NOW()
 RETURNING *;
 
@@ -51,6 +71,12 @@ SELECT *
 FROM model_catalog
 WHERE alias = $1;
 
+-- name: GetModelByAliasForUpdate :one
+SELECT *
+FROM model_catalog
+WHERE alias = $1
+FOR UPDATE;
+
 -- name: ListModelCatalog :many
 SELECT *
 FROM model_catalog
@@ -70,3 +96,26 @@ WHERE alias = ANY($1::text[]);
 -- name: DeleteModelCatalogEntry :exec
 DELETE FROM model_catalog
 WHERE alias = $1;
+
+-- name: ListDeprecatedModels :many
+SELECT *
+FROM model_catalog
+WHERE deprecated_at IS NOT NULL
+ORDER BY deprecated_at, alias;
+
+-- name: SetModelDeprecation :one
+UPDATE model_catalog
+SET deprecated_at = $2,
+    deprecation_message = $3,
+    updated_at = NOW()
+WHERE alias = $1
+RETURNING *;
+
+-- name: DisableDeprecatedModels :many
+UPDATE model_catalog
+SET enabled = false,
+    updated_at = NOW()
+WHERE enabled = true
+  AND deprecated_at IS NOT NULL
+  AND deprecated_at <= $1
+RETURNING alias;
@@ -0,0 +1,16 @@
+-- name: InsertModelPriceHistory :one
+INSERT INTO model_price_history (
+    alias,
+    old_price_input,
+    new_price_input,
+    old_price_output,
+    new_price_output,
+    changed_by_user_id
+) VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: ListModelPriceHistory :many
+SELECT *
+FROM model_price_history
+WHERE alias = $1
+ORDER BY changed_at DESC;
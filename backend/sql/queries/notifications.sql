@@ -0,0 +1,31 @@
+-- name: InsertNotification :one
+INSERT INTO notifications (
+    severity,
+    entity_type,
+    entity_id,
+    message
+) VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListNotifications :many
+SELECT *
+FROM notifications
+WHERE (NOT sqlc.arg(unread_only)::bool OR read_at IS NULL)
+ORDER BY created_at DESC
+LIMIT sqlc.arg(list_limit) OFFSET sqlc.arg(list_offset);
+
+-- name: CountUnreadNotifications :one
+SELECT COUNT(*)::bigint
+FROM notifications
+WHERE read_at IS NULL;
+
+-- name: MarkNotificationRead :one
+UPDATE notifications
+SET read_at = COALESCE(read_at, NOW())
+WHERE id = $1
+RETURNING *;
+
+-- name: MarkAllNotificationsRead :execrows
+UPDATE notifications
+SET read_at = NOW()
+WHERE read_at IS NULL;
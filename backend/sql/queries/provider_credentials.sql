@@ -0,0 +1,25 @@
+-- name: ListProviderCredentials :many
+SELECT *
+FROM provider_credentials
+ORDER BY provider, endpoint;
+
+-- name: SealProviderCredential :exec
+UPDATE provider_credentials
+SET api_key = NULL,
+    api_key_ciphertext = $3
+WHERE provider = $1
+  AND endpoint = $2;
+
+-- name: UpsertProviderCredential :one
+INSERT INTO provider_credentials (
+    provider,
+    endpoint,
+    api_key_ciphertext,
+    key_hash
+) VALUES ($1, $2, $3, $4)
+ON CONFLICT (provider, endpoint) DO UPDATE
+SET api_key = NULL,
+    api_key_ciphertext = EXCLUDED.api_key_ciphertext,
+    key_hash = EXCLUDED.key_hash,
+    rotated_at = NOW()
+RETURNING *;
@@ -0,0 +1,57 @@
+-- name: ListPermissions :many
+SELECT *
+FROM permissions
+ORDER BY name;
+
+-- name: ListRoles :many
+SELECT *
+FROM roles
+ORDER BY builtin DESC, name;
+
+-- name: GetRole :one
+SELECT *
+FROM roles
+WHERE name = $1;
+
+-- name: InsertRole :one
+INSERT INTO roles (name, description, permissions)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: UpdateRole :one
+UPDATE roles
+SET description = $2,
+    permissions = $3
+WHERE name = $1
+RETURNING *;
+
+-- name: DeleteRole :execrows
+DELETE FROM roles
+WHERE name = $1
+  AND NOT builtin;
+
+-- name: GetTenantMembershipRole :one
+SELECT *
+FROM tenant_membership_roles
+WHERE tenant_id = $1 AND user_id = $2;
+
+-- name: ListUserMembershipRoles :many
+SELECT *
+FROM tenant_membership_roles
+WHERE user_id = $1;
+
+-- name: ListTenantMembershipRoles :many
+SELECT *
+FROM tenant_membership_roles
+WHERE tenant_id = $1;
+
+-- name: UpsertTenantMembershipRole :one
+INSERT INTO tenant_membership_roles (tenant_id, user_id, role)
+VALUES ($1, $2, $3)
+ON CONFLICT (tenant_id, user_id) DO UPDATE
+SET role = EXCLUDED.role
+RETURNING *;
+
+-- name: DeleteTenantMembershipRole :execrows
+DELETE FROM tenant_membership_roles
+WHERE tenant_id = $1 AND user_id = $2;
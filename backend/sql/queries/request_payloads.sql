@@ -0,0 +1,18 @@
+-- name: InsertRequestPayload :exec
+INSERT INTO request_payloads (
+    request_id,
+    tenant_id,
+    request_body,
+    response_body,
+    created_at
+) VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (request_id) DO NOTHING;
+
+-- name: GetRequestPayload :one
+SELECT *
+FROM request_payloads
+WHERE request_id = $1;
+
+-- name: DeleteRequestPayloadsBefore :execrows
+DELETE FROM request_payloads
+WHERE created_at < $1;
@@ -13,11 +13,24 @@ INSERT INTO requests (
     cost_cents,
     cost_usd_micros,
     idempotency_key,
-    trace_id
+    trace_id,
+    ab_variant,
+    tags_json,
+    trace_parent,
+    request_metadata,
+    fingerprint,
+    cached_tokens,
+    price_input_snapshot,
+    price_output_snapshot
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)
 RETURNING *;
 
+-- name: GetRequestByID :one
+SELECT *
+FROM requests
+WHERE id = $1;
+
 -- name: GetRequestByIdempotencyKey :one
 SELECT *
 FROM requests
@@ -59,3 +72,225 @@ FROM requests
 WHERE ts >= $1
   AND ts < $2
 GROUP BY model_alias;
+
+-- name: AggregateRequestsByABVariant :many
+SELECT
+    ab_variant,
+    COUNT(*)::bigint AS requests,
+    COUNT(*) FILTER (WHERE status < 400)::bigint AS successes,
+    COALESCE(SUM(input_tokens), 0)::bigint AS input_tokens,
+    COALESCE(SUM(output_tokens), 0)::bigint AS output_tokens,
+    COALESCE(SUM(cost_usd_micros), 0)::bigint AS cost_usd_micros,
+    COALESCE(AVG(latency_ms), 0)::double precision AS avg_latency_ms
+FROM requests
+WHERE model_alias = $1
+  AND ab_variant IS NOT NULL
+  AND ts >= $2
+  AND ts < $3
+GROUP BY ab_variant
+ORDER BY ab_variant;
+
+-- name: SumTaggedRequests :one
+SELECT
+    COUNT(*)::bigint AS total_requests,
+    COALESCE(SUM(input_tokens + output_tokens), 0)::bigint AS total_tokens,
+    COALESCE(SUM(cost_cents), 0)::bigint AS total_cost_cents,
+    COALESCE(SUM(cost_usd_micros), 0)::bigint AS total_cost_usd_micros,
+    COALESCE(SUM(cached_tokens), 0)::bigint AS total_cached_tokens,
+    COALESCE(SUM(input_tokens), 0)::bigint AS total_input_tokens,
+    COALESCE(SUM(output_tokens), 0)::bigint AS total_output_tokens
+FROM requests
+WHERE ($1::uuid IS NULL OR tenant_id = $1)
+  AND ts >= $2
+  AND ts < $3
+  AND tags_json @> $4::jsonb
+  AND request_metadata @> $5::jsonb;
+
+-- name: AggregateTaggedRequestsDaily :many
+SELECT
+    timezone($5::text, date_trunc('day', ts AT TIME ZONE $5::text))::timestamptz AS day,
+    COUNT(*)::bigint AS requests,
+    COALESCE(SUM(input_tokens + output_tokens), 0)::bigint AS tokens,
+    COALESCE(SUM(cost_cents), 0)::bigint AS cost_cents,
+    COALESCE(SUM(cost_usd_micros), 0)::bigint AS cost_usd_micros
+FROM requests
+WHERE ($1::uuid IS NULL OR tenant_id = $1)
+  AND ($2::text = '' OR model_alias = $2)
+  AND ts >= $3
+  AND ts < $4
+  AND tags_json @> $6::jsonb
+  AND request_metadata @> $7::jsonb
+GROUP BY day
+ORDER BY day;
+
+-- name: AggregateTaggedRequestsByTenant :many
+SELECT
+    r.tenant_id,
+    t.name,
+    COUNT(*)::bigint AS requests,
+    COALESCE(SUM(r.input_tokens + r.output_tokens), 0)::bigint AS tokens,
+    COALESCE(SUM(r.cost_cents), 0)::bigint AS cost_cents,
+    COALESCE(SUM(r.cost_usd_micros), 0)::bigint AS cost_usd_micros
+FROM requests r
+JOIN tenants t ON t.id = r.tenant_id
+WHERE r.ts >= $1
+  AND r.ts < $2
+  AND r.tags_json @> $3::jsonb
+  AND r.request_metadata @> $5::jsonb
+GROUP BY r.tenant_id, t.name
+ORDER BY cost_cents DESC, requests DESC
+LIMIT $4;
+
+-- name: AggregateTaggedRequestsByModel :many
+SELECT
+    model_alias,
+    COUNT(*)::bigint AS requests,
+    COALESCE(SUM(input_tokens + output_tokens), 0)::bigint AS tokens,
+    COALESCE(SUM(cost_cents), 0)::bigint AS cost_cents,
+    COALESCE(SUM(cost_usd_micros), 0)::bigint AS cost_usd_micros
+FROM requests
+WHERE ts >= $1
+  AND ts < $2
+  AND tags_json @> $3::jsonb
+  AND request_metadata @> $5::jsonb
+GROUP BY model_alias
+ORDER BY cost_cents DESC, requests DESC
+LIMIT $4;
+
+-- name: ListTopRequestTags :many
+SELECT
+    tag.key::text AS tag_key,
+    tag.value::text AS tag_value,
+    COUNT(*)::bigint AS requests,
+    COALESCE(SUM(r.cost_cents), 0)::bigint AS cost_cents,
+    COALESCE(SUM(r.cost_usd_micros), 0)::bigint AS cost_usd_micros
+FROM requests r
+CROSS JOIN LATERAL jsonb_each_text(r.tags_json) AS tag(key, value)
+WHERE ($1::uuid IS NULL OR r.tenant_id = $1)
+  AND r.ts >= $2
+  AND r.ts < $3
+  AND r.tags_json @> $4::jsonb
+  AND r.request_metadata @> $6::jsonb
+GROUP BY tag.key, tag.value
+ORDER BY cost_cents DESC, requests DESC
+LIMIT $5;
+
+-- name: ListChargebackUsage :many
+SELECT
+    r.tenant_id,
+    t.name AS tenant_name,
+    t.cost_center,
+    r.model_alias,
+    r.provider,
+    COUNT(*)::bigint AS requests,
+    COALESCE(SUM(r.input_tokens + r.output_tokens), 0)::bigint AS tokens,
+    COALESCE(SUM(r.cost_usd_micros), 0)::bigint AS cost_usd_micros
+FROM requests r
+JOIN tenants t ON t.id = r.tenant_id
+WHERE r.ts >= sqlc.arg(start_ts)
+  AND r.ts < sqlc.arg(end_ts)
+  AND r.status < 400
+GROUP BY r.tenant_id, t.name, t.cost_center, r.model_alias, r.provider
+ORDER BY t.cost_center, t.name, r.tenant_id, cost_usd_micros DESC;
+
+-- name: GetOldestRequestBefore :one
+SELECT MIN(ts)::timestamptz AS oldest
+FROM requests
+WHERE ts < $1;
+
+-- name: ListRequestsForArchive :many
+SELECT *
+FROM requests
+WHERE ts >= sqlc.arg(start_ts)
+  AND ts < sqlc.arg(end_ts)
+  AND (ts, id) > (sqlc.arg(after_ts)::timestamptz, sqlc.arg(after_id)::uuid)
+ORDER BY ts, id
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListTenantRequestsForExport :many
+SELECT *
+FROM requests
+WHERE tenant_id = sqlc.arg(tenant_id)
+  AND (ts, id) > (sqlc.arg(after_ts)::timestamptz, sqlc.arg(after_id)::uuid)
+ORDER BY ts, id
+LIMIT sqlc.arg(row_limit);
+
+-- name: DeleteRequestsBeforeBatch :execrows
+DELETE FROM requests
+WHERE id IN (
+    SELECT id
+    FROM requests
+    WHERE ts < sqlc.arg(before_ts)
+      AND (
+        sqlc.narg(tenant_id)::uuid IS NULL
+        OR tenant_id = sqlc.narg(tenant_id)::uuid
+      )
+    LIMIT sqlc.arg(row_limit)
+);
+
+-- name: DeleteRequestsBetween :execrows
+DELETE FROM requests
+WHERE ts >= $1
+  AND ts < $2;
+
+-- name: SummarizeRequestsSince :one
+SELECT
+    COUNT(*)::bigint AS requests,
+    COALESCE(SUM(input_tokens + output_tokens), 0)::bigint AS tokens,
+    COALESCE(SUM(cost_usd_micros), 0)::bigint AS cost_usd_micros,
+    COALESCE(PERCENTILE_CONT(0.95) WITHIN GROUP (ORDER BY latency_ms), 0)::double precision AS p95_latency_ms
+FROM requests
+WHERE ts >= $1;
+
+-- name: ListModelErrorRates :many
+SELECT
+    model_alias,
+    COUNT(*)::bigint AS requests,
+    COUNT(*) FILTER (WHERE status >= 400)::bigint AS errors
+FROM requests
+WHERE ts >= $1
+GROUP BY model_alias
+HAVING COUNT(*) FILTER (WHERE status >= 400) > 0
+ORDER BY COUNT(*) FILTER (WHERE status >= 400)::double precision / COUNT(*) DESC, errors DESC
+LIMIT $2;
+
+-- name: ListSuspiciousRequestPatterns :many
+SELECT
+    r.api_key_id,
+    r.tenant_id,
+    k.prefix,
+    k.name,
+    r.fingerprint::text AS fingerprint,
+    COUNT(*)::bigint AS requests,
+    MIN(r.ts)::timestamptz AS first_seen,
+    MAX(r.ts)::timestamptz AS last_seen
+FROM requests r
+JOIN api_keys k ON k.id = r.api_key_id
+WHERE r.ts >= sqlc.arg(since)
+  AND r.fingerprint IS NOT NULL
+GROUP BY r.api_key_id, r.tenant_id, k.prefix, k.name, r.fingerprint
+HAVING COUNT(*) > sqlc.arg(threshold)::bigint
+ORDER BY requests DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListFingerprintAbuseCandidates :many
+SELECT
+    r.api_key_id,
+    r.tenant_id,
+    k.prefix,
+    k.name,
+    r.fingerprint::text AS fingerprint,
+    COUNT(*)::bigint AS requests,
+    COALESCE(NULLIF(s.abuse_fingerprint_threshold, 0), sqlc.arg(default_threshold)::int)::bigint AS threshold
+FROM requests r
+JOIN api_keys k ON k.id = r.api_key_id
+LEFT JOIN tenant_settings s ON s.tenant_id = r.tenant_id
+WHERE r.ts >= sqlc.arg(since)
+  AND r.fingerprint IS NOT NULL
+  AND k.revoked_at IS NULL
+  AND k.suspended_at IS NULL
+  AND (k.reinstated_at IS NULL OR r.ts > k.reinstated_at)
+  AND NOT k.is_bootstrap
+GROUP BY r.api_key_id, r.tenant_id, k.prefix, k.name, r.fingerprint, s.abuse_fingerprint_threshold
+HAVING COUNT(*) > COALESCE(NULLIF(s.abuse_fingerprint_threshold, 0), sqlc.arg(default_threshold)::int)
+ORDER BY requests DESC;
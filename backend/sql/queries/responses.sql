@@ -0,0 +1,15 @@
+-- name: InsertResponse :exec
+INSERT INTO responses (
+    id,
+    tenant_id,
+    api_key_id,
+    model_alias,
+    previous_response_id,
+    messages
+) VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: GetResponse :one
+SELECT *
+FROM responses
+WHERE id = $1
+  AND tenant_id = $2;
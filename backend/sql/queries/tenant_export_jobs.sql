@@ -0,0 +1,60 @@
+-- name: CreateTenantExportJob :one
+INSERT INTO tenant_export_jobs (tenant_id, sections_total)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetTenantExportJob :one
+SELECT *
+FROM tenant_export_jobs
+WHERE id = $1
+  AND tenant_id = $2;
+
+-- name: StartTenantExportJob :exec
+UPDATE tenant_export_jobs
+SET status = 'running',
+    started_at = NOW(),
+    heartbeat_at = NOW()
+WHERE id = $1;
+
+-- name: UpdateTenantExportJobProgress :exec
+UPDATE tenant_export_jobs
+SET sections_completed = $2,
+    rows_exported = $3,
+    heartbeat_at = NOW()
+WHERE id = $1;
+
+-- name: CompleteTenantExportJob :exec
+UPDATE tenant_export_jobs
+SET status = 'completed',
+    storage_key = $2,
+    bytes = $3,
+    expires_at = $4,
+    completed_at = NOW()
+WHERE id = $1;
+
+-- name: FailTenantExportJob :exec
+UPDATE tenant_export_jobs
+SET status = 'failed',
+    error = $2,
+    completed_at = NOW()
+WHERE id = $1;
+
+-- name: FailStaleTenantExportJobs :execrows
+UPDATE tenant_export_jobs
+SET status = 'failed',
+    error = sqlc.arg(error),
+    completed_at = NOW()
+WHERE status IN ('pending', 'running')
+  AND COALESCE(heartbeat_at, created_at) < sqlc.arg(stale_before)::timestamptz;
+
+-- name: ListExpiredTenantExports :many
+SELECT *
+FROM tenant_export_jobs
+WHERE storage_key IS NOT NULL
+  AND expires_at <= $1
+LIMIT $2;
+
+-- name: ClearTenantExportArchive :exec
+UPDATE tenant_export_jobs
+SET storage_key = NULL
+WHERE id = $1;
@@ -0,0 +1,41 @@
+-- name: CreateTenantInvitation :one
+INSERT INTO tenant_invitations (tenant_id, email, role, token_hash, invited_by, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: RevokePendingTenantInvitationsForEmail :exec
+UPDATE tenant_invitations
+SET revoked_at = NOW()
+WHERE tenant_id = $1
+  AND lower(email) = lower(sqlc.arg(email))
+  AND accepted_at IS NULL
+  AND revoked_at IS NULL;
+
+-- name: ListPendingTenantInvitations :many
+SELECT *
+FROM tenant_invitations
+WHERE tenant_id = $1
+  AND accepted_at IS NULL
+  AND revoked_at IS NULL
+  AND expires_at > NOW()
+ORDER BY created_at DESC;
+
+-- name: GetTenantInvitationByTokenHash :one
+SELECT *
+FROM tenant_invitations
+WHERE token_hash = $1;
+
+-- name: RevokeTenantInvitation :execrows
+UPDATE tenant_invitations
+SET revoked_at = NOW()
+WHERE id = $1
+  AND tenant_id = $2
+  AND accepted_at IS NULL
+  AND revoked_at IS NULL;
+
+-- name: MarkTenantInvitationAccepted :execrows
+UPDATE tenant_invitations
+SET accepted_at = NOW()
+WHERE id = $1
+  AND accepted_at IS NULL
+  AND revoked_at IS NULL;
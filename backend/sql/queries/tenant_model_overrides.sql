@@ -0,0 +1,22 @@
+-- name: ListTenantModelOverrides :many
+SELECT *
+FROM tenant_model_overrides
+WHERE tenant_id = $1
+ORDER BY model_alias;
+
+-- name: UpsertTenantModelOverride :one
+INSERT INTO tenant_model_overrides (
+    tenant_id,
+    model_alias,
+    context_window_override,
+    max_output_tokens_override
+) VALUES ($1, $2, $3, $4)
+ON CONFLICT (tenant_id, model_alias) DO UPDATE
+SET context_window_override = EXCLUDED.context_window_override,
+    max_output_tokens_override = EXCLUDED.max_output_tokens_override,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: DeleteTenantModelOverride :execrows
+DELETE FROM tenant_model_overrides
+WHERE tenant_id = $1 AND model_alias = $2;
@@ -0,0 +1,31 @@
+-- name: GetTenantParent :one
+SELECT *
+FROM tenant_parents
+WHERE tenant_id = $1;
+
+-- name: UpsertTenantParent :one
+INSERT INTO tenant_parents (
+    tenant_id,
+    parent_tenant_id
+) VALUES ($1, $2)
+ON CONFLICT (tenant_id) DO UPDATE
+SET parent_tenant_id = EXCLUDED.parent_tenant_id
+RETURNING *;
+
+-- name: DeleteTenantParent :exec
+DELETE FROM tenant_parents
+WHERE tenant_id = $1;
+
+-- name: IsTenantDescendant :one
+WITH RECURSIVE ancestors AS (
+    SELECT tp.parent_tenant_id
+    FROM tenant_parents tp
+    WHERE tp.tenant_id = $1
+    UNION
+    SELECT tp.parent_tenant_id
+    FROM tenant_parents tp
+    JOIN ancestors a ON tp.tenant_id = a.parent_tenant_id
+)
+SELECT EXISTS (
+    SELECT 1 FROM ancestors WHERE parent_tenant_id = $2
+);
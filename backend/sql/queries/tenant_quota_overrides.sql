@@ -0,0 +1,29 @@
+-- name: ListTenantQuotaOverrides :many
+SELECT *
+FROM tenant_quota_overrides
+ORDER BY created_at DESC;
+
+-- name: GetTenantQuotaOverride :one
+SELECT *
+FROM tenant_quota_overrides
+WHERE tenant_id = $1;
+
+-- name: UpsertTenantQuotaOverride :one
+INSERT INTO tenant_quota_overrides (
+    tenant_id,
+    max_requests_per_period,
+    refresh_schedule,
+    max_request_body_mb,
+    stream_idle_timeout_sec
+) VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (tenant_id) DO UPDATE
+SET max_requests_per_period = EXCLUDED.max_requests_per_period,
+    refresh_schedule = EXCLUDED.refresh_schedule,
+    max_request_body_mb = EXCLUDED.max_request_body_mb,
+    stream_idle_timeout_sec = EXCLUDED.stream_idle_timeout_sec,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: DeleteTenantQuotaOverride :exec
+DELETE FROM tenant_quota_overrides
+WHERE tenant_id = $1;
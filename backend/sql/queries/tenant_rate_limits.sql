@@ -12,12 +12,14 @@ INSERT INTO tenant_rate_limits (
     tenant_id,
     requests_per_minute,
     tokens_per_minute,
-    parallel_requests
-) VALUES ($1, $2, $3, $4)
+    parallel_requests,
+    parallel_request_ttl_seconds
+) VALUES ($1, $2, $3, $4, $5)
 ON CONFLICT (tenant_id) DO UPDATE
 SET requests_per_minute = EXCLUDED.requests_per_minute,
     tokens_per_minute = EXCLUDED.tokens_per_minute,
     parallel_requests = EXCLUDED.parallel_requests,
+    parallel_request_ttl_seconds = EXCLUDED.parallel_request_ttl_seconds,
     updated_at = NOW()
 RETURNING *;
 
@@ -0,0 +1,18 @@
+-- name: GetTenantSettings :one
+SELECT *
+FROM tenant_settings
+WHERE tenant_id = $1;
+
+-- name: UpsertTenantSettings :one
+INSERT INTO tenant_settings (
+    tenant_id,
+    schema_validation_mode,
+    abuse_fingerprint_threshold,
+    data_residency
+) VALUES ($1, $2, $3, $4)
+ON CONFLICT (tenant_id) DO UPDATE
+SET schema_validation_mode = EXCLUDED.schema_validation_mode,
+    abuse_fingerprint_threshold = EXCLUDED.abuse_fingerprint_threshold,
+    data_residency = EXCLUDED.data_residency,
+    updated_at = NOW()
+RETURNING *;
@@ -0,0 +1,20 @@
+-- name: GetTenantSystemPrompt :one
+SELECT *
+FROM tenant_system_prompts
+WHERE tenant_id = $1;
+
+-- name: UpsertTenantSystemPrompt :one
+INSERT INTO tenant_system_prompts (
+    tenant_id,
+    content,
+    mode
+) VALUES ($1, $2, $3)
+ON CONFLICT (tenant_id) DO UPDATE
+SET content = EXCLUDED.content,
+    mode = EXCLUDED.mode,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: DeleteTenantSystemPrompt :exec
+DELETE FROM tenant_system_prompts
+WHERE tenant_id = $1;
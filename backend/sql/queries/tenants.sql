@@ -57,8 +57,29 @@ SET status = $2
 WHERE id = $1
 RETURNING *;
 
+-- name: BulkUpdateTenantStatus :many
+UPDATE tenants
+SET status = sqlc.arg(status)
+WHERE id = ANY(sqlc.arg(ids)::uuid[])
+RETURNING id;
+
+-- name: UpdateTenantCostCenter :one
+UPDATE tenants
+SET cost_center = $2
+WHERE id = $1
+RETURNING *;
+
 -- name: UpdateTenantName :one
 UPDATE tenants
 SET name = $2
 WHERE id = $1
 RETURNING *;
+
+-- name: DeleteTenant :exec
+DELETE FROM tenants
+WHERE id = $1;
+
+-- name: CountActiveTenants :one
+SELECT COUNT(*)::bigint
+FROM tenants
+WHERE status = 'active';
@@ -9,9 +9,12 @@ INSERT INTO usage_records (
     output_tokens,
     requests,
     cost_cents,
-    cost_usd_micros
+    cost_usd_micros,
+    cached_tokens,
+    input_cost_usd_micros,
+    output_cost_usd_micros
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 RETURNING *;
 
 -- name: SumUsageForTenant :one
@@ -31,7 +34,10 @@ SELECT
     COALESCE(SUM(requests), 0)::bigint AS total_requests,
     COALESCE(SUM(input_tokens + output_tokens), 0)::bigint AS total_tokens,
     COALESCE(SUM(cost_cents), 0)::bigint AS total_cost_cents,
-    COALESCE(SUM(cost_usd_micros), 0)::bigint AS total_cost_usd_micros
+    COALESCE(SUM(cost_usd_micros), 0)::bigint AS total_cost_usd_micros,
+    COALESCE(SUM(cached_tokens), 0)::bigint AS total_cached_tokens,
+    COALESCE(SUM(input_tokens), 0)::bigint AS total_input_tokens,
+    COALESCE(SUM(output_tokens), 0)::bigint AS total_output_tokens
 FROM usage_records
 WHERE ($1::uuid IS NULL OR tenant_id = $1)
   AND ts >= $2
@@ -51,6 +57,18 @@ WHERE ($1::uuid IS NULL OR tenant_id = $1)
 GROUP BY day
 ORDER BY day;
 
+-- name: AggregateUsageHourly :many
+SELECT
+    date_trunc('hour', ts)::timestamptz AS hour,
+    COALESCE(SUM(requests), 0)::bigint AS requests,
+    COALESCE(SUM(input_tokens + output_tokens), 0)::bigint AS tokens
+FROM usage_records
+WHERE ($1::uuid IS NULL OR tenant_id = $1)
+  AND ts >= $2
+  AND ts < $3
+GROUP BY hour
+ORDER BY hour;
+
 -- name: ListUserOwnedTenants :many
 SELECT DISTINCT
     tm.tenant_id,
@@ -74,7 +92,10 @@ SELECT
     COALESCE(SUM(u.requests), 0)::bigint AS total_requests,
     COALESCE(SUM(u.input_tokens + u.output_tokens), 0)::bigint AS total_tokens,
     COALESCE(SUM(u.cost_cents), 0)::bigint AS total_cost_cents,
-    COALESCE(SUM(u.cost_usd_micros), 0)::bigint AS total_cost_usd_micros
+    COALESCE(SUM(u.cost_usd_micros), 0)::bigint AS total_cost_usd_micros,
+    COALESCE(SUM(u.cached_tokens), 0)::bigint AS total_cached_tokens,
+    COALESCE(SUM(u.input_tokens), 0)::bigint AS total_input_tokens,
+    COALESCE(SUM(u.output_tokens), 0)::bigint AS total_output_tokens
 FROM usage_records u
 JOIN api_keys k ON u.api_key_id = k.id
 WHERE k.owner_user_id = $1
@@ -129,7 +150,11 @@ SELECT
     COALESCE(SUM(requests), 0)::bigint AS requests,
     COALESCE(SUM(input_tokens + output_tokens), 0)::bigint AS tokens,
     COALESCE(SUM(cost_cents), 0)::bigint AS cost_cents,
-    COALESCE(SUM(cost_usd_micros), 0)::bigint AS cost_usd_micros
+    COALESCE(SUM(cost_usd_micros), 0)::bigint AS cost_usd_micros,
+    COALESCE(SUM(input_tokens), 0)::bigint AS input_tokens,
+    COALESCE(SUM(output_tokens), 0)::bigint AS output_tokens,
+    COALESCE(SUM(input_cost_usd_micros), 0)::bigint AS input_cost_usd_micros,
+    COALESCE(SUM(output_cost_usd_micros), 0)::bigint AS output_cost_usd_micros
 FROM usage_records
 WHERE ts >= $1
   AND ts < $2
@@ -175,7 +200,11 @@ SELECT
     COALESCE(SUM(requests), 0)::bigint AS requests,
     COALESCE(SUM(input_tokens + output_tokens), 0)::bigint AS tokens,
     COALESCE(SUM(cost_cents), 0)::bigint AS cost_cents,
-    COALESCE(SUM(cost_usd_micros), 0)::bigint AS cost_usd_micros
+    COALESCE(SUM(cost_usd_micros), 0)::bigint AS cost_usd_micros,
+    COALESCE(SUM(input_tokens), 0)::bigint AS input_tokens,
+    COALESCE(SUM(output_tokens), 0)::bigint AS output_tokens,
+    COALESCE(SUM(input_cost_usd_micros), 0)::bigint AS input_cost_usd_micros,
+    COALESCE(SUM(output_cost_usd_micros), 0)::bigint AS output_cost_usd_micros
 FROM usage_records
 WHERE model_alias = $1
   AND ts >= $2
@@ -204,7 +233,10 @@ SELECT
     COALESCE(SUM(requests), 0)::bigint AS total_requests,
     COALESCE(SUM(input_tokens + output_tokens), 0)::bigint AS total_tokens,
     COALESCE(SUM(cost_cents), 0)::bigint AS total_cost_cents,
-    COALESCE(SUM(cost_usd_micros), 0)::bigint AS total_cost_usd_micros
+    COALESCE(SUM(cost_usd_micros), 0)::bigint AS total_cost_usd_micros,
+    COALESCE(SUM(cached_tokens), 0)::bigint AS total_cached_tokens,
+    COALESCE(SUM(input_tokens), 0)::bigint AS total_input_tokens,
+    COALESCE(SUM(output_tokens), 0)::bigint AS total_output_tokens
 FROM usage_records
 WHERE api_key_id = $1
   AND ts >= $2
@@ -245,7 +277,10 @@ SELECT
     COALESCE(SUM(r.requests), 0)::bigint AS total_requests,
     COALESCE(SUM(r.input_tokens + r.output_tokens), 0)::bigint AS total_tokens,
     COALESCE(SUM(r.cost_cents), 0)::bigint AS total_cost_cents,
-    COALESCE(SUM(r.cost_usd_micros), 0)::bigint AS total_cost_usd_micros
+    COALESCE(SUM(r.cost_usd_micros), 0)::bigint AS total_cost_usd_micros,
+    COALESCE(SUM(r.cached_tokens), 0)::bigint AS total_cached_tokens,
+    COALESCE(SUM(r.input_tokens), 0)::bigint AS total_input_tokens,
+    COALESCE(SUM(r.output_tokens), 0)::bigint AS total_output_tokens
 FROM usage_records r
 JOIN api_keys k ON r.api_key_id = k.id
 WHERE k.owner_user_id = ANY($1::uuid[])
@@ -259,7 +294,10 @@ SELECT
     COALESCE(SUM(requests), 0)::bigint AS total_requests,
     COALESCE(SUM(input_tokens + output_tokens), 0)::bigint AS total_tokens,
     COALESCE(SUM(cost_cents), 0)::bigint AS total_cost_cents,
-    COALESCE(SUM(cost_usd_micros), 0)::bigint AS total_cost_usd_micros
+    COALESCE(SUM(cost_usd_micros), 0)::bigint AS total_cost_usd_micros,
+    COALESCE(SUM(cached_tokens), 0)::bigint AS total_cached_tokens,
+    COALESCE(SUM(input_tokens), 0)::bigint AS total_input_tokens,
+    COALESCE(SUM(output_tokens), 0)::bigint AS total_output_tokens
 FROM usage_records
 WHERE tenant_id = ANY($1::uuid[])
   AND ts >= $2
@@ -273,7 +311,11 @@ SELECT
     COALESCE(SUM(requests), 0)::bigint AS requests,
     COALESCE(SUM(input_tokens + output_tokens), 0)::bigint AS tokens,
     COALESCE(SUM(cost_cents), 0)::bigint AS cost_cents,
-    COALESCE(SUM(cost_usd_micros), 0)::bigint AS cost_usd_micros
+    COALESCE(SUM(cost_usd_micros), 0)::bigint AS cost_usd_micros,
+    COALESCE(SUM(input_tokens), 0)::bigint AS input_tokens,
+    COALESCE(SUM(output_tokens), 0)::bigint AS output_tokens,
+    COALESCE(SUM(input_cost_usd_micros), 0)::bigint AS input_cost_usd_micros,
+    COALESCE(SUM(output_cost_usd_micros), 0)::bigint AS output_cost_usd_micros
 FROM usage_records
 WHERE model_alias = ANY($1::text[])
   AND ts >= $2
@@ -341,7 +383,12 @@ SELECT
     COALESCE(SUM(requests), 0)::bigint AS total_requests,
     COALESCE(SUM(input_tokens + output_tokens), 0)::bigint AS total_tokens,
     COALESCE(SUM(cost_cents), 0)::bigint AS total_cost_cents,
-    COALESCE(SUM(cost_usd_micros), 0)::bigint AS total_cost_usd_micros
+    COALESCE(SUM(cost_usd_micros), 0)::bigint AS total_cost_usd_micros,
+    COALESCE(SUM(cached_tokens), 0)::bigint AS total_cached_tokens,
+    COALESCE(SUM(input_tokens), 0)::bigint AS total_input_tokens,
+    COALESCE(SUM(output_tokens), 0)::bigint AS total_output_tokens,
+    COALESCE(SUM(input_cost_usd_micros), 0)::bigint AS total_input_cost_usd_micros,
+    COALESCE(SUM(output_cost_usd_micros), 0)::bigint AS total_output_cost_usd_micros
 FROM usage_records
 WHERE model_alias = ANY($1::text[])
   AND ts >= $2
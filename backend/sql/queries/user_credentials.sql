@@ -31,3 +31,7 @@ SELECT *
 FROM user_credentials
 WHERE user_id = $1
 ORDER BY created_at DESC;
+
+-- name: DeleteCredentialsForUser :exec
+DELETE FROM user_credentials
+WHERE user_id = $1;
@@ -51,3 +51,14 @@ SET
     updated_at = NOW()
 WHERE id = sqlc.arg(id)
 RETURNING *;
+
+-- name: AnonymizeUser :one
+UPDATE users
+SET email = $2,
+    name = $3,
+    is_super_admin = FALSE,
+    personal_tenant_id = NULL,
+    last_login_at = NULL,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
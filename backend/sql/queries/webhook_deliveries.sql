@@ -0,0 +1,58 @@
+-- name: InsertWebhookDelivery :one
+INSERT INTO webhook_deliveries (event, webhook_url, payload, tenant_id, next_retry_at)
+VALUES ($1, $2, $3, $4, NOW())
+RETURNING *;
+
+-- name: ClaimDueWebhookDeliveries :many
+-- Claimed rows are leased for five minutes so a crashed worker's deliveries
+-- become due again instead of staying stuck.
+WITH due AS (
+    SELECT id
+    FROM webhook_deliveries
+    WHERE status IN ('pending', 'failed')
+      AND next_retry_at <= NOW()
+    ORDER BY next_retry_at
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+UPDATE webhook_deliveries
+SET next_retry_at = NOW() + INTERVAL '5 minutes'
+WHERE id IN (SELECT id FROM due)
+RETURNING *;
+
+-- name: MarkWebhookDeliveryDelivered :exec
+UPDATE webhook_deliveries
+SET status = 'delivered',
+    attempts = attempts + 1,
+    last_error = NULL,
+    next_retry_at = NULL
+WHERE id = $1;
+
+-- name: MarkWebhookDeliveryFailed :exec
+UPDATE webhook_deliveries
+SET status = $2,
+    attempts = attempts + 1,
+    last_error = $3,
+    next_retry_at = $4
+WHERE id = $1;
+
+-- name: ListWebhookDeliveries :many
+SELECT *
+FROM webhook_deliveries
+WHERE sqlc.narg(status_filter)::webhook_delivery_status IS NULL
+   OR status = sqlc.narg(status_filter)::webhook_delivery_status
+ORDER BY created_at DESC
+LIMIT sqlc.arg(list_limit) OFFSET sqlc.arg(list_offset);
+
+-- name: RetryWebhookDelivery :one
+UPDATE webhook_deliveries
+SET status = 'pending',
+    attempts = 0,
+    next_retry_at = NOW()
+WHERE id = $1 AND status IN ('failed', 'dead')
+RETURNING *;
+
+-- name: DeleteWebhookDeliveriesBefore :execrows
+DELETE FROM webhook_deliveries
+WHERE status IN ('delivered', 'dead')
+  AND updated_at < $1;
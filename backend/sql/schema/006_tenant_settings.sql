@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS tenant_settings (
+    tenant_id UUID PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+    schema_validation_mode TEXT NOT NULL DEFAULT 'strict'
+        CHECK (schema_validation_mode IN ('strict', 'warn_only', 'disabled')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER tenant_settings_updated_at
+    BEFORE UPDATE ON tenant_settings
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_timestamp();
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS request_payloads (
+    request_id UUID PRIMARY KEY REFERENCES requests(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    request_body BYTEA,
+    response_body BYTEA,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_request_payloads_created_at ON request_payloads(created_at);
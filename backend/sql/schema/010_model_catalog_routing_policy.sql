@@ -0,0 +1,2 @@
+ALTER TABLE model_catalog
+    ADD COLUMN routing_policy TEXT NOT NULL DEFAULT '';
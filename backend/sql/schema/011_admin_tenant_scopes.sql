@@ -0,0 +1,8 @@
+CREATE TABLE admin_tenant_scopes (
+    admin_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (admin_user_id, tenant_id)
+);
+
+CREATE INDEX idx_admin_tenant_scopes_tenant ON admin_tenant_scopes(tenant_id);
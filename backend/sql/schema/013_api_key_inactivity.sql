@@ -0,0 +1,3 @@
+ALTER TABLE api_keys
+    ADD COLUMN is_bootstrap BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN inactive_warning_sent_at TIMESTAMPTZ;
@@ -0,0 +1,9 @@
+ALTER TABLE model_catalog
+    ADD COLUMN traffic_split_json JSONB NOT NULL DEFAULT '[]'::jsonb;
+
+ALTER TABLE requests
+    ADD COLUMN ab_variant TEXT;
+
+CREATE INDEX idx_requests_ab_variant
+    ON requests (model_alias, ts)
+    WHERE ab_variant IS NOT NULL;
@@ -0,0 +1,6 @@
+ALTER TABLE batches
+    ADD COLUMN scheduled_at TIMESTAMPTZ;
+
+CREATE INDEX idx_batches_scheduled
+    ON batches (scheduled_at)
+    WHERE status = 'scheduled';
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS tenant_model_overrides (
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    model_alias TEXT NOT NULL REFERENCES model_catalog(alias) ON DELETE CASCADE,
+    context_window_override INT NOT NULL DEFAULT 0 CHECK (context_window_override >= 0),
+    max_output_tokens_override INT NOT NULL DEFAULT 0 CHECK (max_output_tokens_override >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, model_alias)
+);
+
+CREATE TRIGGER tenant_model_overrides_updated_at
+    BEFORE UPDATE ON tenant_model_overrides
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_timestamp();
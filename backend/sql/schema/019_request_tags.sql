@@ -0,0 +1,4 @@
+ALTER TABLE requests
+    ADD COLUMN tags_json JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+CREATE INDEX idx_requests_tags ON requests USING GIN (tags_json);
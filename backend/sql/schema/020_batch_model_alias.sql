@@ -0,0 +1,5 @@
+ALTER TABLE batches
+    ADD COLUMN model_alias TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_batches_created_model
+    ON batches (created_at, model_alias);
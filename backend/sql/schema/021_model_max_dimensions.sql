@@ -0,0 +1,2 @@
+ALTER TABLE model_catalog
+    ADD COLUMN max_dimensions INT NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+ALTER TABLE tenants
+    ADD COLUMN cost_center TEXT NOT NULL DEFAULT '';
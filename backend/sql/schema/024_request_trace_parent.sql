@@ -0,0 +1,2 @@
+ALTER TABLE requests
+    ADD COLUMN trace_parent TEXT;
@@ -0,0 +1,2 @@
+ALTER TABLE api_keys
+    ADD COLUMN zero_retention BOOLEAN NOT NULL DEFAULT FALSE;
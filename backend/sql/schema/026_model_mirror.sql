@@ -0,0 +1,3 @@
+ALTER TABLE model_catalog
+    ADD COLUMN mirror_alias TEXT NOT NULL DEFAULT '',
+    ADD COLUMN mirror_sample_rate NUMERIC(5,4) NOT NULL DEFAULT 0 CHECK (mirror_sample_rate >= 0 AND mirror_sample_rate <= 1);
@@ -0,0 +1,2 @@
+ALTER TABLE tenant_quota_overrides
+    ADD COLUMN max_request_body_mb INTEGER NOT NULL DEFAULT 0 CHECK (max_request_body_mb >= 0);
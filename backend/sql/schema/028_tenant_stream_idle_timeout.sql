@@ -0,0 +1,2 @@
+ALTER TABLE tenant_quota_overrides
+    ADD COLUMN stream_idle_timeout_sec INTEGER NOT NULL DEFAULT 0 CHECK (stream_idle_timeout_sec >= 0);
@@ -0,0 +1,28 @@
+CREATE TYPE image_job_status AS ENUM ('pending', 'processing', 'completed', 'failed');
+
+CREATE TABLE IF NOT EXISTS image_jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    api_key_id UUID NOT NULL REFERENCES api_keys(id) ON DELETE CASCADE,
+    model_alias TEXT NOT NULL,
+    request JSONB NOT NULL,
+    status image_job_status NOT NULL DEFAULT 'pending',
+    result JSONB,
+    error_message TEXT,
+    trace_id TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_image_jobs_queue
+    ON image_jobs (created_at)
+    WHERE status IN ('pending', 'processing');
+
+CREATE INDEX IF NOT EXISTS idx_image_jobs_expires_at ON image_jobs (expires_at);
+
+CREATE TRIGGER image_jobs_updated_at
+    BEFORE UPDATE ON image_jobs
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_timestamp();
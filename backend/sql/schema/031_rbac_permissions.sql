@@ -0,0 +1,73 @@
+CREATE TABLE IF NOT EXISTS permissions (
+    name TEXT PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS roles (
+    name TEXT PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT '',
+    permissions JSONB NOT NULL DEFAULT '[]'::jsonb,
+    builtin BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER roles_updated_at
+    BEFORE UPDATE ON roles
+    FOR EACH ROW
+    EXECUTE FUNCTION trigger_set_timestamp();
+
+-- A custom role assigned to a membership replaces the permissions of the
+-- membership's built-in role.
+CREATE TABLE IF NOT EXISTS tenant_membership_roles (
+    tenant_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    role TEXT NOT NULL REFERENCES roles(name) ON DELETE RESTRICT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, user_id),
+    FOREIGN KEY (tenant_id, user_id) REFERENCES tenant_memberships(tenant_id, user_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS tenant_membership_roles_user_idx ON tenant_membership_roles (user_id);
+
+INSERT INTO permissions (name, description) VALUES
+    ('api_keys:create', 'Issue tenant API keys'),
+    ('api_keys:read', 'List a tenant''s API keys'),
+    ('api_keys:read_all', 'List API keys across all tenants'),
+    ('api_keys:revoke', 'Revoke tenant API keys'),
+    ('audit:read', 'Read the audit log'),
+    ('batches:cancel', 'Cancel tenant batches'),
+    ('batches:read', 'Read tenant batches and their files'),
+    ('budgets:read', 'Read budgets and budget overrides'),
+    ('budgets:write', 'Change tenant budgets and budget overrides'),
+    ('currency_rates:read', 'Read currency rates'),
+    ('currency_rates:write', 'Change currency rates'),
+    ('memberships:read', 'List tenant members and invitations'),
+    ('memberships:write', 'Change tenant members and invitations'),
+    ('models:health', 'Run model health checks'),
+    ('models:read', 'Read the model catalog and tenant model access'),
+    ('models:write', 'Change default models'),
+    ('providers:read', 'Read provider routes'),
+    ('rate_limits:read', 'Read rate limits'),
+    ('rate_limits:write', 'Change default rate limits'),
+    ('requests:read', 'Read captured request payloads'),
+    ('requests:replay', 'Replay captured requests'),
+    ('settings:read', 'Read gateway settings'),
+    ('settings:write', 'Change gateway settings'),
+    ('tenants:create', 'Create tenants'),
+    ('tenants:read', 'Read tenant settings, quotas, and prompts'),
+    ('tenants:write', 'Change tenant details, limits, models, and settings'),
+    ('tokens:read', 'List admin tokens'),
+    ('tokens:write', 'Create and revoke admin tokens'),
+    ('usage:read', 'Read usage reports'),
+    ('users:read', 'List admin users and their tenants'),
+    ('users:write', 'Create admin users')
+ON CONFLICT (name) DO NOTHING;
+
+-- Built-in roles keep the access the membership role ranks granted before.
+INSERT INTO roles (name, description, permissions, builtin) VALUES
+    ('user', 'Tenant member without admin access', '[]'::jsonb, TRUE),
+    ('viewer', 'Read-only tenant access', '["api_keys:read", "audit:read", "budgets:read", "currency_rates:read", "models:read", "providers:read", "rate_limits:read", "tenants:read", "usage:read"]'::jsonb, TRUE),
+    ('admin', 'Tenant administration without ownership rights', '["api_keys:create", "api_keys:read", "api_keys:read_all", "api_keys:revoke", "audit:read", "batches:cancel", "batches:read", "budgets:read", "currency_rates:read", "currency_rates:write", "memberships:read", "models:health", "models:read", "models:write", "providers:read", "rate_limits:read", "rate_limits:write", "requests:read", "requests:replay", "settings:read", "settings:write", "tenants:create", "tenants:read", "tokens:read", "tokens:write", "usage:read", "users:read", "users:write"]'::jsonb, TRUE),
+    ('owner', 'Full tenant access', '["api_keys:create", "api_keys:read", "api_keys:read_all", "api_keys:revoke", "audit:read", "batches:cancel", "batches:read", "budgets:read", "budgets:write", "currency_rates:read", "currency_rates:write", "memberships:read", "memberships:write", "models:health", "models:read", "models:write", "providers:read", "rate_limits:read", "rate_limits:write", "requests:read", "requests:replay", "settings:read", "settings:write", "tenants:create", "tenants:read", "tenants:write", "tokens:read", "tokens:write", "usage:read", "users:read", "users:write"]'::jsonb, TRUE)
+ON CONFLICT (name) DO NOTHING;
@@ -0,0 +1,3 @@
+ALTER TABLE model_catalog
+    ADD COLUMN deprecated_at TIMESTAMPTZ,
+    ADD COLUMN deprecation_message TEXT NOT NULL DEFAULT '';
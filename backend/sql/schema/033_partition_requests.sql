@@ -0,0 +1,38 @@
+ALTER TABLE request_payloads
+    DROP CONSTRAINT IF EXISTS request_payloads_request_id_fkey;
+
+DROP TABLE requests;
+
+CREATE TABLE requests (
+    id               UUID NOT NULL DEFAULT gen_random_uuid(),
+    tenant_id        UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    api_key_id       UUID REFERENCES api_keys(id) ON DELETE SET NULL,
+    ts               TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    model_alias      TEXT NOT NULL,
+    provider         TEXT NOT NULL,
+    latency_ms       INT NOT NULL,
+    status           INT NOT NULL,
+    error_code       TEXT,
+    input_tokens     BIGINT NOT NULL DEFAULT 0,
+    output_tokens    BIGINT NOT NULL DEFAULT 0,
+    cost_cents       BIGINT NOT NULL DEFAULT 0,
+    cost_usd_micros  BIGINT NOT NULL DEFAULT 0,
+    idempotency_key  TEXT,
+    trace_id         TEXT,
+    ab_variant       TEXT,
+    tags_json        JSONB NOT NULL DEFAULT '{}'::jsonb,
+    trace_parent     TEXT,
+    PRIMARY KEY (id, ts)
+) PARTITION BY RANGE (ts);
+
+CREATE TABLE requests_default PARTITION OF requests DEFAULT;
+
+CREATE INDEX idx_requests_tenant_ts ON requests(tenant_id, ts DESC);
+CREATE INDEX idx_requests_model_alias ON requests(model_alias);
+CREATE INDEX idx_requests_tenant_idempotency
+    ON requests(tenant_id, idempotency_key)
+    WHERE idempotency_key IS NOT NULL;
+CREATE INDEX idx_requests_ab_variant
+    ON requests (model_alias, ts)
+    WHERE ab_variant IS NOT NULL;
+CREATE INDEX idx_requests_tags ON requests USING GIN (tags_json);
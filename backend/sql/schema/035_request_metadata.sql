@@ -0,0 +1,4 @@
+ALTER TABLE requests
+    ADD COLUMN request_metadata JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+CREATE INDEX idx_requests_metadata ON requests USING GIN (request_metadata);
@@ -0,0 +1,3 @@
+ALTER TABLE model_catalog
+    ADD COLUMN supports_vision BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN fallback_vision_alias TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,10 @@
+ALTER TABLE requests
+    ADD COLUMN fingerprint TEXT;
+
+CREATE INDEX idx_requests_fingerprint
+    ON requests (ts, api_key_id, fingerprint)
+    WHERE fingerprint IS NOT NULL;
+
+ALTER TABLE tenant_settings
+    ADD COLUMN abuse_fingerprint_threshold INTEGER NOT NULL DEFAULT 0
+        CHECK (abuse_fingerprint_threshold >= 0);
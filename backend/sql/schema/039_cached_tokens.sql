@@ -0,0 +1,9 @@
+ALTER TABLE requests
+    ADD COLUMN cached_tokens BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE usage_records
+    ADD COLUMN cached_tokens BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE model_catalog
+    ADD COLUMN cached_price_ratio NUMERIC(5,4) NOT NULL DEFAULT 0.1
+        CHECK (cached_price_ratio >= 0 AND cached_price_ratio <= 1);
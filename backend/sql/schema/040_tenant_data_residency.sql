@@ -0,0 +1,2 @@
+ALTER TABLE tenant_settings
+    ADD COLUMN data_residency TEXT[] NOT NULL DEFAULT '{}';
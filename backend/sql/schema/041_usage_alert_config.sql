@@ -0,0 +1,2 @@
+ALTER TABLE tenant_budget_overrides
+    ADD COLUMN usage_alert_config JSONB;
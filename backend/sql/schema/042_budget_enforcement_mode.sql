@@ -0,0 +1,3 @@
+ALTER TABLE tenant_budget_overrides
+    ADD COLUMN enforcement_mode TEXT NOT NULL DEFAULT 'hard'
+        CHECK (enforcement_mode IN ('hard', 'soft'));
@@ -0,0 +1,5 @@
+ALTER TABLE tenant_budget_overrides
+    ADD COLUMN alert_webhook_secret TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE webhook_deliveries
+    ADD COLUMN tenant_id UUID REFERENCES tenants(id) ON DELETE CASCADE;
@@ -0,0 +1,6 @@
+CREATE TABLE provider_credentials (
+    provider TEXT PRIMARY KEY,
+    api_key TEXT NOT NULL,
+    key_hash TEXT NOT NULL,
+    rotated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,14 @@
+CREATE TYPE notification_severity AS ENUM ('info', 'warning', 'critical');
+
+CREATE TABLE notifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    severity notification_severity NOT NULL,
+    entity_type TEXT NOT NULL,
+    entity_id TEXT NOT NULL DEFAULT '',
+    message TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    read_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_notifications_created_at ON notifications (created_at DESC);
+CREATE INDEX idx_notifications_unread ON notifications (created_at DESC) WHERE read_at IS NULL;
@@ -0,0 +1,6 @@
+ALTER TABLE tenant_export_jobs
+    DROP COLUMN IF EXISTS file_id,
+    ADD COLUMN storage_key TEXT,
+    ADD COLUMN bytes BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN expires_at TIMESTAMPTZ,
+    ADD COLUMN heartbeat_at TIMESTAMPTZ;
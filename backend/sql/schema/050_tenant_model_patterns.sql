@@ -0,0 +1,2 @@
+ALTER TABLE tenant_models
+    DROP CONSTRAINT IF EXISTS tenant_models_alias_fkey;
@@ -0,0 +1,10 @@
+ALTER TABLE provider_credentials
+    ADD COLUMN endpoint TEXT NOT NULL DEFAULT '',
+    ADD COLUMN api_key_ciphertext TEXT,
+    ALTER COLUMN api_key DROP NOT NULL;
+
+ALTER TABLE provider_credentials
+    DROP CONSTRAINT IF EXISTS provider_credentials_pkey;
+
+ALTER TABLE provider_credentials
+    ADD PRIMARY KEY (provider, endpoint);
@@ -0,0 +1,4 @@
+ALTER TABLE api_keys
+    ADD COLUMN suspended_at TIMESTAMPTZ,
+    ADD COLUMN suspension_reason TEXT NOT NULL DEFAULT '',
+    ADD COLUMN reinstated_at TIMESTAMPTZ;
@@ -0,0 +1,3 @@
+ALTER TABLE usage_records
+    ADD COLUMN input_cost_usd_micros BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN output_cost_usd_micros BIGINT NOT NULL DEFAULT 0;
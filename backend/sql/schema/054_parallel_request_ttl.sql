@@ -0,0 +1,4 @@
+ALTER TABLE tenant_rate_limits
+    ADD COLUMN parallel_request_ttl_seconds INTEGER NOT NULL DEFAULT 0 CHECK (parallel_request_ttl_seconds >= 0);
+ALTER TABLE api_key_rate_limits
+    ADD COLUMN parallel_request_ttl_seconds INTEGER NOT NULL DEFAULT 0 CHECK (parallel_request_ttl_seconds >= 0);